@@ -0,0 +1,121 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	alertsImportOverwrite bool
+	alertsImportDryRun    bool
+)
+
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Share alert rules and geofences",
+	Long: `Export the alert rules and geofences from your settings to a standalone
+JSON file, or merge one into your settings.
+
+Examples:
+  skyspy alerts export my-alerts.json
+  skyspy alerts import my-alerts.json --dry-run
+  skyspy alerts import my-alerts.json --overwrite`,
+}
+
+var alertsExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write alert rules and geofences to a file",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAlertsExport,
+}
+
+var alertsImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Merge alert rules and geofences from a file",
+	Long: `Merge alert rules and geofences from a file into your settings by ID.
+
+New IDs are added. An ID you already have is kept unless --overwrite is
+given. Each entry is validated on its own: invalid rules are reported and
+skipped, and the rest are still imported.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAlertsImport,
+}
+
+// RegisterAlertsCommands sets up the alerts command hierarchy.
+// Call this from the main command initialization.
+func RegisterAlertsCommands() {
+	alertsImportCmd.Flags().BoolVar(&alertsImportOverwrite, "overwrite", false, "Replace rules and geofences whose ID already exists")
+	alertsImportCmd.Flags().BoolVar(&alertsImportDryRun, "dry-run", false, "List what would change without saving")
+	alertsCmd.AddCommand(alertsExportCmd)
+	alertsCmd.AddCommand(alertsImportCmd)
+}
+
+func runAlertsExport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	f := app.ExportAlerts(cfg)
+	if err := app.WriteAlertsFile(args[0], f); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Exported %d rules and %d geofences to %s\n", len(f.Rules), len(f.Geofences), args[0])
+	return nil
+}
+
+func runAlertsImport(cmd *cobra.Command, args []string) error {
+	f, err := app.ReadAlertsFile(args[0])
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	changes := app.ImportAlerts(cfg, f, alertsImportOverwrite)
+	invalid := printImportChanges(cmd.OutOrStdout(), changes, alertsImportDryRun, alertsImportOverwrite)
+
+	if !alertsImportDryRun {
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d invalid entries were not imported", invalid)
+	}
+	return nil
+}
+
+// printImportChanges lists one line per imported entry and a summary, and
+// returns the number of invalid entries
+func printImportChanges(w io.Writer, changes []app.ImportChange, dryRun, overwrite bool) int {
+	counts := make(map[app.ImportAction]int)
+	for _, c := range changes {
+		counts[c.Action]++
+		line := fmt.Sprintf("  %-9s %-8s %-20s %s", c.Action, c.Kind, c.ID, c.Name)
+		if c.Err != nil {
+			line += ": " + strings.ReplaceAll(c.Err.Error(), "\n", "; ")
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	prefix := "Imported"
+	if dryRun {
+		prefix = "Dry run, nothing saved:"
+	}
+	fmt.Fprintf(w, "%s %d added, %d replaced, %d unchanged, %d skipped, %d invalid\n", prefix,
+		counts[app.ImportAdded], counts[app.ImportReplaced], counts[app.ImportUnchanged],
+		counts[app.ImportSkipped], counts[app.ImportInvalid])
+	if counts[app.ImportSkipped] > 0 && !overwrite {
+		fmt.Fprintln(w, "Use --overwrite to replace entries whose ID already exists")
+	}
+	return counts[app.ImportInvalid]
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// useTempConfig points the settings file at a fresh home directory
+func useTempConfig(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+}
+
+func runAlertsCmd(t *testing.T, run func(*cobra.Command, []string) error, cmd *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	defer cmd.SetOut(nil)
+	err := run(cmd, args)
+	return out.String(), err
+}
+
+func TestAlertsExportImport(t *testing.T) {
+	useTempConfig(t)
+	path := filepath.Join(t.TempDir(), "alerts.json")
+
+	out, err := runAlertsCmd(t, runAlertsExport, alertsExportCmd, path)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if !strings.Contains(out, "Exported") {
+		t.Errorf("unexpected export output %q", out)
+	}
+	f, err := app.ReadAlertsFile(path)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if len(f.Rules) == 0 {
+		t.Fatal("a config without custom rules should export the defaults")
+	}
+
+	// Change one rule, add one and add an invalid one
+	f.Rules[0].Priority += 10
+	f.Rules = append(f.Rules,
+		config.AlertRuleConfig{ID: "low", Name: "Low Flyer", Enabled: true,
+			Conditions: []config.ConditionConfig{{Type: "altitude_below", Value: "1000"}}},
+		config.AlertRuleConfig{ID: "bad", Name: "Bad", Enabled: true,
+			Conditions: []config.ConditionConfig{{Type: "no_such_condition", Value: "x"}}},
+	)
+	if err := app.WriteAlertsFile(path, f); err != nil {
+		t.Fatal(err)
+	}
+
+	alertsImportDryRun = true
+	out, err = runAlertsCmd(t, runAlertsImport, alertsImportCmd, path)
+	alertsImportDryRun = false
+	if err == nil {
+		t.Error("expected an error reporting the invalid rule")
+	}
+	if !strings.Contains(out, "Dry run") || !strings.Contains(out, "no_such_condition") {
+		t.Errorf("dry run should list changes and per-rule errors, got:\n%s", out)
+	}
+	if _, statErr := os.Stat(config.GetConfigPath()); !os.IsNotExist(statErr) {
+		t.Error("dry run should not save the config")
+	}
+
+	if _, err = runAlertsCmd(t, runAlertsImport, alertsImportCmd, path); err == nil {
+		t.Error("expected an error reporting the invalid rule")
+	}
+	cfg, _ := config.Load()
+	byID := make(map[string]config.AlertRuleConfig)
+	for _, r := range cfg.Alerts.Rules {
+		byID[r.ID] = r
+	}
+	if _, ok := byID["low"]; !ok {
+		t.Error("valid rule should be imported alongside an invalid one")
+	}
+	if _, ok := byID["bad"]; ok {
+		t.Error("invalid rule should not be imported")
+	}
+	if byID[f.Rules[0].ID].Priority == f.Rules[0].Priority {
+		t.Error("existing rule should be kept without --overwrite")
+	}
+
+	alertsImportOverwrite = true
+	_, _ = runAlertsCmd(t, runAlertsImport, alertsImportCmd, path)
+	alertsImportOverwrite = false
+	cfg, _ = config.Load()
+	for _, r := range cfg.Alerts.Rules {
+		if r.ID == f.Rules[0].ID && r.Priority != f.Rules[0].Priority {
+			t.Error("--overwrite should replace the existing rule")
+		}
+	}
+}
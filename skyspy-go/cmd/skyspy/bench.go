@@ -0,0 +1,261 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/sim"
+	"github.com/spf13/cobra"
+)
+
+// benchSeed fixes the synthetic traffic so runs are comparable
+const benchSeed = 1
+
+var (
+	benchAircraft int
+	benchDuration time.Duration
+	benchOverlays []string
+	benchTrails   bool
+	benchJSON     bool
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Measure rendering and ingestion speed on this machine",
+	Long: `Run the radar headlessly against synthetic traffic and report how fast
+this machine ingests feed messages and renders frames. No server is
+needed.
+
+Each aircraft reports once a second, and frames are drawn at the
+configured refresh rate, using your theme, receiver position, range and
+overlays. Frames that take longer than the refresh interval are counted
+as late; any late frames mean the radar will lag on this machine.
+
+Examples:
+  skyspy bench
+  skyspy bench --aircraft 500 --duration 1m
+  skyspy bench --overlay airspace.geojson --trails=false
+  skyspy bench --json > bench.json`,
+	RunE: runBench,
+}
+
+// RegisterBenchFlags sets up the bench command flags.
+// Call this from the main command initialization.
+func RegisterBenchFlags() {
+	benchCmd.Flags().IntVar(&benchAircraft, "aircraft", 200, "Number of synthetic aircraft")
+	benchCmd.Flags().DurationVar(&benchDuration, "duration", 30*time.Second, "How long to run")
+	benchCmd.Flags().StringSliceVar(&benchOverlays, "overlay", []string{}, "Also load overlay file (GeoJSON/Shapefile)")
+	benchCmd.Flags().BoolVar(&benchTrails, "trails", true, "Draw aircraft trails")
+	benchCmd.Flags().BoolVar(&benchJSON, "json", false, "Print machine-readable JSON")
+}
+
+// benchResult is the report printed by `skyspy bench`
+type benchResult struct {
+	Aircraft       int     `json:"aircraft"`
+	Overlays       int     `json:"overlays"`
+	Trails         bool    `json:"trails"`
+	RefreshRate    int     `json:"refresh_rate"` // frames per second
+	Seconds        float64 `json:"seconds"`
+	Frames         int     `json:"frames"`
+	LateFrames     int     `json:"late_frames"` // frames that overran the refresh interval
+	Messages       int     `json:"messages"`
+	MessageRate    float64 `json:"message_rate"` // messages ingested per second of ingest time
+	FrameMeanMS    float64 `json:"frame_mean_ms"`
+	FrameP95MS     float64 `json:"frame_p95_ms"`
+	AllocsPerFrame float64 `json:"allocs_per_frame"` // heap allocations, including the frame's ingestion
+	BytesPerFrame  float64 `json:"bytes_per_frame"`
+	PeakRSS        uint64  `json:"peak_rss_bytes,omitempty"` // zero where the OS doesn't report it
+}
+
+// benchOptions describes one bench run
+type benchOptions struct {
+	aircraft    int
+	duration    time.Duration
+	refreshRate int
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	if benchAircraft < 1 {
+		return fmt.Errorf("--aircraft must be at least 1")
+	}
+	if benchDuration <= 0 {
+		return fmt.Errorf("--duration must be positive")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	cfg.Display.ShowTrails = benchTrails
+
+	// Unlike the radar, fail on an overlay that can't be read: the numbers
+	// would be for a different workload
+	for _, ov := range benchOverlays {
+		absPath, absErr := filepath.Abs(ov)
+		if absErr != nil {
+			absPath = ov
+		}
+		if _, loadErr := geo.LoadOverlay(absPath); loadErr != nil {
+			return fmt.Errorf("overlay %s: %w", ov, loadErr)
+		}
+		cfg.Overlays.Overlays = append(cfg.Overlays.Overlays, config.OverlayConfig{
+			Path:    absPath,
+			Enabled: true,
+		})
+	}
+
+	result := runBenchLoop(cfg, benchOptions{
+		aircraft:    benchAircraft,
+		duration:    benchDuration,
+		refreshRate: benchRefreshRate(cfg),
+	})
+	return writeBenchResult(cmd.OutOrStdout(), result, benchJSON)
+}
+
+// benchRefreshRate is the configured refresh rate, kept to the 1-60 Hz
+// the settings allow
+func benchRefreshRate(cfg *config.Config) int {
+	rate := cfg.Display.RefreshRate
+	switch {
+	case rate < 1:
+		return 10
+	case rate > 60:
+		return 60
+	}
+	return rate
+}
+
+// runBenchLoop drives a headless radar model with synthetic traffic for the
+// run's duration, one frame per refresh interval
+func runBenchLoop(cfg *config.Config, opts benchOptions) benchResult {
+	model := app.NewModel(cfg)
+	model.SetAudioEnabled(false)
+	model.LoadOverlays()
+
+	traffic := sim.NewTraffic(opts.aircraft, cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon,
+		float64(cfg.Radar.DefaultRange), benchSeed)
+	model.IngestAircraftMessage(traffic.Snapshot())
+
+	interval := time.Second / time.Duration(opts.refreshRate)
+	result := benchResult{
+		Aircraft:    opts.aircraft,
+		Overlays:    len(cfg.Overlays.Overlays),
+		Trails:      cfg.Display.ShowTrails,
+		RefreshRate: opts.refreshRate,
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	var frameTimes []time.Duration
+	var ingestTime time.Duration
+	// Spread each second's reports evenly over its frames
+	perFrame := float64(opts.aircraft) / float64(opts.refreshRate)
+	due, next := 0.0, 0
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	start := time.Now()
+	deadline := start.Add(opts.duration)
+
+	for time.Now().Before(deadline) {
+		frameStart := time.Now()
+		traffic.Step(interval)
+
+		due += perFrame
+		for ; due >= 1; due-- {
+			// Encoding is the server's work, so it stays out of the timing
+			msg := traffic.Update(next)
+			next = (next + 1) % traffic.Len()
+			t0 := time.Now()
+			model.IngestAircraftMessage(msg)
+			ingestTime += time.Since(t0)
+			result.Messages++
+		}
+
+		t0 := time.Now()
+		_ = model.RenderFrame()
+		frameTimes = append(frameTimes, time.Since(t0))
+
+		if time.Since(frameStart) > interval {
+			result.LateFrames++
+		}
+		<-ticker.C
+	}
+
+	result.Seconds = time.Since(start).Seconds()
+	runtime.ReadMemStats(&after)
+
+	result.Frames = len(frameTimes)
+	if ingestTime > 0 {
+		result.MessageRate = float64(result.Messages) / ingestTime.Seconds()
+	}
+	result.FrameMeanMS, result.FrameP95MS = frameStats(frameTimes)
+	if result.Frames > 0 {
+		result.AllocsPerFrame = float64(after.Mallocs-before.Mallocs) / float64(result.Frames)
+		result.BytesPerFrame = float64(after.TotalAlloc-before.TotalAlloc) / float64(result.Frames)
+	}
+	result.PeakRSS = peakRSS()
+	return result
+}
+
+// frameStats returns the mean and 95th percentile of the frame times in
+// milliseconds
+func frameStats(times []time.Duration) (mean, p95 float64) {
+	if len(times) == 0 {
+		return 0, 0
+	}
+	sorted := append([]time.Duration(nil), times...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, t := range sorted {
+		total += t
+	}
+	// Nearest rank: the smallest time at least 95% of frames don't exceed
+	rank := (len(sorted)*95 + 99) / 100
+	return durationMS(total) / float64(len(sorted)), durationMS(sorted[rank-1])
+}
+
+func durationMS(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// writeBenchResult prints the report as a table or JSON
+func writeBenchResult(w io.Writer, r benchResult, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+
+	trails := "off"
+	if r.Trails {
+		trails = "on"
+	}
+	rss := "n/a"
+	if r.PeakRSS > 0 {
+		rss = fmt.Sprintf("%.1f MB", float64(r.PeakRSS)/(1024*1024))
+	}
+
+	fmt.Fprintf(w, "Workload:    %d aircraft, %d overlays, trails %s\n", r.Aircraft, r.Overlays, trails)
+	fmt.Fprintf(w, "Run:         %.1fs at %d Hz\n", r.Seconds, r.RefreshRate)
+	fmt.Fprintf(w, "Frames:      %d (%d late)\n", r.Frames, r.LateFrames)
+	fmt.Fprintf(w, "Ingest:      %.0f msgs/s (%d messages)\n", r.MessageRate, r.Messages)
+	fmt.Fprintf(w, "Frame mean:  %.2f ms\n", r.FrameMeanMS)
+	fmt.Fprintf(w, "Frame p95:   %.2f ms\n", r.FrameP95MS)
+	fmt.Fprintf(w, "Allocs:      %.0f/frame (%.1f KB)\n", r.AllocsPerFrame, r.BytesPerFrame/1024)
+	fmt.Fprintf(w, "Peak RSS:    %s\n", rss)
+	return nil
+}
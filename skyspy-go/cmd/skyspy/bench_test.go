@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func TestRunBenchLoop(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Connection.ReceiverLat = 40.7
+	cfg.Connection.ReceiverLon = -74
+	cfg.Display.ShowTrails = true
+
+	result := runBenchLoop(cfg, benchOptions{aircraft: 40, duration: 300 * time.Millisecond, refreshRate: 20})
+
+	if result.Aircraft != 40 || !result.Trails || result.RefreshRate != 20 {
+		t.Errorf("workload not recorded: %+v", result)
+	}
+	if result.Frames < 2 {
+		t.Fatalf("expected several frames, got %d", result.Frames)
+	}
+	// Each aircraft reports once a second: two per frame at 20 Hz
+	if result.Messages != result.Frames*2 {
+		t.Errorf("expected %d messages for %d frames, got %d", result.Frames*2, result.Frames, result.Messages)
+	}
+	if result.MessageRate <= 0 || result.FrameMeanMS <= 0 || result.FrameP95MS <= 0 {
+		t.Errorf("expected timings, got %+v", result)
+	}
+	if result.AllocsPerFrame <= 0 || result.BytesPerFrame <= 0 {
+		t.Errorf("expected allocation counts, got %+v", result)
+	}
+}
+
+func TestBenchRefreshRate(t *testing.T) {
+	cfg := config.DefaultConfig()
+	for _, tt := range []struct{ set, want int }{{0, 10}, {-5, 10}, {30, 30}, {120, 60}} {
+		cfg.Display.RefreshRate = tt.set
+		if got := benchRefreshRate(cfg); got != tt.want {
+			t.Errorf("refresh rate %d: got %d, want %d", tt.set, got, tt.want)
+		}
+	}
+}
+
+func TestFrameStats(t *testing.T) {
+	if mean, p95 := frameStats(nil); mean != 0 || p95 != 0 {
+		t.Errorf("no frames should give zeros, got %f %f", mean, p95)
+	}
+
+	// 1ms..20ms: mean 10.5ms, and 19 of 20 frames take 19ms or less
+	var times []time.Duration
+	for i := 20; i >= 1; i-- {
+		times = append(times, time.Duration(i)*time.Millisecond)
+	}
+	mean, p95 := frameStats(times)
+	if mean != 10.5 {
+		t.Errorf("mean: got %f, want 10.5", mean)
+	}
+	if p95 != 19 {
+		t.Errorf("p95: got %f, want 19", p95)
+	}
+	if times[0] != 20*time.Millisecond {
+		t.Error("frameStats should not reorder its input")
+	}
+}
+
+func TestWriteBenchResult(t *testing.T) {
+	result := benchResult{
+		Aircraft:       300,
+		Overlays:       1,
+		Trails:         true,
+		RefreshRate:    10,
+		Seconds:        30,
+		Frames:         300,
+		LateFrames:     4,
+		Messages:       9000,
+		MessageRate:    25000,
+		FrameMeanMS:    4.5,
+		FrameP95MS:     7.25,
+		AllocsPerFrame: 12000,
+		BytesPerFrame:  512 * 1024,
+	}
+
+	var buf bytes.Buffer
+	if err := writeBenchResult(&buf, result, false); err != nil {
+		t.Fatalf("writeBenchResult: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"300 aircraft, 1 overlays, trails on",
+		"300 (4 late)",
+		"25000 msgs/s",
+		"7.25 ms",
+		"12000/frame (512.0 KB)",
+		"Peak RSS:    n/a",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	result.PeakRSS = 64 * 1024 * 1024
+	if err := writeBenchResult(&buf, result, true); err != nil {
+		t.Fatalf("writeBenchResult: %v", err)
+	}
+	var decoded benchResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded != result {
+		t.Errorf("JSON round trip: got %+v, want %+v", decoded, result)
+	}
+}
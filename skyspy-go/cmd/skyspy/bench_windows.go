@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+// peakRSS returns zero: Windows doesn't report it through the syscall package
+func peakRSS() uint64 {
+	return 0
+}
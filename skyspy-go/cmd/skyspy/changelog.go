@@ -0,0 +1,93 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/skyspy/skyspy-go/internal/changelog"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// version is the release this binary was built from, set by the Makefile
+// through -ldflags
+var version = "dev"
+
+var changelogSince string
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Show what changed in each release",
+	Long: `Print the changelog built into this binary, newest release first.
+
+Examples:
+  skyspy changelog
+  skyspy changelog --since 0.1.0`,
+	Args: cobra.NoArgs,
+	RunE: runChangelog,
+}
+
+// RegisterChangelogFlags sets up the changelog command flags.
+// Call this from the main command initialization.
+func RegisterChangelogFlags() {
+	changelogCmd.Flags().StringVar(&changelogSince, "since", "", "Only list releases newer than this version")
+}
+
+func runChangelog(cmd *cobra.Command, args []string) error {
+	entries := changelog.Entries()
+	if changelogSince != "" {
+		since, err := changelog.Parse(changelogSince)
+		if err != nil {
+			return err
+		}
+		var newer []changelog.Entry
+		for _, e := range entries {
+			if v, err := changelog.Parse(e.Version); err == nil && v.Compare(since) > 0 {
+				newer = append(newer, e)
+			}
+		}
+		entries = newer
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No changes listed")
+		return nil
+	}
+	return changelog.Write(cmd.OutOrStdout(), entries)
+}
+
+// pendingChangelog returns the changelog entries to show on this launch
+// and records the running release as seen. A fresh install records the
+// release without showing anything. Nothing is shown or recorded for
+// pre-release and development builds, or when the UI isn't on a terminal,
+// so the notes wait for the next interactive launch.
+func pendingChangelog(current, path string, interactive bool) []changelog.Entry {
+	cur, err := changelog.Parse(current)
+	if err != nil || cur.IsPrerelease() || !interactive {
+		return nil
+	}
+
+	seen := changelog.ReadSeen(path)
+	entries := changelog.Since(seen, current)
+	if last, err := changelog.Parse(seen); err != nil || cur.Compare(last) > 0 {
+		_ = changelog.WriteSeen(path, cur.String())
+	}
+	return entries
+}
+
+// isInteractive reports whether stdin and stdout are both terminals
+func isInteractive() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// showChangelog returns the entries for the "What's new" panel on this
+// launch
+func showChangelog() []changelog.Entry {
+	return pendingChangelog(version, config.GetVersionFilePath(), isInteractive())
+}
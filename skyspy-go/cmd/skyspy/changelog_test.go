@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/changelog"
+)
+
+func TestPendingChangelog(t *testing.T) {
+	latest := changelog.Entries()[0].Version
+	path := filepath.Join(t.TempDir(), "last_version")
+
+	// A fresh install records the release without showing anything
+	if got := pendingChangelog(latest, path, true); got != nil {
+		t.Errorf("fresh install should show nothing, got %+v", got)
+	}
+	if got := changelog.ReadSeen(path); got != latest {
+		t.Fatalf("expected %s recorded, got %q", latest, got)
+	}
+
+	// Upgrading shows the notes once
+	_ = changelog.WriteSeen(path, "0.1.0")
+	if got := pendingChangelog(latest, path, true); len(got) == 0 {
+		t.Error("expected notes after an upgrade")
+	}
+	if got := pendingChangelog(latest, path, true); got != nil {
+		t.Errorf("notes should only show once, got %+v", got)
+	}
+}
+
+func TestPendingChangelog_Skipped(t *testing.T) {
+	latest := changelog.Entries()[0].Version
+	tests := []struct {
+		name        string
+		current     string
+		interactive bool
+	}{
+		{"not a terminal", latest, false},
+		{"pre-release", latest + "-rc.1", true},
+		{"development build", "dev", true},
+		{"git describe build", "v" + latest + "-4-gabc1234", true},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "last_version")
+		_ = changelog.WriteSeen(path, "0.1.0")
+		if got := pendingChangelog(tt.current, path, tt.interactive); got != nil {
+			t.Errorf("%s: expected nothing shown, got %+v", tt.name, got)
+		}
+		if got := changelog.ReadSeen(path); got != "0.1.0" {
+			t.Errorf("%s: seen version should be unchanged, got %q", tt.name, got)
+		}
+	}
+}
+
+func TestRunChangelog(t *testing.T) {
+	var out bytes.Buffer
+	changelogCmd.SetOut(&out)
+	defer changelogCmd.SetOut(nil)
+
+	if err := runChangelog(changelogCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "v"+changelog.Entries()[0].Version) {
+		t.Errorf("expected the latest release listed, got %q", out.String())
+	}
+
+	out.Reset()
+	changelogSince = "999.0.0"
+	defer func() { changelogSince = "" }()
+	if err := runChangelog(changelogCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "No changes") {
+		t.Errorf("expected no changes after a future version, got %q", out.String())
+	}
+
+	changelogSince = "not-a-version"
+	if err := runChangelog(changelogCmd, nil); err == nil {
+		t.Error("expected an error for an invalid --since")
+	}
+}
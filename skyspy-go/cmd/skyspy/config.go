@@ -0,0 +1,97 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/audio"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/skyspy/skyspy-go/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Check the settings file",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the settings file and the sound files it uses",
+	Long: `Check the settings file for problems the radar would otherwise skip over:
+JSON that doesn't parse, alert rules and geofences that can't be used,
+alert sounds that are missing or can't be decoded, and an unknown distance
+unit or session log format.
+
+Sound files are WAV files named by an alert rule's sound action, either as
+an absolute path or relative to ~/.config/skyspy/sounds.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+// RegisterConfigCommands sets up the config command hierarchy.
+// Call this from the main command initialization.
+func RegisterConfigCommands() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	path := config.GetConfigPath()
+
+	cfg := config.DefaultConfig()
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		fmt.Fprintf(out, "No settings file at %s; the defaults are used\n", path)
+	case err != nil:
+		return err
+	default:
+		if err := json.Unmarshal(data, cfg); err != nil {
+			fmt.Fprintf(out, "%s: %v\n", path, err)
+			return errors.New("the settings file doesn't parse; the radar would use the defaults")
+		}
+	}
+
+	problems := 0
+	report := func(format string, args ...interface{}) {
+		fmt.Fprintf(out, format+"\n", args...)
+		problems++
+	}
+
+	for _, rc := range cfg.Alerts.Rules {
+		if err := app.ValidateAlertRule(rc); err != nil {
+			report("rule %s: %v", rc.ID, err)
+		}
+	}
+	for _, gc := range cfg.Alerts.Geofences {
+		if err := app.ValidateGeofence(gc); err != nil {
+			report("geofence %s: %v", gc.ID, err)
+		}
+	}
+	if u := cfg.Display.Units; u != "" && units.Distance(u) != u {
+		report("display.units %q: want nm, km or mi", u)
+	}
+	if format := cfg.Export.AutoExport.Format; format != "" && export.SessionFormat(format) != format {
+		report("export.auto_export.format %q: want ndjson or csv", format)
+	}
+	for _, sound := range app.RuleSoundFiles(cfg) {
+		if _, err := audio.LoadSoundFile(audio.ResolveSoundFile(sound, config.GetSoundsDir())); err != nil {
+			report("sound %s: %v", sound, err)
+		}
+	}
+
+	switch {
+	case problems == 1:
+		return fmt.Errorf("1 problem in %s", path)
+	case problems > 1:
+		return fmt.Errorf("%d problems in %s", problems, path)
+	}
+	fmt.Fprintf(out, "%s is valid\n", path)
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// silentWAV returns a short 16-bit mono WAV file
+func silentWAV() []byte {
+	samples := make([]byte, 1600)
+	out := []byte("RIFF\x00\x00\x00\x00WAVEfmt \x10\x00\x00\x00")
+	out = binary.LittleEndian.AppendUint16(out, 1)
+	out = binary.LittleEndian.AppendUint16(out, 1)
+	out = binary.LittleEndian.AppendUint32(out, 8000)
+	out = binary.LittleEndian.AppendUint32(out, 16000)
+	out = binary.LittleEndian.AppendUint16(out, 2)
+	out = binary.LittleEndian.AppendUint16(out, 16)
+	out = append(out, "data"...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(samples)))
+	out = append(out, samples...)
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out
+}
+
+func TestConfigValidate(t *testing.T) {
+	useTempConfig(t)
+
+	out, err := runAlertsCmd(t, runConfigValidate, configValidateCmd)
+	if err != nil || !strings.Contains(out, "No settings file") {
+		t.Fatalf("without a settings file: %q, %v", out, err)
+	}
+
+	sounds := config.GetSoundsDir()
+	if err := os.MkdirAll(sounds, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sounds, "good.wav"), silentWAV(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sounds, "bad.wav"), []byte("not audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rule := func(id, sound string) config.AlertRuleConfig {
+		return config.AlertRuleConfig{ID: id, Name: id, Enabled: true,
+			Conditions: []config.ConditionConfig{{Type: "military", Value: "true"}},
+			Actions:    []config.ActionConfig{{Type: "sound", Sound: sound}}}
+	}
+	cfg := config.DefaultConfig()
+	cfg.Alerts.Rules = []config.AlertRuleConfig{rule("good", "good.wav"), rule("warn", "warning")}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runAlertsCmd(t, runConfigValidate, configValidateCmd)
+	if err != nil || !strings.Contains(out, "is valid") {
+		t.Fatalf("valid settings: %q, %v", out, err)
+	}
+
+	cfg.Alerts.Rules = append(cfg.Alerts.Rules, rule("bad", "bad.wav"), rule("gone", "gone.wav"))
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runAlertsCmd(t, runConfigValidate, configValidateCmd)
+	if err == nil || !strings.Contains(err.Error(), "2 problems") {
+		t.Fatalf("bad sound files: err = %v", err)
+	}
+	if !strings.Contains(out, "sound bad.wav: ") || !strings.Contains(out, "sound gone.wav: ") {
+		t.Errorf("both bad files should be named:\n%s", out)
+	}
+
+	if err := os.WriteFile(config.GetConfigPath(), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runAlertsCmd(t, runConfigValidate, configValidateCmd); err == nil || !strings.Contains(err.Error(), "doesn't parse") {
+		t.Errorf("malformed settings: err = %v", err)
+	}
+}
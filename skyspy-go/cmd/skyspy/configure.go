@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/skyspy/skyspy-go/internal/config"
 	"github.com/skyspy/skyspy-go/internal/theme"
+	"github.com/skyspy/skyspy-go/internal/units"
 	"github.com/spf13/cobra"
 )
 
@@ -21,7 +22,7 @@ var configureCmd = &cobra.Command{
 The wizard guides you through configuring:
   - Connection settings (server host, port, receiver location)
   - Display settings (theme, labels, trails, panels)
-  - Radar settings (range, rings, compass)
+  - Radar settings (range, distance units, rings, compass)
   - Audio settings (alerts, sounds)
 
 Settings are saved to ~/.config/skyspy/settings.json
@@ -57,6 +58,7 @@ const (
 	fieldNameRefreshRate  = "refresh_rate"
 	fieldNameDefaultRange = "default_range"
 	fieldNameRangeRings   = "range_rings"
+	fieldNameUnits        = "units"
 	valueOff              = "OFF"
 )
 
@@ -193,8 +195,16 @@ func newWizardModel(cfg *config.Config) wizardModel {
 	}
 
 	// Radar section
+	unitIndex := 0
+	for i, u := range units.DistanceUnits {
+		if u == units.Distance(cfg.Display.Units) {
+			unitIndex = i
+		}
+	}
 	m.fields[sectionRadar] = []wizardField{
 		m.createNumberField(fieldNameDefaultRange, "Default Range (nm)", "Initial radar range in nautical miles", cfg.Radar.DefaultRange),
+		m.createSelectField(fieldNameUnits, "Distance Units", "Unit for ranges and distances on screen and in exports",
+			[]string{"Nautical miles (nm)", "Kilometers (km)", "Statute miles (mi)"}, units.DistanceUnits, unitIndex),
 		m.createNumberField(fieldNameRangeRings, "Range Rings", "Number of concentric range rings (0-10)", cfg.Radar.RangeRings),
 		m.createNumberField("sweep_speed", "Sweep Speed", "Radar sweep animation speed (1-20)", cfg.Radar.SweepSpeed),
 		m.createBoolField("show_compass", "Show Compass", "Display compass rose around radar", cfg.Radar.ShowCompass),
@@ -208,6 +218,8 @@ func newWizardModel(cfg *config.Config) wizardModel {
 		m.createBoolField("new_aircraft_sound", "New Aircraft Sound", "Play sound for new aircraft", cfg.Audio.NewAircraftSound),
 		m.createBoolField("emergency_sound", "Emergency Sound", "Play sound for emergency squawks", cfg.Audio.EmergencySound),
 		m.createBoolField("military_sound", "Military Sound", "Play sound for military aircraft", cfg.Audio.MilitarySound),
+		m.createBoolField("audio_pan", "Stereo Pan", "Pan alerts left or right toward the aircraft", cfg.Audio.Pan),
+		m.createFloatField("audio_facing", "Facing (deg)", "Direction you face when panning (0 = north)", cfg.Audio.Facing),
 	}
 
 	// Summary section (no fields)
@@ -511,6 +523,10 @@ func (m *wizardModel) applyFields() {
 			if v, err := strconv.Atoi(f.textInput.Value()); err == nil {
 				m.cfg.Radar.DefaultRange = v
 			}
+		case fieldNameUnits:
+			if f.selectIndex < len(f.optionKeys) {
+				m.cfg.Display.Units = f.optionKeys[f.selectIndex]
+			}
 		case fieldNameRangeRings:
 			if v, err := strconv.Atoi(f.textInput.Value()); err == nil {
 				m.cfg.Radar.RangeRings = v
@@ -539,6 +555,12 @@ func (m *wizardModel) applyFields() {
 			m.cfg.Audio.EmergencySound = f.boolValue
 		case "military_sound":
 			m.cfg.Audio.MilitarySound = f.boolValue
+		case "audio_pan":
+			m.cfg.Audio.Pan = f.boolValue
+		case "audio_facing":
+			if v, err := strconv.ParseFloat(f.textInput.Value(), 64); err == nil {
+				m.cfg.Audio.Facing = v
+			}
 		}
 	}
 }
@@ -741,6 +763,8 @@ func (m wizardModel) renderSummary() string {
 			} else {
 				value = valueOff
 			}
+		case fieldSelect:
+			value = f.optionKeys[f.selectIndex]
 		}
 		b.WriteString(fmt.Sprintf("    %s: %s\n", m.dimStyle.Render(f.label), m.valueStyle.Render(value)))
 	}
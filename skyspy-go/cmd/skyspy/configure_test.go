@@ -540,10 +540,17 @@ func TestWizardApplyFieldsRadar(t *testing.T) {
 		if f.name == "show_compass" {
 			m.fields[sectionRadar][i].boolValue = false
 		}
+		if f.name == "units" {
+			m.fields[sectionRadar][i].selectIndex = 1
+		}
 	}
 
 	m.applyFields()
 
+	if cfg.Display.Units != "km" {
+		t.Errorf("Expected Units to be km, got %q", cfg.Display.Units)
+	}
+
 	if cfg.Radar.DefaultRange != 200 {
 		t.Errorf("Expected DefaultRange to be 200, got %d", cfg.Radar.DefaultRange)
 	}
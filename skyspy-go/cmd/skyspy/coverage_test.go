@@ -598,8 +598,9 @@ func TestExportDirWithInvalidPath(t *testing.T) {
 	}
 }
 
-// TestRunWithAuthRequired tests run when auth is required but not provided
-// This should fail before the TUI starts, allowing us to test the auth check path
+// TestRunWithAuthRequired tests run when auth is required but not provided.
+// The auth check no longer aborts; the TUI starts on its connection error
+// screen (and fails here for want of a TTY).
 func TestRunWithAuthRequired(t *testing.T) {
 	_, cleanup := testutil.TempConfigDirWithEnv()
 	defer cleanup()
@@ -636,13 +637,8 @@ func TestRunWithAuthRequired(t *testing.T) {
 	// Capture output
 	output := testutil.CaptureOutput(func() {
 		err := run(rootCmd, []string{})
-		// Should fail with "authentication required" error
-		if err == nil {
-			t.Log("Expected error but got none - server might be in public mode")
-		} else {
-			if !contains(err.Error(), "authentication required") {
-				t.Logf("Expected 'authentication required' error, got: %v", err)
-			}
+		if err != nil && contains(err.Error(), "authentication required") {
+			t.Errorf("auth check should not abort before the TUI, got: %v", err)
 		}
 	})
 
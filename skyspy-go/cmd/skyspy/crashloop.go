@@ -0,0 +1,50 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+)
+
+const (
+	// crashLoopStarts is how many unfinished startups in a row suggest
+	// --safe-mode
+	crashLoopStarts = 3
+	// startupGrace is how long the radar must run for its start to count
+	// as clean
+	startupGrace = 30 * time.Second
+)
+
+// startupCapabilities returns the optional subsystems to start: none with
+// --safe-mode, otherwise whatever the settings turn on
+func startupCapabilities() app.Capabilities {
+	if safeMode {
+		return app.SafeCapabilities()
+	}
+	return app.FullCapabilities()
+}
+
+// beginStartup counts a start in the marker file at path and returns how
+// many starts in a row before it didn't finish. A start finishes when the
+// radar has run for startupGrace or exits cleanly; one that crashes first
+// leaves its count behind.
+func beginStartup(path string) int {
+	failed := 0
+	if data, err := os.ReadFile(path); err == nil {
+		failed, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+		_ = os.WriteFile(path, []byte(strconv.Itoa(failed+1)+"\n"), 0o644)
+	}
+	return failed
+}
+
+// finishStartup records that the start was clean
+func finishStartup(path string) {
+	_ = os.Remove(path)
+}
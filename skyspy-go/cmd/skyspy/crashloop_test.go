@@ -0,0 +1,36 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStartupMarker_CountsUnfinishedStarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skyspy", "startup_failures")
+	for want := 0; want < crashLoopStarts+1; want++ {
+		if got := beginStartup(path); got != want {
+			t.Fatalf("start %d: %d failed before it, want %d", want+1, got, want)
+		}
+	}
+
+	finishStartup(path)
+	if got := beginStartup(path); got != 0 {
+		t.Errorf("a clean start should reset the count, got %d", got)
+	}
+}
+
+func TestSafeModeFlag(t *testing.T) {
+	// Flags are registered by SetupCommands in TestMain
+	if rootCmd.Flag("safe-mode") == nil {
+		t.Fatal("expected --safe-mode flag")
+	}
+	defer func() { safeMode = false }()
+	safeMode = true
+	if caps := startupCapabilities(); caps.Audio || caps.Overlays || caps.SaveSettings {
+		t.Errorf("safe mode should turn the optional subsystems off: %+v", caps)
+	}
+	safeMode = false
+	if caps := startupCapabilities(); len(caps.Skipped()) != 0 {
+		t.Errorf("without safe mode nothing should be skipped: %v", caps.Skipped())
+	}
+}
@@ -0,0 +1,144 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/auth"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	debugDumpStripPosition bool
+	debugDumpWait          time.Duration
+	debugDumpWidth         int
+	debugDumpHeight        int
+
+	// --load-state starts the radar on a state file instead of the feed
+	loadState string
+)
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Tools for bug reports",
+	Long: `Capture the radar's state for a bug report, so the screen can be
+reproduced without your feed.
+
+A state file holds your settings (minus the kiosk unlock keys and server
+API keys), the aircraft being tracked and their trails, the filter, view,
+terminal size and theme, and the last 100 debug log entries, as gzipped
+JSON. API keys and tokens are scrubbed from the log entries. Press Ctrl+D
+in the radar to write one of what's on screen.
+
+Examples:
+  skyspy debug dump state.json.gz
+  skyspy debug dump state.json.gz --strip-position
+  skyspy --load-state state.json.gz`,
+}
+
+var debugDumpCmd = &cobra.Command{
+	Use:   "dump <file>",
+	Short: "Write a state file from the live feed",
+	Long: `Connect to the server, wait for the first snapshot and write the
+radar's state to a file, as Ctrl+D does in the radar.
+
+With --strip-position, or when privacy mode is on, the receiver position is
+replaced by the approximate one privacy mode shows.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDebugDump,
+}
+
+// RegisterDebugCommands sets up the debug command hierarchy.
+// Call this from the main command initialization.
+func RegisterDebugCommands() {
+	debugDumpCmd.Flags().BoolVar(&debugDumpStripPosition, "strip-position", false, "Replace the receiver position with an approximate (~10km) one")
+	debugDumpCmd.Flags().DurationVar(&debugDumpWait, "wait", 10*time.Second, "How long to wait for the first snapshot")
+	debugDumpCmd.Flags().IntVar(&debugDumpWidth, "width", 100, "Terminal width to record, in columns")
+	debugDumpCmd.Flags().IntVar(&debugDumpHeight, "height", 55, "Terminal height to record, in rows")
+	debugCmd.AddCommand(debugDumpCmd)
+}
+
+func runDebugDump(cmd *cobra.Command, args []string) error {
+	if debugDumpWidth < 1 || debugDumpHeight < 1 {
+		return fmt.Errorf("--width and --height must be positive")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if host != "" {
+		cfg.Connection.Host = host
+	}
+	if port != 0 {
+		cfg.Connection.Port = port
+	}
+
+	authMgr, err := auth.NewManager(cfg.Connection.Host, cfg.Connection.Port)
+	if err != nil {
+		return fmt.Errorf("failed to initialize auth: %w", err)
+	}
+	if authMgr.RequiresAuth() && !authMgr.IsAuthenticated() {
+		return fmt.Errorf("server requires authentication: run 'skyspy login' first")
+	}
+
+	client := newFeedClient(cfg, authMgr)
+	model := app.NewModelWithCapabilities(cfg, client, startupCapabilities())
+	model.SetAudioEnabled(false)
+	model.SetVersion(version)
+	if lat, lon, ok := authMgr.ReceiverPosition(); ok {
+		model.SetServerPosition(lat, lon)
+	}
+
+	client.Start()
+	defer client.Stop()
+
+	server := fmt.Sprintf("%s:%d", cfg.Connection.Host, cfg.Connection.Port)
+	if err := awaitSnapshot(model, client.AircraftMessages(), client.ACARSMessages(), debugDumpWait); err != nil {
+		return fmt.Errorf("%s: %w", server, err)
+	}
+	model.Update(tea.WindowSizeMsg{Width: debugDumpWidth, Height: debugDumpHeight})
+
+	st, err := model.DebugState()
+	if err != nil {
+		return err
+	}
+	st.Sanitize(!debugDumpStripPosition && !cfg.Display.PrivacyMode)
+	if err := export.WriteState(st, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote the state of %d aircraft to %s\n", len(st.Aircraft), args[0])
+	return nil
+}
+
+// runLoadState starts the radar on a state file with no feed, at the size
+// it was written at. With --once the frame is printed instead, cut to
+// --width and --height if they are given.
+func runLoadState(cmd *cobra.Command, path string) error {
+	st, err := export.ReadState(path)
+	if err != nil {
+		return err
+	}
+	model := app.NewModelFromState(st)
+
+	if once {
+		width, height := st.View.Width, st.View.Height
+		if cmd.Flags().Changed("width") || width < 1 {
+			width = onceWidth
+		}
+		if cmd.Flags().Changed("height") || height < 1 {
+			height = onceHeight
+		}
+		_, err := io.WriteString(cmd.OutOrStdout(), renderOnce(model, width, height, !onceNoColor))
+		return err
+	}
+
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	_, err = p.Run()
+	return err
+}
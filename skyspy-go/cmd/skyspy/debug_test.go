@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/export"
+)
+
+func TestLoadState_Once(t *testing.T) {
+	model := statusTestModel()
+	lat, lon := 0.2, 0.2
+	model.IngestAircraftMessage(statusTestMessage(t, codec.AircraftSnapshot, []codec.Aircraft{{Hex: "AAA001", Flight: "KLM123", Lat: &lat, Lon: &lon}}))
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	st, err := model.DebugState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "state.json.gz")
+	if err := export.WriteState(st, path); err != nil {
+		t.Fatal(err)
+	}
+
+	once, onceNoColor = true, true
+	t.Cleanup(func() { once, onceNoColor = false, false })
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+
+	if err := runLoadState(rootCmd, path); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 40 {
+		t.Errorf("the frame should be the reporter's 40 rows, got %d", len(lines))
+	}
+	if !strings.Contains(out.String(), "KLM123") {
+		t.Errorf("the loaded aircraft should be drawn:\n%s", out.String())
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w > 120 {
+			t.Errorf("line %d is %d columns wide", i, w)
+		}
+	}
+}
+
+func TestLoadState_Unreadable(t *testing.T) {
+	if err := runLoadState(rootCmd, filepath.Join(t.TempDir(), "missing.json.gz")); err == nil {
+		t.Error("a missing state file should be an error")
+	}
+}
+
+func TestDebugFlags(t *testing.T) {
+	// Flags are registered by SetupCommands in TestMain
+	if rootCmd.Flag("load-state") == nil {
+		t.Error("expected --load-state flag")
+	}
+	for _, name := range []string{"strip-position", "wait", "width", "height"} {
+		if debugDumpCmd.Flag(name) == nil {
+			t.Errorf("expected debug dump --%s flag", name)
+		}
+	}
+}
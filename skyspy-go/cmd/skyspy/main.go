@@ -2,15 +2,22 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/api"
 	"github.com/skyspy/skyspy-go/internal/app"
 	"github.com/skyspy/skyspy-go/internal/auth"
 	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/i18n"
 	"github.com/skyspy/skyspy-go/internal/theme"
+	"github.com/skyspy/skyspy-go/internal/ws"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +33,19 @@ var (
 	apiKey     string
 	exportDir  string
 	noAudio    bool
+	privacy    bool
+	ascii      bool
+	colorblind bool
+	kiosk      bool
+	safeMode   bool
+	logSession bool
+
+	// --once renders a single frame to stdout
+	once        bool
+	onceWait    time.Duration
+	onceWidth   int
+	onceHeight  int
+	onceNoColor bool
 )
 
 var rootCmd = &cobra.Command{
@@ -40,18 +60,34 @@ Authentication:
   skyspy login                    Authenticate with OIDC
   skyspy logout                   Clear stored credentials
   skyspy auth status              Show auth status
+  skyspy status [--json]          Show server status and exit
+  skyspy stream [--filter q]      Write live events as JSON Lines
+  skyspy alerts export <file>     Share alert rules and geofences
+  skyspy config validate          Check the settings file and sound files
+  skyspy changelog                Show what changed in each release
+  skyspy strings                  Check a translation of the UI strings
+  skyspy debug dump <file>        Write a state file for a bug report
+  skyspy replay <file> [--speed]  Play back aircraft exports on the radar
   skyspy --api-key sk_xxx         Use API key authentication
 
 Export:
   [P] Screenshot (HTML)           Export view as styled HTML
   [E] Export aircraft to CSV      Export current aircraft data
   [Ctrl+E] Export to JSON         Export current aircraft as JSON
+  [Ctrl+P] Export to GeoJSON      Export aircraft positions and trails
+  [Ctrl+D] State for bug report   Write a sanitized state file
+  [Y] Copy list rows              Copy visible target list as CSV (OSC 52)
 
 Examples:
   skyspy --theme cyberpunk
   skyspy --overlay airspace.geojson --overlay coastline.shp
   skyspy --lat 40.7128 --lon -74.0060 --range 50
-  skyspy --export-dir ~/exports`,
+  skyspy --kiosk
+  skyspy --safe-mode
+  skyspy --once --no-color > radar.txt
+  skyspy --load-state skyspy_state_20260301_120000.json.gz
+  skyspy --export-dir ~/exports
+  skyspy --log-session --export-dir ~/logs`,
 	RunE: run,
 }
 
@@ -73,12 +109,33 @@ func SetupCommands() {
 	rootCmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication (or use SKYSPY_API_KEY env)")
 	rootCmd.Flags().StringVar(&exportDir, "export-dir", "", "Directory for export files (default: current directory)")
 	rootCmd.Flags().BoolVar(&noAudio, "no-audio", false, "Disable audio alerts")
+	rootCmd.Flags().BoolVar(&privacy, "privacy", false, "Show an approximate (~10km) receiver position for screenshots and streams")
+	rootCmd.Flags().BoolVar(&ascii, "ascii", false, "Draw with 7-bit ASCII glyphs for terminals without Unicode fonts")
+	rootCmd.Flags().BoolVar(&colorblind, "colorblind", false, "Use colorblind-safe colors with shape cues over the theme")
+	rootCmd.Flags().BoolVar(&kiosk, "kiosk", false, "Lock settings, exports and quit for a public display (see kiosk.unlock)")
+	rootCmd.Flags().BoolVar(&logSession, "log-session", false, "Log every aircraft message to rotating files in the export directory (see export.auto_export)")
+	rootCmd.Flags().BoolVar(&safeMode, "safe-mode", false, "Start without audio, overlays, theme, alert rules, auto-exports or the local API, to find what stops SkySpy starting")
+	rootCmd.Flags().BoolVar(&once, "once", false, "Print one frame to stdout once the first snapshot arrives, then exit")
+	rootCmd.Flags().DurationVar(&onceWait, "wait", 10*time.Second, "With --once, how long to wait for the first snapshot")
+	rootCmd.Flags().IntVar(&onceWidth, "width", 100, "With --once, width of the frame in columns")
+	rootCmd.Flags().IntVar(&onceHeight, "height", 55, "With --once, height of the frame in rows")
+	rootCmd.Flags().BoolVar(&onceNoColor, "no-color", false, "With --once, print the frame without ANSI colors")
+	rootCmd.Flags().StringVar(&loadState, "load-state", "", "Show a state file from 'skyspy debug dump' or Ctrl+D instead of the live feed")
 
 	// Add subcommands
-	RegisterAuthCommands()  // Sets up auth command hierarchy
-	RegisterRadioFlags()    // Sets up radio command flags
-	RegisterRadioProFlags() // Sets up radio-pro command flags
-	RegisterAirbandFlags()  // Sets up airband command flags
+	RegisterAuthCommands()      // Sets up auth command hierarchy
+	RegisterRadioFlags()        // Sets up radio command flags
+	RegisterRadioProFlags()     // Sets up radio-pro command flags
+	RegisterAirbandFlags()      // Sets up airband command flags
+	RegisterServerStatusFlags() // Sets up status command flags
+	RegisterStreamFlags()       // Sets up stream command flags
+	RegisterAlertsCommands()    // Sets up alerts command hierarchy
+	RegisterConfigCommands()    // Sets up config command hierarchy
+	RegisterChangelogFlags()    // Sets up changelog command flags
+	RegisterBenchFlags()        // Sets up bench command flags
+	RegisterStringsFlags()      // Sets up strings command flags
+	RegisterReplayFlags()       // Sets up replay command flags
+	RegisterDebugCommands()     // Sets up debug command hierarchy
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(logoutCmd)
 	rootCmd.AddCommand(authCmd)
@@ -86,6 +143,15 @@ func SetupCommands() {
 	rootCmd.AddCommand(radioProCmd)
 	rootCmd.AddCommand(configureCmd)
 	rootCmd.AddCommand(airbandCmd)
+	rootCmd.AddCommand(serverStatusCmd)
+	rootCmd.AddCommand(streamCmd)
+	rootCmd.AddCommand(alertsCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(changelogCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(stringsCmd)
+	rootCmd.AddCommand(debugCmd)
+	rootCmd.AddCommand(replayCmd)
 	rootCmd.AddCommand(genDocsCmd)
 	genDocsCmd.Flags().StringVar(&genDocsDir, "dir", "", "Output directory for generated Markdown")
 }
@@ -115,17 +181,190 @@ func run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Load configuration
-	cfg, err := config.Load()
+	// A state file brings its own settings and aircraft
+	if loadState != "" {
+		return runLoadState(cmd, loadState)
+	}
+
+	// With --once stdout is the frame alone, so warnings go to stderr
+	notes := cmd.OutOrStdout()
+	if once {
+		notes = cmd.ErrOrStderr()
+	}
+	cfg, err := loadRunConfig(notes, true)
 	if err != nil {
 		return err
 	}
+	if once {
+		return runOnce(cmd, cfg, checkAuth(cfg, notes))
+	}
+
+	// Count the start, so repeated crashes while starting can be spotted
+	startups := config.GetStartupMarkerPath()
+	if failed := beginStartup(startups); failed >= crashLoopStarts && !safeMode {
+		fmt.Printf("⚠ Warning: SkySpy didn't start cleanly the last %d times. If it keeps failing, run 'skyspy --safe-mode' to start without optional features.\n", failed)
+	}
+	started := time.AfterFunc(startupGrace, func() { finishStartup(startups) })
+	defer started.Stop()
+
+	for {
+		configure, err := runRadar(cfg, checkAuth(cfg, notes), startups)
+		if err != nil || !configure {
+			return err
+		}
+		// The user chose to fix the connection settings from the error
+		// screen; what the wizard saves replaces --host and --port
+		if err := runConfigure(cmd, args); err != nil {
+			return err
+		}
+		if cfg, err = loadRunConfig(notes, false); err != nil {
+			return err
+		}
+	}
+}
+
+// runRadar runs the radar until the user quits, reporting whether they
+// asked to fix the connection settings from the error screen
+func runRadar(cfg *config.Config, authMgr *auth.Manager, startups string) (configure bool, err error) {
+	// Show startup banner
+	caps := startupCapabilities()
+	bannerTheme := cfg.Display.Theme
+	if !caps.Theme {
+		bannerTheme = ""
+	}
+	t := theme.Get(bannerTheme).WithGlyphs(cfg.Display.GlyphSet).WithCVD(cfg.Display.ColorblindSafe)
+	g := t.GlyphSet()
+	fmt.Printf("\033[38;5;%dm", colorToANSI(string(t.PrimaryBright)))
+	fmt.Println("  " + g.DoubleTL + strings.Repeat(g.DoubleH, 44) + g.DoubleTR)
+	fmt.Println("  " + g.DoubleV + "     SKYSPY RADAR PRO - INITIALIZING...     " + g.DoubleV)
+	fmt.Println("  " + g.DoubleBL + strings.Repeat(g.DoubleH, 44) + g.DoubleBR)
+	fmt.Print("\033[0m")
+	fmt.Printf("  Theme: %s\n", t.Name)
+	if skipped := caps.Skipped(); len(skipped) > 0 {
+		fmt.Printf("  Safe mode: skipped %s\n", strings.Join(skipped, ", "))
+	}
+
+	// Show auth status
+	if authMgr != nil && authMgr.IsAuthenticated() {
+		if username := authMgr.GetUsername(); username != "" {
+			fmt.Printf("  User: %s\n", username)
+		} else if apiKey != "" {
+			fmt.Printf("  Auth: API Key\n")
+		}
+	}
+
+	if feeds := cfg.Connection.Feeds(); len(feeds) > 1 {
+		fmt.Printf("  Connecting to %d servers...\n\n", len(feeds))
+	} else {
+		fmt.Printf("  Connecting to %s:%d...\n\n", feeds[0].Host, feeds[0].Port)
+	}
+
+	// Create and run the Bubble Tea program
+	client := newRadarFeed(cfg, authMgr)
+	model := app.NewModelWithCapabilities(cfg, client, caps)
+	if authMgr != nil && authMgr.RequiresAuth() && !authMgr.IsAuthenticated() {
+		model.SetStartupError(&ws.ConnectError{
+			Kind: ws.KindAuth,
+			URL:  client.URL(),
+			Err:  errors.New("server requires authentication"),
+		}, authHints(authMgr.GetAuthConfig())...)
+	}
+	if authMgr != nil {
+		if skew, ok := authMgr.ClockSkew(); ok {
+			model.SetClockSkew(skew)
+		}
+		if lat, lon, ok := authMgr.ReceiverPosition(); ok {
+			model.SetServerPosition(lat, lon)
+		}
+		model.SetAuth(authMgr)
+	}
+
+	// Translated UI strings; anything the file lacks stays in English
+	strs, err := i18n.Load(config.GetStringsPath())
+	if err != nil {
+		fmt.Printf("⚠ Warning: Could not load UI strings: %v\n", err)
+	}
+	model.SetStrings(strs)
+	model.SetVersion(version)
+
+	model.ShowWhatsNew(version, showChangelog())
+
+	// Serve the radar's state to local web pages and scripts
+	if cfg.API.Enabled && caps.API {
+		server, apiErr := api.Listen(cfg.API.Listen, cfg.API.AllowRemote)
+		if apiErr != nil {
+			return false, fmt.Errorf("local API: %w", apiErr)
+		}
+		server.Serve()
+		defer server.Close()
+		model.SetAPI(server)
+	}
+
+	// Disable audio if --no-audio flag is set
+	if noAudio {
+		model.SetAudioEnabled(false)
+	}
+
+	model.SetKiosk(kiosk)
+
+	// Emergency squawks are kept on record whatever the alert settings
+	model.SetEmergencyLog(config.GetEmergencyLogPath())
+	defer model.CloseEmergencyLog()
+
+	// Everything seen, for unattended receivers
+	if cfg.Export.AutoExport.Enabled || logSession {
+		model.SetSessionLog(cfg.Export.AutoExport)
+		defer model.CloseSessionLog()
+	}
+
+	p := tea.NewProgram(model,
+		tea.WithAltScreen(),
+		tea.WithMouseAllMotion(),
+	)
+
+	// Redraw after SIGCONT; the feed keeps running while suspended
+	stopResume := watchResume(p.Send)
+	defer stopResume()
+
+	if _, err := p.Run(); err != nil {
+		return false, err
+	}
+	finishStartup(startups)
+	if model.ConfigureRequested() {
+		return true, nil
+	}
+
+	// Save config on exit; kiosk and safe mode leave the settings as they
+	// found them
+	if kiosk || !caps.SaveSettings {
+		fmt.Printf("\n  Clear skies!\n\n")
+		return false, nil
+	}
+	_ = config.Save(cfg)
+	fmt.Printf("\n  Settings saved. Clear skies!\n\n")
+
+	return false, nil
+}
+
+// loadRunConfig loads the settings and applies the command line over them.
+// Without connection the settings' host and port stand, as after the
+// configuration wizard has saved new ones.
+func loadRunConfig(notes io.Writer, connection bool) (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if p := cfg.LoadProblem(); p != nil && p.Restored {
+		fmt.Fprintf(notes, "⚠ Warning: Settings file damaged, restored from %s: %v\n", config.GetBackupPath(), p.Err)
+	} else if p != nil {
+		fmt.Fprintf(notes, "⚠ Warning: Settings file damaged, using defaults: %v\n", p.Err)
+	}
 
 	// Apply command line overrides
-	if host != "" {
+	if connection && host != "" {
 		cfg.Connection.Host = host
 	}
-	if port != 0 {
+	if connection && port != 0 {
 		cfg.Connection.Port = port
 	}
 	if lat != 0 {
@@ -140,6 +379,15 @@ func run(cmd *cobra.Command, args []string) error {
 	if themeName != "" {
 		cfg.Display.Theme = themeName
 	}
+	if privacy {
+		cfg.Display.PrivacyMode = true
+	}
+	if ascii {
+		cfg.Display.GlyphSet = theme.GlyphsASCII
+	}
+	if colorblind {
+		cfg.Display.ColorblindSafe = true
+	}
 	if exportDir != "" {
 		absPath, pathErr := filepath.Abs(exportDir)
 		if pathErr == nil {
@@ -163,73 +411,40 @@ func run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Check authentication
-	authMgr, err := auth.NewManager(cfg.Connection.Host, cfg.Connection.Port)
+	return cfg, nil
+}
+
+// checkAuth checks how to authenticate with the primary server when
+// several are merged. The manager is nil if the server couldn't be asked.
+func checkAuth(cfg *config.Config, notes io.Writer) *auth.Manager {
+	primary := cfg.Connection.Feeds()[cfg.Connection.PrimaryServer()]
+	authMgr, err := auth.NewManager(primary.Host, primary.Port)
 	if err != nil {
-		fmt.Printf("⚠ Warning: Could not connect to server for auth check: %v\n", err)
+		fmt.Fprintf(notes, "⚠ Warning: Could not connect to server for auth check: %v\n", err)
 	}
 
 	// Set API key if provided
 	if authMgr != nil && apiKey != "" {
 		authMgr.SetAPIKey(apiKey)
+	} else if authMgr != nil && primary.APIKey != "" {
+		authMgr.SetAPIKey(primary.APIKey)
 	}
+	return authMgr
+}
 
-	// Check if authentication is required
-	if authMgr != nil && authMgr.RequiresAuth() && !authMgr.IsAuthenticated() {
-		authCfg := authMgr.GetAuthConfig()
-		fmt.Printf("⚠ Server requires authentication\n")
-		if authCfg.OIDCEnabled {
-			fmt.Printf("  Run 'skyspy login' to authenticate with %s\n", authCfg.OIDCProviderName)
-		}
-		if authCfg.APIKeyEnabled {
-			fmt.Printf("  Or use --api-key <key> for API key authentication\n")
-		}
-		fmt.Println()
-		return fmt.Errorf("authentication required")
-	}
-
-	// Show startup banner
-	t := theme.Get(cfg.Display.Theme)
-	fmt.Printf("\033[38;5;%dm", colorToANSI(string(t.PrimaryBright)))
-	fmt.Println("  ╔════════════════════════════════════════════╗")
-	fmt.Println("  ║     SKYSPY RADAR PRO - INITIALIZING...     ║")
-	fmt.Println("  ╚════════════════════════════════════════════╝")
-	fmt.Print("\033[0m")
-	fmt.Printf("  Theme: %s\n", t.Name)
-
-	// Show auth status
-	if authMgr != nil && authMgr.IsAuthenticated() {
-		if username := authMgr.GetUsername(); username != "" {
-			fmt.Printf("  User: %s\n", username)
-		} else if apiKey != "" {
-			fmt.Printf("  Auth: API Key\n")
-		}
+// authHints explains how to sign in to a server that requires it
+func authHints(authCfg *auth.AuthConfig) []string {
+	var hints []string
+	if authCfg == nil {
+		return hints
 	}
-
-	fmt.Printf("  Connecting to %s:%d...\n\n", cfg.Connection.Host, cfg.Connection.Port)
-
-	// Create and run the Bubble Tea program
-	model := app.NewModelWithAuth(cfg, authMgr)
-
-	// Disable audio if --no-audio flag is set
-	if noAudio {
-		model.SetAudioEnabled(false)
+	if authCfg.OIDCEnabled {
+		hints = append(hints, fmt.Sprintf("Run 'skyspy login' to authenticate with %s", authCfg.OIDCProviderName))
 	}
-
-	p := tea.NewProgram(model,
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
-
-	if _, err := p.Run(); err != nil {
-		return err
+	if authCfg.APIKeyEnabled {
+		hints = append(hints, "Or restart with --api-key <key> for API key authentication")
 	}
-
-	// Save config on exit
-	_ = config.Save(cfg)
-	fmt.Printf("\n  Settings saved. Clear skies!\n\n")
-
-	return nil
+	return hints
 }
 
 // colorToANSI converts a color to an ANSI code (simplified)
@@ -2,10 +2,13 @@ package main
 
 import (
 	"bytes"
+	"io"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/skyspy/skyspy-go/internal/auth"
+	"github.com/skyspy/skyspy-go/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -306,6 +309,33 @@ func TestHostPortFlags(t *testing.T) {
 	}
 }
 
+func TestLoadRunConfig_WizardHostWins(t *testing.T) {
+	useTempConfig(t)
+	origHost, origPort, origTheme := host, port, themeName
+	host, port, themeName = "flag.example", 9000, "amber"
+	defer func() { host, port, themeName = origHost, origPort, origTheme }()
+
+	saved := config.DefaultConfig()
+	saved.Connection.Host, saved.Connection.Port = "wizard.example", 8443
+	if err := config.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadRunConfig(io.Discard, true)
+	if err != nil || cfg.Connection.Host != "flag.example" || cfg.Connection.Port != 9000 {
+		t.Fatalf("the flags should apply at startup: %v %+v", err, cfg.Connection)
+	}
+
+	// After the wizard its host and port stand; the other flags still apply
+	cfg, err = loadRunConfig(io.Discard, false)
+	if err != nil || cfg.Connection.Host != "wizard.example" || cfg.Connection.Port != 8443 {
+		t.Fatalf("the wizard's connection should stand: %v %+v", err, cfg.Connection)
+	}
+	if cfg.Display.Theme != "amber" {
+		t.Errorf("theme = %q, want the flag's amber", cfg.Display.Theme)
+	}
+}
+
 func TestThemeFlag(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -635,3 +665,20 @@ func TestMultipleFlagsCombined(t *testing.T) {
 		t.Errorf("Expected export-dir '/home/user/exports', got %q", parsedValues.exportDir)
 	}
 }
+
+func TestAuthHints(t *testing.T) {
+	if hints := authHints(nil); len(hints) != 0 {
+		t.Errorf("expected no hints without auth config, got %v", hints)
+	}
+
+	hints := authHints(&auth.AuthConfig{OIDCEnabled: true, OIDCProviderName: "Keycloak", APIKeyEnabled: true})
+	if len(hints) != 2 {
+		t.Fatalf("expected 2 hints, got %v", hints)
+	}
+	if !strings.Contains(hints[0], "skyspy login") || !strings.Contains(hints[0], "Keycloak") {
+		t.Errorf("unexpected OIDC hint %q", hints[0])
+	}
+	if !strings.Contains(hints[1], "--api-key") {
+		t.Errorf("unexpected API key hint %q", hints[1])
+	}
+}
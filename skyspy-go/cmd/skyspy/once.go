@@ -0,0 +1,124 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/auth"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// runOnce connects, waits for the first snapshot, prints a single frame of
+// the radar to stdout and returns. It needs no terminal: the frame is
+// drawn at the --width and --height given rather than the terminal's.
+func runOnce(cmd *cobra.Command, cfg *config.Config, authMgr *auth.Manager) error {
+	if onceWidth < 1 || onceHeight < 1 {
+		return fmt.Errorf("--width and --height must be positive")
+	}
+	if onceWait <= 0 {
+		return fmt.Errorf("--wait must be positive")
+	}
+	if authMgr != nil && authMgr.RequiresAuth() && !authMgr.IsAuthenticated() {
+		return fmt.Errorf("server requires authentication: run 'skyspy login' or use --api-key")
+	}
+
+	client := newFeedClient(cfg, authMgr)
+	model := app.NewModelWithCapabilities(cfg, client, startupCapabilities())
+	model.SetAudioEnabled(false)
+	if authMgr != nil {
+		if lat, lon, ok := authMgr.ReceiverPosition(); ok {
+			model.SetServerPosition(lat, lon)
+		}
+	}
+	strs, err := i18n.Load(config.GetStringsPath())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠ Warning: Could not load UI strings: %v\n", err)
+	}
+	model.SetStrings(strs)
+	model.LoadOverlays()
+
+	client.Start()
+	defer client.Stop()
+
+	server := fmt.Sprintf("%s:%d", cfg.Connection.Host, cfg.Connection.Port)
+	if err := awaitSnapshot(model, client.AircraftMessages(), client.ACARSMessages(), onceWait); err != nil {
+		return fmt.Errorf("%s: %w", server, err)
+	}
+
+	_, err = io.WriteString(cmd.OutOrStdout(), renderOnce(model, onceWidth, onceHeight, !onceNoColor))
+	return err
+}
+
+// awaitSnapshot feeds server messages into the model until the first
+// snapshot has been applied. If none comes within wait, whatever aircraft
+// did arrive are kept; it is an error only when nothing did.
+func awaitSnapshot(model *app.Model, aircraft, acars <-chan codec.Message, wait time.Duration) error {
+	deadline := time.After(wait)
+	received := false
+	for {
+		select {
+		case msg, ok := <-aircraft:
+			if !ok {
+				if received {
+					return nil
+				}
+				return errors.New("connection closed before any data arrived")
+			}
+			model.IngestAircraftMessage(msg)
+			switch codec.MessageType(msg.Type) {
+			case codec.AircraftSnapshot:
+				return nil
+			case codec.AircraftNew, codec.AircraftUpdate:
+				received = true
+			}
+		case msg, ok := <-acars:
+			if !ok {
+				acars = nil
+				continue
+			}
+			model.IngestACARSMessage(msg)
+		case <-deadline:
+			if received {
+				return nil
+			}
+			return fmt.Errorf("no aircraft data within %s", wait)
+		}
+	}
+}
+
+// renderOnce draws one frame through the radar's own view at the given
+// size, cut to fit as a terminal of that size would show it. With color
+// off the ANSI codes are stripped; with it on they are kept even though
+// stdout isn't a terminal.
+func renderOnce(model *app.Model, width, height int, color bool) string {
+	if color {
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	}
+	model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	frame := model.RenderFrame()
+
+	lines := strings.Split(frame, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for i, line := range lines {
+		line = ansi.Truncate(line, width, "")
+		if !color {
+			line = ansi.Strip(line)
+		}
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
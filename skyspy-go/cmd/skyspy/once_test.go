@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+func TestAwaitSnapshot_StopsAtSnapshot(t *testing.T) {
+	msgs := make(chan codec.Message, 4)
+	msgs <- statusTestMessage(t, codec.AircraftSnapshot, []codec.Aircraft{{Hex: "AAA001"}, {Hex: "AAA002"}})
+	msgs <- statusTestMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "AAA003"})
+
+	model := statusTestModel()
+	start := time.Now()
+	if err := awaitSnapshot(model, msgs, nil, time.Second); err != nil {
+		t.Fatalf("awaitSnapshot: %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Error("the snapshot should end the wait")
+	}
+	if n := model.GetStats().Aircraft; n != 2 {
+		t.Errorf("expected the snapshot's 2 aircraft and nothing after, got %d", n)
+	}
+}
+
+func TestAwaitSnapshot_KeepsUpdatesWithoutSnapshot(t *testing.T) {
+	msgs := make(chan codec.Message, 4)
+	msgs <- statusTestMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "AAA001"})
+
+	model := statusTestModel()
+	if err := awaitSnapshot(model, msgs, nil, 50*time.Millisecond); err != nil {
+		t.Fatalf("aircraft arrived, so the wait should succeed: %v", err)
+	}
+	if n := model.GetStats().Aircraft; n != 1 {
+		t.Errorf("expected 1 aircraft, got %d", n)
+	}
+}
+
+func TestAwaitSnapshot_NoData(t *testing.T) {
+	msgs := make(chan codec.Message, 1)
+	msgs <- statusTestMessage(t, codec.Notice, map[string]string{"text": "maintenance"})
+	if err := awaitSnapshot(statusTestModel(), msgs, nil, 50*time.Millisecond); err == nil ||
+		!strings.Contains(err.Error(), "no aircraft data") {
+		t.Errorf("a notice alone isn't data: %v", err)
+	}
+
+	closed := make(chan codec.Message)
+	close(closed)
+	if err := awaitSnapshot(statusTestModel(), closed, nil, time.Second); err == nil {
+		t.Error("expected an error when the feed closes before any data")
+	}
+}
+
+func TestRenderOnce(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	model := statusTestModel()
+	model.IngestAircraftMessage(statusTestMessage(t, codec.AircraftSnapshot, []codec.Aircraft{{Hex: "AAA001", Flight: "KLM123"}}))
+
+	plain := renderOnce(model, 80, 20, false)
+	lines := strings.Split(strings.TrimSuffix(plain, "\n"), "\n")
+	if len(lines) != 20 {
+		t.Errorf("expected 20 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w > 80 {
+			t.Errorf("line %d is %d columns wide", i, w)
+		}
+	}
+	if strings.Contains(plain, "\x1b[") {
+		t.Error("--no-color output should have no escape codes")
+	}
+	if !strings.Contains(plain, "ADS-B TACTICAL DISPLAY") {
+		t.Errorf("expected the radar header:\n%s", plain)
+	}
+
+	colored := renderOnce(model, 80, 20, true)
+	if !strings.Contains(colored, "\x1b[38;5;") {
+		t.Error("colors should be kept without a terminal")
+	}
+	if ansi.Strip(colored) == colored {
+		t.Error("expected ANSI codes in the colored frame")
+	}
+}
+
+func TestOnceFlags(t *testing.T) {
+	// Flags are registered by SetupCommands in TestMain
+	for _, name := range []string{"once", "wait", "width", "height", "no-color"} {
+		if rootCmd.Flag(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+	if got := rootCmd.Flag("wait").DefValue; got != "10s" {
+		t.Errorf("expected default wait 10s, got %s", got)
+	}
+}
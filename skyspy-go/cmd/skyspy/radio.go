@@ -55,7 +55,7 @@ func runRadio(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show startup banner
-	t := theme.Get(cfg.Display.Theme)
+	t := theme.Get(cfg.Display.Theme).WithGlyphs(cfg.Display.GlyphSet).WithCVD(cfg.Display.ColorblindSafe)
 	fmt.Printf("\033[38;5;%dm", colorToANSI(string(t.PrimaryBright)))
 	fmt.Println("")
 	fmt.Println("   _____ _            _____              _____           _ _       ")
@@ -3,6 +3,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/skyspy/skyspy-go/internal/config"
@@ -63,20 +64,25 @@ func runRadioPro(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show startup banner
-	t := theme.Get(cfg.Display.Theme)
+	t := theme.Get(cfg.Display.Theme).WithGlyphs(cfg.Display.GlyphSet).WithCVD(cfg.Display.ColorblindSafe)
+	g := t.GlyphSet()
 	fmt.Printf("\033[38;5;%dm", colorToANSI(string(t.PrimaryBright)))
 	fmt.Println("")
-	fmt.Println("  ████████████████████████████████████████████")
-	fmt.Println("  █                                          █")
-	fmt.Println("  █   SKYSPY RADIO PRO - INITIALIZING...     █")
-	fmt.Println("  █                                          █")
-	fmt.Println("  █   Features:                              █")
-	fmt.Println("  █   ◉ Live Aircraft Tracking               █")
-	fmt.Println("  █   ◉ ACARS/VDL2 Data Link Feed            █")
-	fmt.Println("  █   ◉ VU Meters & Spectrum Display         █")
-	fmt.Println("  █   ◉ Frequency Scanning                   █")
-	fmt.Println("  █                                          █")
-	fmt.Println("  ████████████████████████████████████████████")
+	fmt.Println("  " + strings.Repeat(g.BarFull, 44))
+	for _, line := range []string{
+		"",
+		"  SKYSPY RADIO PRO - INITIALIZING...",
+		"",
+		"  Features:",
+		"  " + g.Live + " Live Aircraft Tracking",
+		"  " + g.Live + " ACARS/VDL2 Data Link Feed",
+		"  " + g.Live + " VU Meters & Spectrum Display",
+		"  " + g.Live + " Frequency Scanning",
+		"",
+	} {
+		fmt.Printf("  %s %-41s%s\n", g.BarFull, line, g.BarFull)
+	}
+	fmt.Println("  " + strings.Repeat(g.BarFull, 44))
 	fmt.Println("")
 	fmt.Print("\033[0m")
 
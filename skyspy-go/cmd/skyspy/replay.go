@@ -0,0 +1,65 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/i18n"
+	"github.com/skyspy/skyspy-go/internal/replay"
+	"github.com/spf13/cobra"
+)
+
+// replaySpeed is how many times faster than recorded a replay plays
+var replaySpeed float64
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <file>...",
+	Short: "Play back aircraft exports on the radar",
+	Long: `Play back aircraft exported with E (CSV) or Ctrl+E (JSON) on the
+radar, at the pace they were recorded. Give several files, such as a
+folder of auto-exports, and they are played as one session.
+
+The radar behaves as it does live: trails grow, aircraft age out, and
+alerts and stats follow the recorded traffic. Space pauses, and Left and
+Right move back or forward 30 seconds.
+
+Examples:
+  skyspy replay skyspy_export_20260301_120000.json
+  skyspy replay exports/*.csv --speed 4`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runReplay,
+}
+
+// RegisterReplayFlags sets up flags for the replay command
+func RegisterReplayFlags() {
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 1, "Play back this many times faster than recorded")
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	if replaySpeed <= 0 {
+		return fmt.Errorf("--speed must be greater than 0")
+	}
+	frames, err := replay.Load(args...)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	model := app.NewModelWithFeed(cfg, replay.NewPlayer(frames, replaySpeed))
+	strs, err := i18n.Load(config.GetStringsPath())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠ Warning: Could not load UI strings: %v\n", err)
+	}
+	model.SetStrings(strs)
+	model.SetVersion(version)
+
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	_, err = p.Run()
+	return err
+}
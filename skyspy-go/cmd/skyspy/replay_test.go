@@ -0,0 +1,31 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplayFlags(t *testing.T) {
+	// Flags are registered by SetupCommands in TestMain
+	flag := replayCmd.Flag("speed")
+	if flag == nil {
+		t.Fatal("expected replay --speed flag")
+	}
+	if flag.DefValue != "1" {
+		t.Errorf("--speed defaults to %s, want 1", flag.DefValue)
+	}
+}
+
+func TestReplay_Errors(t *testing.T) {
+	defer func() { replaySpeed = 1 }()
+
+	replaySpeed = 0
+	if err := runReplay(replayCmd, []string{"export.json"}); err == nil || !strings.Contains(err.Error(), "--speed") {
+		t.Errorf("a zero speed should be refused, got %v", err)
+	}
+	replaySpeed = 1
+	if err := runReplay(replayCmd, []string{filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Error("a missing export should be an error")
+	}
+}
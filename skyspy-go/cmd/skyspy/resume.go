@@ -0,0 +1,37 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"os"
+	"os/signal"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchResume forwards resume signals to send as tea.ResumeMsg until the
+// returned stop function is called. Bubble Tea only redraws after a suspend
+// it started itself (ctrl+z); this also covers the process being stopped and
+// continued from outside, e.g. kill -STOP / kill -CONT or a job-control fg.
+func watchResume(send func(tea.Msg)) (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	if len(resumeSignals) > 0 {
+		signal.Notify(sigCh, resumeSignals...)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-sigCh:
+				send(tea.ResumeMsg{})
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
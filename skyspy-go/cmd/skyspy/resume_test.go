@@ -0,0 +1,45 @@
+//go:build !windows
+
+package main
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWatchResume_ForwardsSIGCONT(t *testing.T) {
+	got := make(chan tea.Msg, 1)
+	stop := watchResume(func(msg tea.Msg) { got <- msg })
+	defer stop()
+
+	// SIGCONT to a running process is harmless
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGCONT); err != nil {
+		t.Fatalf("failed to send SIGCONT: %v", err)
+	}
+
+	select {
+	case msg := <-got:
+		if _, ok := msg.(tea.ResumeMsg); !ok {
+			t.Errorf("expected tea.ResumeMsg, got %T", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for resume message")
+	}
+}
+
+func TestWatchResume_StopUnsubscribes(t *testing.T) {
+	got := make(chan tea.Msg, 1)
+	stop := watchResume(func(msg tea.Msg) { got <- msg })
+	stop()
+
+	_ = syscall.Kill(syscall.Getpid(), syscall.SIGCONT)
+
+	select {
+	case msg := <-got:
+		t.Errorf("expected no message after stop, got %T", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// resumeSignals are the signals that mean the terminal needs a redraw
+var resumeSignals = []os.Signal{syscall.SIGCONT}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// resumeSignals is empty: Windows has no job-control suspend
+var resumeSignals []os.Signal
@@ -0,0 +1,261 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/auth"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/ws"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serverStatusJSON    bool
+	serverStatusTimeout time.Duration
+	serverStatusSample  time.Duration
+)
+
+var serverStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show server status without launching the radar",
+	Long: `Connect to the SkySpy server, sample the live feed and print a
+summary of what the radar would show, then exit.
+
+Reports the server version (if available), auth mode and identity,
+aircraft counts and the message rate over a short sample window.
+
+Examples:
+  skyspy status
+  skyspy status --json
+  skyspy status --host myserver.com --port 443 --timeout 5s`,
+	RunE: runServerStatus,
+}
+
+// RegisterServerStatusFlags sets up the status command flags.
+// Call this from the main command initialization.
+func RegisterServerStatusFlags() {
+	serverStatusCmd.Flags().BoolVar(&serverStatusJSON, "json", false, "Print machine-readable JSON")
+	serverStatusCmd.Flags().DurationVar(&serverStatusTimeout, "timeout", 10*time.Second, "Give up if the server sends nothing within this time")
+	serverStatusCmd.Flags().DurationVar(&serverStatusSample, "sample", 3*time.Second, "Window used to measure the message rate")
+}
+
+// serverStatus is the summary printed by `skyspy status`
+type serverStatus struct {
+	Server        string  `json:"server"`
+	Version       string  `json:"version,omitempty"`
+	AuthMode      string  `json:"auth_mode"`
+	Authenticated bool    `json:"authenticated"`
+	Identity      string  `json:"identity,omitempty"`
+	Aircraft      int     `json:"aircraft"`
+	Military      int     `json:"military"`
+	Emergency     int     `json:"emergency"`
+	MessageRate   float64 `json:"message_rate"` // messages per second
+	SampleSeconds float64 `json:"sample_seconds"`
+}
+
+func runServerStatus(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// Apply command line overrides
+	if host != "" {
+		cfg.Connection.Host = host
+	}
+	if port != 0 {
+		cfg.Connection.Port = port
+	}
+
+	status := serverStatus{
+		Server:   fmt.Sprintf("%s:%d", cfg.Connection.Host, cfg.Connection.Port),
+		AuthMode: "unknown",
+	}
+
+	authMgr, err := auth.NewManager(cfg.Connection.Host, cfg.Connection.Port)
+	if err != nil {
+		return fmt.Errorf("failed to initialize auth: %w", err)
+	}
+	if apiKey != "" {
+		authMgr.SetAPIKey(apiKey)
+	}
+	describeAuth(&status, authMgr)
+
+	client := newFeedClient(cfg, authMgr)
+	client.Start()
+	defer client.Stop()
+
+	// Feed messages through a headless radar model so the counts match the TUI
+	model := app.NewModel(cfg)
+	model.SetAudioEnabled(false)
+
+	if err := sampleFeed(&status, model, client.AircraftMessages(), serverStatusTimeout, serverStatusSample); err != nil {
+		return err
+	}
+
+	return writeServerStatus(cmd.OutOrStdout(), status, serverStatusJSON)
+}
+
+// newFeedClient creates a feed client set up the same way as the radar's, so
+// headless commands see an identical feed
+func newFeedClient(cfg *config.Config, authMgr *auth.Manager) *ws.Client {
+	return newServerClient(cfg, cfg.Connection.Host, cfg.Connection.Port, authMgr)
+}
+
+// newServerClient creates a feed client for one server, set up from the
+// connection settings
+func newServerClient(cfg *config.Config, host string, port int, authMgr *auth.Manager) *ws.Client {
+	var client *ws.Client
+	if authMgr != nil && authMgr.IsAuthenticated() {
+		client = ws.NewClientWithAuth(host, port, cfg.Connection.ReconnectDelay, authMgr.GetAuthHeader)
+	} else {
+		client = ws.NewClient(host, port, cfg.Connection.ReconnectDelay)
+	}
+	client.SetKeepalive(cfg.Connection.Keepalive())
+	client.SetAutoReconnect(cfg.Connection.AutoReconnect)
+	return client
+}
+
+// radarFeed is a feed the radar can name on its connection error screen
+type radarFeed interface {
+	app.Feed
+	URL() string
+}
+
+// newRadarFeed creates the radar's feed: a single client, or with
+// connection.servers set, one client per server merged into one feed.
+// primary is the primary server's auth; the others each get their own,
+// with the server's API key if it has one.
+func newRadarFeed(cfg *config.Config, primary *auth.Manager) radarFeed {
+	if len(cfg.Connection.Servers) == 0 {
+		return newFeedClient(cfg, primary)
+	}
+
+	feeds := cfg.Connection.Feeds()
+	primaryIndex := cfg.Connection.PrimaryServer()
+	sources := make([]app.FeedSource, 0, len(feeds))
+	for i, srv := range feeds {
+		authMgr := primary
+		if i != primaryIndex {
+			var err error
+			if authMgr, err = auth.NewManager(srv.Host, srv.Port); err != nil {
+				fmt.Printf("⚠ Warning: Could not set up auth for %s: %v\n", srv.Label(), err)
+			} else if srv.APIKey != "" {
+				authMgr.SetAPIKey(srv.APIKey)
+			}
+		}
+		src := app.FeedSource{Name: srv.Label(), Feed: newServerClient(cfg, srv.Host, srv.Port, authMgr)}
+		// The primary goes first, as the one the connection error screen
+		// names
+		if i == primaryIndex {
+			sources = append([]app.FeedSource{src}, sources...)
+		} else {
+			sources = append(sources, src)
+		}
+	}
+	return app.NewMultiFeed(sources...)
+}
+
+// describeAuth fills in the auth mode, version and identity
+func describeAuth(status *serverStatus, authMgr *auth.Manager) {
+	if authCfg := authMgr.GetAuthConfig(); authCfg != nil {
+		if authCfg.AuthMode != "" {
+			status.AuthMode = authCfg.AuthMode
+		}
+		status.Version = authCfg.Version
+	}
+
+	info := authMgr.GetTokenInfo()
+	switch info["auth_type"] {
+	case "oidc":
+		status.Authenticated = true
+		if username, ok := info["username"].(string); ok {
+			status.Identity = username
+		}
+	case "api_key":
+		status.Authenticated = true
+		if prefix, ok := info["api_key_prefix"].(string); ok {
+			status.Identity = "API key " + prefix
+		} else {
+			status.Identity = "API key"
+		}
+	}
+}
+
+// sampleFeed waits up to timeout for the server's first message, then keeps
+// consuming for the sample window to measure the message rate
+func sampleFeed(status *serverStatus, model *app.Model, msgs <-chan codec.Message, timeout, sample time.Duration) error {
+	select {
+	case msg, ok := <-msgs:
+		if !ok {
+			return fmt.Errorf("connection to %s closed before any data arrived", status.Server)
+		}
+		model.IngestAircraftMessage(msg)
+	case <-time.After(timeout):
+		return fmt.Errorf("no response from %s within %s", status.Server, timeout)
+	}
+
+	start := time.Now()
+	before := model.GetStats().Messages
+	window := time.After(sample)
+sampling:
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				break sampling
+			}
+			model.IngestAircraftMessage(msg)
+		case <-window:
+			break sampling
+		}
+	}
+
+	stats := model.GetStats()
+	elapsed := time.Since(start).Seconds()
+	status.Aircraft = stats.Aircraft
+	status.Military = stats.Military
+	status.Emergency = stats.Emergency
+	status.SampleSeconds = elapsed
+	if elapsed > 0 {
+		status.MessageRate = float64(stats.Messages-before) / elapsed
+	}
+	return nil
+}
+
+// writeServerStatus prints the summary as text or JSON
+func writeServerStatus(w io.Writer, status serverStatus, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(status)
+	}
+
+	version := status.Version
+	if version == "" {
+		version = "unknown"
+	}
+	identity := "not authenticated"
+	if status.Authenticated {
+		identity = status.Identity
+		if identity == "" {
+			identity = "authenticated"
+		}
+	}
+
+	fmt.Fprintf(w, "Server:    %s\n", status.Server)
+	fmt.Fprintf(w, "Version:   %s\n", version)
+	fmt.Fprintf(w, "Auth:      %s (%s)\n", status.AuthMode, identity)
+	fmt.Fprintf(w, "Aircraft:  %d\n", status.Aircraft)
+	fmt.Fprintf(w, "Military:  %d\n", status.Military)
+	fmt.Fprintf(w, "Emergency: %d\n", status.Emergency)
+	fmt.Fprintf(w, "Msg Rate:  %.1f/s (%.0fs sample)\n", status.MessageRate, status.SampleSeconds)
+	return nil
+}
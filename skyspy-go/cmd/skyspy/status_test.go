@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func statusTestModel() *app.Model {
+	model := app.NewModel(config.DefaultConfig())
+	model.SetAudioEnabled(false)
+	return model
+}
+
+func statusTestMessage(t *testing.T, msgType codec.MessageType, v interface{}) codec.Message {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return codec.Message{Type: string(msgType), Data: data}
+}
+
+func TestSampleFeed_CountsMatchRadar(t *testing.T) {
+	msgs := make(chan codec.Message, 8)
+	msgs <- statusTestMessage(t, codec.AircraftSnapshot, []codec.Aircraft{
+		{Hex: "AAA001", Military: true},
+		{Hex: "AAA002", Squawk: "7700"},
+		{Hex: "AAA003"},
+	})
+	msgs <- statusTestMessage(t, codec.AircraftUpdate, codec.Aircraft{Hex: "AAA003"})
+	msgs <- statusTestMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "AAA004", Military: true})
+
+	status := serverStatus{Server: "localhost:8080"}
+	if err := sampleFeed(&status, statusTestModel(), msgs, time.Second, 50*time.Millisecond); err != nil {
+		t.Fatalf("sampleFeed: %v", err)
+	}
+
+	if status.Aircraft != 4 {
+		t.Errorf("expected 4 aircraft, got %d", status.Aircraft)
+	}
+	if status.Military != 2 {
+		t.Errorf("expected 2 military, got %d", status.Military)
+	}
+	if status.Emergency != 1 {
+		t.Errorf("expected 1 emergency, got %d", status.Emergency)
+	}
+	if status.MessageRate <= 0 {
+		t.Errorf("expected a positive message rate, got %f", status.MessageRate)
+	}
+	if status.SampleSeconds <= 0 {
+		t.Error("sample window should be recorded")
+	}
+}
+
+func TestSampleFeed_Timeout(t *testing.T) {
+	msgs := make(chan codec.Message)
+	status := serverStatus{Server: "nowhere:1"}
+
+	start := time.Now()
+	err := sampleFeed(&status, statusTestModel(), msgs, 50*time.Millisecond, time.Second)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if !strings.Contains(err.Error(), "no response from nowhere:1") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Error("timeout should not wait for the sample window")
+	}
+}
+
+func TestSampleFeed_ClosedFeed(t *testing.T) {
+	msgs := make(chan codec.Message)
+	close(msgs)
+
+	status := serverStatus{Server: "localhost:8080"}
+	if err := sampleFeed(&status, statusTestModel(), msgs, time.Second, time.Second); err == nil {
+		t.Error("expected an error when the feed closes before any data")
+	}
+}
+
+func TestWriteServerStatus_Text(t *testing.T) {
+	var buf bytes.Buffer
+	status := serverStatus{
+		Server:        "radar.local:8080",
+		AuthMode:      "hybrid",
+		Authenticated: true,
+		Identity:      "pilot",
+		Aircraft:      42,
+		Military:      3,
+		Emergency:     1,
+		MessageRate:   12.5,
+		SampleSeconds: 3,
+	}
+	if err := writeServerStatus(&buf, status, false); err != nil {
+		t.Fatalf("writeServerStatus: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"radar.local:8080",
+		"Version:   unknown",
+		"hybrid (pilot)",
+		"Aircraft:  42",
+		"Military:  3",
+		"Emergency: 1",
+		"12.5/s",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteServerStatus_Unauthenticated(t *testing.T) {
+	var buf bytes.Buffer
+	_ = writeServerStatus(&buf, serverStatus{Server: "x:1", AuthMode: "public"}, false)
+
+	if !strings.Contains(buf.String(), "public (not authenticated)") {
+		t.Errorf("expected unauthenticated identity, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteServerStatus_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	status := serverStatus{
+		Server:      "radar.local:8080",
+		Version:     "2.1.0",
+		AuthMode:    "public",
+		Aircraft:    7,
+		MessageRate: 4,
+	}
+	if err := writeServerStatus(&buf, status, true); err != nil {
+		t.Fatalf("writeServerStatus: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded["version"] != "2.1.0" {
+		t.Errorf("expected version 2.1.0, got %v", decoded["version"])
+	}
+	if decoded["aircraft"] != float64(7) {
+		t.Errorf("expected 7 aircraft, got %v", decoded["aircraft"])
+	}
+	if _, ok := decoded["identity"]; ok {
+		t.Error("empty identity should be omitted")
+	}
+}
+
+func TestServerStatusCmd_Flags(t *testing.T) {
+	// Flags are registered by SetupCommands in TestMain
+	for _, name := range []string{"json", "timeout", "sample"} {
+		if serverStatusCmd.Flag(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+	if got := serverStatusCmd.Flag("timeout").DefValue; got != "10s" {
+		t.Errorf("expected default timeout 10s, got %s", got)
+	}
+}
@@ -0,0 +1,355 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/auth"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/search"
+	"github.com/spf13/cobra"
+)
+
+var (
+	streamFilter string
+	streamTypes  []string
+	streamBuffer int
+)
+
+var streamCmd = &cobra.Command{
+	Use:   "stream",
+	Short: "Write live aircraft and ACARS events as JSON Lines",
+	Long: `Connect to the SkySpy server like the radar does and write one JSON
+object per line to stdout for every aircraft new/update/remove event and
+ACARS message, until interrupted. Triggered alert rules can be included
+with --types alert.
+
+Events use the radar's normalized view of each aircraft (distance and
+bearing from your receiver, cleaned-up callsigns), not raw server payloads.
+If the reader falls behind, events are dropped rather than buffered without
+limit; the number dropped is reported on stderr at exit.
+
+Examples:
+  skyspy stream
+  skyspy stream --types new,remove
+  skyspy stream --filter "mil alt:>10000" | jq .callsign`,
+	RunE: runStream,
+}
+
+// RegisterStreamFlags sets up the stream command flags.
+// Call this from the main command initialization.
+func RegisterStreamFlags() {
+	streamCmd.Flags().StringVar(&streamFilter, "filter", "", "Only emit aircraft matching a search query (e.g. \"mil alt:>10000\")")
+	streamCmd.Flags().StringSliceVar(&streamTypes, "types", []string{"new", "update", "remove", "acars"}, "Event types to emit")
+	streamCmd.Flags().IntVar(&streamBuffer, "buffer", 1024, "Events held for a slow reader before dropping")
+}
+
+// streamRecord is one line written by `skyspy stream`
+type streamRecord struct {
+	Event        string       `json:"event"`
+	Time         time.Time    `json:"time"`
+	Hex          string       `json:"hex,omitempty"`
+	Callsign     string       `json:"callsign,omitempty"`
+	Lat          *float64     `json:"lat,omitempty"`
+	Lon          *float64     `json:"lon,omitempty"`
+	Altitude     *int         `json:"altitude,omitempty"`      // feet
+	Speed        *float64     `json:"speed,omitempty"`         // knots
+	Track        *float64     `json:"track,omitempty"`         // degrees
+	VerticalRate *float64     `json:"vertical_rate,omitempty"` // feet per minute
+	Distance     *float64     `json:"distance,omitempty"`      // nautical miles
+	Bearing      *float64     `json:"bearing,omitempty"`       // degrees from the receiver
+	RSSI         *float64     `json:"rssi,omitempty"`
+	Squawk       string       `json:"squawk,omitempty"`
+	AircraftType string       `json:"aircraft_type,omitempty"`
+	Military     bool         `json:"military,omitempty"`
+	Emergency    bool         `json:"emergency,omitempty"`
+	NavAltitude  *int         `json:"nav_altitude,omitempty"` // selected altitude, feet
+	NavHeading   *float64     `json:"nav_heading,omitempty"`  // selected heading, degrees
+	NavQNH       *float64     `json:"nav_qnh,omitempty"`      // hPa
+	NavModes     []string     `json:"nav_modes,omitempty"`
+	ACARS        *acarsRecord `json:"acars,omitempty"`
+	Alert        *alertRecord `json:"alert,omitempty"`
+}
+
+// acarsRecord is the ACARS portion of a stream record
+type acarsRecord struct {
+	Callsign string `json:"callsign,omitempty"`
+	Flight   string `json:"flight,omitempty"`
+	Label    string `json:"label,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+// alertRecord is the alert portion of a stream record
+type alertRecord struct {
+	RuleID   string `json:"rule_id"`
+	Rule     string `json:"rule"`
+	Priority int    `json:"priority"`
+	Message  string `json:"message"`
+}
+
+func runStream(cmd *cobra.Command, args []string) error {
+	types, err := parseStreamTypes(streamTypes)
+	if err != nil {
+		return err
+	}
+	if streamBuffer < 1 {
+		return fmt.Errorf("--buffer must be at least 1")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// Apply command line overrides
+	if host != "" {
+		cfg.Connection.Host = host
+	}
+	if port != 0 {
+		cfg.Connection.Port = port
+	}
+
+	authMgr, err := auth.NewManager(cfg.Connection.Host, cfg.Connection.Port)
+	if err != nil {
+		return fmt.Errorf("failed to initialize auth: %w", err)
+	}
+	if apiKey != "" {
+		authMgr.SetAPIKey(apiKey)
+	}
+	if authMgr.RequiresAuth() && !authMgr.IsAuthenticated() {
+		return fmt.Errorf("server requires authentication: run 'skyspy login' or use --api-key")
+	}
+
+	client := newFeedClient(cfg, authMgr)
+	client.Start()
+	defer client.Stop()
+
+	// Feed messages through a headless radar model so events are normalized
+	// exactly as the TUI sees them
+	model := app.NewModel(cfg)
+	model.SetAudioEnabled(false)
+	if lat, lon, ok := authMgr.ReceiverPosition(); ok {
+		model.SetServerPosition(lat, lon)
+	}
+	model.LoadOverlays()
+
+	stream := newEventStream(cmd.OutOrStdout(), search.ParseQuery(streamFilter), types, streamBuffer)
+	model.SetEventHandler(stream.handle)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	pumpStream(ctx, model, client.AircraftMessages(), client.ACARSMessages(), stream.failed)
+
+	dropped, err := stream.Close()
+	if dropped > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "skyspy stream: dropped %d events (reader too slow)\n", dropped)
+	}
+	// A closed pipe (e.g. `| head`) is a normal way to stop
+	if err != nil && !errors.Is(err, syscall.EPIPE) {
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+	return nil
+}
+
+// parseStreamTypes validates the --types values
+func parseStreamTypes(values []string) (map[app.EventType]bool, error) {
+	types := make(map[app.EventType]bool, len(values))
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		valid := false
+		for _, t := range app.EventTypes {
+			if app.EventType(v) == t {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown event type %q (want new, update, remove, acars or alert)", v)
+		}
+		types[app.EventType(v)] = true
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("--types must name at least one event type")
+	}
+	return types, nil
+}
+
+// pumpStream feeds server messages into the model until ctx is canceled or
+// the output fails
+func pumpStream(ctx context.Context, model *app.Model, aircraft, acars <-chan codec.Message, failed <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-failed:
+			return
+		case msg := <-aircraft:
+			model.IngestAircraftMessage(msg)
+		case msg := <-acars:
+			model.IngestACARSMessage(msg)
+		}
+	}
+}
+
+// eventStream filters model events and writes them as JSON Lines. The feed
+// never waits on the reader: when the queue is full, events are dropped and
+// counted instead.
+type eventStream struct {
+	filter  *search.Filter
+	types   map[app.EventType]bool
+	now     func() time.Time
+	queue   chan streamRecord
+	dropped int
+	failed  chan struct{} // closed when a write fails
+	done    chan struct{} // closed when the writer has exited
+	err     error
+}
+
+func newEventStream(w io.Writer, filter *search.Filter, types map[app.EventType]bool, buffer int) *eventStream {
+	s := &eventStream{
+		filter: filter,
+		types:  types,
+		now:    time.Now,
+		queue:  make(chan streamRecord, buffer),
+		failed: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.run(w)
+	return s
+}
+
+func (s *eventStream) run(w io.Writer) {
+	defer close(s.done)
+	enc := json.NewEncoder(w)
+	for rec := range s.queue {
+		if s.err != nil {
+			continue // keep draining so the feed never blocks
+		}
+		if err := enc.Encode(rec); err != nil {
+			s.err = err
+			close(s.failed)
+		}
+	}
+}
+
+// handle queues ev for output if it passes the type and search filters
+func (s *eventStream) handle(ev app.Event) {
+	if !s.types[ev.Type] || !s.matches(ev) {
+		return
+	}
+	select {
+	case s.queue <- newStreamRecord(ev, s.now()):
+	default:
+		s.dropped++
+	}
+}
+
+// matches applies the search filter. ACARS messages from untracked senders
+// are matched on their flight or callsign alone.
+func (s *eventStream) matches(ev app.Event) bool {
+	if s.filter == nil || !s.filter.IsActive() {
+		return true
+	}
+	target := ev.Target
+	if target == nil && ev.ACARS != nil {
+		callsign := ev.ACARS.Flight
+		if callsign == "" {
+			callsign = ev.ACARS.Callsign
+		}
+		target = &radar.Target{Callsign: callsign}
+	}
+	return target != nil && search.MatchesAircraft(target, s.filter)
+}
+
+// Close flushes queued events and returns the drop count and any write error
+func (s *eventStream) Close() (int, error) {
+	close(s.queue)
+	<-s.done
+	return s.dropped, s.err
+}
+
+// newStreamRecord converts an event to its output form
+func newStreamRecord(ev app.Event, now time.Time) streamRecord {
+	rec := streamRecord{Event: string(ev.Type), Time: now.UTC()}
+
+	if t := ev.Target; t != nil {
+		rec.Hex = t.Hex
+		rec.Callsign = t.Callsign
+		rec.Squawk = t.Squawk
+		rec.AircraftType = t.ACType
+		rec.Military = t.Military
+		rec.Emergency = t.IsEmergency()
+		if t.HasLat && t.HasLon {
+			rec.Lat, rec.Lon = &t.Lat, &t.Lon
+		}
+		if t.HasAlt {
+			rec.Altitude = &t.Altitude
+		}
+		if t.HasSpeed {
+			rec.Speed = &t.Speed
+		}
+		if t.HasTrack {
+			rec.Track = &t.Track
+		}
+		if t.HasVS {
+			rec.VerticalRate = &t.Vertical
+		}
+		if t.HasRSSI {
+			rec.RSSI = &t.RSSI
+		}
+		if t.Distance > 0 {
+			rec.Distance, rec.Bearing = &t.Distance, &t.Bearing
+		}
+		if t.HasNavAlt {
+			rec.NavAltitude = &t.NavAltitude
+		}
+		if t.HasNavHeading {
+			rec.NavHeading = &t.NavHeading
+		}
+		if t.HasNavQNH {
+			rec.NavQNH = &t.NavQNH
+		}
+		rec.NavModes = t.NavModes
+	}
+
+	if a := ev.ACARS; a != nil {
+		rec.ACARS = &acarsRecord{
+			Callsign: a.Callsign,
+			Flight:   a.Flight,
+			Label:    a.Label,
+			Text:     a.Text,
+		}
+		if rec.Callsign == "" {
+			rec.Callsign = strings.TrimSpace(a.Flight)
+		}
+	}
+
+	if a := ev.Alert; a != nil {
+		rec.Alert = &alertRecord{Message: a.Message}
+		if a.Rule != nil {
+			rec.Alert.RuleID = a.Rule.ID
+			rec.Alert.Rule = a.Rule.Name
+			rec.Alert.Priority = a.Rule.Priority
+		}
+		if rec.Hex == "" {
+			rec.Hex = a.Hex
+		}
+	}
+	return rec
+}
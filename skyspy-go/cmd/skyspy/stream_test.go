@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/search"
+)
+
+func allStreamTypes() map[app.EventType]bool {
+	types, _ := parseStreamTypes([]string{"new", "update", "remove", "acars"})
+	return types
+}
+
+// runStreamFeed pushes msgs through a headless model into an event stream
+// and returns the decoded records
+func runStreamFeed(t *testing.T, filter string, types map[app.EventType]bool, aircraft, acars []codec.Message) []streamRecord {
+	t.Helper()
+	var out bytes.Buffer
+	stream := newEventStream(&out, search.ParseQuery(filter), types, 64)
+
+	model := statusTestModel()
+	model.SetEventHandler(stream.handle)
+	for _, msg := range aircraft {
+		model.IngestAircraftMessage(msg)
+	}
+	for _, msg := range acars {
+		model.IngestACARSMessage(msg)
+	}
+
+	dropped, err := stream.Close()
+	if err != nil || dropped != 0 {
+		t.Fatalf("Close: dropped %d, err %v", dropped, err)
+	}
+
+	var records []streamRecord
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var rec streamRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestEventStream_NormalizedAircraftEvents(t *testing.T) {
+	lat, lon, alt, dist, brg := 52.5, 4.9, 12000, 12.5, 90.0
+	records := runStreamFeed(t, "", allStreamTypes(), []codec.Message{
+		statusTestMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "ABC123", Flight: "KLM123  ", Lat: &lat, Lon: &lon, AltBaro: &alt, Distance: &dist, Bearing: &brg, Squawk: "7700"}),
+		statusTestMessage(t, codec.AircraftUpdate, codec.Aircraft{Hex: "ABC123", Flight: "KLM123"}),
+		statusTestMessage(t, codec.AircraftRemove, codec.Aircraft{Hex: "ABC123"}),
+	}, nil)
+
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	if records[0].Event != "new" || records[1].Event != "update" || records[2].Event != "remove" {
+		t.Errorf("unexpected event order: %s, %s, %s", records[0].Event, records[1].Event, records[2].Event)
+	}
+
+	first := records[0]
+	if first.Callsign != "KLM123" {
+		t.Errorf("expected trimmed callsign, got %q", first.Callsign)
+	}
+	if first.Altitude == nil || *first.Altitude != 12000 {
+		t.Errorf("expected altitude 12000, got %v", first.Altitude)
+	}
+	if first.Distance == nil || *first.Distance != 12.5 || first.Bearing == nil {
+		t.Errorf("expected distance and bearing, got %v %v", first.Distance, first.Bearing)
+	}
+	if !first.Emergency {
+		t.Error("expected emergency flag for squawk 7700")
+	}
+	if first.Speed != nil {
+		t.Error("unknown fields should be omitted")
+	}
+	if records[2].Hex != "ABC123" {
+		t.Errorf("remove should carry the hex, got %q", records[2].Hex)
+	}
+}
+
+func TestEventStream_FilterAndTypes(t *testing.T) {
+	types, err := parseStreamTypes([]string{"new", "acars"})
+	if err != nil {
+		t.Fatalf("parseStreamTypes: %v", err)
+	}
+
+	records := runStreamFeed(t, "mil", types, []codec.Message{
+		statusTestMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "MIL001", Flight: "RCH42", Military: true}),
+		statusTestMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "CIV001", Flight: "KLM123"}),
+		statusTestMessage(t, codec.AircraftUpdate, codec.Aircraft{Hex: "MIL001", Flight: "RCH42", Military: true}),
+	}, []codec.Message{
+		statusTestMessage(t, codec.ACARSMessage, codec.ACARSData{Flight: "RCH42", Label: "H1", Text: "POSITION"}),
+		statusTestMessage(t, codec.ACARSMessage, codec.ACARSData{Flight: "KLM123", Label: "H1", Text: "WEATHER"}),
+	})
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d: %+v", len(records), records)
+	}
+	if records[0].Event != "new" || records[0].Hex != "MIL001" {
+		t.Errorf("expected new MIL001, got %s %s", records[0].Event, records[0].Hex)
+	}
+	if records[1].Event != "acars" || records[1].ACARS == nil || records[1].ACARS.Text != "POSITION" {
+		t.Errorf("expected ACARS from the tracked military sender, got %+v", records[1])
+	}
+	if !records[1].Military {
+		t.Error("ACARS record should carry the sender's tracked state")
+	}
+}
+
+func TestEventStream_UntrackedACARSMatchesOnFlight(t *testing.T) {
+	records := runStreamFeed(t, "UAL", allStreamTypes(), nil, []codec.Message{
+		statusTestMessage(t, codec.ACARSMessage, codec.ACARSData{Flight: "UAL9", Text: "ONE"}),
+		statusTestMessage(t, codec.ACARSMessage, codec.ACARSData{Flight: "DAL9", Text: "TWO"}),
+	})
+
+	if len(records) != 1 || records[0].Callsign != "UAL9" {
+		t.Errorf("expected only the UAL9 message, got %+v", records)
+	}
+}
+
+func TestEventStream_DropsWhenReaderStalls(t *testing.T) {
+	pr, pw := io.Pipe()
+	stream := newEventStream(pw, nil, allStreamTypes(), 1)
+
+	for i := 0; i < 10; i++ {
+		stream.handle(app.Event{Type: app.EventACARS, ACARS: &app.ACARSMessage{Text: "X"}})
+	}
+
+	// Nothing ever reads, so the writer is stuck on its first record
+	_ = pr.Close()
+	dropped, err := stream.Close()
+	if dropped < 8 {
+		t.Errorf("expected at least 8 dropped events, got %d", dropped)
+	}
+	if !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("expected closed pipe error, got %v", err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestPumpStream_StopsOnWriteFailure(t *testing.T) {
+	stream := newEventStream(failingWriter{}, nil, allStreamTypes(), 4)
+	model := statusTestModel()
+	model.SetEventHandler(stream.handle)
+
+	aircraft := make(chan codec.Message, 1)
+	aircraft <- statusTestMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "ABC123"})
+
+	done := make(chan struct{})
+	go func() {
+		pumpStream(context.Background(), model, aircraft, nil, stream.failed)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pumpStream should stop after a write failure")
+	}
+	if _, err := stream.Close(); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("expected write error, got %v", err)
+	}
+}
+
+func TestPumpStream_StopsOnCancel(t *testing.T) {
+	stream := newEventStream(io.Discard, nil, allStreamTypes(), 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pumpStream(ctx, statusTestModel(), nil, nil, stream.failed)
+	if _, err := stream.Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseStreamTypes(t *testing.T) {
+	types, err := parseStreamTypes([]string{" NEW ", "remove"})
+	if err != nil {
+		t.Fatalf("parseStreamTypes: %v", err)
+	}
+	if !types[app.EventNew] || !types[app.EventRemove] || types[app.EventUpdate] {
+		t.Errorf("unexpected types: %v", types)
+	}
+
+	if _, err := parseStreamTypes([]string{"landing"}); err == nil || !strings.Contains(err.Error(), `"landing"`) {
+		t.Errorf("expected unknown type error, got %v", err)
+	}
+	if _, err := parseStreamTypes(nil); err == nil {
+		t.Error("expected error for no types")
+	}
+}
+
+func TestStreamCmd_Flags(t *testing.T) {
+	for _, name := range []string{"filter", "types", "buffer"} {
+		if streamCmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag", name)
+		}
+	}
+	if def := streamCmd.Flags().Lookup("types").DefValue; def != "[new,update,remove,acars]" {
+		t.Errorf("unexpected --types default %q", def)
+	}
+}
+
+func TestEventStream_Alerts(t *testing.T) {
+	types, _ := parseStreamTypes([]string{"alert"})
+	records := runStreamFeed(t, "", types, []codec.Message{
+		statusTestMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "ABC123", Flight: "KLM123", Squawk: "7700"}),
+	}, nil)
+
+	if len(records) == 0 {
+		t.Fatal("expected an alert record for the emergency squawk")
+	}
+	rec := records[0]
+	if rec.Event != "alert" || rec.Alert == nil {
+		t.Fatalf("expected an alert record, got %+v", rec)
+	}
+	if rec.Alert.RuleID == "" || rec.Alert.Message == "" {
+		t.Errorf("alert should name its rule and message, got %+v", rec.Alert)
+	}
+	if rec.Callsign != "KLM123" {
+		t.Errorf("alert should carry the aircraft, got callsign %q", rec.Callsign)
+	}
+}
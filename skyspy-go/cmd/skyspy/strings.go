@@ -0,0 +1,71 @@
+// Package main provides the entry point for the SkySpy CLI application
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var stringsFile string
+
+var stringsCmd = &cobra.Command{
+	Use:   "strings",
+	Short: "Check a translation of the UI strings",
+	Long: `Compare a strings file with the built-in English and list the keys it
+doesn't translate, keys English doesn't have, and translations whose
+format verbs (%s, %d, ...) differ from the English.
+
+Untranslated keys show in English. Unknown keys and mismatched verbs are
+errors: those entries are ignored when the radar loads the file.
+
+Examples:
+  skyspy strings
+  skyspy strings --file ~/translations/de.json`,
+	Args: cobra.NoArgs,
+	RunE: runStrings,
+}
+
+// RegisterStringsFlags sets up the strings command flags.
+// Call this from the main command initialization.
+func RegisterStringsFlags() {
+	stringsCmd.Flags().StringVar(&stringsFile, "file", "", "Strings file to check (default: strings.json in the config directory)")
+}
+
+func runStrings(cmd *cobra.Command, args []string) error {
+	path := stringsFile
+	if path == "" {
+		path = config.GetStringsPath()
+	}
+
+	report, err := i18n.Check(path)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if report.OK() {
+		fmt.Fprintf(out, "%s translates all %d strings\n", path, len(i18n.Keys()))
+		return nil
+	}
+	writeKeys := func(heading string, keys []string) {
+		if len(keys) == 0 {
+			return
+		}
+		fmt.Fprintf(out, "%s (%d):\n", heading, len(keys))
+		for _, key := range keys {
+			fmt.Fprintf(out, "  %s\n", key)
+		}
+	}
+	writeKeys("Untranslated", report.Missing)
+	writeKeys("Unknown", report.Unknown)
+	writeKeys("Format verbs differ from English", report.Mismatched)
+
+	if len(report.Unknown) > 0 || len(report.Mismatched) > 0 {
+		return errors.New("strings file has entries that will be ignored")
+	}
+	return nil
+}
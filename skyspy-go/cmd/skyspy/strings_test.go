@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunStrings(t *testing.T) {
+	var out bytes.Buffer
+	stringsCmd.SetOut(&out)
+	defer stringsCmd.SetOut(nil)
+	defer func() { stringsFile = "" }()
+
+	path := filepath.Join(t.TempDir(), "strings.json")
+	stringsFile = path
+	if err := os.WriteFile(path, []byte(`{"help.close": "Fermer"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runStrings(stringsCmd, nil); err != nil {
+		t.Fatalf("untranslated keys alone should not fail: %v", err)
+	}
+	if !strings.Contains(out.String(), "Untranslated") || !strings.Contains(out.String(), "  help.vu\n") {
+		t.Errorf("expected untranslated keys listed, got %q", out.String())
+	}
+	if strings.Contains(out.String(), "  help.close\n") {
+		t.Errorf("help.close is translated, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := os.WriteFile(path, []byte(`{"help.clsoe": "Fermer", "notify.range": "Portée : %s"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runStrings(stringsCmd, nil); err == nil {
+		t.Error("expected an error for unknown and mismatched keys")
+	}
+	if !strings.Contains(out.String(), "help.clsoe") || !strings.Contains(out.String(), "Format verbs differ") {
+		t.Errorf("expected unknown and mismatched keys listed, got %q", out.String())
+	}
+
+	stringsFile = filepath.Join(t.TempDir(), "missing.json")
+	if err := runStrings(stringsCmd, nil); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,165 @@
+// Package actype provides a database of ICAO aircraft type designators,
+// giving the manufacturer, model and class behind codes like "A320"
+package actype
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+//go:embed types.csv
+var typesCSV []byte
+
+// Type classes, from the ICAO wake turbulence category; helicopters are
+// rotorcraft whatever their weight
+const (
+	ClassHeavy      = "heavy"
+	ClassMedium     = "medium"
+	ClassLight      = "light"
+	ClassRotorcraft = "rotorcraft"
+)
+
+// Classes lists the type classes in order of size
+var Classes = []string{ClassHeavy, ClassMedium, ClassLight, ClassRotorcraft}
+
+// Type describes one aircraft type
+type Type struct {
+	Designator   string // ICAO type designator, e.g. "A320"
+	Manufacturer string
+	Model        string
+	Class        string // one of Classes, or empty when unknown
+}
+
+// Description returns the manufacturer and model, e.g. "Airbus A320"
+func (t Type) Description() string {
+	return strings.TrimSpace(t.Manufacturer + " " + t.Model)
+}
+
+// Database maps type designators to aircraft types
+type Database struct {
+	types map[string]Type
+}
+
+var (
+	builtinOnce sync.Once
+	builtin     *Database
+)
+
+// Builtin returns the database compiled into SkySpy
+func Builtin() *Database {
+	builtinOnce.Do(func() {
+		types, err := Parse(bytes.NewReader(typesCSV))
+		if err != nil {
+			panic(fmt.Sprintf("actype: embedded types.csv: %v", err))
+		}
+		builtin = &Database{types: make(map[string]Type, len(types))}
+		for _, t := range types {
+			builtin.types[t.Designator] = t
+		}
+	})
+	return builtin
+}
+
+// Load returns the built-in database with the types in the CSV file at
+// path added over it; a type in the file replaces the built-in one. An
+// empty path returns the built-in database.
+func Load(path string) (*Database, error) {
+	base := Builtin()
+	if path == "" {
+		return base, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return base, err
+	}
+	defer f.Close()
+	types, err := Parse(f)
+	if err != nil {
+		return base, fmt.Errorf("%s: %w", path, err)
+	}
+
+	db := &Database{types: make(map[string]Type, len(base.types)+len(types))}
+	for code, t := range base.types {
+		db.types[code] = t
+	}
+	for _, t := range types {
+		db.types[t.Designator] = t
+	}
+	return db, nil
+}
+
+// Lookup returns the type for a designator, ignoring case and spaces
+func (db *Database) Lookup(code string) (Type, bool) {
+	if db == nil {
+		return Type{}, false
+	}
+	t, ok := db.types[strings.ToUpper(strings.TrimSpace(code))]
+	return t, ok
+}
+
+// Len returns the number of types in the database
+func (db *Database) Len() int {
+	if db == nil {
+		return 0
+	}
+	return len(db.types)
+}
+
+// Parse reads types from CSV with the columns designator, manufacturer,
+// model and class. Lines starting with # and a header row starting with
+// "designator" are skipped; the class may be left empty.
+func Parse(r io.Reader) ([]Type, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var types []Type
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return types, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if len(record) != 4 {
+			return nil, fmt.Errorf("line %d: want 4 fields, got %d", line, len(record))
+		}
+		code := strings.ToUpper(strings.TrimSpace(record[0]))
+		if code == "DESIGNATOR" {
+			continue
+		}
+		if code == "" {
+			return nil, fmt.Errorf("line %d: missing type designator", line)
+		}
+		class := strings.ToLower(strings.TrimSpace(record[3]))
+		if class != "" && !ValidClass(class) {
+			return nil, fmt.Errorf("line %d: unknown class %q (want %s)", line, record[3], strings.Join(Classes, ", "))
+		}
+		types = append(types, Type{
+			Designator:   code,
+			Manufacturer: strings.TrimSpace(record[1]),
+			Model:        strings.TrimSpace(record[2]),
+			Class:        class,
+		})
+	}
+}
+
+// ValidClass reports whether class is one of Classes, ignoring case
+func ValidClass(class string) bool {
+	for _, c := range Classes {
+		if strings.EqualFold(class, c) {
+			return true
+		}
+	}
+	return false
+}
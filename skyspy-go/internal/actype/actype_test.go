@@ -0,0 +1,107 @@
+package actype
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltin(t *testing.T) {
+	db := Builtin()
+	if db.Len() < 100 {
+		t.Fatalf("built-in database has only %d types", db.Len())
+	}
+
+	tests := []struct {
+		code        string
+		description string
+		class       string
+	}{
+		{"A320", "Airbus A320", ClassMedium},
+		{" b77w ", "Boeing 777-300ER", ClassHeavy},
+		{"C172", "Cessna 172 Skyhawk", ClassLight},
+		{"EC35", "Airbus Helicopters H135", ClassRotorcraft},
+	}
+	for _, tt := range tests {
+		got, ok := db.Lookup(tt.code)
+		if !ok {
+			t.Errorf("Lookup(%q) found nothing", tt.code)
+			continue
+		}
+		if got.Description() != tt.description || got.Class != tt.class {
+			t.Errorf("Lookup(%q) = %q %s, want %q %s", tt.code, got.Description(), got.Class, tt.description, tt.class)
+		}
+	}
+
+	if _, ok := db.Lookup("ZZZZ"); ok {
+		t.Error("an unknown designator should not be found")
+	}
+	if _, ok := db.Lookup(""); ok {
+		t.Error("an empty designator should not be found")
+	}
+}
+
+func TestBuiltin_ClassesValid(t *testing.T) {
+	for code, typ := range Builtin().types {
+		if !ValidClass(typ.Class) || typ.Manufacturer == "" || typ.Model == "" {
+			t.Errorf("%s: incomplete entry %+v", code, typ)
+		}
+	}
+}
+
+func TestLoad_Overrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "types.csv")
+	data := "# local additions\n" +
+		"designator,manufacturer,model,class\n" +
+		"a320,Airbus,A320ceo,medium\n" +
+		"GYRO,Magni,M24 Orion,ROTORCRAFT\n" +
+		"XXXX,,Mystery,\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := db.Lookup("A320"); got.Model != "A320ceo" {
+		t.Errorf("A320 should be overridden, got %+v", got)
+	}
+	if got, _ := db.Lookup("GYRO"); got.Class != ClassRotorcraft {
+		t.Errorf("GYRO class = %q", got.Class)
+	}
+	if got, _ := db.Lookup("XXXX"); got.Description() != "Mystery" || got.Class != "" {
+		t.Errorf("XXXX = %+v", got)
+	}
+	if _, ok := db.Lookup("B738"); !ok {
+		t.Error("built-in types should still be found")
+	}
+	if got, _ := Builtin().Lookup("A320"); got.Model != "A320" {
+		t.Error("overrides should not change the built-in database")
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	if db, err := Load(""); err != nil || db != Builtin() {
+		t.Errorf("an empty path should give the built-in database: %v", err)
+	}
+	if db, err := Load(filepath.Join(t.TempDir(), "missing.csv")); err == nil || db != Builtin() {
+		t.Error("a missing file should fail and fall back to the built-in database")
+	}
+
+	for _, bad := range []string{
+		"A320,Airbus,A320\n",
+		",Airbus,A320,medium\n",
+		"A320,Airbus,A320,jumbo\n",
+	} {
+		path := filepath.Join(t.TempDir(), "types.csv")
+		if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Load(path)
+		if err == nil || !strings.Contains(err.Error(), "line 1") {
+			t.Errorf("Load(%q) error = %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Package alerts provides configurable alert rules for aircraft monitoring
+package alerts
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// MessageState is an ACARS message as seen by the alert engine
+type MessageState struct {
+	Callsign string
+	Flight   string
+	Label    string
+	Text     string
+}
+
+// sender returns the callsign the message was sent under
+func (s *MessageState) sender() string {
+	if cs := strings.TrimSpace(s.Callsign); cs != "" {
+		return cs
+	}
+	return strings.TrimSpace(s.Flight)
+}
+
+// isMessageCondition reports whether a condition tests ACARS content
+func isMessageCondition(t ConditionType) bool {
+	return t == ConditionACARSLabel || t == ConditionACARSText
+}
+
+// IsMessageRule reports whether the rule fires on ACARS messages rather
+// than aircraft state updates
+func (r *AlertRule) IsMessageRule() bool {
+	for _, cond := range r.Conditions {
+		if isMessageCondition(cond.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// messageCooldownKey keys message-rule cooldowns on the sender so repeated
+// transmissions don't raise an alert each
+func messageCooldownKey(msg *MessageState) string {
+	return "acars:" + strings.ToUpper(msg.sender())
+}
+
+// CheckMessage checks an ACARS message against the enabled message rules.
+// aircraft is the sender's tracked state, or nil if it isn't tracked;
+// non-message conditions in a rule are evaluated against it.
+func (e *AlertEngine) CheckMessage(msg *MessageState, aircraft *AircraftState) []TriggeredAlert {
+	var triggered []TriggeredAlert
+	if msg == nil {
+		return triggered
+	}
+
+	state := aircraft
+	if state == nil {
+		state = &AircraftState{Callsign: msg.sender()}
+	}
+	key := messageCooldownKey(msg)
+	now := e.now()
+
+	for _, rule := range e.ruleSet.GetEnabledRules() {
+		if !rule.IsMessageRule() || !rule.CanTrigger(key) {
+			continue
+		}
+		if !e.evaluateMessageRule(rule, msg, state) {
+			continue
+		}
+
+		alert := e.createMessageAlert(rule, msg, aircraft)
+		triggered = append(triggered, alert)
+		rule.RecordTrigger(key)
+
+		if aircraft != nil && aircraft.Hex != "" {
+			for _, action := range alert.Actions {
+				if action.Type == ActionHighlight {
+					e.mutex.Lock()
+					e.highlightedAircraft[codec.NormalizeHex(aircraft.Hex)] = now
+					e.mutex.Unlock()
+				}
+			}
+		}
+	}
+
+	if len(triggered) > 0 {
+		e.mutex.Lock()
+		e.recentAlerts = append(e.recentAlerts, triggered...)
+		if len(e.recentAlerts) > e.maxRecentAlerts {
+			e.recentAlerts = e.recentAlerts[len(e.recentAlerts)-e.maxRecentAlerts:]
+		}
+		e.mutex.Unlock()
+	}
+
+	return triggered
+}
+
+// evaluateMessageRule applies the same grouping as evaluateRule: OR within a
+// condition type, AND across types
+func (e *AlertEngine) evaluateMessageRule(rule *AlertRule, msg *MessageState, state *AircraftState) bool {
+	conditionsByType := make(map[ConditionType][]Condition)
+	for _, cond := range rule.Conditions {
+		conditionsByType[cond.Type] = append(conditionsByType[cond.Type], cond)
+	}
+
+	for _, conditions := range conditionsByType {
+		anyMatch := false
+		for _, cond := range conditions {
+			var ok bool
+			if isMessageCondition(cond.Type) {
+				ok = e.evaluateMessageCondition(cond, msg)
+			} else {
+				ok = e.evaluateCondition(cond, state, nil, dwellSnapshot{})
+			}
+			if ok {
+				anyMatch = true
+				break
+			}
+		}
+		if !anyMatch {
+			return false
+		}
+	}
+
+	return len(rule.Conditions) > 0
+}
+
+// evaluateMessageCondition checks one ACARS condition against a message
+func (e *AlertEngine) evaluateMessageCondition(cond Condition, msg *MessageState) bool {
+	switch cond.Type {
+	case ConditionACARSLabel:
+		return MatchesWildcard(cond.Value, strings.TrimSpace(msg.Label))
+
+	case ConditionACARSText:
+		if cond.Value == "" {
+			return false
+		}
+		if cond.Regex {
+			re := e.compileTextPattern(cond.Value)
+			return re != nil && re.MatchString(msg.Text)
+		}
+		return strings.Contains(strings.ToUpper(msg.Text), strings.ToUpper(cond.Value))
+
+	default:
+		return false
+	}
+}
+
+// compileTextPattern compiles a case-insensitive text regex, caching the
+// result. Invalid patterns return nil and never match.
+func (e *AlertEngine) compileTextPattern(pattern string) *regexp.Regexp {
+	e.mutex.RLock()
+	re, ok := e.textPatterns[pattern]
+	e.mutex.RUnlock()
+	if ok {
+		return re
+	}
+
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		re = nil
+	}
+	e.mutex.Lock()
+	e.textPatterns[pattern] = re
+	e.mutex.Unlock()
+	return re
+}
+
+// createMessageAlert creates a triggered alert for an ACARS message, linked
+// to the sender's hex when it is tracked
+func (e *AlertEngine) createMessageAlert(rule *AlertRule, msg *MessageState, aircraft *AircraftState) TriggeredAlert {
+	state := aircraft
+	if state == nil {
+		state = &AircraftState{}
+	}
+	// The message's own callsign is what the crew sent; prefer it
+	named := *state
+	if cs := msg.sender(); cs != "" {
+		named.Callsign = cs
+	}
+
+	message := ""
+	for _, action := range rule.Actions {
+		if action.Type == ActionNotify && action.Message != "" {
+			message = formatMessageFields(e.formatMessage(action.Message, &named), msg)
+			break
+		}
+	}
+	if message == "" {
+		message = fmt.Sprintf("%s: %s [%s]", rule.Name, named.Callsign, strings.TrimSpace(msg.Label))
+	}
+
+	return TriggeredAlert{
+		Rule:      rule,
+		Hex:       state.Hex,
+		Callsign:  named.Callsign,
+		Message:   message,
+		Timestamp: e.now(),
+		Actions:   rule.Actions,
+		ACARS:     true,
+	}
+}
+
+// formatMessageFields fills the ACARS placeholders {label} and {text}
+func formatMessageFields(template string, msg *MessageState) string {
+	text := strings.Join(strings.Fields(msg.Text), " ")
+	if r := []rune(text); len(r) > 60 {
+		text = string(r[:57]) + "..."
+	}
+	template = strings.ReplaceAll(template, "{label}", strings.TrimSpace(msg.Label))
+	return strings.ReplaceAll(template, "{text}", text)
+}
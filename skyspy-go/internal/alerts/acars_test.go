@@ -0,0 +1,185 @@
+package alerts
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestCheckMessage_Label(t *testing.T) {
+	engine := NewAlertEngine()
+
+	rule := NewAlertRule("label", "Label Rule")
+	rule.AddCondition(ConditionACARSLabel, "H*")
+	engine.AddRule(rule)
+
+	if got := engine.CheckMessage(&MessageState{Callsign: "UAL1", Label: "Q0"}, nil); len(got) != 0 {
+		t.Errorf("label Q0 should not match H*, got %d alerts", len(got))
+	}
+	if got := engine.CheckMessage(&MessageState{Callsign: "UAL1", Label: "H1"}, nil); len(got) != 1 {
+		t.Errorf("label H1 should match H*, got %d alerts", len(got))
+	}
+}
+
+func TestCheckMessage_TextSubstring(t *testing.T) {
+	engine := NewAlertEngine()
+
+	rule := NewAlertRule("text", "Text Rule")
+	rule.AddCondition(ConditionACARSText, "medical")
+	engine.AddRule(rule)
+
+	if got := engine.CheckMessage(&MessageState{Callsign: "DAL2", Text: "REQ MEDICAL ASSIST ON ARR"}, nil); len(got) != 1 {
+		t.Errorf("substring match should be case-insensitive, got %d alerts", len(got))
+	}
+	if got := engine.CheckMessage(&MessageState{Callsign: "DAL3", Text: "WX REQUEST"}, nil); len(got) != 0 {
+		t.Errorf("unrelated text should not match, got %d alerts", len(got))
+	}
+}
+
+func TestCheckMessage_TextRegex(t *testing.T) {
+	engine := NewAlertEngine()
+
+	rule := NewAlertRule("regex", "Regex Rule")
+	rule.AddRegexCondition(ConditionACARSText, `MAYDAY|MEDICAL`)
+	engine.AddRule(rule)
+
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"mayday mayday", 1},
+		{"PAX MEDICAL", 1},
+		{"FUEL REMAINING 4.2", 0},
+	}
+	for i, tt := range tests {
+		msg := &MessageState{Callsign: "AAL" + string(rune('A'+i)), Text: tt.text}
+		if got := engine.CheckMessage(msg, nil); len(got) != tt.want {
+			t.Errorf("CheckMessage(%q) = %d alerts, want %d", tt.text, len(got), tt.want)
+		}
+	}
+}
+
+func TestCheckMessage_InvalidRegexNeverMatches(t *testing.T) {
+	engine := NewAlertEngine()
+
+	rule := NewAlertRule("bad", "Bad Regex")
+	rule.AddRegexCondition(ConditionACARSText, `(MAYDAY`)
+	engine.AddRule(rule)
+
+	if got := engine.CheckMessage(&MessageState{Callsign: "UAL1", Text: "(MAYDAY"}, nil); len(got) != 0 {
+		t.Errorf("invalid regex should never match, got %d alerts", len(got))
+	}
+}
+
+func TestCheckMessage_CooldownPerSender(t *testing.T) {
+	engine := NewAlertEngine()
+
+	rule := NewAlertRule("text", "Text Rule")
+	rule.AddCondition(ConditionACARSText, "MAYDAY")
+	rule.SetCooldown(time.Minute)
+	engine.AddRule(rule)
+
+	msg := &MessageState{Callsign: "UAL1", Text: "MAYDAY"}
+	if got := engine.CheckMessage(msg, nil); len(got) != 1 {
+		t.Fatalf("first message should trigger, got %d alerts", len(got))
+	}
+	if got := engine.CheckMessage(&MessageState{Callsign: "ual1", Text: "MAYDAY"}, nil); len(got) != 0 {
+		t.Errorf("repeat from the same sender should be in cooldown, got %d alerts", len(got))
+	}
+	if got := engine.CheckMessage(&MessageState{Callsign: "DAL2", Text: "MAYDAY"}, nil); len(got) != 1 {
+		t.Errorf("a different sender should still trigger, got %d alerts", len(got))
+	}
+}
+
+func TestCheckMessage_LinksTrackedAircraft(t *testing.T) {
+	engine := NewAlertEngine()
+
+	rule := NewAlertRule("text", "Text Rule")
+	rule.AddCondition(ConditionACARSText, "MAYDAY")
+	rule.AddAction(ActionHighlight, "")
+	engine.AddRule(rule)
+
+	aircraft := &AircraftState{Hex: "ABC123", Callsign: "UAL1"}
+	got := engine.CheckMessage(&MessageState{Callsign: "UAL1", Text: "MAYDAY"}, aircraft)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(got))
+	}
+	if got[0].Hex != "ABC123" || !got[0].ACARS {
+		t.Errorf("alert = %+v, want hex ABC123 marked ACARS", got[0])
+	}
+	if !engine.IsHighlighted("ABC123") {
+		t.Error("tracked sender should be highlighted")
+	}
+	if len(engine.GetRecentAlerts()) != 1 {
+		t.Error("message alert should be recorded in recent alerts")
+	}
+}
+
+func TestCheckMessage_AircraftConditions(t *testing.T) {
+	engine := NewAlertEngine()
+
+	rule := NewAlertRule("mil", "Military Text")
+	rule.AddCondition(ConditionACARSText, "RTB")
+	rule.AddCondition(ConditionMilitary, "true")
+	engine.AddRule(rule)
+
+	civil := &AircraftState{Hex: "A00001", Callsign: "UAL1"}
+	if got := engine.CheckMessage(&MessageState{Callsign: "UAL1", Text: "RTB"}, civil); len(got) != 0 {
+		t.Errorf("civil sender should not match a military rule, got %d alerts", len(got))
+	}
+	mil := &AircraftState{Hex: "AE0001", Callsign: "RCH1", Military: true}
+	if got := engine.CheckMessage(&MessageState{Callsign: "RCH1", Text: "RTB"}, mil); len(got) != 1 {
+		t.Errorf("military sender should match, got %d alerts", len(got))
+	}
+}
+
+func TestCheckAircraft_IgnoresMessageRules(t *testing.T) {
+	engine := NewAlertEngine()
+
+	rule := NewAlertRule("label", "Any Label")
+	rule.AddCondition(ConditionACARSLabel, "*")
+	engine.AddRule(rule)
+
+	if got := engine.CheckAircraft(&AircraftState{Hex: "ABC123"}, nil); len(got) != 0 {
+		t.Errorf("message rules should not fire on state updates, got %d alerts", len(got))
+	}
+}
+
+func TestCheckMessage_FormatsFields(t *testing.T) {
+	engine := NewAlertEngine()
+
+	rule := NewAlertRule("text", "Text Rule")
+	rule.AddCondition(ConditionACARSText, "MEDICAL")
+	rule.AddAction(ActionNotify, "{callsign} [{label}] {text}")
+	engine.AddRule(rule)
+
+	got := engine.CheckMessage(&MessageState{Flight: "BAW12", Label: "H1", Text: "PAX\nMEDICAL  ISSUE"}, nil)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(got))
+	}
+	if want := "BAW12 [H1] PAX MEDICAL ISSUE"; got[0].Message != want {
+		t.Errorf("Message = %q, want %q", got[0].Message, want)
+	}
+
+	// Long text is cut by character, never through a multi-byte one
+	long := &MessageState{Flight: "BAW34", Label: "H1", Text: "MEDICAL " + strings.Repeat("é", 60)}
+	got = engine.CheckMessage(long, nil)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(got))
+	}
+	if msg := got[0].Message; !utf8.ValidString(msg) || !strings.HasSuffix(msg, "é...") {
+		t.Errorf("long text should be cut to whole characters, got %q", msg)
+	}
+}
+
+func TestAlertRule_IsMessageRule(t *testing.T) {
+	state := NewAlertRule("state", "State").AddCondition(ConditionSquawk, "7700")
+	if state.IsMessageRule() {
+		t.Error("squawk rule should not be a message rule")
+	}
+	msg := NewAlertRule("msg", "Msg").AddCondition(ConditionACARSLabel, "5Z")
+	if !msg.IsMessageRule() {
+		t.Error("label rule should be a message rule")
+	}
+}
@@ -0,0 +1,120 @@
+// Package alerts provides configurable alert rules for aircraft monitoring
+package alerts
+
+import (
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// dwellSnapshot holds the geofence dwell times observed on a single update
+type dwellSnapshot struct {
+	inside map[string]time.Duration // geofence ID -> time continuously inside
+	exited map[string]time.Duration // geofence ID -> dwell before leaving on this update
+}
+
+// ParseDwellValue parses a dwell condition value of the form
+// "[geofence-id:]duration". The duration is a Go duration ("10m", "90s") or
+// a bare number of minutes. An empty or "*" geofence ID matches any geofence.
+func ParseDwellValue(value string) (geofenceID string, dwell time.Duration, ok bool) {
+	value = strings.TrimSpace(value)
+	durationPart := value
+	if idx := strings.LastIndex(value, ":"); idx >= 0 {
+		geofenceID = strings.TrimSpace(value[:idx])
+		durationPart = strings.TrimSpace(value[idx+1:])
+	}
+	if geofenceID == "*" {
+		geofenceID = ""
+	}
+
+	if d, err := time.ParseDuration(durationPart); err == nil {
+		dwell = d
+	} else if minutes := ParseFloat(durationPart); minutes > 0 {
+		dwell = time.Duration(minutes * float64(time.Minute))
+	}
+	if dwell <= 0 {
+		return "", 0, false
+	}
+	return geofenceID, dwell, true
+}
+
+// updateDwell records geofence entry and exit for an aircraft and returns
+// the dwell times seen on this update. Aircraft without a position keep
+// their existing entries so a dropped position report doesn't reset dwell.
+func (e *AlertEngine) updateDwell(state *AircraftState, now time.Time) dwellSnapshot {
+	snap := dwellSnapshot{
+		inside: make(map[string]time.Duration),
+		exited: make(map[string]time.Duration),
+	}
+	if !state.HasLat || !state.HasLon {
+		return snap
+	}
+
+	hex := codec.NormalizeHex(state.Hex)
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	entries := e.geofenceEntries[hex]
+	current := make(map[string]bool)
+	for _, gf := range e.geofenceManager.GetEnabledGeofences() {
+		if !gf.Contains(state.Lat, state.Lon) {
+			continue
+		}
+		current[gf.ID] = true
+		if entries == nil {
+			entries = make(map[string]time.Time)
+			e.geofenceEntries[hex] = entries
+		}
+		entered, ok := entries[gf.ID]
+		if !ok {
+			entered = now
+			entries[gf.ID] = now
+		}
+		snap.inside[gf.ID] = now.Sub(entered)
+	}
+
+	for id, entered := range entries {
+		if !current[id] {
+			snap.exited[id] = now.Sub(entered)
+			delete(entries, id)
+		}
+	}
+	if entries != nil && len(entries) == 0 {
+		delete(e.geofenceEntries, hex)
+	}
+
+	return snap
+}
+
+// matchDwell reports whether any matching geofence dwell reaches the
+// condition's duration
+func matchDwell(value string, dwells map[string]time.Duration) bool {
+	geofenceID, minDwell, ok := ParseDwellValue(value)
+	if !ok {
+		return false
+	}
+	if geofenceID != "" {
+		d, found := dwells[geofenceID]
+		return found && d >= minDwell
+	}
+	for _, d := range dwells {
+		if d >= minDwell {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDwellTime returns how long an aircraft has been continuously inside a
+// geofence, and false if it is not currently inside
+func (e *AlertEngine) GetDwellTime(hex, geofenceID string) (time.Duration, bool) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	entered, ok := e.geofenceEntries[codec.NormalizeHex(hex)][geofenceID]
+	if !ok {
+		return 0, false
+	}
+	return e.now().Sub(entered), true
+}
@@ -0,0 +1,175 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+)
+
+// newDwellTestEngine returns an engine with a 10nm circular geofence and a
+// controllable clock
+func newDwellTestEngine() (*AlertEngine, *time.Time) {
+	engine := NewAlertEngine()
+	engine.AddGeofence(NewCircleGeofence("AREA", "Test Area", 52.0, 4.0, 10))
+
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	engine.now = func() time.Time { return clock }
+	return engine, &clock
+}
+
+func dwellState(hex string, lat, lon float64) *AircraftState {
+	return &AircraftState{Hex: hex, Callsign: "LOITER1", Lat: lat, Lon: lon, HasLat: true, HasLon: true}
+}
+
+func TestParseDwellValue(t *testing.T) {
+	tests := []struct {
+		value  string
+		wantID string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"10m", "", 10 * time.Minute, true},
+		{"90s", "", 90 * time.Second, true},
+		{"15", "", 15 * time.Minute, true},
+		{"AREA:5m", "AREA", 5 * time.Minute, true},
+		{"*:2", "", 2 * time.Minute, true},
+		{" AREA : 1h ", "AREA", time.Hour, true},
+		{"AREA:", "", 0, false},
+		{"soon", "", 0, false},
+		{"-5m", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			id, d, ok := ParseDwellValue(tt.value)
+			if ok != tt.wantOK || id != tt.wantID || d != tt.want {
+				t.Errorf("ParseDwellValue(%q) = (%q, %v, %v), want (%q, %v, %v)",
+					tt.value, id, d, ok, tt.wantID, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGeofenceDwell_EnterLoiterLeave(t *testing.T) {
+	engine, clock := newDwellTestEngine()
+
+	loiter := NewAlertRule("loiter", "Loitering")
+	loiter.AddCondition(ConditionGeofenceDwell, "AREA:10m")
+	loiter.AddAction(ActionNotify, "{callsign} loitering")
+	loiter.SetCooldown(time.Hour)
+	engine.AddRule(loiter)
+
+	left := NewAlertRule("left", "Left after loitering")
+	left.AddCondition(ConditionGeofenceDwellExit, "AREA:10m")
+	left.AddAction(ActionNotify, "{callsign} left")
+	engine.AddRule(left)
+
+	// Outside the area: nothing tracked
+	if got := engine.CheckAircraft(dwellState("LOI001", 53.0, 4.0), nil); len(got) != 0 {
+		t.Fatalf("expected no alerts outside the area, got %d", len(got))
+	}
+
+	// Enter the area
+	*clock = clock.Add(time.Minute)
+	if got := engine.CheckAircraft(dwellState("LOI001", 52.0, 4.0), nil); len(got) != 0 {
+		t.Fatalf("entering should not trigger a dwell alert, got %d", len(got))
+	}
+	if d, ok := engine.GetDwellTime("LOI001", "AREA"); !ok || d != 0 {
+		t.Errorf("expected dwell tracking to start at 0, got %v (%v)", d, ok)
+	}
+
+	// Loiter for under the threshold
+	*clock = clock.Add(9 * time.Minute)
+	if got := engine.CheckAircraft(dwellState("LOI001", 52.01, 4.01), nil); len(got) != 0 {
+		t.Fatalf("9 minutes should not trigger a 10 minute dwell, got %d", len(got))
+	}
+
+	// Cross the threshold
+	*clock = clock.Add(time.Minute)
+	got := engine.CheckAircraft(dwellState("LOI001", 52.02, 4.0), nil)
+	if len(got) != 1 || got[0].Rule.ID != "loiter" {
+		t.Fatalf("expected the loiter alert after 10 minutes, got %+v", got)
+	}
+
+	// Leave the area
+	*clock = clock.Add(2 * time.Minute)
+	got = engine.CheckAircraft(dwellState("LOI001", 53.0, 4.0), nil)
+	if len(got) != 1 || got[0].Rule.ID != "left" {
+		t.Fatalf("expected the exit alert after dwelling, got %+v", got)
+	}
+	if _, ok := engine.GetDwellTime("LOI001", "AREA"); ok {
+		t.Error("dwell tracking should stop once the aircraft leaves")
+	}
+}
+
+func TestGeofenceDwell_TransitDoesNotTrigger(t *testing.T) {
+	engine, clock := newDwellTestEngine()
+
+	rule := NewAlertRule("loiter", "Loitering")
+	rule.AddCondition(ConditionGeofenceDwell, "10m")
+	engine.AddRule(rule)
+
+	exit := NewAlertRule("left", "Left after loitering")
+	exit.AddCondition(ConditionGeofenceDwellExit, "10m")
+	engine.AddRule(exit)
+
+	// An airliner crossing the area in 4 minutes
+	for i, lat := range []float64{52.1, 52.0, 51.9} {
+		*clock = clock.Add(2 * time.Minute)
+		if got := engine.CheckAircraft(dwellState("AIR001", lat, 4.0), nil); len(got) != 0 {
+			t.Fatalf("step %d: transit should not alert, got %+v", i, got)
+		}
+	}
+	*clock = clock.Add(2 * time.Minute)
+	if got := engine.CheckAircraft(dwellState("AIR001", 51.0, 4.0), nil); len(got) != 0 {
+		t.Errorf("exit after a short transit should not alert, got %+v", got)
+	}
+}
+
+func TestGeofenceDwell_ReentryRestartsTimer(t *testing.T) {
+	engine, clock := newDwellTestEngine()
+
+	rule := NewAlertRule("loiter", "Loitering")
+	rule.AddCondition(ConditionGeofenceDwell, "AREA:10m")
+	engine.AddRule(rule)
+
+	engine.CheckAircraft(dwellState("RE0001", 52.0, 4.0), nil)
+	*clock = clock.Add(8 * time.Minute)
+	engine.CheckAircraft(dwellState("RE0001", 53.0, 4.0), nil) // briefly out
+	*clock = clock.Add(time.Minute)
+	engine.CheckAircraft(dwellState("RE0001", 52.0, 4.0), nil) // back in
+	*clock = clock.Add(5 * time.Minute)
+
+	if got := engine.CheckAircraft(dwellState("RE0001", 52.0, 4.0), nil); len(got) != 0 {
+		t.Errorf("dwell must be continuous; re-entry should restart the timer, got %+v", got)
+	}
+}
+
+func TestGeofenceDwell_MissingPositionKeepsDwell(t *testing.T) {
+	engine, clock := newDwellTestEngine()
+
+	engine.CheckAircraft(dwellState("NOPOS1", 52.0, 4.0), nil)
+	*clock = clock.Add(3 * time.Minute)
+	engine.CheckAircraft(&AircraftState{Hex: "NOPOS1"}, nil)
+
+	if d, ok := engine.GetDwellTime("NOPOS1", "AREA"); !ok || d != 3*time.Minute {
+		t.Errorf("position-less update should keep dwell tracking, got %v (%v)", d, ok)
+	}
+}
+
+func TestGeofenceDwell_Cleanup(t *testing.T) {
+	engine, clock := newDwellTestEngine()
+
+	engine.CheckAircraft(dwellState("GONE01", 52.0, 4.0), nil)
+	engine.CheckAircraft(dwellState("GONE02", 52.0, 4.0), nil)
+
+	engine.RemoveAircraftState("GONE01")
+	if _, ok := engine.geofenceEntries["GONE01"]; ok {
+		t.Error("RemoveAircraftState should drop dwell tracking")
+	}
+
+	*clock = clock.Add(time.Hour)
+	engine.CleanupOldData()
+	if _, ok := engine.geofenceEntries["GONE02"]; ok {
+		t.Error("CleanupOldData should drop dwell tracking for stale aircraft")
+	}
+}
@@ -3,11 +3,19 @@ package alerts
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/units"
 )
 
+// navDescentRate is the vertical rate (ft/min) at or below which an aircraft
+// counts as descending for the nav_alt_mismatch condition
+const navDescentRate = -300
+
 // AlertEngine processes alert rules against aircraft data
 type AlertEngine struct {
 	ruleSet         *RuleSet
@@ -19,6 +27,15 @@ type AlertEngine struct {
 	stateRetention time.Duration
 	mutex          sync.RWMutex
 
+	// Geofence dwell tracking: hex -> geofence ID -> entry time
+	geofenceEntries map[string]map[string]time.Time
+
+	// Clock (injectable for tests)
+	now func() time.Time
+
+	// Compiled acars_text regexes by pattern; nil for invalid ones
+	textPatterns map[string]*regexp.Regexp
+
 	// Alert history
 	recentAlerts    []TriggeredAlert
 	maxRecentAlerts int
@@ -26,6 +43,9 @@ type AlertEngine struct {
 	// Highlight tracking for radar display
 	highlightedAircraft map[string]time.Time
 	highlightDuration   time.Duration
+
+	// Unit {dist} is written in: nm, km or mi
+	distUnit string
 }
 
 // NewAlertEngine creates a new alert engine
@@ -36,15 +56,26 @@ func NewAlertEngine() *AlertEngine {
 		prevStates:          make(map[string]*AircraftState),
 		prevStateSeen:       make(map[string]time.Time),
 		stateRetention:      time.Minute * 5,
+		geofenceEntries:     make(map[string]map[string]time.Time),
+		now:                 time.Now,
+		textPatterns:        make(map[string]*regexp.Regexp),
 		recentAlerts:        []TriggeredAlert{},
 		maxRecentAlerts:     50,
 		highlightedAircraft: make(map[string]time.Time),
 		highlightDuration:   time.Minute * 2,
+		distUnit:            units.NM,
 	}
 
 	return engine
 }
 
+// SetDistanceUnit sets the unit messages write {dist} in: nm, km or mi
+func (e *AlertEngine) SetDistanceUnit(unit string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.distUnit = units.Distance(unit)
+}
+
 // NewAlertEngineWithDefaults creates an alert engine with default rules
 func NewAlertEngineWithDefaults() *AlertEngine {
 	engine := NewAlertEngine()
@@ -84,40 +115,55 @@ func (e *AlertEngine) CheckAircraft(state, prevState *AircraftState) []Triggered
 	if state == nil {
 		return triggered
 	}
+	hex := codec.NormalizeHex(state.Hex)
 
 	// Get previous state from tracking if not provided
 	if prevState == nil {
 		e.mutex.RLock()
-		prevState = e.prevStates[state.Hex]
+		prevState = e.prevStates[hex]
 		e.mutex.RUnlock()
 	}
 
+	now := e.now()
+	dwell := e.updateDwell(state, now)
+	if prevState != nil {
+		state.PrevRegion = prevState.Region
+	}
+
 	// Check each enabled rule
 	for _, rule := range e.ruleSet.GetEnabledRules() {
-		if !rule.CanTrigger(state.Hex) {
+		// Message rules fire from CheckMessage only
+		if rule.IsMessageRule() || !rule.CanTrigger(hex) {
 			continue
 		}
 
-		if e.evaluateRule(rule, state, prevState) {
+		if e.evaluateRule(rule, state, prevState, dwell) {
 			alert := e.createAlert(rule, state)
 			triggered = append(triggered, alert)
-			rule.RecordTrigger(state.Hex)
+			rule.RecordTrigger(hex)
 
 			// Track highlighting
 			for _, action := range alert.Actions {
 				if action.Type == ActionHighlight {
 					e.mutex.Lock()
-					e.highlightedAircraft[state.Hex] = time.Now()
+					e.highlightedAircraft[hex] = now
 					e.mutex.Unlock()
 				}
 			}
 		}
 	}
 
+	// A radius rule's cooldown waits for the aircraft to leave the radius
+	for _, rule := range e.ruleSet.GetEnabledRules() {
+		if rule.insideRadius(state) {
+			rule.holdTrigger(hex)
+		}
+	}
+
 	// Update previous state tracking
 	e.mutex.Lock()
-	e.prevStates[state.Hex] = state
-	e.prevStateSeen[state.Hex] = time.Now()
+	e.prevStates[hex] = state
+	e.prevStateSeen[hex] = now
 	e.mutex.Unlock()
 
 	// Record alerts in history
@@ -134,7 +180,7 @@ func (e *AlertEngine) CheckAircraft(state, prevState *AircraftState) []Triggered
 }
 
 // evaluateRule checks if a rule's conditions are met
-func (e *AlertEngine) evaluateRule(rule *AlertRule, state, prevState *AircraftState) bool {
+func (e *AlertEngine) evaluateRule(rule *AlertRule, state, prevState *AircraftState, dwell dwellSnapshot) bool {
 	// For rules with multiple conditions of the same type (like emergency squawk),
 	// we need OR logic for same-type conditions and AND logic between different types
 	conditionsByType := make(map[ConditionType][]Condition)
@@ -146,7 +192,7 @@ func (e *AlertEngine) evaluateRule(rule *AlertRule, state, prevState *AircraftSt
 	for condType, conditions := range conditionsByType {
 		anyMatch := false
 		for _, cond := range conditions {
-			if e.evaluateCondition(cond, state, prevState) {
+			if e.evaluateCondition(cond, state, prevState, dwell) {
 				anyMatch = true
 				break
 			}
@@ -160,14 +206,30 @@ func (e *AlertEngine) evaluateRule(rule *AlertRule, state, prevState *AircraftSt
 	return len(rule.Conditions) > 0
 }
 
+// withinRadius reports whether the aircraft is known to be within radius
+// nm of the receiver
+func withinRadius(state *AircraftState, radius float64) bool {
+	return state.Distance > 0 && state.Distance <= radius
+}
+
+// matchesRegion matches a region condition value; empty or "*" matches any
+// region
+func matchesRegion(pattern, region string) bool {
+	pattern = strings.TrimSpace(pattern)
+	return pattern == "" || pattern == "*" || MatchesWildcard(pattern, region)
+}
+
 // evaluateCondition checks if a single condition is met
 //
 //nolint:gocyclo // Complex switch statement for multiple condition types is acceptable here
-func (e *AlertEngine) evaluateCondition(cond Condition, state, prevState *AircraftState) bool {
+func (e *AlertEngine) evaluateCondition(cond Condition, state, prevState *AircraftState, dwell dwellSnapshot) bool {
 	switch cond.Type {
 	case ConditionSquawk:
 		return MatchesWildcard(cond.Value, state.Squawk)
 
+	case ConditionSquawkSeverity:
+		return state.SquawkSeverity != "" && strings.EqualFold(strings.TrimSpace(cond.Value), state.SquawkSeverity)
+
 	case ConditionCallsign:
 		return MatchesWildcard(cond.Value, state.Callsign)
 
@@ -196,6 +258,23 @@ func (e *AlertEngine) evaluateCondition(cond Condition, state, prevState *Aircra
 		threshold := ParseFloat(cond.Value)
 		return state.Distance > 0 && state.Distance <= threshold
 
+	case ConditionEnteringRadius:
+		// Inside now, and outside or nowhere before
+		radius := ParseFloat(cond.Value)
+		return withinRadius(state, radius) && (prevState == nil || !withinRadius(prevState, radius))
+
+	case ConditionEnteringRegion:
+		return prevState != nil && state.Region != "" && state.Region != prevState.Region &&
+			matchesRegion(cond.Value, state.Region)
+
+	case ConditionLeavingRegion:
+		return prevState != nil && prevState.Region != "" && state.Region != prevState.Region &&
+			matchesRegion(cond.Value, prevState.Region)
+
+	case ConditionEnteringRestricted:
+		return prevState != nil && state.RegionRestricted && state.Region != prevState.Region &&
+			matchesRegion(cond.Value, state.Region)
+
 	case ConditionEnteringGeofence:
 		if !state.HasLat || !state.HasLon {
 			return false
@@ -228,6 +307,48 @@ func (e *AlertEngine) evaluateCondition(cond Condition, state, prevState *Aircra
 		threshold := ParseFloat(cond.Value)
 		return state.Speed > threshold
 
+	case ConditionHolding:
+		return strings.EqualFold(cond.Value, "true") && state.Holding
+
+	case ConditionHexConflict:
+		return strings.EqualFold(cond.Value, "true") && state.Conflicted
+
+	case ConditionWatched:
+		// On the list now, and off it or unseen before: once a sighting
+		return strings.EqualFold(cond.Value, "true") && state.OnWatchlist &&
+			(prevState == nil || !prevState.OnWatchlist)
+
+	case ConditionNavAltBelow:
+		if !state.HasNavAlt || !state.HasAlt || state.Altitude <= 0 {
+			return false
+		}
+		threshold := ParseInt(cond.Value)
+		return state.NavAltitude < threshold
+
+	case ConditionNavAltMismatch:
+		// Descending through the selected altitude: a possible level bust
+		if !state.HasNavAlt || !state.HasAlt || !state.HasVS || state.VerticalRate > navDescentRate {
+			return false
+		}
+		threshold := ParseInt(cond.Value)
+		return state.NavAltitude-state.Altitude > threshold
+
+	case ConditionVSBelow, ConditionVSAbove:
+		return matchVS(cond, state)
+
+	case ConditionCPABelow:
+		if !state.HasCPA {
+			return false
+		}
+		threshold := ParseFloat(cond.Value)
+		return state.CPADistance < threshold
+
+	case ConditionGeofenceDwell:
+		return matchDwell(cond.Value, dwell.inside)
+
+	case ConditionGeofenceDwellExit:
+		return matchDwell(cond.Value, dwell.exited)
+
 	default:
 		return false
 	}
@@ -257,8 +378,9 @@ func (e *AlertEngine) createAlert(rule *AlertRule, state *AircraftState) Trigger
 		Hex:       state.Hex,
 		Callsign:  state.Callsign,
 		Message:   message,
-		Timestamp: time.Now(),
+		Timestamp: e.now(),
 		Actions:   rule.Actions,
+		Distance:  state.Distance,
 	}
 }
 
@@ -274,6 +396,8 @@ func (e *AlertEngine) formatMessage(template string, state *AircraftState) strin
 	msg = strings.ReplaceAll(msg, "{callsign}", callsign)
 	msg = strings.ReplaceAll(msg, "{hex}", state.Hex)
 	msg = strings.ReplaceAll(msg, "{squawk}", state.Squawk)
+	msg = strings.ReplaceAll(msg, "{region}", state.Region)
+	msg = strings.ReplaceAll(msg, "{prev_region}", state.PrevRegion)
 
 	if state.HasAlt {
 		msg = strings.ReplaceAll(msg, "{altitude}", fmt.Sprintf("%d", state.Altitude))
@@ -281,10 +405,13 @@ func (e *AlertEngine) formatMessage(template string, state *AircraftState) strin
 		msg = strings.ReplaceAll(msg, "{altitude}", "---")
 	}
 
+	// {distance} is always nm; {dist} is in the shown unit, with it
 	if state.Distance > 0 {
 		msg = strings.ReplaceAll(msg, "{distance}", fmt.Sprintf("%.1f", state.Distance))
+		msg = strings.ReplaceAll(msg, "{dist}", fmt.Sprintf("%.1f%s", units.FromNM(state.Distance, e.distUnit), e.distUnit))
 	} else {
 		msg = strings.ReplaceAll(msg, "{distance}", "---")
+		msg = strings.ReplaceAll(msg, "{dist}", "---")
 	}
 
 	if state.HasSpeed {
@@ -293,6 +420,12 @@ func (e *AlertEngine) formatMessage(template string, state *AircraftState) strin
 		msg = strings.ReplaceAll(msg, "{speed}", "---")
 	}
 
+	if vs, ok := state.ClimbRate(); ok {
+		msg = strings.ReplaceAll(msg, "{vs}", fmt.Sprintf("%+.0f", vs))
+	} else {
+		msg = strings.ReplaceAll(msg, "{vs}", "---")
+	}
+
 	return msg
 }
 
@@ -311,7 +444,7 @@ func (e *AlertEngine) IsHighlighted(hex string) bool {
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 
-	if highlightTime, exists := e.highlightedAircraft[hex]; exists {
+	if highlightTime, exists := e.highlightedAircraft[codec.NormalizeHex(hex)]; exists {
 		if time.Since(highlightTime) < e.highlightDuration {
 			return true
 		}
@@ -325,7 +458,7 @@ func (e *AlertEngine) GetHighlightedAircraft() []string {
 	defer e.mutex.RUnlock()
 
 	var result []string
-	now := time.Now()
+	now := e.now()
 	for hex, highlightTime := range e.highlightedAircraft {
 		if now.Sub(highlightTime) < e.highlightDuration {
 			result = append(result, hex)
@@ -340,7 +473,7 @@ func (e *AlertEngine) CleanupOldData() {
 	defer e.mutex.Unlock()
 
 	// Clean up old highlight entries
-	now := time.Now()
+	now := e.now()
 	for hex, highlightTime := range e.highlightedAircraft {
 		if now.Sub(highlightTime) > e.highlightDuration {
 			delete(e.highlightedAircraft, hex)
@@ -352,6 +485,7 @@ func (e *AlertEngine) CleanupOldData() {
 		if now.Sub(seen) > e.stateRetention {
 			delete(e.prevStates, hex)
 			delete(e.prevStateSeen, hex)
+			delete(e.geofenceEntries, hex)
 		}
 	}
 
@@ -361,10 +495,13 @@ func (e *AlertEngine) CleanupOldData() {
 
 // RemoveAircraftState removes tracking data for an aircraft that is no longer seen
 func (e *AlertEngine) RemoveAircraftState(hex string) {
+	hex = codec.NormalizeHex(hex)
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 	delete(e.prevStates, hex)
 	delete(e.prevStateSeen, hex)
+	delete(e.highlightedAircraft, hex)
+	delete(e.geofenceEntries, hex)
 }
 
 // HasAction checks if any triggered alert has a specific action type
@@ -415,7 +552,7 @@ func (e *AlertEngine) GetStats() AlertStats {
 	}
 
 	// Count currently highlighted aircraft
-	now := time.Now()
+	now := e.now()
 	for _, highlightTime := range e.highlightedAircraft {
 		if now.Sub(highlightTime) < e.highlightDuration {
 			stats.Highlighted++
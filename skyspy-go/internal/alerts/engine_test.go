@@ -177,6 +177,54 @@ func TestAlertEngineGeofence(t *testing.T) {
 	}
 }
 
+func TestAlertEngineRegionTransitions(t *testing.T) {
+	engine := NewAlertEngine()
+
+	enter := NewAlertRule("enter_sector", "Entering Sector")
+	enter.AddCondition(ConditionEnteringRegion, "ZMP*")
+	enter.AddAction(ActionNotify, "{callsign} entered {region}")
+	enter.Cooldown = 0
+	engine.AddRule(enter)
+
+	leave := NewAlertRule("leave_any", "Leaving Any Region")
+	leave.AddCondition(ConditionLeavingRegion, "")
+	leave.AddAction(ActionNotify, "{callsign} left {prev_region}")
+	leave.Cooldown = 0
+	engine.AddRule(leave)
+
+	outside := &AircraftState{Hex: "TEST01", Callsign: "TST1"}
+	inside := &AircraftState{Hex: "TEST01", Callsign: "TST1", Region: "ZMP 12"}
+
+	triggered := engine.CheckAircraft(inside, outside)
+	if len(triggered) != 1 || triggered[0].Rule.ID != "enter_sector" {
+		t.Fatalf("expected only the entering rule, got %+v", triggered)
+	}
+	if triggered[0].Message != "TST1 entered ZMP 12" {
+		t.Errorf("message = %q", triggered[0].Message)
+	}
+
+	// Staying inside does not retrigger
+	if triggered := engine.CheckAircraft(inside, inside); len(triggered) != 0 {
+		t.Errorf("staying in a region should not trigger, got %+v", triggered)
+	}
+
+	triggered = engine.CheckAircraft(&AircraftState{Hex: "TEST01", Callsign: "TST1"}, inside)
+	if len(triggered) != 1 || triggered[0].Rule.ID != "leave_any" {
+		t.Fatalf("expected only the leaving rule, got %+v", triggered)
+	}
+	if triggered[0].Message != "TST1 left ZMP 12" {
+		t.Errorf("message = %q", triggered[0].Message)
+	}
+
+	// Other regions don't match the entering pattern
+	other := &AircraftState{Hex: "TEST01", Callsign: "TST1", Region: "ZAU 40"}
+	for _, a := range engine.CheckAircraft(other, outside) {
+		if a.Rule.ID == "enter_sector" {
+			t.Error("ZAU 40 should not match ZMP*")
+		}
+	}
+}
+
 func TestAlertEngineCleanup(t *testing.T) {
 	engine := NewAlertEngine()
 	engine.CleanupOldData()
@@ -310,6 +358,163 @@ func TestRemoveAircraftState(t *testing.T) {
 	engine.CheckAircraft(state, nil)
 }
 
+func TestRemoveAircraftState_ClearsHighlight(t *testing.T) {
+	engine := NewAlertEngine()
+	engine.prevStates["GONE"] = &AircraftState{Hex: "GONE"}
+	engine.prevStateSeen["GONE"] = time.Now()
+	engine.highlightedAircraft["GONE"] = time.Now()
+
+	engine.RemoveAircraftState("GONE")
+
+	if engine.IsHighlighted("GONE") {
+		t.Error("removed aircraft should no longer be highlighted")
+	}
+	if _, ok := engine.prevStates["GONE"]; ok {
+		t.Error("removed aircraft should have no previous state")
+	}
+	if _, ok := engine.prevStateSeen["GONE"]; ok {
+		t.Error("removed aircraft should have no last-seen entry")
+	}
+}
+
+func TestEvaluateCondition_CPABelow(t *testing.T) {
+	engine := NewAlertEngine()
+	rule := NewAlertRule("cpa", "Close Pass")
+	rule.AddCondition(ConditionCPABelow, "2.5")
+	engine.AddRule(rule)
+
+	tests := []struct {
+		name  string
+		state *AircraftState
+		want  int
+	}{
+		{"no prediction", &AircraftState{Hex: "CPA001"}, 0},
+		{"passing close", &AircraftState{Hex: "CPA002", CPADistance: 1.2, HasCPA: true}, 1},
+		{"passing wide", &AircraftState{Hex: "CPA003", CPADistance: 8, HasCPA: true}, 0},
+		{"exactly at threshold", &AircraftState{Hex: "CPA004", CPADistance: 2.5, HasCPA: true}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.CheckAircraft(tt.state, nil); len(got) != tt.want {
+				t.Errorf("expected %d alerts, got %d", tt.want, len(got))
+			}
+		})
+	}
+}
+
+func TestEvaluateCondition_Holding(t *testing.T) {
+	engine := NewAlertEngine()
+	rule := NewAlertRule("hold", "Holding Pattern")
+	rule.AddCondition(ConditionHolding, "true")
+	engine.AddRule(rule)
+
+	if got := engine.CheckAircraft(&AircraftState{Hex: "HLD001"}, nil); len(got) != 0 {
+		t.Errorf("expected no alert for a non-holding aircraft, got %d", len(got))
+	}
+	if got := engine.CheckAircraft(&AircraftState{Hex: "HLD002", Holding: true}, nil); len(got) != 1 {
+		t.Errorf("expected 1 alert for a holding aircraft, got %d", len(got))
+	}
+}
+
+func TestEvaluateCondition_HexConflict(t *testing.T) {
+	engine := NewAlertEngine()
+	rule := NewAlertRule("dup", "Duplicate Hex")
+	rule.AddCondition(ConditionHexConflict, "true")
+	engine.AddRule(rule)
+
+	if got := engine.CheckAircraft(&AircraftState{Hex: "DUP001"}, nil); len(got) != 0 {
+		t.Errorf("expected no alert for an unconflicted aircraft, got %d", len(got))
+	}
+	if got := engine.CheckAircraft(&AircraftState{Hex: "DUP002", Conflicted: true}, nil); len(got) != 1 {
+		t.Errorf("expected 1 alert for a conflicted aircraft, got %d", len(got))
+	}
+}
+
+func TestEvaluateCondition_Watched(t *testing.T) {
+	engine := NewAlertEngine()
+	rule := NewAlertRule("watched", "Watched Aircraft")
+	rule.AddCondition(ConditionWatched, "true")
+	rule.SetCooldown(0)
+	engine.AddRule(rule)
+
+	if got := engine.CheckAircraft(&AircraftState{Hex: "ABC001"}, nil); len(got) != 0 {
+		t.Errorf("expected no alert for an unwatched aircraft, got %d", len(got))
+	}
+	watched := &AircraftState{Hex: "ABC002", OnWatchlist: true}
+	if got := engine.CheckAircraft(watched, nil); len(got) != 1 {
+		t.Errorf("expected 1 alert for a watched aircraft, got %d", len(got))
+	}
+	// Only the sighting fires, however short the cooldown
+	if got := engine.CheckAircraft(watched, watched); len(got) != 0 {
+		t.Errorf("expected no alert while it stays watched, got %d", len(got))
+	}
+	if got := engine.CheckAircraft(watched, &AircraftState{Hex: "ABC002"}); len(got) != 1 {
+		t.Errorf("expected 1 alert when it comes onto the list, got %d", len(got))
+	}
+}
+
+func TestEvaluateCondition_NavAltBelow(t *testing.T) {
+	engine := NewAlertEngine()
+	rule := NewAlertRule("navlow", "Low Selected Altitude")
+	rule.AddCondition(ConditionNavAltBelow, "3000")
+	engine.AddRule(rule)
+
+	tests := []struct {
+		name  string
+		state *AircraftState
+		want  int
+	}{
+		{"no selected altitude", &AircraftState{Hex: "NAV001", Altitude: 8000, HasAlt: true}, 0},
+		{"selected below floor", &AircraftState{Hex: "NAV002", Altitude: 8000, HasAlt: true, NavAltitude: 2000, HasNavAlt: true}, 1},
+		{"selected above floor", &AircraftState{Hex: "NAV003", Altitude: 8000, HasAlt: true, NavAltitude: 5000, HasNavAlt: true}, 0},
+		{"on the ground", &AircraftState{Hex: "NAV004", Altitude: 0, HasAlt: true, NavAltitude: 0, HasNavAlt: true}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.CheckAircraft(tt.state, nil); len(got) != tt.want {
+				t.Errorf("expected %d alerts, got %d", tt.want, len(got))
+			}
+		})
+	}
+}
+
+func TestEvaluateCondition_NavAltMismatch(t *testing.T) {
+	engine := NewAlertEngine()
+	rule := NewAlertRule("bust", "Level Bust")
+	rule.AddCondition(ConditionNavAltMismatch, "300")
+	engine.AddRule(rule)
+
+	descending := func(hex string, alt, sel int, vs float64) *AircraftState {
+		return &AircraftState{
+			Hex: hex, Altitude: alt, HasAlt: true,
+			NavAltitude: sel, HasNavAlt: true,
+			VerticalRate: vs, HasVS: true,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		state *AircraftState
+		want  int
+	}{
+		{"descending toward selected", descending("BST001", 6000, 4000, -1500), 0},
+		{"descended through selected", descending("BST002", 3500, 4000, -1500), 1},
+		{"within tolerance", descending("BST003", 3800, 4000, -1500), 0},
+		{"level below selected", descending("BST004", 3500, 4000, 0), 0},
+		{"no vertical rate", &AircraftState{Hex: "BST005", Altitude: 3500, HasAlt: true, NavAltitude: 4000, HasNavAlt: true}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.CheckAircraft(tt.state, nil); len(got) != tt.want {
+				t.Errorf("expected %d alerts, got %d", tt.want, len(got))
+			}
+		})
+	}
+}
+
 func TestCheckAircraftNilState(t *testing.T) {
 	engine := NewAlertEngine()
 
@@ -876,3 +1081,139 @@ func TestEvaluateConditionUnknownType(t *testing.T) {
 		t.Error("Unknown condition type should not trigger")
 	}
 }
+
+func TestAlertEngineSquawkSeverityDefaults(t *testing.T) {
+	engine := NewAlertEngineWithDefaults()
+
+	ids := func(alerts []TriggeredAlert) []string {
+		var out []string
+		for _, a := range alerts {
+			out = append(out, a.Rule.ID)
+		}
+		return out
+	}
+
+	// An emergency class fires the emergency rule only
+	got := engine.CheckAircraft(&AircraftState{Hex: "EMG001", Squawk: "7400", SquawkSeverity: "emergency"}, nil)
+	if len(got) != 1 || got[0].Rule.ID != "emergency_squawk" {
+		t.Errorf("expected the emergency rule, got %v", ids(got))
+	}
+
+	// A demoted code fires the warning rule with the warning sound
+	got = engine.CheckAircraft(&AircraftState{Hex: "WRN001", Squawk: "7600", SquawkSeverity: "warning"}, nil)
+	if len(got) != 1 || got[0].Rule.ID != "warning_squawk" {
+		t.Fatalf("expected the warning rule, got %v", ids(got))
+	}
+	sound := ""
+	for _, a := range got[0].Actions {
+		if a.Type == ActionSound {
+			sound = a.Sound
+		}
+	}
+	if sound != "warning" {
+		t.Errorf("expected the warning sound, got %q", sound)
+	}
+
+	// The raw code alone no longer decides
+	got = engine.CheckAircraft(&AircraftState{Hex: "NRM001", Squawk: "7700"}, nil)
+	if len(got) != 0 {
+		t.Errorf("an unclassified squawk should not alert, got %v", ids(got))
+	}
+}
+
+func TestAlertEngineEnteringRestricted(t *testing.T) {
+	engine := NewAlertEngine()
+	rule := NewAlertRule("enter_tfr", "Entering TFR")
+	rule.AddCondition(ConditionEnteringRestricted, "")
+	rule.AddAction(ActionNotify, "{callsign} entered {region}")
+	rule.Cooldown = 0
+	engine.AddRule(rule)
+
+	outside := &AircraftState{Hex: "TEST01", Callsign: "TST1"}
+	sector := &AircraftState{Hex: "TEST01", Callsign: "TST1", Region: "ZMP 12"}
+	tfr := &AircraftState{Hex: "TEST01", Callsign: "TST1", Region: "TFR 6/1234", RegionRestricted: true}
+
+	if triggered := engine.CheckAircraft(sector, outside); len(triggered) != 0 {
+		t.Errorf("an untimed region is not a restriction, got %+v", triggered)
+	}
+	triggered := engine.CheckAircraft(tfr, sector)
+	if len(triggered) != 1 || triggered[0].Message != "TST1 entered TFR 6/1234" {
+		t.Fatalf("expected the restriction alert, got %+v", triggered)
+	}
+	if triggered := engine.CheckAircraft(tfr, tfr); len(triggered) != 0 {
+		t.Errorf("staying inside should not retrigger, got %+v", triggered)
+	}
+}
+
+func TestAlertEngineEnteringRadius(t *testing.T) {
+	engine := NewAlertEngine()
+	rule := ProximityRule(5)
+	rule.Enabled = true
+	engine.AddRule(rule)
+
+	at := func(nm float64) *AircraftState {
+		return &AircraftState{Hex: "ABC123", Callsign: "TST1", Distance: nm}
+	}
+	aged := func() {
+		rule.mutex.Lock()
+		rule.lastTriggered["ABC123"] = time.Now().Add(-time.Hour)
+		rule.mutex.Unlock()
+	}
+
+	if triggered := engine.CheckAircraft(at(8), nil); len(triggered) != 0 {
+		t.Fatalf("outside the radius should not alert, got %+v", triggered)
+	}
+	triggered := engine.CheckAircraft(at(4.5), at(8))
+	if len(triggered) != 1 || triggered[0].Distance != 4.5 {
+		t.Fatalf("entering the radius should alert once with the distance, got %+v", triggered)
+	}
+	if triggered[0].Message != "NEARBY: TST1 at 4.5nm" {
+		t.Errorf("message = %q", triggered[0].Message)
+	}
+
+	// Staying inside past the cooldown neither alerts nor lets it go
+	aged()
+	if triggered := engine.CheckAircraft(at(3), at(4.5)); len(triggered) != 0 {
+		t.Errorf("staying inside should not alert, got %+v", triggered)
+	}
+	engine.CheckAircraft(at(6), at(3))
+	if triggered := engine.CheckAircraft(at(4.9), at(6)); len(triggered) != 0 {
+		t.Errorf("coming straight back in should wait for the cooldown, got %+v", triggered)
+	}
+
+	// Outside for the cooldown, it's ready again
+	engine.CheckAircraft(at(7), at(4.9))
+	aged()
+	if triggered := engine.CheckAircraft(at(4), at(7)); len(triggered) != 1 {
+		t.Errorf("entering again after the cooldown should alert, got %+v", triggered)
+	}
+
+	// An aircraft first seen inside counts as entering; one without a
+	// distance is nowhere
+	if triggered := engine.CheckAircraft(&AircraftState{Hex: "DEF456", Distance: 2}, nil); len(triggered) != 1 {
+		t.Errorf("first seen inside should alert, got %+v", triggered)
+	}
+	if triggered := engine.CheckAircraft(&AircraftState{Hex: "FED654"}, nil); len(triggered) != 0 {
+		t.Errorf("no distance should not alert, got %+v", triggered)
+	}
+}
+
+func TestFormatMessageDistInDisplayUnit(t *testing.T) {
+	engine := NewAlertEngine()
+	state := &AircraftState{Hex: "ABC123", Callsign: "UAL123", Distance: 10}
+
+	if got := engine.formatMessage("{callsign} at {dist}", state); got != "UAL123 at 10.0nm" {
+		t.Errorf("default unit: got %q", got)
+	}
+	engine.SetDistanceUnit("km")
+	if got := engine.formatMessage("{callsign} at {dist}", state); got != "UAL123 at 18.5km" {
+		t.Errorf("km: got %q", got)
+	}
+	// {distance} stays nm whatever the display unit
+	if got := engine.formatMessage("{distance}", state); got != "10.0" {
+		t.Errorf("{distance}: got %q", got)
+	}
+	if got := engine.formatMessage("{dist}", &AircraftState{Hex: "ABC123"}); got != "---" {
+		t.Errorf("no distance: got %q", got)
+	}
+}
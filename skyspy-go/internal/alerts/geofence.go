@@ -6,6 +6,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -263,6 +264,16 @@ func (m *GeofenceManager) CheckEntering(prevLat, prevLon, currLat, currLon float
 	return entered
 }
 
+// UniqueID returns base, or base with "_2", "_3" and so on added if a
+// geofence already has that ID
+func (m *GeofenceManager) UniqueID(base string) string {
+	id := base
+	for n := 2; m.geofences[id] != nil; n++ {
+		id = base + "_" + strconv.Itoa(n)
+	}
+	return id
+}
+
 // Count returns the number of geofences
 func (m *GeofenceManager) Count() int {
 	return len(m.geofences)
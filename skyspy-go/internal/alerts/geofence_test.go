@@ -737,3 +737,35 @@ func TestSaveGeofencesToFileMarshalError(t *testing.T) {
 		t.Error("SaveGeofencesToFile should fail for NaN values")
 	}
 }
+
+func TestGeofenceManagerUniqueID(t *testing.T) {
+	mgr := NewGeofenceManager()
+	if id := mgr.UniqueID("circle"); id != "circle" {
+		t.Errorf("UniqueID on an empty manager = %q", id)
+	}
+	mgr.AddGeofence(NewCircleGeofence("circle", "A", 0, 0, 1))
+	mgr.AddGeofence(NewCircleGeofence("circle_2", "B", 0, 0, 1))
+	if id := mgr.UniqueID("circle"); id != "circle_3" {
+		t.Errorf("UniqueID = %q, want circle_3", id)
+	}
+}
+
+func TestRuleUsesGeofence(t *testing.T) {
+	tests := []struct {
+		cond Condition
+		want bool
+	}{
+		{Condition{Type: ConditionEnteringGeofence, Value: "home"}, true},
+		{Condition{Type: ConditionEnteringGeofence, Value: "*"}, false},
+		{Condition{Type: ConditionGeofenceDwell, Value: "home:10m"}, true},
+		{Condition{Type: ConditionGeofenceDwellExit, Value: "home:5"}, true},
+		{Condition{Type: ConditionGeofenceDwell, Value: "10m"}, false},
+		{Condition{Type: ConditionCallsign, Value: "home"}, false},
+	}
+	for _, tt := range tests {
+		rule := NewAlertRule("r", "R").AddCondition(tt.cond.Type, tt.cond.Value)
+		if got := rule.UsesGeofence("home"); got != tt.want {
+			t.Errorf("%s %q: UsesGeofence = %v, want %v", tt.cond.Type, tt.cond.Value, got, tt.want)
+		}
+	}
+}
@@ -13,15 +13,32 @@ import (
 type ConditionType string
 
 const (
-	ConditionSquawk           ConditionType = "squawk"
-	ConditionCallsign         ConditionType = "callsign"
-	ConditionHex              ConditionType = "hex"
-	ConditionMilitary         ConditionType = "military"
-	ConditionAltitudeAbove    ConditionType = "altitude_above"
-	ConditionAltitudeBelow    ConditionType = "altitude_below"
-	ConditionDistanceWithin   ConditionType = "distance_within"
-	ConditionEnteringGeofence ConditionType = "entering_geofence"
-	ConditionSpeedAbove       ConditionType = "speed_above"
+	ConditionSquawk             ConditionType = "squawk"
+	ConditionCallsign           ConditionType = "callsign"
+	ConditionHex                ConditionType = "hex"
+	ConditionMilitary           ConditionType = "military"
+	ConditionAltitudeAbove      ConditionType = "altitude_above"
+	ConditionAltitudeBelow      ConditionType = "altitude_below"
+	ConditionDistanceWithin     ConditionType = "distance_within"
+	ConditionEnteringGeofence   ConditionType = "entering_geofence"
+	ConditionSpeedAbove         ConditionType = "speed_above"
+	ConditionGeofenceDwell      ConditionType = "geofence_dwell"      // value: "[geofence-id:]duration"
+	ConditionGeofenceDwellExit  ConditionType = "geofence_dwell_exit" // value: "[geofence-id:]duration"
+	ConditionCPABelow           ConditionType = "cpa_below"           // value: nm
+	ConditionHolding            ConditionType = "holding"             // value: "true"
+	ConditionNavAltBelow        ConditionType = "nav_alt_below"       // value: feet
+	ConditionNavAltMismatch     ConditionType = "nav_alt_mismatch"    // value: feet
+	ConditionACARSLabel         ConditionType = "acars_label"         // value: label, wildcards allowed
+	ConditionACARSText          ConditionType = "acars_text"          // value: substring, or regex if Regex is set
+	ConditionHexConflict        ConditionType = "hex_conflict"        // value: "true"
+	ConditionSquawkSeverity     ConditionType = "squawk_severity"     // value: info, warning or emergency
+	ConditionEnteringRegion     ConditionType = "entering_region"     // value: region name, wildcards allowed; empty for any
+	ConditionLeavingRegion      ConditionType = "leaving_region"      // value: region name, wildcards allowed; empty for any
+	ConditionEnteringRestricted ConditionType = "entering_restricted" // value: region name, wildcards allowed; empty for any
+	ConditionVSBelow            ConditionType = "vs_below"            // value: "ft/min[:min-altitude]", e.g. "-6000:3000"
+	ConditionVSAbove            ConditionType = "vs_above"            // value: "ft/min[:min-altitude]"
+	ConditionWatched            ConditionType = "watched"             // value: "true"
+	ConditionEnteringRadius     ConditionType = "entering_radius"     // value: nm from the receiver
 )
 
 // ActionType represents the type of action to take when alert triggers
@@ -32,12 +49,15 @@ const (
 	ActionNotify    ActionType = "notify"
 	ActionLog       ActionType = "log"
 	ActionHighlight ActionType = "highlight"
+	ActionBell      ActionType = "bell" // terminal bell or flash, for when audio isn't available
 )
 
 // Condition represents a single condition that must be met for an alert
 type Condition struct {
 	Type  ConditionType `json:"type"`
 	Value string        `json:"value"`
+	// Regex makes an acars_text value a case-insensitive regular expression
+	Regex bool `json:"regex,omitempty"`
 }
 
 // Action represents an action to take when an alert triggers
@@ -86,6 +106,16 @@ func (r *AlertRule) AddCondition(condType ConditionType, value string) *AlertRul
 	return r
 }
 
+// AddRegexCondition adds a condition whose value is a regular expression
+func (r *AlertRule) AddRegexCondition(condType ConditionType, pattern string) *AlertRule {
+	r.Conditions = append(r.Conditions, Condition{
+		Type:  condType,
+		Value: pattern,
+		Regex: true,
+	})
+	return r
+}
+
 // AddAction adds an action to the rule
 func (r *AlertRule) AddAction(actionType ActionType, message string) *AlertRule {
 	r.Actions = append(r.Actions, Action{
@@ -95,6 +125,34 @@ func (r *AlertRule) AddAction(actionType ActionType, message string) *AlertRule
 	return r
 }
 
+// HasAction reports whether the rule has an action of the given type
+func (r *AlertRule) HasAction(actionType ActionType) bool {
+	for _, action := range r.Actions {
+		if action.Type == actionType {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesGeofence reports whether any of the rule's conditions names the
+// geofence, as opposed to matching any geofence
+func (r *AlertRule) UsesGeofence(id string) bool {
+	for _, cond := range r.Conditions {
+		switch cond.Type {
+		case ConditionEnteringGeofence:
+			if cond.Value == id {
+				return true
+			}
+		case ConditionGeofenceDwell, ConditionGeofenceDwellExit:
+			if geofenceID, _, ok := ParseDwellValue(cond.Value); ok && geofenceID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // SetCooldown sets the cooldown duration
 func (r *AlertRule) SetCooldown(d time.Duration) *AlertRule {
 	r.Cooldown = d
@@ -129,6 +187,28 @@ func (r *AlertRule) RecordTrigger(hex string) {
 	r.lastTriggered[hex] = time.Now()
 }
 
+// insideRadius reports whether state is within the radius of one of the
+// rule's entering_radius conditions
+func (r *AlertRule) insideRadius(state *AircraftState) bool {
+	for _, cond := range r.Conditions {
+		if cond.Type == ConditionEnteringRadius && withinRadius(state, ParseFloat(cond.Value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// holdTrigger restarts the cooldown for an aircraft the rule has already
+// fired for; nothing happens for one it hasn't
+func (r *AlertRule) holdTrigger(hex string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.lastTriggered[hex]; ok {
+		r.lastTriggered[hex] = time.Now()
+	}
+}
+
 // ClearOldTriggers removes trigger records older than the cooldown period
 func (r *AlertRule) ClearOldTriggers() {
 	r.mutex.Lock()
@@ -150,6 +230,8 @@ type TriggeredAlert struct {
 	Message   string
 	Timestamp time.Time
 	Actions   []Action
+	ACARS     bool    // raised by an ACARS message rather than a state update
+	Distance  float64 // nm from the receiver when it fired; 0 when unknown
 }
 
 // AircraftState represents the current state of an aircraft for alert checking
@@ -167,6 +249,42 @@ type AircraftState struct {
 	HasLon   bool
 	HasAlt   bool
 	HasSpeed bool
+
+	// Predicted closest approach to the receiver; HasCPA is set only for
+	// closing targets
+	CPADistance float64
+	HasCPA      bool
+
+	// Sustained same-direction turning that looks like a holding pattern
+	Holding bool
+
+	// Position jumps suggest two aircraft are sharing the ICAO address
+	Conflicted bool
+
+	// The aircraft is on the watch list
+	OnWatchlist bool
+
+	// Overlay region (e.g. ATC sector) the aircraft is inside, and the one
+	// it was in before this update; empty outside any region
+	Region     string
+	PrevRegion string
+
+	// Region is a timed feature, such as a TFR, that is in force now
+	RegionRestricted bool
+
+	// Class of the squawk code from the configured special codes: info,
+	// warning or emergency, or empty for an ordinary code
+	SquawkSeverity string
+
+	// Vertical rate (ft/min) and autopilot selected altitude, when known
+	VerticalRate float64
+	NavAltitude  int
+	HasVS        bool
+	HasNavAlt    bool
+
+	// Vertical rate smoothed over recent updates, when tracked
+	VSTrend    float64
+	HasVSTrend bool
 }
 
 // MatchesWildcard checks if a string matches a wildcard pattern
@@ -216,12 +334,10 @@ func ParseInt(s string) int {
 func DefaultAlertRules() []*AlertRule {
 	rules := []*AlertRule{}
 
-	// Emergency squawk rule (7500/7600/7700)
+	// Emergency squawk rule (7500/7600/7700 unless reconfigured)
 	emergency := NewAlertRule("emergency_squawk", "Emergency Squawk")
 	emergency.Description = "Aircraft transmitting emergency squawk code"
-	emergency.AddCondition(ConditionSquawk, "77*")
-	emergency.AddCondition(ConditionSquawk, "76*")
-	emergency.AddCondition(ConditionSquawk, "75*")
+	emergency.AddCondition(ConditionSquawkSeverity, "emergency")
 	emergency.AddAction(ActionNotify, "EMERGENCY: {callsign} squawking {squawk}")
 	emergency.AddAction(ActionSound, "emergency")
 	emergency.AddAction(ActionHighlight, "")
@@ -229,12 +345,23 @@ func DefaultAlertRules() []*AlertRule {
 	emergency.SetPriority(100)
 	rules = append(rules, emergency)
 
+	// Special codes demoted to, or configured as, warnings
+	warning := NewAlertRule("warning_squawk", "Warning Squawk")
+	warning.Description = "Aircraft transmitting a squawk code classed as a warning"
+	warning.AddCondition(ConditionSquawkSeverity, "warning")
+	warning.AddAction(ActionNotify, "SQUAWK: {callsign} squawking {squawk}")
+	warning.Actions = append(warning.Actions, Action{Type: ActionSound, Sound: "warning"})
+	warning.AddAction(ActionHighlight, "")
+	warning.SetCooldown(time.Minute * 1)
+	warning.SetPriority(80)
+	rules = append(rules, warning)
+
 	// Military aircraft nearby
 	military := NewAlertRule("military_nearby", "Military Aircraft Nearby")
 	military.Description = "Military aircraft within 50nm"
 	military.AddCondition(ConditionMilitary, "true")
 	military.AddCondition(ConditionDistanceWithin, "50")
-	military.AddAction(ActionNotify, "MILITARY: {callsign} at {distance}nm")
+	military.AddAction(ActionNotify, "MILITARY: {callsign} at {dist}")
 	military.AddAction(ActionHighlight, "")
 	military.SetCooldown(time.Minute * 10)
 	military.SetPriority(50)
@@ -250,9 +377,79 @@ func DefaultAlertRules() []*AlertRule {
 	lowAlt.SetPriority(30)
 	rules = append(rules, lowAlt)
 
+	// Rapid descent, off until wanted: above 3000ft so landings don't count
+	descent := NewAlertRule("rapid_descent", "Rapid Descent")
+	descent.Description = "Aircraft descending faster than 6000 ft/min above 3000ft"
+	descent.Enabled = false
+	descent.AddCondition(ConditionVSBelow, "-6000:3000")
+	descent.AddAction(ActionNotify, "DESCENT: {callsign} at {vs} ft/min, {altitude}ft")
+	descent.AddAction(ActionHighlight, "")
+	descent.SetCooldown(time.Minute * 5)
+	descent.SetPriority(70)
+	rules = append(rules, descent)
+
 	return rules
 }
 
+// ProximityRuleID is the ID of the built-in rule that pings when an
+// aircraft comes within a radius of the receiver
+const ProximityRuleID = "proximity"
+
+// DefaultProximityRadius is the proximity rule's radius (nm) when none is
+// configured
+const DefaultProximityRadius = 5.0
+
+// ProximityRule returns the built-in proximity rule for a radius in nm.
+// It fires once each time an aircraft enters the radius; the cooldown
+// runs from when the aircraft was last inside, so it is ready again once
+// the aircraft has been outside that long.
+func ProximityRule(radiusNM float64) *AlertRule {
+	proximity := NewAlertRule(ProximityRuleID, "Proximity")
+	proximity.Description = "Aircraft coming within the radius of the receiver"
+	proximity.Enabled = false
+	proximity.AddCondition(ConditionEnteringRadius, strconv.FormatFloat(radiusNM, 'f', -1, 64))
+	proximity.AddAction(ActionNotify, "NEARBY: {callsign} at {dist}")
+	proximity.Actions = append(proximity.Actions, Action{Type: ActionSound, Sound: "ping"})
+	proximity.SetCooldown(time.Minute * 2)
+	proximity.SetPriority(40)
+	return proximity
+}
+
+// ProximityRadius returns the radius (nm) of a rule's entering_radius
+// condition
+func (r *AlertRule) ProximityRadius() (float64, bool) {
+	for _, cond := range r.Conditions {
+		if cond.Type == ConditionEnteringRadius {
+			return ParseFloat(cond.Value), true
+		}
+	}
+	return 0, false
+}
+
+// SetProximityRadius changes the radius (nm) of the rule's entering_radius
+// conditions
+func (r *AlertRule) SetProximityRadius(radiusNM float64) {
+	for i, cond := range r.Conditions {
+		if cond.Type == ConditionEnteringRadius {
+			r.Conditions[i].Value = strconv.FormatFloat(radiusNM, 'f', -1, 64)
+		}
+	}
+}
+
+// IsDefaultRule reports whether id is one of the default rules' IDs or
+// the proximity rule's. These can be turned off but are never deleted.
+func IsDefaultRule(id string) bool {
+	if id == ProximityRuleID {
+		return true
+	}
+	for _, rule := range DefaultAlertRules() {
+		if rule.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 // RuleSet manages a collection of alert rules
 type RuleSet struct {
 	rules []*AlertRule
@@ -321,6 +518,76 @@ func (rs *RuleSet) ToggleRule(id string) bool {
 	return false
 }
 
+// ToggleAction adds an action of the given type to a rule by ID, or removes
+// the ones it has, and reports whether the rule now has one
+func (rs *RuleSet) ToggleAction(id string, actionType ActionType) bool {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	for _, rule := range rs.rules {
+		if rule.ID != id {
+			continue
+		}
+		if !rule.HasAction(actionType) {
+			rule.Actions = append(rule.Actions, Action{Type: actionType})
+			return true
+		}
+		kept := rule.Actions[:0:0]
+		for _, action := range rule.Actions {
+			if action.Type != actionType {
+				kept = append(kept, action)
+			}
+		}
+		rule.Actions = kept
+		return false
+	}
+	return false
+}
+
+// SetAllEnabled enables or disables every rule and returns how many changed
+func (rs *RuleSet) SetAllEnabled(enabled bool) int {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	changed := 0
+	for _, rule := range rs.rules {
+		if rule.Enabled != enabled {
+			rule.Enabled = enabled
+			changed++
+		}
+	}
+	return changed
+}
+
+// ReplaceRule puts rule in place of the rule with the same ID, keeping its
+// position, and reports whether there was one
+func (rs *RuleSet) ReplaceRule(rule *AlertRule) bool {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	for i, existing := range rs.rules {
+		if existing.ID == rule.ID {
+			rs.rules[i] = rule
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveRule removes a rule by ID and reports whether it was there
+func (rs *RuleSet) RemoveRule(id string) bool {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	for i, rule := range rs.rules {
+		if rule.ID == id {
+			rs.rules = append(rs.rules[:i], rs.rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // GetRuleByID returns a rule by its ID
 func (rs *RuleSet) GetRuleByID(id string) *AlertRule {
 	rs.mutex.RLock()
@@ -334,6 +601,23 @@ func (rs *RuleSet) GetRuleByID(id string) *AlertRule {
 	return nil
 }
 
+// UniqueID returns base, or base with the lowest free numeric suffix
+// (base_2, base_3, ...) when a rule already has that ID
+func (rs *RuleSet) UniqueID(base string) string {
+	rs.mutex.RLock()
+	defer rs.mutex.RUnlock()
+
+	taken := make(map[string]bool, len(rs.rules))
+	for _, rule := range rs.rules {
+		taken[rule.ID] = true
+	}
+	id := base
+	for n := 2; taken[id]; n++ {
+		id = base + "_" + strconv.Itoa(n)
+	}
+	return id
+}
+
 // Count returns the number of rules
 func (rs *RuleSet) Count() int {
 	rs.mutex.RLock()
@@ -146,12 +146,36 @@ func TestActionTypes(t *testing.T) {
 		ActionNotify,
 		ActionLog,
 		ActionHighlight,
+		ActionBell,
 	}
 
 	for _, at := range types {
 		if at == "" {
 			t.Error("Action type should not be empty")
 		}
+		if err := (Action{Type: at}).Validate(); err != nil {
+			t.Errorf("%s should validate: %v", at, err)
+		}
+	}
+}
+
+func TestRuleSet_ToggleAction(t *testing.T) {
+	rs := NewRuleSet()
+	rule := NewAlertRule("r1", "Rule")
+	rule.AddAction(ActionNotify, "hello")
+	rs.AddRule(rule)
+
+	if !rs.ToggleAction("r1", ActionBell) || !rule.HasAction(ActionBell) {
+		t.Fatal("toggling should add a bell action")
+	}
+	if rs.ToggleAction("r1", ActionBell) || rule.HasAction(ActionBell) {
+		t.Fatal("toggling again should remove it")
+	}
+	if len(rule.Actions) != 1 || rule.Actions[0].Message != "hello" {
+		t.Errorf("other actions should be kept, got %+v", rule.Actions)
+	}
+	if rs.ToggleAction("missing", ActionBell) {
+		t.Error("an unknown rule has no action to toggle")
 	}
 }
 
@@ -351,3 +375,55 @@ func TestClearAllOldTriggers(t *testing.T) {
 		t.Error("Rule 2 should be able to trigger after clearing")
 	}
 }
+
+func TestSetAllEnabled(t *testing.T) {
+	rs := NewRuleSet()
+	r1 := NewAlertRule("rule1", "Rule 1")
+	r2 := NewAlertRule("rule2", "Rule 2")
+	r2.Enabled = false
+	rs.AddRule(r1)
+	rs.AddRule(r2)
+
+	if changed := rs.SetAllEnabled(false); changed != 1 {
+		t.Errorf("expected 1 rule changed, got %d", changed)
+	}
+	if len(rs.GetEnabledRules()) != 0 {
+		t.Error("all rules should be disabled")
+	}
+	if changed := rs.SetAllEnabled(true); changed != 2 {
+		t.Errorf("expected 2 rules changed, got %d", changed)
+	}
+}
+
+func TestRuleSet_ReplaceAndRemove(t *testing.T) {
+	rs := NewRuleSet()
+	rs.AddRule(NewAlertRule("rule1", "Rule 1"))
+	rs.AddRule(NewAlertRule("rule2", "Rule 2"))
+	rs.AddRule(NewAlertRule("rule3", "Rule 3"))
+
+	if !rs.ReplaceRule(NewAlertRule("rule2", "Renamed")) {
+		t.Fatal("ReplaceRule should find rule2")
+	}
+	if rules := rs.GetRules(); rules[1].Name != "Renamed" || len(rules) != 3 {
+		t.Errorf("the replacement should keep its place, got %q", rules[1].Name)
+	}
+	if rs.ReplaceRule(NewAlertRule("rule4", "Rule 4")) {
+		t.Error("ReplaceRule should not add a rule")
+	}
+
+	if !rs.RemoveRule("rule1") || rs.RemoveRule("rule1") {
+		t.Error("RemoveRule should remove rule1 once")
+	}
+	if rules := rs.GetRules(); len(rules) != 2 || rules[0].ID != "rule2" {
+		t.Errorf("rules left: %d", len(rules))
+	}
+}
+
+func TestIsDefaultRule(t *testing.T) {
+	if !IsDefaultRule("emergency_squawk") || !IsDefaultRule("rapid_descent") {
+		t.Error("default rule IDs should be recognised")
+	}
+	if IsDefaultRule("custom") || IsDefaultRule("") {
+		t.Error("other IDs are not default rules")
+	}
+}
@@ -0,0 +1,182 @@
+// Package alerts provides configurable alert rules for aircraft monitoring
+package alerts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TemplateParam is a value a rule template asks for
+type TemplateParam struct {
+	Label   string // prompt, e.g. "Altitude (ft)"
+	Default string // used when nothing is entered; empty makes it required
+}
+
+// RuleTemplate describes a common alert as data: the values it asks for,
+// and the rule they fill in. $1, $2, ... in the name, condition values and
+// action messages stand for the values, in the order of Params.
+type RuleTemplate struct {
+	ID         string // base for the IDs of rules made from the template
+	Title      string // what the template offers, e.g. "Aircraft below X ft within Y nm"
+	Params     []TemplateParam
+	Name       string
+	Conditions []Condition
+	Actions    []Action
+	Cooldown   time.Duration
+	Priority   int
+}
+
+// RuleTemplates are the templates offered for new rules, in menu order
+var RuleTemplates = []RuleTemplate{
+	{
+		ID:     "low_nearby",
+		Title:  "Aircraft below X ft within Y nm",
+		Params: []TemplateParam{{"Altitude (ft)", "1000"}, {"Distance (nm)", "10"}},
+		Name:   "Below $1ft within $2nm",
+		Conditions: []Condition{
+			{Type: ConditionAltitudeBelow, Value: "$1"},
+			{Type: ConditionDistanceWithin, Value: "$2"},
+		},
+		Actions: []Action{
+			{Type: ActionNotify, Message: "LOW: {callsign} at {altitude}ft, {dist}"},
+			{Type: ActionHighlight},
+		},
+		Cooldown: 5 * time.Minute,
+		Priority: 30,
+	},
+	{
+		ID:         "watch_hex",
+		Title:      "Specific hex appears",
+		Params:     []TemplateParam{{"ICAO hex", ""}},
+		Name:       "Hex $1",
+		Conditions: []Condition{{Type: ConditionHex, Value: "$1"}},
+		Actions: []Action{
+			{Type: ActionNotify, Message: "SPOTTED: {hex} {callsign} at {dist}"},
+			{Type: ActionHighlight},
+		},
+		Cooldown: 30 * time.Minute,
+		Priority: 60,
+	},
+	{
+		ID:         "watch_callsign",
+		Title:      "Specific callsign appears",
+		Params:     []TemplateParam{{"Callsign (* wildcards)", ""}},
+		Name:       "Callsign $1",
+		Conditions: []Condition{{Type: ConditionCallsign, Value: "$1"}},
+		Actions: []Action{
+			{Type: ActionNotify, Message: "SPOTTED: {callsign} at {dist}"},
+			{Type: ActionHighlight},
+		},
+		Cooldown: 30 * time.Minute,
+		Priority: 60,
+	},
+	{
+		ID:         "squawk",
+		Title:      "Squawk equals X",
+		Params:     []TemplateParam{{"Squawk", ""}},
+		Name:       "Squawk $1",
+		Conditions: []Condition{{Type: ConditionSquawk, Value: "$1"}},
+		Actions: []Action{
+			{Type: ActionNotify, Message: "SQUAWK: {callsign} squawking {squawk}"},
+			{Type: ActionHighlight},
+		},
+		Cooldown: 10 * time.Minute,
+		Priority: 70,
+	},
+	{
+		ID:     "military_within",
+		Title:  "Military within Y nm",
+		Params: []TemplateParam{{"Distance (nm)", "50"}},
+		Name:   "Military within $1nm",
+		Conditions: []Condition{
+			{Type: ConditionMilitary, Value: "true"},
+			{Type: ConditionDistanceWithin, Value: "$1"},
+		},
+		Actions: []Action{
+			{Type: ActionNotify, Message: "MILITARY: {callsign} at {dist}"},
+			{Type: ActionHighlight},
+		},
+		Cooldown: 10 * time.Minute,
+		Priority: 50,
+	},
+	{
+		ID:     "rapid_descent",
+		Title:  "Descending faster than X ft/min above Y ft",
+		Params: []TemplateParam{{"Descent rate (ft/min)", "6000"}, {"Above altitude (ft)", "3000"}},
+		Name:   "Descent over $1fpm",
+		Conditions: []Condition{
+			{Type: ConditionVSBelow, Value: "-$1:$2"},
+		},
+		Actions: []Action{
+			{Type: ActionNotify, Message: "DESCENT: {callsign} at {vs} ft/min, {altitude}ft"},
+			{Type: ActionHighlight},
+		},
+		Cooldown: 5 * time.Minute,
+		Priority: 70,
+	},
+	{
+		ID:     "rapid_climb",
+		Title:  "Climbing faster than X ft/min",
+		Params: []TemplateParam{{"Climb rate (ft/min)", "6000"}},
+		Name:   "Climb over $1fpm",
+		Conditions: []Condition{
+			{Type: ConditionVSAbove, Value: "$1"},
+		},
+		Actions: []Action{
+			{Type: ActionNotify, Message: "CLIMB: {callsign} at {vs} ft/min, {altitude}ft"},
+			{Type: ActionHighlight},
+		},
+		Cooldown: 5 * time.Minute,
+		Priority: 40,
+	},
+	{
+		ID:         "geofence_entry",
+		Title:      "Anything enters geofence Z",
+		Params:     []TemplateParam{{"Geofence ID (* for any)", "*"}},
+		Name:       "Entering $1",
+		Conditions: []Condition{{Type: ConditionEnteringGeofence, Value: "$1"}},
+		Actions: []Action{
+			{Type: ActionNotify, Message: "GEOFENCE: {callsign} entered $1"},
+			{Type: ActionLog},
+		},
+		Cooldown: 5 * time.Minute,
+		Priority: 40,
+	},
+}
+
+// Build makes a rule with the given ID from the template. Missing values
+// take their defaults; the rule is checked with Validate.
+func (t RuleTemplate) Build(id string, values []string) (*AlertRule, error) {
+	pairs := make([]string, 0, 2*len(t.Params))
+	for i, p := range t.Params {
+		value := p.Default
+		if i < len(values) && strings.TrimSpace(values[i]) != "" {
+			value = strings.TrimSpace(values[i])
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%s is required", p.Label)
+		}
+		pairs = append(pairs, "$"+strconv.Itoa(i+1), value)
+	}
+	fill := strings.NewReplacer(pairs...).Replace
+
+	rule := NewAlertRule(id, fill(t.Name))
+	rule.Description = t.Title
+	for _, cond := range t.Conditions {
+		cond.Value = fill(cond.Value)
+		rule.Conditions = append(rule.Conditions, cond)
+	}
+	for _, act := range t.Actions {
+		act.Message = fill(act.Message)
+		rule.Actions = append(rule.Actions, act)
+	}
+	rule.SetCooldown(t.Cooldown)
+	rule.SetPriority(t.Priority)
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
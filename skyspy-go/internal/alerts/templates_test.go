@@ -0,0 +1,76 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuleTemplates_BuildWithDefaults(t *testing.T) {
+	samples := map[string]string{"watch_hex": "4CA7B5", "watch_callsign": "KLM*", "squawk": "7000"}
+	seen := map[string]bool{}
+	for _, tmpl := range RuleTemplates {
+		if seen[tmpl.ID] {
+			t.Errorf("template ID %q is used twice", tmpl.ID)
+		}
+		seen[tmpl.ID] = true
+
+		var values []string
+		if v, ok := samples[tmpl.ID]; ok {
+			values = []string{v}
+		}
+		rule, err := tmpl.Build(tmpl.ID, values)
+		if err != nil {
+			t.Errorf("%s: %v", tmpl.ID, err)
+			continue
+		}
+		if rule.ID != tmpl.ID || !rule.Enabled || len(rule.Actions) == 0 || rule.Cooldown <= 0 {
+			t.Errorf("%s: incomplete rule %+v", tmpl.ID, rule)
+		}
+	}
+}
+
+func TestRuleTemplate_Build(t *testing.T) {
+	tmpl := RuleTemplates[0]
+	rule, err := tmpl.Build("low_nearby_2", []string{" 2500 ", "15"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Name != "Below 2500ft within 15nm" {
+		t.Errorf("name = %q", rule.Name)
+	}
+	if rule.Conditions[0].Value != "2500" || rule.Conditions[1].Value != "15" {
+		t.Errorf("conditions = %+v", rule.Conditions)
+	}
+	if rule.Cooldown != 5*time.Minute || rule.Priority != 30 {
+		t.Errorf("cooldown %v, priority %d", rule.Cooldown, rule.Priority)
+	}
+	if RuleTemplates[0].Conditions[0].Value != "$1" {
+		t.Error("building a rule must not change the template")
+	}
+
+	state := &AircraftState{Hex: "ABC123", Altitude: 2000, HasAlt: true, Distance: 10}
+	engine := NewAlertEngine()
+	engine.AddRule(rule)
+	if len(engine.CheckAircraft(state, nil)) != 1 {
+		t.Error("the built rule should match an aircraft inside its values")
+	}
+
+	if _, err := tmpl.Build("low", []string{"low", "15"}); err == nil {
+		t.Error("a value that isn't a number should be rejected")
+	}
+	if _, err := RuleTemplates[1].Build("hex", nil); err == nil {
+		t.Error("a value without a default is required")
+	}
+}
+
+func TestRuleSet_UniqueID(t *testing.T) {
+	rs := NewRuleSet()
+	if id := rs.UniqueID("squawk"); id != "squawk" {
+		t.Errorf("free ID changed to %q", id)
+	}
+	rs.AddRule(NewAlertRule("squawk", "Squawk 7000"))
+	rs.AddRule(NewAlertRule("squawk_2", "Squawk 7001"))
+	if id := rs.UniqueID("squawk"); id != "squawk_3" {
+		t.Errorf("UniqueID = %q, want squawk_3", id)
+	}
+}
@@ -0,0 +1,136 @@
+// Package alerts provides configurable alert rules for aircraft monitoring
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Validate reports why a condition can never match as written
+func (c Condition) Validate() error {
+	value := strings.TrimSpace(c.Value)
+	if c.Regex && c.Type != ConditionACARSText {
+		return fmt.Errorf("%s: regex is only supported for %s", c.Type, ConditionACARSText)
+	}
+
+	switch c.Type {
+	case ConditionSquawk, ConditionCallsign, ConditionHex, ConditionACARSLabel:
+		if value == "" {
+			return fmt.Errorf("%s: value is required", c.Type)
+		}
+	case ConditionACARSText:
+		if value == "" {
+			return fmt.Errorf("%s: value is required", c.Type)
+		}
+		if c.Regex {
+			if _, err := regexp.Compile(c.Value); err != nil {
+				return fmt.Errorf("%s: invalid regex: %w", c.Type, err)
+			}
+		}
+	case ConditionMilitary, ConditionHolding, ConditionHexConflict, ConditionWatched:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s: value must be true or false, got %q", c.Type, c.Value)
+		}
+	case ConditionAltitudeAbove, ConditionAltitudeBelow, ConditionNavAltBelow, ConditionNavAltMismatch:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s: value must be whole feet, got %q", c.Type, c.Value)
+		}
+	case ConditionDistanceWithin, ConditionSpeedAbove, ConditionCPABelow, ConditionEnteringRadius:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s: value must be a number, got %q", c.Type, c.Value)
+		}
+	case ConditionSquawkSeverity:
+		switch strings.ToLower(value) {
+		case "info", "warning", "emergency":
+		default:
+			return fmt.Errorf("%s: value must be info, warning or emergency, got %q", c.Type, c.Value)
+		}
+	case ConditionEnteringGeofence, ConditionEnteringRegion, ConditionLeavingRegion, ConditionEnteringRestricted:
+		// Empty or "*" matches any geofence
+	case ConditionVSBelow, ConditionVSAbove:
+		if _, _, ok := ParseVSValue(c.Value); !ok {
+			return fmt.Errorf("%s: value must be ft/min[:min-altitude], got %q", c.Type, c.Value)
+		}
+	case ConditionGeofenceDwell, ConditionGeofenceDwellExit:
+		if _, _, ok := ParseDwellValue(c.Value); !ok {
+			return fmt.Errorf("%s: value must be [geofence-id:]duration, got %q", c.Type, c.Value)
+		}
+	default:
+		return fmt.Errorf("unknown condition type %q", c.Type)
+	}
+	return nil
+}
+
+// Validate reports an unknown action type
+func (a Action) Validate() error {
+	switch a.Type {
+	case ActionSound, ActionNotify, ActionLog, ActionHighlight, ActionBell:
+		return nil
+	default:
+		return fmt.Errorf("unknown action type %q", a.Type)
+	}
+}
+
+// Validate reports every problem with a rule, joined into one error
+func (r *AlertRule) Validate() error {
+	var errs []error
+	if strings.TrimSpace(r.ID) == "" {
+		errs = append(errs, errors.New("id is required"))
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if len(r.Conditions) == 0 {
+		errs = append(errs, errors.New("at least one condition is required"))
+	}
+	for _, cond := range r.Conditions {
+		if err := cond.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, act := range r.Actions {
+		if err := act.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if r.Cooldown < 0 {
+		errs = append(errs, errors.New("cooldown cannot be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate reports every problem with a geofence, joined into one error
+func (g *Geofence) Validate() error {
+	var errs []error
+	if strings.TrimSpace(g.ID) == "" {
+		errs = append(errs, errors.New("id is required"))
+	}
+
+	validPoint := func(p GeofencePoint) bool {
+		return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+	}
+	switch g.Type {
+	case GeofenceCircle:
+		if g.Center == nil || !validPoint(*g.Center) {
+			errs = append(errs, errors.New("circle needs a valid center"))
+		}
+		if g.RadiusNM <= 0 {
+			errs = append(errs, errors.New("circle radius must be positive"))
+		}
+	case GeofencePolygon:
+		if len(g.Points) < 3 {
+			errs = append(errs, fmt.Errorf("polygon needs at least 3 points, got %d", len(g.Points)))
+		}
+		for i, p := range g.Points {
+			if !validPoint(p) {
+				errs = append(errs, fmt.Errorf("point %d is out of range", i+1))
+			}
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown geofence type %q", g.Type))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,90 @@
+package alerts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCondition_Validate(t *testing.T) {
+	tests := []struct {
+		cond    Condition
+		wantErr bool
+	}{
+		{Condition{Type: ConditionSquawk, Value: "7700"}, false},
+		{Condition{Type: ConditionSquawk, Value: ""}, true},
+		{Condition{Type: ConditionMilitary, Value: "true"}, false},
+		{Condition{Type: ConditionMilitary, Value: "yes"}, true},
+		{Condition{Type: ConditionAltitudeBelow, Value: "1000"}, false},
+		{Condition{Type: ConditionAltitudeBelow, Value: "low"}, true},
+		{Condition{Type: ConditionDistanceWithin, Value: "2.5"}, false},
+		{Condition{Type: ConditionEnteringGeofence, Value: ""}, false},
+		{Condition{Type: ConditionGeofenceDwell, Value: "airport:10m"}, false},
+		{Condition{Type: ConditionGeofenceDwell, Value: "airport:soon"}, true},
+		{Condition{Type: ConditionACARSText, Value: "MAYDAY|PAN", Regex: true}, false},
+		{Condition{Type: ConditionACARSText, Value: "(MAYDAY", Regex: true}, true},
+		{Condition{Type: ConditionCallsign, Value: "UAL*", Regex: true}, true},
+		{Condition{Type: ConditionSquawkSeverity, Value: "Warning"}, false},
+		{Condition{Type: ConditionSquawkSeverity, Value: "urgent"}, true},
+		{Condition{Type: "bogus", Value: "1"}, true},
+	}
+	for _, tt := range tests {
+		err := tt.cond.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%s %q) error = %v, wantErr %v", tt.cond.Type, tt.cond.Value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAlertRule_Validate_ReportsAllProblems(t *testing.T) {
+	rule := &AlertRule{
+		Conditions: []Condition{{Type: "bogus"}},
+		Actions:    []Action{{Type: "page"}},
+	}
+	err := rule.Validate()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"id is required", "name is required", `"bogus"`, `"page"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %s", err, want)
+		}
+	}
+
+	if err := NewAlertRule("empty", "Empty").Validate(); err == nil {
+		t.Error("a rule without conditions should be invalid")
+	}
+}
+
+func TestDefaults_Validate(t *testing.T) {
+	for _, rule := range DefaultAlertRules() {
+		if err := rule.Validate(); err != nil {
+			t.Errorf("default rule %s: %v", rule.ID, err)
+		}
+	}
+	for _, gf := range CreateDefaultGeofences() {
+		if err := gf.Validate(); err != nil {
+			t.Errorf("default geofence %s: %v", gf.ID, err)
+		}
+	}
+}
+
+func TestGeofence_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		gf      *Geofence
+		wantErr bool
+	}{
+		{"circle", NewCircleGeofence("c", "C", 52, 4, 5), false},
+		{"zero radius", NewCircleGeofence("c", "C", 52, 4, 0), true},
+		{"bad center", NewCircleGeofence("c", "C", 95, 4, 5), true},
+		{"triangle", NewPolygonGeofence("p", "P", []GeofencePoint{{52, 4}, {52, 5}, {53, 4}}), false},
+		{"two points", NewPolygonGeofence("p", "P", []GeofencePoint{{52, 4}, {52, 5}}), true},
+		{"no id", NewCircleGeofence("", "C", 52, 4, 5), true},
+		{"unknown type", &Geofence{ID: "x", Type: "square"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.gf.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package alerts provides configurable alert rules for aircraft monitoring
+package alerts
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseVSValue parses a vertical rate condition value of the form
+// "rate[:min-altitude]": a rate in ft/min, negative for descent, and
+// optionally the altitude in feet below which the condition never matches,
+// so that normal approaches don't trigger it.
+func ParseVSValue(value string) (rate float64, minAltitude int, ok bool) {
+	value = strings.TrimSpace(value)
+	ratePart := value
+	if idx := strings.LastIndex(value, ":"); idx >= 0 {
+		ratePart = strings.TrimSpace(value[:idx])
+		alt, err := strconv.Atoi(strings.TrimSpace(value[idx+1:]))
+		if err != nil || alt < 0 {
+			return 0, 0, false
+		}
+		minAltitude = alt
+	}
+	rate, err := strconv.ParseFloat(ratePart, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return rate, minAltitude, true
+}
+
+// ClimbRate returns the vertical rate the vertical rate conditions use: the
+// smoothed trend when there is one, since single reports are noisy, or
+// else the latest reported rate
+func (s *AircraftState) ClimbRate() (float64, bool) {
+	switch {
+	case s.HasVSTrend:
+		return s.VSTrend, true
+	case s.HasVS:
+		return s.VerticalRate, true
+	}
+	return 0, false
+}
+
+// matchVS reports whether an aircraft's climb rate is beyond a vertical
+// rate condition's: below it for vs_below, above it for vs_above
+func matchVS(cond Condition, state *AircraftState) bool {
+	threshold, minAltitude, ok := ParseVSValue(cond.Value)
+	if !ok {
+		return false
+	}
+	vs, known := state.ClimbRate()
+	if !known {
+		return false
+	}
+	if minAltitude > 0 && (!state.HasAlt || state.Altitude < minAltitude) {
+		return false
+	}
+	if cond.Type == ConditionVSBelow {
+		return vs < threshold
+	}
+	return vs > threshold
+}
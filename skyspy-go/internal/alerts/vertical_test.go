@@ -0,0 +1,131 @@
+package alerts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseVSValue(t *testing.T) {
+	tests := []struct {
+		value  string
+		rate   float64
+		minAlt int
+		ok     bool
+	}{
+		{"-6000", -6000, 0, true},
+		{" -6000 : 3000 ", -6000, 3000, true},
+		{"4500:0", 4500, 0, true},
+		{"fast", 0, 0, false},
+		{"-6000:low", 0, 0, false},
+		{"-6000:-100", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		rate, minAlt, ok := ParseVSValue(tt.value)
+		if rate != tt.rate || minAlt != tt.minAlt || ok != tt.ok {
+			t.Errorf("ParseVSValue(%q) = %v, %v, %v; want %v, %v, %v", tt.value, rate, minAlt, ok, tt.rate, tt.minAlt, tt.ok)
+		}
+	}
+}
+
+func TestVSConditions_RawRate(t *testing.T) {
+	engine := NewAlertEngine()
+	descent := NewAlertRule("descent", "Descent").AddCondition(ConditionVSBelow, "-6000:3000")
+	descent.AddAction(ActionNotify, "DESCENT: {callsign} at {vs}")
+	engine.AddRule(descent)
+	engine.AddRule(NewAlertRule("climb", "Climb").AddCondition(ConditionVSAbove, "5000"))
+
+	// Without a trend the reported rate is used
+	state := &AircraftState{Hex: "ABC123", Callsign: "TEST1", HasAlt: true, Altitude: 12000, HasVS: true, VerticalRate: -7000}
+	triggered := engine.CheckAircraft(state, nil)
+	if len(triggered) != 1 || triggered[0].Rule.ID != "descent" {
+		t.Fatalf("expected the descent rule alone, got %d alerts", len(triggered))
+	}
+	if triggered[0].Message != "DESCENT: TEST1 at -7000" {
+		t.Errorf("message = %q", triggered[0].Message)
+	}
+
+	climber := &AircraftState{Hex: "DEF456", HasAlt: true, Altitude: 2000, HasVS: true, VerticalRate: 5500}
+	if triggered := engine.CheckAircraft(climber, nil); len(triggered) != 1 || triggered[0].Rule.ID != "climb" {
+		t.Errorf("a climb without a minimum altitude should match low down, got %d alerts", len(triggered))
+	}
+}
+
+func TestVSConditions_PreferTrend(t *testing.T) {
+	cond := Condition{Type: ConditionVSBelow, Value: "-6000"}
+
+	// A single noisy report doesn't count against a steady trend...
+	spike := &AircraftState{HasVS: true, VerticalRate: -9000, HasVSTrend: true, VSTrend: -1500}
+	if matchVS(cond, spike) {
+		t.Error("the smoothed trend should be used over the raw rate")
+	}
+	// ...and a sustained descent does, even on a calmer report
+	sustained := &AircraftState{HasVS: true, VerticalRate: -5000, HasVSTrend: true, VSTrend: -6500}
+	if !matchVS(cond, sustained) {
+		t.Error("a trend past the threshold should match")
+	}
+	if matchVS(cond, &AircraftState{}) {
+		t.Error("an aircraft without a vertical rate should never match")
+	}
+}
+
+func TestVSConditions_MinAltitude(t *testing.T) {
+	cond := Condition{Type: ConditionVSBelow, Value: "-6000:3000"}
+	for _, tt := range []struct {
+		state *AircraftState
+		want  bool
+	}{
+		{&AircraftState{HasAlt: true, Altitude: 2500, HasVS: true, VerticalRate: -8000}, false},
+		{&AircraftState{HasAlt: true, Altitude: 3000, HasVS: true, VerticalRate: -8000}, true},
+		{&AircraftState{HasVS: true, VerticalRate: -8000}, false},
+	} {
+		if got := matchVS(cond, tt.state); got != tt.want {
+			t.Errorf("altitude %d (known %v): match = %v, want %v", tt.state.Altitude, tt.state.HasAlt, got, tt.want)
+		}
+	}
+}
+
+func TestVSConditions_Validate(t *testing.T) {
+	if err := (Condition{Type: ConditionVSBelow, Value: "-6000:3000"}).Validate(); err != nil {
+		t.Errorf("valid condition: %v", err)
+	}
+	err := (Condition{Type: ConditionVSAbove, Value: "up"}).Validate()
+	if err == nil || !strings.Contains(err.Error(), "ft/min[:min-altitude]") {
+		t.Errorf("expected a format error, got %v", err)
+	}
+}
+
+func TestDefaultRules_RapidDescentDisabled(t *testing.T) {
+	var descent *AlertRule
+	for _, rule := range DefaultAlertRules() {
+		if rule.ID == "rapid_descent" {
+			descent = rule
+		}
+	}
+	if descent == nil {
+		t.Fatal("expected a rapid descent rule in the defaults")
+	}
+	if descent.Enabled {
+		t.Error("the rapid descent rule should ship disabled")
+	}
+	if err := descent.Validate(); err != nil {
+		t.Errorf("rapid descent rule: %v", err)
+	}
+}
+
+func TestRuleTemplates_VerticalRate(t *testing.T) {
+	for _, tmpl := range RuleTemplates {
+		if tmpl.ID != "rapid_descent" {
+			continue
+		}
+		rule, err := tmpl.Build("rapid_descent", []string{"8000", "5000"})
+		if err != nil {
+			t.Fatalf("Build: %v", err)
+		}
+		if rule.Conditions[0].Type != ConditionVSBelow || rule.Conditions[0].Value != "-8000:5000" {
+			t.Errorf("condition = %+v", rule.Conditions[0])
+		}
+		return
+	}
+	t.Error("expected a rapid descent template")
+}
@@ -0,0 +1,222 @@
+// Package api provides the local HTTP listener for the radar's JSON API
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/export"
+)
+
+// ErrRemoteListen is returned for a listen address other machines could
+// reach when remote access hasn't been allowed
+var ErrRemoteListen = errors.New("api: address is reachable from other machines; set api.allow_remote to use it")
+
+// Server answers API requests from the latest published snapshot
+type Server struct {
+	listener net.Listener
+	server   *http.Server
+	snapshot atomic.Pointer[Snapshot]
+}
+
+// Listen opens the API listener on addr. Unless allowRemote is set, addr
+// must be a loopback address such as 127.0.0.1:8088 or localhost:8088.
+func Listen(addr string, allowRemote bool) (*Server, error) {
+	if err := checkListenAddr(addr, allowRemote); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("api: %w", err)
+	}
+	s := &Server{listener: listener}
+	s.server = &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+	return s, nil
+}
+
+// checkListenAddr refuses addresses off the loopback interface, including
+// an empty host, which listens on every interface
+func checkListenAddr(addr string, allowRemote bool) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("api: listen address %q: %w", addr, err)
+	}
+	if allowRemote || strings.EqualFold(host, "localhost") {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrRemoteListen, addr)
+}
+
+// Addr returns the address the server is listening on
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Serve answers requests in the background until Close
+func (s *Server) Serve() {
+	go func() {
+		// Serve only returns on Close or a listener failure; either way
+		// the radar carries on without the API
+		_ = s.server.Serve(s.listener)
+	}()
+}
+
+// Close stops the server
+func (s *Server) Close() error {
+	return s.server.Close()
+}
+
+// Publish makes snap the state served from now on. snap must not be
+// changed afterwards.
+func (s *Server) Publish(snap *Snapshot) {
+	s.snapshot.Store(snap)
+}
+
+// Handler returns the API's request handler
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/aircraft", s.handleAircraftList)
+	mux.HandleFunc("GET /api/aircraft/{hex}", s.handleAircraft)
+	mux.HandleFunc("GET /api/trails/{hex}", s.handleTrail)
+	mux.HandleFunc("GET /api/stats", s.handleStats)
+	mux.HandleFunc("GET /api/alerts/recent", s.handleRecentAlerts)
+	return mux
+}
+
+// aircraftList is the body of /api/aircraft
+type aircraftList struct {
+	TotalAircraft int                     `json:"total_aircraft"`
+	Aircraft      []export.AircraftExport `json:"aircraft"`
+}
+
+// trailResponse is the body of /api/trails/{hex}
+type trailResponse struct {
+	Hex    string       `json:"hex"`
+	Points []TrailPoint `json:"points"`
+}
+
+// errorResponse is the body of a failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handleAircraftList(w http.ResponseWriter, r *http.Request) {
+	if snap := s.current(w); snap != nil {
+		aircraft := snap.Aircraft
+		if aircraft == nil {
+			aircraft = []export.AircraftExport{}
+		}
+		writeJSON(w, r, aircraftList{TotalAircraft: len(aircraft), Aircraft: aircraft})
+	}
+}
+
+func (s *Server) handleAircraft(w http.ResponseWriter, r *http.Request) {
+	snap := s.current(w)
+	if snap == nil {
+		return
+	}
+	ac, ok := snap.findAircraft(r.PathValue("hex"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "aircraft not found")
+		return
+	}
+	writeJSON(w, r, ac)
+}
+
+func (s *Server) handleTrail(w http.ResponseWriter, r *http.Request) {
+	snap := s.current(w)
+	if snap == nil {
+		return
+	}
+	hex := codec.NormalizeHex(r.PathValue("hex"))
+	points, ok := snap.Trails[hex]
+	if !ok {
+		if _, tracked := snap.findAircraft(hex); !tracked {
+			writeError(w, http.StatusNotFound, "aircraft not found")
+			return
+		}
+		points = []TrailPoint{}
+	}
+	writeJSON(w, r, trailResponse{Hex: hex, Points: points})
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	if snap := s.current(w); snap != nil {
+		writeJSON(w, r, snap.Stats)
+	}
+}
+
+func (s *Server) handleRecentAlerts(w http.ResponseWriter, r *http.Request) {
+	if snap := s.current(w); snap != nil {
+		alerts := snap.Alerts
+		if alerts == nil {
+			alerts = []Alert{}
+		}
+		writeJSON(w, r, alerts)
+	}
+}
+
+// current returns the latest snapshot, or answers 503 if the radar hasn't
+// published one yet
+func (s *Server) current(w http.ResponseWriter) *Snapshot {
+	snap := s.snapshot.Load()
+	if snap == nil {
+		writeError(w, http.StatusServiceUnavailable, "radar has not started")
+	}
+	return snap
+}
+
+// writeJSON sends v with an ETag of its content, or 304 Not Modified when
+// the client already holds that version
+func writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(body)
+	etag := fmt.Sprintf(`"%016x"`, h.Sum64())
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// etagMatches reports whether an If-None-Match header names etag. Weak
+// validators match too, as RFC 9110 requires for If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: msg})
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/export"
+)
+
+func testSnapshot() *Snapshot {
+	lat, lon, alt := 52.3, 4.76, 35000
+	return &Snapshot{
+		Aircraft: []export.AircraftExport{
+			{Hex: "4841A1", Callsign: "KLM123", Lat: &lat, Lon: &lon, Altitude: &alt},
+			{Hex: "AE1234", Callsign: "RCH01", Military: true},
+		},
+		Trails: map[string][]TrailPoint{
+			"4841A1": {
+				{Lat: 52.2, Lon: 4.7, Time: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)},
+				{Lat: 52.3, Lon: 4.76, Altitude: &alt, Time: time.Date(2026, 1, 1, 12, 0, 30, 0, time.UTC)},
+			},
+		},
+		Stats: Stats{Aircraft: 2, Peak: 5, Military: 1, Messages: 120, Connected: true},
+		Alerts: []Alert{
+			{RuleID: "mil", Rule: "Military", Priority: 2, Hex: "AE1234", Callsign: "RCH01", Message: "Military: RCH01"},
+		},
+	}
+}
+
+func get(t *testing.T, h http.Handler, path string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Endpoints(t *testing.T) {
+	s := &Server{}
+	s.Publish(testSnapshot())
+	h := s.Handler()
+
+	tests := []struct {
+		path   string
+		status int
+		want   string
+	}{
+		{"/api/aircraft", http.StatusOK, `"total_aircraft":2`},
+		{"/api/aircraft/4841a1", http.StatusOK, `"callsign":"KLM123"`},
+		{"/api/aircraft/ABCDEF", http.StatusNotFound, `"error":"aircraft not found"`},
+		{"/api/trails/4841A1", http.StatusOK, `"altitude":35000`},
+		{"/api/trails/AE1234", http.StatusOK, `"points":[]`},
+		{"/api/trails/ABCDEF", http.StatusNotFound, `"error"`},
+		{"/api/stats", http.StatusOK, `"peak_aircraft":5`},
+		{"/api/alerts/recent", http.StatusOK, `"rule_id":"mil"`},
+	}
+	for _, tt := range tests {
+		rec := get(t, h, tt.path, nil)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status %d, want %d", tt.path, rec.Code, tt.status)
+		}
+		if !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("%s: body %s, want %s", tt.path, rec.Body.String(), tt.want)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: content type %q", tt.path, ct)
+		}
+	}
+}
+
+func TestServer_AircraftMatchesExport(t *testing.T) {
+	s := &Server{}
+	s.Publish(testSnapshot())
+
+	var body struct {
+		TotalAircraft int                     `json:"total_aircraft"`
+		Aircraft      []export.AircraftExport `json:"aircraft"`
+	}
+	rec := get(t, s.Handler(), "/api/aircraft", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(body.Aircraft) != 2 || body.Aircraft[0].Hex != "4841A1" || *body.Aircraft[0].Altitude != 35000 {
+		t.Errorf("unexpected aircraft: %+v", body.Aircraft)
+	}
+}
+
+func TestServer_NotStarted(t *testing.T) {
+	s := &Server{}
+	if rec := get(t, s.Handler(), "/api/stats", nil); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 before the first snapshot, got %d", rec.Code)
+	}
+}
+
+func TestServer_ETag(t *testing.T) {
+	s := &Server{}
+	s.Publish(testSnapshot())
+	h := s.Handler()
+
+	first := get(t, h, "/api/aircraft", nil)
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	// Unchanged data in a new snapshot keeps the ETag
+	s.Publish(testSnapshot())
+	for _, header := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		rec := get(t, h, "/api/aircraft", map[string]string{"If-None-Match": header})
+		if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: status %d with %d bytes, want an empty 304", header, rec.Code, rec.Body.Len())
+		}
+	}
+
+	// Changed data gets a new one
+	snap := testSnapshot()
+	snap.Aircraft = snap.Aircraft[:1]
+	s.Publish(snap)
+	rec := get(t, h, "/api/aircraft", map[string]string{"If-None-Match": etag})
+	if rec.Code != http.StatusOK {
+		t.Errorf("changed data should be sent, got %d", rec.Code)
+	}
+	if rec.Header().Get("ETag") == etag {
+		t.Error("changed data should have a new ETag")
+	}
+}
+
+func TestServer_ReadOnly(t *testing.T) {
+	s := &Server{}
+	s.Publish(testSnapshot())
+	req := httptest.NewRequest(http.MethodPost, "/api/aircraft", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: status %d, want 405", rec.Code)
+	}
+}
+
+func TestCheckListenAddr(t *testing.T) {
+	tests := []struct {
+		addr        string
+		allowRemote bool
+		wantRemote  bool
+	}{
+		{"127.0.0.1:8088", false, false},
+		{"localhost:8088", false, false},
+		{"[::1]:8088", false, false},
+		{":8088", false, true},
+		{"0.0.0.0:8088", false, true},
+		{"192.168.1.10:8088", false, true},
+		{"0.0.0.0:8088", true, false},
+	}
+	for _, tt := range tests {
+		err := checkListenAddr(tt.addr, tt.allowRemote)
+		if got := errors.Is(err, ErrRemoteListen); got != tt.wantRemote {
+			t.Errorf("%s (allow_remote=%v): err = %v", tt.addr, tt.allowRemote, err)
+		}
+	}
+	if err := checkListenAddr("8088", false); err == nil {
+		t.Error("expected an error for an address without a port separator")
+	}
+}
+
+func TestListen(t *testing.T) {
+	if _, err := Listen("0.0.0.0:0", false); !errors.Is(err, ErrRemoteListen) {
+		t.Fatalf("expected ErrRemoteListen, got %v", err)
+	}
+
+	s, err := Listen("127.0.0.1:0", false)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+	s.Serve()
+	s.Publish(testSnapshot())
+
+	resp, err := http.Get("http://" + s.Addr() + "/api/stats")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+	var stats Stats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if stats.Aircraft != 2 || !stats.Connected {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
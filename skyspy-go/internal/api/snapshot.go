@@ -0,0 +1,84 @@
+// Package api serves the radar's state as read-only JSON over a local HTTP
+// listener, for web pages and scripts running beside the TUI
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/export"
+)
+
+// Snapshot is the radar's state at one tick. The radar builds a new one
+// each tick and never changes it after publishing, so handlers can read it
+// from any goroutine.
+type Snapshot struct {
+	Aircraft []export.AircraftExport // sorted by hex
+	Trails   map[string][]TrailPoint // by hex, oldest point first
+	Stats    Stats
+	Alerts   []Alert // oldest first
+}
+
+// TrailPoint is one position in an aircraft's trail
+type TrailPoint struct {
+	Lat      float64   `json:"lat"`
+	Lon      float64   `json:"lon"`
+	Altitude *int      `json:"altitude,omitempty"` // feet
+	Time     time.Time `json:"time"`
+	Break    bool      `json:"break,omitempty"` // first point after a gap; not joined to the one before
+	Segment  int       `json:"segment"`         // trail segment, counted up at each break
+}
+
+// Stats are the radar's tracking counts
+type Stats struct {
+	Aircraft  int      `json:"aircraft"`
+	Peak      int      `json:"peak_aircraft"`
+	Military  int      `json:"military"`
+	Emergency int      `json:"emergency"`
+	Messages  int      `json:"messages"` // this session
+	Shed      int      `json:"shed"`     // aircraft dropped over the aircraft cap
+	Connected bool     `json:"connected"`
+	Latency   *Latency `json:"latency,omitempty"` // nil until an update carries a receive time
+}
+
+// Latency is the radio-to-radar latency of recent aircraft updates
+type Latency struct {
+	Samples int             `json:"samples"`
+	P50Ms   int64           `json:"p50_ms"`
+	P95Ms   int64           `json:"p95_ms"`
+	Buckets []LatencyBucket `json:"buckets"` // cumulative, as in a Prometheus histogram
+}
+
+// LatencyBucket counts the updates at or under a latency
+type LatencyBucket struct {
+	LeMs  int64 `json:"le_ms"`
+	Count int   `json:"count"`
+}
+
+// Alert is a triggered alert rule
+type Alert struct {
+	RuleID   string    `json:"rule_id,omitempty"`
+	Rule     string    `json:"rule,omitempty"`
+	Priority int       `json:"priority"`
+	Hex      string    `json:"hex,omitempty"`
+	Callsign string    `json:"callsign,omitempty"`
+	Message  string    `json:"message"`
+	Time     time.Time `json:"time"`
+	Distance float64   `json:"distance_nm,omitempty"` // from the receiver when it fired
+}
+
+// SortAircraft orders aircraft by hex, as Snapshot.Aircraft must be
+func SortAircraft(aircraft []export.AircraftExport) {
+	sort.Slice(aircraft, func(i, j int) bool { return aircraft[i].Hex < aircraft[j].Hex })
+}
+
+// findAircraft returns the aircraft with the given hex, in any case
+func (s *Snapshot) findAircraft(hex string) (export.AircraftExport, bool) {
+	hex = codec.NormalizeHex(hex)
+	i := sort.Search(len(s.Aircraft), func(i int) bool { return s.Aircraft[i].Hex >= hex })
+	if i < len(s.Aircraft) && s.Aircraft[i].Hex == hex {
+		return s.Aircraft[i], true
+	}
+	return export.AircraftExport{}, false
+}
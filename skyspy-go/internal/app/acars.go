@@ -0,0 +1,89 @@
+// Package app provides ACARS ingestion for the SkySpy radar
+package app
+
+import (
+	"container/list"
+	"hash/fnv"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// acarsDedupCapacity bounds the number of recent message hashes remembered
+const acarsDedupCapacity = 256
+
+// acarsRetention returns the configured ACARS retention cap
+func acarsRetention(cfg *config.Config) int {
+	if cfg.ACARS.MaxMessages > 0 {
+		return cfg.ACARS.MaxMessages
+	}
+	return config.DefaultConfig().ACARS.MaxMessages
+}
+
+// acarsDeduper is a small LRU of recently seen ACARS message hashes. The same
+// message is often relayed by several ground stations within seconds.
+type acarsDeduper struct {
+	window   time.Duration
+	capacity int
+	order    *list.List // front = most recently seen
+	entries  map[uint64]*list.Element
+}
+
+type acarsDedupEntry struct {
+	key  uint64
+	seen time.Time
+}
+
+func newACARSDeduper(window time.Duration, capacity int) *acarsDeduper {
+	return &acarsDeduper{
+		window:   window,
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[uint64]*list.Element),
+	}
+}
+
+// isDuplicate records the message and reports whether an identical one was
+// already seen within the window
+func (d *acarsDeduper) isDuplicate(data codec.ACARSData, now time.Time) bool {
+	if d.window <= 0 {
+		return false
+	}
+
+	key := acarsKey(data)
+	if el, ok := d.entries[key]; ok {
+		entry := el.Value.(*acarsDedupEntry)
+		if now.Sub(entry.seen) < d.window {
+			d.order.MoveToFront(el)
+			return true
+		}
+		entry.seen = now
+		d.order.MoveToFront(el)
+		return false
+	}
+
+	d.entries[key] = d.order.PushFront(&acarsDedupEntry{key: key, seen: now})
+	for d.order.Len() > d.capacity {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.entries, oldest.Value.(*acarsDedupEntry).key)
+	}
+	return false
+}
+
+// acarsKey hashes the fields that identify a repeated transmission
+func acarsKey(data codec.ACARSData) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(data.Callsign))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(data.Label))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(data.Text))
+	return h.Sum64()
+}
+
+// GetACARSDuplicates returns the number of repeated ACARS messages dropped
+func (m *Model) GetACARSDuplicates() int {
+	return m.acarsDuplicates
+}
@@ -0,0 +1,46 @@
+// Package app provides aircraft type names for the SkySpy radar
+package app
+
+import (
+	"github.com/skyspy/skyspy-go/internal/actype"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// loadTypeDatabase reads the configured type database over the built-in
+// one. A file that can't be read leaves the built-in types in use.
+func (m *Model) loadTypeDatabase() {
+	types, err := actype.Load(m.config.Display.TypeDatabase)
+	if err != nil {
+		m.notify(m.trf("notify.types_error", err.Error()))
+	}
+	m.types = types
+}
+
+// describeType fills in the target's type name and class from its ICAO
+// type designator
+func (m *Model) describeType(t *radar.Target) {
+	typ, ok := m.types.Lookup(t.ACType)
+	if !ok {
+		t.TypeName, t.TypeClass = "", ""
+		return
+	}
+	t.TypeName, t.TypeClass = typ.Description(), typ.Class
+}
+
+// formatType returns the type designator with its name, e.g.
+// "A320 Airbus A320"
+func formatType(t *radar.Target) string {
+	if t.TypeName == "" {
+		return t.ACType
+	}
+	return t.ACType + " " + t.TypeName
+}
+
+// listType returns the short model name shown in the target list, or the
+// designator when the type isn't known
+func (m *Model) listType(t *radar.Target) string {
+	if typ, ok := m.types.Lookup(t.ACType); ok && typ.Model != "" {
+		return typ.Model
+	}
+	return t.ACType
+}
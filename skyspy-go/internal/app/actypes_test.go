@@ -0,0 +1,74 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/search"
+)
+
+func TestTypes_DescribedAndShown(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.width, m.height = 160, 60
+
+	jumbo := flying("4CA7B5", 52.40)
+	jumbo.Type = "B77W"
+	m.updateTarget(jumbo, true)
+	odd := flying("484F6D", 52.45)
+	odd.Type = "ZZZZ"
+	m.updateTarget(odd, true)
+
+	target := m.aircraft["4CA7B5"]
+	if target.TypeName != "Boeing 777-300ER" || target.TypeClass != "heavy" {
+		t.Fatalf("type = %q %q", target.TypeName, target.TypeClass)
+	}
+	if unknown := m.aircraft["484F6D"]; unknown.TypeName != "" || unknown.TypeClass != "" {
+		t.Errorf("an unknown type should stay undescribed: %+v", unknown)
+	}
+
+	m.selectedHex = "4CA7B5"
+	if panel := ansi.Strip(m.renderTargetPanel()); !strings.Contains(panel, "B77W Boeing 777-300ER") {
+		t.Errorf("target panel should name the type:\n%s", panel)
+	}
+	m.renderRadar()
+	list := ansi.Strip(m.renderTargetList())
+	if !strings.Contains(list, "777-300ER") || !strings.Contains(list, "ZZZZ") {
+		t.Errorf("target list should show the model, or the code when unknown:\n%s", list)
+	}
+
+	results := search.FilterAircraft(m.aircraft, search.ParseQuery("type:heavy"))
+	if len(results) != 1 || results[0] != "4CA7B5" {
+		t.Errorf("type:heavy found %v", results)
+	}
+}
+
+func TestTypes_DatabaseOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "types.csv")
+	if err := os.WriteFile(path, []byte("B77W,Boeing,Triple Seven,heavy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := newTestConfig()
+	cfg.Display.TypeDatabase = path
+	m := NewModel(cfg)
+
+	ac := flying("4CA7B5", 52.40)
+	ac.Type = "B77W"
+	m.updateTarget(ac, true)
+	if got := m.aircraft["4CA7B5"].TypeName; got != "Boeing Triple Seven" {
+		t.Errorf("override not used: %q", got)
+	}
+
+	cfg = newTestConfig()
+	cfg.Display.TypeDatabase = filepath.Join(t.TempDir(), "missing.csv")
+	m = NewModel(cfg)
+	if !strings.HasPrefix(m.notification, "Aircraft type database not loaded") {
+		t.Errorf("notification = %q", m.notification)
+	}
+	m.updateTarget(ac, true)
+	if got := m.aircraft["4CA7B5"].TypeName; got != "Boeing 777-300ER" {
+		t.Errorf("the built-in types should be used: %q", got)
+	}
+}
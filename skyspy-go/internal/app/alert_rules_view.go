@@ -2,6 +2,8 @@
 package app
 
 import (
+	"math"
+
 	"github.com/skyspy/skyspy-go/internal/alerts"
 )
 
@@ -12,6 +14,9 @@ const (
 	keyEnter = "enter"
 )
 
+// maxAlertRadius is the largest radius (nm) +/- set on a radius rule
+const maxAlertRadius = 100
+
 // handleAlertRulesKey handles keyboard input in alert rules view
 func (m *Model) handleAlertRulesKey(key string) {
 	rules := m.GetAlertRules()
@@ -33,21 +38,79 @@ func (m *Model) handleAlertRulesKey(key string) {
 			rule := rules[m.alertRuleCursor]
 			enabled := m.alertState.ToggleRule(rule.ID)
 			if enabled {
-				m.notify("Rule enabled: " + rule.Name)
+				m.notify(m.trf("notify.rule_enabled", rule.Name))
+			} else {
+				m.notify(m.trf("notify.rule_disabled", rule.Name))
+			}
+		}
+	case "n":
+		m.openRuleTemplates()
+	case "e":
+		if ruleCount > 0 {
+			m.openRuleEditor(rules[m.alertRuleCursor])
+		}
+	case "d":
+		if ruleCount > 0 && m.alertState != nil {
+			m.deleteRule(rules[m.alertRuleCursor])
+		}
+	case "g":
+		m.openGeofencesView()
+	case "b":
+		if ruleCount > 0 && m.alertState != nil {
+			rule := rules[m.alertRuleCursor]
+			if m.alertState.ToggleAction(rule.ID, alerts.ActionBell) {
+				m.notify(m.trf("notify.rule_bell_on", rule.Name))
 			} else {
-				m.notify("Rule disabled: " + rule.Name)
+				m.notify(m.trf("notify.rule_bell_off", rule.Name))
 			}
+			m.alertState.SaveToConfig(m.config)
+			m.saveConfig()
 		}
-	case "a", "A":
+	case "+", "=", "-":
+		if ruleCount > 0 && m.alertState != nil {
+			dir := 1.0
+			if key == "-" {
+				dir = -1
+			}
+			m.stepAlertRadius(rules[m.alertRuleCursor], dir)
+		}
+	case "E":
+		m.showEmergencies = !m.showEmergencies
+	case "a":
 		if m.alertState != nil {
 			m.alertState.AlertsEnabled = !m.alertState.AlertsEnabled
 			if m.alertState.AlertsEnabled {
-				m.notify("Alerts: ON")
+				m.notify(m.tr("notify.alerts_on"))
 			} else {
-				m.notify("Alerts: OFF")
+				m.notify(m.tr("notify.alerts_off"))
 			}
 		}
+	case "A", "D":
+		if m.alertState != nil {
+			enabled := key == "A"
+			changed := m.alertState.SetAllRules(enabled)
+			if enabled {
+				m.notify(m.trf("notify.rules_enabled", changed))
+			} else {
+				m.notify(m.trf("notify.rules_disabled", changed))
+			}
+		}
+	}
+}
+
+// stepAlertRadius widens or narrows the radius of a rule that alerts on
+// aircraft entering one, such as the proximity rule, by 1 nm and saves it
+func (m *Model) stepAlertRadius(rule *alerts.AlertRule, dir float64) {
+	radius, ok := rule.ProximityRadius()
+	if !ok {
+		m.notify(m.trf("notify.rule_no_radius", rule.Name))
+		return
 	}
+	radius = math.Max(1, math.Min(maxAlertRadius, math.Round(radius)+dir))
+	rule.SetProximityRadius(radius)
+	m.alertState.SaveToConfig(m.config)
+	m.saveConfig()
+	m.notify(m.trf("notify.rule_radius", rule.Name, m.formatDist(radius, 0)))
 }
 
 // GetAlertRules returns all alert rules
@@ -99,4 +162,6 @@ func (m *Model) GetAlertStats() alerts.AlertStats {
 func (m *Model) openAlertRulesView() {
 	m.viewMode = ViewAlertRules
 	m.alertRuleCursor = 0
+	m.ruleDraft = nil
+	m.ruleEditor = nil
 }
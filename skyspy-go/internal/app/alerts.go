@@ -7,6 +7,7 @@ import (
 	"github.com/skyspy/skyspy-go/internal/alerts"
 	"github.com/skyspy/skyspy-go/internal/config"
 	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/trails"
 )
 
 // AlertState holds alert-related state for the application
@@ -15,29 +16,53 @@ type AlertState struct {
 	RuleCursor    int
 	RecentAlerts  []alerts.TriggeredAlert
 	AlertsEnabled bool
+
+	// Receiver location for CPA-based conditions
+	ReceiverLat float64
+	ReceiverLon float64
+
+	// Turn history for the holding condition (shared with the radar)
+	Turns *trails.TurnTracker
 }
 
 // NewAlertState creates a new alert state with default rules
 func NewAlertState(cfg *config.Config) *AlertState {
-	engine := alerts.NewAlertEngine()
+	return newAlertState(cfg, true)
+}
 
-	// Load rules from config or use defaults
-	if len(cfg.Alerts.Rules) > 0 {
-		for _, ruleCfg := range cfg.Alerts.Rules {
-			rule := configToAlertRule(ruleCfg)
-			engine.AddRule(rule)
+// newAlertState creates the alert state, with the configured rules and
+// geofences unless withRules is false, when the engine starts with none
+func newAlertState(cfg *config.Config, withRules bool) *AlertState {
+	engine := alerts.NewAlertEngine()
+	engine.SetDistanceUnit(cfg.Display.Units)
+	if withRules {
+		// Load rules from config or use defaults
+		if len(cfg.Alerts.Rules) > 0 {
+			for _, ruleCfg := range cfg.Alerts.Rules {
+				rule := configToAlertRule(ruleCfg)
+				if ruleCfg.ID == alerts.ProximityRuleID {
+					// Keeps its place and actions; the proximity settings
+					// say whether it's on and how far it reaches
+					rule = applyProximity(rule, cfg)
+				}
+				engine.AddRule(rule)
+			}
+		} else {
+			// Add default rules
+			for _, rule := range alerts.DefaultAlertRules() {
+				engine.AddRule(rule)
+			}
 		}
-	} else {
-		// Add default rules
-		for _, rule := range alerts.DefaultAlertRules() {
-			engine.AddRule(rule)
+		// The proximity rule is there whatever rules are configured
+		if engine.GetRuleSet().GetRuleByID(alerts.ProximityRuleID) == nil {
+			engine.AddRule(proximityRule(cfg))
 		}
-	}
 
-	// Load geofences from config
-	for _, gfCfg := range cfg.Alerts.Geofences {
-		gf := configToGeofence(gfCfg)
-		engine.AddGeofence(gf)
+		// Load geofences from config
+		for _, gfCfg := range cfg.Alerts.Geofences {
+			gf := configToGeofence(gfCfg)
+			engine.AddGeofence(gf)
+		}
 	}
 
 	return &AlertState{
@@ -45,7 +70,34 @@ func NewAlertState(cfg *config.Config) *AlertState {
 		RuleCursor:    0,
 		RecentAlerts:  []alerts.TriggeredAlert{},
 		AlertsEnabled: cfg.Alerts.Enabled,
+		ReceiverLat:   cfg.Connection.ReceiverLat,
+		ReceiverLon:   cfg.Connection.ReceiverLon,
+	}
+}
+
+// proximityRule returns the built-in proximity rule as configured
+func proximityRule(cfg *config.Config) *alerts.AlertRule {
+	return applyProximity(alerts.ProximityRule(proximityRadius(cfg)), cfg)
+}
+
+// applyProximity sets a saved proximity rule on or off and its radius from
+// the proximity settings. A rule saved without a radius is replaced by the
+// built-in one.
+func applyProximity(rule *alerts.AlertRule, cfg *config.Config) *alerts.AlertRule {
+	if _, ok := rule.ProximityRadius(); !ok {
+		rule = alerts.ProximityRule(proximityRadius(cfg))
 	}
+	rule.SetProximityRadius(proximityRadius(cfg))
+	rule.Enabled = cfg.Alerts.Proximity
+	return rule
+}
+
+// proximityRadius returns the configured proximity radius (nm)
+func proximityRadius(cfg *config.Config) float64 {
+	if cfg.Alerts.ProximityRadiusNM <= 0 {
+		return alerts.DefaultProximityRadius
+	}
+	return cfg.Alerts.ProximityRadiusNM
 }
 
 // CheckAircraft checks an aircraft against alert rules and returns any triggered alerts
@@ -55,14 +107,44 @@ func (a *AlertState) CheckAircraft(target, prevTarget *radar.Target) []alerts.Tr
 	}
 
 	state := targetToAlertState(target)
+	if cpa, ok := targetCPA(target, a.ReceiverLat, a.ReceiverLon); ok && cpa.Closing {
+		state.CPADistance = cpa.Distance
+		state.HasCPA = true
+	}
+	if a.Turns != nil {
+		if turn, ok := a.Turns.Latest(target.Hex); ok {
+			state.Holding = turn.Holding
+		}
+	}
 	var prevState *alerts.AircraftState
 	if prevTarget != nil {
 		prevState = targetToAlertState(prevTarget)
 	}
 
 	triggered := a.Engine.CheckAircraft(state, prevState)
+	a.recordAlerts(triggered)
+	return triggered
+}
 
-	// Add to recent alerts
+// CheckACARS checks an ACARS message against the message rules. sender is
+// the tracked aircraft that sent it, or nil.
+func (a *AlertState) CheckACARS(msg *ACARSMessage, sender *radar.Target) []alerts.TriggeredAlert {
+	if !a.AlertsEnabled || a.Engine == nil {
+		return nil
+	}
+
+	triggered := a.Engine.CheckMessage(&alerts.MessageState{
+		Callsign: msg.Callsign,
+		Flight:   msg.Flight,
+		Label:    msg.Label,
+		Text:     msg.Text,
+	}, targetToAlertState(sender))
+	a.recordAlerts(triggered)
+	return triggered
+}
+
+// recordAlerts adds triggered alerts to the recent list
+func (a *AlertState) recordAlerts(triggered []alerts.TriggeredAlert) {
 	if len(triggered) > 0 {
 		a.RecentAlerts = append(a.RecentAlerts, triggered...)
 		// Keep only last 20 alerts
@@ -70,8 +152,6 @@ func (a *AlertState) CheckAircraft(target, prevTarget *radar.Target) []alerts.Tr
 			a.RecentAlerts = a.RecentAlerts[len(a.RecentAlerts)-20:]
 		}
 	}
-
-	return triggered
 }
 
 // GetRules returns all alert rules
@@ -90,6 +170,23 @@ func (a *AlertState) ToggleRule(id string) bool {
 	return a.Engine.GetRuleSet().ToggleRule(id)
 }
 
+// SetAllRules enables or disables every rule and returns how many changed
+func (a *AlertState) SetAllRules(enabled bool) int {
+	if a.Engine == nil {
+		return 0
+	}
+	return a.Engine.GetRuleSet().SetAllEnabled(enabled)
+}
+
+// ToggleAction adds or removes an action type on a rule, reporting whether
+// the rule now has it
+func (a *AlertState) ToggleAction(id string, actionType alerts.ActionType) bool {
+	if a.Engine == nil {
+		return false
+	}
+	return a.Engine.GetRuleSet().ToggleAction(id, actionType)
+}
+
 // IsHighlighted checks if an aircraft should be highlighted due to an alert
 func (a *AlertState) IsHighlighted(hex string) bool {
 	if a.Engine == nil {
@@ -113,15 +210,26 @@ func (a *AlertState) Cleanup() {
 	}
 }
 
+// RemoveAircraft drops all per-aircraft alert state for hex
+func (a *AlertState) RemoveAircraft(hex string) {
+	if a.Engine != nil {
+		a.Engine.RemoveAircraftState(hex)
+	}
+}
+
 // SaveToConfig saves alert configuration
 func (a *AlertState) SaveToConfig(cfg *config.Config) {
 	cfg.Alerts.Enabled = a.AlertsEnabled
 
-	// Save rules
+	// Save rules; the proximity rule's state also goes in its own settings
 	rules := a.GetRules()
 	cfg.Alerts.Rules = make([]config.AlertRuleConfig, len(rules))
 	for i, rule := range rules {
 		cfg.Alerts.Rules[i] = alertRuleToConfig(rule)
+		if rule.ID == alerts.ProximityRuleID {
+			cfg.Alerts.Proximity = rule.Enabled
+			cfg.Alerts.ProximityRadiusNM, _ = rule.ProximityRadius()
+		}
 	}
 
 	// Save geofences
@@ -154,7 +262,31 @@ func targetToAlertState(t *radar.Target) *alerts.AircraftState {
 		HasLon:   t.HasLon,
 		HasAlt:   t.HasAlt,
 		HasSpeed: t.HasSpeed,
+
+		Conflicted:     t.Conflicted,
+		OnWatchlist:    t.OnWatchlist,
+		SquawkSeverity: squawkSeverityName(t.SquawkSeverity()),
+		Region:         t.Region,
+
+		RegionRestricted: t.RegionRestricted,
+
+		VerticalRate: t.Vertical,
+		NavAltitude:  t.NavAltitude,
+		HasVS:        t.HasVS,
+		HasNavAlt:    t.HasNavAlt,
+
+		VSTrend:    t.VSTrend,
+		HasVSTrend: t.HasVSTrend,
+	}
+}
+
+// squawkSeverityName names a severity for alert conditions; ordinary
+// codes have none
+func squawkSeverityName(s radar.SquawkSeverity) string {
+	if s == radar.SquawkNormal {
+		return ""
 	}
+	return s.String()
 }
 
 func configToAlertRule(cfg config.AlertRuleConfig) *alerts.AlertRule {
@@ -168,7 +300,11 @@ func configToAlertRule(cfg config.AlertRuleConfig) *alerts.AlertRule {
 	}
 
 	for _, cond := range cfg.Conditions {
-		rule.AddCondition(alerts.ConditionType(cond.Type), cond.Value)
+		if cond.Regex {
+			rule.AddRegexCondition(alerts.ConditionType(cond.Type), cond.Value)
+		} else {
+			rule.AddCondition(alerts.ConditionType(cond.Type), cond.Value)
+		}
 	}
 
 	for _, act := range cfg.Actions {
@@ -198,6 +334,7 @@ func alertRuleToConfig(rule *alerts.AlertRule) config.AlertRuleConfig {
 		cfg.Conditions[i] = config.ConditionConfig{
 			Type:  string(cond.Type),
 			Value: cond.Value,
+			Regex: cond.Regex,
 		}
 	}
 
@@ -266,3 +403,16 @@ func geofenceToConfig(gf *alerts.Geofence) config.GeofenceConfig {
 
 	return cfg
 }
+
+// runAlertAction carries out the actions of a triggered alert that the
+// radar handles itself; notifications are shown for every alert
+func (m *Model) runAlertAction(action alerts.Action, source *radar.Target) {
+	switch action.Type {
+	case alerts.ActionSound:
+		if m.alertPlayer != nil {
+			m.alertPlayer.PlayRuleSoundFrom(action.Sound, alertSource(source))
+		}
+	case alerts.ActionBell:
+		m.ringBell()
+	}
+}
@@ -0,0 +1,185 @@
+// Package app provides alert rule import and export for the SkySpy radar
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// alertsFileVersion is the format version written to exported files
+const alertsFileVersion = 1
+
+// AlertsFile is a standalone, shareable copy of the alert rules and
+// geofences from the Alerts section of the configuration
+type AlertsFile struct {
+	Version   int                      `json:"version"`
+	Rules     []config.AlertRuleConfig `json:"rules"`
+	Geofences []config.GeofenceConfig  `json:"geofences"`
+}
+
+// ImportAction says what an import did, or would do, with one entry
+type ImportAction string
+
+const (
+	ImportAdded     ImportAction = "added"
+	ImportReplaced  ImportAction = "replaced"
+	ImportUnchanged ImportAction = "unchanged"
+	ImportSkipped   ImportAction = "skipped" // ID exists and --overwrite wasn't given
+	ImportInvalid   ImportAction = "invalid"
+)
+
+// ImportChange describes the outcome for one rule or geofence
+type ImportChange struct {
+	Kind   string // "rule" or "geofence"
+	ID     string
+	Name   string
+	Action ImportAction
+	Err    error // set for ImportInvalid
+}
+
+// ExportAlerts returns the alert rules and geofences in effect for cfg. A
+// config without custom rules exports the built-in defaults it runs with.
+func ExportAlerts(cfg *config.Config) *AlertsFile {
+	f := &AlertsFile{
+		Version:   alertsFileVersion,
+		Rules:     effectiveAlertRules(cfg),
+		Geofences: append([]config.GeofenceConfig{}, cfg.Alerts.Geofences...),
+	}
+	return f
+}
+
+// WriteAlertsFile writes an alerts file as indented JSON
+func WriteAlertsFile(path string, f *AlertsFile) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	//nolint:gosec // G306: Alert rules are meant to be shared
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ReadAlertsFile reads an alerts file written by WriteAlertsFile
+func ReadAlertsFile(path string) (*AlertsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f AlertsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if f.Version > alertsFileVersion {
+		return nil, fmt.Errorf("%s: unsupported alerts file version %d", path, f.Version)
+	}
+	return &f, nil
+}
+
+// ValidateAlertRule checks a configured rule the way the alert engine will
+// read it
+func ValidateAlertRule(rc config.AlertRuleConfig) error {
+	return configToAlertRule(rc).Validate()
+}
+
+// ValidateGeofence checks a configured geofence the way the alert engine
+// will read it
+func ValidateGeofence(gc config.GeofenceConfig) error {
+	return configToGeofence(gc).Validate()
+}
+
+// ImportAlerts merges the rules and geofences from f into cfg by ID. An
+// existing ID is only replaced when overwrite is set. Invalid entries are
+// reported and left out; the rest are still imported. cfg is not saved.
+func ImportAlerts(cfg *config.Config, f *AlertsFile, overwrite bool) []ImportChange {
+	var changes []ImportChange
+
+	// Importing into a config without custom rules keeps the defaults it
+	// was running with rather than replacing them
+	rules := effectiveAlertRules(cfg)
+	for _, rc := range f.Rules {
+		change := ImportChange{Kind: "rule", ID: rc.ID, Name: rc.Name}
+		if err := ValidateAlertRule(rc); err != nil {
+			change.Action, change.Err = ImportInvalid, err
+			changes = append(changes, change)
+			continue
+		}
+		idx := -1
+		for i := range rules {
+			if rules[i].ID == rc.ID {
+				idx = i
+				break
+			}
+		}
+		switch {
+		case idx < 0:
+			rules = append(rules, rc)
+			change.Action = ImportAdded
+		case sameJSON(rules[idx], rc):
+			change.Action = ImportUnchanged
+		case overwrite:
+			rules[idx] = rc
+			change.Action = ImportReplaced
+		default:
+			change.Action = ImportSkipped
+		}
+		changes = append(changes, change)
+	}
+
+	geofences := append([]config.GeofenceConfig{}, cfg.Alerts.Geofences...)
+	for _, gc := range f.Geofences {
+		change := ImportChange{Kind: "geofence", ID: gc.ID, Name: gc.Name}
+		if err := ValidateGeofence(gc); err != nil {
+			change.Action, change.Err = ImportInvalid, err
+			changes = append(changes, change)
+			continue
+		}
+		idx := -1
+		for i := range geofences {
+			if geofences[i].ID == gc.ID {
+				idx = i
+				break
+			}
+		}
+		switch {
+		case idx < 0:
+			geofences = append(geofences, gc)
+			change.Action = ImportAdded
+		case sameJSON(geofences[idx], gc):
+			change.Action = ImportUnchanged
+		case overwrite:
+			geofences[idx] = gc
+			change.Action = ImportReplaced
+		default:
+			change.Action = ImportSkipped
+		}
+		changes = append(changes, change)
+	}
+
+	cfg.Alerts.Rules = rules
+	cfg.Alerts.Geofences = geofences
+	return changes
+}
+
+// effectiveAlertRules returns a copy of the configured rules, or the
+// defaults the alert engine falls back to when there are none
+func effectiveAlertRules(cfg *config.Config) []config.AlertRuleConfig {
+	if len(cfg.Alerts.Rules) > 0 {
+		return append([]config.AlertRuleConfig{}, cfg.Alerts.Rules...)
+	}
+	defaults := alerts.DefaultAlertRules()
+	rules := make([]config.AlertRuleConfig, len(defaults))
+	for i, rule := range defaults {
+		rules[i] = alertRuleToConfig(rule)
+	}
+	return rules
+}
+
+// sameJSON reports whether two config entries serialize identically
+func sameJSON(a, b interface{}) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
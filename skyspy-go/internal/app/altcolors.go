@@ -0,0 +1,55 @@
+// Package app provides altitude coloring for the SkySpy radar
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+// toggleAltitudeColors turns coloring targets by altitude on or off
+func (m *Model) toggleAltitudeColors() {
+	m.config.Display.ColorByAltitude = !m.config.Display.ColorByAltitude
+	if m.config.Display.ColorByAltitude {
+		m.notify(m.tr("notify.alt_colors_on"))
+	} else {
+		m.notify(m.tr("notify.alt_colors_off"))
+	}
+}
+
+// listRowStyle returns the style of a target list row. The selected row
+// is highlighted; with altitude colors on, emergencies and military keep
+// their colors and the rest are colored by altitude band.
+func (m *Model) listRowStyle(t *radar.Target, selected bool) lipgloss.Style {
+	switch {
+	case selected:
+		return lipgloss.NewStyle().Foreground(m.theme.Selected).Bold(true)
+	case !m.config.Display.ColorByAltitude:
+	case t.IsEmergency():
+		return m.theme.Style(theme.RoleEmergency)
+	case t.Military:
+		return m.theme.Style(theme.RoleMilitary)
+	case t.HasAlt:
+		return lipgloss.NewStyle().Foreground(m.theme.AltitudeColor(t.Altitude))
+	}
+	return lipgloss.NewStyle().Foreground(m.theme.Secondary)
+}
+
+// altitudeLegend draws the altitude bands in their colors, in thousands of
+// feet, e.g. "✈<2 ✈2 ✈6 ✈10 ✈20 ✈30 ✈40+"
+func (m *Model) altitudeLegend() string {
+	symbol := m.glyphs().Aircraft
+	lowest := theme.AltitudeBands[0]
+	parts := []string{lipgloss.NewStyle().Foreground(m.theme.AltitudeColor(0)).Render(symbol + "<" + strconv.Itoa(lowest/1000))}
+	for i, alt := range theme.AltitudeBands {
+		label := strconv.Itoa(alt / 1000)
+		if i == len(theme.AltitudeBands)-1 {
+			label += "+"
+		}
+		parts = append(parts, lipgloss.NewStyle().Foreground(m.theme.AltitudeColor(alt)).Render(symbol+label))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+func TestAltColors_Toggle(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	m.handleRadarKey("ctrl+a")
+	if !m.config.Display.ColorByAltitude || m.notification != "Altitude colors: ON" {
+		t.Errorf("altitude colors = %v, notification %q", m.config.Display.ColorByAltitude, m.notification)
+	}
+	m.handleRadarKey("ctrl+a")
+	if m.config.Display.ColorByAltitude || m.notification != "Altitude colors: OFF" {
+		t.Errorf("altitude colors = %v, notification %q", m.config.Display.ColorByAltitude, m.notification)
+	}
+}
+
+func TestAltColors_ListRows(t *testing.T) {
+	m := NewModel(newTestConfig())
+	low := &radar.Target{Hex: "LOW001", Altitude: 1500, HasAlt: true}
+	mil := &radar.Target{Hex: "MIL001", Altitude: 1500, HasAlt: true, Military: true}
+	sos := &radar.Target{Hex: "SOS001", Altitude: 1500, HasAlt: true, Squawk: "7700"}
+
+	if got := m.listRowStyle(low, false).GetForeground(); got != m.theme.Secondary {
+		t.Errorf("rows should keep the secondary color by default, got %v", got)
+	}
+
+	m.config.Display.ColorByAltitude = true
+	want := map[*radar.Target]lipgloss.TerminalColor{
+		low: m.theme.AltitudeColor(1500),
+		mil: m.theme.Military,
+		sos: m.theme.Emergency,
+	}
+	for target, color := range want {
+		if got := m.listRowStyle(target, false).GetForeground(); got != color {
+			t.Errorf("%s: color = %v, want %v", target.Hex, got, color)
+		}
+	}
+	if got := m.listRowStyle(low, true).GetForeground(); got != m.theme.Selected {
+		t.Errorf("the selected row should be highlighted, got %v", got)
+	}
+}
+
+func TestAltColors_HelpLegend(t *testing.T) {
+	m := NewModel(newTestConfig())
+	help := ansi.Strip(m.renderHelpPanel())
+	if !strings.Contains(help, "ALTITUDE COLORS") || !strings.Contains(help, "<2 ") || !strings.Contains(help, "40+") {
+		t.Errorf("help should show the altitude legend:\n%s", help)
+	}
+}
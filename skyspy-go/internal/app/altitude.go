@@ -0,0 +1,99 @@
+// Package app provides barometric and GNSS altitudes for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/units"
+)
+
+// Altitude sources
+const (
+	altSourceBaro      = "baro"      // pressure altitude, as ATC sees it
+	altSourceGeometric = "geometric" // GNSS altitude
+)
+
+// altSplitFeet is the baro/GNSS difference the detail panel highlights.
+// Splits this large come from non-standard pressure or bad data.
+const altSplitFeet = 400
+
+// altitudeSource returns the configured altitude source; anything but
+// geometric uses baro
+func altitudeSource(cfg *config.Config) string {
+	if strings.EqualFold(strings.TrimSpace(cfg.Display.AltitudeSource), altSourceGeometric) {
+		return altSourceGeometric
+	}
+	return altSourceBaro
+}
+
+// setAltitudes fills in the target's barometric and GNSS altitudes and
+// picks the one the radar colors and filters by. Either stands in when the
+// configured one isn't reported.
+func (m *Model) setAltitudes(target *radar.Target, ac *codec.Aircraft) {
+	if ac.AltBaro != nil {
+		target.BaroAltitude = *ac.AltBaro
+		target.HasBaroAlt = true
+	} else if ac.Alt != nil {
+		target.BaroAltitude = *ac.Alt
+		target.HasBaroAlt = true
+	}
+	if ac.AltGeom != nil {
+		target.GeomAltitude = *ac.AltGeom
+		target.HasGeomAlt = true
+	}
+
+	useGeom := target.HasGeomAlt && (altitudeSource(m.config) == altSourceGeometric || !target.HasBaroAlt)
+	switch {
+	case useGeom:
+		target.Altitude = target.GeomAltitude
+		target.HasAlt = true
+	case target.HasBaroAlt:
+		target.Altitude = target.BaroAltitude
+		target.HasAlt = true
+	}
+}
+
+// formatAltitudes formats the detail panel's altitude row: baro, then the
+// GNSS altitude when reported, e.g. "FL350  GNSS 35450'". The row is
+// highlighted when the two are more than altSplitFeet apart.
+func (m *Model) formatAltitudes(t *radar.Target, style lipgloss.Style) (string, lipgloss.Style) {
+	baro, hasBaro := t.BaroAlt()
+	var parts []string
+	if hasBaro {
+		parts = append(parts, radar.FormatAltitude(baro))
+	}
+	if t.HasGeomAlt {
+		parts = append(parts, fmt.Sprintf("GNSS %d'", t.GeomAltitude))
+	}
+	if len(parts) == 0 {
+		return emptyPlaceholder, style
+	}
+	if split, ok := t.AltSplit(); ok && (split > altSplitFeet || split < -altSplitFeet) {
+		style = lipgloss.NewStyle().Foreground(m.theme.Warning).Bold(true)
+	}
+	return strings.Join(parts, "  "), style
+}
+
+// formatDualAltitudes formats the altitude rows of the detail panel with
+// dual units: baro, then GNSS when reported, each as e.g.
+// "35,000 ft / 10,670 m". GNSS is empty when it isn't reported.
+func (m *Model) formatDualAltitudes(t *radar.Target) (baro, gnss string) {
+	baro = emptyPlaceholder
+	if alt, ok := t.BaroAlt(); ok {
+		baro = m.formatDualAltitude(alt)
+	}
+	if t.HasGeomAlt {
+		gnss = m.formatDualAltitude(t.GeomAltitude)
+	}
+	return baro, gnss
+}
+
+// formatDualAltitude formats an altitude in feet and meters
+func (m *Model) formatDualAltitude(ft int) string {
+	return m.locale.Int(ft) + " ft / " + m.locale.Int(units.Meters(ft)) + " m"
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+func TestModel_SetAltitudes(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		ac       codec.Aircraft
+		altitude int
+		hasAlt   bool
+	}{
+		{"baro only", "baro", codec.Aircraft{AltBaro: intPtr(12000)}, 12000, true},
+		{"legacy alt", "baro", codec.Aircraft{Alt: intPtr(8000)}, 8000, true},
+		{"GNSS only", "baro", codec.Aircraft{AltGeom: intPtr(12100)}, 12100, true},
+		{"both, baro", "baro", codec.Aircraft{AltBaro: intPtr(35000), AltGeom: intPtr(35450)}, 35000, true},
+		{"both, geometric", "geometric", codec.Aircraft{AltBaro: intPtr(35000), AltGeom: intPtr(35450)}, 35450, true},
+		{"baro only, geometric", "Geometric", codec.Aircraft{AltBaro: intPtr(35000)}, 35000, true},
+		{"neither", "baro", codec.Aircraft{}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.Display.AltitudeSource = tt.source
+			m := NewModel(cfg)
+			ac := tt.ac
+			ac.Hex = "ALT001"
+			m.updateTarget(&ac, true)
+
+			target := m.aircraft["ALT001"]
+			if target.Altitude != tt.altitude || target.HasAlt != tt.hasAlt {
+				t.Errorf("altitude = %d, %v, want %d, %v", target.Altitude, target.HasAlt, tt.altitude, tt.hasAlt)
+			}
+			if baro, ok := target.BaroAlt(); ok != (tt.ac.AltBaro != nil || tt.ac.Alt != nil) || (ok && baro == 0) {
+				t.Errorf("baro altitude = %d, %v", baro, ok)
+			}
+		})
+	}
+}
+
+func TestModel_AltitudeRow(t *testing.T) {
+	m := NewModel(newTestConfig())
+	plain := lipgloss.NewStyle()
+
+	m.updateTarget(&codec.Aircraft{Hex: "BARO01", AltBaro: intPtr(35000)}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "GNSS01", AltGeom: intPtr(4500)}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "BOTH01", AltBaro: intPtr(35000), AltGeom: intPtr(35300)}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "SPLIT1", AltBaro: intPtr(35000), AltGeom: intPtr(35450)}, true)
+
+	tests := []struct {
+		hex       string
+		want      string
+		highlight bool
+	}{
+		{"BARO01", "FL350", false},
+		{"GNSS01", "GNSS 4500'", false},
+		{"BOTH01", "FL350  GNSS 35300'", false},
+		{"SPLIT1", "FL350  GNSS 35450'", true},
+	}
+	for _, tt := range tests {
+		got, style := m.formatAltitudes(m.aircraft[tt.hex], plain)
+		if got != tt.want {
+			t.Errorf("%s altitude row = %q, want %q", tt.hex, got, tt.want)
+		}
+		if highlighted := style.GetBold(); highlighted != tt.highlight {
+			t.Errorf("%s highlighted = %v, want %v", tt.hex, highlighted, tt.highlight)
+		}
+	}
+
+	m.selectedHex = "SPLIT1"
+	if detail := ansi.Strip(m.renderTargetPanel()); !strings.Contains(detail, "ALT  FL350  GNSS 35450'") {
+		t.Errorf("expected both altitudes in the details:\n%s", detail)
+	}
+}
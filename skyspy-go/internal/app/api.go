@@ -0,0 +1,86 @@
+// Package app provides the snapshots the SkySpy radar publishes to the
+// local HTTP API
+package app
+
+import (
+	"github.com/skyspy/skyspy-go/internal/api"
+	"github.com/skyspy/skyspy-go/internal/export"
+)
+
+// SnapshotPublisher receives a copy of the radar's state each tick;
+// *api.Server implements it
+type SnapshotPublisher interface {
+	Publish(snap *api.Snapshot)
+}
+
+// SetAPI publishes the radar's state to p on every tick, unless the local
+// API is turned off
+func (m *Model) SetAPI(p SnapshotPublisher) {
+	if !m.caps.API {
+		return
+	}
+	m.api = p
+	m.publishSnapshot()
+}
+
+// publishSnapshot hands the API a snapshot of the current state. The API
+// serves it from other goroutines, so it shares nothing the radar changes.
+func (m *Model) publishSnapshot() {
+	if m.api != nil {
+		m.api.Publish(m.apiSnapshot())
+	}
+}
+
+// apiSnapshot copies the aircraft, trails, stats and recent alerts. Like
+// the exports, positions are measured from the display receiver.
+func (m *Model) apiSnapshot() *api.Snapshot {
+	// The API's distances are in nm whatever the radar shows
+	opts := m.exportOptions()
+	opts.Units = ""
+	shown := m.displayAircraft()
+	snap := &api.Snapshot{
+		Aircraft: make([]export.AircraftExport, 0, len(shown)),
+		Trails:   make(map[string][]api.TrailPoint),
+	}
+	for _, t := range shown {
+		target := *t
+		target.NavModes = append([]string(nil), t.NavModes...)
+		snap.Aircraft = append(snap.Aircraft, export.AircraftRecord(&target, opts))
+	}
+	api.SortAircraft(snap.Aircraft)
+
+	for hex, trail := range m.trailTracker.GetAllTrails() {
+		points := make([]api.TrailPoint, len(trail))
+		for i, pos := range trail {
+			points[i] = api.TrailPoint{Lat: pos.Lat, Lon: pos.Lon, Time: pos.Timestamp.UTC(), Break: pos.Break, Segment: pos.Segment}
+			if pos.HasAlt {
+				alt := pos.Altitude
+				points[i].Altitude = &alt
+			}
+		}
+		snap.Trails[hex] = points
+	}
+
+	stats := m.GetStats()
+	snap.Stats = api.Stats{
+		Aircraft:  stats.Aircraft,
+		Peak:      stats.Peak,
+		Military:  stats.Military,
+		Emergency: stats.Emergency,
+		Messages:  stats.Messages,
+		Shed:      stats.Shed,
+		Connected: m.feed != nil && m.feed.IsConnected(),
+		Latency:   m.apiLatency(),
+	}
+
+	for _, a := range m.GetRecentAlerts() {
+		alert := api.Alert{Hex: a.Hex, Callsign: a.Callsign, Message: a.Message, Time: a.Timestamp.UTC(), Distance: a.Distance}
+		if a.Rule != nil {
+			alert.RuleID = a.Rule.ID
+			alert.Rule = a.Rule.Name
+			alert.Priority = a.Rule.Priority
+		}
+		snap.Alerts = append(snap.Alerts, alert)
+	}
+	return snap
+}
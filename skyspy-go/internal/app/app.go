@@ -2,24 +2,30 @@
 package app
 
 import (
+	"context"
+	"encoding/json"
 	"math"
-	"path/filepath"
+	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/actype"
 	"github.com/skyspy/skyspy-go/internal/audio"
-	"github.com/skyspy/skyspy-go/internal/auth"
+	"github.com/skyspy/skyspy-go/internal/changelog"
+	"github.com/skyspy/skyspy-go/internal/clipboard"
+	"github.com/skyspy/skyspy-go/internal/codec"
 	"github.com/skyspy/skyspy-go/internal/config"
 	"github.com/skyspy/skyspy-go/internal/export"
 	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/i18n"
 	"github.com/skyspy/skyspy-go/internal/radar"
 	"github.com/skyspy/skyspy-go/internal/search"
 	"github.com/skyspy/skyspy-go/internal/spectrum"
 	"github.com/skyspy/skyspy-go/internal/theme"
 	"github.com/skyspy/skyspy-go/internal/trails"
-	"github.com/skyspy/skyspy-go/internal/ws"
+	"github.com/skyspy/skyspy-go/internal/ui"
 )
 
 // ViewMode represents the current view
@@ -32,6 +38,16 @@ const (
 	ViewOverlays
 	ViewSearch
 	ViewAlertRules
+	ViewWhatsNew
+	ViewLogin
+	ViewNotice  // a high severity server notice, until dismissed
+	ViewNotices // the notice history
+	ViewAway    // notable aircraft missed while away
+	ViewAircraftDetail
+	ViewGeofences // the geofence editor, from the alert rules
+	ViewStatistics
+
+	viewModeCount // not a view; new views go above
 )
 
 // ACARSMessage represents an ACARS message
@@ -40,29 +56,80 @@ type ACARSMessage struct {
 	Flight   string
 	Label    string
 	Text     string
+	Received time.Time // local arrival time
+	Sent     time.Time // server send time from the envelope, if given
 }
 
 // Model is the main application model
 type Model struct {
 	// Data
 	aircraft      map[string]*radar.Target
+	lastSeen      map[string]time.Time
+	shed          map[string]time.Time // aircraft dropped over the cap, by when last heard
+	shedding      bool                 // over the cap; trails trimmed and extras paused
+	shedCutoff    shedRank             // rank of the last aircraft kept when shedding
+	keysCanonical bool                 // no aircraft key is left in another spelling
 	sortedTargets []string
 	acarsMessages []ACARSMessage
+	acarsDedup    *acarsDeduper
+	history       map[string]*aircraftHistory // signal and squawk history by hex
 
 	// Selection and navigation
 	selectedHex    string
 	rangeIdx       int
 	rangeOptions   []int
+	customRange    bool    // targetRange was typed in and is between steps
 	maxRange       float64 // animated current range (eases toward targetRange)
 	targetRange    float64 // selected range the scope zooms toward
+	rangeEntryOpen bool    // custom range prompt is taking keys
+	rangeEntry     string  // digits typed at the custom range prompt
 	settingsCursor int
 	overlayCursor  int
+	pinned         []string       // pinned targets in pin order, at most maxPinned
+	lostSelection  *lostSelection // selected target that dropped out, reselected if it returns
+
+	// Type-ahead in the target list: the callsign prefix typed so far, when
+	// its last key came and whether any row matches it
+	jumpPrefix string
+	jumpAt     time.Time
+	jumpMissed bool
+
+	// Split screen: the second pane's range and center, and whether it has
+	// the zoom controls
+	splitRangeIdx    int
+	splitRange       float64
+	splitTargetRange float64
+	splitFocus       bool
+	splitOnSelected  bool
+
+	// Main scope center: the followed target's position, or where the view
+	// was panned to; the receiver when neither
+	followHex      string
+	panned         bool
+	panLat, panLon float64
+
+	// Heading-up display: the scope turns so the selected target's track
+	// points up, easing from rotation toward targetRotation
+	headingUp       bool
+	rotation        float64
+	targetRotation  float64
+	rotationHex     string
+	rotationUpdated time.Time
 
 	// Animation state
 	sweepAngle float64
 	blink      bool
 	frame      int
-	spinners   []string
+
+	// Wall clock (injectable for tests) and last stale-data sweep
+	now            func() time.Time
+	lastCleanup    time.Time
+	lastTrailPrune time.Time
+
+	// Offset of the server's clock from ours, from the auth Date header
+	// or feed envelope times
+	clockSkew      time.Duration
+	clockSkewKnown bool
 
 	// VU meters and spectrum (pro features)
 	vuLeft           float64
@@ -70,12 +137,101 @@ type Model struct {
 	spectrum         []float64
 	spectrumPeaks    []float64
 	spectrumAnalyzer *spectrum.Analyzer
+	spectrumRange    float64   // range the analyzer's distance bands cover
+	lastSignalData   time.Time // when an aircraft last reported RSSI
+
+	// Vertical profile of the selected target (replaces the spectrum area)
+	showProfile bool
+
+	// Order the target list by arrival at the active point of interest
+	sortByPOI bool
 
 	// Statistics
 	peakAircraft    int
 	sessionMessages int
+	connMessages    int // since the feed last (re)connected
+	acarsDuplicates int
 	militaryCount   int
+	surfaceCount    int // targets on the airport surface
 	emergencyCount  int
+	signalLog       signalLog // session RSSI and per-sector range records
+	statsHistory    statsHistory
+
+	// Altitude plausibility bounds (ft) and updates rejected for them
+	altFloor          int
+	altCeiling        int
+	rejectedAltitudes int
+
+	// Feed values that couldn't be decoded: whole messages and single
+	// fields
+	parseErrors int
+
+	// Radio-to-screen latency of recent updates that carried a receive
+	// time
+	latency *latencyHistogram
+
+	// Status bar segments in display order, and the message rate shown
+	// by one of them
+	statusLayout []statusSegment
+	msgRate      float64 // aircraft messages per second
+	msgRateAt    time.Time
+	msgRateCount int
+
+	// Aircraft type designators looked up for target names and classes
+	types *actype.Database
+
+	debugLog  *os.File // diagnostic entries; nil unless debug_log is set
+	debugTail []string // the latest diagnostic entries, logged or not
+
+	// Special squawk codes targets are classified by, from the settings
+	squawkCodes radar.SquawkTable
+
+	// Emergency squawk history, kept whatever the alert settings
+	emergencyActive map[string]string // hex -> emergency squawk in progress
+	emergencies     []emergencyEntry
+	emergencyLog    *export.Appender // nil until SetEmergencyLog
+	showEmergencies bool             // alert rules view lists the history
+
+	// Session log of every aircraft message; nil unless SetSessionLog
+	sessionLog    *export.Appender
+	sessionFormat string
+
+	// Bell alert action: when it last rang, and until when the BEL and
+	// the status bar flash are drawn
+	lastBell   time.Time
+	bellUntil  time.Time
+	flashUntil time.Time
+
+	// Tracking of recently removed targets, in case they return
+	departed map[string]departedTrack
+
+	// Notable aircraft seen this session, and the "while you were away"
+	// digest of them: the last key press, where the digest starts, and
+	// the digest as shown
+	sightings  map[string]*sighting
+	lastInput  time.Time
+	awayMarker time.Time
+	awayList   []sighting
+	awayTotal  int
+	awayScroll int
+
+	// Receiver position reported by the server, used when the settings
+	// don't give one
+	serverLat    float64
+	serverLon    float64
+	hasServerPos bool
+
+	// Targets carried over a reconnect that the new session hasn't
+	// reported yet; nil when no resync is in progress
+	resyncPending map[string]bool
+
+	// Receiver the aircraft message being handled came from, for a merged
+	// feed, and when each receiver last reported each aircraft
+	source       string
+	receiverSeen map[string]map[string]time.Time
+
+	// Clickable regions of the last render, in screen cells
+	hits ui.HitMap
 
 	// UI state
 	viewMode         ViewMode
@@ -83,151 +239,170 @@ type Model struct {
 	notificationTime float64
 	width, height    int
 	lastRenderedView string
+	fixedSize        bool         // drawn at a state file's size whatever the terminal's
+	suspended        bool         // stopped with ctrl+z; nothing is rendered until resume
+	pendingExport    *exportRetry // failed export W repeats into the temp directory
+
+	// Changelog shown once after an upgrade
+	whatsNewVersion string
+	whatsNew        []changelog.Entry
+
+	// Server notices: the history, high severity ones waiting to be
+	// dismissed and the view to return to after, and receipts to send
+	notices      []ServerNotice
+	noticeQueue  []ServerNotice
+	noticeReturn ViewMode
+	pendingAcks  []string
+
+	// Kiosk mode's lock and idle reset; nil when off
+	kiosk *kioskState
 
 	// Search state
 	searchQuery   string
 	searchFilter  *search.Filter
 	searchResults []string
 	searchCursor  int
+	searcher      search.Incremental
+	searchTyped   time.Time // last keystroke in the search box
+	searchSeq     int       // identifies the latest deferred search update
+
+	// Quick look data blocks: the target under the pointer and since when,
+	// and until when Ctrl+Q shows those around the selection
+	hoverHex       string
+	hoverSince     time.Time
+	quickLookUntil time.Time
+
+	// The aircraft the detail page shows
+	detailHex string
+
+	// Server sign-in shown in the stats panel, and the in-app sign-in flow
+	auth           Authenticator
+	authRefreshing bool
+	login          *loginState
+	loginSeq       int
 
 	// Configuration
 	config         *config.Config
 	theme          *theme.Theme
 	overlayManager *geo.OverlayManager
-
-	// Trail tracking
-	trailTracker *trails.TrailTracker
+	overlayLoads   []*overlayLoad // configured overlays still loading, or failed, in order
+	overlayLoader  func(path string) (*geo.GeoOverlay, error)
+	overlaySums    map[string]loadedOverlay // overlays from files by content hash, to skip copies
+	overlayCtx     context.Context
+	overlayCancel  context.CancelFunc
+
+	// Airport surface mode
+	surfaceMode      int // surfaceAuto, surfaceOn or surfaceOff
+	overlayElevation int // ft, from the nearest airport point in the overlays
+	regions          *geo.RegionIndex
+	text             *i18n.Table // UI strings, English unless SetStrings loaded a translation
+	locale           i18n.Locale // number and time formats
+
+	// Density shading for wide ranges
+	densityMode int // densityAuto, densityOn or densityOff
+
+	// Trail tracking and turn detection
+	trailTracker    *trails.TrailTracker
+	turnTracker     *trails.TurnTracker
+	conflictTracker *trails.ConflictTracker
 
 	// Audio alerts
 	alertPlayer     *audio.AlertPlayer
 	alertedAircraft map[string]bool
+	quietHours      *audio.QuietHours
+	dnd             dndMode
 
 	// Alert rules
 	alertState      *AlertState
 	alertRuleCursor int
-
-	// WebSocket client
-	wsClient *ws.Client
+	ruleDraft       *ruleDraft  // new rule from a template, while it's being filled in
+	ruleEditor      *ruleEditor // rule being written or edited
+	geofenceCursor  int
+	fenceDraft      *fenceDraft // circle geofence whose radius is being typed
+
+	// Live feed; nil for headless models that are fed via Ingest*
+	feed Feed
+
+	// Daily data budget: the local day being counted, the session total
+	// when it began and the highest warning given
+	budgetDay    string
+	budgetBase   int64
+	budgetWarned int
+
+	// Startup connection: if the feed hasn't connected within the timeout,
+	// or failed before the UI started, an error screen replaces the radar
+	connectStarted     time.Time
+	feedConnected      bool            // the feed has connected at least once
+	feedDown           bool            // an established feed has dropped and not come back
+	sourceUp           map[string]bool // receivers of a merged feed connected at the last tick
+	reconnectAttempt   int             // reconnect attempt the dropped feed is waiting on; 0 when it isn't
+	connectFailure     error
+	connectHints       []string
+	configureRequested bool
+
+	// Clipboard for yanking target list rows
+	clipboard *clipboard.Writer
+	// OSC 52 sequence of the last yank, and until when frames carry it
+	clipboardSeq   string
+	clipboardUntil time.Time
+
+	// Headless event consumer (e.g. the stream command)
+	onEvent func(Event)
+
+	// Local HTTP API fed a snapshot each tick; nil when it is off
+	api SnapshotPublisher
+
+	// Optional subsystems allowed to start; all but the feed are off in
+	// safe mode
+	caps Capabilities
+
+	version string // SkySpy version, written into state files
 }
 
 // NewModel creates a new application model
 func NewModel(cfg *config.Config) *Model {
-	t := theme.Get(cfg.Display.Theme)
-
-	// Initialize overlay manager and load configured overlays
-	overlayMgr := geo.NewOverlayManager()
-	for _, ov := range cfg.Overlays.Overlays {
-		if ov.Path != "" {
-			if overlay, err := geo.LoadOverlay(ov.Path); err == nil {
-				overlay.Enabled = ov.Enabled
-				if ov.Color != nil {
-					overlay.Color = *ov.Color
-				}
-				overlayMgr.AddOverlay(overlay, ov.Key)
-			}
-		}
-	}
-
-	rangeOptions := []int{25, 50, 100, 200, 400}
-	rangeIdx := 2 // Default to 100nm
-	maxRange := float64(cfg.Radar.DefaultRange)
-	for i, r := range rangeOptions {
-		if r >= cfg.Radar.DefaultRange {
-			rangeIdx = i
-			maxRange = float64(r)
-			break
-		}
-	}
-
-	spectrumBins := 24
-	analyzer := spectrum.NewAnalyzer()
-
-	return &Model{
-		aircraft:         make(map[string]*radar.Target),
-		sortedTargets:    []string{},
-		acarsMessages:    make([]ACARSMessage, 0, 100),
-		rangeIdx:         rangeIdx,
-		rangeOptions:     rangeOptions,
-		maxRange:         maxRange,
-		targetRange:      maxRange,
-		sweepAngle:       0,
-		blink:            false,
-		frame:            0,
-		spinners:         []string{"◐", "◓", "◑", "◒"},
-		vuLeft:           0,
-		vuRight:          0,
-		spectrum:         make([]float64, spectrumBins),
-		spectrumPeaks:    make([]float64, spectrumBins),
-		spectrumAnalyzer: analyzer,
-		viewMode:         ViewRadar,
-		config:           cfg,
-		theme:            t,
-		overlayManager:   overlayMgr,
-		trailTracker:     trails.NewTrailTracker(),
-		alertPlayer:      audio.NewAlertPlayer(&cfg.Audio),
-		alertedAircraft:  make(map[string]bool),
-		alertState:       NewAlertState(cfg),
-		wsClient:         ws.NewClient(cfg.Connection.Host, cfg.Connection.Port, cfg.Connection.ReconnectDelay),
-	}
+	return NewModelWithCapabilities(cfg, nil, FullCapabilities())
 }
 
-// NewModelWithAuth creates a new application model with authentication support
-func NewModelWithAuth(cfg *config.Config, authMgr *auth.Manager) *Model {
-	t := theme.Get(cfg.Display.Theme)
+// NewModelWithCapabilities creates a model reading from feed, which may be
+// nil, that starts only the optional subsystems caps allows
+func NewModelWithCapabilities(cfg *config.Config, feed Feed, caps Capabilities) *Model {
+	t := themeFor(cfg, caps)
 
-	// Initialize overlay manager and load configured overlays
+	// Configured overlays load in the background once the radar is up
 	overlayMgr := geo.NewOverlayManager()
-	for _, ov := range cfg.Overlays.Overlays {
-		if ov.Path != "" {
-			if overlay, err := geo.LoadOverlay(ov.Path); err == nil {
-				overlay.Enabled = ov.Enabled
-				if ov.Color != nil {
-					overlay.Color = *ov.Color
-				}
-				overlayMgr.AddOverlay(overlay, ov.Key)
-			}
-		}
+	if overlay := poiOverlay(cfg); overlay != nil && caps.Overlays {
+		overlayMgr.AddOverlay(overlay, "poi")
 	}
 
-	rangeOptions := []int{25, 50, 100, 200, 400}
-	rangeIdx := 2 // Default to 100nm
-	maxRange := float64(cfg.Radar.DefaultRange)
-	for i, r := range rangeOptions {
-		if r >= cfg.Radar.DefaultRange {
-			rangeIdx = i
-			maxRange = float64(r)
-			break
-		}
-	}
-
-	// Create WebSocket client with auth provider if available
-	var wsClient *ws.Client
-	if authMgr != nil && authMgr.IsAuthenticated() {
-		wsClient = ws.NewClientWithAuth(
-			cfg.Connection.Host,
-			cfg.Connection.Port,
-			cfg.Connection.ReconnectDelay,
-			authMgr.GetAuthHeader,
-		)
-	} else {
-		wsClient = ws.NewClient(cfg.Connection.Host, cfg.Connection.Port, cfg.Connection.ReconnectDelay)
-	}
+	// Start at the last range used, which may lie between steps
+	rangeOptions := rangeSteps(cfg)
+	initialRange := clampRange(cfg.Radar.DefaultRange)
+	rangeIdx := stepIndex(rangeOptions, initialRange)
+	maxRange := float64(initialRange)
 
 	spectrumBins := 24
 	analyzer := spectrum.NewAnalyzer()
 
-	return &Model{
+	m := &Model{
 		aircraft:         make(map[string]*radar.Target),
+		lastSeen:         make(map[string]time.Time),
+		receiverSeen:     make(map[string]map[string]time.Time),
+		history:          make(map[string]*aircraftHistory),
+		shed:             make(map[string]time.Time),
 		sortedTargets:    []string{},
-		acarsMessages:    make([]ACARSMessage, 0, 100),
+		acarsMessages:    make([]ACARSMessage, 0, acarsRetention(cfg)),
+		acarsDedup:       newACARSDeduper(time.Duration(cfg.ACARS.DedupWindow)*time.Second, acarsDedupCapacity),
 		rangeIdx:         rangeIdx,
 		rangeOptions:     rangeOptions,
+		customRange:      rangeOptions[rangeIdx] != initialRange,
 		maxRange:         maxRange,
 		targetRange:      maxRange,
+		splitRangeIdx:    splitRangeIndex(rangeOptions, cfg.Display.SplitRange),
 		sweepAngle:       0,
 		blink:            false,
 		frame:            0,
-		spinners:         []string{"◐", "◓", "◑", "◒"},
+		now:              time.Now,
 		vuLeft:           0,
 		vuRight:          0,
 		spectrum:         make([]float64, spectrumBins),
@@ -236,13 +411,52 @@ func NewModelWithAuth(cfg *config.Config, authMgr *auth.Manager) *Model {
 		viewMode:         ViewRadar,
 		config:           cfg,
 		theme:            t,
+		text:             i18n.English(),
+		locale:           i18n.ParseLocale(cfg.Display.Locale),
 		overlayManager:   overlayMgr,
-		trailTracker:     trails.NewTrailTracker(),
-		alertPlayer:      audio.NewAlertPlayer(&cfg.Audio),
+		overlayLoader:    geo.LoadOverlay,
+		overlaySums:      make(map[string]loadedOverlay),
+		trailTracker:     newTrailTracker(cfg),
+		turnTracker:      trails.NewTurnTracker(),
+		conflictTracker:  trails.NewConflictTracker(conflictSettings(cfg)),
+		alertPlayer:      alertPlayerFor(cfg, caps),
 		alertedAircraft:  make(map[string]bool),
-		alertState:       NewAlertState(cfg),
-		wsClient:         wsClient,
-	}
+		emergencyActive:  make(map[string]string),
+		departed:         make(map[string]departedTrack),
+		sightings:        make(map[string]*sighting),
+		latency:          newLatencyHistogram(),
+		alertState:       newAlertState(cfg, caps.Alerts),
+		clipboard:        newClipboard(),
+		feed:             feed,
+		caps:             caps,
+	}
+	m.alertState.Turns = m.turnTracker
+	if pb, ok := m.replaying(); ok {
+		m.now = pb.Now
+	}
+	m.queueOverlays()
+	m.updateFieldElevation()
+	m.splitRange = float64(rangeOptions[m.splitRangeIdx])
+	m.splitTargetRange = m.splitRange
+	m.updateOverlayWindows()
+	m.rebuildRegions()
+	m.trailTracker.SetClock(func() time.Time { return m.now() })
+	m.applySquawkCodes()
+	m.applyAltitudeBounds()
+	m.applyStatusBar()
+	m.openDebugLog()
+	m.loadTypeDatabase()
+	m.applyQuietHours()
+	m.prepareRuleSounds()
+
+	// Say so when a damaged settings file was set aside
+	if p := cfg.LoadProblem(); p != nil && p.Restored {
+		m.notify(m.tr("notify.settings_restored"))
+	} else if p != nil {
+		m.notify(m.tr("notify.settings_damaged"))
+	}
+	m.reportSkipped()
+	return m
 }
 
 // SetAudioEnabled enables or disables audio alerts
@@ -254,76 +468,96 @@ func (m *Model) SetAudioEnabled(enabled bool) {
 
 // Init initializes the application
 func (m *Model) Init() tea.Cmd {
-	// Start WebSocket client
-	m.wsClient.Start()
+	if m.feed == nil {
+		return tea.Batch(tickCmd(), m.nextOverlayCmd(), m.outputChannelsCmd())
+	}
+	m.feed.Start()
+	m.connectStarted = m.now()
 
 	return tea.Batch(
 		tickCmd(),
-		aircraftMsgCmd(m.wsClient),
-		acarsMsgCmd(m.wsClient),
+		aircraftMsgCmd(m.feed),
+		acarsMsgCmd(m.feed),
+		feedStateCmd(m.feed),
+		m.nextOverlayCmd(),
+		m.outputChannelsCmd(),
 	)
 }
 
 // tickMsg is sent on each animation tick
 type tickMsg time.Time
 
-// aircraftMsg contains aircraft data
-type aircraftMsg ws.Message
-
-// acarsMsg contains ACARS data
-type acarsMsg ws.Message
+// tickInterval is the time between animation ticks
+const tickInterval = 150 * time.Millisecond
 
 func tickCmd() tea.Cmd {
-	return tea.Tick(150*time.Millisecond, func(t time.Time) tea.Msg {
+	return tea.Tick(tickInterval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
-func aircraftMsgCmd(client *ws.Client) tea.Cmd {
-	return func() tea.Msg {
-		select {
-		case msg := <-client.AircraftMessages():
-			return aircraftMsg(msg)
-		case <-client.Done():
-			// Client stopped; exit so the goroutine doesn't leak
-			return nil
-		}
-	}
-}
-
-func acarsMsgCmd(client *ws.Client) tea.Cmd {
-	return func() tea.Msg {
-		select {
-		case msg := <-client.ACARSMessages():
-			return acarsMsg(msg)
-		case <-client.Done():
-			// Client stopped; exit so the goroutine doesn't leak
-			return nil
-		}
-	}
-}
-
 // Update handles messages and updates state
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
+		if !m.fixedSize {
+			m.width = msg.Width
+			m.height = msg.Height
+		}
 		return m, nil
 
 	case tea.KeyMsg:
 		return m.handleKey(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
+	case tea.ResumeMsg:
+		return m.resume()
+
 	case tickMsg:
 		return m.handleTick()
 
+	case outputChannelsMsg:
+		m.alertPlayer.SetChannels(int(msg))
+		return m, nil
+
 	case aircraftMsg:
-		m.handleAircraftMsg(ws.Message(msg))
-		return m, aircraftMsgCmd(m.wsClient)
+		m.handleAircraftMsg(codec.Message(msg))
+		return m, tea.Batch(aircraftMsgCmd(m.feed), m.ackCmd())
 
 	case acarsMsg:
-		m.handleACARSMsg(ws.Message(msg))
-		return m, acarsMsgCmd(m.wsClient)
+		m.handleACARSMsg(codec.Message(msg))
+		return m, tea.Batch(acarsMsgCmd(m.feed), m.ackCmd())
+
+	case feedStateMsg:
+		m.handleFeedState(msg)
+		return m, feedStateCmd(m.feed)
+
+	case clipboardMsg:
+		m.handleClipboardMsg(msg)
+		return m, nil
+
+	case overlayLoadedMsg:
+		return m, m.handleOverlayLoaded(msg)
+
+	case searchDebounceMsg:
+		m.handleSearchDebounce(msg)
+		return m, nil
+
+	case hoverMsg:
+		// Redraw once the pointer has rested long enough for a data block
+		return m, nil
+
+	case authRefreshMsg:
+		return m, m.handleAuthRefresh(msg)
+
+	case authPromptMsg:
+		return m, m.handleAuthPrompt(msg)
+
+	case authLoginMsg:
+		m.handleAuthLogin(msg)
+		return m, nil
 	}
 
 	return m, nil
@@ -331,34 +565,90 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
+	m.noteInput()
 
-	// Global quit (only when not in search mode)
-	if m.viewMode != ViewSearch && (key == "q" || key == "Q" || key == "ctrl+c") {
-		m.wsClient.Stop()
-		_ = config.Save(m.config)
-		return m, tea.Quit
+	// Kiosk mode locks keys that change anything lasting
+	if m.kioskBlocks(key) {
+		return m, nil
+	}
+
+	// Suspend to the shell from any view
+	if key == "ctrl+z" {
+		return m.suspend()
+	}
+
+	// The connection error screen takes over the keyboard
+	if m.connectFailure != nil {
+		return m.handleConnectFailureKey(key)
+	}
+
+	// The custom range prompt takes everything but quit
+	if m.rangeEntryOpen && key != "ctrl+c" {
+		m.handleRangeEntryKey(key)
+		return m, nil
+	}
+
+	// A rule being made from a template takes everything but quit
+	if m.ruleDraft != nil && m.viewMode == ViewAlertRules && key != "ctrl+c" {
+		m.handleRuleDraftKey(key)
+		return m, nil
+	}
+
+	// So does a rule being written or edited
+	if m.ruleEditor != nil && m.viewMode == ViewAlertRules && key != "ctrl+c" {
+		m.handleRuleEditorKey(key)
+		return m, nil
+	}
+
+	// And the radius of a circle geofence
+	if m.fenceDraft != nil && m.viewMode == ViewGeofences && key != "ctrl+c" {
+		m.handleFenceDraftKey(key)
+		return m, nil
+	}
+
+	// Global quit (only when not in search mode, and not while Q is part of
+	// a callsign being typed)
+	if m.viewMode != ViewSearch && (key == "q" || key == "Q" || key == "ctrl+c") &&
+		!(m.viewMode == ViewRadar && m.jumpActive() && key != "ctrl+c") {
+		return m, m.quit()
 	}
 
 	// Handle ctrl+c in search mode
 	if m.viewMode == ViewSearch && key == "ctrl+c" {
-		m.wsClient.Stop()
-		_ = config.Save(m.config)
-		return m, tea.Quit
+		return m, m.quit()
 	}
 
 	switch m.viewMode {
 	case ViewSettings:
 		return m.handleSettingsKey(key)
-	case ViewHelp:
+	case ViewHelp, ViewWhatsNew, ViewNotices:
 		m.viewMode = ViewRadar
 		return m, nil
+	case ViewNotice:
+		m.handleNoticeKey(key)
+		return m, nil
 	case ViewOverlays:
 		return m.handleOverlaysKey(key)
+	case ViewLogin:
+		m.handleLoginKey(key)
+		return m, nil
 	case ViewSearch:
 		return m.handleSearchKey(msg)
 	case ViewAlertRules:
 		m.handleAlertRulesKey(key)
 		return m, nil
+	case ViewAway:
+		m.handleAwayKey(key)
+		return m, nil
+	case ViewAircraftDetail:
+		m.handleDetailKey(key)
+		return m, nil
+	case ViewGeofences:
+		m.handleGeofencesKey(key)
+		return m, nil
+	case ViewStatistics:
+		m.handleStatisticsKey(key)
+		return m, nil
 	default:
 		return m.handleRadarKey(key)
 	}
@@ -366,6 +656,13 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 //nolint:gocyclo // Large switch statement for keyboard handling
 func (m *Model) handleRadarKey(key string) (tea.Model, tea.Cmd) {
+	if m.continueExportRetry(key) {
+		return m, nil
+	}
+	if m.continueJump(key) {
+		return m, nil
+	}
+
 	switch key {
 	case "up", "k":
 		m.selectPrev()
@@ -375,40 +672,56 @@ func (m *Model) handleRadarKey(key string) (tea.Model, tea.Cmd) {
 		m.zoomOut()
 	case "-", "_":
 		m.zoomIn()
-	case "l", "L":
+	case "n", "N":
+		m.openRangeEntry()
+	case "L":
+		m.cycleLabelDetail()
+	case "l":
 		m.config.Display.ShowLabels = !m.config.Display.ShowLabels
 		if m.config.Display.ShowLabels {
-			m.notify("Labels: ON")
+			m.notify(m.tr("notify.labels_on"))
 		} else {
-			m.notify("Labels: OFF")
+			m.notify(m.tr("notify.labels_off"))
 		}
 	case "m", "M":
 		m.config.Filters.MilitaryOnly = !m.config.Filters.MilitaryOnly
 		if m.config.Filters.MilitaryOnly {
-			m.notify("Military: ON")
+			m.notify(m.tr("notify.military_on"))
 		} else {
-			m.notify("Military: OFF")
+			m.notify(m.tr("notify.military_off"))
 		}
 	case "g", "G":
 		m.config.Filters.HideGround = !m.config.Filters.HideGround
 		if m.config.Filters.HideGround {
-			m.notify("Ground: HIDE")
+			m.notify(m.tr("notify.ground_hide"))
 		} else {
-			m.notify("Ground: SHOW")
+			m.notify(m.tr("notify.ground_show"))
 		}
 	case "a", "A":
 		m.config.Display.ShowACARS = !m.config.Display.ShowACARS
 	case "v", "V":
-		m.config.Display.ShowVUMeters = !m.config.Display.ShowVUMeters
+		// With a target selected V flips the vertical profile instead
+		if _, ok := m.aircraft[m.selectedHex]; ok && m.selectedHex != "" {
+			m.showProfile = !m.showProfile
+			if m.showProfile {
+				m.notify(m.tr("notify.profile_on"))
+			} else {
+				m.notify(m.tr("notify.profile_off"))
+			}
+		} else {
+			m.config.Display.ShowVUMeters = !m.config.Display.ShowVUMeters
+		}
 	case "s", "S":
 		m.config.Display.ShowSpectrum = !m.config.Display.ShowSpectrum
 	case "b", "B":
 		m.config.Display.ShowTrails = !m.config.Display.ShowTrails
 		if m.config.Display.ShowTrails {
-			m.notify("Trails: ON")
+			m.notify(m.tr("notify.trails_on"))
 		} else {
-			m.notify("Trails: OFF")
+			m.notify(m.tr("notify.trails_off"))
 		}
+	case "ctrl+b":
+		m.cycleTrailStyle()
 	case "r", "R":
 		m.openAlertRulesView()
 	case "t", "T":
@@ -419,26 +732,99 @@ func (m *Model) handleRadarKey(key string) (tea.Model, tea.Cmd) {
 		m.overlayCursor = 0
 	case "?", "h", "H":
 		m.viewMode = ViewHelp
+	case "ctrl+n":
+		m.viewMode = ViewNotices
+	case "ctrl+w":
+		m.openAway()
 	case "/":
 		m.enterSearchMode()
 	case "f1":
 		m.applyFilterPreset(search.PresetAllAircraft())
-		m.notify("Filter: ALL")
+		m.notify(m.tr("notify.filter_all"))
 	case "f2":
 		m.applyFilterPreset(search.PresetMilitaryOnly())
-		m.notify("Filter: MILITARY")
+		m.notify(m.tr("notify.filter_military"))
 	case "f3":
 		m.applyFilterPreset(search.PresetEmergencies())
-		m.notify("Filter: EMERGENCY")
+		m.notify(m.tr("notify.filter_emergency"))
 	case "f4":
 		m.applyFilterPreset(search.PresetLowAltitude())
-		m.notify("Filter: LOW ALT")
+		m.notify(m.tr("notify.filter_low_alt"))
 	case "p", "P":
 		m.exportScreenshot()
 	case "e", "E":
 		m.exportAircraftCSV()
 	case "ctrl+e":
 		m.exportAircraftJSON()
+	case "ctrl+p":
+		m.exportAircraftGeoJSON()
+	case "ctrl+r":
+		m.exportSignalReport()
+	case "ctrl+d":
+		m.exportDebugState()
+	case "y", "Y":
+		return m, m.yankListCmd()
+	case "i":
+		m.openStatistics()
+	case "I":
+		m.togglePrivacy()
+	case "ctrl+u":
+		m.toggleHeadingUp()
+	case "ctrl+l":
+		m.cycleDistUnits()
+	case "ctrl+a":
+		m.toggleAltitudeColors()
+	case "w":
+		m.toggleWatchlist()
+	case "x", "X":
+		m.cyclePOI()
+	case "ctrl+t":
+		m.togglePOISort()
+	case "ctrl+g":
+		m.cycleSurfaceMode()
+	case "ctrl+o":
+		m.cycleDensityMode()
+	case "ctrl+q":
+		m.toggleQuickLook()
+	case "ctrl+f":
+		m.openDetail()
+	case " ":
+		m.togglePlayback()
+	case "left":
+		m.seekPlayback(-replaySeekStep)
+	case "right":
+		m.seekPlayback(replaySeekStep)
+	case "z", "Z":
+		m.toggleAltitudeRibbon()
+	case "|":
+		m.toggleSplit()
+	case "tab":
+		m.switchPane()
+	case "c", "C":
+		m.toggleSplitCenter()
+	case "F":
+		m.toggleFollow()
+	case "shift+up":
+		m.pan(0)
+	case "shift+right":
+		m.pan(90)
+	case "shift+down":
+		m.pan(180)
+	case "shift+left":
+		m.pan(270)
+	case "home":
+		m.recenter()
+	case "d", "D":
+		m.cycleDoNotDisturb()
+	case "u", "U":
+		return m, m.renewAuth()
+	case keyEnter:
+		m.togglePin()
+	case "ctrl+j":
+		// Terminals report ctrl+enter as a line feed (ctrl+j)
+		m.clearPins()
+	default:
+		m.startJump(key)
 	}
 	return m, nil
 }
@@ -455,6 +841,8 @@ func (m *Model) handleSettingsKey(key string) (tea.Model, tea.Cmd) {
 		m.settingsCursor = (m.settingsCursor + 1) % len(themes)
 	case keyEnter, " ":
 		m.setTheme(themes[m.settingsCursor])
+	case "c":
+		m.toggleColorblind()
 	}
 	return m, nil
 }
@@ -477,21 +865,35 @@ func (m *Model) handleOverlaysKey(key string) (tea.Model, tea.Cmd) {
 		if len(overlays) > 0 {
 			enabled := m.overlayManager.ToggleOverlay(overlays[m.overlayCursor].Key)
 			if enabled {
-				m.notify("Overlay: ON")
+				m.notify(m.tr("notify.overlay_on"))
 			} else {
-				m.notify("Overlay: OFF")
+				m.notify(m.tr("notify.overlay_off"))
 			}
 			m.saveOverlays()
 		}
+	case "+", "=", "-", "_":
+		if len(overlays) > 0 {
+			brighter := key == "+" || key == "="
+			level := m.overlayManager.AdjustOverlayBrightness(overlays[m.overlayCursor].Key, brighter)
+			m.notify(m.trf("notify.overlay_brightness", strings.ToUpper(string(level))))
+			m.saveOverlays()
+		}
 	case "d", "D":
 		if len(overlays) > 0 {
 			m.overlayManager.RemoveOverlay(overlays[m.overlayCursor].Key)
+			m.forgetOverlaySum(overlays[m.overlayCursor].Key)
+			m.updateFieldElevation()
 			if m.overlayCursor >= len(overlays)-1 && m.overlayCursor > 0 {
 				m.overlayCursor--
 			}
-			m.notify("Overlay removed")
+			m.rebuildRegions()
+			m.notify(m.tr("notify.overlay_removed"))
 			m.saveOverlays()
 		}
+	case "g", "G":
+		if len(overlays) > 0 {
+			m.toggleRegionTagging(overlays[m.overlayCursor].Key)
+		}
 	}
 	return m, nil
 }
@@ -502,31 +904,41 @@ func (m *Model) handleTick() (tea.Model, tea.Cmd) {
 	m.blink = !m.blink
 	m.frame++
 
-	// Ease the scope range toward the selected range so zoom glides
-	// instead of snapping (exponential smoothing, snap when close).
-	if m.maxRange != m.targetRange {
-		const zoomEase = 0.35
-		m.maxRange += (m.targetRange - m.maxRange) * zoomEase
-		if math.Abs(m.targetRange-m.maxRange) < 0.5 {
-			m.maxRange = m.targetRange
-		}
-	}
+	// Give up waiting on the first connection after the timeout
+	m.checkConnection()
+	m.watchFeedState()
+	m.checkDataBudget()
+	m.updateOverlayWindows()
+	m.expireLostSelection()
+	m.checkKioskIdle()
+	m.sampleMessageRate()
 
-	// Update VU meters based on real signal activity
-	m.updateVUMeters()
+	// Ease each scope range toward its selected range so zoom glides
+	// instead of snapping
+	m.maxRange = easeRange(m.maxRange, m.targetRange)
+	m.splitRange = easeRange(m.splitRange, m.splitTargetRange)
 
-	// Update spectrum from real aircraft data
-	m.updateSpectrum()
+	// Follow the selected target's track in heading-up mode
+	m.updateRotation()
+
+	// Update VU meters and spectrum from real aircraft data, unless the
+	// feed is too big to spend the time
+	if !m.shedding {
+		m.updateVUMeters()
+		m.updateSpectrum()
+	}
 
 	// Update stats
 	m.updateStats()
 
-	// Cleanup stale trails periodically (every ~30 seconds, 200 frames at 150ms)
-	if m.frame%200 == 0 {
-		m.trailTracker.Cleanup()
-		if m.alertState != nil {
-			m.alertState.Cleanup()
-		}
+	// Purge stale aircraft, trails and alert data on a wall-clock interval
+	m.maybeCleanup()
+	m.maybePruneTrails()
+	m.publishSnapshot()
+
+	// The typed callsign prefix lapses after a second without a key
+	if m.jumpPrefix != "" && !m.jumpActive() {
+		m.jumpPrefix = ""
 	}
 
 	// Notification timer
@@ -540,79 +952,155 @@ func (m *Model) handleTick() (tea.Model, tea.Cmd) {
 	return m, tickCmd()
 }
 
-func (m *Model) handleAircraftMsg(msg ws.Message) {
+func (m *Model) handleAircraftMsg(msg codec.Message) {
+	m.observeMessageTime(msg)
+	m.source = msg.Source
+	defer func() { m.source = "" }()
 	switch msg.Type {
-	case string(ws.AircraftSnapshot):
-		aircraft, err := ws.ParseAircraftSnapshot(msg.Data)
-		if err == nil {
-			// Snapshot is authoritative: aircraft:remove events missed
-			// during a disconnect must not leave ghost targets behind.
-			seen := make(map[string]bool, len(aircraft))
-			for _, ac := range aircraft {
-				m.updateTarget(&ac, false)
-				seen[ac.Hex] = true
-			}
-			for hex := range m.aircraft {
-				if !seen[hex] {
-					delete(m.aircraft, hex)
-					delete(m.alertedAircraft, hex)
-				}
-			}
+	case string(codec.FeedReconnected):
+		m.beginResync()
+	case string(codec.Notice), string(codec.Broadcast):
+		m.handleNotice(msg)
+	case string(codec.AircraftSnapshot):
+		m.applySnapshot(msg, msg.Data)
+	case string(codec.Response):
+		// The answer to the snapshot request sent on reconnecting
+		resp, err := codec.ParseResponse(msg.Data)
+		if err != nil {
+			m.noteParseError(msg, err)
+		} else if resp.RequestType == codec.SnapshotRequest {
+			m.applySnapshot(msg, resp.Data)
 		}
-	case string(ws.AircraftNew):
-		ac, err := ws.ParseAircraft(msg.Data)
-		if err == nil {
+	case string(codec.AircraftNew):
+		ac, err := codec.ParseAircraft(msg.Data)
+		if err != nil {
+			m.noteParseError(msg, err)
+		} else {
 			m.updateTarget(ac, true)
-			m.sessionMessages++
+			m.logSession(msg.Type, ac)
+			m.observeLatency(ac)
+			m.countMessage()
 		}
-	case string(ws.AircraftUpdate):
-		ac, err := ws.ParseAircraft(msg.Data)
-		if err == nil {
+	case string(codec.AircraftUpdate):
+		ac, err := codec.ParseAircraft(msg.Data)
+		if err != nil {
+			m.noteParseError(msg, err)
+		} else {
 			m.updateTarget(ac, false)
-			m.sessionMessages++
+			m.logSession(msg.Type, ac)
+			m.observeLatency(ac)
+			m.countMessage()
 		}
-	case string(ws.AircraftRemove):
-		ac, err := ws.ParseAircraft(msg.Data)
-		if err == nil && ac.Hex != "" {
-			delete(m.aircraft, ac.Hex)
-			delete(m.alertedAircraft, ac.Hex)
+	case string(codec.AircraftRemove):
+		ac, err := codec.ParseAircraft(msg.Data)
+		if err != nil {
+			m.noteParseError(msg, err)
+		} else {
+			// With merged feeds the aircraft stays while another
+			// receiver still sees it
+			ac.Hex = m.canonicalHex(ac.Hex)
+			if m.releaseReceiver(ac.Hex) {
+				m.removeAircraft(ac.Hex)
+			}
+			m.logSession(msg.Type, ac)
+		}
+	}
+	m.trackFollowed()
+}
+
+// applySnapshot replaces the tracked aircraft with a snapshot's, data
+// being the snapshot carried by msg
+func (m *Model) applySnapshot(msg codec.Message, data json.RawMessage) {
+	aircraft, err := codec.ParseSnapshot(data)
+	if err != nil {
+		m.noteParseError(msg, err)
+		return
+	}
+	// Snapshot is authoritative: aircraft:remove events missed
+	// during a disconnect must not leave ghost targets behind. With
+	// merged feeds it speaks only for its own receiver.
+	seen := make(map[string]bool, len(aircraft))
+	for _, ac := range aircraft {
+		m.updateTarget(&ac, false)
+		m.logSession(string(codec.AircraftSnapshot), &ac)
+		seen[ac.Hex] = true
+	}
+	for hex := range m.shed {
+		if !seen[hex] {
+			m.forgetShed(hex)
+		}
+	}
+	stale := 0
+	for hex := range m.aircraft {
+		if !seen[hex] && m.releaseReceiver(hex) {
+			if m.resyncPending[hex] {
+				stale++
+			}
+			m.removeAircraft(hex)
 		}
 	}
+	m.finishResync(stale)
 }
 
-func (m *Model) handleACARSMsg(msg ws.Message) {
+func (m *Model) handleACARSMsg(msg codec.Message) {
+	m.observeMessageTime(msg)
+	sent, _ := msg.Time()
 	switch msg.Type {
-	case string(ws.ACARSMessage), string(ws.ACARSSnapshot):
-		acarsData, err := ws.ParseACARSData(msg.Data)
+	case string(codec.Notice), string(codec.Broadcast):
+		m.handleNotice(msg)
+	case string(codec.ACARSMessage), string(codec.ACARSSnapshot):
+		acarsData, err := codec.ParseACARS(msg.Data)
 		if err == nil {
+			now := m.now()
 			for _, data := range acarsData {
+				if m.acarsDedup.isDuplicate(data, now) {
+					m.acarsDuplicates++
+					continue
+				}
 				acars := ACARSMessage{
 					Callsign: data.Callsign,
 					Flight:   data.Flight,
 					Label:    data.Label,
 					Text:     data.Text,
+					Received: now,
+					Sent:     sent,
 				}
 				m.acarsMessages = append(m.acarsMessages, acars)
-				if len(m.acarsMessages) > 100 {
-					m.acarsMessages = m.acarsMessages[1:]
+				sender := m.acarsSender(&acars)
+				if m.onEvent != nil {
+					m.emit(Event{Type: EventACARS, Target: sender, ACARS: &acars})
 				}
+				m.checkACARSAlerts(&acars, sender)
+			}
+			if limit := acarsRetention(m.config); len(m.acarsMessages) > limit {
+				m.acarsMessages = m.acarsMessages[len(m.acarsMessages)-limit:]
 			}
 		}
 	}
 }
 
-func (m *Model) updateTarget(ac *ws.Aircraft, isNew bool) {
+func (m *Model) updateTarget(ac *codec.Aircraft, isNew bool) {
+	ac.Hex = m.canonicalHex(ac.Hex)
 	if ac.Hex == "" {
 		return
 	}
+	delete(m.resyncPending, ac.Hex)
+	m.noteBadFields(ac)
 
 	target := &radar.Target{
 		Hex:      ac.Hex,
 		Callsign: strings.TrimSpace(ac.Flight),
 		Squawk:   ac.Squawk,
 		ACType:   ac.Type,
+		Category: strings.ToUpper(strings.TrimSpace(ac.Category)),
+		Reg:      strings.TrimSpace(ac.Reg),
+		Ground:   ac.OnGround,
 		Military: ac.Military,
+
+		SquawkCodes: m.squawkCodes,
 	}
+	target.OnWatchlist = m.onWatchlist(target)
+	m.describeType(target)
 
 	if ac.Lat != nil {
 		target.Lat = *ac.Lat
@@ -622,13 +1110,7 @@ func (m *Model) updateTarget(ac *ws.Aircraft, isNew bool) {
 		target.Lon = *ac.Lon
 		target.HasLon = true
 	}
-	if ac.AltBaro != nil {
-		target.Altitude = *ac.AltBaro
-		target.HasAlt = true
-	} else if ac.Alt != nil {
-		target.Altitude = *ac.Alt
-		target.HasAlt = true
-	}
+	m.setAltitudes(target, ac)
 	if ac.GS != nil {
 		target.Speed = *ac.GS
 		target.HasSpeed = true
@@ -648,13 +1130,26 @@ func (m *Model) updateTarget(ac *ws.Aircraft, isNew bool) {
 		target.RSSI = *ac.RSSI
 		target.HasRSSI = true
 	}
+	if ac.NavAltitude != nil {
+		target.NavAltitude = *ac.NavAltitude
+		target.HasNavAlt = true
+	} else if ac.NavAltitudeFMS != nil {
+		target.NavAltitude = *ac.NavAltitudeFMS
+		target.HasNavAlt = true
+	}
+	if ac.NavHeading != nil {
+		target.NavHeading = *ac.NavHeading
+		target.HasNavHeading = true
+	}
+	if ac.NavQNH != nil {
+		target.NavQNH = *ac.NavQNH
+		target.HasNavQNH = true
+	}
+	target.NavModes = ac.NavModes
 
 	// Calculate distance and bearing if we have position
-	if target.HasLat && target.HasLon && (m.config.Connection.ReceiverLat != 0 || m.config.Connection.ReceiverLon != 0) {
-		target.Distance, target.Bearing = radar.HaversineBearing(
-			m.config.Connection.ReceiverLat, m.config.Connection.ReceiverLon,
-			target.Lat, target.Lon,
-		)
+	if lat, lon, ok := m.receiverPosition(); ok && target.HasLat && target.HasLon {
+		target.Distance, target.Bearing = radar.HaversineBearing(lat, lon, target.Lat, target.Lon)
 	} else if ac.Distance != nil {
 		target.Distance = *ac.Distance
 	}
@@ -662,18 +1157,61 @@ func (m *Model) updateTarget(ac *ws.Aircraft, isNew bool) {
 		target.Bearing = *ac.Bearing
 	}
 
+	// Altitudes that can't be real, often MLAT noise, are dropped or flagged
+	if !m.checkAltitude(target) {
+		return
+	}
+
 	// Snapshot the previous state before overwriting so alert rules can
 	// compare against it (e.g. geofence entry detection)
 	prev := m.aircraft[ac.Hex]
+	if prev == nil && !m.admit(target) {
+		return
+	}
+	// A position implying an impossible speed, usually a corrupt decode,
+	// gives way to the last good one; the rest of the update stands
+	dropped := m.checkPosition(target, prev)
+	m.mergeReceiver(target, prev)
+	if prev != nil {
+		target.Signal = prev.Signal
+	} else {
+		m.startTracking(target)
+	}
+	target.SmoothVS(prev)
+	if target.HasRSSI {
+		target.Signal.Add(target.RSSI)
+	}
 	m.aircraft[ac.Hex] = target
-
-	// Update trail tracker if we have a valid position
-	if target.HasLat && target.HasLon {
-		m.trailTracker.AddPosition(ac.Hex, target.Lat, target.Lon)
+	m.recordSector(target)
+	m.lastSeen[ac.Hex] = m.now()
+	m.recordHistory(target)
+	m.recordEmergency(target)
+
+	// Update trail tracker if we have a valid position, leaving out jumps
+	// from a second aircraft sharing the address and dropped positions
+	flown := m.trailTracker.Flown(ac.Hex)
+	m.trackPosition(target, dropped)
+	continueTracking(target, prev, m.trailTracker.Flown(ac.Hex)-flown)
+	m.locateRegion(target, prev)
+	if target.HasTrack {
+		m.turnTracker.AddTrack(ac.Hex, target.Track, m.now())
+	}
+
+	if prev == nil || search.SearchableChanged(prev, target) {
+		m.searcher.Reset()
+	}
+	if prev == nil {
+		m.reacquireSelection(target)
+		m.emit(Event{Type: EventNew, Target: target})
+	} else {
+		m.emit(Event{Type: EventUpdate, Target: target})
 	}
 
 	// Trigger audio alerts
 	m.triggerAudioAlerts(target, prev, isNew)
+	m.recordSighting(target)
+
+	m.enforceAircraftCap()
 }
 
 // triggerAudioAlerts checks if audio alerts should be triggered for this aircraft
@@ -684,17 +1222,15 @@ func (m *Model) triggerAudioAlerts(target, prev *radar.Target, isNew bool) {
 
 	// Play new aircraft sound for genuinely new aircraft
 	if isNew && !m.alertedAircraft[target.Hex] {
-		m.alertPlayer.PlayNewAircraft()
+		m.alertPlayer.PlayNewAircraftFrom(alertSource(target))
 	}
 
-	// Check for emergency squawk
-	if target.IsEmergency() {
-		m.alertPlayer.PlayEmergency()
-	}
+	// Check for emergency and warning squawks
+	m.playSquawkAlert(target)
 
 	// Check for military aircraft (first time seen)
 	if target.Military && !m.alertedAircraft[target.Hex] {
-		m.alertPlayer.PlayMilitary()
+		m.alertPlayer.PlayMilitaryFrom(alertSource(target))
 	}
 
 	// Mark this aircraft as alerted
@@ -704,6 +1240,15 @@ func (m *Model) triggerAudioAlerts(target, prev *radar.Target, isNew bool) {
 	m.checkAlertRules(target, prev)
 }
 
+// alertSource locates a target for its alert sounds; nil is nowhere. The
+// bearing is only known alongside a distance.
+func alertSource(target *radar.Target) audio.Source {
+	if target == nil {
+		return audio.Source{}
+	}
+	return audio.Source{Distance: target.Distance, Bearing: target.Bearing, HasBearing: target.Distance > 0}
+}
+
 // checkAlertRules checks custom alert rules for this aircraft.
 // prev is the target's previous state (before the current update), used for
 // geofence entry detection; it may be nil for newly seen aircraft.
@@ -716,19 +1261,52 @@ func (m *Model) checkAlertRules(target, prev *radar.Target) {
 	triggered := m.alertState.CheckAircraft(target, prev)
 
 	// Display notifications for triggered alerts
-	for _, alert := range triggered {
+	for i, alert := range triggered {
 		// Show notification
 		m.notify(alert.Message)
+		m.emit(Event{Type: EventAlert, Target: target, Alert: &triggered[i]})
+		if alert.Rule != nil {
+			m.noteAlerted(target, alert.Rule.Name)
+		}
 
-		// Play sound if action specifies
+		// Sounds and bells the rule asks for
 		for _, action := range alert.Actions {
-			if action.Type == "sound" && m.alertPlayer != nil {
-				m.alertPlayer.PlayEmergency()
-			}
+			m.runAlertAction(action, target)
 		}
 	}
 }
 
+// checkACARSAlerts checks an ACARS message against the message alert rules.
+// sender is the tracked aircraft that sent it, or nil.
+func (m *Model) checkACARSAlerts(msg *ACARSMessage, sender *radar.Target) {
+	if m.alertState == nil {
+		return
+	}
+
+	triggered := m.alertState.CheckACARS(msg, sender)
+	for i, alert := range triggered {
+		m.notify(alert.Message)
+		m.emit(Event{Type: EventAlert, Target: sender, ACARS: msg, Alert: &triggered[i]})
+		if alert.Rule != nil {
+			m.noteAlerted(sender, alert.Rule.Name)
+		}
+
+		for _, action := range alert.Actions {
+			m.runAlertAction(action, sender)
+		}
+	}
+}
+
+// signalDataHold is how long the VU meters and spectrum keep showing levels
+// after the last aircraft with RSSI, so brief gaps don't flicker to "n/a"
+const signalDataHold = 3 * time.Second
+
+// hasSignalData reports whether any aircraft has reported RSSI within the
+// hold time
+func (m *Model) hasSignalData() bool {
+	return !m.lastSignalData.IsZero() && m.now().Sub(m.lastSignalData) < signalDataHold
+}
+
 // updateVUMeters updates VU meter values based on aircraft signal data
 func (m *Model) updateVUMeters() {
 	// Calculate average RSSI from all aircraft with signal data
@@ -749,6 +1327,7 @@ func (m *Model) updateVUMeters() {
 	// Normalize RSSI to 0-1 range (typical RSSI: -30 to 0 dBm)
 	var leftTarget, rightTarget float64
 	if rssiCount > 0 {
+		m.lastSignalData = m.now()
 		avgRSSI := totalRSSI / float64(rssiCount)
 		// Normalize: -30 dBm = 0.0, 0 dBm = 1.0
 		leftTarget = (avgRSSI + 30) / 30.0
@@ -778,6 +1357,7 @@ func (m *Model) updateVUMeters() {
 func (m *Model) updateSpectrum() {
 	// Rebuild the per-tick aircraft data while preserving the analyzer's
 	// temporal smoothing and peak-hold state (Reset() would wipe them).
+	m.syncSpectrumBands()
 	m.spectrumAnalyzer.ResetSamples()
 
 	// Add all aircraft with RSSI and distance data
@@ -786,6 +1366,7 @@ func (m *Model) updateSpectrum() {
 			rssi := float64(-20) // Default RSSI if not available
 			if t.HasRSSI {
 				rssi = t.RSSI
+				m.lastSignalData = m.now()
 			}
 			m.spectrumAnalyzer.AddAircraft(hex, rssi, t.Distance)
 		}
@@ -814,14 +1395,19 @@ func (m *Model) updateStats() {
 
 	m.militaryCount = 0
 	m.emergencyCount = 0
+	m.surfaceCount = 0
 	for _, t := range m.aircraft {
 		if t.Military {
 			m.militaryCount++
 		}
+		if m.onGround(t) {
+			m.surfaceCount++
+		}
 		if t.IsEmergency() {
 			m.emergencyCount++
 		}
 	}
+	m.sampleStats()
 }
 
 func (m *Model) selectNext() {
@@ -858,56 +1444,191 @@ func (m *Model) selectPrev() {
 	m.selectedHex = m.sortedTargets[len(m.sortedTargets)-1]
 }
 
+// easeRange moves a scope range toward its target (exponential smoothing,
+// snapping when close)
+func easeRange(current, target float64) float64 {
+	if current == target {
+		return current
+	}
+	const zoomEase = 0.35
+	current += (target - current) * zoomEase
+	if math.Abs(target-current) < 0.5 {
+		return target
+	}
+	return current
+}
+
 func (m *Model) zoomIn() {
+	if m.splitFocused() {
+		m.zoomSplit(-1)
+		return
+	}
+	if m.customRange {
+		// Step down from between steps to the one below
+		for i := len(m.rangeOptions) - 1; i >= 0; i-- {
+			if float64(m.rangeOptions[i]) < m.targetRange {
+				m.setRangeStep(i)
+				return
+			}
+		}
+		return
+	}
 	if m.rangeIdx > 0 {
-		m.rangeIdx--
-		m.targetRange = float64(m.rangeOptions[m.rangeIdx])
-		m.notify("Range: " + itoa(int(m.targetRange)) + "nm")
+		m.setRangeStep(m.rangeIdx - 1)
 	}
 }
 
 func (m *Model) zoomOut() {
+	if m.splitFocused() {
+		m.zoomSplit(1)
+		return
+	}
+	if m.customRange {
+		for i, r := range m.rangeOptions {
+			if float64(r) > m.targetRange {
+				m.setRangeStep(i)
+				return
+			}
+		}
+		return
+	}
 	if m.rangeIdx < len(m.rangeOptions)-1 {
-		m.rangeIdx++
-		m.targetRange = float64(m.rangeOptions[m.rangeIdx])
-		m.notify("Range: " + itoa(int(m.targetRange)) + "nm")
+		m.setRangeStep(m.rangeIdx + 1)
 	}
 }
 
+// cycleLabelDetail steps what radar labels show: none, callsign, then
+// altitude and speed as well. Labels turned off with L come back on.
+func (m *Model) cycleLabelDetail() {
+	next := radar.ParseLabelDetail(m.config.Display.LabelDetail).Next()
+	if !m.config.Display.ShowLabels {
+		m.config.Display.ShowLabels = true
+		if next == radar.LabelNone {
+			next = next.Next()
+		}
+	}
+	m.config.Display.LabelDetail = string(next)
+	m.notify(m.trf("notify.label_detail", next.Description()))
+}
+
 func (m *Model) setTheme(name string) {
-	m.theme = theme.Get(name)
+	m.theme = theme.Get(name).WithGlyphs(m.config.Display.GlyphSet).WithCVD(m.config.Display.ColorblindSafe)
 	m.config.Display.Theme = name
-	_ = config.Save(m.config)
-	m.notify("Theme: " + m.theme.Name)
+	m.saveConfig()
+	m.notify(m.trf("notify.theme", m.theme.Name))
+}
+
+// toggleColorblind switches the colorblind-safe palette and shape cues on
+// or off over the current theme
+func (m *Model) toggleColorblind() {
+	m.config.Display.ColorblindSafe = !m.config.Display.ColorblindSafe
+	m.theme = theme.Get(m.config.Display.Theme).WithGlyphs(m.config.Display.GlyphSet).WithCVD(m.config.Display.ColorblindSafe)
+	m.saveConfig()
+	if m.config.Display.ColorblindSafe {
+		m.notify(m.tr("notify.colorblind_on"))
+	} else {
+		m.notify(m.tr("notify.colorblind_off"))
+	}
+}
+
+// glyphs returns the characters the radar is drawn with
+func (m *Model) glyphs() *theme.GlyphSet {
+	return m.theme.GlyphSet()
 }
 
 func (m *Model) notify(message string) {
+	m.notifyFor(message, 3.0)
+}
+
+// notifyFor shows message in the status bar for the given seconds
+func (m *Model) notifyFor(message string, seconds float64) {
 	m.notification = message
-	m.notificationTime = 3.0
+	m.notificationTime = seconds
+}
+
+// overlayBrightness returns the configured level for an overlay, falling back
+// to the global default for overlays added without one
+func overlayBrightness(cfg *config.Config, ov config.OverlayConfig) geo.Brightness {
+	if ov.Brightness != "" {
+		return geo.ParseBrightness(ov.Brightness)
+	}
+	return geo.ParseBrightness(cfg.Overlays.DefaultBrightness)
 }
 
 func (m *Model) saveOverlays() {
 	overlayConfigs := m.overlayManager.ToConfig()
-	m.config.Overlays.Overlays = make([]config.OverlayConfig, len(overlayConfigs))
-	for i, ov := range overlayConfigs {
+	m.config.Overlays.Overlays = make([]config.OverlayConfig, 0, len(overlayConfigs))
+	for _, ov := range overlayConfigs {
 		path, _ := ov["source_file"].(string)
+		if path == "" {
+			// Generated overlays such as POI markers are rebuilt at startup
+			continue
+		}
 		enabled, _ := ov["enabled"].(bool)
 		key, _ := ov["key"].(string)
-		m.config.Overlays.Overlays[i] = config.OverlayConfig{
-			Path:    path,
-			Enabled: enabled,
-			Key:     key,
+		brightness, _ := ov["brightness"].(string)
+		regionTagging, _ := ov["region_tagging"].(bool)
+		overlay := config.OverlayConfig{
+			Path:          path,
+			Enabled:       enabled,
+			Key:           key,
+			Brightness:    brightness,
+			RegionTagging: regionTagging,
 		}
 		if color, ok := ov["color"].(string); ok && color != "" {
-			m.config.Overlays.Overlays[i].Color = &color
+			overlay.Color = &color
 		}
+		m.config.Overlays.Overlays = append(m.config.Overlays.Overlays, overlay)
 	}
-	_ = config.Save(m.config)
+	m.config.Overlays.Overlays = append(m.config.Overlays.Overlays, m.pendingOverlays()...)
+	m.config.Overlays.Overlays, _ = dedupeOverlays(m.config.Overlays.Overlays)
+	m.saveConfig()
 }
 
 // IsConnected returns true if connected to server
 func (m *Model) IsConnected() bool {
-	return m.wsClient.IsConnected()
+	return m.feed != nil && m.feed.IsConnected()
+}
+
+// Stats is a point-in-time summary of what the radar is tracking
+type Stats struct {
+	Aircraft     int
+	Peak         int
+	Military     int
+	Emergency    int
+	Messages     int
+	ConnMessages int // messages since the feed last (re)connected
+	Shed         int // aircraft in the feed dropped over the aircraft cap
+	ParseErrors  int // feed messages and fields that couldn't be decoded
+}
+
+// IngestAircraftMessage applies an aircraft feed message exactly as the radar
+// would, for headless callers such as the status command
+func (m *Model) IngestAircraftMessage(msg codec.Message) {
+	m.handleAircraftMsg(msg)
+	m.updateStats()
+	m.maybeCleanup()
+}
+
+// RenderFrame advances the animation one tick and renders the view exactly
+// as the radar would, for headless callers such as the bench command
+func (m *Model) RenderFrame() string {
+	m.handleTick()
+	return m.View()
+}
+
+// GetStats returns the current tracking statistics
+func (m *Model) GetStats() Stats {
+	return Stats{
+		Aircraft:     len(m.aircraft),
+		Peak:         m.peakAircraft,
+		Military:     m.militaryCount,
+		Emergency:    m.emergencyCount,
+		Messages:     m.sessionMessages,
+		ConnMessages: m.connMessages,
+		Shed:         len(m.shed),
+		ParseErrors:  m.parseErrors,
+	}
 }
 
 // SetLastRenderedView stores the last rendered view for screenshot exports
@@ -923,52 +1644,102 @@ func (m *Model) GetExportDirectory() string {
 	return ""
 }
 
+// exportOptions returns the optional aircraft export columns from config
+func (m *Model) exportOptions() export.Options {
+	return export.Options{
+		SignalStats: m.config.Export.SignalStats,
+		CoordFormat: geo.ParseCoordFormat(m.config.Display.CoordFormat),
+		Units:       m.distUnit(),
+	}
+}
+
 // exportScreenshot saves the current view as HTML
 func (m *Model) exportScreenshot() {
+	m.exportScreenshotTo(m.GetExportDirectory())
+}
+
+func (m *Model) exportScreenshotTo(dir string) {
 	if m.lastRenderedView == "" {
-		m.notify("No view to export")
+		m.notify(m.tr("notify.no_view"))
 		return
 	}
 
-	filename, err := export.CaptureScreen(m.lastRenderedView, m.GetExportDirectory())
+	filename, err := export.CaptureScreen(m.lastRenderedView, dir)
 	if err != nil {
-		m.notify("Export failed: " + err.Error())
+		m.exportFailed(err, dir, m.exportScreenshotTo)
 		return
 	}
 
-	m.notify("Screenshot: " + filepath.Base(filename))
+	m.notify(m.trf("notify.screenshot", m.exportedName(filename)))
 }
 
 // exportAircraftCSV exports aircraft data to CSV
 func (m *Model) exportAircraftCSV() {
+	m.exportAircraftCSVTo(m.GetExportDirectory())
+}
+
+func (m *Model) exportAircraftCSVTo(dir string) {
 	if len(m.aircraft) == 0 {
-		m.notify("No aircraft to export")
+		m.notify(m.tr("notify.no_aircraft"))
 		return
 	}
 
-	filename, err := export.ExportAircraft(m.aircraft, m.GetExportDirectory())
+	filename, err := export.ExportAircraftWithOptions(m.displayAircraft(), dir, m.exportOptions())
 	if err != nil {
-		m.notify("Export failed: " + err.Error())
+		m.exportFailed(err, dir, m.exportAircraftCSVTo)
 		return
 	}
 
-	m.notify("CSV: " + filepath.Base(filename))
+	m.notify(m.trf("notify.csv", m.exportedName(filename)))
 }
 
 // exportAircraftJSON exports aircraft data to JSON
 func (m *Model) exportAircraftJSON() {
+	m.exportAircraftJSONTo(m.GetExportDirectory())
+}
+
+func (m *Model) exportAircraftJSONTo(dir string) {
+	if len(m.aircraft) == 0 {
+		m.notify(m.tr("notify.no_aircraft"))
+		return
+	}
+
+	filename, err := export.ExportAircraftJSONWithOptions(m.displayAircraft(), dir, m.exportOptions())
+	if err != nil {
+		m.exportFailed(err, dir, m.exportAircraftJSONTo)
+		return
+	}
+
+	m.notify(m.trf("notify.json", m.exportedName(filename)))
+}
+
+// exportAircraftGeoJSON exports aircraft positions, and their trails when
+// trails are shown, to GeoJSON
+func (m *Model) exportAircraftGeoJSON() {
+	m.exportAircraftGeoJSONTo(m.GetExportDirectory())
+}
+
+func (m *Model) exportAircraftGeoJSONTo(dir string) {
 	if len(m.aircraft) == 0 {
-		m.notify("No aircraft to export")
+		m.notify(m.tr("notify.no_aircraft"))
 		return
 	}
 
-	filename, err := export.ExportAircraftJSON(m.aircraft, m.GetExportDirectory())
+	var trails map[string][]radar.TrailPoint
+	if m.config.Display.ShowTrails {
+		trails = m.GetTrailsForRadar()
+	}
+	filename, exported, skipped, err := export.ExportAircraftGeoJSON(m.displayAircraft(), trails, dir)
 	if err != nil {
-		m.notify("Export failed: " + err.Error())
+		m.exportFailed(err, dir, m.exportAircraftGeoJSONTo)
 		return
 	}
 
-	m.notify("JSON: " + filepath.Base(filename))
+	if skipped > 0 {
+		m.notify(m.trf("notify.geojson_skipped", exported, skipped, m.exportedName(filename)))
+	} else {
+		m.notify(m.trf("notify.geojson", exported, m.exportedName(filename)))
+	}
 }
 
 // ExportACARSCSV exports ACARS messages to CSV (can be called externally)
@@ -1008,8 +1779,10 @@ func (m *Model) GetTrailsForRadar() map[string][]radar.TrailPoint {
 		points := make([]radar.TrailPoint, len(trail))
 		for i, pos := range trail {
 			points[i] = radar.TrailPoint{
-				Lat: pos.Lat,
-				Lon: pos.Lon,
+				Lat:     pos.Lat,
+				Lon:     pos.Lon,
+				Break:   pos.Break,
+				Segment: pos.Segment,
 			}
 		}
 		result[hex] = points
@@ -1018,6 +1791,48 @@ func (m *Model) GetTrailsForRadar() map[string][]radar.TrailPoint {
 	return result
 }
 
+// profileGapTimeout is the silence between trail points that breaks the
+// vertical profile line instead of bridging the missing data
+const profileGapTimeout = 60 * time.Second
+
+// GetProfilePoints returns the vertical profile of an aircraft's trail:
+// altitude against along-track distance (nm) from the oldest trail point.
+func (m *Model) GetProfilePoints(hex string) []ui.ProfilePoint {
+	trail := m.trailTracker.GetTrail(hex)
+	points := make([]ui.ProfilePoint, 0, len(trail))
+
+	var along float64
+	for i, pos := range trail {
+		if i > 0 {
+			prev := trail[i-1]
+			// A jump to a new segment adds no distance
+			if !pos.Break {
+				step, _ := radar.HaversineBearing(prev.Lat, prev.Lon, pos.Lat, pos.Lon)
+				along += step
+			}
+			if pos.Break || pos.Timestamp.Sub(prev.Timestamp) > profileGapTimeout {
+				points = append(points, ui.ProfilePoint{X: along, Gap: true})
+			}
+		}
+		points = append(points, ui.ProfilePoint{
+			X:        along,
+			Altitude: pos.Altitude,
+			Gap:      !pos.HasAlt,
+		})
+	}
+
+	return points
+}
+
+// IsProfileVisible returns true if the vertical profile replaces the spectrum
+func (m *Model) IsProfileVisible() bool {
+	if !m.showProfile || m.selectedHex == "" {
+		return false
+	}
+	_, ok := m.aircraft[m.selectedHex]
+	return ok
+}
+
 // GetSpectrumPeaks returns the current spectrum peak values for rendering
 func (m *Model) GetSpectrumPeaks() []float64 {
 	return m.spectrumPeaks
@@ -1058,7 +1873,7 @@ func (m *Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "backspace":
 		if m.searchQuery != "" {
 			m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
-			m.updateSearchResults()
+			return m, m.scheduleSearch()
 		}
 		return m, nil
 	case "up":
@@ -1077,13 +1892,13 @@ func (m *Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			r := rune(key[0])
 			if r >= 32 && r < 127 {
 				m.searchQuery += key
-				m.updateSearchResults()
 				m.searchCursor = 0
+				return m, m.scheduleSearch()
 			}
 		} else if key == "space" {
 			m.searchQuery += " "
-			m.updateSearchResults()
 			m.searchCursor = 0
+			return m, m.scheduleSearch()
 		}
 		return m, nil
 	}
@@ -1094,6 +1909,7 @@ func (m *Model) enterSearchMode() {
 	m.searchQuery = ""
 	m.searchCursor = 0
 	m.searchResults = []string{}
+	m.searcher.Reset()
 }
 
 func (m *Model) applyFilterPreset(filter *search.Filter) {
@@ -1113,8 +1929,44 @@ func (m *Model) updateSearchResults() {
 		m.searchResults = nil
 		return
 	}
-	filter := search.ParseQuery(m.searchQuery)
-	m.searchResults = search.FilterAircraft(m.aircraft, filter)
+	m.searchResults = m.searcher.Update(m.aircraft, m.searchQuery)
+}
+
+// searchDebounce is how long typing must pause before the results follow
+// the query
+const searchDebounce = 50 * time.Millisecond
+
+// searchDebounceMsg asks for a deferred search update
+type searchDebounceMsg struct {
+	seq int
+}
+
+// scheduleSearch updates the results after a keystroke. The first
+// keystroke after a pause updates at once; while typing continues the
+// update waits until it has paused for searchDebounce.
+func (m *Model) scheduleSearch() tea.Cmd {
+	now := m.now()
+	idle := now.Sub(m.searchTyped) >= searchDebounce
+	m.searchTyped = now
+	m.searchSeq++
+	if idle {
+		m.updateSearchResults()
+		return nil
+	}
+
+	seq := m.searchSeq
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return searchDebounceMsg{seq: seq}
+	})
+}
+
+// handleSearchDebounce runs a deferred search update unless a later
+// keystroke has replaced it or search has been closed
+func (m *Model) handleSearchDebounce(msg searchDebounceMsg) {
+	if msg.seq != m.searchSeq || m.viewMode != ViewSearch {
+		return
+	}
+	m.updateSearchResults()
 }
 
 // GetSearchFilter returns the current active search filter
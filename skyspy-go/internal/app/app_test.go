@@ -2,19 +2,35 @@
 package app
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/api"
+	"github.com/skyspy/skyspy-go/internal/auth"
+	"github.com/skyspy/skyspy-go/internal/changelog"
+	"github.com/skyspy/skyspy-go/internal/clipboard"
+	"github.com/skyspy/skyspy-go/internal/codec"
 	"github.com/skyspy/skyspy-go/internal/config"
 	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/i18n"
 	"github.com/skyspy/skyspy-go/internal/radar"
 	"github.com/skyspy/skyspy-go/internal/search"
-	"github.com/skyspy/skyspy-go/internal/ws"
+	"github.com/skyspy/skyspy-go/internal/theme"
 )
 
 // Helper function to create a test configuration
@@ -31,19 +47,19 @@ func newTestConfig() *config.Config {
 }
 
 // Helper function to create a mock aircraft message
-func createMockAircraftMessage(msgType ws.MessageType, aircraft ws.Aircraft) ws.Message {
+func createMockAircraftMessage(msgType codec.MessageType, aircraft codec.Aircraft) codec.Message {
 	data, _ := json.Marshal(aircraft)
-	return ws.Message{
+	return codec.Message{
 		Type: string(msgType),
 		Data: data,
 	}
 }
 
 // Helper function to create a mock ACARS message
-func createMockACARSMessage(acars ws.ACARSData) ws.Message {
-	data, _ := json.Marshal([]ws.ACARSData{acars})
-	return ws.Message{
-		Type: string(ws.ACARSMessage),
+func createMockACARSMessage(acars codec.ACARSData) codec.Message {
+	data, _ := json.Marshal([]codec.ACARSData{acars})
+	return codec.Message{
+		Type: string(codec.ACARSMessage),
 		Data: data,
 	}
 }
@@ -124,19 +140,19 @@ func TestModel_New(t *testing.T) {
 	}
 }
 
-func TestModel_NewWithAuth(t *testing.T) {
+func TestModel_NewWithFeed(t *testing.T) {
 	cfg := newTestConfig()
+	feed := newFakeFeed()
 
-	// Test without auth manager (nil)
-	m := NewModelWithAuth(cfg, nil)
+	m := NewModelWithFeed(cfg, feed)
 
 	if m == nil {
-		t.Fatal("NewModelWithAuth returned nil")
+		t.Fatal("NewModelWithFeed returned nil")
 	}
 
-	// Verify WebSocket client is created
-	if m.wsClient == nil {
-		t.Error("wsClient should be initialized")
+	// Verify the feed is attached
+	if m.feed != feed {
+		t.Error("feed should be attached")
 	}
 
 	// Verify all other components are initialized same as NewModel
@@ -177,7 +193,7 @@ func TestModel_HandleAircraftSnapshot(t *testing.T) {
 	m := NewModel(cfg)
 
 	// Create snapshot data with multiple aircraft
-	snapshotData := map[string]ws.Aircraft{
+	snapshotData := map[string]codec.Aircraft{
 		"ABC123": {
 			Hex:    "ABC123",
 			Flight: "TEST001",
@@ -192,11 +208,11 @@ func TestModel_HandleAircraftSnapshot(t *testing.T) {
 		},
 	}
 	data, _ := json.Marshal(struct {
-		Aircraft map[string]ws.Aircraft `json:"aircraft"`
+		Aircraft map[string]codec.Aircraft `json:"aircraft"`
 	}{Aircraft: snapshotData})
 
-	msg := ws.Message{
-		Type: string(ws.AircraftSnapshot),
+	msg := codec.Message{
+		Type: string(codec.AircraftSnapshot),
 		Data: data,
 	}
 
@@ -226,7 +242,7 @@ func TestModel_HandleAircraftSnapshot_RemovesStaleAircraft(t *testing.T) {
 	m.aircraft["STALE1"] = &radar.Target{Hex: "STALE1", Callsign: "GHOST01"}
 	m.alertedAircraft["STALE1"] = true
 
-	snapshotData := map[string]ws.Aircraft{
+	snapshotData := map[string]codec.Aircraft{
 		"ABC123": {
 			Hex:    "ABC123",
 			Flight: "TEST001",
@@ -235,11 +251,11 @@ func TestModel_HandleAircraftSnapshot_RemovesStaleAircraft(t *testing.T) {
 		},
 	}
 	data, _ := json.Marshal(struct {
-		Aircraft map[string]ws.Aircraft `json:"aircraft"`
+		Aircraft map[string]codec.Aircraft `json:"aircraft"`
 	}{Aircraft: snapshotData})
 
-	m.handleAircraftMsg(ws.Message{
-		Type: string(ws.AircraftSnapshot),
+	m.handleAircraftMsg(codec.Message{
+		Type: string(codec.AircraftSnapshot),
 		Data: data,
 	})
 
@@ -271,13 +287,13 @@ func TestModel_HandleAircraftUpdate(t *testing.T) {
 	}
 
 	// Send update message
-	updateAircraft := ws.Aircraft{
+	updateAircraft := codec.Aircraft{
 		Hex:     "ABC123",
 		Flight:  "TEST001",
 		AltBaro: intPtr(35000),
 		GS:      floatPtr(450),
 	}
-	msg := createMockAircraftMessage(ws.AircraftUpdate, updateAircraft)
+	msg := createMockAircraftMessage(codec.AircraftUpdate, updateAircraft)
 
 	m.handleAircraftMsg(msg)
 
@@ -304,7 +320,7 @@ func TestModel_HandleAircraftNew(t *testing.T) {
 	initialMsgCount := m.sessionMessages
 
 	// Send new aircraft message
-	newAircraft := ws.Aircraft{
+	newAircraft := codec.Aircraft{
 		Hex:      "NEW789",
 		Flight:   "NEWFL01",
 		Lat:      floatPtr(52.2),
@@ -312,7 +328,7 @@ func TestModel_HandleAircraftNew(t *testing.T) {
 		AltBaro:  intPtr(28000),
 		Military: true,
 	}
-	msg := createMockAircraftMessage(ws.AircraftNew, newAircraft)
+	msg := createMockAircraftMessage(codec.AircraftNew, newAircraft)
 
 	m.handleAircraftMsg(msg)
 
@@ -355,10 +371,10 @@ func TestModel_HandleAircraftRemove(t *testing.T) {
 	}
 
 	// Send remove message
-	removeAircraft := ws.Aircraft{
+	removeAircraft := codec.Aircraft{
 		Hex: "REMOVE1",
 	}
-	msg := createMockAircraftMessage(ws.AircraftRemove, removeAircraft)
+	msg := createMockAircraftMessage(codec.AircraftRemove, removeAircraft)
 
 	m.handleAircraftMsg(msg)
 
@@ -379,7 +395,7 @@ func TestModel_HandleACARSMessage(t *testing.T) {
 	initialCount := len(m.acarsMessages)
 
 	// Send ACARS message
-	acars := ws.ACARSData{
+	acars := codec.ACARSData{
 		Callsign: "TEST001",
 		Flight:   "TST001",
 		Label:    "H1",
@@ -659,6 +675,9 @@ func TestModel_TrailTracking(t *testing.T) {
 	cfg.Display.ShowTrails = true
 	m := NewModel(cfg)
 
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+
 	// Simulate aircraft position updates
 	positions := []struct {
 		lat, lon float64
@@ -671,17 +690,17 @@ func TestModel_TrailTracking(t *testing.T) {
 	hex := "TRAIL01"
 
 	for _, pos := range positions {
-		aircraft := ws.Aircraft{
+		aircraft := codec.Aircraft{
 			Hex:    hex,
 			Flight: "TRL001",
 			Lat:    floatPtr(pos.lat),
 			Lon:    floatPtr(pos.lon),
 		}
-		msg := createMockAircraftMessage(ws.AircraftUpdate, aircraft)
+		msg := createMockAircraftMessage(codec.AircraftUpdate, aircraft)
 		m.handleAircraftMsg(msg)
 
-		// Small delay to ensure positions are different enough
-		time.Sleep(10 * time.Millisecond)
+		// Realistic spacing so the moves aren't taken as position jumps
+		clock = clock.Add(30 * time.Second)
 	}
 
 	// Get trails
@@ -916,13 +935,13 @@ func TestModel_ACARSMessageLimit(t *testing.T) {
 	cfg := newTestConfig()
 	m := NewModel(cfg)
 
-	// Add more than 100 ACARS messages
+	// Add more than 100 distinct ACARS messages
 	for i := 0; i < 120; i++ {
-		acars := ws.ACARSData{
+		acars := codec.ACARSData{
 			Callsign: "TEST001",
 			Flight:   "TST001",
 			Label:    "H1",
-			Text:     "Message",
+			Text:     "Message " + itoa(i),
 		}
 		msg := createMockACARSMessage(acars)
 		m.handleACARSMsg(msg)
@@ -1189,13 +1208,13 @@ func TestModel_SequentialAircraftUpdates(t *testing.T) {
 	// Test sequential updates (application is designed for single-threaded access via tea.Program)
 	for i := 0; i < 10; i++ {
 		hex := "AC" + string(rune('A'+i))
-		aircraft := ws.Aircraft{
+		aircraft := codec.Aircraft{
 			Hex:    hex,
 			Flight: "FLT" + string(rune('0'+i)),
 			Lat:    floatPtr(52.0 + float64(i)*0.1),
 			Lon:    floatPtr(4.0 + float64(i)*0.1),
 		}
-		msg := createMockAircraftMessage(ws.AircraftUpdate, aircraft)
+		msg := createMockAircraftMessage(codec.AircraftUpdate, aircraft)
 		m.handleAircraftMsg(msg)
 	}
 
@@ -1431,30 +1450,33 @@ func TestModel_TrailTrackerIntegration(t *testing.T) {
 	cfg := newTestConfig()
 	m := NewModel(cfg)
 
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+
 	// Add positions for an aircraft
 	hex := "TRLINT"
 
 	// First position
-	aircraft1 := ws.Aircraft{
+	aircraft1 := codec.Aircraft{
 		Hex:    hex,
 		Flight: "TRAIL1",
 		Lat:    floatPtr(52.0),
 		Lon:    floatPtr(4.0),
 	}
-	msg := createMockAircraftMessage(ws.AircraftUpdate, aircraft1)
+	msg := createMockAircraftMessage(codec.AircraftUpdate, aircraft1)
 	m.handleAircraftMsg(msg)
 
 	// Wait a bit and add second position
-	time.Sleep(50 * time.Millisecond)
+	clock = clock.Add(30 * time.Second)
 
 	// Different position (more than 0.001 deg difference)
-	aircraft2 := ws.Aircraft{
+	aircraft2 := codec.Aircraft{
 		Hex:    hex,
 		Flight: "TRAIL1",
 		Lat:    floatPtr(52.1),
 		Lon:    floatPtr(4.1),
 	}
-	msg = createMockAircraftMessage(ws.AircraftUpdate, aircraft2)
+	msg = createMockAircraftMessage(codec.AircraftUpdate, aircraft2)
 	m.handleAircraftMsg(msg)
 
 	// Get trails
@@ -1890,27 +1912,60 @@ func TestModel_UpdateSearchResults_EmptyQuery(t *testing.T) {
 	}
 }
 
+func TestModel_UpdateSearchResults_FollowsTrackedChanges(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.updateTarget(&codec.Aircraft{Hex: "ABC123", Flight: "UAL1"}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "DEF456", Flight: "DAL1"}, true)
+
+	m.searchQuery = "AL1"
+	m.updateSearchResults()
+
+	// A rename between keystrokes; narrowing "AL1"'s results misses it
+	m.updateTarget(&codec.Aircraft{Hex: "DEF456", Flight: "XYZ9"}, false)
+	m.searchQuery = "AL1"
+	m.updateSearchResults()
+	m.updateTarget(&codec.Aircraft{Hex: "DEF456", Flight: "UAL12"}, false)
+	m.searchQuery = "UAL1"
+	m.updateSearchResults()
+	if len(m.searchResults) != 2 {
+		t.Errorf("expected the renamed aircraft to be found, got %v", m.searchResults)
+	}
+}
+
 func TestModel_HandleTick_Cleanup(t *testing.T) {
 	cfg := newTestConfig()
 	m := NewModel(cfg)
 
-	// Set frame to trigger cleanup
-	m.frame = 199 // Next tick will be 200
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
 
-	// Add some aircraft with RSSI
 	m.aircraft["TICK01"] = &radar.Target{
 		Hex:      "TICK01",
 		RSSI:     -15,
 		HasRSSI:  true,
 		Distance: 30,
 	}
+	m.lastSeen["TICK01"] = clock.Add(-time.Hour)
 
-	// Run tick
+	// First tick only arms the cleanup timer
 	m.handleTick()
+	if _, ok := m.aircraft["TICK01"]; !ok {
+		t.Fatal("cleanup should not run before the interval elapses")
+	}
+
+	// Many ticks within the interval must not trigger cleanup
+	clock = clock.Add(cleanupInterval(cfg) - time.Second)
+	for i := 0; i < 500; i++ {
+		m.handleTick()
+	}
+	if _, ok := m.aircraft["TICK01"]; !ok {
+		t.Fatal("cleanup should follow wall-clock time, not frame count")
+	}
 
-	// Frame should be 200 now and cleanup should have run
-	if m.frame != 200 {
-		t.Errorf("expected frame 200, got %d", m.frame)
+	clock = clock.Add(time.Second)
+	m.handleTick()
+	if _, ok := m.aircraft["TICK01"]; ok {
+		t.Error("stale aircraft should be removed once the interval elapses")
 	}
 }
 
@@ -2071,6 +2126,45 @@ func TestModel_HandleSettingsKey_Enter(t *testing.T) {
 	}
 }
 
+func TestModel_HandleSettingsKey_Colorblind(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	m := NewModel(newTestConfig())
+	m.viewMode = ViewSettings
+	if m.theme.CVD {
+		t.Fatal("colorblind-safe mode should start off")
+	}
+
+	m.handleSettingsKey("c")
+	if !m.config.Display.ColorblindSafe || !m.theme.CVD {
+		t.Fatal("c should turn colorblind-safe mode on")
+	}
+	if !strings.Contains(ansi.Strip(m.renderSettingsPanel()), "Colorblind safe: ON") {
+		t.Error("the settings panel should show colorblind-safe mode")
+	}
+
+	// It carries over a change of theme
+	m.setTheme("amber")
+	if !m.theme.CVD || m.theme.Name != "Amber" {
+		t.Error("colorblind-safe mode should apply over the new theme")
+	}
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.Display.ColorblindSafe {
+		t.Error("colorblind-safe mode should be saved")
+	}
+
+	m.handleSettingsKey("c")
+	if m.theme.CVD {
+		t.Error("c again should turn it off")
+	}
+}
+
 func TestModel_HandleOverlaysKey_Navigation(t *testing.T) {
 	cfg := newTestConfig()
 	m := NewModel(cfg)
@@ -2115,7 +2209,7 @@ func TestModel_UpdateTarget_AllFields(t *testing.T) {
 	distance := 25.0
 	bearing := 45.0
 
-	ac := &ws.Aircraft{
+	ac := &codec.Aircraft{
 		Hex:      "FULL01",
 		Flight:   "  FULLFL  ", // with spaces to test trimming
 		Squawk:   "1234",
@@ -2165,7 +2259,7 @@ func TestModel_UpdateTarget_EmptyHex(t *testing.T) {
 	m := NewModel(cfg)
 
 	// Create aircraft with empty hex
-	ac := &ws.Aircraft{
+	ac := &codec.Aircraft{
 		Hex:    "",
 		Flight: "TEST",
 	}
@@ -2186,7 +2280,7 @@ func TestModel_UpdateTarget_AltFromAlt(t *testing.T) {
 	// Create aircraft with Alt (not AltBaro)
 	alt := 30000
 
-	ac := &ws.Aircraft{
+	ac := &codec.Aircraft{
 		Hex: "ALT01",
 		Alt: &alt,
 	}
@@ -2203,6 +2297,35 @@ func TestModel_UpdateTarget_AltFromAlt(t *testing.T) {
 	}
 }
 
+func TestModel_UpdateTarget_NavFields(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	m.updateTarget(&codec.Aircraft{
+		Hex:            "NAV01",
+		NavAltitudeFMS: intPtr(12000),
+		NavQNH:         floatPtr(1013.2),
+		NavModes:       []string{"vnav"},
+	}, false)
+
+	target := m.aircraft["NAV01"]
+	if target == nil {
+		t.Fatal("target should be added")
+	}
+	if !target.HasNavAlt || target.NavAltitude != 12000 {
+		t.Errorf("expected FMS selected altitude 12000, got %d (has=%v)", target.NavAltitude, target.HasNavAlt)
+	}
+	if !target.HasNavQNH || target.HasNavHeading {
+		t.Errorf("expected QNH only, got qnh=%v heading=%v", target.HasNavQNH, target.HasNavHeading)
+	}
+
+	// The MCP value wins when both are sent
+	m.updateTarget(&codec.Aircraft{Hex: "NAV01", NavAltitude: intPtr(8000), NavAltitudeFMS: intPtr(12000)}, false)
+	if target = m.aircraft["NAV01"]; target.NavAltitude != 8000 {
+		t.Errorf("expected MCP selected altitude 8000, got %d", target.NavAltitude)
+	}
+}
+
 func TestModel_UpdateTarget_VerticalFromBaroRate(t *testing.T) {
 	cfg := newTestConfig()
 	m := NewModel(cfg)
@@ -2210,7 +2333,7 @@ func TestModel_UpdateTarget_VerticalFromBaroRate(t *testing.T) {
 	// Create aircraft with BaroRate (not VR)
 	baroRate := 1500.0
 
-	ac := &ws.Aircraft{
+	ac := &codec.Aircraft{
 		Hex:      "BARO01",
 		BaroRate: &baroRate,
 	}
@@ -2237,7 +2360,7 @@ func TestModel_UpdateTarget_DistanceFromMessage(t *testing.T) {
 	// Create aircraft with distance in message
 	distance := 50.0
 
-	ac := &ws.Aircraft{
+	ac := &codec.Aircraft{
 		Hex:      "DIST01",
 		Distance: &distance,
 	}
@@ -2386,13 +2509,13 @@ func TestModel_HandleACARSMsg_Snapshot(t *testing.T) {
 	m := NewModel(cfg)
 
 	// Create ACARS snapshot message
-	acarsData := []ws.ACARSData{
+	acarsData := []codec.ACARSData{
 		{Callsign: "SNAP01", Flight: "SN01", Label: "H1", Text: "Snapshot message 1"},
 		{Callsign: "SNAP02", Flight: "SN02", Label: "H2", Text: "Snapshot message 2"},
 	}
 	data, _ := json.Marshal(acarsData)
-	msg := ws.Message{
-		Type: string(ws.ACARSSnapshot),
+	msg := codec.Message{
+		Type: string(codec.ACARSSnapshot),
 		Data: data,
 	}
 
@@ -2594,8 +2717,8 @@ func TestModel_HandleAlertRulesKey_ToggleAlerts(t *testing.T) {
 		t.Error("alerts should have toggled")
 	}
 
-	// Toggle back with A key
-	m.handleAlertRulesKey("A")
+	// Toggle back
+	m.handleAlertRulesKey("a")
 
 	if m.alertState.AlertsEnabled != initialEnabled {
 		t.Error("alerts should have toggled back")
@@ -2709,6 +2832,37 @@ func TestAlertState_SaveToConfig_WithGeofences(t *testing.T) {
 	}
 }
 
+func TestAlertState_DwellRuleConfigRoundTrip(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Rules = []config.AlertRuleConfig{
+		{
+			ID:      "loiter",
+			Name:    "Loitering",
+			Enabled: true,
+			Conditions: []config.ConditionConfig{
+				{Type: "geofence_dwell", Value: "airport:10m"},
+				{Type: "geofence_dwell_exit", Value: "airport:10m"},
+			},
+			Actions:     []config.ActionConfig{{Type: "notify", Message: "{callsign} loitering"}},
+			CooldownSec: 600,
+		},
+	}
+
+	alertState := NewAlertState(cfg)
+	rule := alertState.GetRules()[0]
+	if rule.Conditions[0].Type != alerts.ConditionGeofenceDwell ||
+		rule.Conditions[1].Type != alerts.ConditionGeofenceDwellExit {
+		t.Fatalf("dwell conditions not loaded, got %+v", rule.Conditions)
+	}
+
+	saved := newTestConfig()
+	alertState.SaveToConfig(saved)
+	got := saved.Alerts.Rules[0].Conditions
+	if len(got) != 2 || got[0] != cfg.Alerts.Rules[0].Conditions[0] || got[1] != cfg.Alerts.Rules[0].Conditions[1] {
+		t.Errorf("dwell conditions did not round-trip, got %+v", got)
+	}
+}
+
 func TestAlertState_CheckAircraft_Disabled(t *testing.T) {
 	cfg := newTestConfig()
 	cfg.Alerts.Enabled = false
@@ -2901,6 +3055,63 @@ func TestAlertRuleToConfig(t *testing.T) {
 	}
 }
 
+func TestAlertRuleConfig_VerticalRateRoundTrip(t *testing.T) {
+	var descent *alerts.AlertRule
+	for _, rule := range alerts.DefaultAlertRules() {
+		if rule.ID == "rapid_descent" {
+			descent = rule
+		}
+	}
+	data, err := json.Marshal(alertRuleToConfig(descent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg config.AlertRuleConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	rule := configToAlertRule(cfg)
+	if rule.Enabled || len(rule.Conditions) != 1 {
+		t.Fatalf("round trip changed the rule: %+v", rule)
+	}
+	if c := rule.Conditions[0]; c.Type != alerts.ConditionVSBelow || c.Value != "-6000:3000" {
+		t.Errorf("condition = %+v", c)
+	}
+}
+
+func TestAlertRules_VerticalRateUsesTrend(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.alertState.Engine.AddRule(alerts.NewAlertRule("descent", "Descent").AddCondition(alerts.ConditionVSBelow, "-6000:3000"))
+
+	report := func(vs float64) {
+		*clock = clock.Add(5 * time.Second)
+		ac := flying("484B1C", 52.40)
+		ac.AltBaro = intPtr(20000)
+		ac.BaroRate = floatPtr(vs)
+		m.updateTarget(ac, false)
+	}
+	alerted := func() bool {
+		for _, a := range m.alertState.RecentAlerts {
+			if a.Rule.ID == "descent" {
+				return true
+			}
+		}
+		return false
+	}
+
+	report(-1000)
+	report(-12000)
+	if alerted() {
+		t.Fatal("one noisy report shouldn't trigger a rapid descent alert")
+	}
+	for i := 0; i < 5 && !alerted(); i++ {
+		report(-8000)
+	}
+	if !alerted() {
+		t.Errorf("a sustained descent should trigger, trend at %.0f", m.aircraft["484B1C"].VSTrend)
+	}
+}
+
 func TestConfigToGeofence_Circle(t *testing.T) {
 	gfCfg := config.GeofenceConfig{
 		ID:          "test_circle",
@@ -3123,20 +3334,6 @@ func TestModel_NewModel_WithOverlays(t *testing.T) {
 	}
 }
 
-func TestModel_NewModelWithAuth_WithAuthManager(t *testing.T) {
-	cfg := newTestConfig()
-
-	// Create a mock that implements the auth interface minimally
-	// Since we can't easily mock the auth.Manager, we'll test the nil case
-	// which is already tested, and the non-nil case would require actual auth setup
-
-	m := NewModelWithAuth(cfg, nil)
-
-	if m == nil {
-		t.Error("NewModelWithAuth should not return nil")
-	}
-}
-
 // =============================================================================
 // Additional Coverage Tests - View Rendering
 // =============================================================================
@@ -3702,7 +3899,7 @@ func TestView_RenderStatsPanel_Offline(t *testing.T) {
 	m.width = 150
 	m.height = 50
 
-	// Connection status depends on wsClient.IsConnected()
+	// Connection status depends on feed.IsConnected()
 	output := m.View()
 
 	// Should contain STATUS panel
@@ -3934,13 +4131,13 @@ func TestModel_Update_AircraftMsg(t *testing.T) {
 	m := NewModel(cfg)
 
 	// Create aircraft message
-	ac := ws.Aircraft{
+	ac := codec.Aircraft{
 		Hex:    "TEST01",
 		Flight: "TEST001",
 	}
 	data, _ := json.Marshal(ac)
-	msg := aircraftMsg(ws.Message{
-		Type: string(ws.AircraftNew),
+	msg := aircraftMsg(codec.Message{
+		Type: string(codec.AircraftNew),
 		Data: data,
 	})
 
@@ -3957,12 +4154,12 @@ func TestModel_Update_ACARSMsg(t *testing.T) {
 	m := NewModel(cfg)
 
 	// Create ACARS message
-	acarsData := []ws.ACARSData{
+	acarsData := []codec.ACARSData{
 		{Callsign: "TEST01", Text: "Message"},
 	}
 	data, _ := json.Marshal(acarsData)
-	msg := acarsMsg(ws.Message{
-		Type: string(ws.ACARSMessage),
+	msg := acarsMsg(codec.Message{
+		Type: string(codec.ACARSMessage),
 		Data: data,
 	})
 
@@ -4376,6 +4573,22 @@ func TestView_RenderAlertRulesPanel_LongRuleName(t *testing.T) {
 	}
 }
 
+func TestView_RenderAlertRulesPanel_MultibyteNames(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Enabled = true
+	m := NewModel(cfg)
+	m.viewMode = ViewAlertRules
+
+	rule := alerts.NewAlertRule("umlauts", strings.Repeat("Überflug ", 5))
+	rule.Enabled = true
+	m.alertState.Engine.AddRule(rule)
+
+	panel := ansi.Strip(m.renderAlertRulesPanel())
+	if !utf8.ValidString(panel) || !strings.Contains(panel, "Überflug Überflug Über...") {
+		t.Errorf("long rule names should be cut by character:\n%s", panel)
+	}
+}
+
 func TestView_RenderSignalBars_ExactThresholds(t *testing.T) {
 	cfg := newTestConfig()
 	m := NewModel(cfg)
@@ -4495,8 +4708,7 @@ func TestModel_NewModel_RangeMatchExact(t *testing.T) {
 	cfg := newTestConfig()
 
 	// Test exact match for each range option
-	rangeOptions := []int{25, 50, 100, 200, 400}
-	for i, rangeVal := range rangeOptions {
+	for i, rangeVal := range cfg.Radar.RangeSteps {
 		cfg.Radar.DefaultRange = rangeVal
 		m := NewModel(cfg)
 
@@ -4509,11 +4721,11 @@ func TestModel_NewModel_RangeMatchExact(t *testing.T) {
 	}
 }
 
-func TestModel_NewModelWithAuth_RangeSelection(t *testing.T) {
+func TestModel_NewModelWithFeed_RangeSelection(t *testing.T) {
 	cfg := newTestConfig()
 	cfg.Radar.DefaultRange = 200
 
-	m := NewModelWithAuth(cfg, nil)
+	m := NewModelWithFeed(cfg, nil)
 
 	if m.maxRange != 200 {
 		t.Errorf("expected maxRange 200, got %f", m.maxRange)
@@ -4667,6 +4879,73 @@ func TestModel_HandleOverlaysKey_WithLoadedOverlays(t *testing.T) {
 	}
 }
 
+func TestModel_HandleOverlaysKey_Brightness(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	m.viewMode = ViewOverlays
+	m.overlayManager.AddOverlay(&geo.GeoOverlay{Name: "Airspace", Enabled: true, SourceFile: "/tmp/a.geojson"}, "airspace")
+	m.overlayManager.AddOverlay(&geo.GeoOverlay{Name: "Coast", Enabled: true, SourceFile: "/tmp/c.geojson"}, "coast")
+	m.overlayCursor = 1
+
+	m.handleOverlaysKey("-")
+	m.handleOverlaysKey("-")
+	list := m.overlayManager.GetOverlayList()
+	if list[1].Brightness != geo.BrightnessFaint {
+		t.Errorf("expected highlighted overlay faint, got %q", list[1].Brightness)
+	}
+	if list[0].Brightness != geo.BrightnessNormal {
+		t.Errorf("other overlay should stay normal, got %q", list[0].Brightness)
+	}
+	if m.config.Overlays.Overlays[1].Brightness != "faint" {
+		t.Errorf("expected faint persisted, got %q", m.config.Overlays.Overlays[1].Brightness)
+	}
+
+	m.handleOverlaysKey("+")
+	if got := m.overlayManager.GetOverlayList()[1].Brightness; got != geo.BrightnessDim {
+		t.Errorf("expected dim after +, got %q", got)
+	}
+	if !strings.Contains(m.notification, "DIM") {
+		t.Errorf("expected brightness notification, got %q", m.notification)
+	}
+	if !strings.Contains(m.renderOverlayPanel(), "DIM") {
+		t.Error("overlay panel should show the brightness level")
+	}
+}
+
+func TestModel_NewModel_OverlayDefaultBrightness(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i, name := range []string{"area.geojson", "zone.geojson"} {
+		path := dir + "/" + name
+		content := `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{},` +
+			fmt.Sprintf(`"geometry":{"type":"Point","coordinates":[4.0,%d]}}]}`, 52+i)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write overlay: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	cfg := newTestConfig()
+	cfg.Overlays.DefaultBrightness = "dim"
+	cfg.Overlays.Overlays = []config.OverlayConfig{
+		{Path: paths[0], Enabled: true, Key: "new"},
+		{Path: paths[1], Enabled: true, Key: "saved", Brightness: "bright"},
+	}
+
+	m := NewModel(cfg)
+	m.LoadOverlays()
+	list := m.overlayManager.GetOverlayList()
+	if len(list) != 2 {
+		t.Fatalf("expected 2 overlays, got %d", len(list))
+	}
+	if list[0].Brightness != geo.BrightnessDim {
+		t.Errorf("new overlay should take the default level, got %q", list[0].Brightness)
+	}
+	if list[1].Brightness != geo.BrightnessBright {
+		t.Errorf("saved level should win over the default, got %q", list[1].Brightness)
+	}
+}
+
 func TestModel_SaveOverlays_WithLoadedOverlays(t *testing.T) {
 	cfg := newTestConfig()
 	m := NewModel(cfg)
@@ -4960,6 +5239,7 @@ func TestModel_NewModel_WithValidOverlayFile(t *testing.T) {
 	}
 
 	m := NewModel(cfg)
+	m.LoadOverlays()
 
 	// Should have loaded the overlay
 	overlays := m.overlayManager.GetOverlayList()
@@ -4968,7 +5248,7 @@ func TestModel_NewModel_WithValidOverlayFile(t *testing.T) {
 	}
 }
 
-func TestModel_NewModelWithAuth_WithValidOverlayFile(t *testing.T) {
+func TestModel_NewModelWithFeed_WithValidOverlayFile(t *testing.T) {
 	// Create a temp directory and GeoJSON file
 	tmpDir := t.TempDir()
 	geojsonPath := tmpDir + "/test_overlay.geojson"
@@ -5004,7 +5284,8 @@ func TestModel_NewModelWithAuth_WithValidOverlayFile(t *testing.T) {
 		},
 	}
 
-	m := NewModelWithAuth(cfg, nil)
+	m := NewModelWithFeed(cfg, nil)
+	m.LoadOverlays()
 
 	// Should have loaded the overlay
 	overlays := m.overlayManager.GetOverlayList()
@@ -5554,7 +5835,7 @@ func TestView_RenderSearchPanel_AltitudeHigh(t *testing.T) {
 	}
 }
 
-func TestModel_NewModelWithAuth_NoOverlayColor(t *testing.T) {
+func TestModel_NewModelWithFeed_NoOverlayColor(t *testing.T) {
 	// Create a temp directory and GeoJSON file
 	tmpDir := t.TempDir()
 	geojsonPath := tmpDir + "/test_overlay.geojson"
@@ -5589,7 +5870,8 @@ func TestModel_NewModelWithAuth_NoOverlayColor(t *testing.T) {
 		},
 	}
 
-	m := NewModelWithAuth(cfg, nil)
+	m := NewModelWithFeed(cfg, nil)
+	m.LoadOverlays()
 
 	overlays := m.overlayManager.GetOverlayList()
 	if len(overlays) != 1 {
@@ -6254,3 +6536,3220 @@ func TestView_RenderStatusBar_Padding(t *testing.T) {
 		t.Error("should render with padding")
 	}
 }
+
+// =============================================================================
+// Vertical Profile Tests
+// =============================================================================
+
+func TestModel_ProfileToggle_RequiresSelection(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	// Without a selection V keeps toggling the VU meters
+	initialVU := m.config.Display.ShowVUMeters
+	m.handleRadarKey("v")
+	if m.config.Display.ShowVUMeters == initialVU {
+		t.Error("V without a selection should toggle VU meters")
+	}
+	if m.showProfile {
+		t.Error("profile should not turn on without a selection")
+	}
+
+	m.aircraft["PRO001"] = &radar.Target{Hex: "PRO001", HasLat: true, HasLon: true}
+	m.selectedHex = "PRO001"
+	vuBefore := m.config.Display.ShowVUMeters
+
+	m.handleRadarKey("v")
+	if !m.showProfile || !m.IsProfileVisible() {
+		t.Error("V with a selection should show the profile")
+	}
+	if m.config.Display.ShowVUMeters != vuBefore {
+		t.Error("V with a selection should not touch the VU meters")
+	}
+
+	m.handleRadarKey("V")
+	if m.showProfile {
+		t.Error("second V should hide the profile")
+	}
+}
+
+func TestModel_GetProfilePoints(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+
+	for i, alt := range []int{1000, 3000, 6000} {
+		clock = clock.Add(30 * time.Second)
+		m.updateTarget(&codec.Aircraft{
+			Hex:     "PRO002",
+			Lat:     floatPtr(52.0 + float64(i)*0.1),
+			Lon:     floatPtr(4.9),
+			AltBaro: intPtr(alt),
+		}, i == 0)
+	}
+
+	points := m.GetProfilePoints("PRO002")
+	if len(points) != 3 {
+		t.Fatalf("expected 3 profile points, got %d", len(points))
+	}
+	if points[0].X != 0 {
+		t.Errorf("first point should start at 0nm, got %f", points[0].X)
+	}
+	if points[2].X < 10 || points[2].X > 14 {
+		t.Errorf("expected ~12nm along track, got %f", points[2].X)
+	}
+	if points[2].Altitude != 6000 || points[2].Gap {
+		t.Errorf("expected last point at 6000ft, got %+v", points[2])
+	}
+
+	// A position without altitude becomes a gap
+	clock = clock.Add(60 * time.Second)
+	m.updateTarget(&codec.Aircraft{Hex: "PRO002", Lat: floatPtr(52.4), Lon: floatPtr(4.9)}, false)
+	points = m.GetProfilePoints("PRO002")
+	if !points[len(points)-1].Gap {
+		t.Error("point without altitude should be a gap")
+	}
+}
+
+// =============================================================================
+// ACARS Ingestion Tests
+// =============================================================================
+
+func TestModel_ACARSDuplicateBurst(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	dup := codec.ACARSData{Callsign: "DUP001", Flight: "DP001", Label: "H1", Text: "POSITION REPORT"}
+	for i := 0; i < 3; i++ {
+		m.handleACARSMsg(createMockACARSMessage(dup))
+	}
+
+	if len(m.acarsMessages) != 1 {
+		t.Errorf("expected 1 stored message from a duplicate burst, got %d", len(m.acarsMessages))
+	}
+	if m.GetACARSDuplicates() != 2 {
+		t.Errorf("expected 2 duplicates dropped, got %d", m.GetACARSDuplicates())
+	}
+
+	// A different label is a different message
+	other := dup
+	other.Label = "Q0"
+	m.handleACARSMsg(createMockACARSMessage(other))
+	if len(m.acarsMessages) != 2 {
+		t.Errorf("expected 2 stored messages, got %d", len(m.acarsMessages))
+	}
+}
+
+func TestModel_ACARSDuplicateBurst_InOneBatch(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	dup := codec.ACARSData{Callsign: "DUP002", Label: "H1", Text: "SAME"}
+	data, _ := json.Marshal([]codec.ACARSData{dup, dup, dup})
+	m.handleACARSMsg(codec.Message{Type: string(codec.ACARSSnapshot), Data: data})
+
+	if len(m.acarsMessages) != 1 || m.GetACARSDuplicates() != 2 {
+		t.Errorf("expected 1 stored / 2 dropped, got %d / %d", len(m.acarsMessages), m.GetACARSDuplicates())
+	}
+}
+
+func TestModel_ACARSDuplicateUsesModelClock(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+
+	dup := codec.ACARSData{Callsign: "DUP004", Label: "H1", Text: "SAME"}
+	m.handleACARSMsg(createMockACARSMessage(dup))
+
+	// Replay time moves past the window at once; a wall clock wouldn't
+	clock = clock.Add(time.Duration(cfg.ACARS.DedupWindow+1) * time.Second)
+	m.handleACARSMsg(createMockACARSMessage(dup))
+
+	if len(m.acarsMessages) != 2 || m.GetACARSDuplicates() != 0 {
+		t.Errorf("expected 2 stored / 0 dropped, got %d / %d", len(m.acarsMessages), m.GetACARSDuplicates())
+	}
+	if !m.acarsMessages[1].Received.Equal(clock) {
+		t.Errorf("received = %v, want the model's clock %v", m.acarsMessages[1].Received, clock)
+	}
+}
+
+func TestModel_ACARSDedupDisabled(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.ACARS.DedupWindow = 0
+	m := NewModel(cfg)
+
+	dup := codec.ACARSData{Callsign: "DUP003", Label: "H1", Text: "SAME"}
+	for i := 0; i < 3; i++ {
+		m.handleACARSMsg(createMockACARSMessage(dup))
+	}
+
+	if len(m.acarsMessages) != 3 || m.GetACARSDuplicates() != 0 {
+		t.Errorf("dedup disabled: expected 3 stored / 0 dropped, got %d / %d", len(m.acarsMessages), m.GetACARSDuplicates())
+	}
+}
+
+func TestModel_ACARSConfigurableLimit(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.ACARS.MaxMessages = 10
+	m := NewModel(cfg)
+
+	for i := 0; i < 25; i++ {
+		m.handleACARSMsg(createMockACARSMessage(codec.ACARSData{Callsign: "LIM001", Text: "Message " + itoa(i)}))
+	}
+
+	if len(m.acarsMessages) != 10 {
+		t.Fatalf("expected 10 retained messages, got %d", len(m.acarsMessages))
+	}
+	// Oldest messages are trimmed from the front
+	if m.acarsMessages[0].Text != "Message 15" || m.acarsMessages[9].Text != "Message 24" {
+		t.Errorf("expected messages 15..24, got %q..%q", m.acarsMessages[0].Text, m.acarsMessages[9].Text)
+	}
+}
+
+func TestACARSDeduper_Window(t *testing.T) {
+	d := newACARSDeduper(60*time.Second, 8)
+	msg := codec.ACARSData{Callsign: "WIN001", Label: "H1", Text: "HELLO"}
+	start := time.Now()
+
+	if d.isDuplicate(msg, start) {
+		t.Error("first sighting should not be a duplicate")
+	}
+	if !d.isDuplicate(msg, start.Add(30*time.Second)) {
+		t.Error("repeat inside the window should be a duplicate")
+	}
+	if d.isDuplicate(msg, start.Add(91*time.Second)) {
+		t.Error("repeat after the window should be accepted")
+	}
+}
+
+func TestACARSDeduper_EvictsOldest(t *testing.T) {
+	d := newACARSDeduper(time.Hour, 2)
+	now := time.Now()
+
+	a := codec.ACARSData{Text: "A"}
+	b := codec.ACARSData{Text: "B"}
+	c := codec.ACARSData{Text: "C"}
+	d.isDuplicate(a, now)
+	d.isDuplicate(b, now)
+	d.isDuplicate(c, now) // evicts A
+
+	if len(d.entries) != 2 {
+		t.Errorf("expected LRU bounded to 2 entries, got %d", len(d.entries))
+	}
+	if d.isDuplicate(a, now) {
+		t.Error("evicted message should no longer be treated as a duplicate")
+	}
+}
+
+// =============================================================================
+// Stale Cleanup Tests
+// =============================================================================
+
+func TestModel_Cleanup_RemovesStaleAircraftCompletely(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Radar.CleanupInterval = 10
+	cfg.Radar.AircraftTimeout = 60
+	m := NewModel(cfg)
+
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+
+	stale := codec.Aircraft{Hex: "STALE1", Flight: "OLD1", Lat: floatPtr(52.4), Lon: floatPtr(4.9), Military: true}
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftNew, stale))
+	m.sortedTargets = []string{"STALE1"}
+	m.selectedHex = "STALE1"
+
+	if !m.alertState.IsHighlighted("STALE1") {
+		t.Fatal("military default rule should highlight the aircraft")
+	}
+
+	m.handleTick() // arm the cleanup timer
+
+	// Keep a second aircraft fresh throughout
+	clock = clock.Add(90 * time.Second)
+	fresh := codec.Aircraft{Hex: "FRESH1", Flight: "NEW1", Lat: floatPtr(52.5), Lon: floatPtr(4.8)}
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftUpdate, fresh))
+	m.handleTick()
+
+	if _, ok := m.aircraft["STALE1"]; ok {
+		t.Error("stale aircraft should be removed")
+	}
+	if _, ok := m.lastSeen["STALE1"]; ok {
+		t.Error("last-seen entry should be purged")
+	}
+	if m.alertedAircraft["STALE1"] {
+		t.Error("alerted bookkeeping should be purged")
+	}
+	if m.trailTracker.TrailLength("STALE1") != 0 {
+		t.Error("trail should be purged")
+	}
+	if m.alertState.IsHighlighted("STALE1") {
+		t.Error("alert highlight should be purged")
+	}
+	if m.selectedHex != "" {
+		t.Errorf("selection should be cleared, got %q", m.selectedHex)
+	}
+	for _, hex := range m.sortedTargets {
+		if hex == "STALE1" {
+			t.Error("removed aircraft should leave the target order")
+		}
+	}
+
+	if _, ok := m.aircraft["FRESH1"]; !ok {
+		t.Error("recently updated aircraft should be kept")
+	}
+}
+
+func TestModel_RemoveAircraft_KeepsOtherSelection(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftNew, codec.Aircraft{Hex: "KEEP01"}))
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftNew, codec.Aircraft{Hex: "DROP01"}))
+	m.sortedTargets = []string{"KEEP01", "DROP01"}
+	m.selectedHex = "KEEP01"
+
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftRemove, codec.Aircraft{Hex: "DROP01"}))
+
+	if m.selectedHex != "KEEP01" {
+		t.Errorf("selection of another aircraft should survive, got %q", m.selectedHex)
+	}
+	if len(m.sortedTargets) != 1 || m.sortedTargets[0] != "KEEP01" {
+		t.Errorf("expected target order [KEEP01], got %v", m.sortedTargets)
+	}
+
+	// Selection navigation still works after the selected target disappears
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftRemove, codec.Aircraft{Hex: "KEEP01"}))
+	if m.selectedHex != "" {
+		t.Errorf("selection should be cleared, got %q", m.selectedHex)
+	}
+	m.selectNext()
+	if m.selectedHex != "" {
+		t.Errorf("no target should be selectable, got %q", m.selectedHex)
+	}
+}
+
+func TestModel_Snapshot_PurgesMissingAircraft(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	ghost := codec.Aircraft{Hex: "GHOST1", Lat: floatPtr(52.4), Lon: floatPtr(4.9)}
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftNew, ghost))
+	m.selectedHex = "GHOST1"
+
+	data, _ := json.Marshal([]codec.Aircraft{{Hex: "LIVE01"}})
+	m.handleAircraftMsg(codec.Message{Type: string(codec.AircraftSnapshot), Data: data})
+
+	if _, ok := m.aircraft["GHOST1"]; ok {
+		t.Error("aircraft missing from snapshot should be removed")
+	}
+	if m.trailTracker.TrailLength("GHOST1") != 0 {
+		t.Error("snapshot removal should purge the trail")
+	}
+	if m.selectedHex != "" {
+		t.Error("snapshot removal should clear the selection")
+	}
+}
+
+func TestCleanupSettings_Fallback(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Radar.CleanupInterval = 0
+	cfg.Radar.AircraftTimeout = -1
+
+	if cleanupInterval(cfg) != 30*time.Second {
+		t.Errorf("expected default 30s cleanup interval, got %v", cleanupInterval(cfg))
+	}
+	if aircraftTimeout(cfg) != 300*time.Second {
+		t.Errorf("expected default 300s aircraft timeout, got %v", aircraftTimeout(cfg))
+	}
+}
+
+// =============================================================================
+// Headless Stats Tests
+// =============================================================================
+
+func TestModel_IngestAircraftMessage_UpdatesStats(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	m.IngestAircraftMessage(createMockAircraftMessage(codec.AircraftNew, codec.Aircraft{Hex: "MIL001", Military: true}))
+	m.IngestAircraftMessage(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{Hex: "EMG001", Squawk: "7600"}))
+
+	stats := m.GetStats()
+	if stats.Aircraft != 2 || stats.Peak != 2 {
+		t.Errorf("expected 2 aircraft (peak 2), got %d (peak %d)", stats.Aircraft, stats.Peak)
+	}
+	if stats.Military != 1 || stats.Emergency != 1 {
+		t.Errorf("expected 1 military and 1 emergency, got %d and %d", stats.Military, stats.Emergency)
+	}
+	if stats.Messages != 2 {
+		t.Errorf("expected 2 counted messages, got %d", stats.Messages)
+	}
+
+	m.IngestAircraftMessage(createMockAircraftMessage(codec.AircraftRemove, codec.Aircraft{Hex: "MIL001"}))
+	if stats := m.GetStats(); stats.Aircraft != 1 || stats.Military != 0 {
+		t.Errorf("removal should update counts, got %+v", stats)
+	}
+}
+
+// =============================================================================
+// Closest Point of Approach Tests
+// =============================================================================
+
+// inboundTarget returns a target 10nm due east of the test receiver flying
+// straight at it at 120kt (CPA 0nm in 5:00)
+func inboundTarget(cfg *config.Config) *radar.Target {
+	lat := cfg.Connection.ReceiverLat
+	lonOffset := 10 / (60 * math.Cos(lat*math.Pi/180))
+	return &radar.Target{
+		Hex: "CPA001", Callsign: "INBOUND",
+		Lat: lat, Lon: cfg.Connection.ReceiverLon + lonOffset, HasLat: true, HasLon: true,
+		Track: 270, HasTrack: true, Speed: 120, HasSpeed: true,
+	}
+}
+
+func TestModel_GetCPA_CountsDownBetweenUpdates(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+	m.aircraft["CPA001"] = inboundTarget(cfg)
+	m.lastSeen["CPA001"] = clock
+
+	if got := m.formatCPA(m.aircraft["CPA001"]); got != "0.0nm in 5:00" {
+		t.Errorf("expected \"0.0nm in 5:00\", got %q", got)
+	}
+
+	clock = clock.Add(70 * time.Second)
+	if got := m.formatCPA(m.aircraft["CPA001"]); got != "0.0nm in 3:50" {
+		t.Errorf("expected countdown to 3:50, got %q", got)
+	}
+
+	clock = clock.Add(5 * time.Minute)
+	if got := m.formatCPA(m.aircraft["CPA001"]); got != "opening" {
+		t.Errorf("expected opening once CPA has passed, got %q", got)
+	}
+}
+
+func TestModel_FormatCPA_Opening(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	target := inboundTarget(cfg)
+	target.Track = 90
+	m.aircraft["CPA001"] = target
+
+	if got := m.formatCPA(target); got != "opening" {
+		t.Errorf("expected opening for an outbound target, got %q", got)
+	}
+}
+
+func TestModel_FormatCPA_Unknown(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	target := inboundTarget(cfg)
+	target.HasTrack = false
+	m.aircraft["CPA001"] = target
+	if got := m.formatCPA(target); got != dashPlaceholder {
+		t.Errorf("expected placeholder without a track, got %q", got)
+	}
+
+	target.HasTrack = true
+	target.Speed = 0
+	if got := m.formatCPA(target); got != dashPlaceholder {
+		t.Errorf("expected placeholder for a stationary target, got %q", got)
+	}
+
+	cfg.Connection.ReceiverLat = 0
+	cfg.Connection.ReceiverLon = 0
+	target.Speed = 120
+	if got := m.formatCPA(target); got != dashPlaceholder {
+		t.Errorf("expected placeholder without a receiver location, got %q", got)
+	}
+}
+
+func TestAlertState_CPABelowRule(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Rules = []config.AlertRuleConfig{
+		{
+			ID:         "close_pass",
+			Name:       "Close Pass",
+			Enabled:    true,
+			Conditions: []config.ConditionConfig{{Type: "cpa_below", Value: "2"}},
+			Actions:    []config.ActionConfig{{Type: "notify", Message: "{callsign} passing close"}},
+		},
+	}
+	alertState := NewAlertState(cfg)
+
+	outbound := inboundTarget(cfg)
+	outbound.Hex = "CPA002"
+	outbound.Track = 90
+	if got := alertState.CheckAircraft(outbound, nil); len(got) != 0 {
+		t.Errorf("outbound target should not trigger a CPA alert, got %d", len(got))
+	}
+
+	if got := alertState.CheckAircraft(inboundTarget(cfg), nil); len(got) != 1 {
+		t.Errorf("inbound target passing overhead should trigger, got %d", len(got))
+	}
+}
+
+// =============================================================================
+// Pinning Tests
+// =============================================================================
+
+// addPinTarget adds a positioned target to the model
+func addPinTarget(m *Model, hex, callsign string) {
+	m.aircraft[hex] = &radar.Target{
+		Hex: hex, Callsign: callsign,
+		Lat: 52.4, Lon: 4.9, HasLat: true, HasLon: true,
+		Altitude: 30000, HasAlt: true, Speed: 420, HasSpeed: true,
+		Vertical: 1500, HasVS: true, Distance: 12,
+	}
+	m.lastSeen[hex] = m.now()
+}
+
+func TestModel_TogglePin(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	addPinTarget(m, "ABC123", "UAL123")
+
+	m.handleRadarKey(keyEnter)
+	if m.notification != "No target selected" {
+		t.Errorf("expected no-selection notice, got %q", m.notification)
+	}
+
+	m.selectedHex = "ABC123"
+	m.handleRadarKey(keyEnter)
+	if got := m.GetPinned(); len(got) != 1 || got[0] != "ABC123" {
+		t.Fatalf("expected ABC123 pinned, got %v", got)
+	}
+	if m.notification != "Pinned: UAL123" {
+		t.Errorf("expected pin notice, got %q", m.notification)
+	}
+
+	m.handleRadarKey(keyEnter)
+	if len(m.GetPinned()) != 0 {
+		t.Errorf("expected second toggle to unpin, got %v", m.GetPinned())
+	}
+	if m.notification != "Unpinned: UAL123" {
+		t.Errorf("expected unpin notice, got %q", m.notification)
+	}
+}
+
+func TestModel_TogglePin_Limit(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	for i := 0; i <= maxPinned; i++ {
+		hex := fmt.Sprintf("PIN%03d", i)
+		addPinTarget(m, hex, "")
+		m.selectedHex = hex
+		m.togglePin()
+	}
+
+	if len(m.GetPinned()) != maxPinned {
+		t.Errorf("expected %d pins, got %d", maxPinned, len(m.GetPinned()))
+	}
+	if m.notification != "Pin limit (4) reached" {
+		t.Errorf("expected limit notice, got %q", m.notification)
+	}
+}
+
+func TestModel_ClearPins(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	addPinTarget(m, "ABC123", "UAL123")
+	addPinTarget(m, "DEF456", "DAL456")
+	m.pinned = []string{"ABC123", "DEF456"}
+
+	m.handleRadarKey("ctrl+j")
+
+	if len(m.GetPinned()) != 0 {
+		t.Errorf("expected pins cleared, got %v", m.GetPinned())
+	}
+	if m.notification != "Pins cleared" {
+		t.Errorf("expected clear notice, got %q", m.notification)
+	}
+}
+
+func TestModel_PinLostOnTimeout(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+	addPinTarget(m, "ABC123", "UAL123")
+	m.pinned = []string{"ABC123"}
+
+	m.cleanup(clock.Add(aircraftTimeout(cfg) + time.Second))
+
+	if len(m.GetPinned()) != 0 {
+		t.Errorf("expected timed-out aircraft to be unpinned, got %v", m.GetPinned())
+	}
+	if m.notification != "Pin lost: UAL123" {
+		t.Errorf("expected pin lost notice, got %q", m.notification)
+	}
+}
+
+func TestModel_PinLostOnRemove(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	addPinTarget(m, "ABC123", "")
+	m.pinned = []string{"ABC123"}
+
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftRemove, codec.Aircraft{Hex: "ABC123"}))
+
+	if len(m.GetPinned()) != 0 {
+		t.Errorf("expected removed aircraft to be unpinned, got %v", m.GetPinned())
+	}
+	if m.notification != "Pin lost: ABC123" {
+		t.Errorf("expected pin lost notice, got %q", m.notification)
+	}
+}
+
+func TestModel_PinsSurviveFilters(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	addPinTarget(m, "ABC123", "UAL123")
+	m.pinned = []string{"ABC123"}
+
+	m.handleRadarKey("m")
+	m.handleRadarKey("g")
+
+	if !m.isPinned("ABC123") {
+		t.Error("pins should survive filter toggles")
+	}
+}
+
+func TestModel_RenderPinnedPanel(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	for i := 0; i < 3; i++ {
+		hex := fmt.Sprintf("PIN%03d", i)
+		addPinTarget(m, hex, fmt.Sprintf("CS%d", i))
+		m.pinned = append(m.pinned, hex)
+	}
+
+	full := m.renderPinnedPanel(40)
+	if len(full) != 3*pinBlockHeight {
+		t.Fatalf("expected %d lines of full blocks, got %d", 3*pinBlockHeight, len(full))
+	}
+	for i, line := range full {
+		if w := lipgloss.Width(line); w != pinBlockWidth {
+			t.Errorf("line %d: expected width %d, got %d", i, pinBlockWidth, w)
+		}
+	}
+	if !strings.Contains(ansi.Strip(full[1]), "FL300") || !strings.Contains(ansi.Strip(full[1]), "▲") {
+		t.Errorf("expected altitude and climb trend in block, got %q", ansi.Strip(full[1]))
+	}
+
+	compact := m.renderPinnedPanel(3)
+	if len(compact) != 3 {
+		t.Fatalf("expected compact fallback of 3 lines, got %d", len(compact))
+	}
+	if !strings.Contains(ansi.Strip(compact[0]), "CS0") {
+		t.Errorf("expected compact line for CS0, got %q", ansi.Strip(compact[0]))
+	}
+
+	overflow := m.renderPinnedPanel(2)
+	if len(overflow) != 2 || !strings.Contains(ansi.Strip(overflow[1]), "+2 pinned") {
+		t.Errorf("expected overflow summary, got %q", overflow)
+	}
+
+	if m.renderPinnedPanel(0) != nil {
+		t.Error("expected nothing rendered without room")
+	}
+}
+
+// =============================================================================
+// Event Handler Tests
+// =============================================================================
+
+func TestModel_EventHandler_AircraftLifecycle(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	var events []Event
+	m.SetEventHandler(func(ev Event) { events = append(events, ev) })
+
+	snapshot, _ := json.Marshal([]codec.Aircraft{{Hex: "ABC123"}})
+	m.handleAircraftMsg(codec.Message{Type: string(codec.AircraftSnapshot), Data: snapshot})
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{Hex: "ABC123", Flight: "UAL1"}))
+	m.removeAircraft("ABC123")
+	m.removeAircraft("ABC123")
+
+	want := []EventType{EventNew, EventUpdate, EventRemove}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events))
+	}
+	for i, ev := range events {
+		if ev.Type != want[i] || ev.Target == nil || ev.Target.Hex != "ABC123" {
+			t.Errorf("event %d: expected %s for ABC123, got %+v", i, want[i], ev)
+		}
+	}
+	if events[2].Target.Callsign != "UAL1" {
+		t.Error("remove event should carry the last known state")
+	}
+}
+
+func TestModel_EventHandler_ACARS(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	m.aircraft["ABC123"] = &radar.Target{Hex: "ABC123", Callsign: "UAL1"}
+
+	var events []Event
+	m.SetEventHandler(func(ev Event) { events = append(events, ev) })
+
+	msg := createMockACARSMessage(codec.ACARSData{Flight: "UAL1", Label: "H1", Text: "HELLO"})
+	m.IngestACARSMessage(msg)
+	m.IngestACARSMessage(msg) // duplicate is suppressed
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 ACARS event, got %d", len(events))
+	}
+	if events[0].Type != EventACARS || events[0].ACARS.Text != "HELLO" {
+		t.Errorf("unexpected event %+v", events[0])
+	}
+	if events[0].Target == nil || events[0].Target.Hex != "ABC123" {
+		t.Error("expected the tracked sender to be attached")
+	}
+}
+
+// =============================================================================
+// Suspend / Resume Tests
+// =============================================================================
+
+func TestModel_CtrlZ_Suspends(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	// Works from any view, including search where letters are captured
+	m.viewMode = ViewSearch
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if !m.IsSuspended() {
+		t.Fatal("expected model to be suspended after ctrl+z")
+	}
+	if cmd == nil {
+		t.Fatal("expected a suspend command")
+	}
+	if _, ok := cmd().(tea.SuspendMsg); !ok {
+		t.Error("expected ctrl+z to return tea.Suspend")
+	}
+}
+
+func TestModel_View_SuspendedDoesNotRender(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	m.width, m.height = 120, 40
+
+	before := m.View()
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+
+	// Data keeps arriving while suspended but the frame is frozen
+	m.aircraft["SUS001"] = &radar.Target{
+		Hex: "SUS001", Callsign: "WHILEAWAY",
+		Lat: cfg.Connection.ReceiverLat + 0.1, Lon: cfg.Connection.ReceiverLon, HasLat: true, HasLon: true,
+		Distance: 6, Bearing: 0,
+	}
+	if got := m.View(); got != before {
+		t.Error("expected View to return the last frame while suspended")
+	}
+
+	m.Update(tea.ResumeMsg{})
+	if m.View() == before {
+		t.Error("expected a fresh frame after resume")
+	}
+}
+
+func TestModel_Resume_ForcesRedraw(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	m.suspended = true
+
+	_, cmd := m.Update(tea.ResumeMsg{})
+	if m.IsSuspended() {
+		t.Error("expected resume to clear the suspended flag")
+	}
+	if cmd == nil {
+		t.Fatal("expected redraw commands on resume")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("expected a batch of commands, got %T", cmd())
+	}
+
+	// Alt screen, mouse, clear screen and window size re-query
+	if len(batch) != 4 {
+		t.Errorf("expected 4 redraw commands, got %d", len(batch))
+	}
+}
+
+// =============================================================================
+// Clipboard Yank Tests
+// =============================================================================
+
+func TestModel_Yank_CopiesVisibleListRows(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	var out bytes.Buffer
+	m.clipboard = clipboard.NewWriter(&out)
+	m.clipboard.SetEnv(func(key string) string {
+		if key == "TERM" {
+			return "xterm-256color"
+		}
+		return ""
+	})
+
+	for i := 0; i < 10; i++ {
+		hex := fmt.Sprintf("YNK%03d", i)
+		m.aircraft[hex] = &radar.Target{Hex: hex, Callsign: fmt.Sprintf("ROW%d", i)}
+		m.sortedTargets = append(m.sortedTargets, hex)
+	}
+	// Filtered out of the list since the last render
+	delete(m.aircraft, "YNK001")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("expected a clipboard command")
+	}
+	m.Update(cmd())
+
+	// The sequence goes out with the frame, not straight to the terminal
+	if out.Len() != 0 {
+		t.Errorf("the command should not write to the terminal, got %q", out.String())
+	}
+	frame := m.View()
+	if !strings.HasPrefix(frame, "\x1b]52;c;") {
+		t.Fatalf("expected the frame to start with an OSC 52 sequence, got %q", frame[:min(len(frame), 20)])
+	}
+	seq := frame[:strings.IndexByte(frame, '\x07')+1]
+	payload, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(seq, "\x1b]52;c;"), "\x07"))
+	if err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(payload)), "\n")
+	if len(lines) != 1+targetListRows {
+		t.Fatalf("expected header + %d rows, got %d lines", targetListRows, len(lines))
+	}
+	if !strings.HasPrefix(lines[1], "YNK000,ROW0") || !strings.HasPrefix(lines[2], "YNK002,ROW2") {
+		t.Errorf("expected rows in list order skipping removed targets, got %q, %q", lines[1], lines[2])
+	}
+	if m.notification != "Copied 8 rows" {
+		t.Errorf("expected copy notification, got %q", m.notification)
+	}
+	if strings.Contains(m.lastRenderedView, "\x1b]52") {
+		t.Error("screenshots shouldn't capture the sequence")
+	}
+
+	m.now = func() time.Time { return time.Now().Add(clipboardHold) }
+	if strings.HasPrefix(m.View(), "\x1b]52") {
+		t.Error("the sequence should leave the frame after the hold")
+	}
+}
+
+func TestModel_Yank_FileFallback(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	var out bytes.Buffer
+	m.clipboard = clipboard.NewWriter(&out)
+	m.clipboard.SetEnv(func(string) string { return "" })
+	m.clipboard.SetTempDir(t.TempDir())
+
+	m.aircraft["YNK000"] = &radar.Target{Hex: "YNK000", Callsign: "ROW0"}
+	m.sortedTargets = []string{"YNK000"}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m.Update(cmd())
+
+	if out.Len() != 0 || strings.HasPrefix(m.View(), "\x1b]52") {
+		t.Error("nothing should be sent to the terminal on fallback")
+	}
+	if !strings.HasPrefix(m.notification, "No clipboard; saved ") {
+		t.Errorf("expected temp file notification, got %q", m.notification)
+	}
+}
+
+func TestModel_Yank_EmptyList(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd != nil {
+		t.Error("expected no command with an empty list")
+	}
+	if m.notification != "No rows to copy" {
+		t.Errorf("expected empty notification, got %q", m.notification)
+	}
+}
+
+// =============================================================================
+// Privacy Mode Tests
+// =============================================================================
+
+func TestModel_PrivacyMode_DisplayUsesApproximatePosition(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon = 52.3676, 4.9041
+	m := NewModel(cfg)
+
+	m.updateTarget(&codec.Aircraft{Hex: "PRV001", Lat: floatPtr(52.5), Lon: floatPtr(5.1)}, false)
+	target := m.aircraft["PRV001"]
+	trueDist, trueBrg := target.Distance, target.Bearing
+
+	if shown := m.displayTarget(target); shown != target {
+		t.Error("expected the target itself when privacy is off")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	if !m.IsPrivacyMode() {
+		t.Fatal("expected I to enable privacy mode")
+	}
+
+	shown := m.displayTarget(target)
+	if shown.Distance == trueDist && shown.Bearing == trueBrg {
+		t.Error("expected displayed distance/bearing to come from the approximate position")
+	}
+	if target.Distance != trueDist || target.Bearing != trueBrg {
+		t.Error("privacy mode must not change the stored (alerting) distance")
+	}
+
+	lat, lon := m.displayReceiver()
+	if lat == cfg.Connection.ReceiverLat || lon == cfg.Connection.ReceiverLon {
+		t.Error("expected the displayed receiver position to be rounded")
+	}
+	if lat2, lon2 := m.displayReceiver(); lat2 != lat || lon2 != lon {
+		t.Error("expected the approximate position to be stable")
+	}
+
+	if got := m.formatDistance(target); got != fmt.Sprintf("%.1fnm", shown.Distance) {
+		t.Errorf("expected target panel to show approximate distance, got %q", got)
+	}
+}
+
+func TestModel_PrivacyMode_EventsAndStatusBar(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon = 52.3676, 4.9041
+	cfg.Display.PrivacyMode = true
+	m := NewModel(cfg)
+
+	var events []Event
+	m.SetEventHandler(func(ev Event) { events = append(events, ev) })
+	m.updateTarget(&codec.Aircraft{Hex: "PRV002", Lat: floatPtr(52.5), Lon: floatPtr(5.1)}, true)
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Target.Distance == m.aircraft["PRV002"].Distance {
+		t.Error("expected emitted events to carry the approximate distance")
+	}
+
+	if !strings.Contains(m.renderStatusBar(), "≈POS") {
+		t.Error("expected approximate position indicator in the status bar")
+	}
+
+	m.togglePrivacy()
+	if strings.Contains(m.renderStatusBar(), "≈POS") {
+		t.Error("indicator should go away when privacy is off")
+	}
+}
+
+// =============================================================================
+// Heading-Up Tests
+// =============================================================================
+
+func newHeadingUpModel(t *testing.T) (*Model, *time.Time) {
+	t.Helper()
+	m := NewModel(newTestConfig())
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	m.aircraft["HDG001"] = &radar.Target{Hex: "HDG001", HasLat: true, HasLon: true, Track: 270, HasTrack: true}
+	m.selectedHex = "HDG001"
+	return m, &clock
+}
+
+// settleRotation runs enough ticks for the easing to finish
+func settleRotation(m *Model) {
+	for i := 0; i < 40; i++ {
+		m.updateRotation()
+	}
+}
+
+func TestModel_HeadingUp_RotatesToSelectedTrack(t *testing.T) {
+	m, _ := newHeadingUpModel(t)
+
+	m.handleRadarKey("ctrl+u")
+	if !m.IsHeadingUp() {
+		t.Fatal("expected heading-up mode with a selected target")
+	}
+	settleRotation(m)
+	if m.rotation != 270 {
+		t.Errorf("expected rotation 270, got %.1f", m.rotation)
+	}
+	if !strings.Contains(m.renderStatusBar(), "HDG↑270") {
+		t.Error("expected heading-up indicator in the status bar")
+	}
+
+	m.handleRadarKey("ctrl+u")
+	settleRotation(m)
+	if m.IsHeadingUp() || m.rotation != 0 {
+		t.Errorf("expected north-up after toggling off, got rotation %.1f", m.rotation)
+	}
+}
+
+func TestModel_HeadingUp_EasesShortWayRound(t *testing.T) {
+	m, _ := newHeadingUpModel(t)
+	m.aircraft["HDG001"].Track = 350
+	m.toggleHeadingUp()
+
+	// From 0 to 350 should turn left through 359, not right through 180
+	if m.rotation < 340 {
+		t.Errorf("expected first step to turn left past north, got %.1f", m.rotation)
+	}
+}
+
+func TestModel_HeadingUp_RateLimitsTrackChanges(t *testing.T) {
+	m, clock := newHeadingUpModel(t)
+	m.toggleHeadingUp()
+	settleRotation(m)
+
+	// Small wobble inside the deadband is ignored
+	*clock = clock.Add(10 * time.Second)
+	m.aircraft["HDG001"].Track = 273
+	settleRotation(m)
+	if m.rotation != 270 {
+		t.Errorf("expected jitter within deadband ignored, got %.1f", m.rotation)
+	}
+
+	// A real turn is followed, but not again until the interval passes
+	m.aircraft["HDG001"].Track = 290
+	settleRotation(m)
+	if m.targetRotation != 290 {
+		t.Errorf("expected rotation to follow the turn, got %.1f", m.targetRotation)
+	}
+	m.aircraft["HDG001"].Track = 310
+	settleRotation(m)
+	if m.targetRotation != 290 {
+		t.Errorf("expected update held until the interval passes, got %.1f", m.targetRotation)
+	}
+	*clock = clock.Add(headingUpInterval)
+	m.updateRotation()
+	if m.targetRotation != 310 {
+		t.Errorf("expected update after the interval, got %.1f", m.targetRotation)
+	}
+}
+
+func TestModel_HeadingUp_FallsBackWhenSelectionClears(t *testing.T) {
+	m, _ := newHeadingUpModel(t)
+	m.toggleHeadingUp()
+	settleRotation(m)
+
+	m.selectedHex = ""
+	settleRotation(m)
+	if m.rotation != 0 || m.IsHeadingUp() {
+		t.Errorf("expected north-up without a selection, got %.1f", m.rotation)
+	}
+	if !m.headingUp {
+		t.Error("mode should stay armed for the next selection")
+	}
+
+	m.selectedHex = "HDG001"
+	m.updateRotation()
+	if m.targetRotation != 270 {
+		t.Errorf("expected new selection to rotate straight away, got %.1f", m.targetRotation)
+	}
+}
+
+// =============================================================================
+// Signal Statistics Tests
+// =============================================================================
+
+func TestModel_UpdateTarget_SignalStats(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	for _, rssi := range []float64{-20, -30, -10} {
+		m.updateTarget(&codec.Aircraft{Hex: "SIG001", RSSI: floatPtr(rssi)}, false)
+	}
+	// An update without RSSI keeps the stats
+	m.updateTarget(&codec.Aircraft{Hex: "SIG001"}, false)
+
+	s := m.aircraft["SIG001"].Signal
+	if s.Samples != 3 || s.Min != -30 || s.Max != -10 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+	if s.Avg <= s.Min || s.Avg >= s.Max {
+		t.Errorf("expected average between min and max, got %.2f", s.Avg)
+	}
+	if got := m.formatSignalStats(m.aircraft["SIG001"]); !strings.HasPrefix(got, "-30.0/") || !strings.HasSuffix(got, "/-10.0") {
+		t.Errorf("unexpected formatted stats %q", got)
+	}
+	if m.formatSignalStats(&radar.Target{}) != "" {
+		t.Error("expected no stats without samples")
+	}
+}
+
+func TestModel_SignalReport_WeakestIncludesRemoved(t *testing.T) {
+	m := NewModel(newTestConfig())
+	for i := 0; i < 12; i++ {
+		hex := fmt.Sprintf("W%05d", i)
+		m.updateTarget(&codec.Aircraft{Hex: hex, RSSI: floatPtr(-float64(i))}, false)
+	}
+	m.updateTarget(&codec.Aircraft{Hex: "NORSSI"}, false)
+
+	// The weakest aircraft is cleaned up but stays in the session report
+	m.removeAircraft("W00011")
+
+	report := m.SignalReport()
+	if len(report.Weakest) != weakestReported {
+		t.Fatalf("expected %d entries, got %d", weakestReported, len(report.Weakest))
+	}
+	if report.Weakest[0].Hex != "W00011" || report.Weakest[9].Hex != "W00002" {
+		t.Errorf("unexpected order: first %s, last %s", report.Weakest[0].Hex, report.Weakest[9].Hex)
+	}
+	for _, e := range report.Weakest {
+		if e.Hex == "NORSSI" {
+			t.Error("aircraft without RSSI should not be reported")
+		}
+	}
+}
+
+func TestModel_SignalReport_FarthestPerSector(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.updateTarget(&codec.Aircraft{Hex: "NEAR01", Distance: floatPtr(20), Bearing: floatPtr(10)}, false)
+	m.updateTarget(&codec.Aircraft{Hex: "FAR001", Distance: floatPtr(80), Bearing: floatPtr(25)}, false)
+	m.updateTarget(&codec.Aircraft{Hex: "WEST01", Distance: floatPtr(40), Bearing: floatPtr(359)}, false)
+	m.removeAircraft("FAR001")
+	// Coming closer later doesn't lower the sector record
+	m.updateTarget(&codec.Aircraft{Hex: "WEST01", Distance: floatPtr(5), Bearing: floatPtr(359)}, false)
+
+	report := m.SignalReport()
+	if len(report.Farthest) != signalSectors {
+		t.Fatalf("expected %d sectors, got %d", signalSectors, len(report.Farthest))
+	}
+	if s := report.Farthest[0]; s.Hex != "FAR001" || s.Distance != 80 {
+		t.Errorf("expected FAR001 at 80nm in sector 0, got %+v", s)
+	}
+	if s := report.Farthest[11]; s.Start != 330 || s.Hex != "WEST01" || s.Distance != 40 {
+		t.Errorf("expected WEST01 at 40nm in sector 330, got %+v", s)
+	}
+	if report.Farthest[5].Hex != "" {
+		t.Error("expected empty sector without aircraft")
+	}
+}
+
+func TestModel_ExportSignalReport(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Export.Directory = t.TempDir()
+	m := NewModel(cfg)
+
+	m.handleRadarKey("ctrl+r")
+	if m.notification != "No signal data to report" {
+		t.Errorf("unexpected notification %q", m.notification)
+	}
+
+	m.updateTarget(&codec.Aircraft{Hex: "SIG001", RSSI: floatPtr(-25), Distance: floatPtr(30), Bearing: floatPtr(90)}, false)
+	m.handleRadarKey("ctrl+r")
+	if !strings.HasPrefix(m.notification, "Signal report: skyspy_signal_") {
+		t.Errorf("unexpected notification %q", m.notification)
+	}
+}
+
+func TestModel_ExportOptions(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	if m.exportOptions().SignalStats {
+		t.Error("signal stats should be off by default")
+	}
+	cfg.Export.SignalStats = true
+	if !m.exportOptions().SignalStats {
+		t.Error("expected signal stats from config")
+	}
+}
+
+// =============================================================================
+// Trail Retention Tests
+// =============================================================================
+
+func TestModel_TrailRetentionFromConfig(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.TrailMinutes = 2
+	cfg.Display.TrailMaxPoints = 500
+	m := NewModel(cfg)
+	if m.trailTracker.Retention() != 2*time.Minute || m.trailTracker.MaxPoints() != 500 {
+		t.Errorf("unexpected retention %v / budget %d", m.trailTracker.Retention(), m.trailTracker.MaxPoints())
+	}
+
+	cfg.Display.TrailMinutes = 0
+	if trailRetention(cfg) != 5*time.Minute {
+		t.Errorf("expected default retention, got %v", trailRetention(cfg))
+	}
+}
+
+func TestModel_GetTrailsForRadar_OnlyWithinRetention(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.TrailMinutes = 1
+	m := NewModel(cfg)
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+
+	m.trailTracker.AddPosition("TRL001", 52.0, 4.0)
+	clock = clock.Add(40 * time.Second)
+	m.trailTracker.AddPosition("TRL001", 52.1, 4.1)
+	clock = clock.Add(40 * time.Second)
+
+	// The first point is past the window though nothing has pruned it yet
+	trail := m.GetTrailsForRadar()["TRL001"]
+	if len(trail) != 1 || trail[0].Lat != 52.1 {
+		t.Errorf("expected only the recent point to be drawn, got %v", trail)
+	}
+}
+
+func TestTrailDotSettings(t *testing.T) {
+	cfg := newTestConfig()
+	if trailStyle(cfg) != trailStyleLine || trailDots(cfg) != 6 {
+		t.Errorf("defaults: style %q, %d dots", trailStyle(cfg), trailDots(cfg))
+	}
+	// One dot per sweep: 360/6 ticks of 150ms
+	if got := trailDotInterval(cfg); got != 9*time.Second {
+		t.Errorf("sweep interval = %v, want 9s", got)
+	}
+	cfg.Display.TrailDotSeconds = 4
+	cfg.Display.TrailDots = 0
+	cfg.Display.TrailStyle = " Dots "
+	if trailDotInterval(cfg) != 4*time.Second || trailDots(cfg) != defaultTrailDots || trailStyle(cfg) != trailStyleDots {
+		t.Errorf("configured: %v, %d dots, style %q", trailDotInterval(cfg), trailDots(cfg), trailStyle(cfg))
+	}
+}
+
+func TestModel_CycleTrailStyle(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.handleRadarKey("ctrl+b")
+	if m.config.Display.TrailStyle != trailStyleDots || m.notification != "Trails: history dots every 9s" {
+		t.Errorf("style %q, notification %q", m.config.Display.TrailStyle, m.notification)
+	}
+	m.handleRadarKey("ctrl+b")
+	if m.config.Display.TrailStyle != trailStyleLine || m.notification != "Trails: lines" {
+		t.Errorf("style %q, notification %q", m.config.Display.TrailStyle, m.notification)
+	}
+}
+
+func TestModel_GetTrailDotsForRadar(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.ShowTrails = true
+	cfg.Display.TrailStyle = trailStyleDots
+	cfg.Display.TrailDotSeconds = 10
+	cfg.Display.TrailDots = 4
+	m := NewModel(cfg)
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+
+	// A slow and a fast aircraft reporting every 5 s for a minute
+	for s := 0; s <= 60; s += 5 {
+		m.trailTracker.AddPosition("SLOW01", 52.0, 4.0+float64(s)*0.0005)
+		m.trailTracker.AddPosition("FAST01", 52.2, 4.0+float64(s)*0.002)
+		clock = clock.Add(5 * time.Second)
+	}
+
+	dots := m.GetTrailDotsForRadar()
+	slow, fast := dots["SLOW01"], dots["FAST01"]
+	if len(slow) != 4 || len(fast) != 4 {
+		t.Fatalf("got %d slow and %d fast dots, want 4 each", len(slow), len(fast))
+	}
+	for i, want := range []int{4, 3, 2, 1} {
+		if slow[i].Age != want {
+			t.Errorf("dot %d age = %d, want %d", i, slow[i].Age, want)
+		}
+	}
+	slowSpan := slow[3].Lon - slow[0].Lon
+	fastSpan := fast[3].Lon - fast[0].Lon
+	if fastSpan < 3.9*slowSpan {
+		t.Errorf("fast dots should spread 4x as far: %.4f vs %.4f", fastSpan, slowSpan)
+	}
+
+	m.width, m.height = 120, 40
+	if view := m.View(); view == "" {
+		t.Error("expected the radar to render with history dots")
+	}
+}
+
+func TestModel_MaybePruneTrails(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.TrailMinutes = 1
+	m := NewModel(cfg)
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+
+	m.trailTracker.AddPosition("TRL001", 52.0, 4.0)
+	m.trailTracker.AddPosition("TRL001", 52.1, 4.1)
+	m.maybePruneTrails()
+
+	clock = clock.Add(2 * time.Minute)
+	m.handleTick()
+	if got := m.trailTracker.Stats().Points; got != 0 {
+		t.Errorf("expected expired points pruned on tick, got %d", got)
+	}
+
+	// Not again until the interval has passed
+	m.trailTracker.AddPosition("TRL001", 52.2, 4.2)
+	clock = clock.Add(2 * time.Minute)
+	m.lastTrailPrune = clock.Add(-trailPruneInterval / 2)
+	m.maybePruneTrails()
+	if got := m.trailTracker.Stats().Points; got != 1 {
+		t.Errorf("expected no prune within the interval, got %d points", got)
+	}
+}
+
+func TestModel_FormatTrailMemory(t *testing.T) {
+	m := NewModel(newTestConfig())
+	if got := m.formatTrailMemory(); got != "0 pts 0K" {
+		t.Errorf("unexpected empty trail memory %q", got)
+	}
+	m.trailTracker.AddPosition("TRL001", 52.0, 4.0)
+	if got := m.formatTrailMemory(); !strings.HasPrefix(got, "1 pts ") {
+		t.Errorf("unexpected trail memory %q", got)
+	}
+	if !strings.Contains(m.renderStatsPanel(), "TRL") {
+		t.Error("stats panel should show trail memory")
+	}
+}
+
+// =============================================================================
+// Clock Skew Tests
+// =============================================================================
+
+func newSkewModel(t *testing.T) (*Model, *time.Time) {
+	t.Helper()
+	m := NewModel(newTestConfig())
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	return m, &clock
+}
+
+func TestModel_ClockSkew_Warning(t *testing.T) {
+	m, _ := newSkewModel(t)
+	if m.IsClockSkewed() || strings.Contains(m.renderStatusBar(), "CLOCK") {
+		t.Error("no warning expected before skew is measured")
+	}
+
+	m.SetClockSkew(30 * time.Second)
+	if m.IsClockSkewed() {
+		t.Error("skew under the threshold should not warn")
+	}
+
+	for _, tt := range []struct {
+		skew time.Duration
+		want string
+	}{
+		{-2 * time.Hour, "CLOCK-2h"},
+		{90 * time.Second, "CLOCK+1m"},
+		{-400 * 24 * time.Hour, "CLOCK-400d"},
+	} {
+		m.SetClockSkew(tt.skew)
+		if !m.IsClockSkewed() {
+			t.Errorf("expected %v to be flagged", tt.skew)
+		}
+		if bar := m.renderStatusBar(); !strings.Contains(bar, tt.want) {
+			t.Errorf("expected %q in status bar", tt.want)
+		}
+	}
+}
+
+func TestModel_ClockSkew_FromEnvelopeTimes(t *testing.T) {
+	for _, offset := range []time.Duration{3 * time.Minute, -3 * time.Minute} {
+		m, clock := newSkewModel(t)
+		sent := clock.Add(offset)
+		msg := codec.Message{
+			Type:      string(codec.AircraftUpdate),
+			Data:      json.RawMessage(`{"hex":"abc123"}`),
+			Timestamp: json.RawMessage(fmt.Sprintf("%d", sent.Unix())),
+		}
+		m.IngestAircraftMessage(msg)
+		if skew, ok := m.ClockSkew(); !ok || skew != offset {
+			t.Errorf("expected skew %v from the first sample, got %v", offset, skew)
+		}
+
+		// Later samples are smoothed rather than taken outright
+		msg.Timestamp = json.RawMessage(fmt.Sprintf("%d", clock.Unix()))
+		m.IngestAircraftMessage(msg)
+		if skew, _ := m.ClockSkew(); skew == offset || skew == 0 {
+			t.Errorf("expected a smoothed skew, got %v", skew)
+		}
+	}
+
+	// Messages without a time leave the estimate alone
+	m, _ := newSkewModel(t)
+	m.IngestAircraftMessage(codec.Message{Type: string(codec.AircraftUpdate), Data: json.RawMessage(`{"hex":"abc123"}`)})
+	if _, ok := m.ClockSkew(); ok {
+		t.Error("expected no skew without envelope times")
+	}
+}
+
+func TestModel_MessageAge_AdjustedForSkew(t *testing.T) {
+	m, clock := newSkewModel(t)
+
+	// Our clock is two hours fast, as measured from the auth Date header
+	m.SetClockSkew(-2 * time.Hour)
+	m.handleACARSMsg(codec.Message{
+		Type:      string(codec.ACARSMessage),
+		Data:      json.RawMessage(`{"callsign":"UAL1","label":"H1","text":"hello"}`),
+		Timestamp: json.RawMessage(fmt.Sprintf("%d", clock.Add(-2*time.Hour).Unix())),
+	})
+	if len(m.acarsMessages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(m.acarsMessages))
+	}
+	msg := m.acarsMessages[0]
+
+	*clock = clock.Add(30 * time.Second)
+	if got := m.messageAgeLabel(msg); got != "30s" {
+		t.Errorf("expected 30s, got %q", got)
+	}
+	if !strings.Contains(m.renderACARSPanel(), "30s UAL1") {
+		t.Error("expected the age in the ACARS panel")
+	}
+
+	// Without the skew the age would come out two hours late
+	m.clockSkew = 0
+	if age, _ := m.messageAge(msg); age < 2*time.Hour {
+		t.Errorf("expected unadjusted age over 2h, got %v", age)
+	}
+
+	// Server time ahead of the unadjusted clock clamps to zero, not negative
+	m.clockSkew = -4 * time.Hour
+	if age, _ := m.messageAge(msg); age != 0 {
+		t.Errorf("expected age clamped to 0, got %v", age)
+	}
+
+	// Messages without an envelope time age on the local clock
+	local := ACARSMessage{Received: clock.Add(-5 * time.Minute)}
+	if got := m.messageAgeLabel(local); got != "5m" {
+		t.Errorf("expected 5m, got %q", got)
+	}
+	if got := m.messageAgeLabel(ACARSMessage{}); got != "" {
+		t.Errorf("expected no age for an untimed message, got %q", got)
+	}
+}
+
+// =============================================================================
+// ACARS Alert Tests
+// =============================================================================
+
+func newACARSAlertModel(t *testing.T) *Model {
+	t.Helper()
+	cfg := newTestConfig()
+	cfg.Alerts.Enabled = true
+	cfg.Alerts.Rules = []config.AlertRuleConfig{
+		{
+			ID:      "mayday",
+			Name:    "Mayday Text",
+			Enabled: true,
+			Conditions: []config.ConditionConfig{
+				{Type: "acars_text", Value: "MAYDAY|MEDICAL", Regex: true},
+			},
+			Actions: []config.ActionConfig{
+				{Type: "notify", Message: "{callsign} {label}: {text}"},
+				{Type: "highlight"},
+			},
+		},
+	}
+	return NewModel(cfg)
+}
+
+func TestModel_ACARSAlert_TrackedSender(t *testing.T) {
+	m := newACARSAlertModel(t)
+	m.aircraft["abc123"] = &radar.Target{Hex: "abc123", Callsign: "UAL1"}
+
+	m.handleACARSMsg(createMockACARSMessage(codec.ACARSData{Callsign: "UAL1", Label: "H1", Text: "pax medical"}))
+
+	if m.notification != "UAL1 H1: pax medical" {
+		t.Errorf("unexpected notification %q", m.notification)
+	}
+	if !m.alertState.IsHighlighted("abc123") {
+		t.Error("tracked sender should be highlighted")
+	}
+	recent := m.GetRecentAlerts()
+	if len(recent) != 1 || recent[0].Hex != "abc123" || !recent[0].ACARS {
+		t.Errorf("expected one ACARS alert linked to abc123, got %+v", recent)
+	}
+}
+
+func TestModel_ACARSAlert_UntrackedSender(t *testing.T) {
+	m := newACARSAlertModel(t)
+
+	m.handleACARSMsg(createMockACARSMessage(codec.ACARSData{Callsign: "DAL2", Label: "H1", Text: "WX REQ"}))
+	if len(m.GetRecentAlerts()) != 0 {
+		t.Error("unrelated text should not alert")
+	}
+
+	m.handleACARSMsg(createMockACARSMessage(codec.ACARSData{Callsign: "DAL2", Label: "H1", Text: "MAYDAY"}))
+	recent := m.GetRecentAlerts()
+	if len(recent) != 1 || recent[0].Hex != "" || recent[0].Callsign != "DAL2" {
+		t.Errorf("expected one unlinked alert for DAL2, got %+v", recent)
+	}
+}
+
+func TestModel_ACARSAlert_RegexRoundTrip(t *testing.T) {
+	m := newACARSAlertModel(t)
+	rule := m.GetAlertRules()[0]
+	if !rule.Conditions[0].Regex {
+		t.Fatal("expected regex flag to be loaded from config")
+	}
+	cfg := alertRuleToConfig(rule)
+	if !cfg.Conditions[0].Regex {
+		t.Error("expected regex flag to be saved to config")
+	}
+}
+
+func TestModel_RenderAlertRulesPanel_MessageRules(t *testing.T) {
+	m := newACARSAlertModel(t)
+	if !strings.Contains(m.renderAlertRulesPanel(), "MSG") {
+		t.Error("message rules should be tagged in the rules panel")
+	}
+}
+
+// =============================================================================
+// Glyph Set Tests
+// =============================================================================
+
+func TestModel_View_ASCIIGlyphs(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.GlyphSet = theme.GlyphsASCII
+	cfg.Display.ShowACARS = true
+	m := NewModel(cfg)
+	m.aircraft["abc123"] = &radar.Target{
+		Hex: "abc123", Callsign: "UAL1", Lat: 52.5, Lon: 5.0, HasLat: true, HasLon: true,
+		Altitude: 35000, HasAlt: true, Track: 90, HasTrack: true, Vertical: 1500, HasVS: true,
+		RSSI: -10, HasRSSI: true, Distance: 20, Bearing: 45,
+	}
+	m.selectedHex = "abc123"
+	m.pinned = []string{"abc123"}
+	m.acarsMessages = append(m.acarsMessages, ACARSMessage{Callsign: "UAL1", Label: "H1", Text: "TEST"})
+
+	for _, mode := range []ViewMode{ViewRadar, ViewSettings, ViewHelp, ViewOverlays, ViewSearch, ViewAlertRules} {
+		m.viewMode = mode
+		out := ansi.Strip(m.View())
+		for i, r := range out {
+			if r >= 0x80 {
+				t.Errorf("view mode %d: non-ASCII %q at byte %d", mode, r, i)
+				break
+			}
+		}
+	}
+
+	m.setTheme("amber")
+	if m.theme.Glyphs != theme.GlyphsASCII {
+		t.Error("changing theme should keep the configured glyph set")
+	}
+}
+
+// =============================================================================
+// Alert Import/Export Tests
+// =============================================================================
+
+func TestModel_HandleAlertRulesKey_EnableDisableAll(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.viewMode = ViewAlertRules
+
+	m.handleAlertRulesKey("D")
+	if n := m.GetAlertStats().EnabledRules; n != 0 {
+		t.Errorf("expected all rules disabled, %d still enabled", n)
+	}
+	if !m.IsAlertsEnabled() {
+		t.Error("D should not turn alerts off globally")
+	}
+
+	m.handleAlertRulesKey("A")
+	stats := m.GetAlertStats()
+	if stats.EnabledRules != stats.TotalRules {
+		t.Errorf("expected all %d rules enabled, got %d", stats.TotalRules, stats.EnabledRules)
+	}
+}
+
+func TestImportAlerts_Merge(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Rules = []config.AlertRuleConfig{
+		{ID: "keep", Name: "Keep", Enabled: true, Conditions: []config.ConditionConfig{{Type: "squawk", Value: "7700"}}},
+		{ID: "same", Name: "Same", Enabled: true, Conditions: []config.ConditionConfig{{Type: "military", Value: "true"}}},
+	}
+	f := &AlertsFile{
+		Rules: []config.AlertRuleConfig{
+			{ID: "keep", Name: "Keep v2", Enabled: true, Conditions: []config.ConditionConfig{{Type: "squawk", Value: "7600"}}},
+			cfg.Alerts.Rules[1],
+			{ID: "new", Name: "New", Enabled: true, Conditions: []config.ConditionConfig{{Type: "speed_above", Value: "500"}}},
+			{ID: "bad", Name: "Bad", Conditions: []config.ConditionConfig{{Type: "speed_above", Value: "fast"}}},
+		},
+		Geofences: []config.GeofenceConfig{
+			{ID: "zone", Name: "Zone", Type: "circle", CenterLat: 52, CenterLon: 4, RadiusNM: 5, Enabled: true},
+			{ID: "line", Name: "Line", Type: "polygon", Points: []config.GeofencePointConfig{{Lat: 52, Lon: 4}}},
+		},
+	}
+
+	changes := ImportAlerts(cfg, f, false)
+	want := map[string]ImportAction{
+		"keep": ImportSkipped, "same": ImportUnchanged, "new": ImportAdded,
+		"bad": ImportInvalid, "zone": ImportAdded, "line": ImportInvalid,
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d", len(want), len(changes))
+	}
+	for _, c := range changes {
+		if c.Action != want[c.ID] {
+			t.Errorf("%s: got %s, want %s", c.ID, c.Action, want[c.ID])
+		}
+		if (c.Action == ImportInvalid) != (c.Err != nil) {
+			t.Errorf("%s: Err should be set only for invalid entries, got %v", c.ID, c.Err)
+		}
+	}
+	if len(cfg.Alerts.Rules) != 3 || cfg.Alerts.Rules[0].Name != "Keep" {
+		t.Errorf("unexpected rules after merge: %+v", cfg.Alerts.Rules)
+	}
+	if len(cfg.Alerts.Geofences) != 1 {
+		t.Errorf("expected 1 geofence, got %d", len(cfg.Alerts.Geofences))
+	}
+
+	changes = ImportAlerts(cfg, f, true)
+	if changes[0].Action != ImportReplaced || cfg.Alerts.Rules[0].Name != "Keep v2" {
+		t.Errorf("overwrite should replace keep, got %s %q", changes[0].Action, cfg.Alerts.Rules[0].Name)
+	}
+}
+
+func TestImportAlerts_KeepsDefaults(t *testing.T) {
+	cfg := newTestConfig()
+	f := &AlertsFile{Rules: []config.AlertRuleConfig{
+		{ID: "new", Name: "New", Enabled: true, Conditions: []config.ConditionConfig{{Type: "hex", Value: "AE*"}}},
+	}}
+	ImportAlerts(cfg, f, false)
+	if want := len(alerts.DefaultAlertRules()) + 1; len(cfg.Alerts.Rules) != want {
+		t.Errorf("expected defaults plus the import (%d rules), got %d", want, len(cfg.Alerts.Rules))
+	}
+}
+
+func TestAlertsFile_RoundTrip(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Geofences = []config.GeofenceConfig{{ID: "zone", Name: "Zone", Type: "circle", CenterLat: 52, CenterLon: 4, RadiusNM: 5}}
+	path := t.TempDir() + "/alerts.json"
+
+	if err := WriteAlertsFile(path, ExportAlerts(cfg)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := ReadAlertsFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Version != alertsFileVersion || len(f.Rules) != len(alerts.DefaultAlertRules()) || len(f.Geofences) != 1 {
+		t.Errorf("unexpected round trip: version %d, %d rules, %d geofences", f.Version, len(f.Rules), len(f.Geofences))
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadAlertsFile(path); err == nil {
+		t.Error("expected an error for a newer file version")
+	}
+}
+
+// =============================================================================
+// Duplicate Address Tests
+// =============================================================================
+
+func TestModel_HexConflict_TeleportingUpdates(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon = 52.0, 4.5
+	cfg.Alerts.Rules = []config.AlertRuleConfig{{
+		ID: "dup", Name: "Duplicate Hex", Enabled: true,
+		Conditions: []config.ConditionConfig{{Type: "hex_conflict", Value: "true"}},
+		Actions:    []config.ActionConfig{{Type: "notify"}},
+	}}
+	m := NewModel(cfg)
+
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+
+	// One aircraft cruising north at 450 kt and another 60 nm away
+	// squawking the same address, taking turns to be heard
+	step := 450.0 / 3600 / 60
+	for i := 0; i < 10; i++ {
+		lat := 52.0 + float64(i/2)*2*step
+		if i%2 == 1 {
+			lat++
+		}
+		ac := codec.Aircraft{Hex: "DUP001", Flight: "TEST1", Lat: floatPtr(lat), Lon: floatPtr(4.5), GS: floatPtr(450)}
+		m.updateTarget(&ac, i == 0)
+		clock = clock.Add(time.Second)
+	}
+
+	target := m.aircraft["DUP001"]
+	if !target.Conflicted {
+		t.Fatal("expected the target to be flagged as conflicted")
+	}
+	for _, pos := range m.trailTracker.GetTrail("DUP001") {
+		if pos.Lat > 52.5 {
+			t.Errorf("trail includes a jumped position at %.3f", pos.Lat)
+		}
+	}
+	if info, ok := m.GetConflict("DUP001"); !ok || info.Jumps != 5 {
+		t.Errorf("expected 5 jumps, got %+v", info)
+	}
+
+	m.selectedHex = "DUP001"
+	if panel := ansi.Strip(m.renderTargetPanel()); !strings.Contains(panel, "DUP HEX") {
+		t.Error("expected a duplicate address note in the target panel")
+	}
+	if radarView := ansi.Strip(m.renderRadar()); !strings.Contains(radarView, m.glyphs().Conflict) {
+		t.Error("expected the conflict glyph on the radar")
+	}
+
+	found := false
+	for _, a := range m.alertState.RecentAlerts {
+		if a.Rule.ID == "dup" && a.Hex == "DUP001" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected the hex_conflict rule to trigger")
+	}
+}
+
+func TestModel_HexConflict_RelocationSplitsTrail(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+
+	step := 450.0 / 3600 / 60
+	for i := 0; i < 10; i++ {
+		lat := 52.0 + float64(i)*step
+		if i >= 5 {
+			lat++
+		}
+		ac := codec.Aircraft{Hex: "JMP001", Lat: floatPtr(lat), Lon: floatPtr(4.5), AltBaro: intPtr(30000), GS: floatPtr(450)}
+		m.updateTarget(&ac, i == 0)
+		clock = clock.Add(time.Second)
+	}
+
+	trail := m.trailTracker.GetTrail("JMP001")
+	breaks := 0
+	for _, pos := range trail {
+		if pos.Break {
+			breaks++
+		}
+	}
+	if breaks != 1 {
+		t.Fatalf("expected the trail to be split once, got %d breaks in %d points", breaks, len(trail))
+	}
+
+	// The jump itself adds no along-track distance to the profile
+	points := m.GetProfilePoints("JMP001")
+	if last := points[len(points)-1].X; last > 1 {
+		t.Errorf("expected the profile to skip the jump, spans %.1f nm", last)
+	}
+}
+
+// =============================================================================
+// Coordinate Format Tests
+// =============================================================================
+
+func TestModel_TargetPanel_CoordFormats(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon = 38.8, -77.2
+	m := NewModel(cfg)
+
+	m.updateTarget(&codec.Aircraft{Hex: "POS001", Lat: floatPtr(38.889467), Lon: floatPtr(-77.035239)}, true)
+	m.selectedHex = "POS001"
+	target := m.aircraft["POS001"]
+
+	tests := map[string]string{
+		"decimal": "38.88947, -77.03524",
+		"dms":     "38°53'22\"N 77°02'07\"W",
+		"mgrs":    "18S UJ 23483 06479",
+	}
+	for format, want := range tests {
+		m.config.Display.CoordFormat = format
+		if got := m.formatPosition(target); got != want {
+			t.Errorf("%s: got %q, want %q", format, got, want)
+		}
+		if panel := ansi.Strip(m.renderTargetPanel()); !strings.Contains(panel, want) {
+			t.Errorf("%s: expected the position in the target panel", format)
+		}
+	}
+
+	want := fmt.Sprintf("R-%03d°/%.1fnm", int(math.Round(target.Bearing)), target.Distance)
+	if got := m.formatRelative(target); got != want {
+		t.Errorf("relative position: got %q, want %q", got, want)
+	}
+	if !strings.Contains(ansi.Strip(m.renderTargetPanel()), want) {
+		t.Error("expected the relative position in the target panel")
+	}
+
+	m.config.Display.CoordFormat = "mgrs"
+	if opts := m.exportOptions(); opts.CoordFormat != geo.CoordMGRS {
+		t.Errorf("expected exports to use the MGRS format, got %q", opts.CoordFormat)
+	}
+}
+
+func TestModel_FormatPosition_ASCIIDegree(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.CoordFormat = "dms"
+	cfg.Display.GlyphSet = theme.GlyphsASCII
+	m := NewModel(cfg)
+
+	target := &radar.Target{Hex: "POS002", Lat: -33.8568, Lon: 151.2153, HasLat: true, HasLon: true}
+	if got := m.formatPosition(target); got != "33d51'24\"S 151d12'55\"E" {
+		t.Errorf("got %q", got)
+	}
+	if got := m.formatPosition(&radar.Target{Hex: "POS003"}); got != dashPlaceholder {
+		t.Errorf("expected a placeholder without a position, got %q", got)
+	}
+}
+
+// =============================================================================
+// Search Debounce Tests
+// =============================================================================
+
+func TestModel_SearchDebounce(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	m.aircraft["ABC123"] = &radar.Target{Hex: "ABC123", Callsign: "UAL123"}
+	m.aircraft["DEF456"] = &radar.Target{Hex: "DEF456", Callsign: "DAL456"}
+
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+	m.enterSearchMode()
+
+	typeKey := func(c rune) tea.Cmd {
+		_, cmd := m.handleSearchKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{c}})
+		return cmd
+	}
+
+	// The first keystroke after a pause updates at once
+	if cmd := typeKey('A'); cmd != nil {
+		t.Error("expected no deferred update for the first keystroke")
+	}
+	if len(m.searchResults) != 2 {
+		t.Fatalf("expected both aircraft to match A, got %v", m.searchResults)
+	}
+
+	// Fast typing defers the update until typing pauses
+	clock = clock.Add(10 * time.Millisecond)
+	first := typeKey('L')
+	clock = clock.Add(10 * time.Millisecond)
+	second := typeKey('1')
+	if first == nil || second == nil {
+		t.Fatal("expected deferred updates while typing quickly")
+	}
+	if len(m.searchResults) != 2 {
+		t.Error("results should not follow the query until typing pauses")
+	}
+
+	m.Update(first())
+	if len(m.searchResults) != 2 {
+		t.Error("a superseded update should be ignored")
+	}
+	m.Update(second())
+	if len(m.searchResults) != 1 || m.searchResults[0] != "ABC123" {
+		t.Errorf("expected only UAL123 to match AL1, got %v", m.searchResults)
+	}
+}
+
+// =============================================================================
+// Hex Normalization Tests
+// =============================================================================
+
+func TestModel_MixedCaseHexTracksOneTarget(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.SetAudioEnabled(false)
+
+	snapshot, _ := json.Marshal(map[string]interface{}{
+		"aircraft": map[string]codec.Aircraft{
+			"3c6589": {Flight: "DLH4AB", Lat: floatPtr(52.3), Lon: floatPtr(4.8)},
+		},
+	})
+	m.handleAircraftMsg(codec.Message{Type: string(codec.AircraftSnapshot), Data: snapshot})
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{
+		Hex:     "3C6589",
+		AltBaro: intPtr(36000),
+	}))
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{
+		Hex:    " 3c6589 ",
+		Flight: "DLH4AB",
+		Squawk: "1000",
+	}))
+
+	if len(m.aircraft) != 1 {
+		t.Fatalf("expected exactly one target, got %d: %v", len(m.aircraft), trackedHexes(m))
+	}
+	target, ok := m.aircraft["3C6589"]
+	if !ok {
+		t.Fatal("target should be keyed by the upper-case hex")
+	}
+	if target.Hex != "3C6589" || target.Callsign != "DLH4AB" || target.Squawk != "1000" {
+		t.Errorf("expected merged data from both casings, got %+v", target)
+	}
+	if m.trailTracker.TrailLength("3c6589") != m.trailTracker.TrailLength("3C6589") {
+		t.Error("trail lookups should not depend on case")
+	}
+
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftRemove, codec.Aircraft{Hex: "3c6589"}))
+	if len(m.aircraft) != 0 {
+		t.Errorf("removal in the other casing should drop the target, %d left", len(m.aircraft))
+	}
+}
+
+func TestModel_MixedCaseHexMigratesExistingKey(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.SetAudioEnabled(false)
+
+	// State left under a lower-case key is moved on the next update
+	m.aircraft["abc123"] = &radar.Target{Hex: "abc123", Callsign: "UAL1"}
+	m.lastSeen["abc123"] = m.now()
+	m.sortedTargets = []string{"abc123"}
+	m.selectedHex = "abc123"
+	m.pinned = []string{"abc123"}
+
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{Hex: "ABC123", Flight: "UAL1", AltBaro: intPtr(5000)}))
+
+	if len(m.aircraft) != 1 || m.aircraft["ABC123"] == nil {
+		t.Fatalf("expected the target to move to ABC123, got %v", trackedHexes(m))
+	}
+	if _, ok := m.lastSeen["abc123"]; ok {
+		t.Error("old last-seen key should be gone")
+	}
+	if m.selectedHex != "ABC123" || len(m.pinned) != 1 || m.pinned[0] != "ABC123" {
+		t.Errorf("selection and pins should follow the target, got %q %v", m.selectedHex, m.pinned)
+	}
+	if m.aircraft["ABC123"].Altitude != 5000 {
+		t.Error("update should apply to the migrated target")
+	}
+}
+
+// trackedHexes lists tracked hex keys for failure messages
+func trackedHexes(m *Model) []string {
+	keys := make([]string, 0, len(m.aircraft))
+	for hex := range m.aircraft {
+		keys = append(keys, hex)
+	}
+	return keys
+}
+
+// =============================================================================
+// Point of Interest Tests
+// =============================================================================
+
+// poiTestConfig places HOME on the receiver and FARM 5nm north of it
+func poiTestConfig() *config.Config {
+	cfg := newTestConfig()
+	lat, lon := cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon
+	cfg.POI.Points = []config.POIConfig{
+		{Label: "HOME", Lat: lat, Lon: lon},
+		{Label: "FARM", Lat: lat + 5.0/60, Lon: lon},
+	}
+	cfg.POI.CorridorNM = 2
+	return cfg
+}
+
+func TestModel_GetPOIApproach_WithinCorridor(t *testing.T) {
+	cfg := poiTestConfig()
+	cfg.POI.Active = "home"
+	m := NewModel(cfg)
+
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+	m.aircraft["CPA001"] = inboundTarget(cfg)
+	m.lastSeen["CPA001"] = clock
+
+	if got := m.formatPOI("CPA001"); got != "0.0nm in 5:00" {
+		t.Errorf("expected \"0.0nm in 5:00\", got %q", got)
+	}
+
+	clock = clock.Add(70 * time.Second)
+	if cpa, ok := m.GetPOIApproach("CPA001"); !ok || formatETA(cpa) != "3:50" {
+		t.Errorf("expected ETA to count down to 3:50, got %v %v", cpa, ok)
+	}
+}
+
+func TestModel_GetPOIApproach_OutsideCorridor(t *testing.T) {
+	cfg := poiTestConfig()
+	cfg.POI.Active = "FARM"
+	m := NewModel(cfg)
+	m.aircraft["CPA001"] = inboundTarget(cfg)
+
+	// The inbound track passes 5nm south of FARM
+	if _, ok := m.GetPOIApproach("CPA001"); ok {
+		t.Error("a track outside the corridor should have no ETA")
+	}
+	if got := m.formatPOI("CPA001"); got != dashPlaceholder {
+		t.Errorf("expected placeholder, got %q", got)
+	}
+
+	cfg.POI.CorridorNM = 6
+	if _, ok := m.GetPOIApproach("CPA001"); !ok {
+		t.Error("widening the corridor should give an ETA")
+	}
+
+	cfg.POI.Active = ""
+	if _, ok := m.GetPOIApproach("CPA001"); ok {
+		t.Error("no ETA should be given without an active point")
+	}
+}
+
+func TestModel_SortTargetsByPOI(t *testing.T) {
+	cfg := poiTestConfig()
+	cfg.POI.Active = "HOME"
+	m := NewModel(cfg)
+
+	m.aircraft["CPA001"] = inboundTarget(cfg)
+	near := inboundTarget(cfg)
+	near.Hex, near.Lon = "CPA002", cfg.Connection.ReceiverLon+(near.Lon-cfg.Connection.ReceiverLon)/2
+	m.aircraft["CPA002"] = near
+	outbound := inboundTarget(cfg)
+	outbound.Hex, outbound.Track = "CPA003", 90
+	m.aircraft["CPA003"] = outbound
+
+	hexes := []string{"CPA003", "CPA001", "CPA002"}
+	m.sortTargetsByPOI(hexes)
+
+	want := []string{"CPA002", "CPA001", "CPA003"}
+	for i := range want {
+		if hexes[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, hexes)
+		}
+	}
+}
+
+func TestModel_CyclePOI(t *testing.T) {
+	m := NewModel(poiTestConfig())
+
+	m.cyclePOI()
+	if m.config.POI.Active != "HOME" || m.notification != "POI: HOME" {
+		t.Errorf("expected HOME active, got %q (%q)", m.config.POI.Active, m.notification)
+	}
+	m.cyclePOI()
+	if m.config.POI.Active != "FARM" {
+		t.Errorf("expected FARM active, got %q", m.config.POI.Active)
+	}
+	m.cyclePOI()
+	if m.config.POI.Active != "" || m.notification != "POI: OFF" {
+		t.Errorf("expected no active point, got %q (%q)", m.config.POI.Active, m.notification)
+	}
+}
+
+func TestModel_POIMarkersOverlay(t *testing.T) {
+	m := NewModel(poiTestConfig())
+	list := m.overlayManager.GetOverlayList()
+	if len(list) != 1 || list[0].Key != "poi" {
+		t.Fatalf("expected a POI marker overlay, got %+v", list)
+	}
+
+	m.saveOverlays()
+	for _, ov := range m.config.Overlays.Overlays {
+		if ov.Key == "poi" {
+			t.Error("generated POI overlay should not be saved to the config")
+		}
+	}
+}
+
+// =============================================================================
+// Reconnect Resync Tests
+// =============================================================================
+
+// snapshotOf builds an aircraft snapshot message from a list of aircraft
+func snapshotOf(t *testing.T, aircraft ...codec.Aircraft) codec.Message {
+	t.Helper()
+	data, err := json.Marshal(aircraft)
+	if err != nil {
+		t.Fatalf("marshal snapshot: %v", err)
+	}
+	return codec.Message{Type: string(codec.AircraftSnapshot), Data: data}
+}
+
+func TestModel_ReconnectResyncsFromSnapshot(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.SetAudioEnabled(false)
+
+	// First session: three aircraft with trails and some traffic
+	m.IngestAircraftMessage(snapshotOf(t,
+		codec.Aircraft{Hex: "AAA001", Lat: floatPtr(52.0), Lon: floatPtr(4.0)},
+		codec.Aircraft{Hex: "AAA002", Lat: floatPtr(52.1), Lon: floatPtr(4.1)},
+		codec.Aircraft{Hex: "AAA003", Lat: floatPtr(52.2), Lon: floatPtr(4.2)},
+	))
+	m.IngestAircraftMessage(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{Hex: "AAA002", Lat: floatPtr(52.11), Lon: floatPtr(4.11)}))
+	m.alertedAircraft["AAA003"] = true
+
+	// The server restarts and the feed reconnects with a smaller picture
+	m.IngestAircraftMessage(codec.Message{Type: string(codec.FeedReconnected)})
+	if got := m.GetStats(); got.ConnMessages != 0 || got.Messages != 1 {
+		t.Errorf("reconnect should reset only per-connection counts, got %+v", got)
+	}
+	m.IngestAircraftMessage(snapshotOf(t,
+		codec.Aircraft{Hex: "AAA001", Lat: floatPtr(52.0), Lon: floatPtr(4.0)},
+	))
+
+	if len(m.aircraft) != 1 || m.aircraft["AAA001"] == nil {
+		t.Fatalf("expected only AAA001 after resync, got %v", trackedHexes(m))
+	}
+	for _, hex := range []string{"AAA002", "AAA003"} {
+		if n := m.trailTracker.TrailLength(hex); n != 0 {
+			t.Errorf("trail for %s should be dropped, has %d points", hex, n)
+		}
+	}
+	if m.alertedAircraft["AAA003"] {
+		t.Error("alert state for a stale target should be dropped")
+	}
+	if got := m.GetStats(); got.Peak != 3 || got.Aircraft != 1 {
+		t.Errorf("peak should survive the resync, got %+v", got)
+	}
+	if m.notification != "Resynced after reconnect (removed 2 stale)" {
+		t.Errorf("unexpected notification %q", m.notification)
+	}
+	if m.resyncPending != nil {
+		t.Error("resync should be finished after the snapshot")
+	}
+}
+
+func TestModel_ResyncCountsOnlyCarriedOverTargets(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.SetAudioEnabled(false)
+
+	m.IngestAircraftMessage(snapshotOf(t,
+		codec.Aircraft{Hex: "AAA001"},
+		codec.Aircraft{Hex: "AAA002"},
+	))
+	m.IngestAircraftMessage(codec.Message{Type: string(codec.FeedReconnected)})
+
+	// Traffic from the new session confirms AAA001 before its snapshot
+	m.IngestAircraftMessage(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{Hex: "AAA001"}))
+	m.IngestAircraftMessage(createMockAircraftMessage(codec.AircraftNew, codec.Aircraft{Hex: "BBB001"}))
+	if m.resyncPending["AAA001"] || !m.resyncPending["AAA002"] {
+		t.Errorf("only unconfirmed targets should stay pending, got %v", m.resyncPending)
+	}
+	if got := m.GetStats().ConnMessages; got != 2 {
+		t.Errorf("expected 2 messages this connection, got %d", got)
+	}
+
+	// AAA001 is dropped too, but it was heard from the new session
+	m.IngestAircraftMessage(snapshotOf(t, codec.Aircraft{Hex: "BBB001"}))
+	if len(m.aircraft) != 1 || m.aircraft["BBB001"] == nil {
+		t.Fatalf("expected only BBB001 after resync, got %v", trackedHexes(m))
+	}
+	if m.notification != "Resynced after reconnect (removed 1 stale)" {
+		t.Errorf("unexpected notification %q", m.notification)
+	}
+}
+
+func TestModel_SnapshotWithoutReconnectIsQuiet(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.SetAudioEnabled(false)
+
+	m.IngestAircraftMessage(snapshotOf(t, codec.Aircraft{Hex: "AAA001"}))
+	m.IngestAircraftMessage(snapshotOf(t, codec.Aircraft{Hex: "AAA002"}))
+	if m.notification != "" {
+		t.Errorf("a snapshot outside a reconnect should not notify, got %q", m.notification)
+	}
+}
+
+// =============================================================================
+// Trail Gap Tests
+// =============================================================================
+
+func TestModel_TrailGapFromConfig(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.TrailGapSeconds = 90
+	m := NewModel(cfg)
+	if got := m.trailTracker.GapThreshold(); got != 90*time.Second {
+		t.Errorf("expected 90s gap threshold, got %v", got)
+	}
+
+	cfg.Display.TrailGapSeconds = 0
+	if got := trailGap(cfg); got != 60*time.Second {
+		t.Errorf("expected default gap threshold, got %v", got)
+	}
+	cfg.Display.TrailGapSeconds = -1
+	if got := trailGap(cfg); got != 0 {
+		t.Errorf("expected gap detection off, got %v", got)
+	}
+}
+
+func TestModel_GetTrailsForRadar_MarksGaps(t *testing.T) {
+	m := NewModel(newTestConfig())
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+
+	m.trailTracker.AddPosition("ABC123", 52.0, 4.0)
+	clock = clock.Add(5 * time.Second)
+	m.trailTracker.AddPosition("ABC123", 52.1, 4.1)
+	clock = clock.Add(3 * time.Minute)
+	m.trailTracker.AddPosition("ABC123", 52.5, 4.5)
+
+	points := m.GetTrailsForRadar()["ABC123"]
+	if len(points) != 3 {
+		t.Fatalf("expected 3 trail points, got %d", len(points))
+	}
+	if points[1].Break || !points[2].Break {
+		t.Errorf("expected only the point after the gap to be marked, got %+v", points)
+	}
+}
+
+// =============================================================================
+// What's New Tests
+// =============================================================================
+
+func TestModel_ShowWhatsNew(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	m.ShowWhatsNew("v0.2.0", nil)
+	if m.viewMode != ViewRadar {
+		t.Error("an empty changelog should not open the panel")
+	}
+
+	m.ShowWhatsNew("v0.2.0", []changelog.Entry{{Version: "0.2.0", Items: []string{"Altitude ribbon beside the radar"}}})
+	if m.viewMode != ViewWhatsNew {
+		t.Fatalf("expected the what's new panel, got view %d", m.viewMode)
+	}
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"WHAT'S NEW IN v0.2.0", "Altitude ribbon beside the radar"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the panel", want)
+		}
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if m.viewMode != ViewRadar {
+		t.Error("any key should dismiss the panel")
+	}
+}
+
+// =============================================================================
+// Squawk Severity Tests
+// =============================================================================
+
+func TestModel_CustomSquawkCodes(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Squawks["7400"] = "emergency"
+	cfg.Alerts.Squawks["7600"] = "warning"
+	m := NewModel(cfg)
+	// A second model with the default codes doesn't change the first's
+	other := NewModel(newTestConfig())
+
+	for hex, squawk := range map[string]string{"UAV01": "7400", "NORDO": "7600", "EMR01": "7700"} {
+		m.updateTarget(&codec.Aircraft{Hex: hex, Squawk: squawk}, true)
+		other.updateTarget(&codec.Aircraft{Hex: hex, Squawk: squawk}, true)
+	}
+	m.updateStats()
+	if m.emergencyCount != 2 {
+		t.Errorf("expected 7400 and 7700 counted as emergencies, got %d", m.emergencyCount)
+	}
+	if !other.aircraft["NORDO"].IsEmergency() || other.aircraft["UAV01"].IsEmergency() {
+		t.Error("the other model should keep the default codes")
+	}
+
+	// The warning code alerts through the warning rule
+	nordo := *m.aircraft["NORDO"]
+	nordo.Hex = "NORDO2"
+	triggered := m.alertState.CheckAircraft(&nordo, nil)
+	if len(triggered) != 1 || triggered[0].Rule.ID != "warning_squawk" {
+		t.Errorf("expected the warning squawk rule, got %+v", triggered)
+	}
+
+	if got := m.getSquawkStyle(m.aircraft["NORDO"]).GetForeground(); got != m.theme.Warning {
+		t.Errorf("expected the warning color for 7600, got %v", got)
+	}
+}
+
+func TestModel_SquawkCodesDefaultFallback(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Squawks = nil
+	m := NewModel(cfg)
+
+	for _, code := range []string{"7500", "7600", "7700"} {
+		if !(&radar.Target{Squawk: code, SquawkCodes: m.squawkCodes}).IsEmergency() {
+			t.Errorf("%s should fall back to an emergency", code)
+		}
+	}
+
+	// Invalid entries are skipped with a notice
+	cfg.Alerts.Squawks = map[string]string{"7400": "urgent"}
+	m = NewModel(cfg)
+	if !strings.Contains(m.notification, "7400") {
+		t.Errorf("expected a notice about the bad entry, got %q", m.notification)
+	}
+	if (&radar.Target{Squawk: "7400", SquawkCodes: m.squawkCodes}).IsEmergency() {
+		t.Error("an invalid entry should not be applied")
+	}
+}
+
+// =============================================================================
+// Region Tagging Tests
+// =============================================================================
+
+func newRegionModel(t *testing.T) *Model {
+	t.Helper()
+	m := NewModel(newTestConfig())
+	m.overlayManager.AddOverlay(&geo.GeoOverlay{
+		Name:       "Sectors",
+		SourceFile: "/tmp/sectors.geojson",
+		Features: []geo.GeoFeature{
+			{Type: geo.OverlayPolygon, Name: "ZMP 12", Points: []geo.GeoPoint{
+				{Lat: 45, Lon: -94}, {Lat: 46, Lon: -94}, {Lat: 46, Lon: -93}, {Lat: 45, Lon: -93},
+			}},
+		},
+	}, "sectors")
+	return m
+}
+
+func TestModel_RegionTaggingToggle(t *testing.T) {
+	m := newRegionModel(t)
+	m.updateTarget(&codec.Aircraft{Hex: "RGN01", Lat: floatPtr(45.5), Lon: floatPtr(-93.5)}, true)
+	if got := m.aircraft["RGN01"].Region; got != "" {
+		t.Fatalf("region tagging is opt-in, got %q", got)
+	}
+
+	m.viewMode = ViewOverlays
+	m.overlayCursor = 0
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if m.notification != "Region tagging: ON" {
+		t.Errorf("notification = %q", m.notification)
+	}
+	if got := m.aircraft["RGN01"].Region; got != "ZMP 12" {
+		t.Errorf("existing aircraft should be re-tagged, got %q", got)
+	}
+	if !m.config.Overlays.Overlays[0].RegionTagging {
+		t.Error("region tagging should be saved with the overlay")
+	}
+
+	// Updates without a position keep the last region
+	m.updateTarget(&codec.Aircraft{Hex: "RGN01", AltBaro: intPtr(12000)}, false)
+	if got := m.aircraft["RGN01"].Region; got != "ZMP 12" {
+		t.Errorf("expected the region kept without a fix, got %q", got)
+	}
+	m.updateTarget(&codec.Aircraft{Hex: "RGN01", Lat: floatPtr(47), Lon: floatPtr(-93.5)}, false)
+	if got := m.aircraft["RGN01"].Region; got != "" {
+		t.Errorf("expected no region outside the polygon, got %q", got)
+	}
+}
+
+func TestModel_RegionColumnAndDetail(t *testing.T) {
+	m := newRegionModel(t)
+	m.overlayManager.ToggleRegionTagging("sectors")
+	m.rebuildRegions()
+	m.config.Display.ShowRegionColumn = true
+	m.updateTarget(&codec.Aircraft{Hex: "RGN01", Flight: "TST1", Lat: floatPtr(45.5), Lon: floatPtr(-93.5)}, true)
+	m.sortedTargets = []string{"RGN01"}
+	m.selectedHex = "RGN01"
+
+	list := ansi.Strip(m.renderTargetList())
+	if !strings.Contains(list, "REGION") || !strings.Contains(list, "ZMP 12") {
+		t.Errorf("expected the region column in the list:\n%s", list)
+	}
+	if detail := ansi.Strip(m.renderTargetPanel()); !strings.Contains(detail, "RGN") {
+		t.Errorf("expected the region row in the details:\n%s", detail)
+	}
+
+	// Long names are cut by character, never through a multi-byte one
+	m.aircraft["RGN01"].Region = "Zürich Öst Sektor"
+	if list := ansi.Strip(m.renderTargetList()); !utf8.ValidString(list) || !strings.Contains(list, "Zürich Ö") || strings.Contains(list, "Zürich Ös") {
+		t.Errorf("expected the region cut to 8 characters:\n%s", list)
+	}
+
+	m.config.Display.ShowRegionColumn = false
+	if list := ansi.Strip(m.renderTargetList()); strings.Contains(list, "REGION") {
+		t.Error("the region column should be optional")
+	}
+}
+
+// =============================================================================
+// Split Screen Tests
+// =============================================================================
+
+func TestModel_SplitScreenToggle(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'|'}})
+	if !m.splitActive() {
+		t.Fatal("| should turn on split screen")
+	}
+
+	radarView := ansi.Strip(m.renderRadar())
+	first := strings.Split(radarView, "\n")[0]
+	if !strings.Contains(first, " 100nm ") || !strings.Contains(first, " 25nm ") {
+		t.Errorf("expected both pane ranges in the top border, got %q", first)
+	}
+
+	// Too narrow for two panes and the sidebar: fall back to one pane
+	m.width = m.splitMinWidth() - 1
+	if m.splitActive() {
+		t.Error("split screen should degrade below the width threshold")
+	}
+	if first := strings.Split(ansi.Strip(m.renderRadar()), "\n")[0]; strings.Contains(first, " 25nm ") {
+		t.Errorf("expected a single pane, got %q", first)
+	}
+	m.width = m.splitMinWidth()
+	if !m.splitActive() {
+		t.Error("split screen should come back once the terminal is wide enough")
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'|'}})
+	if m.splitActive() || m.config.Display.SplitScreen {
+		t.Error("| should turn split screen off again")
+	}
+}
+
+func TestModel_SplitPaneZoom(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Radar.RangeSteps = []int{25, 50, 100, 200, 400}
+	m := NewModel(cfg)
+	m.config.Display.SplitScreen = true
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	if !m.splitFocused() {
+		t.Fatal("Tab should make the right pane active")
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	if m.splitTargetRange != 50 || m.targetRange != 100 {
+		t.Errorf("zoom should only change the active pane: right %v, left %v", m.splitTargetRange, m.targetRange)
+	}
+	if m.config.Display.SplitRange != 50 {
+		t.Errorf("split range should be saved, got %d", m.config.Display.SplitRange)
+	}
+
+	// Zooming the right pane below its smallest range does nothing
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	if m.splitTargetRange != 25 {
+		t.Errorf("expected the right pane to stop at 25nm, got %v", m.splitTargetRange)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	if m.targetRange != 50 || m.splitTargetRange != 25 {
+		t.Errorf("left pane zoom: left %v, right %v", m.targetRange, m.splitTargetRange)
+	}
+
+	// Without split screen Tab does nothing and zoom drives the main scope
+	m.config.Display.SplitScreen = false
+	m.splitFocus = true
+	m.zoomIn()
+	if m.targetRange != 25 {
+		t.Errorf("expected the main scope to zoom, got %v", m.targetRange)
+	}
+}
+
+func TestModel_SplitPaneCenter(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.config.Display.SplitScreen = true
+	m.aircraft["SEL01"] = &radar.Target{Hex: "SEL01", Lat: 52.6, Lon: 4.9, HasLat: true, HasLon: true, Distance: 14}
+	m.aircraft["NBR01"] = &radar.Target{Hex: "NBR01", Lat: 52.7, Lon: 4.9, HasLat: true, HasLon: true, Distance: 20}
+	m.selectedHex = "SEL01"
+
+	if lat, _ := m.splitCenter(); lat != m.config.Connection.ReceiverLat {
+		t.Errorf("expected the receiver as center, got %v", lat)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	lat, lon := m.splitCenter()
+	if lat != 52.6 || lon != 4.9 {
+		t.Fatalf("expected the selected aircraft as center, got %v,%v", lat, lon)
+	}
+	shown := m.aircraftFrom(lat, lon)
+	if d := shown["SEL01"].Distance; d > 0.01 {
+		t.Errorf("selected aircraft should be at the center, got %.2fnm", d)
+	}
+	if d := shown["NBR01"].Distance; d < 5.9 || d > 6.1 {
+		t.Errorf("neighbor should be about 6nm from the center, got %.2fnm", d)
+	}
+	if m.aircraft["NBR01"].Distance != 20 {
+		t.Error("recentering must not change the tracked targets")
+	}
+
+	// Falls back to the receiver when the selection has no position
+	m.selectedHex = ""
+	if lat, _ := m.splitCenter(); lat != m.config.Connection.ReceiverLat {
+		t.Errorf("expected the receiver without a selection, got %v", lat)
+	}
+}
+
+// =============================================================================
+// Emitter Category Tests
+// =============================================================================
+
+func TestModel_EmitterCategory(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.updateTarget(&codec.Aircraft{Hex: "HEL01", Flight: "LIFE1", Category: " a7", Lat: floatPtr(52.4), Lon: floatPtr(4.9)}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "PLN01", Flight: "KLM1", Lat: floatPtr(52.5), Lon: floatPtr(4.9)}, true)
+
+	if got := m.aircraft["HEL01"].Category; got != "A7" {
+		t.Fatalf("expected the category normalized to A7, got %q", got)
+	}
+
+	m.selectedHex = "HEL01"
+	if detail := ansi.Strip(m.renderTargetPanel()); !strings.Contains(detail, "A7 Rotorcraft") {
+		t.Errorf("expected the category in the details:\n%s", detail)
+	}
+	m.selectedHex = "PLN01"
+	if detail := ansi.Strip(m.renderTargetPanel()); strings.Contains(detail, "Rotorcraft") {
+		t.Error("aircraft without a category should not show one")
+	}
+
+	m.searchQuery = "category:rotorcraft"
+	m.updateSearchResults()
+	if len(m.searchResults) != 1 || m.searchResults[0] != "HEL01" {
+		t.Errorf("expected only the rotorcraft to match, got %v", m.searchResults)
+	}
+}
+
+// =============================================================================
+// Do Not Disturb Tests
+// =============================================================================
+
+func TestModel_QuietHoursSchedule(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Audio.QuietHours = []config.QuietHoursRange{{Start: "22:00", End: "07:00"}}
+	m := NewModel(cfg)
+	m.quietHours.SetLocation(time.UTC)
+
+	night := time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return night }
+	if !m.doNotDisturb() {
+		t.Fatal("02:00 should be inside quiet hours")
+	}
+	if status := ansi.Strip(m.renderStatusBar()); !strings.Contains(status, "DND") {
+		t.Errorf("expected the DND indicator in the status bar:\n%s", status)
+	}
+
+	day := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return day }
+	if m.doNotDisturb() {
+		t.Error("noon should be outside quiet hours")
+	}
+	if status := ansi.Strip(m.renderStatusBar()); strings.Contains(status, "DND") {
+		t.Error("the DND indicator should only show while silenced")
+	}
+}
+
+func TestModel_DoNotDisturbOverride(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Audio.QuietHours = []config.QuietHoursRange{{Start: "22:00", End: "07:00"}}
+	m := NewModel(cfg)
+	m.quietHours.SetLocation(time.UTC)
+	m.now = func() time.Time { return time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC) }
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if !m.doNotDisturb() || m.notification != "DND: ON" {
+		t.Errorf("D should force DND on, got %v %q", m.doNotDisturb(), m.notification)
+	}
+
+	m.now = func() time.Time { return time.Date(2026, 10, 17, 23, 0, 0, 0, time.UTC) }
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if m.doNotDisturb() || m.notification != "DND: OFF" {
+		t.Errorf("D again should force alerts on inside quiet hours, got %v %q", m.doNotDisturb(), m.notification)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if !m.doNotDisturb() || m.notification != "DND: SCHEDULE (quiet now)" {
+		t.Errorf("D a third time should follow the schedule, got %v %q", m.doNotDisturb(), m.notification)
+	}
+}
+
+func TestModel_QuietHoursInvalid(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Audio.QuietHours = []config.QuietHoursRange{{Start: "late", End: "07:00"}}
+	m := NewModel(cfg)
+	if !strings.Contains(m.notification, "Quiet hours") {
+		t.Errorf("expected a notice about the bad range, got %q", m.notification)
+	}
+	if m.doNotDisturb() {
+		t.Error("an invalid range should not silence alerts")
+	}
+}
+
+// =============================================================================
+// Timed Overlay Feature Tests
+// =============================================================================
+
+// newTFRModel returns a model with a region-tagged TFR in force 1400-1800Z
+// and its clock just before the window opens
+func newTFRModel(t *testing.T) (*Model, *time.Time) {
+	t.Helper()
+	m := NewModel(newTestConfig())
+	clock := time.Date(2026, 10, 17, 13, 59, 50, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	m.overlayManager.AddOverlay(&geo.GeoOverlay{
+		Name:          "TFRs",
+		SourceFile:    "/tmp/tfr.geojson",
+		Enabled:       true,
+		RegionTagging: true,
+		Features: []geo.GeoFeature{
+			{Type: geo.OverlayPolygon, Name: "TFR 6/1234", Points: []geo.GeoPoint{
+				{Lat: 45, Lon: -94}, {Lat: 46, Lon: -94}, {Lat: 46, Lon: -93}, {Lat: 45, Lon: -93},
+			}, Window: geo.TimeWindow{
+				Start: time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC),
+			}},
+		},
+	}, "tfrs")
+	m.rebuildRegions()
+	return m, &clock
+}
+
+func TestModel_TimedFeatureTagging(t *testing.T) {
+	m, clock := newTFRModel(t)
+	m.handleTick()
+	m.updateTarget(&codec.Aircraft{Hex: "TFR01", Lat: floatPtr(45.5), Lon: floatPtr(-93.5)}, true)
+	if target := m.aircraft["TFR01"]; target.Region != "" || target.RegionRestricted {
+		t.Fatalf("an inactive TFR should not tag aircraft, got %q", target.Region)
+	}
+
+	*clock = clock.Add(10 * time.Second)
+	m.handleTick()
+	if target := m.aircraft["TFR01"]; target.Region != "TFR 6/1234" || !target.RegionRestricted {
+		t.Errorf("the TFR going active should re-tag aircraft inside, got %q %v", target.Region, target.RegionRestricted)
+	}
+
+	*clock = clock.Add(4 * time.Hour)
+	m.updateOverlayWindows()
+	if target := m.aircraft["TFR01"]; target.Region != "" || target.RegionRestricted {
+		t.Errorf("the TFR expiring should clear the tag, got %q", target.Region)
+	}
+}
+
+func TestModel_OverlayPanelShowsTimeWindows(t *testing.T) {
+	m, clock := newTFRModel(t)
+	m.handleTick()
+	m.viewMode = ViewOverlays
+	m.overlayCursor = 0
+
+	panel := ansi.Strip(m.renderOverlayPanel())
+	if !strings.Contains(panel, "TFR 6/1234") || !strings.Contains(panel, "INACTIVE") ||
+		!strings.Contains(panel, "17 Oct 1400-1800Z") {
+		t.Errorf("expected the TFR window in the panel:\n%s", panel)
+	}
+
+	*clock = clock.Add(time.Hour)
+	m.handleTick()
+	if panel := ansi.Strip(m.renderOverlayPanel()); strings.Contains(panel, "INACTIVE") || !strings.Contains(panel, "ACTIVE") {
+		t.Errorf("expected the TFR shown active at 1500Z:\n%s", panel)
+	}
+}
+
+// =============================================================================
+// Sign-In Tests
+// =============================================================================
+
+// fakeAuth is an Authenticator whose refresh and login are scripted
+type fakeAuth struct {
+	status     auth.Status
+	refreshErr error
+	refreshes  int
+	login      func(ctx context.Context, prompt auth.LoginPrompt) error
+}
+
+func (a *fakeAuth) Status() auth.Status { return a.status }
+
+func (a *fakeAuth) RefreshNow() error {
+	a.refreshes++
+	return a.refreshErr
+}
+
+func (a *fakeAuth) LoginWithPrompt(ctx context.Context, prompt auth.LoginPrompt) error {
+	return a.login(ctx, prompt)
+}
+
+// newAuthModel returns a model signed in as anna with 42 minutes left
+func newAuthModel(t *testing.T) (*Model, *fakeAuth) {
+	t.Helper()
+	m := NewModel(newTestConfig())
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	a := &fakeAuth{status: auth.Status{
+		Method:     "oidc",
+		Username:   "anna",
+		ExpiresAt:  now.Add(42 * time.Minute),
+		CanRefresh: true,
+		CanLogin:   true,
+		Required:   true,
+	}}
+	m.SetAuth(a)
+	return m, a
+}
+
+func TestModel_AuthStatusRow(t *testing.T) {
+	m, a := newAuthModel(t)
+	text := lipgloss.NewStyle()
+	warn := lipgloss.NewStyle().PaddingLeft(1)
+	alert := lipgloss.NewStyle().PaddingLeft(2)
+
+	if panel := ansi.Strip(m.renderStatsPanel()); !strings.Contains(panel, "USR  anna ⏲ 42m") {
+		t.Errorf("expected the sign-in row, got:\n%s", panel)
+	}
+
+	tests := []struct {
+		left    time.Duration
+		want    string
+		padding int
+	}{
+		{42 * time.Minute, "anna ⏲ 42m", 0},
+		{3*time.Hour + 5*time.Minute, "anna ⏲ 3h05m", 0},
+		{9 * time.Minute, "anna ⏲ 9m", 1},
+		{30 * time.Second, "anna ⏲ 30s", 1},
+		{-time.Minute, "anna ⏲ expired", 2},
+	}
+	for _, tt := range tests {
+		a.status.ExpiresAt = m.now().Add(tt.left)
+		value, style, ok := m.authStatusRow(text, warn, alert)
+		if !ok || value != tt.want || style.GetPaddingLeft() != tt.padding {
+			t.Errorf("%v left: got %q (padding %d), want %q (padding %d)",
+				tt.left, value, style.GetPaddingLeft(), tt.want, tt.padding)
+		}
+	}
+
+	a.status = auth.Status{Method: "api_key"}
+	if value, _, _ := m.authStatusRow(text, warn, alert); value != "API key" {
+		t.Errorf("API key sign-in = %q", value)
+	}
+	a.status = auth.Status{Method: "none", Required: true}
+	if value, style, _ := m.authStatusRow(text, warn, alert); value != "signed out" || style.GetPaddingLeft() != 2 {
+		t.Errorf("signed out = %q", value)
+	}
+	a.status = auth.Status{Method: "none"}
+	if _, _, ok := m.authStatusRow(text, warn, alert); ok {
+		t.Error("servers without sign-in should have no row")
+	}
+	if panel := NewModel(newTestConfig()).renderStatsPanel(); strings.Contains(panel, "USR") {
+		t.Error("no row without an authenticator")
+	}
+}
+
+func TestModel_AuthRefreshKey(t *testing.T) {
+	m, a := newAuthModel(t)
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if cmd == nil || m.notification != "Refreshing sign-in..." {
+		t.Fatalf("U should start a refresh, got %q", m.notification)
+	}
+	if _, again := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}}); again != nil {
+		t.Error("a second U while refreshing should do nothing")
+	}
+
+	a.status.ExpiresAt = m.now().Add(time.Hour)
+	m.Update(cmd())
+	if a.refreshes != 1 || m.notification != "Sign-in renewed: expires in 1h00m" {
+		t.Errorf("refreshes = %d, notification = %q", a.refreshes, m.notification)
+	}
+}
+
+func TestModel_AuthLoginAfterRefreshFails(t *testing.T) {
+	m, a := newAuthModel(t)
+	a.refreshErr = auth.ErrNoRefreshToken
+	signedIn := make(chan struct{})
+	a.login = func(ctx context.Context, prompt auth.LoginPrompt) error {
+		prompt("https://sso.example/authorize?x=1", false)
+		<-signedIn
+		return nil
+	}
+
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	_, cmd = m.Update(cmd())
+	if m.viewMode != ViewLogin || m.login == nil {
+		t.Fatalf("a refused refresh should open the sign-in panel")
+	}
+
+	_, cmd = m.Update(cmd())
+	panel := ansi.Strip(m.View())
+	if !strings.Contains(panel, "SIGN IN") || !strings.Contains(panel, "https://sso.example/authorize?x=1") {
+		t.Errorf("expected the sign-in URL in the panel:\n%s", panel)
+	}
+
+	close(signedIn)
+	m.Update(cmd())
+	if m.viewMode != ViewRadar || m.login != nil || m.notification != "Signed in: anna" {
+		t.Errorf("sign-in should close the panel, got view %v, notification %q", m.viewMode, m.notification)
+	}
+}
+
+func TestModel_AuthLoginCancel(t *testing.T) {
+	m, a := newAuthModel(t)
+	a.status.CanRefresh = false
+	a.login = func(ctx context.Context, prompt auth.LoginPrompt) error {
+		prompt("https://sso.example/authorize", true)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if a.refreshes != 0 || m.viewMode != ViewLogin {
+		t.Fatal("without a refresh token U should go straight to sign-in")
+	}
+	_, cmd = m.Update(cmd())
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if m.viewMode != ViewLogin {
+		t.Error("only Esc should close a sign-in in progress")
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.viewMode != ViewRadar || m.login != nil {
+		t.Fatal("Esc should cancel the sign-in")
+	}
+
+	// The cancelled login's result is dropped
+	m.notification = ""
+	m.Update(cmd())
+	if m.notification != "" || m.viewMode != ViewRadar {
+		t.Errorf("a cancelled sign-in should be ignored, got %q", m.notification)
+	}
+}
+
+func TestModel_AuthLoginFailureStaysOpen(t *testing.T) {
+	m, a := newAuthModel(t)
+	a.status.CanRefresh = false
+	a.login = func(ctx context.Context, prompt auth.LoginPrompt) error {
+		return errors.New("failed to get authorization URL: status 500")
+	}
+
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	m.Update(cmd())
+	if panel := ansi.Strip(m.renderLoginPanel()); !strings.Contains(panel, "status 500") {
+		t.Errorf("expected the error in the panel:\n%s", panel)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if m.viewMode != ViewRadar {
+		t.Error("any key should close a failed sign-in")
+	}
+}
+
+func TestModel_AuthRenewWithoutSignIn(t *testing.T) {
+	m := NewModel(newTestConfig())
+	if _, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}}); cmd != nil {
+		t.Error("nothing to renew without an authenticator")
+	}
+
+	m.SetAuth(&fakeAuth{status: auth.Status{Method: "none", Required: true}})
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if m.notification != "Sign-in unavailable; restart with --api-key" {
+		t.Errorf("notification = %q", m.notification)
+	}
+}
+
+// =============================================================================
+// Label Detail Tests
+// =============================================================================
+
+func TestModel_CycleLabelDetail(t *testing.T) {
+	m := NewModel(newTestConfig())
+	shiftL := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}}
+
+	want := []struct {
+		detail, notification string
+	}{
+		{"altitude", "Labels: CALLSIGN + ALT"},
+		{"speed", "Labels: CALLSIGN + ALT + SPD"},
+		{"none", "Labels: NONE"},
+		{"callsign", "Labels: CALLSIGN"},
+	}
+	for _, w := range want {
+		m.handleKey(shiftL)
+		if m.config.Display.LabelDetail != w.detail || m.notification != w.notification {
+			t.Errorf("got %q %q, want %q %q", m.config.Display.LabelDetail, m.notification, w.detail, w.notification)
+		}
+	}
+
+	// l still turns labels off; Shift+L brings them back at the next level
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if m.config.Display.ShowLabels || m.config.Display.LabelDetail != "callsign" {
+		t.Fatal("l should only toggle labels")
+	}
+	m.handleKey(shiftL)
+	if !m.config.Display.ShowLabels || m.config.Display.LabelDetail != "altitude" {
+		t.Errorf("Shift+L should turn labels back on, got %v %q", m.config.Display.ShowLabels, m.config.Display.LabelDetail)
+	}
+}
+
+func TestModel_LabelDetailOnRadar(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.LabelDetail = "altitude"
+	m := NewModel(cfg)
+	m.updateTarget(&codec.Aircraft{Hex: "LBL01", Flight: "UAL12", Lat: floatPtr(52.0), Lon: floatPtr(4.05),
+		AltBaro: intPtr(35000), GS: floatPtr(450)}, true)
+	m.selectedHex = "LBL01"
+
+	radarView := ansi.Strip(m.renderRadar())
+	if !strings.Contains(radarView, "UAL12") || !strings.Contains(radarView, m.formatAlt(m.aircraft["LBL01"])) {
+		t.Errorf("expected the callsign and FL350 on the radar:\n%s", radarView)
+	}
+	if strings.Contains(radarView, "450kt") {
+		t.Error("speed is only shown at the speed level")
+	}
+}
+
+// =============================================================================
+// Selection Reacquisition Tests
+// =============================================================================
+
+func newReacquireModel(t *testing.T, grace int) (*Model, *time.Time) {
+	t.Helper()
+	cfg := newTestConfig()
+	cfg.Display.SelectionGrace = grace
+	m := NewModel(cfg)
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	m.updateTarget(&codec.Aircraft{Hex: "4841A1", Flight: "KLM123", Lat: floatPtr(52.0), Lon: floatPtr(4.05)}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "4841A2", Flight: "KLM456", Lat: floatPtr(52.1), Lon: floatPtr(4.2)}, true)
+	m.selectedHex = "4841A1"
+	return m, &clock
+}
+
+func TestModel_ReacquireSelection(t *testing.T) {
+	m, clock := newReacquireModel(t, 120)
+
+	m.removeAircraft("4841A1")
+	if m.selectedHex != "" {
+		t.Fatal("a removed target can't stay selected")
+	}
+	if panel := ansi.Strip(m.renderTargetPanel()); !strings.Contains(panel, "Lost KLM123") {
+		t.Errorf("expected the lost selection in the target panel:\n%s", panel)
+	}
+	if radarView := ansi.Strip(m.renderRadar()); !strings.Contains(radarView, m.glyphs().Ghost+"KLM12") {
+		t.Errorf("expected a ghost at the last known position:\n%s", radarView)
+	}
+
+	*clock = clock.Add(20 * time.Second)
+	m.handleTick()
+	m.updateTarget(&codec.Aircraft{Hex: "4841A1", Flight: "KLM123", Lat: floatPtr(52.02), Lon: floatPtr(4.06)}, true)
+	if m.selectedHex != "4841A1" {
+		t.Errorf("returning target should be reselected, got %q", m.selectedHex)
+	}
+	if m.notification != "Reacquired KLM123" {
+		t.Errorf("notification = %q", m.notification)
+	}
+	if strings.Contains(ansi.Strip(m.renderRadar()), m.glyphs().Ghost) {
+		t.Error("ghost should go once the target is back")
+	}
+}
+
+func TestModel_ReacquireSelectionExpires(t *testing.T) {
+	m, clock := newReacquireModel(t, 120)
+	m.removeAircraft("4841A1")
+
+	*clock = clock.Add(2 * time.Minute)
+	m.handleTick()
+	if m.lostSelection != nil {
+		t.Error("lost selection should be forgotten after the grace period")
+	}
+	if strings.Contains(ansi.Strip(m.renderRadar()), m.glyphs().Ghost) {
+		t.Error("ghost should expire with the grace period")
+	}
+	m.updateTarget(&codec.Aircraft{Hex: "4841A1", Flight: "KLM123", Lat: floatPtr(52.0), Lon: floatPtr(4.05)}, true)
+	if m.selectedHex != "" {
+		t.Error("a target returning after the grace period should not be reselected")
+	}
+}
+
+func TestModel_ReacquireSelectionGivenUp(t *testing.T) {
+	m, _ := newReacquireModel(t, 120)
+	m.removeAircraft("4841A1")
+
+	// Picking another target gives up waiting
+	m.selectedHex = "4841A2"
+	m.updateTarget(&codec.Aircraft{Hex: "4841A1", Flight: "KLM123", Lat: floatPtr(52.0), Lon: floatPtr(4.05)}, true)
+	if m.selectedHex != "4841A2" {
+		t.Errorf("a returning target should not take over a new selection, got %q", m.selectedHex)
+	}
+}
+
+func TestModel_ReacquireSelectionDisabled(t *testing.T) {
+	m, _ := newReacquireModel(t, 0)
+	m.removeAircraft("4841A1")
+
+	if m.lostSelection != nil {
+		t.Error("a zero grace period should not remember the selection")
+	}
+	m.updateTarget(&codec.Aircraft{Hex: "4841A1", Flight: "KLM123", Lat: floatPtr(52.0), Lon: floatPtr(4.05)}, true)
+	if m.selectedHex != "" {
+		t.Error("reacquisition should be off with a zero grace period")
+	}
+}
+
+// =============================================================================
+// Local API Tests
+// =============================================================================
+
+// capturePublisher keeps every snapshot the radar publishes
+type capturePublisher struct {
+	snaps []*api.Snapshot
+}
+
+func (p *capturePublisher) Publish(snap *api.Snapshot) {
+	p.snaps = append(p.snaps, snap)
+}
+
+func TestModel_PublishesAPISnapshot(t *testing.T) {
+	m := NewModel(newTestConfig())
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	pub := &capturePublisher{}
+	m.SetAPI(pub)
+	if len(pub.snaps) != 1 || len(pub.snaps[0].Aircraft) != 0 {
+		t.Fatalf("SetAPI should publish the initial empty state, got %d snapshots", len(pub.snaps))
+	}
+
+	m.updateTarget(&codec.Aircraft{Hex: "AE1234", Flight: "RCH01", Military: true, Lat: floatPtr(52.1), Lon: floatPtr(4.1)}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "4841A1", Flight: "KLM123", Lat: floatPtr(52.0), Lon: floatPtr(4.05), AltBaro: intPtr(12000)}, true)
+	m.handleTick()
+
+	snap := pub.snaps[len(pub.snaps)-1]
+	if len(snap.Aircraft) != 2 || snap.Aircraft[0].Hex != "4841A1" || snap.Aircraft[1].Hex != "AE1234" {
+		t.Fatalf("expected both aircraft sorted by hex, got %+v", snap.Aircraft)
+	}
+	if snap.Stats.Aircraft != 2 || snap.Stats.Military != 1 {
+		t.Errorf("unexpected stats: %+v", snap.Stats)
+	}
+	trail := snap.Trails["4841A1"]
+	if len(trail) != 1 || trail[0].Altitude == nil || *trail[0].Altitude != 12000 || !trail[0].Time.Equal(clock) {
+		t.Errorf("expected the trail point with altitude and time, got %+v", trail)
+	}
+
+	// Later updates leave published snapshots alone
+	m.updateTarget(&codec.Aircraft{Hex: "4841A1", Flight: "KLM123", Lat: floatPtr(52.2), Lon: floatPtr(4.2), AltBaro: intPtr(14000)}, false)
+	m.aircraft["4841A1"].BaroAltitude = 99999
+	m.handleTick()
+	if *snap.Aircraft[0].Altitude != 12000 || *snap.Aircraft[0].Lat != 52.0 {
+		t.Errorf("published snapshot changed: %+v", snap.Aircraft[0])
+	}
+	if latest := pub.snaps[len(pub.snaps)-1]; *latest.Aircraft[0].Altitude != 99999 {
+		t.Errorf("the next snapshot should carry the update, got %d", *latest.Aircraft[0].Altitude)
+	}
+
+	// Points after a break carry the new segment
+	m.trailTracker.StartSegment("4841A1", 52.5, 4.5, 15000, true)
+	m.handleTick()
+	trail = pub.snaps[len(pub.snaps)-1].Trails["4841A1"]
+	if last := trail[len(trail)-1]; !last.Break || last.Segment != 1 || trail[0].Segment != 0 {
+		t.Errorf("expected the break to start segment 1, got %+v", trail)
+	}
+}
+
+func TestModel_APISnapshotAlerts(t *testing.T) {
+	m := NewModel(newTestConfig())
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.alertState.RecentAlerts = append(m.alertState.RecentAlerts, alerts.TriggeredAlert{
+		Rule:      &alerts.AlertRule{ID: "mil", Name: "Military", Priority: 2},
+		Hex:       "AE1234",
+		Callsign:  "RCH01",
+		Message:   "Military: RCH01",
+		Timestamp: at,
+	})
+
+	snap := m.apiSnapshot()
+	if len(snap.Alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(snap.Alerts))
+	}
+	want := api.Alert{RuleID: "mil", Rule: "Military", Priority: 2, Hex: "AE1234", Callsign: "RCH01", Message: "Military: RCH01", Time: at}
+	if snap.Alerts[0] != want {
+		t.Errorf("got %+v, want %+v", snap.Alerts[0], want)
+	}
+}
+
+// =============================================================================
+// Range Step Tests
+// =============================================================================
+
+func typeKeys(m *Model, keys string) {
+	for _, r := range keys {
+		m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestRangeSteps_Normalized(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Radar.RangeSteps = []int{100, 2, 65, 900, 65}
+	got := rangeSteps(cfg)
+	want := []int{5, 65, 100, 500}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	cfg.Radar.RangeSteps = nil
+	if len(rangeSteps(cfg)) != len(config.DefaultConfig().Radar.RangeSteps) {
+		t.Error("an empty list should fall back to the default steps")
+	}
+}
+
+func TestModel_CustomRangeEntry(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	typeKeys(m, "n65")
+	if !m.rangeEntryOpen || m.targetRange != 100 {
+		t.Fatalf("typing should not zoom before Enter: open %v, range %v", m.rangeEntryOpen, m.targetRange)
+	}
+	if !strings.Contains(ansi.Strip(m.renderStatusBar()), "RANGE 65_ nm") {
+		t.Error("status bar should show the range being typed")
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.rangeEntryOpen || m.targetRange != 65 || !m.customRange {
+		t.Fatalf("expected a custom 65nm range, got %v (custom %v)", m.targetRange, m.customRange)
+	}
+	if m.config.Radar.DefaultRange != 65 {
+		t.Errorf("the last range should be saved, got %d", m.config.Radar.DefaultRange)
+	}
+	if !strings.Contains(ansi.Strip(m.renderStatusBar()), " 65nm ") {
+		t.Error("status bar should show the custom range")
+	}
+
+	// +/- step to the neighbouring configured steps
+	m.zoomOut()
+	if m.targetRange != 75 || m.customRange {
+		t.Errorf("zoom out from 65 should reach 75, got %v", m.targetRange)
+	}
+	m.setCustomRange(65)
+	m.zoomIn()
+	if m.targetRange != 50 {
+		t.Errorf("zoom in from 65 should reach 50, got %v", m.targetRange)
+	}
+}
+
+func TestModel_CustomRangeEntry_Clamped(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	typeKeys(m, "n9999")
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.targetRange != 500 {
+		t.Errorf("expected 999 clamped to 500, got %v", m.targetRange)
+	}
+
+	typeKeys(m, "n2")
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.targetRange != 5 {
+		t.Errorf("expected 2 clamped to 5, got %v", m.targetRange)
+	}
+
+	// Esc leaves the range alone; q is not a digit and doesn't quit
+	typeKeys(m, "n4q")
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.rangeEntryOpen || m.targetRange != 5 {
+		t.Errorf("Esc should cancel, got open %v, range %v", m.rangeEntryOpen, m.targetRange)
+	}
+
+	// A typed value that matches a step is that step
+	m.setCustomRange(150)
+	if m.customRange || m.rangeOptions[m.rangeIdx] != 150 {
+		t.Errorf("150 should select the 150nm step, got idx %d", m.rangeIdx)
+	}
+}
+
+func TestModel_NewModel_CustomDefaultRange(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Radar.DefaultRange = 65
+	m := NewModel(cfg)
+
+	if m.targetRange != 65 || !m.customRange {
+		t.Errorf("expected to start at the saved 65nm, got %v", m.targetRange)
+	}
+}
+
+func TestModel_SpectrumBandsFollowRange(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.setCustomRange(40)
+	m.updateSpectrum()
+
+	labels := m.GetSpectrumLabels()
+	if last := labels[len(labels)-1]; last != "36-40" {
+		t.Errorf("expected bands to end at 40nm, got %v", labels)
+	}
+}
+
+// =============================================================================
+// String Table and Locale Tests
+// =============================================================================
+
+func loadTestStrings(t *testing.T, content string) *i18n.Table {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "strings.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	table, err := i18n.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestModel_TranslatedNotification(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.SetStrings(loadTestStrings(t, `{"notify.range": "Reichweite: %d sm"}`))
+
+	m.setCustomRange(65)
+	if m.notification != "Reichweite: 65 sm" {
+		t.Errorf("expected the translated notification, got %q", m.notification)
+	}
+
+	// Keys the file lacks stay in English
+	if got := m.tr("help.close"); got != "Press any key to close" {
+		t.Errorf("expected English for an untranslated key, got %q", got)
+	}
+}
+
+func TestModel_HelpWrapsLongTranslations(t *testing.T) {
+	m := NewModel(newTestConfig())
+	english := m.renderHelpPanel()
+
+	m.SetStrings(loadTestStrings(t, `{
+		"help.vu": "Pegelanzeige und Höhenprofil der ausgewählten Ziele umschalten",
+		"help.section_display": "ANZEIGE"
+	}`))
+	translated := ansi.Strip(m.renderHelpPanel())
+
+	if lipgloss.Width(translated) > lipgloss.Width(english) {
+		t.Errorf("translated help is %d wide, wider than the English %d", lipgloss.Width(translated), lipgloss.Width(english))
+	}
+	if !strings.Contains(translated, "ANZEIGE") {
+		t.Error("expected the translated section title")
+	}
+	for _, word := range []string{"Pegelanzeige", "Höhenprofil", "umschalten"} {
+		if !strings.Contains(translated, word) {
+			t.Errorf("expected %q kept whole after wrapping", word)
+		}
+	}
+}
+
+func TestModel_LocaleFormats(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.Locale = "de-DE"
+	m := NewModel(cfg)
+
+	target := &radar.Target{Distance: 12.5}
+	if got := m.formatDistance(target); got != "12,5nm" {
+		t.Errorf("expected a decimal comma, got %q", got)
+	}
+	if got := m.formatBytes(1.5e6); got != "1,5 MB" {
+		t.Errorf("expected a decimal comma in byte counts, got %q", got)
+	}
+}
+
+func TestModel_ExportAircraftGeoJSON(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Export.Directory = t.TempDir()
+	cfg.Display.ShowTrails = true
+	m := NewModel(cfg)
+
+	m.aircraft["GEO01"] = &radar.Target{Hex: "GEO01", Callsign: "GEO1", Lat: 52.3, Lon: 4.9, HasLat: true, HasLon: true}
+	m.aircraft["GEO02"] = &radar.Target{Hex: "GEO02", Callsign: "GEO2"}
+
+	m.handleRadarKey("ctrl+p")
+
+	files, err := filepath.Glob(filepath.Join(cfg.Export.Directory, "*.geojson"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one GeoJSON file, got %v", files)
+	}
+	if want := m.trf("notify.geojson_skipped", 1, 1, m.exportedName(files[0])); m.notification != want {
+		t.Errorf("notification = %q, want %q", m.notification, want)
+	}
+}
@@ -0,0 +1,297 @@
+// Package app provides sign-in status and renewal for the SkySpy radar
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/auth"
+)
+
+const (
+	// authWarnBefore is how long before expiry the sign-in row turns amber
+	authWarnBefore = 10 * time.Minute
+
+	// authNameWidth leaves room in the stats panel for the countdown
+	authNameWidth = 14
+)
+
+// Authenticator is the server sign-in the radar shows and renews;
+// *auth.Manager implements it
+type Authenticator interface {
+	Status() auth.Status
+	RefreshNow() error
+	LoginWithPrompt(ctx context.Context, prompt auth.LoginPrompt) error
+}
+
+// authRefreshMsg reports the outcome of a token refresh
+type authRefreshMsg struct {
+	err error
+}
+
+// authPromptMsg carries the sign-in URL from a running login; next yields
+// the login's remaining messages
+type authPromptMsg struct {
+	seq    int
+	url    string
+	opened bool
+	next   <-chan tea.Msg
+}
+
+// authLoginMsg reports the outcome of a login
+type authLoginMsg struct {
+	seq int
+	err error
+}
+
+// loginState is the in-app sign-in flow shown in the login panel
+type loginState struct {
+	seq    int
+	url    string
+	opened bool
+	done   bool
+	err    error
+	cancel context.CancelFunc
+}
+
+// SetAuth gives the radar the sign-in to show in the stats panel and renew
+// with U
+func (m *Model) SetAuth(a Authenticator) {
+	m.auth = a
+}
+
+// renewAuth refreshes the access token, or starts signing in again when
+// there is no refresh token to use
+func (m *Model) renewAuth() tea.Cmd {
+	if m.auth == nil {
+		m.notify(m.tr("notify.no_sign_in"))
+		return nil
+	}
+	if m.authRefreshing || m.login != nil {
+		return nil
+	}
+	st := m.auth.Status()
+	switch {
+	case st.Method == "api_key":
+		m.notify(m.tr("notify.api_key_renew"))
+		return nil
+	case st.CanRefresh:
+		m.authRefreshing = true
+		m.notify(m.tr("notify.refreshing"))
+		a := m.auth
+		return func() tea.Msg {
+			return authRefreshMsg{err: a.RefreshNow()}
+		}
+	case st.CanLogin:
+		return m.startLogin()
+	case st.Required:
+		m.notify(m.tr("notify.sign_in_unavailable"))
+		return nil
+	}
+	m.notify(m.tr("notify.no_sign_in"))
+	return nil
+}
+
+// handleAuthRefresh reports a refresh and falls back to signing in again
+// when the refresh token has been refused
+func (m *Model) handleAuthRefresh(msg authRefreshMsg) tea.Cmd {
+	m.authRefreshing = false
+	if msg.err == nil {
+		m.notify(m.trf("notify.sign_in_renewed", formatCountdown(m.auth.Status().ExpiresAt.Sub(m.now()))))
+		return nil
+	}
+	if m.auth.Status().CanLogin {
+		return m.startLogin()
+	}
+	m.notify(m.trf("notify.refresh_failed", msg.err.Error()))
+	return nil
+}
+
+// startLogin runs the browser sign-in in the background and opens the
+// login panel, which shows the URL until sign-in completes
+func (m *Model) startLogin() tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.loginSeq++
+	seq := m.loginSeq
+	m.login = &loginState{seq: seq, cancel: cancel}
+	m.viewMode = ViewLogin
+
+	a := m.auth
+	msgs := make(chan tea.Msg, 2)
+	go func() {
+		err := a.LoginWithPrompt(ctx, func(url string, opened bool) {
+			msgs <- authPromptMsg{seq: seq, url: url, opened: opened, next: msgs}
+		})
+		msgs <- authLoginMsg{seq: seq, err: err}
+	}()
+	return waitForLogin(msgs)
+}
+
+// waitForLogin delivers the next message from a running login
+func waitForLogin(msgs <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-msgs
+	}
+}
+
+// handleAuthPrompt shows the sign-in URL; messages from a cancelled login
+// are dropped
+func (m *Model) handleAuthPrompt(msg authPromptMsg) tea.Cmd {
+	if m.login != nil && m.login.seq == msg.seq {
+		m.login.url = msg.url
+		m.login.opened = msg.opened
+	}
+	return waitForLogin(msg.next)
+}
+
+// handleAuthLogin closes the login panel on success, or leaves the error
+// on it
+func (m *Model) handleAuthLogin(msg authLoginMsg) {
+	if m.login == nil || m.login.seq != msg.seq {
+		return
+	}
+	if msg.err != nil {
+		m.login.done = true
+		m.login.err = msg.err
+		return
+	}
+	m.closeLogin()
+	name := m.auth.Status().Username
+	if name == "" {
+		name = "server"
+	}
+	m.notify(m.trf("notify.signed_in", name))
+}
+
+// closeLogin abandons any running sign-in and returns to the radar
+func (m *Model) closeLogin() {
+	if m.login != nil {
+		m.login.cancel()
+		m.login = nil
+	}
+	m.viewMode = ViewRadar
+}
+
+// handleLoginKey closes the login panel; Esc also cancels a sign-in still
+// waiting on the browser
+func (m *Model) handleLoginKey(key string) {
+	if m.login == nil || m.login.done || key == keyEsc {
+		m.closeLogin()
+	}
+}
+
+// authStatusRow returns the stats panel's sign-in value, e.g.
+// "anna ⏲ 42m", and its style. ok is false when the server doesn't use
+// sign-in.
+func (m *Model) authStatusRow(text, warn, alert lipgloss.Style) (value string, style lipgloss.Style, ok bool) {
+	if m.auth == nil {
+		return "", text, false
+	}
+	st := m.auth.Status()
+	switch st.Method {
+	case "api_key":
+		return "API key", text, true
+	case "oidc":
+	default:
+		if st.Required {
+			return "signed out", alert, true
+		}
+		return "", text, false
+	}
+
+	name := st.Username
+	if name == "" {
+		name = "signed in"
+	}
+	if len(name) > authNameWidth {
+		name = name[:authNameWidth]
+	}
+	left := st.ExpiresAt.Sub(m.now())
+	style = text
+	switch {
+	case left <= 0:
+		style = alert
+	case left < authWarnBefore:
+		style = warn
+	}
+	return name + " " + m.glyphs().Timer + " " + formatCountdown(left), style, true
+}
+
+// formatCountdown shows time left in its largest useful unit
+func formatCountdown(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "expired"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dd", int(d.Hours())/24)
+}
+
+func (m *Model) renderLoginPanel() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
+	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error)
+	wrap := lipgloss.NewStyle().Width(32)
+	g := m.glyphs()
+
+	var sb strings.Builder
+
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 34) + g.DoubleTR))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.sign_in"), 34)) + borderStyle.Render(g.DoubleV))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 34) + g.DoubleBR))
+	sb.WriteString("\n\n")
+
+	lines := func(style lipgloss.Style, s string) {
+		for _, line := range strings.Split(wrap.Render(s), "\n") {
+			sb.WriteString("  " + style.Render(strings.TrimRight(line, " ")))
+			sb.WriteString("\n")
+		}
+	}
+
+	login := m.login
+	switch {
+	case login == nil:
+	case login.err != nil:
+		msg := login.err.Error()
+		if errors.Is(login.err, context.DeadlineExceeded) {
+			msg = "Timed out waiting for the browser"
+		}
+		lines(errorStyle, "Sign-in failed: "+msg)
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  " + m.tr("help.close")))
+		return sb.String()
+	case login.url == "":
+		lines(textStyle, "Contacting server...")
+	default:
+		if login.opened {
+			lines(textStyle, "Finish signing in in the browser window that opened. If it didn't, open:")
+		} else {
+			lines(textStyle, "Open this URL in a browser to sign in:")
+		}
+		sb.WriteString("\n")
+		// Not wrapped, so the whole URL can be selected and copied
+		sb.WriteString("  " + infoStyle.Render(login.url))
+		sb.WriteString("\n\n")
+		lines(textDim, "Waiting for sign-in (up to 5 minutes)...")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(textDim.Render("  [Esc] Cancel"))
+
+	return sb.String()
+}
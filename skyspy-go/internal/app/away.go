@@ -0,0 +1,274 @@
+// Package app provides the "while you were away" digest for the SkySpy radar
+package app
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+const (
+	// awayIdle is how long without a key press counts as being away
+	awayIdle = 5 * time.Minute
+	// maxSightings is how many notable aircraft are remembered
+	maxSightings = 500
+	// maxAwayDigest is how many aircraft the digest lists
+	maxAwayDigest = 50
+	// awayRows is how many digest entries are shown at once
+	awayRows = 8
+)
+
+// sighting is a notable aircraft seen this session: military traffic, an
+// emergency, one on the watch list or one an alert rule fired for. The
+// first two count whether or not alerts are on.
+type sighting struct {
+	hex       string
+	callsign  string
+	firstSeen time.Time
+	lastSeen  time.Time
+	noted     time.Time // when it first became notable
+	closest   float64   // nearest distance in nm, 0 if never known
+	reasons   []string  // names of the rules that fired
+	military  bool
+	emergency bool
+	gone      bool
+}
+
+// label names a sighting by callsign, or hex without one
+func (s *sighting) label() string {
+	if s.callsign != "" {
+		return s.callsign
+	}
+	return strings.ToUpper(s.hex)
+}
+
+// addReason records why an aircraft is notable, once per reason
+func (s *sighting) addReason(reason string) {
+	for _, r := range s.reasons {
+		if r == reason {
+			return
+		}
+	}
+	s.reasons = append(s.reasons, reason)
+}
+
+// recordSighting keeps the times and closest approach of a notable target
+func (m *Model) recordSighting(t *radar.Target) {
+	switch {
+	case t.OnWatchlist:
+		m.sightingOf(t).addReason(m.tr("away.watched"))
+	case t.Military, t.IsEmergency():
+		s := m.sightingOf(t)
+		s.military = s.military || t.Military
+		s.emergency = s.emergency || t.IsEmergency()
+	case m.sightings[t.Hex] != nil:
+		m.sightingOf(t)
+	}
+}
+
+// sightingReasons says why a sighting is notable: the rules that fired for
+// it, or failing any, what it is
+func (m *Model) sightingReasons(s sighting) string {
+	reasons := s.reasons
+	if len(reasons) == 0 {
+		if s.emergency {
+			reasons = append(reasons, m.tr("away.emergency"))
+		}
+		if s.military {
+			reasons = append(reasons, m.tr("away.military"))
+		}
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// noteAlerted records a target an alert rule fired for
+func (m *Model) noteAlerted(t *radar.Target, rule string) {
+	if t == nil {
+		return
+	}
+	m.sightingOf(t).addReason(rule)
+}
+
+// sightingOf returns the sighting of a target, started if new, brought up
+// to date with it
+func (m *Model) sightingOf(t *radar.Target) *sighting {
+	now := m.now()
+	s := m.sightings[t.Hex]
+	if s == nil {
+		m.evictSighting()
+		s = &sighting{hex: t.Hex, firstSeen: t.FirstSeen, noted: now}
+		if s.firstSeen.IsZero() {
+			s.firstSeen = now
+		}
+		m.sightings[t.Hex] = s
+	}
+	s.lastSeen = now
+	s.gone = false
+	if t.Callsign != "" {
+		s.callsign = t.Callsign
+	}
+	if d := m.displayTarget(t).Distance; d > 0 && (s.closest == 0 || d < s.closest) {
+		s.closest = d
+	}
+	return s
+}
+
+// evictSighting makes room for a new sighting once the limit is reached,
+// dropping the one gone longest, or failing that the one seen longest ago
+func (m *Model) evictSighting() {
+	if len(m.sightings) < maxSightings {
+		return
+	}
+	var oldest *sighting
+	for _, s := range m.sightings {
+		if oldest == nil || s.gone && !oldest.gone || s.gone == oldest.gone && s.lastSeen.Before(oldest.lastSeen) {
+			oldest = s
+		}
+	}
+	delete(m.sightings, oldest.hex)
+}
+
+// sightingGone marks a notable target as no longer on the radar
+func (m *Model) sightingGone(hex string) {
+	if s := m.sightings[hex]; s != nil {
+		s.gone = true
+	}
+}
+
+// noteInput records a key press. The first after being away for awayIdle
+// or more moves the marker to the last one before, and points at the
+// digest if anything notable came and went in between.
+func (m *Model) noteInput() {
+	now := m.now()
+	if !m.lastInput.IsZero() && now.Sub(m.lastInput) >= awayIdle {
+		m.awayMarker = m.lastInput
+		if n := len(m.awayDigest()); n > 0 {
+			m.notify(m.trf("notify.away_digest", n))
+		}
+	}
+	m.lastInput = now
+}
+
+// awayDigest lists the notable aircraft that have come and gone since the
+// marker, most recently gone first. Aircraft that were already notable
+// before the marker were on screen then, so are left out.
+func (m *Model) awayDigest() []sighting {
+	var list []sighting
+	for _, s := range m.sightings {
+		if s.gone && !s.noted.Before(m.awayMarker) {
+			list = append(list, *s)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].lastSeen.Equal(list[j].lastSeen) {
+			return list[i].lastSeen.After(list[j].lastSeen)
+		}
+		return list[i].hex < list[j].hex
+	})
+	return list
+}
+
+// openAway shows the digest, fixed as it is now so it doesn't shift while
+// being read
+func (m *Model) openAway() {
+	m.awayList = m.awayDigest()
+	m.awayTotal = len(m.awayList)
+	if len(m.awayList) > maxAwayDigest {
+		m.awayList = m.awayList[:maxAwayDigest]
+	}
+	m.awayScroll = 0
+	m.viewMode = ViewAway
+}
+
+// handleAwayKey scrolls the digest; dismissing it starts the next one from
+// now
+func (m *Model) handleAwayKey(key string) {
+	last := len(m.awayList) - awayRows
+	switch key {
+	case "up", "k":
+		m.awayScroll--
+	case keyDown, "j":
+		m.awayScroll++
+	case "pgup":
+		m.awayScroll -= awayRows
+	case "pgdown":
+		m.awayScroll += awayRows
+	case "home":
+		m.awayScroll = 0
+	case "end":
+		m.awayScroll = last
+	case keyEsc, "enter", "ctrl+w":
+		m.awayMarker = m.now()
+		m.awayList = nil
+		m.viewMode = ViewRadar
+		return
+	}
+	m.awayScroll = max(0, min(m.awayScroll, last))
+}
+
+// renderAwayPanel renders the digest of notable aircraft missed
+func (m *Model) renderAwayPanel() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright).Bold(true)
+	warning := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	borderDim := lipgloss.NewStyle().Foreground(m.theme.BorderDim)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	g := m.glyphs()
+
+	var sb strings.Builder
+
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 42) + g.DoubleTR))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.away"), 42)) + borderStyle.Render(g.DoubleV))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 42) + g.DoubleBR))
+	sb.WriteString("\n\n")
+
+	since := m.tr("away.session")
+	if !m.awayMarker.IsZero() {
+		since = m.locale.Clock(m.awayMarker)
+	}
+	sb.WriteString(textDim.Render("  " + fit(m.trf("away.since", since, m.awayTotal), 40)))
+	sb.WriteString("\n")
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
+	sb.WriteString("\n")
+
+	if len(m.awayList) == 0 {
+		sb.WriteString(textDim.Render("   " + m.tr("away.none")))
+		sb.WriteString("\n\n")
+	}
+
+	end := min(m.awayScroll+awayRows, len(m.awayList))
+	if m.awayScroll > 0 {
+		sb.WriteString(textDim.Render("  " + m.trf("away.more_above", m.awayScroll)))
+		sb.WriteString("\n")
+	}
+	for _, s := range m.awayList[m.awayScroll:end] {
+		sb.WriteString("  " + secondaryBright.Render(fit(s.label(), 8)) + " " + warning.Render(fit(m.sightingReasons(s), 31)))
+		sb.WriteString("\n")
+		closest := dashPlaceholder
+		if s.closest > 0 {
+			closest = m.formatDist(s.closest, 1)
+		}
+		times := m.locale.Clock(s.firstSeen) + "-" + m.locale.Clock(s.lastSeen)
+		sb.WriteString(textDim.Render("    " + fit(m.trf("away.entry", times, closest), 38)))
+		sb.WriteString("\n")
+	}
+	if below := len(m.awayList) - end; below > 0 {
+		sb.WriteString(textDim.Render("  " + m.trf("away.more_below", below)))
+		sb.WriteString("\n")
+	}
+	if extra := m.awayTotal - len(m.awayList); extra > 0 {
+		sb.WriteString(textDim.Render("  " + m.trf("away.capped", extra)))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(textDim.Render("  " + m.tr("away.help")))
+
+	return sb.String()
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// passBy reports hex flying through the given latitudes, 10s apart, then
+// removes it
+func passBy(m *Model, clock *time.Time, ac *codec.Aircraft, lats ...float64) {
+	for _, lat := range lats {
+		*clock = clock.Add(10 * time.Second)
+		ac.Lat = floatPtr(lat)
+		m.updateTarget(ac, false)
+	}
+	*clock = clock.Add(10 * time.Second)
+	m.removeAircraft(ac.Hex)
+}
+
+func TestAway_DigestListsNotableAircraft(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.config.Filters.MaxPositionSpeed = 0 // the passes jump faster than any aircraft
+	rule := alerts.NewAlertRule("watch_klm", "Watch KLM").AddCondition(alerts.ConditionCallsign, "KLM*")
+	m.alertState.Engine.AddRule(rule)
+
+	pressKey(m, keyDown)
+	*clock = clock.Add(time.Hour)
+
+	mil := flying("AE1234", 0)
+	mil.Flight, mil.Military = "RCH401", true
+	passBy(m, clock, mil, 52.60, 52.45, 52.50)
+
+	emergency := flying("400ABC", 0)
+	emergency.Squawk = "7700"
+	passBy(m, clock, emergency, 52.40)
+
+	watched := flying("484B1C", 0)
+	watched.Flight = "KLM123"
+	passBy(m, clock, watched, 52.70)
+
+	// On the watch list, with no rule for it
+	m.config.Watchlist.Hexes = []string{"4CA7B5"}
+	passBy(m, clock, flying("4CA7B5", 0), 52.45)
+
+	// Ordinary traffic, and a notable one still on the radar, are left out
+	passBy(m, clock, flying("3C6444", 0), 52.40)
+	still := flying("AE9999", 52.40)
+	still.Military = true
+	m.updateTarget(still, true)
+
+	// The first key back points at the digest
+	pressKey(m, keyDown)
+	if m.notification != "4 notable aircraft while you were away [Ctrl+W]" {
+		t.Errorf("notification = %q", m.notification)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlW})
+	if m.viewMode != ViewAway || len(m.awayList) != 4 {
+		t.Fatalf("ctrl+w should list 4 aircraft, got %d", len(m.awayList))
+	}
+	if m.awayList[0].label() != "4CA7B5" || m.awayList[0].reasons[0] != "Watch list" {
+		t.Errorf("the watched aircraft should be listed: %s %v", m.awayList[0].label(), m.awayList[0].reasons)
+	}
+	got := m.awayList[1:]
+	if got[0].label() != "KLM123" || got[1].label() != "400ABC" || got[2].label() != "RCH401" {
+		t.Errorf("expected the most recently gone first: %s, %s, %s", got[0].label(), got[1].label(), got[2].label())
+	}
+	if got[0].reasons[0] != "Watch KLM" || got[1].reasons[0] != "Emergency Squawk" || got[2].reasons[0] != "Military Aircraft Nearby" {
+		t.Errorf("reasons = %v, %v, %v", got[0].reasons, got[1].reasons, got[2].reasons)
+	}
+	// 52.45 is the nearest RCH401 came to the receiver at 52.3676
+	if d := got[2].closest; d < 4.9 || d > 5.0 {
+		t.Errorf("closest approach = %.2fnm", d)
+	}
+	if span := got[2].lastSeen.Sub(got[2].firstSeen); span != 20*time.Second {
+		t.Errorf("RCH401 seen for %s, want 20s", span)
+	}
+
+	m.width, m.height = 160, 60
+	panel := ansi.Strip(m.renderAwayPanel())
+	for _, want := range []string{"WHILE YOU WERE AWAY", "RCH401", "Emergency Squawk", "closest 4.9nm"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("panel should show %q:\n%s", want, panel)
+		}
+	}
+
+	// Dismissing starts the next digest from now
+	pressKey(m, keyEsc)
+	if m.viewMode != ViewRadar || !m.awayMarker.Equal(*clock) {
+		t.Fatal("Esc should close the digest and move the marker")
+	}
+	if n := len(m.awayDigest()); n != 0 {
+		t.Errorf("the digest should be empty after dismissing, got %d", n)
+	}
+}
+
+func TestAway_CappedAndScrollable(t *testing.T) {
+	m, clock := newTrackingModel()
+	for i := 0; i < maxAwayDigest+10; i++ {
+		ac := flying(fmt.Sprintf("AE%04d", i), 0)
+		ac.Military = true
+		passBy(m, clock, ac, 52.40)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlW})
+	if len(m.awayList) != maxAwayDigest || m.awayTotal != maxAwayDigest+10 {
+		t.Fatalf("digest lists %d of %d, want %d of %d", len(m.awayList), m.awayTotal, maxAwayDigest, maxAwayDigest+10)
+	}
+	panel := ansi.Strip(m.renderAwayPanel())
+	if !strings.Contains(panel, "42 more below") || !strings.Contains(panel, "10 older not listed") {
+		t.Errorf("the panel should say what isn't shown:\n%s", panel)
+	}
+
+	pressKey(m, keyDown)
+	pressKey(m, keyDown)
+	if m.awayScroll != 2 {
+		t.Errorf("down should scroll, at %d", m.awayScroll)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnd})
+	pressKey(m, keyDown)
+	if m.awayScroll != maxAwayDigest-awayRows {
+		t.Errorf("scrolling should stop at the last page, at %d", m.awayScroll)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyPgUp})
+	if m.awayScroll != maxAwayDigest-2*awayRows {
+		t.Errorf("pgup should scroll a page, at %d", m.awayScroll)
+	}
+	if panel := ansi.Strip(m.renderAwayPanel()); !strings.Contains(panel, "34 more above") {
+		t.Errorf("the panel should count the entries above:\n%s", panel)
+	}
+}
+
+func TestAway_ShortPauseKeepsMarker(t *testing.T) {
+	m, clock := newTrackingModel()
+	pressKey(m, keyDown)
+	*clock = clock.Add(time.Minute)
+	pressKey(m, keyDown)
+	if !m.awayMarker.IsZero() {
+		t.Error("a pause shorter than the idle time shouldn't move the marker")
+	}
+	*clock = clock.Add(awayIdle)
+	start := *clock
+	pressKey(m, keyDown)
+	if !m.awayMarker.Equal(start.Add(-awayIdle)) {
+		t.Errorf("marker = %v, want the last key before the pause", m.awayMarker)
+	}
+}
+
+func TestAway_NotableWithAlertsOff(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.alertState.AlertsEnabled = false
+	pressKey(m, keyDown)
+	*clock = clock.Add(time.Hour)
+
+	mil := flying("AE1234", 0)
+	mil.Flight, mil.Military = "RCH401", true
+	passBy(m, clock, mil, 52.45)
+	emergency := flying("400ABC", 0)
+	emergency.Squawk = "7700"
+	passBy(m, clock, emergency, 52.40)
+	passBy(m, clock, flying("3C6444", 0), 52.40)
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlW})
+	if len(m.awayList) != 2 {
+		t.Fatalf("military and emergency traffic should be listed without alerts, got %d", len(m.awayList))
+	}
+	if got := m.sightingReasons(m.awayList[0]); got != "Emergency" {
+		t.Errorf("400ABC reasons = %q", got)
+	}
+	if got := m.sightingReasons(m.awayList[1]); got != "Military" {
+		t.Errorf("RCH401 reasons = %q", got)
+	}
+}
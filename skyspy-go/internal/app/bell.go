@@ -0,0 +1,89 @@
+// Package app provides the terminal bell alert action for the SkySpy radar
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// bellInterval is the least time between bells, however many rules
+	// ring at once
+	bellInterval = time.Second
+	// bellHold is how long the BEL stays in the frame. Bubble Tea only
+	// rewrites changed lines, so a BEL kept for a few frames on a line that
+	// doesn't otherwise change reaches the terminal once, and isn't lost
+	// when several updates land between frames.
+	bellHold = 100 * time.Millisecond
+	// flashHold is how long a visual bell inverts the status bar
+	flashHold = 200 * time.Millisecond
+)
+
+// Bell modes (alerts.bell)
+const (
+	bellAudible = "audible"
+	bellVisual  = "visual"
+	bellBoth    = "both"
+)
+
+// bellMode returns how bell actions ring; anything unrecognized is audible
+func (m *Model) bellMode() string {
+	mode := strings.ToLower(strings.TrimSpace(m.config.Alerts.Bell))
+	switch mode {
+	case bellVisual, bellBoth:
+		return mode
+	}
+	return bellAudible
+}
+
+// ringBell rings the terminal bell, flashes the status bar, or both, at
+// most once per bellInterval. Do not disturb silences the bell but leaves
+// the flash. It reports whether it rang.
+func (m *Model) ringBell() bool {
+	now := m.now()
+	if !m.lastBell.IsZero() && now.Sub(m.lastBell) < bellInterval {
+		return false
+	}
+	mode, quiet := m.bellMode(), m.doNotDisturb()
+	if quiet && mode == bellAudible {
+		return false
+	}
+	m.lastBell = now
+
+	if mode != bellVisual && !quiet {
+		m.bellUntil = now.Add(bellHold)
+	}
+	if mode != bellAudible {
+		m.flashUntil = now.Add(flashHold)
+	}
+	return true
+}
+
+// withBell puts the BEL character in front of a rendered frame while a bell
+// is ringing. The bell goes out through the renderer like the rest of the
+// frame; writing it to stdout directly would interleave with a frame.
+func (m *Model) withBell(frame string) string {
+	if m.now().Before(m.bellUntil) {
+		return "\a" + frame
+	}
+	return frame
+}
+
+// flashing reports whether a visual bell is inverting the status bar
+func (m *Model) flashing() bool {
+	return m.now().Before(m.flashUntil)
+}
+
+// invertLines draws rendered lines in reverse video, dropping their own
+// styling so the inversion isn't undone part way along
+func invertLines(s string) string {
+	inverse := lipgloss.NewStyle().Reverse(true)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = inverse.Render(ansi.Strip(line))
+	}
+	return strings.Join(lines, "\n")
+}
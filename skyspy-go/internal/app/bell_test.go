@@ -0,0 +1,173 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func newBellModel(mode string) (*Model, *time.Time) {
+	cfg := newTestConfig()
+	cfg.Alerts.Bell = mode
+	m := NewModel(cfg)
+	m.width, m.height = 160, 50
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	return m, &clock
+}
+
+func TestBell_RateLimited(t *testing.T) {
+	m, clock := newBellModel("audible")
+	steps := []struct {
+		after time.Duration
+		rang  bool
+	}{
+		{0, true},
+		{100 * time.Millisecond, false},
+		{800 * time.Millisecond, false},
+		{100 * time.Millisecond, true}, // a second after the first
+		{999 * time.Millisecond, false},
+		{time.Millisecond, true},
+	}
+	for i, step := range steps {
+		*clock = clock.Add(step.after)
+		if rang := m.ringBell(); rang != step.rang {
+			t.Errorf("step %d: rang = %v, want %v", i, rang, step.rang)
+		}
+	}
+}
+
+func TestBell_AudibleGoesThroughTheFrame(t *testing.T) {
+	m, clock := newBellModel("audible")
+	m.ringBell()
+
+	view := m.View()
+	if !strings.HasPrefix(view, "\a") || strings.Count(view, "\a") != 1 {
+		t.Fatal("the frame should start with one BEL while the bell rings")
+	}
+	if strings.Contains(m.lastRenderedView, "\a") {
+		t.Error("screenshots shouldn't capture the BEL")
+	}
+	if m.flashing() {
+		t.Error("an audible bell shouldn't flash")
+	}
+
+	*clock = clock.Add(bellHold)
+	if strings.Contains(m.View(), "\a") {
+		t.Error("the BEL should leave the frame after the hold")
+	}
+}
+
+func TestBell_Visual(t *testing.T) {
+	m, clock := newBellModel("visual")
+	m.ringBell()
+
+	if strings.Contains(m.View(), "\a") {
+		t.Error("a visual bell shouldn't ring")
+	}
+	if !m.flashing() {
+		t.Error("a visual bell should invert the status bar")
+	}
+
+	*clock = clock.Add(flashHold)
+	if m.flashing() {
+		t.Error("the flash should end after ~200ms")
+	}
+}
+
+func TestBell_Both(t *testing.T) {
+	m, _ := newBellModel("BOTH")
+	m.ringBell()
+	if !strings.HasPrefix(m.View(), "\a") || !m.flashing() {
+		t.Error("both should ring and flash")
+	}
+}
+
+func TestBell_RuleAction(t *testing.T) {
+	m, clock := newBellModel("")
+	rule := alerts.NewAlertRule("bell_rule", "Bell rule").AddCondition(alerts.ConditionCallsign, "BELL*")
+	rule.Actions = append(rule.Actions, alerts.Action{Type: alerts.ActionBell})
+	m.alertState.Engine.AddRule(rule)
+
+	m.updateTarget(&codec.Aircraft{Hex: "BEL001", Flight: "BELL1"}, true)
+	if !strings.HasPrefix(m.View(), "\a") {
+		t.Fatal("a rule with a bell action should ring the bell")
+	}
+
+	// Many rules firing together still ring once a second
+	*clock = clock.Add(200 * time.Millisecond)
+	last := m.lastBell
+	m.updateTarget(&codec.Aircraft{Hex: "BEL002", Flight: "BELL2"}, true)
+	if m.lastBell != last {
+		t.Error("a second bell within the interval should be held back")
+	}
+}
+
+func TestBell_SelectableInEditor(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	m, _ := newBellModel("audible")
+	m.openAlertRulesView()
+	rule := m.GetAlertRules()[0]
+
+	pressKey(m, "b")
+	if !rule.HasAction(alerts.ActionBell) {
+		t.Fatal("b should add a bell to the rule under the cursor")
+	}
+	if !strings.Contains(m.renderAlertRulesPanel(), "BEL") {
+		t.Error("the rule list should mark rules that ring the bell")
+	}
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasBellAction(saved.Alerts.Rules, rule.ID) {
+		t.Error("the bell should be saved with the rule")
+	}
+
+	// A rule from a template can ring the bell too
+	pressKeys(m, "n", "b", "1", "enter", "enter")
+	rules := m.GetAlertRules()
+	if added := rules[len(rules)-1]; added.ID != "low_nearby" || !added.HasAction(alerts.ActionBell) {
+		t.Errorf("the new rule should have a bell action: %+v", added.Actions)
+	}
+}
+
+func hasBellAction(rules []config.AlertRuleConfig, id string) bool {
+	for _, r := range rules {
+		if r.ID != id {
+			continue
+		}
+		for _, a := range r.Actions {
+			if a.Type == string(alerts.ActionBell) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestBell_DoNotDisturb(t *testing.T) {
+	m, _ := newBellModel("audible")
+	m.dnd = dndOn
+	if m.ringBell() || strings.Contains(m.View(), "\a") {
+		t.Error("do not disturb should silence an audible bell")
+	}
+
+	m, _ = newBellModel("both")
+	m.dnd = dndOn
+	m.ringBell()
+	if strings.Contains(m.View(), "\a") {
+		t.Error("do not disturb should silence the bell")
+	}
+	if !m.flashing() {
+		t.Error("do not disturb should leave the flash")
+	}
+}
@@ -0,0 +1,97 @@
+// Package app provides the optional subsystems and safe mode for the SkySpy
+// radar
+package app
+
+import (
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/audio"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+// Capabilities are the optional subsystems the radar may start. Each is
+// used only when both its capability and its settings allow it, so safe
+// mode can turn them all off in one place without touching the settings.
+type Capabilities struct {
+	Audio        bool // alert sounds, including rule sound files
+	Overlays     bool // configured overlays and the points of interest overlay
+	Theme        bool // the configured theme; off uses the default
+	Alerts       bool // configured alert rules and geofences; off starts with none
+	Exports      bool // automatic exports, such as the emergency log
+	API          bool // the local HTTP API
+	SaveSettings bool // settings changes are written back
+}
+
+// FullCapabilities allows every subsystem the settings turn on
+func FullCapabilities() Capabilities {
+	return Capabilities{Audio: true, Overlays: true, Theme: true, Alerts: true, Exports: true, API: true, SaveSettings: true}
+}
+
+// SafeCapabilities allows none of the optional subsystems, for telling
+// whether one of them is what stops the radar starting. The feed and its
+// server connection are still used.
+func SafeCapabilities() Capabilities {
+	return Capabilities{}
+}
+
+// Skipped names the subsystems c turns off, in a fixed order
+func (c Capabilities) Skipped() []string {
+	var skipped []string
+	for _, s := range []struct {
+		name string
+		on   bool
+	}{
+		{"audio", c.Audio},
+		{"overlays", c.Overlays},
+		{"theme", c.Theme},
+		{"alert rules", c.Alerts},
+		{"auto-exports", c.Exports},
+		{"local API", c.API},
+		{"saving settings", c.SaveSettings},
+	} {
+		if !s.on {
+			skipped = append(skipped, s.name)
+		}
+	}
+	return skipped
+}
+
+// Capabilities returns the optional subsystems the radar was started with
+func (m *Model) Capabilities() Capabilities {
+	return m.caps
+}
+
+// themeFor returns the configured theme, or the default when themes are
+// turned off. Glyph sets and colorblind-safe colors are built in, so they
+// are kept either way.
+func themeFor(cfg *config.Config, caps Capabilities) *theme.Theme {
+	name := cfg.Display.Theme
+	if !caps.Theme {
+		name = ""
+	}
+	return theme.Get(name).WithGlyphs(cfg.Display.GlyphSet).WithCVD(cfg.Display.ColorblindSafe)
+}
+
+// alertPlayerFor returns the audio alert player. With audio turned off it
+// plays from a silenced copy of the audio settings, so the settings
+// themselves are left as they were.
+func alertPlayerFor(cfg *config.Config, caps Capabilities) *audio.AlertPlayer {
+	if caps.Audio {
+		return audio.NewAlertPlayer(&cfg.Audio)
+	}
+	silent := cfg.Audio
+	silent.Enabled = false
+	return audio.NewAlertPlayer(&silent)
+}
+
+// reportSkipped says which subsystems were turned off, in the debug log
+// and a notification
+func (m *Model) reportSkipped() {
+	skipped := m.caps.Skipped()
+	if len(skipped) == 0 {
+		return
+	}
+	m.debugf("safe mode: skipped %s", strings.Join(skipped, ", "))
+	m.notifyFor(m.trf("notify.safe_mode", strings.Join(skipped, ", ")), 10)
+}
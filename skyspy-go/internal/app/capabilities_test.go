@@ -0,0 +1,66 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// loadedConfig turns on every optional subsystem safe mode skips
+func loadedConfig() *config.Config {
+	cfg := newTestConfig()
+	cfg.Display.Theme = "amber"
+	cfg.Audio.Enabled = true
+	cfg.Overlays.Overlays = []config.OverlayConfig{{Path: "/nonexistent/airspace.geojson", Enabled: true}}
+	cfg.POI.ShowMarkers = true
+	cfg.POI.Points = []config.POIConfig{{Label: "Tower", Lat: 52.31, Lon: 4.76}}
+	return cfg
+}
+
+func TestCapabilities_SafeModeSkipsOptionalSubsystems(t *testing.T) {
+	cfg := loadedConfig()
+	m := NewModelWithCapabilities(cfg, nil, SafeCapabilities())
+
+	if m.theme.Name == themeFor(cfg, FullCapabilities()).Name {
+		t.Error("safe mode should use the default theme")
+	}
+	if len(m.overlayLoads) != 0 || len(m.overlayManager.GetOverlayList()) != 0 {
+		t.Error("safe mode shouldn't load overlays or points of interest")
+	}
+	if n := m.alertState.Engine.GetRuleSet().Count(); n != 0 {
+		t.Errorf("safe mode should start with no alert rules, got %d", n)
+	}
+	m.SetEmergencyLog(filepath.Join(t.TempDir(), "emergencies.jsonl"))
+	if m.emergencyLog != nil {
+		t.Error("safe mode shouldn't write the emergency log")
+	}
+	m.SetAPI(&capturePublisher{})
+	if m.api != nil {
+		t.Error("safe mode shouldn't publish to the local API")
+	}
+
+	// What it turned off is left as it was in the settings
+	if !cfg.Audio.Enabled || cfg.Display.Theme != "amber" || len(cfg.Overlays.Overlays) != 1 {
+		t.Errorf("safe mode shouldn't change the settings: %+v", cfg.Display)
+	}
+	if m.notification != "Safe mode: skipped audio, overlays, theme, alert rules, auto-exports, local API, saving settings" {
+		t.Errorf("notification = %q", m.notification)
+	}
+}
+
+func TestCapabilities_FullStartsEverything(t *testing.T) {
+	m := NewModel(loadedConfig())
+	if m.theme.Name == themeFor(m.config, SafeCapabilities()).Name {
+		t.Error("the configured theme should be used")
+	}
+	if len(m.overlayLoads) != 1 || len(m.overlayManager.GetOverlayList()) != 1 {
+		t.Error("the configured overlay should be queued and the POI overlay added")
+	}
+	if m.alertState.Engine.GetRuleSet().Count() == 0 {
+		t.Error("the default alert rules should be loaded")
+	}
+	if len(m.Capabilities().Skipped()) != 0 || m.notification != "" {
+		t.Errorf("nothing should be reported skipped, got %q", m.notification)
+	}
+}
@@ -0,0 +1,163 @@
+// Package app provides stale-data cleanup for the SkySpy radar
+package app
+
+import (
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/trails"
+)
+
+// cleanupInterval returns how often stale data is purged
+func cleanupInterval(cfg *config.Config) time.Duration {
+	if cfg.Radar.CleanupInterval > 0 {
+		return time.Duration(cfg.Radar.CleanupInterval) * time.Second
+	}
+	return time.Duration(config.DefaultConfig().Radar.CleanupInterval) * time.Second
+}
+
+// aircraftTimeout returns how long an aircraft may go without an update
+func aircraftTimeout(cfg *config.Config) time.Duration {
+	if cfg.Radar.AircraftTimeout > 0 {
+		return time.Duration(cfg.Radar.AircraftTimeout) * time.Second
+	}
+	return time.Duration(config.DefaultConfig().Radar.AircraftTimeout) * time.Second
+}
+
+// trailPruneInterval is how often trail points past the retention window
+// are dropped
+const trailPruneInterval = 5 * time.Second
+
+// trailRetention returns how long trail points are kept
+func trailRetention(cfg *config.Config) time.Duration {
+	if cfg.Display.TrailMinutes > 0 {
+		return time.Duration(cfg.Display.TrailMinutes) * time.Minute
+	}
+	return time.Duration(config.DefaultConfig().Display.TrailMinutes) * time.Minute
+}
+
+// trailGap returns the silence that breaks a trail into segments; zero
+// turns gap detection off
+func trailGap(cfg *config.Config) time.Duration {
+	switch {
+	case cfg.Display.TrailGapSeconds > 0:
+		return time.Duration(cfg.Display.TrailGapSeconds) * time.Second
+	case cfg.Display.TrailGapSeconds < 0:
+		return 0
+	}
+	return trails.DefaultGapThreshold
+}
+
+// newTrailTracker creates the trail tracker with the configured retention,
+// point budget and gap threshold
+func newTrailTracker(cfg *config.Config) *trails.TrailTracker {
+	tracker := trails.NewTrailTrackerWithRetention(trailRetention(cfg), cfg.Display.TrailMaxPoints)
+	tracker.SetGapThreshold(trailGap(cfg))
+	return tracker
+}
+
+// maybePruneTrails ages out trail points once per trailPruneInterval
+func (m *Model) maybePruneTrails() {
+	now := m.now()
+	if now.Sub(m.lastTrailPrune) < trailPruneInterval {
+		return
+	}
+	m.lastTrailPrune = now
+	m.trailTracker.Prune()
+}
+
+// maybeCleanup runs cleanup once per interval of wall-clock time, so the
+// cadence does not depend on the tick rate
+func (m *Model) maybeCleanup() {
+	now := m.now()
+	if m.lastCleanup.IsZero() {
+		m.lastCleanup = now
+		return
+	}
+	if now.Sub(m.lastCleanup) < cleanupInterval(m.config) {
+		return
+	}
+	m.lastCleanup = now
+	m.cleanup(now)
+}
+
+// Sweep runs the periodic stale-data cleanup for headless callers, which
+// have no render tick to drive it when the feed goes quiet
+func (m *Model) Sweep() {
+	m.maybeCleanup()
+}
+
+// cleanup removes aircraft that have timed out and expires old trail and
+// alert data
+func (m *Model) cleanup(now time.Time) {
+	timeout := aircraftTimeout(m.config)
+	for hex, seen := range m.lastSeen {
+		if now.Sub(seen) > timeout {
+			m.removeAircraft(hex)
+		}
+	}
+
+	m.expireShed(now)
+	m.expireDeparted(now)
+
+	m.trailTracker.Cleanup()
+	if m.alertState != nil {
+		m.alertState.Cleanup()
+	}
+}
+
+// removeAircraft purges every piece of per-aircraft state for hex. All
+// removal paths go through here so no bookkeeping map is left behind.
+func (m *Model) removeAircraft(hex string) {
+	target, ok := m.aircraft[hex]
+	if ok && m.unpin(hex) {
+		m.notify(m.trf("notify.pin_lost", pinLabel(target)))
+	}
+	if hex == m.followHex {
+		m.stopFollowing("notify.follow_lost")
+	}
+	if ok && hex == m.detailHex && m.viewMode == ViewAircraftDetail {
+		m.viewMode = ViewRadar
+		m.notify(m.trf("notify.detail_lost", pinLabel(target)))
+	}
+
+	if ok {
+		m.retireSignal(target)
+		m.rememberTracking(target)
+	}
+	m.sightingGone(hex)
+	m.endEmergency(hex, target)
+	delete(m.aircraft, hex)
+	delete(m.lastSeen, hex)
+	delete(m.receiverSeen, hex)
+	delete(m.history, hex)
+	delete(m.alertedAircraft, hex)
+	delete(m.shed, hex)
+	m.trailTracker.RemoveTrail(hex)
+	m.turnTracker.Remove(hex)
+	m.conflictTracker.Remove(hex)
+	if m.regions != nil {
+		m.regions.Forget(hex)
+	}
+	m.searcher.Reset()
+	if m.alertState != nil {
+		m.alertState.RemoveAircraft(hex)
+	}
+
+	for i, h := range m.sortedTargets {
+		if h == hex {
+			m.sortedTargets = append(m.sortedTargets[:i], m.sortedTargets[i+1:]...)
+			break
+		}
+	}
+	if m.selectedHex == hex {
+		m.selectedHex = ""
+		if ok {
+			m.rememberSelection(target)
+		}
+	}
+
+	if ok {
+		m.emit(Event{Type: EventRemove, Target: target})
+	}
+}
@@ -0,0 +1,103 @@
+// Package app provides clock skew detection for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+const (
+	// clockSkewThreshold is the offset from the server's clock beyond which
+	// the local clock is flagged as wrong
+	clockSkewThreshold = 60 * time.Second
+	// skewEase smooths envelope-time samples, which carry network latency
+	skewEase = 0.2
+)
+
+// SetClockSkew records how far the server's clock is ahead of ours
+// (negative if behind), e.g. as measured from an HTTP Date header
+func (m *Model) SetClockSkew(skew time.Duration) {
+	m.clockSkew = skew
+	m.clockSkewKnown = true
+}
+
+// ClockSkew returns the measured offset of the server's clock from ours
+func (m *Model) ClockSkew() (skew time.Duration, ok bool) {
+	return m.clockSkew, m.clockSkewKnown
+}
+
+// IsClockSkewed reports whether the local clock is off by more than the
+// threshold
+func (m *Model) IsClockSkewed() bool {
+	return m.clockSkewKnown && (m.clockSkew > clockSkewThreshold || m.clockSkew < -clockSkewThreshold)
+}
+
+// observeMessageTime folds a feed message's send time into the skew
+// estimate
+func (m *Model) observeMessageTime(msg codec.Message) {
+	sent, ok := msg.Time()
+	if !ok {
+		return
+	}
+	sample := sent.Sub(m.now())
+	if !m.clockSkewKnown {
+		m.SetClockSkew(sample)
+		return
+	}
+	m.clockSkew += time.Duration(float64(sample-m.clockSkew) * skewEase)
+}
+
+// messageAge returns how long ago an ACARS message was sent. Server send
+// times are read on the server's clock so a drifted local clock doesn't
+// give negative or inflated ages. ok is false if the message has no time.
+func (m *Model) messageAge(msg ACARSMessage) (age time.Duration, ok bool) {
+	switch {
+	case !msg.Sent.IsZero():
+		age = m.now().Add(m.clockSkew).Sub(msg.Sent)
+	case !msg.Received.IsZero():
+		age = m.now().Sub(msg.Received)
+	default:
+		return 0, false
+	}
+	return max(age, 0), true
+}
+
+// messageAgeLabel formats an ACARS message's age, or "" if unknown
+func (m *Model) messageAgeLabel(msg ACARSMessage) string {
+	age, ok := m.messageAge(msg)
+	if !ok {
+		return ""
+	}
+	return formatAge(age)
+}
+
+// formatAge formats a message age compactly, e.g. "42s" or "7m"
+func formatAge(d time.Duration) string {
+	if d >= 100*time.Hour {
+		return "old"
+	}
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	if d >= time.Minute {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
+
+// formatSkew formats the clock offset for the status bar, e.g. "+2h"
+func formatSkew(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+	}
+	abs := time.Duration(math.Abs(float64(d)))
+	if abs >= 48*time.Hour {
+		// A dead RTC can be off by years
+		return fmt.Sprintf("%s%dd", sign, int(abs.Hours()/24))
+	}
+	return sign + formatAge(abs)
+}
@@ -0,0 +1,51 @@
+// Package app provides duplicate ICAO address detection for the SkySpy radar
+package app
+
+import (
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/trails"
+)
+
+// conflictSettings converts the configured conflict tuning for the tracker
+func conflictSettings(cfg *config.Config) trails.ConflictSettings {
+	return trails.ConflictSettings{
+		MaxSpeed:    cfg.Conflicts.MaxSpeed,
+		SpeedFactor: cfg.Conflicts.SpeedFactor,
+		MinJump:     cfg.Conflicts.MinJump,
+		Hold:        time.Duration(cfg.Conflicts.HoldSeconds) * time.Second,
+	}
+}
+
+// trackPosition checks a target's position fix for an implausible jump,
+// flags the target if its address looks shared, and adds the fix to the
+// trail unless it is a jump. A position the plausibility filter dropped
+// still counts towards a shared address, but never reaches the trail.
+func (m *Model) trackPosition(target *radar.Target, dropped *positionFix) {
+	if dropped != nil {
+		_, info := m.conflictTracker.AddFix(target.Hex, dropped.lat, dropped.lon, target.Speed, m.now())
+		target.Conflicted = info.Conflicted
+		return
+	}
+	if !target.HasLat || !target.HasLon {
+		info, _ := m.conflictTracker.Get(target.Hex, m.now())
+		target.Conflicted = info.Conflicted
+		return
+	}
+
+	verdict, info := m.conflictTracker.AddFix(target.Hex, target.Lat, target.Lon, target.Speed, m.now())
+	target.Conflicted = info.Conflicted
+	switch verdict {
+	case trails.FixAccept:
+		m.trailTracker.AddPositionWithAltitude(target.Hex, target.Lat, target.Lon, target.Altitude, target.HasAlt)
+	case trails.FixRelocate:
+		m.trailTracker.StartSegment(target.Hex, target.Lat, target.Lon, target.Altitude, target.HasAlt)
+	}
+}
+
+// GetConflict returns the duplicate address state for hex
+func (m *Model) GetConflict(hex string) (trails.ConflictInfo, bool) {
+	return m.conflictTracker.Get(hex, m.now())
+}
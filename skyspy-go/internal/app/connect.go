@@ -0,0 +1,210 @@
+// Package app provides the startup connection check for the SkySpy radar
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/ws"
+)
+
+// connectionReporter is implemented by feeds that can explain a failed
+// connection, such as the WebSocket client
+type connectionReporter interface {
+	URL() string
+	LastError() error
+	Retry()
+}
+
+// SetStartupError shows the connection error screen from the start, for
+// failures found before the UI runs such as a server that requires login.
+// hints replace the generic advice for the error.
+func (m *Model) SetStartupError(err error, hints ...string) {
+	m.connectFailure = err
+	m.connectHints = hints
+}
+
+// ConnectFailure returns the error shown on the connection error screen,
+// or nil if it isn't showing
+func (m *Model) ConnectFailure() error {
+	return m.connectFailure
+}
+
+// ConfigureRequested reports whether the user quit from the connection
+// error screen to run the configuration wizard
+func (m *Model) ConfigureRequested() bool {
+	return m.configureRequested
+}
+
+// checkConnection shows the connection error screen if the feed hasn't
+// connected within the configured timeout. Once the feed has connected,
+// later drops are left to the reconnect loop and the status bar.
+func (m *Model) checkConnection() {
+	if m.feed == nil || m.feedConnected {
+		return
+	}
+	if m.feed.IsConnected() {
+		m.feedConnected = true
+		m.connectFailure = nil
+		m.connectHints = nil
+		return
+	}
+	if m.connectFailure != nil {
+		return
+	}
+
+	timeout := time.Duration(m.config.Connection.ConnectTimeout) * time.Second
+	if timeout <= 0 {
+		return
+	}
+	now := m.now()
+	if m.connectStarted.IsZero() {
+		m.connectStarted = now
+		return
+	}
+	if now.Sub(m.connectStarted) >= timeout {
+		m.connectFailure = m.feedError(timeout)
+	}
+}
+
+// feedError explains why the feed hasn't connected, falling back to a
+// timeout when the feed can't say
+func (m *Model) feedError(timeout time.Duration) error {
+	noResponse := fmt.Errorf("no connection after %s", timeout)
+	r, ok := m.feed.(connectionReporter)
+	if !ok {
+		return noResponse
+	}
+	if err := r.LastError(); err != nil {
+		return err
+	}
+	return &ws.ConnectError{Kind: ws.KindTimeout, URL: r.URL(), Err: noResponse}
+}
+
+// retryConnection hides the error screen and restarts the timeout
+func (m *Model) retryConnection() {
+	m.connectFailure = nil
+	m.connectHints = nil
+	m.connectStarted = m.now()
+	if r, ok := m.feed.(connectionReporter); ok {
+		r.Retry()
+	}
+	m.notify(m.tr("notify.retrying"))
+}
+
+// handleConnectFailureKey handles keys while the connection error screen
+// is showing
+func (m *Model) handleConnectFailureKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "r", "R":
+		m.retryConnection()
+	case "c", "C":
+		m.configureRequested = true
+		return m, m.quit()
+	case "q", "Q", "ctrl+c":
+		return m, m.quit()
+	}
+	return m, nil
+}
+
+// connectAdvice returns a next step for a connection error
+func connectAdvice(err error) []string {
+	var ce *ws.ConnectError
+	if !errors.As(err, &ce) {
+		return nil
+	}
+	switch ce.Kind {
+	case ws.KindDNS:
+		return []string{"Check the host name (--host or connection.host)"}
+	case ws.KindRefused:
+		return []string{"Nothing is listening on that port; is the server running?"}
+	case ws.KindTimeout:
+		return []string{"The server didn't answer; check the host, port and firewall"}
+	case ws.KindTLS:
+		return []string{"The server's certificate couldn't be verified"}
+	case ws.KindAuth:
+		return []string{"Run 'skyspy login', or start with --api-key <key>"}
+	case ws.KindHTTP:
+		return []string{"Check that the port points at a SkySpy server"}
+	default:
+		return nil
+	}
+}
+
+// renderConnectFailure draws the full-screen connection error
+func (m *Model) renderConnectFailure() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error).Bold(true)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	keyStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+	g := m.glyphs()
+
+	const width = 60
+	wrap := lipgloss.NewStyle().Width(width - 4)
+
+	url := fmt.Sprintf("ws://%s:%d", m.config.Connection.Host, m.config.Connection.Port)
+	headline := "Connection failed"
+	detail := m.connectFailure.Error()
+	var ce *ws.ConnectError
+	if errors.As(m.connectFailure, &ce) {
+		if ce.URL != "" {
+			url = ce.URL
+		}
+		headline = ce.Kind.String()
+		if ce.Status != 0 {
+			headline += fmt.Sprintf(" (HTTP %d)", ce.Status)
+		}
+		detail = ""
+		if ce.Err != nil {
+			detail = ce.Err.Error()
+		}
+	}
+	hints := m.connectHints
+	if len(hints) == 0 {
+		hints = connectAdvice(m.connectFailure)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, width-2) + g.DoubleTR))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleV) + errorStyle.Render(fmt.Sprintf("%-*s", width-2, "  CANNOT CONNECT TO SKYSPY SERVER")) + borderStyle.Render(g.DoubleV))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, width-2) + g.DoubleBR))
+	sb.WriteString("\n\n")
+
+	sb.WriteString("  " + textDim.Render("URL    ") + textStyle.Render(url))
+	sb.WriteString("\n")
+	sb.WriteString("  " + textDim.Render("Error  ") + errorStyle.Render(headline))
+	sb.WriteString("\n")
+	if detail != "" {
+		for _, line := range strings.Split(wrap.Render(detail), "\n") {
+			sb.WriteString("  " + textDim.Render(line))
+			sb.WriteString("\n")
+		}
+	}
+	if len(hints) > 0 {
+		sb.WriteString("\n")
+		for _, hint := range hints {
+			for _, line := range strings.Split(wrap.Render(hint), "\n") {
+				sb.WriteString("  " + textStyle.Render(line))
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	sb.WriteString("\n  ")
+	sb.WriteString(keyStyle.Render("[R]") + textDim.Render(" Retry   "))
+	sb.WriteString(keyStyle.Render("[C]") + textDim.Render(" Configure   "))
+	sb.WriteString(keyStyle.Render("[Q]") + textDim.Render(" Quit"))
+
+	view := sb.String()
+	if m.width > 0 && m.height > 0 {
+		view = lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, view)
+	}
+	return view
+}
@@ -0,0 +1,69 @@
+// Package app provides closest-point-of-approach prediction for the SkySpy radar
+package app
+
+import (
+	"fmt"
+
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// targetCPA predicts a target's closest approach to the receiver. It returns
+// false when the receiver location or the target's position, track or
+// ground speed is unknown.
+func targetCPA(t *radar.Target, refLat, refLon float64) (geo.CPA, bool) {
+	if refLat == 0 && refLon == 0 {
+		return geo.CPA{}, false
+	}
+	if !t.HasLat || !t.HasLon || !t.HasTrack || !t.HasSpeed || t.Speed <= 0 {
+		return geo.CPA{}, false
+	}
+	return geo.ComputeCPA(refLat, refLon, t.Lat, t.Lon, t.Track, t.Speed), true
+}
+
+// GetCPA returns the predicted closest approach of a target to the displayed
+// receiver position (approximate in privacy mode). Time to CPA counts down
+// from the last position report so it stays current between updates.
+func (m *Model) GetCPA(hex string) (geo.CPA, bool) {
+	target, ok := m.aircraft[hex]
+	if !ok {
+		return geo.CPA{}, false
+	}
+	receiverLat, receiverLon := m.displayReceiver()
+	cpa, ok := targetCPA(target, receiverLat, receiverLon)
+	if !ok {
+		return cpa, false
+	}
+	return m.ageCPA(hex, cpa), true
+}
+
+// ageCPA counts a prediction's time to CPA down by the time since the
+// target's last position report
+func (m *Model) ageCPA(hex string, cpa geo.CPA) geo.CPA {
+	if !cpa.Closing {
+		return cpa
+	}
+	if seen, tracked := m.lastSeen[hex]; tracked {
+		elapsed := m.now().Sub(seen)
+		if elapsed >= cpa.Time {
+			cpa.Time = 0
+			cpa.Closing = false
+		} else if elapsed > 0 {
+			cpa.Time -= elapsed
+		}
+	}
+	return cpa
+}
+
+func (m *Model) formatCPA(t *radar.Target) string {
+	cpa, ok := m.GetCPA(t.Hex)
+	if !ok {
+		return dashPlaceholder
+	}
+	if !cpa.Closing {
+		return "opening"
+	}
+	secs := int(cpa.Time.Seconds())
+	d, unit := m.inUnit(cpa.Distance)
+	return fmt.Sprintf("%.1f%s in %d:%02d", d, unit, secs/60, secs%60)
+}
@@ -0,0 +1,87 @@
+// Package app provides the diagnostic log for the SkySpy radar
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/export"
+)
+
+// openDebugLog opens the configured debug log for appending. A log that
+// can't be opened stays off, with a notification.
+func (m *Model) openDebugLog() {
+	if m.config.DebugLog == "" {
+		return
+	}
+	f, err := os.OpenFile(m.config.DebugLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		m.notify(m.trf("notify.debug_log_error", err.Error()))
+		return
+	}
+	m.debugLog = f
+	m.debugTail = readLogTail(m.config.DebugLog, export.StateLogLines)
+}
+
+// logTailChunk is how much of the log readLogTail reads at a time, from
+// the end back
+const logTailChunk = 64 * 1024
+
+// readLogTail returns the last n lines of the log at path, so entries from
+// earlier sessions go into state files too. Only the end of the file is
+// read, however long the log has grown.
+func readLogTail(path string, n int) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil
+	}
+
+	// Read back a chunk at a time until there are more than n line breaks,
+	// enough for n whole lines after the trailing one
+	var data []byte
+	for offset := info.Size(); offset > 0 && bytes.Count(data, []byte("\n")) <= n; {
+		size := min(offset, logTailChunk)
+		offset -= size
+		chunk := make([]byte, size)
+		if _, err := f.ReadAt(chunk, offset); err != nil {
+			return nil
+		}
+		data = append(chunk, data...)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	return lines[max(0, len(lines)-n):]
+}
+
+// debugf appends a timestamped entry to the debug log, if one is open.
+// The latest entries are kept either way for state files.
+func (m *Model) debugf(format string, args ...any) {
+	line := m.now().UTC().Format(time.RFC3339) + " " + fmt.Sprintf(format, args...)
+	m.debugTail = append(m.debugTail, line)
+	if over := len(m.debugTail) - export.StateLogLines; over > 0 {
+		m.debugTail = append(m.debugTail[:0], m.debugTail[over:]...)
+	}
+	if m.debugLog == nil {
+		return
+	}
+	fmt.Fprintln(m.debugLog, line)
+}
+
+// closeDebugLog closes the debug log
+func (m *Model) closeDebugLog() {
+	if m.debugLog != nil {
+		_ = m.debugLog.Close()
+		m.debugLog = nil
+	}
+}
@@ -0,0 +1,155 @@
+// Package app provides state files for bug reports for the SkySpy radar
+package app
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/skyspy/skyspy-go/internal/search"
+	"github.com/skyspy/skyspy-go/internal/trails"
+)
+
+// SetVersion records the SkySpy version, which state files carry
+func (m *Model) SetVersion(version string) {
+	m.version = version
+}
+
+// DebugState snapshots the radar for a bug report: the settings, the
+// aircraft and their trails, and how they are being shown. It isn't
+// sanitized; the caller decides what to strip.
+func (m *Model) DebugState() (*export.State, error) {
+	// The settings are copied so sanitizing leaves the session's alone
+	data, err := json.Marshal(m.config)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if m.positionFromServer() {
+		cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon = m.serverLat, m.serverLon
+	}
+
+	st := &export.State{
+		Format:   export.StateFormat,
+		Version:  m.version,
+		Created:  m.now().UTC(),
+		Config:   cfg,
+		Aircraft: make([]export.StateAircraft, 0, len(m.aircraft)),
+		Trails:   make(map[string][]trails.Position),
+		View: export.StateView{
+			Mode:     int(m.viewMode),
+			Width:    m.width,
+			Height:   m.height,
+			Selected: m.selectedHex,
+			Follow:   m.followHex,
+			Pinned:   append([]string(nil), m.pinned...),
+		},
+		Log: append([]string(nil), m.debugTail...),
+	}
+	if m.caps.Theme {
+		st.View.Theme = m.config.Display.Theme
+	}
+	if m.searchFilter != nil {
+		st.View.Filter = m.searchFilter.Query
+	}
+
+	for _, t := range m.aircraft {
+		st.Aircraft = append(st.Aircraft, export.StateAircraft{Target: *t, LastSeen: m.lastSeen[t.Hex]})
+	}
+	sort.Slice(st.Aircraft, func(i, j int) bool { return st.Aircraft[i].Hex < st.Aircraft[j].Hex })
+	for hex, trail := range m.trailTracker.GetAllTrails() {
+		if len(trail) > 0 {
+			st.Trails[hex] = trail
+		}
+	}
+	return st, nil
+}
+
+// replayViews are the views a state file may open in. The rest need
+// state of their own that isn't saved, and open as the radar.
+var replayViews = map[ViewMode]bool{
+	ViewRadar: true, ViewSettings: true, ViewHelp: true, ViewOverlays: true, ViewAlertRules: true,
+}
+
+// replayCapabilities are what a loaded state file starts with: nothing
+// that would sound, serve, write files or save the reporter's settings
+func replayCapabilities() Capabilities {
+	return Capabilities{Overlays: true, Theme: true, Alerts: true}
+}
+
+// NewModelFromState creates a model showing a state file as it was
+// written, with no feed. The clock stays at the time it was written, so
+// nothing ages out, and the screen keeps the reporter's size.
+func NewModelFromState(st *export.State) *Model {
+	cfg := st.Config
+	cfg.Display.Theme = st.View.Theme
+	cfg.DebugLog = ""
+
+	m := NewModelWithCapabilities(cfg, nil, replayCapabilities())
+	created := st.Created
+	m.now = func() time.Time { return created }
+	m.version = st.Version
+
+	for _, a := range st.Aircraft {
+		t := a.Target
+		t.SquawkCodes = m.squawkCodes
+		m.aircraft[t.Hex] = &t
+		m.lastSeen[t.Hex] = a.LastSeen
+	}
+	for hex, trail := range st.Trails {
+		m.trailTracker.Restore(hex, trail)
+	}
+	m.updateStats()
+
+	if _, ok := m.aircraft[st.View.Selected]; ok {
+		m.selectedHex = st.View.Selected
+	}
+	for _, hex := range st.View.Pinned {
+		if _, ok := m.aircraft[hex]; ok {
+			m.pinned = append(m.pinned, hex)
+		}
+	}
+	if _, ok := m.aircraft[st.View.Follow]; ok {
+		m.followHex = st.View.Follow
+		m.trackFollowed()
+	}
+	if st.View.Filter != "" {
+		m.searchFilter = search.ParseQuery(st.View.Filter)
+	}
+	if mode := ViewMode(st.View.Mode); replayViews[mode] {
+		m.viewMode = mode
+	}
+	m.width, m.height = st.View.Width, st.View.Height
+	m.fixedSize = m.width > 0 && m.height > 0
+
+	m.debugTail = append([]string(nil), st.Log...)
+	m.notifyFor(m.trf("notify.state_loaded", m.locale.Clock(created.Local())), 10)
+	return m
+}
+
+// exportDebugState writes a state file for a bug report. Privacy mode
+// keeps the receiver's position out of it.
+func (m *Model) exportDebugState() {
+	m.exportDebugStateTo(m.GetExportDirectory())
+}
+
+func (m *Model) exportDebugStateTo(dir string) {
+	st, err := m.DebugState()
+	if err != nil {
+		m.notify(m.trf("notify.export_failed", err.Error()))
+		return
+	}
+	st.Sanitize(!m.IsPrivacyMode())
+
+	filename, err := export.ExportState(st, dir)
+	if err != nil {
+		m.exportFailed(err, dir, m.exportDebugStateTo)
+		return
+	}
+	m.notify(m.trf("notify.state_saved", m.exportedName(filename)))
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/skyspy/skyspy-go/internal/search"
+)
+
+// stateScene is a radar worth reporting: traffic with trails, a filter, a
+// selection and a followed target
+func stateScene(t *testing.T) (*Model, *time.Time) {
+	t.Helper()
+	m, clock := newTrackingModel()
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	m.updateTarget(flying("484B1C", 52.00), true)
+	m.updateTarget(flying("AE1234", 52.50), true)
+	flyLeg(m, clock, "484B1C", 52.02, 52.04, 52.06)
+	m.searchFilter = search.ParseQuery("alt:<40000")
+	m.selectedHex = "484B1C"
+	m.toggleFollow()
+	m.debugf("unreadable fields from 484B1C: alt_baro")
+	m.updateStats()
+	m.notification = ""
+	return m, clock
+}
+
+func TestDebugState_ReplaysTheSameScreen(t *testing.T) {
+	m, clock := stateScene(t)
+	st, err := m.DebugState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := export.EncodeState(&buf, st); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := export.DecodeState(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay := NewModelFromState(loaded)
+
+	if replay.feed != nil || !replay.now().Equal(*clock) {
+		t.Error("a replay should have no feed and a clock stopped at the dump")
+	}
+	if !strings.Contains(replay.notification, "Replaying state from") {
+		t.Errorf("notification = %q", replay.notification)
+	}
+	if len(replay.debugTail) != 1 || !strings.HasSuffix(replay.debugTail[0], "alt_baro") {
+		t.Errorf("log = %q", replay.debugTail)
+	}
+	if replay.trailTracker.TrailLength("484B1C") != 4 || replay.Following() == "" {
+		t.Error("trails and follow should be restored")
+	}
+
+	// Apart from the notification the screens match
+	replay.notification = ""
+	if got, want := ansi.Strip(replay.View()), ansi.Strip(m.View()); got != want {
+		t.Errorf("replayed screen differs:\n%s\nwant:\n%s", got, want)
+	}
+
+	// The reporter's size is kept whatever the terminal
+	replay.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if replay.width != 160 || replay.height != 60 {
+		t.Errorf("size = %dx%d, want the reporter's 160x60", replay.width, replay.height)
+	}
+
+	// Nothing ages out while the replay is left open
+	replay.handleTick()
+	if len(replay.aircraft) != 2 {
+		t.Errorf("aircraft = %d, want 2", len(replay.aircraft))
+	}
+}
+
+func TestDebugState_DoesNotTouchSession(t *testing.T) {
+	m, _ := stateScene(t)
+	m.config.Kiosk.Unlock = "ctrl+k"
+	st, err := m.DebugState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.Sanitize(false)
+	if m.config.Kiosk.Unlock != "ctrl+k" || m.config.Connection.ReceiverLat != 52.3676 {
+		t.Error("sanitizing a state should leave the session's settings alone")
+	}
+}
+
+func TestDebugState_ServerPosition(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon = 0, 0
+	m := NewModel(cfg)
+	m.SetServerPosition(51.5, -0.12)
+	st, err := m.DebugState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Config.Connection.ReceiverLat != 51.5 || st.Config.Connection.ReceiverLon != -0.12 {
+		t.Errorf("the server's receiver position should be kept, got %v,%v",
+			st.Config.Connection.ReceiverLat, st.Config.Connection.ReceiverLon)
+	}
+}
+
+func TestDebugState_KeyWritesSanitizedFile(t *testing.T) {
+	m, _ := stateScene(t)
+	dir := t.TempDir()
+	m.config.Export.Directory = dir
+	m.config.Display.PrivacyMode = true
+	m.debugf("refreshing with token=abc123")
+
+	pressKey(m, "ctrl+d")
+	matches, _ := filepath.Glob(filepath.Join(dir, "skyspy_state_*.json.gz"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one state file, got %v (notification %q)", matches, m.notification)
+	}
+	if !strings.HasPrefix(m.notification, "State for bug report: skyspy_state_") {
+		t.Errorf("notification = %q", m.notification)
+	}
+
+	st, err := export.ReadState(matches[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.PositionStripped || st.Config.Connection.ReceiverLat == 52.3676 {
+		t.Error("privacy mode should keep the receiver position out of the file")
+	}
+	if last := st.Log[len(st.Log)-1]; !strings.HasSuffix(last, "token=[redacted]") {
+		t.Errorf("tokens should be scrubbed from the log, got %q", last)
+	}
+}
+
+func TestDebugLog_KeepsLatestEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+	var earlier strings.Builder
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&earlier, "2026-02-28T10:00:00Z earlier %d\n", i)
+	}
+	if err := os.WriteFile(path, []byte(earlier.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := newTestConfig()
+	cfg.DebugLog = path
+	m := NewModel(cfg)
+	defer m.closeDebugLog()
+	if len(m.debugTail) != export.StateLogLines || m.debugTail[0] != "2026-02-28T10:00:00Z earlier 50" {
+		t.Fatalf("the log's last %d lines should be kept, got %d from %q", export.StateLogLines, len(m.debugTail), m.debugTail[0])
+	}
+
+	m.debugf("latest")
+	if len(m.debugTail) != export.StateLogLines || !strings.HasSuffix(m.debugTail[len(m.debugTail)-1], " latest") {
+		t.Errorf("new entries should push out the oldest: %q", m.debugTail[len(m.debugTail)-1])
+	}
+
+	// Entries are kept without a log file too
+	m = NewModel(newTestConfig())
+	m.debugf("no file")
+	if len(m.debugTail) != 1 {
+		t.Errorf("log = %q", m.debugTail)
+	}
+}
+
+func TestReadLogTail_LongLog(t *testing.T) {
+	// Well over a chunk, with lines that straddle the chunk boundaries
+	var sb strings.Builder
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&sb, "entry %04d %s\n", i, strings.Repeat("x", i%300))
+	}
+	path := filepath.Join(t.TempDir(), "debug.log")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tail := readLogTail(path, 100)
+	if len(tail) != 100 || !strings.HasPrefix(tail[0], "entry 1900 ") || !strings.HasPrefix(tail[99], "entry 1999 ") {
+		t.Fatalf("expected entries 1900 to 1999, got %d from %q", len(tail), tail[0][:min(len(tail[0]), 20)])
+	}
+	if want := "entry 1900 " + strings.Repeat("x", 1900%300); tail[0] != want {
+		t.Errorf("the first line should be whole, got %q", tail[0])
+	}
+	if got := readLogTail(path, 5000); len(got) != 2000 {
+		t.Errorf("asking for more than there are should give them all, got %d", len(got))
+	}
+}
@@ -0,0 +1,60 @@
+// Package app provides density shading for wide radar ranges for the SkySpy radar
+package app
+
+// Density shading settings, cycled with Ctrl+O
+const (
+	densityAuto = iota // on at or beyond the configured density range
+	densityOn
+	densityOff
+)
+
+// densityActive reports whether a scope at rangeNM shades targets by
+// density
+func (m *Model) densityActive(rangeNM float64) bool {
+	switch m.densityMode {
+	case densityOn:
+		return true
+	case densityOff:
+		return false
+	}
+	limit := m.config.Radar.DensityRange
+	return limit > 0 && rangeNM >= float64(limit)
+}
+
+// cycleDensityMode steps density shading from automatic to on to off
+func (m *Model) cycleDensityMode() {
+	m.densityMode = (m.densityMode + 1) % 3
+	switch m.densityMode {
+	case densityOn:
+		m.notify(m.tr("notify.density_on"))
+	case densityOff:
+		m.notify(m.tr("notify.density_off"))
+	default:
+		m.notifyRange("notify.density_auto", m.config.Radar.DensityRange)
+	}
+}
+
+// densityRamp returns the shading characters, light to full: the
+// configured ones, else the glyph set's shades
+func (m *Model) densityRamp() []rune {
+	if ramp := m.config.Radar.DensityRamp; ramp != "" {
+		return []rune(ramp)
+	}
+	var ramp []rune
+	for _, shade := range m.glyphs().Shades {
+		ramp = append(ramp, []rune(shade)[0])
+	}
+	return ramp
+}
+
+// watchedHexes returns the notable aircraft in view, which density shading
+// still draws one by one
+func (m *Model) watchedHexes() []string {
+	var hexes []string
+	for hex, s := range m.sightings {
+		if !s.gone {
+			hexes = append(hexes, hex)
+		}
+	}
+	return hexes
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDensityMode_AutoAndCycle(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	if !m.densityActive(300) || !m.densityActive(400) {
+		t.Error("density shading should turn on at or beyond the density range")
+	}
+	if m.densityActive(200) {
+		t.Error("density shading should stay off below the density range")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !m.densityActive(10) || m.notification != "Density shading: ON" {
+		t.Errorf("Ctrl+O should force density shading on, notification %q", m.notification)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m.densityActive(400) {
+		t.Error("a second Ctrl+O should force density shading off")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !m.densityActive(400) || m.densityActive(200) {
+		t.Error("a third Ctrl+O should return to automatic")
+	}
+
+	m.config.Radar.DensityRange = 0
+	if m.densityActive(1000) {
+		t.Error("a density range of 0 should leave density shading to Ctrl+O")
+	}
+}
+
+func TestDensityMode_Ramp(t *testing.T) {
+	m := NewModel(newTestConfig())
+	if got := string(m.densityRamp()); got != "░▒▓█" {
+		t.Errorf("ramp = %q, want the theme's shades", got)
+	}
+	m.config.Radar.DensityRamp = ".oO@"
+	if got := string(m.densityRamp()); got != ".oO@" {
+		t.Errorf("ramp = %q, want the configured one", got)
+	}
+}
+
+func TestDensityMode_SelectionIteratesTargets(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	m.maxRange = 400
+	for i := 0; i < 20; i++ {
+		m.updateTarget(flying(fmt.Sprintf("DEN%03d", i), 53+float64(i)*0.001), true)
+	}
+	if frame := ansi.Strip(m.View()); !strings.Contains(frame, "█") {
+		t.Fatalf("the cluster should be shaded at 400 nm:\n%s", frame)
+	}
+	seen := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		pressKey(m, "j")
+		m.View()
+		seen[m.selectedHex] = true
+	}
+	if len(seen) != 20 {
+		t.Errorf("j should still step through every shaded target, saw %d of 20", len(seen))
+	}
+}
@@ -0,0 +1,266 @@
+// Package app provides the aircraft detail page for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// Detail page layout: as wide as the header and as tall as the radar it
+// stands in for
+const (
+	detailWidth    = 98 // inside the frame
+	detailColumn   = 49 // each of the two field columns
+	detailSpark    = 60 // RSSI readings drawn in the sparkline
+	detailMaxACARS = 6  // newest messages listed
+)
+
+// openDetail shows the detail page of the selected aircraft
+func (m *Model) openDetail() {
+	if _, ok := m.aircraft[m.selectedHex]; !ok || m.selectedHex == "" {
+		m.notify(m.tr("notify.detail_no_target"))
+		return
+	}
+	m.detailHex = m.selectedHex
+	m.viewMode = ViewAircraftDetail
+}
+
+// handleDetailKey closes the detail page
+func (m *Model) handleDetailKey(key string) {
+	switch key {
+	case keyEsc, "ctrl+f":
+		m.viewMode = ViewRadar
+	}
+}
+
+// detailACARS returns the newest ACARS messages from the flight, oldest
+// first
+func (m *Model) detailACARS(callsign string) []ACARSMessage {
+	callsign = strings.ToUpper(strings.TrimSpace(callsign))
+	if callsign == "" {
+		return nil
+	}
+	var msgs []ACARSMessage
+	for i := len(m.acarsMessages) - 1; i >= 0 && len(msgs) < detailMaxACARS; i-- {
+		msg := m.acarsMessages[i]
+		if strings.ToUpper(strings.TrimSpace(msg.Callsign)) == callsign || strings.ToUpper(strings.TrimSpace(msg.Flight)) == callsign {
+			msgs = append(msgs, msg)
+		}
+	}
+	for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+		msgs[i], msgs[j] = msgs[j], msgs[i]
+	}
+	return msgs
+}
+
+// sparkline draws the last width samples as bars scaled between their
+// lowest and highest, at least 1 apart so steady signals stay flat
+func sparkline(samples []float64, levels []string, width int) string {
+	if len(samples) > width {
+		samples = samples[len(samples)-width:]
+	}
+	if len(samples) == 0 || len(levels) == 0 {
+		return ""
+	}
+	lo, hi := samples[0], samples[0]
+	for _, v := range samples {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	span := math.Max(hi-lo, 1)
+	var sb strings.Builder
+	for _, v := range samples {
+		sb.WriteString(levels[int(math.Round((v-lo)/span*float64(len(levels)-1)))])
+	}
+	return sb.String()
+}
+
+// detailField is a labelled value on the detail page
+type detailField struct {
+	label string
+	value string
+	style lipgloss.Style
+}
+
+// renderDetailPage renders everything known about one aircraft, in place
+// of the radar and sidebar
+func (m *Model) renderDetailPage() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.theme.Selected).Bold(true)
+	militaryStyle := lipgloss.NewStyle().Foreground(m.theme.Military).Bold(true)
+	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	g := m.glyphs()
+
+	var lines []string
+	row := func(content string) {
+		pad := max(detailWidth-lipgloss.Width(content), 0)
+		lines = append(lines, borderStyle.Render(g.DoubleV)+content+strings.Repeat(" ", pad)+borderStyle.Render(g.DoubleV))
+	}
+	rule := func() {
+		lines = append(lines, borderStyle.Render(g.DoubleSepLeft+strings.Repeat(g.H, detailWidth)+g.DoubleSepRight))
+	}
+	cell := func(f detailField) string {
+		if f.label == "" {
+			return strings.Repeat(" ", detailColumn)
+		}
+		value := f.value
+		if value == "" {
+			value = emptyPlaceholder
+		}
+		return textDim.Render(fmt.Sprintf("  %-5s ", f.label)) + f.style.Render(fmt.Sprintf("%-*s", detailColumn-8, fit(value, detailColumn-8)))
+	}
+
+	target := m.aircraft[m.detailHex]
+	if target == nil {
+		target = &radar.Target{Hex: m.detailHex, SquawkCodes: m.squawkCodes}
+	}
+	history := m.history[m.detailHex]
+	if history == nil {
+		history = &aircraftHistory{}
+	}
+
+	lines = append(lines, borderStyle.Render(g.DoubleTL+strings.Repeat(g.DoubleH, detailWidth)+g.DoubleTR))
+	row(titleStyle.Render(panelHeading(m.trf("title.detail", pinLabel(target)), detailWidth)))
+	lines = append(lines, borderStyle.Render(g.DoubleTeeLeft+strings.Repeat(g.DoubleH, detailWidth)+g.DoubleTeeRight))
+
+	// Identity and flight on the left, where and how long on the right
+	flags := strings.ToUpper(target.Hex)
+	flagStyle := secondaryBright
+	switch {
+	case target.Military:
+		flags += " MIL"
+		flagStyle = militaryStyle
+	case target.Conflicted:
+		flags += " " + g.Conflict + " DUP HEX"
+		flagStyle = warningStyle
+	}
+	if target.Ground {
+		flags += " GND"
+	}
+	altValue, altStyle := m.formatAltitudes(target, primaryBright)
+	speedValue, gnssValue := m.formatSpeed(target), ""
+	if m.config.Display.DualUnits {
+		altValue, gnssValue = m.formatDualAltitudes(target)
+		speedValue = m.formatDualSpeed(target)
+	}
+	left := []detailField{
+		{"CALL", target.Callsign, selectedStyle},
+		{"HEX", flags, flagStyle},
+		{"REG", target.Reg, primaryBright},
+		{"TYPE", formatType(target), primaryBright},
+		{"CAT", formatCategory(target), primaryBright},
+		{"ALT", altValue, altStyle},
+	}
+	if gnssValue != "" {
+		left = append(left, detailField{"GNSS", gnssValue, altStyle})
+	}
+	left = append(left, []detailField{
+		{"GS", speedValue, primaryBright},
+		{"VS", m.formatVS(target), m.getVSStyle(target)},
+		{"HDG", m.formatTrack(target), primaryBright},
+		{"TURN", m.formatTurn(target), primaryBright},
+		{"SEL", m.formatNavSelected(target), primaryBright},
+		{"MODE", m.formatNavModes(target), primaryBright},
+	}...)
+
+	var lastSeen, trail string
+	if seen, ok := m.lastSeen[target.Hex]; ok {
+		lastSeen = m.trf("detail.ago", m.locale.Clock(seen), formatAge(m.now().Sub(seen)))
+	}
+	if points := m.trailTracker.TrailLength(target.Hex); points > 0 {
+		trail = m.trf("detail.trail", m.locale.Int(points), m.formatDist(m.trailTracker.Flown(target.Hex), 1))
+	}
+	var firstSeen string
+	if !target.FirstSeen.IsZero() {
+		firstSeen = m.locale.Clock(target.FirstSeen)
+	}
+	right := []detailField{
+		{"POS", m.formatPosition(target), secondaryBright},
+	}
+	if rejected := m.formatRejectedPositions(target); rejected != "" {
+		right = append(right, detailField{"REJ", rejected, warningStyle})
+	}
+	right = append(right, []detailField{
+		{"REL", m.formatRelative(target), secondaryBright},
+		{"CPA", m.formatCPA(target), secondaryBright},
+	}...)
+	if _, ok := m.activePOI(); ok {
+		right = append(right, detailField{"POI", m.formatPOI(target.Hex), secondaryBright})
+	}
+	if m.hasRegions() {
+		right = append(right, detailField{"RGN", target.Region, secondaryBright})
+	}
+	right = append(right, []detailField{
+		{"SQ", m.formatSquawk(target), m.getSquawkStyle(target)},
+		{"RSSI", m.formatSignalStats(target), secondaryBright},
+	}...)
+	if receivers := m.formatReceivers(target); receivers != "" {
+		right = append(right, detailField{"RCV", receivers, secondaryBright})
+	}
+	right = append(right, []detailField{
+		{"FIRST", firstSeen, textStyle},
+		{"LAST", lastSeen, textStyle},
+		{"TRKD", formatTracked(target, m.now(), m.distUnit()), textStyle},
+		{"TRAIL", trail, textStyle},
+	}...)
+	for i := 0; i < max(len(left), len(right)); i++ {
+		var l, r detailField
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		row(cell(l) + cell(r))
+	}
+
+	// Signal and squawk history
+	rule()
+	signal := textDim.Render(m.tr("detail.no_signal"))
+	if len(history.rssi) > 0 {
+		latest := history.rssi[len(history.rssi)-1]
+		signal = secondaryBright.Render(sparkline(history.rssi, g.BarLevels, detailSpark)) + textDim.Render(" "+m.locale.Float(latest, 1)+" dBFS")
+	}
+	row(textDim.Render(fmt.Sprintf("  %-5s ", "SIG")) + signal)
+	squawks := make([]string, 0, len(history.squawks))
+	for _, sq := range history.squawks {
+		squawks = append(squawks, sq.code+" "+m.locale.Clock(sq.at))
+	}
+	// The newest codes are kept when they don't all fit
+	for len(squawks) > 1 && lipgloss.Width(strings.Join(squawks, ", ")) > detailWidth-8 {
+		squawks = squawks[1:]
+	}
+	sqValue := strings.Join(squawks, ", ")
+	if sqValue == "" {
+		sqValue = emptyPlaceholder
+	}
+	row(textDim.Render(fmt.Sprintf("  %-5s ", "SQ")) + m.getSquawkStyle(target).Render(fit(sqValue, detailWidth-8)))
+
+	// ACARS from this flight
+	rule()
+	row(titleStyle.Render("  " + m.tr("detail.acars")))
+	msgs := m.detailACARS(target.Callsign)
+	if len(msgs) == 0 {
+		row(textDim.Render("  " + m.tr("detail.no_acars")))
+	}
+	for _, msg := range msgs {
+		head := m.locale.Clock(msg.Received) + " [" + msg.Label + "] "
+		row(textDim.Render("  "+head) + textStyle.Render(fit(strings.Join(strings.Fields(msg.Text), " "), detailWidth-4-lipgloss.Width(head))))
+	}
+
+	// Fill to the radar's height, with the keys on the last line
+	for len(lines) < radar.RadarHeight {
+		row("")
+	}
+	row(textDim.Render("  " + m.tr("detail.help")))
+	lines = append(lines, borderStyle.Render(g.DoubleBL+strings.Repeat(g.DoubleH, detailWidth)+g.DoubleBR))
+	return strings.Join(lines, "\n")
+}
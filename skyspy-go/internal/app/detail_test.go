@@ -0,0 +1,158 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// newDetailModel tracks KLM1234 for a minute, its RSSI rising and its
+// squawk changing to 7700 halfway, and opens its detail page
+func newDetailModel(t *testing.T) (*Model, *time.Time) {
+	t.Helper()
+	m, clock := newTrackingModel()
+	m.width, m.height = 100, 55
+	ac := flying("4840d6", 52.40)
+	ac.Flight = "KLM1234"
+	ac.Squawk = "1000"
+	for i := 0; i < 12; i++ {
+		if i == 6 {
+			ac.Squawk = "7700"
+		}
+		ac.RSSI = floatPtr(-20 + float64(i))
+		m.updateTarget(ac, i == 0)
+		*clock = clock.Add(5 * time.Second)
+	}
+	m.selectedHex = "4840D6"
+	pressKey(m, "ctrl+f")
+	if m.viewMode != ViewAircraftDetail {
+		t.Fatalf("Ctrl+F should open the detail page, view %v", m.viewMode)
+	}
+	return m, clock
+}
+
+func TestDetail_OpenNeedsATarget(t *testing.T) {
+	m, _ := newTrackingModel()
+	pressKey(m, "ctrl+f")
+	if m.viewMode != ViewRadar {
+		t.Errorf("no target is selected, view %v", m.viewMode)
+	}
+	if m.notification != m.tr("notify.detail_no_target") {
+		t.Errorf("notification = %q", m.notification)
+	}
+}
+
+func TestDetail_History(t *testing.T) {
+	m, _ := newDetailModel(t)
+	h := m.history["4840D6"]
+	if h == nil {
+		t.Fatal("no history recorded")
+	}
+	if len(h.rssi) != 12 || h.rssi[0] != -20 || h.rssi[11] != -9 {
+		t.Errorf("rssi = %v", h.rssi)
+	}
+	if len(h.squawks) != 2 || h.squawks[0].code != "1000" || h.squawks[1].code != "7700" {
+		t.Fatalf("squawks = %+v", h.squawks)
+	}
+	if want := time.Date(2026, 3, 1, 12, 0, 30, 0, time.UTC); !h.squawks[1].at.Equal(want) {
+		t.Errorf("7700 set at %v, want %v", h.squawks[1].at, want)
+	}
+
+	// Reports without an RSSI or squawk leave the history alone
+	ac := flying("4840d6", 52.41)
+	m.updateTarget(ac, false)
+	if len(h.rssi) != 12 || len(h.squawks) != 2 {
+		t.Errorf("history changed by a bare report: %d readings, %d squawks", len(h.rssi), len(h.squawks))
+	}
+}
+
+func TestDetail_HistoryIsCapped(t *testing.T) {
+	m, clock := newTrackingModel()
+	ac := flying("4840d6", 52.40)
+	for i := 0; i < maxRSSISamples+10; i++ {
+		ac.RSSI = floatPtr(float64(-i))
+		ac.Squawk = []string{"1000", "2000"}[i%2]
+		m.updateTarget(ac, false)
+		*clock = clock.Add(time.Second)
+	}
+	h := m.history["4840D6"]
+	if len(h.rssi) != maxRSSISamples || h.rssi[len(h.rssi)-1] != float64(-(maxRSSISamples+9)) {
+		t.Errorf("kept %d readings ending %v, want the newest %d", len(h.rssi), h.rssi[len(h.rssi)-1], maxRSSISamples)
+	}
+	if len(h.squawks) != maxSquawkChanges {
+		t.Errorf("kept %d squawk changes, want %d", len(h.squawks), maxSquawkChanges)
+	}
+}
+
+func TestDetail_Page(t *testing.T) {
+	m, clock := newDetailModel(t)
+	m.acarsMessages = append(m.acarsMessages,
+		ACARSMessage{Callsign: "KLM1234", Label: "H1", Text: "REQUEST\nWX EHAM", Received: *clock},
+		ACARSMessage{Callsign: "BAW1", Label: "Q0", Text: "OTHER FLIGHT", Received: *clock},
+	)
+
+	view := m.View()
+	page := ansi.Strip(view)
+	for _, want := range []string{"AIRCRAFT DETAIL  KLM1234", "4840D6", "450 kt", "1000 12:00:00, 7700 12:00:30", "[H1] REQUEST WX EHAM", "-9.0 dBFS", "12:00:55 (5s ago)"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page lacks %q:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "OTHER FLIGHT") {
+		t.Error("ACARS from other flights should not be listed")
+	}
+	for i, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > 100 {
+			t.Errorf("line %d is %d columns wide", i, w)
+		}
+	}
+
+	// It follows new reports
+	ac := flying("4840d6", 52.41)
+	ac.GS = floatPtr(300)
+	m.updateTarget(ac, false)
+	if page := ansi.Strip(m.View()); !strings.Contains(page, "300 kt") {
+		t.Errorf("page should show the new speed:\n%s", page)
+	}
+
+	pressKey(m, keyEsc)
+	if m.viewMode != ViewRadar {
+		t.Errorf("Esc should close the page, view %v", m.viewMode)
+	}
+}
+
+func TestDetail_ClosesWhenTheAircraftGoes(t *testing.T) {
+	m, _ := newDetailModel(t)
+	m.removeAircraft("4840D6")
+	if m.viewMode != ViewRadar {
+		t.Errorf("the page should close, view %v", m.viewMode)
+	}
+	if !strings.Contains(m.notification, "KLM1234") {
+		t.Errorf("notification = %q", m.notification)
+	}
+	if _, ok := m.history["4840D6"]; ok {
+		t.Error("history should go with the aircraft")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	levels := []string{"1", "2", "3", "4"}
+	tests := []struct {
+		samples []float64
+		width   int
+		want    string
+	}{
+		{nil, 5, ""},
+		{[]float64{-10, -7, -4, -1}, 5, "1234"},
+		{[]float64{-10, -7, -4, -1}, 2, "14"},
+		{[]float64{-5, -5.2, -5}, 5, "212"}, // under 1 dB apart stays low
+	}
+	for _, tt := range tests {
+		if got := sparkline(tt.samples, levels, tt.width); got != tt.want {
+			t.Errorf("sparkline(%v, %d) = %q, want %q", tt.samples, tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package app provides the distance units of the SkySpy radar
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/units"
+)
+
+// distUnit returns the unit distances and ranges are shown in. They are
+// kept in nautical miles whatever it is.
+func (m *Model) distUnit() string {
+	return units.Distance(m.config.Display.Units)
+}
+
+// formatDist writes a distance in nm in the shown unit with prec
+// decimals, e.g. "12.4nm" or "23.0km"
+func (m *Model) formatDist(nm float64, prec int) string {
+	unit := m.distUnit()
+	return m.locale.Float(units.FromNM(nm, unit), prec) + unit
+}
+
+// inUnit converts a distance in nm to the shown unit, returning the unit
+// with it
+func (m *Model) inUnit(nm float64) (float64, string) {
+	unit := m.distUnit()
+	return units.FromNM(nm, unit), unit
+}
+
+// rangeLabel writes a range in nm in the shown unit as a whole number,
+// e.g. "100nm" or "185km"
+func (m *Model) rangeLabel(nm int) string {
+	unit := m.distUnit()
+	return strconv.Itoa(int(units.FromNM(float64(nm), unit))) + unit
+}
+
+// notifyRange shows a range notification. In nm it keeps the message
+// translations already have; other units use the key's _units message.
+func (m *Model) notifyRange(key string, nm int) {
+	if m.distUnit() == units.NM {
+		m.notify(m.trf(key, nm))
+		return
+	}
+	m.notify(m.trf(key+"_units", m.rangeLabel(nm)))
+}
+
+// cycleDistUnits moves on to the next distance unit: nm, km, mi
+func (m *Model) cycleDistUnits() {
+	current := m.distUnit()
+	next := units.DistanceUnits[0]
+	for i, u := range units.DistanceUnits {
+		if u == current {
+			next = units.DistanceUnits[(i+1)%len(units.DistanceUnits)]
+		}
+	}
+	m.config.Display.Units = next
+	if m.alertState != nil && m.alertState.Engine != nil {
+		m.alertState.Engine.SetDistanceUnit(next)
+	}
+	m.notify(m.trf("notify.units", strings.ToUpper(next)))
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDistUnits_Cycle(t *testing.T) {
+	m := NewModel(newTestConfig())
+	for _, want := range []string{"km", "mi", "nm"} {
+		m.handleRadarKey("ctrl+l")
+		if m.config.Display.Units != want {
+			t.Fatalf("units = %q, want %q", m.config.Display.Units, want)
+		}
+		if m.notification != "Units: "+strings.ToUpper(want) {
+			t.Errorf("notification = %q", m.notification)
+		}
+	}
+}
+
+func TestDistUnits_Shown(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.width, m.height = 160, 60
+	m.config.Display.Units = "km"
+
+	// 0.5 degrees of latitude north of the receiver is 30nm, 55.6km
+	m.updateTarget(flying("KMT001", 52.8676), true)
+	m.sortedTargets = []string{"KMT001"}
+	m.selectedHex = "KMT001"
+
+	if got := m.formatDistance(m.aircraft["KMT001"]); got != "55.6km" {
+		t.Errorf("distance = %q, want 55.6km", got)
+	}
+	if got := m.formatRelative(m.aircraft["KMT001"]); !strings.HasSuffix(got, "/55.6km") {
+		t.Errorf("relative position = %q", got)
+	}
+	if list := ansi.Strip(m.renderTargetList()); !strings.Contains(list, " 56") {
+		t.Errorf("the target list should show 56 km:\n%s", list)
+	}
+
+	// The scope, status bar and spectrum label the 100nm range in km
+	if top := strings.Split(ansi.Strip(m.renderRadar()), "\n")[0]; !strings.Contains(top, " 185km ") {
+		t.Errorf("scope heading = %q", top)
+	}
+	if bar := ansi.Strip(m.renderStatusBar()); !strings.Contains(bar, " 185km ") {
+		t.Errorf("status bar = %q", bar)
+	}
+	if axis := m.spectrumAxis(); len(axis) != 31 || !strings.HasSuffix(axis, "185 km") || !strings.Contains(axis, " 93 ") {
+		t.Errorf("spectrum axis = %q", axis)
+	}
+}
+
+func TestDistUnits_RangeEntry(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.config.Display.Units = "mi"
+
+	typeKeys(m, "n115")
+	if !strings.Contains(ansi.Strip(m.renderStatusBar()), "RANGE 115_ mi") {
+		t.Error("the range should be asked for in miles")
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Ranges are kept in nm
+	if m.targetRange != 100 || m.config.Radar.DefaultRange != 100 {
+		t.Errorf("range = %v, want 100nm", m.targetRange)
+	}
+	if m.notification != "Range: 115mi" {
+		t.Errorf("notification = %q", m.notification)
+	}
+}
+
+func TestDistUnits_AutoModeNotices(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.config.Display.Units = "km"
+
+	notices := map[string]bool{}
+	for range 3 {
+		m.cycleSurfaceMode()
+		notices[m.notification] = true
+		m.cycleDensityMode()
+		notices[m.notification] = true
+	}
+	// 10nm and 300nm, in km
+	for _, want := range []string{"Surface mode: AUTO (18km and in)", "Density shading: AUTO (555km and out)"} {
+		if !notices[want] {
+			t.Errorf("expected %q among %v", want, notices)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Package app provides the emergency squawk history for the SkySpy radar
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+const (
+	// maxEmergencies is how many history entries the session keeps
+	maxEmergencies = 50
+	// emergencyLogBuffer is how many entries may wait for the disk
+	emergencyLogBuffer = 64
+)
+
+// Emergency history events
+const (
+	emergencyStart = "start"
+	emergencyEnd   = "end"
+)
+
+// emergencyEntry is one line of the emergency history: a target starting
+// or stopping an emergency squawk. Position fields are left out when the
+// target didn't report them.
+type emergencyEntry struct {
+	Time     time.Time `json:"time"`
+	Event    string    `json:"event"`
+	Hex      string    `json:"hex"`
+	Callsign string    `json:"callsign,omitempty"`
+	Squawk   string    `json:"squawk"`
+	Lat      *float64  `json:"lat,omitempty"`
+	Lon      *float64  `json:"lon,omitempty"`
+	Altitude *int      `json:"altitude,omitempty"`
+	Distance *float64  `json:"distance_nm,omitempty"`
+}
+
+// SetEmergencyLog appends the emergency history to path from now on. The
+// history is kept whatever the alert settings; without a log it is only
+// kept for the session, as it is when automatic exports are turned off.
+func (m *Model) SetEmergencyLog(path string) {
+	m.CloseEmergencyLog()
+	if !m.caps.Exports {
+		return
+	}
+	m.emergencyLog = export.NewAppender(path, emergencyLogBuffer)
+}
+
+// recordEmergency notes a target starting, changing or stopping an
+// emergency squawk. A target squawking the same code update after update
+// is one entry.
+func (m *Model) recordEmergency(t *radar.Target) {
+	squawk, active := m.emergencyActive[t.Hex]
+	switch {
+	case t.IsEmergency() && (!active || squawk != t.Squawk):
+		m.emergencyActive[t.Hex] = t.Squawk
+		m.logEmergency(emergencyStart, t, t.Squawk)
+	case !t.IsEmergency() && active:
+		delete(m.emergencyActive, t.Hex)
+		m.logEmergency(emergencyEnd, t, squawk)
+	}
+}
+
+// endEmergency closes the entry of a target that was lost while still
+// squawking an emergency
+func (m *Model) endEmergency(hex string, t *radar.Target) {
+	squawk, active := m.emergencyActive[hex]
+	if !active {
+		return
+	}
+	delete(m.emergencyActive, hex)
+	if t == nil {
+		t = &radar.Target{Hex: hex, SquawkCodes: m.squawkCodes}
+	}
+	m.logEmergency(emergencyEnd, t, squawk)
+}
+
+// logEmergency adds an entry to the session history and the log
+func (m *Model) logEmergency(event string, t *radar.Target, squawk string) {
+	entry := emergencyEntry{
+		Time:     m.now().UTC(),
+		Event:    event,
+		Hex:      t.Hex,
+		Callsign: t.Callsign,
+		Squawk:   squawk,
+	}
+	if t.HasLat && t.HasLon {
+		lat, lon := t.Lat, t.Lon
+		entry.Lat, entry.Lon = &lat, &lon
+	}
+	if t.HasAlt {
+		alt := t.Altitude
+		entry.Altitude = &alt
+	}
+	if t.Distance > 0 {
+		dist := t.Distance
+		entry.Distance = &dist
+	}
+
+	m.emergencies = append(m.emergencies, entry)
+	if len(m.emergencies) > maxEmergencies {
+		m.emergencies = m.emergencies[len(m.emergencies)-maxEmergencies:]
+	}
+
+	if m.emergencyLog == nil {
+		return
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if !m.emergencyLog.Append(line) {
+		m.debugf("emergency log: dropped entry for %s", t.Hex)
+	}
+}
+
+// CloseEmergencyLog writes out the entries still queued. It is called on
+// quit and is safe to call again.
+func (m *Model) CloseEmergencyLog() {
+	if m.emergencyLog == nil {
+		return
+	}
+	if err := m.emergencyLog.Close(); err != nil {
+		m.debugf("emergency log: %v", err)
+	}
+	m.emergencyLog = nil
+}
+
+// GetEmergencies returns the session's emergency history, oldest first
+func (m *Model) GetEmergencies() []emergencyEntry {
+	return m.emergencies
+}
+
+// renderEmergencyHistory lists the latest emergency history entries for the
+// alert rules view, with their UTC times
+func (m *Model) renderEmergencyHistory(sb *strings.Builder) {
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error)
+	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
+
+	if len(m.emergencies) == 0 {
+		sb.WriteString("  " + textDim.Render(m.tr("emergency.none")))
+		sb.WriteString("\n")
+		return
+	}
+	start := max(len(m.emergencies)-5, 0)
+	for _, e := range m.emergencies[start:] {
+		name := e.Callsign
+		if name == "" {
+			name = e.Hex
+		}
+		event, style := m.tr("emergency.start"), errorStyle
+		if e.Event == emergencyEnd {
+			event, style = m.tr("emergency.end"), successStyle
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s %s %s\n",
+			textDim.Render(e.Time.Format("15:04:05Z")),
+			style.Render(event),
+			style.Render(e.Squawk),
+			truncate(name, 20),
+		))
+	}
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func squawking(hex, squawk string) *codec.Aircraft {
+	return &codec.Aircraft{
+		Hex:     hex,
+		Flight:  "MAYDAY1 ",
+		Squawk:  squawk,
+		Lat:     floatPtr(52.5),
+		Lon:     floatPtr(4.9041),
+		AltBaro: intPtr(12000),
+	}
+}
+
+func TestEmergencies_OneEntryPerTransition(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	m.updateTarget(squawking("EMG001", "1200"), true)
+	for i := 0; i < 3; i++ {
+		m.updateTarget(squawking("EMG001", "7700"), false)
+	}
+	m.updateTarget(squawking("EMG001", "7600"), false) // a different emergency
+	m.updateTarget(squawking("EMG001", "1200"), false)
+	m.updateTarget(squawking("EMG001", "1200"), false)
+
+	var got []string
+	for _, e := range m.GetEmergencies() {
+		got = append(got, e.Event+" "+e.Squawk)
+	}
+	want := "start 7700, start 7600, end 7600"
+	if strings.Join(got, ", ") != want {
+		t.Fatalf("history = %v, want %s", got, want)
+	}
+
+	first := m.GetEmergencies()[0]
+	if first.Callsign != "MAYDAY1" || first.Altitude == nil || *first.Altitude != 12000 ||
+		first.Lat == nil || first.Distance == nil || *first.Distance <= 0 {
+		t.Errorf("entry should carry the target's details: %+v", first)
+	}
+}
+
+func TestEmergencies_RecordedWithAlertsDisabled(t *testing.T) {
+	m := NewModel(newTestConfig())
+	if m.alertState != nil {
+		m.alertState.AlertsEnabled = false
+	}
+	m.updateTarget(squawking("EMG002", "7500"), true)
+	if len(m.GetEmergencies()) != 1 {
+		t.Fatal("emergencies should be recorded whatever the alert settings")
+	}
+}
+
+func TestEmergencies_EndWhenLost(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.updateTarget(squawking("EMG003", "7700"), true)
+	m.removeAircraft("EMG003")
+
+	history := m.GetEmergencies()
+	if len(history) != 2 || history[1].Event != emergencyEnd || history[1].Squawk != "7700" {
+		t.Fatalf("losing a squawking target should end its entry: %+v", history)
+	}
+	if len(m.emergencyActive) != 0 {
+		t.Error("the lost target shouldn't be left as active")
+	}
+}
+
+func TestEmergencies_WrittenToLog(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	path := filepath.Join(t.TempDir(), "emergencies.jsonl")
+	m := NewModel(newTestConfig())
+	m.SetEmergencyLog(path)
+
+	m.updateTarget(squawking("EMG004", "7700"), true)
+	m.updateTarget(squawking("EMG004", "2000"), false)
+	m.quit()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("the log should be written by quit: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got %q", data)
+	}
+	var entry emergencyEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("line isn't JSON: %v", err)
+	}
+	if entry.Event != emergencyStart || entry.Hex != "EMG004" || entry.Time.Location().String() != "UTC" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+}
+
+func TestEmergencies_AlertRulesViewToggle(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.width, m.height = 160, 60
+	m.updateTarget(squawking("EMG005", "7700"), true)
+	m.openAlertRulesView()
+
+	if view := ansi.Strip(m.renderAlertRulesPanel()); !strings.Contains(view, "RECENT ALERTS") {
+		t.Fatal("the view should open on recent alerts")
+	}
+	pressKey(m, "E")
+	view := ansi.Strip(m.renderAlertRulesPanel())
+	if !strings.Contains(view, "EMERGENCY HISTORY") || !strings.Contains(view, "SQK 7700 MAYDAY1") {
+		t.Errorf("E should show the emergency history:\n%s", view)
+	}
+}
@@ -0,0 +1,70 @@
+// Package app provides normalized feed events for headless SkySpy consumers
+package app
+
+import (
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// EventType identifies a normalized feed event
+type EventType string
+
+const (
+	EventNew    EventType = "new"
+	EventUpdate EventType = "update"
+	EventRemove EventType = "remove"
+	EventACARS  EventType = "acars"
+	EventAlert  EventType = "alert"
+)
+
+// EventTypes lists every event type in emission order
+var EventTypes = []EventType{EventNew, EventUpdate, EventRemove, EventACARS, EventAlert}
+
+// Event is a feed change after the radar has applied it. Target is set for
+// aircraft events (the last known state for removals) and for ACARS messages
+// whose sender is being tracked. Alert events carry the triggered alert and
+// the aircraft or ACARS message that raised it.
+type Event struct {
+	Type   EventType
+	Target *radar.Target
+	ACARS  *ACARSMessage
+	Alert  *alerts.TriggeredAlert
+}
+
+// SetEventHandler registers fn to receive every normalized feed event
+func (m *Model) SetEventHandler(fn func(Event)) {
+	m.onEvent = fn
+}
+
+// IngestACARSMessage applies an ACARS feed message exactly as the radar
+// would, for headless callers such as the stream command
+func (m *Model) IngestACARSMessage(msg codec.Message) {
+	m.handleACARSMsg(msg)
+}
+
+func (m *Model) emit(ev Event) {
+	if m.onEvent != nil {
+		if ev.Target != nil {
+			ev.Target = m.displayTarget(ev.Target)
+		}
+		m.onEvent(ev)
+	}
+}
+
+// acarsSender returns the tracked aircraft whose callsign matches the
+// message's flight or callsign
+func (m *Model) acarsSender(msg *ACARSMessage) *radar.Target {
+	for _, t := range m.aircraft {
+		if t.Callsign == "" {
+			continue
+		}
+		if strings.EqualFold(t.Callsign, strings.TrimSpace(msg.Flight)) ||
+			strings.EqualFold(t.Callsign, strings.TrimSpace(msg.Callsign)) {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Package app provides export failure reporting for the SkySpy radar
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/export"
+)
+
+const (
+	// exportRetryWindow is how long W offers to repeat a failed export
+	// into the system temp directory
+	exportRetryWindow = 8 * time.Second
+	// exportPathChars caps the path shown with a failed export; the end of
+	// the path, naming the file, is kept
+	exportPathChars = 32
+)
+
+// exportRetry is a failed export that W repeats into the temp directory
+type exportRetry struct {
+	retry func(dir string)
+	until time.Time
+}
+
+// exportFailed reports a failed export with the path and the OS error, in
+// words that say whether the disk is full or can't be written, and offers
+// to repeat it into the temp directory. No offer is made for a failure in
+// the temp directory itself.
+func (m *Model) exportFailed(err error, dir string, retry func(dir string)) {
+	msg := m.trf("notify.export_failed", err.Error())
+	var we *export.WriteError
+	if errors.As(err, &we) {
+		path, reason := shortenPath(we.Path, exportPathChars), we.Err.Error()
+		switch {
+		case errors.Is(err, export.ErrNoSpace):
+			msg = m.trf("notify.export_no_space", path, reason)
+		case errors.Is(err, export.ErrNotWritable):
+			msg = m.trf("notify.export_not_writable", path, reason)
+		default:
+			msg = m.trf("notify.export_failed", path+": "+reason)
+		}
+	}
+
+	m.pendingExport = nil
+	if sameDir(dir, os.TempDir()) {
+		m.notify(msg)
+		return
+	}
+	m.pendingExport = &exportRetry{retry: retry, until: m.now().Add(exportRetryWindow)}
+	m.notifyFor(m.trf("notify.export_retry", msg), exportRetryWindow.Seconds())
+}
+
+// exportRetryOpen reports whether W would repeat a failed export
+func (m *Model) exportRetryOpen() bool {
+	return m.pendingExport != nil && m.now().Before(m.pendingExport.until)
+}
+
+// continueExportRetry repeats a failed export into the temp directory when
+// W is pressed while the offer stands. Other keys keep their usual
+// meaning. Reports whether key was used.
+func (m *Model) continueExportRetry(key string) bool {
+	if !m.exportRetryOpen() {
+		m.pendingExport = nil
+		return false
+	}
+	if key != "w" && key != "W" {
+		return false
+	}
+	retry := m.pendingExport.retry
+	m.pendingExport = nil
+	retry(os.TempDir())
+	return true
+}
+
+// exportedName is how a finished export is named in its notification: the
+// file name alone in the export directory, the full path anywhere else
+func (m *Model) exportedName(filename string) string {
+	if sameDir(filepath.Dir(filename), m.GetExportDirectory()) {
+		return filepath.Base(filename)
+	}
+	return filename
+}
+
+// sameDir reports whether two directory paths name the same place, taking
+// an empty path as the working directory
+func sameDir(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// shortenPath cuts a path to n characters by dropping the start of it
+func shortenPath(path string, n int) string {
+	runes := []rune(path)
+	if len(runes) <= n {
+		return path
+	}
+	return "..." + string(runes[len(runes)-(n-3):])
+}
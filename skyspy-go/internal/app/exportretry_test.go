@@ -0,0 +1,125 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// newExportModel returns a model exporting to dir, with one aircraft and a
+// temp directory of its own
+func newExportModel(t *testing.T, dir string) (*Model, *time.Time, string) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	cfg := newTestConfig()
+	cfg.Export.Directory = dir
+	m := NewModel(cfg)
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	m.aircraft["EXP001"] = &radar.Target{Hex: "EXP001", Callsign: "EXPORT1"}
+	return m, &clock, tmp
+}
+
+// blockedDir returns an export directory that can't be made, because a
+// file is in the way
+func blockedDir(t *testing.T) string {
+	file := filepath.Join(t.TempDir(), "card")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(file, "exports")
+}
+
+func TestExportRetry_ToTempDir(t *testing.T) {
+	m, _, tmp := newExportModel(t, blockedDir(t))
+
+	m.handleRadarKey("e")
+	if !strings.Contains(m.notification, "Export failed") || !strings.Contains(m.notification, "[W]") {
+		t.Fatalf("the failure should offer the temp directory: %q", m.notification)
+	}
+	if !strings.Contains(m.notification, "not a directory") {
+		t.Errorf("the notification should give the OS error: %q", m.notification)
+	}
+
+	m.handleRadarKey("w")
+	files, _ := filepath.Glob(filepath.Join(tmp, "skyspy_aircraft_*.csv"))
+	if len(files) != 1 {
+		t.Fatalf("W should export into the temp directory, found %v", files)
+	}
+	if !strings.Contains(m.notification, files[0]) {
+		t.Errorf("the notification should give the full path: %q", m.notification)
+	}
+	if m.exportRetryOpen() || m.jumpActive() {
+		t.Error("the offer should be used up, and W shouldn't start a jump")
+	}
+}
+
+func TestExportRetry_Expires(t *testing.T) {
+	m, clock, tmp := newExportModel(t, blockedDir(t))
+	m.handleRadarKey("ctrl+e")
+
+	*clock = clock.Add(exportRetryWindow)
+	if m.keyMutates("W") {
+		t.Error("W should be an ordinary key once the offer lapses")
+	}
+	m.handleRadarKey("W")
+	if files, _ := filepath.Glob(filepath.Join(tmp, "*.json")); len(files) != 0 {
+		t.Errorf("an expired offer shouldn't export: %v", files)
+	}
+}
+
+func TestExportRetry_NotOfferedForTempDir(t *testing.T) {
+	m, _, _ := newExportModel(t, "")
+	m.exportFailed(errors.New("boom"), os.TempDir(), m.exportAircraftCSVTo)
+	if m.exportRetryOpen() || strings.Contains(m.notification, "[W]") {
+		t.Errorf("a failure in the temp directory shouldn't offer it again: %q", m.notification)
+	}
+}
+
+func TestExportRetry_Messages(t *testing.T) {
+	m, _, _ := newExportModel(t, "")
+	long := "/media/sdcard/a/very/long/path/to/exports/skyspy_aircraft_20260301_120000.csv"
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&export.WriteError{Path: long, Kind: export.ErrNoSpace, Err: syscall.ENOSPC}, "Export disk full"},
+		{&export.WriteError{Path: long, Kind: export.ErrNotWritable, Err: syscall.EROFS}, "Export dir not writable"},
+		{&export.WriteError{Path: long, Err: syscall.EIO}, "Export failed"},
+	}
+	for _, tt := range tests {
+		m.exportFailed(tt.err, "/media/sdcard", m.exportAircraftCSVTo)
+		we := tt.err.(*export.WriteError)
+		if !strings.HasPrefix(m.notification, tt.want) || !strings.Contains(m.notification, we.Err.Error()) {
+			t.Errorf("%v: notification = %q, want %q with the OS error", tt.err, m.notification, tt.want)
+		}
+		if strings.Contains(m.notification, "/media") || !strings.Contains(m.notification, "...") ||
+			!strings.Contains(m.notification, "120000.csv") {
+			t.Errorf("long paths should keep their end: %q", m.notification)
+		}
+	}
+}
+
+func TestExportRetry_ReadOnlyDir(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+	dir := t.TempDir()
+	if err := os.Chmod(dir, 0o555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(dir, 0o755) })
+
+	m, _, _ := newExportModel(t, dir)
+	m.exportAircraftJSON()
+	if !strings.HasPrefix(m.notification, "Export dir not writable") {
+		t.Errorf("notification = %q", m.notification)
+	}
+}
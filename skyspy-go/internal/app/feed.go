@@ -0,0 +1,79 @@
+// Package app provides the live feed hookup for the SkySpy radar
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// Feed is a source of raw feed messages, such as the WebSocket client or a
+// replay of captured traffic. Payloads are decoded with the codec package.
+type Feed interface {
+	Start()
+	Stop()
+	Done() <-chan struct{}
+	IsConnected() bool
+	AircraftMessages() <-chan codec.Message
+	ACARSMessages() <-chan codec.Message
+}
+
+// NewModelWithFeed creates a new application model that reads from feed
+func NewModelWithFeed(cfg *config.Config, feed Feed) *Model {
+	return NewModelWithCapabilities(cfg, feed, FullCapabilities())
+}
+
+// aircraftMsg contains aircraft data
+type aircraftMsg codec.Message
+
+// acarsMsg contains ACARS data
+type acarsMsg codec.Message
+
+func aircraftMsgCmd(feed Feed) tea.Cmd {
+	if feed == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		select {
+		case msg := <-feed.AircraftMessages():
+			return aircraftMsg(msg)
+		case <-feed.Done():
+			// Feed stopped; exit so the goroutine doesn't leak
+			return nil
+		}
+	}
+}
+
+func acarsMsgCmd(feed Feed) tea.Cmd {
+	if feed == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		select {
+		case msg := <-feed.ACARSMessages():
+			return acarsMsg(msg)
+		case <-feed.Done():
+			// Feed stopped; exit so the goroutine doesn't leak
+			return nil
+		}
+	}
+}
+
+// stopFeed stops the feed, if any
+func (m *Model) stopFeed() {
+	if m.feed != nil {
+		m.feed.Stop()
+	}
+}
+
+// quit ends the session: the feed and any overlay still loading are
+// stopped and the settings saved
+func (m *Model) quit() tea.Cmd {
+	m.stopFeed()
+	m.stopOverlayLoads()
+	m.saveConfig()
+	m.CloseEmergencyLog()
+	m.CloseSessionLog()
+	m.closeDebugLog()
+	return tea.Quit
+}
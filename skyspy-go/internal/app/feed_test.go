@@ -0,0 +1,488 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/ws"
+)
+
+// fakeFeed is an in-memory Feed for driving the model without a server
+type fakeFeed struct {
+	aircraft  chan codec.Message
+	acars     chan codec.Message
+	done      chan struct{}
+	stopOnce  sync.Once
+	started   bool
+	connected bool
+}
+
+func newFakeFeed() *fakeFeed {
+	return &fakeFeed{
+		aircraft: make(chan codec.Message, 4),
+		acars:    make(chan codec.Message, 4),
+		done:     make(chan struct{}),
+	}
+}
+
+func (f *fakeFeed) Start()                                 { f.started = true }
+func (f *fakeFeed) Stop()                                  { f.stopOnce.Do(func() { close(f.done) }) }
+func (f *fakeFeed) Done() <-chan struct{}                  { return f.done }
+func (f *fakeFeed) IsConnected() bool                      { return f.connected }
+func (f *fakeFeed) AircraftMessages() <-chan codec.Message { return f.aircraft }
+func (f *fakeFeed) ACARSMessages() <-chan codec.Message    { return f.acars }
+
+func TestFeed_InitStartsFeed(t *testing.T) {
+	feed := newFakeFeed()
+	m := NewModelWithFeed(newTestConfig(), feed)
+
+	if cmd := m.Init(); cmd == nil {
+		t.Fatal("Init should return a command")
+	}
+	if !feed.started {
+		t.Error("Init should start the feed")
+	}
+}
+
+func TestFeed_MessagesReachModel(t *testing.T) {
+	feed := newFakeFeed()
+	m := NewModelWithFeed(newTestConfig(), feed)
+
+	feed.aircraft <- codec.Message{
+		Type: string(codec.AircraftNew),
+		Data: json.RawMessage(`{"hex":"abc123","flight":"TEST1"}`),
+	}
+	msg := aircraftMsgCmd(feed)()
+	if _, ok := msg.(aircraftMsg); !ok {
+		t.Fatalf("expected aircraftMsg, got %T", msg)
+	}
+	if _, cmd := m.Update(msg); cmd == nil {
+		t.Error("Update should keep reading from the feed")
+	}
+	if _, ok := m.aircraft["ABC123"]; !ok {
+		t.Error("aircraft from the feed should be tracked")
+	}
+}
+
+func TestFeed_StoppedFeedEndsRead(t *testing.T) {
+	feed := newFakeFeed()
+	feed.Stop()
+
+	if msg := aircraftMsgCmd(feed)(); msg != nil {
+		t.Errorf("expected nil after stop, got %T", msg)
+	}
+	if msg := acarsMsgCmd(feed)(); msg != nil {
+		t.Errorf("expected nil after stop, got %T", msg)
+	}
+}
+
+func TestFeed_QuitStopsFeed(t *testing.T) {
+	feed := newFakeFeed()
+	m := NewModelWithFeed(newTestConfig(), feed)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+	select {
+	case <-feed.Done():
+	default:
+		t.Error("quitting should stop the feed")
+	}
+}
+
+func TestFeed_NilFeed(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	if m.IsConnected() {
+		t.Error("model without a feed should not report connected")
+	}
+	if aircraftMsgCmd(nil) != nil || acarsMsgCmd(nil) != nil {
+		t.Error("no read commands should be issued without a feed")
+	}
+	if cmd := m.Init(); cmd == nil {
+		t.Error("Init should still tick without a feed")
+	}
+	m.stopFeed()
+
+	feed := newFakeFeed()
+	feed.connected = true
+	m = NewModelWithFeed(newTestConfig(), feed)
+	if !m.IsConnected() {
+		t.Error("model should report the feed's connection state")
+	}
+}
+
+func FuzzHandleAircraftMsg(f *testing.F) {
+	f.Add(string(codec.AircraftSnapshot), `{"aircraft":{"a1b2c3":{"hex":"a1b2c3","flight":"UAL1234 ","lat":37.6188,"lon":-122.3756,"alt_baro":35000,"gs":452.3,"track":284.1,"nav_altitude_mcp":36000,"nav_modes":["autopilot","vnav"]},"c0ffee":{"hex":"c0ffee","alt_baro":"ground"}}}`)
+	f.Add(string(codec.AircraftUpdate), `{"hex":"a1b2c3","lat":37.6201,"lon":-122.3812,"alt_baro":34975,"vr":-128,"nav_altitude_fms":34000}`)
+	f.Add(string(codec.AircraftNew), `{"hex":"ae1460","squawk":"7700","military":true}`)
+	f.Add(string(codec.AircraftRemove), `{"hex":"ae1460"}`)
+	f.Add(string(codec.AircraftUpdate), `{"hex":"x","lat":null,"lon":1e308,"track":-1e308}`)
+
+	f.Fuzz(func(t *testing.T, msgType, data string) {
+		cfg := newTestConfig()
+		cfg.Connection.ReceiverLat = 37.6
+		cfg.Connection.ReceiverLon = -122.4
+		m := NewModel(cfg)
+		msg := codec.Message{Type: msgType, Data: json.RawMessage(data)}
+		m.IngestAircraftMessage(msg)
+		m.IngestAircraftMessage(msg)
+		m.renderRadar()
+		if len(m.sortedTargets) > 0 {
+			m.selectedHex = m.sortedTargets[0]
+		}
+		m.renderTargetPanel()
+	})
+}
+
+// reportingFeed is a fakeFeed that explains its connection failures
+type reportingFeed struct {
+	*fakeFeed
+	err     error
+	retries int
+}
+
+func (f *reportingFeed) URL() string      { return "ws://badhost:8000/ws/aircraft/?topics=aircraft" }
+func (f *reportingFeed) LastError() error { return f.err }
+func (f *reportingFeed) Retry()           { f.retries++ }
+
+func newConnectModel(t *testing.T, feed Feed) (*Model, *time.Time) {
+	t.Helper()
+	m := NewModelWithFeed(newTestConfig(), feed)
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	m.Init()
+	return m, &clock
+}
+
+func TestFeed_ConnectTimeout(t *testing.T) {
+	feed := &reportingFeed{fakeFeed: newFakeFeed()}
+	feed.err = &ws.ConnectError{Kind: ws.KindDNS, URL: feed.URL(), Err: errors.New("lookup badhost: no such host")}
+	m, clock := newConnectModel(t, feed)
+
+	*clock = clock.Add(9 * time.Second)
+	m.handleTick()
+	if m.ConnectFailure() != nil {
+		t.Fatal("error screen shown before the timeout")
+	}
+
+	*clock = clock.Add(time.Second)
+	m.handleTick()
+	if !errors.Is(m.ConnectFailure(), feed.err) {
+		t.Fatalf("expected the feed's error, got %v", m.ConnectFailure())
+	}
+
+	view := m.View()
+	for _, want := range []string{"CANNOT CONNECT", feed.URL(), "host not found", "no such host", "--host", "[R]", "[C]", "[Q]"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("error screen missing %q", want)
+		}
+	}
+}
+
+func TestFeed_ConnectTimeout_NoFeedError(t *testing.T) {
+	m, clock := newConnectModel(t, &reportingFeed{fakeFeed: newFakeFeed()})
+
+	*clock = clock.Add(10 * time.Second)
+	m.handleTick()
+	var ce *ws.ConnectError
+	if !errors.As(m.ConnectFailure(), &ce) || ce.Kind != ws.KindTimeout {
+		t.Fatalf("expected a timeout, got %v", m.ConnectFailure())
+	}
+}
+
+func TestFeed_ConnectTimeout_Disabled(t *testing.T) {
+	feed := newFakeFeed()
+	cfg := newTestConfig()
+	cfg.Connection.ConnectTimeout = 0
+	m := NewModelWithFeed(cfg, feed)
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	m.Init()
+
+	clock = clock.Add(time.Hour)
+	m.handleTick()
+	if m.ConnectFailure() != nil {
+		t.Errorf("timeout 0 should wait forever, got %v", m.ConnectFailure())
+	}
+}
+
+func TestFeed_ConnectTimeout_OnlyBeforeFirstConnect(t *testing.T) {
+	feed := newFakeFeed()
+	m, clock := newConnectModel(t, feed)
+
+	feed.connected = true
+	m.handleTick()
+	feed.connected = false
+
+	*clock = clock.Add(time.Minute)
+	m.handleTick()
+	if m.ConnectFailure() != nil {
+		t.Error("drops after the first connection should not show the error screen")
+	}
+}
+
+func TestFeed_ConnectFailureKeys(t *testing.T) {
+	feed := &reportingFeed{fakeFeed: newFakeFeed()}
+	m, clock := newConnectModel(t, feed)
+	m.SetStartupError(&ws.ConnectError{Kind: ws.KindAuth, URL: feed.URL()}, "Run 'skyspy login' to sign in with Keycloak")
+
+	if view := m.View(); !strings.Contains(view, "Keycloak") || strings.Contains(view, "--api-key") {
+		t.Error("startup hints should replace the generic advice")
+	}
+
+	// Other keys are ignored
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if m.config.Display.ShowTrails != newTestConfig().Display.ShowTrails {
+		t.Error("radar keys should be ignored on the error screen")
+	}
+
+	// Retry hides the screen and restarts the timeout
+	*clock = clock.Add(time.Minute)
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if m.ConnectFailure() != nil || feed.retries != 1 {
+		t.Fatalf("retry should clear the error and retry the feed (retries=%d)", feed.retries)
+	}
+	*clock = clock.Add(5 * time.Second)
+	m.handleTick()
+	if m.ConnectFailure() != nil {
+		t.Error("timeout should restart from the retry")
+	}
+
+	// The feed connecting clears a startup error
+	m.SetStartupError(errors.New("auth required"))
+	feed.connected = true
+	m.handleTick()
+	if m.ConnectFailure() != nil {
+		t.Error("connecting should clear the error screen")
+	}
+}
+
+func TestFeed_ConnectFailureConfigure(t *testing.T) {
+	feed := &reportingFeed{fakeFeed: newFakeFeed()}
+	m, _ := newConnectModel(t, feed)
+	m.SetStartupError(errors.New("boom"))
+
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if cmd == nil || !m.ConfigureRequested() {
+		t.Error("c should quit and request the configuration wizard")
+	}
+	select {
+	case <-feed.Done():
+	default:
+		t.Error("feed should be stopped")
+	}
+}
+
+// quietFeed is a fakeFeed that reports when its last message arrived
+type quietFeed struct {
+	*fakeFeed
+	last time.Time
+}
+
+func (f *quietFeed) LastMessage() time.Time { return f.last }
+
+func TestFeed_MessageAge(t *testing.T) {
+	feed := &quietFeed{fakeFeed: newFakeFeed()}
+	m, clock := newConnectModel(t, feed)
+
+	if _, ok := m.FeedMessageAge(); ok {
+		t.Error("a feed that never connected has no message age")
+	}
+
+	feed.connected = true
+	feed.last = *clock
+	*clock = clock.Add(4 * time.Second)
+	if age, ok := m.FeedMessageAge(); !ok || age != 4*time.Second {
+		t.Errorf("expected 4s, got %v %v", age, ok)
+	}
+	if strings.Contains(m.renderStatusBar(), "IDLE") {
+		t.Error("a feed with recent messages should not show as idle")
+	}
+
+	*clock = clock.Add(40 * time.Second)
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "IDLE 44s") {
+		t.Errorf("expected the idle age in the status bar, got:\n%s", bar)
+	}
+
+	if _, ok := NewModelWithFeed(newTestConfig(), newFakeFeed()).FeedMessageAge(); ok {
+		t.Error("feeds that can't report message times have no age")
+	}
+}
+
+func TestFeed_NotifiesWhenConnectionDrops(t *testing.T) {
+	feed := newFakeFeed()
+	m, _ := newConnectModel(t, feed)
+
+	feed.connected = true
+	m.handleTick()
+	if m.notification != "" {
+		t.Errorf("connecting should not notify, got %q", m.notification)
+	}
+
+	feed.connected = false
+	m.handleTick()
+	if m.notification != "Connection lost, reconnecting..." {
+		t.Errorf("expected a connection lost notice, got %q", m.notification)
+	}
+
+	m.notification = ""
+	m.handleTick()
+	if m.notification != "" {
+		t.Error("the drop should only be announced once")
+	}
+}
+
+// meteredFeed is a Feed that counts its traffic
+type meteredFeed struct {
+	*fakeFeed
+	stats ws.TrafficStats
+}
+
+func (f *meteredFeed) Traffic() ws.TrafficStats { return f.stats }
+
+func TestFeed_TrafficInStatsPanel(t *testing.T) {
+	feed := &meteredFeed{fakeFeed: newFakeFeed()}
+	feed.stats = ws.TrafficStats{RxBytes: 4_230_000, RxPerMinute: 18_200}
+	m := NewModelWithFeed(newTestConfig(), feed)
+
+	if panel := ansi.Strip(m.renderStatsPanel()); !strings.Contains(panel, "RX   4.2 MB, 18 kB/min") {
+		t.Errorf("expected the traffic row, got:\n%s", panel)
+	}
+	if panel := NewModelWithFeed(newTestConfig(), newFakeFeed()).renderStatsPanel(); strings.Contains(panel, "RX ") {
+		t.Error("feeds that don't count traffic should have no traffic row")
+	}
+}
+
+func TestFeed_DataBudget(t *testing.T) {
+	feed := &meteredFeed{fakeFeed: newFakeFeed()}
+	m, clock := newConnectModel(t, feed)
+	m.config.Connection.DataBudgetMB = 10
+
+	feed.stats.RxBytes = 7_900_000
+	m.checkDataBudget()
+	if m.notification != "" {
+		t.Errorf("no warning expected below 80%%, got %q", m.notification)
+	}
+
+	feed.stats.RxBytes, feed.stats.TxBytes = 8_000_000, 100_000
+	m.checkDataBudget()
+	if m.notification != "Data: 81% of daily budget (8.1 MB of 10.0 MB)" {
+		t.Errorf("expected the 80%% warning, got %q", m.notification)
+	}
+	m.notification = ""
+	m.checkDataBudget()
+	if m.notification != "" {
+		t.Error("the 80% warning should only be given once")
+	}
+
+	feed.stats.RxBytes = 10_000_000
+	m.checkDataBudget()
+	if m.notification != "Data budget reached: 10.1 MB today" {
+		t.Errorf("expected the budget warning, got %q", m.notification)
+	}
+	m.notification = ""
+	m.checkDataBudget()
+	if m.notification != "" {
+		t.Error("the budget warning should only be given once")
+	}
+
+	// The next day counts from the session total at midnight
+	*clock = clock.Add(24 * time.Hour)
+	m.checkDataBudget()
+	if m.notification != "" || m.budgetWarned != 0 {
+		t.Errorf("a new day should start afresh, got %q", m.notification)
+	}
+	feed.stats.RxBytes += 8_000_000
+	m.checkDataBudget()
+	if !strings.HasPrefix(m.notification, "Data: 80%") {
+		t.Errorf("expected the 80%% warning on the new day, got %q", m.notification)
+	}
+}
+
+func TestFeed_DataBudgetDisabled(t *testing.T) {
+	feed := &meteredFeed{fakeFeed: newFakeFeed()}
+	feed.stats.RxBytes = 1 << 40
+	m, _ := newConnectModel(t, feed)
+	m.checkDataBudget()
+	if m.notification != "" {
+		t.Errorf("no budget means no warnings, got %q", m.notification)
+	}
+}
+
+// statesFeed is a fakeFeed that reports its connection state changes
+type statesFeed struct {
+	*fakeFeed
+	states chan ws.ConnState
+}
+
+func (f *statesFeed) States() <-chan ws.ConnState { return f.states }
+
+func TestFeed_ReconnectingStatus(t *testing.T) {
+	feed := &statesFeed{fakeFeed: newFakeFeed(), states: make(chan ws.ConnState, 1)}
+	feed.connected = true
+	m, _ := newConnectModel(t, feed)
+	m.width, m.height = 160, 60
+	m.handleTick()
+
+	// The feed drops and waits to make its third attempt
+	feed.connected = false
+	feed.states <- ws.ConnState{State: ws.StateDisconnected, Attempt: 3, Delay: 4 * time.Second}
+	msg := feedStateCmd(feed)()
+	if _, cmd := m.Update(msg); cmd == nil {
+		t.Error("the model should keep listening for states")
+	}
+	if bar := ansi.Strip(m.renderStatusBar()); !strings.Contains(bar, "Reconnecting (attempt 3)...") {
+		t.Errorf("status bar = %q", bar)
+	}
+	if panel := ansi.Strip(m.renderStatsPanel()); !strings.Contains(panel, "RECONNECTING (3)") || strings.Contains(panel, "OFFLINE") {
+		t.Errorf("the stats panel should show the attempt:\n%s", panel)
+	}
+
+	// Back up, the attempt is forgotten
+	feed.connected = true
+	m.Update(feedStateMsg{State: ws.StateConnected})
+	if _, ok := m.reconnecting(); ok || strings.Contains(ansi.Strip(m.renderStatusBar()), "Reconnecting") {
+		t.Error("a connected feed isn't reconnecting")
+	}
+
+	// With automatic reconnection off a drop is just offline
+	feed.connected = false
+	m.Update(feedStateMsg{State: ws.StateDisconnected})
+	if bar := ansi.Strip(m.renderStatusBar()); strings.Contains(bar, "Reconnecting") || !strings.Contains(bar, " OFF ") {
+		t.Errorf("status bar = %q", bar)
+	}
+}
+
+func TestFeed_BriefDisconnectKeepsAircraft(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.updateTarget(flying("KEEP01", 52.40), true)
+
+	// The feed is down for a minute, well inside the aircraft timeout
+	for i := 0; i < 60; i++ {
+		*clock = clock.Add(time.Second)
+		m.handleTick()
+	}
+	if m.aircraft["KEEP01"] == nil {
+		t.Fatal("a brief disconnect shouldn't clear the aircraft")
+	}
+
+	// The answer to the snapshot request brings the picture up to date
+	m.IngestAircraftMessage(codec.Message{Type: string(codec.FeedReconnected)})
+	m.IngestAircraftMessage(codec.Message{
+		Type: string(codec.Response),
+		Data: json.RawMessage(`{"request_id":"skyspy-resync","request_type":"aircraft-snapshot","data":{"aircraft":[{"hex":"NEW001"}],"count":1}}`),
+	})
+	if m.aircraft["KEEP01"] != nil || m.aircraft["NEW001"] == nil {
+		t.Errorf("the snapshot response should replace the picture, got %v", trackedHexes(m))
+	}
+	if m.notification != "Resynced after reconnect (removed 1 stale)" {
+		t.Errorf("notification = %q", m.notification)
+	}
+}
@@ -0,0 +1,125 @@
+// Package app provides feed health reporting for the SkySpy radar
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/ws"
+)
+
+// feedQuietAfter is how long a connected feed may go without a message
+// before the status bar shows its age
+const feedQuietAfter = 10 * time.Second
+
+// messageReporter is implemented by feeds that know when their last
+// message arrived, such as the WebSocket client
+type messageReporter interface {
+	LastMessage() time.Time
+}
+
+// stateReporter is implemented by feeds that report their connection
+// state as it changes, such as the WebSocket client
+type stateReporter interface {
+	States() <-chan ws.ConnState
+}
+
+// feedStateMsg is a change of the feed's connection state
+type feedStateMsg ws.ConnState
+
+func feedStateCmd(feed Feed) tea.Cmd {
+	r, ok := feed.(stateReporter)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		select {
+		case state := <-r.States():
+			return feedStateMsg(state)
+		case <-feed.Done():
+			// Feed stopped; exit so the goroutine doesn't leak
+			return nil
+		}
+	}
+}
+
+// handleFeedState follows the feed reconnecting, for the status bar
+func (m *Model) handleFeedState(msg feedStateMsg) {
+	if msg.State == ws.StateConnected {
+		m.reconnectAttempt = 0
+		return
+	}
+	m.reconnectAttempt = msg.Attempt
+}
+
+// reconnecting returns the reconnect attempt a dropped feed is waiting on,
+// or false if it is connected or won't reconnect on its own
+func (m *Model) reconnecting() (int, bool) {
+	if m.IsConnected() || m.reconnectAttempt == 0 {
+		return 0, false
+	}
+	return m.reconnectAttempt, true
+}
+
+// FeedMessageAge returns how long ago the feed last delivered a message
+// (or connected). ok is false if the feed can't say or hasn't connected.
+func (m *Model) FeedMessageAge() (age time.Duration, ok bool) {
+	r, isReporter := m.feed.(messageReporter)
+	if !isReporter {
+		return 0, false
+	}
+	last := r.LastMessage()
+	if last.IsZero() {
+		return 0, false
+	}
+	return max(m.now().Sub(last), 0), true
+}
+
+// feedQuietAge returns the age to show in the status bar for a connected
+// feed that has gone quiet, or false while messages are flowing
+func (m *Model) feedQuietAge() (time.Duration, bool) {
+	if !m.IsConnected() {
+		return 0, false
+	}
+	age, ok := m.FeedMessageAge()
+	if !ok || age < feedQuietAfter {
+		return 0, false
+	}
+	return age, true
+}
+
+// watchFeedState notifies when an established feed drops, or one of the
+// receivers of a merged feed does. Startup failures are left to the
+// connection error screen.
+func (m *Model) watchFeedState() {
+	if m.feed == nil || !m.feedConnected {
+		return
+	}
+	m.watchSources()
+	up := m.feed.IsConnected()
+	if m.feedDown == !up {
+		return
+	}
+	m.feedDown = !up
+	if m.feedDown {
+		m.notify(m.tr("notify.connection_lost"))
+	}
+}
+
+// watchSources notifies when a receiver of a merged feed that was
+// connected drops; it reconnects on its own while the others carry on
+func (m *Model) watchSources() {
+	sources, ok := m.feedSources()
+	if !ok {
+		return
+	}
+	if m.sourceUp == nil {
+		m.sourceUp = make(map[string]bool, len(sources))
+	}
+	for _, src := range sources {
+		if m.sourceUp[src.Name] && !src.Connected {
+			m.notify(m.trf("notify.source_lost", src.Name))
+		}
+		m.sourceUp[src.Name] = src.Connected
+	}
+}
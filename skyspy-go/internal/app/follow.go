@@ -0,0 +1,95 @@
+// Package app provides follow mode and panning for the SkySpy radar
+package app
+
+import (
+	"github.com/skyspy/skyspy-go/internal/geo"
+)
+
+// panFraction is how far one pan step moves the view, as a share of the
+// range shown
+const panFraction = 0.25
+
+// mainCenter returns the position the main scope is centered on: the
+// followed target or the panned-to position, otherwise the receiver
+func (m *Model) mainCenter() (float64, float64) {
+	if m.followHex != "" || m.panned {
+		return m.panLat, m.panLon
+	}
+	return m.displayReceiver()
+}
+
+// Following returns the callsign (or hex) of the target the view follows,
+// or "" when not following
+func (m *Model) Following() string {
+	t, ok := m.aircraft[m.followHex]
+	if !ok {
+		return ""
+	}
+	return pinLabel(t)
+}
+
+// toggleFollow starts following the selected target, keeping it at the
+// center of the scope as it moves, or stops following and returns the
+// view to the receiver. The target needs a position to follow.
+func (m *Model) toggleFollow() {
+	if m.followHex != "" {
+		m.followHex = ""
+		m.panned = false
+		m.notify(m.tr("notify.follow_off"))
+		return
+	}
+	t, ok := m.aircraft[m.selectedHex]
+	if !ok || m.selectedHex == "" {
+		m.notify(m.tr("notify.no_target"))
+		return
+	}
+	if !t.HasLat || !t.HasLon {
+		m.notify(m.tr("notify.follow_no_position"))
+		return
+	}
+	m.followHex = t.Hex
+	m.trackFollowed()
+	m.notify(m.trf("notify.follow_on", pinLabel(t)))
+}
+
+// trackFollowed moves the view center to the followed target's latest
+// position. A target that loses its position holds the view where it was
+// last seen.
+func (m *Model) trackFollowed() {
+	if t, ok := m.aircraft[m.followHex]; ok && t.HasLat && t.HasLon {
+		m.panLat, m.panLon = t.Lat, t.Lon
+	}
+}
+
+// stopFollowing ends follow mode without moving the view, telling the
+// user with the notification key given
+func (m *Model) stopFollowing(key string) {
+	label := m.followHex
+	if t, ok := m.aircraft[m.followHex]; ok {
+		label = pinLabel(t)
+	}
+	m.followHex = ""
+	m.panned = true
+	m.notify(m.trf(key, label))
+}
+
+// pan moves the view a step toward a screen direction (0 is up), which is
+// turned with the scope in heading-up mode. Panning ends follow mode.
+func (m *Model) pan(screenBearing float64) {
+	if m.followHex != "" {
+		m.stopFollowing("notify.follow_panned")
+	}
+	lat, lon := m.mainCenter()
+	m.panLat, m.panLon = geo.DestinationPoint(lat, lon, screenBearing+m.rotation, m.maxRange*panFraction)
+	m.panned = true
+}
+
+// recenter returns the view to the receiver, ending follow mode
+func (m *Model) recenter() {
+	if !m.panned && m.followHex == "" {
+		return
+	}
+	m.followHex = ""
+	m.panned = false
+	m.notify(m.tr("notify.recentered"))
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// followKLM starts a model following KLM123, reported at lat
+func followKLM(t *testing.T, lat float64) *Model {
+	t.Helper()
+	m, _ := newTrackingModel()
+	m.config.Filters.MaxPositionSpeed = 0 // the reports jump faster than any aircraft
+	ac := flying("484B1C", lat)
+	ac.Flight = "KLM123"
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftNew, *ac))
+	m.selectedHex = "484B1C"
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if m.Following() != "KLM123" {
+		t.Fatalf("F should follow the selected target: %q", m.notification)
+	}
+	return m
+}
+
+// moveKLM reports KLM123 at lat
+func moveKLM(m *Model, lat float64) {
+	ac := flying("484B1C", lat)
+	ac.Flight = "KLM123"
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftUpdate, *ac))
+}
+
+func TestFollow_TracksMovingTarget(t *testing.T) {
+	m := followKLM(t, 52.50)
+
+	for _, lat := range []float64{52.52, 52.54, 52.56} {
+		moveKLM(m, lat)
+		clat, clon := m.mainCenter()
+		if clat != lat || clon != 4.9041 {
+			t.Fatalf("center = %v,%v, want the target at %v,4.9041", clat, clon, lat)
+		}
+		if d := m.aircraftFrom(clat, clon)["484B1C"].Distance; d > 0.01 {
+			t.Errorf("the followed target should be drawn at the center, %.2fnm off", d)
+		}
+	}
+	if d := m.aircraft["484B1C"].Distance; d < 11 {
+		t.Errorf("the target's own distance should stay from the receiver, got %.1fnm", d)
+	}
+
+	m.width, m.height = 160, 60
+	m.renderRadar()
+	if len(m.sortedTargets) == 0 || m.sortedTargets[0] != "484B1C" {
+		t.Errorf("the followed target should be drawn nearest the center: %v", m.sortedTargets)
+	}
+	if bar := ansi.Strip(m.renderStatusBar()); !strings.Contains(bar, "FOLLOWING KLM123") {
+		t.Errorf("the status bar should name the followed target:\n%s", bar)
+	}
+
+	// F again stops following and returns to the receiver
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if lat, _ := m.mainCenter(); m.Following() != "" || lat != m.config.Connection.ReceiverLat {
+		t.Errorf("F should stop following and recenter, center at %v", lat)
+	}
+}
+
+func TestFollow_RemovedTargetDisengages(t *testing.T) {
+	m := followKLM(t, 52.50)
+	moveKLM(m, 52.52)
+
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftRemove, codec.Aircraft{Hex: "484B1C"}))
+	if m.Following() != "" || m.followHex != "" {
+		t.Fatal("follow should end when the target is removed")
+	}
+	if !strings.Contains(m.notification, "KLM123 lost") {
+		t.Errorf("notification = %q", m.notification)
+	}
+	if lat, _ := m.mainCenter(); lat != 52.52 {
+		t.Errorf("the view should stay where the target was last seen, got %v", lat)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyHome})
+	if lat, _ := m.mainCenter(); lat != m.config.Connection.ReceiverLat || m.panned {
+		t.Errorf("Home should return to the receiver, got %v", lat)
+	}
+}
+
+func TestFollow_PanDisengages(t *testing.T) {
+	m := followKLM(t, 52.50)
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyShiftUp})
+	if m.Following() != "" || !strings.Contains(m.notification, "Follow ended") {
+		t.Fatalf("panning should end follow with a notification: %q", m.notification)
+	}
+	// A quarter of the 100nm range north of where the target was
+	lat, lon := m.mainCenter()
+	if want := 52.50 + 25.0/60; math.Abs(lat-want) > 0.01 || math.Abs(lon-4.9041) > 0.01 {
+		t.Errorf("center = %v,%v, want %v,4.9041", lat, lon, want)
+	}
+	moveKLM(m, 52.60)
+	if l, _ := m.mainCenter(); l != lat {
+		t.Error("a panned view shouldn't track the target")
+	}
+	m.width, m.height = 160, 60
+	if bar := ansi.Strip(m.renderStatusBar()); !strings.Contains(bar, "PANNED") {
+		t.Errorf("the status bar should show the view is panned:\n%s", bar)
+	}
+
+	// The same key takes it up again
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if l, _ := m.mainCenter(); m.Following() != "KLM123" || l != 52.60 {
+		t.Errorf("F should follow again from the target, center at %v", l)
+	}
+}
+
+func TestFollow_NeedsPosition(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if m.followHex != "" || m.notification != "No target selected" {
+		t.Errorf("F without a selection: notification = %q", m.notification)
+	}
+
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftNew, codec.Aircraft{Hex: "ABC123"}))
+	m.selectedHex = "ABC123"
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if m.followHex != "" || !strings.Contains(m.notification, "no position") {
+		t.Errorf("F on a target without a position: notification = %q", m.notification)
+	}
+}
@@ -0,0 +1,282 @@
+// Package app provides the geofence editor for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/units"
+)
+
+// Radius entry limits for circle geofences
+const (
+	fenceRadiusChars = 6   // characters typed for a radius
+	maxFenceRadiusNM = 500 // largest radius accepted, in nm
+)
+
+// fenceDraft is a circle geofence whose radius is being typed, either a
+// new one around a fixed centre or an existing one being resized
+type fenceDraft struct {
+	id       string // geofence being resized; empty for a new one
+	name     string
+	lat, lon float64
+	entry    string
+}
+
+// openGeofencesView lists the geofences, from the alert rules view
+func (m *Model) openGeofencesView() {
+	if m.alertState == nil || m.alertState.Engine == nil {
+		return
+	}
+	m.viewMode = ViewGeofences
+	m.geofenceCursor = 0
+	m.fenceDraft = nil
+}
+
+// geofences returns the alert engine's geofences in order
+func (m *Model) geofences() []*alerts.Geofence {
+	if m.alertState == nil || m.alertState.Engine == nil {
+		return nil
+	}
+	return m.alertState.Engine.GetGeofenceManager().GetAllGeofences()
+}
+
+// saveGeofences writes the geofences to the settings with the rules
+func (m *Model) saveGeofences() {
+	m.alertState.SaveToConfig(m.config)
+	m.saveConfig()
+}
+
+// handleGeofencesKey handles keyboard input in the geofence editor
+func (m *Model) handleGeofencesKey(key string) {
+	fences := m.geofences()
+	count := len(fences)
+	var current *alerts.Geofence
+	if count > 0 {
+		m.geofenceCursor = min(m.geofenceCursor, count-1)
+		current = fences[m.geofenceCursor]
+	}
+	manager := m.alertState.Engine.GetGeofenceManager()
+
+	switch key {
+	case keyEsc, "g":
+		m.viewMode = ViewAlertRules
+	case "up", "k":
+		if count > 0 {
+			m.geofenceCursor = (m.geofenceCursor - 1 + count) % count
+		}
+	case keyDown, "j":
+		if count > 0 {
+			m.geofenceCursor = (m.geofenceCursor + 1) % count
+		}
+	case keyEnter, " ":
+		if current == nil {
+			return
+		}
+		if manager.ToggleGeofence(current.ID) {
+			m.notify(m.trf("notify.geofence_enabled", current.Name))
+		} else {
+			m.notify(m.trf("notify.geofence_disabled", current.Name))
+		}
+		m.saveGeofences()
+	case "d", "delete":
+		if current == nil {
+			return
+		}
+		manager.RemoveGeofence(current.ID)
+		m.geofenceCursor = max(0, min(m.geofenceCursor, count-2))
+		m.saveGeofences()
+		// Rules naming it are kept; they no longer match until it's back
+		if uses := m.rulesUsingGeofence(current.ID); uses > 0 {
+			m.notify(m.trf("notify.geofence_deleted_used", current.Name, uses))
+		} else {
+			m.notify(m.trf("notify.geofence_deleted", current.Name))
+		}
+	case "c":
+		lat, lon, ok := m.receiverPosition()
+		if !ok {
+			m.notify(m.tr("notify.geofence_no_receiver"))
+			return
+		}
+		m.fenceDraft = &fenceDraft{name: m.tr("geofence.receiver"), lat: lat, lon: lon}
+	case "s":
+		target, ok := m.aircraft[m.selectedHex]
+		if !ok || !target.HasLat || !target.HasLon {
+			m.notify(m.tr("notify.geofence_no_target"))
+			return
+		}
+		name := strings.TrimSpace(target.Callsign)
+		if name == "" {
+			name = target.Hex
+		}
+		m.fenceDraft = &fenceDraft{name: name, lat: target.Lat, lon: target.Lon}
+	case "e":
+		if current == nil {
+			return
+		}
+		if current.Type != alerts.GeofenceCircle || current.Center == nil {
+			m.notify(m.trf("notify.geofence_not_circle", current.Name))
+			return
+		}
+		m.fenceDraft = &fenceDraft{
+			id:    current.ID,
+			name:  current.Name,
+			lat:   current.Center.Lat,
+			lon:   current.Center.Lon,
+			entry: strconv.FormatFloat(math.Round(units.FromNM(current.RadiusNM, m.distUnit())*100)/100, 'f', -1, 64),
+		}
+	}
+}
+
+// handleFenceDraftKey takes the radius of a drafted circle, in the unit
+// shown; Enter saves it and Esc abandons it
+func (m *Model) handleFenceDraftKey(key string) {
+	d := m.fenceDraft
+	switch key {
+	case keyEsc:
+		m.fenceDraft = nil
+	case keyEnter:
+		m.saveFenceDraft()
+	case "backspace":
+		if d.entry != "" {
+			d.entry = d.entry[:len(d.entry)-1]
+		}
+	default:
+		if len(key) == 1 && (key[0] >= '0' && key[0] <= '9' || key[0] == '.') && len(d.entry) < fenceRadiusChars {
+			d.entry += key
+		}
+	}
+}
+
+// saveFenceDraft adds the drafted circle, or resizes the one being
+// edited, and saves the geofences. A radius out of range is asked for
+// again.
+func (m *Model) saveFenceDraft() {
+	d := m.fenceDraft
+	typed, err := strconv.ParseFloat(d.entry, 64)
+	radius := units.ToNM(typed, m.distUnit())
+	if err != nil || radius <= 0 || radius > maxFenceRadiusNM {
+		d.entry = ""
+		m.notify(m.trf("notify.geofence_bad_radius", m.rangeLabel(maxFenceRadiusNM)))
+		return
+	}
+
+	manager := m.alertState.Engine.GetGeofenceManager()
+	if gf := manager.GetGeofence(d.id); gf != nil {
+		gf.RadiusNM = radius
+		m.notify(m.trf("notify.geofence_resized", gf.Name, m.formatDist(radius, 1)))
+	} else {
+		gf := alerts.NewCircleGeofence(manager.UniqueID("circle"), d.name, d.lat, d.lon, radius)
+		manager.AddGeofence(gf)
+		m.geofenceCursor = manager.Count() - 1
+		m.notify(m.trf("notify.geofence_added", gf.ID, m.formatDist(radius, 1)))
+	}
+	m.fenceDraft = nil
+	m.saveGeofences()
+}
+
+// rulesUsingGeofence counts the alert rules that name the geofence
+func (m *Model) rulesUsingGeofence(id string) int {
+	n := 0
+	for _, rule := range m.GetAlertRules() {
+		if rule.UsesGeofence(id) {
+			n++
+		}
+	}
+	return n
+}
+
+// renderGeofencesPanel lists the geofences, with the radius prompt below
+// them while a circle is drafted
+func (m *Model) renderGeofencesPanel() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright).Bold(true)
+	borderDim := lipgloss.NewStyle().Foreground(m.theme.BorderDim)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.theme.Selected).Bold(true)
+	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
+	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
+	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+	g := m.glyphs()
+
+	var sb strings.Builder
+
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 42) + g.DoubleTR))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.geofences"), 42)) + borderStyle.Render(g.DoubleV))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 42) + g.DoubleBR))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(secondaryBright.Render("  " + m.tr("title.geofences")))
+	sb.WriteString("\n")
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
+	sb.WriteString("\n")
+
+	fences := m.geofences()
+	if len(fences) == 0 {
+		sb.WriteString("  " + textDim.Render(m.tr("geofence.none")))
+		sb.WriteString("\n")
+	}
+	for i, gf := range fences {
+		prefix, style := "  ", textStyle
+		if i == m.geofenceCursor {
+			prefix, style = g.Cursor+" ", selectedStyle
+		}
+
+		marker, markerStyle := g.Off, textDim
+		if gf.Enabled {
+			marker, markerStyle = g.On, successStyle
+		}
+
+		// Circles show their radius, polygons their corners
+		shape := m.trf("geofence.points", len(gf.Points))
+		if gf.Type == alerts.GeofenceCircle {
+			radius, unit := m.inUnit(gf.RadiusNM)
+			shape = m.locale.Float(radius, 1) + " " + unit
+		}
+
+		sb.WriteString(fmt.Sprintf("%s%s %s %s\n",
+			prefix,
+			markerStyle.Render(marker),
+			style.Render(fit(gf.Name, 24)),
+			infoStyle.Render(fmt.Sprintf("%10s", truncate(shape, 10))),
+		))
+	}
+
+	if d := m.fenceDraft; d != nil {
+		heading := "  " + m.tr("geofence.new")
+		if d.id != "" {
+			heading = "  " + m.tr("geofence.resize")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(secondaryBright.Render(heading))
+		sb.WriteString("\n")
+		sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
+		sb.WriteString("\n")
+		sb.WriteString("  " + textDim.Render(m.tr("geofence.around")) + " " + textStyle.Render(truncate(d.name, 30)) + "\n")
+		sb.WriteString("  " + textDim.Render(m.tr("geofence.centre")) + " " + textStyle.Render(fmt.Sprintf("%s, %s", m.locale.Float(d.lat, 4), m.locale.Float(d.lon, 4))) + "\n")
+		sb.WriteString("  " + textStyle.Render(m.trf("geofence.radius", m.distUnit())) + " " + primaryBright.Render(d.entry+"_") + "\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
+	sb.WriteString("\n")
+	if m.fenceDraft != nil {
+		sb.WriteString(textDim.Render("  " + m.tr("geofence.help_draft")))
+	} else {
+		sb.WriteString(textDim.Render("  " + m.tr("geofence.help_toggle")))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  " + m.tr("geofence.help_add")))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  " + m.tr("geofence.help_edit")))
+	}
+
+	return sb.String()
+}
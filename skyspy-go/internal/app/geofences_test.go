@@ -0,0 +1,160 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// newGeofenceModel opens the geofence editor from the alert rules, with
+// settings saved under a temporary home
+func newGeofenceModel(t *testing.T) *Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	m, _ := newTrackingModel()
+	m.openAlertRulesView()
+	pressKey(m, "g")
+	if m.viewMode != ViewGeofences {
+		t.Fatalf("g should open the geofences, view %v", m.viewMode)
+	}
+	return m
+}
+
+func TestGeofences_CircleOnReceiver(t *testing.T) {
+	m := newGeofenceModel(t)
+	if panel := ansi.Strip(m.renderGeofencesPanel()); !strings.Contains(panel, "No geofences configured") {
+		t.Fatalf("the empty list should say so:\n%s", panel)
+	}
+
+	// Only digits and a point are taken for the radius
+	pressKeys(m, "c", "1", "x", "2", ".", "5")
+	if panel := ansi.Strip(m.renderGeofencesPanel()); !strings.Contains(panel, "Radius (nm): 12.5_") {
+		t.Fatalf("the radius prompt should show what was typed:\n%s", panel)
+	}
+	pressKey(m, keyEnter)
+
+	fences := m.geofences()
+	if len(fences) != 1 || m.fenceDraft != nil {
+		t.Fatalf("expected one geofence, got %d", len(fences))
+	}
+	gf := fences[0]
+	if gf.ID != "circle" || gf.Name != "Receiver" || gf.RadiusNM != 12.5 || !gf.Enabled {
+		t.Errorf("added %+v", gf)
+	}
+	if gf.Center.Lat != 52.3676 || gf.Center.Lon != 4.9041 {
+		t.Errorf("centre = %+v, want the receiver", gf.Center)
+	}
+
+	// It's saved with the rules and comes back on the next start
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := NewAlertState(cfg).Engine.GetGeofenceManager().GetGeofence("circle")
+	if restored == nil || restored.RadiusNM != 12.5 {
+		t.Errorf("saved geofence = %+v", restored)
+	}
+}
+
+func TestGeofences_CircleOnSelectedAircraft(t *testing.T) {
+	m := newGeofenceModel(t)
+	pressKey(m, "s")
+	if m.fenceDraft != nil || m.notification != m.tr("notify.geofence_no_target") {
+		t.Fatalf("nothing is selected, notification %q", m.notification)
+	}
+
+	ac := flying("4840d6", 52.40)
+	ac.Flight = "KLM1234"
+	m.updateTarget(ac, true)
+	m.selectedHex = "4840D6"
+	pressKeys(m, "s", "5", keyEnter, "s", "3", keyEnter)
+
+	fences := m.geofences()
+	if len(fences) != 2 {
+		t.Fatalf("expected two geofences, got %d", len(fences))
+	}
+	if fences[0].Name != "KLM1234" || fences[0].Center.Lat != 52.40 {
+		t.Errorf("added %+v", fences[0])
+	}
+	if fences[1].ID != "circle_2" {
+		t.Errorf("the second circle should get its own ID, got %q", fences[1].ID)
+	}
+	if m.geofenceCursor != 1 {
+		t.Error("the cursor should move to the new geofence")
+	}
+}
+
+func TestGeofences_BadRadiusAsksAgain(t *testing.T) {
+	m := newGeofenceModel(t)
+	for _, radius := range []string{"", "0", "501", "1.2.3"} {
+		pressKey(m, "c")
+		pressKeys(m, strings.Split(radius, "")...)
+		pressKey(m, keyEnter)
+		if len(m.geofences()) != 0 || m.fenceDraft == nil || m.fenceDraft.entry != "" {
+			t.Errorf("radius %q should be asked for again", radius)
+		}
+		pressKey(m, keyEsc)
+	}
+	if m.fenceDraft != nil || m.viewMode != ViewGeofences {
+		t.Error("Esc should drop the draft and stay in the editor")
+	}
+}
+
+func TestGeofences_ToggleEditDelete(t *testing.T) {
+	m := newGeofenceModel(t)
+	manager := m.alertState.Engine.GetGeofenceManager()
+	manager.AddGeofence(alerts.NewCircleGeofence("home", "Home", 52.3, 4.9, 10))
+	manager.AddGeofence(alerts.NewPolygonGeofence("ctr", "CTR", []alerts.GeofencePoint{{Lat: 52, Lon: 4}, {Lat: 53, Lon: 4}, {Lat: 53, Lon: 5}}))
+	m.alertState.Engine.AddRule(alerts.NewAlertRule("home_dwell", "Home dwell").
+		AddCondition(alerts.ConditionGeofenceDwell, "home:10m"))
+
+	pressKey(m, " ")
+	if manager.GetGeofence("home").Enabled {
+		t.Error("Space should disable the geofence")
+	}
+	if len(m.config.Alerts.Geofences) != 2 || m.config.Alerts.Geofences[0].Enabled {
+		t.Errorf("the toggle should be saved, config %+v", m.config.Alerts.Geofences)
+	}
+
+	// The radius prompt starts from the current radius
+	pressKeys(m, "e", "backspace", "backspace", "4", keyEnter)
+	if got := manager.GetGeofence("home").RadiusNM; got != 4 {
+		t.Errorf("radius = %v, want 4", got)
+	}
+	if m.config.Alerts.Geofences[0].RadiusNM != 4 {
+		t.Error("the new radius should be saved")
+	}
+
+	// Polygons are listed but not resized here
+	pressKeys(m, keyDown, "e")
+	if m.fenceDraft != nil || m.notification != m.trf("notify.geofence_not_circle", "CTR") {
+		t.Errorf("a polygon can't be resized, notification %q", m.notification)
+	}
+	if panel := ansi.Strip(m.renderGeofencesPanel()); !strings.Contains(panel, "3 pts") || !strings.Contains(panel, "4.0 nm") {
+		t.Errorf("the list should show each shape:\n%s", panel)
+	}
+
+	pressKeys(m, "up", "d")
+	if manager.GetGeofence("home") != nil || len(m.config.Alerts.Geofences) != 1 {
+		t.Fatal("d should delete and save")
+	}
+	if m.notification != m.trf("notify.geofence_deleted_used", "Home", 1) {
+		t.Errorf("notification = %q, want a warning about the rule", m.notification)
+	}
+	pressKey(m, "d")
+	if manager.Count() != 0 || m.geofenceCursor != 0 {
+		t.Errorf("%d geofences left, cursor %d", manager.Count(), m.geofenceCursor)
+	}
+
+	pressKey(m, "g")
+	if m.viewMode != ViewAlertRules {
+		t.Errorf("g should go back to the rules, view %v", m.viewMode)
+	}
+}
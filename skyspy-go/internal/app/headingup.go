@@ -0,0 +1,77 @@
+// Package app provides the heading-up display mode for the SkySpy radar
+package app
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// headingUpDeadband ignores track changes smaller than this (degrees)
+	// so noisy track data doesn't keep nudging the scope
+	headingUpDeadband = 5.0
+	// headingUpInterval is the minimum time between rotation updates
+	headingUpInterval = 2 * time.Second
+	// rotationEase is the fraction of the remaining turn applied per tick
+	rotationEase = 0.35
+)
+
+// IsHeadingUp reports whether the scope is currently rotated to the selected
+// target's track
+func (m *Model) IsHeadingUp() bool {
+	return m.headingUp && m.rotationHex != ""
+}
+
+// toggleHeadingUp switches between north-up and heading-up display
+func (m *Model) toggleHeadingUp() {
+	m.headingUp = !m.headingUp
+	if m.headingUp {
+		m.notify(m.tr("notify.heading_up_on"))
+	} else {
+		m.notify(m.tr("notify.heading_up_off"))
+	}
+	m.updateRotation()
+}
+
+// updateRotation picks the bearing to put at the top of the scope and eases
+// the display toward it. The target follows the selected aircraft's track,
+// rate-limited; without a selection the scope returns to north-up.
+func (m *Model) updateRotation() {
+	t := m.aircraft[m.selectedHex]
+	switch {
+	case !m.headingUp || t == nil || !t.HasTrack:
+		m.rotationHex = ""
+		m.targetRotation = 0
+	case t.Hex != m.rotationHex:
+		// New selection: turn to it straight away
+		m.rotationHex = t.Hex
+		m.targetRotation = t.Track
+		m.rotationUpdated = m.now()
+	case math.Abs(angleDelta(m.targetRotation, t.Track)) >= headingUpDeadband &&
+		m.now().Sub(m.rotationUpdated) >= headingUpInterval:
+		m.targetRotation = t.Track
+		m.rotationUpdated = m.now()
+	}
+
+	// Ease along the shorter way round, snapping when close
+	d := angleDelta(m.rotation, m.targetRotation)
+	if math.Abs(d) < 0.5 {
+		m.rotation = m.targetRotation
+		return
+	}
+	m.rotation = normalizeBearing(m.rotation + d*rotationEase)
+}
+
+// angleDelta returns the signed shortest turn from a to b in degrees
+func angleDelta(a, b float64) float64 {
+	return math.Mod(b-a+540, 360) - 180
+}
+
+// normalizeBearing wraps a bearing into [0, 360)
+func normalizeBearing(b float64) float64 {
+	b = math.Mod(b, 360)
+	if b < 0 {
+		b += 360
+	}
+	return b
+}
@@ -0,0 +1,86 @@
+// Package app provides hex address normalization for the SkySpy radar
+package app
+
+import (
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// canonicalHex normalizes hex and, if the aircraft is still tracked under
+// another spelling of the same address, moves its state to the canonical
+// key so the update merges into it rather than creating a second target.
+// Every key the model adds is canonical, so once a scan finds no other
+// spelling left the map isn't scanned again; on a feed of thousands of
+// aircraft a scan per new arrival would stall ingestion.
+func (m *Model) canonicalHex(hex string) string {
+	canon := codec.NormalizeHex(hex)
+	if _, ok := m.aircraft[canon]; ok || m.keysCanonical {
+		return canon
+	}
+	match, loose := "", 0
+	for old := range m.aircraft {
+		if codec.NormalizeHex(old) == old {
+			continue
+		}
+		if codec.NormalizeHex(old) == canon && match == "" {
+			match = old
+		} else {
+			loose++
+		}
+	}
+	if match != "" {
+		m.rekeyAircraft(match, canon)
+	}
+	m.keysCanonical = loose == 0
+	return canon
+}
+
+// rekeyAircraft moves every piece of per-aircraft state from old to canon.
+// The trail and alert trackers normalize their own keys, so only the
+// model's bookkeeping needs moving.
+func (m *Model) rekeyAircraft(old, canon string) {
+	target := m.aircraft[old]
+	target.Hex = canon
+	m.aircraft[canon] = target
+	delete(m.aircraft, old)
+
+	if seen, ok := m.lastSeen[old]; ok {
+		m.lastSeen[canon] = seen
+		delete(m.lastSeen, old)
+	}
+	if seen, ok := m.receiverSeen[old]; ok {
+		m.receiverSeen[canon] = seen
+		delete(m.receiverSeen, old)
+	}
+	if h, ok := m.history[old]; ok {
+		m.history[canon] = h
+		delete(m.history, old)
+	}
+	if m.alertedAircraft[old] {
+		m.alertedAircraft[canon] = true
+		delete(m.alertedAircraft, old)
+	}
+	if squawk, ok := m.emergencyActive[old]; ok {
+		m.emergencyActive[canon] = squawk
+		delete(m.emergencyActive, old)
+	}
+
+	rename := func(list []string) {
+		for i, h := range list {
+			if h == old {
+				list[i] = canon
+			}
+		}
+	}
+	rename(m.sortedTargets)
+	rename(m.pinned)
+	if m.selectedHex == old {
+		m.selectedHex = canon
+	}
+	if m.rotationHex == old {
+		m.rotationHex = canon
+	}
+	if m.detailHex == old {
+		m.detailHex = canon
+	}
+	m.searcher.Reset()
+}
@@ -0,0 +1,50 @@
+// Package app provides per-aircraft history for the SkySpy radar
+package app
+
+import (
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// History kept for each aircraft, for its detail page
+const (
+	maxRSSISamples   = 90 // the most recent RSSI readings
+	maxSquawkChanges = 8  // the most recent squawk codes
+)
+
+// aircraftHistory is what the radar remembers of an aircraft beyond its
+// latest report
+type aircraftHistory struct {
+	rssi    []float64      // RSSI readings, oldest first
+	squawks []squawkChange // codes set, oldest first
+}
+
+// squawkChange is a squawk code and when the aircraft was first seen
+// setting it
+type squawkChange struct {
+	code string
+	at   time.Time
+}
+
+// recordHistory adds a target's report to its history. Reports without
+// an RSSI or squawk leave those untouched.
+func (m *Model) recordHistory(t *radar.Target) {
+	h := m.history[t.Hex]
+	if h == nil {
+		h = &aircraftHistory{}
+		m.history[t.Hex] = h
+	}
+	if t.HasRSSI {
+		h.rssi = append(h.rssi, t.RSSI)
+		if n := len(h.rssi); n > maxRSSISamples {
+			h.rssi = append(h.rssi[:0], h.rssi[n-maxRSSISamples:]...)
+		}
+	}
+	if t.Squawk != "" && (len(h.squawks) == 0 || h.squawks[len(h.squawks)-1].code != t.Squawk) {
+		h.squawks = append(h.squawks, squawkChange{code: t.Squawk, at: m.now()})
+		if n := len(h.squawks); n > maxSquawkChanges {
+			h.squawks = append(h.squawks[:0], h.squawks[n-maxSquawkChanges:]...)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package app provides history-dot trails for the SkySpy radar
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/trails"
+)
+
+// Trail styles
+const (
+	trailStyleLine = "line" // every trail point, joined
+	trailStyleDots = "dots" // history dots at fixed intervals
+)
+
+// defaultTrailDots is how many history dots are shown when the setting
+// isn't positive
+const defaultTrailDots = 6
+
+// trailStyle returns the configured trail style; anything but dots draws
+// lines
+func trailStyle(cfg *config.Config) string {
+	if strings.EqualFold(strings.TrimSpace(cfg.Display.TrailStyle), trailStyleDots) {
+		return trailStyleDots
+	}
+	return trailStyleLine
+}
+
+// trailDots returns how many history dots each aircraft shows
+func trailDots(cfg *config.Config) int {
+	if cfg.Display.TrailDots > 0 {
+		return cfg.Display.TrailDots
+	}
+	return defaultTrailDots
+}
+
+// trailDotInterval returns the time between history dots: as configured,
+// or one sweep of the scope
+func trailDotInterval(cfg *config.Config) time.Duration {
+	if cfg.Display.TrailDotSeconds > 0 {
+		return time.Duration(cfg.Display.TrailDotSeconds) * time.Second
+	}
+	speed := cfg.Radar.SweepSpeed
+	if speed <= 0 {
+		speed = config.DefaultConfig().Radar.SweepSpeed
+	}
+	return tickInterval * 360 / time.Duration(speed)
+}
+
+// cycleTrailStyle switches trails between lines and history dots
+func (m *Model) cycleTrailStyle() {
+	if trailStyle(m.config) == trailStyleDots {
+		m.config.Display.TrailStyle = trailStyleLine
+		m.notify(m.tr("notify.trail_style_line"))
+		return
+	}
+	m.config.Display.TrailStyle = trailStyleDots
+	m.notify(m.trf("notify.trail_style_dots", trailDotInterval(m.config).Seconds()))
+}
+
+// GetTrailDotsForRadar returns each aircraft's history dots for the radar
+// scope, aged in dot intervals before its current position
+func (m *Model) GetTrailDotsForRadar() map[string][]radar.TrailPoint {
+	interval := trailDotInterval(m.config)
+	limit := trailDots(m.config)
+
+	allTrails := m.trailTracker.GetAllTrails()
+	result := make(map[string][]radar.TrailPoint, len(allTrails))
+	for hex, trail := range allTrails {
+		dots := trails.HistoryDots(trail, interval, limit)
+		if len(dots) == 0 {
+			continue
+		}
+		latest := trail[len(trail)-1].Timestamp
+		points := make([]radar.TrailPoint, len(dots))
+		for i, dot := range dots {
+			points[i] = radar.TrailPoint{
+				Lat: dot.Lat,
+				Lon: dot.Lon,
+				Age: int((latest.Sub(dot.Timestamp) + interval/2) / interval),
+			}
+		}
+		result[hex] = points
+	}
+	return result
+}
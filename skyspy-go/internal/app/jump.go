@@ -0,0 +1,102 @@
+// Package app provides type-ahead callsign jumping in the target list for
+// the SkySpy radar
+package app
+
+import (
+	"strings"
+	"time"
+)
+
+// jumpTimeout is how long the typed prefix lasts without another key
+const jumpTimeout = time.Second
+
+// jumpActive reports whether a callsign prefix is being typed
+func (m *Model) jumpActive() bool {
+	return m.jumpPrefix != "" && m.now().Sub(m.jumpAt) < jumpTimeout
+}
+
+// isJumpKey reports whether key can be part of a callsign
+func isJumpKey(key string) bool {
+	if len(key) != 1 {
+		return false
+	}
+	c := key[0]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// startJump begins a callsign prefix with a key that has no other meaning
+// in the radar view
+func (m *Model) startJump(key string) {
+	if isJumpKey(key) {
+		m.typeJump(key)
+	}
+}
+
+// continueJump takes a key while a prefix is being typed: letters and
+// digits extend it, Backspace shortens it and Esc clears it. Any other key
+// clears it and keeps its usual meaning. Reports whether key was used.
+func (m *Model) continueJump(key string) bool {
+	if !m.jumpActive() {
+		m.jumpPrefix = ""
+		return false
+	}
+	switch {
+	case isJumpKey(key):
+		m.typeJump(key)
+	case key == "backspace":
+		m.jumpPrefix = m.jumpPrefix[:len(m.jumpPrefix)-1]
+		m.jumpAt = m.now()
+		m.jumpTo()
+	case key == keyEsc:
+		m.jumpPrefix = ""
+	default:
+		m.jumpPrefix = ""
+		return false
+	}
+	return true
+}
+
+// typeJump adds a key to the prefix and moves the cursor to the first row
+// it matches
+func (m *Model) typeJump(key string) {
+	m.jumpPrefix += strings.ToUpper(key)
+	m.jumpAt = m.now()
+	m.jumpTo()
+}
+
+// jumpTo selects the first row, in list order, whose callsign starts with
+// the prefix. The cursor stays put when nothing matches.
+func (m *Model) jumpTo() {
+	if m.jumpPrefix == "" {
+		return
+	}
+	for _, hex := range m.sortedTargets {
+		if target, ok := m.aircraft[hex]; ok && m.jumpMatch(listCallsign(target.Callsign, hex)) > 0 {
+			m.selectedHex = hex
+			m.jumpMissed = false
+			return
+		}
+	}
+	m.jumpMissed = true
+}
+
+// jumpMatch returns how many leading characters of a row's callsign the
+// prefix matches, or 0 when it doesn't match
+func (m *Model) jumpMatch(callsign string) int {
+	if m.jumpPrefix == "" || len(callsign) < len(m.jumpPrefix) {
+		return 0
+	}
+	if !strings.EqualFold(callsign[:len(m.jumpPrefix)], m.jumpPrefix) {
+		return 0
+	}
+	return len(m.jumpPrefix)
+}
+
+// listCallsign is the callsign a target list row shows: the hex code for
+// an aircraft without one
+func listCallsign(callsign, hex string) string {
+	if callsign == "" {
+		return hex
+	}
+	return callsign
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+func newJumpModel(callsigns ...string) (*Model, *time.Time) {
+	m := NewModel(newTestConfig())
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	for i, cs := range callsigns {
+		hex := string(rune('a'+i)) + "00000"
+		m.aircraft[hex] = &radar.Target{Hex: hex, Callsign: cs}
+		m.sortedTargets = append(m.sortedTargets, hex)
+	}
+	return m, &clock
+}
+
+func TestJump_TypingSelectsFirstMatch(t *testing.T) {
+	m, _ := newJumpModel("KLM12", "WZZ1", "WBA9", "wba10")
+
+	// W has no other meaning (only w toggles the watch list), so it starts
+	// the prefix; b then extends it rather than toggling trails
+	typeKeys(m, "Wb")
+	if m.jumpPrefix != "WB" || m.config.Display.ShowTrails {
+		t.Fatalf("prefix %q, trails %v", m.jumpPrefix, m.config.Display.ShowTrails)
+	}
+	if m.selectedHex != "c00000" {
+		t.Errorf("selected %q, want the first WB row", m.selectedHex)
+	}
+
+	// Lower-case callsigns match too, and digits extend the prefix
+	typeKeys(m, "a1")
+	if m.selectedHex != "d00000" {
+		t.Errorf("selected %q, want wba10", m.selectedHex)
+	}
+
+	// Backspace goes back to the first match of the shorter prefix
+	m.handleKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.jumpPrefix != "WBA" || m.selectedHex != "c00000" {
+		t.Errorf("after backspace: prefix %q, selected %q", m.jumpPrefix, m.selectedHex)
+	}
+}
+
+func TestJump_BoundKeysWinWhenEmpty(t *testing.T) {
+	m, _ := newJumpModel("BAW1")
+	typeKeys(m, "b")
+	if m.jumpPrefix != "" || !m.config.Display.ShowTrails {
+		t.Errorf("b with no prefix should toggle trails, prefix %q", m.jumpPrefix)
+	}
+
+	// K is free (only k moves up), so it can start a prefix
+	m2, _ := newJumpModel("KLM1")
+	typeKeys(m2, "KL")
+	if m2.selectedHex != "a00000" || m2.jumpPrefix != "KL" {
+		t.Errorf("prefix %q, selected %q", m2.jumpPrefix, m2.selectedHex)
+	}
+}
+
+func TestJump_QuitAndOtherKeys(t *testing.T) {
+	m, _ := newJumpModel("WQ1", "WX2")
+	typeKeys(m, "Wq")
+	if m.jumpPrefix != "WQ" || m.selectedHex != "a00000" {
+		t.Fatalf("Q should extend the prefix, got %q", m.jumpPrefix)
+	}
+
+	// A key with no place in a callsign ends the prefix and does its job
+	m.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	if m.jumpPrefix != "" || m.selectedHex != "b00000" {
+		t.Errorf("down: prefix %q, selected %q", m.jumpPrefix, m.selectedHex)
+	}
+
+	typeKeys(m, "W")
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.jumpPrefix != "" {
+		t.Error("Esc should clear the prefix")
+	}
+}
+
+func TestJump_LapsesAfterASecond(t *testing.T) {
+	m, clock := newJumpModel("WZZ1", "BAW2")
+	typeKeys(m, "W")
+	*clock = clock.Add(1100 * time.Millisecond)
+	m.handleTick()
+	if m.jumpPrefix != "" {
+		t.Fatal("the prefix should lapse after a second")
+	}
+	typeKeys(m, "b")
+	if !m.config.Display.ShowTrails {
+		t.Error("b after the prefix lapsed should toggle trails")
+	}
+}
+
+func TestJump_StatusBarAndNoMatch(t *testing.T) {
+	m, _ := newJumpModel("WZZ1")
+	m.width, m.height = 120, 40
+	typeKeys(m, "W")
+	m.selectedHex = ""
+	typeKeys(m, "q")
+	if !m.jumpMissed || m.selectedHex != "" {
+		t.Errorf("no row matches WQ: missed %v, selected %q", m.jumpMissed, m.selectedHex)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "GOTO WQ_") {
+		t.Error("the status bar should show the typed prefix")
+	}
+}
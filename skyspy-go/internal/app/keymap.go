@@ -0,0 +1,89 @@
+// Package app provides the key bindings of the SkySpy radar
+package app
+
+// binding is a set of keys with one meaning
+type binding struct {
+	keys     []string
+	mutating bool // changes settings or files, or ends the session; locked in kiosk mode
+}
+
+// keymap lists a screen's bindings
+type keymap []binding
+
+// lookup returns the binding for key, if any
+func (km keymap) lookup(key string) (binding, bool) {
+	for _, b := range km {
+		for _, k := range b.keys {
+			if k == key {
+				return b, true
+			}
+		}
+	}
+	return binding{}, false
+}
+
+// globalKeys work on every screen
+var globalKeys = keymap{
+	{keys: []string{"q", "Q", "ctrl+c"}, mutating: true}, // quit
+	{keys: []string{"ctrl+z"}, mutating: true},           // suspend to the shell
+}
+
+// radarKeys are the radar view's bindings. Keys not listed start a
+// callsign jump, which only moves the selection.
+var radarKeys = keymap{
+	{keys: []string{"up", "k", keyDown, "j"}},                               // select
+	{keys: []string{"+", "=", "-", "_"}},                                    // zoom
+	{keys: []string{"n", "N"}},                                              // custom range
+	{keys: []string{keyEnter, "ctrl+j"}},                                    // pin, clear pins
+	{keys: []string{"tab"}},                                                 // switch pane
+	{keys: []string{"F", "home"}},                                           // follow, recenter
+	{keys: []string{"shift+up", "shift+down", "shift+left", "shift+right"}}, // pan
+	{keys: []string{"?", "h", "H"}},                                         // help
+	{keys: []string{"ctrl+n"}},                                              // server notices
+	{keys: []string{"ctrl+w"}},                                              // while you were away
+	{keys: []string{"ctrl+g"}},                                              // surface mode, for the session
+	{keys: []string{"ctrl+o"}},                                              // density shading, for the session
+	{keys: []string{"ctrl+q"}},                                              // quick look
+	{keys: []string{"ctrl+f"}},                                              // aircraft detail
+	{keys: []string{" ", "left", "right"}},                                  // replay pause and seek
+	{keys: []string{"l", "L", "b", "B", "ctrl+b"}, mutating: true},          // labels and trails
+	{keys: []string{"m", "M", "g", "G"}, mutating: true},                    // filter toggles
+	{keys: []string{"f1", "f2", "f3", "f4", "/"}, mutating: true},           // filter presets, search
+	{keys: []string{"a", "A", "v", "V", "s", "S"}, mutating: true},          // panels
+	{keys: []string{"i"}},                                                   // statistics
+	{keys: []string{"I", "ctrl+u", "z", "Z"}, mutating: true},               // privacy, heading up, ribbon
+	{keys: []string{"ctrl+l"}, mutating: true},                              // distance units
+	{keys: []string{"ctrl+a"}, mutating: true},                              // altitude colors
+	{keys: []string{"w"}, mutating: true},                                   // watch list
+	{keys: []string{"x", "X", "ctrl+t"}, mutating: true},                    // points of interest
+	{keys: []string{"|", "c", "C"}, mutating: true},                         // split screen
+	{keys: []string{"d", "D"}, mutating: true},                              // do not disturb
+	{keys: []string{"t", "T", "o", "O", "r", "R"}, mutating: true},          // settings, overlays, alert rules
+	{keys: []string{"p", "P", "e", "E", "ctrl+e", "ctrl+p", "ctrl+r", "ctrl+d", "y", "Y"}, mutating: true}, // exports
+	{keys: []string{"u", "U"}, mutating: true},                                                             // renew sign-in
+}
+
+// viewEdits declares, for every view besides the radar, whether it edits
+// settings, files or rules. Kiosk mode locks the editors and lets every
+// key through on the rest, which only look. A new view must be listed.
+var viewEdits = map[ViewMode]bool{
+	ViewSettings:       true,
+	ViewOverlays:       true,
+	ViewSearch:         true,
+	ViewAlertRules:     true,
+	ViewLogin:          true,
+	ViewGeofences:      true,
+	ViewHelp:           false,
+	ViewWhatsNew:       false,
+	ViewNotice:         false,
+	ViewNotices:        false,
+	ViewAway:           false,
+	ViewAircraftDetail: false,
+	ViewStatistics:     false,
+}
+
+// connectFailureKeys are the connection error screen's bindings
+var connectFailureKeys = keymap{
+	{keys: []string{"r", "R"}},                 // retry
+	{keys: []string{"c", "C"}, mutating: true}, // configure
+}
@@ -0,0 +1,160 @@
+// Package app provides kiosk mode, which locks the controls of a radar on
+// public display, for the SkySpy radar
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// kioskState is kiosk mode's lock and idle timer
+type kioskState struct {
+	unlock   []string // key sequence that unlocks, and locks again
+	typed    int      // how much of the sequence has been typed
+	unlocked bool
+	idle     time.Duration // 0 never resets
+	lastKey  time.Time
+	reset    bool // the view has been reset since the last key
+	rangeNM  int  // range the reset zooms back to
+}
+
+// SetKiosk turns kiosk mode on or off. In kiosk mode keys that change
+// settings, write files or end the session are locked until the unlock
+// sequence is typed, settings are never saved, and after the idle timeout
+// the selection and zoom are reset and the controls lock again.
+func (m *Model) SetKiosk(on bool) {
+	if !on {
+		m.kiosk = nil
+		return
+	}
+	m.kiosk = &kioskState{
+		unlock:  strings.Fields(m.config.Kiosk.Unlock),
+		idle:    time.Duration(m.config.Kiosk.IdleSeconds) * time.Second,
+		lastKey: m.now(),
+		rangeNM: clampRange(m.config.Radar.DefaultRange),
+	}
+}
+
+// Kiosk reports whether kiosk mode is on
+func (m *Model) Kiosk() bool {
+	return m.kiosk != nil
+}
+
+// kioskLocked reports whether kiosk mode is locking the controls
+func (m *Model) kioskLocked() bool {
+	return m.kiosk != nil && !m.kiosk.unlocked
+}
+
+// kioskBlocks watches keys in kiosk mode for the unlock sequence and
+// reports whether key is locked. The key completing the sequence is used
+// up by it.
+func (m *Model) kioskBlocks(key string) bool {
+	k := m.kiosk
+	if k == nil {
+		return false
+	}
+	k.lastKey = m.now()
+	k.reset = false
+
+	if k.typeUnlock(key) {
+		k.unlocked = !k.unlocked
+		if k.unlocked {
+			m.notify(m.tr("notify.kiosk_unlocked"))
+		} else {
+			m.notify(m.tr("notify.kiosk_locked"))
+		}
+		return true
+	}
+	return !k.unlocked && m.keyMutates(key)
+}
+
+// typeUnlock adds key to the unlock sequence typed so far and reports
+// whether it completed it
+func (k *kioskState) typeUnlock(key string) bool {
+	if len(k.unlock) == 0 {
+		return false
+	}
+	switch {
+	case key == k.unlock[k.typed]:
+		k.typed++
+	case key == k.unlock[0]:
+		k.typed = 1
+	default:
+		k.typed = 0
+	}
+	if k.typed == len(k.unlock) {
+		k.typed = 0
+		return true
+	}
+	return false
+}
+
+// keyMutates reports whether key would change settings or files, or end
+// the session, on the current screen
+func (m *Model) keyMutates(key string) bool {
+	if b, ok := globalKeys.lookup(key); ok {
+		return b.mutating
+	}
+	if m.connectFailure != nil {
+		b, _ := connectFailureKeys.lookup(key)
+		return b.mutating
+	}
+	switch m.viewMode {
+	case ViewRadar:
+		// The range prompt and a callsign being typed take keys that
+		// otherwise toggle things, but W repeats a failed export while it
+		// is offered
+		if m.rangeEntryOpen {
+			return false
+		}
+		if m.exportRetryOpen() && (key == "w" || key == "W") {
+			return true
+		}
+		if m.jumpActive() && (isJumpKey(key) || key == "backspace" || key == keyEsc) {
+			return false
+		}
+		b, _ := radarKeys.lookup(key)
+		return b.mutating
+	}
+	if editing, ok := viewEdits[m.viewMode]; ok && !editing {
+		return false
+	}
+	// An editor, or a view that hasn't declared itself: locked, but for
+	// Esc so a visitor who reaches one can always leave
+	return key != keyEsc
+}
+
+// checkKioskIdle resets the view once no key has been pressed for the
+// idle timeout: the selection and pins are cleared, the zoom returns to
+// the starting range and the controls lock again
+func (m *Model) checkKioskIdle() {
+	k := m.kiosk
+	if k == nil || k.idle <= 0 || k.reset || m.now().Sub(k.lastKey) < k.idle {
+		return
+	}
+	k.reset = true
+	k.unlocked = false
+	k.typed = 0
+
+	m.selectedHex = ""
+	m.lostSelection = nil
+	m.pinned = nil
+	m.jumpPrefix = ""
+	m.rangeEntryOpen = false
+	if m.viewMode != ViewNotice {
+		m.viewMode = ViewRadar
+	}
+	m.setCustomRange(k.rangeNM)
+}
+
+// saveConfig writes the settings, except in kiosk mode, where nothing a
+// visitor does may outlast the session, and in safe mode, which would
+// write back the subsystems it turned off
+func (m *Model) saveConfig() {
+	if m.kiosk != nil || !m.caps.SaveSettings {
+		return
+	}
+	_ = config.Save(m.config)
+}
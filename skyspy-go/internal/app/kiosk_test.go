@@ -0,0 +1,281 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+func newKioskModel() (*Model, *time.Time) {
+	cfg := newTestConfig()
+	cfg.Radar.DefaultRange = 100
+	m := NewModel(cfg)
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	for _, hex := range []string{"a00000", "b00000"} {
+		m.aircraft[hex] = &radar.Target{Hex: hex, Callsign: "TEST" + hex[:1], Distance: 10}
+		m.sortedTargets = append(m.sortedTargets, hex)
+	}
+	m.SetKiosk(true)
+	return m, &clock
+}
+
+func pressKey(m *Model, key string) tea.Cmd {
+	var msg tea.KeyMsg
+	switch key {
+	case "ctrl+c":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlC}
+	case "ctrl+x":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlX}
+	case "ctrl+k":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlK}
+	case "ctrl+d":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlD}
+	case "ctrl+z":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlZ}
+	case "ctrl+f":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlF}
+	case keyDown:
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case keyEnter:
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case keyEsc:
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	_, cmd := m.handleKey(msg)
+	return cmd
+}
+
+func TestKiosk_LocksMutatingKeys(t *testing.T) {
+	m, _ := newKioskModel()
+	before, _ := json.Marshal(m.config)
+
+	for _, key := range []string{"t", "o", "r", "m", "l", "b", "e", "p", "/"} {
+		pressKey(m, key)
+	}
+	if m.viewMode != ViewRadar {
+		t.Errorf("locked keys should not open panels, view %v", m.viewMode)
+	}
+	if after, _ := json.Marshal(m.config); string(after) != string(before) {
+		t.Error("locked keys should not change settings")
+	}
+	for _, key := range []string{"q", "ctrl+c", "ctrl+z"} {
+		if cmd := pressKey(m, key); cmd != nil {
+			t.Errorf("%s should be locked in kiosk mode", key)
+		}
+	}
+
+	// Navigation still works
+	pressKey(m, keyDown)
+	if m.selectedHex != "a00000" {
+		t.Errorf("selection should move, got %q", m.selectedHex)
+	}
+	pressKey(m, "+")
+	if m.targetRange == 100 {
+		t.Error("zoom should still work")
+	}
+	pressKey(m, keyEnter)
+	if len(m.pinned) != 1 {
+		t.Error("pinning should still work")
+	}
+	pressKey(m, "?")
+	if m.viewMode != ViewHelp {
+		t.Error("help should still open")
+	}
+	pressKey(m, "t")
+	if m.viewMode != ViewRadar {
+		t.Error("any key should still close help")
+	}
+}
+
+func TestKiosk_AircraftDetailCloses(t *testing.T) {
+	m, _ := newKioskModel()
+	pressKey(m, keyDown)
+	pressKey(m, "ctrl+f")
+	if m.viewMode != ViewAircraftDetail {
+		t.Fatalf("the aircraft detail should open, view %v", m.viewMode)
+	}
+	pressKey(m, keyEsc)
+	if m.viewMode != ViewRadar {
+		t.Errorf("esc should close the aircraft detail, view %v", m.viewMode)
+	}
+}
+
+func TestKiosk_StatisticsCloses(t *testing.T) {
+	m, _ := newKioskModel()
+	for _, closeKey := range []string{keyEsc, "i"} {
+		pressKey(m, "i")
+		if m.viewMode != ViewStatistics {
+			t.Fatalf("the statistics should open, view %v", m.viewMode)
+		}
+		pressKey(m, closeKey)
+		if m.viewMode != ViewRadar {
+			t.Errorf("%s should close the statistics, view %v", closeKey, m.viewMode)
+		}
+	}
+}
+
+// TestKiosk_EveryViewDeclared checks each view says whether it edits
+// anything, and that Esc still leaves one that doesn't
+func TestKiosk_EveryViewDeclared(t *testing.T) {
+	for mode := ViewSettings; mode < viewModeCount; mode++ {
+		if _, ok := viewEdits[mode]; !ok {
+			t.Errorf("view %d isn't declared in viewEdits", mode)
+		}
+	}
+
+	m, _ := newKioskModel()
+	m.viewMode = viewModeCount
+	if m.keyMutates(keyEsc) || !m.keyMutates("x") {
+		t.Error("an undeclared view should lock every key but Esc")
+	}
+}
+
+func TestKiosk_UnlockSequence(t *testing.T) {
+	m, _ := newKioskModel()
+
+	// A wrong key in the middle starts over
+	pressKey(m, "ctrl+x")
+	pressKey(m, "j")
+	pressKey(m, "ctrl+k")
+	if !m.kioskLocked() {
+		t.Fatal("a broken sequence should not unlock")
+	}
+
+	pressKey(m, "ctrl+x")
+	pressKey(m, "ctrl+k")
+	if m.kioskLocked() {
+		t.Fatal("the unlock sequence should unlock")
+	}
+	if !strings.Contains(ansi.Strip(m.renderStatusBar()), "KIOSK UNLOCKED") {
+		t.Error("the status bar should show the controls are unlocked")
+	}
+	pressKey(m, "m")
+	if !m.config.Filters.MilitaryOnly {
+		t.Error("unlocked keys should work")
+	}
+
+	pressKey(m, "ctrl+x")
+	pressKey(m, "ctrl+k")
+	if !m.kioskLocked() {
+		t.Fatal("the sequence again should lock")
+	}
+	status := ansi.Strip(m.renderStatusBar())
+	if !strings.Contains(status, m.glyphs().Lock+"KIOSK") {
+		t.Errorf("the status bar should show the lock:\n%s", status)
+	}
+
+	// No sequence configured never unlocks
+	m.config.Kiosk.Unlock = ""
+	m.SetKiosk(true)
+	pressKey(m, "ctrl+x")
+	pressKey(m, "ctrl+k")
+	if !m.kioskLocked() {
+		t.Error("with no unlock sequence the controls stay locked")
+	}
+}
+
+func TestKiosk_IdleReset(t *testing.T) {
+	m, clock := newKioskModel()
+
+	pressKey(m, "ctrl+x")
+	pressKey(m, "ctrl+k")
+	pressKey(m, keyDown)
+	pressKey(m, keyEnter)
+	pressKey(m, "+")
+	pressKey(m, "?")
+
+	*clock = clock.Add(119 * time.Second)
+	m.handleTick()
+	if m.selectedHex == "" || m.kioskLocked() {
+		t.Fatal("nothing should reset before the idle timeout")
+	}
+
+	*clock = clock.Add(time.Second)
+	m.handleTick()
+	if m.selectedHex != "" || len(m.pinned) != 0 {
+		t.Errorf("the selection and pins should clear, got %q, %v", m.selectedHex, m.pinned)
+	}
+	if m.targetRange != 100 {
+		t.Errorf("zoom should return to 100 nm, got %v", m.targetRange)
+	}
+	if m.viewMode != ViewRadar || !m.kioskLocked() {
+		t.Errorf("the view should return to the radar, locked; view %v", m.viewMode)
+	}
+}
+
+func TestKiosk_NoSave(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	m, _ := newKioskModel()
+	if !m.Kiosk() {
+		t.Fatal("kiosk mode should be on")
+	}
+	m.saveConfig()
+	if _, err := os.Stat(config.ConfigFile); err == nil {
+		t.Error("kiosk mode should not write the settings file")
+	}
+
+	// Quitting once unlocked doesn't save either
+	pressKey(m, "ctrl+x")
+	pressKey(m, "ctrl+k")
+	if cmd := pressKey(m, "q"); cmd == nil {
+		t.Fatal("q should quit once unlocked")
+	}
+	if _, err := os.Stat(config.ConfigFile); err == nil {
+		t.Error("quitting in kiosk mode should not write the settings file")
+	}
+
+	m.SetKiosk(false)
+	m.saveConfig()
+	if _, err := os.Stat(config.ConfigFile); err != nil {
+		t.Errorf("settings should save outside kiosk mode: %v", err)
+	}
+}
+
+// TestKiosk_KeymapCoversSettings presses every key on the radar view and
+// checks any that changes a setting is flagged as mutating
+func TestKiosk_KeymapCoversSettings(t *testing.T) {
+	keys := []string{"up", "down", "tab", "enter", "f1", "f2", "f3", "f4"}
+	for c := '!'; c <= '~'; c++ {
+		keys = append(keys, string(c))
+	}
+	for c := 'a'; c <= 'z'; c++ {
+		keys = append(keys, "ctrl+"+string(c))
+	}
+
+	for _, key := range keys {
+		if key == "ctrl+z" || key == "ctrl+c" || key == "q" || key == "Q" {
+			continue
+		}
+		m, _ := newKioskModel()
+		m.SetKiosk(false)
+		m.selectedHex = "a00000"
+		m.config.Export.Directory = t.TempDir()
+		before, _ := json.Marshal(m.config)
+
+		m.handleRadarKey(key)
+
+		// Zooming moves the starting range, which kiosk mode never saves
+		m.config.Radar.DefaultRange = 100
+		after, _ := json.Marshal(m.config)
+		changed := string(before) != string(after)
+		opened := m.viewMode != ViewRadar && viewEdits[m.viewMode]
+
+		if (changed || opened) && !m.keyMutates(key) {
+			t.Errorf("%q changes settings or opens an editor but isn't flagged as mutating", key)
+		}
+	}
+}
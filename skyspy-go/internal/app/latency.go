@@ -0,0 +1,171 @@
+// Package app provides feed latency measurement for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/api"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// latencyWindow is how many recent updates the latency histogram covers
+const latencyWindow = 1000
+
+// latencyBounds are the upper bounds of the latency histogram's buckets.
+// Latencies past the last go in an overflow bucket.
+var latencyBounds = []time.Duration{
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+// latencyHistogram counts the latencies of the last latencyWindow updates
+// by bucket. The samples are kept so the oldest can be taken back out as
+// new ones arrive.
+type latencyHistogram struct {
+	samples []time.Duration // ring of the latest samples
+	next    int             // where the next sample goes once full
+	counts  []int           // per bucket of latencyBounds, plus overflow
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{counts: make([]int, len(latencyBounds)+1)}
+}
+
+// latencyBucket returns the index of the bucket d falls in
+func latencyBucket(d time.Duration) int {
+	for i, bound := range latencyBounds {
+		if d <= bound {
+			return i
+		}
+	}
+	return len(latencyBounds)
+}
+
+// Observe adds a latency, dropping the oldest once the window is full.
+// Negative latencies, from a timestamp ahead of the local clock, count as
+// zero.
+func (h *latencyHistogram) Observe(d time.Duration) {
+	d = max(d, 0)
+	if len(h.samples) < latencyWindow {
+		h.samples = append(h.samples, d)
+	} else {
+		h.counts[latencyBucket(h.samples[h.next])]--
+		h.samples[h.next] = d
+		h.next = (h.next + 1) % latencyWindow
+	}
+	h.counts[latencyBucket(d)]++
+}
+
+// Quantile estimates the q quantile (0-1) from the buckets, interpolating
+// within the bucket it falls in as Prometheus does. The overflow bucket
+// has no upper bound, so a quantile in it reads as the last bound. ok is
+// false when there are no samples.
+func (h *latencyHistogram) Quantile(q float64) (d time.Duration, ok bool) {
+	total := len(h.samples)
+	if total == 0 {
+		return 0, false
+	}
+	rank := q * float64(total)
+	seen := 0
+	for i, n := range h.counts {
+		if n == 0 || float64(seen+n) < rank {
+			seen += n
+			continue
+		}
+		if i == len(latencyBounds) {
+			break
+		}
+		var lower time.Duration
+		if i > 0 {
+			lower = latencyBounds[i-1]
+		}
+		frac := (rank - float64(seen)) / float64(n)
+		return lower + time.Duration(frac*float64(latencyBounds[i]-lower)), true
+	}
+	return latencyBounds[len(latencyBounds)-1], true
+}
+
+// Buckets returns the cumulative count at or under each bound, as
+// Prometheus histograms report them, and the total
+func (h *latencyHistogram) Buckets() (cumulative []int, total int) {
+	cumulative = make([]int, len(latencyBounds))
+	for i := range latencyBounds {
+		total += h.counts[i]
+		cumulative[i] = total
+	}
+	return cumulative, len(h.samples)
+}
+
+// observeLatency records how long an update took from the server's radio
+// to here. Updates without a receive time are left out. The server stamps
+// them on its own clock, so a local clock flagged as skewed is corrected
+// for; smaller skew estimates come from message times that themselves
+// carry network delay, so correcting for them would hide the latency
+// being measured.
+func (m *Model) observeLatency(ac *codec.Aircraft) {
+	if ac.Received.IsZero() {
+		return
+	}
+	now := m.now()
+	if m.IsClockSkewed() {
+		now = now.Add(m.clockSkew)
+	}
+	m.latency.Observe(now.Sub(ac.Received))
+}
+
+// latencyWarn is the p95 latency above which it's shown as a warning, or
+// 0 if it never is
+func (m *Model) latencyWarn() time.Duration {
+	return time.Duration(m.config.Connection.LatencyWarnMs) * time.Millisecond
+}
+
+// latencySlow reports whether the p95 latency is over the warning threshold
+func (m *Model) latencySlow() bool {
+	p95, ok := m.latency.Quantile(0.95)
+	return ok && m.latencyWarn() > 0 && p95 > m.latencyWarn()
+}
+
+// formatLatency formats a latency compactly, e.g. "120ms" or "2.5s"
+func (m *Model) formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return m.locale.Float(d.Seconds(), 1) + "s"
+}
+
+// latencyLabel is the stats panel's p50 and p95 latency, or "" before any
+// update has carried a receive time
+func (m *Model) latencyLabel() string {
+	p50, ok := m.latency.Quantile(0.5)
+	if !ok {
+		return ""
+	}
+	p95, _ := m.latency.Quantile(0.95)
+	return m.trf("stat.lat_value", m.formatLatency(p50), m.formatLatency(p95))
+}
+
+// apiLatency copies the latency histogram for the API, or nil while it's
+// empty
+func (m *Model) apiLatency() *api.Latency {
+	p50, ok := m.latency.Quantile(0.5)
+	if !ok {
+		return nil
+	}
+	p95, _ := m.latency.Quantile(0.95)
+	cumulative, total := m.latency.Buckets()
+	out := &api.Latency{Samples: total, P50Ms: p50.Milliseconds(), P95Ms: p95.Milliseconds()}
+	for i, bound := range latencyBounds {
+		out.Buckets = append(out.Buckets, api.LatencyBucket{LeMs: bound.Milliseconds(), Count: cumulative[i]})
+	}
+	return out
+}
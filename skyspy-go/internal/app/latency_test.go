@@ -0,0 +1,142 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+func TestLatencyHistogram_Quantiles(t *testing.T) {
+	h := newLatencyHistogram()
+	if _, ok := h.Quantile(0.5); ok {
+		t.Error("an empty histogram has no quantiles")
+	}
+	for i := 0; i < 50; i++ {
+		h.Observe(10 * time.Millisecond)
+		h.Observe(300 * time.Millisecond)
+	}
+
+	// Half fill the first bucket, up to 25ms; the rest sit in 250-500ms
+	if p50, _ := h.Quantile(0.5); p50 != 25*time.Millisecond {
+		t.Errorf("p50 = %v, want 25ms", p50)
+	}
+	if p95, _ := h.Quantile(0.95); p95 != 475*time.Millisecond {
+		t.Errorf("p95 = %v, want 475ms", p95)
+	}
+
+	cumulative, total := h.Buckets()
+	if total != 100 || cumulative[0] != 50 || cumulative[3] != 50 || cumulative[4] != 100 {
+		t.Errorf("buckets = %v of %d", cumulative, total)
+	}
+
+	// Past the last bound there's no upper edge to interpolate to
+	h.Observe(-time.Second)
+	h.Observe(5 * time.Minute)
+	if p100, _ := h.Quantile(1); p100 != time.Minute {
+		t.Errorf("p100 = %v, want the last bound", p100)
+	}
+	if p0, _ := h.Quantile(0); p0 != 0 {
+		t.Errorf("p0 = %v; a negative latency should count as zero", p0)
+	}
+}
+
+func TestLatencyHistogram_Rolls(t *testing.T) {
+	h := newLatencyHistogram()
+	for i := 0; i < latencyWindow; i++ {
+		h.Observe(10 * time.Millisecond)
+	}
+	for i := 0; i < latencyWindow; i++ {
+		h.Observe(300 * time.Millisecond)
+	}
+	cumulative, total := h.Buckets()
+	if total != latencyWindow || cumulative[3] != 0 {
+		t.Errorf("the oldest samples should have rolled out: %v of %d", cumulative, total)
+	}
+	if p50, _ := h.Quantile(0.5); p50 != 375*time.Millisecond {
+		t.Errorf("p50 = %v, want 375ms", p50)
+	}
+}
+
+// stampedUpdate is an aircraft update the radio received at received
+func stampedUpdate(hex string, received time.Time) codec.Message {
+	return rawAircraftMessage(codec.AircraftUpdate, fmt.Sprintf(
+		`{"hex":%q,"lat":52.40,"lon":4.90,"timestamp":%q}`, hex, received.Format(time.RFC3339Nano)))
+}
+
+func TestLatency_SkewCorrection(t *testing.T) {
+	tests := []struct {
+		name string
+		skew time.Duration // server clock ahead of ours
+	}{
+		{"in step", 0},
+		{"flagged skew corrected", 2 * time.Hour},
+		{"flagged skew behind corrected", -10 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, clock := newTrackingModel()
+			if tt.skew != 0 {
+				m.SetClockSkew(tt.skew)
+			}
+			m.handleAircraftMsg(stampedUpdate("484B1C", clock.Add(tt.skew-800*time.Millisecond)))
+			if len(m.latency.samples) != 1 || m.latency.samples[0] != 800*time.Millisecond {
+				t.Errorf("samples = %v, want [800ms]", m.latency.samples)
+			}
+		})
+	}
+
+	// A skew estimate within the threshold is mostly network delay, so
+	// taking it off would hide the latency
+	m, clock := newTrackingModel()
+	m.SetClockSkew(-3 * time.Second)
+	m.handleAircraftMsg(stampedUpdate("484B1C", clock.Add(-800*time.Millisecond)))
+	if m.latency.samples[0] != 800*time.Millisecond {
+		t.Errorf("an unflagged skew shouldn't be corrected for, got %v", m.latency.samples[0])
+	}
+}
+
+func TestLatency_MissingTimestampExcluded(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.handleAircraftMsg(rawAircraftMessage(codec.AircraftUpdate, `{"hex":"484b1c","lat":52.40,"lon":4.90}`))
+	m.handleAircraftMsg(rawAircraftMessage(codec.AircraftUpdate, `{"hex":"484b1c","lat":52.40,"lon":4.90,"timestamp":"soon"}`))
+	if len(m.latency.samples) != 0 || m.latencyLabel() != "" || m.apiSnapshot().Stats.Latency != nil {
+		t.Error("updates without a usable timestamp should be left out")
+	}
+}
+
+func TestLatency_StatsRowWarns(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Connection.LatencyWarnMs = 500
+	m := NewModel(cfg)
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+
+	m.handleAircraftMsg(stampedUpdate("484B1C", clock.Add(-800*time.Millisecond)))
+	if got := m.latencyLabel(); got != "750ms p50 975ms p95" {
+		t.Errorf("label = %q", got)
+	}
+	if !m.latencySlow() {
+		t.Error("a p95 over latency_warn_ms should warn")
+	}
+	m.config.Connection.LatencyWarnMs = 0
+	if m.latencySlow() {
+		t.Error("latency_warn_ms 0 should never warn")
+	}
+
+	m.width, m.height = 160, 60
+	if panel := ansi.Strip(m.renderStatsPanel()); !strings.Contains(panel, "LAT  750ms p50 975ms p95") {
+		t.Errorf("the stats panel should show the latency:\n%s", panel)
+	}
+
+	lat := m.apiSnapshot().Stats.Latency
+	if lat == nil || lat.Samples != 1 || lat.P50Ms != 750 || lat.P95Ms != 975 {
+		t.Fatalf("API latency = %+v", lat)
+	}
+	if b := lat.Buckets[5]; b.LeMs != 1000 || b.Count != 1 || lat.Buckets[4].Count != 0 {
+		t.Errorf("API buckets = %+v", lat.Buckets)
+	}
+}
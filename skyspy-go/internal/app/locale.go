@@ -0,0 +1,54 @@
+// Package app provides translated UI strings and locale formats for the
+// SkySpy radar
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/i18n"
+)
+
+// sidebarTitleWidth is the width a sidebar panel's title and the rule
+// after it fill
+const sidebarTitleWidth = 27
+
+// SetStrings replaces the UI strings, e.g. with a table loaded from the
+// config directory
+func (m *Model) SetStrings(t *i18n.Table) {
+	m.text = t
+}
+
+// tr returns the UI text for key
+func (m *Model) tr(key string) string {
+	return m.text.T(key)
+}
+
+// trf formats the UI text for key with args
+func (m *Model) trf(key string, args ...interface{}) string {
+	return m.text.Tf(key, args...)
+}
+
+// fit cuts s to at most width columns, so a longer translation can't push
+// a fixed-width panel out of shape
+func fit(s string, width int) string {
+	return ansi.Truncate(s, width, "")
+}
+
+// sidebarTop draws a sidebar panel's top border with its title, keeping the
+// border the same width whatever the title's length
+func (m *Model) sidebarTop(title string) string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+	g := m.glyphs()
+
+	title = fit(title, sidebarTitleWidth-1)
+	rule := strings.Repeat(g.H, sidebarTitleWidth-lipgloss.Width(title))
+	return borderStyle.Render(g.TL+g.H) + titleStyle.Render(title) + borderStyle.Render(rule+g.TR)
+}
+
+// panelHeading centers a full panel's title in width columns
+func panelHeading(title string, width int) string {
+	return lipgloss.PlaceHorizontal(width, lipgloss.Center, fit(title, width))
+}
@@ -0,0 +1,41 @@
+// Package app provides mouse input for the SkySpy radar
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Clickable regions, recorded in m.hits as the view is drawn
+const (
+	hitStatTotal     = "stat.total"     // target count: clears filters
+	hitStatMilitary  = "stat.military"  // military count: military preset
+	hitStatEmergency = "stat.emergency" // emergency count: emergency preset
+)
+
+// hitKeys maps a clickable region to the key a click on it stands for, so
+// clicks do exactly what the keyboard does, kiosk locks included
+var hitKeys = map[string]tea.KeyType{
+	hitStatTotal:     tea.KeyF1,
+	hitStatMilitary:  tea.KeyF2,
+	hitStatEmergency: tea.KeyF3,
+}
+
+// handleMouse acts on a left click on a clickable region of the last
+// render, and tracks the pointer resting on a target for a quick look;
+// other mouse input is ignored
+func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action == tea.MouseActionMotion {
+		return m, m.hover(msg.X, msg.Y)
+	}
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+	id, ok := m.hits.At(msg.X, msg.Y)
+	if !ok {
+		return m, nil
+	}
+	if key, ok := hitKeys[id]; ok {
+		return m.handleKey(tea.KeyMsg{Type: key})
+	}
+	return m, nil
+}
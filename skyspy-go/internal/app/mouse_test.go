@@ -0,0 +1,76 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// statCell finds the screen cell of a stats panel label in the rendered view
+func statCell(t *testing.T, view, label string) (x, y int) {
+	t.Helper()
+	for y, line := range strings.Split(ansi.Strip(view), "\n") {
+		if i := strings.Index(line, "  "+label+" "); i >= 0 {
+			return ansi.StringWidth(line[:i]) + 2, y
+		}
+	}
+	t.Fatalf("%s not found in the view", label)
+	return 0, 0
+}
+
+func click(m *Model, x, y int) {
+	m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+}
+
+func TestMouse_StatCountersFilter(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.width, m.height = 180, 60
+	m.updateTarget(&codec.Aircraft{Hex: "MIL001", Military: true}, true)
+	m.updateStats()
+	view := m.View()
+
+	x, y := statCell(t, view, "MIL")
+	click(m, x, y)
+	if !m.IsFilterActive() || !m.searchFilter.MilitaryOnly {
+		t.Fatal("clicking the military count should apply the military preset")
+	}
+
+	x, y = statCell(t, view, "EMRG")
+	click(m, x+10, y)
+	if !m.IsFilterActive() || m.searchFilter.MilitaryOnly {
+		t.Fatal("clicking the emergency count should apply the emergency preset")
+	}
+
+	// Only presses of the left button count
+	x, y = statCell(t, view, "TGT")
+	m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionMotion})
+	m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonRight})
+	if !m.IsFilterActive() {
+		t.Fatal("motion and right clicks should do nothing")
+	}
+	click(m, x, y)
+	if m.IsFilterActive() {
+		t.Error("clicking the target count should clear the filter")
+	}
+
+	// Elsewhere does nothing
+	x, y = statCell(t, view, "MIL")
+	click(m, x, y)
+	click(m, 0, 0)
+	if !m.searchFilter.MilitaryOnly {
+		t.Error("a click outside the counters shouldn't change the filter")
+	}
+}
+
+func TestMouse_StatCountersKioskLocked(t *testing.T) {
+	m, _ := newKioskModel()
+	m.width, m.height = 180, 60
+	x, y := statCell(t, m.View(), "MIL")
+	click(m, x, y)
+	if m.IsFilterActive() {
+		t.Error("a locked kiosk should ignore clicks as it ignores F2")
+	}
+}
@@ -0,0 +1,238 @@
+// Package app provides merging of several receivers' feeds for the SkySpy radar
+package app
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/ws"
+)
+
+// FeedSource is one receiver's feed in a MultiFeed
+type FeedSource struct {
+	Name string
+	Feed Feed
+}
+
+// SourceStatus is the state of one receiver's feed: connected, or down
+// and waiting on reconnect attempt Attempt (0 when it won't reconnect on
+// its own)
+type SourceStatus struct {
+	Name      string
+	Connected bool
+	Attempt   int
+}
+
+// sourceReporter is implemented by feeds that merge several receivers,
+// such as MultiFeed
+type sourceReporter interface {
+	Sources() []SourceStatus
+}
+
+// MultiFeed merges the feeds of several receivers into one. Messages are
+// tagged with the name of the receiver they came from. Each feed connects
+// and reconnects on its own; the merged feed is connected while any of
+// them is.
+type MultiFeed struct {
+	sources  []FeedSource
+	aircraft chan codec.Message
+	acars    chan codec.Message
+	stopCh   chan struct{}
+	stopOnce sync.Once
+
+	mu       sync.RWMutex
+	attempts []int // reconnect attempt each source is waiting on
+}
+
+// NewMultiFeed merges sources. The first source's address is the one the
+// connection error screen shows.
+func NewMultiFeed(sources ...FeedSource) *MultiFeed {
+	return &MultiFeed{
+		sources:  sources,
+		aircraft: make(chan codec.Message, 100),
+		acars:    make(chan codec.Message, 100),
+		stopCh:   make(chan struct{}),
+		attempts: make([]int, len(sources)),
+	}
+}
+
+// Start starts every feed and begins merging them
+func (f *MultiFeed) Start() {
+	for i, src := range f.sources {
+		src.Feed.Start()
+		go f.forward(src, src.Feed.AircraftMessages(), f.aircraft)
+		go f.forward(src, src.Feed.ACARSMessages(), f.acars)
+		if r, ok := src.Feed.(stateReporter); ok {
+			go f.followStates(i, src.Feed, r)
+		}
+	}
+}
+
+// forward tags messages from one source's channel and passes them on
+// until the merged feed or the source stops
+func (f *MultiFeed) forward(src FeedSource, in <-chan codec.Message, out chan<- codec.Message) {
+	for {
+		select {
+		case msg := <-in:
+			msg.Source = src.Name
+			select {
+			case out <- msg:
+			case <-f.stopCh:
+				return
+			}
+		case <-src.Feed.Done():
+			return
+		case <-f.stopCh:
+			return
+		}
+	}
+}
+
+// followStates keeps the reconnect attempt of source i up to date
+func (f *MultiFeed) followStates(i int, feed Feed, r stateReporter) {
+	for {
+		select {
+		case state := <-r.States():
+			f.mu.Lock()
+			f.attempts[i] = state.Attempt
+			if state.State == ws.StateConnected {
+				f.attempts[i] = 0
+			}
+			f.mu.Unlock()
+		case <-feed.Done():
+			return
+		case <-f.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops every feed
+func (f *MultiFeed) Stop() {
+	f.stopOnce.Do(func() {
+		close(f.stopCh)
+		for _, src := range f.sources {
+			src.Feed.Stop()
+		}
+	})
+}
+
+// Done is closed when the merged feed stops
+func (f *MultiFeed) Done() <-chan struct{} {
+	return f.stopCh
+}
+
+// IsConnected reports whether any receiver's feed is connected
+func (f *MultiFeed) IsConnected() bool {
+	for _, src := range f.sources {
+		if src.Feed.IsConnected() {
+			return true
+		}
+	}
+	return false
+}
+
+// AircraftMessages returns the merged aircraft messages
+func (f *MultiFeed) AircraftMessages() <-chan codec.Message {
+	return f.aircraft
+}
+
+// ACARSMessages returns the merged ACARS messages
+func (f *MultiFeed) ACARSMessages() <-chan codec.Message {
+	return f.acars
+}
+
+// Sources returns the state of each receiver's feed, in order
+func (f *MultiFeed) Sources() []SourceStatus {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	out := make([]SourceStatus, len(f.sources))
+	for i, src := range f.sources {
+		out[i] = SourceStatus{Name: src.Name, Connected: src.Feed.IsConnected()}
+		if !out[i].Connected {
+			out[i].Attempt = f.attempts[i]
+		}
+	}
+	return out
+}
+
+// URL returns the first receiver's feed address
+func (f *MultiFeed) URL() string {
+	if len(f.sources) == 0 {
+		return ""
+	}
+	if r, ok := f.sources[0].Feed.(connectionReporter); ok {
+		return r.URL()
+	}
+	return ""
+}
+
+// LastError returns why the first failing receiver's feed last failed to
+// connect
+func (f *MultiFeed) LastError() error {
+	for _, src := range f.sources {
+		if r, ok := src.Feed.(connectionReporter); ok {
+			if err := r.LastError(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Retry cuts every receiver's reconnect wait short
+func (f *MultiFeed) Retry() {
+	for _, src := range f.sources {
+		if r, ok := src.Feed.(connectionReporter); ok {
+			r.Retry()
+		}
+	}
+}
+
+// LastMessage returns when any receiver's feed last delivered a message
+func (f *MultiFeed) LastMessage() time.Time {
+	var last time.Time
+	for _, src := range f.sources {
+		if r, ok := src.Feed.(messageReporter); ok {
+			if at := r.LastMessage(); at.After(last) {
+				last = at
+			}
+		}
+	}
+	return last
+}
+
+// Traffic returns the data usage of every receiver's feed together
+func (f *MultiFeed) Traffic() ws.TrafficStats {
+	var total ws.TrafficStats
+	for _, src := range f.sources {
+		if r, ok := src.Feed.(trafficReporter); ok {
+			t := r.Traffic()
+			total.RxBytes += t.RxBytes
+			total.TxBytes += t.TxBytes
+			total.RxMessages += t.RxMessages
+			total.RxPerMinute += t.RxPerMinute
+		}
+	}
+	return total
+}
+
+// Acknowledge passes a notice acknowledgement to every receiver's server,
+// as any of them may have sent it. It fails only if none took it.
+func (f *MultiFeed) Acknowledge(id string) error {
+	var errs []error
+	for _, src := range f.sources {
+		acker, ok := src.Feed.(noticeAcknowledger)
+		if !ok {
+			continue
+		}
+		err := acker.Acknowledge(id)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
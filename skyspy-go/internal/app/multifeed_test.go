@@ -0,0 +1,162 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/ws"
+)
+
+// receiveMsg waits briefly for a message on ch
+func receiveMsg(t *testing.T, ch <-chan codec.Message) codec.Message {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message from the merged feed")
+		return codec.Message{}
+	}
+}
+
+func TestMultiFeed_MergesAndTags(t *testing.T) {
+	home := &statesFeed{fakeFeed: newFakeFeed(), states: make(chan ws.ConnState, 1)}
+	club := &statesFeed{fakeFeed: newFakeFeed(), states: make(chan ws.ConnState, 1)}
+	feed := NewMultiFeed(FeedSource{Name: "HOME", Feed: home}, FeedSource{Name: "CLUB", Feed: club})
+	feed.Start()
+	if !home.started || !club.started {
+		t.Fatal("every receiver's feed should start")
+	}
+
+	club.aircraft <- codec.Message{Type: string(codec.AircraftUpdate)}
+	if msg := receiveMsg(t, feed.AircraftMessages()); msg.Source != "CLUB" {
+		t.Errorf("source = %q, want CLUB", msg.Source)
+	}
+	home.acars <- codec.Message{Type: string(codec.ACARSMessage)}
+	if msg := receiveMsg(t, feed.ACARSMessages()); msg.Source != "HOME" {
+		t.Errorf("source = %q, want HOME", msg.Source)
+	}
+
+	// Connected while any receiver is; each reconnects on its own
+	if feed.IsConnected() {
+		t.Error("no receiver is connected yet")
+	}
+	home.connected = true
+	club.states <- ws.ConnState{State: ws.StateDisconnected, Attempt: 2}
+	deadline := time.Now().Add(time.Second)
+	for feed.Sources()[1].Attempt != 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	sources := feed.Sources()
+	if !feed.IsConnected() || !sources[0].Connected || sources[1].Connected || sources[1].Attempt != 2 {
+		t.Errorf("sources = %+v", sources)
+	}
+
+	feed.Stop()
+	select {
+	case <-home.Done():
+	default:
+		t.Error("stopping should stop every receiver's feed")
+	}
+	select {
+	case <-feed.Done():
+	default:
+		t.Error("the merged feed should be done")
+	}
+}
+
+// sourceMsg is an aircraft message as a merged feed delivers it
+func sourceMsg(source string, msgType codec.MessageType, ac *codec.Aircraft) codec.Message {
+	msg := createMockAircraftMessage(msgType, *ac)
+	msg.Source = source
+	return msg
+}
+
+func TestReceivers_MergedByHex(t *testing.T) {
+	home := newFakeFeed()
+	club := newFakeFeed()
+	m := NewModelWithFeed(newTestConfig(), NewMultiFeed(FeedSource{Name: "HOME", Feed: home}, FeedSource{Name: "CLUB", Feed: club}))
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+
+	m.handleAircraftMsg(sourceMsg("HOME", codec.AircraftNew, flying("MRG001", 52.40)))
+
+	// CLUB hears it without a position: HOME's stays
+	clock = clock.Add(time.Second)
+	m.handleAircraftMsg(sourceMsg("CLUB", codec.AircraftUpdate, &codec.Aircraft{Hex: "MRG001", Squawk: "1000"}))
+	target := m.aircraft["MRG001"]
+	if !target.HasLat || target.Lat != 52.40 || target.Distance == 0 || target.Squawk != "1000" {
+		t.Fatalf("the position should be kept from HOME: %+v", target)
+	}
+	if got := strings.Join(target.Receivers, ","); got != "CLUB,HOME" {
+		t.Errorf("receivers = %q", got)
+	}
+	m.selectedHex = "MRG001"
+	if panel := ansi.Strip(m.renderTargetPanel()); !strings.Contains(panel, "RCV  2: CLUB, HOME") {
+		t.Errorf("the target panel should count the receivers:\n%s", panel)
+	}
+
+	// The newest position wins, whichever receiver has it
+	clock = clock.Add(time.Second)
+	m.handleAircraftMsg(sourceMsg("CLUB", codec.AircraftUpdate, flying("MRG001", 52.41)))
+	if lat := m.aircraft["MRG001"].Lat; lat != 52.41 {
+		t.Errorf("lat = %v, want CLUB's 52.41", lat)
+	}
+
+	// Dropped by one receiver it stays; by every receiver it goes
+	m.handleAircraftMsg(sourceMsg("CLUB", codec.AircraftRemove, &codec.Aircraft{Hex: "MRG001"}))
+	if target := m.aircraft["MRG001"]; target == nil || strings.Join(target.Receivers, ",") != "HOME" {
+		t.Fatalf("HOME still sees the aircraft: %+v", target)
+	}
+	m.handleAircraftMsg(codec.Message{Type: string(codec.AircraftSnapshot), Data: []byte(`{"aircraft":[]}`), Source: "HOME"})
+	if m.aircraft["MRG001"] != nil {
+		t.Error("no receiver sees the aircraft any more")
+	}
+}
+
+func TestReceivers_SnapshotSpeaksForItsReceiver(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.handleAircraftMsg(sourceMsg("HOME", codec.AircraftNew, flying("MRG002", 52.40)))
+	m.handleAircraftMsg(sourceMsg("CLUB", codec.AircraftNew, flying("MRG003", 52.50)))
+
+	m.handleAircraftMsg(codec.Message{Type: string(codec.AircraftSnapshot), Data: []byte(`{"aircraft":[]}`), Source: "CLUB"})
+	if m.aircraft["MRG002"] == nil || m.aircraft["MRG003"] != nil {
+		t.Errorf("CLUB's empty snapshot should only drop CLUB's aircraft")
+	}
+
+	// A receiver that stops reporting an aircraft stops counting
+	*clock = clock.Add(receiverSeenFor + time.Second)
+	m.handleAircraftMsg(sourceMsg("CLUB", codec.AircraftUpdate, flying("MRG002", 52.40)))
+	if got := strings.Join(m.aircraft["MRG002"].Receivers, ","); got != "CLUB" {
+		t.Errorf("receivers = %q, want CLUB alone", got)
+	}
+}
+
+func TestReceivers_StatusBarPerConnection(t *testing.T) {
+	home := newFakeFeed()
+	club := newFakeFeed()
+	home.connected, club.connected = true, true
+	m, _ := newConnectModel(t, NewMultiFeed(FeedSource{Name: "HOME", Feed: home}, FeedSource{Name: "CLUB", Feed: club}))
+	m.width, m.height = 160, 60
+	m.handleTick()
+
+	club.connected = false
+	m.handleTick()
+	if m.notification != "CLUB: connection lost, reconnecting..." {
+		t.Errorf("notification = %q", m.notification)
+	}
+	g := m.glyphs()
+	cell, _ := m.connectionCell()
+	if got := ansi.Strip(cell.draw(cell.pref)); got != " "+g.Live+" HOME "+g.Off+" CLUB " {
+		t.Errorf("connection cell = %q", got)
+	}
+	if got := ansi.Strip(cell.draw(cell.min)); got != " "+g.Live+g.Off+" " {
+		t.Errorf("squeezed connection cell = %q", got)
+	}
+	if !m.IsConnected() {
+		t.Error("the radar is connected while any receiver is")
+	}
+}
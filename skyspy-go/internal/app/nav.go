@@ -0,0 +1,65 @@
+// Package app provides autopilot/nav mode display for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// navModeNames maps server nav_modes values to short panel labels
+var navModeNames = map[string]string{
+	"autopilot": "AP",
+	"vnav":      "VNAV",
+	"althold":   "ALT",
+	"approach":  "APP",
+	"lnav":      "LNAV",
+	"tcas":      "TCAS",
+}
+
+// formatNavSelected formats the selected altitude, selected heading and
+// baro setting, e.g. "4000 HDG 270 QNH 1013". Each part is shown only when
+// the server sent it.
+func (m *Model) formatNavSelected(t *radar.Target) string {
+	var parts []string
+	if t.HasNavAlt {
+		if t.NavAltitude >= 18000 {
+			parts = append(parts, fmt.Sprintf("FL%03d", t.NavAltitude/100))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d", t.NavAltitude))
+		}
+	}
+	if t.HasNavHeading {
+		parts = append(parts, fmt.Sprintf("HDG %03d", int(t.NavHeading)))
+	}
+	if t.HasNavQNH {
+		parts = append(parts, fmt.Sprintf("QNH %d", int(t.NavQNH+0.5)))
+	}
+	if len(parts) == 0 {
+		return dashPlaceholder
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatNavModes formats the active autopilot modes, e.g. "AP ALT LNAV"
+func (m *Model) formatNavModes(t *radar.Target) string {
+	if len(t.NavModes) == 0 {
+		return dashPlaceholder
+	}
+	names := make([]string, 0, len(t.NavModes))
+	for _, mode := range t.NavModes {
+		name, ok := navModeNames[strings.ToLower(mode)]
+		if !ok {
+			name = strings.ToUpper(mode)
+		}
+		names = append(names, name)
+	}
+	// Drop trailing modes that don't fit the panel's value column
+	s := strings.Join(names, " ")
+	for len(s) > 23 && len(names) > 1 {
+		names = names[:len(names)-1]
+		s = strings.Join(names, " ")
+	}
+	return s
+}
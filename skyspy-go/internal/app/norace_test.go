@@ -0,0 +1,7 @@
+//go:build !race
+
+package app
+
+// raceEnabled relaxes timing checks under the race detector, which slows
+// everything several times over
+const raceEnabled = false
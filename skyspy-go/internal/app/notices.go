@@ -0,0 +1,196 @@
+// Package app provides server notices and the notice history for the
+// SkySpy radar
+package app
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// maxNotices is how many notices the history keeps
+const maxNotices = 20
+
+// noticeSeconds is how long a low severity notice stays in the status bar
+const noticeSeconds = 30.0
+
+// ServerNotice is a notice from the server's operator as received
+type ServerNotice struct {
+	codec.NoticeData
+	Received time.Time
+}
+
+// Summary returns the notice's title, or its body when it has none
+func (n *ServerNotice) Summary() string {
+	if n.Title != "" {
+		return n.Title
+	}
+	return n.Body
+}
+
+// noticeAcknowledger is implemented by feeds that can tell the server a
+// notice arrived, such as the WebSocket client
+type noticeAcknowledger interface {
+	Acknowledge(id string) error
+}
+
+// handleNotice shows a notice from either feed. High severity notices
+// interrupt with a panel that stays until dismissed; the rest pass through
+// the status bar. Either way they are kept in the notice history.
+func (m *Model) handleNotice(msg codec.Message) {
+	data, err := codec.ParseNotice(msg.Data)
+	if err != nil {
+		return
+	}
+	notice := ServerNotice{NoticeData: *data, Received: m.now()}
+
+	m.notices = append(m.notices, notice)
+	if len(m.notices) > maxNotices {
+		m.notices = m.notices[len(m.notices)-maxNotices:]
+	}
+	if notice.Ack && notice.ID != "" {
+		m.pendingAcks = append(m.pendingAcks, notice.ID)
+	}
+
+	if !notice.High() {
+		m.notifyFor(m.trf("notify.notice", notice.Summary()), noticeSeconds)
+		return
+	}
+	m.noticeQueue = append(m.noticeQueue, notice)
+	if m.viewMode != ViewNotice {
+		m.noticeReturn = m.viewMode
+		m.viewMode = ViewNotice
+	}
+}
+
+// ackCmd sends the receipts notices asked for, if the feed can
+func (m *Model) ackCmd() tea.Cmd {
+	ids := m.pendingAcks
+	m.pendingAcks = nil
+	acker, ok := m.feed.(noticeAcknowledger)
+	if len(ids) == 0 || !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		for _, id := range ids {
+			_ = acker.Acknowledge(id)
+		}
+		return nil
+	}
+}
+
+// handleNoticeKey dismisses the notice shown with Enter, Esc or space,
+// moving on to the next one waiting. Other keys are ignored so a notice
+// can't be dismissed by accident.
+func (m *Model) handleNoticeKey(key string) {
+	switch key {
+	case keyEnter, keyEsc, " ":
+	default:
+		return
+	}
+	if len(m.noticeQueue) > 0 {
+		m.noticeQueue = m.noticeQueue[1:]
+	}
+	if len(m.noticeQueue) == 0 {
+		m.viewMode = m.noticeReturn
+	}
+}
+
+func (m *Model) renderNoticePanel() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.Error).Bold(true)
+	headStyle := lipgloss.NewStyle().Foreground(m.theme.Warning).Bold(true)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	wrap := lipgloss.NewStyle().Width(whatsNewWidth)
+	g := m.glyphs()
+
+	var sb strings.Builder
+
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 42) + g.DoubleTR))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.notice"), 42)) + borderStyle.Render(g.DoubleV))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 42) + g.DoubleBR))
+	sb.WriteString("\n\n")
+
+	if len(m.noticeQueue) > 0 {
+		notice := m.noticeQueue[0]
+		if notice.Title != "" {
+			for _, line := range strings.Split(wrap.Render(notice.Title), "\n") {
+				sb.WriteString("   " + headStyle.Render(strings.TrimRight(line, " ")))
+				sb.WriteString("\n")
+			}
+			sb.WriteString("\n")
+		}
+		for _, line := range strings.Split(wrap.Render(notice.Body), "\n") {
+			sb.WriteString("   " + textStyle.Render(strings.TrimRight(line, " ")))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("   " + m.trf("notice.received", m.locale.Clock(notice.Received))))
+		sb.WriteString("\n")
+		if waiting := len(m.noticeQueue) - 1; waiting > 0 {
+			sb.WriteString(textDim.Render("   " + m.trf("notice.waiting", waiting)))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(textDim.Render("  " + m.tr("help.notice_dismiss")))
+
+	return sb.String()
+}
+
+func (m *Model) renderNoticesPanel() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright).Bold(true)
+	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error).Bold(true)
+	borderDim := lipgloss.NewStyle().Foreground(m.theme.BorderDim)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	wrap := lipgloss.NewStyle().Width(whatsNewWidth)
+	g := m.glyphs()
+
+	var sb strings.Builder
+
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 42) + g.DoubleTR))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.notices"), 42)) + borderStyle.Render(g.DoubleV))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 42) + g.DoubleBR))
+	sb.WriteString("\n\n")
+
+	if len(m.notices) == 0 {
+		sb.WriteString(textDim.Render("   " + m.tr("notice.none")))
+		sb.WriteString("\n\n")
+	}
+
+	// Newest first
+	for i := len(m.notices) - 1; i >= 0; i-- {
+		notice := &m.notices[i]
+		head := secondaryBright
+		if notice.High() {
+			head = errorStyle
+		}
+		sb.WriteString("  " + textDim.Render(m.locale.Clock(notice.Received)) + " " + head.Render(fit(notice.Summary(), 32)))
+		sb.WriteString("\n")
+		sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
+		sb.WriteString("\n")
+		if notice.Title != "" && notice.Body != "" {
+			for _, line := range strings.Split(wrap.Render(notice.Body), "\n") {
+				sb.WriteString("   " + textStyle.Render(strings.TrimRight(line, " ")))
+				sb.WriteString("\n")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(textDim.Render("  " + m.tr("help.close")))
+
+	return sb.String()
+}
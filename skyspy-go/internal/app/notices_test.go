@@ -0,0 +1,148 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// ackFeed is a fakeFeed that records acknowledged notices
+type ackFeed struct {
+	*fakeFeed
+	acked []string
+}
+
+func (f *ackFeed) Acknowledge(id string) error {
+	f.acked = append(f.acked, id)
+	return nil
+}
+
+func noticeMsg(typ codec.MessageType, data string) codec.Message {
+	return codec.Message{Type: string(typ), Data: json.RawMessage(data)}
+}
+
+func TestNotice_HighSeverityOpensPanel(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.viewMode = ViewHelp
+
+	m.handleAircraftMsg(noticeMsg(codec.Notice, `{"severity":"critical","title":"Feed outage","body":"The receiver is offline until 14:00Z","unknown":[1,2]}`))
+	m.handleACARSMsg(noticeMsg(codec.Broadcast, `{"severity":"high","body":"Second notice"}`))
+	if m.viewMode != ViewNotice {
+		t.Fatalf("view mode %v, want the notice panel", m.viewMode)
+	}
+	if m.notification != "" {
+		t.Errorf("a high severity notice should not use the status bar, got %q", m.notification)
+	}
+
+	panel := ansi.Strip(m.renderNoticePanel())
+	for _, want := range []string{"SERVER NOTICE", "Feed outage", "The receiver is offline", "1 more waiting"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("panel should show %q:\n%s", want, panel)
+		}
+	}
+
+	// Other keys don't dismiss it, including radar keys
+	typeKeys(m, "t")
+	if m.viewMode != ViewNotice {
+		t.Fatal("an unrelated key should not dismiss the notice")
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.viewMode != ViewNotice || !strings.Contains(ansi.Strip(m.renderNoticePanel()), "Second notice") {
+		t.Fatal("Enter should move on to the next notice")
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.viewMode != ViewHelp {
+		t.Errorf("dismissing the last notice should return to the view it interrupted, got %v", m.viewMode)
+	}
+	if len(m.notices) != 2 {
+		t.Errorf("both notices should be in the history, got %d", len(m.notices))
+	}
+}
+
+func TestNotice_LowSeverityUsesStatusBar(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	m.handleAircraftMsg(noticeMsg(codec.Broadcast, `{"severity":"info","title":"Maintenance tonight","body":"Expect a short gap at 02:00Z"}`))
+	if m.viewMode != ViewRadar {
+		t.Errorf("a low severity notice should not interrupt, got view %v", m.viewMode)
+	}
+	if !strings.Contains(m.notification, "Maintenance tonight") {
+		t.Errorf("notification %q should carry the title", m.notification)
+	}
+	if m.notificationTime < noticeSeconds {
+		t.Errorf("notice should stay up for %.0fs, got %.1f", noticeSeconds, m.notificationTime)
+	}
+
+	// Body-only notices are summarized by their body
+	m.handleAircraftMsg(noticeMsg(codec.Notice, `{"body":"Now on v2"}`))
+	if !strings.Contains(m.notification, "Now on v2") {
+		t.Errorf("notification %q should carry the body", m.notification)
+	}
+
+	// Unreadable notices are dropped
+	m.handleAircraftMsg(noticeMsg(codec.Notice, `{"severity":"high"}`))
+	m.handleAircraftMsg(noticeMsg(codec.Notice, `[1]`))
+	if len(m.notices) != 2 || m.viewMode != ViewRadar {
+		t.Errorf("notices without text should be ignored, have %d", len(m.notices))
+	}
+}
+
+func TestNotice_HistoryKeepsLast20(t *testing.T) {
+	m := NewModel(newTestConfig())
+	for i := 0; i < maxNotices+5; i++ {
+		m.handleAircraftMsg(noticeMsg(codec.Notice, fmt.Sprintf(`{"title":"Notice %d"}`, i)))
+	}
+	if len(m.notices) != maxNotices {
+		t.Fatalf("history has %d notices, want %d", len(m.notices), maxNotices)
+	}
+	if m.notices[0].Title != "Notice 5" {
+		t.Errorf("oldest kept notice is %q, want Notice 5", m.notices[0].Title)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlN})
+	if m.viewMode != ViewNotices {
+		t.Fatalf("Ctrl+N should open the notice history, got %v", m.viewMode)
+	}
+	panel := ansi.Strip(m.renderNoticesPanel())
+	if strings.Index(panel, "Notice 24") > strings.Index(panel, "Notice 23") || strings.Contains(panel, "Notice 4\n") {
+		t.Errorf("history should list the kept notices newest first:\n%s", panel)
+	}
+	typeKeys(m, "x")
+	if m.viewMode != ViewRadar {
+		t.Errorf("any key should close the history, got %v", m.viewMode)
+	}
+}
+
+func TestNotice_Acknowledged(t *testing.T) {
+	feed := &ackFeed{fakeFeed: newFakeFeed()}
+	m := NewModelWithFeed(newTestConfig(), feed)
+
+	m.handleAircraftMsg(noticeMsg(codec.Notice, `{"id":"n1","body":"Please confirm","ack":true}`))
+	m.handleAircraftMsg(noticeMsg(codec.Notice, `{"id":"n2","body":"No receipt needed"}`))
+	m.handleACARSMsg(noticeMsg(codec.Notice, `{"id":"n3","severity":"critical","body":"Confirm this too","ack":true}`))
+
+	cmd := m.ackCmd()
+	if cmd == nil {
+		t.Fatal("expected a command to send the receipts")
+	}
+	cmd()
+	if strings.Join(feed.acked, ",") != "n1,n3" {
+		t.Errorf("acknowledged %v, want n1 and n3", feed.acked)
+	}
+	if m.ackCmd() != nil {
+		t.Error("receipts should only be sent once")
+	}
+
+	// Feeds that can't acknowledge are left alone
+	m = NewModelWithFeed(newTestConfig(), newFakeFeed())
+	m.handleAircraftMsg(noticeMsg(codec.Notice, `{"id":"n1","body":"Please confirm","ack":true}`))
+	if m.ackCmd() != nil {
+		t.Error("a feed without acknowledgements should get no command")
+	}
+}
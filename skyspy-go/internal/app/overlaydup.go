@@ -0,0 +1,154 @@
+// Package app provides duplicate overlay detection for the SkySpy radar
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/geo"
+)
+
+// loadedOverlay is an overlay on the radar that came from a file, kept to
+// catch the same file loaded again under another path
+type loadedOverlay struct {
+	key string
+	cfg config.OverlayConfig
+}
+
+// canonicalOverlayPath returns the absolute path of an overlay file with
+// symlinks resolved, or just the absolute path when it can't be resolved
+// (e.g. the file is missing)
+func canonicalOverlayPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	return abs
+}
+
+// overlaySettingCount counts the settings an overlay's config carries
+// beyond its path, to choose which of two duplicates to keep
+func overlaySettingCount(ov config.OverlayConfig) int {
+	n := 0
+	for _, set := range []bool{ov.Color != nil, ov.Name != nil, ov.Key != "", ov.Brightness != "", ov.RegionTagging} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// dedupeOverlays collapses overlays that name the same file, by canonical
+// path. Of each set the one carrying the most settings is kept, in the
+// place of the first; on a tie the first is kept. It returns the kept
+// overlays in order and the file names of those dropped.
+func dedupeOverlays(overlays []config.OverlayConfig) ([]config.OverlayConfig, []string) {
+	kept := make([]config.OverlayConfig, 0, len(overlays))
+	index := make(map[string]int, len(overlays))
+	var skipped []string
+	for _, ov := range overlays {
+		if ov.Path == "" {
+			kept = append(kept, ov)
+			continue
+		}
+		path := canonicalOverlayPath(ov.Path)
+		i, seen := index[path]
+		if !seen {
+			index[path] = len(kept)
+			kept = append(kept, ov)
+			continue
+		}
+		if overlaySettingCount(ov) > overlaySettingCount(kept[i]) {
+			skipped = append(skipped, filepath.Base(kept[i].Path))
+			kept[i] = ov
+		} else {
+			skipped = append(skipped, filepath.Base(ov.Path))
+		}
+	}
+	return kept, skipped
+}
+
+// notifySkippedOverlays tells the user which duplicate overlays were left out
+func (m *Model) notifySkippedOverlays(names []string) {
+	if len(names) > 0 {
+		m.notify(m.trf("notify.overlay_duplicate", strings.Join(names, ", ")))
+	}
+}
+
+// loadOverlayFile loads an overlay with a hash of the file's content, which
+// catches the same file reached by paths that don't resolve alike (e.g.
+// hard links or bind mounts). The hash is empty if the file can't be read
+// again.
+func loadOverlayFile(loader func(string) (*geo.GeoOverlay, error), path string) (*geo.GeoOverlay, string, error) {
+	overlay, err := loader(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return overlay, fileSum(path), nil
+}
+
+// fileSum returns the SHA-256 of a file's content, or "" if it can't be read
+func fileSum(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// keepLoadedOverlay decides whether a freshly loaded overlay is a copy of
+// one already on the radar, by content. When it is, the one whose config
+// carries fewer settings is dropped (the new one on a tie), taken out of
+// the settings and reported. Reports whether the new overlay should be
+// added.
+func (m *Model) keepLoadedOverlay(ov config.OverlayConfig, sum string) bool {
+	prev, ok := m.overlaySums[sum]
+	if sum == "" || !ok {
+		return true
+	}
+	if overlaySettingCount(ov) <= overlaySettingCount(prev.cfg) {
+		m.dropOverlayConfig(ov.Path)
+		m.notifySkippedOverlays([]string{filepath.Base(ov.Path)})
+		return false
+	}
+	m.overlayManager.RemoveOverlay(prev.key)
+	delete(m.overlaySums, sum)
+	m.dropOverlayConfig(prev.cfg.Path)
+	m.notifySkippedOverlays([]string{filepath.Base(prev.cfg.Path)})
+	return true
+}
+
+// dropOverlayConfig removes the overlay with the given path from the
+// settings
+func (m *Model) dropOverlayConfig(path string) {
+	overlays := m.config.Overlays.Overlays
+	for i, ov := range overlays {
+		if ov.Path == path {
+			m.config.Overlays.Overlays = append(overlays[:i:i], overlays[i+1:]...)
+			return
+		}
+	}
+}
+
+// forgetOverlaySum drops the content hash of an overlay removed from the
+// radar, so the same file can be added again
+func (m *Model) forgetOverlaySum(key string) {
+	for sum, loaded := range m.overlaySums {
+		if loaded.key == key {
+			delete(m.overlaySums, sum)
+		}
+	}
+}
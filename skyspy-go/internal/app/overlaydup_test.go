@@ -0,0 +1,138 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/geo"
+)
+
+// writeOverlayFile writes a one-point GeoJSON overlay into dir
+func writeOverlayFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	content := `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{},` +
+		`"geometry":{"type":"Point","coordinates":[4.9,52.3]}}]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newDupModel returns a model configured with overlays, isolated from the
+// user's settings
+func newDupModel(t *testing.T, overlays ...config.OverlayConfig) *Model {
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	cfg := newTestConfig()
+	cfg.Overlays.Overlays = overlays
+	return NewModel(cfg)
+}
+
+func TestOverlayDedupe_CLIAndConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := writeOverlayFile(t, dir, "airspace.geojson")
+	color := "#00FF00"
+
+	// The saved entry, then the same file again from --overlay by another
+	// spelling of its path
+	m := newDupModel(t,
+		config.OverlayConfig{Path: path, Enabled: true, Key: "airspace", Color: &color, Brightness: "dim"},
+		config.OverlayConfig{Path: dir + "/./sub/../airspace.geojson", Enabled: true},
+	)
+	if got := m.config.Overlays.Overlays; len(got) != 1 || got[0].Key != "airspace" {
+		t.Fatalf("the entry with settings should be kept alone: %+v", got)
+	}
+	if m.notification != "Skipped duplicate overlay airspace.geojson" {
+		t.Errorf("notification = %q", m.notification)
+	}
+
+	m.LoadOverlays()
+	overlays := m.overlayManager.GetOverlays()
+	if len(overlays) != 1 || overlays[0].Color != color || overlays[0].Brightness != geo.BrightnessDim {
+		t.Errorf("expected one overlay with the saved settings, got %d", len(overlays))
+	}
+}
+
+func TestOverlayDedupe_KeepsRicherEntry(t *testing.T) {
+	path := writeOverlayFile(t, t.TempDir(), "coast.geojson")
+	kept, skipped := dedupeOverlays([]config.OverlayConfig{
+		{Path: path, Enabled: true},
+		{Path: "/other.geojson", Enabled: true},
+		{Path: path, Enabled: true, Key: "coast", Brightness: "faint"},
+	})
+	if len(kept) != 2 || kept[0].Key != "coast" || kept[1].Path != "/other.geojson" {
+		t.Errorf("the richer entry should take the first one's place: %+v", kept)
+	}
+	if len(skipped) != 1 || skipped[0] != "coast.geojson" {
+		t.Errorf("skipped = %v", skipped)
+	}
+}
+
+func TestOverlayDedupe_Symlink(t *testing.T) {
+	dir := t.TempDir()
+	path := writeOverlayFile(t, dir, "airspace.geojson")
+	link := filepath.Join(dir, "current.geojson")
+	if err := os.Symlink(path, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	m := newDupModel(t,
+		config.OverlayConfig{Path: link, Enabled: true, Key: "current"},
+		config.OverlayConfig{Path: path, Enabled: true},
+	)
+	m.LoadOverlays()
+	if n := m.overlayManager.Count(); n != 1 {
+		t.Errorf("a symlink to a listed file should load once, got %d overlays", n)
+	}
+	if len(m.config.Overlays.Overlays) != 1 || m.config.Overlays.Overlays[0].Path != link {
+		t.Errorf("the settings should keep the entry with settings: %+v", m.config.Overlays.Overlays)
+	}
+}
+
+func TestOverlayDedupe_SameContent(t *testing.T) {
+	dir := t.TempDir()
+	path := writeOverlayFile(t, dir, "airspace.geojson")
+	// A hard link doesn't resolve to the same path, so only the content
+	// shows it is the same file
+	hard := filepath.Join(dir, "copy.geojson")
+	if err := os.Link(path, hard); err != nil {
+		t.Skipf("hard links unavailable: %v", err)
+	}
+
+	m := newDupModel(t,
+		config.OverlayConfig{Path: path, Enabled: true},
+		config.OverlayConfig{Path: hard, Enabled: true, Key: "copy", Brightness: "bright"},
+	)
+	m.LoadOverlays()
+	list := m.overlayManager.GetOverlayList()
+	if len(list) != 1 || list[0].Brightness != geo.BrightnessBright {
+		t.Fatalf("the copy with settings should replace the first, got %+v", list)
+	}
+	if !strings.Contains(m.notification, "Skipped duplicate overlay airspace.geojson") {
+		t.Errorf("notification = %q", m.notification)
+	}
+
+	m.saveOverlays()
+	if got := m.config.Overlays.Overlays; len(got) != 1 || got[0].Path != hard {
+		t.Errorf("the saved settings should list the file once: %+v", got)
+	}
+}
+
+func TestSaveOverlays_CollapsesDuplicates(t *testing.T) {
+	path := writeOverlayFile(t, t.TempDir(), "airspace.geojson")
+	m := newDupModel(t)
+	m.overlayManager.AddOverlay(&geo.GeoOverlay{Name: "a", Enabled: true, SourceFile: path}, "")
+	m.overlayManager.AddOverlay(&geo.GeoOverlay{Name: "b", Enabled: true, SourceFile: path}, "")
+
+	m.saveOverlays()
+	if n := len(m.config.Overlays.Overlays); n != 1 {
+		t.Errorf("saving should collapse the duplicate, got %d entries", n)
+	}
+}
@@ -0,0 +1,160 @@
+// Package app provides background overlay loading for the SkySpy radar
+package app
+
+import (
+	"context"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/geo"
+)
+
+// overlayLoad is a configured overlay that hasn't loaded yet, or failed to
+type overlayLoad struct {
+	cfg     config.OverlayConfig
+	started bool
+	err     error // why it failed; nil while loading
+}
+
+// name is what the overlays panel calls the overlay while it loads
+func (l *overlayLoad) name() string {
+	return filepath.Base(l.cfg.Path)
+}
+
+// overlayLoadedMsg carries the result of loading one overlay
+type overlayLoadedMsg struct {
+	load    *overlayLoad
+	overlay *geo.GeoOverlay
+	sum     string // hash of the file's content
+	err     error
+}
+
+// queueOverlays lists the configured overlays to load once the radar is up.
+// Large files can take seconds to parse, so nothing is read here. The same
+// file listed twice (say in the settings and with --overlay) is queued
+// once, and the settings lose the copy.
+func (m *Model) queueOverlays() {
+	m.overlayCtx, m.overlayCancel = context.WithCancel(context.Background())
+	if !m.caps.Overlays {
+		return
+	}
+	overlays, skipped := dedupeOverlays(m.config.Overlays.Overlays)
+	m.config.Overlays.Overlays = overlays
+	m.notifySkippedOverlays(skipped)
+	for _, ov := range overlays {
+		if ov.Path != "" {
+			m.overlayLoads = append(m.overlayLoads, &overlayLoad{cfg: ov})
+		}
+	}
+}
+
+// nextOverlayCmd starts loading the first overlay still waiting. Overlays
+// load one at a time in the order they are configured, so the first listed
+// is drawn first; nil when none are waiting or loading has been stopped.
+func (m *Model) nextOverlayCmd() tea.Cmd {
+	if m.overlayCtx == nil || m.overlayCtx.Err() != nil {
+		return nil
+	}
+	for _, load := range m.overlayLoads {
+		if load.started {
+			continue
+		}
+		load.started = true
+		ctx, loader, path := m.overlayCtx, m.overlayLoader, load.cfg.Path
+		return func() tea.Msg {
+			done := make(chan overlayLoadedMsg, 1)
+			go func() {
+				overlay, sum, err := loadOverlayFile(loader, path)
+				done <- overlayLoadedMsg{load: load, overlay: overlay, sum: sum, err: err}
+			}()
+			select {
+			case msg := <-done:
+				return msg
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// handleOverlayLoaded adds a loaded overlay to the radar, or records why it
+// failed, and starts on the next one
+func (m *Model) handleOverlayLoaded(msg overlayLoadedMsg) tea.Cmd {
+	if m.overlayCtx == nil || m.overlayCtx.Err() != nil {
+		return nil
+	}
+	m.addLoadedOverlay(msg.load, msg.overlay, msg.sum, msg.err)
+	return m.nextOverlayCmd()
+}
+
+// addLoadedOverlay applies an overlay's saved settings and adds it to the
+// radar, unless the same content is already there. A failed load stays
+// listed with its error until the session ends.
+func (m *Model) addLoadedOverlay(load *overlayLoad, overlay *geo.GeoOverlay, sum string, err error) {
+	if err != nil {
+		load.err = err
+		m.notify(m.trf("notify.overlay_failed", load.name()))
+		return
+	}
+	for i, l := range m.overlayLoads {
+		if l == load {
+			m.overlayLoads = append(m.overlayLoads[:i], m.overlayLoads[i+1:]...)
+			break
+		}
+	}
+
+	ov := load.cfg
+	if !m.keepLoadedOverlay(ov, sum) {
+		return
+	}
+	overlay.Enabled = ov.Enabled
+	if ov.Color != nil {
+		overlay.Color = *ov.Color
+	}
+	overlay.Brightness = overlayBrightness(m.config, ov)
+	overlay.RegionTagging = ov.RegionTagging
+	overlay.HideInactive = m.config.Overlays.InactiveFeatures == "hide"
+	key := m.overlayManager.AddOverlay(overlay, ov.Key)
+	if sum != "" {
+		m.overlaySums[sum] = loadedOverlay{key: key, cfg: ov}
+	}
+
+	m.overlayManager.UpdateActive(m.now().UTC())
+	m.rebuildRegions()
+	m.updateFieldElevation()
+}
+
+// LoadOverlays loads every configured overlay still waiting, in order,
+// before returning. Headless commands use it in place of the background
+// loading the radar does.
+func (m *Model) LoadOverlays() {
+	for _, load := range append([]*overlayLoad(nil), m.overlayLoads...) {
+		if load.err != nil {
+			continue
+		}
+		load.started = true
+		overlay, sum, err := loadOverlayFile(m.overlayLoader, load.cfg.Path)
+		m.addLoadedOverlay(load, overlay, sum, err)
+	}
+}
+
+// pendingOverlays returns the settings of overlays that haven't loaded
+// yet, so saving before they arrive doesn't lose them
+func (m *Model) pendingOverlays() []config.OverlayConfig {
+	var pending []config.OverlayConfig
+	for _, load := range m.overlayLoads {
+		if load.err == nil {
+			pending = append(pending, load.cfg)
+		}
+	}
+	return pending
+}
+
+// stopOverlayLoads abandons any overlay still loading
+func (m *Model) stopOverlayLoads() {
+	if m.overlayCancel != nil {
+		m.overlayCancel()
+	}
+}
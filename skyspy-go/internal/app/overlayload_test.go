@@ -0,0 +1,185 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/geo"
+)
+
+// fakeOverlay builds an overlay named after its path with n features
+func fakeOverlay(path string, n int) *geo.GeoOverlay {
+	overlay := &geo.GeoOverlay{Name: strings.TrimSuffix(path, ".geojson"), SourceFile: path}
+	for i := 0; i < n; i++ {
+		overlay.Features = append(overlay.Features, geo.GeoFeature{
+			Type:   geo.OverlayPoint,
+			Points: []geo.GeoPoint{{Lat: 52, Lon: 4}},
+		})
+	}
+	return overlay
+}
+
+func newOverlayLoadModel(t *testing.T, paths ...string) *Model {
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	cfg := newTestConfig()
+	for _, path := range paths {
+		cfg.Overlays.Overlays = append(cfg.Overlays.Overlays, config.OverlayConfig{Path: path, Key: path, Enabled: true})
+	}
+	return NewModel(cfg)
+}
+
+// runCmds runs a command, and the commands of any batch it returns, sending
+// each message to msgs
+func runCmds(cmd tea.Cmd, msgs chan<- tea.Msg) {
+	if cmd == nil {
+		return
+	}
+	go func() {
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, c := range batch {
+				runCmds(c, msgs)
+			}
+			return
+		}
+		msgs <- msg
+	}()
+}
+
+// waitOverlay waits for the next overlay load to finish
+func waitOverlay(t *testing.T, msgs <-chan tea.Msg) overlayLoadedMsg {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg := <-msgs:
+			if loaded, ok := msg.(overlayLoadedMsg); ok {
+				return loaded
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for an overlay to load")
+		}
+	}
+}
+
+func TestOverlayLoad_InteractiveWhileLoading(t *testing.T) {
+	m := newOverlayLoadModel(t, "airspace.geojson", "coast.geojson")
+	release := make(chan struct{})
+	var order []string
+	m.overlayLoader = func(path string) (*geo.GeoOverlay, error) {
+		if path == "airspace.geojson" {
+			<-release
+		}
+		order = append(order, path)
+		return fakeOverlay(path, 1200), nil
+	}
+
+	msgs := make(chan tea.Msg, 16)
+	runCmds(m.Init(), msgs)
+
+	// The slow overlay is still loading, and the radar answers keys
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if m.targetRange == m.maxRange {
+		t.Error("zoom should work while overlays load")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if m.viewMode != ViewOverlays {
+		t.Fatal("the overlays panel should open while overlays load")
+	}
+	if m.overlayManager.Count() != 0 {
+		t.Fatal("no overlay should have loaded yet")
+	}
+	panel := ansi.Strip(m.renderOverlayPanel())
+	if strings.Count(panel, "loading…") != 2 {
+		t.Errorf("both overlays should show as loading:\n%s", panel)
+	}
+
+	// The first listed loads first, then the next starts
+	close(release)
+	_, cmd := m.Update(waitOverlay(t, msgs))
+	if list := m.overlayManager.GetOverlayList(); len(list) != 1 || list[0].Key != "airspace.geojson" {
+		t.Fatalf("the first overlay should be on the radar, got %+v", list)
+	}
+	runCmds(cmd, msgs)
+	m.Update(waitOverlay(t, msgs))
+
+	if strings.Join(order, ",") != "airspace.geojson,coast.geojson" {
+		t.Errorf("overlays should load in config order, got %v", order)
+	}
+	panel = ansi.Strip(m.renderOverlayPanel())
+	if strings.Contains(panel, "loading…") || strings.Count(panel, "1200") != 2 {
+		t.Errorf("loaded overlays should show their feature counts:\n%s", panel)
+	}
+}
+
+func TestOverlayLoad_Failure(t *testing.T) {
+	m := newOverlayLoadModel(t, "missing.geojson", "coast.geojson")
+	m.overlayLoader = func(path string) (*geo.GeoOverlay, error) {
+		if path == "missing.geojson" {
+			return nil, errors.New("no such file")
+		}
+		return fakeOverlay(path, 3), nil
+	}
+	m.LoadOverlays()
+
+	if m.overlayManager.Count() != 1 {
+		t.Errorf("a failed overlay shouldn't stop the next, got %d loaded", m.overlayManager.Count())
+	}
+	panel := ansi.Strip(m.renderOverlayPanel())
+	if !strings.Contains(panel, "missing.geojs") || !strings.Contains(panel, "FAILED") || !strings.Contains(panel, "no such file") {
+		t.Errorf("the panel should show the failure and why:\n%s", panel)
+	}
+}
+
+func TestOverlayLoad_SaveKeepsPending(t *testing.T) {
+	m := newOverlayLoadModel(t, "airspace.geojson", "coast.geojson")
+	m.overlayLoader = func(path string) (*geo.GeoOverlay, error) {
+		return fakeOverlay(path, 1), nil
+	}
+	msgs := make(chan tea.Msg, 4)
+	runCmds(m.nextOverlayCmd(), msgs)
+	m.Update(waitOverlay(t, msgs))
+
+	// Saving while the second is still waiting keeps both, in order
+	m.saveOverlays()
+	got := m.config.Overlays.Overlays
+	if len(got) != 2 || got[0].Path != "airspace.geojson" || got[1].Path != "coast.geojson" {
+		t.Errorf("saving should keep overlays still loading, got %+v", got)
+	}
+}
+
+func TestOverlayLoad_QuitCancels(t *testing.T) {
+	m := newOverlayLoadModel(t, "airspace.geojson", "coast.geojson")
+	release := make(chan struct{})
+	defer close(release)
+	m.overlayLoader = func(path string) (*geo.GeoOverlay, error) {
+		<-release
+		return fakeOverlay(path, 1), nil
+	}
+
+	done := make(chan tea.Msg, 1)
+	cmd := m.nextOverlayCmd()
+	go func() { done <- cmd() }()
+
+	m.quit()
+	select {
+	case msg := <-done:
+		if msg != nil {
+			t.Errorf("a cancelled load should send nothing, got %T", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("quitting should abandon the load in progress")
+	}
+	if cmd := m.nextOverlayCmd(); cmd != nil {
+		t.Error("no more overlays should start loading after quitting")
+	}
+}
@@ -0,0 +1,30 @@
+// Package app provides feed decoding error counts for the SkySpy radar
+package app
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// noteParseError counts an aircraft message that couldn't be decoded at
+// all. Missing hex addresses and empty payloads are expected from some
+// servers and aren't counted.
+func (m *Model) noteParseError(msg codec.Message, err error) {
+	if !errors.Is(err, codec.ErrMalformed) {
+		return
+	}
+	m.parseErrors++
+	m.debugf("unreadable %s message: %v", msg.Type, err)
+}
+
+// noteBadFields counts the fields of an update that couldn't be read; the
+// codec has left them unset and the rest of the update is used
+func (m *Model) noteBadFields(ac *codec.Aircraft) {
+	if len(ac.BadFields) == 0 {
+		return
+	}
+	m.parseErrors += len(ac.BadFields)
+	m.debugf("unreadable fields from %s: %s", ac.Hex, strings.Join(ac.BadFields, ", "))
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// rawAircraftMessage builds a feed message from raw JSON data
+func rawAircraftMessage(msgType codec.MessageType, data string) codec.Message {
+	return codec.Message{Type: string(msgType), Data: []byte(data)}
+}
+
+func TestParseErrors_BadFieldKeepsPosition(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.handleAircraftMsg(rawAircraftMessage(codec.AircraftNew,
+		`{"hex":"484b1c","lat":52.40,"lon":4.90,"alt_baro":"35000","gs":"fast"}`))
+
+	target := m.aircraft["484B1C"]
+	if target == nil || !target.HasLat || !target.HasLon {
+		t.Fatal("an unreadable speed shouldn't lose the update")
+	}
+	if !target.HasAlt || target.Altitude != 35000 || target.HasSpeed {
+		t.Errorf("alt %d (%v), speed set %v", target.Altitude, target.HasAlt, target.HasSpeed)
+	}
+	if got := m.GetStats().ParseErrors; got != 1 {
+		t.Errorf("ParseErrors = %d, want 1", got)
+	}
+
+	m.handleAircraftMsg(rawAircraftMessage(codec.AircraftUpdate, `{"hex":"484b1c","lat":"north"}`))
+	if got := m.GetStats().ParseErrors; got != 2 {
+		t.Errorf("an undecodable message should be counted, ParseErrors = %d", got)
+	}
+	m.handleAircraftMsg(rawAircraftMessage(codec.AircraftUpdate, `{"flight":"NOHEX"}`))
+	if got := m.GetStats().ParseErrors; got != 2 {
+		t.Errorf("a missing hex isn't a parse error, ParseErrors = %d", got)
+	}
+
+	m.width, m.height = 160, 60
+	if panel := ansi.Strip(m.renderSidebar()); !strings.Contains(panel, "ERR") {
+		t.Errorf("the stats should show the count:\n%s", panel)
+	}
+}
+
+func TestParseErrors_GroundStringHidden(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.handleAircraftMsg(rawAircraftMessage(codec.AircraftSnapshot,
+		`[{"hex":"484b1c","lat":52.31,"lon":4.76,"alt_baro":"ground","gs":"12"},{"hex":"4ca123","lat":52.40,"lon":4.90,"alt":"12000"}]`))
+
+	taxiing := m.aircraft["484B1C"]
+	if taxiing == nil || !taxiing.Ground || taxiing.Speed != 12 {
+		t.Fatalf("ground in a snapshot should mark the target on the ground: %+v", taxiing)
+	}
+	if m.parseErrors != 0 {
+		t.Errorf("ground and quoted numbers aren't errors, got %d", m.parseErrors)
+	}
+
+	m.config.Filters.HideGround = true
+	m.width, m.height = 160, 60
+	m.renderRadar()
+	if len(m.sortedTargets) != 1 || m.sortedTargets[0] != "4CA123" {
+		t.Errorf("hide ground should hide only the target reported on the ground: %v", m.sortedTargets)
+	}
+}
@@ -0,0 +1,176 @@
+// Package app provides aircraft pinning for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+const (
+	// maxPinned is the most aircraft that can be pinned at once
+	maxPinned = 4
+	// pinBlockHeight is the number of lines in a full pinned data block
+	pinBlockHeight = 5
+	// pinBlockWidth is the rendered width of a pinned data block
+	pinBlockWidth = 22
+)
+
+// GetPinned returns the pinned aircraft in pin order
+func (m *Model) GetPinned() []string {
+	return m.pinned
+}
+
+// isPinned reports whether hex is pinned
+func (m *Model) isPinned(hex string) bool {
+	for _, h := range m.pinned {
+		if h == hex {
+			return true
+		}
+	}
+	return false
+}
+
+// togglePin pins or unpins the selected aircraft
+func (m *Model) togglePin() {
+	target, ok := m.aircraft[m.selectedHex]
+	if !ok || m.selectedHex == "" {
+		m.notify(m.tr("notify.no_target"))
+		return
+	}
+
+	if m.unpin(m.selectedHex) {
+		m.notify(m.trf("notify.unpinned", pinLabel(target)))
+		return
+	}
+	if len(m.pinned) >= maxPinned {
+		m.notify(m.trf("notify.pin_limit", maxPinned))
+		return
+	}
+	m.pinned = append(m.pinned, m.selectedHex)
+	m.notify(m.trf("notify.pinned", pinLabel(target)))
+}
+
+// clearPins removes every pin
+func (m *Model) clearPins() {
+	if len(m.pinned) == 0 {
+		return
+	}
+	m.pinned = nil
+	m.notify(m.tr("notify.pins_cleared"))
+}
+
+// unpin removes hex from the pins and reports whether it was pinned
+func (m *Model) unpin(hex string) bool {
+	for i, h := range m.pinned {
+		if h == hex {
+			m.pinned = append(m.pinned[:i], m.pinned[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// pinLabel returns the display name for a pinned target
+func pinLabel(t *radar.Target) string {
+	if t.Callsign != "" {
+		return t.Callsign
+	}
+	return strings.ToUpper(t.Hex)
+}
+
+// vsTrend returns an arrow for the target's vertical trend
+func vsTrend(g *theme.GlyphSet, t *radar.Target) string {
+	switch {
+	case !t.HasVS:
+		return " "
+	case t.Vertical > 100:
+		return g.TrendUp
+	case t.Vertical < -100:
+		return g.TrendDown
+	default:
+		return g.TrendFlat
+	}
+}
+
+// renderPinnedPanel renders the pinned data blocks within maxLines. When the
+// full blocks don't fit it falls back to one line per pin, and beyond that
+// summarises the overflow.
+func (m *Model) renderPinnedPanel(maxLines int) []string {
+	if len(m.pinned) == 0 || maxLines <= 0 {
+		return nil
+	}
+	if len(m.pinned)*pinBlockHeight <= maxLines {
+		lines := make([]string, 0, len(m.pinned)*pinBlockHeight)
+		for i, hex := range m.pinned {
+			lines = append(lines, m.renderPinBlock(i+1, m.aircraft[hex])...)
+		}
+		return lines
+	}
+	return m.renderPinCompact(maxLines)
+}
+
+func (m *Model) renderPinBlock(n int, t *radar.Target) []string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.Selected).Bold(true)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright)
+	g := m.glyphs()
+
+	inner := pinBlockWidth - 2
+	title := fmt.Sprintf(" %d %s ", n, truncate(pinLabel(t), inner-5))
+
+	row := func(label, value string, style lipgloss.Style) string {
+		return borderStyle.Render(g.V) + textDim.Render(fmt.Sprintf(" %-4s", label)) +
+			style.Render(fmt.Sprintf("%-*s", inner-5, value)) + borderStyle.Render(g.V)
+	}
+
+	alt := fmt.Sprintf("%-8s", m.formatAlt(t))
+	altRow := borderStyle.Render(g.V) + textDim.Render(" ALT ") + primaryBright.Render(alt) +
+		m.getVSStyle(t).Render(vsTrend(g, t)) + strings.Repeat(" ", inner-5-len(alt)-1) + borderStyle.Render(g.V)
+
+	return []string{
+		borderStyle.Render(g.TL+g.H) + titleStyle.Render(title) +
+			borderStyle.Render(strings.Repeat(g.H, inner-1-lipgloss.Width(title))+g.TR),
+		altRow,
+		row("GS", m.formatSpeed(t), primaryBright),
+		row("DST", m.formatDistance(t), secondaryBright),
+		borderStyle.Render(g.BL + strings.Repeat(g.H, inner) + g.BR),
+	}
+}
+
+func (m *Model) renderPinCompact(maxLines int) []string {
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.Selected)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	g := m.glyphs()
+
+	shown := len(m.pinned)
+	if shown > maxLines {
+		shown = maxLines - 1
+	}
+
+	lines := make([]string, 0, maxLines)
+	for _, hex := range m.pinned[:shown] {
+		t := m.aircraft[hex]
+		text := fmt.Sprintf("%s %-7s %-8s", g.Pin, truncate(pinLabel(t), 7), m.formatAlt(t))
+		lines = append(lines, titleStyle.Render(text)+m.getVSStyle(t).Render(vsTrend(g, t))+
+			strings.Repeat(" ", pinBlockWidth-lipgloss.Width(text)-1))
+	}
+	if hidden := len(m.pinned) - shown; hidden > 0 {
+		lines = append(lines, textDim.Render(fmt.Sprintf("%-*s", pinBlockWidth, fmt.Sprintf("  +%d pinned", hidden))))
+	}
+	return lines
+}
+
+// truncate shortens s to at most n runes
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -0,0 +1,66 @@
+// Package app provides the altitude plausibility filter for the SkySpy radar
+package app
+
+import (
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// What happens to updates with implausible altitudes
+const (
+	implausibleReject = "reject" // dropped and counted
+	implausibleFlag   = "flag"   // kept, and marked in the detail panel
+	implausibleOff    = "off"    // not checked
+)
+
+// applyAltitudeBounds installs the plausible altitude bounds. Bounds that
+// don't make sense fall back to the defaults with a notification.
+func (m *Model) applyAltitudeBounds() {
+	floor, ceiling, err := m.config.Filters.AltitudeBounds()
+	m.altFloor, m.altCeiling = floor, ceiling
+	if err != nil {
+		m.notify(m.trf("notify.altitude_bounds_error", err.Error()))
+	}
+}
+
+// implausibleAction returns the configured handling of implausible
+// altitudes; anything unrecognized rejects them
+func (m *Model) implausibleAction() string {
+	action := strings.ToLower(strings.TrimSpace(m.config.Filters.ImplausibleAltitude))
+	switch action {
+	case implausibleFlag, implausibleOff:
+		return action
+	}
+	return implausibleReject
+}
+
+// altitudePlausible reports whether a target's altitude is inside the
+// bounds. Military aircraft are only held to the floor.
+func (m *Model) altitudePlausible(t *radar.Target) bool {
+	if !t.HasAlt {
+		return true
+	}
+	if t.Altitude < m.altFloor {
+		return false
+	}
+	return t.Military || t.Altitude <= m.altCeiling
+}
+
+// checkAltitude applies the plausibility filter to an update and reports
+// whether to keep it. Every implausible update is written to the debug log.
+func (m *Model) checkAltitude(t *radar.Target) bool {
+	action := m.implausibleAction()
+	if action == implausibleOff || m.altitudePlausible(t) {
+		return true
+	}
+
+	m.debugf("implausible altitude %s: hex=%s callsign=%q alt=%d baro=%d geom=%d floor=%d ceiling=%d military=%v",
+		action, t.Hex, t.Callsign, t.Altitude, t.BaroAltitude, t.GeomAltitude, m.altFloor, m.altCeiling, t.Military)
+	if action == implausibleFlag {
+		t.AltImplausible = true
+		return true
+	}
+	m.rejectedAltitudes++
+	return false
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+func TestPlausibleAltitude_Bounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		altitude int
+		military bool
+		kept     bool
+	}{
+		{"at the floor", -1500, false, true},
+		{"below the floor", -1501, false, false},
+		{"sea level", 0, false, true},
+		{"at the ceiling", 60000, false, true},
+		{"above the ceiling", 60001, false, false},
+		{"military above the ceiling", 85000, true, true},
+		{"military below the floor", -1501, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel(newTestConfig())
+			m.updateTarget(&codec.Aircraft{Hex: "ALT001", AltBaro: intPtr(tt.altitude), Military: tt.military}, true)
+			if _, kept := m.aircraft["ALT001"]; kept != tt.kept {
+				t.Errorf("kept = %v, want %v", kept, tt.kept)
+			}
+			if rejected := m.rejectedAltitudes == 1; rejected == tt.kept {
+				t.Errorf("rejected count = %d", m.rejectedAltitudes)
+			}
+		})
+	}
+}
+
+func TestPlausibleAltitude_RejectKeepsLastGood(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.DebugLog = filepath.Join(t.TempDir(), "debug.log")
+	m := NewModel(cfg)
+
+	m.updateTarget(&codec.Aircraft{Hex: "MLAT01", Flight: "KLM123", AltBaro: intPtr(12000)}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "MLAT01", Flight: "KLM123", AltBaro: intPtr(-3000), AltGeom: intPtr(-2900)}, false)
+	if m.aircraft["MLAT01"].Altitude != 12000 {
+		t.Errorf("a rejected update should leave the last good one, got %d", m.aircraft["MLAT01"].Altitude)
+	}
+
+	m.width, m.height = 160, 50
+	if stats := ansi.Strip(m.renderStatsPanel()); !strings.Contains(stats, "REJ") {
+		t.Errorf("the stats panel should count rejected updates:\n%s", stats)
+	}
+
+	m.closeDebugLog()
+	data, err := os.ReadFile(cfg.DebugLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"implausible altitude reject", "hex=MLAT01", `callsign="KLM123"`, "alt=-3000", "geom=-2900", "floor=-1500"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("debug log missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestPlausibleAltitude_Flag(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Filters.ImplausibleAltitude = "Flag"
+	m := NewModel(cfg)
+
+	m.updateTarget(&codec.Aircraft{Hex: "HIGH01", AltBaro: intPtr(72000)}, true)
+	target, ok := m.aircraft["HIGH01"]
+	if !ok || !target.AltImplausible || m.rejectedAltitudes != 0 {
+		t.Fatal("a flagged update should be kept and marked, not counted as rejected")
+	}
+	m.selectedHex = "HIGH01"
+	if detail := ansi.Strip(m.renderTargetPanel()); !strings.Contains(detail, "ALT?") {
+		t.Errorf("the detail panel should mark the altitude:\n%s", detail)
+	}
+
+	m.updateTarget(&codec.Aircraft{Hex: "HIGH01", AltBaro: intPtr(41000)}, false)
+	if m.aircraft["HIGH01"].AltImplausible {
+		t.Error("the mark should clear once the altitude is plausible")
+	}
+}
+
+func TestPlausibleAltitude_OffAndBadBounds(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Filters.ImplausibleAltitude = "off"
+	m := NewModel(cfg)
+	m.updateTarget(&codec.Aircraft{Hex: "LOW001", AltBaro: intPtr(-5000)}, true)
+	if _, ok := m.aircraft["LOW001"]; !ok {
+		t.Error("with the filter off every altitude should be kept")
+	}
+
+	cfg = newTestConfig()
+	cfg.Filters.AltitudeFloor, cfg.Filters.AltitudeCeiling = 10000, 5000
+	m = NewModel(cfg)
+	if m.altFloor != -1500 || m.altCeiling != 60000 {
+		t.Errorf("bad bounds should fall back to the defaults, got %d..%d", m.altFloor, m.altCeiling)
+	}
+	if !strings.Contains(m.notification, "altitude_floor") {
+		t.Errorf("bad bounds should be reported, got %q", m.notification)
+	}
+}
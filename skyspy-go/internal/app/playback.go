@@ -0,0 +1,64 @@
+// Package app provides replay controls for the SkySpy radar
+package app
+
+import "time"
+
+// replaySeekStep is how far Left and Right move a replay
+const replaySeekStep = 30 * time.Second
+
+// playback is implemented by feeds that play recorded traffic back, such
+// as a replay of exports. The radar runs on their clock, so trails, aging
+// and alerts go as they did when recorded.
+type playback interface {
+	Now() time.Time
+	Paused() bool
+	SetPaused(paused bool)
+	Seek(d time.Duration) time.Time
+}
+
+// replaying returns the feed's playback controls when it is a replay
+func (m *Model) replaying() (playback, bool) {
+	pb, ok := m.feed.(playback)
+	return pb, ok
+}
+
+// togglePlayback pauses or resumes a replay
+func (m *Model) togglePlayback() {
+	pb, ok := m.replaying()
+	if !ok {
+		return
+	}
+	pb.SetPaused(!pb.Paused())
+	if pb.Paused() {
+		m.notify(m.trf("notify.replay_paused", m.locale.Clock(m.now().Local())))
+	} else {
+		m.notify(m.tr("notify.replay_resumed"))
+	}
+}
+
+// seekPlayback moves a replay by d. Going back clears the trails, which
+// would otherwise run on into positions not yet replayed.
+func (m *Model) seekPlayback(d time.Duration) {
+	pb, ok := m.replaying()
+	if !ok {
+		return
+	}
+	at := pb.Seek(d)
+	if d < 0 {
+		m.trailTracker.Clear()
+	}
+	m.notify(m.trf("notify.replay_seek", m.locale.Clock(at.Local())))
+}
+
+// feedLabel is the header's word for the feed: live, or a replay playing
+// or paused
+func (m *Model) feedLabel() string {
+	pb, ok := m.replaying()
+	switch {
+	case !ok:
+		return "LIVE"
+	case pb.Paused():
+		return "PAUSED"
+	}
+	return "REPLAY"
+}
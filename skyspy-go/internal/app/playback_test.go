@@ -0,0 +1,111 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// playbackFeed is a fake feed with replay controls
+type playbackFeed struct {
+	*fakeFeed
+	at     time.Time
+	paused bool
+	seeks  []time.Duration
+}
+
+func (f *playbackFeed) Now() time.Time        { return f.at }
+func (f *playbackFeed) Paused() bool          { return f.paused }
+func (f *playbackFeed) SetPaused(paused bool) { f.paused = paused }
+func (f *playbackFeed) Seek(d time.Duration) time.Time {
+	f.seeks = append(f.seeks, d)
+	f.at = f.at.Add(d)
+	return f.at
+}
+
+func newPlaybackModel() (*Model, *playbackFeed) {
+	feed := &playbackFeed{fakeFeed: newFakeFeed(), at: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	return NewModelWithFeed(newTestConfig(), feed), feed
+}
+
+func TestPlayback_RunsOnTheReplayClock(t *testing.T) {
+	m, feed := newPlaybackModel()
+	if !m.now().Equal(feed.at) {
+		t.Fatalf("model time %v, want the replay's %v", m.now(), feed.at)
+	}
+	m.updateTarget(flying("a00001", 52.40), false)
+	feed.at = feed.at.Add(10 * time.Second)
+	m.updateTarget(flying("a00001", 52.41), false)
+	if n := m.trailTracker.TrailLength("A00001"); n != 2 {
+		t.Errorf("trail has %d points, want 2", n)
+	}
+	if got := m.lastSeen["A00001"]; !got.Equal(feed.at) {
+		t.Errorf("last seen %v, want the replay time", got)
+	}
+}
+
+func TestPlayback_Keys(t *testing.T) {
+	m, feed := newPlaybackModel()
+
+	pressKey(m, " ")
+	if !feed.paused {
+		t.Error("space should pause the replay")
+	}
+	if m.feedLabel() != "PAUSED" {
+		t.Errorf("label = %q, want PAUSED", m.feedLabel())
+	}
+	pressKey(m, " ")
+	if feed.paused || m.feedLabel() != "REPLAY" {
+		t.Errorf("space should resume the replay, label %q", m.feedLabel())
+	}
+
+	m.updateTarget(flying("a00001", 52.40), false)
+	pressKey(m, "right")
+	if m.trailTracker.Count() == 0 {
+		t.Error("seeking forward should keep trails")
+	}
+	pressKey(m, "left")
+	if m.trailTracker.Count() != 0 {
+		t.Error("seeking back should clear trails")
+	}
+	if len(feed.seeks) != 2 || feed.seeks[0] != replaySeekStep || feed.seeks[1] != -replaySeekStep {
+		t.Errorf("seeks = %v", feed.seeks)
+	}
+}
+
+func TestPlayback_LiveFeedIgnoresKeys(t *testing.T) {
+	m := NewModelWithFeed(newTestConfig(), newFakeFeed())
+	pressKey(m, " ")
+	pressKey(m, "left")
+	if m.feedLabel() != "LIVE" {
+		t.Errorf("label = %q, want LIVE", m.feedLabel())
+	}
+	if m.notification != "" {
+		t.Errorf("a live feed should not notify on replay keys, got %q", m.notification)
+	}
+}
+
+func TestPlayback_Header(t *testing.T) {
+	m, feed := newPlaybackModel()
+	m.width, m.height = 100, 55
+	live := NewModelWithFeed(newTestConfig(), newFakeFeed())
+	live.width, live.height = 100, 55
+
+	header := strings.Split(m.renderHeader(), "\n")[1]
+	if !strings.Contains(ansi.Strip(header), "REPLAY") {
+		t.Errorf("header should say REPLAY: %q", header)
+	}
+	if w, want := lipgloss.Width(header), lipgloss.Width(strings.Split(live.renderHeader(), "\n")[1]); w != want {
+		t.Errorf("replay header is %d wide, live %d", w, want)
+	}
+	feed.paused = true
+	if header := ansi.Strip(m.renderHeader()); !strings.Contains(header, "PAUSED") {
+		t.Errorf("header should say PAUSED: %q", header)
+	}
+	if !strings.Contains(ansi.Strip(m.renderHelpPanel()), "REPLAY") || strings.Contains(ansi.Strip(live.renderHelpPanel()), "REPLAY") {
+		t.Error("replay keys should be in the help only when replaying")
+	}
+}
@@ -0,0 +1,148 @@
+// Package app provides point-of-interest approach times for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/geo"
+)
+
+// defaultPOICorridor is the approach corridor used when none is configured
+const defaultPOICorridor = 2.0
+
+// activePOI returns the configured point of interest named as active
+func (m *Model) activePOI() (config.POIConfig, bool) {
+	active := strings.TrimSpace(m.config.POI.Active)
+	if active == "" {
+		return config.POIConfig{}, false
+	}
+	for _, p := range m.config.POI.Points {
+		if strings.EqualFold(p.Label, active) {
+			return p, true
+		}
+	}
+	return config.POIConfig{}, false
+}
+
+func (m *Model) poiCorridor() float64 {
+	if m.config.POI.CorridorNM > 0 {
+		return m.config.POI.CorridorNM
+	}
+	return defaultPOICorridor
+}
+
+// GetPOIApproach returns a target's predicted closest approach to the
+// active point of interest. It reports false unless the target is closing
+// and its track passes within the configured corridor.
+func (m *Model) GetPOIApproach(hex string) (geo.CPA, bool) {
+	poi, ok := m.activePOI()
+	if !ok {
+		return geo.CPA{}, false
+	}
+	target, ok := m.aircraft[hex]
+	if !ok {
+		return geo.CPA{}, false
+	}
+	cpa, ok := targetCPA(target, poi.Lat, poi.Lon)
+	if !ok {
+		return geo.CPA{}, false
+	}
+	cpa = m.ageCPA(hex, cpa)
+	if !cpa.Closing || cpa.Distance > m.poiCorridor() {
+		return geo.CPA{}, false
+	}
+	return cpa, true
+}
+
+// formatPOI writes the approach to the active point of interest for the
+// target panel
+func (m *Model) formatPOI(hex string) string {
+	cpa, ok := m.GetPOIApproach(hex)
+	if !ok {
+		return dashPlaceholder
+	}
+	d, unit := m.inUnit(cpa.Distance)
+	return fmt.Sprintf("%.1f%s in %s", d, unit, formatETA(cpa))
+}
+
+// formatETA writes the time to closest approach as m:ss
+func formatETA(cpa geo.CPA) string {
+	secs := int(cpa.Time.Seconds())
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
+
+// cyclePOI makes the next configured point of interest active, passing
+// through none after the last
+func (m *Model) cyclePOI() {
+	points := m.config.POI.Points
+	if len(points) == 0 {
+		m.notify(m.tr("notify.no_poi"))
+		return
+	}
+
+	next := 0
+	if current, ok := m.activePOI(); ok {
+		for i, p := range points {
+			if p.Label == current.Label {
+				next = i + 1
+				break
+			}
+		}
+	}
+	if next >= len(points) {
+		m.config.POI.Active = ""
+		m.notify(m.tr("notify.poi_off"))
+		return
+	}
+	m.config.POI.Active = points[next].Label
+	m.notify(m.trf("notify.poi", points[next].Label))
+}
+
+// togglePOISort switches the target list between distance order and
+// soonest arrival at the active point of interest
+func (m *Model) togglePOISort() {
+	m.sortByPOI = !m.sortByPOI
+	if m.sortByPOI {
+		m.notify(m.tr("notify.sort_eta"))
+	} else {
+		m.notify(m.tr("notify.sort_distance"))
+	}
+}
+
+// sortTargetsByPOI moves targets approaching the active point of interest
+// to the front of hexes, soonest first. The rest keep their order.
+func (m *Model) sortTargetsByPOI(hexes []string) {
+	if _, ok := m.activePOI(); !ok {
+		return
+	}
+	etas := make(map[string]geo.CPA, len(hexes))
+	for _, hex := range hexes {
+		if cpa, ok := m.GetPOIApproach(hex); ok {
+			etas[hex] = cpa
+		}
+	}
+	sort.SliceStable(hexes, func(i, j int) bool {
+		a, aok := etas[hexes[i]]
+		b, bok := etas[hexes[j]]
+		if aok != bok {
+			return aok
+		}
+		return aok && a.Time < b.Time
+	})
+}
+
+// poiOverlay builds the synthetic overlay marking the configured points of
+// interest, or nil when there is nothing to draw
+func poiOverlay(cfg *config.Config) *geo.GeoOverlay {
+	if !cfg.POI.ShowMarkers || len(cfg.POI.Points) == 0 {
+		return nil
+	}
+	points := make([]geo.GeoPoint, 0, len(cfg.POI.Points))
+	for _, p := range cfg.POI.Points {
+		points = append(points, geo.GeoPoint{Lat: p.Lat, Lon: p.Lon, Label: p.Label})
+	}
+	return geo.CreatePOIOverlay(points)
+}
@@ -0,0 +1,73 @@
+// Package app provides the position plausibility filter for the SkySpy radar
+package app
+
+import (
+	"math"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// Position filter tuning
+const (
+	// Moves shorter than this (nm) are position noise however soon they
+	// come, as MLAT and ADS-B fixes of one aircraft can
+	positionNoiseNM = 1
+
+	// After this many implausible positions in a row the latest is kept:
+	// either the last kept one was the bad one, or the aircraft really has
+	// moved, e.g. after a long gap in coverage
+	positionRelocateFixes = 3
+)
+
+// positionFix is a reported position
+type positionFix struct {
+	lat, lon float64
+}
+
+// checkPosition drops a target's reported position if reaching it from
+// the previous one would take more than the configured speed, keeping the
+// previous position, distance and bearing in its place. It returns the
+// dropped position, or nil when the reported one is kept.
+func (m *Model) checkPosition(target, prev *radar.Target) *positionFix {
+	if prev != nil {
+		target.PositionAt = prev.PositionAt
+		target.RejectedPositions = prev.RejectedPositions
+		target.RejectedRun = prev.RejectedRun
+	}
+	if !target.HasLat || !target.HasLon {
+		return nil
+	}
+
+	now := m.now()
+	limit := m.config.Filters.MaxPositionSpeed
+	if limit <= 0 || prev == nil || !prev.HasLat || !prev.HasLon || prev.PositionAt.IsZero() {
+		target.PositionAt, target.RejectedRun = now, 0
+		return nil
+	}
+	dist, _ := radar.HaversineBearing(prev.Lat, prev.Lon, target.Lat, target.Lon)
+	// Positions in the same second are timed as a second apart
+	hours := math.Max(now.Sub(prev.PositionAt).Hours(), 1.0/3600)
+	speed := dist / hours
+	if dist < positionNoiseNM || speed <= limit || target.RejectedRun+1 >= positionRelocateFixes {
+		target.PositionAt, target.RejectedRun = now, 0
+		return nil
+	}
+
+	m.debugf("implausible position dropped: hex=%s callsign=%q pos=%.5f,%.5f last=%.5f,%.5f dist=%.1fnm speed=%.0fkt limit=%.0fkt",
+		target.Hex, target.Callsign, target.Lat, target.Lon, prev.Lat, prev.Lon, dist, speed, limit)
+	dropped := &positionFix{lat: target.Lat, lon: target.Lon}
+	target.Lat, target.Lon = prev.Lat, prev.Lon
+	target.Distance, target.Bearing = prev.Distance, prev.Bearing
+	target.RejectedPositions++
+	target.RejectedRun++
+	return dropped
+}
+
+// formatRejectedPositions writes how many of a target's positions were
+// dropped, e.g. "3 dropped", or "" when none were
+func (m *Model) formatRejectedPositions(target *radar.Target) string {
+	if target.RejectedPositions == 0 {
+		return ""
+	}
+	return m.trf("detail.rejected_positions", m.locale.Int(target.RejectedPositions))
+}
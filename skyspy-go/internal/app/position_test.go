@@ -0,0 +1,103 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestPosition_ImpossibleJumpDropped(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Proximity = true
+	m := NewModel(cfg)
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+
+	m.updateTarget(flying("BAD001", 52.60), true) // 14nm north
+	clock = clock.Add(10 * time.Second)
+
+	// 14nm in 10s; the rest of the update still applies
+	bogus := flying("BAD001", 52.37)
+	bogus.Squawk = "1234"
+	m.updateTarget(bogus, false)
+
+	target := m.aircraft["BAD001"]
+	if target.Lat != 52.60 || target.Distance < 13.5 || target.RejectedPositions != 1 {
+		t.Fatalf("the jump should be dropped: lat=%v dist=%.1f rejected=%d", target.Lat, target.Distance, target.RejectedPositions)
+	}
+	if target.Squawk != "1234" {
+		t.Errorf("the other fields should be kept, squawk %q", target.Squawk)
+	}
+	if n := m.trailTracker.TrailLength("BAD001"); n != 1 {
+		t.Errorf("the dropped position reached the trail: %d points", n)
+	}
+	if len(m.alertState.RecentAlerts) != 0 {
+		t.Errorf("the dropped position reached the alerts: %v", m.alertState.RecentAlerts)
+	}
+	if !target.Conflicted {
+		t.Error("a dropped jump should still count towards a shared address")
+	}
+
+	m.selectedHex = "BAD001"
+	if panel := ansi.Strip(m.renderTargetPanel()); !strings.Contains(panel, "REJ  1 dropped") {
+		t.Errorf("the target panel should show the dropped position:\n%s", panel)
+	}
+
+	// A plausible move afterwards is kept, and the count stays
+	clock = clock.Add(10 * time.Second)
+	m.updateTarget(flying("BAD001", 52.62), false)
+	if target := m.aircraft["BAD001"]; target.Lat != 52.62 || target.RejectedPositions != 1 {
+		t.Errorf("lat=%v rejected=%d, want 52.62 with 1 dropped", target.Lat, target.RejectedPositions)
+	}
+}
+
+func TestPosition_KeptAfterRunOfJumps(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.updateTarget(flying("MOV001", 52.0), true)
+
+	// Coverage resumes far away: after two drops the third is taken
+	for i, lat := range []float64{53.0, 53.01, 53.02} {
+		*clock = clock.Add(5 * time.Second)
+		m.updateTarget(flying("MOV001", lat), false)
+		target := m.aircraft["MOV001"]
+		if kept := target.Lat == lat; kept != (i == 2) {
+			t.Fatalf("report %d at %v: lat %v", i, lat, target.Lat)
+		}
+	}
+	if target := m.aircraft["MOV001"]; target.RejectedPositions != 2 || target.RejectedRun != 0 {
+		t.Errorf("rejected=%d run=%d, want 2 and 0", target.RejectedPositions, target.RejectedRun)
+	}
+}
+
+func TestPosition_ThresholdConfigurable(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.updateTarget(flying("FST001", 52.0), true)
+
+	// 6nm in 20s is 1080kt: fine at the default, too fast at 900
+	*clock = clock.Add(20 * time.Second)
+	m.updateTarget(flying("FST001", 52.1), false)
+	if m.aircraft["FST001"].RejectedPositions != 0 {
+		t.Fatal("1080kt should pass the default limit")
+	}
+
+	m.config.Filters.MaxPositionSpeed = 900
+	*clock = clock.Add(20 * time.Second)
+	m.updateTarget(flying("FST001", 52.2), false)
+	if m.aircraft["FST001"].RejectedPositions != 1 {
+		t.Error("1080kt should be dropped at 900kt")
+	}
+
+	// Small moves are noise however soon they come
+	m.updateTarget(flying("FST001", 52.11), false)
+	if m.aircraft["FST001"].RejectedPositions != 1 {
+		t.Error("a 0.6nm move should be kept")
+	}
+
+	m.config.Filters.MaxPositionSpeed = 0
+	m.updateTarget(flying("FST001", 54.0), false)
+	if m.aircraft["FST001"].Lat != 54.0 {
+		t.Error("0 should turn the check off")
+	}
+}
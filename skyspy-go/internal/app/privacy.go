@@ -0,0 +1,62 @@
+// Package app provides receiver privacy mode for the SkySpy radar
+package app
+
+import (
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// IsPrivacyMode reports whether displayed positions are approximate
+func (m *Model) IsPrivacyMode() bool {
+	return m.config.Display.PrivacyMode
+}
+
+// togglePrivacy switches receiver privacy mode on or off
+func (m *Model) togglePrivacy() {
+	m.config.Display.PrivacyMode = !m.config.Display.PrivacyMode
+	if m.config.Display.PrivacyMode {
+		m.notify(m.tr("notify.privacy_on"))
+	} else {
+		m.notify(m.tr("notify.privacy_off"))
+	}
+}
+
+// displayReceiver returns the receiver position used for anything the user
+// can see or export. In privacy mode it is snapped to a ~10km grid; alerts
+// and audio keep measuring from the true position.
+func (m *Model) displayReceiver() (float64, float64) {
+	lat, lon, ok := m.receiverPosition()
+	if !m.IsPrivacyMode() || !ok {
+		return lat, lon
+	}
+	return geo.ApproximatePosition(lat, lon)
+}
+
+// displayTarget returns t as it should be shown: in privacy mode, a copy
+// with distance and bearing measured from the approximate receiver position
+// so they can't be combined with the aircraft's position to locate us
+func (m *Model) displayTarget(t *radar.Target) *radar.Target {
+	if !m.IsPrivacyMode() || !t.HasLat || !t.HasLon {
+		return t
+	}
+	lat, lon := m.displayReceiver()
+	if lat == 0 && lon == 0 {
+		return t
+	}
+
+	shown := *t
+	shown.Distance, shown.Bearing = radar.HaversineBearing(lat, lon, t.Lat, t.Lon)
+	return &shown
+}
+
+// displayAircraft returns the aircraft map as it should be drawn or exported
+func (m *Model) displayAircraft() map[string]*radar.Target {
+	if !m.IsPrivacyMode() {
+		return m.aircraft
+	}
+	shown := make(map[string]*radar.Target, len(m.aircraft))
+	for hex, t := range m.aircraft {
+		shown[hex] = m.displayTarget(t)
+	}
+	return shown
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func proximityIndex(m *Model) int {
+	for i, rule := range m.GetAlertRules() {
+		if rule.ID == alerts.ProximityRuleID {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestProximity_AlertsOnEntry(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Proximity = true
+	cfg.Alerts.ProximityRadiusNM = 5
+	cfg.Filters.MaxPositionSpeed = 0 // the reports jump faster than any aircraft
+	m := NewModel(cfg)
+
+	// Receiver at 52.3676: 0.15 deg north is 9nm, 0.07 is 4.2nm
+	m.updateTarget(flying("4CA7B5", 52.5176), true)
+	if len(m.alertState.RecentAlerts) != 0 {
+		t.Fatalf("outside the radius should not alert: %v", m.alertState.RecentAlerts)
+	}
+	m.updateTarget(flying("4CA7B5", 52.4376), false)
+	recent := m.alertState.RecentAlerts
+	if len(recent) != 1 || recent[0].Rule.ID != alerts.ProximityRuleID {
+		t.Fatalf("entering the radius should alert once: %v", recent)
+	}
+	if recent[0].Distance < 4 || recent[0].Distance > 4.5 {
+		t.Errorf("distance at trigger = %.2f", recent[0].Distance)
+	}
+
+	m.updateTarget(flying("4CA7B5", 52.4076), false)
+	if len(m.alertState.RecentAlerts) != 1 {
+		t.Error("staying inside should not alert again")
+	}
+
+	m.viewMode = ViewAlertRules
+	panel := ansi.Strip(m.renderAlertRulesPanel())
+	if !strings.Contains(panel, "Proximity 5nm") || !strings.Contains(panel, "4.2nm") {
+		t.Errorf("the panel should show the radius and the alert's distance:\n%s", panel)
+	}
+}
+
+func TestProximity_RadiusFromView(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	m := NewModel(newTestConfig())
+	m.viewMode = ViewAlertRules
+	index := proximityIndex(m)
+	if index < 0 {
+		t.Fatal("the proximity rule should be built in")
+	}
+	if m.GetAlertRules()[index].Enabled {
+		t.Error("the proximity rule should start off")
+	}
+
+	m.alertRuleCursor = index
+	pressKeys(m, "+", "+", "-", keyEnter)
+	if m.notification == "" {
+		t.Error("changes should be reported")
+	}
+	m.alertState.SaveToConfig(m.config)
+	saved := m.config.Alerts
+	if !saved.Proximity || saved.ProximityRadiusNM != 6 {
+		t.Errorf("saved proximity %v at %v nm", saved.Proximity, saved.ProximityRadiusNM)
+	}
+
+	// Deleting and editing are refused; reloading keeps its place
+	pressKey(m, "d")
+	if proximityIndex(m) != index {
+		t.Error("the proximity rule can't be deleted")
+	}
+	reloaded := NewModel(m.config)
+	if proximityIndex(reloaded) != index || len(reloaded.GetAlertRules()) != len(m.GetAlertRules()) {
+		t.Errorf("reloaded at %d, want %d", proximityIndex(reloaded), index)
+	}
+	if radius, _ := reloaded.GetAlertRules()[index].ProximityRadius(); radius != 6 {
+		t.Errorf("reloaded radius = %v", radius)
+	}
+
+	// The bell is kept through a reload, along with the settings' state
+	pressKey(m, "b")
+	reloaded = NewModel(m.config)
+	rule := reloaded.GetAlertRules()[index]
+	if !rule.HasAction(alerts.ActionBell) || !rule.Enabled {
+		t.Errorf("reloaded rule lost its bell or state: %+v", rule)
+	}
+
+	// Rules without a radius have nothing to change
+	m.alertRuleCursor = 0
+	pressKey(m, "+")
+	if !strings.Contains(m.notification, "has no radius") {
+		t.Errorf("notification = %q", m.notification)
+	}
+}
+
+func TestProximity_MessageInDisplayUnit(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Alerts.Proximity = true
+	cfg.Alerts.ProximityRadiusNM = 5
+	cfg.Filters.MaxPositionSpeed = 0
+	m := NewModel(cfg)
+	m.cycleDistUnits() // nm to km
+
+	m.updateTarget(flying("4CA7B5", 52.5176), true)
+	m.updateTarget(flying("4CA7B5", 52.4376), false)
+	recent := m.alertState.RecentAlerts
+	if len(recent) != 1 {
+		t.Fatalf("entering the radius should alert once: %v", recent)
+	}
+	if !strings.HasSuffix(recent[0].Message, "km") {
+		t.Errorf("message should give the distance in km: %q", recent[0].Message)
+	}
+}
@@ -0,0 +1,111 @@
+// Package app provides quick-look data blocks for the SkySpy radar
+package app
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+const (
+	// quickLookHover is how long the pointer rests on a target before its
+	// data block shows
+	quickLookHover = 300 * time.Millisecond
+
+	// quickLookDuration is how long Ctrl+Q shows the blocks around the
+	// selected target
+	quickLookDuration = 5 * time.Second
+
+	// quickLookCells is how far from the selected target, in scope cells,
+	// Ctrl+Q shows blocks
+	quickLookCells = 5
+)
+
+// hitTarget prefixes the clickable region of a target symbol on the radar;
+// the target's hex follows
+const hitTarget = "target:"
+
+// hoverMsg arrives once the pointer may have rested on a target long
+// enough for its data block
+type hoverMsg struct{}
+
+// hover tracks the pointer over the radar. Resting on a target starts the
+// wait for its data block; moving off it hides the block. The selection
+// is left alone.
+func (m *Model) hover(x, y int) tea.Cmd {
+	id, _ := m.hits.At(x, y)
+	hex, ok := strings.CutPrefix(id, hitTarget)
+	if !ok {
+		m.hoverHex = ""
+		return nil
+	}
+	if hex == m.hoverHex {
+		return nil
+	}
+	m.hoverHex, m.hoverSince = hex, m.now()
+	return tea.Tick(quickLookHover, func(time.Time) tea.Msg {
+		return hoverMsg{}
+	})
+}
+
+// hovered returns the target whose data block the pointer has rested on
+// long enough to show, if any
+func (m *Model) hovered() string {
+	if m.hoverHex == "" || m.now().Sub(m.hoverSince) < quickLookHover {
+		return ""
+	}
+	if _, ok := m.aircraft[m.hoverHex]; !ok {
+		return ""
+	}
+	return m.hoverHex
+}
+
+// quickLookActive reports whether Ctrl+Q's blocks are showing
+func (m *Model) quickLookActive() bool {
+	return m.now().Before(m.quickLookUntil)
+}
+
+// toggleQuickLook shows data blocks for the targets around the selected
+// one for a few seconds, or hides them early
+func (m *Model) toggleQuickLook() {
+	if m.quickLookActive() {
+		m.quickLookUntil = time.Time{}
+		return
+	}
+	if m.selectedHex == "" {
+		m.notify(m.tr("notify.quick_look_no_target"))
+		return
+	}
+	m.quickLookUntil = m.now().Add(quickLookDuration)
+}
+
+// drawQuickLook draws the data blocks showing on the main scope, the
+// hovered target's first, and makes its targets answer to the pointer
+func (m *Model) drawQuickLook(scope *radar.Scope, targets map[string]*radar.Target) {
+	var hexes []string
+	hovered := m.hovered()
+	if hovered != "" {
+		hexes = append(hexes, hovered)
+	}
+	if m.quickLookActive() {
+		for _, hex := range scope.Nearby(m.selectedHex, quickLookCells) {
+			if hex != hovered {
+				hexes = append(hexes, hex)
+			}
+		}
+	}
+	var blocks []radar.DataBlock
+	for _, hex := range hexes {
+		if t, ok := targets[hex]; ok {
+			blocks = append(blocks, radar.TargetBlock(t))
+		}
+	}
+	scope.DrawDataBlocks(blocks)
+
+	// Scope cells sit inside its border
+	for _, p := range scope.Symbols() {
+		m.hits.Add(hitTarget+p.Hex, p.X+1, p.Y+1, 1, 1)
+	}
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// targetCell finds the screen cell of a target's symbol in the last render
+func targetCell(t *testing.T, m *Model, hex string) (x, y int) {
+	t.Helper()
+	for _, r := range m.hits.Regions() {
+		if r.ID == hitTarget+hex {
+			return r.X, r.Y
+		}
+	}
+	t.Fatalf("%s not found on the radar", hex)
+	return 0, 0
+}
+
+func hoverAt(m *Model, x, y int) tea.Cmd {
+	_, cmd := m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionMotion})
+	return cmd
+}
+
+// scopeText renders the view and returns the radar scope's rows
+func scopeText(m *Model) string {
+	var rows []string
+	for _, line := range strings.Split(ansi.Strip(m.View()), "\n") {
+		if strings.HasPrefix(line, "║") {
+			rows = append(rows, ansi.Truncate(line, radar.RadarWidth+2, ""))
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// blocksShown counts the data blocks on the radar by their speed line
+func blocksShown(m *Model) int {
+	return strings.Count(scopeText(m), "450kt")
+}
+
+func quickLookModel(t *testing.T) (*Model, *time.Time) {
+	t.Helper()
+	m, clock := newTrackingModel()
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	m.updateTarget(flying("SEL001", 52.00), true)
+	m.updateTarget(flying("NEAR01", 52.03), true)
+	m.updateTarget(flying("FAR001", 53.00), true)
+	m.View()
+	return m, clock
+}
+
+func TestQuickLook_HoverTiming(t *testing.T) {
+	m, clock := quickLookModel(t)
+	x, y := targetCell(t, m, "FAR001")
+
+	if cmd := hoverAt(m, x, y); cmd == nil {
+		t.Fatal("resting on a target should start the hover timer")
+	}
+	if blocksShown(m) != 0 {
+		t.Error("the block shouldn't show before the pointer has rested")
+	}
+	*clock = clock.Add(quickLookHover - time.Millisecond)
+	if blocksShown(m) != 0 {
+		t.Error("the block shouldn't show before 300ms")
+	}
+
+	// Small moves over the same target don't restart the wait
+	if cmd := hoverAt(m, x, y); cmd != nil {
+		t.Error("staying on the same target shouldn't restart the timer")
+	}
+	*clock = clock.Add(time.Millisecond)
+	if blocksShown(m) != 1 || !strings.Contains(scopeText(m), "FAR001") {
+		t.Errorf("the block should show after 300ms:\n%s", scopeText(m))
+	}
+	if m.selectedHex != "" {
+		t.Errorf("hovering shouldn't select, got %q", m.selectedHex)
+	}
+
+	// Moving away hides it
+	hoverAt(m, 0, 0)
+	if blocksShown(m) != 0 {
+		t.Error("the block should go when the pointer moves away")
+	}
+
+	// Another target starts the wait over
+	x, y = targetCell(t, m, "SEL001")
+	hoverAt(m, x, y)
+	if blocksShown(m) != 0 {
+		t.Error("a new target should wait its own 300ms")
+	}
+	*clock = clock.Add(quickLookHover)
+	if blocksShown(m) != 1 {
+		t.Error("the new target's block should show after 300ms")
+	}
+
+	// A target that has gone shows nothing
+	delete(m.aircraft, "SEL001")
+	if m.hovered() != "" {
+		t.Error("a target that has gone shouldn't be hovered")
+	}
+}
+
+func TestQuickLook_KeyAroundSelection(t *testing.T) {
+	m, clock := quickLookModel(t)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlQ})
+	if m.quickLookActive() || m.notification != "Select a target for a quick look" {
+		t.Errorf("quick look needs a selection, notification %q", m.notification)
+	}
+
+	m.selectedHex = "SEL001"
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlQ})
+	view := scopeText(m)
+	if blocksShown(m) != 2 || !strings.Contains(view, "NEAR01") || strings.Contains(view, "FAR001") {
+		t.Errorf("blocks should show for the selected target and those within 5 cells:\n%s", view)
+	}
+
+	*clock = clock.Add(quickLookDuration)
+	if blocksShown(m) != 0 {
+		t.Error("quick look should end after 5 seconds")
+	}
+
+	// Pressed again while showing, it ends early
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlQ})
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlQ})
+	if m.quickLookActive() || blocksShown(m) != 0 {
+		t.Error("a second Ctrl+Q should hide the blocks")
+	}
+}
@@ -0,0 +1,57 @@
+// Package app provides do-not-disturb quiet hours for the SkySpy radar
+package app
+
+import "github.com/skyspy/skyspy-go/internal/audio"
+
+// dndMode is the manual do-not-disturb override
+type dndMode int
+
+const (
+	dndSchedule dndMode = iota // follow the quiet hours schedule
+	dndOn                      // silence alerts regardless of schedule
+	dndOff                     // sound alerts regardless of schedule
+)
+
+// applyQuietHours installs the configured quiet hours and hooks them into
+// the alert player. Invalid ranges are skipped with a notification.
+func (m *Model) applyQuietHours() {
+	quiet, err := audio.ParseQuietHours(m.config.Audio.QuietHours)
+	m.quietHours = quiet
+	if err != nil {
+		m.notify(m.trf("notify.quiet_hours_error", err.Error()))
+	}
+	if m.alertPlayer != nil {
+		m.alertPlayer.SetQuiet(m.doNotDisturb)
+	}
+}
+
+// doNotDisturb reports whether audio alerts are silenced right now. Visual
+// alerts are unaffected.
+func (m *Model) doNotDisturb() bool {
+	switch m.dnd {
+	case dndOn:
+		return true
+	case dndOff:
+		return false
+	}
+	return m.quietHours.Len() > 0 && m.quietHours.Active(m.now())
+}
+
+// cycleDoNotDisturb steps the override: schedule, forced on, forced off
+func (m *Model) cycleDoNotDisturb() {
+	switch m.dnd {
+	case dndSchedule:
+		m.dnd = dndOn
+		m.notify(m.tr("notify.dnd_on"))
+	case dndOn:
+		m.dnd = dndOff
+		m.notify(m.tr("notify.dnd_off"))
+	default:
+		m.dnd = dndSchedule
+		if m.doNotDisturb() {
+			m.notify(m.tr("notify.dnd_schedule_quiet"))
+		} else {
+			m.notify(m.tr("notify.dnd_schedule"))
+		}
+	}
+}
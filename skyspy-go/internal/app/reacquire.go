@@ -0,0 +1,80 @@
+// Package app provides reacquisition of a selected target that briefly
+// drops out, such as in terrain shadow, for the SkySpy radar
+package app
+
+import (
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// lostSelection is a selected target that has dropped out. Its last known
+// state is drawn as a ghost until it returns or the grace period ends.
+type lostSelection struct {
+	target radar.Target
+	until  time.Time
+}
+
+// selectionGrace is how long a lost selection waits to be reacquired; zero
+// turns reacquisition off
+func (m *Model) selectionGrace() time.Duration {
+	if m.config.Display.SelectionGrace <= 0 {
+		return 0
+	}
+	return time.Duration(m.config.Display.SelectionGrace) * time.Second
+}
+
+// rememberSelection keeps the selected target that was just removed so it
+// can be reselected if it reappears within the grace period
+func (m *Model) rememberSelection(t *radar.Target) {
+	grace := m.selectionGrace()
+	if grace == 0 {
+		m.lostSelection = nil
+		return
+	}
+	m.lostSelection = &lostSelection{target: *t, until: m.now().Add(grace)}
+}
+
+// activeLostSelection returns the lost selection while it can still be
+// reacquired. Selecting another target gives it up.
+func (m *Model) activeLostSelection() *lostSelection {
+	lost := m.lostSelection
+	if lost == nil || m.selectedHex != "" || !m.now().Before(lost.until) {
+		return nil
+	}
+	return lost
+}
+
+// reacquireSelection reselects a returning target that was selected when it
+// dropped out
+func (m *Model) reacquireSelection(t *radar.Target) {
+	lost := m.activeLostSelection()
+	if lost == nil || lost.target.Hex != t.Hex {
+		return
+	}
+	m.lostSelection = nil
+	m.selectedHex = t.Hex
+	m.notify(m.trf("notify.reacquired", pinLabel(t)))
+}
+
+// expireLostSelection forgets a lost selection once it can no longer be
+// reacquired
+func (m *Model) expireLostSelection() {
+	if m.lostSelection != nil && m.activeLostSelection() == nil {
+		m.lostSelection = nil
+	}
+}
+
+// selectionGhost returns the lost selection's last known state measured from
+// a scope centered on lat/lon, or nil when there is nothing to draw
+func (m *Model) selectionGhost(lat, lon float64) *radar.Target {
+	lost := m.activeLostSelection()
+	if lost == nil || !lost.target.HasLat || !lost.target.HasLon {
+		return nil
+	}
+	ghost := lost.target
+	if lat != 0 || lon != 0 {
+		ghost.Distance, ghost.Bearing = radar.HaversineBearing(lat, lon, ghost.Lat, ghost.Lon)
+	}
+	return &ghost
+}
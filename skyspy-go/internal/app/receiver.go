@@ -0,0 +1,53 @@
+// Package app provides the receiver position for the SkySpy radar
+package app
+
+import (
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/units"
+)
+
+// receiverMismatchNM is how far apart (~1km) the configured and server
+// receiver positions may be before the user is warned
+const receiverMismatchNM = 0.54
+
+// SetServerPosition gives the receiver position the server reports. It is
+// only used when the settings don't give one, so a position set locally,
+// perhaps deliberately vague, always wins; the user is warned when the two
+// are more than ~1km apart. The server's position is never saved.
+func (m *Model) SetServerPosition(lat, lon float64) {
+	m.serverLat, m.serverLon, m.hasServerPos = lat, lon, true
+
+	conn := m.config.Connection
+	if conn.HasReceiver() {
+		if nm, _ := radar.HaversineBearing(conn.ReceiverLat, conn.ReceiverLon, lat, lon); nm > receiverMismatchNM {
+			m.notify(m.trf("notify.receiver_mismatch", units.Km(nm)))
+		}
+		return
+	}
+
+	// Alerts and the field elevation were set up without a position
+	if m.alertState != nil {
+		m.alertState.ReceiverLat, m.alertState.ReceiverLon = lat, lon
+	}
+	m.updateFieldElevation()
+}
+
+// receiverPosition returns the receiver position distances and bearings are
+// measured from: the configured one, else the server's. ok is false when
+// neither is known.
+func (m *Model) receiverPosition() (lat, lon float64, ok bool) {
+	conn := m.config.Connection
+	switch {
+	case conn.HasReceiver():
+		return conn.ReceiverLat, conn.ReceiverLon, true
+	case m.hasServerPos:
+		return m.serverLat, m.serverLon, true
+	}
+	return 0, 0, false
+}
+
+// positionFromServer reports whether the receiver position in use is the
+// server's
+func (m *Model) positionFromServer() bool {
+	return m.hasServerPos && !m.config.Connection.HasReceiver()
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+func TestReceiverPosition_FromServer(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon = 0, 0
+	m := NewModel(cfg)
+	if _, _, ok := m.receiverPosition(); ok {
+		t.Fatal("no position should be known before the server reports one")
+	}
+
+	m.SetServerPosition(52.3086, 4.7639)
+	lat, lon, ok := m.receiverPosition()
+	if !ok || lat != 52.3086 || lon != 4.7639 || !m.positionFromServer() {
+		t.Fatalf("the server's position should be used, got %v, %v, %v", lat, lon, ok)
+	}
+	if m.alertState.ReceiverLat != 52.3086 {
+		t.Error("alerts should measure from the server's position")
+	}
+
+	m.updateTarget(&codec.Aircraft{Hex: "DST001", Lat: floatPtr(52.5), Lon: floatPtr(4.7639)}, true)
+	want, _ := radar.HaversineBearing(52.3086, 4.7639, 52.5, 4.7639)
+	if got := m.aircraft["DST001"].Distance; math.Abs(got-want) > 0.01 {
+		t.Errorf("distance = %.2f, want %.2f from the server's position", got, want)
+	}
+
+	m.width, m.height = 160, 50
+	if stats := ansi.Strip(m.renderStatsPanel()); !strings.Contains(stats, "POS  from server") {
+		t.Errorf("the stats panel should say where the position came from:\n%s", stats)
+	}
+	if m.config.Connection.HasReceiver() {
+		t.Error("the server's position must not end up in the settings")
+	}
+}
+
+func TestReceiverPosition_ConfigWins(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		warned   bool
+	}{
+		{"same place", 52.3676, 4.9041, false},
+		{"a few hundred meters off", 52.3706, 4.9041, false},
+		{"several km off", 52.4176, 4.9041, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel(newTestConfig())
+			m.notification = ""
+			m.SetServerPosition(tt.lat, tt.lon)
+
+			lat, lon, ok := m.receiverPosition()
+			if !ok || lat != 52.3676 || lon != 4.9041 || m.positionFromServer() {
+				t.Errorf("the configured position should win, got %v, %v", lat, lon)
+			}
+			if warned := strings.Contains(m.notification, "from the server's"); warned != tt.warned {
+				t.Errorf("warned = %v, want %v (%q)", warned, tt.warned, m.notification)
+			}
+			if m.alertState.ReceiverLat != 52.3676 {
+				t.Error("alerts should keep the configured position")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Package app provides the receivers seeing each aircraft, for the SkySpy radar
+package app
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// receiverSeenFor is how long after its last report of an aircraft a
+// receiver still counts as seeing it
+const receiverSeenFor = 30 * time.Second
+
+// mergeReceiver records that the receiver the message being handled came
+// from sees target, and lists the receivers seeing it on the target. If
+// this receiver can't position the aircraft but another could, the
+// newest position from prev is kept. Nothing happens for a single
+// server's feed.
+func (m *Model) mergeReceiver(target, prev *radar.Target) {
+	if m.source == "" {
+		return
+	}
+	seen := m.receiverSeen[target.Hex]
+	if seen == nil {
+		seen = make(map[string]time.Time)
+		m.receiverSeen[target.Hex] = seen
+	}
+	seen[m.source] = m.now()
+	target.Receivers = m.seeing(seen)
+
+	if len(target.Receivers) > 1 && prev != nil && prev.HasLat && prev.HasLon && (!target.HasLat || !target.HasLon) {
+		target.Lat, target.Lon = prev.Lat, prev.Lon
+		target.HasLat, target.HasLon = true, true
+		target.Distance, target.Bearing = prev.Distance, prev.Bearing
+		target.PositionAt = prev.PositionAt
+	}
+}
+
+// releaseReceiver drops the receiver the message being handled came from
+// from those seeing hex, and reports whether the aircraft should go
+// because no receiver sees it any more. For a single server's feed it
+// always should.
+func (m *Model) releaseReceiver(hex string) bool {
+	if m.source == "" {
+		return true
+	}
+	seen := m.receiverSeen[hex]
+	delete(seen, m.source)
+	left := m.seeing(seen)
+	if len(left) == 0 {
+		return true
+	}
+	if target := m.aircraft[hex]; target != nil {
+		target.Receivers = left
+	}
+	return false
+}
+
+// seenByReceiver reports whether the receiver the message being handled
+// came from sees hex; for a single server's feed every aircraft is
+func (m *Model) seenByReceiver(hex string) bool {
+	if m.source == "" {
+		return true
+	}
+	_, ok := m.receiverSeen[hex][m.source]
+	return ok
+}
+
+// seeing forgets receivers that haven't reported lately and returns the
+// names of the rest, sorted
+func (m *Model) seeing(seen map[string]time.Time) []string {
+	now := m.now()
+	names := make([]string, 0, len(seen))
+	for name, at := range seen {
+		if now.Sub(at) > receiverSeenFor {
+			delete(seen, name)
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatReceivers writes how many receivers see a target and which, e.g.
+// "2: HOME, CLUB", or "" for a single server's feed
+func (m *Model) formatReceivers(target *radar.Target) string {
+	if len(target.Receivers) == 0 {
+		return ""
+	}
+	return m.locale.Int(len(target.Receivers)) + ": " + strings.Join(target.Receivers, ", ")
+}
+
+// feedSources returns the state of each receiver's feed, or false unless
+// the feed merges several
+func (m *Model) feedSources() ([]SourceStatus, bool) {
+	r, ok := m.feed.(sourceReporter)
+	if !ok {
+		return nil, false
+	}
+	sources := r.Sources()
+	return sources, len(sources) > 1
+}
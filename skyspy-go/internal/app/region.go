@@ -0,0 +1,57 @@
+// Package app provides overlay region tagging for the SkySpy radar
+package app
+
+import (
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// rebuildRegions re-indexes the polygons of region-tagging overlays and
+// re-tags every tracked aircraft against them
+func (m *Model) rebuildRegions() {
+	m.regions = geo.NewRegionIndex(m.overlayManager.GetRegionOverlays())
+	for _, target := range m.aircraft {
+		target.Region, target.RegionRestricted = "", false
+		m.locateRegion(target, nil)
+	}
+}
+
+// locateRegion tags a target with the region it is inside. Without a
+// position fix the previous region is kept rather than flickering off.
+func (m *Model) locateRegion(target, prev *radar.Target) {
+	if m.regions == nil || m.regions.Len() == 0 {
+		return
+	}
+	if target.HasLat && target.HasLon {
+		target.Region = m.regions.Locate(target.Hex, target.Lat, target.Lon)
+		target.RegionRestricted = target.Region != "" && m.regions.Timed(target.Hex)
+	} else if prev != nil {
+		target.Region = prev.Region
+		target.RegionRestricted = prev.RegionRestricted
+	}
+}
+
+// updateOverlayWindows switches timed overlay features, such as TFRs, on
+// and off as their windows open and close. Regions are re-indexed on a
+// change so aircraft are only tagged with restrictions in force.
+func (m *Model) updateOverlayWindows() {
+	if m.overlayManager.UpdateActive(m.now().UTC()) {
+		m.rebuildRegions()
+	}
+}
+
+// toggleRegionTagging turns region tagging on or off for an overlay
+func (m *Model) toggleRegionTagging(key string) {
+	if m.overlayManager.ToggleRegionTagging(key) {
+		m.notify(m.tr("notify.region_on"))
+	} else {
+		m.notify(m.tr("notify.region_off"))
+	}
+	m.rebuildRegions()
+	m.saveOverlays()
+}
+
+// hasRegions reports whether any region-tagging polygons are loaded
+func (m *Model) hasRegions() bool {
+	return m.regions != nil && m.regions.Len() > 0
+}
@@ -0,0 +1,33 @@
+// Package app provides reconnect resynchronization for the SkySpy radar
+package app
+
+// beginResync runs when the feed reconnects. The server may have restarted,
+// so every tracked target is held as unconfirmed until the new session
+// reports it, and per-connection counters start again. Session totals such
+// as the peak aircraft count are kept. With merged feeds only the targets
+// the reconnected receiver sees are held.
+func (m *Model) beginResync() {
+	m.resyncPending = make(map[string]bool, len(m.aircraft))
+	for hex := range m.aircraft {
+		if m.seenByReceiver(hex) {
+			m.resyncPending[hex] = true
+		}
+	}
+	m.connMessages = 0
+}
+
+// finishResync ends a resync once the first snapshot after a reconnect has
+// been applied. stale is the number of carried-over targets it dropped.
+func (m *Model) finishResync(stale int) {
+	if m.resyncPending == nil {
+		return
+	}
+	m.resyncPending = nil
+	m.notify(m.trf("notify.resynced", stale))
+}
+
+// countMessage counts an aircraft message for the session and connection
+func (m *Model) countMessage() {
+	m.sessionMessages++
+	m.connMessages++
+}
@@ -0,0 +1,85 @@
+// Package app provides the altitude ribbon for the SkySpy radar
+package app
+
+import (
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/ui"
+)
+
+// ribbonMinSpan keeps an open-ended altitude band from collapsing the scale
+const ribbonMinSpan = 10000
+
+// toggleAltitudeRibbon shows or hides the altitude ribbon
+func (m *Model) toggleAltitudeRibbon() {
+	m.config.Display.ShowAltitudeRibbon = !m.config.Display.ShowAltitudeRibbon
+	if m.config.Display.ShowAltitudeRibbon {
+		m.notify(m.tr("notify.ribbon_on"))
+	} else {
+		m.notify(m.tr("notify.ribbon_off"))
+	}
+}
+
+// ribbonBand returns the altitude range the ribbon covers: the active
+// filter's altitude band if it has one, otherwise the ground to 45,000ft
+func (m *Model) ribbonBand() (floor, ceiling int) {
+	floor, ceiling = 0, ui.DefaultRibbonCeiling
+	if !m.IsFilterActive() {
+		return floor, ceiling
+	}
+	if m.searchFilter.MinAltitude > 0 {
+		floor = m.searchFilter.MinAltitude
+	}
+	if m.searchFilter.MaxAltitude > 0 {
+		ceiling = m.searchFilter.MaxAltitude
+	}
+	if ceiling <= floor {
+		ceiling = floor + ribbonMinSpan
+	}
+	return floor, ceiling
+}
+
+// renderAltitudeRibbon draws the targets on the scope, as listed in
+// sortedTargets, on the altitude ribbon. Targets without an altitude or
+// outside the band, and ground targets in surface mode, are left off.
+func (m *Model) renderAltitudeRibbon() []string {
+	ribbon := ui.NewAltitudeRibbon(m.theme, radar.RadarHeight)
+	ribbon.Floor, ribbon.Ceiling = m.ribbonBand()
+
+	// Surface mode keeps ground targets off the airborne scale
+	surface := m.surfaceActive(m.maxRange)
+	marks := make([]ui.RibbonMark, 0, len(m.sortedTargets))
+	for _, hex := range m.sortedTargets {
+		t, ok := m.aircraft[hex]
+		if !ok || !t.HasAlt || t.Altitude < ribbon.Floor || t.Altitude > ribbon.Ceiling {
+			continue
+		}
+		if surface && m.onGround(t) {
+			continue
+		}
+		marks = append(marks, ui.RibbonMark{
+			Altitude:  t.Altitude,
+			Selected:  hex == m.selectedHex,
+			Military:  t.Military,
+			Emergency: t.IsEmergency(),
+		})
+	}
+	return ribbon.Render(marks, m.blink)
+}
+
+// attachAltitudeRibbon adds the ribbon to the right of the rendered scope,
+// padding the border rows so the sidebar stays aligned
+func (m *Model) attachAltitudeRibbon(scope string) string {
+	lines := strings.Split(scope, "\n")
+	ribbon := m.renderAltitudeRibbon()
+	pad := strings.Repeat(" ", ui.RibbonWidth)
+	for i := range lines {
+		if row := i - 1; row >= 0 && row < len(ribbon) {
+			lines[i] += ribbon[row]
+		} else {
+			lines[i] += pad
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,344 @@
+// Package app provides the alert rule editor for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+)
+
+// Rule editor fields, in the order they're filled in
+const (
+	editName = iota
+	editCondition
+	editValue
+	editPriority
+	editCooldown
+	editAction
+	editFields
+)
+
+// editorCondition is a condition type the editor offers, with the value a
+// new condition of the type starts with
+type editorCondition struct {
+	Type    alerts.ConditionType
+	Label   string
+	Default string
+}
+
+// editorConditions are the conditions a rule can be given in the editor
+var editorConditions = []editorCondition{
+	{alerts.ConditionSquawk, "Squawk", "7700"},
+	{alerts.ConditionAltitudeBelow, "Altitude below (ft)", "1000"},
+	{alerts.ConditionMilitary, "Military", "true"},
+	{alerts.ConditionEnteringGeofence, "Enters geofence", "*"},
+	{alerts.ConditionWatched, "On watch list", "true"},
+	{alerts.ConditionCallsign, "Callsign pattern", ""},
+}
+
+// Actions offered by the editor
+const (
+	editNotify = iota
+	editSound
+	editNotifySound
+)
+
+var editorActions = []string{"Notify", "Sound", "Notify + sound"}
+
+// ruleEditor is a rule being written or edited in the alert rules view
+type ruleEditor struct {
+	id        string // rule being edited; empty for a new one
+	field     int
+	name      string
+	condition int                // index into editorConditions; -1 keeps kept
+	kept      []alerts.Condition // conditions the editor can't show, left as they are
+	value     string
+	priority  string
+	cooldown  string
+	action    int
+}
+
+// Limits on what's typed into the editor's text fields
+var editorFieldChars = map[int]int{
+	editName:     32,
+	editValue:    ruleEntryChars,
+	editPriority: 3,
+	editCooldown: 6,
+}
+
+// openRuleEditor starts a rule from scratch, or edits rule when it isn't
+// nil. The default rules can only be turned on and off.
+func (m *Model) openRuleEditor(rule *alerts.AlertRule) {
+	if m.alertState == nil || m.alertState.Engine == nil {
+		return
+	}
+	if rule == nil {
+		m.ruleEditor = &ruleEditor{
+			value:    editorConditions[0].Default,
+			priority: "50",
+			cooldown: "300",
+		}
+		return
+	}
+	if alerts.IsDefaultRule(rule.ID) {
+		m.notify(m.trf("notify.rule_builtin", rule.Name))
+		return
+	}
+
+	e := &ruleEditor{
+		id:        rule.ID,
+		name:      rule.Name,
+		condition: -1,
+		kept:      rule.Conditions,
+		priority:  strconv.Itoa(rule.Priority),
+		cooldown:  strconv.Itoa(int(rule.Cooldown.Seconds())),
+	}
+	// A single condition the editor offers can be changed; anything else
+	// is kept as it is
+	if len(rule.Conditions) == 1 && !rule.Conditions[0].Regex {
+		for i, c := range editorConditions {
+			if c.Type == rule.Conditions[0].Type {
+				e.condition, e.kept, e.value = i, nil, rule.Conditions[0].Value
+			}
+		}
+	}
+	switch notify, sound := rule.HasAction(alerts.ActionNotify), rule.HasAction(alerts.ActionSound); {
+	case notify && sound:
+		e.action = editNotifySound
+	case sound:
+		e.action = editSound
+	}
+	m.ruleEditor = e
+}
+
+// editable reports whether the editor shows the field; the condition and
+// its value are skipped when the rule's conditions are kept
+func (e *ruleEditor) editable(field int) bool {
+	return e.condition >= 0 || (field != editCondition && field != editValue)
+}
+
+// step moves to the next or previous field the editor shows
+func (e *ruleEditor) step(dir int) {
+	for f := e.field + dir; f >= 0 && f < editFields; f += dir {
+		if e.editable(f) {
+			e.field = f
+			return
+		}
+	}
+}
+
+// text returns the text field being edited, or nil on a choice
+func (e *ruleEditor) text() *string {
+	switch e.field {
+	case editName:
+		return &e.name
+	case editValue:
+		return &e.value
+	case editPriority:
+		return &e.priority
+	case editCooldown:
+		return &e.cooldown
+	}
+	return nil
+}
+
+// handleRuleEditorKey fills in the rule: the arrows move between fields
+// and change choices, Enter moves on and saves from the last field, and
+// Esc abandons the rule
+func (m *Model) handleRuleEditorKey(key string) {
+	e := m.ruleEditor
+	switch key {
+	case keyEsc:
+		m.ruleEditor = nil
+	case "up", "shift+tab":
+		e.step(-1)
+	case keyDown, "tab":
+		e.step(1)
+	case keyEnter:
+		if e.field == editAction {
+			m.saveRuleEditor()
+		} else {
+			e.step(1)
+		}
+	case "left", "right":
+		dir := 1
+		if key == "left" {
+			dir = -1
+		}
+		switch e.field {
+		case editCondition:
+			count := len(editorConditions)
+			e.condition = (e.condition + dir + count) % count
+			e.value = editorConditions[e.condition].Default
+		case editAction:
+			e.action = (e.action + dir + len(editorActions)) % len(editorActions)
+		}
+	case "backspace":
+		if t := e.text(); t != nil && *t != "" {
+			*t = (*t)[:len(*t)-1]
+		}
+	default:
+		t := e.text()
+		if t == nil || len(key) != 1 || key[0] < ' ' || key[0] > '~' || len(*t) >= editorFieldChars[e.field] {
+			return
+		}
+		// Priority and cooldown are whole numbers
+		if (e.field == editPriority || e.field == editCooldown) && (key[0] < '0' || key[0] > '9') {
+			return
+		}
+		*t += key
+	}
+}
+
+// buildRule makes the rule the editor describes. An edited rule keeps its
+// ID, description, enabled state and any actions the editor doesn't offer.
+func (m *Model) buildRule(e *ruleEditor, old *alerts.AlertRule) (*alerts.AlertRule, error) {
+	priority, err := strconv.Atoi(e.priority)
+	if err != nil || priority > 100 {
+		return nil, fmt.Errorf("priority must be 0 to 100")
+	}
+	cooldown, err := strconv.Atoi(e.cooldown)
+	if err != nil {
+		return nil, fmt.Errorf("cooldown must be whole seconds")
+	}
+
+	id := e.id
+	if old == nil {
+		id = m.alertState.Engine.GetRuleSet().UniqueID("custom")
+	}
+	rule := alerts.NewAlertRule(id, strings.TrimSpace(e.name))
+	if e.condition >= 0 {
+		rule.AddCondition(editorConditions[e.condition].Type, strings.TrimSpace(e.value))
+	} else {
+		rule.Conditions = e.kept
+	}
+
+	var notify, sound *alerts.Action
+	if old != nil {
+		rule.Description = old.Description
+		rule.Enabled = old.Enabled
+		for _, act := range old.Actions {
+			switch act.Type {
+			case alerts.ActionNotify:
+				notify = &act
+			case alerts.ActionSound:
+				sound = &act
+			default:
+				rule.Actions = append(rule.Actions, act)
+			}
+		}
+	}
+	// Offered actions go first, keeping an edited rule's message and sound
+	var offered []alerts.Action
+	if e.action != editSound {
+		if notify == nil {
+			notify = &alerts.Action{Type: alerts.ActionNotify}
+		}
+		offered = append(offered, *notify)
+	}
+	if e.action != editNotify {
+		if sound == nil {
+			sound = &alerts.Action{Type: alerts.ActionSound, Sound: "warning"}
+		}
+		offered = append(offered, *sound)
+	}
+	rule.Actions = append(offered, rule.Actions...)
+
+	rule.SetPriority(priority)
+	rule.SetCooldown(time.Duration(cooldown) * time.Second)
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// saveRuleEditor adds the new rule, or puts the edited one in place of the
+// old, and saves the rules. A rule that doesn't validate stays open.
+func (m *Model) saveRuleEditor() {
+	e := m.ruleEditor
+	rules := m.alertState.Engine.GetRuleSet()
+	old := rules.GetRuleByID(e.id)
+	rule, err := m.buildRule(e, old)
+	if err != nil {
+		key := "notify.rule_invalid"
+		if old != nil {
+			key = "notify.rule_not_saved"
+		}
+		m.notify(m.trf(key, strings.ReplaceAll(err.Error(), "\n", "; ")))
+		return
+	}
+
+	if old != nil {
+		rules.ReplaceRule(rule)
+		m.notify(m.trf("notify.rule_saved", rule.Name))
+	} else {
+		m.alertState.Engine.AddRule(rule)
+		m.alertRuleCursor = rules.Count() - 1
+		m.notify(m.trf("notify.rule_added", rule.Name))
+	}
+	m.alertState.SaveToConfig(m.config)
+	m.saveConfig()
+	m.ruleEditor = nil
+}
+
+// deleteRule removes the highlighted rule unless it's a default one
+func (m *Model) deleteRule(rule *alerts.AlertRule) {
+	if alerts.IsDefaultRule(rule.ID) {
+		m.notify(m.trf("notify.rule_builtin", rule.Name))
+		return
+	}
+	rules := m.alertState.Engine.GetRuleSet()
+	rules.RemoveRule(rule.ID)
+	m.alertRuleCursor = max(0, min(m.alertRuleCursor, rules.Count()-1))
+	m.alertState.SaveToConfig(m.config)
+	m.saveConfig()
+	m.notify(m.trf("notify.rule_deleted", rule.Name))
+}
+
+// renderRuleEditor draws the editor's fields in place of the rule list
+func (m *Model) renderRuleEditor(sb *strings.Builder) {
+	e := m.ruleEditor
+	g := m.glyphs()
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.theme.Selected).Bold(true)
+	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+
+	conditions := "kept: " + describeConditions(e.kept)
+	if e.condition >= 0 {
+		conditions = editorConditions[e.condition].Label
+	}
+	values := []string{e.name, conditions, e.value, e.priority, e.cooldown, editorActions[e.action]}
+	labels := []string{"Name", "Condition", "Value", "Priority", "Cooldown (s)", "Action"}
+
+	for f := 0; f < editFields; f++ {
+		if f == editValue && !e.editable(f) {
+			continue
+		}
+		prefix, label := "  ", textDim.Render(fmt.Sprintf("%-13s", labels[f]))
+		value := textStyle.Render(truncate(values[f], 26))
+		if f == e.field {
+			prefix, label = g.Cursor+" ", selectedStyle.Render(fmt.Sprintf("%-13s", labels[f]))
+			switch {
+			case e.text() != nil:
+				value = primaryBright.Render(values[f] + "_")
+			case e.editable(f):
+				value = primaryBright.Render("< " + truncate(values[f], 22) + " >")
+			}
+		}
+		sb.WriteString(prefix + label + " " + value + "\n")
+	}
+}
+
+// describeConditions lists conditions briefly, as type and value
+func describeConditions(conds []alerts.Condition) string {
+	parts := make([]string, len(conds))
+	for i, c := range conds {
+		parts[i] = strings.TrimSpace(string(c.Type) + " " + c.Value)
+	}
+	return strings.Join(parts, ", ")
+}
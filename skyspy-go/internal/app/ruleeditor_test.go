@@ -0,0 +1,177 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// newRuleEditorModel opens the alert rules with settings saved under a
+// temporary home
+func newRuleEditorModel(t *testing.T) *Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	m := NewModel(newTestConfig())
+	m.openAlertRulesView()
+	return m
+}
+
+// typeText presses each character of s
+func typeText(m *Model, s string) {
+	pressKeys(m, strings.Split(s, "")...)
+}
+
+func TestRuleEditor_NewRule(t *testing.T) {
+	m := newRuleEditorModel(t)
+	defaults := len(m.GetAlertRules())
+
+	// The custom rule comes after the templates
+	pressKey(m, "n")
+	if panel := ansi.Strip(m.renderAlertRulesPanel()); !strings.Contains(panel, "Custom rule...") {
+		t.Fatalf("the custom rule should be offered:\n%s", panel)
+	}
+	pressKey(m, "9")
+	if m.ruleEditor == nil || m.ruleDraft != nil {
+		t.Fatal("picking the custom rule should open the editor")
+	}
+
+	// Name, then the callsign condition, a pattern with a Q in it, a
+	// priority, a cooldown and both actions
+	typeText(m, "Qatari")
+	pressKeys(m, keyEnter, "left", keyEnter)
+	typeText(m, "QTR*")
+	pressKeys(m, keyEnter, "backspace", "backspace", "7", "x", "5", keyEnter)
+	pressKeys(m, "backspace", "backspace", "backspace", "6", "0", keyEnter, "left")
+	if panel := ansi.Strip(m.renderAlertRulesPanel()); !strings.Contains(panel, "< Notify + sound >") {
+		t.Fatalf("the action choice should be shown:\n%s", panel)
+	}
+	pressKey(m, keyEnter)
+
+	rules := m.GetAlertRules()
+	if len(rules) != defaults+1 || m.ruleEditor != nil {
+		t.Fatalf("expected a new rule, got %d rules", len(rules))
+	}
+	rule := rules[len(rules)-1]
+	if rule.ID != "custom" || rule.Name != "Qatari" || rule.Priority != 75 || rule.Cooldown != time.Minute {
+		t.Errorf("added %q %q P%d %v", rule.ID, rule.Name, rule.Priority, rule.Cooldown)
+	}
+	if len(rule.Conditions) != 1 || rule.Conditions[0].Type != alerts.ConditionCallsign || rule.Conditions[0].Value != "QTR*" {
+		t.Errorf("conditions = %+v", rule.Conditions)
+	}
+	if !rule.HasAction(alerts.ActionNotify) || !rule.HasAction(alerts.ActionSound) {
+		t.Errorf("actions = %+v", rule.Actions)
+	}
+	if m.alertRuleCursor != len(rules)-1 {
+		t.Error("the cursor should move to the new rule")
+	}
+
+	// Saved, so it's there on the next start
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved := cfg.Alerts.Rules[len(cfg.Alerts.Rules)-1]; saved.ID != "custom" || saved.CooldownSec != 60 {
+		t.Errorf("saved %+v", saved)
+	}
+}
+
+func TestRuleEditor_InvalidRuleStaysOpen(t *testing.T) {
+	m := newRuleEditorModel(t)
+	defaults := len(m.GetAlertRules())
+	pressKeys(m, "n", "9")
+
+	// No name, and a callsign condition with no pattern
+	pressKeys(m, keyEnter, "left", keyEnter, keyEnter, keyEnter, keyEnter, keyEnter)
+	if len(m.GetAlertRules()) != defaults || m.ruleEditor == nil {
+		t.Fatal("an invalid rule should not be added")
+	}
+	if !strings.Contains(m.notification, "name is required") || !strings.Contains(m.notification, "callsign: value is required") {
+		t.Errorf("notification = %q", m.notification)
+	}
+
+	pressKey(m, keyEsc)
+	if m.ruleEditor != nil || m.viewMode != ViewAlertRules {
+		t.Error("Esc should drop the rule and stay in the rules")
+	}
+}
+
+func TestRuleEditor_EditAndDelete(t *testing.T) {
+	m := newRuleEditorModel(t)
+	engine := m.alertState.Engine
+	rule := alerts.NewAlertRule("watch", "Watch").AddCondition(alerts.ConditionSquawk, "7600")
+	rule.AddAction(alerts.ActionNotify, "NORDO {callsign}")
+	rule.AddAction(alerts.ActionHighlight, "")
+	rule.Enabled = false
+	engine.AddRule(rule)
+	index := len(m.GetAlertRules()) - 1
+
+	// Default rules are only turned on and off
+	m.alertRuleCursor = 0
+	pressKeys(m, "e", "d")
+	if m.ruleEditor != nil || len(m.GetAlertRules()) != index+1 {
+		t.Fatal("a default rule can't be edited or deleted")
+	}
+	if m.notification != m.trf("notify.rule_builtin", "Emergency Squawk") {
+		t.Errorf("notification = %q", m.notification)
+	}
+
+	m.alertRuleCursor = index
+	pressKey(m, "e")
+	if m.ruleEditor == nil || m.ruleEditor.value != "7600" || m.ruleEditor.condition != 0 {
+		t.Fatalf("the editor should start from the rule, got %+v", m.ruleEditor)
+	}
+	pressKeys(m, keyEnter, keyEnter, "backspace", "1", keyEnter, keyEnter, keyEnter, "left", keyEnter)
+
+	edited := engine.GetRuleSet().GetRuleByID("watch")
+	if edited == nil || edited == rule || len(m.GetAlertRules()) != index+1 {
+		t.Fatal("the edited rule should replace the old one")
+	}
+	if edited.Conditions[0].Value != "7601" || edited.Enabled {
+		t.Errorf("edited %+v", edited)
+	}
+	// The message and highlight are kept, and the sound added
+	if len(edited.Actions) != 3 || edited.Actions[0].Message != "NORDO {callsign}" || edited.Actions[1].Type != alerts.ActionSound || edited.Actions[2].Type != alerts.ActionHighlight {
+		t.Errorf("actions = %+v", edited.Actions)
+	}
+
+	pressKey(m, "d")
+	if engine.GetRuleSet().GetRuleByID("watch") != nil || len(m.config.Alerts.Rules) != index {
+		t.Error("d should delete the rule and save")
+	}
+	if m.alertRuleCursor != index-1 {
+		t.Errorf("cursor = %d, want the last rule", m.alertRuleCursor)
+	}
+}
+
+func TestRuleEditor_KeepsConditionsItCantShow(t *testing.T) {
+	m := newRuleEditorModel(t)
+	rule := alerts.NewAlertRule("low_mil", "Low military").
+		AddCondition(alerts.ConditionMilitary, "true").
+		AddCondition(alerts.ConditionAltitudeBelow, "3000")
+	m.alertState.Engine.AddRule(rule)
+	m.alertRuleCursor = len(m.GetAlertRules()) - 1
+
+	pressKey(m, "e")
+	if panel := ansi.Strip(m.renderAlertRulesPanel()); !strings.Contains(panel, "kept: military true") {
+		t.Errorf("kept conditions should be listed:\n%s", panel)
+	}
+	// Enter from the name goes straight to the priority
+	pressKeys(m, keyEnter)
+	if m.ruleEditor.field != editPriority {
+		t.Fatalf("field = %d, want the priority", m.ruleEditor.field)
+	}
+	pressKeys(m, keyEnter, keyEnter, keyEnter)
+
+	edited := m.alertState.Engine.GetRuleSet().GetRuleByID("low_mil")
+	if len(edited.Conditions) != 2 || edited.Conditions[1].Value != "3000" {
+		t.Errorf("conditions = %+v", edited.Conditions)
+	}
+}
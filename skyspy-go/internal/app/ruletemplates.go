@@ -0,0 +1,184 @@
+// Package app provides new alert rules from templates for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+)
+
+// ruleEntryChars caps a value typed for a rule template
+const ruleEntryChars = 32
+
+// ruleDraft is a rule being made from a template in the alert rules view:
+// first a template is picked, then its values are asked for in turn
+type ruleDraft struct {
+	template *alerts.RuleTemplate // nil while picking
+	cursor   int                  // highlighted template while picking
+	values   []string             // values entered so far
+	entry    string               // the value being typed
+	bell     bool                 // add a bell action to the rule
+}
+
+// openRuleTemplates starts a new rule by listing the templates
+func (m *Model) openRuleTemplates() {
+	if m.alertState == nil || m.alertState.Engine == nil {
+		return
+	}
+	m.ruleDraft = &ruleDraft{}
+}
+
+// handleRuleDraftKey picks a template, then takes its values; Enter moves
+// on and Esc abandons the rule
+func (m *Model) handleRuleDraftKey(key string) {
+	d := m.ruleDraft
+	if key == keyEsc {
+		m.ruleDraft = nil
+		return
+	}
+
+	if d.template == nil {
+		// The custom rule follows the templates
+		count := len(alerts.RuleTemplates) + 1
+		switch key {
+		case "up", "k":
+			d.cursor = (d.cursor - 1 + count) % count
+		case keyDown, "j":
+			d.cursor = (d.cursor + 1) % count
+		case keyEnter:
+			m.pickTemplate(d.cursor)
+		case "b":
+			d.bell = !d.bell
+		default:
+			if len(key) == 1 && key[0] >= '1' && int(key[0]-'1') < count {
+				d.cursor = int(key[0] - '1')
+				m.pickTemplate(d.cursor)
+			}
+		}
+		return
+	}
+
+	switch key {
+	case keyEnter:
+		d.values = append(d.values, d.entry)
+		d.entry = ""
+		if len(d.values) == len(d.template.Params) {
+			m.addRuleFromDraft()
+		}
+	case "backspace":
+		if d.entry != "" {
+			d.entry = d.entry[:len(d.entry)-1]
+		}
+	default:
+		if len(key) == 1 && key[0] >= ' ' && key[0] <= '~' && len(d.entry) < ruleEntryChars {
+			d.entry += key
+		}
+	}
+}
+
+// pickTemplate asks for the values of the i'th template, or opens the
+// rule editor for the custom rule after them
+func (m *Model) pickTemplate(i int) {
+	if i == len(alerts.RuleTemplates) {
+		m.ruleDraft = nil
+		m.openRuleEditor(nil)
+		return
+	}
+	m.ruleDraft.template = &alerts.RuleTemplates[i]
+}
+
+// addRuleFromDraft builds the drafted rule, adds it to the engine and
+// saves it with the other rules. A rule that doesn't validate asks for
+// its values again.
+func (m *Model) addRuleFromDraft() {
+	d := m.ruleDraft
+	rules := m.alertState.Engine.GetRuleSet()
+	rule, err := d.template.Build(rules.UniqueID(d.template.ID), d.values)
+	if err != nil {
+		d.values = nil
+		m.notify(m.trf("notify.rule_invalid", strings.ReplaceAll(err.Error(), "\n", "; ")))
+		return
+	}
+	if d.bell {
+		rule.Actions = append(rule.Actions, alerts.Action{Type: alerts.ActionBell})
+	}
+
+	m.alertState.Engine.AddRule(rule)
+	m.alertState.SaveToConfig(m.config)
+	m.saveConfig()
+	m.ruleDraft = nil
+	m.alertRuleCursor = rules.Count() - 1
+	m.notify(m.trf("notify.rule_added", rule.Name))
+}
+
+// renderRuleDraft draws the template list, or the prompt for the next
+// value, in place of the rule list
+func (m *Model) renderRuleDraft(sb *strings.Builder) {
+	d := m.ruleDraft
+	g := m.glyphs()
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.theme.Selected).Bold(true)
+	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+
+	if d.template == nil {
+		for i, t := range alerts.RuleTemplates {
+			prefix, style := "  ", textStyle
+			if i == d.cursor {
+				prefix, style = g.Cursor+" ", selectedStyle
+			}
+			sb.WriteString(fmt.Sprintf("%s%s %s\n", prefix, textDim.Render(fmt.Sprintf("%d", i+1)), style.Render(truncate(m.templateTitle(&t), 36))))
+		}
+		prefix, style := "  ", textStyle
+		if d.cursor == len(alerts.RuleTemplates) {
+			prefix, style = g.Cursor+" ", selectedStyle
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %s\n", prefix, textDim.Render(fmt.Sprintf("%d", len(alerts.RuleTemplates)+1)), style.Render(m.tr("template.custom"))))
+		bell := m.tr("template.bell_off")
+		if d.bell {
+			bell = m.tr("template.bell_on")
+		}
+		sb.WriteString("  " + textDim.Render(m.tr("template.bell")) + " " + textStyle.Render(bell) + "\n")
+		return
+	}
+
+	sb.WriteString("  " + selectedStyle.Render(truncate(m.templateTitle(d.template), 40)) + "\n")
+	for i, p := range d.template.Params {
+		label := truncate(m.templateLabel(d.template, i), 24) + ":"
+		switch {
+		case i < len(d.values):
+			value := d.values[i]
+			if value == "" {
+				value = p.Default
+			}
+			sb.WriteString("  " + textDim.Render(label) + " " + textStyle.Render(value) + "\n")
+		case i == len(d.values):
+			line := "  " + textStyle.Render(label) + " " + primaryBright.Render(d.entry+"_")
+			if p.Default != "" && d.entry == "" {
+				line += " " + textDim.Render("["+p.Default+"]")
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+}
+
+// templateTitle returns what the template offers, from the strings file
+func (m *Model) templateTitle(t *alerts.RuleTemplate) string {
+	key := "template." + t.ID
+	if text := m.tr(key); text != key {
+		return text
+	}
+	return t.Title
+}
+
+// templateLabel returns the prompt for the template's i'th value, from
+// the strings file
+func (m *Model) templateLabel(t *alerts.RuleTemplate, i int) string {
+	key := fmt.Sprintf("template.%s.%d", t.ID, i+1)
+	if text := m.tr(key); text != key {
+		return text
+	}
+	return t.Params[i].Label
+}
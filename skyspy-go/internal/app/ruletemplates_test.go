@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/i18n"
+)
+
+// pressKeys presses each key in turn
+func pressKeys(m *Model, keys ...string) {
+	for _, key := range keys {
+		pressKey(m, key)
+	}
+}
+
+func TestRuleTemplates_AddFromView(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	m := NewModel(newTestConfig())
+	defaults := len(m.GetAlertRules())
+	m.openAlertRulesView()
+
+	pressKeys(m, "n")
+	if panel := ansi.Strip(m.renderAlertRulesPanel()); !strings.Contains(panel, "Aircraft below X ft within Y nm") {
+		t.Fatalf("the templates should be listed:\n%s", panel)
+	}
+
+	// A callsign with a Q in it is typed, not taken as quit
+	pressKeys(m, "3", "Q", "T", "R", "*", "enter")
+	rules := m.GetAlertRules()
+	if len(rules) != defaults+1 || m.ruleDraft != nil {
+		t.Fatalf("expected a new rule, got %d rules", len(rules))
+	}
+	added := rules[len(rules)-1]
+	if added.ID != "watch_callsign" || added.Name != "Callsign QTR*" {
+		t.Errorf("added %q %q", added.ID, added.Name)
+	}
+	if m.alertRuleCursor != len(rules)-1 {
+		t.Error("the cursor should move to the new rule")
+	}
+
+	// The same template again gets its own ID; empty values take defaults
+	pressKeys(m, "n", "1", "enter", "enter", "n", "1", "enter", "enter")
+	rules = m.GetAlertRules()
+	if got := rules[len(rules)-2].ID + "," + rules[len(rules)-1].ID; got != "low_nearby,low_nearby_2" {
+		t.Errorf("IDs = %s", got)
+	}
+	if rules[len(rules)-1].Name != "Below 1000ft within 10nm" {
+		t.Errorf("defaults not used: %q", rules[len(rules)-1].Name)
+	}
+
+	// The rules are saved, and load back with the same IDs
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := NewAlertState(saved).GetRules()
+	if len(reloaded) != len(rules) {
+		t.Fatalf("reloaded %d rules, want %d", len(reloaded), len(rules))
+	}
+	for i := range rules {
+		if reloaded[i].ID != rules[i].ID || reloaded[i].Cooldown != rules[i].Cooldown {
+			t.Errorf("rule %d reloaded as %q %v, want %q %v", i, reloaded[i].ID, reloaded[i].Cooldown, rules[i].ID, rules[i].Cooldown)
+		}
+	}
+}
+
+func TestRuleTemplates_InvalidAndCancel(t *testing.T) {
+	m := NewModel(newTestConfig())
+	defaults := len(m.GetAlertRules())
+	m.openAlertRulesView()
+
+	pressKeys(m, "n", "1", "l", "o", "w", "enter", "enter")
+	if len(m.GetAlertRules()) != defaults || m.ruleDraft == nil || len(m.ruleDraft.values) != 0 {
+		t.Error("an invalid value should ask for the values again")
+	}
+	if !strings.Contains(m.notification, "Rule not added") {
+		t.Errorf("expected the error, got %q", m.notification)
+	}
+
+	pressKeys(m, "esc")
+	if m.ruleDraft != nil || m.viewMode != ViewAlertRules {
+		t.Error("Esc should abandon the rule and stay in the rules view")
+	}
+}
+
+func TestRuleTemplates_InStringsFile(t *testing.T) {
+	keys := make(map[string]bool)
+	for _, key := range i18n.Keys() {
+		keys[key] = true
+	}
+	for _, tmpl := range alerts.RuleTemplates {
+		if !keys["template."+tmpl.ID] {
+			t.Errorf("template %s has no title in the strings file", tmpl.ID)
+		}
+		for i := range tmpl.Params {
+			if key := fmt.Sprintf("template.%s.%d", tmpl.ID, i+1); !keys[key] {
+				t.Errorf("template %s has no %s in the strings file", tmpl.ID, key)
+			}
+		}
+	}
+}
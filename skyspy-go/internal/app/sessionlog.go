@@ -0,0 +1,71 @@
+// Package app provides session logging for the SkySpy radar
+package app
+
+import (
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/export"
+)
+
+// sessionLogBuffer is how many aircraft messages may wait for the disk; a
+// snapshot of a busy sky is several hundred
+const sessionLogBuffer = 4096
+
+// SetSessionLog logs every aircraft message to files in the export
+// directory from now on, rotated as settings say. It's off without
+// automatic exports.
+func (m *Model) SetSessionLog(settings config.AutoExportSettings) {
+	m.CloseSessionLog()
+	if !m.caps.Exports {
+		return
+	}
+	dir := m.GetExportDirectory()
+	if dir == "" {
+		dir = "."
+	}
+	m.sessionFormat = export.SessionFormat(settings.Format)
+	m.sessionLog = export.NewRotatingAppender(export.Rotation{
+		Dir:    dir,
+		Prefix: "skyspy_session",
+		Ext:    m.sessionFormat,
+		Header: export.SessionHeader(m.sessionFormat),
+		Every:  time.Duration(settings.Interval) * time.Minute,
+		Max:    int64(settings.MaxSizeMB) << 20,
+	}, sessionLogBuffer)
+}
+
+// IsSessionLogging reports whether aircraft messages are being logged
+func (m *Model) IsSessionLogging() bool {
+	return m.sessionLog != nil
+}
+
+// logSession adds an aircraft message to the session log. Lines that
+// don't fit the buffer are dropped rather than hold up the radar.
+func (m *Model) logSession(event string, ac *codec.Aircraft) {
+	if m.sessionLog == nil {
+		return
+	}
+	line, err := export.SessionLine(m.sessionFormat, m.now(), event, ac)
+	if err != nil {
+		return
+	}
+	if !m.sessionLog.Append(line) {
+		m.debugf("session log: dropped %s for %s", event, ac.Hex)
+	}
+}
+
+// CloseSessionLog writes the lines still queued and closes the session log
+func (m *Model) CloseSessionLog() {
+	if m.sessionLog == nil {
+		return
+	}
+	if err := m.sessionLog.Close(); err != nil {
+		m.debugf("session log: %v", err)
+	}
+	if dropped := m.sessionLog.Dropped(); dropped > 0 {
+		m.debugf("session log: %d lines dropped", dropped)
+	}
+	m.sessionLog = nil
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func TestSessionLog_LogsAircraftMessages(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.config.Export.Directory = t.TempDir()
+	m.SetSessionLog(config.AutoExportSettings{Enabled: true, Format: "ndjson", Interval: 60, MaxSizeMB: 100})
+	if !m.IsSessionLogging() {
+		t.Fatal("the session log should be on")
+	}
+	if !strings.Contains(m.renderStatusBar(), "REC") {
+		t.Error("the status bar should show the log is recording")
+	}
+
+	update, _ := json.Marshal(flying("4840d6", 52.1))
+	m.handleAircraftMsg(codec.Message{Type: string(codec.AircraftUpdate), Data: update})
+	remove, _ := json.Marshal(codec.Aircraft{Hex: "4840d6"})
+	m.handleAircraftMsg(codec.Message{Type: string(codec.AircraftRemove), Data: remove})
+
+	log := m.sessionLog
+	m.CloseSessionLog()
+	path := log.Path()
+	if m.IsSessionLogging() || strings.Contains(m.renderStatusBar(), "REC") {
+		t.Error("closing the log should clear the indicator")
+	}
+
+	if name := filepath.Base(path); !strings.HasPrefix(name, "skyspy_session_") || filepath.Ext(name) != ".ndjson" {
+		t.Errorf("logged to %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the session log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), data)
+	}
+	for i, event := range []string{"aircraft:update", "aircraft:remove"} {
+		var record struct {
+			Event    string         `json:"event"`
+			Aircraft codec.Aircraft `json:"aircraft"`
+		}
+		if err := json.Unmarshal([]byte(lines[i]), &record); err != nil {
+			t.Fatalf("line %d isn't JSON: %v", i, err)
+		}
+		// Logged with the hex the radar uses
+		if record.Event != event || record.Aircraft.Hex != "4840D6" {
+			t.Errorf("line %d = %s", i, lines[i])
+		}
+	}
+}
+
+func TestSessionLog_CSVHeader(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.config.Export.Directory = t.TempDir()
+	m.SetSessionLog(config.AutoExportSettings{Format: "csv"})
+
+	snapshot, _ := json.Marshal([]*codec.Aircraft{flying("AAA001", 52.1), flying("AAA002", 52.2)})
+	m.handleAircraftMsg(codec.Message{Type: string(codec.AircraftSnapshot), Data: snapshot})
+	log := m.sessionLog
+	m.CloseSessionLog()
+	path := log.Path()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the session log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "time,event,hex,") || !strings.Contains(lines[1], ",aircraft:snapshot,AAA001,") {
+		t.Errorf("log =\n%s", data)
+	}
+}
+
+func TestSessionLog_OffWithoutAutoExports(t *testing.T) {
+	m := NewModelWithCapabilities(newTestConfig(), nil, SafeCapabilities())
+	m.config.Export.Directory = t.TempDir()
+	m.SetSessionLog(config.AutoExportSettings{Enabled: true})
+	if m.IsSessionLogging() {
+		t.Error("safe mode should not log the session")
+	}
+	m.CloseSessionLog()
+}
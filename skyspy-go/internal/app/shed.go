@@ -0,0 +1,170 @@
+// Package app provides load shedding for the SkySpy radar on very large
+// feeds
+package app
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/search"
+)
+
+// shedSlack sets how far below the cap shedding trims, as a fraction of
+// it, so a busy feed doesn't rank every aircraft on each new arrival
+const shedSlack = 20
+
+// shedTrailRetention caps trail retention while aircraft are being shed
+const shedTrailRetention = time.Minute
+
+// maxAircraft returns the most aircraft tracked at once, or 0 for no cap
+func maxAircraft(cfg *config.Config) int {
+	switch {
+	case cfg.Radar.MaxAircraft > 0:
+		return cfg.Radar.MaxAircraft
+	case cfg.Radar.MaxAircraft < 0:
+		return 0
+	}
+	return config.DefaultConfig().Radar.MaxAircraft
+}
+
+// shedRank orders aircraft for load shedding; aircraft that sort first are
+// kept first
+type shedRank struct {
+	protected bool    // selected, pinned or squawking an emergency; never shed
+	matches   bool    // passes the active filters
+	distance  float64 // nm; unknown positions count as farthest
+}
+
+// before reports whether a is kept ahead of b
+func (a shedRank) before(b shedRank) bool {
+	if a.protected != b.protected {
+		return a.protected
+	}
+	if a.matches != b.matches {
+		return a.matches
+	}
+	return a.distance < b.distance
+}
+
+// rankForShed ranks t against the current selection, pins and filters. A
+// lost selection coming back counts as selected so it can be reacquired.
+func (m *Model) rankForShed(t *radar.Target) shedRank {
+	lost := m.activeLostSelection()
+	r := shedRank{
+		protected: t.Hex == m.selectedHex || m.isPinned(t.Hex) || t.IsEmergency() ||
+			(lost != nil && lost.target.Hex == t.Hex),
+		matches:  !m.config.Filters.MilitaryOnly || t.Military,
+		distance: t.Distance,
+	}
+	if r.matches && m.IsFilterActive() {
+		r.matches = search.MatchesAircraft(t, m.searchFilter)
+	}
+	if t.Distance <= 0 {
+		r.distance = math.Inf(1)
+	}
+	return r
+}
+
+// admit decides whether an aircraft not yet tracked may be added. While
+// shedding, aircraft refill the room left by departures freely, but above
+// that they must rank ahead of the last aircraft kept; the rest are counted
+// as shed.
+func (m *Model) admit(t *radar.Target) bool {
+	limit := maxAircraft(m.config)
+	if limit == 0 || !m.shedding || len(m.aircraft) < shedKeep(limit) || m.rankForShed(t).before(m.shedCutoff) {
+		delete(m.shed, t.Hex)
+		return true
+	}
+	m.shed[t.Hex] = m.now()
+	return false
+}
+
+// shedKeep returns how many aircraft are left after shedding
+func shedKeep(limit int) int {
+	return limit - limit/shedSlack
+}
+
+// enforceAircraftCap sheds the lowest-ranked aircraft once the tracked
+// count goes over the cap
+func (m *Model) enforceAircraftCap() {
+	limit := maxAircraft(m.config)
+	if limit == 0 || len(m.aircraft) <= limit {
+		return
+	}
+
+	type ranked struct {
+		hex  string
+		rank shedRank
+	}
+	all := make([]ranked, 0, len(m.aircraft))
+	for hex, t := range m.aircraft {
+		all = append(all, ranked{hex, m.rankForShed(t)})
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].rank.before(all[j].rank)
+	})
+
+	keep := shedKeep(limit)
+	m.shedCutoff = all[keep-1].rank
+	now := m.now()
+	for _, r := range all[keep:] {
+		if r.rank.protected {
+			continue
+		}
+		m.removeAircraft(r.hex)
+		m.shed[r.hex] = now
+		// It was announced once; coming back in range is not a new arrival
+		m.alertedAircraft[r.hex] = true
+	}
+
+	if !m.shedding {
+		m.startShedding(limit)
+	}
+}
+
+// startShedding trims trail retention and stops the per-frame VU and
+// spectrum updates until the load drops
+func (m *Model) startShedding(limit int) {
+	m.shedding = true
+	if trailRetention(m.config) > shedTrailRetention {
+		m.trailTracker.SetRetention(shedTrailRetention)
+	}
+	m.notify(m.trf("notify.shedding", limit))
+}
+
+// stopShedding restores what startShedding turned down
+func (m *Model) stopShedding() {
+	m.shedding = false
+	m.shedCutoff = shedRank{}
+	m.trailTracker.SetRetention(trailRetention(m.config))
+	m.notify(m.tr("notify.shedding_over"))
+}
+
+// forgetShed drops a shed aircraft that has left the feed
+func (m *Model) forgetShed(hex string) {
+	delete(m.shed, hex)
+	delete(m.alertedAircraft, hex)
+}
+
+// expireShed forgets shed aircraft that have gone quiet, and ends shedding
+// once none are left
+func (m *Model) expireShed(now time.Time) {
+	timeout := aircraftTimeout(m.config)
+	for hex, seen := range m.shed {
+		if now.Sub(seen) > timeout {
+			m.forgetShed(hex)
+		}
+	}
+	if m.shedding && len(m.shed) == 0 {
+		m.stopShedding()
+	}
+}
+
+// ShedCount returns how many aircraft in the feed are not being tracked
+// because of the aircraft cap
+func (m *Model) ShedCount() int {
+	return len(m.shed)
+}
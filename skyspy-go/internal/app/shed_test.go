@@ -0,0 +1,295 @@
+package app
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/sim"
+)
+
+// sendAt feeds an aircraft at dist nm with no position, so the distance is
+// taken as reported
+func sendAt(m *Model, hex string, dist float64) {
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{
+		Hex:      hex,
+		Flight:   hex,
+		Distance: floatPtr(dist),
+	}))
+}
+
+func newCappedModel(limit int) (*Model, *time.Time) {
+	cfg := newTestConfig()
+	cfg.Radar.MaxAircraft = limit
+	cfg.Radar.AircraftTimeout = 60
+	cfg.Radar.CleanupInterval = 10
+	m := NewModel(cfg)
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+	return m, &clock
+}
+
+func TestMaxAircraft(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if got := maxAircraft(cfg); got != 5000 {
+		t.Errorf("default cap = %d, want 5000", got)
+	}
+	cfg.Radar.MaxAircraft = 0
+	if got := maxAircraft(cfg); got != 5000 {
+		t.Errorf("unset cap should use the default, got %d", got)
+	}
+	cfg.Radar.MaxAircraft = -1
+	if got := maxAircraft(cfg); got != 0 {
+		t.Errorf("negative cap should disable it, got %d", got)
+	}
+}
+
+func TestShed_KeepsNearest(t *testing.T) {
+	m, _ := newCappedModel(10)
+	for i := 20; i >= 1; i-- {
+		sendAt(m, fmt.Sprintf("AC%02d", i), float64(i*10))
+	}
+
+	if len(m.aircraft) != 10 {
+		t.Fatalf("expected 10 aircraft kept, got %d", len(m.aircraft))
+	}
+	for i := 1; i <= 10; i++ {
+		if _, ok := m.aircraft[fmt.Sprintf("AC%02d", i)]; !ok {
+			t.Errorf("AC%02d at %dnm should be kept", i, i*10)
+		}
+	}
+	if m.ShedCount() != 10 || m.GetStats().Shed != 10 {
+		t.Errorf("expected 10 shed, got %d", m.ShedCount())
+	}
+	if !m.shedding {
+		t.Error("expected shedding to start")
+	}
+	if m.trailTracker.Retention() != shedTrailRetention {
+		t.Errorf("expected trail retention cut to %v, got %v", shedTrailRetention, m.trailTracker.Retention())
+	}
+}
+
+func TestShed_AdmitsOnlyCloserAircraft(t *testing.T) {
+	m, _ := newCappedModel(10)
+	for i := 1; i <= 11; i++ {
+		sendAt(m, fmt.Sprintf("AC%02d", i), float64(i*10))
+	}
+	if _, ok := m.aircraft["AC11"]; ok {
+		t.Fatal("the farthest aircraft should be shed")
+	}
+
+	// A shed aircraft that keeps reporting stays out without churn
+	events := 0
+	m.SetEventHandler(func(Event) { events++ })
+	sendAt(m, "AC11", 110)
+	sendAt(m, "FAR", 500)
+	if _, ok := m.aircraft["AC11"]; ok || events != 0 {
+		t.Errorf("far aircraft should stay shed quietly, got %d events", events)
+	}
+
+	// A closer newcomer takes the place of the farthest kept
+	sendAt(m, "NEAR", 5)
+	if _, ok := m.aircraft["NEAR"]; !ok {
+		t.Error("a nearer aircraft should be admitted")
+	}
+	if len(m.aircraft) > 10 {
+		t.Errorf("cap exceeded: %d aircraft", len(m.aircraft))
+	}
+	if _, ok := m.aircraft["AC10"]; ok {
+		t.Error("the farthest kept aircraft should make room")
+	}
+}
+
+func TestShed_ProtectsSelectionPinsAndEmergencies(t *testing.T) {
+	m, _ := newCappedModel(5)
+	sendAt(m, "SEL", 400)
+	sendAt(m, "PIN", 390)
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{
+		Hex: "EMRG", Squawk: "7700", Distance: floatPtr(380),
+	}))
+	m.selectedHex = "SEL"
+	m.pinned = append(m.pinned, "PIN")
+
+	for i := 1; i <= 10; i++ {
+		sendAt(m, fmt.Sprintf("AC%02d", i), float64(i))
+	}
+
+	for _, hex := range []string{"SEL", "PIN", "EMRG"} {
+		if _, ok := m.aircraft[hex]; !ok {
+			t.Errorf("%s should never be shed", hex)
+		}
+	}
+	if m.selectedHex != "SEL" || !m.isPinned("PIN") {
+		t.Error("selection and pins should survive shedding")
+	}
+	if len(m.aircraft) != 5 {
+		t.Errorf("expected the cap held at 5, got %d", len(m.aircraft))
+	}
+}
+
+func TestShed_PrefersFilterMatches(t *testing.T) {
+	m, _ := newCappedModel(4)
+	m.config.Filters.MilitaryOnly = true
+	m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftUpdate, codec.Aircraft{
+		Hex: "MIL1", Military: true, Distance: floatPtr(300),
+	}))
+	for i := 1; i <= 6; i++ {
+		sendAt(m, fmt.Sprintf("CIV%d", i), float64(i))
+	}
+
+	if _, ok := m.aircraft["MIL1"]; !ok {
+		t.Error("an aircraft matching the active filter should be kept over nearer ones")
+	}
+}
+
+func TestShed_NoReplayedAlertsOnReturn(t *testing.T) {
+	m, _ := newCappedModel(3)
+	for i := 1; i <= 4; i++ {
+		sendAt(m, fmt.Sprintf("AC%02d", i), float64(i*10))
+	}
+	if !m.alertedAircraft["AC04"] {
+		t.Error("a shed aircraft should stay marked as announced")
+	}
+
+	// Once the near traffic leaves, the shed aircraft comes back as tracked
+	for i := 1; i <= 3; i++ {
+		m.handleAircraftMsg(createMockAircraftMessage(codec.AircraftRemove, codec.Aircraft{Hex: fmt.Sprintf("AC%02d", i)}))
+	}
+	sendAt(m, "AC04", 40)
+	if _, ok := m.aircraft["AC04"]; !ok {
+		t.Fatal("the shed aircraft should return once there is room")
+	}
+	if m.ShedCount() != 0 {
+		t.Errorf("expected no shed aircraft, got %d", m.ShedCount())
+	}
+}
+
+func TestShed_EndsWhenShedAircraftGoQuiet(t *testing.T) {
+	m, clock := newCappedModel(5)
+	for i := 1; i <= 8; i++ {
+		sendAt(m, fmt.Sprintf("AC%02d", i), float64(i*10))
+	}
+	if !m.shedding {
+		t.Fatal("expected shedding")
+	}
+
+	// The kept aircraft keep reporting; the shed ones stop
+	m.handleTick()
+	for step := 0; step < 8; step++ {
+		*clock = clock.Add(10 * time.Second)
+		for i := 1; i <= 5; i++ {
+			sendAt(m, fmt.Sprintf("AC%02d", i), float64(i*10))
+		}
+		m.handleTick()
+	}
+
+	if m.shedding || m.ShedCount() != 0 {
+		t.Errorf("shedding should end once shed aircraft time out, %d left", m.ShedCount())
+	}
+	if m.trailTracker.Retention() != trailRetention(m.config) {
+		t.Errorf("trail retention should be restored, got %v", m.trailTracker.Retention())
+	}
+	if m.alertedAircraft["AC08"] {
+		t.Error("expired shed aircraft should be forgotten")
+	}
+}
+
+func TestShed_PausesExtras(t *testing.T) {
+	m, _ := newCappedModel(5)
+	for i := 1; i <= 8; i++ {
+		sendAt(m, fmt.Sprintf("AC%02d", i), float64(i*10))
+	}
+	m.vuLeft, m.vuRight = 0.5, 0.5
+	m.handleTick()
+	if m.vuLeft != 0.5 || m.vuRight != 0.5 {
+		t.Error("VU meters should not update while shedding")
+	}
+
+	m.width, m.height = 160, 50
+	m.config.Display.ShowStatsPanel = true
+	view := ansi.Strip(m.renderStatsPanel())
+	if !strings.Contains(view, "SHED 3") {
+		t.Errorf("expected the shed count in the stats panel, got %q", view)
+	}
+	if strings.Contains(view, "VU L") || strings.Contains(view, "SPECTRUM") {
+		t.Error("VU meters and spectrum should be hidden while shedding")
+	}
+}
+
+func TestShed_Snapshot(t *testing.T) {
+	m, _ := newCappedModel(10)
+	traffic := sim.NewTraffic(40, m.config.Connection.ReceiverLat, m.config.Connection.ReceiverLon, 200, 1)
+	m.handleAircraftMsg(traffic.Snapshot())
+	if len(m.aircraft) > 10 || m.ShedCount() != 40-len(m.aircraft) {
+		t.Errorf("expected at most 10 kept and the rest shed, got %d kept, %d shed", len(m.aircraft), m.ShedCount())
+	}
+
+	// A later snapshot without the shed aircraft forgets them
+	empty := sim.NewTraffic(0, 0, 0, 1, 1)
+	m.handleAircraftMsg(empty.Snapshot())
+	if len(m.aircraft) != 0 || m.ShedCount() != 0 {
+		t.Errorf("expected everything forgotten, got %d kept, %d shed", len(m.aircraft), m.ShedCount())
+	}
+}
+
+// TestShed_Stress feeds 20,000 aircraft through the default cap and checks
+// that memory stays bounded and a tick still fits the tick interval
+func TestShed_Stress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("stress test")
+	}
+	cfg := newTestConfig()
+	cfg.Alerts.Enabled = false
+	m := NewModel(cfg)
+	m.width, m.height = 200, 60
+	limit := maxAircraft(cfg)
+
+	traffic := sim.NewTraffic(20000, cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon, 2000, 7)
+	m.handleAircraftMsg(traffic.Snapshot())
+
+	var before runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	const rounds = 3
+	var worst time.Duration
+	for round := 0; round < rounds; round++ {
+		traffic.Step(10 * time.Second)
+		for i := 0; i < traffic.Len(); i++ {
+			m.handleAircraftMsg(traffic.Update(i))
+		}
+		if len(m.aircraft) > limit {
+			t.Fatalf("round %d: %d aircraft tracked, over the cap of %d", round, len(m.aircraft), limit)
+		}
+
+		start := time.Now()
+		m.handleTick()
+		_ = m.View()
+		if d := time.Since(start); d > worst {
+			worst = d
+		}
+	}
+
+	if got := m.ShedCount() + len(m.aircraft); got != traffic.Len() {
+		t.Errorf("kept plus shed should account for every aircraft, got %d", got)
+	}
+	if m.GetStats().Shed < traffic.Len()-limit {
+		t.Errorf("expected at least %d shed, got %d", traffic.Len()-limit, m.GetStats().Shed)
+	}
+
+	var after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	if grown := int64(after.HeapAlloc) - int64(before.HeapAlloc); grown > 32<<20 {
+		t.Errorf("heap grew by %d MB under steady load", grown>>20)
+	}
+
+	if budget := 150 * time.Millisecond; !raceEnabled && worst > budget {
+		t.Errorf("slowest tick took %v, over the %v tick interval", worst, budget)
+	}
+}
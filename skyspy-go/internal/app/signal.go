@@ -0,0 +1,129 @@
+// Package app provides session signal statistics for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+const (
+	// signalSectors splits the compass into 30-degree bearing sectors
+	signalSectors = 12
+	// weakestReported is how many weak-signal aircraft the report lists
+	weakestReported = 10
+)
+
+// signalLog keeps session-wide signal records that outlive the aircraft
+// they came from
+type signalLog struct {
+	// farthest is the most distant target seen in each bearing sector
+	farthest [signalSectors]*radar.Target
+	// retired holds the weakest-average aircraft already cleaned up,
+	// trimmed to weakestReported
+	retired []*radar.Target
+}
+
+// recordSector updates the farthest-target record for t's bearing sector
+func (m *Model) recordSector(t *radar.Target) {
+	if t.Distance <= 0 {
+		return
+	}
+	sector := int(normalizeBearing(t.Bearing)/(360/signalSectors)) % signalSectors
+	if best := m.signalLog.farthest[sector]; best == nil || t.Distance > best.Distance {
+		m.signalLog.farthest[sector] = t
+	}
+}
+
+// retireSignal keeps t's signal stats for the session report once it is
+// removed from the live picture
+func (m *Model) retireSignal(t *radar.Target) {
+	if t.Signal.Samples == 0 {
+		return
+	}
+	m.signalLog.retired = weakest(append(m.signalLog.retired, t), weakestReported)
+}
+
+// weakest sorts targets by ascending average RSSI and returns the first n
+func weakest(targets []*radar.Target, n int) []*radar.Target {
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Signal.Avg != targets[j].Signal.Avg {
+			return targets[i].Signal.Avg < targets[j].Signal.Avg
+		}
+		return targets[i].Hex < targets[j].Hex
+	})
+	if len(targets) > n {
+		targets = targets[:n]
+	}
+	return targets
+}
+
+// SignalReport returns the session's weakest-signal aircraft and the
+// farthest aircraft per bearing sector. Live aircraft take precedence over
+// retired records for the same hex.
+func (m *Model) SignalReport() export.SignalReport {
+	candidates := make([]*radar.Target, 0, len(m.aircraft)+len(m.signalLog.retired))
+	for _, t := range m.aircraft {
+		if t.Signal.Samples > 0 {
+			candidates = append(candidates, t)
+		}
+	}
+	for _, t := range m.signalLog.retired {
+		if _, live := m.aircraft[t.Hex]; !live {
+			candidates = append(candidates, t)
+		}
+	}
+
+	report := export.SignalReport{Units: m.distUnit()}
+	for _, t := range weakest(candidates, weakestReported) {
+		report.Weakest = append(report.Weakest, export.SignalEntry{
+			Hex:      t.Hex,
+			Callsign: t.Callsign,
+			Stats:    t.Signal,
+		})
+	}
+	for i, t := range m.signalLog.farthest {
+		entry := export.SectorEntry{Start: i * 360 / signalSectors}
+		if t != nil {
+			shown := m.displayTarget(t)
+			entry.Hex = t.Hex
+			entry.Callsign = t.Callsign
+			entry.Distance = shown.Distance
+			entry.Bearing = shown.Bearing
+		}
+		report.Farthest = append(report.Farthest, entry)
+	}
+	return report
+}
+
+// exportSignalReport writes the session signal report to a text file
+func (m *Model) exportSignalReport() {
+	m.exportSignalReportTo(m.GetExportDirectory())
+}
+
+func (m *Model) exportSignalReportTo(dir string) {
+	report := m.SignalReport()
+	if len(report.Weakest) == 0 && !report.HasSectors() {
+		m.notify(m.tr("notify.no_signal_data"))
+		return
+	}
+
+	filename, err := export.ExportSignalReport(report, dir)
+	if err != nil {
+		m.exportFailed(err, dir, m.exportSignalReportTo)
+		return
+	}
+
+	m.notify(m.trf("notify.signal_report", m.exportedName(filename)))
+}
+
+// formatSignalStats formats lifetime RSSI as min/avg/max
+func (m *Model) formatSignalStats(t *radar.Target) string {
+	if t.Signal.Samples == 0 {
+		return ""
+	}
+	s := t.Signal
+	return fmt.Sprintf("%.1f/%.1f/%.1f", s.Min, s.Avg, s.Max)
+}
@@ -0,0 +1,59 @@
+// Package app provides alert rule sound files for the SkySpy radar
+package app
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/skyspy/skyspy-go/internal/audio"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// RuleSoundFiles returns the WAV files the alert rules play, each once, in
+// rule order
+func RuleSoundFiles(cfg *config.Config) []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, rule := range effectiveAlertRules(cfg) {
+		for _, action := range rule.Actions {
+			if action.Type == "sound" && audio.IsSoundFile(action.Sound) && !seen[action.Sound] {
+				seen[action.Sound] = true
+				files = append(files, action.Sound)
+			}
+		}
+	}
+	return files
+}
+
+// prepareRuleSounds hooks sound file warnings into the notifications and,
+// with audio on, loads the rules' sound files so a bad one is reported at
+// startup rather than when its alert fires
+func (m *Model) prepareRuleSounds() {
+	if m.alertPlayer == nil {
+		return
+	}
+	m.alertPlayer.SetWarn(func(sound string, err error) {
+		m.notify(m.trf("notify.sound_fallback", filepath.Base(sound)))
+	})
+	if !m.config.Audio.Enabled || !m.caps.Audio {
+		return
+	}
+	for _, sound := range RuleSoundFiles(m.config) {
+		_ = m.alertPlayer.PrepareSound(sound)
+	}
+}
+
+// outputChannelsMsg carries the output device's channel count
+type outputChannelsMsg int
+
+// outputChannelsCmd asks the sound server for the output's channel count,
+// off the update loop since it can run pactl; nil when nothing is panned
+func (m *Model) outputChannelsCmd() tea.Cmd {
+	if m.alertPlayer == nil || !m.config.Audio.Pan {
+		return nil
+	}
+	return func() tea.Msg {
+		return outputChannelsMsg(audio.OutputChannels())
+	}
+}
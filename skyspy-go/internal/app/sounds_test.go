@@ -0,0 +1,67 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/audio"
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func soundRule(id string, sounds ...string) config.AlertRuleConfig {
+	rule := config.AlertRuleConfig{ID: id, Name: id, Enabled: true,
+		Conditions: []config.ConditionConfig{{Type: "military", Value: "true"}}}
+	for _, sound := range sounds {
+		rule.Actions = append(rule.Actions, config.ActionConfig{Type: "sound", Sound: sound})
+	}
+	return rule
+}
+
+func TestRuleSoundFiles(t *testing.T) {
+	cfg := newTestConfig()
+	if files := RuleSoundFiles(cfg); len(files) != 0 {
+		t.Errorf("default rules play no files, got %v", files)
+	}
+
+	cfg.Alerts.Rules = []config.AlertRuleConfig{
+		soundRule("a", "warning", "klaxon.wav"),
+		soundRule("b", "/abs/horn.WAV", "klaxon.wav"),
+	}
+	cfg.Alerts.Rules[1].Actions = append(cfg.Alerts.Rules[1].Actions, config.ActionConfig{Type: "notify", Sound: "x.wav"})
+	files := RuleSoundFiles(cfg)
+	if strings.Join(files, ",") != "klaxon.wav,/abs/horn.WAV" {
+		t.Errorf("RuleSoundFiles = %v", files)
+	}
+}
+
+func TestRuleSounds_MissingFileNotifiedAtStartup(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "klaxon.wav")
+
+	cfg := newTestConfig()
+	cfg.Audio.Enabled = true
+	cfg.Alerts.Rules = []config.AlertRuleConfig{soundRule("a", missing)}
+	m := NewModel(cfg)
+	if !strings.Contains(m.notification, "klaxon.wav") {
+		t.Errorf("notification = %q, want the missing file named", m.notification)
+	}
+
+	cfg.Audio.Enabled = false
+	m = NewModel(cfg)
+	if strings.Contains(m.notification, "klaxon.wav") {
+		t.Error("sound files shouldn't be checked with audio off")
+	}
+}
+
+func TestOutputChannelsCmd(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.alertPlayer = audio.NewAlertPlayer(&m.config.Audio)
+	m.config.Audio.Pan = false
+	if m.outputChannelsCmd() != nil {
+		t.Error("with panning off the sound server shouldn't be asked")
+	}
+	m.config.Audio.Pan = true
+	if m.outputChannelsCmd() == nil {
+		t.Error("with panning on the channel count should be asked for off the update loop")
+	}
+}
@@ -0,0 +1,152 @@
+// Package app provides the split-screen dual radar for the SkySpy radar
+package app
+
+import (
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/ui"
+)
+
+// splitSidebarWidth is the room the sidebar needs beside the radar panes
+const splitSidebarWidth = 34
+
+// toggleSplit shows or hides the second radar pane
+func (m *Model) toggleSplit() {
+	m.config.Display.SplitScreen = !m.config.Display.SplitScreen
+	m.splitFocus = false
+	switch {
+	case !m.config.Display.SplitScreen:
+		m.notify(m.tr("notify.split_off"))
+	case !m.splitFits():
+		m.notify(m.tr("notify.split_narrow"))
+	default:
+		m.notify(m.tr("notify.split_on"))
+	}
+}
+
+// splitMinWidth is the terminal width two panes and the sidebar need
+func (m *Model) splitMinWidth() int {
+	pane := radar.RadarWidth + 2
+	width := 2*pane + 1 + splitSidebarWidth
+	if m.config.Display.ShowAltitudeRibbon {
+		width += ui.RibbonWidth
+	}
+	return width
+}
+
+// splitFits reports whether the terminal is wide enough for two panes. The
+// width is unknown until the first resize, so assume it fits until then.
+func (m *Model) splitFits() bool {
+	return m.width == 0 || m.width >= m.splitMinWidth()
+}
+
+// splitActive reports whether the second pane is shown. Split mode falls
+// back to a single pane while the terminal is too narrow.
+func (m *Model) splitActive() bool {
+	return m.config.Display.SplitScreen && m.splitFits()
+}
+
+// splitFocused reports whether the second pane has the zoom controls
+func (m *Model) splitFocused() bool {
+	return m.splitFocus && m.splitActive()
+}
+
+// switchPane moves the zoom controls to the other pane
+func (m *Model) switchPane() {
+	if !m.splitActive() {
+		return
+	}
+	m.splitFocus = !m.splitFocus
+	if m.splitFocus {
+		m.notify(m.tr("notify.pane_right"))
+	} else {
+		m.notify(m.tr("notify.pane_left"))
+	}
+}
+
+// toggleSplitCenter centers the second pane on the selected aircraft or
+// back on the receiver
+func (m *Model) toggleSplitCenter() {
+	m.splitOnSelected = !m.splitOnSelected
+	if m.splitOnSelected {
+		m.notify(m.tr("notify.split_center_selected"))
+	} else {
+		m.notify(m.tr("notify.split_center_receiver"))
+	}
+}
+
+// splitRangeIndex returns the index of the first range option at or above
+// nm, or the largest option
+func splitRangeIndex(options []int, nm int) int {
+	for i, r := range options {
+		if r >= nm {
+			return i
+		}
+	}
+	return len(options) - 1
+}
+
+// zoomSplit steps the second pane's range by delta options
+func (m *Model) zoomSplit(delta int) {
+	idx := m.splitRangeIdx + delta
+	if idx < 0 || idx >= len(m.rangeOptions) {
+		return
+	}
+	m.splitRangeIdx = idx
+	m.splitTargetRange = float64(m.rangeOptions[idx])
+	m.config.Display.SplitRange = m.rangeOptions[idx]
+	m.notifyRange("notify.right_range", m.rangeOptions[idx])
+}
+
+// splitCenter returns the position the second pane is centered on: the
+// selected aircraft when following it and it has a position, otherwise the
+// receiver
+func (m *Model) splitCenter() (float64, float64) {
+	if m.splitOnSelected {
+		if t, ok := m.aircraft[m.selectedHex]; ok && t.HasLat && t.HasLon {
+			return t.Lat, t.Lon
+		}
+	}
+	return m.displayReceiver()
+}
+
+// aircraftFrom returns the aircraft measured from a scope center other than
+// the receiver; at the receiver they are the aircraft as displayed
+func (m *Model) aircraftFrom(lat, lon float64) map[string]*radar.Target {
+	receiverLat, receiverLon := m.displayReceiver()
+	if lat == receiverLat && lon == receiverLon {
+		return m.displayAircraft()
+	}
+	shown := make(map[string]*radar.Target, len(m.aircraft))
+	for hex, t := range m.aircraft {
+		if !t.HasLat || !t.HasLon {
+			continue
+		}
+		moved := *t
+		moved.Distance, moved.Bearing = radar.HaversineBearing(lat, lon, t.Lat, t.Lon)
+		shown[hex] = &moved
+	}
+	return shown
+}
+
+// renderSplitPane draws the second radar pane. It shares the aircraft and
+// selection with the main pane but leaves the target list order alone.
+func (m *Model) renderSplitPane() string {
+	lat, lon := m.splitCenter()
+	scope, _ := m.drawScope(m.splitRange, lat, lon, m.aircraftFrom(lat, lon), false)
+	scope.SetHighlight(m.splitFocus)
+	return scope.Render()
+}
+
+// joinPanes places two rendered panes side by side
+func joinPanes(left, right string) string {
+	leftLines := strings.Split(left, "\n")
+	rightLines := strings.Split(right, "\n")
+	for i := range leftLines {
+		if i < len(rightLines) {
+			leftLines[i] += " " + rightLines[i]
+		}
+	}
+	return strings.Join(leftLines, "\n")
+}
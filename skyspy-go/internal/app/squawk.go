@@ -0,0 +1,40 @@
+// Package app provides squawk code classification for the SkySpy radar
+package app
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+// applySquawkCodes takes the special squawk codes targets are classified by
+// from the settings. Invalid entries are skipped with a notification.
+func (m *Model) applySquawkCodes() {
+	codes, err := radar.ParseSquawkCodes(m.config.Alerts.Squawks)
+	m.squawkCodes = codes
+	if err != nil {
+		m.notify(m.trf("notify.squawk_error", err.Error()))
+	}
+}
+
+// playSquawkAlert sounds the alert matching a target's squawk severity
+func (m *Model) playSquawkAlert(target *radar.Target) {
+	switch target.SquawkSeverity() {
+	case radar.SquawkEmergency:
+		m.alertPlayer.PlayEmergencyFrom(alertSource(target))
+	case radar.SquawkWarning:
+		m.alertPlayer.PlayWarningFrom(alertSource(target))
+	}
+}
+
+func (m *Model) getSquawkStyle(t *radar.Target) lipgloss.Style {
+	switch t.SquawkSeverity() {
+	case radar.SquawkEmergency:
+		return m.theme.Style(theme.RoleEmergency)
+	case radar.SquawkWarning:
+		return m.theme.Style(theme.RoleWarning)
+	case radar.SquawkInfo:
+		return m.theme.Style(theme.RoleInfo)
+	}
+	return lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+}
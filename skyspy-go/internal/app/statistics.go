@@ -0,0 +1,194 @@
+// Package app provides the statistics view of the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/ui"
+)
+
+// Statistics history: one sample a second for the last hour, kept for
+// the session only
+const (
+	statsInterval = time.Second
+	statsSamples  = 3600
+)
+
+// statsSeries is a ring of the newest samples of one statistic
+type statsSeries struct {
+	samples []float64
+	next    int // where the next sample goes once the ring is full
+}
+
+// add appends a sample, dropping the oldest once statsSamples are held
+func (s *statsSeries) add(v float64) {
+	if len(s.samples) < statsSamples {
+		s.samples = append(s.samples, v)
+		return
+	}
+	s.samples[s.next] = v
+	s.next = (s.next + 1) % statsSamples
+}
+
+// values returns the samples, oldest first
+func (s *statsSeries) values() []float64 {
+	out := make([]float64, 0, len(s.samples))
+	out = append(out, s.samples[s.next:]...)
+	return append(out, s.samples[:s.next]...)
+}
+
+// summary returns the lowest, average and highest sample
+func (s *statsSeries) summary() (lo, avg, hi float64) {
+	if len(s.samples) == 0 {
+		return 0, 0, 0
+	}
+	lo, hi = math.Inf(1), math.Inf(-1)
+	sum := 0.0
+	for _, v := range s.samples {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+		sum += v
+	}
+	return lo, sum / float64(len(s.samples)), hi
+}
+
+// statsHistory holds the series the statistics view charts
+type statsHistory struct {
+	messageRate statsSeries // aircraft messages per second
+	aircraft    statsSeries
+	military    statsSeries
+	maxRange    statsSeries // nm to the farthest aircraft with a position
+
+	sampledAt    time.Time
+	sampledCount int // session messages at the last sample
+}
+
+// sampleStats adds a sample to each series once per interval. A pause,
+// such as a suspend, leaves a single sample rather than a backfill.
+func (m *Model) sampleStats() {
+	h := &m.statsHistory
+	now := m.now()
+	if h.sampledAt.IsZero() {
+		h.sampledAt, h.sampledCount = now, m.sessionMessages
+		return
+	}
+	elapsed := now.Sub(h.sampledAt)
+	if elapsed < statsInterval {
+		return
+	}
+
+	farthest := 0.0
+	for _, t := range m.aircraft {
+		if t.HasLat && t.HasLon {
+			farthest = math.Max(farthest, t.Distance)
+		}
+	}
+	h.messageRate.add(float64(m.sessionMessages-h.sampledCount) / elapsed.Seconds())
+	h.aircraft.add(float64(len(m.aircraft)))
+	h.military.add(float64(m.militaryCount))
+	h.maxRange.add(farthest)
+	h.sampledAt, h.sampledCount = now, m.sessionMessages
+}
+
+// resetStats empties the statistics history
+func (m *Model) resetStats() {
+	m.statsHistory = statsHistory{}
+	m.notify(m.tr("notify.stats_reset"))
+}
+
+// openStatistics shows the statistics view
+func (m *Model) openStatistics() {
+	m.viewMode = ViewStatistics
+}
+
+// handleStatisticsKey resets or closes the statistics view
+func (m *Model) handleStatisticsKey(key string) {
+	switch key {
+	case keyEsc, "i":
+		m.viewMode = ViewRadar
+	case "r", "R":
+		m.resetStats()
+	}
+}
+
+// statisticsWidth is the width inside the statistics page's frame: the
+// terminal's, or the detail page's when the terminal size isn't known
+func (m *Model) statisticsWidth() int {
+	if m.width <= 0 {
+		return detailWidth
+	}
+	return max(m.width-2, 40)
+}
+
+// renderStatistics charts the statistics history, in place of the radar
+// and sidebar
+func (m *Model) renderStatistics() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+	headStyle := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright).Bold(true)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	g := m.glyphs()
+	width := m.statisticsWidth()
+
+	var lines []string
+	row := func(content string) {
+		content = fit(content, width)
+		pad := max(width-lipgloss.Width(content), 0)
+		lines = append(lines, borderStyle.Render(g.DoubleV)+content+strings.Repeat(" ", pad)+borderStyle.Render(g.DoubleV))
+	}
+	lines = append(lines, borderStyle.Render(g.DoubleTL+strings.Repeat(g.DoubleH, width)+g.DoubleTR))
+
+	h := &m.statsHistory
+	span := time.Duration(len(h.aircraft.samples)) * statsInterval
+	row(titleStyle.Render(panelHeading(m.trf("title.statistics", formatAge(span)), width)))
+	lines = append(lines, borderStyle.Render(g.DoubleTeeLeft+strings.Repeat(g.DoubleH, width)+g.DoubleTeeRight))
+
+	series := []struct {
+		title string
+		data  *statsSeries
+		unit  string
+	}{
+		{m.tr("stats.message_rate"), &h.messageRate, "/s"},
+		{m.tr("stats.aircraft"), &h.aircraft, ""},
+		{m.tr("stats.military"), &h.military, ""},
+		{m.tr("stats.max_range"), &h.maxRange, m.distUnit()},
+	}
+	// The charts share the radar's height below the title, less a
+	// heading each
+	chartRows := max((radar.RadarHeight-len(lines))/len(series)-1, 1)
+	chart := ui.NewChart(m.theme, width-2-ui.ChartLabelWidth, chartRows)
+	for _, s := range series {
+		values := s.data.values()
+		if s.data == &h.maxRange {
+			for i, v := range values {
+				values[i], _ = m.inUnit(v)
+			}
+		}
+		lo, avg, hi := minAvgMax(values)
+		summary := m.trf("stats.summary",
+			ui.ChartValue(lo)+s.unit, ui.ChartValue(avg)+s.unit, ui.ChartValue(hi)+s.unit)
+		row(headStyle.Render(fmt.Sprintf("  %-20s", s.title)) + textDim.Render(summary))
+		for _, line := range chart.Render(values) {
+			row("  " + line)
+		}
+	}
+
+	// Fill to the radar's height, with the keys on the last line
+	for len(lines) < radar.RadarHeight {
+		row("")
+	}
+	row(textDim.Render("  " + m.tr("stats.help")))
+	lines = append(lines, borderStyle.Render(g.DoubleBL+strings.Repeat(g.DoubleH, width)+g.DoubleBR))
+	return strings.Join(lines, "\n")
+}
+
+// minAvgMax returns the lowest, average and highest of values, or zeros
+// when there are none
+func minAvgMax(values []float64) (lo, avg, hi float64) {
+	s := statsSeries{samples: values}
+	return s.summary()
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestStatistics_SamplesOncePerSecond(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.updateTarget(flying("STA001", 52.5176), true) // 9nm north
+	m.updateStats()                                 // starts the clock
+
+	m.sessionMessages += 10
+	*clock = clock.Add(500 * time.Millisecond)
+	m.updateStats()
+	if n := len(m.statsHistory.aircraft.samples); n != 0 {
+		t.Fatalf("sampled %d times within a second", n)
+	}
+
+	*clock = clock.Add(1500 * time.Millisecond)
+	m.updateStats()
+	h := &m.statsHistory
+	if len(h.aircraft.samples) != 1 {
+		t.Fatalf("expected one sample, got %d", len(h.aircraft.samples))
+	}
+	if got := h.messageRate.values()[0]; got != 5 {
+		t.Errorf("message rate = %v, want 5 over 2s", got)
+	}
+	if got := h.aircraft.values()[0]; got != 1 {
+		t.Errorf("aircraft = %v, want 1", got)
+	}
+	if got := h.maxRange.values()[0]; got < 8.9 || got > 9.1 {
+		t.Errorf("max range = %.2f, want 9", got)
+	}
+}
+
+func TestStatistics_SeriesKeepsTheNewestHour(t *testing.T) {
+	var s statsSeries
+	for i := 0; i < statsSamples+5; i++ {
+		s.add(float64(i))
+	}
+	values := s.values()
+	if len(values) != statsSamples || values[0] != 5 || values[len(values)-1] != statsSamples+4 {
+		t.Errorf("got %d samples from %v to %v", len(values), values[0], values[len(values)-1])
+	}
+	if lo, avg, hi := minAvgMax([]float64{2, 4, 9}); lo != 2 || avg != 5 || hi != 9 {
+		t.Errorf("minAvgMax = %v %v %v", lo, avg, hi)
+	}
+}
+
+func TestStatistics_ViewAndReset(t *testing.T) {
+	m, clock := newTrackingModel()
+	for i := 0; i < 3; i++ {
+		m.updateStats()
+		*clock = clock.Add(time.Second)
+	}
+
+	pressKey(m, "i")
+	if m.viewMode != ViewStatistics {
+		t.Fatalf("i should open the statistics view, got %v", m.viewMode)
+	}
+	page := ansi.Strip(m.View())
+	for _, want := range []string{"STATISTICS", "Messages", "Max range", "min 0/s  avg 0/s  max 0/s"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("statistics page missing %q:\n%s", want, page)
+		}
+	}
+
+	// The history survives leaving and reopening the view
+	pressKeys(m, "esc", "i")
+	if n := len(m.statsHistory.aircraft.samples); n != 2 {
+		t.Fatalf("expected 2 samples after switching views, got %d", n)
+	}
+
+	pressKey(m, "r")
+	if n := len(m.statsHistory.aircraft.samples); n != 0 || m.viewMode != ViewStatistics {
+		t.Errorf("r should clear the history in place, %d samples left", n)
+	}
+	pressKey(m, "i")
+	if m.viewMode != ViewRadar {
+		t.Errorf("i should close the statistics view, got %v", m.viewMode)
+	}
+}
@@ -0,0 +1,497 @@
+// Package app provides the configurable status bar for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusBarWidth is the width inside the status bar's borders
+const statusBarWidth = 98
+
+// statusSegment is a part of the status bar that can be chosen, ordered
+// and prioritized in the settings
+type statusSegment struct {
+	name     string
+	priority int // default; higher is kept longer when the bar is full
+	render   func(m *Model) (statusCell, bool)
+}
+
+// statusCell is a segment as drawn this frame. It is drawn at pref
+// columns when there is room and can be squeezed down to min.
+type statusCell struct {
+	pref int
+	min  int
+	draw func(width int) string
+}
+
+// fixedCell is a cell that is drawn whole or not at all
+func fixedCell(s string) statusCell {
+	w := lipgloss.Width(s)
+	return statusCell{pref: w, min: w, draw: func(int) string { return s }}
+}
+
+// textCell is a cell of text that can be cut to as little as minChars
+func textCell(style lipgloss.Style, text string, minChars int) statusCell {
+	pref := lipgloss.Width(text) + 2
+	return statusCell{
+		pref: pref,
+		min:  min(pref, minChars+2),
+		draw: func(width int) string { return style.Render(" " + fit(text, width-2) + " ") },
+	}
+}
+
+// statusSegments are the segments the status bar can show. Their order
+// here is the default order.
+var statusSegments = []statusSegment{
+	{"connection", 90, (*Model).connectionCell},
+	{"counts", 70, (*Model).countsCell},
+	{"range", 100, (*Model).rangeCell},
+	{"heading", 60, (*Model).headingCell},
+	{"follow", 80, (*Model).followCell},
+	{"clock-skew", 50, (*Model).clockSkewCell},
+	{"privacy", 75, (*Model).privacyCell},
+	{"kiosk", 65, (*Model).kioskCell},
+	{"dnd", 55, (*Model).dndCell},
+	{"rec", 72, (*Model).recCell},
+	{"filters", 85, (*Model).filtersCell},
+	{"overlays", 20, (*Model).overlaysCell},
+	{"theme", 10, (*Model).themeCell},
+	{"clock", 40, (*Model).clockCell},
+	{"notification", 95, (*Model).notificationCell},
+	{"msg-rate", 30, (*Model).msgRateCell},
+}
+
+// defaultStatusSegments are the segments shown when the settings don't
+// list any: all but the message rate
+func defaultStatusSegments() []string {
+	var names []string
+	for _, seg := range statusSegments {
+		if seg.name != "msg-rate" {
+			names = append(names, seg.name)
+		}
+	}
+	return names
+}
+
+// lookupStatusSegment finds a segment by name
+func lookupStatusSegment(name string) (statusSegment, bool) {
+	for _, seg := range statusSegments {
+		if seg.name == name {
+			return seg, true
+		}
+	}
+	return statusSegment{}, false
+}
+
+// applyStatusBar sets up the status bar from the settings. Segment names
+// are matched ignoring case and surrounding space; ones it doesn't know, in
+// the list or the priorities, are left out with a notification.
+func (m *Model) applyStatusBar() {
+	names := m.config.Display.StatusBar
+	if len(names) == 0 {
+		names = defaultStatusSegments()
+	}
+	priorities := make(map[string]int, len(m.config.Display.StatusPriority))
+	for name, p := range m.config.Display.StatusPriority {
+		priorities[strings.ToLower(strings.TrimSpace(name))] = p
+	}
+
+	var unknown []string
+	seen := make(map[string]bool)
+	m.statusLayout = m.statusLayout[:0]
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		seg, ok := lookupStatusSegment(name)
+		switch {
+		case !ok:
+			unknown = append(unknown, name)
+		case !seen[name]:
+			seen[name] = true
+			if p, set := priorities[name]; set {
+				seg.priority = p
+			}
+			m.statusLayout = append(m.statusLayout, seg)
+		}
+	}
+
+	// Map order isn't stable, so these are sorted for a steady warning
+	var unknownPriority []string
+	for name := range priorities {
+		if _, ok := lookupStatusSegment(name); !ok {
+			unknownPriority = append(unknownPriority, name)
+		}
+	}
+	sort.Strings(unknownPriority)
+	unknown = append(unknown, unknownPriority...)
+	if len(unknown) > 0 {
+		m.notify(m.trf("notify.status_unknown", strings.Join(unknown, ", ")))
+	}
+}
+
+// statusPromptShown reports whether the range segment is holding a prompt
+// being typed, which is shown even when the segment isn't chosen
+func (m *Model) statusPromptShown() bool {
+	return m.rangeEntryOpen || m.jumpActive()
+}
+
+// layoutStatus fits cells into width, separated by sep. The lowest
+// priority cells are dropped until the rest fit at their minimum widths;
+// the space left then goes to the highest priority cells first, up to
+// their preferred widths. Equal priorities favour the earlier cell.
+func layoutStatus(cells []statusCell, priorities []int, width int, sep string) string {
+	sepWidth := lipgloss.Width(sep)
+	kept := make([]bool, len(cells))
+	for i := range kept {
+		kept[i] = true
+	}
+
+	// Lowest priority first; of equals, the later one first
+	order := make([]int, len(cells))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if priorities[order[a]] != priorities[order[b]] {
+			return priorities[order[a]] < priorities[order[b]]
+		}
+		return order[a] > order[b]
+	})
+
+	need := func() int {
+		total, n := 0, 0
+		for i, c := range cells {
+			if kept[i] {
+				total += c.min
+				n++
+			}
+		}
+		if n > 1 {
+			total += (n - 1) * sepWidth
+		}
+		return total
+	}
+	for _, i := range order {
+		if need() <= width {
+			break
+		}
+		kept[i] = false
+	}
+
+	widths := make([]int, len(cells))
+	spare := width - need()
+	for i, c := range cells {
+		widths[i] = c.min
+	}
+	for k := len(order) - 1; k >= 0 && spare > 0; k-- {
+		i := order[k]
+		if !kept[i] {
+			continue
+		}
+		grow := min(cells[i].pref-cells[i].min, spare)
+		widths[i] += grow
+		spare -= grow
+	}
+
+	var parts []string
+	for i, c := range cells {
+		if kept[i] {
+			parts = append(parts, c.draw(widths[i]))
+		}
+	}
+	line := strings.Join(parts, sep)
+	if pad := width - lipgloss.Width(line); pad > 0 {
+		line += strings.Repeat(" ", pad)
+	}
+	return line
+}
+
+// renderStatusLine lays out the status bar's segments in width columns
+func (m *Model) renderStatusLine(width int) string {
+	borderDim := lipgloss.NewStyle().Foreground(m.theme.BorderDim)
+
+	var cells []statusCell
+	var priorities []int
+	layout := m.statusLayout
+	if m.statusPromptShown() && !m.statusShows("range") {
+		seg, _ := lookupStatusSegment("range")
+		layout = append([]statusSegment{seg}, layout...)
+	}
+	for _, seg := range layout {
+		if cell, ok := seg.render(m); ok {
+			cells = append(cells, cell)
+			priorities = append(priorities, seg.priority)
+		}
+	}
+	return layoutStatus(cells, priorities, width, borderDim.Render(m.glyphs().V))
+}
+
+// statusShows reports whether a segment is chosen for the status bar
+func (m *Model) statusShows(name string) bool {
+	for _, seg := range m.statusLayout {
+		if seg.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// messageRateWindow is how often the message rate is worked out
+const messageRateWindow = 5 * time.Second
+
+// sampleMessageRate works out the aircraft message rate once per window
+func (m *Model) sampleMessageRate() {
+	now := m.now()
+	if m.msgRateAt.IsZero() {
+		m.msgRateAt, m.msgRateCount = now, m.sessionMessages
+		return
+	}
+	elapsed := now.Sub(m.msgRateAt)
+	if elapsed < messageRateWindow {
+		return
+	}
+	m.msgRate = float64(m.sessionMessages-m.msgRateCount) / elapsed.Seconds()
+	m.msgRateAt, m.msgRateCount = now, m.sessionMessages
+}
+
+func (m *Model) connectionCell() (statusCell, bool) {
+	if sources, ok := m.feedSources(); ok {
+		return m.sourcesCell(sources), true
+	}
+	g := m.glyphs()
+	if !m.IsConnected() {
+		errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error).Bold(true)
+		off := errorStyle.Render(" " + g.Off + " " + m.tr("status.off") + " ")
+		attempt, ok := m.reconnecting()
+		if !ok {
+			return fixedCell(off), true
+		}
+		warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning).Bold(true)
+		full := warningStyle.Render(" " + g.Off + " " + m.trf("status.reconnecting", attempt) + " ")
+		return statusCell{
+			pref: lipgloss.Width(full),
+			min:  lipgloss.Width(off),
+			draw: func(width int) string {
+				if width >= lipgloss.Width(full) {
+					return full
+				}
+				return off
+			},
+		}, true
+	}
+	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success).Bold(true)
+	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	ind := g.Live
+	if !m.blink {
+		ind = g.Off
+	}
+	on := successStyle.Render(" " + ind + " " + m.tr("status.on") + " ")
+	age, quiet := m.feedQuietAge()
+	if !quiet {
+		return fixedCell(on), true
+	}
+	full := on + warningStyle.Render(m.tr("status.idle")+" "+formatAge(age)+" ")
+	return statusCell{
+		pref: lipgloss.Width(full),
+		min:  lipgloss.Width(on),
+		draw: func(width int) string {
+			if width >= lipgloss.Width(full) {
+				return full
+			}
+			return on
+		},
+	}, true
+}
+
+// sourcesCell shows each receiver of a merged feed: green while it is
+// connected, amber while it waits to reconnect and red when it won't. It
+// shrinks to just the indicators.
+func (m *Model) sourcesCell(sources []SourceStatus) statusCell {
+	g := m.glyphs()
+	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success).Bold(true)
+	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning).Bold(true)
+	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error).Bold(true)
+
+	full, short := " ", " "
+	for i, src := range sources {
+		style, ind, label := errorStyle, g.Off, src.Name
+		switch {
+		case src.Connected:
+			style, ind = successStyle, g.Live
+		case src.Attempt > 0:
+			style, label = warningStyle, m.trf("status.source_retry", src.Name, src.Attempt)
+		}
+		if i > 0 {
+			full += " "
+		}
+		full += style.Render(ind + " " + label)
+		short += style.Render(ind)
+	}
+	full, short = full+" ", short+" "
+	return statusCell{
+		pref: lipgloss.Width(full),
+		min:  lipgloss.Width(short),
+		draw: func(width int) string {
+			if width >= lipgloss.Width(full) {
+				return full
+			}
+			return short
+		},
+	}
+}
+
+func (m *Model) countsCell() (statusCell, bool) {
+	style := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright)
+	return fixedCell(style.Render(fmt.Sprintf(" %3d ", len(m.aircraft)))), true
+}
+
+func (m *Model) rangeCell() (statusCell, bool) {
+	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	switch {
+	case m.rangeEntryOpen:
+		return fixedCell(primaryBright.Render(" " + m.tr("status.range_entry") + " " + m.rangeEntry + "_ " + m.distUnit() + " ")), true
+	case m.jumpActive() && m.jumpMissed:
+		return fixedCell(warningStyle.Render(" " + m.tr("status.jump") + " " + m.jumpPrefix + "_ ")), true
+	case m.jumpActive():
+		return fixedCell(primaryBright.Render(" " + m.tr("status.jump") + " " + m.jumpPrefix + "_ ")), true
+	}
+	return fixedCell(primaryBright.Render(" " + m.rangeLabel(int(m.targetRange)) + " ")), true
+}
+
+// headingCell reminds that north is no longer at the top
+func (m *Model) headingCell() (statusCell, bool) {
+	if !m.IsHeadingUp() {
+		return statusCell{}, false
+	}
+	style := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+	return fixedCell(style.Render(fmt.Sprintf(" %s%s%03.0f ", m.tr("status.hdg"), m.glyphs().ArrowUp, m.targetRotation))), true
+}
+
+// followCell shows follow mode, or a view panned away from the receiver
+func (m *Model) followCell() (statusCell, bool) {
+	if label := m.Following(); label != "" {
+		style := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+		return textCell(style, m.trf("status.following", label), 6), true
+	}
+	if m.panned {
+		style := lipgloss.NewStyle().Foreground(m.theme.Warning)
+		return fixedCell(style.Render(" " + m.tr("status.panned") + " ")), true
+	}
+	return statusCell{}, false
+}
+
+// clockSkewCell stays up until the local clock agrees with the server's
+func (m *Model) clockSkewCell() (statusCell, bool) {
+	if !m.IsClockSkewed() {
+		return statusCell{}, false
+	}
+	style := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	return fixedCell(style.Render(" " + m.tr("status.clock") + formatSkew(m.clockSkew) + " ")), true
+}
+
+func (m *Model) privacyCell() (statusCell, bool) {
+	if !m.IsPrivacyMode() {
+		return statusCell{}, false
+	}
+	style := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	return fixedCell(style.Render(" " + m.glyphs().Approx + m.tr("status.pos") + " ")), true
+}
+
+// kioskCell shows kiosk mode, and whether the controls are locked
+func (m *Model) kioskCell() (statusCell, bool) {
+	if m.kioskLocked() {
+		style := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+		return fixedCell(style.Render(" " + m.glyphs().Lock + m.tr("status.kiosk") + " ")), true
+	}
+	if m.kiosk != nil {
+		style := lipgloss.NewStyle().Foreground(m.theme.Warning)
+		return fixedCell(style.Render(" " + m.tr("status.kiosk_unlocked") + " ")), true
+	}
+	return statusCell{}, false
+}
+
+// dndCell shows audio alerts silenced by quiet hours or the override
+func (m *Model) dndCell() (statusCell, bool) {
+	if !m.doNotDisturb() {
+		return statusCell{}, false
+	}
+	style := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	return fixedCell(style.Render(" " + m.glyphs().Quiet + m.tr("status.dnd") + " ")), true
+}
+
+// recCell shows that aircraft messages are being logged to disk
+func (m *Model) recCell() (statusCell, bool) {
+	if !m.IsSessionLogging() {
+		return statusCell{}, false
+	}
+	style := lipgloss.NewStyle().Foreground(m.theme.Error).Bold(true)
+	return fixedCell(style.Render(" " + m.tr("status.rec") + " ")), true
+}
+
+func (m *Model) filtersCell() (statusCell, bool) {
+	var filters []string
+	if m.config.Filters.MilitaryOnly {
+		filters = append(filters, m.tr("status.mil"))
+	}
+	if m.config.Filters.HideGround {
+		filters = append(filters, m.tr("status.air"))
+	}
+	if m.IsFilterActive() {
+		filterDesc := m.searchFilter.Description()
+		if len(filterDesc) > 15 {
+			filterDesc = filterDesc[:15] + "..."
+		}
+		filters = append(filters, filterDesc)
+	}
+	if len(filters) == 0 {
+		return statusCell{}, false
+	}
+	style := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	return textCell(style, strings.Join(filters, "/"), 3), true
+}
+
+func (m *Model) overlaysCell() (statusCell, bool) {
+	enabled := 0
+	for _, ov := range m.overlayManager.GetOverlayList() {
+		if ov.Enabled {
+			enabled++
+		}
+	}
+	if enabled == 0 {
+		return statusCell{}, false
+	}
+	style := lipgloss.NewStyle().Foreground(m.theme.Info)
+	return fixedCell(style.Render(fmt.Sprintf(" %s:%d ", m.tr("status.ovl"), enabled))), true
+}
+
+func (m *Model) themeCell() (statusCell, bool) {
+	style := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	name := m.theme.Name
+	if len(name) > 12 {
+		name = name[:12]
+	}
+	return textCell(style, name, 6), true
+}
+
+func (m *Model) clockCell() (statusCell, bool) {
+	style := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright)
+	return fixedCell(style.Render(" " + m.locale.Clock(m.now()) + " ")), true
+}
+
+func (m *Model) notificationCell() (statusCell, bool) {
+	if m.notification == "" || m.notificationTime <= 0 {
+		return statusCell{}, false
+	}
+	style := lipgloss.NewStyle().Foreground(m.theme.Info).Bold(true)
+	return textCell(style, m.notification, 16), true
+}
+
+func (m *Model) msgRateCell() (statusCell, bool) {
+	style := lipgloss.NewStyle().Foreground(m.theme.Info)
+	return fixedCell(style.Render(" " + m.trf("status.msg_rate", m.locale.Float(m.msgRate, 1)) + " ")), true
+}
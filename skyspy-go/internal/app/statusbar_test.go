@@ -0,0 +1,144 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden status bars in testdata")
+
+// statusScene is a fixed status bar: every segment, a filter and a long
+// notification competing for room
+func statusScene() *Model {
+	cfg := newTestConfig()
+	cfg.Display.StatusBar = []string{"connection", "counts", "range", "filters", "overlays", "theme", "clock", "msg-rate", "notification"}
+	cfg.Filters.MilitaryOnly = true
+	m := NewModel(cfg)
+	clock := time.Date(2026, 3, 1, 12, 34, 56, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	for i := 0; i < 12; i++ {
+		m.updateTarget(flying(fmt.Sprintf("AE%04d", i), 52.40), true)
+	}
+	m.msgRate = 42.5
+	m.notify("Emergency squawk 7700 from KLM123 over the North Sea")
+	return m
+}
+
+func TestStatusBar_Golden(t *testing.T) {
+	for _, width := range []int{98, 72, 48, 32} {
+		t.Run(fmt.Sprint(width), func(t *testing.T) {
+			m := statusScene()
+			line := m.renderStatusLine(width)
+			if w := lipgloss.Width(line); w != width {
+				t.Errorf("status line is %d wide, want %d", w, width)
+			}
+			got := ansi.Strip(line) + "\n"
+
+			path := filepath.Join("testdata", fmt.Sprintf("statusbar_%d.golden", width))
+			if *updateGolden {
+				if err := os.MkdirAll("testdata", 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading golden status bar (run with -update to create it): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("status bar differs from %s:\ngot:  %q\nwant: %q", path, got, want)
+			}
+		})
+	}
+}
+
+func TestStatusBar_OrderAndPriority(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.StatusBar = []string{"clock", "range", "theme"}
+	cfg.Display.StatusPriority = map[string]int{"theme": 200, "range": 1}
+	m := NewModel(cfg)
+	m.now = func() time.Time { return time.Date(2026, 3, 1, 9, 5, 0, 0, time.UTC) }
+
+	name := m.theme.Name[:min(12, len(m.theme.Name))]
+	line := ansi.Strip(m.renderStatusLine(statusBarWidth))
+	clock, rng, at := strings.Index(line, "09:05"), strings.Index(line, "100nm"), strings.Index(line, name)
+	if clock < 0 || !(clock < rng && rng < at) {
+		t.Errorf("segments should follow the configured order: %q", line)
+	}
+	if strings.Contains(line, "OFF") {
+		t.Errorf("segments not listed shouldn't be shown: %q", line)
+	}
+
+	// The range goes first, now the lowest priority, then the clock
+	if line := ansi.Strip(m.renderStatusLine(20)); strings.Contains(line, "100nm") || !strings.Contains(line, "09:05") {
+		t.Errorf("the range should be dropped first: %q", line)
+	}
+	if line := ansi.Strip(m.renderStatusLine(len(name) + 2)); strings.Contains(line, "09:05") || !strings.Contains(line, name) {
+		t.Errorf("the clock should be dropped before the theme: %q", line)
+	}
+}
+
+func TestStatusBar_PriorityKeysNormalized(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.StatusBar = []string{"clock", "range"}
+	cfg.Display.StatusPriority = map[string]int{" Range ": 7, "CLOCK": 3}
+	m := NewModel(cfg)
+
+	if m.notification != "" {
+		t.Errorf("the keys should be recognized, got %q", m.notification)
+	}
+	if len(m.statusLayout) != 2 || m.statusLayout[0].priority != 3 || m.statusLayout[1].priority != 7 {
+		t.Errorf("priorities should apply whatever the case and spacing: %+v", m.statusLayout)
+	}
+}
+
+func TestStatusBar_UnknownSegmentsWarn(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.StatusBar = []string{"Range", "weather", "range", "clock"}
+	cfg.Display.StatusPriority = map[string]int{"zulu": 5, "alpha": 1}
+	m := NewModel(cfg)
+
+	if m.notification != "Unknown status bar segments ignored: weather, alpha, zulu" {
+		t.Errorf("notification = %q", m.notification)
+	}
+	if len(m.statusLayout) != 2 || m.statusLayout[0].name != "range" || m.statusLayout[1].name != "clock" {
+		t.Errorf("layout should keep range and clock once each, got %d segments", len(m.statusLayout))
+	}
+}
+
+func TestStatusBar_PromptShownWithoutRange(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.StatusBar = []string{"clock"}
+	m := NewModel(cfg)
+
+	m.rangeEntryOpen, m.rangeEntry = true, "25"
+	if line := ansi.Strip(m.renderStatusBar()); !strings.Contains(line, "25_ nm") {
+		t.Errorf("the range prompt should show while typing: %q", line)
+	}
+}
+
+func TestStatusBar_MessageRate(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.sampleMessageRate()
+	m.sessionMessages += 50
+	*clock = clock.Add(2 * time.Second)
+	m.sampleMessageRate()
+	if m.msgRate != 0 {
+		t.Errorf("the rate shouldn't change within the window, got %v", m.msgRate)
+	}
+	*clock = clock.Add(3 * time.Second)
+	m.sampleMessageRate()
+	if m.msgRate != 10 {
+		t.Errorf("msgRate = %v, want 10", m.msgRate)
+	}
+}
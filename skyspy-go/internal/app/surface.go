@@ -0,0 +1,76 @@
+// Package app provides the airport surface mode for the SkySpy radar
+package app
+
+import (
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// Surface mode settings, cycled with Ctrl+G
+const (
+	surfaceAuto = iota // on at or below the configured surface range
+	surfaceOn
+	surfaceOff
+)
+
+// fieldSearchNM is how far from the receiver an airport point in the
+// overlays may be to give the field elevation
+const fieldSearchNM = 20
+
+// updateFieldElevation looks up the field elevation from the nearest
+// airport point in the overlays; call it when overlays are added or removed
+func (m *Model) updateFieldElevation() {
+	lat, lon, _ := m.receiverPosition()
+	m.overlayElevation, _ = geo.NearestElevation(m.overlayManager.GetOverlays(), lat, lon, fieldSearchNM)
+}
+
+// fieldElevation returns the elevation (ft) at or below which targets are
+// taken to be on the ground: the configured one, else the nearest airport
+// point in the overlays, else sea level
+func (m *Model) fieldElevation() int {
+	if e := m.config.Radar.FieldElevation; e != nil {
+		return *e
+	}
+	return m.overlayElevation
+}
+
+// surfaceActive reports whether a scope at rangeNM draws in surface mode
+func (m *Model) surfaceActive(rangeNM float64) bool {
+	switch m.surfaceMode {
+	case surfaceOn:
+		return true
+	case surfaceOff:
+		return false
+	}
+	limit := m.config.Radar.SurfaceRange
+	return limit > 0 && rangeNM <= float64(limit)
+}
+
+// cycleSurfaceMode steps surface mode from automatic to on to off
+func (m *Model) cycleSurfaceMode() {
+	m.surfaceMode = (m.surfaceMode + 1) % 3
+	switch m.surfaceMode {
+	case surfaceOn:
+		m.notify(m.tr("notify.surface_on"))
+	case surfaceOff:
+		m.notify(m.tr("notify.surface_off"))
+	default:
+		m.notifyRange("notify.surface_auto", m.config.Radar.SurfaceRange)
+	}
+}
+
+// onGround reports whether a target is on the airport surface
+func (m *Model) onGround(t *radar.Target) bool {
+	return t.OnGround(m.fieldElevation())
+}
+
+// dropGroundTrails leaves the trails of ground targets out of a scope in
+// surface mode, where they would only smear across the apron
+func (m *Model) dropGroundTrails(trails map[string][]radar.TrailPoint, targets map[string]*radar.Target) map[string][]radar.TrailPoint {
+	for hex := range trails {
+		if t, ok := targets[hex]; ok && m.onGround(t) {
+			delete(trails, hex)
+		}
+	}
+	return trails
+}
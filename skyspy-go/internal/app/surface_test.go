@@ -0,0 +1,107 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+func TestSurfaceMode_AutoAndCycle(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	if !m.surfaceActive(10) || !m.surfaceActive(5) {
+		t.Error("surface mode should turn on at or below the surface range")
+	}
+	if m.surfaceActive(25) {
+		t.Error("surface mode should stay off above the surface range")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if !m.surfaceActive(100) {
+		t.Error("Ctrl+G should force surface mode on")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.surfaceActive(5) {
+		t.Error("a second Ctrl+G should force surface mode off")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if !m.surfaceActive(5) || m.surfaceActive(25) {
+		t.Error("a third Ctrl+G should return to automatic")
+	}
+
+	m.config.Radar.SurfaceRange = 0
+	if m.surfaceActive(1) {
+		t.Error("a surface range of 0 should leave surface mode to Ctrl+G")
+	}
+}
+
+func TestSurfaceMode_FieldElevation(t *testing.T) {
+	m := newOverlayLoadModel(t, "airports.geojson")
+	m.overlayLoader = func(path string) (*geo.GeoOverlay, error) {
+		return &geo.GeoOverlay{Name: "airports", Features: []geo.GeoFeature{{
+			Type:       geo.OverlayPoint,
+			Points:     []geo.GeoPoint{{Lat: 52.31, Lon: 4.76}},
+			Properties: map[string]interface{}{"name": "EHAM", "elevation": -11.0},
+		}}}, nil
+	}
+	if m.fieldElevation() != 0 {
+		t.Errorf("field elevation should be sea level before overlays load, got %d", m.fieldElevation())
+	}
+
+	m.LoadOverlays()
+	if m.fieldElevation() != -11 {
+		t.Errorf("field elevation should come from the nearest airport, got %d", m.fieldElevation())
+	}
+
+	m.config.Radar.FieldElevation = intPtr(1200)
+	if m.fieldElevation() != 1200 {
+		t.Errorf("a configured field elevation should win, got %d", m.fieldElevation())
+	}
+}
+
+func TestSurfaceMode_GroundTargets(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Radar.FieldElevation = intPtr(100)
+	m := NewModel(cfg)
+
+	m.updateTarget(&codec.Aircraft{Hex: "GND001", Reg: " PH-BXA ", OnGround: true}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "GND002", AltBaro: intPtr(75)}, true)
+	m.updateTarget(&codec.Aircraft{Hex: "AIR001", AltBaro: intPtr(3500)}, true)
+	m.updateStats()
+
+	if got := m.aircraft["GND001"]; !got.Ground || got.Reg != "PH-BXA" {
+		t.Errorf("ground flag and registration should be kept, got %v %q", got.Ground, got.Reg)
+	}
+	if m.surfaceCount != 2 {
+		t.Errorf("surface count = %d, want 2", m.surfaceCount)
+	}
+	m.width, m.height = 160, 50
+	if stats := ansi.Strip(m.renderStatsPanel()); !strings.Contains(stats, "SFC") {
+		t.Errorf("the stats panel should count surface targets:\n%s", stats)
+	}
+
+	trails := map[string][]radar.TrailPoint{"GND001": {{}}, "GND002": {{}}, "AIR001": {{}}}
+	trails = m.dropGroundTrails(trails, m.aircraft)
+	if len(trails) != 1 || trails["AIR001"] == nil {
+		t.Errorf("only the airborne trail should be kept, got %v", trails)
+	}
+}
+
+func TestSurfaceMode_RibbonSkipsGround(t *testing.T) {
+	m := NewModel(newTestConfig())
+	m.updateTarget(&codec.Aircraft{Hex: "GND001", OnGround: true, AltBaro: intPtr(0)}, true)
+	m.sortedTargets = []string{"GND001"}
+
+	m.maxRange = 5
+	surface := strings.Join(m.renderAltitudeRibbon(), "\n")
+	m.maxRange = 50
+	airborne := strings.Join(m.renderAltitudeRibbon(), "\n")
+	if surface == airborne {
+		t.Error("the ribbon should leave ground targets off in surface mode")
+	}
+}
@@ -0,0 +1,32 @@
+// Package app provides terminal suspend/resume handling for the SkySpy radar
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// IsSuspended reports whether the program is suspended to the shell
+func (m *Model) IsSuspended() bool {
+	return m.suspended
+}
+
+// suspend hands the terminal back to the shell. Bubble Tea leaves the alt
+// screen, shows the cursor and stops the process with SIGTSTP; the WebSocket
+// client is left running so no data is lost across the suspend.
+func (m *Model) suspend() (tea.Model, tea.Cmd) {
+	m.suspended = true
+	return m, tea.Suspend
+}
+
+// resume restores the screen after SIGCONT. The terminal may have been
+// resized or scribbled on while we were stopped, so re-enter the alt screen,
+// re-enable the mouse, re-query the size and force a full repaint.
+func (m *Model) resume() (tea.Model, tea.Cmd) {
+	m.suspended = false
+	return m, tea.Batch(
+		tea.EnterAltScreen,
+		tea.EnableMouseCellMotion,
+		tea.ClearScreen,
+		tea.WindowSize(),
+	)
+}
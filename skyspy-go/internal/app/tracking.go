@@ -0,0 +1,79 @@
+// Package app provides per-target session tracking time and distance for
+// the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/units"
+)
+
+// departedTrack is the tracking of a removed target, kept so a target that
+// returns within the grace period carries on rather than starting afresh
+type departedTrack struct {
+	firstSeen time.Time
+	trackNM   float64
+	until     time.Time
+}
+
+// startTracking sets when a new target was first seen and how far it has
+// flown: its earlier tracking if it has only briefly dropped out, otherwise
+// from now
+func (m *Model) startTracking(t *radar.Target) {
+	t.FirstSeen = m.now()
+	if d, ok := m.departed[t.Hex]; ok {
+		delete(m.departed, t.Hex)
+		if m.now().Before(d.until) {
+			t.FirstSeen, t.TrackNM = d.firstSeen, d.trackNM
+		}
+	}
+}
+
+// continueTracking carries a target's tracking over from its previous
+// state, adding the distance the trail tracker measured for this update
+func continueTracking(t, prev *radar.Target, flown float64) {
+	if prev != nil {
+		t.FirstSeen, t.TrackNM = prev.FirstSeen, prev.TrackNM
+	}
+	t.TrackNM += flown
+}
+
+// rememberTracking keeps a removed target's tracking for the grace period
+// in which a return counts as the same flight (selection_grace)
+func (m *Model) rememberTracking(t *radar.Target) {
+	grace := m.selectionGrace()
+	if grace == 0 || t.FirstSeen.IsZero() {
+		return
+	}
+	m.departed[t.Hex] = departedTrack{firstSeen: t.FirstSeen, trackNM: t.TrackNM, until: m.now().Add(grace)}
+}
+
+// expireDeparted forgets departed targets whose grace period has ended
+func (m *Model) expireDeparted(now time.Time) {
+	for hex, d := range m.departed {
+		if !now.Before(d.until) {
+			delete(m.departed, hex)
+		}
+	}
+}
+
+// formatTracked describes how long a target has been tracked and how far it
+// has flown in unit, e.g. "23m 187nm"
+func formatTracked(t *radar.Target, now time.Time, unit string) string {
+	if t.FirstSeen.IsZero() {
+		return ""
+	}
+	age := now.Sub(t.FirstSeen)
+	var span string
+	switch {
+	case age < time.Minute:
+		span = fmt.Sprintf("%ds", int(age.Seconds()))
+	case age < time.Hour:
+		span = fmt.Sprintf("%dm", int(age.Minutes()))
+	default:
+		span = fmt.Sprintf("%dh%02dm", int(age.Hours()), int(age.Minutes())%60)
+	}
+	return fmt.Sprintf("%s %.0f%s", span, units.FromNM(t.TrackNM, unit), unit)
+}
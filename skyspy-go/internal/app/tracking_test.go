@@ -0,0 +1,98 @@
+package app
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// flying returns a report for an aircraft at lat on the receiver's meridian,
+// moving at a speed that keeps 1.2nm steps 10s apart plausible
+func flying(hex string, lat float64) *codec.Aircraft {
+	return &codec.Aircraft{Hex: hex, Lat: floatPtr(lat), Lon: floatPtr(4.9041), GS: floatPtr(450)}
+}
+
+// flyLeg reports hex at each latitude in turn, 10s apart
+func flyLeg(m *Model, clock *time.Time, hex string, lats ...float64) {
+	for _, lat := range lats {
+		*clock = clock.Add(10 * time.Second)
+		m.updateTarget(flying(hex, lat), false)
+	}
+}
+
+func newTrackingModel() (*Model, *time.Time) {
+	m := NewModel(newTestConfig())
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+	return m, &clock
+}
+
+func TestTracking_TimeAndDistance(t *testing.T) {
+	m, clock := newTrackingModel()
+	start := *clock
+	m.updateTarget(flying("TRK001", 52.00), true)
+	flyLeg(m, clock, "TRK001", 52.02, 52.04, 52.06)
+
+	target := m.aircraft["TRK001"]
+	if !target.FirstSeen.Equal(start) {
+		t.Errorf("FirstSeen = %v, want %v", target.FirstSeen, start)
+	}
+	if math.Abs(target.TrackNM-3.6) > 0.05 {
+		t.Errorf("TrackNM = %.2f, want 3.6", target.TrackNM)
+	}
+
+	m.selectedHex = "TRK001"
+	m.width, m.height = 160, 60
+	if panel := ansi.Strip(m.renderTargetPanel()); !strings.Contains(panel, "TRKD 30s 4nm") {
+		t.Errorf("the detail panel should show time and distance tracked:\n%s", panel)
+	}
+}
+
+func TestTracking_GapNotCounted(t *testing.T) {
+	m, clock := newTrackingModel()
+	m.updateTarget(flying("TRK002", 52.00), true)
+	flyLeg(m, clock, "TRK002", 52.02)
+
+	// Out of coverage for two minutes, reappearing 6nm on
+	*clock = clock.Add(2 * time.Minute)
+	m.updateTarget(flying("TRK002", 52.12), false)
+	flyLeg(m, clock, "TRK002", 52.14)
+
+	if got := m.aircraft["TRK002"].TrackNM; math.Abs(got-2.4) > 0.05 {
+		t.Errorf("TrackNM = %.2f, want 2.4 without the gap", got)
+	}
+}
+
+func TestTracking_Reappearance(t *testing.T) {
+	m, clock := newTrackingModel()
+	start := *clock
+	m.updateTarget(flying("TRK003", 52.00), true)
+	flyLeg(m, clock, "TRK003", 52.02)
+
+	// Back within the grace period: the same flight carries on
+	m.removeAircraft("TRK003")
+	*clock = clock.Add(time.Minute)
+	m.updateTarget(flying("TRK003", 52.10), true)
+	flyLeg(m, clock, "TRK003", 52.12)
+	target := m.aircraft["TRK003"]
+	if !target.FirstSeen.Equal(start) || math.Abs(target.TrackNM-2.4) > 0.05 {
+		t.Errorf("a brief dropout should continue the track, got %v %.2f", target.FirstSeen, target.TrackNM)
+	}
+
+	// Back after it: a new flight
+	m.removeAircraft("TRK003")
+	*clock = clock.Add(time.Duration(m.config.Display.SelectionGrace+1) * time.Second)
+	m.cleanup(*clock)
+	m.updateTarget(flying("TRK003", 52.50), true)
+	target = m.aircraft["TRK003"]
+	if !target.FirstSeen.Equal(*clock) || target.TrackNM != 0 {
+		t.Errorf("a return after the grace period should start afresh, got %v %.2f", target.FirstSeen, target.TrackNM)
+	}
+	if len(m.departed) != 0 {
+		t.Error("departed tracking should be forgotten once used or expired")
+	}
+}
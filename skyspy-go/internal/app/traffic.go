@@ -0,0 +1,84 @@
+// Package app provides data usage reporting for the SkySpy radar
+package app
+
+import (
+	"github.com/skyspy/skyspy-go/internal/ws"
+)
+
+// bytesPerMB is the budget unit; metered plans count in decimal megabytes
+const bytesPerMB = 1_000_000
+
+// Budget warning levels, in percent of the daily budget
+const (
+	budgetWarnPercent = 80
+	budgetFullPercent = 100
+)
+
+// trafficReporter is implemented by feeds that count the data they move,
+// such as the WebSocket client
+type trafficReporter interface {
+	Traffic() ws.TrafficStats
+}
+
+// FeedTraffic returns the feed's data usage. ok is false if the feed
+// doesn't count it.
+func (m *Model) FeedTraffic() (stats ws.TrafficStats, ok bool) {
+	r, isReporter := m.feed.(trafficReporter)
+	if !isReporter {
+		return ws.TrafficStats{}, false
+	}
+	return r.Traffic(), true
+}
+
+// checkDataBudget warns once a day when the data used today passes 80% and
+// 100% of the configured budget. Usage restarts from the session totals at
+// local midnight.
+func (m *Model) checkDataBudget() {
+	budget := m.config.Connection.DataBudgetMB * bytesPerMB
+	if budget <= 0 {
+		return
+	}
+	st, ok := m.FeedTraffic()
+	if !ok {
+		return
+	}
+	total := st.RxBytes + st.TxBytes
+
+	day := m.now().Format("2006-01-02")
+	if day != m.budgetDay {
+		if m.budgetDay != "" {
+			m.budgetBase = total
+		}
+		m.budgetDay = day
+		m.budgetWarned = 0
+	}
+
+	used := float64(total - m.budgetBase)
+	percent := int(used * 100 / budget)
+	switch {
+	case percent >= budgetFullPercent && m.budgetWarned < budgetFullPercent:
+		m.budgetWarned = budgetFullPercent
+		m.notify(m.trf("notify.budget_reached", m.formatBytes(used)))
+	case percent >= budgetWarnPercent && m.budgetWarned < budgetWarnPercent:
+		m.budgetWarned = budgetWarnPercent
+		m.notify(m.trf("notify.budget_warning", percent, m.formatBytes(used), m.formatBytes(budget)))
+	}
+}
+
+// formatTraffic shows bytes received and the rate, e.g. "4.2 MB, 18 kB/min"
+func (m *Model) formatTraffic(st ws.TrafficStats) string {
+	return m.formatBytes(float64(st.RxBytes)) + ", " + m.formatBytes(st.RxPerMinute) + "/min"
+}
+
+// formatBytes shows a byte count in decimal units
+func (m *Model) formatBytes(n float64) string {
+	switch {
+	case n >= 1e9:
+		return m.locale.Float(n/1e9, 1) + " GB"
+	case n >= 1e6:
+		return m.locale.Float(n/1e6, 1) + " MB"
+	case n >= 1e3:
+		return m.locale.Float(n/1e3, 0) + " kB"
+	}
+	return m.locale.Float(n, 0) + " B"
+}
@@ -0,0 +1,54 @@
+// Package app provides turn detection display for the SkySpy radar
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/trails"
+)
+
+// GetTurn returns the current turn classification for hex
+func (m *Model) GetTurn(hex string) (trails.TurnInfo, bool) {
+	return m.turnTracker.Get(hex, m.now())
+}
+
+// turnMarks returns the scope indicators for every turning target
+func (m *Model) turnMarks() map[string]radar.TurnMark {
+	marks := make(map[string]radar.TurnMark)
+	for hex := range m.aircraft {
+		turn, ok := m.GetTurn(hex)
+		if !ok || turn.State == trails.TurnStraight {
+			continue
+		}
+		marks[hex] = radar.TurnMark{
+			Right:   turn.State == trails.TurnRight,
+			Holding: turn.Holding,
+		}
+	}
+	return marks
+}
+
+// formatTurn formats the turn direction and rate for the target panel
+func (m *Model) formatTurn(t *radar.Target) string {
+	turn, ok := m.GetTurn(t.Hex)
+	if !ok {
+		return dashPlaceholder
+	}
+
+	g := m.glyphs()
+	var s string
+	switch turn.State {
+	case trails.TurnLeft:
+		s = fmt.Sprintf("%s L %.1f%s/s", g.TurnLeft, math.Abs(turn.Rate), g.Degree)
+	case trails.TurnRight:
+		s = fmt.Sprintf("%s R %.1f%s/s", g.TurnRight, turn.Rate, g.Degree)
+	default:
+		s = "straight"
+	}
+	if turn.Holding {
+		s += " HOLD?"
+	}
+	return s
+}
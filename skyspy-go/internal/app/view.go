@@ -6,32 +6,60 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/geo"
 	"github.com/skyspy/skyspy-go/internal/radar"
 	"github.com/skyspy/skyspy-go/internal/theme"
+	"github.com/skyspy/skyspy-go/internal/ui"
+	"github.com/skyspy/skyspy-go/internal/units"
 )
 
 // View constants
 const (
 	emptyPlaceholder = "----"
 	dashPlaceholder  = "---"
-	bulletFilled     = "●"
-	bulletEmpty      = "○"
-	playIndicator    = "▶ "
 )
 
 // View renders the application
 func (m *Model) View() string {
+	// The terminal belongs to the shell while suspended
+	if m.suspended {
+		return m.lastRenderedView
+	}
+
+	if m.connectFailure != nil {
+		m.lastRenderedView = m.renderConnectFailure()
+		return m.lastRenderedView
+	}
+
 	var sb strings.Builder
+	m.hits.Reset()
 
 	// Header
 	sb.WriteString(m.renderHeader())
 	sb.WriteString("\n")
 
-	// Main content area
-	radarView := m.renderRadar()
+	// Main content area; the radar's and sidebar's clickable regions are
+	// recorded relative to them and moved into place once their positions
+	// are known
+	radarHits := m.hits.Len()
+	var radarView string
+	switch m.viewMode {
+	case ViewAircraftDetail:
+		// The detail page stands in for both the radar and the sidebar
+		radarView = m.renderDetailPage()
+	case ViewStatistics:
+		radarView = m.renderStatistics()
+	default:
+		radarView = m.renderRadar()
+	}
+	m.hits.ShiftFrom(radarHits, 0, strings.Count(sb.String(), "\n"))
+	sidebarHits := m.hits.Len()
 	var sidebarView string
 
 	switch m.viewMode {
+	case ViewAircraftDetail, ViewStatistics:
 	case ViewSettings:
 		sidebarView = m.renderSettingsPanel()
 	case ViewHelp:
@@ -42,19 +70,65 @@ func (m *Model) View() string {
 		sidebarView = m.renderSearchPanel()
 	case ViewAlertRules:
 		sidebarView = m.renderAlertRulesPanel()
+	case ViewGeofences:
+		sidebarView = m.renderGeofencesPanel()
+	case ViewWhatsNew:
+		sidebarView = m.renderWhatsNewPanel()
+	case ViewLogin:
+		sidebarView = m.renderLoginPanel()
+	case ViewNotice:
+		sidebarView = m.renderNoticePanel()
+	case ViewNotices:
+		sidebarView = m.renderNoticesPanel()
+	case ViewAway:
+		sidebarView = m.renderAwayPanel()
 	default:
 		sidebarView = m.renderSidebar()
 	}
 
+	// Render the chrome first so the pinned column knows how much room it has
+	var acarsView string
+	if m.config.Display.ShowACARS && m.viewMode == ViewRadar {
+		acarsView = m.renderACARSPanel()
+	}
+	statusView := m.renderStatusBar()
+	if m.flashing() {
+		statusView = invertLines(statusView)
+	}
+	footerView := m.renderFooter()
+
 	// Side by side layout
 	radarLines := strings.Split(radarView, "\n")
 	sidebarLines := strings.Split(sidebarView, "\n")
+	m.hits.ShiftFrom(sidebarHits, lipgloss.Width(radarLines[0])+1, strings.Count(sb.String(), "\n"))
 
 	maxLines := len(radarLines)
 	if len(sidebarLines) > maxLines {
 		maxLines = len(sidebarLines)
 	}
 
+	// Pinned blocks stack to the right of the sidebar without growing the view
+	var pinnedLines []string
+	sidebarWidth := 0
+	if m.viewMode == ViewRadar && len(m.pinned) > 0 {
+		budget := maxLines
+		if m.height > 0 {
+			chrome := strings.Count(sb.String(), "\n") + strings.Count(statusView, "\n") + strings.Count(footerView, "\n") + 2
+			if acarsView != "" {
+				chrome += strings.Count(acarsView, "\n") + 1
+			}
+			if avail := m.height - chrome; avail < budget {
+				budget = avail
+			}
+		}
+		pinnedLines = m.renderPinnedPanel(budget)
+		for _, line := range sidebarLines {
+			if w := lipgloss.Width(line); w > sidebarWidth {
+				sidebarWidth = w
+			}
+		}
+	}
+
 	for i := 0; i < maxLines; i++ {
 		radarLine := ""
 		if i < len(radarLines) {
@@ -65,30 +139,36 @@ func (m *Model) View() string {
 			sidebarLine = sidebarLines[i]
 		}
 		sb.WriteString(radarLine)
-		sb.WriteString(" ")
+		if sidebarView != "" {
+			sb.WriteString(" ")
+		}
 		sb.WriteString(sidebarLine)
+		if i < len(pinnedLines) {
+			sb.WriteString(strings.Repeat(" ", sidebarWidth-lipgloss.Width(sidebarLine)+1))
+			sb.WriteString(pinnedLines[i])
+		}
 		sb.WriteString("\n")
 	}
 
 	// ACARS panel if enabled
-	if m.config.Display.ShowACARS && m.viewMode == ViewRadar {
-		sb.WriteString(m.renderACARSPanel())
+	if acarsView != "" {
+		sb.WriteString(acarsView)
 		sb.WriteString("\n")
 	}
 
 	// Status bar
-	sb.WriteString(m.renderStatusBar())
+	sb.WriteString(statusView)
 	sb.WriteString("\n")
 
 	// Footer
-	sb.WriteString(m.renderFooter())
+	sb.WriteString(footerView)
 
 	result := sb.String()
 
 	// Store last rendered view for screenshot exports
 	m.lastRenderedView = result
 
-	return result
+	return m.withClipboard(m.withBell(result))
 }
 
 func (m *Model) renderHeader() string {
@@ -97,32 +177,68 @@ func (m *Model) renderHeader() string {
 	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright).Bold(true)
 	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
 	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	g := m.glyphs()
 
 	var sb strings.Builder
-	sb.WriteString(borderStyle.Render("╔" + strings.Repeat("═", 98) + "╗"))
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 98) + g.DoubleTR))
 	sb.WriteString("\n")
 
-	sb.WriteString(borderStyle.Render("║ "))
-	sb.WriteString(textDim.Render("░░ "))
+	sb.WriteString(borderStyle.Render(g.DoubleV + " "))
+	sb.WriteString(textDim.Render(strings.Repeat(g.Shades[0], 2) + " "))
 	sb.WriteString(primaryBright.Render("SKYSPY RADAR PRO"))
-	sb.WriteString(textDim.Render(" ░░ "))
-	sb.WriteString(borderStyle.Render(strings.Repeat("═", 18)))
+	sb.WriteString(textDim.Render(" " + strings.Repeat(g.Shades[0], 2) + " "))
+	// A longer feed label takes its room from the rule before it
+	label := m.feedLabel()
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.DoubleH, 18)))
 	sb.WriteString(secondaryBright.Render(" ADS-B TACTICAL DISPLAY "))
-	sb.WriteString(borderStyle.Render(strings.Repeat("═", 18)))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.DoubleH, 18-(len(label)-len("LIVE")))))
 
-	spin := m.spinners[m.frame%4]
+	spin := g.Spinner[m.frame%len(g.Spinner)]
 	sb.WriteString(infoStyle.Render(" " + spin + " "))
-	sb.WriteString(infoStyle.Bold(true).Render("LIVE"))
+	sb.WriteString(infoStyle.Bold(true).Render(label))
 	sb.WriteString(infoStyle.Render(" " + spin + "  "))
-	sb.WriteString(borderStyle.Render("║"))
+	sb.WriteString(borderStyle.Render(g.DoubleV))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("╠" + strings.Repeat("═", 98) + "╣"))
+	sb.WriteString(borderStyle.Render(g.DoubleTeeLeft + strings.Repeat(g.DoubleH, 98) + g.DoubleTeeRight))
 
 	return sb.String()
 }
 
 func (m *Model) renderRadar() string {
-	scope := radar.NewScope(m.theme, m.maxRange, m.config.Radar.RangeRings, m.config.Radar.ShowCompass)
+	lat, lon := m.mainCenter()
+	targets := m.aircraftFrom(lat, lon)
+	scope, sorted := m.drawScope(m.maxRange, lat, lon, targets, true)
+	m.sortedTargets = sorted
+	if m.sortByPOI {
+		m.sortTargetsByPOI(m.sortedTargets)
+	}
+	m.watchlistFirst(m.sortedTargets)
+	m.drawQuickLook(scope, targets)
+
+	split := m.splitActive()
+	scope.SetHighlight(split && !m.splitFocus)
+	view := scope.Render()
+	if m.config.Display.ShowAltitudeRibbon {
+		view = m.attachAltitudeRibbon(view)
+	}
+	if split {
+		view = joinPanes(view, m.renderSplitPane())
+	}
+	return view
+}
+
+// drawScope draws a radar scope at the given range centered on lat/lon, with
+// targets already measured from that center. With receiverRings the range
+// rings, sweep and compass stay around the receiver when the center is
+// elsewhere. It returns the scope and the drawn targets nearest first.
+func (m *Model) drawScope(maxRange, lat, lon float64, targets map[string]*radar.Target, receiverRings bool) (*radar.Scope, []string) {
+	scope := radar.NewScope(m.theme, maxRange, m.config.Radar.RangeRings, m.config.Radar.ShowCompass)
+	scope.SetRotation(m.rotation)
+	scope.SetUnits(m.distUnit())
+	scope.SetAltitudeColors(m.config.Display.ColorByAltitude)
+	if receiverLat, receiverLon := m.displayReceiver(); receiverRings && (lat != receiverLat || lon != receiverLon) {
+		scope.SetReceiver(radar.HaversineBearing(lat, lon, receiverLat, receiverLon))
+	}
 	scope.Clear()
 	scope.DrawRangeRings()
 	scope.DrawCompass()
@@ -131,34 +247,56 @@ func (m *Model) renderRadar() string {
 	if m.config.Radar.ShowOverlays {
 		scope.DrawOverlays(
 			m.overlayManager.GetEnabledOverlays(),
-			m.config.Connection.ReceiverLat,
-			m.config.Connection.ReceiverLon,
+			lat,
+			lon,
 			m.config.Radar.OverlayColor,
 		)
 	}
 
-	// Draw trails before targets so targets are rendered on top
+	// Draw trails before targets so targets are rendered on top; surface
+	// mode leaves out those of ground targets
+	surface := m.surfaceActive(maxRange)
 	if m.config.Display.ShowTrails {
-		scope.DrawTrails(
-			m.GetTrailsForRadar(),
-			m.config.Connection.ReceiverLat,
-			m.config.Connection.ReceiverLon,
-		)
+		if trailStyle(m.config) == trailStyleDots {
+			dots := m.GetTrailDotsForRadar()
+			if surface {
+				dots = m.dropGroundTrails(dots, targets)
+			}
+			scope.DrawTrailDots(dots, trailDots(m.config), lat, lon)
+		} else {
+			trails := m.GetTrailsForRadar()
+			if surface {
+				trails = m.dropGroundTrails(trails, targets)
+			}
+			scope.DrawTrails(
+				trails,
+				lat,
+				lon,
+			)
+		}
 	}
 
 	scope.DrawSweep(m.sweepAngle)
 
-	// Draw targets and update sorted list
-	m.sortedTargets = scope.DrawTargets(
-		m.aircraft,
+	// Draw targets
+	scope.SetPinned(m.pinned)
+	scope.SetTurns(m.turnMarks())
+	scope.SetLabelDetail(radar.ParseLabelDetail(m.config.Display.LabelDetail))
+	scope.SetSurface(m.fieldElevation(), surface, m.config.Radar.SurfaceReg)
+	if m.densityActive(maxRange) {
+		scope.SetDensity(m.densityRamp())
+		scope.SetWatched(m.watchedHexes())
+	}
+	scope.DrawGhost(m.selectionGhost(lat, lon))
+	sorted := scope.DrawTargets(
+		targets,
 		m.selectedHex,
 		m.config.Filters.MilitaryOnly,
 		m.config.Filters.HideGround,
 		m.config.Display.ShowLabels,
 		m.blink,
 	)
-
-	return scope.Render()
+	return scope, sorted
 }
 
 func (m *Model) renderSidebar() string {
@@ -170,8 +308,11 @@ func (m *Model) renderSidebar() string {
 
 	// Stats panel
 	if m.config.Display.ShowStatsPanel {
+		mark := m.hits.Len()
+		top := strings.Count(sb.String(), "\n")
 		sb.WriteString(m.renderStatsPanel())
 		sb.WriteString("\n")
+		m.hits.ShiftFrom(mark, 0, top)
 	}
 
 	// Target list
@@ -190,7 +331,6 @@ func (m *Model) renderSidebar() string {
 
 func (m *Model) renderTargetPanel() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
-	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
 	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
 	selectedStyle := lipgloss.NewStyle().Foreground(m.theme.Selected).Bold(true)
 	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright)
@@ -199,25 +339,31 @@ func (m *Model) renderTargetPanel() string {
 	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
 	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error)
 	emergencyStyle := lipgloss.NewStyle().Foreground(m.theme.Emergency)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╭─") + titleStyle.Render("◄ TARGET ►") + borderStyle.Render("─────────────────╮"))
+	sb.WriteString(m.sidebarTop(g.PagePrev + " " + m.tr("title.target") + " " + g.PageNext))
 	sb.WriteString("\n")
 
 	target, exists := m.aircraft[m.selectedHex]
 	if !exists || m.selectedHex == "" {
-		sb.WriteString(borderStyle.Render("│") + textDim.Render("  No target selected           ") + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render(fmt.Sprintf("  %-29s", fit(m.tr("notify.no_target"), 29))) + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("│") + textDim.Render("                               ") + borderStyle.Render("│"))
+		lostLine := ""
+		if lost := m.activeLostSelection(); lost != nil {
+			// Waiting for the lost selection to come back
+			lostLine = fit(m.trf("status.lost", pinLabel(&lost.target))+" "+g.Timer+" "+formatCountdown(lost.until.Sub(m.now())), 29)
+		}
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render(fmt.Sprintf("  %-29s", lostLine)) + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("│") + textDim.Render("  [↑↓] Select  [+-] Range      ") + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render("  ["+g.ArrowUp+g.ArrowDown+"] Select  [+-] Range      ") + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("│") + textDim.Render("  [T] Themes   [O] Overlays    ") + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render("  [T] Themes   [O] Overlays    ") + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("│") + textDim.Render("  [?] Help     [Q] Quit        ") + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render("  [?] Help     [Q] Quit        ") + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("╰───────────────────────────────╯"))
+		sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, 31) + g.BR))
 		return sb.String()
 	}
 
@@ -227,48 +373,83 @@ func (m *Model) renderTargetPanel() string {
 	}
 
 	// Callsign and hex
-	sb.WriteString(borderStyle.Render("│") + selectedStyle.Render(fmt.Sprintf("  %-28s", cs)) + borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V) + selectedStyle.Render(fmt.Sprintf("  %-28s", cs)) + borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
 	hexLine := secondaryBright.Render("  " + strings.ToUpper(target.Hex))
 	if target.Military {
 		hexLine += militaryStyle.Render(" MIL")
 	}
-	sb.WriteString(borderStyle.Render("│") + fmt.Sprintf("%-31s", hexLine) + borderStyle.Render("│"))
+	if target.Conflicted {
+		hexLine += lipgloss.NewStyle().Foreground(m.theme.Warning).Render(" " + g.Conflict + " DUP HEX")
+	}
+	if target.AltImplausible {
+		hexLine += lipgloss.NewStyle().Foreground(m.theme.Warning).Render(" " + g.Conflict + " ALT?")
+	}
+	sb.WriteString(borderStyle.Render(g.V) + fmt.Sprintf("%-31s", hexLine) + borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
-	sb.WriteString(borderStyle.Render("│") + "                               " + borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V) + "                               " + borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
-	// Data rows
+	// Data rows; the POI row only appears while a point of interest is active
+	_, poiActive := m.activePOI()
+	altValue, altStyle := m.formatAltitudes(target, primaryBright)
+	speedValue, gnssValue := m.formatSpeed(target), ""
+	if m.config.Display.DualUnits {
+		// Dual units leave no room for GNSS beside baro; it gets a row
+		altValue, gnssValue = m.formatDualAltitudes(target)
+		speedValue = m.formatDualSpeed(target)
+	}
 	rows := []struct {
 		label string
 		value string
 		style lipgloss.Style
 	}{
-		{"TYPE", target.ACType, primaryBright},
-		{"ALT", m.formatAlt(target), primaryBright},
-		{"GS", m.formatSpeed(target), primaryBright},
+		{"TYPE", fit(formatType(target), 23), primaryBright},
+		{"CAT", formatCategory(target), primaryBright},
+		{"ALT", altValue, altStyle},
+		{"GNSS", gnssValue, altStyle},
+		{"GS", speedValue, primaryBright},
 		{"VS", m.formatVS(target), m.getVSStyle(target)},
 		{"HDG", m.formatTrack(target), primaryBright},
-		{"DST", m.formatDistance(target), secondaryBright},
-		{"BRG", m.formatBearing(target), secondaryBright},
+		{"TURN", m.formatTurn(target), primaryBright},
+		{"SEL", m.formatNavSelected(target), primaryBright},
+		{"MODE", m.formatNavModes(target), primaryBright},
+		{"POS", m.formatPosition(target), secondaryBright},
+		{"REJ", m.formatRejectedPositions(target), lipgloss.NewStyle().Foreground(m.theme.Warning)},
+		{"REL", m.formatRelative(target), secondaryBright},
+		{"CPA", m.formatCPA(target), secondaryBright},
+		{"POI", m.formatPOI(target.Hex), secondaryBright},
+		{"RGN", target.Region, secondaryBright},
 		{"SQ", m.formatSquawk(target), m.getSquawkStyle(target)},
+		{"RSSI", m.formatSignalStats(target), secondaryBright},
+		{"RCV", fit(m.formatReceivers(target), 23), secondaryBright},
+		{"TRKD", formatTracked(target, m.now(), m.distUnit()), secondaryBright},
 	}
 
 	for _, row := range rows {
+		if row.label == "POI" && !poiActive {
+			continue
+		}
+		if row.label == "RGN" && !m.hasRegions() {
+			continue
+		}
+		if (row.label == "GNSS" || row.label == "REJ" || row.label == "RCV") && row.value == "" {
+			continue
+		}
 		if row.value == "" {
 			row.value = emptyPlaceholder
 		}
-		sb.WriteString(borderStyle.Render("│") + textDim.Render(fmt.Sprintf("  %-4s ", row.label)) + row.style.Render(fmt.Sprintf("%-23s", row.value)) + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render(fmt.Sprintf("  %-4s ", row.label)) + row.style.Render(fmt.Sprintf("%-23s", row.value)) + borderStyle.Render(g.V))
 		sb.WriteString("\n")
 	}
 
 	// Signal strength
-	sb.WriteString(borderStyle.Render("│") + textDim.Render("  SIG  ") + m.renderSignalBars(target) + strings.Repeat(" ", 18) + borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V) + textDim.Render("  SIG  ") + m.renderSignalBars(target) + strings.Repeat(" ", 18) + borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
-	sb.WriteString(borderStyle.Render("╰───────────────────────────────╯"))
+	sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, 31) + g.BR))
 
 	_ = successStyle
 	_ = errorStyle
@@ -279,7 +460,6 @@ func (m *Model) renderTargetPanel() string {
 
 func (m *Model) renderStatsPanel() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
-	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
 	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
 	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
 	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error)
@@ -288,24 +468,27 @@ func (m *Model) renderStatsPanel() string {
 	militaryStyle := lipgloss.NewStyle().Foreground(m.theme.Military)
 	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
 	emergencyStyle := lipgloss.NewStyle().Foreground(m.theme.Emergency)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╭─") + titleStyle.Render("STATUS") + borderStyle.Render("─────────────────────╮"))
+	sb.WriteString(m.sidebarTop(m.tr("title.status")))
 	sb.WriteString("\n")
 
 	// Connection status
 	if m.IsConnected() {
-		ind := "◉"
+		ind := g.Live
 		if !m.blink {
-			ind = bulletEmpty
+			ind = g.Off
 		}
-		sb.WriteString(borderStyle.Render("│") + successStyle.Render("  "+ind+" ") + successStyle.Bold(true).Render("RECEIVING") + strings.Repeat(" ", 16) + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + successStyle.Render("  "+ind+" ") + successStyle.Bold(true).Render(fmt.Sprintf("%-25s", fit(m.tr("status.receiving"), 25))) + borderStyle.Render(g.V))
+	} else if attempt, ok := m.reconnecting(); ok {
+		sb.WriteString(borderStyle.Render(g.V) + warningStyle.Render("  "+g.Off+" ") + warningStyle.Bold(true).Render(fmt.Sprintf("%-25s", fit(m.trf("status.reconnecting_short", attempt), 25))) + borderStyle.Render(g.V))
 	} else {
-		sb.WriteString(borderStyle.Render("│") + errorStyle.Render("  ○ ") + errorStyle.Bold(true).Render("OFFLINE") + strings.Repeat(" ", 18) + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + errorStyle.Render("  "+g.Off+" ") + errorStyle.Bold(true).Render(fmt.Sprintf("%-25s", fit(m.tr("status.offline"), 25))) + borderStyle.Render(g.V))
 	}
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("│") + "                               " + borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V) + "                               " + borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
 	// Stats
@@ -314,83 +497,190 @@ func (m *Model) renderStatsPanel() string {
 		value string
 		style lipgloss.Style
 	}{
-		{"TGT", fmt.Sprintf("%3d", len(m.aircraft)), secondaryBright},
-		{"PEAK", fmt.Sprintf("%3d", m.peakAircraft), warningStyle},
-		{"MIL", fmt.Sprintf("%3d", m.militaryCount), militaryStyle},
-		{"EMRG", fmt.Sprintf("%3d", m.emergencyCount), emergencyStyle},
-		{"MSG", fmt.Sprintf("%d", m.sessionMessages), infoStyle},
+		{m.tr("stat.tgt"), fmt.Sprintf("%3s", m.locale.Int(len(m.aircraft))), secondaryBright},
+		{m.tr("stat.peak"), fmt.Sprintf("%3s", m.locale.Int(m.peakAircraft)), warningStyle},
+		{m.tr("stat.mil"), fmt.Sprintf("%3s", m.locale.Int(m.militaryCount)), militaryStyle},
+		{m.tr("stat.sfc"), fmt.Sprintf("%3s", m.locale.Int(m.surfaceCount)), textDim},
+		{m.tr("stat.emrg"), fmt.Sprintf("%3s", m.locale.Int(m.emergencyCount)), emergencyStyle},
+		{m.tr("stat.msg"), m.locale.Int(m.sessionMessages), infoStyle},
+		{m.tr("stat.dup"), m.locale.Int(m.acarsDuplicates), textDim},
+		{m.tr("stat.trl"), m.formatTrailMemory(), textDim},
+	}
+	if m.positionFromServer() {
+		stats = append(stats, struct {
+			label string
+			value string
+			style lipgloss.Style
+		}{m.tr("stat.pos"), m.tr("stat.pos_server"), textDim})
+	}
+	if m.rejectedAltitudes > 0 {
+		stats = append(stats, struct {
+			label string
+			value string
+			style lipgloss.Style
+		}{m.tr("stat.rej"), m.locale.Int(m.rejectedAltitudes), warningStyle})
+	}
+	if m.parseErrors > 0 {
+		stats = append(stats, struct {
+			label string
+			value string
+			style lipgloss.Style
+		}{m.tr("stat.err"), m.locale.Int(m.parseErrors), warningStyle})
+	}
+	if label := m.latencyLabel(); label != "" {
+		style := infoStyle
+		if m.latencySlow() {
+			style = warningStyle
+		}
+		stats = append(stats, struct {
+			label string
+			value string
+			style lipgloss.Style
+		}{m.tr("stat.lat"), label, style})
+	}
+	if m.shedding {
+		stats = append(stats, struct {
+			label string
+			value string
+			style lipgloss.Style
+		}{m.tr("stat.shed"), m.locale.Int(len(m.shed)), warningStyle})
+	}
+	if traffic, ok := m.FeedTraffic(); ok {
+		style := infoStyle
+		if m.budgetWarned > 0 {
+			style = warningStyle
+		}
+		stats = append(stats, struct {
+			label string
+			value string
+			style lipgloss.Style
+		}{m.tr("stat.rx"), m.formatTraffic(traffic), style})
+	}
+	if value, style, ok := m.authStatusRow(infoStyle, warningStyle, errorStyle); ok {
+		stats = append(stats, struct {
+			label string
+			value string
+			style lipgloss.Style
+		}{m.tr("stat.usr"), value, style})
+	}
+
+	// Clicking a counter filters the radar to what it counts
+	clickable := map[string]string{
+		m.tr("stat.tgt"):  hitStatTotal,
+		m.tr("stat.mil"):  hitStatMilitary,
+		m.tr("stat.emrg"): hitStatEmergency,
 	}
-
 	for _, stat := range stats {
-		sb.WriteString(borderStyle.Render("│") + textDim.Render(fmt.Sprintf("  %-4s ", stat.label)) + stat.style.Render(fmt.Sprintf("%-23s", stat.value)) + borderStyle.Render("│"))
+		if id, ok := clickable[stat.label]; ok {
+			m.hits.Add(id, 1, strings.Count(sb.String(), "\n"), 30, 1)
+		}
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render(fmt.Sprintf("  %-4s ", fit(stat.label, 4))) + stat.style.Render(fmt.Sprintf("%-23s", stat.value)) + borderStyle.Render(g.V))
 		sb.WriteString("\n")
 	}
 
-	// VU Meters
-	if m.config.Display.ShowVUMeters {
-		sb.WriteString(borderStyle.Render("│") + "                               " + borderStyle.Render("│"))
+	// VU Meters; paused while shedding
+	if m.config.Display.ShowVUMeters && !m.shedding {
+		sb.WriteString(borderStyle.Render(g.V) + "                               " + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("│") + textDim.Render("  VU L ") + m.renderVUMeter(m.vuLeft, 10) + strings.Repeat(" ", 13) + borderStyle.Render("│"))
+		left, right := m.renderVUMeter(m.vuLeft, 10)+strings.Repeat(" ", 13), m.renderVUMeter(m.vuRight, 10)+strings.Repeat(" ", 13)
+		if !m.hasSignalData() {
+			left, right = m.renderNoSignal(10, 23), m.renderNoSignal(10, 23)
+		}
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render("  VU L ") + left + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("│") + textDim.Render("  VU R ") + m.renderVUMeter(m.vuRight, 10) + strings.Repeat(" ", 13) + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render("  VU R ") + right + borderStyle.Render(g.V))
 		sb.WriteString("\n")
 	}
 
-	// Spectrum Analyzer
-	if m.config.Display.ShowSpectrum {
-		sb.WriteString(borderStyle.Render("│") + "                               " + borderStyle.Render("│"))
+	// Vertical profile of the selected target takes over the spectrum area
+	if m.IsProfileVisible() {
+		sb.WriteString(borderStyle.Render(g.V) + "                               " + borderStyle.Render(g.V))
+		sb.WriteString("\n")
+		sb.WriteString(m.renderProfile())
+	} else if m.config.Display.ShowSpectrum && !m.shedding {
+		sb.WriteString(borderStyle.Render(g.V) + "                               " + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("│") + textDim.Render(" SPECTRUM (RSSI by Distance)   ") + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render(" SPECTRUM (RSSI by Distance)   ") + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("│") + m.renderSpectrumBar() + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + m.renderSpectrumBar() + borderStyle.Render(g.V))
 		sb.WriteString("\n")
-		sb.WriteString(borderStyle.Render("│") + textDim.Render("  0    50   100   200   400+ nm") + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render(m.spectrumAxis()) + borderStyle.Render(g.V))
 		sb.WriteString("\n")
 	}
 
-	sb.WriteString(borderStyle.Render("╰───────────────────────────────╯"))
+	sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, 31) + g.BR))
+
+	return sb.String()
+}
+
+// renderProfile renders the altitude-vs-distance side view of the selected
+// target as bordered sidebar rows
+func (m *Model) renderProfile() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	selectedStyle := lipgloss.NewStyle().Foreground(m.theme.Selected)
+	g := m.glyphs()
+
+	var sb strings.Builder
+
+	sb.WriteString(borderStyle.Render(g.V) + textDim.Render(" PROFILE (ALT vs DIST)         ") + borderStyle.Render(g.V))
+	sb.WriteString("\n")
+
+	points := m.GetProfilePoints(m.selectedHex)
+	plot := ui.NewProfilePlot(m.theme, 25, 6)
+	for _, line := range plot.Render(points) {
+		sb.WriteString(borderStyle.Render(g.V) + " " + line + borderStyle.Render(g.V))
+		sb.WriteString("\n")
+	}
+
+	var along float64
+	if len(points) > 0 {
+		along = points[len(points)-1].X
+	}
+	d, unit := m.inUnit(along)
+	distLabel := fmt.Sprintf("-%.1f%s", d, unit)
+	sb.WriteString(borderStyle.Render(g.V) + textDim.Render(fmt.Sprintf("      %-22s", distLabel)) + selectedStyle.Render("NOW") + borderStyle.Render(g.V))
+	sb.WriteString("\n")
 
 	return sb.String()
 }
 
 func (m *Model) renderTargetList() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
-	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
 	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
-	selectedStyle := lipgloss.NewStyle().Foreground(m.theme.Selected).Bold(true)
-	secondaryStyle := lipgloss.NewStyle().Foreground(m.theme.Secondary)
 	primaryStyle := lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╭─") + titleStyle.Render(fmt.Sprintf("LIST (%d)", len(m.aircraft))) + borderStyle.Render("─────────────────╮"))
+	sb.WriteString(m.sidebarTop(m.trf("title.list", len(m.aircraft))))
 	sb.WriteString("\n")
 
 	// Header
-	sb.WriteString(borderStyle.Render("│") + primaryStyle.Render("   CALL     ALT    D") + strings.Repeat(" ", 10) + borderStyle.Render("│"))
+	_, poiActive := m.activePOI()
+	showRegion := !poiActive && m.config.Display.ShowRegionColumn && m.hasRegions()
+	if poiActive {
+		sb.WriteString(borderStyle.Render(g.V) + primaryStyle.Render("   CALL     ALT    D    ETA") + strings.Repeat(" ", 3) + borderStyle.Render(g.V))
+	} else if showRegion {
+		sb.WriteString(borderStyle.Render(g.V) + primaryStyle.Render("   CALL     ALT    D  REGION") + strings.Repeat(" ", 2) + borderStyle.Render(g.V))
+	} else {
+		sb.WriteString(borderStyle.Render(g.V) + primaryStyle.Render("   CALL     ALT    D  TYPE") + strings.Repeat(" ", 4) + borderStyle.Render(g.V))
+	}
 	sb.WriteString("\n")
 
-	// List up to 8 targets
+	// List up to targetListRows targets
 	count := 0
-	for _, hex := range m.sortedTargets {
-		if count >= 8 {
-			break
-		}
-		target, exists := m.aircraft[hex]
-		if !exists {
-			continue
-		}
-
-		isSelected := hex == m.selectedHex
+	for _, target := range m.listTargets() {
+		isSelected := target.Hex == m.selectedHex
 		marker := " "
 		if isSelected {
-			marker = "▶"
+			marker = g.Cursor
+		} else if target.OnWatchlist {
+			marker = g.Watchlist
 		}
 
-		cs := target.Callsign
-		if cs == "" {
-			cs = target.Hex
-		}
+		fullCallsign := listCallsign(target.Callsign, target.Hex)
+		cs := fullCallsign
 		if len(cs) > 6 {
 			cs = cs[:6]
 		}
@@ -406,46 +696,62 @@ func (m *Model) renderTargetList() string {
 
 		dist := "-"
 		if target.Distance > 0 {
-			dist = fmt.Sprintf("%.0f", target.Distance)
+			d, _ := m.inUnit(target.Distance)
+			dist = fmt.Sprintf("%.0f", d)
 		}
 
-		var lineStyle lipgloss.Style
-		if isSelected {
-			lineStyle = selectedStyle
-		} else {
-			lineStyle = secondaryStyle
-		}
+		lineStyle := m.listRowStyle(target, isSelected)
 
 		line := fmt.Sprintf("%s %-6s  %4s  %3s", marker, cs, alt, dist)
-		sb.WriteString(borderStyle.Render("│") + lineStyle.Render(fmt.Sprintf(" %-29s", line)) + borderStyle.Render("│"))
+		if poiActive {
+			eta := "-"
+			if cpa, ok := m.GetPOIApproach(target.Hex); ok {
+				eta = formatETA(cpa)
+			}
+			line += fmt.Sprintf("  %5s", eta)
+		} else if showRegion {
+			line += fmt.Sprintf("  %-8s", truncate(target.Region, 8))
+		} else {
+			line += "  " + fit(m.listType(target), 9)
+		}
+		row := fmt.Sprintf(" %-29s", line)
+		if n := m.jumpMatch(fullCallsign); n > 0 && m.jumpActive() {
+			// Underline the part of the callsign typed so far
+			start := 1 + len(marker) + 1
+			end := start + min(n, len(cs))
+			row = lineStyle.Render(row[:start]) + lineStyle.Underline(true).Render(row[start:end]) + lineStyle.Render(row[end:])
+		} else {
+			row = lineStyle.Render(row)
+		}
+		sb.WriteString(borderStyle.Render(g.V) + row + borderStyle.Render(g.V))
 		sb.WriteString("\n")
 		count++
 	}
 
 	// Fill remaining rows if needed
-	for count < 8 {
-		sb.WriteString(borderStyle.Render("│") + textDim.Render(strings.Repeat(" ", 31)) + borderStyle.Render("│"))
+	for count < targetListRows {
+		sb.WriteString(borderStyle.Render(g.V) + textDim.Render(strings.Repeat(" ", 31)) + borderStyle.Render(g.V))
 		sb.WriteString("\n")
 		count++
 	}
 
-	sb.WriteString(borderStyle.Render("╰───────────────────────────────╯"))
+	sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, 31) + g.BR))
 
 	return sb.String()
 }
 
 func (m *Model) renderFreqPanel() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
-	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
 	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
 	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
 	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
 	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright)
 	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╭─") + titleStyle.Render("FREQ") + borderStyle.Render("───────────────────────╮"))
+	sb.WriteString(m.sidebarTop(m.tr("title.freq")))
 	sb.WriteString("\n")
 
 	freqs := []struct {
@@ -460,18 +766,18 @@ func (m *Model) renderFreqPanel() string {
 	}
 
 	for _, f := range freqs {
-		ind := bulletEmpty
+		ind := g.Off
 		indStyle := textDim
 		// Simulate random activity
 		if m.blink && m.frame%7 < 3 {
-			ind = bulletFilled
+			ind = g.On
 			indStyle = f.style
 		}
-		sb.WriteString(borderStyle.Render("│") + "  " + indStyle.Render(ind) + " " + f.style.Render(f.freq) + " " + textDim.Render(fmt.Sprintf("[%-5s]", f.label)) + strings.Repeat(" ", 8) + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V) + "  " + indStyle.Render(ind) + " " + f.style.Render(f.freq) + " " + textDim.Render(fmt.Sprintf("[%-5s]", f.label)) + strings.Repeat(" ", 8) + borderStyle.Render(g.V))
 		sb.WriteString("\n")
 	}
 
-	sb.WriteString(borderStyle.Render("╰───────────────────────────────╯"))
+	sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, 31) + g.BR))
 
 	return sb.String()
 }
@@ -482,10 +788,11 @@ func (m *Model) renderACARSPanel() string {
 	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright)
 	primaryStyle := lipgloss.NewStyle().Foreground(m.theme.Primary)
 	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╭─") + infoStyle.Render("ACARS") + borderStyle.Render(strings.Repeat("─", 87)+"╮"))
+	sb.WriteString(borderStyle.Render(g.TL+g.H) + infoStyle.Render("ACARS") + borderStyle.Render(strings.Repeat(g.H, 87)+g.TR))
 	sb.WriteString("\n")
 
 	// Show last 3 messages
@@ -513,10 +820,11 @@ func (m *Model) renderACARSPanel() string {
 			text = text[:70]
 		}
 
-		line := secondaryBright.Render(fmt.Sprintf("%-6s ", cs)) +
+		line := textDim.Render(fmt.Sprintf("%4s ", m.messageAgeLabel(msg))) +
+			secondaryBright.Render(fmt.Sprintf("%-6s ", cs)) +
 			primaryStyle.Render(fmt.Sprintf("%2s ", label)) +
 			textDim.Render(text)
-		sb.WriteString(borderStyle.Render("│ ") + fmt.Sprintf("%-91s", line) + borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V+" ") + fmt.Sprintf("%-91s", line) + borderStyle.Render(g.V))
 		sb.WriteString("\n")
 		count++
 	}
@@ -524,119 +832,41 @@ func (m *Model) renderACARSPanel() string {
 	// Fill remaining rows
 	for count < 3 {
 		if count == 0 {
-			sb.WriteString(borderStyle.Render("│") + textDim.Render("  Awaiting ACARS...") + strings.Repeat(" ", 73) + borderStyle.Render("│"))
+			sb.WriteString(borderStyle.Render(g.V) + textDim.Render("  Awaiting ACARS...") + strings.Repeat(" ", 73) + borderStyle.Render(g.V))
 		} else {
-			sb.WriteString(borderStyle.Render("│") + strings.Repeat(" ", 92) + borderStyle.Render("│"))
+			sb.WriteString(borderStyle.Render(g.V) + strings.Repeat(" ", 92) + borderStyle.Render(g.V))
 		}
 		sb.WriteString("\n")
 		count++
 	}
 
-	sb.WriteString(borderStyle.Render("╰" + strings.Repeat("─", 92) + "╯"))
+	sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, 92) + g.BR))
 
 	return sb.String()
 }
 
 func (m *Model) renderStatusBar() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
-	borderDim := lipgloss.NewStyle().Foreground(m.theme.BorderDim)
-	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success).Bold(true)
-	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error).Bold(true)
-	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright)
-	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
-	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning)
-	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
-	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╟"))
-	sb.WriteString(borderStyle.Render(strings.Repeat("─", 98)))
-	sb.WriteString(borderStyle.Render("╢"))
+	sb.WriteString(borderStyle.Render(g.DoubleSepLeft))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.H, statusBarWidth)))
+	sb.WriteString(borderStyle.Render(g.DoubleSepRight))
 	sb.WriteString("\n")
 
-	sb.WriteString(borderStyle.Render("║ "))
-
-	// Connection indicator
-	if m.IsConnected() {
-		ind := "◉"
-		if !m.blink {
-			ind = bulletEmpty
-		}
-		sb.WriteString(successStyle.Render(ind + " ON "))
-	} else {
-		sb.WriteString(errorStyle.Render("○ OFF "))
-	}
-
-	sb.WriteString(borderDim.Render("│"))
-	sb.WriteString(secondaryBright.Render(fmt.Sprintf(" %3d ", len(m.aircraft))))
-	sb.WriteString(borderDim.Render("│"))
-	sb.WriteString(primaryBright.Render(fmt.Sprintf(" %dnm ", int(m.targetRange))))
-	sb.WriteString(borderDim.Render("│"))
-
-	// Active filters
-	var filters []string
-	if m.config.Filters.MilitaryOnly {
-		filters = append(filters, "MIL")
-	}
-	if m.config.Filters.HideGround {
-		filters = append(filters, "AIR")
-	}
-	if m.IsFilterActive() {
-		filterDesc := m.searchFilter.Description()
-		if len(filterDesc) > 15 {
-			filterDesc = filterDesc[:15] + "..."
-		}
-		filters = append(filters, filterDesc)
-	}
-	if len(filters) > 0 {
-		sb.WriteString(warningStyle.Render(" " + strings.Join(filters, "/") + " "))
-		sb.WriteString(borderDim.Render("│"))
-	}
-
-	// Overlays
-	enabledOverlays := 0
-	for _, ov := range m.overlayManager.GetOverlayList() {
-		if ov.Enabled {
-			enabledOverlays++
-		}
-	}
-	if enabledOverlays > 0 {
-		sb.WriteString(infoStyle.Render(fmt.Sprintf(" OVL:%d ", enabledOverlays)))
-		sb.WriteString(borderDim.Render("│"))
-	}
-
-	// Theme name
-	themeName := m.theme.Name
-	if len(themeName) > 12 {
-		themeName = themeName[:12]
-	}
-	sb.WriteString(textDim.Render(" " + themeName + " "))
-	sb.WriteString(borderDim.Render("│"))
-
-	// Time
-	sb.WriteString(secondaryBright.Render(" " + time.Now().Format("15:04:05") + " "))
-
-	// Notification
-	if m.notification != "" && m.notificationTime > 0 {
-		sb.WriteString(borderDim.Render("│"))
-		sb.WriteString(infoStyle.Bold(true).Render(" " + m.notification + " "))
-	}
-
-	// Pad to width
-	remaining := 98 - lipgloss.Width(sb.String()) + 3 // Account for borders
-	if remaining > 0 {
-		sb.WriteString(strings.Repeat(" ", remaining))
-	}
-
-	sb.WriteString(borderStyle.Render("║"))
+	sb.WriteString(borderStyle.Render(g.DoubleV))
+	sb.WriteString(m.renderStatusLine(statusBarWidth))
+	sb.WriteString(borderStyle.Render(g.DoubleV))
 
 	return sb.String()
 }
 
 func (m *Model) renderFooter() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
-	return borderStyle.Render("╚" + strings.Repeat("═", 98) + "╝")
+	g := m.glyphs()
+	return borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 98) + g.DoubleBR)
 }
 
 func (m *Model) renderSettingsPanel() string {
@@ -648,19 +878,20 @@ func (m *Model) renderSettingsPanel() string {
 	selectedStyle := lipgloss.NewStyle().Foreground(m.theme.Selected).Bold(true)
 	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
 	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╔══════════════════════════════════╗"))
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 34) + g.DoubleTR))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("║") + titleStyle.Render("         SETTINGS & THEMES        ") + borderStyle.Render("║"))
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.settings"), 34)) + borderStyle.Render(g.DoubleV))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("╚══════════════════════════════════╝"))
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 34) + g.DoubleBR))
 	sb.WriteString("\n\n")
 
 	sb.WriteString(secondaryBright.Render("  THEMES"))
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 34)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 34)))
 	sb.WriteString("\n")
 
 	themes := theme.GetInfo()
@@ -670,11 +901,11 @@ func (m *Model) renderSettingsPanel() string {
 
 		prefix := "  "
 		if isCursor {
-			prefix = playIndicator
+			prefix = g.Cursor + " "
 		}
-		marker := bulletEmpty
+		marker := g.Off
 		if isCurrent {
-			marker = bulletFilled
+			marker = g.On
 		}
 
 		var style, markerStyle lipgloss.Style
@@ -703,15 +934,62 @@ func (m *Model) renderSettingsPanel() string {
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 34)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 34)))
+	sb.WriteString("\n")
+	colorblind := m.tr("status.off")
+	if m.config.Display.ColorblindSafe {
+		colorblind = m.tr("status.on")
+	}
+	sb.WriteString(textDim.Render("  [c] Colorblind safe: ") + textStyle.Render(colorblind))
 	sb.WriteString("\n")
-	sb.WriteString(textDim.Render("  [↑/↓] Navigate  [Enter] Apply"))
+	sb.WriteString(textDim.Render("  [" + g.ArrowUp + "/" + g.ArrowDown + "] Navigate  [Enter] Apply"))
 	sb.WriteString("\n")
 	sb.WriteString(textDim.Render("  [T/Esc] Close"))
 
 	return sb.String()
 }
 
+// maxFeatureWindows caps the timed features listed under an overlay
+const maxFeatureWindows = 4
+
+// renderFeatureWindows lists the time windows of an overlay's timed
+// features, such as TFRs, and whether each is in force
+func (m *Model) renderFeatureWindows(key string) string {
+	windows := m.overlayManager.GetFeatureWindows(key)
+	if len(windows) == 0 {
+		return ""
+	}
+	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright).Bold(true)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	g := m.glyphs()
+
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(secondaryBright.Render("  TIME WINDOWS (UTC)"))
+	sb.WriteString("\n")
+	for i, w := range windows {
+		if i == maxFeatureWindows {
+			sb.WriteString(textDim.Render(fmt.Sprintf("    +%d more", len(windows)-i)))
+			sb.WriteString("\n")
+			break
+		}
+		name := w.Name
+		if len(name) > 22 {
+			name = name[:22]
+		}
+		marker, state, style := g.Off, "INACTIVE", textDim
+		if w.Active {
+			marker, state, style = g.On, "ACTIVE", warningStyle
+		}
+		sb.WriteString("  " + style.Render(fmt.Sprintf("%s %-22s %s", marker, name, state)))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("    " + w.Window.String()))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
 func (m *Model) renderOverlayPanel() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
 	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
@@ -722,22 +1000,23 @@ func (m *Model) renderOverlayPanel() string {
 	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
 	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
 	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╔══════════════════════════════════╗"))
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 34) + g.DoubleTR))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("║") + titleStyle.Render("         OVERLAY MANAGER          ") + borderStyle.Render("║"))
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.overlays"), 34)) + borderStyle.Render(g.DoubleV))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("╚══════════════════════════════════╝"))
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 34) + g.DoubleBR))
 	sb.WriteString("\n\n")
 
 	overlays := m.overlayManager.GetOverlayList()
 
-	if len(overlays) > 0 {
+	if len(overlays) > 0 || len(m.overlayLoads) > 0 {
 		sb.WriteString(secondaryBright.Render("  LOADED OVERLAYS"))
 		sb.WriteString("\n")
-		sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 34)))
+		sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 34)))
 		sb.WriteString("\n")
 
 		for i, ov := range overlays {
@@ -745,11 +1024,11 @@ func (m *Model) renderOverlayPanel() string {
 
 			prefix := "  "
 			if isCursor {
-				prefix = playIndicator
+				prefix = g.Cursor + " "
 			}
-			marker := bulletEmpty
+			marker := g.Off
 			if ov.Enabled {
-				marker = bulletFilled
+				marker = g.On
 			}
 
 			var style, markerStyle lipgloss.Style
@@ -764,25 +1043,34 @@ func (m *Model) renderOverlayPanel() string {
 				markerStyle = textDim
 			}
 
-			name := ov.Name
-			if len(name) > 25 {
-				name = name[:25]
+			level := strings.ToUpper(string(ov.Brightness))
+			if ov.RegionTagging {
+				level += " RGN"
 			}
 
-			sb.WriteString("  " + style.Render(prefix) + markerStyle.Render(marker+" ") + style.Render(name))
+			sb.WriteString("  " + style.Render(prefix) + markerStyle.Render(marker+" ") +
+				style.Render(fmt.Sprintf("%-13s", truncate(ov.Name, 13))) + " " +
+				textDim.Render(fmt.Sprintf("%6s ", m.locale.Int(ov.Features))+level))
 			sb.WriteString("\n")
 		}
+		sb.WriteString(m.renderOverlayLoads())
+
+		if m.overlayCursor < len(overlays) {
+			sb.WriteString(m.renderFeatureWindows(overlays[m.overlayCursor].Key))
+		}
 	} else {
 		sb.WriteString(textDim.Render("  No overlays loaded"))
 		sb.WriteString("\n")
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 34)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 34)))
+	sb.WriteString("\n")
+	sb.WriteString(textDim.Render("  [" + g.ArrowUp + "/" + g.ArrowDown + "] Navigate  [Enter] Toggle"))
 	sb.WriteString("\n")
-	sb.WriteString(textDim.Render("  [↑/↓] Navigate  [Enter] Toggle"))
+	sb.WriteString(textDim.Render("  [+/-] Brightness  [D] Delete"))
 	sb.WriteString("\n")
-	sb.WriteString(textDim.Render("  [D] Delete  [O/Esc] Close"))
+	sb.WriteString(textDim.Render("  [G] Region tagging  [O/Esc] Close"))
 	sb.WriteString("\n\n")
 	sb.WriteString(textDim.Render("  Add overlays:"))
 	sb.WriteString("\n")
@@ -791,6 +1079,31 @@ func (m *Model) renderOverlayPanel() string {
 	return sb.String()
 }
 
+// renderOverlayLoads lists the overlays still loading, with a spinner, and
+// those that failed, with the reason
+func (m *Model) renderOverlayLoads() string {
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
+	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error)
+	g := m.glyphs()
+
+	var sb strings.Builder
+	for _, load := range m.overlayLoads {
+		name := fmt.Sprintf("%-13s", truncate(load.name(), 13))
+		if load.err != nil {
+			sb.WriteString("    " + errorStyle.Render(g.Conflict+" ") + textDim.Render(name) + " " + errorStyle.Render("FAILED"))
+			sb.WriteString("\n")
+			sb.WriteString(textDim.Render("      " + truncate(load.err.Error(), 30)))
+			sb.WriteString("\n")
+			continue
+		}
+		spin := g.Spinner[m.frame%len(g.Spinner)]
+		sb.WriteString("    " + infoStyle.Render(spin+" ") + textDim.Render(name+" loading"+g.Ellipsis))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
 //nolint:gocyclo // Complex rendering with many conditional branches is acceptable
 func (m *Model) renderSearchPanel() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
@@ -803,20 +1116,21 @@ func (m *Model) renderSearchPanel() string {
 	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
 	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning)
 	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╔══════════════════════════════════╗"))
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 34) + g.DoubleTR))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("║") + titleStyle.Render("        SEARCH & FILTER           ") + borderStyle.Render("║"))
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.search"), 34)) + borderStyle.Render(g.DoubleV))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("╚══════════════════════════════════╝"))
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 34) + g.DoubleBR))
 	sb.WriteString("\n\n")
 
 	// Search input box
 	sb.WriteString(secondaryBright.Render("  SEARCH"))
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 34)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 34)))
 	sb.WriteString("\n")
 
 	// Input field with cursor
@@ -845,7 +1159,7 @@ func (m *Model) renderSearchPanel() string {
 	// Results list
 	sb.WriteString(secondaryBright.Render("  RESULTS"))
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 34)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 34)))
 	sb.WriteString("\n")
 
 	switch {
@@ -871,7 +1185,7 @@ func (m *Model) renderSearchPanel() string {
 			isCursor := i == m.searchCursor
 			prefix := "  "
 			if isCursor {
-				prefix = playIndicator
+				prefix = g.Cursor + " "
 			}
 
 			// Format callsign/hex with highlighting
@@ -942,7 +1256,7 @@ func (m *Model) renderSearchPanel() string {
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 34)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 34)))
 	sb.WriteString("\n")
 	sb.WriteString(secondaryBright.Render("  SYNTAX"))
 	sb.WriteString("\n")
@@ -954,10 +1268,14 @@ func (m *Model) renderSearchPanel() string {
 	sb.WriteString("\n")
 	sb.WriteString(textDim.Render("  dist:<50    Distance filter"))
 	sb.WriteString("\n")
+	sb.WriteString(textDim.Render("  cat:rotorcraft  Category"))
+	sb.WriteString("\n")
+	sb.WriteString(textDim.Render("  type:heavy  Aircraft type"))
+	sb.WriteString("\n")
 	sb.WriteString(textDim.Render("  mil      Military only"))
 	sb.WriteString("\n\n")
 
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 34)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 34)))
 	sb.WriteString("\n")
 	sb.WriteString(secondaryBright.Render("  PRESETS"))
 	sb.WriteString("\n")
@@ -966,13 +1284,17 @@ func (m *Model) renderSearchPanel() string {
 	sb.WriteString(textDim.Render("  [F3] Emergency  [F4] Low Alt"))
 	sb.WriteString("\n\n")
 
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 34)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 34)))
 	sb.WriteString("\n")
 	sb.WriteString(textDim.Render("  [Enter] Apply  [Esc] Cancel"))
 
 	return sb.String()
 }
 
+// helpTextWidth is the room for a description after its key in the help
+// panel
+const helpTextWidth = 29
+
 func (m *Model) renderHelpPanel() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
 	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
@@ -981,40 +1303,64 @@ func (m *Model) renderHelpPanel() string {
 	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
 	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
 	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╔══════════════════════════════════════════╗"))
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 42) + g.DoubleTR))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("║") + titleStyle.Render("           SKYSPY RADAR HELP              ") + borderStyle.Render("║"))
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.help"), 42)) + borderStyle.Render(g.DoubleV))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("╚══════════════════════════════════════════╝"))
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 42) + g.DoubleBR))
 	sb.WriteString("\n\n")
 
 	sections := []struct {
 		title string
 		items [][]string
 	}{
-		{"NAVIGATION", [][]string{{"↑/↓ j/k", "Select target"}, {"+/-", "Zoom range"}, {"/", "Search"}}},
-		{"DISPLAY", [][]string{{"L", "Labels"}, {"B", "Trails"}, {"M", "Military only"}, {"G", "Ground filter"}, {"A", "ACARS"}, {"V", "VU meters"}}},
-		{"EXPORT", [][]string{{"P", "Screenshot (HTML)"}, {"E", "Export CSV"}, {"Ctrl+E", "Export JSON"}}},
-		{"PANELS", [][]string{{"T", "Themes"}, {"O", "Overlays"}, {"R", "Alert Rules"}, {"?", "Help"}, {"Q", "Quit"}}},
-		{"SYMBOLS", [][]string{{"✦", "Aircraft"}, {"◉", "Selected"}, {"◆", "Military"}, {"!", "Emergency"}}},
+		{"help.section_navigation", [][]string{{g.ArrowUp + "/" + g.ArrowDown + " j/k", "help.select_target"}, {"+/-", "help.zoom"}, {"N", "help.custom_range"}, {"/", "help.search"}, {"Enter", "help.pin"}, {"Ctrl+J", "help.clear_pins"}, {"w", "help.watchlist"}, {"Tab", "help.switch_pane"}, {"Shift+F", "help.follow"}, {"Shift+Arrows", "help.pan"}, {"Home", "help.recenter"}}},
+		{"help.section_display", [][]string{{"l", "help.labels"}, {"Shift+L", "help.label_detail"}, {"B", "help.trails"}, {"Ctrl+B", "help.trail_style"}, {"M", "help.military"}, {"G", "help.ground"}, {"A", "help.acars"}, {"V", "help.vu"}, {"I", "help.privacy"}, {"i", "help.statistics"}, {"Ctrl+U", "help.heading_up"}, {"Ctrl+L", "help.units"}, {"Ctrl+A", "help.alt_colors"}, {"X", "help.poi"}, {"Ctrl+T", "help.poi_sort"}, {"Ctrl+G", "help.surface"}, {"Ctrl+O", "help.density"}, {"Ctrl+Q", "help.quick_look"}, {"Ctrl+F", "help.detail"}, {"Z", "help.ribbon"}, {"D", "help.dnd"}, {"|", "help.split"}, {"C", "help.split_center"}}},
+		{"help.section_export", [][]string{{"P", "help.screenshot"}, {"E", "help.export_csv"}, {"Ctrl+E", "help.export_json"}, {"Ctrl+P", "help.export_geojson"}, {"Ctrl+R", "help.signal_report"}, {"Ctrl+D", "help.debug_state"}, {"Y", "help.copy_rows"}}},
+		{"help.section_panels", [][]string{{"T", "help.themes"}, {"O", "help.overlays"}, {"R", "help.alert_rules"}, {"U", "help.renew"}, {"Ctrl+N", "help.notices"}, {"Ctrl+W", "help.away"}, {"?", "help.help"}, {"Ctrl+Z", "help.suspend"}, {"Q", "help.quit"}}},
+		{"help.section_symbols", [][]string{{g.Aircraft, "help.aircraft"}, {g.Selected, "help.selected"}, {g.PinOpen + g.Aircraft + g.PinClose, "help.pinned"}, {g.Watchlist + g.Aircraft, "help.watched"}, {g.Military, "help.military_symbol"}, {g.EmergencyAlt, "help.emergency"}, {g.Rotorcraft, "help.rotorcraft"}, {g.Glider, "help.glider"}, {g.UAV, "help.uav"}, {g.Vehicle, "help.vehicle"}}},
+	}
+	if _, ok := m.replaying(); ok {
+		sections = append(sections, struct {
+			title string
+			items [][]string
+		}{"help.section_replay", [][]string{{"Space", "help.replay_pause"}, {"Arrows", "help.replay_seek"}}})
 	}
 
+	// Translations may run longer than the English, so descriptions wrap
+	// under themselves rather than past the panel's edge
+	wrap := lipgloss.NewStyle().Width(helpTextWidth)
+	indent := strings.Repeat(" ", 13)
+
 	for _, section := range sections {
-		sb.WriteString(secondaryBright.Render("  " + section.title))
+		sb.WriteString(secondaryBright.Render("  " + m.tr(section.title)))
 		sb.WriteString("\n")
-		sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 40)))
+		sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
 		sb.WriteString("\n")
 		for _, item := range section.items {
-			sb.WriteString("   " + primaryBright.Render(fmt.Sprintf("[%7s]", item[0])) + " " + textStyle.Render(item[1]))
+			lines := strings.Split(wrap.Render(m.tr(item[1])), "\n")
+			sb.WriteString("   " + primaryBright.Render(fmt.Sprintf("[%7s]", item[0])) + " " + textStyle.Render(strings.TrimRight(lines[0], " ")))
 			sb.WriteString("\n")
+			for _, line := range lines[1:] {
+				sb.WriteString(indent + textStyle.Render(strings.TrimRight(line, " ")))
+				sb.WriteString("\n")
+			}
 		}
 		sb.WriteString("\n")
 	}
 
-	sb.WriteString(textDim.Render("  Press any key to close"))
+	sb.WriteString(secondaryBright.Render("  " + m.tr("help.section_alt_colors")))
+	sb.WriteString("\n")
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
+	sb.WriteString("\n")
+	sb.WriteString("   " + m.altitudeLegend())
+	sb.WriteString("\n\n")
+
+	sb.WriteString(textDim.Render("  " + m.tr("help.close")))
 
 	return sb.String()
 }
@@ -1025,10 +1371,7 @@ func (m *Model) formatAlt(t *radar.Target) string {
 	if !t.HasAlt {
 		return emptyPlaceholder
 	}
-	if t.Altitude >= 18000 {
-		return fmt.Sprintf("FL%03d", t.Altitude/100)
-	}
-	return fmt.Sprintf("%d'", t.Altitude)
+	return radar.FormatAltitude(t.Altitude)
 }
 
 func (m *Model) formatSpeed(t *radar.Target) string {
@@ -1038,6 +1381,15 @@ func (m *Model) formatSpeed(t *radar.Target) string {
 	return fmt.Sprintf("%d kt", int(t.Speed))
 }
 
+// formatDualSpeed formats ground speed in knots and km/h, for the detail
+// panel with dual units
+func (m *Model) formatDualSpeed(t *radar.Target) string {
+	if !t.HasSpeed {
+		return dashPlaceholder
+	}
+	return fmt.Sprintf("%d kt / %s km/h", int(t.Speed), m.locale.Int(units.KmH(t.Speed)))
+}
+
 func (m *Model) formatVS(t *radar.Target) string {
 	if !t.HasVS {
 		return dashPlaceholder
@@ -1052,21 +1404,60 @@ func (m *Model) formatTrack(t *radar.Target) string {
 	if !t.HasTrack {
 		return dashPlaceholder
 	}
-	return fmt.Sprintf("%03d°", int(t.Track))
+	return fmt.Sprintf("%03d%s", int(t.Track), m.glyphs().Degree)
 }
 
 func (m *Model) formatDistance(t *radar.Target) string {
+	t = m.displayTarget(t)
 	if t.Distance <= 0 {
 		return dashPlaceholder
 	}
-	return fmt.Sprintf("%.1fnm", t.Distance)
+	return m.formatDist(t.Distance, 1)
 }
 
 func (m *Model) formatBearing(t *radar.Target) string {
+	t = m.displayTarget(t)
 	if t.Bearing <= 0 {
 		return dashPlaceholder
 	}
-	return fmt.Sprintf("%03d°", int(t.Bearing))
+	return fmt.Sprintf("%03d%s", int(t.Bearing), m.glyphs().Degree)
+}
+
+// formatPosition writes the target's position in the configured format
+func (m *Model) formatPosition(t *radar.Target) string {
+	if !t.HasLat || !t.HasLon {
+		return dashPlaceholder
+	}
+	f := geo.ParseCoordFormat(m.config.Display.CoordFormat)
+	if f == geo.CoordDMS {
+		degree := m.glyphs().Degree
+		if degree == "" {
+			degree = "d"
+		}
+		return geo.FormatDMS(t.Lat, t.Lon, degree)
+	}
+	return geo.FormatCoords(t.Lat, t.Lon, f)
+}
+
+// formatRelative writes the target's bearing and distance from the
+// receiver in the shown unit, e.g. R-245°/18.2nm
+func (m *Model) formatRelative(t *radar.Target) string {
+	t = m.displayTarget(t)
+	if t.Distance <= 0 {
+		return dashPlaceholder
+	}
+	d, unit := m.inUnit(t.Distance)
+	return geo.RelativePosition(t.Bearing, d, unit, m.glyphs().Degree)
+}
+
+// formatCategory shows the emitter category code and name, e.g.
+// "A7 Rotorcraft"; empty when the target broadcasts none
+func formatCategory(t *radar.Target) string {
+	c, ok := t.EmitterCategory()
+	if !ok {
+		return ""
+	}
+	return c.Code + " " + c.Name
 }
 
 func (m *Model) formatSquawk(t *radar.Target) string {
@@ -1078,28 +1469,28 @@ func (m *Model) formatSquawk(t *radar.Target) string {
 
 func (m *Model) getVSStyle(t *radar.Target) lipgloss.Style {
 	if !t.HasVS {
-		return lipgloss.NewStyle().Foreground(m.theme.TextDim)
+		return m.theme.Style(theme.RoleTextDim)
 	}
 	if t.Vertical > 0 {
-		return lipgloss.NewStyle().Foreground(m.theme.Success)
+		return m.theme.Style(theme.RoleClimb)
 	}
-	return lipgloss.NewStyle().Foreground(m.theme.Error)
+	return m.theme.Style(theme.RoleDescend)
 }
 
-func (m *Model) getSquawkStyle(t *radar.Target) lipgloss.Style {
-	if t.IsEmergency() {
-		return lipgloss.NewStyle().Foreground(m.theme.Emergency)
-	}
-	return lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+// formatTrailMemory summarises trail points held and their memory use
+func (m *Model) formatTrailMemory() string {
+	st := m.trailTracker.Stats()
+	return fmt.Sprintf("%d pts %dK", st.Points, (st.Bytes+1023)/1024)
 }
 
 func (m *Model) renderSignalBars(t *radar.Target) string {
-	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
-	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning)
-	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	successStyle := m.theme.Style(theme.RoleClimb)
+	warningStyle := m.theme.Style(theme.RoleWarning)
+	textDim := m.theme.Style(theme.RoleTextDim)
+	g := m.glyphs()
 
 	if !t.HasRSSI {
-		return textDim.Render("░░░░░")
+		return textDim.Render(strings.Repeat(g.BarEmpty, 5))
 	}
 
 	bars := int((t.RSSI + 30) / 6)
@@ -1114,12 +1505,12 @@ func (m *Model) renderSignalBars(t *radar.Target) string {
 	for i := 0; i < 5; i++ {
 		if i < bars {
 			if bars > 2 {
-				sb.WriteString(successStyle.Render("█"))
+				sb.WriteString(successStyle.Render(g.BarFull))
 			} else {
-				sb.WriteString(warningStyle.Render("█"))
+				sb.WriteString(warningStyle.Render(g.BarFull))
 			}
 		} else {
-			sb.WriteString(textDim.Render("░"))
+			sb.WriteString(textDim.Render(g.BarEmpty))
 		}
 	}
 	return sb.String()
@@ -1131,6 +1522,7 @@ func (m *Model) renderVUMeter(level float64, width int) string {
 	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning)
 	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error)
 	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	g := m.glyphs()
 
 	filled := int(level * float64(width))
 	var sb strings.Builder
@@ -1138,18 +1530,41 @@ func (m *Model) renderVUMeter(level float64, width int) string {
 	for i := 0; i < width; i++ {
 		switch {
 		case i >= filled:
-			sb.WriteString(textDim.Render("░"))
+			sb.WriteString(textDim.Render(g.BarEmpty))
 		case float64(i) < float64(width)*0.6:
-			sb.WriteString(successStyle.Render("█"))
+			sb.WriteString(successStyle.Render(g.BarFull))
 		case float64(i) < float64(width)*0.8:
-			sb.WriteString(warningStyle.Render("█"))
+			sb.WriteString(warningStyle.Render(g.BarFull))
 		default:
-			sb.WriteString(errorStyle.Render("█"))
+			sb.WriteString(errorStyle.Render(g.BarFull))
 		}
 	}
 	return sb.String()
 }
 
+// renderNoSignal draws a greyed-out meter of bars cells tagged "n/a",
+// padded to width, for when no aircraft report signal strength
+func (m *Model) renderNoSignal(bars, width int) string {
+	borderDim := lipgloss.NewStyle().Foreground(m.theme.BorderDim)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+
+	tag := fit(m.tr("status.no_signal"), width-bars-1)
+	return borderDim.Render(strings.Repeat(m.glyphs().BarEmpty, bars)) + " " +
+		textDim.Render(tag+strings.Repeat(" ", width-bars-1-lipgloss.Width(tag)))
+}
+
+// spectrumAxis labels the spectrum's distance axis, which spans the scope's
+// range, in the shown unit
+func (m *Model) spectrumAxis() string {
+	const width = 31
+	top, unit := m.inUnit(m.targetRange)
+	left := "  0"
+	mid := fmt.Sprintf("%.0f", top/2)
+	right := fmt.Sprintf("%.0f %s", top, unit)
+	gap := max(width-len(left)-len(mid)-len(right), 2)
+	return left + strings.Repeat(" ", gap/2) + mid + strings.Repeat(" ", gap-gap/2) + right
+}
+
 // renderSpectrumBar renders a spectrum analyzer bar showing RSSI by distance band
 func (m *Model) renderSpectrumBar() string {
 	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
@@ -1157,10 +1572,16 @@ func (m *Model) renderSpectrumBar() string {
 	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error)
 	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
 	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+	g := m.glyphs()
 
 	var sb strings.Builder
 	sb.WriteString(" ")
 
+	if !m.hasSignalData() {
+		sb.WriteString(m.renderNoSignal(24, 29))
+		return sb.String()
+	}
+
 	// Spectrum bars - we have up to 24 bins but display 29 chars wide
 	displayBins := 29
 	if len(m.spectrum) < displayBins {
@@ -1182,7 +1603,7 @@ func (m *Model) renderSpectrumBar() string {
 
 		// Get bar character based on level (0.0 to 1.0)
 		// Use different characters for different heights
-		barChar := "░"
+		barChar := g.BarEmpty
 		var style lipgloss.Style
 
 		if level > 0.05 {
@@ -1199,24 +1620,24 @@ func (m *Model) renderSpectrumBar() string {
 			// Choose bar character based on height
 			switch {
 			case level < 0.15:
-				barChar = "▁"
+				barChar = g.BarLevels[0]
 			case level < 0.3:
-				barChar = "▂"
+				barChar = g.BarLevels[1]
 			case level < 0.45:
-				barChar = "▃"
+				barChar = g.BarLevels[2]
 			case level < 0.6:
-				barChar = "▄"
+				barChar = g.BarLevels[3]
 			case level < 0.75:
-				barChar = "▅"
+				barChar = g.BarLevels[4]
 			case level < 0.9:
-				barChar = "▆"
+				barChar = g.BarLevels[5]
 			default:
-				barChar = "▇"
+				barChar = g.BarLevels[6]
 			}
 
 			// Show peak indicator if peak is higher than current
 			if peakLevel > level+0.1 && peakLevel > 0.3 {
-				barChar = "▇"
+				barChar = g.BarLevels[6]
 				style = primaryBright
 			}
 
@@ -1229,7 +1650,7 @@ func (m *Model) renderSpectrumBar() string {
 	// Pad remaining space
 	remaining := 30 - displayBins - 1
 	for i := 0; i < remaining; i++ {
-		sb.WriteString(textDim.Render("░"))
+		sb.WriteString(textDim.Render(g.BarEmpty))
 	}
 
 	return sb.String()
@@ -1246,14 +1667,16 @@ func (m *Model) renderAlertRulesPanel() string {
 	successStyle := lipgloss.NewStyle().Foreground(m.theme.Success)
 	errorStyle := lipgloss.NewStyle().Foreground(m.theme.Error)
 	warningStyle := lipgloss.NewStyle().Foreground(m.theme.Warning)
+	infoStyle := lipgloss.NewStyle().Foreground(m.theme.Info)
+	g := m.glyphs()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╔══════════════════════════════════════════╗"))
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 42) + g.DoubleTR))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("║") + titleStyle.Render("            ALERT RULES                   ") + borderStyle.Render("║"))
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(m.tr("title.alert_rules"), 42)) + borderStyle.Render(g.DoubleV))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("╚══════════════════════════════════════════╝"))
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 42) + g.DoubleBR))
 	sb.WriteString("\n\n")
 
 	alertsEnabled := m.IsAlertsEnabled()
@@ -1263,31 +1686,46 @@ func (m *Model) renderAlertRulesPanel() string {
 		enabledText = "ENABLED"
 		enabledStyle = successStyle
 	}
-	sb.WriteString("  Alerts: " + enabledStyle.Render(enabledText) + " " + textDim.Render("[A] toggle"))
+	sb.WriteString("  Alerts: " + enabledStyle.Render(enabledText) + " " + textDim.Render("[a] toggle"))
 	sb.WriteString("\n\n")
 
-	sb.WriteString(secondaryBright.Render("  RULES"))
+	rules := m.GetAlertRules()
+	heading := "  RULES"
+	switch {
+	case m.ruleDraft != nil:
+		heading = "  " + m.tr("title.rule_templates")
+	case m.ruleEditor != nil && m.ruleEditor.id != "":
+		heading = "  EDIT RULE"
+	case m.ruleEditor != nil:
+		heading = "  NEW RULE"
+	}
+	sb.WriteString(secondaryBright.Render(heading))
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 40)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
 	sb.WriteString("\n")
 
-	rules := m.GetAlertRules()
-	if len(rules) == 0 {
+	switch {
+	case m.ruleDraft != nil:
+		// The templates, or the values one asks for, stand in for the rules
+		m.renderRuleDraft(&sb)
+	case m.ruleEditor != nil:
+		m.renderRuleEditor(&sb)
+	case len(rules) == 0:
 		sb.WriteString("  " + textDim.Render("No alert rules configured"))
 		sb.WriteString("\n")
-	} else {
+	default:
 		for i, rule := range rules {
 			isCursor := i == m.alertRuleCursor
 
 			prefix := "  "
 			if isCursor {
-				prefix = playIndicator
+				prefix = g.Cursor + " "
 			}
 
-			marker := bulletEmpty
+			marker := g.Off
 			markerStyle := textDim
 			if rule.Enabled {
-				marker = bulletFilled
+				marker = g.On
 				markerStyle = successStyle
 			}
 
@@ -1299,9 +1737,10 @@ func (m *Model) renderAlertRulesPanel() string {
 			}
 
 			name := rule.Name
-			if len(name) > 25 {
-				name = name[:22] + "..."
+			if radius, ok := rule.ProximityRadius(); ok && rule.ID == alerts.ProximityRuleID {
+				name += " " + m.formatDist(radius, 0)
 			}
+			name = ansi.Truncate(name, 25, "...")
 
 			priorityStyle := textDim
 			if rule.Priority >= 80 {
@@ -1310,26 +1749,47 @@ func (m *Model) renderAlertRulesPanel() string {
 				priorityStyle = warningStyle
 			}
 
-			sb.WriteString(fmt.Sprintf("%s%s %s %s\n",
+			// Message rules match ACARS content rather than aircraft state
+			kind := "   "
+			if rule.IsMessageRule() {
+				kind = "MSG"
+			}
+
+			// Rules that ring the bell say so after the priority
+			bell := ""
+			if rule.HasAction(alerts.ActionBell) {
+				bell = " BEL"
+			}
+
+			sb.WriteString(fmt.Sprintf("%s%s %s %s %s%s\n",
 				prefix,
 				markerStyle.Render(marker),
 				style.Render(fmt.Sprintf("%-25s", name)),
+				infoStyle.Render(kind),
 				priorityStyle.Render(fmt.Sprintf("P%d", rule.Priority)),
+				infoStyle.Render(bell),
 			))
 		}
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 40)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
 	sb.WriteString("\n")
 
-	sb.WriteString(secondaryBright.Render("  RECENT ALERTS"))
+	recentHeading := "  RECENT ALERTS"
+	if m.showEmergencies {
+		recentHeading = "  " + m.tr("title.emergencies")
+	}
+	sb.WriteString(secondaryBright.Render(recentHeading))
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 40)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
 	sb.WriteString("\n")
 
 	recentAlerts := m.GetRecentAlerts()
-	if len(recentAlerts) == 0 {
+	if m.showEmergencies {
+		// Emergencies are recorded even while alerts are off
+		m.renderEmergencyHistory(&sb)
+	} else if len(recentAlerts) == 0 {
 		sb.WriteString("  " + textDim.Render("No recent alerts"))
 		sb.WriteString("\n")
 	} else {
@@ -1345,20 +1805,23 @@ func (m *Model) renderAlertRulesPanel() string {
 				agoStr = fmt.Sprintf("%dm", int(ago.Minutes()))
 			}
 
-			msg := alert.Message
-			if len(msg) > 28 {
-				msg = msg[:25] + "..."
+			// Where the aircraft was when the alert fired
+			dist := ""
+			if alert.Distance > 0 {
+				dist = " " + m.formatDist(alert.Distance, 1)
 			}
+			msg := ansi.Truncate(alert.Message, 28-lipgloss.Width(dist), "...")
 
-			sb.WriteString(fmt.Sprintf("  %s %s\n",
+			sb.WriteString(fmt.Sprintf("  %s %s%s\n",
 				textDim.Render(fmt.Sprintf("[%4s]", agoStr)),
 				warningStyle.Render(msg),
+				textDim.Render(dist),
 			))
 		}
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 40)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
 	sb.WriteString("\n")
 
 	stats := m.GetAlertStats()
@@ -1366,11 +1829,30 @@ func (m *Model) renderAlertRulesPanel() string {
 	sb.WriteString(fmt.Sprintf("  Geofences: %d  Highlighted: %d\n", stats.TotalGeofences, stats.Highlighted))
 
 	sb.WriteString("\n")
-	sb.WriteString(borderDim.Render("  " + strings.Repeat("─", 40)))
+	sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
 	sb.WriteString("\n")
-	sb.WriteString(textDim.Render("  [Space/Enter] Toggle rule"))
-	sb.WriteString("\n")
-	sb.WriteString(textDim.Render("  [A] Toggle alerts  [R/Esc] Close"))
+	switch {
+	case m.ruleDraft != nil:
+		sb.WriteString(textDim.Render("  " + m.tr("template.help_next")))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  " + m.tr("template.help_defaults")))
+	case m.ruleEditor != nil:
+		sb.WriteString(textDim.Render("  [Up/Down] Field  [Left/Right] Choose"))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  [Enter] Next, save on Action  [Esc] Cancel"))
+	default:
+		sb.WriteString(textDim.Render("  [Space/Enter] Toggle rule  [A/D] All on/off"))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  [n] New rule  [e] Edit  [d] Delete"))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  [b] Toggle bell on rule  [a] Toggle alerts"))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  [+/-] Proximity radius"))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  " + m.tr("geofence.hint") + "  " + m.tr("emergency.hint")))
+		sb.WriteString("\n")
+		sb.WriteString(textDim.Render("  [R/Esc] Close"))
+	}
 
 	return sb.String()
 }
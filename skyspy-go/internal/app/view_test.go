@@ -4,9 +4,12 @@ package app
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/skyspy/skyspy-go/internal/radar"
 	"github.com/skyspy/skyspy-go/internal/search"
+	"github.com/skyspy/skyspy-go/internal/ui"
 )
 
 // =============================================================================
@@ -900,6 +903,60 @@ func TestView_FormatSpeed(t *testing.T) {
 	}
 }
 
+func TestView_DualUnits(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.DualUnits = true
+	cfg.Display.Locale = "en-GB"
+	m := NewModel(cfg)
+	m.aircraft["DUAL01"] = &radar.Target{Hex: "DUAL01", Callsign: "KLM123",
+		BaroAltitude: 35000, HasBaroAlt: true, Speed: 450, HasSpeed: true}
+	m.selectedHex = "DUAL01"
+
+	detail := ansi.Strip(m.renderTargetPanel())
+	for _, want := range []string{"ALT  35,000 ft / 10,670 m", "GS   450 kt / 835 km/h"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("expected %q in the details:\n%s", want, detail)
+		}
+	}
+	if strings.Contains(detail, "GNSS") {
+		t.Errorf("no GNSS row without a GNSS altitude:\n%s", detail)
+	}
+
+	// Lists and labels stay single-unit
+	if got := m.formatSpeed(m.aircraft["DUAL01"]); got != "450 kt" {
+		t.Errorf("formatSpeed = %q, want single-unit", got)
+	}
+}
+
+func TestView_DualUnitsFitPanel(t *testing.T) {
+	// The longest realistic values, in a locale with long grouping
+	for _, locale := range []string{"", "en-GB", "fr-FR"} {
+		cfg := newTestConfig()
+		cfg.Display.DualUnits = true
+		cfg.Display.Locale = locale
+		m := NewModel(cfg)
+		m.aircraft["DUAL01"] = &radar.Target{Hex: "DUAL01", Callsign: "KLM123",
+			BaroAltitude: 60000, HasBaroAlt: true, GeomAltitude: -1500, HasGeomAlt: true,
+			Speed: 1500, HasSpeed: true}
+		m.selectedHex = "DUAL01"
+
+		detail := ansi.Strip(m.renderTargetPanel())
+		if !strings.Contains(detail, "GNSS ") {
+			t.Errorf("%q: expected a GNSS row:\n%s", locale, detail)
+		}
+		lines := strings.Split(detail, "\n")
+		width := ansi.StringWidth(lines[len(lines)-1])
+		for i, line := range lines {
+			if w := ansi.StringWidth(line); w > width {
+				t.Errorf("%q: line %d is %d columns, wider than the panel's %d: %q", locale, i, w, width, line)
+			}
+			if strings.HasPrefix(line, "│  ") && !strings.HasSuffix(line, " │") {
+				t.Errorf("%q: line %d runs into the border: %q", locale, i, line)
+			}
+		}
+	}
+}
+
 func TestView_FormatVS(t *testing.T) {
 	cfg := newTestConfig()
 	m := NewModel(cfg)
@@ -1098,6 +1155,53 @@ func TestView_RenderVUMeter(t *testing.T) {
 	}
 }
 
+func TestView_VUMeters_NoSignalData(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.ShowVUMeters = true
+	cfg.Display.ShowSpectrum = true
+	m := NewModel(cfg)
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+
+	// Traffic without RSSI is not signal data
+	m.aircraft["NOSIG1"] = &radar.Target{Hex: "NOSIG1", Distance: 20}
+	m.handleTick()
+	panel := ansi.Strip(m.renderStatsPanel())
+	for _, label := range []string{"VU L", "VU R"} {
+		if !strings.Contains(panel, label+" "+strings.Repeat(m.glyphs().BarEmpty, 10)+" n/a") {
+			t.Errorf("expected %s greyed out and tagged n/a, got %q", label, panel)
+		}
+	}
+	spectrum := ansi.Strip(m.renderSpectrumBar())
+	if !strings.Contains(spectrum, "n/a") {
+		t.Errorf("expected the spectrum tagged n/a, got %q", spectrum)
+	}
+	if w := len([]rune(spectrum)); w != 30 {
+		t.Errorf("no-data spectrum should keep the bar's width, got %d", w)
+	}
+
+	// One aircraft with RSSI brings the meters back
+	m.aircraft["SIG001"] = &radar.Target{Hex: "SIG001", Distance: 30, RSSI: -5, HasRSSI: true}
+	m.handleTick()
+	if strings.Contains(ansi.Strip(m.renderStatsPanel()), "n/a") || strings.Contains(ansi.Strip(m.renderSpectrumBar()), "n/a") {
+		t.Error("panels should show levels while aircraft report RSSI")
+	}
+
+	// A brief gap holds the last state instead of flickering
+	m.aircraft["SIG001"].HasRSSI = false
+	clock = clock.Add(signalDataHold / 2)
+	m.handleTick()
+	if strings.Contains(ansi.Strip(m.renderStatsPanel()), "n/a") {
+		t.Error("a gap shorter than the hold time should not show n/a")
+	}
+
+	clock = clock.Add(signalDataHold)
+	m.handleTick()
+	if !strings.Contains(ansi.Strip(m.renderStatsPanel()), "n/a") {
+		t.Error("expected n/a once the hold time has passed without RSSI")
+	}
+}
+
 // =============================================================================
 // Theme Display Tests
 // =============================================================================
@@ -1220,3 +1324,186 @@ func TestView_UnicodeCharacters(t *testing.T) {
 		t.Log("View may use different border characters in some terminals")
 	}
 }
+
+func TestView_ProfileReplacesSpectrum(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Display.ShowSpectrum = true
+	m := NewModel(cfg)
+
+	m.aircraft["PRO003"] = &radar.Target{Hex: "PRO003", Lat: 52.5, Lon: 4.9, HasLat: true, HasLon: true}
+	m.trailTracker.AddPositionWithAltitude("PRO003", 52.4, 4.9, 12000, true)
+	m.trailTracker.AddPositionWithAltitude("PRO003", 52.5, 4.9, 15000, true)
+	m.selectedHex = "PRO003"
+
+	if !strings.Contains(m.View(), "SPECTRUM") {
+		t.Error("spectrum should render before the profile is toggled")
+	}
+
+	m.showProfile = true
+	output := m.View()
+	if !strings.Contains(output, "PROFILE") {
+		t.Error("expected profile panel")
+	}
+	if strings.Contains(output, "SPECTRUM") {
+		t.Error("profile should replace the spectrum panel")
+	}
+	if !strings.Contains(output, "10k") {
+		t.Error("expected 10k gridline label")
+	}
+}
+
+func TestView_TargetPanel_ShowsCPA(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	target := &radar.Target{
+		Hex: "CPA003", Callsign: "INBOUND",
+		Lat: cfg.Connection.ReceiverLat + 0.1, Lon: cfg.Connection.ReceiverLon, HasLat: true, HasLon: true,
+		Track: 180, HasTrack: true, Speed: 240, HasSpeed: true,
+	}
+	m.aircraft["CPA003"] = target
+	m.selectedHex = "CPA003"
+
+	output := m.renderTargetPanel()
+	if !strings.Contains(output, "CPA") || !strings.Contains(output, "0.0nm in 1:30") {
+		t.Errorf("expected CPA row with a 1:30 countdown, got:\n%s", output)
+	}
+
+	target.Track = 0
+	if !strings.Contains(m.renderTargetPanel(), "opening") {
+		t.Error("outbound target should show opening")
+	}
+}
+
+func TestView_PinnedBlocks(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	m.width = 140
+	addPinTarget(m, "ABC123", "UAL123")
+	m.pinned = []string{"ABC123"}
+
+	view := m.View()
+	if !strings.Contains(view, "1 UAL123") {
+		t.Error("expected pinned data block in radar view")
+	}
+
+	m.height = 30
+	pinnedLines := strings.Count(m.View(), "\n")
+	m.pinned = nil
+	if plain := strings.Count(m.View(), "\n"); pinnedLines != plain {
+		t.Errorf("pinned panel should not grow the view, got %d lines vs %d", pinnedLines, plain)
+	}
+}
+
+func TestView_TargetPanel_ShowsTurn(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	clock := time.Now()
+	m.now = func() time.Time { return clock }
+	m.aircraft["TRN001"] = &radar.Target{Hex: "TRN001", Callsign: "TURNER", Track: 30, HasTrack: true}
+	m.selectedHex = "TRN001"
+
+	if !strings.Contains(m.renderTargetPanel(), "TURN") {
+		t.Fatal("expected TURN row in target panel")
+	}
+
+	for i := 0; i <= 10; i++ {
+		m.turnTracker.AddTrack("TRN001", float64(i*3), clock.Add(time.Duration(i)*time.Second))
+	}
+	clock = clock.Add(10 * time.Second)
+
+	if got := m.formatTurn(m.aircraft["TRN001"]); got != "↻ R 3.0°/s" {
+		t.Errorf("expected right turn at 3.0 deg/s, got %q", got)
+	}
+	if mark, ok := m.turnMarks()["TRN001"]; !ok || !mark.Right {
+		t.Errorf("expected right turn mark, got %+v (ok=%v)", mark, ok)
+	}
+}
+
+func TestView_TargetPanel_NavModes(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+
+	target := &radar.Target{Hex: "NAV001", Callsign: "AUTOPLT"}
+	m.aircraft["NAV001"] = target
+	m.selectedHex = "NAV001"
+
+	if got := m.formatNavSelected(target); got != dashPlaceholder {
+		t.Errorf("expected placeholder without nav data, got %q", got)
+	}
+	if got := m.formatNavModes(target); got != dashPlaceholder {
+		t.Errorf("expected placeholder without nav modes, got %q", got)
+	}
+
+	target.NavAltitude, target.HasNavAlt = 4000, true
+	target.NavHeading, target.HasNavHeading = 270, true
+	target.NavQNH, target.HasNavQNH = 1013.2, true
+	target.NavModes = []string{"autopilot", "althold", "lnav"}
+
+	output := m.renderTargetPanel()
+	if !strings.Contains(output, "4000 HDG 270 QNH 1013") {
+		t.Errorf("expected selected altitude/heading/QNH row, got:\n%s", output)
+	}
+	if !strings.Contains(output, "AP ALT LNAV") {
+		t.Errorf("expected nav modes row, got:\n%s", output)
+	}
+
+	// Only the fields that are present are shown
+	target.HasNavHeading, target.HasNavQNH = false, false
+	target.NavAltitude = 35000
+	if got := m.formatNavSelected(target); got != "FL350" {
+		t.Errorf("expected FL350 alone, got %q", got)
+	}
+}
+
+// =============================================================================
+// Altitude Ribbon Tests
+// =============================================================================
+
+func TestView_AltitudeRibbon(t *testing.T) {
+	cfg := newTestConfig()
+	m := NewModel(cfg)
+	m.aircraft["TGT001"] = &radar.Target{
+		Hex: "TGT001", HasLat: true, Lat: 52.4, HasLon: true, Lon: 4.95,
+		HasAlt: true, Altitude: 35000, Distance: 30, Bearing: 90,
+	}
+
+	plain := strings.Split(m.renderRadar(), "\n")
+
+	m.handleRadarKey("z")
+	if !cfg.Display.ShowAltitudeRibbon || m.notification != "Altitude ribbon: ON" {
+		t.Fatalf("Z should turn the ribbon on, got %v (%q)", cfg.Display.ShowAltitudeRibbon, m.notification)
+	}
+	withRibbon := strings.Split(m.renderRadar(), "\n")
+
+	if len(withRibbon) != len(plain) {
+		t.Fatalf("ribbon should not change the radar height: %d vs %d lines", len(withRibbon), len(plain))
+	}
+	for i := range plain {
+		if got := ansi.StringWidth(withRibbon[i]) - ansi.StringWidth(plain[i]); got != ui.RibbonWidth {
+			t.Errorf("line %d: expected ribbon to add %d columns, added %d", i, ui.RibbonWidth, got)
+		}
+	}
+	if !strings.Contains(ansi.Strip(strings.Join(withRibbon, "\n")), "40┤") {
+		t.Error("ribbon should show its altitude scale")
+	}
+}
+
+func TestModel_RibbonBand(t *testing.T) {
+	m := NewModel(newTestConfig())
+
+	if floor, ceiling := m.ribbonBand(); floor != 0 || ceiling != ui.DefaultRibbonCeiling {
+		t.Errorf("expected the default scale, got %d-%d", floor, ceiling)
+	}
+
+	m.applyFilterPreset(search.PresetLowAltitude())
+	if floor, ceiling := m.ribbonBand(); floor != 0 || ceiling != 10000 {
+		t.Errorf("expected the low-altitude band, got %d-%d", floor, ceiling)
+	}
+
+	m.searchFilter = search.ParseQuery("alt:>50000")
+	if floor, ceiling := m.ribbonBand(); floor != 50000 || ceiling <= floor {
+		t.Errorf("an open band above the default ceiling should still have a span, got %d-%d", floor, ceiling)
+	}
+}
@@ -0,0 +1,82 @@
+// Package app provides the watch list for the SkySpy radar
+package app
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// onWatchlist reports whether the target's hex or callsign is on the
+// watch list
+func (m *Model) onWatchlist(t *radar.Target) bool {
+	return m.watchlistHex(t.Hex) >= 0 || m.watchlistPattern(t.Callsign) != ""
+}
+
+// watchlistHex returns the index of hex in the watch list, or -1
+func (m *Model) watchlistHex(hex string) int {
+	for i, h := range m.config.Watchlist.Hexes {
+		if strings.EqualFold(strings.TrimSpace(h), hex) {
+			return i
+		}
+	}
+	return -1
+}
+
+// watchlistPattern returns the first callsign pattern on the watch list
+// that matches callsign, or "" when none does
+func (m *Model) watchlistPattern(callsign string) string {
+	if callsign == "" {
+		return ""
+	}
+	for _, pattern := range m.config.Watchlist.Callsigns {
+		if alerts.MatchesWildcard(strings.TrimSpace(pattern), callsign) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// toggleWatchlist adds the selected aircraft's hex to the watch list, or
+// takes it off, and saves the list. An aircraft watched for its callsign
+// stays watched until the pattern is taken out of the settings.
+func (m *Model) toggleWatchlist() {
+	target, ok := m.aircraft[m.selectedHex]
+	if !ok || m.selectedHex == "" {
+		m.notify(m.tr("notify.no_target"))
+		return
+	}
+
+	watch := &m.config.Watchlist
+	if i := m.watchlistHex(target.Hex); i >= 0 {
+		watch.Hexes = append(watch.Hexes[:i], watch.Hexes[i+1:]...)
+		if pattern := m.watchlistPattern(target.Callsign); pattern != "" {
+			m.notify(m.trf("notify.watch_pattern", pinLabel(target), pattern))
+		} else {
+			m.notify(m.trf("notify.watch_removed", pinLabel(target)))
+		}
+	} else {
+		watch.Hexes = append(watch.Hexes, strings.ToUpper(target.Hex))
+		m.notify(m.trf("notify.watch_added", pinLabel(target)))
+	}
+
+	for _, t := range m.aircraft {
+		t.OnWatchlist = m.onWatchlist(t)
+	}
+	m.searcher.Reset()
+	m.saveConfig()
+}
+
+// watchlistFirst moves watched aircraft to the front of hexes. The rest
+// keep their order.
+func (m *Model) watchlistFirst(hexes []string) {
+	watched := func(hex string) bool {
+		t, ok := m.aircraft[hex]
+		return ok && t.OnWatchlist
+	}
+	sort.SliceStable(hexes, func(i, j int) bool {
+		return watched(hexes[i]) && !watched(hexes[j])
+	})
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/search"
+)
+
+func TestWatchlist_ToggleAndSave(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.ResetConfigPathsForTesting()
+	config.InitConfigPaths()
+	t.Cleanup(config.ResetConfigPathsForTesting)
+
+	m, _ := newTrackingModel()
+	m.updateTarget(flying("4ca7b5", 52.40), true)
+	m.selectedHex = "4CA7B5"
+
+	pressKey(m, "w")
+	if !m.aircraft["4CA7B5"].OnWatchlist || m.notification != "Watching: 4CA7B5" {
+		t.Fatalf("w should watch the selected aircraft: %q", m.notification)
+	}
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Watchlist.Hexes) != 1 || saved.Watchlist.Hexes[0] != "4CA7B5" {
+		t.Errorf("saved hexes = %v", saved.Watchlist.Hexes)
+	}
+
+	pressKey(m, "w")
+	if m.aircraft["4CA7B5"].OnWatchlist || len(m.config.Watchlist.Hexes) != 0 {
+		t.Error("w again should stop watching it")
+	}
+	if m.notification != "No longer watching: 4CA7B5" {
+		t.Errorf("notification = %q", m.notification)
+	}
+}
+
+func TestWatchlist_CallsignPatterns(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.config.Watchlist.Hexes = []string{"ae1234"}
+	m.config.Watchlist.Callsigns = []string{"RCH*"}
+
+	ac := flying("AE5678", 52.40)
+	ac.Flight = "RCH401  "
+	m.updateTarget(ac, true)
+	m.updateTarget(flying("AE1234", 52.50), true)
+	m.updateTarget(&codec.Aircraft{Hex: "484F6D", Flight: "KLM1234", Lat: floatPtr(52.45), Lon: floatPtr(4.9041)}, true)
+
+	for hex, want := range map[string]bool{"AE5678": true, "AE1234": true, "484F6D": false} {
+		if got := m.aircraft[hex].OnWatchlist; got != want {
+			t.Errorf("%s watched = %v, want %v", hex, got, want)
+		}
+	}
+
+	// Taking the hex of an aircraft the pattern matches off the list
+	// leaves it watched, and says why
+	m.config.Watchlist.Hexes = append(m.config.Watchlist.Hexes, "AE5678")
+	m.selectedHex = "AE5678"
+	m.toggleWatchlist()
+	if !m.aircraft["AE5678"].OnWatchlist || m.notification != "RCH401 is still watched as RCH*" {
+		t.Errorf("watched %v, notification %q", m.aircraft["AE5678"].OnWatchlist, m.notification)
+	}
+
+	results := search.FilterAircraft(m.aircraft, search.ParseQuery("watched:"))
+	if len(results) != 2 {
+		t.Errorf("watched: found %v", results)
+	}
+}
+
+func TestWatchlist_ListedFirstAndMarked(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.width, m.height = 160, 60
+	m.config.Watchlist.Hexes = []string{"FAR001"}
+
+	m.updateTarget(flying("NEAR01", 52.40), true)
+	m.updateTarget(flying("FAR001", 53.00), true)
+	m.renderRadar()
+
+	if len(m.sortedTargets) != 2 || m.sortedTargets[0] != "FAR001" {
+		t.Fatalf("the watched aircraft should lead the list: %v", m.sortedTargets)
+	}
+	marker := m.glyphs().Watchlist
+	rows := strings.Split(ansi.Strip(m.renderTargetList()), "\n")
+	for _, row := range rows {
+		if strings.Contains(row, "FAR001") && !strings.Contains(row, marker+" FAR001") {
+			t.Errorf("the watched row should be marked: %q", row)
+		}
+	}
+}
+
+func TestWatchlist_Alert(t *testing.T) {
+	m, _ := newTrackingModel()
+	m.config.Watchlist.Hexes = []string{"4CA7B5"}
+	engine := alerts.NewAlertEngine()
+	engine.AddRule(alerts.NewAlertRule("watched", "Watched").AddCondition(alerts.ConditionWatched, "true"))
+	m.alertState = &AlertState{Engine: engine, AlertsEnabled: true}
+
+	m.updateTarget(flying("484F6D", 52.40), true)
+	m.updateTarget(flying("4CA7B5", 52.45), true)
+	recent := m.alertState.RecentAlerts
+	if len(recent) != 1 || recent[0].Hex != "4CA7B5" {
+		t.Fatalf("only the watched aircraft should alert, got %v", recent)
+	}
+
+	// Not again on every update
+	m.updateTarget(flying("4CA7B5", 52.46), false)
+	if len(m.alertState.RecentAlerts) != 1 {
+		t.Errorf("the alert fired again: %v", m.alertState.RecentAlerts)
+	}
+}
@@ -0,0 +1,67 @@
+// Package app provides the post-upgrade changelog panel for the SkySpy radar
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/changelog"
+)
+
+// whatsNewWidth is the wrap width of changelog items in the panel
+const whatsNewWidth = 36
+
+// ShowWhatsNew opens the "What's new" panel listing the changes since the
+// last release the user ran. Any key dismisses it.
+func (m *Model) ShowWhatsNew(version string, entries []changelog.Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	m.whatsNewVersion = strings.TrimPrefix(version, "v")
+	m.whatsNew = entries
+	m.viewMode = ViewWhatsNew
+}
+
+func (m *Model) renderWhatsNewPanel() string {
+	borderStyle := lipgloss.NewStyle().Foreground(m.theme.Border)
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright).Bold(true)
+	secondaryBright := lipgloss.NewStyle().Foreground(m.theme.SecondaryBright).Bold(true)
+	borderDim := lipgloss.NewStyle().Foreground(m.theme.BorderDim)
+	textDim := lipgloss.NewStyle().Foreground(m.theme.TextDim)
+	primaryBright := lipgloss.NewStyle().Foreground(m.theme.PrimaryBright)
+	textStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	wrap := lipgloss.NewStyle().Width(whatsNewWidth)
+	g := m.glyphs()
+
+	var sb strings.Builder
+
+	title := m.trf("title.whats_new", m.whatsNewVersion)
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 42) + g.DoubleTR))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleV) + titleStyle.Render(panelHeading(title, 42)) + borderStyle.Render(g.DoubleV))
+	sb.WriteString("\n")
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 42) + g.DoubleBR))
+	sb.WriteString("\n\n")
+
+	for _, entry := range m.whatsNew {
+		sb.WriteString(secondaryBright.Render("  v" + strings.TrimPrefix(entry.Version, "v")))
+		sb.WriteString("\n")
+		sb.WriteString(borderDim.Render("  " + strings.Repeat(g.H, 40)))
+		sb.WriteString("\n")
+		for _, item := range entry.Items {
+			for i, line := range strings.Split(wrap.Render(item), "\n") {
+				bullet := "  "
+				if i == 0 {
+					bullet = primaryBright.Render(g.Cursor) + " "
+				}
+				sb.WriteString("   " + bullet + textStyle.Render(strings.TrimRight(line, " ")))
+				sb.WriteString("\n")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(textDim.Render("  " + m.tr("help.close")))
+
+	return sb.String()
+}
@@ -0,0 +1,101 @@
+// Package app provides target list clipboard copy for the SkySpy radar
+package app
+
+import (
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/clipboard"
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// targetListRows is the number of rows in the sidebar target list
+const targetListRows = 8
+
+// clipboardHold is how long an OSC 52 sequence stays in the frame; like
+// the bell's BEL, long enough for one frame to carry it out
+const clipboardHold = bellHold
+
+// clipboardMsg reports the outcome of a yank, with the sequence that sets
+// the clipboard
+type clipboardMsg struct {
+	rows   int
+	seq    string
+	result clipboard.Result
+	err    error
+}
+
+// listTargets returns the targets shown in the target list, in list order,
+// as displayed. sortedTargets already reflects the active filters and range.
+func (m *Model) listTargets() []*radar.Target {
+	targets := make([]*radar.Target, 0, targetListRows)
+	for _, hex := range m.sortedTargets {
+		if len(targets) >= targetListRows {
+			break
+		}
+		if target, ok := m.aircraft[hex]; ok {
+			targets = append(targets, m.displayTarget(target))
+		}
+	}
+	return targets
+}
+
+// yankListCmd copies the visible target list rows to the clipboard as CSV.
+// Encoding, or the temp file, happens in the command so it stays off the
+// update loop; the sequence itself goes out with the next frame.
+func (m *Model) yankListCmd() tea.Cmd {
+	targets := m.listTargets()
+	if len(targets) == 0 {
+		m.notify(m.tr("notify.no_rows"))
+		return nil
+	}
+
+	var sb strings.Builder
+	if err := export.WriteAircraftCSV(&sb, targets, m.distUnit()); err != nil {
+		m.notify(m.trf("notify.copy_failed", err.Error()))
+		return nil
+	}
+
+	writer := m.clipboard
+	data := []byte(sb.String())
+	return func() tea.Msg {
+		seq, result, err := writer.Sequence(data)
+		return clipboardMsg{rows: len(targets), seq: seq, result: result, err: err}
+	}
+}
+
+// handleClipboardMsg sends the sequence with the next frame and notifies
+// the user where the rows went
+func (m *Model) handleClipboardMsg(msg clipboardMsg) {
+	if msg.seq != "" {
+		m.clipboardSeq = msg.seq
+		m.clipboardUntil = m.now().Add(clipboardHold)
+	}
+	switch {
+	case msg.err != nil:
+		m.notify(m.trf("notify.copy_failed", msg.err.Error()))
+	case msg.result.Method == clipboard.MethodFile:
+		m.notify(m.trf("notify.clipboard_saved", msg.result.Path))
+	case msg.result.Truncated:
+		m.notify(m.trf("notify.copied_truncated", msg.rows, msg.result.Bytes))
+	default:
+		m.notify(m.trf("notify.copied", msg.rows))
+	}
+}
+
+// newClipboard returns the clipboard writer. It never writes itself: its
+// sequences go out through the renderer with a frame.
+func newClipboard() *clipboard.Writer {
+	return clipboard.NewWriter(io.Discard)
+}
+
+// withClipboard puts a pending clipboard sequence in front of a rendered
+// frame. Writing it to stdout from the command would race the renderer.
+func (m *Model) withClipboard(frame string) string {
+	if m.clipboardSeq != "" && m.now().Before(m.clipboardUntil) {
+		return m.clipboardSeq + frame
+	}
+	return frame
+}
@@ -0,0 +1,135 @@
+// Package app provides range steps and custom range entry for the SkySpy
+// radar
+package app
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/spectrum"
+	"github.com/skyspy/skyspy-go/internal/units"
+)
+
+// Bounds for any range, stepped or typed in, in nm
+const (
+	minRangeNM = 5
+	maxRangeNM = 500
+)
+
+// rangeEntryDigits caps the custom range prompt; 500 nm is three digits
+// in any unit
+const rangeEntryDigits = 3
+
+// rangeSteps returns the configured range steps, clamped, sorted and
+// without duplicates. An empty list falls back to the default steps.
+func rangeSteps(cfg *config.Config) []int {
+	steps := cfg.Radar.RangeSteps
+	if len(steps) == 0 {
+		steps = config.DefaultConfig().Radar.RangeSteps
+	}
+	out := make([]int, 0, len(steps))
+	for _, nm := range steps {
+		out = append(out, clampRange(nm))
+	}
+	sort.Ints(out)
+	deduped := out[:1]
+	for _, nm := range out[1:] {
+		if nm != deduped[len(deduped)-1] {
+			deduped = append(deduped, nm)
+		}
+	}
+	return deduped
+}
+
+// clampRange keeps a range within 5 to 500 nm
+func clampRange(nm int) int {
+	if nm < minRangeNM {
+		return minRangeNM
+	}
+	if nm > maxRangeNM {
+		return maxRangeNM
+	}
+	return nm
+}
+
+// stepIndex returns the index of the first step at or above nm, or the
+// last step when nm is beyond them all
+func stepIndex(steps []int, nm int) int {
+	for i, r := range steps {
+		if r >= nm {
+			return i
+		}
+	}
+	return len(steps) - 1
+}
+
+// setRangeStep zooms the main scope to the given step
+func (m *Model) setRangeStep(idx int) {
+	m.rangeIdx = idx
+	m.customRange = false
+	m.applyRange(m.rangeOptions[idx])
+}
+
+// setCustomRange zooms the main scope to nm, clamped to the allowed
+// range. A value that matches a step is treated as that step.
+func (m *Model) setCustomRange(nm int) {
+	nm = clampRange(nm)
+	m.rangeIdx = stepIndex(m.rangeOptions, nm)
+	m.customRange = m.rangeOptions[m.rangeIdx] != nm
+	m.applyRange(nm)
+}
+
+// applyRange sets the range the scope eases to and remembers it as the
+// range to start with next time
+func (m *Model) applyRange(nm int) {
+	m.targetRange = float64(nm)
+	m.config.Radar.DefaultRange = nm
+	m.notifyRange("notify.range", nm)
+}
+
+// openRangeEntry starts the custom range prompt, shown in the status bar
+func (m *Model) openRangeEntry() {
+	m.rangeEntryOpen = true
+	m.rangeEntry = ""
+}
+
+// handleRangeEntryKey takes digits for the custom range; Enter applies
+// it and Esc cancels
+func (m *Model) handleRangeEntryKey(key string) {
+	switch key {
+	case keyEsc:
+		m.rangeEntryOpen = false
+	case keyEnter:
+		m.rangeEntryOpen = false
+		if m.rangeEntry == "" {
+			return
+		}
+		d, err := strconv.Atoi(m.rangeEntry)
+		if err != nil {
+			m.notify(m.tr("notify.invalid_range"))
+			return
+		}
+		// Typed in the unit shown
+		m.setCustomRange(int(math.Round(units.ToNM(float64(d), m.distUnit()))))
+	case "backspace":
+		if m.rangeEntry != "" {
+			m.rangeEntry = m.rangeEntry[:len(m.rangeEntry)-1]
+		}
+	default:
+		if len(key) == 1 && key[0] >= '0' && key[0] <= '9' && len(m.rangeEntry) < rangeEntryDigits {
+			m.rangeEntry += key
+		}
+	}
+}
+
+// syncSpectrumBands spreads the spectrum's distance bands across the
+// current range whenever it changes
+func (m *Model) syncSpectrumBands() {
+	if m.spectrumRange == m.targetRange {
+		return
+	}
+	m.spectrumRange = m.targetRange
+	m.spectrumAnalyzer.SetDistanceBands(spectrum.RangeBands(m.targetRange, len(spectrum.DefaultDistanceBands)))
+}
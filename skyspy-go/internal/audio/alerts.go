@@ -2,6 +2,7 @@
 package audio
 
 import (
+	"errors"
 	"os/exec"
 	"runtime"
 	"sync"
@@ -24,6 +25,7 @@ const (
 	AlertNewAircraft AlertType = iota
 	AlertEmergency
 	AlertMilitary
+	AlertWarning // squawk codes classed below an emergency
 )
 
 // debounceInterval is the minimum time between same alert types
@@ -35,6 +37,10 @@ type AlertPlayer struct {
 	lastPlayed   map[AlertType]time.Time
 	mu           sync.Mutex
 	soundManager *SoundManager
+	quiet        func() bool                   // reports do-not-disturb; nil never silences
+	warn         func(sound string, err error) // told once when a sound file can't be used
+	warned       map[string]bool
+	channels     int // output channel count, 0 until SetChannels says
 }
 
 // NewAlertPlayer creates a new alert player with the given configuration
@@ -53,6 +59,31 @@ func (p *AlertPlayer) SetEnabled(enabled bool) {
 	p.config.Enabled = enabled
 }
 
+// SetQuiet installs a do-not-disturb check. While it reports true every
+// alert sound is skipped.
+func (p *AlertPlayer) SetQuiet(quiet func() bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.quiet = quiet
+}
+
+// SetChannels sets the output channel count, from OutputChannels, so a
+// mono output gets the mono sounds
+func (p *AlertPlayer) SetChannels(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.channels = n
+}
+
+// SetWarn installs the hook told when an alert rule's sound file is
+// missing or can't be decoded. It is told once per file until the file
+// loads again.
+func (p *AlertPlayer) SetWarn(warn func(sound string, err error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.warn = warn
+}
+
 // IsEnabled returns whether audio alerts are enabled
 func (p *AlertPlayer) IsEnabled() bool {
 	p.mu.Lock()
@@ -62,6 +93,12 @@ func (p *AlertPlayer) IsEnabled() bool {
 
 // PlayNewAircraft plays the new aircraft alert sound
 func (p *AlertPlayer) PlayNewAircraft() {
+	p.PlayNewAircraftFrom(Source{})
+}
+
+// PlayNewAircraftFrom plays the new aircraft alert sound, panned toward
+// the aircraft when panning is on
+func (p *AlertPlayer) PlayNewAircraftFrom(src Source) {
 	if !p.shouldPlay(AlertNewAircraft) {
 		return
 	}
@@ -72,11 +109,23 @@ func (p *AlertPlayer) PlayNewAircraft() {
 	}
 	p.mu.Unlock()
 
-	p.playSound(AlertNewAircraft)
+	p.playSound(AlertNewAircraft, src)
 }
 
 // PlayEmergency plays the emergency alert sound
 func (p *AlertPlayer) PlayEmergency() {
+	p.PlayEmergencyAt(0)
+}
+
+// PlayEmergencyAt plays the emergency alert with urgency scaled to the
+// target's distance (nm); 0 means unknown
+func (p *AlertPlayer) PlayEmergencyAt(distance float64) {
+	p.PlayEmergencyFrom(Source{Distance: distance})
+}
+
+// PlayEmergencyFrom plays the emergency alert with urgency scaled to the
+// target's distance, panned toward it when panning is on
+func (p *AlertPlayer) PlayEmergencyFrom(src Source) {
 	if !p.shouldPlay(AlertEmergency) {
 		return
 	}
@@ -87,11 +136,46 @@ func (p *AlertPlayer) PlayEmergency() {
 	}
 	p.mu.Unlock()
 
-	p.playSound(AlertEmergency)
+	p.playSoundFrom(AlertEmergency, src)
+}
+
+// PlayWarningAt plays the warning squawk alert with urgency scaled to the
+// target's distance (nm); 0 means unknown. It follows the emergency sound
+// setting.
+func (p *AlertPlayer) PlayWarningAt(distance float64) {
+	p.PlayWarningFrom(Source{Distance: distance})
+}
+
+// PlayWarningFrom plays the warning squawk alert with urgency scaled to
+// the target's distance, panned toward it when panning is on
+func (p *AlertPlayer) PlayWarningFrom(src Source) {
+	if !p.shouldPlay(AlertWarning) {
+		return
+	}
+	p.mu.Lock()
+	if !p.config.EmergencySound {
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
+	p.playSoundFrom(AlertWarning, src)
 }
 
 // PlayMilitary plays the military aircraft alert sound
 func (p *AlertPlayer) PlayMilitary() {
+	p.PlayMilitaryAt(0)
+}
+
+// PlayMilitaryAt plays the military alert with urgency scaled to the
+// target's distance (nm); 0 means unknown
+func (p *AlertPlayer) PlayMilitaryAt(distance float64) {
+	p.PlayMilitaryFrom(Source{Distance: distance})
+}
+
+// PlayMilitaryFrom plays the military alert with urgency scaled to the
+// target's distance, panned toward it when panning is on
+func (p *AlertPlayer) PlayMilitaryFrom(src Source) {
 	if !p.shouldPlay(AlertMilitary) {
 		return
 	}
@@ -102,7 +186,79 @@ func (p *AlertPlayer) PlayMilitary() {
 	}
 	p.mu.Unlock()
 
-	p.playSound(AlertMilitary)
+	p.playSoundFrom(AlertMilitary, src)
+}
+
+// PlayRuleSoundAt plays an alert rule's sound: the warning tone for
+// "warning", the new aircraft ping for "ping", a WAV file for a name
+// ending in .wav, or the emergency tone.
+// A sound file follows the emergency sound setting, and one that can't be
+// used plays the emergency tone instead.
+func (p *AlertPlayer) PlayRuleSoundAt(sound string, distance float64) {
+	p.PlayRuleSoundFrom(sound, Source{Distance: distance})
+}
+
+// PlayRuleSoundFrom plays an alert rule's sound as PlayRuleSoundAt does,
+// panned toward the target when panning is on
+func (p *AlertPlayer) PlayRuleSoundFrom(sound string, src Source) {
+	switch {
+	case sound == "warning":
+		p.PlayWarningFrom(src)
+	case sound == "ping":
+		// Asked for by the rule, whatever the new aircraft setting
+		if p.shouldPlay(AlertNewAircraft) {
+			p.playSoundFrom(AlertNewAircraft, src)
+		}
+	case IsSoundFile(sound):
+		p.playFileFrom(sound, src)
+	default:
+		p.PlayEmergencyFrom(src)
+	}
+}
+
+// PrepareSound loads and converts an alert rule's sound file ahead of its
+// first use, reporting why it can't be played
+func (p *AlertPlayer) PrepareSound(sound string) error {
+	path, err := p.soundManager.GetFileSoundPath(sound)
+	if err == nil && path == "" {
+		err = errors.New("no playable copy")
+	}
+
+	p.mu.Lock()
+	warn := p.warn
+	first := err != nil && !p.warned[sound]
+	if p.warned == nil {
+		p.warned = make(map[string]bool)
+	}
+	p.warned[sound] = err != nil
+	p.mu.Unlock()
+
+	if first && warn != nil {
+		warn(sound, err)
+	}
+	return err
+}
+
+// playFileFrom plays an alert rule's sound file, falling back to the
+// emergency tone
+func (p *AlertPlayer) playFileFrom(sound string, src Source) {
+	if !p.shouldPlay(AlertEmergency) {
+		return
+	}
+	p.mu.Lock()
+	if !p.config.EmergencySound {
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
+	if p.PrepareSound(sound) == nil {
+		path, _ := p.soundManager.GetFileSoundPath(sound)
+		if p.playPlatformSound(p.panned(path, src)) {
+			return
+		}
+	}
+	p.playSoundFrom(AlertEmergency, src)
 }
 
 // shouldPlay checks if enough time has passed since the last alert of this type
@@ -113,6 +269,9 @@ func (p *AlertPlayer) shouldPlay(alertType AlertType) bool {
 	if !p.config.Enabled {
 		return false
 	}
+	if p.quiet != nil && p.quiet() {
+		return false
+	}
 
 	now := time.Now()
 	if lastTime, exists := p.lastPlayed[alertType]; exists {
@@ -125,21 +284,56 @@ func (p *AlertPlayer) shouldPlay(alertType AlertType) bool {
 	return true
 }
 
-// playSound plays the sound for the given alert type
-func (p *AlertPlayer) playSound(alertType AlertType) {
-	soundPath := p.soundManager.GetSoundPath(alertType)
+// playSound plays the sound for the given alert type, panned toward src
+func (p *AlertPlayer) playSound(alertType AlertType, src Source) {
+	p.playPath(p.soundManager.GetSoundPath(alertType), src)
+}
+
+// playSoundFrom plays the sound for the given alert type in the urgency
+// band matching the source's distance, falling back to the built-in sound
+func (p *AlertPlayer) playSoundFrom(alertType AlertType, src Source) {
+	p.mu.Lock()
+	band, ok := SelectUrgencyBand(p.config.UrgencyBands, src.Distance)
+	p.mu.Unlock()
 
+	if !ok || isNeutralBand(band) {
+		p.playSound(alertType, src)
+		return
+	}
+	p.playPath(p.soundManager.GetBandSoundPath(alertType, band), src)
+}
+
+// playPath plays the sound file at soundPath panned toward src, falling
+// back to the terminal bell
+func (p *AlertPlayer) playPath(soundPath string, src Source) {
 	// Try platform-specific audio playback
-	if soundPath != "" {
-		if p.playPlatformSound(soundPath) {
-			return
-		}
+	if soundPath != "" && p.playPlatformSound(p.panned(soundPath, src)) {
+		return
 	}
 
 	// Fall back to terminal bell
 	p.playTerminalBell()
 }
 
+// panned returns a copy of the sound at path panned toward src, or path
+// itself when panning is off, the bearing is unknown or the output is mono
+func (p *AlertPlayer) panned(path string, src Source) string {
+	p.mu.Lock()
+	on, facing, channels := p.config.Pan, p.config.Facing, p.channels
+	p.mu.Unlock()
+
+	if !on || !src.HasBearing {
+		return path
+	}
+	if channels == 1 {
+		return path
+	}
+	if stereo := p.soundManager.GetPannedSoundPath(path, BearingPan(src.Bearing, facing)); stereo != "" {
+		return stereo
+	}
+	return path
+}
+
 // playPlatformSound attempts to play a sound file using platform-specific tools
 //
 //nolint:gosec // G204: soundPath is validated before use, not user-controllable
@@ -259,7 +259,7 @@ func TestAlertPlayer_PlaySound_EmptyPath(t *testing.T) {
 	}
 
 	// Should fall back to terminal bell without panicking
-	player.playSound(AlertNewAircraft)
+	player.playSound(AlertNewAircraft, Source{})
 }
 
 func TestAlertPlayer_PlayPlatformSound_Darwin(t *testing.T) {
@@ -560,7 +560,7 @@ func TestAlertPlayer_PlaySound_WithValidPath(t *testing.T) {
 	}
 
 	// This should try to play the sound
-	player.playSound(AlertNewAircraft)
+	player.playSound(AlertNewAircraft, Source{})
 }
 
 func TestAlertPlayer_PlaySound_FallbackToBell(t *testing.T) {
@@ -576,5 +576,5 @@ func TestAlertPlayer_PlaySound_FallbackToBell(t *testing.T) {
 	}
 
 	// With no sound path, should fall back to terminal bell
-	player.playSound(AlertNewAircraft)
+	player.playSound(AlertNewAircraft, Source{})
 }
@@ -0,0 +1,126 @@
+// Package audio provides audio alert functionality for SkySpy CLI
+package audio
+
+import (
+	"context"
+	"math"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source is where the aircraft an alert is about lies from the receiver
+type Source struct {
+	Distance   float64 // nm; 0 when unknown
+	Bearing    float64 // degrees true
+	HasBearing bool
+}
+
+// panSteps is how many pan positions there are on each side of center.
+// Each one used is a cached copy of the sound.
+const panSteps = 10
+
+// BearingPan maps a bearing to a stereo position from -1, full left, to 1,
+// full right, relative to the direction the listener faces. Ahead and
+// behind are both centered and abeam is fully to one side, so 90° from
+// the facing direction is full right and 270° full left.
+func BearingPan(bearing, facing float64) float64 {
+	return math.Sin((bearing - facing) * math.Pi / 180)
+}
+
+// PanGains returns the left and right channel gains for a pan position.
+// The nearer channel stays at full volume while the other fades, so a
+// centered sound is as loud as the mono original.
+func PanGains(pan float64) (left, right float64) {
+	pan = math.Max(-1, math.Min(1, pan))
+	return math.Min(1, 1-pan), math.Min(1, 1+pan)
+}
+
+// quantizePan rounds a pan position to the nearest step, -panSteps to
+// panSteps
+func quantizePan(pan float64) int {
+	pan = math.Max(-1, math.Min(1, pan))
+	return int(math.Round(pan * panSteps))
+}
+
+// StereoWAV encodes the audio as a 16-bit stereo WAV file, scaling each
+// channel by its gain
+func (p *PCM) StereoWAV(left, right float64) []byte {
+	dataSize := len(p.Samples) * 4
+	out := make([]byte, 44+dataSize)
+
+	copy(out[0:4], "RIFF")
+	writeLE32(out[4:8], uint32(36+dataSize))
+	copy(out[8:12], "WAVE")
+	copy(out[12:16], "fmt ")
+	writeLE32(out[16:20], 16)
+	writeLE16(out[20:22], wavPCM)
+	writeLE16(out[22:24], 2)
+	writeLE32(out[24:28], uint32(p.Rate))
+	writeLE32(out[28:32], uint32(p.Rate*4))
+	writeLE16(out[32:34], 4)
+	writeLE16(out[34:36], 16)
+	copy(out[36:40], "data")
+	writeLE32(out[40:44], uint32(dataSize))
+
+	for i, s := range p.Samples {
+		v := float64(s) / 32767
+		writeLE16(out[44+i*4:], uint16(toInt16(v*left)))
+		writeLE16(out[46+i*4:], uint16(toInt16(v*right)))
+	}
+	return out
+}
+
+var (
+	channelsOnce     sync.Once
+	detectedChannels int
+)
+
+// OutputChannels returns the channel count of the default output device,
+// detected once; 0 means it couldn't be told. The first call may run pactl,
+// so make it off the UI goroutine.
+func OutputChannels() int {
+	channelsOnce.Do(func() {
+		detectedChannels = detectOutputChannels()
+	})
+	return detectedChannels
+}
+
+// detectOutputChannels asks the sound server how many channels the
+// default output has. Only PulseAudio and PipeWire, through pactl, can be
+// asked; elsewhere the output is taken to be stereo.
+func detectOutputChannels() int {
+	if runtime.GOOS != osLinux {
+		return 0
+	}
+	if _, err := exec.LookPath("pactl"); err != nil {
+		return 0
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "pactl", "info").Output()
+	if err != nil {
+		return 0
+	}
+	return parseSampleSpec(string(out))
+}
+
+// parseSampleSpec reads the channel count from pactl info's "Default
+// Sample Specification: s16le 2ch 44100Hz" line
+func parseSampleSpec(info string) int {
+	for _, line := range strings.Split(info, "\n") {
+		spec, ok := strings.CutPrefix(strings.TrimSpace(line), "Default Sample Specification:")
+		if !ok {
+			continue
+		}
+		for _, field := range strings.Fields(spec) {
+			if n, err := strconv.Atoi(strings.TrimSuffix(field, "ch")); err == nil && strings.HasSuffix(field, "ch") {
+				return n
+			}
+		}
+	}
+	return 0
+}
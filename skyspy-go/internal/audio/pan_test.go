@@ -0,0 +1,182 @@
+// Package audio provides audio alert functionality for SkySpy CLI
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func TestBearingPan(t *testing.T) {
+	tests := []struct {
+		bearing, facing, want float64
+	}{
+		{0, 0, 0},
+		{90, 0, 1},
+		{180, 0, 0},
+		{270, 0, -1},
+		{360, 0, 0},
+		{45, 0, math.Sqrt2 / 2},
+		{315, 0, -math.Sqrt2 / 2},
+		{-90, 0, -1},
+		// Facing east, traffic to the north is on the left
+		{0, 90, -1},
+		{90, 90, 0},
+		{180, 90, 1},
+	}
+	for _, tt := range tests {
+		if got := BearingPan(tt.bearing, tt.facing); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("BearingPan(%v, %v) = %v, want %v", tt.bearing, tt.facing, got, tt.want)
+		}
+	}
+
+	// Smooth: no step between neighbouring degrees is large
+	prev := BearingPan(0, 0)
+	for b := 1.0; b <= 360; b++ {
+		got := BearingPan(b, 0)
+		if math.Abs(got-prev) > 0.02 {
+			t.Fatalf("pan jumps from %v to %v at %v°", prev, got, b)
+		}
+		prev = got
+	}
+}
+
+func TestPanGains(t *testing.T) {
+	tests := []struct {
+		pan, left, right float64
+	}{
+		{0, 1, 1},
+		{1, 0, 1},
+		{-1, 1, 0},
+		{0.5, 0.5, 1},
+		{-0.25, 1, 0.75},
+		{2, 0, 1},
+	}
+	for _, tt := range tests {
+		left, right := PanGains(tt.pan)
+		if math.Abs(left-tt.left) > 1e-9 || math.Abs(right-tt.right) > 1e-9 {
+			t.Errorf("PanGains(%v) = %v, %v, want %v, %v", tt.pan, left, right, tt.left, tt.right)
+		}
+	}
+}
+
+func TestPCM_StereoWAV(t *testing.T) {
+	pcm := &PCM{Rate: 44100, Samples: []int16{10000, -20000}}
+	wav := pcm.StereoWAV(0.5, 1)
+
+	if channels := binary.LittleEndian.Uint16(wav[22:24]); channels != 2 {
+		t.Fatalf("channels = %d, want 2", channels)
+	}
+	frame := func(i int) (int16, int16) {
+		off := 44 + i*4
+		return int16(binary.LittleEndian.Uint16(wav[off:])), int16(binary.LittleEndian.Uint16(wav[off+2:]))
+	}
+	if l, r := frame(0); l != 5000 || r != 10000 {
+		t.Errorf("frame 0 = %d, %d, want 5000, 10000", l, r)
+	}
+	if l, r := frame(1); l != -10000 || r != -20000 {
+		t.Errorf("frame 1 = %d, %d, want -10000, -20000", l, r)
+	}
+
+	decoded, err := DecodeWAV(wav)
+	if err != nil || len(decoded.Samples) != 2 {
+		t.Fatalf("stereo WAV should decode, got %v, %v", decoded, err)
+	}
+}
+
+func TestParseSampleSpec(t *testing.T) {
+	info := "Server Name: PulseAudio (on PipeWire 1.0.5)\nDefault Sample Specification: float32le 2ch 48000Hz\nDefault Channel Map: front-left,front-right\n"
+	if got := parseSampleSpec(info); got != 2 {
+		t.Errorf("parseSampleSpec = %d, want 2", got)
+	}
+	if got := parseSampleSpec("Default Sample Specification: s16le 1ch 44100Hz"); got != 1 {
+		t.Errorf("parseSampleSpec mono = %d, want 1", got)
+	}
+	if got := parseSampleSpec("nothing useful"); got != 0 {
+		t.Errorf("parseSampleSpec unknown = %d, want 0", got)
+	}
+}
+
+func TestSoundManager_GetPannedSoundPath(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "emergency.wav")
+	if err := os.WriteFile(src, generateWav(800, 50, 0.5), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sm := &SoundManager{soundDir: dir}
+
+	if got := sm.GetPannedSoundPath(src, 0.02); got != src {
+		t.Errorf("a centered pan should play the sound itself, got %q", got)
+	}
+
+	right := sm.GetPannedSoundPath(src, 1)
+	if filepath.Base(right) != "emergency_panR10.wav" {
+		t.Fatalf("full right copy = %q", right)
+	}
+	left := sm.GetPannedSoundPath(src, -0.34)
+	if filepath.Base(left) != "emergency_panL03.wav" {
+		t.Fatalf("left copy = %q", left)
+	}
+
+	data, err := os.ReadFile(right)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var leftEnergy, rightEnergy float64
+	for off := 44; off+4 <= len(data); off += 4 {
+		leftEnergy += math.Abs(float64(int16(binary.LittleEndian.Uint16(data[off:]))))
+		rightEnergy += math.Abs(float64(int16(binary.LittleEndian.Uint16(data[off+2:]))))
+	}
+	if leftEnergy != 0 || rightEnergy == 0 {
+		t.Errorf("full right should be silent on the left: left %v, right %v", leftEnergy, rightEnergy)
+	}
+
+	if got := sm.GetPannedSoundPath(filepath.Join(dir, "missing.wav"), 1); got != "" {
+		t.Errorf("a missing sound should give no copy, got %q", got)
+	}
+}
+
+func TestAlertPlayer_Panned(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "warning.wav")
+	if err := os.WriteFile(src, generateWav(800, 50, 0.5), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.AudioSettings{Enabled: true, Pan: true}
+	player := &AlertPlayer{
+		config:       cfg,
+		soundManager: &SoundManager{soundDir: dir},
+		channels:     2,
+	}
+	west := Source{Distance: 10, Bearing: 270, HasBearing: true}
+
+	if got := player.panned(src, west); !strings.Contains(got, "_panL10") {
+		t.Errorf("traffic to the west should be full left, got %q", got)
+	}
+	if got := player.panned(src, Source{Distance: 10}); got != src {
+		t.Errorf("an unknown bearing should stay centered, got %q", got)
+	}
+
+	// Facing west puts the same traffic straight ahead
+	cfg.Facing = 270
+	if got := player.panned(src, west); got != src {
+		t.Errorf("traffic ahead should be centered, got %q", got)
+	}
+	cfg.Facing = 0
+
+	player.SetChannels(1)
+	if got := player.panned(src, west); got != src {
+		t.Errorf("a mono output should get the mono sound, got %q", got)
+	}
+
+	player.channels = 2
+	cfg.Pan = false
+	if got := player.panned(src, west); got != src {
+		t.Errorf("with panning off the sound should be unchanged, got %q", got)
+	}
+}
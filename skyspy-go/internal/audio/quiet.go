@@ -0,0 +1,164 @@
+// Package audio provides quiet hours for SkySpy CLI audio alerts
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// minutesPerDay is the end of the day in minutes, written 24:00
+const minutesPerDay = 24 * 60
+
+// quietWindow is one weekly range in minutes since local midnight. A window
+// ending at or before its start runs into the next day; the days are the
+// days it starts on.
+type quietWindow struct {
+	days       [7]bool
+	start, end int
+}
+
+// QuietHours is a weekly do-not-disturb schedule. It is evaluated on the
+// local wall clock, so a window from 22:00 to 07:00 keeps those times when
+// daylight saving time starts or ends overnight.
+type QuietHours struct {
+	windows []quietWindow
+	loc     *time.Location
+}
+
+// ParseQuietHours builds a schedule from the configured ranges. Invalid
+// ranges are skipped and reported together in the error.
+func ParseQuietHours(ranges []config.QuietHoursRange) (*QuietHours, error) {
+	q := &QuietHours{loc: time.Local}
+	var errs []error
+	for i, r := range ranges {
+		w, err := parseQuietWindow(r)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("range %d: %w", i+1, err))
+			continue
+		}
+		q.windows = append(q.windows, w)
+	}
+	return q, errors.Join(errs...)
+}
+
+func parseQuietWindow(r config.QuietHoursRange) (quietWindow, error) {
+	var w quietWindow
+	start, err := parseClock(r.Start, false)
+	if err != nil {
+		return w, fmt.Errorf("start: %w", err)
+	}
+	end, err := parseClock(r.End, true)
+	if err != nil {
+		return w, fmt.Errorf("end: %w", err)
+	}
+	w.start, w.end = start, end
+
+	if len(r.Days) == 0 {
+		w.days = [7]bool{true, true, true, true, true, true, true}
+	}
+	for _, day := range r.Days {
+		if !setDays(&w.days, day) {
+			return w, fmt.Errorf("unknown day %q", day)
+		}
+	}
+	return w, nil
+}
+
+// parseClock reads an HH:MM time of day as minutes since midnight; 24:00
+// is allowed where the end of the day is meant
+func parseClock(s string, allowEndOfDay bool) (int, error) {
+	hh, mm, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, fmt.Errorf("%q is not HH:MM", s)
+	}
+	h, err1 := strconv.Atoi(hh)
+	m, err2 := strconv.Atoi(mm)
+	if err1 != nil || err2 != nil || len(mm) != 2 || m < 0 || m > 59 || h < 0 || h > 24 {
+		return 0, fmt.Errorf("%q is not HH:MM", s)
+	}
+	minutes := h*60 + m
+	if minutes > minutesPerDay || (minutes == minutesPerDay && !allowEndOfDay) {
+		return 0, fmt.Errorf("%q is past the end of the day", s)
+	}
+	return minutes, nil
+}
+
+// setDays marks the days a name stands for
+func setDays(days *[7]bool, name string) bool {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "*", "daily", "all":
+		for d := range days {
+			days[d] = true
+		}
+	case "weekdays":
+		for d := time.Monday; d <= time.Friday; d++ {
+			days[d] = true
+		}
+	case "weekends":
+		days[time.Saturday], days[time.Sunday] = true, true
+	case "sun", "sunday":
+		days[time.Sunday] = true
+	case "mon", "monday":
+		days[time.Monday] = true
+	case "tue", "tues", "tuesday":
+		days[time.Tuesday] = true
+	case "wed", "wednesday":
+		days[time.Wednesday] = true
+	case "thu", "thur", "thurs", "thursday":
+		days[time.Thursday] = true
+	case "fri", "friday":
+		days[time.Friday] = true
+	case "sat", "saturday":
+		days[time.Saturday] = true
+	default:
+		return false
+	}
+	return true
+}
+
+// SetLocation sets the time zone the schedule is read in; nil is local time
+func (q *QuietHours) SetLocation(loc *time.Location) {
+	if loc == nil {
+		loc = time.Local
+	}
+	q.loc = loc
+}
+
+// Len returns the number of windows in the schedule
+func (q *QuietHours) Len() int {
+	if q == nil {
+		return 0
+	}
+	return len(q.windows)
+}
+
+// Active reports whether t falls inside any quiet window
+func (q *QuietHours) Active(t time.Time) bool {
+	if q.Len() == 0 {
+		return false
+	}
+	local := t.In(q.loc)
+	minute := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+	yesterday := (today + 6) % 7
+
+	for _, w := range q.windows {
+		if w.start < w.end {
+			if w.days[today] && minute >= w.start && minute < w.end {
+				return true
+			}
+			continue
+		}
+		// Past midnight: the evening part belongs to today's window and
+		// the early morning part to yesterday's
+		if (w.days[today] && minute >= w.start) || (w.days[yesterday] && minute < w.end) {
+			return true
+		}
+	}
+	return false
+}
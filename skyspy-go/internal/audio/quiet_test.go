@@ -0,0 +1,168 @@
+package audio
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // DST transitions without relying on the host's zoneinfo
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func mustQuietHours(t *testing.T, ranges ...config.QuietHoursRange) *QuietHours {
+	t.Helper()
+	q, err := ParseQuietHours(ranges)
+	if err != nil {
+		t.Fatalf("ParseQuietHours: %v", err)
+	}
+	return q
+}
+
+func TestQuietHours_SameDay(t *testing.T) {
+	q := mustQuietHours(t, config.QuietHoursRange{Days: []string{"weekdays"}, Start: "12:00", End: "13:30"})
+	q.SetLocation(time.UTC)
+
+	// 2026-10-14 is a Wednesday
+	tests := []struct {
+		at   string
+		want bool
+	}{
+		{"2026-10-14T11:59:00Z", false},
+		{"2026-10-14T12:00:00Z", true},
+		{"2026-10-14T13:29:59Z", true},
+		{"2026-10-14T13:30:00Z", false},
+		{"2026-10-17T12:30:00Z", false}, // Saturday
+	}
+	for _, tt := range tests {
+		at, _ := time.Parse(time.RFC3339, tt.at)
+		if got := q.Active(at); got != tt.want {
+			t.Errorf("Active(%s) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestQuietHours_CrossingMidnight(t *testing.T) {
+	// Friday night only: quiet from Friday 23:00 to Saturday 06:00
+	q := mustQuietHours(t, config.QuietHoursRange{Days: []string{"fri"}, Start: "23:00", End: "06:00"})
+	q.SetLocation(time.UTC)
+
+	tests := []struct {
+		at   string
+		want bool
+	}{
+		{"2026-10-16T22:59:00Z", false}, // Friday
+		{"2026-10-16T23:00:00Z", true},
+		{"2026-10-17T00:00:00Z", true}, // Saturday, still Friday's window
+		{"2026-10-17T05:59:00Z", true},
+		{"2026-10-17T06:00:00Z", false},
+		{"2026-10-17T23:30:00Z", false}, // Saturday night isn't scheduled
+		{"2026-10-16T03:00:00Z", false}, // Friday morning belongs to Thursday
+	}
+	for _, tt := range tests {
+		at, _ := time.Parse(time.RFC3339, tt.at)
+		if got := q.Active(at); got != tt.want {
+			t.Errorf("Active(%s) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestQuietHours_DSTTransitions(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	q := mustQuietHours(t, config.QuietHoursRange{Start: "22:00", End: "07:00"})
+	q.SetLocation(loc)
+
+	// Clocks go forward at 02:00 on 2026-03-08 and back at 02:00 on
+	// 2026-11-01. The window follows the wall clock through both, so the
+	// night is an hour shorter or longer in absolute time.
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"spring, before the jump", time.Date(2026, 3, 8, 1, 59, 0, 0, loc), true},
+		{"spring, after the jump", time.Date(2026, 3, 8, 3, 0, 0, 0, loc), true},
+		{"spring, end", time.Date(2026, 3, 8, 7, 0, 0, 0, loc), false},
+		{"spring, just before end", time.Date(2026, 3, 8, 6, 59, 0, 0, loc), true},
+		{"autumn, first 01:30", time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC), true},  // 01:30 EDT
+		{"autumn, second 01:30", time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC), true}, // 01:30 EST
+		{"autumn, end", time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC), false},         // 07:00 EST
+		{"autumn, UTC offset shifted", time.Date(2026, 11, 1, 11, 30, 0, 0, time.UTC), true},
+		{"evening start", time.Date(2026, 11, 1, 22, 0, 0, 0, loc), true},
+		{"afternoon", time.Date(2026, 11, 1, 15, 0, 0, 0, loc), false},
+	}
+	for _, tt := range tests {
+		if got := q.Active(tt.at); got != tt.want {
+			t.Errorf("%s (%s): Active = %v, want %v", tt.name, tt.at.In(loc), got, tt.want)
+		}
+	}
+
+	// A window inside the skipped hour ends at the first time after it
+	skipped := mustQuietHours(t, config.QuietHoursRange{Start: "01:30", End: "02:30"})
+	skipped.SetLocation(loc)
+	if !skipped.Active(time.Date(2026, 3, 8, 1, 45, 0, 0, loc)) {
+		t.Error("01:45 should be quiet")
+	}
+	if skipped.Active(time.Date(2026, 3, 8, 3, 0, 0, 0, loc)) {
+		t.Error("03:00 comes after the skipped 02:30 and should not be quiet")
+	}
+}
+
+func TestQuietHours_WholeDay(t *testing.T) {
+	q := mustQuietHours(t,
+		config.QuietHoursRange{Days: []string{"sunday"}, Start: "00:00", End: "24:00"},
+	)
+	q.SetLocation(time.UTC)
+	for _, at := range []string{"2026-10-18T00:00:00Z", "2026-10-18T12:00:00Z", "2026-10-18T23:59:00Z"} {
+		ts, _ := time.Parse(time.RFC3339, at)
+		if !q.Active(ts) {
+			t.Errorf("%s should be quiet all Sunday", at)
+		}
+	}
+	if ts, _ := time.Parse(time.RFC3339, "2026-10-19T00:00:00Z"); q.Active(ts) {
+		t.Error("Monday should not be quiet")
+	}
+}
+
+func TestParseQuietHours_Errors(t *testing.T) {
+	q, err := ParseQuietHours([]config.QuietHoursRange{
+		{Start: "22:00", End: "07:00"},
+		{Start: "25:00", End: "07:00"},
+		{Start: "22:00", End: "7"},
+		{Days: []string{"caturday"}, Start: "22:00", End: "07:00"},
+		{Start: "24:00", End: "07:00"},
+	})
+	if err == nil {
+		t.Fatal("expected errors for the invalid ranges")
+	}
+	for _, want := range []string{"range 2", "range 3", "caturday", "range 5"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
+	}
+	if q.Len() != 1 {
+		t.Errorf("valid ranges should still apply, got %d", q.Len())
+	}
+
+	var empty *QuietHours
+	if empty.Len() != 0 || (&QuietHours{}).Active(time.Now()) {
+		t.Error("an empty schedule is never quiet")
+	}
+}
+
+func TestAlertPlayer_QuietSkipsSounds(t *testing.T) {
+	cfg := &config.AudioSettings{Enabled: true, NewAircraftSound: true}
+	player := NewAlertPlayer(cfg)
+
+	quiet := true
+	player.SetQuiet(func() bool { return quiet })
+	if player.shouldPlay(AlertEmergency) {
+		t.Error("alerts should be skipped during quiet hours")
+	}
+	quiet = false
+	if !player.shouldPlay(AlertEmergency) {
+		t.Error("alerts should play outside quiet hours")
+	}
+}
@@ -2,27 +2,35 @@
 package audio
 
 import (
+	"fmt"
+	"hash/fnv"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
+
+	"github.com/skyspy/skyspy-go/internal/config"
 )
 
 // SoundManager handles sound file management and generation
 type SoundManager struct {
 	soundDir    string
 	soundPaths  map[AlertType]string
+	bandPaths   map[string]string    // urgency variants keyed by filename
+	filePaths   map[string]fileSound // rule sound files keyed by source path
+	panPaths    map[string]fileSound // stereo copies keyed by source path and pan step
 	initialized bool
 	mu          sync.Mutex
 }
 
 // NewSoundManager creates a new sound manager
 func NewSoundManager() *SoundManager {
-	homeDir, _ := os.UserHomeDir()
-	soundDir := filepath.Join(homeDir, ".config", "skyspy", "sounds")
-
 	return &SoundManager{
-		soundDir:   soundDir,
+		soundDir:   config.GetSoundsDir(),
 		soundPaths: make(map[AlertType]string),
+		bandPaths:  make(map[string]string),
 	}
 }
 
@@ -51,6 +59,7 @@ func (m *SoundManager) initializeSounds() {
 	m.soundPaths[AlertNewAircraft] = m.generateSound(AlertNewAircraft, "new_aircraft.wav")
 	m.soundPaths[AlertEmergency] = m.generateSound(AlertEmergency, "emergency.wav")
 	m.soundPaths[AlertMilitary] = m.generateSound(AlertMilitary, "military.wav")
+	m.soundPaths[AlertWarning] = m.generateSound(AlertWarning, "warning.wav")
 }
 
 // generateSound creates a WAV file for the given alert type
@@ -63,26 +72,236 @@ func (m *SoundManager) generateSound(alertType AlertType, filename string) strin
 	}
 
 	// Generate the WAV data based on alert type
-	var wavData []byte
+	wavData := generateAlertWav(alertType, 1.0)
+
+	// Write the WAV file
+	//nolint:gosec // G306: Sound files are non-sensitive and can be world-readable
+	if err := os.WriteFile(soundPath, wavData, 0o644); err != nil {
+		return ""
+	}
+
+	return soundPath
+}
+
+// GetBandSoundPath returns the sound for an alert type re-pitched and
+// repeated for an urgency band, generating and caching it on first use
+func (m *SoundManager) GetBandSoundPath(alertType AlertType, band config.AudioUrgencyBand) string {
+	if isNeutralBand(band) {
+		return m.GetSoundPath(alertType)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.initialized {
+		m.initializeSounds()
+		m.initialized = true
+	}
+
+	base := soundBaseName(alertType)
+	if base == "" {
+		return ""
+	}
+
+	pitch := band.Pitch
+	if pitch <= 0 {
+		pitch = 1.0
+	}
+	filename := base + "_p" + itoa(int(pitch*100)) + "_r" + itoa(band.Repeats) + "_g" + itoa(band.GapMs) + ".wav"
+	if path, ok := m.bandPaths[filename]; ok {
+		return path
+	}
+
+	soundPath := filepath.Join(m.soundDir, filename)
+	if _, err := os.Stat(soundPath); err != nil {
+		wavData := repeatWav(generateAlertWav(alertType, pitch), band.Repeats, band.GapMs)
+		//nolint:gosec // G306: Sound files are non-sensitive and can be world-readable
+		if err := os.WriteFile(soundPath, wavData, 0o644); err != nil {
+			return ""
+		}
+	}
+
+	if m.bandPaths == nil {
+		m.bandPaths = make(map[string]string)
+	}
+	m.bandPaths[filename] = soundPath
+	return soundPath
+}
+
+// fileSound is an alert rule's sound file converted for playback
+type fileSound struct {
+	modTime time.Time // of the source when it was converted
+	path    string    // converted copy in the sound directory
+	err     error
+}
+
+// GetFileSoundPath returns a playable copy of an alert rule's sound file,
+// decoded, resampled to the output rate and written as 16-bit mono. The
+// copy is cached until the source changes, and so is a failure to load it.
+func (m *SoundManager) GetFileSoundPath(sound string) (string, error) {
+	src := ResolveSoundFile(sound, m.soundDir)
+	info, err := os.Stat(src)
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cached, ok := m.filePaths[src]; ok && cached.modTime.Equal(info.ModTime()) {
+		return cached.path, cached.err
+	}
+	path, err := m.convertSoundFile(src)
+	if m.filePaths == nil {
+		m.filePaths = make(map[string]fileSound)
+	}
+	m.filePaths[src] = fileSound{modTime: info.ModTime(), path: path, err: err}
+	return path, err
+}
+
+// convertSoundFile writes the playable copy of the sound file at src
+func (m *SoundManager) convertSoundFile(src string) (string, error) {
+	pcm, err := LoadSoundFile(src)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(m.soundDir, 0o755); err != nil {
+		return "", err
+	}
+
+	// The hash keeps files of the same name from different directories apart,
+	// and the stem is kept to safe characters for the platform players
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(src))
+	stem := strings.Map(func(r rune) rune {
+		if r < 0x80 && (r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '_'
+	}, strings.TrimSuffix(filepath.Base(src), filepath.Ext(src)))
+	path := filepath.Join(m.soundDir, fmt.Sprintf("file_%s_%08x.wav", stem, h.Sum32()))
+
+	//nolint:gosec // G306: Sound files are non-sensitive and can be world-readable
+	if err := os.WriteFile(path, pcm.Resample(outputRate).WAV(), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// GetPannedSoundPath returns a stereo copy of the sound at path, panned to
+// pan (-1 left to 1 right) in steps of a tenth. A centered pan is the
+// sound itself. The copy is cached until the sound changes; on failure
+// the empty string is returned.
+func (m *SoundManager) GetPannedSoundPath(path string, pan float64) string {
+	step := quantizePan(pan)
+	if step == 0 {
+		return path
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := path + "|" + itoa(step)
+	if cached, ok := m.panPaths[key]; ok && cached.modTime.Equal(info.ModTime()) {
+		return cached.path
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	pcm, err := DecodeWAV(data)
+	if err != nil {
+		return ""
+	}
+	side := "R"
+	if step < 0 {
+		side = "L"
+	}
+	abs := step
+	if abs < 0 {
+		abs = -abs
+	}
+	panned := filepath.Join(m.soundDir, fmt.Sprintf("%s_pan%s%02d.wav", strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), side, abs))
+	left, right := PanGains(float64(step) / panSteps)
+	//nolint:gosec // G306: Sound files are non-sensitive and can be world-readable
+	if err := os.WriteFile(panned, pcm.StereoWAV(left, right), 0o644); err != nil {
+		return ""
+	}
+
+	if m.panPaths == nil {
+		m.panPaths = make(map[string]fileSound)
+	}
+	m.panPaths[key] = fileSound{modTime: info.ModTime(), path: panned}
+	return panned
+}
+
+// soundBaseName returns the built-in sound file stem for an alert type
+func soundBaseName(alertType AlertType) string {
+	switch alertType {
+	case AlertNewAircraft:
+		return "new_aircraft"
+	case AlertEmergency:
+		return "emergency"
+	case AlertMilitary:
+		return "military"
+	case AlertWarning:
+		return "warning"
+	default:
+		return ""
+	}
+}
+
+// generateAlertWav creates the built-in sound for an alert type with all
+// frequencies scaled by pitch
+func generateAlertWav(alertType AlertType, pitch float64) []byte {
+	hz := func(f float64) int { return int(f * pitch) }
+
 	switch alertType {
 	case AlertNewAircraft:
 		// Short pleasant beep - 800Hz for 150ms
-		wavData = generateWav(800, 150, 0.5)
+		return generateWav(hz(800), 150, 0.5)
 	case AlertEmergency:
 		// Urgent alarm - alternating 1000Hz/800Hz for 400ms
-		wavData = generateAlarmWav(1000, 800, 400, 0.7)
+		return generateAlarmWav(hz(1000), hz(800), 400, 0.7)
 	case AlertMilitary:
 		// Two-tone alert - 600Hz then 900Hz, 100ms each
-		wavData = generateTwoToneWav(600, 900, 100, 0.6)
+		return generateTwoToneWav(hz(600), hz(900), 100, 0.6)
+	case AlertWarning:
+		// Falling two-tone - 900Hz then 700Hz, 150ms each
+		return generateTwoToneWav(hz(900), hz(700), 150, 0.6)
+	default:
+		return nil
 	}
+}
 
-	// Write the WAV file
-	//nolint:gosec // G306: Sound files are non-sensitive and can be world-readable
-	if err := os.WriteFile(soundPath, wavData, 0o644); err != nil {
-		return ""
+// repeatWav plays a mono 16-bit WAV repeats times with gapMs of silence
+// between each, rewriting the header sizes
+func repeatWav(wav []byte, repeats, gapMs int) []byte {
+	if len(wav) <= 44 || repeats <= 1 {
+		return wav
 	}
 
-	return soundPath
+	pcm := wav[44:]
+	gap := make([]byte, 44100*gapMs/1000*2)
+
+	out := make([]byte, 44, 44+len(pcm)*repeats+len(gap)*(repeats-1))
+	copy(out, wav[:44])
+	for i := 0; i < repeats; i++ {
+		if i > 0 {
+			out = append(out, gap...)
+		}
+		out = append(out, pcm...)
+	}
+
+	dataSize := len(out) - 44
+	writeLE32(out[4:8], uint32(36+dataSize))
+	writeLE32(out[40:44], uint32(dataSize))
+	return out
 }
 
 // generateWav creates a simple sine wave WAV file
@@ -306,7 +525,5 @@ func writeLE32(b []byte, v uint32) {
 
 // GetCustomSoundPath returns the path for custom sounds in the config directory
 func GetCustomSoundPath(soundType string) string {
-	homeDir, _ := os.UserHomeDir()
-	soundDir := filepath.Join(homeDir, ".config", "skyspy", "sounds")
-	return filepath.Join(soundDir, soundType+".wav")
+	return filepath.Join(config.GetSoundsDir(), soundType+".wav")
 }
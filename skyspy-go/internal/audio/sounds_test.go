@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/config"
 )
 
 func TestNewSoundManager(t *testing.T) {
@@ -22,8 +24,7 @@ func TestNewSoundManager(t *testing.T) {
 		t.Error("initialized should be false initially")
 	}
 
-	homeDir, _ := os.UserHomeDir()
-	expectedSoundDir := filepath.Join(homeDir, ".config", "skyspy", "sounds")
+	expectedSoundDir := config.GetSoundsDir()
 	if sm.soundDir != expectedSoundDir {
 		t.Errorf("soundDir = %q, want %q", sm.soundDir, expectedSoundDir)
 	}
@@ -325,8 +326,7 @@ func TestWriteLE32(t *testing.T) {
 }
 
 func TestGetCustomSoundPath(t *testing.T) {
-	homeDir, _ := os.UserHomeDir()
-	expected := filepath.Join(homeDir, ".config", "skyspy", "sounds", "test.wav")
+	expected := filepath.Join(config.GetSoundsDir(), "test.wav")
 
 	result := GetCustomSoundPath("test")
 	if result != expected {
@@ -335,8 +335,7 @@ func TestGetCustomSoundPath(t *testing.T) {
 }
 
 func TestGetCustomSoundPath_DifferentTypes(t *testing.T) {
-	homeDir, _ := os.UserHomeDir()
-	soundDir := filepath.Join(homeDir, ".config", "skyspy", "sounds")
+	soundDir := config.GetSoundsDir()
 
 	tests := []struct {
 		soundType string
@@ -431,3 +430,59 @@ func TestSoundManager_InitializeSounds_AllTypes(t *testing.T) {
 		t.Error("AlertMilitary sound path should be set")
 	}
 }
+
+func TestRepeatWav(t *testing.T) {
+	base := generateWav(800, 100, 0.5)
+	pcmLen := len(base) - 44
+
+	out := repeatWav(base, 3, 50)
+	gapLen := 44100 * 50 / 1000 * 2
+	want := 44 + pcmLen*3 + gapLen*2
+	if len(out) != want {
+		t.Fatalf("repeatWav length = %d, want %d", len(out), want)
+	}
+
+	dataSize := uint32(out[40]) | uint32(out[41])<<8 | uint32(out[42])<<16 | uint32(out[43])<<24
+	if int(dataSize) != want-44 {
+		t.Errorf("data chunk size = %d, want %d", dataSize, want-44)
+	}
+
+	if got := repeatWav(base, 1, 50); len(got) != len(base) {
+		t.Error("single repeat should return the sound unchanged")
+	}
+}
+
+func TestSoundManager_GetBandSoundPath(t *testing.T) {
+	tempDir := t.TempDir()
+	sm := &SoundManager{
+		soundDir:   tempDir,
+		soundPaths: make(map[AlertType]string),
+	}
+
+	neutral := sm.GetBandSoundPath(AlertEmergency, config.AudioUrgencyBand{Pitch: 1.0, Repeats: 1})
+	if neutral != sm.GetSoundPath(AlertEmergency) {
+		t.Errorf("neutral band should reuse the built-in sound, got %q", neutral)
+	}
+
+	band := config.AudioUrgencyBand{MaxDistance: 25, Pitch: 1.5, Repeats: 3, GapMs: 80}
+	path := sm.GetBandSoundPath(AlertEmergency, band)
+	if path == "" || path == neutral {
+		t.Fatalf("expected a distinct urgency sound, got %q", path)
+	}
+	if filepath.Base(path) != "emergency_p150_r3_g80.wav" {
+		t.Errorf("unexpected urgency sound name %q", filepath.Base(path))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("urgency sound not written: %v", err)
+	}
+	baseInfo, _ := os.Stat(neutral)
+	if info.Size() <= baseInfo.Size() {
+		t.Error("tripled sound should be longer than the built-in sound")
+	}
+
+	if again := sm.GetBandSoundPath(AlertEmergency, band); again != path {
+		t.Errorf("expected cached path %q, got %q", path, again)
+	}
+}
@@ -0,0 +1,52 @@
+// Package audio provides audio alert functionality for SkySpy CLI
+package audio
+
+import (
+	"math"
+	"sort"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// SelectUrgencyBand returns the urgency band for a target at the given
+// distance (nm). Bands match nearest-first on MaxDistance, where 0 means
+// unbounded. A missing distance (<= 0) falls back to the middle band so an
+// unlocated target is neither muted nor treated as overhead.
+// The second result is false when no bands are configured.
+func SelectUrgencyBand(bands []config.AudioUrgencyBand, distance float64) (config.AudioUrgencyBand, bool) {
+	if len(bands) == 0 {
+		return config.AudioUrgencyBand{}, false
+	}
+
+	sorted := make([]config.AudioUrgencyBand, len(bands))
+	copy(sorted, bands)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return bandLimit(sorted[i]) < bandLimit(sorted[j])
+	})
+
+	if distance <= 0 {
+		return sorted[(len(sorted)-1)/2], true
+	}
+
+	for _, band := range sorted {
+		if band.MaxDistance <= 0 || distance <= band.MaxDistance {
+			return band, true
+		}
+	}
+
+	// Beyond every bounded band: use the farthest one
+	return sorted[len(sorted)-1], true
+}
+
+// isNeutralBand reports whether a band leaves the built-in sound unchanged
+func isNeutralBand(band config.AudioUrgencyBand) bool {
+	return (band.Pitch == 0 || band.Pitch == 1) && band.Repeats <= 1
+}
+
+// bandLimit orders unbounded bands last
+func bandLimit(band config.AudioUrgencyBand) float64 {
+	if band.MaxDistance <= 0 {
+		return math.MaxFloat64
+	}
+	return band.MaxDistance
+}
@@ -0,0 +1,94 @@
+// Package audio provides audio alert functionality for SkySpy CLI
+package audio
+
+import (
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func TestSelectUrgencyBand_Defaults(t *testing.T) {
+	bands := config.DefaultConfig().Audio.UrgencyBands
+
+	tests := []struct {
+		name      string
+		distance  float64
+		wantPitch float64
+		wantReps  int
+	}{
+		{"overhead", 2, 1.5, 3},
+		{"close band edge", 25, 1.5, 3},
+		{"middle", 60, 1.0, 1},
+		{"middle band edge", 100, 1.0, 1},
+		{"far", 180, 0.75, 1},
+		{"missing distance falls back to middle", 0, 1.0, 1},
+		{"negative distance falls back to middle", -5, 1.0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			band, ok := SelectUrgencyBand(bands, tt.distance)
+			if !ok {
+				t.Fatal("expected a band")
+			}
+			if band.Pitch != tt.wantPitch || band.Repeats != tt.wantReps {
+				t.Errorf("distance %.0f: got pitch %.2f x%d, want %.2f x%d",
+					tt.distance, band.Pitch, band.Repeats, tt.wantPitch, tt.wantReps)
+			}
+		})
+	}
+}
+
+func TestSelectUrgencyBand_Unordered(t *testing.T) {
+	bands := []config.AudioUrgencyBand{
+		{MaxDistance: 0, Pitch: 0.5},
+		{MaxDistance: 10, Pitch: 2.0},
+		{MaxDistance: 50, Pitch: 1.0},
+	}
+
+	if band, _ := SelectUrgencyBand(bands, 5); band.Pitch != 2.0 {
+		t.Errorf("expected nearest band for 5nm, got pitch %.2f", band.Pitch)
+	}
+	if band, _ := SelectUrgencyBand(bands, 30); band.Pitch != 1.0 {
+		t.Errorf("expected 50nm band for 30nm, got pitch %.2f", band.Pitch)
+	}
+	if band, _ := SelectUrgencyBand(bands, 500); band.Pitch != 0.5 {
+		t.Errorf("expected unbounded band for 500nm, got pitch %.2f", band.Pitch)
+	}
+	// Input order must not be modified
+	if bands[0].Pitch != 0.5 {
+		t.Error("SelectUrgencyBand should not reorder the caller's slice")
+	}
+}
+
+func TestSelectUrgencyBand_AllBounded(t *testing.T) {
+	bands := []config.AudioUrgencyBand{
+		{MaxDistance: 10, Pitch: 2.0},
+		{MaxDistance: 50, Pitch: 1.0},
+	}
+
+	if band, _ := SelectUrgencyBand(bands, 300); band.Pitch != 1.0 {
+		t.Errorf("beyond every band should use the farthest, got pitch %.2f", band.Pitch)
+	}
+}
+
+func TestSelectUrgencyBand_Empty(t *testing.T) {
+	if _, ok := SelectUrgencyBand(nil, 10); ok {
+		t.Error("expected no band when none are configured")
+	}
+}
+
+func TestIsNeutralBand(t *testing.T) {
+	if !isNeutralBand(config.AudioUrgencyBand{Pitch: 1.0, Repeats: 1}) {
+		t.Error("pitch 1 single repeat should be neutral")
+	}
+	if !isNeutralBand(config.AudioUrgencyBand{}) {
+		t.Error("zero band should be neutral")
+	}
+	if isNeutralBand(config.AudioUrgencyBand{Pitch: 1.5, Repeats: 1}) {
+		t.Error("re-pitched band should not be neutral")
+	}
+	if isNeutralBand(config.AudioUrgencyBand{Pitch: 1.0, Repeats: 3}) {
+		t.Error("repeated band should not be neutral")
+	}
+}
@@ -0,0 +1,230 @@
+// Package audio provides audio alert functionality for SkySpy CLI
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Limits on sound files that alert rules play from disk
+const (
+	MaxSoundFileBytes = 8 << 20
+	MaxSoundDuration  = 5 * time.Second
+)
+
+// outputRate is the sample rate sound files are converted to, matching the
+// built-in tones
+const outputRate = 44100
+
+// PCM is decoded mono audio
+type PCM struct {
+	Rate    int
+	Samples []int16
+}
+
+// Duration returns how long the audio plays
+func (p *PCM) Duration() time.Duration {
+	if p.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(len(p.Samples)) * time.Second / time.Duration(p.Rate)
+}
+
+// IsSoundFile reports whether an alert rule's sound names a WAV file rather
+// than a built-in tone
+func IsSoundFile(sound string) bool {
+	return strings.EqualFold(filepath.Ext(sound), ".wav")
+}
+
+// ResolveSoundFile returns the path of a sound file named by an alert rule.
+// Relative names are looked up in dir, the sounds directory.
+func ResolveSoundFile(sound, dir string) string {
+	if filepath.IsAbs(sound) {
+		return sound
+	}
+	return filepath.Join(dir, sound)
+}
+
+// LoadSoundFile reads and decodes a WAV file, enforcing the size and
+// duration limits
+func LoadSoundFile(path string) (*PCM, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > MaxSoundFileBytes {
+		return nil, fmt.Errorf("%s: %d bytes is over the %d byte limit", path, info.Size(), MaxSoundFileBytes)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pcm, err := DecodeWAV(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if d := pcm.Duration(); d > MaxSoundDuration {
+		return nil, fmt.Errorf("%s: %.1fs is longer than %v", path, d.Seconds(), MaxSoundDuration)
+	}
+	return pcm, nil
+}
+
+// WAV format codes
+const (
+	wavPCM        = 1
+	wavFloat      = 3
+	wavExtensible = 0xFFFE
+)
+
+// DecodeWAV decodes an uncompressed WAV file of 8, 16, 24 or 32-bit
+// integer or 32-bit float samples, mixing multiple channels down to mono
+func DecodeWAV(data []byte) (*PCM, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, errors.New("not a WAV file")
+	}
+
+	var (
+		format, channels, bits int
+		rate                   int
+		haveFmt                bool
+		samples                []byte
+		haveData               bool
+	)
+	for pos := 12; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		body := data[pos+8:]
+		if size > len(body) {
+			// A truncated final chunk keeps what arrived
+			size = len(body)
+		}
+		body = body[:size]
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, errors.New("fmt chunk too short")
+			}
+			format = int(binary.LittleEndian.Uint16(body[0:2]))
+			channels = int(binary.LittleEndian.Uint16(body[2:4]))
+			rate = int(binary.LittleEndian.Uint32(body[4:8]))
+			bits = int(binary.LittleEndian.Uint16(body[14:16]))
+			if format == wavExtensible && size >= 26 {
+				format = int(binary.LittleEndian.Uint16(body[24:26]))
+			}
+			haveFmt = true
+		case "data":
+			samples = body
+			haveData = true
+		}
+		pos += 8 + size + size%2
+	}
+
+	switch {
+	case !haveFmt:
+		return nil, errors.New("missing fmt chunk")
+	case !haveData:
+		return nil, errors.New("missing data chunk")
+	case channels < 1 || channels > 8:
+		return nil, fmt.Errorf("unsupported channel count %d", channels)
+	case rate < 8000 || rate > 192000:
+		return nil, fmt.Errorf("unsupported sample rate %d", rate)
+	}
+
+	var sample func(b []byte) float64
+	switch {
+	case format == wavPCM && bits == 8:
+		sample = func(b []byte) float64 { return (float64(b[0]) - 128) / 128 }
+	case format == wavPCM && bits == 16:
+		sample = func(b []byte) float64 { return float64(int16(binary.LittleEndian.Uint16(b))) / 32768 }
+	case format == wavPCM && bits == 24:
+		sample = func(b []byte) float64 {
+			return float64(int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24)>>8) / 8388608
+		}
+	case format == wavPCM && bits == 32:
+		sample = func(b []byte) float64 { return float64(int32(binary.LittleEndian.Uint32(b))) / 2147483648 }
+	case format == wavFloat && bits == 32:
+		sample = func(b []byte) float64 { return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))) }
+	default:
+		return nil, fmt.Errorf("unsupported encoding (format %d, %d-bit); use uncompressed PCM", format, bits)
+	}
+
+	width := bits / 8
+	frame := width * channels
+	frames := len(samples) / frame
+	if frames == 0 {
+		return nil, errors.New("no audio data")
+	}
+	pcm := &PCM{Rate: rate, Samples: make([]int16, frames)}
+	for i := 0; i < frames; i++ {
+		var sum float64
+		for c := 0; c < channels; c++ {
+			off := i*frame + c*width
+			sum += sample(samples[off : off+width])
+		}
+		pcm.Samples[i] = toInt16(sum / float64(channels))
+	}
+	return pcm, nil
+}
+
+// toInt16 converts a sample in -1..1 to 16 bits, clipping anything beyond
+func toInt16(v float64) int16 {
+	v = math.Max(-1, math.Min(1, v))
+	return int16(math.Round(v * 32767))
+}
+
+// Resample converts the audio to rate by linear interpolation
+func (p *PCM) Resample(rate int) *PCM {
+	if rate == p.Rate || len(p.Samples) == 0 {
+		return p
+	}
+	n := int(int64(len(p.Samples)) * int64(rate) / int64(p.Rate))
+	if n < 1 {
+		n = 1
+	}
+	out := &PCM{Rate: rate, Samples: make([]int16, n)}
+	step := float64(p.Rate) / float64(rate)
+	last := len(p.Samples) - 1
+	for i := range out.Samples {
+		pos := float64(i) * step
+		j := int(pos)
+		if j >= last {
+			out.Samples[i] = p.Samples[last]
+			continue
+		}
+		frac := pos - float64(j)
+		out.Samples[i] = int16(math.Round(float64(p.Samples[j])*(1-frac) + float64(p.Samples[j+1])*frac))
+	}
+	return out
+}
+
+// WAV encodes the audio as a 16-bit mono WAV file
+func (p *PCM) WAV() []byte {
+	dataSize := len(p.Samples) * 2
+	out := make([]byte, 44+dataSize)
+
+	copy(out[0:4], "RIFF")
+	writeLE32(out[4:8], uint32(36+dataSize))
+	copy(out[8:12], "WAVE")
+	copy(out[12:16], "fmt ")
+	writeLE32(out[16:20], 16)
+	writeLE16(out[20:22], wavPCM)
+	writeLE16(out[22:24], 1)
+	writeLE32(out[24:28], uint32(p.Rate))
+	writeLE32(out[28:32], uint32(p.Rate*2))
+	writeLE16(out[32:34], 2)
+	writeLE16(out[34:36], 16)
+	copy(out[36:40], "data")
+	writeLE32(out[40:44], uint32(dataSize))
+
+	for i, s := range p.Samples {
+		writeLE16(out[44+i*2:], uint16(s))
+	}
+	return out
+}
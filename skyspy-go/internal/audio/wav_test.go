@@ -0,0 +1,261 @@
+// Package audio provides audio alert functionality for SkySpy CLI
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// buildWAV assembles a WAV file with the given fmt fields and raw sample data
+func buildWAV(format, channels, rate, bits int, samples []byte) []byte {
+	fmtChunk := make([]byte, 16)
+	binary.LittleEndian.PutUint16(fmtChunk[0:2], uint16(format))
+	binary.LittleEndian.PutUint16(fmtChunk[2:4], uint16(channels))
+	binary.LittleEndian.PutUint32(fmtChunk[4:8], uint32(rate))
+	binary.LittleEndian.PutUint32(fmtChunk[8:12], uint32(rate*channels*bits/8))
+	binary.LittleEndian.PutUint16(fmtChunk[12:14], uint16(channels*bits/8))
+	binary.LittleEndian.PutUint16(fmtChunk[14:16], uint16(bits))
+
+	var out []byte
+	out = append(out, "RIFF\x00\x00\x00\x00WAVE"...)
+	// An unknown chunk before fmt must be skipped
+	out = append(out, "LIST\x03\x00\x00\x00abc\x00"...)
+	out = append(out, "fmt \x10\x00\x00\x00"...)
+	out = append(out, fmtChunk...)
+	out = append(out, "data"...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(samples)))
+	out = append(out, samples...)
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out
+}
+
+func writeSoundFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDecodeWAV_Formats(t *testing.T) {
+	le16 := func(v ...int16) []byte {
+		var b []byte
+		for _, s := range v {
+			b = binary.LittleEndian.AppendUint16(b, uint16(s))
+		}
+		return b
+	}
+	f32 := func(v ...float32) []byte {
+		var b []byte
+		for _, s := range v {
+			b = binary.LittleEndian.AppendUint32(b, math.Float32bits(s))
+		}
+		return b
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want []int16
+	}{
+		{"8-bit", buildWAV(wavPCM, 1, 8000, 8, []byte{128, 255, 0}), []int16{0, 32511, -32767}},
+		{"16-bit", buildWAV(wavPCM, 1, 22050, 16, le16(0, 16384, -32768)), []int16{0, 16384, -32767}},
+		{"24-bit", buildWAV(wavPCM, 1, 48000, 24, []byte{0, 0, 0x40, 0, 0, 0xC0}), []int16{16384, -16384}},
+		{"float", buildWAV(wavFloat, 1, 44100, 32, f32(0.5, -2)), []int16{16384, -32767}},
+		{"stereo mixed down", buildWAV(wavPCM, 2, 44100, 16, le16(1000, 3000, -100, 100)), []int16{2000, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pcm, err := DecodeWAV(tt.data)
+			if err != nil {
+				t.Fatalf("DecodeWAV: %v", err)
+			}
+			if len(pcm.Samples) != len(tt.want) {
+				t.Fatalf("got %d samples, want %d", len(pcm.Samples), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if d := int(pcm.Samples[i]) - int(want); d < -1 || d > 1 {
+					t.Errorf("sample %d = %d, want %d", i, pcm.Samples[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeWAV_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"empty", nil, "not a WAV file"},
+		{"not RIFF", []byte("ID3\x04 an mp3 file, really"), "not a WAV file"},
+		{"no data", buildWAV(wavPCM, 1, 44100, 16, nil)[:48], "missing data chunk"},
+		{"compressed", buildWAV(2, 1, 44100, 4, []byte{1, 2}), "unsupported encoding"},
+		{"no channels", buildWAV(wavPCM, 0, 44100, 16, []byte{1, 2}), "channel count"},
+		{"bad rate", buildWAV(wavPCM, 1, 1000, 16, []byte{1, 2}), "sample rate"},
+		{"empty data", buildWAV(wavPCM, 1, 44100, 16, nil), "no audio data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeWAV(tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPCM_ResampleAndEncode(t *testing.T) {
+	pcm := &PCM{Rate: 22050, Samples: []int16{0, 1000, 2000, 3000}}
+	up := pcm.Resample(44100)
+	if up.Rate != 44100 || len(up.Samples) != 8 {
+		t.Fatalf("resampled to %d samples at %d", len(up.Samples), up.Rate)
+	}
+	if up.Samples[1] != 500 || up.Samples[2] != 1000 {
+		t.Errorf("interpolation: got %v", up.Samples[:3])
+	}
+	if pcm.Resample(22050) != pcm {
+		t.Error("resampling to the same rate should return the audio unchanged")
+	}
+
+	round, err := DecodeWAV(up.WAV())
+	if err != nil {
+		t.Fatalf("decoding encoded audio: %v", err)
+	}
+	if round.Rate != 44100 || len(round.Samples) != len(up.Samples) || round.Samples[7] != up.Samples[7] {
+		t.Errorf("round trip changed the audio: %+v", round)
+	}
+}
+
+func TestLoadSoundFile_Limits(t *testing.T) {
+	dir := t.TempDir()
+
+	long := make([]byte, 2*8000*6) // 6 s of 16-bit mono at 8 kHz
+	path := writeSoundFile(t, dir, "long.wav", buildWAV(wavPCM, 1, 8000, 16, long))
+	if _, err := LoadSoundFile(path); err == nil || !strings.Contains(err.Error(), "longer than") {
+		t.Errorf("6 s file: err = %v", err)
+	}
+
+	big := writeSoundFile(t, dir, "big.wav", make([]byte, MaxSoundFileBytes+1))
+	if _, err := LoadSoundFile(big); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Errorf("oversized file: err = %v", err)
+	}
+
+	if _, err := LoadSoundFile(filepath.Join(dir, "missing.wav")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file: err = %v", err)
+	}
+
+	ok := writeSoundFile(t, dir, "ok.wav", buildWAV(wavPCM, 1, 8000, 16, make([]byte, 2*8000)))
+	pcm, err := LoadSoundFile(ok)
+	if err != nil {
+		t.Fatalf("1 s file: %v", err)
+	}
+	if pcm.Duration() != time.Second {
+		t.Errorf("duration = %v", pcm.Duration())
+	}
+}
+
+func TestSoundFileNames(t *testing.T) {
+	if !IsSoundFile("Klaxon.WAV") || IsSoundFile("warning") || IsSoundFile("beep.mp3") {
+		t.Error("IsSoundFile should match only .wav names")
+	}
+	abs := filepath.Join(t.TempDir(), "a.wav")
+	if got := ResolveSoundFile(abs, "/sounds"); got != abs {
+		t.Errorf("absolute path resolved to %q", got)
+	}
+	if got := ResolveSoundFile("alerts/a.wav", "/sounds"); got != filepath.Join("/sounds", "alerts", "a.wav") {
+		t.Errorf("relative path resolved to %q", got)
+	}
+}
+
+func TestSoundManager_GetFileSoundPath(t *testing.T) {
+	dir := t.TempDir()
+	m := &SoundManager{soundDir: dir}
+	src := writeSoundFile(t, dir, "my klaxon.wav", buildWAV(wavPCM, 2, 22050, 16, make([]byte, 4*2205)))
+
+	path, err := m.GetFileSoundPath("my klaxon.wav")
+	if err != nil {
+		t.Fatalf("GetFileSoundPath: %v", err)
+	}
+	if strings.ContainsAny(filepath.Base(path), " '") {
+		t.Errorf("playable copy %q should have a safe name", path)
+	}
+	pcm, err := LoadSoundFile(path)
+	if err != nil {
+		t.Fatalf("playable copy: %v", err)
+	}
+	if pcm.Rate != outputRate || len(pcm.Samples) != 4410 {
+		t.Errorf("copy is %d samples at %d, want 4410 at %d", len(pcm.Samples), pcm.Rate, outputRate)
+	}
+
+	// A cached result is returned until the source changes
+	if again, err := m.GetFileSoundPath(src); err != nil || again != path {
+		t.Errorf("absolute name: got %q, %v", again, err)
+	}
+	writeSoundFile(t, dir, "my klaxon.wav", []byte("garbage"))
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(src, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.GetFileSoundPath(src); err == nil {
+		t.Error("a changed, malformed source should fail")
+	}
+}
+
+func TestAlertPlayer_PlayRuleSoundAt_Fallback(t *testing.T) {
+	dir := t.TempDir()
+	player := &AlertPlayer{
+		config:       &config.AudioSettings{Enabled: true, EmergencySound: true},
+		lastPlayed:   make(map[AlertType]time.Time),
+		soundManager: &SoundManager{soundDir: dir, soundPaths: make(map[AlertType]string)},
+	}
+	var warned []string
+	player.SetWarn(func(sound string, err error) {
+		warned = append(warned, sound)
+	})
+
+	// The missing file falls back to the emergency tone and warns once
+	player.PlayRuleSoundAt("missing.wav", 5)
+	player.lastPlayed = make(map[AlertType]time.Time)
+	player.PlayRuleSoundAt("missing.wav", 5)
+	if len(warned) != 1 || warned[0] != "missing.wav" {
+		t.Errorf("warnings = %v, want one for missing.wav", warned)
+	}
+	if player.lastPlayed[AlertEmergency].IsZero() {
+		t.Error("a sound file should be debounced as an emergency alert")
+	}
+
+	// Once the file loads it warns again if it breaks
+	writeSoundFile(t, dir, "missing.wav", buildWAV(wavPCM, 1, 8000, 16, make([]byte, 800)))
+	if err := player.PrepareSound("missing.wav"); err != nil {
+		t.Fatalf("PrepareSound: %v", err)
+	}
+	if err := player.PrepareSound("gone.wav"); err == nil {
+		t.Error("PrepareSound should report a missing file")
+	}
+	if len(warned) != 2 {
+		t.Errorf("warnings = %v", warned)
+	}
+}
+
+func TestAlertPlayer_PlayRuleSoundAt_EmergencySoundOff(t *testing.T) {
+	player := &AlertPlayer{
+		config:       &config.AudioSettings{Enabled: true, EmergencySound: false},
+		lastPlayed:   make(map[AlertType]time.Time),
+		soundManager: &SoundManager{soundDir: t.TempDir()},
+	}
+	player.SetWarn(func(sound string, err error) {
+		t.Errorf("warned about %s with emergency sounds off", sound)
+	})
+	player.PlayRuleSoundAt("missing.wav", 0)
+}
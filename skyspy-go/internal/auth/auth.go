@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,6 +30,10 @@ type Manager struct {
 	tokens     *TokenSet
 	apiKey     string
 	mu         sync.RWMutex
+
+	// Offset of the server clock from ours, measured once at startup
+	skew      time.Duration
+	skewKnown bool
 }
 
 // NewManager creates a new authentication manager
@@ -37,7 +42,7 @@ func NewManager(host string, port int) (*Manager, error) {
 	hostKey := fmt.Sprintf("%s:%d", host, port)
 
 	// Fetch auth configuration
-	config, err := FetchAuthConfig(baseURL)
+	config, skew, skewKnown, err := fetchAuthConfig(baseURL)
 	if err != nil {
 		// If we can't fetch config, assume public mode
 		config = &AuthConfig{
@@ -57,6 +62,8 @@ func NewManager(host string, port int) (*Manager, error) {
 		host:       hostKey,
 		config:     config,
 		tokenStore: tokenStore,
+		skew:       skew,
+		skewKnown:  skewKnown,
 	}
 
 	// Load existing tokens
@@ -100,7 +107,7 @@ func (m *Manager) IsAuthenticated() bool {
 		return true
 	}
 
-	return !m.tokens.IsExpired()
+	return !m.tokens.IsExpiredAt(m.serverNow())
 }
 
 // GetAuthConfig returns the auth configuration
@@ -108,6 +115,15 @@ func (m *Manager) GetAuthConfig() *AuthConfig {
 	return m.config
 }
 
+// ReceiverPosition returns the receiver position the server reported with
+// its auth configuration; ok is false if it reported none
+func (m *Manager) ReceiverPosition() (lat, lon float64, ok bool) {
+	if !m.config.Receiver.Valid() {
+		return 0, 0, false
+	}
+	return m.config.Receiver.Lat, m.config.Receiver.Lon, true
+}
+
 // GetUsername returns the authenticated user's username
 func (m *Manager) GetUsername() string {
 	m.mu.RLock()
@@ -118,14 +134,25 @@ func (m *Manager) GetUsername() string {
 	return ""
 }
 
-// Login initiates the login flow
+// LoginPrompt is told the sign-in URL once it is known, and whether a
+// browser was opened at it
+type LoginPrompt func(url string, opened bool)
+
+// Login initiates the login flow, printing the sign-in URL
 func (m *Manager) Login(ctx context.Context) error {
+	return m.LoginWithPrompt(ctx, printLoginPrompt)
+}
+
+// LoginWithPrompt initiates the login flow, handing the sign-in URL to
+// prompt rather than printing it, so it can run inside the TUI. It blocks
+// until sign-in completes, fails or ctx is cancelled.
+func (m *Manager) LoginWithPrompt(ctx context.Context, prompt LoginPrompt) error {
 	if !m.config.AuthEnabled {
 		return fmt.Errorf("server does not require authentication")
 	}
 
 	if m.config.OIDCEnabled {
-		return m.loginOIDC(ctx)
+		return m.loginOIDC(ctx, prompt)
 	}
 
 	if m.config.LocalAuthEnabled {
@@ -135,8 +162,20 @@ func (m *Manager) Login(ctx context.Context) error {
 	return fmt.Errorf("no supported authentication method available")
 }
 
+// printLoginPrompt tells a terminal user where to sign in
+func printLoginPrompt(url string, opened bool) {
+	fmt.Printf("Opening browser for authentication...\n")
+	if !opened {
+		if CanOpenBrowser() {
+			fmt.Printf("Could not open browser automatically.\n")
+		}
+		fmt.Printf("Please open this URL in your browser:\n\n%s\n\n", url)
+	}
+	fmt.Printf("Waiting for authentication (timeout: 5 minutes)...\n")
+}
+
 // loginOIDC performs OIDC authentication flow
-func (m *Manager) loginOIDC(ctx context.Context) error {
+func (m *Manager) loginOIDC(ctx context.Context, prompt LoginPrompt) error {
 	// Start callback server
 	callbackServer, err := NewCallbackServer()
 	if err != nil {
@@ -157,18 +196,10 @@ func (m *Manager) loginOIDC(ctx context.Context) error {
 	}
 
 	// Open browser
-	fmt.Printf("Opening browser for authentication...\n")
-	if CanOpenBrowser() {
-		if browserErr := OpenBrowser(authResp.AuthorizationURL); browserErr != nil {
-			fmt.Printf("Could not open browser automatically.\n")
-			fmt.Printf("Please open this URL in your browser:\n\n%s\n\n", authResp.AuthorizationURL)
-		}
-	} else {
-		fmt.Printf("Please open this URL in your browser:\n\n%s\n\n", authResp.AuthorizationURL)
-	}
+	opened := CanOpenBrowser() && OpenBrowser(authResp.AuthorizationURL) == nil
+	prompt(authResp.AuthorizationURL, opened)
 
 	// Wait for callback
-	fmt.Printf("Waiting for authentication (timeout: 5 minutes)...\n")
 	result, err := callbackServer.WaitForCallback(ctx, 5*time.Minute)
 	if err != nil {
 		return err
@@ -238,9 +269,9 @@ func (m *Manager) exchangeCodeForTokens(code, state, redirectURI string) (*Token
 			return nil, fmt.Errorf("failed to parse token response: %w", err)
 		}
 
-		expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+		expiresAt := m.serverNow().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
 		if tokenResp.ExpiresIn == 0 {
-			expiresAt = time.Now().Add(60 * time.Minute) // Default 60 min
+			expiresAt = m.serverNow().Add(60 * time.Minute) // Default 60 min
 		}
 
 		tokens := &TokenSet{
@@ -306,7 +337,7 @@ func (m *Manager) parseTokensFromRedirect(redirectURL string) (*TokenSet, error)
 	tokens := &TokenSet{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-		ExpiresAt:    time.Now().Add(time.Duration(expiresIn) * time.Second),
+		ExpiresAt:    m.serverNow().Add(time.Duration(expiresIn) * time.Second),
 		TokenType:    values.Get("token_type"),
 		Host:         m.host,
 	}
@@ -337,17 +368,17 @@ func (m *Manager) GetAccessToken() (string, error) {
 	}
 
 	// Check if refresh is needed
-	if m.tokens.NeedsRefresh() && m.tokens.RefreshToken != "" {
+	if m.tokens.NeedsRefreshAt(m.serverNow()) && m.tokens.RefreshToken != "" {
 		if err := m.refreshTokenLocked(); err != nil {
 			// If refresh fails and token is expired, return error
-			if m.tokens.IsExpired() {
+			if m.tokens.IsExpiredAt(m.serverNow()) {
 				return "", fmt.Errorf("token expired and refresh failed: %w", err)
 			}
 			// Token not yet expired, use existing one
 		}
 	}
 
-	if m.tokens.IsExpired() {
+	if m.tokens.IsExpiredAt(m.serverNow()) {
 		return "", fmt.Errorf("token expired")
 	}
 
@@ -388,9 +419,9 @@ func (m *Manager) refreshTokenLocked() error {
 		m.tokens.RefreshToken = tokenResp.RefreshToken
 	}
 
-	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	expiresAt := m.serverNow().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
 	if tokenResp.ExpiresIn == 0 {
-		expiresAt = time.Now().Add(60 * time.Minute)
+		expiresAt = m.serverNow().Add(60 * time.Minute)
 	}
 	m.tokens.ExpiresAt = expiresAt
 
@@ -403,6 +434,62 @@ func (m *Manager) refreshTokenLocked() error {
 	return nil
 }
 
+// Status is a snapshot of the credentials in use, for display
+type Status struct {
+	Method   string // oidc, api_key or none
+	Username string
+	// ExpiresAt is when the access token expires, on the local clock; zero
+	// for API keys
+	ExpiresAt  time.Time
+	CanRefresh bool // a refresh token is held
+	CanLogin   bool // the server offers browser sign-in
+	Required   bool // the server requires authentication
+}
+
+// Status returns the current sign-in state. Expiry is shifted from the
+// server's clock to ours so a local countdown ends when the server says.
+func (m *Manager) Status() Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	st := Status{
+		Method:   authTypeNone,
+		CanLogin: m.config.AuthEnabled && m.config.OIDCEnabled,
+		Required: m.RequiresAuth(),
+	}
+	switch {
+	case m.apiKey != "":
+		st.Method = authTypeAPIKey
+	case m.tokens != nil:
+		st.Method = authTypeOIDC
+		st.Username = m.tokens.Username
+		st.ExpiresAt = m.tokens.ExpiresAt.Add(-m.skew)
+		st.CanRefresh = m.tokens.RefreshToken != ""
+	}
+	return st
+}
+
+// ErrNoRefreshToken is returned by RefreshNow when signing in again is the
+// only way to renew the session
+var ErrNoRefreshToken = errors.New("no refresh token; sign in again")
+
+// RefreshNow renews the access token immediately, however long it has left.
+// It is safe to call from any goroutine.
+func (m *Manager) RefreshNow() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	case m.apiKey != "":
+		return errors.New("API keys don't expire")
+	case m.tokens == nil:
+		return errors.New("not authenticated")
+	case m.tokens.RefreshToken == "":
+		return ErrNoRefreshToken
+	}
+	return m.refreshTokenLocked()
+}
+
 // Logout clears stored credentials
 func (m *Manager) Logout() error {
 	m.mu.Lock()
@@ -434,11 +521,14 @@ func (m *Manager) GetTokenInfo() map[string]interface{} {
 		info["auth_type"] = authTypeOIDC
 		info["username"] = m.tokens.Username
 		info["expires_at"] = m.tokens.ExpiresAt.Format(time.RFC3339)
-		info["expired"] = m.tokens.IsExpired()
+		info["expired"] = m.tokens.IsExpiredAt(m.serverNow())
 		info["has_refresh_token"] = m.tokens.RefreshToken != ""
 	default:
 		info["auth_type"] = authTypeNone
 	}
+	if m.skewKnown {
+		info["clock_skew_seconds"] = int(m.skew.Seconds())
+	}
 
 	return info
 }
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -1790,7 +1791,7 @@ func TestManager_loginOIDC_GetAuthURLError(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	err := m.loginOIDC(ctx)
+	err := m.loginOIDC(ctx, func(string, bool) {})
 	if err == nil {
 		t.Error("expected error when GetOIDCAuthorizationURL fails")
 	}
@@ -1824,7 +1825,7 @@ func TestManager_loginOIDC_WaitForCallbackTimeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
-	err := m.loginOIDC(ctx)
+	err := m.loginOIDC(ctx, func(string, bool) {})
 	if err == nil {
 		t.Error("expected error when context times out")
 	}
@@ -1875,7 +1876,7 @@ func TestManager_loginOIDC_ExchangeCodeError(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	err := m.loginOIDC(ctx)
+	err := m.loginOIDC(ctx, func(string, bool) {})
 	if err == nil {
 		t.Error("expected error when exchangeCodeForTokens fails")
 	}
@@ -1939,7 +1940,7 @@ func TestManager_loginOIDC_SaveTokensError(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	err := m.loginOIDC(ctx)
+	err := m.loginOIDC(ctx, func(string, bool) {})
 	if err == nil {
 		t.Error("expected error when saving tokens fails")
 	}
@@ -2007,7 +2008,7 @@ func TestManager_loginOIDC_Success(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	err := m.loginOIDC(ctx)
+	err := m.loginOIDC(ctx, func(string, bool) {})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -2067,7 +2068,7 @@ func TestManager_loginOIDC_CallbackError(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	err := m.loginOIDC(ctx)
+	err := m.loginOIDC(ctx, func(string, bool) {})
 	if err == nil {
 		t.Error("expected error when callback returns error")
 	}
@@ -2140,3 +2141,84 @@ func TestNewManager_WithExistingTokens(t *testing.T) {
 	// Cleanup
 	m2.tokenStore.Delete("127.0.0.1:59998")
 }
+
+func TestManager_Status(t *testing.T) {
+	expires := time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+	m := &Manager{
+		config: &AuthConfig{AuthMode: "private", AuthEnabled: true, OIDCEnabled: true},
+		tokens: &TokenSet{Username: "anna", ExpiresAt: expires, RefreshToken: "r"},
+		skew:   2 * time.Minute,
+	}
+
+	st := m.Status()
+	if st.Method != authTypeOIDC || st.Username != "anna" || !st.CanRefresh || !st.CanLogin || !st.Required {
+		t.Errorf("unexpected status %+v", st)
+	}
+	// The server is 2 minutes ahead, so the token expires 2 minutes earlier
+	// on our clock
+	if want := expires.Add(-2 * time.Minute); !st.ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", st.ExpiresAt, want)
+	}
+
+	m.SetAPIKey("sk_test")
+	if st := m.Status(); st.Method != authTypeAPIKey || !st.ExpiresAt.IsZero() || st.CanRefresh {
+		t.Errorf("API keys have no expiry, got %+v", st)
+	}
+
+	m = &Manager{config: &AuthConfig{AuthMode: authModePublic}}
+	if st := m.Status(); st.Method != authTypeNone || st.Required || st.CanLogin {
+		t.Errorf("unexpected status for a public server %+v", st)
+	}
+}
+
+func TestManager_RefreshNow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/refresh" {
+			json.NewEncoder(w).Encode(TokenResponse{AccessToken: "new-access-token", ExpiresIn: 3600})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	host := "test:8080"
+	store := newMockTokenStore()
+	m := &Manager{
+		baseURL:    server.URL,
+		host:       host,
+		config:     &AuthConfig{AuthMode: "oidc", AuthEnabled: true},
+		tokenStore: store,
+		// Far from needing a refresh; RefreshNow renews anyway
+		tokens: &TokenSet{AccessToken: "old", RefreshToken: "r", ExpiresAt: time.Now().Add(30 * time.Minute)},
+	}
+
+	if err := m.RefreshNow(); err != nil {
+		t.Fatalf("RefreshNow: %v", err)
+	}
+	if m.tokens.AccessToken != "new-access-token" || time.Until(m.tokens.ExpiresAt) < 55*time.Minute {
+		t.Errorf("expected a renewed token, got %+v", m.tokens)
+	}
+	if store.tokens[host] == nil {
+		t.Error("the renewed token should be saved")
+	}
+
+	m.tokens.RefreshToken = ""
+	if err := m.RefreshNow(); !errors.Is(err, ErrNoRefreshToken) {
+		t.Errorf("expected ErrNoRefreshToken, got %v", err)
+	}
+	m.tokens = nil
+	if err := m.RefreshNow(); err == nil {
+		t.Error("expected an error when not signed in")
+	}
+}
+
+func TestManager_LoginWithPrompt_NotRequired(t *testing.T) {
+	m := &Manager{config: &AuthConfig{AuthMode: authModePublic}}
+	called := false
+	if err := m.LoginWithPrompt(context.Background(), func(string, bool) { called = true }); err == nil {
+		t.Error("expected an error from a server without auth")
+	}
+	if called {
+		t.Error("the prompt should not be called when there is nothing to sign in to")
+	}
+}
@@ -0,0 +1,41 @@
+// Package auth provides authentication functionality for SkySpy CLI
+package auth
+
+import (
+	"net/http"
+	"time"
+)
+
+// SkewThreshold is the clock offset from the server beyond which local
+// time is treated as wrong
+const SkewThreshold = 60 * time.Second
+
+// measureSkew estimates how far the server's clock is ahead of ours from a
+// response's Date header. The request is assumed to have reached the server
+// halfway between sent and received. ok is false without a usable header.
+func measureSkew(resp *http.Response, sent, received time.Time) (skew time.Duration, ok bool) {
+	if resp == nil {
+		return 0, false
+	}
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return 0, false
+	}
+	local := sent.Add(received.Sub(sent) / 2)
+	// Date has one-second resolution, so don't report the truncation
+	skew = date.Sub(local.Truncate(time.Second))
+	return skew, true
+}
+
+// ClockSkew returns how far the server's clock was ahead of the local clock
+// (negative if behind) when the auth config was fetched. ok is false if the
+// server gave no Date header.
+func (m *Manager) ClockSkew() (skew time.Duration, ok bool) {
+	return m.skew, m.skewKnown
+}
+
+// serverNow is the current time on the server's clock, used for token
+// expiry so a drifted local clock doesn't expire tokens early or late
+func (m *Manager) serverNow() time.Time {
+	return time.Now().Add(m.skew)
+}
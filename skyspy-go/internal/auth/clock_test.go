@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// skewedServer serves the auth config with a Date header offset from real
+// time, like a server seen from a host whose clock has drifted
+func skewedServer(t *testing.T, offset time.Duration) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(offset).UTC().Format(http.TimeFormat))
+		_ = json.NewEncoder(w).Encode(AuthConfig{AuthMode: "public"})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchAuthConfig_MeasuresSkew(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset time.Duration
+	}{
+		{"server ahead", 2 * time.Hour},
+		{"server behind", -90 * time.Second},
+		{"in sync", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := skewedServer(t, tt.offset)
+
+			config, skew, ok, err := fetchAuthConfig(server.URL)
+			if err != nil || config == nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !ok {
+				t.Fatal("expected skew to be measured")
+			}
+			if d := skew - tt.offset; d < -2*time.Second || d > 2*time.Second {
+				t.Errorf("expected skew near %v, got %v", tt.offset, skew)
+			}
+		})
+	}
+}
+
+func TestFetchAuthConfig_SkewOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, skew, ok, err := fetchAuthConfig(server.URL)
+	if err == nil {
+		t.Fatal("expected error status to fail")
+	}
+	if !ok || skew > -59*time.Minute {
+		t.Errorf("expected skew measured despite the error, got %v (%v)", skew, ok)
+	}
+}
+
+func TestMeasureSkew_NoDate(t *testing.T) {
+	now := time.Now()
+	if _, ok := measureSkew(&http.Response{Header: http.Header{}}, now, now); ok {
+		t.Error("expected no skew without a Date header")
+	}
+	if _, ok := measureSkew(nil, now, now); ok {
+		t.Error("expected no skew without a response")
+	}
+}
+
+func TestManager_TokenExpiryUsesServerClock(t *testing.T) {
+	// The local clock runs two hours fast: a token the server says is good
+	// for another hour looks an hour stale locally
+	const localAhead = 2 * time.Hour
+	tokens := &TokenSet{
+		AccessToken: "token",
+		ExpiresAt:   time.Now().Add(-localAhead + time.Hour),
+	}
+	m := createTestManager(&AuthConfig{AuthEnabled: true, AuthMode: "oidc"}, tokens, "")
+
+	if m.IsAuthenticated() {
+		t.Fatal("without a skew measurement the token should look expired")
+	}
+
+	m.skew, m.skewKnown = -localAhead, true
+	if !m.IsAuthenticated() {
+		t.Error("expected token valid on the server's clock")
+	}
+	if token, err := m.GetAccessToken(); err != nil || token != "token" {
+		t.Errorf("expected access token, got %q, %v", token, err)
+	}
+	if skew, ok := m.ClockSkew(); !ok || skew != -localAhead {
+		t.Errorf("unexpected ClockSkew %v, %v", skew, ok)
+	}
+	if info := m.GetTokenInfo(); info["expired"] != false || info["clock_skew_seconds"] != -7200 {
+		t.Errorf("unexpected token info %v", info)
+	}
+
+	// Local clock slow instead: the token is past expiry on the server
+	tokens.ExpiresAt = time.Now().Add(30 * time.Minute)
+	m.skew = time.Hour
+	if m.IsAuthenticated() {
+		t.Error("expected token expired on the server's clock")
+	}
+}
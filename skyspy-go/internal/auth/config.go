@@ -20,6 +20,20 @@ type AuthConfig struct {
 	LocalAuthEnabled bool                     `json:"local_auth_enabled"`
 	APIKeyEnabled    bool                     `json:"api_key_enabled"`
 	Features         map[string]FeatureAccess `json:"features,omitempty"`
+	Version          string                   `json:"version,omitempty"`  // server version, if reported
+	Receiver         *ReceiverPosition        `json:"receiver,omitempty"` // the server's receiver, if it shares its position
+}
+
+// ReceiverPosition is where the server's receiver is
+type ReceiverPosition struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Valid reports whether the position is a real one; 0, 0 is taken to mean
+// the server doesn't know
+func (p *ReceiverPosition) Valid() bool {
+	return p != nil && p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180 && (p.Lat != 0 || p.Lon != 0)
 }
 
 // FeatureAccess represents access configuration for a feature
@@ -54,24 +68,34 @@ type UserProfile struct {
 
 // FetchAuthConfig retrieves authentication configuration from the API
 func FetchAuthConfig(baseURL string) (*AuthConfig, error) {
+	config, _, _, err := fetchAuthConfig(baseURL)
+	return config, err
+}
+
+// fetchAuthConfig retrieves the auth configuration and measures the server's
+// clock skew from the response. The skew is reported even if the config
+// itself can't be used.
+func fetchAuthConfig(baseURL string) (config *AuthConfig, skew time.Duration, skewKnown bool, err error) {
 	client := &http.Client{Timeout: 10 * time.Second}
 
+	sent := time.Now()
 	resp, err := client.Get(baseURL + "/api/v1/auth/config")
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch auth config: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to fetch auth config: %w", err)
 	}
 	defer resp.Body.Close()
+	skew, skewKnown = measureSkew(resp, sent, time.Now())
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("auth config returned status %d", resp.StatusCode)
+		return nil, skew, skewKnown, fmt.Errorf("auth config returned status %d", resp.StatusCode)
 	}
 
-	var config AuthConfig
-	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
-		return nil, fmt.Errorf("failed to decode auth config: %w", err)
+	config = &AuthConfig{}
+	if err := json.NewDecoder(resp.Body).Decode(config); err != nil {
+		return nil, skew, skewKnown, fmt.Errorf("failed to decode auth config: %w", err)
 	}
 
-	return &config, nil
+	return config, skew, skewKnown, nil
 }
 
 // GetOIDCAuthorizationURL gets the OIDC authorization URL from the API
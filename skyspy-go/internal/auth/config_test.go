@@ -702,3 +702,35 @@ func TestFetchUserProfile_NewRequestError(t *testing.T) {
 		t.Error("expected error for invalid URL")
 	}
 }
+
+func TestFetchAuthConfig_ReceiverPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		lat  float64
+		lon  float64
+		ok   bool
+	}{
+		{"reported", `{"auth_mode":"public","receiver":{"lat":52.3086,"lon":4.7639}}`, 52.3086, 4.7639, true},
+		{"not reported", `{"auth_mode":"public"}`, 0, 0, false},
+		{"unknown", `{"auth_mode":"public","receiver":{"lat":0,"lon":0}}`, 0, 0, false},
+		{"out of range", `{"auth_mode":"public","receiver":{"lat":95,"lon":4.7}}`, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			config, err := FetchAuthConfig(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lat, lon, ok := (&Manager{config: config}).ReceiverPosition()
+			if lat != tt.lat || lon != tt.lon || ok != tt.ok {
+				t.Errorf("ReceiverPosition() = %v, %v, %v, want %v, %v, %v", lat, lon, ok, tt.lat, tt.lon, tt.ok)
+			}
+		})
+	}
+}
@@ -27,12 +27,22 @@ type TokenSet struct {
 
 // IsExpired returns true if the access token is expired
 func (t *TokenSet) IsExpired() bool {
-	return time.Now().After(t.ExpiresAt)
+	return t.IsExpiredAt(time.Now())
+}
+
+// IsExpiredAt returns true if the access token is expired at now
+func (t *TokenSet) IsExpiredAt(now time.Time) bool {
+	return now.After(t.ExpiresAt)
 }
 
 // NeedsRefresh returns true if the token should be refreshed (5 min before expiry)
 func (t *TokenSet) NeedsRefresh() bool {
-	return time.Now().After(t.ExpiresAt.Add(-5 * time.Minute))
+	return t.NeedsRefreshAt(time.Now())
+}
+
+// NeedsRefreshAt returns true if the token should be refreshed at now
+func (t *TokenSet) NeedsRefreshAt(now time.Time) bool {
+	return now.After(t.ExpiresAt.Add(-5 * time.Minute))
 }
 
 // TokenStore defines the interface for token storage
@@ -0,0 +1,103 @@
+// Package changelog provides the release notes embedded in the SkySpy
+// binary and tracks which release the user has last seen
+package changelog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//go:embed changelog.json
+var changelogJSON []byte
+
+// Entry lists the changes in one release
+type Entry struct {
+	Version string   `json:"version"`
+	Items   []string `json:"items"`
+}
+
+// Entries returns the embedded changelog, newest release first
+func Entries() []Entry {
+	var entries []Entry
+	if err := json.Unmarshal(changelogJSON, &entries); err != nil {
+		panic(fmt.Sprintf("changelog: embedded changelog.json: %v", err))
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, _ := Parse(entries[i].Version)
+		b, _ := Parse(entries[j].Version)
+		return a.Compare(b) > 0
+	})
+	return entries
+}
+
+// Since returns the entries newer than seen up to and including current,
+// newest first. It returns nil when current is not a release (a
+// pre-release or development build), when seen is unknown, or when
+// current is not newer than seen.
+func Since(seen, current string) []Entry {
+	cur, err := Parse(current)
+	if err != nil || cur.IsPrerelease() {
+		return nil
+	}
+	last, err := Parse(seen)
+	if err != nil || cur.Compare(last) <= 0 {
+		return nil
+	}
+
+	var result []Entry
+	for _, e := range Entries() {
+		v, err := Parse(e.Version)
+		if err != nil || v.IsPrerelease() {
+			continue
+		}
+		if v.Compare(last) > 0 && v.Compare(cur) <= 0 {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Write prints entries as a plain list, one heading per release
+func Write(w io.Writer, entries []Entry) error {
+	for i, e := range entries {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "v%s\n", strings.TrimPrefix(e.Version, "v")); err != nil {
+			return err
+		}
+		for _, item := range e.Items {
+			if _, err := fmt.Fprintf(w, "  - %s\n", item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ReadSeen returns the version recorded at path, or "" if none has been
+// recorded yet
+func ReadSeen(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// WriteSeen records version at path as the last release the user has seen
+func WriteSeen(path, version string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	//nolint:gosec // G306: the version marker is not sensitive
+	return os.WriteFile(path, []byte(version+"\n"), 0o644)
+}
@@ -0,0 +1,138 @@
+package changelog
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"1.4.2", Version{Major: 1, Minor: 4, Patch: 2}},
+		{"v0.10.0", Version{Minor: 10}},
+		{"2.0.0-rc.1", Version{Major: 2, Pre: "rc.1"}},
+		{"1.0.0+build.5", Version{Major: 1}},
+		{"v0.2.0-3-gabc1234", Version{Minor: 2, Pre: "3-gabc1234"}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "dev", "1.2", "1.2.3.4", "1.02.3", "1.2.x", "1.2.3-"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Each version sorts before the next, per the semver spec example
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0",
+	}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, _ := Parse(ordered[i])
+		b, _ := Parse(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", ordered[i], ordered[i+1])
+		}
+	}
+	a, _ := Parse("v1.2.3")
+	b, _ := Parse("1.2.3+meta")
+	if a.Compare(b) != 0 {
+		t.Error("build metadata should not affect ordering")
+	}
+}
+
+func TestEntries_NewestFirst(t *testing.T) {
+	entries := Entries()
+	if len(entries) == 0 {
+		t.Fatal("embedded changelog is empty")
+	}
+	for i, e := range entries {
+		v, err := Parse(e.Version)
+		if err != nil {
+			t.Fatalf("entry %d: %v", i, err)
+		}
+		if len(e.Items) == 0 {
+			t.Errorf("entry %s lists no changes", e.Version)
+		}
+		if i > 0 {
+			prev, _ := Parse(entries[i-1].Version)
+			if v.Compare(prev) >= 0 {
+				t.Errorf("entry %s is not older than %s", e.Version, entries[i-1].Version)
+			}
+		}
+	}
+}
+
+func TestSince(t *testing.T) {
+	latest := Entries()[0].Version
+
+	if got := Since("0.1.0", latest); len(got) == 0 || got[0].Version != latest {
+		t.Errorf("expected the latest release after upgrading, got %+v", got)
+	}
+	for _, e := range Since("0.1.0", latest) {
+		if e.Version == "0.1.0" {
+			t.Error("the release already seen should not be listed")
+		}
+	}
+
+	tests := []struct {
+		name, seen, current string
+	}{
+		{"same version", latest, latest},
+		{"downgrade", "99.0.0", latest},
+		{"pre-release build", "0.1.0", latest + "-rc.1"},
+		{"development build", "0.1.0", "dev"},
+		{"nothing seen", "", latest},
+	}
+	for _, tt := range tests {
+		if got := Since(tt.seen, tt.current); got != nil {
+			t.Errorf("%s: expected nothing, got %+v", tt.name, got)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, []Entry{
+		{Version: "0.2.0", Items: []string{"Second"}},
+		{Version: "0.1.0", Items: []string{"First"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "v0.2.0\n  - Second\n\nv0.1.0\n  - First\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestSeenRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skyspy", "last_version")
+	if got := ReadSeen(path); got != "" {
+		t.Errorf("expected nothing recorded, got %q", got)
+	}
+	if err := WriteSeen(path, "0.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	if got := ReadSeen(path); got != "0.2.0" {
+		t.Errorf("expected 0.2.0, got %q", got)
+	}
+	if strings.Contains(ReadSeen(path), "\n") {
+		t.Error("recorded version should be trimmed")
+	}
+}
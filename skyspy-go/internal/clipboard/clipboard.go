@@ -0,0 +1,144 @@
+// Package clipboard copies text to the system clipboard from the terminal
+//
+// The clipboard is set with an OSC 52 escape sequence, which the terminal
+// emulator handles itself, so it works over SSH and inside tmux without any
+// local clipboard tools. Terminals known not to support OSC 52 get a temp
+// file instead.
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// MaxEncodedSize is the largest base64 payload sent in one OSC 52 sequence.
+// Terminals silently drop sequences over their limit; this is the smallest
+// limit in common use (hterm), so copies work everywhere.
+const MaxEncodedSize = 74994
+
+// maxPayload is the most raw bytes that fit in MaxEncodedSize once encoded
+const maxPayload = MaxEncodedSize / 4 * 3
+
+// Method is how data reached the user
+type Method int
+
+// Copy methods
+const (
+	MethodOSC52 Method = iota
+	MethodFile
+)
+
+// Result describes a completed copy
+type Result struct {
+	Method    Method
+	Path      string // temp file, for MethodFile
+	Bytes     int    // bytes copied after any truncation
+	Truncated bool   // data was cut to fit MaxEncodedSize
+}
+
+// Writer copies data to the clipboard through a terminal
+type Writer struct {
+	out     io.Writer
+	getenv  func(string) string
+	tempDir string
+}
+
+// NewWriter creates a Writer that sends escape sequences to out, which should
+// be the terminal the program is drawing on
+func NewWriter(out io.Writer) *Writer {
+	return &Writer{
+		out:    out,
+		getenv: os.Getenv,
+	}
+}
+
+// SetEnv replaces the environment lookup used to detect the terminal
+func (w *Writer) SetEnv(getenv func(string) string) {
+	w.getenv = getenv
+}
+
+// SetTempDir sets where fallback files are written (default os.TempDir)
+func (w *Writer) SetTempDir(dir string) {
+	w.tempDir = dir
+}
+
+// Copy puts data on the clipboard, or in a temp file when the terminal
+// can't take OSC 52
+func (w *Writer) Copy(data []byte) (Result, error) {
+	seq, result, err := w.Sequence(data)
+	if err != nil || seq == "" {
+		return result, err
+	}
+	if _, err := io.WriteString(w.out, seq); err != nil {
+		return Result{}, fmt.Errorf("failed to write to terminal: %w", err)
+	}
+	return result, nil
+}
+
+// Sequence is Copy for a caller that owns the terminal: it returns the
+// OSC 52 sequence to send rather than writing it, or "" when the terminal
+// can't take OSC 52 and data went to a temp file
+func (w *Writer) Sequence(data []byte) (string, Result, error) {
+	if !Supported(w.getenv) {
+		result, err := w.copyToFile(data)
+		return "", result, err
+	}
+
+	seq, n, truncated := Encode(data, w.getenv("TMUX") != "")
+	return seq, Result{Method: MethodOSC52, Bytes: n, Truncated: truncated}, nil
+}
+
+func (w *Writer) copyToFile(data []byte) (Result, error) {
+	f, err := os.CreateTemp(w.tempDir, "skyspy_clip_*.csv")
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		return Result{}, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return Result{Method: MethodFile, Path: f.Name(), Bytes: len(data)}, nil
+}
+
+// Supported reports whether the terminal described by the environment is
+// expected to honor OSC 52. Detection is best effort: there is no way to
+// query support, so only terminals known to lack it are rejected.
+func Supported(getenv func(string) string) bool {
+	term := getenv("TERM")
+	switch {
+	case term == "", term == "dumb":
+		return false
+	case term == "linux":
+		// The Linux virtual console ignores OSC 52
+		return false
+	}
+	return true
+}
+
+// Encode builds the OSC 52 sequence that sets the clipboard to data. Data
+// over the size cap is cut at the last line break that fits, so CSV rows
+// are never split. It returns the sequence, the number of bytes encoded and
+// whether data was truncated. With tmux set, the sequence is wrapped in a
+// DCS passthrough so tmux forwards it to the outer terminal.
+func Encode(data []byte, tmux bool) (string, int, bool) {
+	truncated := false
+	if len(data) > maxPayload {
+		cut := maxPayload
+		if i := bytes.LastIndexByte(data[:maxPayload], '\n'); i >= 0 {
+			cut = i + 1
+		}
+		data = data[:cut]
+		truncated = true
+	}
+
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString(data) + "\x07"
+	if tmux {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq, len(data), truncated
+}
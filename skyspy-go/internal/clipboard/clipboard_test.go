@@ -0,0 +1,177 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+// decode extracts the payload from a plain OSC 52 sequence
+func decode(t *testing.T, seq string) []byte {
+	t.Helper()
+	if !strings.HasPrefix(seq, "\x1b]52;c;") || !strings.HasSuffix(seq, "\x07") {
+		t.Fatalf("not an OSC 52 sequence: %q", seq)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(seq, "\x1b]52;c;"), "\x07"))
+	if err != nil {
+		t.Fatalf("invalid base64 payload: %v", err)
+	}
+	return data
+}
+
+func TestEncode(t *testing.T) {
+	seq, n, truncated := Encode([]byte("hex,callsign\nABC123,UAL1\n"), false)
+
+	if seq != "\x1b]52;c;aGV4LGNhbGxzaWduCkFCQzEyMyxVQUwxCg==\x07" {
+		t.Errorf("unexpected sequence %q", seq)
+	}
+	if n != 25 || truncated {
+		t.Errorf("expected 25 bytes untruncated, got %d (truncated=%v)", n, truncated)
+	}
+}
+
+func TestEncode_Tmux(t *testing.T) {
+	seq, _, _ := Encode([]byte("hi"), true)
+
+	want := "\x1bPtmux;\x1b\x1b]52;c;aGk=\x07\x1b\\"
+	if seq != want {
+		t.Errorf("expected tmux passthrough %q, got %q", want, seq)
+	}
+}
+
+func TestEncode_TruncatesAtLineBreak(t *testing.T) {
+	line := strings.Repeat("x", 99) + "\n"
+	data := []byte(strings.Repeat(line, 1000)) // 100KB
+
+	seq, n, truncated := Encode(data, false)
+	if !truncated {
+		t.Fatal("expected oversize data to be truncated")
+	}
+
+	encoded := len(seq) - len("\x1b]52;c;") - len("\x07")
+	if encoded > MaxEncodedSize {
+		t.Errorf("encoded payload %d exceeds cap %d", encoded, MaxEncodedSize)
+	}
+
+	payload := decode(t, seq)
+	if len(payload) != n {
+		t.Errorf("reported %d bytes, encoded %d", n, len(payload))
+	}
+	if n%len(line) != 0 || !bytes.HasSuffix(payload, []byte("\n")) {
+		t.Errorf("expected whole lines only, got %d bytes", n)
+	}
+}
+
+func TestEncode_TruncatesWithoutLineBreak(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), maxPayload+10)
+
+	seq, n, truncated := Encode(data, false)
+	if !truncated || n != maxPayload {
+		t.Errorf("expected hard cut at %d, got %d (truncated=%v)", maxPayload, n, truncated)
+	}
+	if len(decode(t, seq)) != maxPayload {
+		t.Error("payload does not match reported size")
+	}
+}
+
+func TestEncode_ExactlyAtCap(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), maxPayload)
+
+	_, n, truncated := Encode(data, false)
+	if truncated || n != maxPayload {
+		t.Errorf("data at the cap should not be truncated, got %d (truncated=%v)", n, truncated)
+	}
+}
+
+func TestSupported(t *testing.T) {
+	tests := []struct {
+		term string
+		want bool
+	}{
+		{"xterm-256color", true},
+		{"screen", true},
+		{"linux", false},
+		{"dumb", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := Supported(env(map[string]string{"TERM": tt.term})); got != tt.want {
+			t.Errorf("Supported(TERM=%q) = %v, want %v", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestWriter_CopyOSC52(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	w.SetEnv(env(map[string]string{"TERM": "xterm-256color"}))
+
+	res, err := w.Copy([]byte("a,b\n"))
+	if err != nil {
+		t.Fatalf("Copy failed: %v", err)
+	}
+	if res.Method != MethodOSC52 || res.Bytes != 4 || res.Truncated {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if got := decode(t, out.String()); string(got) != "a,b\n" {
+		t.Errorf("expected payload %q, got %q", "a,b\n", got)
+	}
+}
+
+func TestWriter_Sequence(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	w.SetEnv(env(map[string]string{"TERM": "xterm-256color"}))
+
+	seq, res, err := w.Sequence([]byte("a,b\n"))
+	if err != nil {
+		t.Fatalf("Sequence failed: %v", err)
+	}
+	if res.Method != MethodOSC52 || res.Bytes != 4 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if got := decode(t, seq); string(got) != "a,b\n" {
+		t.Errorf("expected payload %q, got %q", "a,b\n", got)
+	}
+	if out.Len() != 0 {
+		t.Error("Sequence should leave the writing to the caller")
+	}
+
+	w.SetEnv(env(map[string]string{"TERM": "dumb"}))
+	w.SetTempDir(t.TempDir())
+	if seq, res, _ := w.Sequence([]byte("a,b\n")); seq != "" || res.Method != MethodFile {
+		t.Errorf("expected a file and no sequence, got %q %+v", seq, res)
+	}
+}
+
+func TestWriter_FallsBackToFile(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	w.SetEnv(env(map[string]string{"TERM": "linux"}))
+	w.SetTempDir(t.TempDir())
+
+	res, err := w.Copy([]byte("a,b\n"))
+	if err != nil {
+		t.Fatalf("Copy failed: %v", err)
+	}
+	if res.Method != MethodFile || res.Path == "" {
+		t.Fatalf("expected file fallback, got %+v", res)
+	}
+	if out.Len() != 0 {
+		t.Error("nothing should be written to the terminal on fallback")
+	}
+
+	data, err := os.ReadFile(res.Path)
+	if err != nil {
+		t.Fatalf("failed to read fallback file: %v", err)
+	}
+	if string(data) != "a,b\n" {
+		t.Errorf("expected %q in fallback file, got %q", "a,b\n", data)
+	}
+}
@@ -0,0 +1,425 @@
+// Package codec decodes SkySpy feed payloads into aircraft and ACARS values.
+// It has no transport dependencies so live, replay and alternate sources can
+// share it.
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MessageType represents the type of feed message
+type MessageType string
+
+const (
+	AircraftSnapshot MessageType = "aircraft:snapshot"
+	AircraftUpdate   MessageType = "aircraft:update"
+	AircraftNew      MessageType = "aircraft:new"
+	AircraftRemove   MessageType = "aircraft:remove"
+	ACARSMessage     MessageType = "acars:message"
+	ACARSSnapshot    MessageType = "acars:snapshot"
+
+	// Notices are messages from the server's operator, such as a
+	// maintenance window; broadcast is an older name for the same thing
+	Notice    MessageType = "notice"
+	Broadcast MessageType = "broadcast"
+
+	// FeedReconnected is never sent by the server. A client queues it ahead
+	// of the first message of each connection after the first, so readers
+	// know later messages come from a fresh server session.
+	FeedReconnected MessageType = "feed:reconnected"
+
+	// Response answers a request the client sent, such as SnapshotRequest
+	Response MessageType = "response"
+)
+
+// SnapshotRequest is the request type that asks the server for every
+// aircraft it is tracking. The response carries snapshot data.
+const SnapshotRequest = "aircraft-snapshot"
+
+// Errors returned by the parse functions. Decoding failures wrap ErrMalformed.
+var (
+	ErrEmpty      = errors.New("codec: empty payload")
+	ErrMalformed  = errors.New("codec: malformed payload")
+	ErrMissingHex = errors.New("codec: aircraft has no hex")
+	ErrNoText     = errors.New("codec: notice has no title or body")
+)
+
+// Message is a raw feed message: a type tag and its undecoded data, plus
+// the server's send time when it provides one
+type Message struct {
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	Timestamp json.RawMessage `json:"timestamp,omitempty"`
+
+	// Source names the receiver a merged feed took the message from; it
+	// is empty for a single server's feed
+	Source string `json:"-"`
+}
+
+// Time returns the server's send time from the envelope. Unix seconds,
+// Unix milliseconds and RFC 3339 strings are accepted; ok is false if the
+// timestamp is missing or unreadable.
+func (m Message) Time() (t time.Time, ok bool) {
+	return decodeTime(m.Timestamp)
+}
+
+// decodeTime reads a timestamp in any of the forms Message.Time accepts
+func decodeTime(raw json.RawMessage) (t time.Time, ok bool) {
+	if isEmpty(raw) {
+		return time.Time{}, false
+	}
+	var secs float64
+	if err := json.Unmarshal(raw, &secs); err == nil {
+		if secs <= 0 || math.IsInf(secs, 0) {
+			return time.Time{}, false
+		}
+		if secs > unixMillisCutoff {
+			secs /= 1000
+		}
+		whole, frac := math.Modf(secs)
+		return time.Unix(int64(whole), int64(frac*1e9)), true
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
+
+// unixMillisCutoff separates Unix seconds from milliseconds; as seconds it
+// is tens of thousands of years away
+const unixMillisCutoff = 1e12
+
+// Aircraft represents aircraft data from the feed
+type Aircraft struct {
+	Hex      string   `json:"hex"`
+	Flight   string   `json:"flight"`
+	Lat      *float64 `json:"lat"`
+	Lon      *float64 `json:"lon"`
+	AltBaro  *int     `json:"alt_baro"`
+	AltGeom  *int     `json:"alt_geom"` // GNSS altitude above the WGS84 ellipsoid
+	Alt      *int     `json:"alt"`
+	GS       *float64 `json:"gs"`
+	Track    *float64 `json:"track"`
+	BaroRate *float64 `json:"baro_rate"`
+	VR       *float64 `json:"vr"`
+	Squawk   string   `json:"squawk"`
+	RSSI     *float64 `json:"rssi"`
+	Type     string   `json:"t"`
+	Military bool     `json:"military"`
+	Distance *float64 `json:"distance_nm"`
+	Bearing  *float64 `json:"bearing"`
+	Category string   `json:"category"` // ADS-B emitter category, e.g. "A7"
+	Reg      string   `json:"r"`        // registration, e.g. "PH-BXA"; from the feed's aircraft database
+	OnGround bool     `json:"-"`        // an altitude was "ground"
+
+	// Received is when the server's radio received the update, from its
+	// timestamp field; zero if it sent none
+	Received time.Time `json:"-"`
+
+	// BadFields names the fields whose values couldn't be read; they are
+	// left unset and the rest of the update kept
+	BadFields []string `json:"-"`
+
+	// Mode S enhanced surveillance (selected altitude/heading, baro
+	// setting and autopilot modes); usually absent
+	NavAltitude    *int     `json:"nav_altitude_mcp"`
+	NavAltitudeFMS *int     `json:"nav_altitude_fms"`
+	NavHeading     *float64 `json:"nav_heading"`
+	NavQNH         *float64 `json:"nav_qnh"`
+	NavModes       []string `json:"nav_modes"`
+}
+
+// groundAltitude is the altitude readsb reports for aircraft on the
+// ground, in place of a number
+const groundAltitude = "ground"
+
+// UnmarshalJSON decodes aircraft data. Decoders disagree on how they send
+// altitudes and ground speed, so those accept a number, a number in a
+// string, and for altitudes "ground": alt_baro and alt then decode as zero
+// and mark the aircraft on the ground, while alt_geom (height above the
+// ellipsoid, not zero on the surface) only marks it. A value that is none
+// of these is left unset and its field named in BadFields, rather than
+// losing the whole update. The radio's receive time, timestamp, takes the
+// same forms as a message's.
+func (a *Aircraft) UnmarshalJSON(data []byte) error {
+	type plain Aircraft
+	aux := struct {
+		*plain
+		AltBaro   json.RawMessage `json:"alt_baro"`
+		AltGeom   json.RawMessage `json:"alt_geom"`
+		Alt       json.RawMessage `json:"alt"`
+		GS        json.RawMessage `json:"gs"`
+		Timestamp json.RawMessage `json:"timestamp"`
+	}{plain: (*plain)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	a.OnGround = false
+	a.BadFields = nil
+	a.AltBaro = a.decodeAltitude("alt_baro", aux.AltBaro, true)
+	a.Alt = a.decodeAltitude("alt", aux.Alt, true)
+	a.AltGeom = a.decodeAltitude("alt_geom", aux.AltGeom, false)
+	a.GS = nil
+	if v, ok, _ := decodeNumber(aux.GS); ok {
+		a.GS = &v
+	} else if !isEmpty(aux.GS) {
+		a.BadFields = append(a.BadFields, "gs")
+	}
+	a.Received = time.Time{}
+	if t, ok := decodeTime(aux.Timestamp); ok {
+		a.Received = t
+	} else if !isEmpty(aux.Timestamp) {
+		a.BadFields = append(a.BadFields, "timestamp")
+	}
+	return nil
+}
+
+// decodeAltitude decodes an altitude field to whole feet. "ground" marks
+// the aircraft on the ground, and gives zero when groundIsZero.
+func (a *Aircraft) decodeAltitude(field string, raw json.RawMessage, groundIsZero bool) *int {
+	v, ok, ground := decodeNumber(raw)
+	switch {
+	case ground:
+		a.OnGround = true
+		if groundIsZero {
+			zero := 0
+			return &zero
+		}
+		return nil
+	case ok:
+		rounded := int(math.Round(v))
+		return &rounded
+	case !isEmpty(raw):
+		a.BadFields = append(a.BadFields, field)
+	}
+	return nil
+}
+
+// decodeNumber reads a JSON number or a string holding one. ok is false for
+// anything else, including a missing value; ground reports the string
+// "ground".
+func decodeNumber(raw json.RawMessage) (v float64, ok, ground bool) {
+	if isEmpty(raw) {
+		return 0, false, false
+	}
+	if err := json.Unmarshal(raw, &v); err == nil {
+		return v, true, false
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return 0, false, false
+	}
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, groundAltitude) {
+		return 0, false, true
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false, false
+	}
+	return v, true, false
+}
+
+// AircraftSnapshotData represents snapshot data containing multiple aircraft
+type AircraftSnapshotData struct {
+	Aircraft map[string]Aircraft `json:"aircraft"`
+}
+
+// ResponseData is the answer to a request: the request's ID and type, and
+// its undecoded result
+type ResponseData struct {
+	RequestID   string          `json:"request_id"`
+	RequestType string          `json:"request_type"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// ACARSData represents ACARS message data
+type ACARSData struct {
+	Callsign string `json:"callsign"`
+	Flight   string `json:"flight"`
+	Label    string `json:"label"`
+	Text     string `json:"text"`
+}
+
+// NoticeData represents a notice from the server's operator. Fields the
+// client doesn't know are ignored.
+type NoticeData struct {
+	ID       string `json:"id"`
+	Severity string `json:"severity"` // e.g. "info", "warning", "critical"
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+	Ack      bool   `json:"ack"` // the server wants a receipt, sent with ID
+}
+
+// highSeverities are the severities that interrupt the user; anything
+// else, including no severity, is shown in passing
+var highSeverities = map[string]bool{
+	"high":      true,
+	"critical":  true,
+	"error":     true,
+	"alert":     true,
+	"emergency": true,
+}
+
+// High reports whether the notice is severe enough to interrupt the user
+func (n *NoticeData) High() bool {
+	return highSeverities[n.Severity]
+}
+
+// NormalizeHex returns the canonical form of an ICAO hex address: trimmed
+// and upper case, as the radar displays it. Servers disagree on case, and
+// some mix both, so every hex-keyed map uses this form.
+func NormalizeHex(hex string) string {
+	return strings.ToUpper(strings.TrimSpace(hex))
+}
+
+// ParseMessage decodes a raw frame into a Message
+func ParseMessage(data []byte) (Message, error) {
+	var msg Message
+	if isEmpty(data) {
+		return msg, ErrEmpty
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return Message{}, malformed(err)
+	}
+	return msg, nil
+}
+
+// ParseAircraft parses single aircraft data, normalizing its hex address.
+// An aircraft without a hex address cannot be tracked and is reported as
+// ErrMissingHex.
+func ParseAircraft(data json.RawMessage) (*Aircraft, error) {
+	if isEmpty(data) {
+		return nil, ErrEmpty
+	}
+	var ac Aircraft
+	if err := json.Unmarshal(data, &ac); err != nil {
+		return nil, malformed(err)
+	}
+	ac.Hex = NormalizeHex(ac.Hex)
+	if ac.Hex == "" {
+		return nil, ErrMissingHex
+	}
+	return &ac, nil
+}
+
+// ParseSnapshot parses aircraft snapshot data, either an object with an
+// aircraft map keyed by hex or an aircraft array, or a plain array. Entries
+// without a hex are skipped; map entries fall back to their key. Hex
+// addresses are normalized.
+func ParseSnapshot(data json.RawMessage) ([]Aircraft, error) {
+	if isEmpty(data) {
+		return nil, ErrEmpty
+	}
+
+	// Try parsing as object with aircraft map
+	var snapshot AircraftSnapshotData
+	if err := json.Unmarshal(data, &snapshot); err == nil && snapshot.Aircraft != nil {
+		aircraft := make([]Aircraft, 0, len(snapshot.Aircraft))
+		for hex, ac := range snapshot.Aircraft {
+			if ac.Hex == "" {
+				ac.Hex = hex
+			}
+			ac.Hex = NormalizeHex(ac.Hex)
+			if ac.Hex != "" {
+				aircraft = append(aircraft, ac)
+			}
+		}
+		return aircraft, nil
+	}
+
+	// Try parsing as object with aircraft array, then as a plain array
+	var list []Aircraft
+	var wrapped struct {
+		Aircraft []Aircraft `json:"aircraft"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err == nil && wrapped.Aircraft != nil {
+		list = wrapped.Aircraft
+	} else if err := json.Unmarshal(data, &list); err != nil {
+		return nil, malformed(err)
+	}
+	aircraft := make([]Aircraft, 0, len(list))
+	for _, ac := range list {
+		ac.Hex = NormalizeHex(ac.Hex)
+		if ac.Hex != "" {
+			aircraft = append(aircraft, ac)
+		}
+	}
+	return aircraft, nil
+}
+
+// ParseResponse parses the answer to a request. A response without a
+// request type can't be matched to its request and is malformed.
+func ParseResponse(data json.RawMessage) (*ResponseData, error) {
+	if isEmpty(data) {
+		return nil, ErrEmpty
+	}
+	var resp ResponseData
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, malformed(err)
+	}
+	if resp.RequestType == "" {
+		return nil, malformed(errors.New("response has no request type"))
+	}
+	return &resp, nil
+}
+
+// ParseACARS parses ACARS message data, either a single message or an array
+func ParseACARS(data json.RawMessage) ([]ACARSData, error) {
+	if isEmpty(data) {
+		return nil, ErrEmpty
+	}
+
+	// Try parsing as array
+	var list []ACARSData
+	if err := json.Unmarshal(data, &list); err == nil {
+		return list, nil
+	}
+
+	// Try parsing as single message
+	var single ACARSData
+	if err := json.Unmarshal(data, &single); err != nil {
+		return nil, malformed(err)
+	}
+	return []ACARSData{single}, nil
+}
+
+// ParseNotice parses notice data. Text is trimmed and the severity lower
+// cased; a notice with neither title nor body is reported as ErrNoText.
+func ParseNotice(data json.RawMessage) (*NoticeData, error) {
+	if isEmpty(data) {
+		return nil, ErrEmpty
+	}
+	var n NoticeData
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, malformed(err)
+	}
+	n.ID = strings.TrimSpace(n.ID)
+	n.Severity = strings.ToLower(strings.TrimSpace(n.Severity))
+	n.Title = strings.TrimSpace(n.Title)
+	n.Body = strings.TrimSpace(n.Body)
+	if n.Title == "" && n.Body == "" {
+		return nil, ErrNoText
+	}
+	return &n, nil
+}
+
+// isEmpty reports whether a payload carries no value at all
+func isEmpty(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
+func malformed(err error) error {
+	return fmt.Errorf("%w: %v", ErrMalformed, err)
+}
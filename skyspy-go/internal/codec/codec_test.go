@@ -0,0 +1,764 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSnapshot_Map(t *testing.T) {
+	data := json.RawMessage(`{
+		"aircraft": {
+			"ABC123": {"hex": "ABC123", "flight": "TEST1", "lat": 45.0, "lon": -93.0},
+			"DEF456": {"hex": "DEF456", "flight": "TEST2", "lat": 46.0, "lon": -94.0}
+		}
+	}`)
+
+	aircraft, err := ParseSnapshot(data)
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed: %v", err)
+	}
+
+	if len(aircraft) != 2 {
+		t.Errorf("Expected 2 aircraft, got %d", len(aircraft))
+	}
+
+	// Check that both aircraft are present (order may vary due to map)
+	hexes := make(map[string]bool)
+	for _, ac := range aircraft {
+		hexes[ac.Hex] = true
+	}
+
+	if !hexes["ABC123"] {
+		t.Error("Missing aircraft ABC123")
+	}
+	if !hexes["DEF456"] {
+		t.Error("Missing aircraft DEF456")
+	}
+}
+
+func TestParseSnapshot_Array(t *testing.T) {
+	data := json.RawMessage(`[
+		{"hex": "ABC123", "flight": "TEST1", "lat": 45.0, "lon": -93.0},
+		{"hex": "DEF456", "flight": "TEST2", "lat": 46.0, "lon": -94.0},
+		{"hex": "GHI789", "flight": "TEST3", "lat": 47.0, "lon": -95.0}
+	]`)
+
+	aircraft, err := ParseSnapshot(data)
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed: %v", err)
+	}
+
+	if len(aircraft) != 3 {
+		t.Errorf("Expected 3 aircraft, got %d", len(aircraft))
+	}
+
+	if aircraft[0].Hex != "ABC123" {
+		t.Errorf("Expected first aircraft hex ABC123, got %s", aircraft[0].Hex)
+	}
+	if aircraft[2].Hex != "GHI789" {
+		t.Errorf("Expected third aircraft hex GHI789, got %s", aircraft[2].Hex)
+	}
+}
+
+func TestParseAircraft_AllFields(t *testing.T) {
+	lat := 45.5
+	lon := -93.5
+	altBaro := 35000
+	alt := 34800
+	gs := 450.5
+	track := 180.5
+	baroRate := -500.0
+	vr := -480.0
+	rssi := -25.5
+	distance := 15.5
+	bearing := 270.0
+
+	data := json.RawMessage(`{
+		"hex": "ABC123",
+		"flight": "UAL123  ",
+		"lat": 45.5,
+		"lon": -93.5,
+		"alt_baro": 35000,
+		"alt": 34800,
+		"gs": 450.5,
+		"track": 180.5,
+		"baro_rate": -500.0,
+		"vr": -480.0,
+		"squawk": "7500",
+		"rssi": -25.5,
+		"t": "B738",
+		"military": true,
+		"distance_nm": 15.5,
+		"bearing": 270.0
+	}`)
+
+	aircraft, err := ParseAircraft(data)
+	if err != nil {
+		t.Fatalf("ParseAircraft failed: %v", err)
+	}
+
+	// Verify all fields
+	tests := []struct {
+		name     string
+		got      interface{}
+		expected interface{}
+	}{
+		{"Hex", aircraft.Hex, "ABC123"},
+		{"Flight", aircraft.Flight, "UAL123  "},
+		{"Lat", *aircraft.Lat, lat},
+		{"Lon", *aircraft.Lon, lon},
+		{"AltBaro", *aircraft.AltBaro, altBaro},
+		{"Alt", *aircraft.Alt, alt},
+		{"GS", *aircraft.GS, gs},
+		{"Track", *aircraft.Track, track},
+		{"BaroRate", *aircraft.BaroRate, baroRate},
+		{"VR", *aircraft.VR, vr},
+		{"Squawk", aircraft.Squawk, "7500"},
+		{"RSSI", *aircraft.RSSI, rssi},
+		{"Type", aircraft.Type, "B738"},
+		{"Military", aircraft.Military, true},
+		{"Distance", *aircraft.Distance, distance},
+		{"Bearing", *aircraft.Bearing, bearing},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: got %v, expected %v", tt.name, tt.got, tt.expected)
+		}
+	}
+}
+
+func TestParseAircraft_NavFields(t *testing.T) {
+	data := json.RawMessage(`{
+		"hex": "ABC123",
+		"nav_altitude_mcp": 4000,
+		"nav_heading": 270.5,
+		"nav_qnh": 1013.2,
+		"nav_modes": ["autopilot", "althold"]
+	}`)
+
+	aircraft, err := ParseAircraft(data)
+	if err != nil {
+		t.Fatalf("ParseAircraft failed: %v", err)
+	}
+
+	if aircraft.NavAltitude == nil || *aircraft.NavAltitude != 4000 {
+		t.Errorf("Expected NavAltitude 4000, got %v", aircraft.NavAltitude)
+	}
+	if aircraft.NavHeading == nil || *aircraft.NavHeading != 270.5 {
+		t.Errorf("Expected NavHeading 270.5, got %v", aircraft.NavHeading)
+	}
+	if aircraft.NavQNH == nil || *aircraft.NavQNH != 1013.2 {
+		t.Errorf("Expected NavQNH 1013.2, got %v", aircraft.NavQNH)
+	}
+	if len(aircraft.NavModes) != 2 || aircraft.NavModes[0] != "autopilot" {
+		t.Errorf("Expected nav modes [autopilot althold], got %v", aircraft.NavModes)
+	}
+	if aircraft.NavAltitudeFMS != nil {
+		t.Error("Expected NavAltitudeFMS to be nil")
+	}
+}
+
+func TestParseAircraft_Category(t *testing.T) {
+	aircraft, err := ParseAircraft(json.RawMessage(`{"hex": "ABC123", "category": "A7"}`))
+	if err != nil {
+		t.Fatalf("ParseAircraft failed: %v", err)
+	}
+	if aircraft.Category != "A7" {
+		t.Errorf("Expected category A7, got %q", aircraft.Category)
+	}
+
+	aircraft, err = ParseAircraft(json.RawMessage(`{"hex": "ABC123"}`))
+	if err != nil {
+		t.Fatalf("ParseAircraft failed: %v", err)
+	}
+	if aircraft.Category != "" {
+		t.Errorf("Expected no category, got %q", aircraft.Category)
+	}
+}
+
+func TestParseAircraft_PartialFields(t *testing.T) {
+	data := json.RawMessage(`{
+		"hex": "ABC123",
+		"flight": "TEST001",
+		"lat": 45.0
+	}`)
+
+	aircraft, err := ParseAircraft(data)
+	if err != nil {
+		t.Fatalf("ParseAircraft failed: %v", err)
+	}
+
+	// Required/present fields
+	if aircraft.Hex != "ABC123" {
+		t.Errorf("Expected hex ABC123, got %s", aircraft.Hex)
+	}
+	if aircraft.Flight != "TEST001" {
+		t.Errorf("Expected flight TEST001, got %s", aircraft.Flight)
+	}
+	if aircraft.Lat == nil {
+		t.Error("Expected Lat to be set")
+	} else if *aircraft.Lat != 45.0 {
+		t.Errorf("Expected Lat 45.0, got %f", *aircraft.Lat)
+	}
+
+	// Optional/missing fields should be nil
+	if aircraft.Lon != nil {
+		t.Error("Expected Lon to be nil")
+	}
+	if aircraft.AltBaro != nil {
+		t.Error("Expected AltBaro to be nil")
+	}
+	if aircraft.GS != nil {
+		t.Error("Expected GS to be nil")
+	}
+	if aircraft.Track != nil {
+		t.Error("Expected Track to be nil")
+	}
+	if aircraft.RSSI != nil {
+		t.Error("Expected RSSI to be nil")
+	}
+	if aircraft.Military {
+		t.Error("Expected Military to be false (default)")
+	}
+}
+
+func TestParseACARS_Single(t *testing.T) {
+	data := json.RawMessage(`{
+		"callsign": "UAL123",
+		"flight": "UA123",
+		"label": "H1",
+		"text": "POSITION REPORT LAT 45.0 LON -93.0"
+	}`)
+
+	acarsData, err := ParseACARS(data)
+	if err != nil {
+		t.Fatalf("ParseACARS failed: %v", err)
+	}
+
+	if len(acarsData) != 1 {
+		t.Fatalf("Expected 1 ACARS message, got %d", len(acarsData))
+	}
+
+	msg := acarsData[0]
+	if msg.Callsign != "UAL123" {
+		t.Errorf("Expected callsign UAL123, got %s", msg.Callsign)
+	}
+	if msg.Flight != "UA123" {
+		t.Errorf("Expected flight UA123, got %s", msg.Flight)
+	}
+	if msg.Label != "H1" {
+		t.Errorf("Expected label H1, got %s", msg.Label)
+	}
+	if msg.Text != "POSITION REPORT LAT 45.0 LON -93.0" {
+		t.Errorf("Unexpected text: %s", msg.Text)
+	}
+}
+
+func TestParseACARS_Array(t *testing.T) {
+	data := json.RawMessage(`[
+		{"callsign": "UAL123", "flight": "UA123", "label": "H1", "text": "MSG1"},
+		{"callsign": "DAL456", "flight": "DL456", "label": "H2", "text": "MSG2"},
+		{"callsign": "AAL789", "flight": "AA789", "label": "H3", "text": "MSG3"}
+	]`)
+
+	acarsData, err := ParseACARS(data)
+	if err != nil {
+		t.Fatalf("ParseACARS failed: %v", err)
+	}
+
+	if len(acarsData) != 3 {
+		t.Fatalf("Expected 3 ACARS messages, got %d", len(acarsData))
+	}
+
+	expectedCallsigns := []string{"UAL123", "DAL456", "AAL789"}
+	for i, expected := range expectedCallsigns {
+		if acarsData[i].Callsign != expected {
+			t.Errorf("Message %d: expected callsign %s, got %s", i, expected, acarsData[i].Callsign)
+		}
+	}
+}
+
+// ============================================================================
+// State Tests
+// ============================================================================
+
+func TestParseSnapshot_Empty(t *testing.T) {
+	// Empty object with aircraft map
+	data := json.RawMessage(`{"aircraft":{}}`)
+	aircraft, err := ParseSnapshot(data)
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed on empty: %v", err)
+	}
+	if len(aircraft) != 0 {
+		t.Errorf("Expected 0 aircraft, got %d", len(aircraft))
+	}
+
+	// Empty array
+	data = json.RawMessage(`[]`)
+	aircraft, err = ParseSnapshot(data)
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed on empty array: %v", err)
+	}
+	if len(aircraft) != 0 {
+		t.Errorf("Expected 0 aircraft, got %d", len(aircraft))
+	}
+}
+
+func TestParseSnapshot_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      json.RawMessage
+		expectErr bool
+	}{
+		{"string", json.RawMessage(`"not an object or array"`), true},
+		{"number", json.RawMessage(`123`), true},
+		{"null", json.RawMessage(`null`), true},
+		{"other_field", json.RawMessage(`{"other_field": "value"}`), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSnapshot(tt.data)
+			if tt.expectErr && err == nil {
+				t.Error("Expected error for invalid data, got none")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseACARS_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      json.RawMessage
+		expectErr bool
+	}{
+		{"string", json.RawMessage(`"not valid"`), true},
+		{"number", json.RawMessage(`123`), true},
+		{"null", json.RawMessage(`null`), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseACARS(tt.data)
+			if tt.expectErr && err == nil {
+				t.Error("Expected error for invalid ACARS data, got none")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMessageTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		msgType  MessageType
+		expected string
+	}{
+		{"AircraftSnapshot", AircraftSnapshot, "aircraft:snapshot"},
+		{"AircraftUpdate", AircraftUpdate, "aircraft:update"},
+		{"AircraftNew", AircraftNew, "aircraft:new"},
+		{"AircraftRemove", AircraftRemove, "aircraft:remove"},
+		{"ACARSMessage", ACARSMessage, "acars:message"},
+		{"ACARSSnapshot", ACARSSnapshot, "acars:snapshot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if string(tt.msgType) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, string(tt.msgType))
+			}
+		})
+	}
+}
+
+// Table-driven test for client states
+func TestParseAircraft_InvalidJSON(t *testing.T) {
+	data := json.RawMessage(`{invalid json}`)
+
+	aircraft, err := ParseAircraft(data)
+	if err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+	if aircraft != nil {
+		t.Error("Expected nil aircraft for invalid JSON")
+	}
+}
+
+func TestParseSnapshot_NestedAircraftFormat(t *testing.T) {
+	// Test the nested format parsing (third try in ParseSnapshot)
+	data := json.RawMessage(`{
+		"aircraft": {
+			"TEST1": {"hex": "TEST1", "flight": "FL1"},
+			"TEST2": {"hex": "TEST2", "flight": "FL2"}
+		},
+		"extra_field": "ignored"
+	}`)
+
+	aircraft, err := ParseSnapshot(data)
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed: %v", err)
+	}
+
+	if len(aircraft) != 2 {
+		t.Errorf("Expected 2 aircraft, got %d", len(aircraft))
+	}
+}
+
+func TestParseSnapshot_AircraftArray(t *testing.T) {
+	// The answer to a snapshot request lists the aircraft in an array
+	data := json.RawMessage(`{"aircraft": [{"hex": "abc123"}, {"hex": ""}, {"hex": "DEF456"}], "count": 3}`)
+
+	aircraft, err := ParseSnapshot(data)
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed: %v", err)
+	}
+	if len(aircraft) != 2 || aircraft[0].Hex != "ABC123" || aircraft[1].Hex != "DEF456" {
+		t.Errorf("unexpected aircraft %+v", aircraft)
+	}
+}
+
+func TestParseSnapshot_FallbackFormats(t *testing.T) {
+	// Test with data that passes the first parse but has nil Aircraft
+	// This will fall through to try array parsing
+	data := json.RawMessage(`{}`)
+
+	aircraft, err := ParseSnapshot(data)
+	// Empty object should fail or return empty
+	if err == nil && len(aircraft) > 0 {
+		t.Error("Expected empty result or error for empty object")
+	}
+
+	// Test data that is definitely not an array and not the expected format
+	data = json.RawMessage(`{"not_aircraft": {"a": "b"}}`)
+	_, err = ParseSnapshot(data)
+	if err == nil {
+		t.Error("Expected error for object without aircraft field")
+	}
+}
+
+func TestParse_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(json.RawMessage) error
+		data string
+		want error
+	}{
+		{"aircraft empty", parseAircraftErr, ``, ErrEmpty},
+		{"aircraft null", parseAircraftErr, ` null `, ErrEmpty},
+		{"aircraft malformed", parseAircraftErr, `{"hex":`, ErrMalformed},
+		{"aircraft wrong type", parseAircraftErr, `{"hex":"abc","lat":"north"}`, ErrMalformed},
+		{"aircraft no hex", parseAircraftErr, `{"flight":"TEST1"}`, ErrMissingHex},
+		{"snapshot empty", parseSnapshotErr, `   `, ErrEmpty},
+		{"snapshot malformed", parseSnapshotErr, `[{"hex":1}]`, ErrMalformed},
+		{"acars empty", parseACARSErr, `null`, ErrEmpty},
+		{"acars malformed", parseACARSErr, `[1,2]`, ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(json.RawMessage(tt.data)); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func parseAircraftErr(data json.RawMessage) error {
+	_, err := ParseAircraft(data)
+	return err
+}
+
+func parseSnapshotErr(data json.RawMessage) error {
+	_, err := ParseSnapshot(data)
+	return err
+}
+
+func parseACARSErr(data json.RawMessage) error {
+	_, err := ParseACARS(data)
+	return err
+}
+
+func TestParseMessage(t *testing.T) {
+	msg, err := ParseMessage([]byte(`{"type":"aircraft:remove","data":{"hex":"abc"}}`))
+	if err != nil {
+		t.Fatalf("ParseMessage failed: %v", err)
+	}
+	if msg.Type != string(AircraftRemove) || string(msg.Data) != `{"hex":"abc"}` {
+		t.Errorf("unexpected message %+v", msg)
+	}
+
+	if _, err := ParseMessage(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+	if _, err := ParseMessage([]byte(`not json`)); !errors.Is(err, ErrMalformed) {
+		t.Errorf("expected ErrMalformed, got %v", err)
+	}
+}
+
+func TestMessage_Time(t *testing.T) {
+	want := time.Date(2026, 3, 1, 12, 0, 30, 500_000_000, time.UTC)
+	tests := []struct {
+		name      string
+		timestamp string
+		ok        bool
+	}{
+		{"unix seconds", `1772366430.5`, true},
+		{"unix millis", `1772366430500`, true},
+		{"rfc3339", `"2026-03-01T12:00:30.5Z"`, true},
+		{"missing", ``, false},
+		{"null", `null`, false},
+		{"zero", `0`, false},
+		{"garbage", `"yesterday"`, false},
+		{"object", `{}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Message{Timestamp: json.RawMessage(tt.timestamp)}.Time()
+			if ok != tt.ok {
+				t.Fatalf("expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && got.Sub(want).Abs() > time.Millisecond {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+		})
+	}
+
+	msg, err := ParseMessage([]byte(`{"type":"aircraft:update","data":{},"timestamp":1772366430.5}`))
+	if err != nil {
+		t.Fatalf("ParseMessage failed: %v", err)
+	}
+	if _, ok := msg.Time(); !ok {
+		t.Error("expected envelope timestamp to be kept")
+	}
+}
+
+func TestParseAircraft_Received(t *testing.T) {
+	want := time.Date(2026, 3, 1, 12, 0, 30, 500_000_000, time.UTC)
+	for _, raw := range []string{`1772366430.5`, `"2026-03-01T12:00:30.5Z"`} {
+		ac, err := ParseAircraft(json.RawMessage(`{"hex":"c0ffee","timestamp":` + raw + `}`))
+		if err != nil {
+			t.Fatalf("ParseAircraft failed: %v", err)
+		}
+		if ac.Received.Sub(want).Abs() > time.Millisecond {
+			t.Errorf("timestamp %s: Received = %v, want %v", raw, ac.Received, want)
+		}
+	}
+
+	ac, _ := ParseAircraft(json.RawMessage(`{"hex":"c0ffee"}`))
+	if !ac.Received.IsZero() || len(ac.BadFields) != 0 {
+		t.Errorf("a missing timestamp should leave Received zero: %+v", ac)
+	}
+	ac, _ = ParseAircraft(json.RawMessage(`{"hex":"c0ffee","timestamp":"soon"}`))
+	if !ac.Received.IsZero() || len(ac.BadFields) != 1 || ac.BadFields[0] != "timestamp" {
+		t.Errorf("an unreadable timestamp should be a bad field: %+v", ac)
+	}
+}
+
+func TestParseAircraft_GroundAltitude(t *testing.T) {
+	ac, err := ParseAircraft(json.RawMessage(`{"hex":"c0ffee","alt_baro":"ground","gs":12.1}`))
+	if err != nil {
+		t.Fatalf("ParseAircraft failed: %v", err)
+	}
+	if ac.AltBaro == nil || *ac.AltBaro != 0 {
+		t.Errorf("expected ground to decode as altitude 0, got %v", ac.AltBaro)
+	}
+	if !ac.OnGround {
+		t.Error("expected ground to mark the aircraft on the ground")
+	}
+	if ac, _ := ParseAircraft(json.RawMessage(`{"hex":"c0ffee","alt_baro":0}`)); ac.OnGround {
+		t.Error("a zero altitude alone shouldn't mark the aircraft on the ground")
+	}
+
+	ac, err = ParseAircraft(json.RawMessage(`{"hex":"c0ffee","alt":"ground"}`))
+	if err != nil || ac.Alt == nil || *ac.Alt != 0 || !ac.OnGround {
+		t.Errorf("ground in alt should decode like alt_baro: %+v, %v", ac, err)
+	}
+	ac, err = ParseAircraft(json.RawMessage(`{"hex":"c0ffee","alt_geom":"ground"}`))
+	if err != nil || ac.AltGeom != nil || !ac.OnGround {
+		t.Errorf("ground in alt_geom should only mark the aircraft on the ground: %+v, %v", ac, err)
+	}
+}
+
+func TestParseAircraft_NumericStrings(t *testing.T) {
+	ac, err := ParseAircraft(json.RawMessage(`{"hex":"c0ffee","alt_baro":"35000","alt_geom":" 35449.6 ","alt":"12000","gs":"451.2"}`))
+	if err != nil {
+		t.Fatalf("ParseAircraft failed: %v", err)
+	}
+	if ac.AltBaro == nil || *ac.AltBaro != 35000 {
+		t.Errorf("alt_baro = %v, want 35000", ac.AltBaro)
+	}
+	if ac.AltGeom == nil || *ac.AltGeom != 35450 {
+		t.Errorf("alt_geom = %v, want 35450", ac.AltGeom)
+	}
+	if ac.Alt == nil || *ac.Alt != 12000 {
+		t.Errorf("alt = %v, want 12000", ac.Alt)
+	}
+	if ac.GS == nil || *ac.GS != 451.2 {
+		t.Errorf("gs = %v, want 451.2", ac.GS)
+	}
+	if ac.OnGround || len(ac.BadFields) != 0 {
+		t.Errorf("numbers in strings are plain values: ground=%v bad=%v", ac.OnGround, ac.BadFields)
+	}
+}
+
+func TestParseAircraft_BadFieldsKeepUpdate(t *testing.T) {
+	ac, err := ParseAircraft(json.RawMessage(
+		`{"hex":"c0ffee","lat":52.3,"lon":4.9,"alt_baro":"high","alt_geom":true,"gs":"fast","alt":{"ft":1}}`))
+	if err != nil {
+		t.Fatalf("unreadable fields shouldn't lose the update: %v", err)
+	}
+	if ac.Lat == nil || ac.Lon == nil {
+		t.Error("the position should be kept")
+	}
+	if ac.AltBaro != nil || ac.AltGeom != nil || ac.Alt != nil || ac.GS != nil {
+		t.Errorf("unreadable values should be left unset: %+v", ac)
+	}
+	want := []string{"alt_baro", "alt", "alt_geom", "gs"}
+	if strings.Join(ac.BadFields, ",") != strings.Join(want, ",") {
+		t.Errorf("BadFields = %v, want %v", ac.BadFields, want)
+	}
+
+	for _, raw := range []string{`"NaN"`, `"Inf"`, `""`} {
+		ac, err := ParseAircraft(json.RawMessage(`{"hex":"c0ffee","alt_baro":` + raw + `}`))
+		if err != nil || ac.AltBaro != nil || len(ac.BadFields) != 1 {
+			t.Errorf("alt_baro %s: %+v, %v", raw, ac, err)
+		}
+	}
+	if ac, _ := ParseAircraft(json.RawMessage(`{"hex":"c0ffee","alt_baro":null}`)); len(ac.BadFields) != 0 {
+		t.Error("a null altitude is missing, not unreadable")
+	}
+}
+
+func TestParseAircraft_GeomAltitude(t *testing.T) {
+	ac, err := ParseAircraft(json.RawMessage(`{"hex":"c0ffee","alt_baro":35000,"alt_geom":35449.6}`))
+	if err != nil {
+		t.Fatalf("ParseAircraft failed: %v", err)
+	}
+	if ac.AltGeom == nil || *ac.AltGeom != 35450 {
+		t.Errorf("expected GNSS altitude 35450, got %v", ac.AltGeom)
+	}
+	if ac.AltBaro == nil || *ac.AltBaro != 35000 {
+		t.Errorf("baro altitude should be kept, got %v", ac.AltBaro)
+	}
+
+	ac, err = ParseAircraft(json.RawMessage(`{"hex":"c0ffee","alt_baro":35000}`))
+	if err != nil {
+		t.Fatalf("ParseAircraft failed: %v", err)
+	}
+	if ac.AltGeom != nil {
+		t.Errorf("a missing GNSS altitude should stay nil, got %v", *ac.AltGeom)
+	}
+}
+
+func TestParseSnapshot_SkipsMissingHex(t *testing.T) {
+	aircraft, err := ParseSnapshot(json.RawMessage(`{"aircraft":{"abc123":{"flight":"KEYED"}}}`))
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed: %v", err)
+	}
+	if len(aircraft) != 1 || aircraft[0].Hex != "ABC123" {
+		t.Errorf("map entry should take its key as hex, got %+v", aircraft)
+	}
+
+	aircraft, err = ParseSnapshot(json.RawMessage(`[{"hex":"abc123"},{"flight":"NOHEX"},null]`))
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed: %v", err)
+	}
+	if len(aircraft) != 1 {
+		t.Errorf("entries without a hex should be skipped, got %d", len(aircraft))
+	}
+}
+
+func TestParseSnapshot_CapturedPayload(t *testing.T) {
+	msg := loadCapture(t, "snapshot.json")
+	aircraft, err := ParseSnapshot(msg.Data)
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed on captured payload: %v", err)
+	}
+	if len(aircraft) != 3 {
+		t.Errorf("expected 3 aircraft, got %d", len(aircraft))
+	}
+}
+
+func TestNormalizeHex(t *testing.T) {
+	for _, in := range []string{"3c6589", "3C6589", " 3c6589\t", "3C6589 "} {
+		if got := NormalizeHex(in); got != "3C6589" {
+			t.Errorf("NormalizeHex(%q) = %q, want 3C6589", in, got)
+		}
+	}
+
+	aircraft, err := ParseSnapshot(json.RawMessage(`{"aircraft":{"3c6589":{},"abc123":{"hex":"Abc123"}}}`))
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed: %v", err)
+	}
+	for _, ac := range aircraft {
+		if ac.Hex != NormalizeHex(ac.Hex) {
+			t.Errorf("snapshot hex %q should be normalized", ac.Hex)
+		}
+	}
+	ac, err := ParseAircraft(json.RawMessage(`{"hex":"3c6589"}`))
+	if err != nil || ac.Hex != "3C6589" {
+		t.Errorf("ParseAircraft should normalize the hex, got %v, %v", ac, err)
+	}
+	if _, err := ParseAircraft(json.RawMessage(`{"hex":"  "}`)); !errors.Is(err, ErrMissingHex) {
+		t.Errorf("a blank hex should be missing, got %v", err)
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	resp, err := ParseResponse(json.RawMessage(`{"request_id":"r1","request_type":"aircraft-snapshot","data":{"aircraft":[{"hex":"ABC123"}],"count":1}}`))
+	if err != nil {
+		t.Fatalf("ParseResponse failed: %v", err)
+	}
+	if resp.RequestID != "r1" || resp.RequestType != SnapshotRequest {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if aircraft, err := ParseSnapshot(resp.Data); err != nil || len(aircraft) != 1 {
+		t.Errorf("the response should carry the snapshot: %v, %v", aircraft, err)
+	}
+
+	if _, err := ParseResponse(json.RawMessage(`{"request_id":"r1"}`)); !errors.Is(err, ErrMalformed) {
+		t.Errorf("a response without a request type should be ErrMalformed, got %v", err)
+	}
+	if _, err := ParseResponse(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("no data should be ErrEmpty, got %v", err)
+	}
+}
+
+func TestParseNotice(t *testing.T) {
+	n, err := ParseNotice(json.RawMessage(`{"id":"m1","severity":" Critical ","title":" Maintenance ","body":"Feed down 02:00-03:00Z","ack":true,"expires":"tomorrow","extra":{"x":1}}`))
+	if err != nil {
+		t.Fatalf("ParseNotice failed: %v", err)
+	}
+	if n.ID != "m1" || n.Title != "Maintenance" || n.Body != "Feed down 02:00-03:00Z" || !n.Ack {
+		t.Errorf("unexpected notice %+v", n)
+	}
+	if !n.High() {
+		t.Errorf("severity %q should be high", n.Severity)
+	}
+
+	n, err = ParseNotice(json.RawMessage(`{"body":"New server version tonight"}`))
+	if err != nil {
+		t.Fatalf("ParseNotice failed: %v", err)
+	}
+	if n.High() || n.Ack {
+		t.Errorf("a notice without severity should be low and unacknowledged, got %+v", n)
+	}
+
+	if _, err := ParseNotice(json.RawMessage(`{"title":"  ","severity":"high"}`)); !errors.Is(err, ErrNoText) {
+		t.Errorf("a notice without text should be ErrNoText, got %v", err)
+	}
+	if _, err := ParseNotice(json.RawMessage(`null`)); !errors.Is(err, ErrEmpty) {
+		t.Errorf("null should be ErrEmpty, got %v", err)
+	}
+	if _, err := ParseNotice(json.RawMessage(`"maintenance"`)); !errors.Is(err, ErrMalformed) {
+		t.Errorf("a string should be ErrMalformed, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// loadCapture reads a captured feed message from testdata
+func loadCapture(t testing.TB, name string) Message {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read capture: %v", err)
+	}
+	msg, err := ParseMessage(raw)
+	if err != nil {
+		t.Fatalf("failed to parse capture %s: %v", name, err)
+	}
+	return msg
+}
+
+// addCaptures seeds the fuzz corpus with every captured message and its data
+func addCaptures(f *testing.F, frames bool) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil || len(paths) == 0 {
+		f.Fatalf("no captures found: %v", err)
+	}
+	for _, p := range paths {
+		raw, err := os.ReadFile(p)
+		if err != nil {
+			f.Fatalf("failed to read capture: %v", err)
+		}
+		if frames {
+			f.Add(raw)
+			continue
+		}
+		msg := loadCapture(f, filepath.Base(p))
+		f.Add([]byte(msg.Data))
+	}
+	f.Add([]byte(`null`))
+	f.Add([]byte(`{"hex":"abc","alt_baro":"ground","nav_modes":null}`))
+}
+
+// checkErr fails unless err is nil or one of the codec's documented errors
+func checkErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil && !errors.Is(err, ErrEmpty) && !errors.Is(err, ErrMalformed) && !errors.Is(err, ErrMissingHex) {
+		t.Fatalf("undocumented error: %v", err)
+	}
+}
+
+func FuzzParseMessage(f *testing.F) {
+	addCaptures(f, true)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, err := ParseMessage(data)
+		checkErr(t, err)
+	})
+}
+
+func FuzzParseAircraft(f *testing.F) {
+	addCaptures(f, false)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ac, err := ParseAircraft(json.RawMessage(data))
+		checkErr(t, err)
+		if err == nil && (ac == nil || ac.Hex == "") {
+			t.Fatalf("successful parse returned %+v", ac)
+		}
+	})
+}
+
+func FuzzParseSnapshot(f *testing.F) {
+	addCaptures(f, false)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		aircraft, err := ParseSnapshot(json.RawMessage(data))
+		checkErr(t, err)
+		for _, ac := range aircraft {
+			if ac.Hex == "" {
+				t.Fatal("snapshot returned an aircraft without a hex")
+			}
+		}
+	})
+}
+
+func FuzzParseACARS(f *testing.F) {
+	addCaptures(f, false)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, err := ParseACARS(json.RawMessage(data))
+		checkErr(t, err)
+	})
+}
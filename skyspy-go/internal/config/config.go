@@ -3,9 +3,11 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 )
 
 // Config directories and files
@@ -13,6 +15,7 @@ var (
 	ConfigDir   string
 	ConfigFile  string
 	OverlaysDir string
+	VersionFile string
 	configOnce  sync.Once
 )
 
@@ -24,6 +27,7 @@ func InitConfigPaths() {
 		ConfigDir = filepath.Join(homeDir, ".config", "skyspy")
 		ConfigFile = filepath.Join(ConfigDir, "settings.json")
 		OverlaysDir = filepath.Join(ConfigDir, "overlays")
+		VersionFile = filepath.Join(ConfigDir, "last_version")
 	})
 }
 
@@ -34,6 +38,7 @@ func ResetConfigPathsForTesting() {
 	ConfigDir = ""
 	ConfigFile = ""
 	OverlaysDir = ""
+	VersionFile = ""
 }
 
 // initConfigPaths is kept for backward compatibility (lowercase)
@@ -48,28 +53,61 @@ func ensurePathsInitialized() {
 
 // DisplaySettings contains UI display options
 type DisplaySettings struct {
-	Theme           string `json:"theme"`
-	ShowLabels      bool   `json:"show_labels"`
-	ShowTrails      bool   `json:"show_trails"`
-	RefreshRate     int    `json:"refresh_rate"`
-	CompactMode     bool   `json:"compact_mode"`
-	ShowACARS       bool   `json:"show_acars"`
-	ShowTargetList  bool   `json:"show_target_list"`
-	ShowVUMeters    bool   `json:"show_vu_meters"`
-	ShowSpectrum    bool   `json:"show_spectrum"`
-	ShowFrequencies bool   `json:"show_frequencies"`
-	ShowStatsPanel  bool   `json:"show_stats_panel"`
+	Theme              string         `json:"theme"`
+	GlyphSet           string         `json:"glyph_set,omitempty"` // rich, simple or ascii; empty uses the theme's
+	ColorblindSafe     bool           `json:"colorblind_safe"`     // colorblind-safe colors and shape cues over any theme
+	ShowLabels         bool           `json:"show_labels"`
+	LabelDetail        string         `json:"label_detail"` // none, callsign, altitude (adds altitude) or speed (adds altitude and speed)
+	ShowTrails         bool           `json:"show_trails"`
+	RefreshRate        int            `json:"refresh_rate"`
+	CompactMode        bool           `json:"compact_mode"`
+	ShowACARS          bool           `json:"show_acars"`
+	ShowTargetList     bool           `json:"show_target_list"`
+	ShowVUMeters       bool           `json:"show_vu_meters"`
+	ShowSpectrum       bool           `json:"show_spectrum"`
+	ShowFrequencies    bool           `json:"show_frequencies"`
+	ShowStatsPanel     bool           `json:"show_stats_panel"`
+	ShowAltitudeRibbon bool           `json:"show_altitude_ribbon"`      // altitude strip beside the radar
+	PrivacyMode        bool           `json:"privacy_mode"`              // show an approximate receiver position
+	CoordFormat        string         `json:"coord_format"`              // decimal, dms or mgrs for positions and exports
+	TrailMinutes       int            `json:"trail_minutes"`             // how long trail points are kept
+	TrailMaxPoints     int            `json:"trail_max_points"`          // trail point budget across all aircraft
+	TrailGapSeconds    int            `json:"trail_gap_seconds"`         // silence that breaks a trail; negative disables
+	TrailStyle         string         `json:"trail_style"`               // line, or dots for history dots at fixed intervals
+	TrailDotSeconds    int            `json:"trail_dot_seconds"`         // time between history dots; 0 for one per sweep
+	TrailDots          int            `json:"trail_dots"`                // history dots shown per aircraft
+	ShowRegionColumn   bool           `json:"show_region_column"`        // overlay region column in the target list
+	SplitScreen        bool           `json:"split_screen"`              // second radar pane at its own range
+	SplitRange         int            `json:"split_range"`               // starting range of the second pane in nm
+	SelectionGrace     int            `json:"selection_grace"`           // seconds a lost selection waits to be reacquired; 0 turns it off
+	Locale             string         `json:"locale"`                    // number and time formats, e.g. de-DE; empty for the built-in ones
+	TypeDatabase       string         `json:"type_database,omitempty"`   // CSV of aircraft types that add to or replace the built-in ones
+	AltitudeSource     string         `json:"altitude_source"`           // baro, or geometric to color and filter by GNSS altitude
+	DualUnits          bool           `json:"dual_units"`                // metric beside feet and knots in the target detail panel
+	Units              string         `json:"units"`                     // nm, km or mi for distances and ranges
+	ColorByAltitude    bool           `json:"color_by_altitude"`         // color radar targets and target list rows by altitude band
+	StatusBar          []string       `json:"status_bar,omitempty"`      // status bar segments in display order; empty for the default
+	StatusPriority     map[string]int `json:"status_priority,omitempty"` // segment priorities; the lowest are dropped first when the bar is full
 }
 
 // RadarSettings contains radar scope options
 type RadarSettings struct {
-	DefaultRange int    `json:"default_range"`
-	RangeRings   int    `json:"range_rings"`
-	SweepSpeed   int    `json:"sweep_speed"`
-	ShowCompass  bool   `json:"show_compass"`
-	ShowGrid     bool   `json:"show_grid"`
-	ShowOverlays bool   `json:"show_overlays"`
-	OverlayColor string `json:"overlay_color"`
+	DefaultRange    int    `json:"default_range"` // nm; follows the last range used
+	RangeSteps      []int  `json:"range_steps"`   // nm ranges +/- step through
+	RangeRings      int    `json:"range_rings"`
+	SweepSpeed      int    `json:"sweep_speed"`
+	ShowCompass     bool   `json:"show_compass"`
+	ShowGrid        bool   `json:"show_grid"`
+	ShowOverlays    bool   `json:"show_overlays"`
+	OverlayColor    string `json:"overlay_color"`
+	CleanupInterval int    `json:"cleanup_interval"`          // seconds between stale-data sweeps
+	AircraftTimeout int    `json:"aircraft_timeout"`          // seconds without an update before removal
+	MaxAircraft     int    `json:"max_aircraft"`              // nearest kept beyond this many; negative for no cap
+	SurfaceRange    int    `json:"surface_range"`             // nm at or below which surface mode turns on; 0 leaves it to Ctrl+G
+	FieldElevation  *int   `json:"field_elevation,omitempty"` // ft; unset takes the nearest airport point in the overlays
+	SurfaceReg      bool   `json:"surface_registration"`      // label ground targets by registration in surface mode
+	DensityRange    int    `json:"density_range"`             // nm at or beyond which targets are shaded by density; 0 leaves it to Ctrl+O
+	DensityRamp     string `json:"density_ramp,omitempty"`    // shading characters, light to full; empty for the theme's
 }
 
 // FilterSettings contains aircraft filter options
@@ -80,6 +118,36 @@ type FilterSettings struct {
 	MinDistance  *float64 `json:"min_distance,omitempty"`
 	MaxDistance  *float64 `json:"max_distance,omitempty"`
 	HideGround   bool     `json:"hide_ground"`
+
+	// Plausible altitudes; updates outside them, often MLAT noise, are
+	// rejected or flagged as ImplausibleAltitude says
+	AltitudeFloor       int    `json:"altitude_floor"`       // ft
+	AltitudeCeiling     int    `json:"altitude_ceiling"`     // ft; military aircraft may fly above it
+	ImplausibleAltitude string `json:"implausible_altitude"` // reject, flag or off
+
+	// Positions reached from the last one faster than this, usually
+	// corrupt decodes, are dropped
+	MaxPositionSpeed float64 `json:"max_position_speed"` // knots; 0 turns the check off
+}
+
+// Default plausible altitude bounds
+const (
+	DefaultAltitudeFloor   = -1500
+	DefaultAltitudeCeiling = 60000
+)
+
+// DefaultMaxPositionSpeed is the fastest plausible ground speed (kt)
+// between position reports
+const DefaultMaxPositionSpeed = 1200
+
+// AltitudeBounds returns the plausible altitude bounds, or the defaults
+// and an error when the configured floor isn't below the ceiling
+func (f FilterSettings) AltitudeBounds() (floor, ceiling int, err error) {
+	if f.AltitudeFloor >= f.AltitudeCeiling {
+		return DefaultAltitudeFloor, DefaultAltitudeCeiling,
+			fmt.Errorf("altitude_floor %d must be below altitude_ceiling %d", f.AltitudeFloor, f.AltitudeCeiling)
+	}
+	return f.AltitudeFloor, f.AltitudeCeiling, nil
 }
 
 // ConnectionSettings contains server connection options
@@ -88,42 +156,160 @@ type ConnectionSettings struct {
 	Port           int     `json:"port"`
 	ReceiverLat    float64 `json:"receiver_lat"`
 	ReceiverLon    float64 `json:"receiver_lon"`
-	AutoReconnect  bool    `json:"auto_reconnect"`
-	ReconnectDelay int     `json:"reconnect_delay"`
+	AutoReconnect  bool    `json:"auto_reconnect"`  // redial a dropped connection, backing off from 1s to 60s
+	ReconnectDelay int     `json:"reconnect_delay"` // seconds between attempts to make the first connection
+	ConnectTimeout int     `json:"connect_timeout"` // seconds to wait for the first connection; 0 waits forever
+	PingInterval   int     `json:"ping_interval"`   // seconds between keepalive pings; 0 disables keepalive
+	PongTimeout    int     `json:"pong_timeout"`    // seconds past a ping without a reply before reconnecting
+	DataBudgetMB   float64 `json:"data_budget_mb"`  // daily data budget in MB, warned at 80% and 100%; 0 disables
+	LatencyWarnMs  int     `json:"latency_warn_ms"` // p95 radio-to-screen latency in ms shown as a warning; 0 disables
+
+	// Servers whose feeds are merged into one picture, in place of Host
+	// and Port
+	Servers []ServerConfig `json:"servers,omitempty"`
+}
+
+// ServerConfig is one of several SkySpy servers whose feeds are merged
+type ServerConfig struct {
+	Name    string `json:"name,omitempty"` // shown in the status bar; defaults to the host
+	Host    string `json:"host"`
+	Port    int    `json:"port,omitempty"`    // defaults to connection.port
+	APIKey  string `json:"api_key,omitempty"` // for servers that require authentication
+	Primary bool   `json:"primary,omitempty"` // receiver the server reports is where distances are measured from
+}
+
+// Label returns the name the server is shown by
+func (s ServerConfig) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Host
+}
+
+// HasReceiver reports whether the receiver position is set; 0, 0 is taken
+// as unset
+func (c ConnectionSettings) HasReceiver() bool {
+	return c.ReceiverLat != 0 || c.ReceiverLon != 0
+}
+
+// defaultPongTimeout is used when keepalive is on but no timeout is set
+const defaultPongTimeout = 10
+
+// Feeds returns the servers whose feeds the radar shows: the configured
+// servers that have a host, or the single Host and Port
+func (c ConnectionSettings) Feeds() []ServerConfig {
+	var feeds []ServerConfig
+	for _, srv := range c.Servers {
+		if srv.Host == "" {
+			continue
+		}
+		if srv.Port == 0 {
+			srv.Port = c.Port
+		}
+		feeds = append(feeds, srv)
+	}
+	if len(feeds) == 0 {
+		return []ServerConfig{{Host: c.Host, Port: c.Port, Primary: true}}
+	}
+	return feeds
+}
+
+// PrimaryServer returns the index in Feeds of the server whose receiver
+// distances and bearings are measured from, when receiver_lat and
+// receiver_lon aren't set: the first marked primary, or the first
+func (c ConnectionSettings) PrimaryServer() int {
+	for i, srv := range c.Feeds() {
+		if srv.Primary {
+			return i
+		}
+	}
+	return 0
+}
+
+// Keepalive returns the keepalive ping interval and reply timeout, both
+// zero when keepalive is disabled
+func (c ConnectionSettings) Keepalive() (interval, timeout time.Duration) {
+	if c.PingInterval <= 0 {
+		return 0, 0
+	}
+	pongTimeout := c.PongTimeout
+	if pongTimeout <= 0 {
+		pongTimeout = defaultPongTimeout
+	}
+	return time.Duration(c.PingInterval) * time.Second, time.Duration(pongTimeout) * time.Second
+}
+
+// AudioUrgencyBand maps a distance band to alert pitch and repetition
+type AudioUrgencyBand struct {
+	MaxDistance float64 `json:"max_distance"` // nm; 0 = no upper bound
+	Pitch       float64 `json:"pitch"`        // frequency multiplier
+	Repeats     int     `json:"repeats"`
+	GapMs       int     `json:"gap_ms"` // silence between repeats
 }
 
 // AudioSettings contains audio feedback options
 type AudioSettings struct {
-	Enabled          bool `json:"enabled"`
-	NewAircraftSound bool `json:"new_aircraft_sound"`
-	EmergencySound   bool `json:"emergency_sound"`
-	MilitarySound    bool `json:"military_sound"`
+	Enabled          bool               `json:"enabled"`
+	NewAircraftSound bool               `json:"new_aircraft_sound"`
+	EmergencySound   bool               `json:"emergency_sound"`
+	MilitarySound    bool               `json:"military_sound"`
+	UrgencyBands     []AudioUrgencyBand `json:"urgency_bands"`
+	QuietHours       []QuietHoursRange  `json:"quiet_hours"` // audio alerts are silenced within these
+	Pan              bool               `json:"pan"`         // pan alerts left or right by the aircraft's bearing
+	Facing           float64            `json:"facing"`      // degrees true the listener faces when panning
+}
+
+// QuietHoursRange is a weekly do-not-disturb window in local time. End at
+// or before Start runs past midnight into the next day.
+type QuietHoursRange struct {
+	Days  []string `json:"days,omitempty"` // mon..sun, weekdays or weekends; empty for every day
+	Start string   `json:"start"`          // HH:MM
+	End   string   `json:"end"`            // HH:MM; 24:00 for midnight
 }
 
 // OverlayConfig represents a single overlay configuration
 type OverlayConfig struct {
-	Path    string  `json:"path"`
-	Enabled bool    `json:"enabled"`
-	Color   *string `json:"color,omitempty"`
-	Name    *string `json:"name,omitempty"`
-	Key     string  `json:"key,omitempty"`
+	Path       string  `json:"path"`
+	Enabled    bool    `json:"enabled"`
+	Color      *string `json:"color,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Key        string  `json:"key,omitempty"`
+	Brightness string  `json:"brightness,omitempty"` // bright, normal, dim or faint
+	// Tag aircraft inside this overlay's polygons with the polygon name
+	RegionTagging bool `json:"region_tagging,omitempty"`
 }
 
 // OverlaySettings contains overlay management options
 type OverlaySettings struct {
-	Overlays         []OverlayConfig `json:"overlays"`
-	CustomRangeRings []int           `json:"custom_range_rings"`
+	Overlays          []OverlayConfig `json:"overlays"`
+	CustomRangeRings  []int           `json:"custom_range_rings"`
+	DefaultBrightness string          `json:"default_brightness"` // Applied to overlays added without a level
+	InactiveFeatures  string          `json:"inactive_features"`  // dim or hide features outside their time window
 }
 
 // ExportSettings contains export options
 type ExportSettings struct {
 	Directory string `json:"directory"`
+	// SignalStats adds lifetime RSSI min/max/average columns to aircraft
+	// CSV and JSON exports
+	SignalStats bool               `json:"signal_stats,omitempty"`
+	AutoExport  AutoExportSettings `json:"auto_export"`
+}
+
+// AutoExportSettings logs every aircraft message received to files in the
+// export directory, a new file every interval or before one grows too big
+type AutoExportSettings struct {
+	Enabled   bool   `json:"enabled"`     // also turned on for a session by --log-session
+	Format    string `json:"format"`      // "ndjson" or "csv"
+	Interval  int    `json:"interval"`    // minutes between new files; 0 starts them on size only
+	MaxSizeMB int    `json:"max_size_mb"` // a file is never let grow past this; 0 disables
 }
 
 // ConditionConfig represents a condition in configuration
 type ConditionConfig struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`
+	Regex bool   `json:"regex,omitempty"` // acars_text value is a regular expression
 }
 
 // ActionConfig represents an action in configuration
@@ -171,6 +357,50 @@ type AlertSettings struct {
 	Geofences []GeofenceConfig  `json:"geofences"`
 	LogFile   string            `json:"log_file,omitempty"`
 	SoundDir  string            `json:"sound_dir,omitempty"`
+	Squawks   map[string]string `json:"squawks"` // special code -> emergency, warning, info or none
+	Bell      string            `json:"bell"`    // how bell actions ring: audible, visual or both
+	// Built-in proximity rule: a ping when an aircraft comes within
+	// proximity_radius_nm of the receiver
+	Proximity         bool    `json:"proximity"`
+	ProximityRadiusNM float64 `json:"proximity_radius_nm"`
+}
+
+// ACARSSettings contains ACARS ingestion options
+type ACARSSettings struct {
+	MaxMessages int `json:"max_messages"` // retained messages
+	DedupWindow int `json:"dedup_window"` // seconds; 0 disables de-duplication
+}
+
+// ConflictSettings tunes detection of two aircraft sharing one ICAO address.
+// Zero values use the built-in defaults.
+type ConflictSettings struct {
+	MaxSpeed    float64 `json:"max_speed"`    // knots; faster implied speeds between fixes are jumps
+	SpeedFactor float64 `json:"speed_factor"` // fast movers may also reach this multiple of their ground speed
+	MinJump     float64 `json:"min_jump"`     // nm; shorter jumps are ignored as position noise
+	HoldSeconds int     `json:"hold_seconds"` // how long a target stays flagged after its last jump
+}
+
+// POIConfig is a named point of interest, such as your house under an
+// approach path
+type POIConfig struct {
+	Label string  `json:"label"`
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+}
+
+// POISettings contains point of interest options
+type POISettings struct {
+	Points      []POIConfig `json:"points"`
+	Active      string      `json:"active,omitempty"` // label of the point approach ETAs are shown for
+	CorridorNM  float64     `json:"corridor_nm"`      // aircraft passing within this distance of the point get an ETA
+	ShowMarkers bool        `json:"show_markers"`     // draw the points on the radar
+}
+
+// WatchlistSettings lists aircraft that should stand out whenever they
+// appear
+type WatchlistSettings struct {
+	Hexes     []string `json:"hexes"`     // ICAO addresses
+	Callsigns []string `json:"callsigns"` // callsign patterns; * matches any run of characters, e.g. RCH*
 }
 
 // AirbandSettings contains RTL-Airband uploader configuration
@@ -187,8 +417,23 @@ type AirbandSettings struct {
 	FrequencyMap     map[string]string `json:"frequency_map"` // Hz string -> label
 }
 
+// APISettings configures the local HTTP API serving the radar's state
+type APISettings struct {
+	Enabled     bool   `json:"enabled"`
+	Listen      string `json:"listen"`       // host:port; must be a loopback address unless allow_remote is set
+	AllowRemote bool   `json:"allow_remote"` // allow listening where other machines can connect
+}
+
+// KioskSettings configures kiosk mode, started with --kiosk, for a radar
+// on public display
+type KioskSettings struct {
+	Unlock      string `json:"unlock"`       // keys that unlock the controls, space separated; empty never unlocks
+	IdleSeconds int    `json:"idle_seconds"` // idle time before the view resets and locks again; 0 never
+}
+
 // Config is the main configuration container
 type Config struct {
+	Version     int                `json:"version"` // settings file format; see SchemaVersion
 	Display     DisplaySettings    `json:"display"`
 	Radar       RadarSettings      `json:"radar"`
 	Filters     FilterSettings     `json:"filters"`
@@ -197,8 +442,30 @@ type Config struct {
 	Overlays    OverlaySettings    `json:"overlays"`
 	Export      ExportSettings     `json:"export"`
 	Alerts      AlertSettings      `json:"alerts"`
+	ACARS       ACARSSettings      `json:"acars"`
+	Conflicts   ConflictSettings   `json:"conflicts"`
+	POI         POISettings        `json:"poi"`
+	Watchlist   WatchlistSettings  `json:"watchlist"`
 	Airband     AirbandSettings    `json:"airband"`
+	API         APISettings        `json:"api"`
+	Kiosk       KioskSettings      `json:"kiosk"`
 	RecentHosts []string           `json:"recent_hosts"`
+	DebugLog    string             `json:"debug_log,omitempty"` // file diagnostic entries are appended to; empty disables
+
+	extra   map[string]json.RawMessage // sections from a newer version, kept on save
+	problem *LoadProblem
+}
+
+// LoadProblem describes a settings file that couldn't be used as it was
+type LoadProblem struct {
+	Err      error // why the settings file couldn't be read
+	Restored bool  // the backup was loaded instead of the defaults
+}
+
+// LoadProblem reports why Load didn't use the settings file as it was, or
+// nil if it did
+func (c *Config) LoadProblem() *LoadProblem {
+	return c.problem
 }
 
 // DefaultConfig returns a new Config with default values
@@ -207,6 +474,7 @@ func DefaultConfig() *Config {
 		Display: DisplaySettings{
 			Theme:           "classic",
 			ShowLabels:      true,
+			LabelDetail:     "callsign",
 			ShowTrails:      false,
 			RefreshRate:     10,
 			CompactMode:     false,
@@ -216,19 +484,40 @@ func DefaultConfig() *Config {
 			ShowSpectrum:    true,
 			ShowFrequencies: true,
 			ShowStatsPanel:  true,
+			CoordFormat:     "decimal",
+			TrailMinutes:    5,
+			TrailMaxPoints:  20000,
+			TrailGapSeconds: 60,
+			TrailStyle:      "line",
+			TrailDots:       6,
+			SplitRange:      25,
+			SelectionGrace:  120,
+			AltitudeSource:  "baro",
+			Units:           "nm",
 		},
 		Radar: RadarSettings{
-			DefaultRange: 100,
-			RangeRings:   4,
-			SweepSpeed:   6,
-			ShowCompass:  true,
-			ShowGrid:     false,
-			ShowOverlays: true,
-			OverlayColor: "cyan",
+			DefaultRange:    100,
+			RangeSteps:      []int{10, 25, 50, 75, 100, 150, 200, 300, 400},
+			RangeRings:      4,
+			SweepSpeed:      6,
+			ShowCompass:     true,
+			ShowGrid:        false,
+			ShowOverlays:    true,
+			OverlayColor:    "cyan",
+			CleanupInterval: 30,
+			AircraftTimeout: 300,
+			MaxAircraft:     5000,
+			SurfaceRange:    10,
+			DensityRange:    300,
 		},
 		Filters: FilterSettings{
 			MilitaryOnly: false,
 			HideGround:   false,
+
+			AltitudeFloor:       DefaultAltitudeFloor,
+			AltitudeCeiling:     DefaultAltitudeCeiling,
+			MaxPositionSpeed:    DefaultMaxPositionSpeed,
+			ImplausibleAltitude: "reject",
 		},
 		Connection: ConnectionSettings{
 			Host:           "localhost",
@@ -237,19 +526,34 @@ func DefaultConfig() *Config {
 			ReceiverLon:    0.0,
 			AutoReconnect:  true,
 			ReconnectDelay: 2,
+			ConnectTimeout: 10,
+			PingInterval:   20,
+			PongTimeout:    10,
+			LatencyWarnMs:  2000,
 		},
 		Audio: AudioSettings{
 			Enabled:          false,
 			NewAircraftSound: true,
 			EmergencySound:   true,
 			MilitarySound:    false,
+			UrgencyBands: []AudioUrgencyBand{
+				{MaxDistance: 25, Pitch: 1.5, Repeats: 3, GapMs: 80},
+				{MaxDistance: 100, Pitch: 1.0, Repeats: 1},
+				{MaxDistance: 0, Pitch: 0.75, Repeats: 1},
+			},
 		},
 		Overlays: OverlaySettings{
-			Overlays:         []OverlayConfig{},
-			CustomRangeRings: []int{},
+			Overlays:          []OverlayConfig{},
+			CustomRangeRings:  []int{},
+			DefaultBrightness: "normal",
 		},
 		Export: ExportSettings{
 			Directory: "",
+			AutoExport: AutoExportSettings{
+				Format:    "ndjson",
+				Interval:  60,
+				MaxSizeMB: 100,
+			},
 		},
 		Alerts: AlertSettings{
 			Enabled:   true,
@@ -257,6 +561,32 @@ func DefaultConfig() *Config {
 			Geofences: []GeofenceConfig{},
 			LogFile:   "",
 			SoundDir:  "",
+			Squawks: map[string]string{
+				"7500": "emergency",
+				"7600": "emergency",
+				"7700": "emergency",
+			},
+			Bell:              "audible",
+			ProximityRadiusNM: 5,
+		},
+		ACARS: ACARSSettings{
+			MaxMessages: 100,
+			DedupWindow: 60,
+		},
+		Conflicts: ConflictSettings{
+			MaxSpeed:    1500,
+			SpeedFactor: 2,
+			MinJump:     2,
+			HoldSeconds: 120,
+		},
+		POI: POISettings{
+			Points:      []POIConfig{},
+			CorridorNM:  2,
+			ShowMarkers: true,
+		},
+		Watchlist: WatchlistSettings{
+			Hexes:     []string{},
+			Callsigns: []string{},
 		},
 		Airband: AirbandSettings{
 			RecordingsDir:    "",
@@ -270,6 +600,13 @@ func DefaultConfig() *Config {
 			StabilitySeconds: 2,
 			FrequencyMap:     map[string]string{},
 		},
+		API: APISettings{
+			Listen: "127.0.0.1:8088",
+		},
+		Kiosk: KioskSettings{
+			Unlock:      "ctrl+x ctrl+k",
+			IdleSeconds: 120,
+		},
 		RecentHosts: []string{},
 	}
 }
@@ -283,41 +620,155 @@ func EnsureConfigDir() error {
 	return os.MkdirAll(OverlaysDir, 0o755)
 }
 
-// Load loads configuration from file or returns defaults
+// backupSuffix names the copy of the settings file kept from before the
+// last save
+const backupSuffix = ".bak"
+
+// Load loads configuration from file or returns defaults. Older file
+// formats are upgraded. A settings file that can't be read or parsed, as
+// after a crash while it was written, is replaced by the backup when that
+// loads, or else by the defaults; LoadProblem says which.
 func Load() (*Config, error) {
 	ensurePathsInitialized()
 	if _, err := os.Stat(ConfigFile); os.IsNotExist(err) {
 		return DefaultConfig(), nil
 	}
 
-	data, err := os.ReadFile(ConfigFile)
+	config, err := readConfig(ConfigFile)
+	if err == nil {
+		return config, nil
+	}
+
+	// Intentional: fall back rather than fail on a damaged file
+	if backup, backupErr := readConfig(ConfigFile + backupSuffix); backupErr == nil {
+		backup.problem = &LoadProblem{Err: err, Restored: true}
+		return backup, nil
+	}
+	config = DefaultConfig()
+	config.problem = &LoadProblem{Err: err}
+	return config, nil
+}
+
+// readConfig reads and upgrades the settings file at path
+func readConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		//nolint:nilerr // Intentional: return default config on read error
-		return DefaultConfig(), nil
+		return nil, err
+	}
+	config, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Parse decodes settings in the settings file format, upgrading older
+// formats as Load does. Anything the data leaves out has its default.
+func Parse(data []byte) (*Config, error) {
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, err
+	}
+	if sections == nil {
+		sections = make(map[string]json.RawMessage)
+	}
+	if err := migrate(sections); err != nil {
+		return nil, err
 	}
 
+	data, err := json.Marshal(sections)
+	if err != nil {
+		return nil, err
+	}
 	config := DefaultConfig()
 	if err := json.Unmarshal(data, config); err != nil {
-		//nolint:nilerr // Intentional: return default config on parse error
-		return DefaultConfig(), nil
+		return nil, err
 	}
-
+	config.extra = unknownSections(sections)
 	return config, nil
 }
 
-// Save saves configuration to file
+// Save saves configuration to file. The file is replaced atomically, so a
+// crash part way through leaves the old one, and the old one is kept as a
+// backup.
 func Save(config *Config) error {
 	if err := EnsureConfigDir(); err != nil {
 		return err
 	}
 
+	if config.Version < SchemaVersion() {
+		config.Version = SchemaVersion()
+	}
+	data, err := marshalConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// Only a file that parses replaces the backup, so a damaged one can't
+	// overwrite the last good copy
+	if old, err := os.ReadFile(ConfigFile); err == nil && json.Valid(old) {
+		_ = writeFileAtomic(ConfigFile+backupSuffix, old)
+	}
+	return writeFileAtomic(ConfigFile, data)
+}
+
+// marshalConfig encodes config with any sections from a newer version
+// that it was loaded with
+func marshalConfig(config *Config) ([]byte, error) {
 	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil || len(config.extra) == 0 {
+		return data, err
+	}
+
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, err
+	}
+	for key, raw := range config.extra {
+		if _, ok := sections[key]; !ok {
+			sections[key] = raw
+		}
+	}
+	return json.MarshalIndent(sections, "", "  ")
+}
+
+// writeFileAtomic writes data to a temporary file beside path and renames
+// it into place, so readers see the old file or the new one but never
+// part of one
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	f, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
 	if err != nil {
 		return err
 	}
+	tmp := f.Name()
+	defer os.Remove(tmp) // gone already once renamed
 
-	//nolint:gosec // G306: Config file is non-sensitive and can be world-readable
-	return os.WriteFile(ConfigFile, data, 0o644)
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// Owner-only: the settings can hold servers' API keys
+	if err := os.Chmod(tmp, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	// Make the rename itself durable; not every platform can sync a directory
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		d.Close()
+	}
+	return nil
 }
 
 // GetConfigPath returns the config file path
@@ -326,6 +777,45 @@ func GetConfigPath() string {
 	return ConfigFile
 }
 
+// GetVersionFilePath returns the file recording the last release whose
+// changelog the user has seen
+func GetVersionFilePath() string {
+	ensurePathsInitialized()
+	return VersionFile
+}
+
+// GetStringsPath returns the optional file of translated UI strings
+func GetStringsPath() string {
+	ensurePathsInitialized()
+	return filepath.Join(ConfigDir, "strings.json")
+}
+
+// GetBackupPath returns the path of the settings backup
+func GetBackupPath() string {
+	ensurePathsInitialized()
+	return ConfigFile + backupSuffix
+}
+
+// GetSoundsDir returns the directory alert rules' sound files are looked up
+// in when given a relative path
+func GetSoundsDir() string {
+	ensurePathsInitialized()
+	return filepath.Join(ConfigDir, "sounds")
+}
+
+// GetEmergencyLogPath returns the path of the emergency squawk history
+func GetEmergencyLogPath() string {
+	ensurePathsInitialized()
+	return filepath.Join(ConfigDir, "emergencies.jsonl")
+}
+
+// GetStartupMarkerPath returns the file counting startups in a row that
+// didn't finish
+func GetStartupMarkerPath() string {
+	ensurePathsInitialized()
+	return filepath.Join(ConfigDir, "startup_failures")
+}
+
 // GetOverlaysDir returns the overlays directory path
 func GetOverlaysDir() string {
 	_ = EnsureConfigDir()
@@ -3,9 +3,13 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -112,6 +116,9 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.Connection.ReconnectDelay != 2 {
 		t.Errorf("Connection.ReconnectDelay = %d, want 2", cfg.Connection.ReconnectDelay)
 	}
+	if cfg.Connection.ConnectTimeout != 10 {
+		t.Errorf("Connection.ConnectTimeout = %d, want 10", cfg.Connection.ConnectTimeout)
+	}
 
 	// Test Audio defaults
 	if cfg.Audio.Enabled {
@@ -615,6 +622,7 @@ func TestConfigStructs_JSON(t *testing.T) {
 					Enabled:     true,
 					Conditions: []ConditionConfig{
 						{Type: "altitude", Value: ">10000"},
+						{Type: "acars_text", Value: "MAYDAY|MEDICAL", Regex: true},
 					},
 					Actions: []ActionConfig{
 						{Type: "sound", Sound: "alert.wav"},
@@ -690,9 +698,12 @@ func TestConfigStructs_JSON(t *testing.T) {
 	if len(loaded.Alerts.Rules) != 1 {
 		t.Error("Alerts.Rules not preserved")
 	}
-	if len(loaded.Alerts.Rules[0].Conditions) != 1 {
+	if len(loaded.Alerts.Rules[0].Conditions) != 2 {
 		t.Error("Alert conditions not preserved")
 	}
+	if !loaded.Alerts.Rules[0].Conditions[1].Regex {
+		t.Error("Alert condition regex flag not preserved")
+	}
 	if len(loaded.Alerts.Rules[0].Actions) != 2 {
 		t.Error("Alert actions not preserved")
 	}
@@ -742,3 +753,302 @@ func floatPtr(f float64) *float64 {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestDefaultConfig_ACARS(t *testing.T) {
+	cfg := DefaultConfig()
+
+	if cfg.ACARS.MaxMessages != 100 {
+		t.Errorf("ACARS.MaxMessages = %d, want 100", cfg.ACARS.MaxMessages)
+	}
+	if cfg.ACARS.DedupWindow != 60 {
+		t.Errorf("ACARS.DedupWindow = %d, want 60", cfg.ACARS.DedupWindow)
+	}
+}
+
+func TestDefaultConfig_RadarCleanup(t *testing.T) {
+	cfg := DefaultConfig()
+
+	if cfg.Radar.CleanupInterval != 30 {
+		t.Errorf("Radar.CleanupInterval = %d, want 30", cfg.Radar.CleanupInterval)
+	}
+	if cfg.Radar.AircraftTimeout != 300 {
+		t.Errorf("Radar.AircraftTimeout = %d, want 300", cfg.Radar.AircraftTimeout)
+	}
+}
+
+func TestDefaultConfig_AudioUrgencyBands(t *testing.T) {
+	bands := DefaultConfig().Audio.UrgencyBands
+
+	if len(bands) != 3 {
+		t.Fatalf("expected 3 default urgency bands, got %d", len(bands))
+	}
+	if bands[0].MaxDistance != 25 || bands[0].Repeats != 3 {
+		t.Errorf("closest band = %+v, want 25nm triple beep", bands[0])
+	}
+	if bands[2].MaxDistance != 0 || bands[2].Repeats != 1 {
+		t.Errorf("farthest band = %+v, want unbounded single beep", bands[2])
+	}
+}
+
+func TestConnectionSettings_Keepalive(t *testing.T) {
+	interval, timeout := DefaultConfig().Connection.Keepalive()
+	if interval != 20*time.Second || timeout != 10*time.Second {
+		t.Errorf("default keepalive = %v/%v, want 20s/10s", interval, timeout)
+	}
+
+	if interval, timeout := (ConnectionSettings{PingInterval: 0, PongTimeout: 5}).Keepalive(); interval != 0 || timeout != 0 {
+		t.Errorf("ping_interval 0 should disable keepalive, got %v/%v", interval, timeout)
+	}
+	if _, timeout := (ConnectionSettings{PingInterval: 30}).Keepalive(); timeout != 10*time.Second {
+		t.Errorf("missing pong_timeout should default to 10s, got %v", timeout)
+	}
+}
+
+func TestConnectionSettings_Feeds(t *testing.T) {
+	single := DefaultConfig().Connection
+	if feeds := single.Feeds(); len(feeds) != 1 || feeds[0].Host != "localhost" || feeds[0].Port != 8000 || single.PrimaryServer() != 0 {
+		t.Errorf("without servers the feed is host:port, got %+v", feeds)
+	}
+
+	multi := ConnectionSettings{Port: 8000, Servers: []ServerConfig{
+		{Name: "HOME", Host: "home.local"},
+		{Host: ""},
+		{Host: "club.example.org", Port: 443, APIKey: "sk_club", Primary: true},
+	}}
+	feeds := multi.Feeds()
+	if len(feeds) != 2 || feeds[0].Port != 8000 || feeds[1].Port != 443 {
+		t.Fatalf("servers without a host are skipped and the port defaults, got %+v", feeds)
+	}
+	if feeds[0].Label() != "HOME" || feeds[1].Label() != "club.example.org" {
+		t.Errorf("labels = %q, %q", feeds[0].Label(), feeds[1].Label())
+	}
+	if multi.PrimaryServer() != 1 {
+		t.Errorf("primary = %d, want the server marked primary", multi.PrimaryServer())
+	}
+	multi.Servers[2].Primary = false
+	if multi.PrimaryServer() != 0 {
+		t.Error("without one marked, the first server is primary")
+	}
+}
+
+func TestFilterSettings_AltitudeBounds(t *testing.T) {
+	floor, ceiling, err := DefaultConfig().Filters.AltitudeBounds()
+	if err != nil || floor != -1500 || ceiling != 60000 {
+		t.Errorf("default bounds = %d..%d, %v, want -1500..60000", floor, ceiling, err)
+	}
+
+	if floor, ceiling, err := (FilterSettings{AltitudeFloor: 0, AltitudeCeiling: 50000}).AltitudeBounds(); err != nil || floor != 0 || ceiling != 50000 {
+		t.Errorf("custom bounds = %d..%d, %v", floor, ceiling, err)
+	}
+	for _, f := range []FilterSettings{{AltitudeFloor: 1000, AltitudeCeiling: 1000}, {AltitudeFloor: 5000, AltitudeCeiling: -100}} {
+		floor, ceiling, err := f.AltitudeBounds()
+		if err == nil || floor != DefaultAltitudeFloor || ceiling != DefaultAltitudeCeiling {
+			t.Errorf("%d..%d should fall back to the defaults with an error, got %d..%d, %v", f.AltitudeFloor, f.AltitudeCeiling, floor, ceiling, err)
+		}
+	}
+}
+
+// useTempSettings points the config paths at a fresh directory
+func useTempSettings(t *testing.T) {
+	t.Helper()
+	origConfigDir, origConfigFile, origOverlaysDir := ConfigDir, ConfigFile, OverlaysDir
+	ConfigDir = t.TempDir()
+	ConfigFile = filepath.Join(ConfigDir, "settings.json")
+	OverlaysDir = filepath.Join(ConfigDir, "overlays")
+	t.Cleanup(func() {
+		ConfigDir, ConfigFile, OverlaysDir = origConfigDir, origConfigFile, origOverlaysDir
+	})
+}
+
+func TestSave_AtomicWithBackup(t *testing.T) {
+	useTempSettings(t)
+
+	first := DefaultConfig()
+	first.Display.Theme = "amber"
+	if err := Save(first); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(GetBackupPath()); !os.IsNotExist(err) {
+		t.Error("the first save has nothing to back up")
+	}
+
+	second := DefaultConfig()
+	second.Display.Theme = "cyberpunk"
+	if err := Save(second); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := readConfig(GetBackupPath())
+	if err != nil || backup.Display.Theme != "amber" {
+		t.Fatalf("backup should hold the previous save: %v", err)
+	}
+	if second.Version != SchemaVersion() {
+		t.Errorf("saved version %d, want %d", second.Version, SchemaVersion())
+	}
+
+	if runtime.GOOS != "windows" {
+		for _, path := range []string{ConfigFile, GetBackupPath()} {
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if perm := info.Mode().Perm(); perm != 0o600 {
+				t.Errorf("%s has mode %v, want it readable by its owner alone", path, perm)
+			}
+		}
+	}
+
+	entries, _ := os.ReadDir(ConfigDir)
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".tmp") {
+			t.Errorf("temporary file %s left behind", e.Name())
+		}
+	}
+
+	// A damaged file doesn't replace a good backup
+	if err := os.WriteFile(ConfigFile, []byte(`{"display": {"theme": "gre`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(DefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	if backup, err := readConfig(GetBackupPath()); err != nil || backup.Display.Theme != "amber" {
+		t.Errorf("the damaged file replaced the backup: %v", err)
+	}
+}
+
+func TestLoad_TruncatedFileRecoversBackup(t *testing.T) {
+	useTempSettings(t)
+
+	cfg := DefaultConfig()
+	cfg.Alerts.Rules = []AlertRuleConfig{{ID: "mine", Name: "Mine", Enabled: true}}
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// Power lost part way through a write
+	data, err := os.ReadFile(ConfigFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigFile, data[:len(data)/2], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	p := loaded.LoadProblem()
+	if p == nil || !p.Restored || p.Err == nil {
+		t.Fatalf("LoadProblem = %+v, want a restore from the backup", p)
+	}
+	if len(loaded.Alerts.Rules) != 1 || loaded.Alerts.Rules[0].ID != "mine" {
+		t.Errorf("alert rules lost: %+v", loaded.Alerts.Rules)
+	}
+
+	// With the backup damaged too, the defaults are used
+	if err := os.WriteFile(GetBackupPath(), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, _ = Load()
+	if p := loaded.LoadProblem(); p == nil || p.Restored {
+		t.Errorf("LoadProblem = %+v, want defaults", p)
+	}
+
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _ := Load(); loaded.LoadProblem() != nil {
+		t.Error("a good file should load without a problem")
+	}
+}
+
+func TestLoad_MigratesOldVersions(t *testing.T) {
+	useTempSettings(t)
+
+	// A file from before versioning loads as the current version
+	if err := os.WriteFile(ConfigFile, []byte(`{"display": {"theme": "amber"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := Load()
+	if cfg.Version != SchemaVersion() || cfg.Display.Theme != "amber" {
+		t.Errorf("version %d, theme %q", cfg.Version, cfg.Display.Theme)
+	}
+
+	// A sample next step: the theme moves to its own section
+	orig := migrations
+	t.Cleanup(func() { migrations = orig })
+	from := SchemaVersion()
+	migrations = append(append([]migration{}, orig...), func(sections map[string]json.RawMessage) error {
+		var old struct {
+			Theme string `json:"theme"`
+		}
+		if err := json.Unmarshal(sections["look"], &old); err != nil {
+			return err
+		}
+		display := map[string]string{"theme": old.Theme}
+		data, err := json.Marshal(display)
+		if err != nil {
+			return err
+		}
+		sections["display"] = data
+		delete(sections, "look")
+		return nil
+	})
+
+	old := fmt.Sprintf(`{"version": %d, "look": {"theme": "cyberpunk"}}`, from)
+	if err := os.WriteFile(ConfigFile, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ = Load()
+	if cfg.LoadProblem() != nil {
+		t.Fatalf("migration failed: %v", cfg.LoadProblem().Err)
+	}
+	if cfg.Version != from+1 || cfg.Display.Theme != "cyberpunk" {
+		t.Errorf("version %d, theme %q after migration", cfg.Version, cfg.Display.Theme)
+	}
+	if cfg.extra["look"] != nil {
+		t.Error("the migrated section should not be kept")
+	}
+
+	// A failing step leaves the file unused rather than half upgraded
+	if err := os.WriteFile(ConfigFile, []byte(fmt.Sprintf(`{"version": %d, "look": 5}`, from)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ = Load()
+	if p := cfg.LoadProblem(); p == nil || !strings.Contains(p.Err.Error(), "upgrading settings") {
+		t.Errorf("LoadProblem = %+v", p)
+	}
+}
+
+func TestSave_KeepsSectionsFromNewerVersions(t *testing.T) {
+	useTempSettings(t)
+
+	newer := fmt.Sprintf(`{"version": %d, "display": {"theme": "amber"}, "holograms": {"enabled": true}}`, SchemaVersion()+1)
+	if err := os.WriteFile(ConfigFile, []byte(newer), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := Load()
+	cfg.Display.Theme = "cyberpunk"
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(ConfigFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		t.Fatal(err)
+	}
+	if string(sections["holograms"]) == "" || !strings.Contains(string(sections["holograms"]), "true") {
+		t.Errorf("unknown section dropped: %s", data)
+	}
+	if string(sections["version"]) != fmt.Sprint(SchemaVersion()+1) {
+		t.Errorf("a newer file's version should be kept, got %s", sections["version"])
+	}
+}
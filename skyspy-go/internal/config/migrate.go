@@ -0,0 +1,78 @@
+// Package config handles configuration loading, saving, and defaults for SkySpy CLI
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// migration upgrades a settings file's top-level sections in place from
+// one format version to the next
+type migration func(sections map[string]json.RawMessage) error
+
+// migrations[v] upgrades a settings file from version v to v+1, so the
+// current version is len(migrations). Files written before the version
+// field was added are version 0. A change to the file format adds a step
+// here rather than letting old files lose the settings it moves.
+var migrations = []migration{
+	// 0 to 1: the version field is added; nothing else changes
+	func(map[string]json.RawMessage) error { return nil },
+}
+
+// SchemaVersion returns the settings file format version this build writes
+func SchemaVersion() int {
+	return len(migrations)
+}
+
+// migrate upgrades sections to the current format version. A file from a
+// newer build is left as it is.
+func migrate(sections map[string]json.RawMessage) error {
+	version := 0
+	if raw, ok := sections["version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return fmt.Errorf("version: %w", err)
+		}
+	}
+	current := SchemaVersion()
+	if version >= current {
+		return nil
+	}
+	for v := version; v < current; v++ {
+		if err := migrations[v](sections); err != nil {
+			return fmt.Errorf("upgrading settings from version %d: %w", v, err)
+		}
+	}
+	sections["version"] = json.RawMessage(fmt.Sprint(current))
+	return nil
+}
+
+var (
+	knownSectionsOnce sync.Once
+	knownSections     map[string]bool
+)
+
+// unknownSections returns the top-level sections this build has no field
+// for, as written by a newer version, so saving can keep them
+func unknownSections(sections map[string]json.RawMessage) map[string]json.RawMessage {
+	knownSectionsOnce.Do(func() {
+		var known map[string]json.RawMessage
+		data, _ := json.Marshal(DefaultConfig())
+		_ = json.Unmarshal(data, &known)
+		knownSections = make(map[string]bool, len(known))
+		for key := range known {
+			knownSections[key] = true
+		}
+	})
+
+	var extra map[string]json.RawMessage
+	for key, raw := range sections {
+		if !knownSections[key] {
+			if extra == nil {
+				extra = make(map[string]json.RawMessage)
+			}
+			extra[key] = raw
+		}
+	}
+	return extra
+}
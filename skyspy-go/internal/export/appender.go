@@ -0,0 +1,236 @@
+// Package export provides export functionality for SkySpy CLI
+package export
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Appender appends lines to a file from a background goroutine, so the
+// caller never waits on the disk. The file is created on the first line.
+type Appender struct {
+	path  string
+	lines chan []byte
+	done  chan struct{}
+
+	// Set for a rotating appender; path is then the current file
+	rotate *Rotation
+	now    func() time.Time
+	period time.Time // start of the current file's interval
+	size   int64     // bytes in the current file
+
+	mu      sync.Mutex
+	closed  bool
+	dropped int
+	err     error // first write error
+}
+
+// NewAppender starts an appender for path holding up to buffer lines not
+// yet written
+func NewAppender(path string, buffer int) *Appender {
+	a := &Appender{
+		path:  path,
+		lines: make(chan []byte, buffer),
+		done:  make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Rotation moves a rotating appender on to a new file every interval, or
+// before a file grows past a size. Files are named like the other
+// exports, from the time they're started: Dir/Prefix_20060102_150405.Ext.
+type Rotation struct {
+	Dir    string
+	Prefix string
+	Ext    string
+	Header []byte        // written at the top of each new file, such as a CSV header
+	Every  time.Duration // 0 never starts a new file on time
+	Max    int64         // bytes; 0 never starts a new file on size
+}
+
+// NewRotatingAppender starts an appender that writes to files per r,
+// holding up to buffer lines not yet written
+func NewRotatingAppender(r Rotation, buffer int) *Appender {
+	a := &Appender{
+		lines:  make(chan []byte, buffer),
+		done:   make(chan struct{}),
+		rotate: &r,
+		now:    time.Now,
+	}
+	go a.run()
+	return a
+}
+
+// Append queues a line, adding the newline. It never blocks: when the
+// buffer is full, or the appender is closed, the line is dropped and false
+// returned.
+func (a *Appender) Append(line []byte) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		a.dropped++
+		return false
+	}
+	select {
+	case a.lines <- append(line, '\n'):
+		return true
+	default:
+		a.dropped++
+		return false
+	}
+}
+
+// Dropped returns how many lines were dropped
+func (a *Appender) Dropped() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.dropped
+}
+
+// Close writes the lines still queued and closes the file, returning the
+// first error writing it
+func (a *Appender) Close() error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.lines)
+	}
+	a.mu.Unlock()
+
+	<-a.done
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// run writes queued lines, flushing whenever the queue empties so a crash
+// loses little
+func (a *Appender) run() {
+	defer close(a.done)
+
+	var f *os.File
+	var w *bufio.Writer
+	for line := range a.lines {
+		if f != nil && a.rotateDue(len(line)) {
+			a.fail(errors.Join(w.Flush(), f.Close()))
+			f = nil
+		}
+		if f == nil {
+			var err error
+			if f, err = a.open(); err != nil {
+				a.fail(err)
+				continue
+			}
+			w = bufio.NewWriter(f)
+		}
+		n, err := w.Write(line)
+		a.size += int64(n)
+		if err != nil {
+			a.fail(err)
+		}
+		if len(a.lines) == 0 {
+			a.fail(w.Flush())
+		}
+	}
+	if f != nil {
+		a.fail(errors.Join(w.Flush(), f.Close()))
+	}
+}
+
+// rotateDue reports whether a rotating appender should start a new file
+// before writing n more bytes
+func (a *Appender) rotateDue(n int) bool {
+	r := a.rotate
+	if r == nil {
+		return false
+	}
+	if r.Every > 0 && !a.now().Truncate(r.Every).Equal(a.period) {
+		return true
+	}
+	return r.Max > 0 && a.size > 0 && a.size+int64(n) > r.Max
+}
+
+// open opens the file for appending, creating it and its directory
+func (a *Appender) open() (*os.File, error) {
+	if a.rotate != nil {
+		return a.openNext()
+	}
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+}
+
+// openNext opens a rotating appender's file for now. A file of the same
+// name with room left, from a restart in the same second, is carried on;
+// otherwise _2, _3 and so on are added to the name. The header goes at the
+// top of a new file.
+func (a *Appender) openNext() (*os.File, error) {
+	r := a.rotate
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	now := a.now()
+	if r.Every > 0 {
+		a.period = now.Truncate(r.Every)
+	}
+
+	// The file just closed is never reopened, whatever its size
+	prev := a.Path()
+	base := r.Prefix + "_" + now.Format("20060102_150405")
+	path := filepath.Join(r.Dir, base+"."+r.Ext)
+	for n := 2; ; n++ {
+		info, err := os.Stat(path)
+		if err != nil || (path != prev && (r.Max == 0 || info.Size() < r.Max)) {
+			break
+		}
+		path = filepath.Join(r.Dir, fmt.Sprintf("%s_%d.%s", base, n, r.Ext))
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	a.path = path
+	a.mu.Unlock()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+	a.size = info.Size()
+	if a.size == 0 && len(r.Header) > 0 {
+		n, err := f.Write(r.Header)
+		a.size += int64(n)
+		if err != nil {
+			return nil, errors.Join(err, f.Close())
+		}
+	}
+	return f, nil
+}
+
+// Path returns the file being written: for a rotating appender, the
+// current one, or "" before the first line
+func (a *Appender) Path() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.path
+}
+
+// fail records the first write error
+func (a *Appender) fail(err error) {
+	if err == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.err == nil {
+		a.err = err
+	}
+}
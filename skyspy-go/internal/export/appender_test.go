@@ -0,0 +1,200 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppender_AppendsAcrossRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "events.jsonl")
+
+	a := NewAppender(path, 8)
+	for _, line := range []string{"one", "two"} {
+		if !a.Append([]byte(line)) {
+			t.Fatalf("append %q dropped", line)
+		}
+	}
+	if err := a.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	// A later run adds to the file rather than replacing it
+	a = NewAppender(path, 8)
+	a.Append([]byte("three"))
+	if err := a.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	if got, want := string(data), "one\ntwo\nthree\n"; got != want {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+}
+
+func TestAppender_NoFileUntilWritten(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	if err := NewAppender(path, 8).Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("an appender with nothing to write shouldn't create the file")
+	}
+}
+
+func TestAppender_DropsAfterClose(t *testing.T) {
+	a := NewAppender(filepath.Join(t.TempDir(), "events.jsonl"), 8)
+	if err := a.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatalf("second close failed: %v", err)
+	}
+	if a.Append([]byte("late")) || a.Dropped() != 1 {
+		t.Errorf("a line after close should be dropped, dropped = %d", a.Dropped())
+	}
+}
+
+func TestAppender_ReportsWriteErrors(t *testing.T) {
+	// The directory can't be created under a regular file
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a := NewAppender(filepath.Join(blocker, "events.jsonl"), 8)
+	a.Append([]byte("lost"))
+	if err := a.Close(); err == nil {
+		t.Error("close should report that the log couldn't be written")
+	}
+}
+
+// testClock is a clock a test moves on while an appender reads it
+type testClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+// newTestRotatingAppender starts a rotating appender on clock. The clock
+// is set before the first line, so the appender only reads it after.
+func newTestRotatingAppender(r Rotation, clock *testClock) *Appender {
+	a := NewRotatingAppender(r, 8)
+	a.now = clock.Now
+	return a
+}
+
+// waitForFile waits until the appender has opened a file
+func waitForFile(t *testing.T, a *Appender) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for a.Path() == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("the appender never opened a file")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return a.Path()
+}
+
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	return string(data)
+}
+
+func TestAppender_RotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	clock := &testClock{t: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	a := newTestRotatingAppender(Rotation{
+		Dir: dir, Prefix: "session", Ext: "csv", Header: []byte("h\n"), Max: 10,
+	}, clock)
+	for _, line := range []string{"aaa", "bbb", "ccc", "ddd"} {
+		a.Append([]byte(line))
+	}
+	if err := a.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	// Each file starts with the header and stops short of 10 bytes; files
+	// started in the same second are numbered
+	want := map[string]string{
+		"session_20240601_120000.csv":   "h\naaa\nbbb\n",
+		"session_20240601_120000_2.csv": "h\nccc\nddd\n",
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != len(want) {
+		t.Fatalf("wrote %d files, want %d", len(entries), len(want))
+	}
+	for name, content := range want {
+		if got := readLog(t, filepath.Join(dir, name)); got != content {
+			t.Errorf("%s = %q, want %q", name, got, content)
+		}
+	}
+}
+
+func TestAppender_RotatesOnTime(t *testing.T) {
+	dir := t.TempDir()
+	clock := &testClock{t: time.Date(2024, 6, 1, 12, 59, 30, 0, time.UTC)}
+	a := newTestRotatingAppender(Rotation{Dir: dir, Prefix: "session", Ext: "ndjson", Every: time.Hour}, clock)
+
+	a.Append([]byte("one"))
+	first := waitForFile(t, a)
+
+	// Into the next hour, a new file is started
+	clock.Add(time.Minute)
+	a.Append([]byte("two"))
+	if err := a.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	if filepath.Base(first) != "session_20240601_125930.ndjson" {
+		t.Errorf("first file = %s", first)
+	}
+	if got := readLog(t, first); got != "one\n" {
+		t.Errorf("first file = %q", got)
+	}
+	second := a.Path()
+	if filepath.Base(second) != "session_20240601_130030.ndjson" {
+		t.Errorf("second file = %s", second)
+	}
+	if got := readLog(t, second); got != "two\n" {
+		t.Errorf("second file = %q", got)
+	}
+}
+
+func TestAppender_RotatingCarriesOnAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	clock := &testClock{t: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	r := Rotation{Dir: dir, Prefix: "session", Ext: "csv", Header: []byte("h\n"), Max: 100}
+
+	// A restart in the same second adds to the file without a second header
+	for _, line := range []string{"one", "two"} {
+		a := newTestRotatingAppender(r, clock)
+		a.Append([]byte(line))
+		if err := a.Close(); err != nil {
+			t.Fatalf("close failed: %v", err)
+		}
+	}
+	if got := readLog(t, filepath.Join(dir, "session_20240601_120000.csv")); got != "h\none\ntwo\n" {
+		t.Errorf("log = %q", got)
+	}
+}
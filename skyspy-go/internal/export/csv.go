@@ -4,14 +4,16 @@
 package export
 
 import (
+	"bytes"
 	"encoding/csv"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/units"
 )
 
 // ACARSMessage represents an ACARS message for export
@@ -23,42 +25,119 @@ type ACARSMessage struct {
 	Text      string
 }
 
-// ExportAircraft exports aircraft data to CSV format
-func ExportAircraft(aircraft map[string]*radar.Target, directory string) (string, error) {
-	filename := GenerateFilename("skyspy_aircraft", "csv", directory)
+// aircraftHeader is the column list for aircraft CSV exports
+var aircraftHeader = []string{
+	"hex",
+	"callsign",
+	"lat",
+	"lon",
+	"altitude",
+	"geom_altitude",
+	"speed",
+	"track",
+	"vertical_rate",
+	"squawk",
+	"distance_nm",
+	"bearing",
+	"military",
+	"rssi",
+	"aircraft_type",
+	"nav_altitude",
+	"nav_heading",
+	"nav_qnh",
+	"nav_modes",
+	"first_seen",
+	"tracked_nm",
+	"timestamp",
+}
 
-	file, err := os.Create(filename)
-	if err != nil {
-		if mkdirErr := os.MkdirAll(filepath.Dir(filename), 0o755); mkdirErr != nil {
-			return "", fmt.Errorf("failed to create directory: %w", mkdirErr)
+// aircraftColumns returns aircraftHeader with the distance columns named
+// for unit, e.g. distance_km
+func aircraftColumns(unit string) []string {
+	header := append([]string{}, aircraftHeader...)
+	for i, col := range header {
+		switch col {
+		case "distance_nm":
+			header[i] = "distance_" + unit
+		case "tracked_nm":
+			header[i] = "tracked_" + unit
 		}
-		file, err = os.Create(filename)
-		if err != nil {
-			return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	return header
+}
+
+// aircraftRow formats one aircraft as a CSV row matching aircraftColumns,
+// with distances in unit
+func aircraftRow(ac *radar.Target, timestamp, unit string) []string {
+	return []string{
+		csvText(ac.Hex),
+		csvText(ac.Callsign),
+		formatFloat(ac.Lat, ac.HasLat),
+		formatFloat(ac.Lon, ac.HasLon),
+		formatInt(ac.BaroAlt()),
+		formatInt(ac.GeomAltitude, ac.HasGeomAlt),
+		formatFloat(ac.Speed, ac.HasSpeed),
+		formatFloat(ac.Track, ac.HasTrack),
+		formatFloat(ac.Vertical, ac.HasVS),
+		csvText(ac.Squawk),
+		formatFloatAlways(units.FromNM(ac.Distance, unit)),
+		formatFloatAlways(ac.Bearing),
+		strconv.FormatBool(ac.Military),
+		formatFloat(ac.RSSI, ac.HasRSSI),
+		csvText(ac.ACType),
+		formatInt(ac.NavAltitude, ac.HasNavAlt),
+		formatFloat(ac.NavHeading, ac.HasNavHeading),
+		formatFloat(ac.NavQNH, ac.HasNavQNH),
+		csvText(strings.Join(ac.NavModes, " ")),
+		formatTime(ac.FirstSeen),
+		formatFloatAlways(units.FromNM(ac.TrackNM, unit)),
+		timestamp,
+	}
+}
+
+// WriteAircraftCSV writes aircraft as CSV to w in the given order, using the
+// same columns as ExportAircraft with distances in unit (nm, km or mi;
+// empty for nm)
+func WriteAircraftCSV(w io.Writer, aircraft []*radar.Target, unit string) error {
+	writer := csv.NewWriter(w)
+	unit = units.Distance(unit)
+
+	if err := writer.Write(aircraftColumns(unit)); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	timestamp := time.Now().Format(time.RFC3339)
+	for _, ac := range aircraft {
+		if err := writer.Write(aircraftRow(ac, timestamp, unit)); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
 		}
 	}
-	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportAircraft exports aircraft data to CSV format
+func ExportAircraft(aircraft map[string]*radar.Target, directory string) (string, error) {
+	return ExportAircraftWithOptions(aircraft, directory, Options{})
+}
+
+// ExportAircraftWithOptions exports aircraft data to CSV format, adding the
+// optional columns selected by opts after the default ones
+func ExportAircraftWithOptions(aircraft map[string]*radar.Target, directory string, opts Options) (string, error) {
+	filename := GenerateFilename("skyspy_aircraft", "csv", directory)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
 
 	// Write header
-	header := []string{
-		"hex",
-		"callsign",
-		"lat",
-		"lon",
-		"altitude",
-		"speed",
-		"track",
-		"vertical_rate",
-		"squawk",
-		"distance_nm",
-		"bearing",
-		"military",
-		"rssi",
-		"aircraft_type",
-		"timestamp",
+	unit := units.Distance(opts.Units)
+	header := aircraftColumns(unit)
+	if opts.SignalStats {
+		header = append(header, signalHeader...)
+	}
+	if opts.hasPosition() {
+		header = append(header, opts.positionColumn())
 	}
 	if err := writer.Write(header); err != nil {
 		return "", fmt.Errorf("failed to write header: %w", err)
@@ -68,67 +147,32 @@ func ExportAircraft(aircraft map[string]*radar.Target, directory string) (string
 
 	// Write aircraft data
 	for _, ac := range aircraft {
-		row := []string{
-			ac.Hex,
-			ac.Callsign,
-			formatFloat(ac.Lat, ac.HasLat),
-			formatFloat(ac.Lon, ac.HasLon),
-			formatInt(ac.Altitude, ac.HasAlt),
-			formatFloat(ac.Speed, ac.HasSpeed),
-			formatFloat(ac.Track, ac.HasTrack),
-			formatFloat(ac.Vertical, ac.HasVS),
-			ac.Squawk,
-			formatFloatAlways(ac.Distance),
-			formatFloatAlways(ac.Bearing),
-			strconv.FormatBool(ac.Military),
-			formatFloat(ac.RSSI, ac.HasRSSI),
-			ac.ACType,
-			timestamp,
+		row := aircraftRow(ac, timestamp, unit)
+		if opts.SignalStats {
+			row = append(row, signalRow(ac)...)
+		}
+		if opts.hasPosition() {
+			row = append(row, opts.formatPosition(ac))
 		}
 		if err := writer.Write(row); err != nil {
 			return "", fmt.Errorf("failed to write row: %w", err)
 		}
 	}
 
+	writer.Flush()
+	if err := writeFile(filename, buf.Bytes()); err != nil {
+		return "", err
+	}
 	return filename, nil
 }
 
 // ExportAircraftToFile exports aircraft data to a specific file
 func ExportAircraftToFile(aircraft map[string]*radar.Target, filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		if mkdirErr := os.MkdirAll(filepath.Dir(filename), 0o755); mkdirErr != nil {
-			return fmt.Errorf("failed to create directory: %w", mkdirErr)
-		}
-		file, err = os.Create(filename)
-		if err != nil {
-			return fmt.Errorf("failed to create file: %w", err)
-		}
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
 
 	// Write header
-	header := []string{
-		"hex",
-		"callsign",
-		"lat",
-		"lon",
-		"altitude",
-		"speed",
-		"track",
-		"vertical_rate",
-		"squawk",
-		"distance_nm",
-		"bearing",
-		"military",
-		"rssi",
-		"aircraft_type",
-		"timestamp",
-	}
-	if err := writer.Write(header); err != nil {
+	if err := writer.Write(aircraftHeader); err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
 	}
 
@@ -136,49 +180,22 @@ func ExportAircraftToFile(aircraft map[string]*radar.Target, filename string) er
 
 	// Write aircraft data
 	for _, ac := range aircraft {
-		row := []string{
-			ac.Hex,
-			ac.Callsign,
-			formatFloat(ac.Lat, ac.HasLat),
-			formatFloat(ac.Lon, ac.HasLon),
-			formatInt(ac.Altitude, ac.HasAlt),
-			formatFloat(ac.Speed, ac.HasSpeed),
-			formatFloat(ac.Track, ac.HasTrack),
-			formatFloat(ac.Vertical, ac.HasVS),
-			ac.Squawk,
-			formatFloatAlways(ac.Distance),
-			formatFloatAlways(ac.Bearing),
-			strconv.FormatBool(ac.Military),
-			formatFloat(ac.RSSI, ac.HasRSSI),
-			ac.ACType,
-			timestamp,
-		}
+		row := aircraftRow(ac, timestamp, units.NM)
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write row: %w", err)
 		}
 	}
 
-	return nil
+	writer.Flush()
+	return writeFile(filename, buf.Bytes())
 }
 
 // ExportACARSMessages exports ACARS messages to CSV format
 func ExportACARSMessages(messages []ACARSMessage, directory string) (string, error) {
 	filename := GenerateFilename("skyspy_acars", "csv", directory)
 
-	file, err := os.Create(filename)
-	if err != nil {
-		if mkdirErr := os.MkdirAll(filepath.Dir(filename), 0o755); mkdirErr != nil {
-			return "", fmt.Errorf("failed to create directory: %w", mkdirErr)
-		}
-		file, err = os.Create(filename)
-		if err != nil {
-			return "", fmt.Errorf("failed to create file: %w", err)
-		}
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
 
 	// Write header
 	header := []string{
@@ -201,35 +218,27 @@ func ExportACARSMessages(messages []ACARSMessage, directory string) (string, err
 
 		row := []string{
 			timestamp,
-			msg.Callsign,
-			msg.Flight,
-			msg.Label,
-			msg.Text,
+			csvText(msg.Callsign),
+			csvText(msg.Flight),
+			csvText(msg.Label),
+			csvText(msg.Text),
 		}
 		if err := writer.Write(row); err != nil {
 			return "", fmt.Errorf("failed to write row: %w", err)
 		}
 	}
 
+	writer.Flush()
+	if err := writeFile(filename, buf.Bytes()); err != nil {
+		return "", err
+	}
 	return filename, nil
 }
 
 // ExportACARSMessagesToFile exports ACARS messages to a specific file
 func ExportACARSMessagesToFile(messages []ACARSMessage, filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		if mkdirErr := os.MkdirAll(filepath.Dir(filename), 0o755); mkdirErr != nil {
-			return fmt.Errorf("failed to create directory: %w", mkdirErr)
-		}
-		file, err = os.Create(filename)
-		if err != nil {
-			return fmt.Errorf("failed to create file: %w", err)
-		}
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
 
 	// Write header
 	header := []string{
@@ -252,17 +261,30 @@ func ExportACARSMessagesToFile(messages []ACARSMessage, filename string) error {
 
 		row := []string{
 			timestamp,
-			msg.Callsign,
-			msg.Flight,
-			msg.Label,
-			msg.Text,
+			csvText(msg.Callsign),
+			csvText(msg.Flight),
+			csvText(msg.Label),
+			csvText(msg.Text),
 		}
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write row: %w", err)
 		}
 	}
 
-	return nil
+	writer.Flush()
+	return writeFile(filename, buf.Bytes())
+}
+
+// csvText guards a text field taken from radio data against formula
+// injection: spreadsheets run a cell starting with =, +, -, @, tab or
+// carriage return as a formula, so such a value gets a leading quote and
+// is read as text instead. Numeric columns are left alone, since a
+// negative number is not a formula.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
 }
 
 // formatFloat formats a float64 value for CSV, returning empty string if not available
@@ -281,6 +303,15 @@ func formatFloatAlways(val float64) string {
 	return strconv.FormatFloat(val, 'f', 6, 64)
 }
 
+// formatTime formats a timestamp for CSV as RFC 3339 UTC, returning empty
+// string if not set
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 // formatInt formats an int value for CSV, returning empty string if not available
 func formatInt(val int, hasVal bool) string {
 	if !hasVal {
@@ -37,6 +37,14 @@ func TestExportAircraft_CSV(t *testing.T) {
 			HasTrack: true,
 			HasVS:    true,
 			HasRSSI:  true,
+
+			NavAltitude:   24000,
+			NavHeading:    270,
+			NavQNH:        1013.2,
+			NavModes:      []string{"autopilot", "vnav"},
+			HasNavAlt:     true,
+			HasNavHeading: true,
+			HasNavQNH:     true,
 		},
 		"DEF456": {
 			Hex:      "DEF456",
@@ -93,9 +101,10 @@ func TestExportAircraft_CSV(t *testing.T) {
 
 	header := records[0]
 	expectedHeader := []string{
-		"hex", "callsign", "lat", "lon", "altitude", "speed", "track",
-		"vertical_rate", "squawk", "distance_nm", "bearing", "military",
-		"rssi", "aircraft_type", "timestamp",
+		"hex", "callsign", "lat", "lon", "altitude", "geom_altitude", "speed",
+		"track", "vertical_rate", "squawk", "distance_nm", "bearing",
+		"military", "rssi", "aircraft_type", "nav_altitude", "nav_heading",
+		"nav_qnh", "nav_modes", "first_seen", "tracked_nm", "timestamp",
 	}
 
 	if len(header) != len(expectedHeader) {
@@ -121,8 +130,11 @@ func TestExportAircraft_CSV(t *testing.T) {
 				if row[1] != "UAL123" {
 					t.Errorf("ABC123 callsign: expected 'UAL123', got %q", row[1])
 				}
-				if row[11] != "false" {
-					t.Errorf("ABC123 military: expected 'false', got %q", row[11])
+				if row[12] != "false" {
+					t.Errorf("ABC123 military: expected 'false', got %q", row[12])
+				}
+				if row[15] != "24000" || row[18] != "autopilot vnav" {
+					t.Errorf("ABC123 nav: expected 24000 and 'autopilot vnav', got %q and %q", row[15], row[18])
 				}
 			}
 			if row[0] == "DEF456" {
@@ -130,8 +142,11 @@ func TestExportAircraft_CSV(t *testing.T) {
 				if row[1] != "AAL456" {
 					t.Errorf("DEF456 callsign: expected 'AAL456', got %q", row[1])
 				}
-				if row[11] != "true" {
-					t.Errorf("DEF456 military: expected 'true', got %q", row[11])
+				if row[12] != "true" {
+					t.Errorf("DEF456 military: expected 'true', got %q", row[12])
+				}
+				if row[15] != "" || row[16] != "" || row[17] != "" || row[18] != "" {
+					t.Errorf("DEF456 has no nav data, expected empty columns, got %v", row[15:19])
 				}
 			}
 		}
@@ -172,8 +187,8 @@ func TestExportAircraft_CSV_Empty(t *testing.T) {
 	}
 
 	header := records[0]
-	if len(header) != 15 {
-		t.Errorf("expected 15 columns in header, got %d", len(header))
+	if len(header) != 22 {
+		t.Errorf("expected 22 columns in header, got %d", len(header))
 	}
 }
 
@@ -1182,3 +1197,131 @@ func TestExportACARSMessagesToFile_CSV_WriteError(t *testing.T) {
 		t.Log("expected error when writing to read-only directory (may pass as root)")
 	}
 }
+
+func TestWriteAircraftCSV(t *testing.T) {
+	aircraft := []*radar.Target{
+		{Hex: "BBB222", Callsign: "SECOND"},
+		{Hex: "AAA111", Callsign: "FIRST", Altitude: 12000, HasAlt: true},
+	}
+
+	var buf strings.Builder
+	if err := WriteAircraftCSV(&buf, aircraft, ""); err != nil {
+		t.Fatalf("WriteAircraftCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d", len(records))
+	}
+	if len(records[0]) != len(aircraftHeader) {
+		t.Errorf("expected %d columns, got %d", len(aircraftHeader), len(records[0]))
+	}
+	// Rows keep the caller's order
+	if records[1][0] != "BBB222" || records[2][0] != "AAA111" {
+		t.Errorf("expected rows in input order, got %s, %s", records[1][0], records[2][0])
+	}
+	if records[2][4] != "12000" || records[1][4] != "" {
+		t.Errorf("unexpected altitude columns %q, %q", records[1][4], records[2][4])
+	}
+}
+
+func TestWriteAircraftCSV_Altitudes(t *testing.T) {
+	aircraft := []*radar.Target{
+		{Hex: "BARO01", Altitude: 12000, HasAlt: true, BaroAltitude: 12000, HasBaroAlt: true},
+		{Hex: "GNSS01", Altitude: 12100, HasAlt: true, GeomAltitude: 12100, HasGeomAlt: true},
+		{Hex: "BOTH01", Altitude: 35450, HasAlt: true, BaroAltitude: 35000, HasBaroAlt: true, GeomAltitude: 35450, HasGeomAlt: true},
+	}
+
+	var buf strings.Builder
+	if err := WriteAircraftCSV(&buf, aircraft, ""); err != nil {
+		t.Fatalf("WriteAircraftCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	if records[0][4] != "altitude" || records[0][5] != "geom_altitude" {
+		t.Fatalf("unexpected altitude headers %v", records[0][4:6])
+	}
+	want := [][2]string{{"12000", ""}, {"", "12100"}, {"35000", "35450"}}
+	for i, w := range want {
+		if got := [2]string{records[i+1][4], records[i+1][5]}; got != w {
+			t.Errorf("%s altitudes = %v, want %v", records[i+1][0], got, w)
+		}
+	}
+}
+
+func TestWriteAircraftCSV_FormulaInjection(t *testing.T) {
+	aircraft := []*radar.Target{{
+		Hex:      "ABC123",
+		Callsign: "=HYPERLINK(\"http://x\")",
+		Squawk:   "+7700",
+		ACType:   "@SUM(A1)",
+		NavModes: []string{"-2+3"},
+		Lon:      -74.5,
+		HasLon:   true,
+		Vertical: -1200,
+		HasVS:    true,
+	}}
+
+	var buf strings.Builder
+	if err := WriteAircraftCSV(&buf, aircraft, ""); err != nil {
+		t.Fatalf("WriteAircraftCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	row := records[1]
+
+	for col, want := range map[int]string{
+		1:  `'=HYPERLINK("http://x")`,
+		9:  "'+7700",
+		14: "'@SUM(A1)",
+		18: "'-2+3",
+		0:  "ABC123",
+	} {
+		if row[col] != want {
+			t.Errorf("%s = %q, want %q", aircraftHeader[col], row[col], want)
+		}
+	}
+
+	// Negative numbers are data, not formulas
+	if row[3] != "-74.500000" || row[8] != "-1200.000000" {
+		t.Errorf("numeric columns should be unquoted, got lon %q, vertical rate %q", row[3], row[8])
+	}
+}
+
+func TestExportACARSMessages_FormulaInjection(t *testing.T) {
+	tmpDir := t.TempDir()
+	messages := []ACARSMessage{{
+		Timestamp: time.Now(),
+		Callsign:  "@BAD",
+		Flight:    "\tTAB",
+		Label:     "H1",
+		Text:      "=cmd|' /C calc'!A0",
+	}}
+
+	filename, err := ExportACARSMessages(messages, tmpDir)
+	if err != nil {
+		t.Fatalf("ExportACARSMessages failed: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+
+	want := []string{"'@BAD", "'\tTAB", "H1", "'=cmd|' /C calc'!A0"}
+	for i, w := range want {
+		if got := records[1][i+1]; got != w {
+			t.Errorf("column %s = %q, want %q", records[0][i+1], got, w)
+		}
+	}
+}
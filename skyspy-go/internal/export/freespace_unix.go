@@ -0,0 +1,23 @@
+//go:build !windows
+
+package export
+
+import (
+	"errors"
+	"syscall"
+)
+
+// freeSpace returns the bytes available to this user on dir's filesystem
+func freeSpace(dir string) (uint64, bool) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return 0, false
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), true //nolint:unconvert // field types vary by platform
+}
+
+// noSpace reports whether err means the filesystem or the user's quota is
+// full
+func noSpace(err error) bool {
+	return errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EDQUOT)
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package export
+
+import (
+	"errors"
+	"syscall"
+)
+
+// errorDiskFull is ERROR_DISK_FULL
+const errorDiskFull syscall.Errno = 112
+
+// freeSpace reports nothing: the syscall package has no free space call on
+// Windows, so the write itself finds a full disk
+func freeSpace(string) (uint64, bool) {
+	return 0, false
+}
+
+// noSpace reports whether err means the disk is full
+func noSpace(err error) bool {
+	return errors.Is(err, errorDiskFull) || errors.Is(err, syscall.ENOSPC)
+}
@@ -0,0 +1,155 @@
+// Package export provides export functionality for SkySpy CLI
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// GeoJSONFeatureCollection is the top level of a GeoJSON export
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+// GeoJSONFeature is an aircraft position or a stretch of its trail
+type GeoJSONFeature struct {
+	Type       string            `json:"type"`
+	Geometry   GeoJSONGeometry   `json:"geometry"`
+	Properties GeoJSONProperties `json:"properties"`
+}
+
+// GeoJSONGeometry is a Point, or a LineString for a trail. Coordinates
+// are longitude first, as GeoJSON has them.
+type GeoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// GeoJSONProperties describes the aircraft a feature belongs to. Trails
+// carry only the hex, callsign and segment.
+type GeoJSONProperties struct {
+	Kind     string   `json:"kind"` // "aircraft" or "trail"
+	Hex      string   `json:"hex"`
+	Callsign string   `json:"callsign,omitempty"`
+	Segment  *int     `json:"segment,omitempty"`  // trails: which segment of the trail the line is
+	Altitude *int     `json:"altitude,omitempty"` // barometric
+	Speed    *float64 `json:"speed,omitempty"`
+	Track    *float64 `json:"track,omitempty"`
+	Squawk   string   `json:"squawk,omitempty"`
+	Military *bool    `json:"military,omitempty"`
+	RSSI     *float64 `json:"rssi,omitempty"`
+}
+
+// ExportAircraftGeoJSON exports aircraft positions as a GeoJSON
+// FeatureCollection of Points, one per aircraft, sorted by hex. Aircraft
+// without a position are left out and counted in skipped. Each trail in
+// trails (oldest point first) of an exported aircraft is added as a
+// LineString per unbroken stretch; pass nil to leave trails out.
+func ExportAircraftGeoJSON(aircraft map[string]*radar.Target, trails map[string][]radar.TrailPoint, directory string) (filename string, exported, skipped int, err error) {
+	collection, exported, skipped := aircraftGeoJSON(aircraft, trails)
+	filename = GenerateFilename("skyspy_aircraft", "geojson", directory)
+
+	jsonData, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to marshal GeoJSON: %w", err)
+	}
+
+	if err := writeFile(filename, jsonData); err != nil {
+		return "", 0, 0, err
+	}
+
+	return filename, exported, skipped, nil
+}
+
+// aircraftGeoJSON builds the feature collection for ExportAircraftGeoJSON
+func aircraftGeoJSON(aircraft map[string]*radar.Target, trails map[string][]radar.TrailPoint) (collection GeoJSONFeatureCollection, exported, skipped int) {
+	collection = GeoJSONFeatureCollection{Type: "FeatureCollection", Features: []GeoJSONFeature{}}
+
+	hexes := make([]string, 0, len(aircraft))
+	for hex := range aircraft {
+		hexes = append(hexes, hex)
+	}
+	sort.Strings(hexes)
+
+	for _, hex := range hexes {
+		ac := aircraft[hex]
+		if !ac.HasLat || !ac.HasLon {
+			skipped++
+			continue
+		}
+		exported++
+		collection.Features = append(collection.Features, GeoJSONFeature{
+			Type:       "Feature",
+			Geometry:   GeoJSONGeometry{Type: "Point", Coordinates: []float64{ac.Lon, ac.Lat}},
+			Properties: aircraftProperties(ac),
+		})
+
+		for _, line := range trailLines(trails[hex]) {
+			collection.Features = append(collection.Features, GeoJSONFeature{
+				Type:       "Feature",
+				Geometry:   GeoJSONGeometry{Type: "LineString", Coordinates: line.coords},
+				Properties: GeoJSONProperties{Kind: "trail", Hex: ac.Hex, Callsign: ac.Callsign, Segment: &line.segment},
+			})
+		}
+	}
+
+	return collection, exported, skipped
+}
+
+// aircraftProperties returns the properties of an aircraft's Point
+func aircraftProperties(ac *radar.Target) GeoJSONProperties {
+	props := GeoJSONProperties{
+		Kind:     "aircraft",
+		Hex:      ac.Hex,
+		Callsign: ac.Callsign,
+		Squawk:   ac.Squawk,
+		Military: &ac.Military,
+	}
+	if baro, ok := ac.BaroAlt(); ok {
+		props.Altitude = &baro
+	}
+	if ac.HasSpeed {
+		props.Speed = &ac.Speed
+	}
+	if ac.HasTrack {
+		props.Track = &ac.Track
+	}
+	if ac.HasRSSI {
+		props.RSSI = &ac.RSSI
+	}
+	return props
+}
+
+// trailLine is an unbroken stretch of a trail
+type trailLine struct {
+	segment int
+	coords  [][]float64 // [lon, lat] pairs
+}
+
+// trailLines splits a trail at its breaks into lines, dropping stretches
+// too short to draw
+func trailLines(trail []radar.TrailPoint) []trailLine {
+	var lines []trailLine
+	var line trailLine
+	flush := func() {
+		if len(line.coords) >= 2 {
+			lines = append(lines, line)
+		}
+		line = trailLine{}
+	}
+	for _, p := range trail {
+		if p.Break {
+			flush()
+		}
+		if len(line.coords) == 0 {
+			line.segment = p.Segment
+		}
+		line.coords = append(line.coords, []float64{p.Lon, p.Lat})
+	}
+	flush()
+	return lines
+}
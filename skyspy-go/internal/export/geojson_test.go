@@ -0,0 +1,116 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+func TestExportAircraftGeoJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	aircraft := map[string]*radar.Target{
+		"BBB222": {Hex: "BBB222", Callsign: "RCH42", Lat: 52.1, Lon: 4.5, Military: true,
+			HasLat: true, HasLon: true},
+		"AAA111": {Hex: "AAA111", Callsign: "KLM1234", Lat: 52.3, Lon: 4.9, Altitude: 12000, Speed: 310,
+			Track: 270, Squawk: "1000", RSSI: -12.5,
+			HasLat: true, HasLon: true, HasAlt: true, HasSpeed: true, HasTrack: true, HasRSSI: true},
+		"CCC333": {Hex: "CCC333", Callsign: "NOPOS"},
+	}
+	trails := map[string][]radar.TrailPoint{
+		"AAA111": {{Lat: 52.0, Lon: 4.0}, {Lat: 52.1, Lon: 4.1}, {Lat: 52.2, Lon: 4.2, Break: true, Segment: 1}, {Lat: 52.3, Lon: 4.3, Segment: 1}},
+		"BBB222": {{Lat: 52.1, Lon: 4.5}},
+		"CCC333": {{Lat: 51.0, Lon: 3.0}, {Lat: 51.1, Lon: 3.1}},
+	}
+
+	filename, exported, skipped, err := ExportAircraftGeoJSON(aircraft, trails, tmpDir)
+	if err != nil {
+		t.Fatalf("ExportAircraftGeoJSON failed: %v", err)
+	}
+	if exported != 2 || skipped != 1 {
+		t.Errorf("exported %d, skipped %d; want 2 and 1", exported, skipped)
+	}
+	if !strings.HasSuffix(filename, ".geojson") || filepath.Dir(filename) != tmpDir {
+		t.Errorf("filename = %q", filename)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(data, &collection); err != nil {
+		t.Fatalf("invalid GeoJSON: %v", err)
+	}
+	if collection.Type != "FeatureCollection" {
+		t.Errorf("type = %q", collection.Type)
+	}
+
+	// AAA111's point and two trail stretches, then BBB222's point; its
+	// one-point trail and CCC333, with no position, are left out
+	var kinds []string
+	for _, f := range collection.Features {
+		kinds = append(kinds, f.Properties["hex"].(string)+" "+f.Geometry.Type)
+	}
+	if got := strings.Join(kinds, ", "); got != "AAA111 Point, AAA111 LineString, AAA111 LineString, BBB222 Point" {
+		t.Fatalf("features = %s", got)
+	}
+
+	point := collection.Features[0]
+	var lonLat []float64
+	if err := json.Unmarshal(point.Geometry.Coordinates, &lonLat); err != nil || len(lonLat) != 2 || lonLat[0] != 4.9 || lonLat[1] != 52.3 {
+		t.Errorf("coordinates should be longitude first, got %v", lonLat)
+	}
+	props := point.Properties
+	if props["kind"] != "aircraft" || props["callsign"] != "KLM1234" || props["altitude"] != 12000.0 ||
+		props["speed"] != 310.0 || props["track"] != 270.0 || props["squawk"] != "1000" ||
+		props["military"] != false || props["rssi"] != -12.5 {
+		t.Errorf("properties = %v", props)
+	}
+	if _, ok := collection.Features[3].Properties["altitude"]; ok {
+		t.Error("unknown values should be left out")
+	}
+	if collection.Features[3].Properties["military"] != true {
+		t.Error("military should be set on BBB222")
+	}
+	trail := collection.Features[1]
+	var line [][]float64
+	if err := json.Unmarshal(trail.Geometry.Coordinates, &line); err != nil || len(line) != 2 || line[1][0] != 4.1 {
+		t.Errorf("the first trail stretch should end before the break, got %v", line)
+	}
+	if trail.Properties["kind"] != "trail" || trail.Properties["callsign"] != "KLM1234" || trail.Properties["segment"] != 0.0 {
+		t.Errorf("trail properties = %v", trail.Properties)
+	}
+	if segment := collection.Features[2].Properties["segment"]; segment != 1.0 {
+		t.Errorf("the second stretch should be segment 1, got %v", segment)
+	}
+}
+
+func TestExportAircraftGeoJSON_NoTrails(t *testing.T) {
+	aircraft := map[string]*radar.Target{
+		"AAA111": {Hex: "AAA111", Lat: 52.3, Lon: 4.9, HasLat: true, HasLon: true},
+	}
+	collection, exported, skipped := aircraftGeoJSON(aircraft, nil)
+	if exported != 1 || skipped != 0 || len(collection.Features) != 1 {
+		t.Errorf("exported %d, skipped %d, %d features", exported, skipped, len(collection.Features))
+	}
+
+	// An empty collection still has a features array
+	data, err := json.Marshal(GeoJSONFeatureCollection{Type: "FeatureCollection", Features: []GeoJSONFeature{}})
+	if err != nil || !strings.Contains(string(data), `"features":[]`) {
+		t.Errorf("empty collection = %s", data)
+	}
+}
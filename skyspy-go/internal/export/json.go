@@ -6,11 +6,10 @@ package export
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/units"
 )
 
 // AircraftExport represents aircraft data for JSON export
@@ -19,16 +18,34 @@ type AircraftExport struct {
 	Callsign     string   `json:"callsign,omitempty"`
 	Lat          *float64 `json:"lat,omitempty"`
 	Lon          *float64 `json:"lon,omitempty"`
-	Altitude     *int     `json:"altitude,omitempty"`
+	Altitude     *int     `json:"altitude,omitempty"` // barometric
+	GeomAltitude *int     `json:"geom_altitude,omitempty"`
 	Speed        *float64 `json:"speed,omitempty"`
 	Track        *float64 `json:"track,omitempty"`
 	VerticalRate *float64 `json:"vertical_rate,omitempty"`
 	Squawk       string   `json:"squawk,omitempty"`
 	DistanceNM   *float64 `json:"distance_nm,omitempty"`
+	DistanceKM   *float64 `json:"distance_km,omitempty"` // in place of distance_nm when Options.Units is km
+	DistanceMI   *float64 `json:"distance_mi,omitempty"` // or mi
 	Bearing      *float64 `json:"bearing,omitempty"`
 	Military     bool     `json:"military"`
 	RSSI         *float64 `json:"rssi,omitempty"`
 	AircraftType string   `json:"aircraft_type,omitempty"`
+	NavAltitude  *int     `json:"nav_altitude,omitempty"`
+	NavHeading   *float64 `json:"nav_heading,omitempty"`
+	NavQNH       *float64 `json:"nav_qnh,omitempty"`
+	NavModes     []string `json:"nav_modes,omitempty"`
+	FirstSeen    string   `json:"first_seen,omitempty"` // RFC 3339 UTC
+	TrackedNM    *float64 `json:"tracked_nm,omitempty"`
+	TrackedKM    *float64 `json:"tracked_km,omitempty"`
+	TrackedMI    *float64 `json:"tracked_mi,omitempty"`
+
+	// Only filled in when Options.CoordFormat is dms or mgrs
+	Position       string `json:"position,omitempty"`
+	PositionFormat string `json:"position_format,omitempty"`
+
+	// Only filled in when Options.SignalStats is set
+	Signal *SignalStatsExport `json:"signal,omitempty"`
 }
 
 // AircraftExportData represents the full JSON export structure
@@ -58,70 +75,21 @@ type ACARSExportItem struct {
 
 // ExportAircraftJSON exports aircraft data to pretty-printed JSON
 func ExportAircraftJSON(aircraft map[string]*radar.Target, directory string) (string, error) {
-	filename := GenerateFilename("skyspy_aircraft", "json", directory)
-
-	data := AircraftExportData{
-		Timestamp:     time.Now().Format(time.RFC3339),
-		ExportVersion: "1.0",
-		TotalAircraft: len(aircraft),
-		Aircraft:      make([]AircraftExport, 0, len(aircraft)),
-	}
-
-	for _, ac := range aircraft {
-		export := AircraftExport{
-			Hex:      ac.Hex,
-			Callsign: ac.Callsign,
-			Military: ac.Military,
-			Squawk:   ac.Squawk,
-		}
-
-		if ac.ACType != "" {
-			export.AircraftType = ac.ACType
-		}
-
-		if ac.HasLat {
-			export.Lat = &ac.Lat
-		}
-		if ac.HasLon {
-			export.Lon = &ac.Lon
-		}
-		if ac.HasAlt {
-			export.Altitude = &ac.Altitude
-		}
-		if ac.HasSpeed {
-			export.Speed = &ac.Speed
-		}
-		if ac.HasTrack {
-			export.Track = &ac.Track
-		}
-		if ac.HasVS {
-			export.VerticalRate = &ac.Vertical
-		}
-		if ac.HasRSSI {
-			export.RSSI = &ac.RSSI
-		}
-		if ac.Distance > 0 {
-			export.DistanceNM = &ac.Distance
-		}
-		if ac.Bearing > 0 {
-			export.Bearing = &ac.Bearing
-		}
+	return ExportAircraftJSONWithOptions(aircraft, directory, Options{})
+}
 
-		data.Aircraft = append(data.Aircraft, export)
-	}
+// ExportAircraftJSONWithOptions exports aircraft data to pretty-printed JSON,
+// including the optional fields selected by opts
+func ExportAircraftJSONWithOptions(aircraft map[string]*radar.Target, directory string, opts Options) (string, error) {
+	filename := GenerateFilename("skyspy_aircraft", "json", directory)
 
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	jsonData, err := json.MarshalIndent(aircraftExportData(aircraft, opts), "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil && filepath.Dir(filename) != "" && filepath.Dir(filename) != "." {
-		return "", fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	//nolint:gosec // G306: Export files are non-sensitive and can be world-readable
-	if err := os.WriteFile(filename, jsonData, 0o644); err != nil {
-		return "", fmt.Errorf("failed to write file: %w", err)
+	if err := writeFile(filename, jsonData); err != nil {
+		return "", err
 	}
 
 	return filename, nil
@@ -131,6 +99,16 @@ func ExportAircraftJSON(aircraft map[string]*radar.Target, directory string) (st
 //
 //nolint:revive // Function name is intentional for API clarity
 func ExportAircraftJSONToFile(aircraft map[string]*radar.Target, filename string) error {
+	jsonData, err := json.MarshalIndent(aircraftExportData(aircraft, Options{}), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return writeFile(filename, jsonData)
+}
+
+// aircraftExportData builds the JSON export structure for aircraft
+func aircraftExportData(aircraft map[string]*radar.Target, opts Options) AircraftExportData {
 	data := AircraftExportData{
 		Timestamp:     time.Now().Format(time.RFC3339),
 		ExportVersion: "1.0",
@@ -139,62 +117,107 @@ func ExportAircraftJSONToFile(aircraft map[string]*radar.Target, filename string
 	}
 
 	for _, ac := range aircraft {
-		export := AircraftExport{
-			Hex:      ac.Hex,
-			Callsign: ac.Callsign,
-			Military: ac.Military,
-			Squawk:   ac.Squawk,
-		}
-
-		if ac.ACType != "" {
-			export.AircraftType = ac.ACType
-		}
+		data.Aircraft = append(data.Aircraft, AircraftRecord(ac, opts))
+	}
 
-		if ac.HasLat {
-			export.Lat = &ac.Lat
-		}
-		if ac.HasLon {
-			export.Lon = &ac.Lon
-		}
-		if ac.HasAlt {
-			export.Altitude = &ac.Altitude
-		}
-		if ac.HasSpeed {
-			export.Speed = &ac.Speed
-		}
-		if ac.HasTrack {
-			export.Track = &ac.Track
-		}
-		if ac.HasVS {
-			export.VerticalRate = &ac.Vertical
-		}
-		if ac.HasRSSI {
-			export.RSSI = &ac.RSSI
-		}
-		if ac.Distance > 0 {
-			export.DistanceNM = &ac.Distance
-		}
-		if ac.Bearing > 0 {
-			export.Bearing = &ac.Bearing
-		}
+	return data
+}
 
-		data.Aircraft = append(data.Aircraft, export)
+// AircraftRecord returns one aircraft as it appears in the JSON export,
+// including the optional fields selected by opts. The record points into
+// ac, so pass a copy of a target that may still change.
+func AircraftRecord(ac *radar.Target, opts Options) AircraftExport {
+	export := AircraftExport{
+		Hex:      ac.Hex,
+		Callsign: ac.Callsign,
+		Military: ac.Military,
+		Squawk:   ac.Squawk,
 	}
 
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
+	if ac.ACType != "" {
+		export.AircraftType = ac.ACType
 	}
 
-	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil && filepath.Dir(filename) != "" && filepath.Dir(filename) != "." {
-		return fmt.Errorf("failed to create directory: %w", err)
+	if ac.HasLat {
+		export.Lat = &ac.Lat
+	}
+	if ac.HasLon {
+		export.Lon = &ac.Lon
+	}
+	if baro, ok := ac.BaroAlt(); ok {
+		export.Altitude = &baro
+	}
+	if ac.HasGeomAlt {
+		export.GeomAltitude = &ac.GeomAltitude
+	}
+	if ac.HasSpeed {
+		export.Speed = &ac.Speed
+	}
+	if ac.HasTrack {
+		export.Track = &ac.Track
+	}
+	if ac.HasVS {
+		export.VerticalRate = &ac.Vertical
+	}
+	if ac.HasRSSI {
+		export.RSSI = &ac.RSSI
+	}
+	unit := units.Distance(opts.Units)
+	distance, tracked := export.distanceFields(unit)
+	if ac.Distance > 0 {
+		d := units.FromNM(ac.Distance, unit)
+		*distance = &d
 	}
+	if ac.Bearing > 0 {
+		export.Bearing = &ac.Bearing
+	}
+	if ac.HasNavAlt {
+		export.NavAltitude = &ac.NavAltitude
+	}
+	if ac.HasNavHeading {
+		export.NavHeading = &ac.NavHeading
+	}
+	if ac.HasNavQNH {
+		export.NavQNH = &ac.NavQNH
+	}
+	export.NavModes = ac.NavModes
+	export.FirstSeen = formatTime(ac.FirstSeen)
+	if ac.TrackNM > 0 {
+		d := units.FromNM(ac.TrackNM, unit)
+		*tracked = &d
+	}
+	if opts.SignalStats {
+		export.Signal = signalExport(ac)
+	}
+	if opts.hasPosition() {
+		if export.Position = opts.formatPosition(ac); export.Position != "" {
+			export.PositionFormat = string(opts.CoordFormat)
+		}
+	}
+
+	return export
+}
 
-	//nolint:gosec // G306: Export files are non-sensitive and can be world-readable
-	if err := os.WriteFile(filename, jsonData, 0o644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+// distanceFields returns the distance and tracked distance fields for unit
+func (e *AircraftExport) distanceFields(unit string) (distance, tracked **float64) {
+	switch unit {
+	case units.KM:
+		return &e.DistanceKM, &e.TrackedKM
+	case units.MI:
+		return &e.DistanceMI, &e.TrackedMI
 	}
+	return &e.DistanceNM, &e.TrackedNM
+}
 
+// DistanceInNM returns the distance in nm, whichever unit it was exported
+// in, or nil without one
+func (e *AircraftExport) DistanceInNM() *float64 {
+	for _, unit := range units.DistanceUnits {
+		if d, _ := e.distanceFields(unit); *d != nil {
+			nm := units.ToNM(**d, unit)
+			return &nm
+		}
+	}
 	return nil
 }
 
@@ -229,13 +252,8 @@ func ExportACARSJSON(messages []ACARSMessage, directory string) (string, error)
 		return "", fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil && filepath.Dir(filename) != "" && filepath.Dir(filename) != "." {
-		return "", fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	//nolint:gosec // G306: Export files are non-sensitive and can be world-readable
-	if err := os.WriteFile(filename, jsonData, 0o644); err != nil {
-		return "", fmt.Errorf("failed to write file: %w", err)
+	if err := writeFile(filename, jsonData); err != nil {
+		return "", err
 	}
 
 	return filename, nil
@@ -270,14 +288,5 @@ func ExportACARSJSONToFile(messages []ACARSMessage, filename string) error {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil && filepath.Dir(filename) != "" && filepath.Dir(filename) != "." {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	//nolint:gosec // G306: Export files are non-sensitive and can be world-readable
-	if err := os.WriteFile(filename, jsonData, 0o644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	return nil
+	return writeFile(filename, jsonData)
 }
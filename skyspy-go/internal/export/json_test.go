@@ -535,6 +535,89 @@ func TestJSON_OptionalFieldsOmitted(t *testing.T) {
 	if strings.Contains(content, `"altitude":null`) {
 		t.Error("null altitude should be omitted from JSON")
 	}
+	if strings.Contains(content, `"nav_`) {
+		t.Error("absent nav fields should be omitted from JSON")
+	}
+}
+
+func TestExportAircraftJSON_NavFields(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	aircraft := map[string]*radar.Target{
+		"ABC123": {
+			Hex:         "ABC123",
+			NavAltitude: 4000,
+			NavQNH:      1013.2,
+			NavModes:    []string{"autopilot", "althold"},
+			HasNavAlt:   true,
+			HasNavQNH:   true,
+		},
+	}
+
+	filename, err := ExportAircraftJSON(aircraft, tmpDir)
+	if err != nil {
+		t.Fatalf("ExportAircraftJSON failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read exported file: %v", err)
+	}
+
+	var exportData AircraftExportData
+	if err := json.Unmarshal(data, &exportData); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	ac := exportData.Aircraft[0]
+	if ac.NavAltitude == nil || *ac.NavAltitude != 4000 {
+		t.Errorf("expected nav_altitude 4000, got %v", ac.NavAltitude)
+	}
+	if ac.NavQNH == nil || *ac.NavQNH != 1013.2 {
+		t.Errorf("expected nav_qnh 1013.2, got %v", ac.NavQNH)
+	}
+	if ac.NavHeading != nil {
+		t.Errorf("expected nav_heading omitted, got %v", *ac.NavHeading)
+	}
+	if len(ac.NavModes) != 2 {
+		t.Errorf("expected 2 nav modes, got %v", ac.NavModes)
+	}
+}
+
+func TestExportAircraftJSON_Altitudes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	aircraft := map[string]*radar.Target{
+		"BOTH01": {
+			Hex:          "BOTH01",
+			Altitude:     35450,
+			BaroAltitude: 35000,
+			GeomAltitude: 35450,
+			HasAlt:       true,
+			HasBaroAlt:   true,
+			HasGeomAlt:   true,
+		},
+	}
+
+	filename, err := ExportAircraftJSON(aircraft, tmpDir)
+	if err != nil {
+		t.Fatalf("ExportAircraftJSON failed: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read exported file: %v", err)
+	}
+
+	var exportData AircraftExportData
+	if err := json.Unmarshal(data, &exportData); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	ac := exportData.Aircraft[0]
+	if ac.Altitude == nil || *ac.Altitude != 35000 {
+		t.Errorf("expected baro altitude 35000, got %v", ac.Altitude)
+	}
+	if ac.GeomAltitude == nil || *ac.GeomAltitude != 35450 {
+		t.Errorf("expected geom_altitude 35450, got %v", ac.GeomAltitude)
+	}
 }
 
 func TestExportAircraftJSON_CreatesDirectory(t *testing.T) {
@@ -1208,3 +1291,45 @@ func TestExportACARSJSONToFile_WriteFileError(t *testing.T) {
 		t.Log("expected error when writing to read-only directory (may pass as root)")
 	}
 }
+
+func TestExportAircraft_Tracking(t *testing.T) {
+	tmpDir := t.TempDir()
+	firstSeen := time.Date(2026, 3, 1, 11, 37, 0, 0, time.FixedZone("CET", 3600))
+	aircraft := map[string]*radar.Target{
+		"TRK001": {Hex: "TRK001", FirstSeen: firstSeen, TrackNM: 187.25},
+		"NEW001": {Hex: "NEW001"},
+	}
+
+	filename, err := ExportAircraftJSON(aircraft, tmpDir)
+	if err != nil {
+		t.Fatalf("ExportAircraftJSON failed: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read exported file: %v", err)
+	}
+	var exportData AircraftExportData
+	if err := json.Unmarshal(data, &exportData); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	for _, ac := range exportData.Aircraft {
+		switch ac.Hex {
+		case "TRK001":
+			if ac.FirstSeen != "2026-03-01T10:37:00Z" || ac.TrackedNM == nil || *ac.TrackedNM != 187.25 {
+				t.Errorf("unexpected tracking %q %v", ac.FirstSeen, ac.TrackedNM)
+			}
+		case "NEW001":
+			if ac.FirstSeen != "" || ac.TrackedNM != nil {
+				t.Error("untracked fields should be omitted")
+			}
+		}
+	}
+
+	var buf strings.Builder
+	if err := WriteAircraftCSV(&buf, []*radar.Target{aircraft["TRK001"]}, ""); err != nil {
+		t.Fatalf("WriteAircraftCSV failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), ",2026-03-01T10:37:00Z,187.250000,") {
+		t.Errorf("CSV should carry first_seen and tracked_nm:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,27 @@
+// Package export provides export functionality for SkySpy CLI
+package export
+
+import (
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// hasPosition reports whether opts asks for a formatted position column
+func (o Options) hasPosition() bool {
+	return o.CoordFormat != "" && o.CoordFormat != geo.CoordDecimal
+}
+
+// positionColumn is the header for the formatted position, e.g.
+// position_mgrs
+func (o Options) positionColumn() string {
+	return "position_" + string(o.CoordFormat)
+}
+
+// formatPosition writes ac's position in the format selected by opts, or
+// "" without a position
+func (o Options) formatPosition(ac *radar.Target) string {
+	if !ac.HasLat || !ac.HasLon {
+		return ""
+	}
+	return geo.FormatCoords(ac.Lat, ac.Lon, o.CoordFormat)
+}
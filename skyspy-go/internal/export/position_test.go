@@ -0,0 +1,93 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+func positionTarget() *radar.Target {
+	return &radar.Target{Hex: "ABC123", Lat: 38.889467, Lon: -77.035239, HasLat: true, HasLon: true}
+}
+
+func TestExportAircraftWithOptions_CoordFormat(t *testing.T) {
+	dir := t.TempDir()
+	aircraft := map[string]*radar.Target{"ABC123": positionTarget()}
+
+	filename, err := ExportAircraftWithOptions(aircraft, dir, Options{CoordFormat: geo.CoordMGRS})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("failed to open export: %v", err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+
+	n := len(aircraftHeader)
+	if len(records[0]) != n+1 || records[0][n] != "position_mgrs" {
+		t.Fatalf("unexpected header %v", records[0])
+	}
+	if !strings.HasPrefix(records[1][n], "18S UJ 234") {
+		t.Errorf("unexpected MGRS column %q", records[1][n])
+	}
+	if records[1][2] != "38.889467" {
+		t.Errorf("decimal lat should be kept, got %q", records[1][2])
+	}
+}
+
+func TestExportAircraftWithOptions_DecimalAddsNoColumn(t *testing.T) {
+	dir := t.TempDir()
+	aircraft := map[string]*radar.Target{"ABC123": positionTarget()}
+
+	filename, err := ExportAircraftWithOptions(aircraft, dir, Options{CoordFormat: geo.CoordDecimal})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	content, _ := os.ReadFile(filename)
+	if strings.Contains(string(content), "position_") {
+		t.Error("decimal format should not add a position column")
+	}
+}
+
+func TestExportAircraftJSONWithOptions_CoordFormat(t *testing.T) {
+	dir := t.TempDir()
+	aircraft := map[string]*radar.Target{
+		"ABC123": positionTarget(),
+		"DEF456": {Hex: "DEF456"},
+	}
+
+	filename, err := ExportAircraftJSONWithOptions(aircraft, dir, Options{CoordFormat: geo.CoordDMS})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	content, _ := os.ReadFile(filename)
+	var data AircraftExportData
+	if err := json.Unmarshal(content, &data); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, ac := range data.Aircraft {
+		switch ac.Hex {
+		case "ABC123":
+			if ac.Position != "38°53'22\"N 77°02'07\"W" || ac.PositionFormat != "dms" {
+				t.Errorf("unexpected position %q (%s)", ac.Position, ac.PositionFormat)
+			}
+			if ac.Lat == nil || *ac.Lat != 38.889467 {
+				t.Error("decimal lat should be kept")
+			}
+		case "DEF456":
+			if ac.Position != "" || ac.PositionFormat != "" {
+				t.Error("aircraft without a position should have no formatted position")
+			}
+		}
+	}
+}
@@ -6,7 +6,6 @@ package export
 import (
 	"fmt"
 	"html"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -274,6 +273,14 @@ var ansiColorMap = map[string]string{
 	"255": "#eeeeee",
 }
 
+// Escape sequences in rendered content. Only SGR (color and style) codes
+// become HTML. OSC sequences such as hyperlinks are dropped whole; of any
+// other sequence only the ESC is dropped, leaving the rest as plain text.
+var (
+	sgrSequence = regexp.MustCompile(`^\x1b\[([0-9;]*)m`)
+	oscSequence = regexp.MustCompile(`^\x1b\][^\x07\x1b]*(\x07|\x1b\\)`)
+)
+
 // GenerateFilename generates a filename with timestamp
 func GenerateFilename(prefix, extension, directory string) string {
 	timestamp := time.Now().Format("20060102_150405")
@@ -294,16 +301,7 @@ func SaveAsText(content string, filename string) error {
 	ansiRegex := regexp.MustCompile(`\x1b\[[0-9;]*m`)
 	plainText := ansiRegex.ReplaceAllString(content, "")
 
-	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil && filepath.Dir(filename) != "" && filepath.Dir(filename) != "." {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	//nolint:gosec // G306: Screenshot exports are non-sensitive
-	if err := os.WriteFile(filename, []byte(plainText), 0o644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	return nil
+	return writeFile(filename, []byte(plainText))
 }
 
 // SaveAsHTML saves content as styled HTML with ANSI colors converted
@@ -314,16 +312,7 @@ func SaveAsHTML(content string, filename string) error {
 
 	htmlContent := convertANSIToHTML(content)
 
-	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil && filepath.Dir(filename) != "" && filepath.Dir(filename) != "." {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	//nolint:gosec // G306: Screenshot exports are non-sensitive
-	if err := os.WriteFile(filename, []byte(htmlContent), 0o644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	return nil
+	return writeFile(filename, []byte(htmlContent))
 }
 
 // CaptureScreen saves the current view as both text and HTML
@@ -402,29 +391,30 @@ func parseANSI(content string) string {
 	var currentFg, currentBg string
 	var bold, dim, italic, underline, blink, reverse bool
 
-	// ANSI escape sequence regex
-	ansiRegex := regexp.MustCompile(`\x1b\[([0-9;]*)m`)
-
 	i := 0
 	for i < len(content) {
-		// Check for escape sequence
-		if i+1 < len(content) && content[i] == '\x1b' && content[i+1] == '[' {
-			// Find end of sequence
-			loc := ansiRegex.FindStringIndex(content[i:])
-			if loc != nil {
-				match := ansiRegex.FindStringSubmatch(content[i:])
-				if len(match) > 1 {
-					codes := strings.Split(match[1], ";")
-					processCodes(codes, &currentFg, &currentBg, &bold, &dim, &italic, &underline, &blink, &reverse)
-				}
+		// Callsigns and ACARS text are untrusted radio data, so nothing
+		// but color codes may pass through as escapes
+		if content[i] == '\x1b' {
+			if match := sgrSequence.FindStringSubmatch(content[i:]); match != nil {
+				codes := strings.Split(match[1], ";")
+				processCodes(codes, &currentFg, &currentBg, &bold, &dim, &italic, &underline, &blink, &reverse)
+				i += len(match[0])
+			} else if loc := oscSequence.FindStringIndex(content[i:]); loc != nil {
 				i += loc[1]
-				continue
+			} else {
+				i++
 			}
+			continue
 		}
 
 		// Write the character with current style (decode full rune so
 		// multi-byte UTF-8 glyphs are not split across spans)
 		r, size := utf8.DecodeRuneInString(content[i:])
+		if hiddenControl(r) {
+			i += size
+			continue
+		}
 		char := string(r)
 
 		// HTML escape
@@ -443,6 +433,22 @@ func parseANSI(content string) string {
 	return result.String()
 }
 
+// hiddenControl reports whether r is a non-printing control character that
+// could garble or disguise the exported text: C0 and C1 controls other than
+// tab and newline, and the bidirectional overrides and isolates that can
+// make a callsign read backwards
+func hiddenControl(r rune) bool {
+	switch {
+	case r == '\n', r == '\t':
+		return false
+	case r < 0x20, r >= 0x7f && r <= 0x9f:
+		return true
+	case r >= 0x202a && r <= 0x202e, r >= 0x2066 && r <= 0x2069:
+		return true
+	}
+	return false
+}
+
 // processCodes processes ANSI SGR codes and updates state
 func processCodes(codes []string, fg, bg *string, bold, dim, italic, underline, blink, reverse *bool) {
 	i := 0
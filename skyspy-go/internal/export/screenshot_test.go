@@ -3,6 +3,7 @@ package export
 import (
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -1109,3 +1110,80 @@ func TestSaveAsHTML_WriteFileError(t *testing.T) {
 		t.Log("expected error when writing to read-only directory (may pass as root)")
 	}
 }
+
+// Test that untrusted callsigns and ACARS text come out inert
+func TestConvertANSIToHTML_MaliciousText(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		visible string   // text that must still appear, after HTML escaping
+		banned  []string // markup or characters that must not appear
+	}{
+		{
+			name:    "script tag",
+			input:   "<script>alert('x')</script>",
+			visible: "&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt;",
+			banned:  []string{"<script", "</script"},
+		},
+		{
+			name:    "CDATA terminator",
+			input:   "UAL]]>123",
+			visible: "UAL]]&gt;123",
+			banned:  []string{"]]>"},
+		},
+		{
+			name:    "attribute breakout",
+			input:   `"><img src=x onerror=alert(1)>`,
+			visible: "&#34;&gt;&lt;img src=x onerror=alert(1)&gt;",
+			banned:  []string{"<img"},
+		},
+		{
+			name:    "RTL override",
+			input:   "KLM‮321‬⁦X⁩",
+			visible: "KLM321X",
+			banned:  []string{"‮", "‬", "⁦", "⁩"},
+		},
+		{
+			name:    "control characters",
+			input:   "BA\x00W\x07\x08\r\u009b1\x7f",
+			visible: "BAW1",
+			banned:  []string{"\x00", "\x07", "\x08", "\r", "\u009b", "\x7f"},
+		},
+		{
+			name:    "hyperlink and cursor escapes",
+			input:   "\x1b]8;;https://evil.example\x07DLH\x1b]8;;\x07\x1b[2J4",
+			visible: "DLH[2J4",
+			banned:  []string{"\x1b", "evil.example"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Plain and inside a color span, as a callsign would be drawn
+			for _, content := range []string{tt.input, "\x1b[38;5;46m" + tt.input + "\x1b[0m"} {
+				got := convertANSIToHTML(content)
+				body := got[strings.Index(got, "<pre>")+len("<pre>") : strings.LastIndex(got, "</pre>")]
+				// Once the exporter's own spans are gone, no markup is left
+				plain := regexp.MustCompile(`</?span[^>]*>`).ReplaceAllString(body, "")
+				if strings.ContainsAny(plain, `<>"`) {
+					t.Errorf("output contains raw markup: %q", plain)
+				}
+				for _, bad := range tt.banned {
+					if strings.Contains(plain, bad) {
+						t.Errorf("output contains %q: %q", bad, plain)
+					}
+				}
+				if plain != tt.visible {
+					t.Errorf("visible text = %q, want %q", plain, tt.visible)
+				}
+			}
+		})
+	}
+}
+
+// Test that tabs and newlines survive control character stripping
+func TestParseANSI_KeepsLayoutWhitespace(t *testing.T) {
+	if got := parseANSI("A\tB\nC"); got != "A\tB\nC" {
+		t.Errorf("parseANSI() = %q, want tabs and newlines kept", got)
+	}
+}
@@ -0,0 +1,95 @@
+// Package export provides export functionality for SkySpy CLI
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// Session log formats
+const (
+	SessionNDJSON = "ndjson"
+	SessionCSV    = "csv"
+)
+
+// SessionFormat returns the session log format named by s, NDJSON unless
+// it's "csv"
+func SessionFormat(s string) string {
+	if s == SessionCSV {
+		return SessionCSV
+	}
+	return SessionNDJSON
+}
+
+// sessionColumns are the session log's CSV columns
+var sessionColumns = []string{
+	"time", "event", "hex", "flight", "lat", "lon", "alt_baro", "alt_geom", "on_ground",
+	"gs", "track", "baro_rate", "squawk", "rssi", "military", "category", "type", "registration",
+}
+
+// SessionHeader returns the line that starts each file of a session log:
+// the CSV header, or nothing for NDJSON
+func SessionHeader(format string) []byte {
+	if format != SessionCSV {
+		return nil
+	}
+	return csvLine(sessionColumns)
+}
+
+// sessionRecord is a line of an NDJSON session log
+type sessionRecord struct {
+	Time     string          `json:"time"`
+	Event    string          `json:"event"`
+	OnGround bool            `json:"on_ground,omitempty"`
+	Aircraft *codec.Aircraft `json:"aircraft"`
+}
+
+// SessionLine returns one aircraft message as a line of a session log,
+// without the newline. event is the message type, such as aircraft:update.
+func SessionLine(format string, at time.Time, event string, ac *codec.Aircraft) ([]byte, error) {
+	stamp := at.UTC().Format(time.RFC3339Nano)
+	if format != SessionCSV {
+		return json.Marshal(sessionRecord{Time: stamp, Event: event, OnGround: ac.OnGround, Aircraft: ac})
+	}
+
+	line := csvLine([]string{
+		stamp, event, csvText(ac.Hex), csvText(ac.Flight),
+		optFloat(ac.Lat, -1), optFloat(ac.Lon, -1),
+		optInt(ac.AltBaro), optInt(ac.AltGeom), strconv.FormatBool(ac.OnGround),
+		optFloat(ac.GS, 1), optFloat(ac.Track, 1), optFloat(ac.BaroRate, 0),
+		csvText(ac.Squawk), optFloat(ac.RSSI, 1), strconv.FormatBool(ac.Military),
+		csvText(ac.Category), csvText(ac.Type), csvText(ac.Reg),
+	})
+	return bytes.TrimSuffix(line, []byte("\n")), nil
+}
+
+// csvLine writes one CSV record with its newline
+func csvLine(fields []string) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(fields)
+	w.Flush()
+	return buf.Bytes()
+}
+
+// optFloat formats an optional value with prec decimals, -1 for as many
+// as it needs, or "" when unset
+func optFloat(v *float64, prec int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', prec, 64)
+}
+
+// optInt formats an optional value, or "" when unset
+func optInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
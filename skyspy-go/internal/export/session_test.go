@@ -0,0 +1,93 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+func sessionAircraft() *codec.Aircraft {
+	lat, lon, gs := 52.3676, 4.9041, 251.5
+	alt := 3500
+	return &codec.Aircraft{
+		Hex: "4840D6", Flight: "=KLM1023", Lat: &lat, Lon: &lon, AltBaro: &alt,
+		GS: &gs, Squawk: "1000", Military: true, Reg: "PH-BXA",
+	}
+}
+
+func TestSessionLine_NDJSON(t *testing.T) {
+	at := time.Date(2024, 6, 1, 14, 0, 5, 250e6, time.FixedZone("CEST", 2*3600))
+	line, err := SessionLine(SessionNDJSON, at, "aircraft:update", sessionAircraft())
+	if err != nil {
+		t.Fatalf("SessionLine failed: %v", err)
+	}
+	if strings.Contains(string(line), "\n") {
+		t.Error("the line should come without a newline")
+	}
+
+	var record struct {
+		Time     string         `json:"time"`
+		Event    string         `json:"event"`
+		Aircraft map[string]any `json:"aircraft"`
+	}
+	if err := json.Unmarshal(line, &record); err != nil {
+		t.Fatalf("line isn't JSON: %v\n%s", err, line)
+	}
+	if record.Time != "2024-06-01T12:00:05.25Z" || record.Event != "aircraft:update" {
+		t.Errorf("time %q event %q", record.Time, record.Event)
+	}
+	// The aircraft is as the feed sends it
+	if record.Aircraft["hex"] != "4840D6" || record.Aircraft["alt_baro"] != 3500.0 || record.Aircraft["r"] != "PH-BXA" {
+		t.Errorf("aircraft = %v", record.Aircraft)
+	}
+}
+
+func TestSessionLine_CSV(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 5, 0, time.UTC)
+	line, err := SessionLine(SessionCSV, at, "aircraft:new", sessionAircraft())
+	if err != nil {
+		t.Fatalf("SessionLine failed: %v", err)
+	}
+
+	header, err := csv.NewReader(strings.NewReader(string(SessionHeader(SessionCSV)))).Read()
+	if err != nil {
+		t.Fatalf("header isn't CSV: %v", err)
+	}
+	row, err := csv.NewReader(strings.NewReader(string(line))).Read()
+	if err != nil {
+		t.Fatalf("line isn't CSV: %v", err)
+	}
+	if len(row) != len(header) {
+		t.Fatalf("%d fields for %d columns", len(row), len(header))
+	}
+
+	got := make(map[string]string, len(row))
+	for i, col := range header {
+		got[col] = row[i]
+	}
+	want := map[string]string{
+		"time": "2024-06-01T12:00:05Z", "event": "aircraft:new", "hex": "4840D6",
+		"flight": "'=KLM1023", "lat": "52.3676", "lon": "4.9041", "alt_baro": "3500",
+		"alt_geom": "", "gs": "251.5", "military": "true", "registration": "PH-BXA",
+	}
+	for col, v := range want {
+		if got[col] != v {
+			t.Errorf("%s = %q, want %q", col, got[col], v)
+		}
+	}
+}
+
+func TestSessionFormat(t *testing.T) {
+	for in, want := range map[string]string{"csv": SessionCSV, "ndjson": SessionNDJSON, "": SessionNDJSON} {
+		if got := SessionFormat(in); got != want {
+			t.Errorf("SessionFormat(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if SessionHeader(SessionNDJSON) != nil {
+		t.Error("NDJSON logs have no header")
+	}
+}
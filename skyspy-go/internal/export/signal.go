@@ -0,0 +1,154 @@
+// Package export provides export functionality for SkySpy CLI
+//
+//nolint:revive // Export* function names are intentional for API clarity
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/units"
+)
+
+// Options selects optional columns for aircraft exports. The zero value
+// gives the default export.
+type Options struct {
+	// SignalStats adds lifetime RSSI min/max/average and sample count
+	SignalStats bool
+	// CoordFormat adds the position written in this format alongside the
+	// decimal lat/lon; empty or decimal adds nothing
+	CoordFormat geo.CoordFormat
+	// Units is the unit of the distance and tracked distance: nm, km or
+	// mi, named in the column or field, e.g. distance_km; empty for nm
+	Units string
+}
+
+// signalHeader is appended to aircraftHeader when Options.SignalStats is set
+var signalHeader = []string{
+	"rssi_min",
+	"rssi_max",
+	"rssi_avg",
+	"rssi_samples",
+}
+
+// signalRow formats ac's signal stats to match signalHeader
+func signalRow(ac *radar.Target) []string {
+	s := ac.Signal
+	has := s.Samples > 0
+	return []string{
+		formatFloat(s.Min, has),
+		formatFloat(s.Max, has),
+		formatFloat(s.Avg, has),
+		strconv.Itoa(s.Samples),
+	}
+}
+
+// SignalStatsExport is the JSON form of a target's signal stats
+type SignalStatsExport struct {
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Avg     float64 `json:"avg"`
+	Samples int     `json:"samples"`
+}
+
+// signalExport returns ac's signal stats for JSON, or nil without samples
+func signalExport(ac *radar.Target) *SignalStatsExport {
+	s := ac.Signal
+	if s.Samples == 0 {
+		return nil
+	}
+	return &SignalStatsExport{Min: s.Min, Max: s.Max, Avg: s.Avg, Samples: s.Samples}
+}
+
+// SignalEntry is one aircraft in the weakest-signal list
+type SignalEntry struct {
+	Hex      string
+	Callsign string
+	Stats    radar.SignalStats
+}
+
+// SectorEntry is the farthest aircraft heard in one bearing sector; Hex is
+// empty if nothing was heard there
+type SectorEntry struct {
+	Start    int // first bearing of the sector in degrees
+	Hex      string
+	Callsign string
+	Distance float64
+	Bearing  float64
+}
+
+// SignalReport summarises a session's reception for antenna tuning
+type SignalReport struct {
+	Weakest  []SignalEntry
+	Farthest []SectorEntry
+	Units    string // unit the farthest distances are written in; empty for nm
+}
+
+// HasSectors reports whether any sector has a record
+func (r SignalReport) HasSectors() bool {
+	for _, s := range r.Farthest {
+		if s.Hex != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteSignalReport writes the report as plain text to w
+func WriteSignalReport(w io.Writer, r SignalReport) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "SkySpy signal report  %s\n\n", time.Now().Format(time.RFC3339))
+
+	sb.WriteString("WEAKEST AVERAGE SIGNAL\n")
+	fmt.Fprintf(&sb, "%-3s %-7s %-8s %7s %7s %7s %7s\n", "#", "HEX", "CALLSIGN", "AVG", "MIN", "MAX", "SAMPLES")
+	if len(r.Weakest) == 0 {
+		sb.WriteString("(no RSSI data)\n")
+	}
+	for i, e := range r.Weakest {
+		fmt.Fprintf(&sb, "%-3d %-7s %-8s %7.1f %7.1f %7.1f %7d\n",
+			i+1, strings.ToUpper(e.Hex), reportCallsign(e.Callsign), e.Stats.Avg, e.Stats.Min, e.Stats.Max, e.Stats.Samples)
+	}
+
+	sb.WriteString("\nFARTHEST PER SECTOR\n")
+	unit := units.Distance(r.Units)
+	fmt.Fprintf(&sb, "%-7s %-7s %-8s %7s %5s\n", "SECTOR", "HEX", "CALLSIGN", "DIST "+strings.ToUpper(unit), "BRG")
+	for _, s := range r.Farthest {
+		sector := fmt.Sprintf("%03d-%03d", s.Start, s.Start+360/max(len(r.Farthest), 1))
+		if s.Hex == "" {
+			fmt.Fprintf(&sb, "%-7s %-7s\n", sector, "-")
+			continue
+		}
+		fmt.Fprintf(&sb, "%-7s %-7s %-8s %7.1f %5.0f\n",
+			sector, strings.ToUpper(s.Hex), reportCallsign(s.Callsign), units.FromNM(s.Distance, unit), s.Bearing)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func reportCallsign(cs string) string {
+	if cs == "" {
+		return "-"
+	}
+	return cs
+}
+
+// ExportSignalReport writes the report to a timestamped text file
+func ExportSignalReport(r SignalReport, directory string) (string, error) {
+	filename := GenerateFilename("skyspy_signal", "txt", directory)
+
+	var buf bytes.Buffer
+	if err := WriteSignalReport(&buf, r); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := writeFile(filename, buf.Bytes()); err != nil {
+		return "", err
+	}
+	return filename, nil
+}
@@ -0,0 +1,195 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+func signalTarget() *radar.Target {
+	ac := &radar.Target{Hex: "ABC123", Callsign: "WEAK1", RSSI: -30, HasRSSI: true}
+	ac.Signal = radar.SignalStats{Min: -32.5, Max: -18, Avg: -27.25, Samples: 14}
+	return ac
+}
+
+func TestExportAircraftWithOptions_SignalStats(t *testing.T) {
+	dir := t.TempDir()
+	aircraft := map[string]*radar.Target{"ABC123": signalTarget()}
+
+	filename, err := ExportAircraftWithOptions(aircraft, dir, Options{SignalStats: true})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("failed to open export: %v", err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+
+	n := len(aircraftHeader)
+	if len(records[0]) != n+len(signalHeader) || records[0][n] != "rssi_min" {
+		t.Fatalf("unexpected header %v", records[0])
+	}
+	if got := records[1][n:]; strings.Join(got, ",") != "-32.500000,-18.000000,-27.250000,14" {
+		t.Errorf("unexpected signal columns %v", got)
+	}
+}
+
+func TestExportAircraft_DefaultHasNoSignalStats(t *testing.T) {
+	dir := t.TempDir()
+	aircraft := map[string]*radar.Target{"ABC123": signalTarget()}
+
+	filename, err := ExportAircraft(aircraft, dir)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	content, _ := os.ReadFile(filename)
+	if strings.Contains(string(content), "rssi_min") {
+		t.Error("default CSV export should not include signal stats")
+	}
+
+	filename, err = ExportAircraftJSON(aircraft, dir)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	content, _ = os.ReadFile(filename)
+	if strings.Contains(string(content), `"signal"`) {
+		t.Error("default JSON export should not include signal stats")
+	}
+}
+
+func TestExportAircraftJSONWithOptions_SignalStats(t *testing.T) {
+	dir := t.TempDir()
+	aircraft := map[string]*radar.Target{
+		"ABC123": signalTarget(),
+		"DEF456": {Hex: "DEF456"},
+	}
+
+	filename, err := ExportAircraftJSONWithOptions(aircraft, dir, Options{SignalStats: true})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	content, _ := os.ReadFile(filename)
+	var data AircraftExportData
+	if err := json.Unmarshal(content, &data); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, ac := range data.Aircraft {
+		switch ac.Hex {
+		case "ABC123":
+			if ac.Signal == nil || ac.Signal.Avg != -27.25 || ac.Signal.Samples != 14 {
+				t.Errorf("unexpected signal stats %+v", ac.Signal)
+			}
+		case "DEF456":
+			if ac.Signal != nil {
+				t.Error("aircraft without samples should have no signal stats")
+			}
+		}
+	}
+}
+
+func TestWriteSignalReport(t *testing.T) {
+	report := SignalReport{
+		Weakest: []SignalEntry{{Hex: "abc123", Callsign: "WEAK1", Stats: signalTarget().Signal}},
+		Farthest: []SectorEntry{
+			{Start: 0, Hex: "def456", Distance: 182.4, Bearing: 17},
+			{Start: 180},
+		},
+	}
+	if !report.HasSectors() {
+		t.Error("expected sector records")
+	}
+
+	var sb strings.Builder
+	if err := WriteSignalReport(&sb, report); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	out := sb.String()
+	for _, want := range []string{"WEAKEST AVERAGE SIGNAL", "ABC123", "WEAK1", "-27.2", "FARTHEST PER SECTOR", "000-180 DEF456", "182.4", "180-360 -"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportSignalReport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+
+	filename, err := ExportSignalReport(SignalReport{}, dir)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if !strings.HasPrefix(filepath.Base(filename), "skyspy_signal_") {
+		t.Errorf("unexpected filename %s", filename)
+	}
+	content, _ := os.ReadFile(filename)
+	if !strings.Contains(string(content), "(no RSSI data)") {
+		t.Errorf("expected empty marker, got:\n%s", content)
+	}
+}
+
+func TestExportAircraftWithOptions_Units(t *testing.T) {
+	dir := t.TempDir()
+	aircraft := map[string]*radar.Target{"ABC123": {Hex: "ABC123", Distance: 10, TrackNM: 100}}
+
+	filename, err := ExportAircraftWithOptions(aircraft, dir, Options{Units: "km"})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("failed to open export: %v", err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	header := strings.Join(records[0], ",")
+	if strings.Contains(header, "_nm") || !strings.Contains(header, "distance_km") || !strings.Contains(header, "tracked_km") {
+		t.Fatalf("unexpected header %v", records[0])
+	}
+	if row := strings.Join(records[1], ","); !strings.Contains(row, "18.520000") || !strings.Contains(row, "185.200000") {
+		t.Errorf("distances should be in km: %v", records[1])
+	}
+
+	filename, err = ExportAircraftJSONWithOptions(aircraft, dir, Options{Units: "mi"})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	content, _ := os.ReadFile(filename)
+	var data AircraftExportData
+	if err := json.Unmarshal(content, &data); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	ac := data.Aircraft[0]
+	if ac.DistanceNM != nil || ac.DistanceMI == nil || ac.TrackedMI == nil {
+		t.Fatalf("distances should be in mi: %+v", ac)
+	}
+	if d := ac.DistanceInNM(); d == nil || *d < 9.999 || *d > 10.001 {
+		t.Errorf("DistanceInNM = %v, want 10", d)
+	}
+}
+
+func TestWriteSignalReport_Units(t *testing.T) {
+	report := SignalReport{
+		Farthest: []SectorEntry{{Start: 0, Hex: "def456", Distance: 100, Bearing: 17}},
+		Units:    "km",
+	}
+	var sb strings.Builder
+	if err := WriteSignalReport(&sb, report); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if out := sb.String(); !strings.Contains(out, "DIST KM") || !strings.Contains(out, "185.2") {
+		t.Errorf("report should be in km:\n%s", out)
+	}
+}
@@ -0,0 +1,197 @@
+// Package export provides export functionality for SkySpy CLI
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/geo"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/trails"
+)
+
+// StateFormat is the version of the state file format written. Files from
+// a newer version are refused rather than half read.
+const StateFormat = 1
+
+// StateLogLines is how many recent debug log entries a state file keeps
+const StateLogLines = 100
+
+// State is a snapshot of the radar for a bug report: the settings, what
+// was being tracked and how it was being shown. Loading it draws the same
+// screen without the feed.
+type State struct {
+	Format   int                          `json:"format"`
+	Version  string                       `json:"version,omitempty"` // SkySpy version that wrote it
+	Created  time.Time                    `json:"created"`
+	Config   *config.Config               `json:"config"`
+	Aircraft []StateAircraft              `json:"aircraft"`
+	Trails   map[string][]trails.Position `json:"trails,omitempty"`
+	View     StateView                    `json:"view"`
+	Log      []string                     `json:"log,omitempty"` // oldest first
+
+	// The receiver position was replaced by an approximate one
+	PositionStripped bool `json:"position_stripped,omitempty"`
+}
+
+// StateAircraft is a tracked aircraft and when it was last heard
+type StateAircraft struct {
+	radar.Target
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// StateView is how the radar was being shown
+type StateView struct {
+	Mode     int      `json:"mode"` // the app's view mode
+	Width    int      `json:"width"`
+	Height   int      `json:"height"`
+	Theme    string   `json:"theme"` // theme key in use; empty for the default
+	Selected string   `json:"selected,omitempty"`
+	Follow   string   `json:"follow,omitempty"`
+	Pinned   []string `json:"pinned,omitempty"`
+	Filter   string   `json:"filter,omitempty"` // search filter query
+}
+
+// secretPatterns match credentials that may turn up in log entries, with
+// the part to keep as the first group
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[^\s"',]+`),
+	regexp.MustCompile(`(?i)((?:api[_-]?key|access[_-]?token|refresh[_-]?token|id[_-]?token|token|password|secret|unlock)["']?\s*[:=]\s*["']?)[^\s"'&,]+`),
+	regexp.MustCompile(`()\bsk_[A-Za-z0-9_-]+`),
+}
+
+// ScrubSecrets replaces API keys, tokens and passwords in s with
+// [redacted]
+func ScrubSecrets(s string) string {
+	for _, re := range secretPatterns {
+		s = re.ReplaceAllString(s, "${1}[redacted]")
+	}
+	return s
+}
+
+// Sanitize strips what shouldn't leave the reporter's machine: the kiosk
+// unlock keys, the servers' API keys and any credentials in the log
+// entries. Unless keepPosition is set, the receiver is also moved to the
+// approximate position privacy mode shows, with distances and bearings
+// measured from there, so the aircraft can't be used to find it. Points of
+// interest and geofences move with it, keeping their place around it.
+func (s *State) Sanitize(keepPosition bool) {
+	for i, line := range s.Log {
+		s.Log[i] = ScrubSecrets(line)
+	}
+	if s.Config == nil {
+		return
+	}
+	s.Config.Kiosk.Unlock = ""
+	for i := range s.Config.Connection.Servers {
+		s.Config.Connection.Servers[i].APIKey = ""
+	}
+
+	conn := &s.Config.Connection
+	if keepPosition || !conn.HasReceiver() {
+		return
+	}
+	lat, lon := geo.ApproximatePosition(conn.ReceiverLat, conn.ReceiverLon)
+	dLat, dLon := lat-conn.ReceiverLat, lon-conn.ReceiverLon
+	conn.ReceiverLat, conn.ReceiverLon = lat, lon
+	for i := range s.Config.POI.Points {
+		p := &s.Config.POI.Points[i]
+		p.Lat, p.Lon = p.Lat+dLat, p.Lon+dLon
+	}
+	for i := range s.Config.Alerts.Geofences {
+		gf := &s.Config.Alerts.Geofences[i]
+		if gf.CenterLat != 0 || gf.CenterLon != 0 {
+			gf.CenterLat, gf.CenterLon = gf.CenterLat+dLat, gf.CenterLon+dLon
+		}
+		for j := range gf.Points {
+			gf.Points[j].Lat, gf.Points[j].Lon = gf.Points[j].Lat+dLat, gf.Points[j].Lon+dLon
+		}
+	}
+	for i := range s.Aircraft {
+		if t := &s.Aircraft[i].Target; t.HasLat && t.HasLon {
+			t.Distance, t.Bearing = radar.HaversineBearing(conn.ReceiverLat, conn.ReceiverLon, t.Lat, t.Lon)
+		}
+	}
+	s.PositionStripped = true
+}
+
+// EncodeState writes s to w as gzipped JSON
+func EncodeState(w io.Writer, s *State) error {
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	return zw.Close()
+}
+
+// DecodeState reads a state written by EncodeState. Its settings are
+// upgraded as a settings file's would be.
+func DecodeState(r io.Reader) (*State, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a state file: %w", err)
+	}
+	defer zr.Close()
+
+	// The settings are decoded on their own so older formats are upgraded
+	var raw struct {
+		State
+		Config json.RawMessage `json:"config"`
+	}
+	if err := json.NewDecoder(zr).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("not a state file: %w", err)
+	}
+	if raw.Format < 1 || raw.Format > StateFormat {
+		return nil, fmt.Errorf("state file format %d is not supported (this version reads up to %d)", raw.Format, StateFormat)
+	}
+	s := raw.State
+	s.Config = config.DefaultConfig()
+	if len(raw.Config) > 0 {
+		if s.Config, err = config.Parse(raw.Config); err != nil {
+			return nil, fmt.Errorf("state file settings: %w", err)
+		}
+	}
+	return &s, nil
+}
+
+// WriteState writes s to filename
+func WriteState(s *State, filename string) error {
+	var buf bytes.Buffer
+	if err := EncodeState(&buf, s); err != nil {
+		return err
+	}
+	return writeFile(filename, buf.Bytes())
+}
+
+// ExportState writes s to a timestamped file in directory
+func ExportState(s *State, directory string) (string, error) {
+	filename := GenerateFilename("skyspy_state", "json.gz", directory)
+	if err := WriteState(s, filename); err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+// ReadState reads a state file written by WriteState
+func ReadState(filename string) (*State, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s, err := DecodeState(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return s, nil
+}
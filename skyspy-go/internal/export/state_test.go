@@ -0,0 +1,188 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/radar"
+	"github.com/skyspy/skyspy-go/internal/trails"
+)
+
+// testState is a state with something in every field
+func testState() *State {
+	cfg := config.DefaultConfig()
+	cfg.Version = config.SchemaVersion()
+	cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon = 52.3676, 4.9041
+	cfg.Display.Theme = "amber"
+	cfg.Kiosk.Unlock = "ctrl+k"
+	seen := time.Date(2026, 3, 1, 11, 59, 58, 0, time.UTC)
+
+	return &State{
+		Format:  StateFormat,
+		Version: "1.4.0",
+		Created: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Config:  cfg,
+		Aircraft: []StateAircraft{{
+			Target: radar.Target{
+				Hex: "484B1C", Callsign: "KLM123", Lat: 52.5, Lon: 4.8, HasLat: true, HasLon: true,
+				Altitude: 12000, HasAlt: true, Distance: 8.2, Bearing: 350,
+				NavModes:  []string{"autopilot"},
+				Signal:    radar.SignalStats{Min: -30, Max: -10, Avg: -20, Samples: 4},
+				FirstSeen: seen.Add(-time.Minute),
+			},
+			LastSeen: seen,
+		}},
+		Trails: map[string][]trails.Position{
+			"484B1C": {
+				{Lat: 52.4, Lon: 4.85, Altitude: 11000, HasAlt: true, Timestamp: seen.Add(-30 * time.Second)},
+				{Lat: 52.5, Lon: 4.8, Altitude: 12000, HasAlt: true, Timestamp: seen, Break: true, Segment: 1},
+			},
+		},
+		View: StateView{Mode: 4, Width: 160, Height: 60, Theme: "amber", Selected: "484B1C", Follow: "484B1C", Pinned: []string{"484B1C"}, Filter: "alt:>10000"},
+		Log:  []string{"2026-03-01T11:59:00Z unreadable fields from 484B1C: alt_baro"},
+	}
+}
+
+func TestState_RoundTrip(t *testing.T) {
+	want := testState()
+	filename := filepath.Join(t.TempDir(), "state.json.gz")
+	if err := WriteState(want, filename); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadState(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got.Config, want.Config) {
+		t.Errorf("settings differ after the round trip:\ngot  %+v\nwant %+v", got.Config, want.Config)
+	}
+	got.Config, want.Config = nil, nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("state differs after the round trip:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestState_Gzipped(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeState(&buf, testState()); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("state should be gzipped: %v", err)
+	}
+	var plain bytes.Buffer
+	if _, err := plain.ReadFrom(zr); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(plain.String(), `"format": 1`) || !strings.Contains(plain.String(), `"last_seen"`) {
+		t.Errorf("state should be readable JSON once unzipped:\n%.300s", plain.String())
+	}
+}
+
+func TestState_RejectsOtherFiles(t *testing.T) {
+	if _, err := DecodeState(strings.NewReader(`{"format": 1}`)); err == nil {
+		t.Error("plain JSON isn't a state file")
+	}
+
+	newer := testState()
+	newer.Format = StateFormat + 1
+	var buf bytes.Buffer
+	if err := EncodeState(&buf, newer); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeState(&buf); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("a newer format should be refused, got %v", err)
+	}
+}
+
+func TestState_OlderSettingsUpgraded(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write([]byte(`{"format": 1, "config": {"display": {"theme": "matrix"}}}`))
+	_ = zw.Close()
+
+	s, err := DecodeState(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Config.Display.Theme != "matrix" || s.Config.Version != config.SchemaVersion() {
+		t.Errorf("settings should be upgraded to version %d, got theme %q version %d", config.SchemaVersion(), s.Config.Display.Theme, s.Config.Version)
+	}
+	if s.Config.Radar.DefaultRange != config.DefaultConfig().Radar.DefaultRange {
+		t.Error("settings the state leaves out should have their defaults")
+	}
+}
+
+func TestScrubSecrets(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"auth: Authorization: Bearer eyJhbGciOi.abc", "auth: Authorization: Bearer [redacted]"},
+		{"GET /ws?token=abc123&x=1", "GET /ws?token=[redacted]&x=1"},
+		{`refresh failed {"refresh_token": "r-456"}`, `refresh failed {"refresh_token": "[redacted]"}`},
+		{"using key sk_live_9f8e7d", "using key [redacted]"},
+		{"api_key=sk_live_9f8e7d", "api_key=[redacted]"},
+		{"unreadable fields from 484B1C: alt_baro", "unreadable fields from 484B1C: alt_baro"},
+	}
+	for _, tt := range tests {
+		if got := ScrubSecrets(tt.in); got != tt.want {
+			t.Errorf("ScrubSecrets(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestState_Sanitize(t *testing.T) {
+	s := testState()
+	s.Log = append(s.Log, "login: password=hunter2")
+	s.Config.Connection.Servers = []config.ServerConfig{
+		{Name: "HOME", Host: "localhost", Port: 80, Primary: true},
+		{Name: "CLUB", Host: "club.example", Port: 443, APIKey: "sk-club-1234"},
+	}
+	s.Sanitize(true)
+	if s.Config.Kiosk.Unlock != "" || s.Log[1] != "login: password=[redacted]" {
+		t.Errorf("secrets should be stripped: unlock %q, log %q", s.Config.Kiosk.Unlock, s.Log)
+	}
+	if servers := s.Config.Connection.Servers; servers[1].APIKey != "" || servers[1].Host != "club.example" {
+		t.Errorf("server API keys should be stripped, the rest kept: %+v", servers)
+	}
+	if s.Config.Connection.ReceiverLat != 52.3676 || s.PositionStripped {
+		t.Error("keepPosition should leave the receiver where it is")
+	}
+
+	s = testState()
+	s.Config.POI.Points = []config.POIConfig{{Label: "HOME", Lat: 52.3676, Lon: 4.9041}}
+	s.Config.Alerts.Geofences = []config.GeofenceConfig{
+		{ID: "near", Type: "circle", CenterLat: 52.4676, CenterLon: 4.9041, RadiusNM: 2},
+		{ID: "box", Type: "polygon", Points: []config.GeofencePointConfig{{Lat: 52.3676, Lon: 5.0041}}},
+	}
+	s.Sanitize(false)
+	conn := s.Config.Connection
+	if !s.PositionStripped || conn.ReceiverLat == 52.3676 || conn.ReceiverLon == 4.9041 {
+		t.Fatalf("the receiver should be moved, got %v,%v", conn.ReceiverLat, conn.ReceiverLon)
+	}
+	// Points of interest and geofences move with the receiver
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	if poi := s.Config.POI.Points[0]; !near(poi.Lat, conn.ReceiverLat) || !near(poi.Lon, conn.ReceiverLon) {
+		t.Errorf("the point of interest should move with the receiver, got %v,%v", poi.Lat, poi.Lon)
+	}
+	if gf := s.Config.Alerts.Geofences[0]; !near(gf.CenterLat, conn.ReceiverLat+0.1) || !near(gf.CenterLon, conn.ReceiverLon) {
+		t.Errorf("the geofence centre should move with the receiver, got %v,%v", gf.CenterLat, gf.CenterLon)
+	}
+	if p := s.Config.Alerts.Geofences[1].Points[0]; !near(p.Lat, conn.ReceiverLat) || !near(p.Lon, conn.ReceiverLon+0.1) {
+		t.Errorf("the polygon should move with the receiver, got %v,%v", p.Lat, p.Lon)
+	}
+	target := s.Aircraft[0].Target
+	dist, brg := radar.HaversineBearing(conn.ReceiverLat, conn.ReceiverLon, target.Lat, target.Lon)
+	if target.Distance != dist || target.Bearing != brg {
+		t.Error("distance and bearing should be measured from the approximate receiver")
+	}
+}
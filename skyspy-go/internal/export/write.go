@@ -0,0 +1,113 @@
+// Package export provides export functionality for SkySpy CLI
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// Reasons an export couldn't be written, matched with errors.Is
+var (
+	ErrNoSpace     = errors.New("no space left for export")
+	ErrNotWritable = errors.New("export directory not writable")
+)
+
+// WriteError is an export that couldn't be written to Path. Err is the
+// underlying OS error; Kind is ErrNoSpace or ErrNotWritable when the cause
+// is one of those, and nil otherwise.
+type WriteError struct {
+	Path string
+	Kind error
+	Err  error
+}
+
+func (e *WriteError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap lets errors.Is match both the kind and the OS error
+func (e *WriteError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// diskFree reports the bytes free for a directory; swapped out in tests
+var diskFree = freeSpace
+
+// writeFile writes an export to filename. It goes to a temp file beside
+// it first, renamed into place once complete, so a failure never leaves a
+// partial or empty file behind. The directory is made if needed, and
+// checked for room before anything is written.
+func writeFile(filename string, data []byte) (err error) {
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return writeError(filename, err)
+	}
+	if free, ok := diskFree(dir); ok && free < uint64(len(data)) {
+		return &WriteError{Path: filename, Kind: ErrNoSpace,
+			Err: fmt.Errorf("%d bytes free, %d needed", free, len(data))}
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return writeError(filename, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return writeError(filename, err)
+	}
+	// A full card may take the data and only fail on sync
+	if err := tmp.Sync(); err != nil {
+		return writeError(filename, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return writeError(filename, err)
+	}
+	//nolint:gosec // G302: Export files are non-sensitive and can be world-readable
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return writeError(filename, err)
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return writeError(filename, err)
+	}
+	return nil
+}
+
+// writeError wraps an OS error from writing filename, classing it as out
+// of space or not writable where it is either
+func writeError(filename string, err error) *WriteError {
+	we := &WriteError{Path: filename, Err: osCause(err)}
+	switch {
+	case noSpace(err):
+		we.Kind = ErrNoSpace
+	case errors.Is(err, syscall.EROFS), errors.Is(err, fs.ErrPermission):
+		we.Kind = ErrNotWritable
+	}
+	return we
+}
+
+// osCause returns the OS error inside a path or link error, whose own
+// message repeats the (temp) file name
+func osCause(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	var linkErr *os.LinkError
+	if errors.As(err, &linkErr) {
+		return linkErr.Err
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// readOnlyDir returns a temp directory that can't be written to. Root
+// writes anyway, so tests that need one are skipped when run as root.
+func readOnlyDir(t *testing.T) string {
+	t.Helper()
+	if os.Geteuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+	dir := t.TempDir()
+	if err := os.Chmod(dir, 0o555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(dir, 0o755) })
+	return dir
+}
+
+// entries lists a directory's file names
+func entries(t *testing.T, dir string) []string {
+	t.Helper()
+	list, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, len(list))
+	for i, e := range list {
+		names[i] = e.Name()
+	}
+	return names
+}
+
+func TestWriteFile_ReadOnlyDir(t *testing.T) {
+	dir := readOnlyDir(t)
+
+	_, err := CaptureScreen("frame", dir)
+	if !errors.Is(err, ErrNotWritable) || errors.Is(err, ErrNoSpace) {
+		t.Fatalf("err = %v, want ErrNotWritable", err)
+	}
+	var we *WriteError
+	if !errors.As(err, &we) || filepath.Dir(we.Path) != dir || !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("the error should carry the path and the OS error: %#v", err)
+	}
+	if names := entries(t, dir); len(names) != 0 {
+		t.Errorf("a failed export left %v behind", names)
+	}
+
+	aircraft := map[string]*radar.Target{"abc123": {Hex: "abc123"}}
+	if _, err := ExportAircraftJSON(aircraft, dir); !errors.Is(err, ErrNotWritable) {
+		t.Errorf("JSON export: err = %v, want ErrNotWritable", err)
+	}
+	if _, err := ExportAircraft(aircraft, dir); !errors.Is(err, ErrNotWritable) {
+		t.Errorf("CSV export: err = %v, want ErrNotWritable", err)
+	}
+}
+
+func TestWriteFile_NoSpace(t *testing.T) {
+	diskFree = func(string) (uint64, bool) { return 10, true }
+	t.Cleanup(func() { diskFree = freeSpace })
+	dir := t.TempDir()
+
+	_, err := CaptureScreen(strings.Repeat("x", 100), dir)
+	if !errors.Is(err, ErrNoSpace) {
+		t.Fatalf("err = %v, want ErrNoSpace", err)
+	}
+	if !strings.Contains(err.Error(), "10 bytes free") {
+		t.Errorf("the error should say how much room there is: %v", err)
+	}
+	if names := entries(t, dir); len(names) != 0 {
+		t.Errorf("a failed export left %v behind", names)
+	}
+}
+
+func TestWriteFile_NoPartialFile(t *testing.T) {
+	dir := t.TempDir()
+	// A directory in the way makes the final rename fail after the data
+	// has been written
+	target := filepath.Join(dir, "export.txt")
+	if err := os.Mkdir(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFile(target, []byte("data")); err == nil {
+		t.Fatal("expected the rename to fail")
+	}
+	if names := entries(t, dir); len(names) != 1 || names[0] != "export.txt" {
+		t.Errorf("the temp file should be removed, found %v", names)
+	}
+}
+
+func TestWriteFile_Replaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "export.txt")
+	for _, data := range []string{"first", "second"} {
+		if err := writeFile(path, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := os.ReadFile(path)
+	if err != nil || string(got) != "second" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o644 {
+		t.Errorf("exports should be world-readable: %v", info.Mode())
+	}
+}
+
+func TestWriteError_Kinds(t *testing.T) {
+	tests := []struct {
+		err  error
+		kind error
+	}{
+		{&fs.PathError{Op: "write", Path: "/card/x.tmp", Err: syscall.ENOSPC}, ErrNoSpace},
+		{&fs.PathError{Op: "open", Path: "/card/x.tmp", Err: syscall.EROFS}, ErrNotWritable},
+		{&fs.PathError{Op: "open", Path: "/card/x.tmp", Err: syscall.EACCES}, ErrNotWritable},
+		{&fs.PathError{Op: "open", Path: "/card/x.tmp", Err: syscall.EIO}, nil},
+	}
+	for _, tt := range tests {
+		we := writeError("/card/skyspy.csv", tt.err)
+		if we.Kind != tt.kind {
+			t.Errorf("%v: kind = %v, want %v", tt.err, we.Kind, tt.kind)
+		}
+		if want := "/card/skyspy.csv: " + tt.err.(*fs.PathError).Err.Error(); we.Error() != want {
+			t.Errorf("Error() = %q, want %q", we.Error(), want)
+		}
+	}
+}
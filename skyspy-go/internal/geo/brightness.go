@@ -0,0 +1,208 @@
+// Package geo provides overlay brightness levels for SkySpy radar display
+package geo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Brightness is an overlay dim level
+type Brightness string
+
+const (
+	BrightnessBright Brightness = "bright"
+	BrightnessNormal Brightness = "normal"
+	BrightnessDim    Brightness = "dim"
+	BrightnessFaint  Brightness = "faint"
+)
+
+// brightnessLevels lists the levels from brightest to faintest
+var brightnessLevels = []Brightness{BrightnessBright, BrightnessNormal, BrightnessDim, BrightnessFaint}
+
+// brightnessFactors scales each RGB channel; values above 1 blend toward white
+var brightnessFactors = map[Brightness]float64{
+	BrightnessBright: 1.3,
+	BrightnessNormal: 1.0,
+	BrightnessDim:    0.6,
+	BrightnessFaint:  0.35,
+}
+
+// namedColors maps basic color names to their ANSI index
+var namedColors = map[string]int{
+	"black":   0,
+	"red":     1,
+	"green":   2,
+	"yellow":  3,
+	"blue":    4,
+	"magenta": 5,
+	"cyan":    6,
+	"white":   7,
+	"gray":    8,
+	"grey":    8,
+}
+
+// ansiBase holds the xterm RGB values for ANSI colors 0-15
+var ansiBase = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 xterm color cube
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// ParseBrightness returns the named level, or normal when unrecognized
+func ParseBrightness(s string) Brightness {
+	b := Brightness(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := brightnessFactors[b]; ok {
+		return b
+	}
+	return BrightnessNormal
+}
+
+// Brighter returns the next brighter level, stopping at bright
+func (b Brightness) Brighter() Brightness {
+	i := b.index()
+	if i > 0 {
+		i--
+	}
+	return brightnessLevels[i]
+}
+
+// Dimmer returns the next dimmer level, stopping at faint
+func (b Brightness) Dimmer() Brightness {
+	i := b.index()
+	if i < len(brightnessLevels)-1 {
+		i++
+	}
+	return brightnessLevels[i]
+}
+
+func (b Brightness) index() int {
+	b = ParseBrightness(string(b))
+	for i, l := range brightnessLevels {
+		if l == b {
+			return i
+		}
+	}
+	return 1
+}
+
+// AdjustColor applies a brightness level to a color. Hex colors are scaled in
+// RGB; ANSI indexes and basic names map to the nearest 256-color entry.
+// Colors that cannot be parsed are returned unchanged.
+func AdjustColor(color string, b Brightness) string {
+	b = ParseBrightness(string(b))
+	if b == BrightnessNormal {
+		return color
+	}
+	rgb, hex, ok := parseColor(color)
+	if !ok {
+		return color
+	}
+	f := brightnessFactors[b]
+	for i, c := range rgb {
+		if f > 1 {
+			rgb[i] = c + int(float64(255-c)*(f-1))
+		} else {
+			rgb[i] = int(float64(c) * f)
+		}
+	}
+	if hex {
+		return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+	}
+	return strconv.Itoa(nearestANSI(rgb))
+}
+
+// parseColor resolves a hex, ANSI index or named color to RGB
+func parseColor(color string) (rgb [3]int, hex bool, ok bool) {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if strings.HasPrefix(color, "#") {
+		h := color[1:]
+		if len(h) == 3 {
+			h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+		}
+		if len(h) != 6 {
+			return rgb, false, false
+		}
+		v, err := strconv.ParseUint(h, 16, 32)
+		if err != nil {
+			return rgb, false, false
+		}
+		return [3]int{int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff)}, true, true
+	}
+	idx, err := strconv.Atoi(color)
+	if err != nil {
+		name := color
+		bright := strings.HasPrefix(name, "bright")
+		if bright {
+			name = strings.TrimLeft(strings.TrimPrefix(name, "bright"), "_- ")
+		}
+		n, found := namedColors[name]
+		if !found {
+			return rgb, false, false
+		}
+		if bright && n < 8 {
+			n += 8
+		}
+		idx = n
+	}
+	if idx < 0 || idx > 255 {
+		return rgb, false, false
+	}
+	return ansiToRGB(idx), false, true
+}
+
+// ansiToRGB returns the xterm RGB value of a 256-color index
+func ansiToRGB(idx int) [3]int {
+	switch {
+	case idx < 16:
+		return ansiBase[idx]
+	case idx < 232:
+		i := idx - 16
+		return [3]int{cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]}
+	default:
+		g := 8 + (idx-232)*10
+		return [3]int{g, g, g}
+	}
+}
+
+// nearestANSI picks the closest cube or grayscale entry for an RGB value
+func nearestANSI(rgb [3]int) int {
+	var ci [3]int
+	for i, c := range rgb {
+		best := 0
+		for j, l := range cubeLevels {
+			if abs(c-l) < abs(c-cubeLevels[best]) {
+				best = j
+			}
+		}
+		ci[i] = best
+	}
+	cube := 16 + ci[0]*36 + ci[1]*6 + ci[2]
+
+	avg := (rgb[0] + rgb[1] + rgb[2]) / 3
+	gi := (avg - 8 + 5) / 10
+	if gi < 0 {
+		gi = 0
+	}
+	if gi > 23 {
+		gi = 23
+	}
+	gray := 232 + gi
+
+	if colorDistance(rgb, ansiToRGB(gray)) < colorDistance(rgb, ansiToRGB(cube)) {
+		return gray
+	}
+	return cube
+}
+
+func colorDistance(a, b [3]int) int {
+	d := 0
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return d
+}
@@ -0,0 +1,117 @@
+package geo
+
+import "testing"
+
+func TestParseBrightness(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Brightness
+	}{
+		{"bright", BrightnessBright},
+		{"DIM", BrightnessDim},
+		{" faint ", BrightnessFaint},
+		{"", BrightnessNormal},
+		{"blinding", BrightnessNormal},
+	}
+	for _, tt := range tests {
+		if got := ParseBrightness(tt.in); got != tt.want {
+			t.Errorf("ParseBrightness(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBrightness_StepsClamp(t *testing.T) {
+	if got := BrightnessNormal.Dimmer(); got != BrightnessDim {
+		t.Errorf("normal dimmer = %q, want dim", got)
+	}
+	if got := BrightnessFaint.Dimmer(); got != BrightnessFaint {
+		t.Errorf("faint dimmer = %q, want faint", got)
+	}
+	if got := BrightnessBright.Brighter(); got != BrightnessBright {
+		t.Errorf("bright brighter = %q, want bright", got)
+	}
+	if got := Brightness("").Brighter(); got != BrightnessBright {
+		t.Errorf("unset brighter = %q, want bright", got)
+	}
+}
+
+func TestAdjustColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		color string
+		level Brightness
+		want  string
+	}{
+		{"normal unchanged", "cyan", BrightnessNormal, "cyan"},
+		{"hex dim", "#c86432", BrightnessDim, "#783c1e"},
+		{"short hex faint", "#fff", BrightnessFaint, "#595959"},
+		{"hex bright", "#000000", BrightnessBright, "#4c4c4c"},
+		{"named dim", "cyan", BrightnessDim, "30"},
+		{"ansi index faint", "46", BrightnessFaint, "22"},
+		{"gray ansi dim", "250", BrightnessDim, "242"},
+		{"unknown name", "chartreuse", BrightnessDim, "chartreuse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AdjustColor(tt.color, tt.level); got != tt.want {
+				t.Errorf("AdjustColor(%q, %q) = %q, want %q", tt.color, tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustColor_DimmerIsDarker(t *testing.T) {
+	prev := 1 << 30
+	for _, level := range []Brightness{BrightnessBright, BrightnessNormal, BrightnessDim, BrightnessFaint} {
+		rgb, _, ok := parseColor(AdjustColor("cyan", level))
+		if !ok {
+			t.Fatalf("level %q produced an unparseable color", level)
+		}
+		sum := rgb[0] + rgb[1] + rgb[2]
+		if sum >= prev {
+			t.Errorf("level %q is not darker than the previous level", level)
+		}
+		prev = sum
+	}
+}
+
+func TestRenderOverlayToRadar_AppliesBrightness(t *testing.T) {
+	overlay := &GeoOverlay{
+		Name:       "Dim",
+		Color:      "#ff0000",
+		Brightness: BrightnessFaint,
+		Features: []GeoFeature{
+			{Type: OverlayPoint, Points: []GeoPoint{{Lat: 52.05, Lon: 4.0}}},
+		},
+	}
+	points := RenderOverlayToRadar(overlay, 52.0, 4.0, 50, 60, 30, "cyan")
+	if len(points) == 0 {
+		t.Fatal("expected overlay point to render")
+	}
+	if points[0].Color != "#590000" {
+		t.Errorf("expected faint red #590000, got %q", points[0].Color)
+	}
+}
+
+func TestOverlayManager_Brightness(t *testing.T) {
+	m := NewOverlayManager()
+	key := m.AddOverlay(&GeoOverlay{Name: "Airspace"}, "")
+
+	if got := m.AdjustOverlayBrightness(key, false); got != BrightnessDim {
+		t.Errorf("expected dim after one step down, got %q", got)
+	}
+	if got := m.GetOverlayList()[0].Brightness; got != BrightnessDim {
+		t.Errorf("list brightness = %q, want dim", got)
+	}
+	if got := m.ToConfig()[0]["brightness"]; got != "dim" {
+		t.Errorf("config brightness = %v, want dim", got)
+	}
+
+	m.SetOverlayBrightness(key, "bogus")
+	if got := m.GetOverlayList()[0].Brightness; got != BrightnessNormal {
+		t.Errorf("invalid level should reset to normal, got %q", got)
+	}
+	if got := m.AdjustOverlayBrightness("missing", true); got != BrightnessNormal {
+		t.Errorf("missing overlay should report normal, got %q", got)
+	}
+}
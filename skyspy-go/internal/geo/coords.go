@@ -0,0 +1,190 @@
+// Package geo provides coordinate formatting for SkySpy radar display
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// CoordFormat selects how a position is written out
+type CoordFormat string
+
+const (
+	CoordDecimal CoordFormat = "decimal" // 52.36760, 4.90410
+	CoordDMS     CoordFormat = "dms"     // 52°22'03"N 4°54'15"E
+	CoordMGRS    CoordFormat = "mgrs"    // 31U FU 29577 05227
+)
+
+// CoordFormats lists the formats in the order they are offered
+var CoordFormats = []CoordFormat{CoordDecimal, CoordDMS, CoordMGRS}
+
+// ParseCoordFormat returns the format named by s, ignoring case and
+// surrounding space. Empty or unknown names give CoordDecimal.
+func ParseCoordFormat(s string) CoordFormat {
+	f := CoordFormat(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range CoordFormats {
+		if f == known {
+			return f
+		}
+	}
+	return CoordDecimal
+}
+
+// FormatCoords writes a position in the given format. MGRS is undefined
+// beyond 80°S and 84°N, so polar positions fall back to decimal degrees.
+func FormatCoords(lat, lon float64, f CoordFormat) string {
+	switch f {
+	case CoordDMS:
+		return FormatDMS(lat, lon, "°")
+	case CoordMGRS:
+		if s, err := MGRS(lat, lon, 5); err == nil {
+			return s
+		}
+	}
+	return FormatDecimal(lat, lon)
+}
+
+// FormatDecimal writes a position as signed decimal degrees to about a metre
+func FormatDecimal(lat, lon float64) string {
+	return fmt.Sprintf("%.5f, %.5f", lat, lon)
+}
+
+// FormatDMS writes a position as degrees, minutes and whole seconds with a
+// hemisphere letter, using degree as the degree sign
+func FormatDMS(lat, lon float64, degree string) string {
+	return dms(lat, degree, "N", "S") + " " + dms(lon, degree, "E", "W")
+}
+
+func dms(v float64, degree, pos, neg string) string {
+	hemi := pos
+	if v < 0 {
+		hemi = neg
+		v = -v
+	}
+	total := int(math.Round(v * 3600))
+	return fmt.Sprintf("%d%s%02d'%02d\"%s", total/3600, degree, total/60%60, total%60, hemi)
+}
+
+// WGS84 ellipsoid and UTM projection constants
+const (
+	wgs84A        = 6378137.0
+	wgs84F        = 1 / 298.257223563
+	utmScale      = 0.9996
+	utmFalseEast  = 500000.0
+	utmFalseNorth = 10000000.0
+)
+
+// mgrsBands are the 8° latitude bands from 80°S; X covers 72°N to 84°N
+const mgrsBands = "CDEFGHJKLMNPQRSTUVWX"
+
+// mgrsColumns and mgrsRows are the 100 km square letters, which skip I and O
+const (
+	mgrsColumns = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	mgrsRows    = "ABCDEFGHJKLMNPQRSTUV"
+)
+
+// ErrMGRSRange is returned for latitudes outside the MGRS UTM bands
+var ErrMGRSRange = errors.New("mgrs: latitude outside 80°S to 84°N")
+
+// MGRS converts a WGS84 position to a Military Grid Reference System
+// reference such as "31U DQ 48251 11932". digits (1 to 5) is the number of
+// digits each for easting and northing; 5 gives 1 m squares. Coordinates
+// are truncated, as MGRS requires, so the reference names the square that
+// contains the position.
+func MGRS(lat, lon float64, digits int) (string, error) {
+	if lat < -80 || lat > 84 {
+		return "", ErrMGRSRange
+	}
+	if digits < 1 || digits > 5 {
+		return "", fmt.Errorf("mgrs: precision must be 1 to 5 digits, got %d", digits)
+	}
+
+	zone := utmZone(lat, lon)
+	easting, northing := utm(lat, lon, zone)
+
+	band := mgrsBands[min(int((lat+80)/8), len(mgrsBands)-1)]
+
+	// Column letters repeat every three zones and row letters every two,
+	// with even zones offset by five rows
+	col := int(easting / 100000)
+	colLetter := mgrsColumns[((zone-1)%3)*8+col-1]
+	row := int(math.Mod(northing, 2000000) / 100000)
+	if zone%2 == 0 {
+		row += 5
+	}
+	rowLetter := mgrsRows[row%len(mgrsRows)]
+
+	scale := math.Pow(10, float64(5-digits))
+	e := int(math.Mod(easting, 100000) / scale)
+	n := int(math.Mod(northing, 100000) / scale)
+	return fmt.Sprintf("%d%c %c%c %0*d %0*d", zone, band, colLetter, rowLetter, digits, e, digits, n), nil
+}
+
+// utmZone returns the UTM zone for a position, including the Norway and
+// Svalbard exceptions
+func utmZone(lat, lon float64) int {
+	lon = math.Mod(lon+540, 360) - 180
+	zone := int((lon+180)/6) + 1
+	if zone > 60 {
+		zone = 60
+	}
+
+	if lat >= 56 && lat < 64 && lon >= 3 && lon < 12 {
+		return 32
+	}
+	if lat >= 72 && lat <= 84 && lon >= 0 && lon < 42 {
+		switch {
+		case lon < 9:
+			return 31
+		case lon < 21:
+			return 33
+		case lon < 33:
+			return 35
+		default:
+			return 37
+		}
+	}
+	return zone
+}
+
+// utm projects a position onto the given UTM zone, returning easting and
+// northing in metres. Southern latitudes use the 10,000 km false northing.
+func utm(lat, lon float64, zone int) (float64, float64) {
+	e2 := wgs84F * (2 - wgs84F)
+	ep2 := e2 / (1 - e2)
+
+	phi := lat * math.Pi / 180
+	lon0 := float64((zone-1)*6-180+3) * math.Pi / 180
+	lambda := math.Mod(lon+540, 360) - 180
+	lambda = lambda * math.Pi / 180
+
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+	n := wgs84A / math.Sqrt(1-e2*sinPhi*sinPhi)
+	t := math.Tan(phi) * math.Tan(phi)
+	c := ep2 * cosPhi * cosPhi
+	a := cosPhi * (lambda - lon0)
+
+	e4, e6 := e2*e2, e2*e2*e2
+	m := wgs84A * ((1-e2/4-3*e4/64-5*e6/256)*phi -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*phi) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*phi) -
+		(35*e6/3072)*math.Sin(6*phi))
+
+	easting := utmScale*n*(a+(1-t+c)*math.Pow(a, 3)/6+
+		(5-18*t+t*t+72*c-58*ep2)*math.Pow(a, 5)/120) + utmFalseEast
+	northing := utmScale * (m + n*math.Tan(phi)*(a*a/2+
+		(5-t+9*c+4*c*c)*math.Pow(a, 4)/24+
+		(61-58*t+t*t+600*c-330*ep2)*math.Pow(a, 6)/720))
+	if lat < 0 {
+		northing += utmFalseNorth
+	}
+	return easting, northing
+}
+
+// RelativePosition writes a bearing and a distance in unit from the
+// receiver in the "R-245°/18.2nm" form, using degree as the degree sign
+func RelativePosition(bearing, distance float64, unit, degree string) string {
+	return fmt.Sprintf("R-%03d%s/%.1f%s", int(math.Round(bearing))%360, degree, distance, unit)
+}
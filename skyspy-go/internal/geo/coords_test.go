@@ -0,0 +1,123 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMGRS_PublishedExamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		digits   int
+		want     string
+	}{
+		// Washington Monument, as given in the MGRS article on Wikipedia
+		{"washington monument", 38.889467, -77.035239, 3, "18S UJ 234 064"},
+		// GeoConvert documentation example (GeographicLib)
+		{"geoconvert", 33.3, 44.4, 2, "38S MB 44 84"},
+		// The equator at the prime meridian is 166021 m east in zone 31
+		{"null island", 0, 0, 5, "31N AA 66021 00000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MGRS(tt.lat, tt.lon, tt.digits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("MGRS(%v, %v) = %q, want %q", tt.lat, tt.lon, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMGRS_ZonesAndBands(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		prefix   string
+	}{
+		{"norway exception", 60.39, 5.32, "32V "},
+		{"svalbard exception", 78.22, 15.65, "33X "},
+		{"southern hemisphere", -33.8568, 151.2153, "56H "},
+		{"band C at 80S", -80, 0, "31C "},
+		{"band X at 84N", 84, 0, "31X "},
+		{"antimeridian", 10, 180, "1P "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MGRS(tt.lat, tt.lon, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(got, tt.prefix) {
+				t.Errorf("MGRS(%v, %v) = %q, want prefix %q", tt.lat, tt.lon, got, tt.prefix)
+			}
+		})
+	}
+}
+
+func TestMGRS_Errors(t *testing.T) {
+	if _, err := MGRS(85, 0, 5); err != ErrMGRSRange {
+		t.Errorf("expected ErrMGRSRange north of 84N, got %v", err)
+	}
+	if _, err := MGRS(-81, 0, 5); err != ErrMGRSRange {
+		t.Errorf("expected ErrMGRSRange south of 80S, got %v", err)
+	}
+	if _, err := MGRS(0, 0, 6); err == nil {
+		t.Error("expected an error for 6 digit precision")
+	}
+}
+
+func TestFormatCoords(t *testing.T) {
+	tests := []struct {
+		format CoordFormat
+		want   string
+	}{
+		{CoordDecimal, "52.36760, 4.90410"},
+		{CoordDMS, "52°22'03\"N 4°54'15\"E"},
+		{CoordMGRS, "31U FU 29"},
+	}
+	for _, tt := range tests {
+		if got := FormatCoords(52.3676, 4.9041, tt.format); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.format, got, tt.want)
+		}
+	}
+
+	if got := FormatDMS(-33.8568, -70.6483, "d"); got != "33d51'24\"S 70d38'54\"W" {
+		t.Errorf("southern/western DMS = %q", got)
+	}
+	if got := FormatCoords(89, 0, CoordMGRS); got != "89.00000, 0.00000" {
+		t.Errorf("expected polar MGRS to fall back to decimal, got %q", got)
+	}
+}
+
+func TestParseCoordFormat(t *testing.T) {
+	tests := map[string]CoordFormat{
+		"":        CoordDecimal,
+		"MGRS":    CoordMGRS,
+		" dms ":   CoordDMS,
+		"decimal": CoordDecimal,
+		"utm":     CoordDecimal,
+	}
+	for in, want := range tests {
+		if got := ParseCoordFormat(in); got != want {
+			t.Errorf("ParseCoordFormat(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRelativePosition(t *testing.T) {
+	if got := RelativePosition(245.4, 18.23, "nm", "°"); got != "R-245°/18.2nm" {
+		t.Errorf("got %q", got)
+	}
+	if got := RelativePosition(245.4, 33.76, "km", "°"); got != "R-245°/33.8km" {
+		t.Errorf("got %q", got)
+	}
+	if got := RelativePosition(359.7, 3, "nm", ""); got != "R-000/3.0nm" {
+		t.Errorf("expected bearings to wrap at north, got %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+// Package geo provides geographic overlay support for SkySpy radar display
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+// nmPerDegreeLat is the length of one degree of latitude in nautical miles
+const nmPerDegreeLat = 60.0
+
+// CPA describes a target's predicted closest point of approach to a
+// reference point, assuming it holds its current track and ground speed
+type CPA struct {
+	Distance float64       // nm at closest approach
+	Time     time.Duration // time until closest approach; 0 when not closing
+	Closing  bool          // false when the target is opening, stationary or already at CPA
+}
+
+// ComputeCPA predicts the closest point of approach of a target at lat/lon,
+// flying trackDeg at speedKt, to the reference point refLat/refLon. Positions
+// are projected onto a local flat plane around the reference, which is
+// accurate at radar ranges.
+func ComputeCPA(refLat, refLon, lat, lon, trackDeg, speedKt float64) CPA {
+	dLon := lon - refLon
+	if dLon > 180 {
+		dLon -= 360
+	} else if dLon < -180 {
+		dLon += 360
+	}
+	east := dLon * nmPerDegreeLat * math.Cos(refLat*math.Pi/180)
+	north := (lat - refLat) * nmPerDegreeLat
+	current := math.Hypot(east, north)
+
+	trackRad := trackDeg * math.Pi / 180
+	vEast := speedKt * math.Sin(trackRad)
+	vNorth := speedKt * math.Cos(trackRad)
+	speedSq := vEast*vEast + vNorth*vNorth
+	if speedSq == 0 {
+		return CPA{Distance: current}
+	}
+
+	// Time (hours) minimising |p + v*t|; non-positive means the range is
+	// already opening
+	hours := -(east*vEast + north*vNorth) / speedSq
+	if hours <= 0 {
+		return CPA{Distance: current}
+	}
+
+	return CPA{
+		Distance: math.Hypot(east+vEast*hours, north+vNorth*hours),
+		Time:     time.Duration(hours * float64(time.Hour)),
+		Closing:  true,
+	}
+}
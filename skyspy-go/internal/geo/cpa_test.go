@@ -0,0 +1,75 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// eastOf returns the longitude n nautical miles east of lon 0 on the equator
+func eastOf(n float64) float64 {
+	return n / nmPerDegreeLat
+}
+
+func TestComputeCPA(t *testing.T) {
+	tests := []struct {
+		name        string
+		lat, lon    float64
+		track, gs   float64
+		wantDist    float64
+		wantTime    time.Duration
+		wantClosing bool
+	}{
+		// 10nm east flying straight at the receiver at 120kt: overhead in 5 min
+		{"head-on", 0, eastOf(10), 270, 120, 0, 5 * time.Minute, true},
+		// 10nm east, 3nm north, flying west at 60kt: passes 3nm north in 10 min
+		{"offset pass", 3.0 / nmPerDegreeLat, eastOf(10), 270, 60, 3, 10 * time.Minute, true},
+		// 10nm east heading northwest at 60kt: CPA at (5,5) = 7.07nm after 7.07 min
+		{"diagonal", 0, eastOf(10), 315, 60, 5 * math.Sqrt2, 7*time.Minute + 4243*time.Millisecond, true},
+		// Flying directly away
+		{"opening", 0, eastOf(10), 90, 300, 10, 0, false},
+		// Crossing perpendicular: already at CPA
+		{"abeam", 10.0 / nmPerDegreeLat, 0, 90, 200, 10, 0, false},
+		// Stationary target never gets closer
+		{"stationary", 0, eastOf(10), 270, 0, 10, 0, false},
+		// Directly overhead: range can only open
+		{"overhead", 0, 0, 45, 250, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeCPA(0, 0, tt.lat, tt.lon, tt.track, tt.gs)
+			if math.Abs(got.Distance-tt.wantDist) > 0.01 {
+				t.Errorf("Distance = %.3f, want %.3f", got.Distance, tt.wantDist)
+			}
+			if diff := got.Time - tt.wantTime; diff > time.Second || diff < -time.Second {
+				t.Errorf("Time = %v, want %v", got.Time, tt.wantTime)
+			}
+			if got.Closing != tt.wantClosing {
+				t.Errorf("Closing = %v, want %v", got.Closing, tt.wantClosing)
+			}
+		})
+	}
+}
+
+func TestComputeCPA_HighLatitude(t *testing.T) {
+	// At 60°N a degree of longitude is half as long: 0.2° east is 6nm
+	got := ComputeCPA(60, 10, 60, 10.2, 270, 360)
+	if math.Abs(got.Distance) > 0.01 {
+		t.Errorf("expected a head-on pass, got %.3fnm", got.Distance)
+	}
+	if diff := got.Time - time.Minute; diff > time.Second || diff < -time.Second {
+		t.Errorf("expected 6nm at 360kt to take 1 min, got %v", got.Time)
+	}
+}
+
+func TestComputeCPA_AntimeridianWrap(t *testing.T) {
+	// Receiver just west of the antimeridian, target 6nm east across it
+	got := ComputeCPA(0, 179.95, 0, -179.95, 270, 120)
+	if !got.Closing {
+		t.Fatal("target across the antimeridian flying west should be closing")
+	}
+	if diff := got.Time - 3*time.Minute; diff > time.Second || diff < -time.Second {
+		t.Errorf("expected 6nm at 120kt to take 3 min, got %v", got.Time)
+	}
+}
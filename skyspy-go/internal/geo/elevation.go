@@ -0,0 +1,57 @@
+// Package geo provides field elevation lookup against overlay points
+package geo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// elevationKeys are the point properties read as an elevation in feet,
+// most specific first
+var elevationKeys = []string{"field_elevation", "elevation_ft", "elevation", "elev"}
+
+// NearestElevation returns the elevation (ft) of the point feature nearest
+// to lat/lon, within maxNM, that carries one, such as an airport reference
+// point with an "elevation" property
+func NearestElevation(overlays []*GeoOverlay, lat, lon, maxNM float64) (int, bool) {
+	best, found := maxNM, false
+	var elevation float64
+	for _, ov := range overlays {
+		for _, f := range ov.Features {
+			if f.Type != OverlayPoint || len(f.Points) == 0 {
+				continue
+			}
+			elev, ok := featureElevation(f.Properties)
+			if !ok {
+				continue
+			}
+			if d := HaversineDistance(lat, lon, f.Points[0].Lat, f.Points[0].Lon); d <= best {
+				best, elevation, found = d, elev, true
+			}
+		}
+	}
+	return int(elevation), found
+}
+
+// featureElevation reads the elevation property of a feature; numbers may
+// come as JSON numbers or, from shapefiles, as text
+func featureElevation(props map[string]interface{}) (float64, bool) {
+	for _, key := range elevationKeys {
+		for k, v := range props {
+			if !strings.EqualFold(k, key) {
+				continue
+			}
+			switch v := v.(type) {
+			case float64:
+				return v, true
+			case int:
+				return float64(v), true
+			case string:
+				if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+					return f, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
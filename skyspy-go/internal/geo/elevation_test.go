@@ -0,0 +1,25 @@
+package geo
+
+import "testing"
+
+func TestNearestElevation(t *testing.T) {
+	airports := &GeoOverlay{Name: "Airports", Features: []GeoFeature{
+		{Type: OverlayPoint, Points: []GeoPoint{{Lat: 52.31, Lon: 4.76}}, Properties: map[string]interface{}{"name": "EHAM", "elevation": -11.0}},
+		{Type: OverlayPoint, Points: []GeoPoint{{Lat: 51.96, Lon: 4.44}}, Properties: map[string]interface{}{"name": "EHRD", "ELEV": "-15"}},
+		{Type: OverlayPoint, Points: []GeoPoint{{Lat: 52.30, Lon: 4.77}}, Properties: map[string]interface{}{"name": "No elevation"}},
+		{Type: OverlayPolygon, Points: []GeoPoint{{Lat: 52.30, Lon: 4.76}}, Properties: map[string]interface{}{"elevation": 500.0}},
+	}}
+
+	if elev, ok := NearestElevation([]*GeoOverlay{airports}, 52.30, 4.76, 20); !ok || elev != -11 {
+		t.Errorf("near EHAM: got %d, %v, want -11", elev, ok)
+	}
+	if elev, ok := NearestElevation([]*GeoOverlay{airports}, 51.95, 4.45, 20); !ok || elev != -15 {
+		t.Errorf("near EHRD: got %d, %v, want -15 from a text property", elev, ok)
+	}
+	if _, ok := NearestElevation([]*GeoOverlay{airports}, 40.0, -74.0, 20); ok {
+		t.Error("no airport within range should find nothing")
+	}
+	if _, ok := NearestElevation(nil, 52.30, 4.76, 20); ok {
+		t.Error("no overlays should find nothing")
+	}
+}
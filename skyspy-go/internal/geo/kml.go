@@ -44,6 +44,7 @@ type kmlPlacemark struct {
 	Name        string         `xml:"name"`
 	Description string         `xml:"description"`
 	StyleURL    string         `xml:"styleUrl"`
+	Style       *kmlStyle      `xml:"Style"`
 	Point       *kmlPoint      `xml:"Point"`
 	LineString  *kmlLineString `xml:"LineString"`
 	Polygon     *kmlPolygon    `xml:"Polygon"`
@@ -225,15 +226,134 @@ func parseKMLData(data []byte, sourcePath string) (*GeoOverlay, error) {
 		placemarks = append(placemarks, collectPlacemarks(*kml.Folder)...)
 	}
 
-	// Convert placemarks to features
+	if len(placemarks) == 0 {
+		return nil, fmt.Errorf("no placemarks in KML (network links and image overlays are not supported)")
+	}
+
+	// Convert placemarks to features, colored by their styles
+	styles := newKMLStyles(kml.Document)
 	for _, pm := range placemarks {
+		if err := checkPlacemark(pm); err != nil {
+			return nil, err
+		}
 		features := convertPlacemarkToFeatures(pm)
+		color := styles.color(pm)
+		for i := range features {
+			features[i].Style = color
+		}
 		overlay.Features = append(overlay.Features, features...)
 	}
 
 	return overlay, nil
 }
 
+// kmlStyles resolves placemark style URLs to colors
+type kmlStyles struct {
+	colors map[string]string // style id to color
+	maps   map[string]string // style map id to its normal style URL
+}
+
+// newKMLStyles indexes a document's shared styles and style maps
+func newKMLStyles(doc kmlDocument) kmlStyles {
+	s := kmlStyles{colors: make(map[string]string), maps: make(map[string]string)}
+	for _, st := range doc.Styles {
+		if color := styleColor(st); color != "" {
+			s.colors[st.ID] = color
+		}
+	}
+	for _, sm := range doc.StyleMaps {
+		for _, pair := range sm.Pairs {
+			if strings.TrimSpace(pair.Key) == "normal" {
+				s.maps[sm.ID] = pair.StyleURL
+			}
+		}
+	}
+	return s
+}
+
+// color returns the placemark's color as "#rrggbb": its own style if it
+// has one, else the shared style it refers to, else ""
+func (s kmlStyles) color(pm kmlPlacemark) string {
+	if pm.Style != nil {
+		if color := styleColor(*pm.Style); color != "" {
+			return color
+		}
+	}
+	id := strings.TrimPrefix(strings.TrimSpace(pm.StyleURL), "#")
+	if url, ok := s.maps[id]; ok {
+		id = strings.TrimPrefix(strings.TrimSpace(url), "#")
+	}
+	return s.colors[id]
+}
+
+// styleColor returns a style's color as "#rrggbb", preferring the line
+// color since outlines are what the radar draws
+func styleColor(st kmlStyle) string {
+	var colors []string
+	if st.LineStyle != nil {
+		colors = append(colors, st.LineStyle.Color)
+	}
+	if st.PolyStyle != nil {
+		colors = append(colors, st.PolyStyle.Color)
+	}
+	if st.IconStyle != nil {
+		colors = append(colors, st.IconStyle.Color)
+	}
+	for _, c := range colors {
+		if color, ok := kmlColor(c); ok {
+			return color
+		}
+	}
+	return ""
+}
+
+// kmlColor converts a KML aabbggrr color to "#rrggbb". Fully transparent
+// colors are refused, as they would hide the feature.
+func kmlColor(s string) (string, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) != 8 {
+		return "", false
+	}
+	if _, err := strconv.ParseUint(s, 16, 32); err != nil || s[:2] == "00" {
+		return "", false
+	}
+	return "#" + strings.ToLower(s[6:8]+s[4:6]+s[2:4]), true
+}
+
+// checkPlacemark returns an error naming the placemark when one of its
+// coordinates can't be read. Empty geometries are allowed and skipped.
+func checkPlacemark(pm kmlPlacemark) error {
+	var coords []string
+	if pm.Point != nil {
+		coords = append(coords, pm.Point.Coordinates)
+	}
+	if pm.LineString != nil {
+		coords = append(coords, pm.LineString.Coordinates)
+	}
+	if pm.Polygon != nil {
+		coords = append(coords, pm.Polygon.OuterBoundaryIs.LinearRing.Coordinates)
+	}
+	if pm.MultiGeom != nil {
+		for _, pt := range pm.MultiGeom.Points {
+			coords = append(coords, pt.Coordinates)
+		}
+		for _, ls := range pm.MultiGeom.LineStrings {
+			coords = append(coords, ls.Coordinates)
+		}
+		for _, poly := range pm.MultiGeom.Polygons {
+			coords = append(coords, poly.OuterBoundaryIs.LinearRing.Coordinates)
+		}
+	}
+	for _, c := range coords {
+		for _, tuple := range strings.Fields(c) {
+			if len(parseKMLCoordinates(tuple)) == 0 {
+				return fmt.Errorf("placemark %q: bad coordinate %q (want longitude,latitude[,altitude])", pm.Name, tuple)
+			}
+		}
+	}
+	return nil
+}
+
 // collectPlacemarks recursively collects placemarks from a folder and its subfolders
 func collectPlacemarks(folder kmlFolder) []kmlPlacemark {
 	var result []kmlPlacemark
@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -799,3 +800,140 @@ func TestParseKMLCoordinatesSinglePart(t *testing.T) {
 		t.Errorf("Expected 0 coordinates with single part, got %d", len(coords))
 	}
 }
+
+func TestParseKMLStyles(t *testing.T) {
+	tmpDir := t.TempDir()
+	kmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Style id="restricted">
+      <LineStyle><color>ff0000ff</color></LineStyle>
+      <PolyStyle><color>7f00ff00</color></PolyStyle>
+    </Style>
+    <Style id="danger-normal">
+      <PolyStyle><color>ff00a5ff</color></PolyStyle>
+    </Style>
+    <Style id="hidden">
+      <LineStyle><color>00ffffff</color></LineStyle>
+    </Style>
+    <StyleMap id="danger">
+      <Pair><key>normal</key><styleUrl>#danger-normal</styleUrl></Pair>
+      <Pair><key>highlight</key><styleUrl>#restricted</styleUrl></Pair>
+    </StyleMap>
+    <Placemark>
+      <name>EHR1</name>
+      <styleUrl>#restricted</styleUrl>
+      <LineString><coordinates>4.0,52.0 4.1,52.1</coordinates></LineString>
+    </Placemark>
+    <Placemark>
+      <name>EHD2</name>
+      <styleUrl>#danger</styleUrl>
+      <LineString><coordinates>4.0,52.0 4.1,52.1</coordinates></LineString>
+    </Placemark>
+    <Placemark>
+      <name>Inline</name>
+      <styleUrl>#restricted</styleUrl>
+      <Style><IconStyle><color>FF112233</color></IconStyle></Style>
+      <Point><coordinates>4.0,52.0</coordinates></Point>
+    </Placemark>
+    <Placemark>
+      <name>Transparent</name>
+      <styleUrl>#hidden</styleUrl>
+      <Point><coordinates>4.0,52.0</coordinates></Point>
+    </Placemark>
+    <Placemark>
+      <name>Unknown</name>
+      <styleUrl>other.kml#style</styleUrl>
+      <Point><coordinates>4.0,52.0</coordinates></Point>
+    </Placemark>
+  </Document>
+</kml>`
+	kmlPath := filepath.Join(tmpDir, "styles.kml")
+	if err := os.WriteFile(kmlPath, []byte(kmlContent), 0o644); err != nil {
+		t.Fatalf("Failed to write KML file: %v", err)
+	}
+
+	overlay, err := LoadOverlay(kmlPath)
+	if err != nil {
+		t.Fatalf("Failed to load KML: %v", err)
+	}
+	want := map[string]string{
+		"EHR1":        "#ff0000", // line color over poly color
+		"EHD2":        "#ffa500", // style map's normal style
+		"Inline":      "#332211", // the placemark's own style
+		"Transparent": "",
+		"Unknown":     "",
+	}
+	for _, f := range overlay.Features {
+		if f.Style != want[f.Name] {
+			t.Errorf("%s: style %q, want %q", f.Name, f.Style, want[f.Name])
+		}
+	}
+	if overlay.Color != "" {
+		t.Errorf("styles should not set the overlay color, got %q", overlay.Color)
+	}
+}
+
+func TestRenderOverlayUsesFeatureStyles(t *testing.T) {
+	overlay := &GeoOverlay{Features: []GeoFeature{
+		{Type: OverlayPoint, Points: []GeoPoint{{Lat: 52.05, Lon: 4.0}}, Style: "#ff0000"},
+		{Type: OverlayPoint, Points: []GeoPoint{{Lat: 51.95, Lon: 4.0}}},
+	}}
+	colors := func() []string {
+		var got []string
+		for _, p := range RenderOverlayToRadar(overlay, 52.0, 4.0, 20, 40, 20, "cyan") {
+			got = append(got, p.Color)
+		}
+		return got
+	}
+
+	if got := colors(); len(got) != 2 || got[0] != "#ff0000" || got[1] != "cyan" {
+		t.Errorf("styled then unstyled point: %v", got)
+	}
+	overlay.Color = "green"
+	if got := colors(); len(got) != 2 || got[0] != "green" || got[1] != "green" {
+		t.Errorf("the overlay's color should win: %v", got)
+	}
+}
+
+func TestParseKMLErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "not KML",
+			content: `<?xml version="1.0"?><gpx><trk/></gpx>`,
+			want:    "failed to parse KML",
+		},
+		{
+			name: "no placemarks",
+			content: `<kml xmlns="http://www.opengis.net/kml/2.2"><Document>
+  <NetworkLink><Link><href>https://example.com/airspace.kml</href></Link></NetworkLink>
+</Document></kml>`,
+			want: "no placemarks",
+		},
+		{
+			name: "bad coordinates",
+			content: `<kml xmlns="http://www.opengis.net/kml/2.2"><Document><Placemark>
+  <name>EHR4</name>
+  <Polygon><outerBoundaryIs><LinearRing><coordinates>4.0,52.0 4.1;52.1 4.2,52.0</coordinates></LinearRing></outerBoundaryIs></Polygon>
+</Placemark></Document></kml>`,
+			want: `placemark "EHR4": bad coordinate "4.1;52.1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, strings.ReplaceAll(tt.name, " ", "_")+".kml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("Failed to write KML file: %v", err)
+			}
+			_, err := LoadOverlay(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
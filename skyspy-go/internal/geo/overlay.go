@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -36,7 +37,16 @@ type GeoFeature struct {
 	Points     []GeoPoint
 	Properties map[string]interface{}
 	Name       string
-	Style      string
+
+	// Style is the feature's own color from its file, such as a KML
+	// style's, drawn when the overlay has no color set
+	Style string
+
+	// Window is when the feature is in force, read from its start and end
+	// properties; the zero window is always in force. Inactive is set by
+	// OverlayManager.UpdateActive while the clock is outside the window.
+	Window   TimeWindow
+	Inactive bool
 }
 
 // GeoOverlay represents a collection of geographic features
@@ -46,7 +56,15 @@ type GeoOverlay struct {
 	Enabled    bool
 	Color      string
 	Opacity    float64
+	Brightness Brightness
 	SourceFile string
+
+	// RegionTagging tags aircraft with the polygon feature they are inside
+	RegionTagging bool
+
+	// HideInactive hides features outside their time window instead of
+	// drawing them dimmed
+	HideInactive bool
 }
 
 // RenderPoint represents a point to render on the radar
@@ -119,6 +137,62 @@ func (m *OverlayManager) SetOverlayColor(key, color string) {
 	}
 }
 
+// SetOverlayBrightness sets an overlay's brightness level
+func (m *OverlayManager) SetOverlayBrightness(key string, b Brightness) {
+	if overlay, exists := m.overlays[key]; exists {
+		overlay.Brightness = ParseBrightness(string(b))
+	}
+}
+
+// AdjustOverlayBrightness steps an overlay one level brighter or dimmer and
+// returns the new level
+func (m *OverlayManager) AdjustOverlayBrightness(key string, brighter bool) Brightness {
+	overlay, exists := m.overlays[key]
+	if !exists {
+		return BrightnessNormal
+	}
+	if brighter {
+		overlay.Brightness = overlay.Brightness.Brighter()
+	} else {
+		overlay.Brightness = overlay.Brightness.Dimmer()
+	}
+	return overlay.Brightness
+}
+
+// ToggleRegionTagging turns region tagging on or off for an overlay and
+// returns the new state
+func (m *OverlayManager) ToggleRegionTagging(key string) bool {
+	if overlay, exists := m.overlays[key]; exists {
+		overlay.RegionTagging = !overlay.RegionTagging
+		return overlay.RegionTagging
+	}
+	return false
+}
+
+// GetRegionOverlays returns the overlays with region tagging turned on, in
+// load order, whether or not they are drawn
+func (m *OverlayManager) GetRegionOverlays() []*GeoOverlay {
+	var result []*GeoOverlay
+	for _, key := range m.overlayOrder {
+		if overlay, exists := m.overlays[key]; exists && overlay.RegionTagging {
+			result = append(result, overlay)
+		}
+	}
+	return result
+}
+
+// GetOverlays returns every overlay in load order, whether or not it is
+// drawn
+func (m *OverlayManager) GetOverlays() []*GeoOverlay {
+	result := make([]*GeoOverlay, 0, len(m.overlayOrder))
+	for _, key := range m.overlayOrder {
+		if overlay, exists := m.overlays[key]; exists {
+			result = append(result, overlay)
+		}
+	}
+	return result
+}
+
 // GetEnabledOverlays returns all enabled overlays in render order
 func (m *OverlayManager) GetEnabledOverlays() []*GeoOverlay {
 	var result []*GeoOverlay
@@ -130,6 +204,56 @@ func (m *OverlayManager) GetEnabledOverlays() []*GeoOverlay {
 	return result
 }
 
+// UpdateActive marks each timed feature active or inactive at now and
+// reports whether any changed
+func (m *OverlayManager) UpdateActive(now time.Time) bool {
+	changed := false
+	for _, key := range m.overlayOrder {
+		overlay, exists := m.overlays[key]
+		if !exists {
+			continue
+		}
+		for i := range overlay.Features {
+			f := &overlay.Features[i]
+			if f.Window.IsZero() {
+				continue
+			}
+			if inactive := !f.Window.Contains(now); inactive != f.Inactive {
+				f.Inactive = inactive
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// FeatureWindow describes a timed feature for display
+type FeatureWindow struct {
+	Name   string
+	Window TimeWindow
+	Active bool
+}
+
+// GetFeatureWindows returns the timed features of an overlay in file order
+func (m *OverlayManager) GetFeatureWindows(key string) []FeatureWindow {
+	overlay, exists := m.overlays[key]
+	if !exists {
+		return nil
+	}
+	var result []FeatureWindow
+	for i, f := range overlay.Features {
+		if f.Window.IsZero() {
+			continue
+		}
+		name := f.Name
+		if name == "" {
+			name = fmt.Sprintf("%s #%d", overlay.Name, i+1)
+		}
+		result = append(result, FeatureWindow{Name: name, Window: f.Window, Active: !f.Inactive})
+	}
+	return result
+}
+
 // LoadFromFile loads an overlay from file and adds it
 func (m *OverlayManager) LoadFromFile(filepath string) (string, error) {
 	overlay, err := LoadOverlay(filepath)
@@ -141,9 +265,12 @@ func (m *OverlayManager) LoadFromFile(filepath string) (string, error) {
 
 // OverlayInfo contains overlay metadata
 type OverlayInfo struct {
-	Key     string
-	Name    string
-	Enabled bool
+	Key           string
+	Name          string
+	Enabled       bool
+	Brightness    Brightness
+	RegionTagging bool
+	Features      int
 }
 
 // GetOverlayList returns list of all overlays
@@ -152,9 +279,12 @@ func (m *OverlayManager) GetOverlayList() []OverlayInfo {
 	for _, key := range m.overlayOrder {
 		if overlay, exists := m.overlays[key]; exists {
 			result = append(result, OverlayInfo{
-				Key:     key,
-				Name:    overlay.Name,
-				Enabled: overlay.Enabled,
+				Key:           key,
+				Name:          overlay.Name,
+				Enabled:       overlay.Enabled,
+				Brightness:    ParseBrightness(string(overlay.Brightness)),
+				RegionTagging: overlay.RegionTagging,
+				Features:      len(overlay.Features),
 			})
 		}
 	}
@@ -171,10 +301,14 @@ func (m *OverlayManager) ToConfig() []map[string]interface{} {
 				"name":        overlay.Name,
 				"source_file": overlay.SourceFile,
 				"enabled":     overlay.Enabled,
+				"brightness":  string(ParseBrightness(string(overlay.Brightness))),
 			}
 			if overlay.Color != "" {
 				item["color"] = overlay.Color
 			}
+			if overlay.RegionTagging {
+				item["region_tagging"] = true
+			}
 			config = append(config, item)
 		}
 	}
@@ -371,6 +505,11 @@ func parseGeoJSONFeature(feature map[string]interface{}) []GeoFeature {
 		}
 	}
 
+	if window := parseTimeWindow(properties); !window.IsZero() {
+		for i := range result {
+			result[i].Window = window
+		}
+	}
 	return result
 }
 
@@ -504,30 +643,58 @@ func abs(x int) int {
 	return x
 }
 
-// RenderOverlayToRadar renders an overlay to radar coordinates
+// Default characters for overlay points without a label and for line and
+// polygon segments
+const (
+	PointChar = '◇'
+	LineChar  = '·'
+)
+
+// RenderOverlayToRadar renders an overlay to north-up radar coordinates
 func RenderOverlayToRadar(overlay *GeoOverlay, centerLat, centerLon, maxRange float64,
 	radarWidth, radarHeight int, themeColor string) []RenderPoint {
-	var points []RenderPoint
+	return RenderRotatedOverlay(overlay, centerLat, centerLon, maxRange, 0, radarWidth, radarHeight, themeColor)
+}
 
-	color := overlay.Color
-	if color == "" {
-		color = themeColor
-	}
+// RenderRotatedOverlay renders an overlay to radar coordinates on a scope
+// turned so that the rotation bearing points up
+func RenderRotatedOverlay(overlay *GeoOverlay, centerLat, centerLon, maxRange, rotation float64,
+	radarWidth, radarHeight int, themeColor string) []RenderPoint {
+	var points []RenderPoint
 
 	centerX := radarWidth / 2
 	centerY := radarHeight / 2
 	maxRadius := MaxRadarRadius(radarWidth, radarHeight)
 
 	for _, feature := range overlay.Features {
+		// The overlay's color wins over the feature's own, and either
+		// over the theme's
+		color := overlay.Color
+		if color == "" {
+			color = feature.Style
+		}
+		if color == "" {
+			color = themeColor
+		}
+		brightness := overlay.Brightness
+		if feature.Inactive {
+			if overlay.HideInactive {
+				continue
+			}
+			// Features outside their time window are drawn two levels dimmer
+			brightness = brightness.Dimmer().Dimmer()
+		}
+		color = AdjustColor(color, brightness)
+
 		switch feature.Type {
 		case OverlayPoint:
 			for _, point := range feature.Points {
 				dist := HaversineDistance(centerLat, centerLon, point.Lat, point.Lon)
 				if dist <= maxRange {
 					brg := BearingBetween(centerLat, centerLon, point.Lat, point.Lon)
-					x, y := GeoToRadar(dist, brg, maxRange, centerX, centerY, maxRadius)
+					x, y := GeoToRadar(dist, brg-rotation, maxRange, centerX, centerY, maxRadius)
 					if x >= 0 && x < radarWidth && y >= 0 && y < radarHeight {
-						char := '◇'
+						char := PointChar
 						if point.Label != "" {
 							char, _ = utf8.DecodeRuneInString(point.Label)
 						}
@@ -559,13 +726,13 @@ func RenderOverlayToRadar(overlay *GeoOverlay, centerLat, centerLon, maxRange fl
 				brg1 := BearingBetween(centerLat, centerLon, p1.Lat, p1.Lon)
 				brg2 := BearingBetween(centerLat, centerLon, p2.Lat, p2.Lon)
 
-				x1, y1 := GeoToRadar(dist1, brg1, maxRange, centerX, centerY, maxRadius)
-				x2, y2 := GeoToRadar(dist2, brg2, maxRange, centerX, centerY, maxRadius)
+				x1, y1 := GeoToRadar(dist1, brg1-rotation, maxRange, centerX, centerY, maxRadius)
+				x2, y2 := GeoToRadar(dist2, brg2-rotation, maxRange, centerX, centerY, maxRadius)
 
 				linePoints := BresenhamLine(x1, y1, x2, y2)
 				for _, lp := range linePoints {
 					if lp[0] >= 0 && lp[0] < radarWidth && lp[1] >= 0 && lp[1] < radarHeight {
-						points = append(points, RenderPoint{X: lp[0], Y: lp[1], Char: '·', Color: color})
+						points = append(points, RenderPoint{X: lp[0], Y: lp[1], Char: LineChar, Color: color})
 					}
 				}
 			}
@@ -604,6 +771,23 @@ func CreateRangeRingOverlay(centerLat, centerLon float64, ranges []float64, poin
 	return overlay
 }
 
+// CreatePOIOverlay creates an overlay marking points of interest. Each
+// point is drawn with the first letter of its label.
+func CreatePOIOverlay(points []GeoPoint) *GeoOverlay {
+	overlay := &GeoOverlay{
+		Name:    "Points of Interest",
+		Enabled: true,
+	}
+	for _, p := range points {
+		overlay.Features = append(overlay.Features, GeoFeature{
+			Type:   OverlayPoint,
+			Points: []GeoPoint{p},
+			Name:   p.Label,
+		})
+	}
+	return overlay
+}
+
 func minInt(a, b int) int {
 	if a < b {
 		return a
@@ -1336,3 +1336,45 @@ func TestLoadGeoJSONReadError(t *testing.T) {
 		t.Error("Expected error when reading directory as file")
 	}
 }
+
+func TestRenderRotatedOverlay_BearingUp(t *testing.T) {
+	overlay := &GeoOverlay{
+		Name: "Fix",
+		Features: []GeoFeature{
+			{Type: OverlayPoint, Points: []GeoPoint{{Lat: 52.2, Lon: 4.3}}},
+		},
+	}
+	brg := BearingBetween(52.0, 4.0, 52.2, 4.3)
+
+	points := RenderRotatedOverlay(overlay, 52.0, 4.0, 50, brg, 60, 30, "cyan")
+	if len(points) != 1 {
+		t.Fatalf("expected 1 point, got %d", len(points))
+	}
+	if points[0].X != 30 || points[0].Y >= 15 {
+		t.Errorf("expected point straight above center, got (%d,%d)", points[0].X, points[0].Y)
+	}
+
+	northUp := RenderOverlayToRadar(overlay, 52.0, 4.0, 50, 60, 30, "cyan")
+	if northUp[0].X == points[0].X {
+		t.Error("north-up rendering should differ from the rotated one")
+	}
+}
+
+func TestCreatePOIOverlay(t *testing.T) {
+	overlay := CreatePOIOverlay([]GeoPoint{
+		{Lat: 52.0, Lon: 4.0, Label: "Home"},
+		{Lat: 52.1, Lon: 4.1, Label: "Farm"},
+	})
+
+	if overlay.Name != "Points of Interest" || !overlay.Enabled {
+		t.Errorf("unexpected overlay %q enabled=%v", overlay.Name, overlay.Enabled)
+	}
+	if len(overlay.Features) != 2 {
+		t.Fatalf("expected one feature per point, got %d", len(overlay.Features))
+	}
+	for _, f := range overlay.Features {
+		if f.Type != OverlayPoint || len(f.Points) != 1 || f.Name != f.Points[0].Label {
+			t.Errorf("expected a labelled point feature, got %+v", f)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package geo provides geographic overlay support for SkySpy radar display
+package geo
+
+import "math"
+
+// PrivacyGridKm is the spacing of the grid positions are snapped to by
+// ApproximatePosition
+const PrivacyGridKm = 10.0
+
+// kmPerDegreeLat is the length of one degree of latitude in kilometres
+const kmPerDegreeLat = 111.32
+
+// ApproximatePosition snaps lat/lon to the centre of a ~PrivacyGridKm grid
+// cell so a displayed position can't be traced back to an exact location.
+// The grid is fixed, so the same input always gives the same output and a
+// stationary receiver never moves on screen.
+func ApproximatePosition(lat, lon float64) (float64, float64) {
+	latStep := PrivacyGridKm / kmPerDegreeLat
+	approxLat := (math.Floor(lat/latStep) + 0.5) * latStep
+	if approxLat > 90 {
+		approxLat = 90
+	} else if approxLat < -90 {
+		approxLat = -90
+	}
+
+	// Longitude cells widen toward the poles; size them from the snapped
+	// latitude so every point in a latitude band uses the same step
+	cosLat := math.Cos(approxLat * math.Pi / 180)
+	if cosLat < 0.01 {
+		return approxLat, 0
+	}
+	lonStep := latStep / cosLat
+	approxLon := (math.Floor(lon/lonStep) + 0.5) * lonStep
+	if approxLon > 180 {
+		approxLon -= 360
+	}
+	return approxLat, approxLon
+}
@@ -0,0 +1,55 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// kmBetween returns the flat-plane distance between two nearby points in km
+func kmBetween(lat1, lon1, lat2, lon2 float64) float64 {
+	dy := (lat2 - lat1) * kmPerDegreeLat
+	dx := (lon2 - lon1) * kmPerDegreeLat * math.Cos(lat1*math.Pi/180)
+	return math.Hypot(dx, dy)
+}
+
+func TestApproximatePosition_WithinCell(t *testing.T) {
+	points := [][2]float64{
+		{52.3676, 4.9041},
+		{40.7128, -74.0060},
+		{-33.8688, 151.2093},
+		{0.001, -0.001},
+		{64.1466, -21.9426},
+	}
+
+	// A point is at most half a cell diagonal from its cell centre
+	maxKm := PrivacyGridKm * math.Sqrt2 / 2 * 1.01
+	for _, p := range points {
+		lat, lon := ApproximatePosition(p[0], p[1])
+		if d := kmBetween(p[0], p[1], lat, lon); d > maxKm {
+			t.Errorf("ApproximatePosition(%v, %v) moved %.1fkm, want <= %.1fkm", p[0], p[1], d, maxKm)
+		}
+	}
+}
+
+func TestApproximatePosition_HidesSmallMoves(t *testing.T) {
+	lat, lon := ApproximatePosition(52.3676, 4.9041)
+
+	// Anywhere nearby in the same cell gives the identical answer
+	for _, d := range []float64{-0.001, 0.0005, 0.001} {
+		gotLat, gotLon := ApproximatePosition(52.3676+d, 4.9041-d)
+		if gotLat != lat || gotLon != lon {
+			t.Errorf("small move of %v gave a different position (%v, %v) vs (%v, %v)", d, gotLat, gotLon, lat, lon)
+		}
+	}
+}
+
+func TestApproximatePosition_Deterministic(t *testing.T) {
+	lat1, lon1 := ApproximatePosition(40.7128, -74.0060)
+	lat2, lon2 := ApproximatePosition(40.7128, -74.0060)
+	if lat1 != lat2 || lon1 != lon2 {
+		t.Error("expected the same input to always give the same position")
+	}
+	if lat1 == 40.7128 && lon1 == -74.0060 {
+		t.Error("expected the position to be moved to a cell centre")
+	}
+}
@@ -0,0 +1,107 @@
+// Package geo provides region lookup against overlay polygons
+package geo
+
+import "fmt"
+
+// region is one named polygon with its bounding box
+type region struct {
+	name                           string
+	points                         []GeoPoint
+	timed                          bool // in force only for a time window
+	minLat, minLon, maxLat, maxLon float64
+}
+
+// contains reports whether the point is inside the polygon, checking the
+// bounding box first (ray casting)
+func (r *region) contains(lat, lon float64) bool {
+	if lat < r.minLat || lat > r.maxLat || lon < r.minLon || lon > r.maxLon {
+		return false
+	}
+	inside := false
+	j := len(r.points) - 1
+	for i := range r.points {
+		pi, pj := r.points[i], r.points[j]
+		if (pi.Lon > lon) != (pj.Lon > lon) &&
+			lat < (pj.Lat-pi.Lat)*(lon-pi.Lon)/(pj.Lon-pi.Lon)+pi.Lat {
+			inside = !inside
+		}
+		j = i
+	}
+	return inside
+}
+
+// RegionIndex finds which overlay polygon, such as an ATC sector or FIR,
+// each aircraft is inside. The last match per aircraft is checked first,
+// since aircraft rarely change region between updates.
+type RegionIndex struct {
+	regions []region
+	last    map[string]int // hex -> index of the last matched region
+}
+
+// NewRegionIndex indexes the polygon features of the overlays with region
+// tagging turned on. Features without a name are called "<overlay> #n".
+// Timed features outside their window are left out, so rebuild the index
+// when OverlayManager.UpdateActive reports a change.
+func NewRegionIndex(overlays []*GeoOverlay) *RegionIndex {
+	x := &RegionIndex{last: make(map[string]int)}
+	for _, ov := range overlays {
+		if !ov.RegionTagging {
+			continue
+		}
+		for i, f := range ov.Features {
+			if f.Type != OverlayPolygon || len(f.Points) < 3 || f.Inactive {
+				continue
+			}
+			name := f.Name
+			if name == "" {
+				name = fmt.Sprintf("%s #%d", ov.Name, i+1)
+			}
+			r := region{name: name, points: f.Points, timed: !f.Window.IsZero()}
+			r.minLat, r.minLon = f.Points[0].Lat, f.Points[0].Lon
+			r.maxLat, r.maxLon = r.minLat, r.minLon
+			for _, p := range f.Points[1:] {
+				r.minLat, r.maxLat = min(r.minLat, p.Lat), max(r.maxLat, p.Lat)
+				r.minLon, r.maxLon = min(r.minLon, p.Lon), max(r.maxLon, p.Lon)
+			}
+			x.regions = append(x.regions, r)
+		}
+	}
+	return x
+}
+
+// Len returns the number of indexed regions
+func (x *RegionIndex) Len() int {
+	return len(x.regions)
+}
+
+// Locate returns the name of the region containing the position, or ""
+// when it is outside them all. Where regions overlap the first indexed
+// wins, unless the aircraft was already in another that still contains it.
+func (x *RegionIndex) Locate(hex string, lat, lon float64) string {
+	if len(x.regions) == 0 {
+		return ""
+	}
+	if i, ok := x.last[hex]; ok && x.regions[i].contains(lat, lon) {
+		return x.regions[i].name
+	}
+	for i := range x.regions {
+		if x.regions[i].contains(lat, lon) {
+			x.last[hex] = i
+			return x.regions[i].name
+		}
+	}
+	delete(x.last, hex)
+	return ""
+}
+
+// Timed reports whether the aircraft's last located region is a timed
+// feature, such as an active TFR
+func (x *RegionIndex) Timed(hex string) bool {
+	i, ok := x.last[hex]
+	return ok && x.regions[i].timed
+}
+
+// Forget drops the cached match for an aircraft that has gone
+func (x *RegionIndex) Forget(hex string) {
+	delete(x.last, hex)
+}
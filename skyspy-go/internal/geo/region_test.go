@@ -0,0 +1,107 @@
+package geo
+
+import "testing"
+
+// sectorOverlay returns an overlay with two adjacent square sectors and an
+// unnamed triangle to the south
+func sectorOverlay() *GeoOverlay {
+	return &GeoOverlay{
+		Name:          "Sectors",
+		RegionTagging: true,
+		Features: []GeoFeature{
+			{Type: OverlayPolygon, Name: "WEST", Points: []GeoPoint{
+				{Lat: 45, Lon: -94}, {Lat: 46, Lon: -94}, {Lat: 46, Lon: -93}, {Lat: 45, Lon: -93},
+			}},
+			{Type: OverlayPolygon, Name: "EAST", Points: []GeoPoint{
+				{Lat: 45, Lon: -93}, {Lat: 46, Lon: -93}, {Lat: 46, Lon: -92}, {Lat: 45, Lon: -92},
+			}},
+			{Type: OverlayLine, Name: "ROUTE", Points: []GeoPoint{
+				{Lat: 40, Lon: -100}, {Lat: 50, Lon: -90}, {Lat: 40, Lon: -90},
+			}},
+			{Type: OverlayPolygon, Points: []GeoPoint{
+				{Lat: 43, Lon: -94}, {Lat: 44, Lon: -93}, {Lat: 43, Lon: -92},
+			}},
+		},
+	}
+}
+
+func TestRegionIndex_Locate(t *testing.T) {
+	x := NewRegionIndex([]*GeoOverlay{sectorOverlay()})
+	if x.Len() != 3 {
+		t.Fatalf("expected 3 polygon regions, got %d", x.Len())
+	}
+
+	tests := []struct {
+		lat, lon float64
+		want     string
+	}{
+		{45.5, -93.5, "WEST"},
+		{45.5, -92.5, "EAST"},
+		{43.2, -93.0, "Sectors #4"},
+		{43.9, -93.9, ""}, // inside the triangle's bounding box only
+		{47.0, -93.0, ""},
+	}
+	for _, tt := range tests {
+		if got := x.Locate("ABC123", tt.lat, tt.lon); got != tt.want {
+			t.Errorf("Locate(%v, %v) = %q, want %q", tt.lat, tt.lon, got, tt.want)
+		}
+	}
+}
+
+func TestRegionIndex_StickyOnSharedBorder(t *testing.T) {
+	x := NewRegionIndex([]*GeoOverlay{sectorOverlay()})
+
+	if got := x.Locate("ABC123", 45.5, -92.5); got != "EAST" {
+		t.Fatalf("expected EAST, got %q", got)
+	}
+	// On the shared edge both squares match; the cached region wins
+	x.Locate("ABC123", 45.5, -92.999)
+	if got := x.Locate("ABC123", 45.5, -93.0); got != "EAST" {
+		t.Errorf("expected the aircraft to stay in EAST on the border, got %q", got)
+	}
+
+	x.Forget("ABC123")
+	if _, ok := x.last["ABC123"]; ok {
+		t.Error("Forget should drop the cached region")
+	}
+}
+
+func TestRegionIndex_IgnoresUntaggedOverlays(t *testing.T) {
+	ov := sectorOverlay()
+	ov.RegionTagging = false
+	x := NewRegionIndex([]*GeoOverlay{ov})
+	if x.Len() != 0 {
+		t.Errorf("untagged overlays should not be indexed, got %d regions", x.Len())
+	}
+	if got := x.Locate("ABC123", 45.5, -93.5); got != "" {
+		t.Errorf("expected no region, got %q", got)
+	}
+}
+
+func TestOverlayManager_RegionTagging(t *testing.T) {
+	m := NewOverlayManager()
+	key := m.AddOverlay(&GeoOverlay{Name: "Sectors"}, "")
+	m.AddOverlay(&GeoOverlay{Name: "Coast"}, "")
+
+	if len(m.GetRegionOverlays()) != 0 {
+		t.Fatal("region tagging should be off by default")
+	}
+	if !m.ToggleRegionTagging(key) {
+		t.Error("toggle should turn region tagging on")
+	}
+	if got := m.GetRegionOverlays(); len(got) != 1 || got[0].Name != "Sectors" {
+		t.Errorf("expected only Sectors tagged, got %v", got)
+	}
+	if !m.GetOverlayList()[0].RegionTagging {
+		t.Error("overlay list should report region tagging")
+	}
+	if got := m.ToConfig()[0]["region_tagging"]; got != true {
+		t.Errorf("config region_tagging = %v, want true", got)
+	}
+	if _, ok := m.ToConfig()[1]["region_tagging"]; ok {
+		t.Error("untagged overlays should not write region_tagging")
+	}
+	if m.ToggleRegionTagging("missing") {
+		t.Error("missing overlay should report off")
+	}
+}
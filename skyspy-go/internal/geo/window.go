@@ -0,0 +1,100 @@
+// Package geo provides time windows for temporary overlay features such as
+// TFRs and NOTAM areas
+package geo
+
+import (
+	"strings"
+	"time"
+)
+
+// TimeWindow is the period a feature is in force. A zero Start or End
+// leaves that side open; the zero window is always active.
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IsZero reports whether the window is unbounded on both sides
+func (w TimeWindow) IsZero() bool {
+	return w.Start.IsZero() && w.End.IsZero()
+}
+
+// Contains reports whether t falls inside the window. The start is
+// inclusive and the end exclusive.
+func (w TimeWindow) Contains(t time.Time) bool {
+	if !w.Start.IsZero() && t.Before(w.Start) {
+		return false
+	}
+	if !w.End.IsZero() && !t.Before(w.End) {
+		return false
+	}
+	return true
+}
+
+// String shows the window in UTC the way NOTAMs write it, e.g.
+// "17 Oct 1400-1800Z" or "from 17 Oct 1400Z"
+func (w TimeWindow) String() string {
+	const day, clock = "02 Jan ", "1504Z"
+	start, end := w.Start.UTC(), w.End.UTC()
+	switch {
+	case w.IsZero():
+		return "always"
+	case w.End.IsZero():
+		return "from " + start.Format(day+clock)
+	case w.Start.IsZero():
+		return "until " + end.Format(day+clock)
+	case start.YearDay() == end.YearDay() && start.Year() == end.Year():
+		return start.Format(day+"1504") + "-" + end.Format(clock)
+	}
+	return start.Format(day+clock) + "-" + end.Format(day+clock)
+}
+
+// Property names read for a feature's start and end, in order of preference
+var (
+	windowStartKeys = []string{"start", "start_time", "startTime", "effective"}
+	windowEndKeys   = []string{"end", "end_time", "endTime", "expires"}
+)
+
+// windowTimeLayouts are the accepted time formats; those without a zone are
+// taken as UTC
+var windowTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// parseTimeWindow reads a feature's time window from its properties. Times
+// may be ISO 8601 strings or Unix seconds. A value that can't be read
+// leaves that side open, so a malformed restriction is shown rather than
+// hidden.
+func parseTimeWindow(properties map[string]interface{}) TimeWindow {
+	return TimeWindow{
+		Start: propertyTime(properties, windowStartKeys),
+		End:   propertyTime(properties, windowEndKeys),
+	}
+}
+
+func propertyTime(properties map[string]interface{}, keys []string) time.Time {
+	for _, key := range keys {
+		switch v := properties[key].(type) {
+		case float64:
+			return time.Unix(int64(v), 0).UTC()
+		case string:
+			return parseWindowTime(v)
+		}
+	}
+	return time.Time{}
+}
+
+func parseWindowTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range windowTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
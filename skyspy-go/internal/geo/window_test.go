@@ -0,0 +1,190 @@
+package geo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTimeWindow_Contains(t *testing.T) {
+	start := time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		window TimeWindow
+		at     time.Time
+		want   bool
+	}{
+		{"before", TimeWindow{start, end}, start.Add(-time.Minute), false},
+		{"at start", TimeWindow{start, end}, start, true},
+		{"inside", TimeWindow{start, end}, start.Add(2 * time.Hour), true},
+		{"at end", TimeWindow{start, end}, end, false},
+		{"open start", TimeWindow{End: end}, start.Add(-48 * time.Hour), true},
+		{"open end", TimeWindow{Start: start}, end.Add(48 * time.Hour), true},
+		{"open end, before", TimeWindow{Start: start}, start.Add(-time.Second), false},
+		{"unbounded", TimeWindow{}, start, true},
+	}
+	for _, tt := range tests {
+		if got := tt.window.Contains(tt.at); got != tt.want {
+			t.Errorf("%s: Contains = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTimeWindow_String(t *testing.T) {
+	start := time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC)
+	local := time.FixedZone("CDT", -5*3600)
+
+	tests := []struct {
+		window TimeWindow
+		want   string
+	}{
+		{TimeWindow{start, start.Add(4 * time.Hour)}, "17 Oct 1400-1800Z"},
+		{TimeWindow{start.In(local), start.Add(4 * time.Hour).In(local)}, "17 Oct 1400-1800Z"},
+		{TimeWindow{start, start.Add(12 * time.Hour)}, "17 Oct 1400Z-18 Oct 0200Z"},
+		{TimeWindow{Start: start}, "from 17 Oct 1400Z"},
+		{TimeWindow{End: start}, "until 17 Oct 1400Z"},
+		{TimeWindow{}, "always"},
+	}
+	for _, tt := range tests {
+		if got := tt.window.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeWindow(t *testing.T) {
+	want := time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		props map[string]interface{}
+		start time.Time
+	}{
+		{"RFC 3339", map[string]interface{}{"start": "2026-10-17T14:00:00Z"}, want},
+		{"offset", map[string]interface{}{"start_time": "2026-10-17T09:00-05:00"}, want},
+		{"no zone is UTC", map[string]interface{}{"startTime": "2026-10-17 14:00"}, want},
+		{"unix seconds", map[string]interface{}{"effective": float64(want.Unix())}, want},
+		{"unreadable", map[string]interface{}{"start": "tomorrow"}, time.Time{}},
+		{"absent", map[string]interface{}{}, time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := parseTimeWindow(tt.props).Start; !got.Equal(tt.start) {
+			t.Errorf("%s: start = %v, want %v", tt.name, got, tt.start)
+		}
+	}
+}
+
+// tfrGeoJSON has a TFR in force 1400-1800Z and an untimed boundary
+const tfrGeoJSON = `{
+  "type": "FeatureCollection",
+  "features": [
+    {"type": "Feature",
+     "properties": {"name": "TFR 6/1234", "start": "2026-10-17T14:00:00Z", "end": "2026-10-17T18:00:00Z"},
+     "geometry": {"type": "Polygon", "coordinates": [[[-94,45],[-94,46],[-93,46],[-93,45],[-94,45]]]}},
+    {"type": "Feature",
+     "properties": {"name": "Boundary"},
+     "geometry": {"type": "LineString", "coordinates": [[-95,44],[-92,47]]}}
+  ]
+}`
+
+func loadTFROverlay(t *testing.T) (*OverlayManager, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tfr.geojson")
+	if err := os.WriteFile(path, []byte(tfrGeoJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewOverlayManager()
+	key, err := m.LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+	return m, key
+}
+
+func TestOverlayManager_UpdateActive(t *testing.T) {
+	m, key := loadTFROverlay(t)
+
+	windows := m.GetFeatureWindows(key)
+	if len(windows) != 1 || windows[0].Name != "TFR 6/1234" {
+		t.Fatalf("expected only the TFR to be timed, got %+v", windows)
+	}
+	if got := windows[0].Window.String(); got != "17 Oct 1400-1800Z" {
+		t.Errorf("window = %q", got)
+	}
+
+	before := time.Date(2026, 10, 17, 13, 0, 0, 0, time.UTC)
+	if !m.UpdateActive(before) {
+		t.Error("the TFR should go inactive before its window")
+	}
+	if m.UpdateActive(before.Add(30 * time.Minute)) {
+		t.Error("no change expected while still before the window")
+	}
+	if m.GetFeatureWindows(key)[0].Active {
+		t.Error("TFR should be inactive at 1330Z")
+	}
+
+	if !m.UpdateActive(before.Add(time.Hour)) || !m.GetFeatureWindows(key)[0].Active {
+		t.Error("TFR should be active from 1400Z")
+	}
+	if !m.UpdateActive(before.Add(5*time.Hour)) || m.GetFeatureWindows(key)[0].Active {
+		t.Error("TFR should be inactive from 1800Z")
+	}
+	if m.GetFeatureWindows("missing") != nil {
+		t.Error("unknown overlays have no windows")
+	}
+}
+
+func TestRenderOverlay_InactiveFeatures(t *testing.T) {
+	m, _ := loadTFROverlay(t)
+	overlay := m.GetEnabledOverlays()[0]
+	overlay.Color = "#00ff00"
+	render := func() map[string]int {
+		colors := make(map[string]int)
+		for _, p := range RenderOverlayToRadar(overlay, 45.5, -93.5, 100, 80, 40, "") {
+			colors[p.Color]++
+		}
+		return colors
+	}
+
+	active := render()
+	m.UpdateActive(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	dimmed := render()
+	if len(active) != 1 || len(dimmed) != 2 {
+		t.Errorf("expected the inactive TFR in a second, dimmer color: active %v, dimmed %v", active, dimmed)
+	}
+	if _, ok := dimmed[AdjustColor("#00ff00", BrightnessFaint)]; !ok {
+		t.Errorf("expected the TFR two levels dimmer, got %v", dimmed)
+	}
+
+	overlay.HideInactive = true
+	hidden := render()
+	if len(hidden) != 1 || hidden["#00ff00"] >= active["#00ff00"] {
+		t.Errorf("expected only the boundary drawn, got %v (all %v)", hidden, active)
+	}
+}
+
+func TestRegionIndex_SkipsInactiveFeatures(t *testing.T) {
+	m, _ := loadTFROverlay(t)
+	overlay := m.GetEnabledOverlays()[0]
+	overlay.RegionTagging = true
+
+	m.UpdateActive(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	if x := NewRegionIndex(m.GetRegionOverlays()); x.Len() != 0 {
+		t.Errorf("an inactive TFR should not be a region, got %d", x.Len())
+	}
+
+	m.UpdateActive(time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC))
+	x := NewRegionIndex(m.GetRegionOverlays())
+	if got := x.Locate("abc123", 45.5, -93.5); got != "TFR 6/1234" {
+		t.Fatalf("Locate = %q", got)
+	}
+	if !x.Timed("abc123") {
+		t.Error("the TFR region should be reported as timed")
+	}
+	if x.Timed("other") {
+		t.Error("an aircraft outside every region is not in a timed one")
+	}
+}
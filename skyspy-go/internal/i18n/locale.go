@@ -0,0 +1,129 @@
+// Package i18n provides locale-aware number and date formatting for the SkySpy UI
+package i18n
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale formats numbers, dates and times for display
+type Locale struct {
+	Tag        string // as configured, e.g. "de-DE"; empty for the built-in formats
+	Decimal    string // decimal separator
+	Group      string // thousands separator; empty for none
+	Clock12    bool   // 12-hour clock with AM/PM
+	DateLayout string // time.Format layout for dates
+}
+
+// localeFormat is a language's separators and date layout
+type localeFormat struct {
+	decimal, group, date string
+}
+
+// localeFormats lists the languages whose formats differ from the built-in
+// ones. Regions are ignored except for en-US, which uses a 12-hour clock
+// and month-first dates.
+var localeFormats = map[string]localeFormat{
+	"en": {".", ",", "02/01/2006"},
+	"de": {",", ".", "02.01.2006"},
+	"da": {",", ".", "02.01.2006"},
+	"nl": {",", ".", "02-01-2006"},
+	"it": {",", ".", "02/01/2006"},
+	"es": {",", ".", "02/01/2006"},
+	"pt": {",", ".", "02/01/2006"},
+	"el": {",", ".", "02/01/2006"},
+	"tr": {",", ".", "02.01.2006"},
+	"id": {",", ".", "02/01/2006"},
+	"fr": {",", " ", "02/01/2006"},
+	"sv": {",", " ", "2006-01-02"},
+	"fi": {",", " ", "02.01.2006"},
+	"nb": {",", " ", "02.01.2006"},
+	"no": {",", " ", "02.01.2006"},
+	"pl": {",", " ", "02.01.2006"},
+	"cs": {",", " ", "02.01.2006"},
+	"sk": {",", " ", "02.01.2006"},
+	"ru": {",", " ", "02.01.2006"},
+	"uk": {",", " ", "02.01.2006"},
+	"hu": {",", " ", "2006.01.02."},
+}
+
+// ParseLocale returns the formats for a locale tag such as "de-DE",
+// "fr_FR.UTF-8" or "en-US". An empty or unknown tag gives the built-in
+// formats: a decimal point, no digit grouping, a 24-hour clock and ISO
+// dates.
+func ParseLocale(tag string) Locale {
+	loc := Locale{Tag: tag, Decimal: ".", DateLayout: "2006-01-02"}
+
+	norm := strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if i := strings.IndexByte(norm, '.'); i >= 0 {
+		norm = norm[:i]
+	}
+	lang, region, _ := strings.Cut(norm, "-")
+	f, ok := localeFormats[lang]
+	if !ok {
+		return loc
+	}
+	loc.Decimal, loc.Group, loc.DateLayout = f.decimal, f.group, f.date
+	if lang == "en" && region == "us" {
+		loc.Clock12 = true
+		loc.DateLayout = "01/02/2006"
+	}
+	return loc
+}
+
+// Int formats n with digit grouping
+func (l Locale) Int(n int) string {
+	s := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + l.group(s[1:])
+	}
+	return l.group(s)
+}
+
+// Float formats f with prec decimal places
+func (l Locale) Float(f float64, prec int) string {
+	s := strconv.FormatFloat(f, 'f', prec, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	s = sign + l.group(whole)
+	if hasFrac {
+		s += l.Decimal + frac
+	}
+	return s
+}
+
+// group puts the thousands separator into a string of digits
+func (l Locale) group(digits string) string {
+	if l.Group == "" || len(digits) <= 3 {
+		return digits
+	}
+	var sb strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		sb.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteString(l.Group)
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+// Clock formats the time of day with seconds
+func (l Locale) Clock(t time.Time) string {
+	if l.Clock12 {
+		return t.Format("3:04:05 PM")
+	}
+	return t.Format("15:04:05")
+}
+
+// Date formats a calendar date
+func (l Locale) Date(t time.Time) string {
+	return t.Format(l.DateLayout)
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		tag            string
+		decimal, group string
+		clock12        bool
+		dateLayout     string
+	}{
+		{"", ".", "", false, "2006-01-02"},
+		{"xx-YY", ".", "", false, "2006-01-02"},
+		{"de-DE", ",", ".", false, "02.01.2006"},
+		{"fr_FR.UTF-8", ",", " ", false, "02/01/2006"},
+		{"en-US", ".", ",", true, "01/02/2006"},
+		{"en-GB", ".", ",", false, "02/01/2006"},
+	}
+	for _, tt := range tests {
+		l := ParseLocale(tt.tag)
+		if l.Decimal != tt.decimal || l.Group != tt.group || l.Clock12 != tt.clock12 || l.DateLayout != tt.dateLayout {
+			t.Errorf("ParseLocale(%q) = %+v", tt.tag, l)
+		}
+	}
+}
+
+func TestLocale_Numbers(t *testing.T) {
+	de := ParseLocale("de-DE")
+	tests := []struct {
+		got, want string
+	}{
+		{de.Int(1234567), "1.234.567"},
+		{de.Int(-4500), "-4.500"},
+		{de.Int(999), "999"},
+		{de.Float(12345.678, 1), "12.345,7"},
+		{de.Float(-0.5, 2), "-0,50"},
+		{ParseLocale("").Int(1234567), "1234567"},
+		{ParseLocale("").Float(1234.5, 1), "1234.5"},
+		{ParseLocale("fr-FR").Float(35000, 0), "35 000"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestLocale_Clock(t *testing.T) {
+	at := time.Date(2024, 3, 9, 14, 5, 7, 0, time.UTC)
+	if got := ParseLocale("de-DE").Clock(at); got != "14:05:07" {
+		t.Errorf("24h clock = %q", got)
+	}
+	if got := ParseLocale("en-US").Clock(at); got != "2:05:07 PM" {
+		t.Errorf("12h clock = %q", got)
+	}
+	if got := ParseLocale("de-DE").Date(at); got != "09.03.2024" {
+		t.Errorf("date = %q", got)
+	}
+	if got := ParseLocale("").Date(at); got != "2024-03-09" {
+		t.Errorf("date = %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+// Package i18n provides the SkySpy UI's string table and locale-aware
+// number and date formatting. English is built in; a strings file in the
+// config directory can replace any of it, key by key.
+package i18n
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+//go:embed en.json
+var englishJSON []byte
+
+var (
+	englishOnce    sync.Once
+	englishEntries map[string]string
+)
+
+// english returns the built-in English strings by key
+func english() map[string]string {
+	englishOnce.Do(func() {
+		if err := json.Unmarshal(englishJSON, &englishEntries); err != nil {
+			panic(fmt.Sprintf("i18n: embedded en.json: %v", err))
+		}
+	})
+	return englishEntries
+}
+
+// Table looks up UI strings by key
+type Table struct {
+	entries map[string]string
+}
+
+// English returns the built-in English table
+func English() *Table {
+	return &Table{entries: english()}
+}
+
+// Load returns the English table with the translations in the strings
+// file at path laid over it. A missing file is not an error. Keys English
+// doesn't have, and translations whose format verbs differ from the
+// English, are ignored so a stale or mistyped file can't garble the
+// display. On a read or parse error the English table is returned with
+// the error.
+func Load(path string) (*Table, error) {
+	translated, err := readFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return English(), nil
+	}
+	if err != nil {
+		return English(), err
+	}
+
+	base := english()
+	entries := make(map[string]string, len(base))
+	for key, text := range base {
+		entries[key] = text
+		if tr, ok := translated[key]; ok && tr != "" && sameVerbs(text, tr) {
+			entries[key] = tr
+		}
+	}
+	return &Table{entries: entries}, nil
+}
+
+// readFile parses a strings file: a JSON object of key to text
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("i18n: %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// T returns the text for key, or the key itself if there is none
+func (t *Table) T(key string) string {
+	if text, ok := t.entries[key]; ok {
+		return text
+	}
+	return key
+}
+
+// Tf formats the text for key with args, as fmt.Sprintf does
+func (t *Table) Tf(key string, args ...interface{}) string {
+	return fmt.Sprintf(t.T(key), args...)
+}
+
+// Keys returns every key in the built-in English table, sorted
+func Keys() []string {
+	keys := make([]string, 0, len(english()))
+	for key := range english() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Report lists the problems in a strings file, each sorted by key
+type Report struct {
+	Missing    []string // English keys with no translation; English is shown
+	Unknown    []string // keys English doesn't have, perhaps misspelled
+	Mismatched []string // translations whose format verbs differ from the English
+}
+
+// OK reports whether the file would be used without any changes
+func (r *Report) OK() bool {
+	return len(r.Missing) == 0 && len(r.Unknown) == 0 && len(r.Mismatched) == 0
+}
+
+// Check compares the strings file at path with the English table
+func Check(path string) (*Report, error) {
+	translated, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	base := english()
+	report := &Report{}
+	for _, key := range Keys() {
+		tr, ok := translated[key]
+		switch {
+		case !ok || tr == "":
+			report.Missing = append(report.Missing, key)
+		case !sameVerbs(base[key], tr):
+			report.Mismatched = append(report.Mismatched, key)
+		}
+	}
+	for key := range translated {
+		if _, ok := base[key]; !ok {
+			report.Unknown = append(report.Unknown, key)
+		}
+	}
+	sort.Strings(report.Unknown)
+	return report, nil
+}
+
+// formatVerb matches a fmt verb such as %s, %d or %.1f
+var formatVerb = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+
+// sameVerbs reports whether a translation takes the same format arguments,
+// in the same order, as the English text
+func sameVerbs(english, translated string) bool {
+	a, b := verbs(english), verbs(translated)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// verbs returns the argument-consuming verb letters in s
+func verbs(s string) []byte {
+	var out []byte
+	for _, v := range formatVerb.FindAllString(s, -1) {
+		if last := v[len(v)-1]; last != '%' {
+			out = append(out, last)
+		}
+	}
+	return out
+}
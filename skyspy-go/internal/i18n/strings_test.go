@@ -0,0 +1,136 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeStrings(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "strings.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestEnglish(t *testing.T) {
+	table := English()
+	if got := table.T("help.close"); got != "Press any key to close" {
+		t.Errorf("help.close = %q", got)
+	}
+	if got := table.Tf("notify.range", 75); got != "Range: 75nm" {
+		t.Errorf("notify.range = %q", got)
+	}
+	if got := table.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key should come back as itself, got %q", got)
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	table, err := Load(filepath.Join(t.TempDir(), "strings.json"))
+	if err != nil {
+		t.Fatalf("a missing file is not an error: %v", err)
+	}
+	if got := table.T("help.close"); got != "Press any key to close" {
+		t.Errorf("expected English, got %q", got)
+	}
+}
+
+func TestLoad_FallsBackPerKey(t *testing.T) {
+	path := writeStrings(t, `{
+		"help.close": "Beliebige Taste zum Schließen",
+		"notify.range": "Reichweite: %d sm",
+		"title.list": "LISTE (%s)",
+		"status.clock": "",
+		"help.bogus": "ignored"
+	}`)
+	table, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := table.T("help.close"); got != "Beliebige Taste zum Schließen" {
+		t.Errorf("help.close = %q", got)
+	}
+	if got := table.Tf("notify.range", 50); got != "Reichweite: 50 sm" {
+		t.Errorf("notify.range = %q", got)
+	}
+	// Wrong verb, empty text and untranslated keys all stay English
+	if got := table.Tf("title.list", 3); got != "LIST (3)" {
+		t.Errorf("title.list = %q", got)
+	}
+	if got := table.T("status.clock"); got != "CLOCK" {
+		t.Errorf("status.clock = %q", got)
+	}
+	if got := table.T("help.vu"); got != "VU / Profile" {
+		t.Errorf("help.vu = %q", got)
+	}
+	if got := table.T("help.bogus"); got != "help.bogus" {
+		t.Errorf("unknown keys should not be added, got %q", got)
+	}
+}
+
+func TestLoad_BadJSON(t *testing.T) {
+	table, err := Load(writeStrings(t, `{"help.close": `))
+	if err == nil {
+		t.Fatal("expected a parse error")
+	}
+	if got := table.T("help.close"); got != "Press any key to close" {
+		t.Errorf("expected English after an error, got %q", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	path := writeStrings(t, `{
+		"help.close": "Fermer",
+		"notify.range": "Portée : %s",
+		"help.typo": "x"
+	}`)
+	report, err := Check(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.OK() {
+		t.Fatal("report should not be OK")
+	}
+	if !reflect.DeepEqual(report.Unknown, []string{"help.typo"}) {
+		t.Errorf("Unknown = %v", report.Unknown)
+	}
+	if !reflect.DeepEqual(report.Mismatched, []string{"notify.range"}) {
+		t.Errorf("Mismatched = %v", report.Mismatched)
+	}
+	if want := len(Keys()) - 2; len(report.Missing) != want {
+		t.Errorf("Missing has %d keys, want %d", len(report.Missing), want)
+	}
+	for _, key := range report.Missing {
+		if key == "help.close" || key == "notify.range" {
+			t.Errorf("%s is translated, not missing", key)
+		}
+	}
+
+	if _, err := Check(filepath.Join(t.TempDir(), "none.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestSameVerbs(t *testing.T) {
+	tests := []struct {
+		english, translated string
+		want                bool
+	}{
+		{"Range: %dnm", "%d sm", true},
+		{"Lost %s", "%s perdu", true},
+		{"%d of %s", "%s of %d", false},
+		{"Saved %s", "Gespeichert", false},
+		{"100%% done", "fertig", true},
+		{"%.1f nm", "%.2f nm", true},
+	}
+	for _, tt := range tests {
+		if got := sameVerbs(tt.english, tt.translated); got != tt.want {
+			t.Errorf("sameVerbs(%q, %q) = %v, want %v", tt.english, tt.translated, got, tt.want)
+		}
+	}
+}
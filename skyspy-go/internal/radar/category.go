@@ -0,0 +1,92 @@
+// Package radar provides ADS-B emitter categories for the radar
+package radar
+
+import "strings"
+
+// Category is an ADS-B emitter category: the code broadcast by the
+// aircraft, a readable name and the class used for glyphs and search
+type Category struct {
+	Code  string
+	Name  string
+	Class string
+}
+
+// Category classes; aircraft of one class share a radar glyph
+const (
+	ClassLight      = "light"
+	ClassSmall      = "small"
+	ClassLarge      = "large"
+	ClassHeavy      = "heavy"
+	ClassFast       = "fast"
+	ClassRotorcraft = "rotorcraft"
+	ClassGlider     = "glider"
+	ClassBalloon    = "balloon"
+	ClassParachute  = "parachutist"
+	ClassUltralight = "ultralight"
+	ClassUAV        = "uav"
+	ClassSpace      = "space"
+	ClassSurface    = "surface"
+	ClassObstacle   = "obstacle"
+)
+
+// categories maps emitter category codes to their meaning (DO-260B). Set A
+// is powered aircraft by weight, B is unpowered and unusual aircraft, C is
+// surface vehicles and obstacles. The "0" codes mean no information and
+// the rest of the codes are reserved; both are left out.
+var categories = map[string]Category{
+	"A1": {"A1", "Light", ClassLight},             // under 15,500 lb
+	"A2": {"A2", "Small", ClassSmall},             // 15,500 to 75,000 lb
+	"A3": {"A3", "Large", ClassLarge},             // 75,000 to 300,000 lb
+	"A4": {"A4", "High vortex large", ClassLarge}, // e.g. B757
+	"A5": {"A5", "Heavy", ClassHeavy},             // over 300,000 lb
+	"A6": {"A6", "High performance", ClassFast},   // over 5g and 400 kt
+	"A7": {"A7", "Rotorcraft", ClassRotorcraft},
+	"B1": {"B1", "Glider", ClassGlider}, // glider or sailplane
+	"B2": {"B2", "Lighter than air", ClassBalloon},
+	"B3": {"B3", "Parachutist", ClassParachute}, // parachutist or skydiver
+	"B4": {"B4", "Ultralight", ClassUltralight}, // ultralight, hang glider or paraglider
+	"B6": {"B6", "UAV", ClassUAV},               // unmanned aerial vehicle
+	"B7": {"B7", "Space vehicle", ClassSpace},   // space or trans-atmospheric vehicle
+	"C1": {"C1", "Emergency vehicle", ClassSurface},
+	"C2": {"C2", "Service vehicle", ClassSurface},
+	"C3": {"C3", "Point obstacle", ClassObstacle}, // including tethered balloons
+	"C4": {"C4", "Cluster obstacle", ClassObstacle},
+	"C5": {"C5", "Line obstacle", ClassObstacle},
+}
+
+// LookupCategory returns the category for an emitter category code. Codes
+// without information, reserved codes and anything else report false.
+func LookupCategory(code string) (Category, bool) {
+	c, ok := categories[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// MatchesCategory reports whether an emitter category code matches a
+// search term: its code ("A7") or its class ("rotorcraft"), ignoring case
+func MatchesCategory(code, term string) bool {
+	c, ok := LookupCategory(code)
+	if !ok {
+		return false
+	}
+	return strings.EqualFold(term, c.Code) || strings.EqualFold(term, c.Class)
+}
+
+// EmitterCategory returns the target's emitter category, if it broadcasts
+// a known one
+func (t *Target) EmitterCategory() (Category, bool) {
+	return LookupCategory(t.Category)
+}
+
+// OnSurface reports whether the target is a surface vehicle or obstacle by
+// its emitter category, whatever altitude it reports
+func (t *Target) OnSurface() bool {
+	c, ok := t.EmitterCategory()
+	return ok && (c.Class == ClassSurface || c.Class == ClassObstacle)
+}
+
+// OnGround reports whether the target is on the airport surface: it says
+// it is on the ground, its altitude is at or below the field elevation
+// (ft), or it is a surface vehicle or obstacle
+func (t *Target) OnGround(fieldElevation int) bool {
+	return t.Ground || t.HasAlt && t.Altitude <= fieldElevation || t.OnSurface()
+}
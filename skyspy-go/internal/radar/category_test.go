@@ -0,0 +1,204 @@
+package radar
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+func TestLookupCategory_AllCodes(t *testing.T) {
+	tests := []struct {
+		code  string
+		name  string
+		class string
+	}{
+		{"A1", "Light", ClassLight},
+		{"A2", "Small", ClassSmall},
+		{"A3", "Large", ClassLarge},
+		{"A4", "High vortex large", ClassLarge},
+		{"A5", "Heavy", ClassHeavy},
+		{"A6", "High performance", ClassFast},
+		{"A7", "Rotorcraft", ClassRotorcraft},
+		{"B1", "Glider", ClassGlider},
+		{"B2", "Lighter than air", ClassBalloon},
+		{"B3", "Parachutist", ClassParachute},
+		{"B4", "Ultralight", ClassUltralight},
+		{"B6", "UAV", ClassUAV},
+		{"B7", "Space vehicle", ClassSpace},
+		{"C1", "Emergency vehicle", ClassSurface},
+		{"C2", "Service vehicle", ClassSurface},
+		{"C3", "Point obstacle", ClassObstacle},
+		{"C4", "Cluster obstacle", ClassObstacle},
+		{"C5", "Line obstacle", ClassObstacle},
+	}
+	for _, tt := range tests {
+		c, ok := LookupCategory(tt.code)
+		if !ok {
+			t.Errorf("%s: not found", tt.code)
+			continue
+		}
+		if c.Code != tt.code || c.Name != tt.name || c.Class != tt.class {
+			t.Errorf("%s = %+v, want %s/%s", tt.code, c, tt.name, tt.class)
+		}
+	}
+	if len(categories) != len(tests) {
+		t.Errorf("table has %d codes, tests cover %d", len(categories), len(tests))
+	}
+}
+
+func TestLookupCategory_UnknownCodes(t *testing.T) {
+	// No information, reserved and malformed codes are all unknown
+	for _, code := range []string{"", "A0", "B0", "B5", "C0", "C6", "C7", "D0", "D3", "A8", "Z1", "rotorcraft"} {
+		if c, ok := LookupCategory(code); ok {
+			t.Errorf("%q should be unknown, got %+v", code, c)
+		}
+	}
+	if c, ok := LookupCategory(" a7 "); !ok || c.Code != "A7" {
+		t.Errorf("codes should be matched ignoring case and space, got %+v", c)
+	}
+}
+
+func TestMatchesCategory(t *testing.T) {
+	if !MatchesCategory("A7", "rotorcraft") || !MatchesCategory("A7", "ROTORCRAFT") {
+		t.Error("A7 should match the rotorcraft class")
+	}
+	if !MatchesCategory("B6", "b6") {
+		t.Error("B6 should match its own code")
+	}
+	if !MatchesCategory("A4", "large") {
+		t.Error("A4 should match the large class")
+	}
+	if MatchesCategory("A3", "heavy") || MatchesCategory("", "light") || MatchesCategory("A0", "A0") {
+		t.Error("unexpected category match")
+	}
+}
+
+func TestTarget_OnSurface(t *testing.T) {
+	for code, want := range map[string]bool{"C1": true, "C2": true, "C3": true, "C5": true, "A7": false, "B6": false, "C0": false, "": false} {
+		if got := (&Target{Category: code}).OnSurface(); got != want {
+			t.Errorf("OnSurface(%q) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestScope_DrawTargets_CategoryGlyph(t *testing.T) {
+	th := theme.Get("classic")
+	g := th.GlyphSet()
+	tests := []struct {
+		category string
+		military bool
+		want     string
+	}{
+		{"A7", false, g.Rotorcraft},
+		{"B1", false, g.Glider},
+		{"B4", false, g.Glider},
+		{"B6", false, g.UAV},
+		{"C2", false, g.Vehicle},
+		{"A3", false, g.Aircraft},
+		{"", false, g.Aircraft},
+		{"A7", true, g.Military}, // status symbols win over the category
+	}
+	for _, tt := range tests {
+		scope := NewScope(th, 100.0, 4, false)
+		scope.Clear()
+		target := &Target{Hex: "CAT01", Distance: 20, Bearing: 90, HasLat: true, HasLon: true, Category: tt.category, Military: tt.military}
+		scope.DrawTargets(map[string]*Target{"CAT01": target}, "", false, false, false, false)
+
+		x, y := RotatedRadarPos(target.Distance, target.Bearing, 0, 100.0)
+		if got := string(scope.cells[y][x].char); got != tt.want {
+			t.Errorf("category %q: drew %q, want %q", tt.category, got, tt.want)
+		}
+	}
+}
+
+func TestScope_DrawTargets_HideGroundSurfaceVehicle(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+
+	targets := map[string]*Target{
+		// A tug reporting a field-elevation altitude
+		"tug":  {Hex: "tug", Distance: 5, Bearing: 10, HasLat: true, HasLon: true, HasAlt: true, Altitude: 1200, Category: "C2"},
+		"heli": {Hex: "heli", Distance: 8, Bearing: 20, HasLat: true, HasLon: true, HasAlt: true, Altitude: 1200, Category: "A7"},
+	}
+
+	scope.Clear()
+	sorted := scope.DrawTargets(targets, "", false, true, false, false)
+	if len(sorted) != 1 || sorted[0] != "heli" {
+		t.Errorf("expected the surface vehicle hidden, got %v", sorted)
+	}
+
+	scope.Clear()
+	if sorted := scope.DrawTargets(targets, "", false, false, false, false); len(sorted) != 2 {
+		t.Errorf("surface vehicles should show without the ground filter, got %v", sorted)
+	}
+}
+
+func TestTarget_OnGround(t *testing.T) {
+	tests := []struct {
+		name   string
+		target Target
+		field  int
+		want   bool
+	}{
+		{"reported on the ground", Target{Ground: true}, 0, true},
+		{"zero altitude", Target{HasAlt: true}, 0, true},
+		{"at field elevation", Target{HasAlt: true, Altitude: 1200}, 1225, true},
+		{"above the field", Target{HasAlt: true, Altitude: 1500}, 1225, false},
+		{"no altitude", Target{}, 0, false},
+		{"surface vehicle", Target{HasAlt: true, Altitude: 3000, Category: "C1"}, 0, true},
+	}
+	for _, tt := range tests {
+		if got := tt.target.OnGround(tt.field); got != tt.want {
+			t.Errorf("%s: OnGround(%d) = %v, want %v", tt.name, tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestScope_DrawTargets_SurfaceMode(t *testing.T) {
+	th := theme.Get("classic")
+	g := th.GlyphSet()
+	targets := map[string]*Target{
+		"taxi": {Hex: "taxi", Callsign: "KLM1234", Reg: "PH-BXA", Distance: 0.8, Bearing: 90, HasLat: true, HasLon: true, HasAlt: true, Altitude: -25},
+		"tug":  {Hex: "tug", Distance: 0.8, Bearing: 180, HasLat: true, HasLon: true, Category: "C2"},
+		"app":  {Hex: "app", Callsign: "EZY12", Reg: "G-EZAA", Distance: 0.8, Bearing: 270, HasLat: true, HasLon: true, HasAlt: true, Altitude: 800},
+	}
+	at := func(scope *Scope, hex string) cell {
+		x, y := RotatedRadarPos(targets[hex].Distance, targets[hex].Bearing, 0, 5)
+		return scope.cells[y][x]
+	}
+
+	scope := NewScope(th, 5, 4, false)
+	scope.SetSurface(-11, true, true)
+	scope.Clear()
+	scope.DrawTargets(targets, "", false, false, true, false)
+
+	if c := at(scope, "taxi"); string(c.char) != g.Taxiing || c.color != th.TextDim {
+		t.Errorf("an aircraft on the ground should be drawn small and dim, got %q", string(c.char))
+	}
+	if c := at(scope, "tug"); string(c.char) != g.Vehicle {
+		t.Errorf("a surface vehicle should keep its glyph, got %q", string(c.char))
+	}
+	if c := at(scope, "app"); string(c.char) != g.Aircraft || c.color != th.RadarTarget {
+		t.Errorf("airborne traffic should be drawn as usual, got %q", string(c.char))
+	}
+	rendered := ansi.Strip(scope.Render())
+	if !strings.Contains(rendered, "PHBXA") || !strings.Contains(rendered, "EZY12") {
+		t.Errorf("ground targets should be labelled by registration, airborne ones by callsign:\n%s", rendered)
+	}
+
+	// Out of surface mode ground aircraft look like any other
+	scope.SetSurface(-11, false, true)
+	scope.Clear()
+	scope.DrawTargets(targets, "", false, false, true, false)
+	if c := at(scope, "taxi"); string(c.char) != g.Aircraft {
+		t.Errorf("outside surface mode a ground aircraft should use the aircraft glyph, got %q", string(c.char))
+	}
+
+	// The ground filter uses the same field elevation
+	scope.Clear()
+	if sorted := scope.DrawTargets(targets, "", false, true, false, false); len(sorted) != 1 || sorted[0] != "app" {
+		t.Errorf("hiding ground targets should leave only the airborne one, got %v", sorted)
+	}
+}
@@ -0,0 +1,83 @@
+package radar
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+// cvdScene adds a military target and an emergency to the label scene
+func cvdScene() map[string]*Target {
+	targets := labelScene()
+	targets["ae1234"] = &Target{Hex: "ae1234", Callsign: "RCH42", Distance: 22, Bearing: 215, HasLat: true, HasLon: true,
+		Military: true}
+	targets["7e7e7e"] = &Target{Hex: "7e7e7e", Callsign: "BAW9", Distance: 20, Bearing: 20, HasLat: true, HasLon: true,
+		Squawk: "7700"}
+	return targets
+}
+
+// drawCVDFrame draws the scene with a theme, returning the frame's lines
+func drawCVDFrame(t *theme.Theme) []string {
+	scope := NewScope(t, 50, 4, false)
+	scope.Clear()
+	scope.DrawRangeRings()
+	scope.DrawTargets(cvdScene(), "d4e5f6", false, false, true, false)
+	return strings.Split(ansi.Strip(scope.Render()), "\n")
+}
+
+func TestScope_CVDGolden(t *testing.T) {
+	classic := theme.Get("classic")
+	normal := drawCVDFrame(classic)
+	safe := drawCVDFrame(classic.WithCVD(true))
+
+	// Normal and colorblind-safe frames side by side
+	var sb strings.Builder
+	for i := range normal {
+		sb.WriteString(normal[i] + "  " + safe[i] + "\n")
+	}
+	got := sb.String()
+
+	path := filepath.Join("testdata", "cvd.golden")
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden frame (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("frames differ from %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestScope_CVDCues(t *testing.T) {
+	scope := NewScope(theme.Get("classic").WithCVD(true), 50, 4, false)
+	scope.Clear()
+	scope.DrawTargets(cvdScene(), "d4e5f6", false, false, true, false)
+
+	var military, emergency, selected bool
+	for _, row := range scope.cells {
+		for x, c := range row {
+			switch c.char {
+			case '◆':
+				military = x > 0 && row[x-1].char == '[' && x+1 < len(row) && row[x+1].char == ']'
+			case '✖':
+				emergency = c.bold
+			case '◉':
+				selected = c.bold
+			}
+		}
+	}
+	if !military {
+		t.Error("military targets should be outlined")
+	}
+	if !emergency || !selected {
+		t.Error("emergencies and the selected target should be bold")
+	}
+}
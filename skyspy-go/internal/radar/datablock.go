@@ -0,0 +1,133 @@
+// Package radar provides quick-look data blocks for the radar scope
+package radar
+
+import "strings"
+
+// DataBlock is a floating block of target data, as a controller's quick
+// look shows
+type DataBlock struct {
+	Hex   string
+	Lines []string
+}
+
+// Symbols returns the targets the last DrawTargets drew one by one,
+// nearest first. Density shading leaves the rest out.
+func (s *Scope) Symbols() []TargetPosition {
+	var symbols []TargetPosition
+	for _, p := range s.placed {
+		if !s.shaded[p.Hex] {
+			symbols = append(symbols, p)
+		}
+	}
+	return symbols
+}
+
+// Nearby returns the targets the last DrawTargets placed within cells
+// cells of hex, hex included, nearest the receiver first
+func (s *Scope) Nearby(hex string, cells int) []string {
+	var center *TargetPosition
+	for i := range s.placed {
+		if s.placed[i].Hex == hex {
+			center = &s.placed[i]
+			break
+		}
+	}
+	if center == nil {
+		return nil
+	}
+	var near []string
+	for _, p := range s.placed {
+		if abs(p.X-center.X) <= cells && abs(p.Y-center.Y) <= cells {
+			near = append(near, p.Hex)
+		}
+	}
+	return near
+}
+
+// TargetBlock returns a target's data block: callsign, then altitude and
+// speed, e.g. "KLM123" over "FL350 450kt"
+func TargetBlock(t *Target) DataBlock {
+	label := t.Callsign
+	if label == "" {
+		label = strings.ToUpper(t.Hex)
+	}
+	lines := []string{label}
+	if extra := labelExtra(t, LabelSpeed); extra != "" {
+		lines = append(lines, extra)
+	}
+	return DataBlock{Hex: t.Hex, Lines: lines}
+}
+
+// DrawDataBlocks draws blocks beside their targets, as placed by the last
+// DrawTargets. Each block takes the first corner around its target where
+// it covers no target symbol and no earlier block, else the corner where
+// it covers fewest. Draw them last so nothing covers them.
+func (s *Scope) DrawDataBlocks(blocks []DataBlock) {
+	at := make(map[string]TargetPosition, len(s.placed))
+	taken := make(map[[2]int]bool, len(s.placed))
+	for _, p := range s.placed {
+		at[p.Hex] = p
+		taken[[2]int{p.X, p.Y}] = true
+	}
+
+	color := s.theme.PrimaryBright
+	for _, b := range blocks {
+		pos, ok := at[b.Hex]
+		if !ok || len(b.Lines) == 0 {
+			continue
+		}
+		width := 0
+		for _, line := range b.Lines {
+			width = max(width, len([]rune(line)))
+		}
+		height := len(b.Lines)
+
+		// Above right, below right, above left, below left
+		corners := [][2]int{
+			{pos.X + 2, pos.Y - height},
+			{pos.X + 2, pos.Y + 1},
+			{pos.X - 1 - width, pos.Y - height},
+			{pos.X - 1 - width, pos.Y + 1},
+		}
+		best, bestCovered := [2]int{}, -1
+		for _, c := range corners {
+			if c[0] < 0 || c[1] < 0 || c[0]+width > RadarWidth || c[1]+height > RadarHeight {
+				continue
+			}
+			covered := 0
+			for y := c[1]; y < c[1]+height; y++ {
+				for x := c[0]; x < c[0]+width; x++ {
+					if taken[[2]int{x, y}] {
+						covered++
+					}
+				}
+			}
+			if bestCovered < 0 || covered < bestCovered {
+				best, bestCovered = c, covered
+			}
+			if covered == 0 {
+				break
+			}
+		}
+		if bestCovered < 0 {
+			continue // no room on the scope
+		}
+
+		for i, line := range b.Lines {
+			y := best[1] + i
+			for x := best[0]; x < best[0]+width; x++ {
+				s.cells[y][x] = cell{char: ' '}
+				taken[[2]int{x, y}] = true
+			}
+			s.writeText(best[0], y, line, color)
+		}
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
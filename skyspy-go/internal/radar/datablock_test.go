@@ -0,0 +1,91 @@
+package radar
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+// blockRows draws targets, then blocks for hexes, and returns the frame's
+// rows without the border
+func blockRows(targets map[string]*Target, hexes ...string) []string {
+	scope := NewScope(theme.Get("classic"), 50, 4, false)
+	scope.Clear()
+	scope.DrawTargets(targets, "", false, false, false, false)
+	var blocks []DataBlock
+	for _, hex := range hexes {
+		blocks = append(blocks, TargetBlock(targets[hex]))
+	}
+	scope.DrawDataBlocks(blocks)
+	lines := strings.Split(ansi.Strip(scope.Render()), "\n")
+	rows := lines[1 : len(lines)-1]
+	for i, row := range rows {
+		rows[i] = string([]rune(row)[1 : RadarWidth+1])
+	}
+	return rows
+}
+
+// polarAt finds a distance and bearing that land on a scope cell
+func polarAt(t *testing.T, x, y int, maxRange float64) (float64, float64) {
+	t.Helper()
+	for d := 0.0; d <= maxRange; d += 0.1 {
+		for b := 0.0; b < 360; b++ {
+			if px, py := TargetToRadarPos(d, b, maxRange); px == x && py == y {
+				return d, b
+			}
+		}
+	}
+	t.Fatalf("no position lands on %d,%d", x, y)
+	return 0, 0
+}
+
+func TestDataBlocks_Placement(t *testing.T) {
+	targets := map[string]*Target{
+		"a1": {Hex: "a1", Callsign: "KLM123", Distance: 10, Bearing: 0, HasLat: true, HasLon: true,
+			Altitude: 35000, HasAlt: true, Speed: 450, HasSpeed: true},
+	}
+	x, y := TargetToRadarPos(10, 0, 50)
+
+	// Above and to the right of the symbol by default
+	rows := blockRows(targets, "a1")
+	if got := strings.TrimRight(string([]rune(rows[y-2])[x+2:]), " "); got != "KLM123" {
+		t.Errorf("callsign row = %q, want KLM123 above right", got)
+	}
+	if got := strings.TrimRight(string([]rune(rows[y-1])[x+2:]), " "); got != "FL350 450kt" {
+		t.Errorf("data row = %q, want FL350 450kt", got)
+	}
+
+	// A target in that corner pushes the block below
+	targets["b2"] = &Target{Hex: "b2", Callsign: "BAW9", HasLat: true, HasLon: true}
+	targets["b2"].Distance, targets["b2"].Bearing = polarAt(t, x+4, y-1, 50)
+	rows = blockRows(targets, "a1")
+	if !strings.Contains(rows[y+1], "KLM123") {
+		t.Errorf("the block should move below to keep off the other target:\n%s", strings.Join(rows, "\n"))
+	}
+
+	// Two blocks don't overlap
+	rows = blockRows(targets, "a1", "b2")
+	frame := strings.Join(rows, "\n")
+	if !strings.Contains(frame, "KLM123") || !strings.Contains(frame, "BAW9") {
+		t.Errorf("both blocks should be readable:\n%s", frame)
+	}
+}
+
+func TestDataBlocks_Nearby(t *testing.T) {
+	targets := map[string]*Target{
+		"a1": {Hex: "a1", Distance: 10, Bearing: 0, HasLat: true, HasLon: true},
+		"a2": {Hex: "a2", Distance: 12, Bearing: 5, HasLat: true, HasLon: true},
+		"a3": {Hex: "a3", Distance: 40, Bearing: 180, HasLat: true, HasLon: true},
+	}
+	scope := NewScope(theme.Get("classic"), 50, 4, false)
+	scope.DrawTargets(targets, "", false, false, false, false)
+	got := strings.Join(scope.Nearby("a1", 5), ",")
+	if got != "a1,a2" {
+		t.Errorf("Nearby = %s, want a1,a2", got)
+	}
+	if scope.Nearby("zz", 5) != nil {
+		t.Error("an unplaced target has nothing nearby")
+	}
+}
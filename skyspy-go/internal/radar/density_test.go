@@ -0,0 +1,123 @@
+package radar
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+// densityScene is continental traffic: a few thousand targets clustered
+// around hubs, plus a military target, an emergency and a watched one
+func densityScene() map[string]*Target {
+	rng := rand.New(rand.NewSource(1))
+	hubs := []struct{ distance, bearing, spread float64 }{
+		{40, 250, 25}, {180, 60, 40}, {260, 140, 30}, {320, 300, 50}, {120, 10, 15},
+	}
+	targets := make(map[string]*Target)
+	for i := 0; i < 3000; i++ {
+		hub := hubs[i%len(hubs)]
+		hex := fmt.Sprintf("%06x", i)
+		targets[hex] = &Target{
+			Hex:      hex,
+			Distance: max(0, hub.distance+rng.NormFloat64()*hub.spread),
+			Bearing:  hub.bearing + rng.NormFloat64()*hub.spread/4,
+			HasLat:   true,
+			HasLon:   true,
+		}
+	}
+	targets["ae1234"] = &Target{Hex: "ae1234", Callsign: "RCH42", Distance: 180, Bearing: 60, HasLat: true, HasLon: true,
+		Military: true}
+	targets["7e7e7e"] = &Target{Hex: "7e7e7e", Callsign: "BAW9", Distance: 260, Bearing: 140, HasLat: true, HasLon: true,
+		Squawk: "7700"}
+	targets["4ca1fe"] = &Target{Hex: "4ca1fe", Callsign: "EIN1", Distance: 40, Bearing: 250, HasLat: true, HasLon: true}
+	return targets
+}
+
+// drawDensityScene shades the scene at 400 nm with 000007 selected
+func drawDensityScene(targets map[string]*Target) (*Scope, []string) {
+	scope := NewScope(theme.Get("classic"), 400, 4, false)
+	scope.Clear()
+	scope.DrawRangeRings()
+	scope.SetDensity([]rune("░▒▓█"))
+	scope.SetWatched([]string{"4ca1fe"})
+	return scope, scope.DrawTargets(targets, "000007", false, false, true, false)
+}
+
+func TestScope_DensityGolden(t *testing.T) {
+	scope, _ := drawDensityScene(densityScene())
+	got := ansi.Strip(scope.Render()) + "\n"
+
+	path := filepath.Join("testdata", "density.golden")
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden frame (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("frame differs from %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestScope_DensityKeepsTargets(t *testing.T) {
+	targets := densityScene()
+	scope, sorted := drawDensityScene(targets)
+
+	// Every target on the scope can still be selected, nearest first
+	plain := NewScope(theme.Get("classic"), 400, 4, false)
+	want := plain.DrawTargets(targets, "000007", false, false, true, false)
+	if len(sorted) != len(want) || len(sorted) < 2500 {
+		t.Fatalf("shading should still return every target on the scope: %d, want %d", len(sorted), len(want))
+	}
+	for i := range want {
+		if sorted[i] != want[i] {
+			t.Fatalf("target %d = %s, want %s", i, sorted[i], want[i])
+		}
+	}
+
+	// Standouts are drawn with their own symbols over the shading
+	frame := ansi.Strip(scope.Render())
+	for _, symbol := range []string{"◆", "✖", "◉", "✦EIN1"} {
+		if !strings.Contains(frame, symbol) {
+			t.Errorf("%s should be drawn over the shading:\n%s", symbol, frame)
+		}
+	}
+	if strings.Count(frame, "✦") != 1 {
+		t.Errorf("ordinary targets should be shaded, not drawn:\n%s", frame)
+	}
+}
+
+func TestScope_DensityRamp(t *testing.T) {
+	targets := make(map[string]*Target)
+	for i := 0; i < 9; i++ {
+		hex := fmt.Sprintf("%06x", i)
+		targets[hex] = &Target{Hex: hex, Distance: 100, Bearing: 90, HasLat: true, HasLon: true}
+	}
+	x, y := TargetToRadarPos(100, 90, 400)
+	for _, tc := range []struct {
+		count int
+		want  rune
+	}{{1, '.'}, {2, ':'}, {3, ':'}, {4, '#'}, {9, '#'}} {
+		scope := NewScope(theme.Get("classic"), 400, 4, false)
+		scope.Clear()
+		scope.SetDensity([]rune(".:#"))
+		some := make(map[string]*Target)
+		for i := 0; i < tc.count; i++ {
+			hex := fmt.Sprintf("%06x", i)
+			some[hex] = targets[hex]
+		}
+		scope.DrawTargets(some, "", false, false, true, false)
+		if got := scope.cells[y][x].char; got != tc.want {
+			t.Errorf("%d targets shade as %q, want %q", tc.count, got, tc.want)
+		}
+	}
+}
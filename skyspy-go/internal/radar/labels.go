@@ -0,0 +1,124 @@
+// Package radar provides target label detail levels for the radar scope
+package radar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LabelDetail selects what a target's label shows
+type LabelDetail string
+
+const (
+	LabelNone     LabelDetail = "none"
+	LabelCallsign LabelDetail = "callsign"
+	LabelAltitude LabelDetail = "altitude" // callsign and altitude
+	LabelSpeed    LabelDetail = "speed"    // callsign, altitude and speed
+)
+
+// LabelDetails lists the levels in the order they are cycled
+var LabelDetails = []LabelDetail{LabelNone, LabelCallsign, LabelAltitude, LabelSpeed}
+
+// labelDescriptions names each level for notifications
+var labelDescriptions = map[LabelDetail]string{
+	LabelNone:     "NONE",
+	LabelCallsign: "CALLSIGN",
+	LabelAltitude: "CALLSIGN + ALT",
+	LabelSpeed:    "CALLSIGN + ALT + SPD",
+}
+
+// ParseLabelDetail returns the level named by s, ignoring case and
+// surrounding space. Empty or unknown names give LabelCallsign.
+func ParseLabelDetail(s string) LabelDetail {
+	d := LabelDetail(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := labelDescriptions[d]; ok {
+		return d
+	}
+	return LabelCallsign
+}
+
+// Next returns the following level, wrapping from the most detailed to none
+func (d LabelDetail) Next() LabelDetail {
+	for i, known := range LabelDetails {
+		if known == d {
+			return LabelDetails[(i+1)%len(LabelDetails)]
+		}
+	}
+	return LabelCallsign
+}
+
+// Description names the level, e.g. "CALLSIGN + ALT"
+func (d LabelDetail) Description() string {
+	return labelDescriptions[ParseLabelDetail(string(d))]
+}
+
+// FormatAltitude writes a barometric altitude as a flight level at and
+// above 18,000 ft and in feet below, e.g. "FL350" or "4500'"
+func FormatAltitude(alt int) string {
+	if alt >= 18000 {
+		return fmt.Sprintf("FL%03d", alt/100)
+	}
+	return fmt.Sprintf("%d'", alt)
+}
+
+// SetLabelDetail sets what target labels show
+func (s *Scope) SetLabelDetail(d LabelDetail) {
+	s.labelDetail = ParseLabelDetail(string(d))
+}
+
+// labelExtra is the altitude and speed a label shows beyond the callsign,
+// e.g. "FL350 450kt"; fields the target hasn't reported are left out
+func labelExtra(t *Target, d LabelDetail) string {
+	var parts []string
+	if (d == LabelAltitude || d == LabelSpeed) && t.HasAlt {
+		parts = append(parts, FormatAltitude(t.Altitude))
+	}
+	if d == LabelSpeed && t.HasSpeed {
+		parts = append(parts, fmt.Sprintf("%dkt", int(t.Speed)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// drawLabel writes a target's label beside its symbol. The altitude and
+// speed go on the row below the symbol when those cells are free, and
+// otherwise follow the callsign on one line.
+func (s *Scope) drawLabel(pos TargetPosition, labelX int, label, extra string, color lipgloss.Color, occupied map[[2]int]bool) {
+	if extra != "" && !s.rowFree(pos.X, pos.Y+1, len([]rune(extra)), occupied) {
+		label += " " + extra
+		extra = ""
+	}
+	s.writeText(labelX, pos.Y, label, color)
+	if extra != "" {
+		s.writeText(pos.X, pos.Y+1, extra, color)
+	}
+}
+
+// rowFree reports whether n cells from x on row y hold nothing but
+// background: rings, sweep, trails or overlays, and no target symbol
+func (s *Scope) rowFree(x, y, n int, occupied map[[2]int]bool) bool {
+	if y >= RadarHeight || x+n > RadarWidth {
+		return false
+	}
+	for i := x; i < x+n; i++ {
+		c := s.cells[y][i]
+		if occupied[[2]int{i, y}] || (c.char != ' ' && !c.background && !c.overlay) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeText writes a string into a row, cut at the scope's edge
+func (s *Scope) writeText(x, y int, text string, color lipgloss.Color) {
+	for _, ch := range text {
+		if x >= RadarWidth {
+			return
+		}
+		if x >= 0 {
+			s.cells[y][x] = cell{char: ch, color: color}
+		}
+		x++
+	}
+}
@@ -0,0 +1,96 @@
+package radar
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden frames in testdata")
+
+// labelScene is a fixed 50nm scene: two targets with room below their
+// symbols, and DAL45 with SWA9 just below it so its label must collapse
+func labelScene() map[string]*Target {
+	return map[string]*Target{
+		"a1b2c3": {Hex: "a1b2c3", Callsign: "UAL123", Distance: 6, Bearing: 60, HasLat: true, HasLon: true,
+			Altitude: 35000, HasAlt: true, Speed: 452, HasSpeed: true},
+		"d4e5f6": {Hex: "d4e5f6", Callsign: "N123AB", Distance: 30, Bearing: 250, HasLat: true, HasLon: true,
+			Altitude: 4500, HasAlt: true, Speed: 110, HasSpeed: true},
+		"0a0b0c": {Hex: "0a0b0c", Callsign: "DAL45", Distance: 5, Bearing: 150, HasLat: true, HasLon: true,
+			Altitude: 12000, HasAlt: true, Speed: 280, HasSpeed: true},
+		"1a1b1c": {Hex: "1a1b1c", Callsign: "SWA9", Distance: 9.4, Bearing: 152.5, HasLat: true, HasLon: true,
+			Altitude: 19000, HasAlt: true},
+	}
+}
+
+func TestScope_LabelDetailGolden(t *testing.T) {
+	for _, detail := range LabelDetails {
+		t.Run(string(detail), func(t *testing.T) {
+			scope := NewScope(theme.Get("classic"), 50, 4, false)
+			scope.Clear()
+			scope.DrawRangeRings()
+			scope.SetLabelDetail(detail)
+			scope.DrawTargets(labelScene(), "d4e5f6", false, false, true, false)
+			got := ansi.Strip(scope.Render()) + "\n"
+
+			path := filepath.Join("testdata", "labels_"+string(detail)+".golden")
+			if *updateGolden {
+				if err := os.MkdirAll("testdata", 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading golden frame (run with -update to create it): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("frame differs from %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+			}
+		})
+	}
+}
+
+func TestLabelDetail_Cycle(t *testing.T) {
+	d := LabelCallsign
+	var seen []LabelDetail
+	for range LabelDetails {
+		d = d.Next()
+		seen = append(seen, d)
+	}
+	want := []LabelDetail{LabelAltitude, LabelSpeed, LabelNone, LabelCallsign}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("cycle = %v, want %v", seen, want)
+		}
+	}
+	if ParseLabelDetail(" Speed ") != LabelSpeed || ParseLabelDetail("bogus") != LabelCallsign {
+		t.Error("ParseLabelDetail should ignore case and fall back to callsign")
+	}
+}
+
+func TestFormatAltitude(t *testing.T) {
+	tests := map[int]string{35000: "FL350", 18000: "FL180", 17900: "17900'", 0: "0'"}
+	for alt, want := range tests {
+		if got := FormatAltitude(alt); got != want {
+			t.Errorf("FormatAltitude(%d) = %q, want %q", alt, got, want)
+		}
+	}
+}
+
+func TestScope_LabelsOffHidesEveryLevel(t *testing.T) {
+	scope := NewScope(theme.Get("classic"), 50, 4, false)
+	scope.Clear()
+	scope.SetLabelDetail(LabelSpeed)
+	scope.DrawTargets(labelScene(), "", false, false, false, false)
+	if out := ansi.Strip(scope.Render()); strings.Contains(out, "UAL") || strings.Contains(out, "FL350") {
+		t.Errorf("show_labels off should hide labels at any level:\n%s", out)
+	}
+}
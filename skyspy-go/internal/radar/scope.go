@@ -6,11 +6,16 @@ package radar
 import (
 	"fmt"
 	"math"
+	"math/bits"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/skyspy/skyspy-go/internal/geo"
 	"github.com/skyspy/skyspy-go/internal/theme"
+	"github.com/skyspy/skyspy-go/internal/units"
 )
 
 // Radar dimensions
@@ -36,6 +41,9 @@ type Target struct {
 	RSSI     float64
 	Squawk   string
 	ACType   string
+	Category string // ADS-B emitter category code; empty when not broadcast
+	Reg      string // registration; empty when the feed doesn't know it
+	Ground   bool   // the aircraft reports being on the ground
 	Military bool
 	HasLat   bool
 	HasLon   bool
@@ -44,17 +52,149 @@ type Target struct {
 	HasTrack bool
 	HasVS    bool
 	HasRSSI  bool
+
+	// Barometric and GNSS altitudes as reported; Altitude is whichever the
+	// radar colors and filters by
+	BaroAltitude int
+	GeomAltitude int
+	HasBaroAlt   bool
+	HasGeomAlt   bool
+
+	// The altitude is outside the plausible bounds but was kept
+	AltImplausible bool
+
+	// When Lat/Lon were reported, and how many reported positions have
+	// been dropped for implying an impossible speed: in all, and since the
+	// last one kept
+	PositionAt        time.Time
+	RejectedPositions int
+	RejectedRun       int
+
+	// Selected altitude/heading, baro setting and autopilot modes
+	NavAltitude   int
+	NavHeading    float64
+	NavQNH        float64
+	NavModes      []string
+	HasNavAlt     bool
+	HasNavHeading bool
+	HasNavQNH     bool
+
+	// RSSI statistics over the target's lifetime
+	Signal SignalStats
+
+	// Vertical rate smoothed over recent updates, steadier than Vertical
+	// for telling a sustained climb or descent from a noisy report
+	VSTrend    float64
+	HasVSTrend bool
+
+	// When the session first saw the target, and how far (nm) it has flown
+	// along its trail since, not counting trail gaps
+	FirstSeen time.Time
+	TrackNM   float64
+
+	// Position jumps suggest a second aircraft is using the same address
+	Conflicted bool
+
+	// Receivers seeing the aircraft, by name, when the feeds of several
+	// are merged
+	Receivers []string
+
+	// The aircraft is on the watch list
+	OnWatchlist bool
+
+	// Special squawk codes the target is classified by; nil for the
+	// built-in ones. Not saved with the target: it comes from the settings.
+	SquawkCodes SquawkTable `json:"-"`
+
+	// Manufacturer and model for ACType, e.g. "Airbus A320", and its class:
+	// heavy, medium, light or rotorcraft. Empty when the type isn't known.
+	TypeName  string
+	TypeClass string
+
+	// Name of the tagged overlay region the target is inside, if any, and
+	// whether that region is a timed feature such as an active TFR
+	Region           string
+	RegionRestricted bool
+}
+
+// signalEWMAAlpha weights the newest RSSI reading in the running average
+const signalEWMAAlpha = 0.2
+
+// SignalStats accumulates RSSI readings: lifetime minimum and maximum and an
+// exponentially weighted moving average
+type SignalStats struct {
+	Min     float64
+	Max     float64
+	Avg     float64
+	Samples int
+}
+
+// Add records one RSSI reading
+func (s *SignalStats) Add(rssi float64) {
+	if s.Samples == 0 {
+		s.Min, s.Max, s.Avg = rssi, rssi, rssi
+	} else {
+		s.Min = math.Min(s.Min, rssi)
+		s.Max = math.Max(s.Max, rssi)
+		s.Avg += (rssi - s.Avg) * signalEWMAAlpha
+	}
+	s.Samples++
+}
+
+// vsTrendAlpha weights the newest vertical rate in the smoothed trend
+const vsTrendAlpha = 0.3
+
+// SmoothVS carries the vertical rate trend over from the target's previous
+// state and adds this update's rate to it. An update without a rate keeps
+// the trend as it was.
+func (t *Target) SmoothVS(prev *Target) {
+	if prev != nil {
+		t.VSTrend, t.HasVSTrend = prev.VSTrend, prev.HasVSTrend
+	}
+	switch {
+	case !t.HasVS:
+	case t.HasVSTrend:
+		t.VSTrend += (t.Vertical - t.VSTrend) * vsTrendAlpha
+	default:
+		t.VSTrend, t.HasVSTrend = t.Vertical, true
+	}
 }
 
-// IsEmergency returns true if the target has an emergency squawk
+// IsEmergency returns true if the target's squawk is classed as an
+// emergency
 func (t *Target) IsEmergency() bool {
-	return t.Squawk == "7500" || t.Squawk == "7600" || t.Squawk == "7700"
+	return t.SquawkSeverity() == SquawkEmergency
+}
+
+// BaroAlt returns the barometric altitude. Targets built without the
+// separate altitudes give Altitude.
+func (t *Target) BaroAlt() (int, bool) {
+	if t.HasBaroAlt {
+		return t.BaroAltitude, true
+	}
+	if t.HasGeomAlt {
+		return 0, false
+	}
+	return t.Altitude, t.HasAlt
+}
+
+// AltSplit returns how far the GNSS altitude is above the barometric one,
+// when both are known
+func (t *Target) AltSplit() (int, bool) {
+	baro, ok := t.BaroAlt()
+	if !ok || !t.HasGeomAlt {
+		return 0, false
+	}
+	return t.GeomAltitude - baro, true
 }
 
 // cell represents a single radar cell with character and color
 type cell struct {
-	char  rune
-	color lipgloss.Color
+	char       rune
+	color      lipgloss.Color
+	overlay    bool
+	background bool // a ring, sweep or trail that labels may cover
+	bold       bool
 }
 
 // Scope handles radar scope rendering
@@ -62,8 +202,39 @@ type Scope struct {
 	cells       [][]cell
 	theme       *theme.Theme
 	maxRange    float64
+	unit        string // distance unit the range is labelled in
 	rangeRings  int
 	showCompass bool
+	pinned      map[string]bool
+	turns       map[string]TurnMark
+	rotation    float64 // bearing drawn at the top of the scope; 0 is north-up
+	highlight   bool    // draw the border highlighted, e.g. as the active pane
+	altColors   bool    // color ordinary targets by altitude
+	labelDetail LabelDetail
+
+	// The receiver's place relative to the center of the scope, for a view
+	// centered elsewhere. Rings, sweep and compass are drawn around it.
+	receiverDistance float64
+	receiverBearing  float64
+
+	// Ground targets are judged against the field elevation (ft). In
+	// surface mode they are drawn apart from airborne traffic, labelled
+	// with their registration when surfaceReg is set.
+	fieldElevation int
+	surface        bool
+	surfaceReg     bool
+
+	// With a density ramp, ordinary targets are counted into their cells
+	// and each cell is shaded by its count. Emergencies, military,
+	// watched, watch list, pinned and selected targets are still drawn on
+	// top.
+	density []rune
+	watched map[string]bool
+
+	// Where the last DrawTargets put each target, nearest first, and
+	// which of them were shaded rather than drawn
+	placed []TargetPosition
+	shaded map[string]bool
 }
 
 // NewScope creates a new radar scope
@@ -79,8 +250,10 @@ func NewScope(t *theme.Theme, maxRange float64, rangeRings int, showCompass bool
 		cells:       cells,
 		theme:       t,
 		maxRange:    maxRange,
+		unit:        units.NM,
 		rangeRings:  rangeRings,
 		showCompass: showCompass,
+		labelDetail: LabelCallsign,
 	}
 }
 
@@ -93,16 +266,116 @@ func (s *Scope) Clear() {
 	}
 }
 
+// SetPinned marks targets that are drawn with a persistent ring and kept
+// visible regardless of filters
+func (s *Scope) SetPinned(hexes []string) {
+	s.pinned = make(map[string]bool, len(hexes))
+	for _, hex := range hexes {
+		s.pinned[hex] = true
+	}
+}
+
+// SetSurface sets the field elevation (ft) ground targets are judged
+// against, for hiding them and for surface mode. In surface mode ground
+// targets get small symbols of their own, outside the airborne colors,
+// and registration labels them by registration where it is known.
+func (s *Scope) SetSurface(fieldElevation int, on, registration bool) {
+	s.fieldElevation = fieldElevation
+	s.surface = on
+	s.surfaceReg = registration
+}
+
+// SetDensity shades cells by how many targets they hold, stepping up the
+// ramp (light to full) each time the count doubles, instead of drawing
+// every target. A nil ramp draws every target.
+func (s *Scope) SetDensity(ramp []rune) {
+	s.density = ramp
+}
+
+// SetWatched marks targets an alert rule has fired for, which density
+// shading still draws one by one
+func (s *Scope) SetWatched(hexes []string) {
+	s.watched = make(map[string]bool, len(hexes))
+	for _, hex := range hexes {
+		s.watched[hex] = true
+	}
+}
+
+// standsOut reports whether density shading still draws a target
+func (s *Scope) standsOut(t *Target, selectedHex string) bool {
+	return t.Hex == selectedHex || s.pinned[t.Hex] || s.watched[t.Hex] || t.OnWatchlist || t.Military ||
+		t.SquawkSeverity() == SquawkEmergency
+}
+
+// TurnMark is the turn indicator drawn beside a turning target
+type TurnMark struct {
+	Right   bool // turning right (clockwise); otherwise left
+	Holding bool // sustained turning that looks like a holding pattern
+}
+
+// SetTurns sets the turn indicators for the next DrawTargets call. Targets
+// flying straight are simply left out of the map.
+func (s *Scope) SetTurns(turns map[string]TurnMark) {
+	s.turns = turns
+}
+
+// SetRotation turns the scope so the given bearing points up. Zero is the
+// standard north-up display.
+func (s *Scope) SetRotation(bearing float64) {
+	s.rotation = bearing
+}
+
+// SetReceiver places the receiver distance nm from the center of the scope
+// on the given bearing, for a view centered somewhere else. Range rings,
+// sweep and compass stay around the receiver. Set it before drawing them.
+func (s *Scope) SetReceiver(distance, bearing float64) {
+	s.receiverDistance = distance
+	s.receiverBearing = bearing
+}
+
+// origin returns the receiver's cell, which may be off the scope
+func (s *Scope) origin() (int, int) {
+	if s.receiverDistance == 0 {
+		return RadarCenterX, RadarCenterY
+	}
+	return radarOffset(s.receiverDistance, s.receiverBearing, s.rotation, s.maxRange)
+}
+
+// SetHighlight draws the scope border in the highlight color, marking it as
+// the active pane when two scopes are shown
+func (s *Scope) SetHighlight(on bool) {
+	s.highlight = on
+}
+
 // SetTheme updates the theme
 func (s *Scope) SetTheme(t *theme.Theme) {
 	s.theme = t
 }
 
+// glyph returns the scope cell character for a glyph set entry
+func glyph(g string) rune {
+	r, _ := utf8.DecodeRuneInString(g)
+	return r
+}
+
 // SetRange updates the max range
 func (s *Scope) SetRange(maxRange float64) {
 	s.maxRange = maxRange
 }
 
+// SetUnits labels the range in the given distance unit; nm unless it's km
+// or mi
+func (s *Scope) SetUnits(unit string) {
+	s.unit = units.Distance(unit)
+}
+
+// SetAltitudeColors colors ordinary targets by altitude band, on the
+// theme's altitude gradient. Emergencies, military, special squawks and
+// the selected target keep their own colors.
+func (s *Scope) SetAltitudeColors(on bool) {
+	s.altColors = on
+}
+
 // SetRangeRings updates range ring count
 func (s *Scope) SetRangeRings(rings int) {
 	s.rangeRings = rings
@@ -110,8 +383,9 @@ func (s *Scope) SetRangeRings(rings int) {
 
 // DrawRangeRings draws the range rings
 func (s *Scope) DrawRangeRings() {
-	cx, cy := RadarCenterX, RadarCenterY
+	cx, cy := s.origin()
 	maxRadius := geo.MaxRadarRadius(RadarWidth, RadarHeight)
+	ringChar := glyph(s.theme.GlyphSet().RangeRing)
 
 	for ring := 1; ring <= s.rangeRings; ring++ {
 		ringRadius := float64(ring) / float64(s.rangeRings) * float64(maxRadius)
@@ -121,7 +395,7 @@ func (s *Scope) DrawRangeRings() {
 			y := int(float64(cy) + ringRadius*math.Sin(angleRad))
 			if x >= 0 && x < RadarWidth && y >= 0 && y < RadarHeight {
 				if s.cells[y][x].char == ' ' {
-					s.cells[y][x] = cell{char: '·', color: s.theme.RadarRing}
+					s.cells[y][x] = cell{char: ringChar, color: s.theme.RadarRing, background: true}
 				}
 			}
 		}
@@ -134,59 +408,79 @@ func (s *Scope) DrawCompass() {
 		return
 	}
 
-	cx, cy := RadarCenterX, RadarCenterY
+	cx, cy := s.origin()
 	maxRadius := geo.MaxRadarRadius(RadarWidth, RadarHeight)
-
-	// Draw axes
-	for i := 1; i < maxRadius; i++ {
-		// Vertical (N-S)
-		for _, dy := range []int{-i, i} {
-			ny := cy + dy
-			if ny >= 0 && ny < RadarHeight {
-				s.cells[ny][cx] = cell{char: '│', color: s.theme.RadarRing}
-			}
-		}
-		// Horizontal (E-W)
-		for _, dx := range []int{-i * 2, i * 2} {
-			nx := cx + dx
-			if nx >= 0 && nx < RadarWidth {
-				s.cells[cy][nx] = cell{char: '─', color: s.theme.RadarRing}
+	g := s.theme.GlyphSet()
+
+	// Draw axes along the (possibly rotated) cardinal directions
+	for _, bearing := range []float64{0, 90, 180, 270} {
+		ch := axisChar(g, bearing-s.rotation)
+		for i := 1; i < maxRadius; i++ {
+			x, y := compassOffset(bearing-s.rotation, float64(i))
+			if nx, ny := cx+x, cy+y; nx >= 0 && nx < RadarWidth && ny >= 0 && ny < RadarHeight {
+				s.cells[ny][nx] = cell{char: ch, color: s.theme.RadarRing, background: true}
 			}
 		}
 	}
 
 	// Draw cardinal labels
 	labels := []struct {
-		label  string
-		dx, dy int
+		label   rune
+		bearing float64
 	}{
-		{"N", 0, -maxRadius},
-		{"S", 0, maxRadius},
-		{"E", maxRadius * 2, 0},
-		{"W", -maxRadius * 2, 0},
+		{'N', 0},
+		{'E', 90},
+		{'S', 180},
+		{'W', 270},
 	}
 	for _, l := range labels {
-		lx, ly := cx+l.dx, cy+l.dy
+		dx, dy := compassOffset(l.bearing-s.rotation, float64(maxRadius))
+		lx, ly := cx+dx, cy+dy
 		if lx >= 0 && lx < RadarWidth && ly >= 0 && ly < RadarHeight {
-			s.cells[ly][lx] = cell{char: rune(l.label[0]), color: s.theme.SecondaryBright}
+			s.cells[ly][lx] = cell{char: l.label, color: s.theme.SecondaryBright}
 		}
 	}
 
-	// Center crosshair
-	s.cells[cy][cx] = cell{char: '╋', color: s.theme.PrimaryBright}
+	// Receiver crosshair, when it is on the scope
+	if cx >= 0 && cx < RadarWidth && cy >= 0 && cy < RadarHeight {
+		s.cells[cy][cx] = cell{char: glyph(g.Center), color: s.theme.PrimaryBright}
+	}
+}
+
+// compassOffset returns the cell offset of a point radius rows out from the
+// center along a screen bearing (0 = up)
+func compassOffset(screenBearing, radius float64) (int, int) {
+	rad := (screenBearing - 90) * math.Pi / 180
+	return int(math.Round(radius * math.Cos(rad) * 2)), int(math.Round(radius * math.Sin(rad)))
+}
+
+// axisChar picks the line character closest to a screen bearing
+func axisChar(g *theme.GlyphSet, screenBearing float64) rune {
+	a := math.Mod(math.Mod(screenBearing, 180)+180, 180)
+	switch {
+	case a < 22.5 || a >= 157.5:
+		return glyph(g.AxisVertical)
+	case a < 67.5:
+		return glyph(g.AxisRising)
+	case a < 112.5:
+		return glyph(g.AxisLevel)
+	default:
+		return glyph(g.AxisFalling)
+	}
 }
 
 // DrawSweep draws the radar sweep line
 func (s *Scope) DrawSweep(sweepAngle float64) {
-	cx, cy := RadarCenterX, RadarCenterY
+	cx, cy := s.origin()
 	maxRadius := geo.MaxRadarRadius(RadarWidth, RadarHeight)
 	sweepRad := (sweepAngle - 90) * math.Pi / 180
+	sweepChar := glyph(s.theme.GlyphSet().Sweep)
 
 	for i := 1; i <= maxRadius; i++ {
 		x := int(float64(cx) + float64(i)*math.Cos(sweepRad)*2)
 		y := int(float64(cy) + float64(i)*math.Sin(sweepRad))
 		if x >= 0 && x < RadarWidth && y >= 0 && y < RadarHeight {
-			s.cells[y][x] = cell{char: '░', color: s.theme.RadarSweep}
+			s.cells[y][x] = cell{char: sweepChar, color: s.theme.RadarSweep, background: true}
 		}
 	}
 }
@@ -196,20 +490,35 @@ func (s *Scope) DrawOverlays(overlays []*geo.GeoOverlay, receiverLat, receiverLo
 	if receiverLat == 0 && receiverLon == 0 {
 		return
 	}
+	g := s.theme.GlyphSet()
+	ringChar := glyph(g.RangeRing)
 
 	for _, overlay := range overlays {
-		points := geo.RenderOverlayToRadar(overlay, receiverLat, receiverLon, s.maxRange,
+		points := geo.RenderRotatedOverlay(overlay, receiverLat, receiverLon, s.maxRange, s.rotation,
 			RadarWidth, RadarHeight, overlayColor)
 		for _, p := range points {
 			if p.X >= 0 && p.X < RadarWidth && p.Y >= 0 && p.Y < RadarHeight {
-				if s.cells[p.Y][p.X].char == ' ' || s.cells[p.Y][p.X].char == '·' {
-					s.cells[p.Y][p.X] = cell{char: p.Char, color: lipgloss.Color(p.Color)}
+				if s.cells[p.Y][p.X].char == ' ' || s.cells[p.Y][p.X].char == ringChar {
+					s.cells[p.Y][p.X] = cell{char: overlayChar(g, p.Char), color: lipgloss.Color(p.Color), overlay: true}
 				}
 			}
 		}
 	}
 }
 
+// overlayChar swaps geo's default point and line characters for the glyph
+// set's; characters from overlay labels are kept
+func overlayChar(g *theme.GlyphSet, ch rune) rune {
+	switch ch {
+	case geo.PointChar:
+		return glyph(g.OverlayPoint)
+	case geo.LineChar:
+		return glyph(g.OverlayLine)
+	default:
+		return ch
+	}
+}
+
 // TargetPosition represents a target's position on radar for sorting
 type TargetPosition struct {
 	Hex      string
@@ -221,18 +530,30 @@ type TargetPosition struct {
 func (s *Scope) DrawTargets(targets map[string]*Target, selectedHex string, militaryOnly, hideGround, showLabels, blink bool) []string {
 	var positions []TargetPosition
 
+	// Density shading counts ordinary targets as they are placed
+	var bins [][]int
+	shaded := make(map[string]bool)
+	if len(s.density) > 0 {
+		bins = make([][]int, RadarHeight)
+		for y := range bins {
+			bins[y] = make([]int, RadarWidth)
+		}
+	}
+
 	for hex, t := range targets {
 		if !t.HasLat || !t.HasLon {
 			continue
 		}
-		if militaryOnly && !t.Military {
-			continue
-		}
-		if hideGround && t.HasAlt && t.Altitude <= 0 {
-			continue
+		if !s.pinned[hex] {
+			if militaryOnly && !t.Military {
+				continue
+			}
+			if hideGround && t.OnGround(s.fieldElevation) {
+				continue
+			}
 		}
 
-		x, y := TargetToRadarPos(t.Distance, t.Bearing, s.maxRange)
+		x, y := RotatedRadarPos(t.Distance, t.Bearing, s.rotation, s.maxRange)
 		if x >= 0 && x < RadarWidth && y >= 0 && y < RadarHeight {
 			positions = append(positions, TargetPosition{
 				Hex:      hex,
@@ -240,17 +561,20 @@ func (s *Scope) DrawTargets(targets map[string]*Target, selectedHex string, mili
 				X:        x,
 				Y:        y,
 			})
+			if bins != nil && !s.standsOut(t, selectedHex) {
+				bins[y][x]++
+				shaded[hex] = true
+			}
 		}
 	}
 
 	// Sort by distance
-	for i := 0; i < len(positions)-1; i++ {
-		for j := i + 1; j < len(positions); j++ {
-			if positions[i].Distance > positions[j].Distance {
-				positions[i], positions[j] = positions[j], positions[i]
-			}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Distance != positions[j].Distance {
+			return positions[i].Distance < positions[j].Distance
 		}
-	}
+		return positions[i].Hex < positions[j].Hex
+	})
 
 	// Build sorted hex list
 	sortedHexes := make([]string, len(positions))
@@ -258,37 +582,138 @@ func (s *Scope) DrawTargets(targets map[string]*Target, selectedHex string, mili
 		sortedHexes[i] = p.Hex
 	}
 
+	s.placed, s.shaded = positions, shaded
+
+	// Target symbols, so labels don't run into targets drawn after them
+	occupied := make(map[[2]int]bool, len(positions))
+	for _, p := range positions {
+		occupied[[2]int{p.X, p.Y}] = true
+	}
+
+	// Shade the cells of targets not drawn one by one
+	for y, row := range bins {
+		for x, n := range row {
+			if n > 0 {
+				level := min(bits.Len(uint(n))-1, len(s.density)-1)
+				s.cells[y][x] = cell{char: s.density[level], color: s.theme.Color(theme.RoleTarget), background: true}
+			}
+		}
+	}
+
 	// Draw targets
+	g := s.theme.GlyphSet()
 	for _, pos := range positions {
+		if shaded[pos.Hex] {
+			continue
+		}
 		t := targets[pos.Hex]
 		isSelected := pos.Hex == selectedHex
+		isPinned := s.pinned[pos.Hex]
 
 		var symbol rune
-		var color lipgloss.Color
+		var role theme.Role
 
-		if t.IsEmergency() {
+		severity := t.SquawkSeverity()
+		switch {
+		case severity == SquawkEmergency:
 			if blink {
-				symbol = '!'
+				symbol = glyph(g.EmergencyAlt)
 			} else {
-				symbol = '✖'
+				symbol = glyph(g.Emergency)
+			}
+			role = theme.RoleEmergency
+		case t.Military:
+			symbol = glyph(g.Military)
+			role = theme.RoleMilitary
+		case isSelected:
+			symbol = glyph(g.Selected)
+			role = theme.RoleSelected
+		case s.surface && t.OnGround(s.fieldElevation):
+			symbol = glyph(g.Taxiing)
+			if t.OnSurface() {
+				symbol = glyph(g.Vehicle)
+			}
+			role = theme.RoleTextDim
+		default:
+			symbol = glyph(categoryGlyph(g, t))
+			role = theme.RoleTarget
+		}
+		// Lesser special codes tint the symbol
+		switch severity {
+		case SquawkWarning:
+			role = theme.RoleWarning
+		case SquawkInfo:
+			if !t.Military && !isSelected {
+				role = theme.RoleInfo
+			}
+		}
+
+		// A colorblind-safe theme brightens emergencies and the selected
+		// target, as hue alone may not set them apart
+		bold := s.theme.CVD && (role == theme.RoleEmergency || isSelected)
+		color := s.theme.Color(role)
+		if s.altColors && role == theme.RoleTarget && t.HasAlt {
+			color = s.theme.AltitudeColor(t.Altitude)
+		}
+		s.cells[pos.Y][pos.X] = cell{char: symbol, color: color, bold: bold}
+
+		// Ring pinned targets so they stay easy to find
+		labelX := pos.X + 1
+		if isPinned {
+			if pos.X > 0 {
+				s.cells[pos.Y][pos.X-1] = cell{char: glyph(g.PinOpen), color: s.theme.Selected}
+			}
+			if pos.X+1 < RadarWidth {
+				s.cells[pos.Y][pos.X+1] = cell{char: glyph(g.PinClose), color: s.theme.Selected}
+			}
+			labelX++
+		} else if s.theme.CVD && t.Military && severity != SquawkEmergency {
+			// Outline military targets where color can't be relied on
+			if pos.X > 0 {
+				s.cells[pos.Y][pos.X-1] = cell{char: glyph(g.MilitaryOpen), color: s.theme.Military}
+			}
+			if pos.X+1 < RadarWidth {
+				s.cells[pos.Y][pos.X+1] = cell{char: glyph(g.MilitaryClose), color: s.theme.Military}
+			}
+			labelX++
+		} else if t.OnWatchlist && pos.X > 0 {
+			// Mark watch list targets wherever they appear
+			s.cells[pos.Y][pos.X-1] = cell{char: glyph(g.Watchlist), color: s.theme.Info}
+		}
+
+		// Warning beside targets whose address looks shared
+		if t.Conflicted {
+			if labelX < RadarWidth {
+				s.cells[pos.Y][labelX] = cell{char: glyph(g.Conflict), color: s.theme.Warning}
 			}
-			color = s.theme.Emergency
-		} else if t.Military {
-			symbol = '◆'
-			color = s.theme.Military
-		} else if isSelected {
-			symbol = '◉'
-			color = s.theme.Selected
-		} else {
-			symbol = '✦'
-			color = s.theme.RadarTarget
+			labelX++
 		}
 
-		s.cells[pos.Y][pos.X] = cell{char: symbol, color: color}
+		// Curved arrow for turning targets, with a hold annotation below
+		if mark, ok := s.turns[pos.Hex]; ok {
+			arrow := glyph(g.TurnLeft)
+			if mark.Right {
+				arrow = glyph(g.TurnRight)
+			}
+			if labelX < RadarWidth {
+				s.cells[pos.Y][labelX] = cell{char: arrow, color: s.theme.Secondary}
+			}
+			labelX++
+			if mark.Holding && pos.Y+1 < RadarHeight {
+				for j, ch := range "HOLD?" {
+					if hx := pos.X + j; hx < RadarWidth {
+						s.cells[pos.Y+1][hx] = cell{char: ch, color: s.theme.Warning}
+					}
+				}
+			}
+		}
 
-		// Draw label for selected or close targets
-		if showLabels && (isSelected || t.Distance < s.maxRange*0.2) {
+		// Draw label for selected, pinned or close targets
+		if showLabels && s.labelDetail != LabelNone && (isSelected || isPinned || t.Distance < s.maxRange*0.2) {
 			label := t.Callsign
+			if s.surfaceReg && t.Reg != "" && s.surface && t.OnGround(s.fieldElevation) {
+				label = strings.ReplaceAll(t.Reg, "-", "")
+			}
 			if label == "" {
 				label = t.Hex
 			}
@@ -297,28 +722,23 @@ func (s *Scope) DrawTargets(targets map[string]*Target, selectedHex string, mili
 			}
 
 			labelColor := s.theme.TextDim
-			if isSelected {
+			if isSelected || isPinned {
 				labelColor = s.theme.Selected
 			}
 
-			for j, ch := range label {
-				lx := pos.X + 1 + j
-				if lx < RadarWidth {
-					s.cells[pos.Y][lx] = cell{char: ch, color: labelColor}
-				}
-			}
+			s.drawLabel(pos, labelX, label, labelExtra(t, s.labelDetail), labelColor, occupied)
 		}
 
 		// Draw heading vector for selected target
 		if isSelected && t.HasTrack {
-			hdgRad := (t.Track - 90) * math.Pi / 180
+			hdgRad := (t.Track - s.rotation - 90) * math.Pi / 180
 			for v := 1; v <= 2; v++ {
 				hx := int(float64(pos.X) + float64(v)*math.Cos(hdgRad)*2)
 				hy := int(float64(pos.Y) + float64(v)*math.Sin(hdgRad))
 				if hx >= 0 && hx < RadarWidth && hy >= 0 && hy < RadarHeight {
-					ch := '─'
+					ch := glyph(g.Vector)
 					if v == 2 {
-						ch = '›'
+						ch = glyph(g.VectorHead)
 					}
 					s.cells[hy][hx] = cell{char: ch, color: s.theme.Selected}
 				}
@@ -329,12 +749,54 @@ func (s *Scope) DrawTargets(targets map[string]*Target, selectedHex string, mili
 	return sortedHexes
 }
 
+// DrawGhost marks the last known position of a target that has dropped
+// out, with its callsign beside it. Draw it before DrawTargets so live
+// targets cover it.
+func (s *Scope) DrawGhost(t *Target) {
+	if t == nil || !t.HasLat || !t.HasLon {
+		return
+	}
+	x, y := RotatedRadarPos(t.Distance, t.Bearing, s.rotation, s.maxRange)
+	if x < 0 || x >= RadarWidth || y < 0 || y >= RadarHeight {
+		return
+	}
+	label := t.Callsign
+	if label == "" {
+		label = t.Hex
+	}
+	if len(label) > 5 {
+		label = label[:5]
+	}
+	s.cells[y][x] = cell{char: glyph(s.theme.GlyphSet().Ghost), color: s.theme.TextDim}
+	s.writeText(x+1, y, label, s.theme.TextDim)
+}
+
+// categoryGlyph returns the symbol for a target's emitter category, or the
+// plain aircraft symbol when it has none
+func categoryGlyph(g *theme.GlyphSet, t *Target) string {
+	c, ok := t.EmitterCategory()
+	if !ok {
+		return g.Aircraft
+	}
+	switch c.Class {
+	case ClassRotorcraft:
+		return g.Rotorcraft
+	case ClassGlider, ClassBalloon, ClassParachute, ClassUltralight:
+		return g.Glider
+	case ClassUAV:
+		return g.UAV
+	case ClassSurface, ClassObstacle:
+		return g.Vehicle
+	}
+	return g.Aircraft
+}
+
 // Render renders the radar scope to a string
 func (s *Scope) Render() string {
 	var sb strings.Builder
 
 	// Top border with range
-	rangeStr := fmt.Sprintf(" %dnm ", int(s.maxRange))
+	rangeStr := fmt.Sprintf(" %d%s ", int(units.FromNM(s.maxRange, s.unit)), s.unit)
 	// Guard against an over-wide range label (maxRange is an unbounded float set
 	// via SetRange/animation) that would make the repeat counts negative and panic.
 	if len(rangeStr) > RadarWidth {
@@ -343,43 +805,50 @@ func (s *Scope) Render() string {
 	pad := (RadarWidth - len(rangeStr)) / 2
 
 	borderStyle := lipgloss.NewStyle().Foreground(s.theme.Border)
+	if s.highlight {
+		borderStyle = lipgloss.NewStyle().Foreground(s.theme.PrimaryBright)
+	}
+	g := s.theme.GlyphSet()
 
-	sb.WriteString(borderStyle.Render("╔"))
-	sb.WriteString(borderStyle.Render(strings.Repeat("═", pad)))
+	sb.WriteString(borderStyle.Render(g.DoubleTL))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.DoubleH, pad)))
 	sb.WriteString(borderStyle.Render(rangeStr))
-	sb.WriteString(borderStyle.Render(strings.Repeat("═", RadarWidth-pad-len(rangeStr))))
-	sb.WriteString(borderStyle.Render("╗"))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.DoubleH, RadarWidth-pad-len(rangeStr))))
+	sb.WriteString(borderStyle.Render(g.DoubleTR))
 	sb.WriteString("\n")
 
 	// Radar content
 	for y := 0; y < RadarHeight; y++ {
-		sb.WriteString(borderStyle.Render("║"))
+		sb.WriteString(borderStyle.Render(g.DoubleV))
 		for x := 0; x < RadarWidth; x++ {
 			c := s.cells[y][x]
 			if c.color != "" {
-				style := lipgloss.NewStyle().Foreground(c.color)
+				style := lipgloss.NewStyle().Foreground(c.color).Bold(c.bold)
 				sb.WriteString(style.Render(string(c.char)))
 			} else {
 				style := lipgloss.NewStyle().Foreground(s.theme.TextDim)
 				sb.WriteString(style.Render(string(c.char)))
 			}
 		}
-		sb.WriteString(borderStyle.Render("║"))
+		sb.WriteString(borderStyle.Render(g.DoubleV))
 		sb.WriteString("\n")
 	}
 
 	// Bottom border
-	sb.WriteString(borderStyle.Render("╚"))
-	sb.WriteString(borderStyle.Render(strings.Repeat("═", RadarWidth)))
-	sb.WriteString(borderStyle.Render("╝"))
+	sb.WriteString(borderStyle.Render(g.DoubleBL))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.DoubleH, RadarWidth)))
+	sb.WriteString(borderStyle.Render(g.DoubleBR))
 
 	return sb.String()
 }
 
 // TrailPoint represents a single point in an aircraft's trail for rendering
 type TrailPoint struct {
-	Lat float64
-	Lon float64
+	Lat     float64
+	Lon     float64
+	Break   bool // first point after a gap; marked rather than drawn as trail
+	Segment int  // trail segment, counted up at each break
+	Age     int  // history dots: sample intervals before the current position
 }
 
 // DrawTrails draws aircraft trails on the radar
@@ -389,6 +858,8 @@ func (s *Scope) DrawTrails(trails map[string][]TrailPoint, receiverLat, receiver
 	if receiverLat == 0 && receiverLon == 0 {
 		return
 	}
+	g := s.theme.GlyphSet()
+	ringChar := glyph(g.RangeRing)
 
 	for _, trail := range trails {
 		if len(trail) < 2 {
@@ -404,40 +875,95 @@ func (s *Scope) DrawTrails(trails map[string][]TrailPoint, receiverLat, receiver
 				continue
 			}
 
-			x, y := TargetToRadarPos(distance, bearing, s.maxRange)
+			x, y := RotatedRadarPos(distance, bearing, s.rotation, s.maxRange)
 			if x >= 0 && x < RadarWidth && y >= 0 && y < RadarHeight {
-				// Only draw if the cell is empty or has a range ring
-				if s.cells[y][x].char == ' ' || s.cells[y][x].char == '·' {
+				// Only draw if the cell is empty, has a range ring or holds overlay
+				// geometry; trails always sit above overlays
+				c := s.cells[y][x]
+				if c.char == ' ' || c.char == ringChar || c.overlay {
 					// Use different characters based on trail age
 					// Older points are more faded (use dots), newer points use small dots
 					var char rune
 					switch {
+					case point.Break:
+						// The trail resumes here after a gap in coverage
+						char = glyph(g.TrailGap)
 					case i < len(trail)/3:
 						// Oldest third - faintest
-						char = '·'
+						char = glyph(g.TrailOld)
 					case i < 2*len(trail)/3:
 						// Middle third
-						char = '•'
+						char = glyph(g.TrailMid)
 					default:
 						// Newest third (but not current position)
-						char = '∘'
+						char = glyph(g.TrailNew)
 					}
-					s.cells[y][x] = cell{char: char, color: s.theme.RadarTrail}
+					s.cells[y][x] = cell{char: char, color: s.theme.RadarTrail, background: true}
 				}
 			}
 		}
 	}
 }
 
-// TargetToRadarPos converts distance/bearing to radar coordinates
+// DrawTrailDots draws history dots: each aircraft's position at fixed
+// intervals, fading with age. maxAge is the age of the oldest dot shown,
+// so the same age always fades the same whatever the aircraft.
+func (s *Scope) DrawTrailDots(dots map[string][]TrailPoint, maxAge int, receiverLat, receiverLon float64) {
+	if (receiverLat == 0 && receiverLon == 0) || maxAge <= 0 {
+		return
+	}
+	g := s.theme.GlyphSet()
+	ringChar := glyph(g.RangeRing)
+
+	for _, trail := range dots {
+		for _, point := range trail {
+			distance, bearing := HaversineBearing(receiverLat, receiverLon, point.Lat, point.Lon)
+			if distance > s.maxRange {
+				continue
+			}
+			x, y := RotatedRadarPos(distance, bearing, s.rotation, s.maxRange)
+			if x < 0 || x >= RadarWidth || y < 0 || y >= RadarHeight {
+				continue
+			}
+			c := s.cells[y][x]
+			if c.char != ' ' && c.char != ringChar && !c.overlay {
+				continue
+			}
+
+			// Newest dots are the boldest; the oldest third is dimmed too
+			char, color := glyph(g.TrailMid), s.theme.RadarTrail
+			switch {
+			case point.Age*3 > maxAge*2:
+				char, color = glyph(g.TrailOld), s.theme.PrimaryDim
+			case point.Age*3 > maxAge:
+				char = glyph(g.TrailOld)
+			}
+			s.cells[y][x] = cell{char: char, color: color, background: true}
+		}
+	}
+}
+
+// TargetToRadarPos converts distance/bearing to north-up radar coordinates
 func TargetToRadarPos(distance, bearing, maxRange float64) (int, int) {
+	return RotatedRadarPos(distance, bearing, 0, maxRange)
+}
+
+// RotatedRadarPos converts distance/bearing to radar coordinates on a scope
+// turned so that the rotation bearing points straight up
+func RotatedRadarPos(distance, bearing, rotation, maxRange float64) (int, int) {
 	if distance > maxRange {
 		return -1, -1
 	}
+	return radarOffset(distance, bearing, rotation, maxRange)
+}
+
+// radarOffset is RotatedRadarPos without the range limit, so the cell may
+// be off the scope
+func radarOffset(distance, bearing, rotation, maxRange float64) (int, int) {
 	// Radius is in rows (y cells); x offsets are doubled below to compensate
 	// for the ~2:1 aspect ratio of terminal cells.
 	radius := (distance / maxRange) * float64(geo.MaxRadarRadius(RadarWidth, RadarHeight))
-	angleRad := (bearing - 90) * math.Pi / 180
+	angleRad := (bearing - rotation - 90) * math.Pi / 180
 	x := int(float64(RadarCenterX) + radius*math.Cos(angleRad)*2)
 	y := int(float64(RadarCenterY) + radius*math.Sin(angleRad))
 	return x, y
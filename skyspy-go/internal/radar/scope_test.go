@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/skyspy/skyspy-go/internal/geo"
 	"github.com/skyspy/skyspy-go/internal/theme"
 )
@@ -694,6 +695,22 @@ func TestScope_SetRange(t *testing.T) {
 	}
 }
 
+func TestScope_SetUnits(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 50.0, 3, true)
+
+	scope.SetUnits("mi")
+	scope.Clear()
+	if output := scope.Render(); !strings.Contains(output, " 57mi ") {
+		t.Error("the range should be labelled in statute miles")
+	}
+
+	scope.SetUnits("furlongs")
+	if scope.unit != "nm" {
+		t.Errorf("unknown units should fall back to nm, got %q", scope.unit)
+	}
+}
+
 func TestScope_SetRangeRings(t *testing.T) {
 	th := theme.Get("classic")
 	scope := NewScope(th, 100.0, 4, true)
@@ -988,6 +1005,104 @@ func TestScope_DrawTargets_Labels(t *testing.T) {
 	}
 }
 
+func TestScope_DrawTargets_PinnedRing(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+
+	targets := map[string]*Target{
+		"pin001": {Hex: "pin001", Callsign: "PINNED", Distance: 50, Bearing: 90, HasLat: true, HasLon: true},
+	}
+	x, y := TargetToRadarPos(50, 90, 100)
+
+	scope.Clear()
+	scope.SetPinned([]string{"pin001"})
+	scope.DrawTargets(targets, "", false, false, true, false)
+
+	if scope.cells[y][x-1].char != '(' || scope.cells[y][x+1].char != ')' {
+		t.Errorf("expected ring around pinned target, got %q%q%q",
+			scope.cells[y][x-1].char, scope.cells[y][x].char, scope.cells[y][x+1].char)
+	}
+	// Label is pushed past the ring and always shown for pinned targets
+	if scope.cells[y][x+2].char != 'P' || scope.cells[y][x+2].color != th.Selected {
+		t.Errorf("expected pinned label after the ring, got %q", scope.cells[y][x+2].char)
+	}
+}
+
+func TestScope_DrawTargets_WatchlistMarker(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+
+	targets := map[string]*Target{
+		"wat001": {Hex: "wat001", Distance: 50, Bearing: 90, HasLat: true, HasLon: true, OnWatchlist: true},
+		"oth001": {Hex: "oth001", Distance: 50, Bearing: 270, HasLat: true, HasLon: true},
+	}
+	wx, wy := TargetToRadarPos(50, 90, 100)
+	ox, oy := TargetToRadarPos(50, 270, 100)
+
+	scope.Clear()
+	scope.DrawTargets(targets, "", false, false, true, false)
+
+	marker := glyph(th.GlyphSet().Watchlist)
+	if got := scope.cells[wy][wx-1]; got.char != marker || got.color != th.Info {
+		t.Errorf("expected the watch list marker before the target, got %q", got.char)
+	}
+	if got := scope.cells[oy][ox-1]; got.char == marker {
+		t.Error("an unwatched target shouldn't be marked")
+	}
+}
+
+func TestScope_DrawTargets_AltitudeColors(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+
+	targets := map[string]*Target{
+		"low001": {Hex: "low001", Distance: 50, Bearing: 90, Altitude: 1500, HasAlt: true, HasLat: true, HasLon: true},
+		"hi0001": {Hex: "hi0001", Distance: 50, Bearing: 180, Altitude: 38000, HasAlt: true, HasLat: true, HasLon: true},
+		"mil001": {Hex: "mil001", Distance: 50, Bearing: 270, Altitude: 1500, HasAlt: true, HasLat: true, HasLon: true, Military: true},
+		"sel001": {Hex: "sel001", Distance: 50, Bearing: 0, Altitude: 1500, HasAlt: true, HasLat: true, HasLon: true},
+	}
+	colorAt := func(bearing float64) string {
+		x, y := TargetToRadarPos(50, bearing, 100)
+		return string(scope.cells[y][x].color)
+	}
+
+	scope.Clear()
+	scope.DrawTargets(targets, "sel001", false, false, false, false)
+	if colorAt(90) != string(th.RadarTarget) || colorAt(180) != string(th.RadarTarget) {
+		t.Error("targets should be drawn in the target color by default")
+	}
+
+	scope.SetAltitudeColors(true)
+	scope.Clear()
+	scope.DrawTargets(targets, "sel001", false, false, false, false)
+	if colorAt(90) != string(th.AltitudeColor(1500)) || colorAt(180) != string(th.AltitudeColor(38000)) {
+		t.Errorf("targets should be colored by altitude, got %s and %s", colorAt(90), colorAt(180))
+	}
+	// Military and selected targets keep their highlighting
+	if colorAt(270) != string(th.Military) || colorAt(0) != string(th.Selected) {
+		t.Errorf("highlights should win over altitude, got %s and %s", colorAt(270), colorAt(0))
+	}
+}
+
+func TestScope_DrawTargets_PinnedIgnoresFilters(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+
+	targets := map[string]*Target{
+		"civ001": {Hex: "civ001", Distance: 30, Bearing: 45, HasLat: true, HasLon: true},
+		"gnd001": {Hex: "gnd001", Distance: 10, Bearing: 180, HasLat: true, HasLon: true, HasAlt: true, Altitude: 0},
+		"civ002": {Hex: "civ002", Distance: 40, Bearing: 270, HasLat: true, HasLon: true},
+	}
+
+	scope.Clear()
+	scope.SetPinned([]string{"civ001", "gnd001"})
+	sorted := scope.DrawTargets(targets, "", true, true, false, false)
+
+	if len(sorted) != 2 || sorted[0] != "gnd001" || sorted[1] != "civ001" {
+		t.Errorf("pinned targets should survive filters, got %v", sorted)
+	}
+}
+
 func TestScope_DrawTargets_LabelUseHex(t *testing.T) {
 	th := theme.Get("classic")
 	scope := NewScope(th, 100.0, 4, false)
@@ -1143,6 +1258,77 @@ func TestScope_DrawOverlays(t *testing.T) {
 	}
 }
 
+func TestScope_DrawTrails_AboveOverlays(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+	scope.Clear()
+
+	overlay := &geo.GeoOverlay{
+		Name:    "Airspace",
+		Enabled: true,
+		Features: []geo.GeoFeature{
+			{Type: geo.OverlayPoint, Points: []geo.GeoPoint{{Lat: 52.5, Lon: 4.0, Label: "X"}}},
+		},
+	}
+	scope.DrawOverlays([]*geo.GeoOverlay{overlay}, 52.0, 4.0, "cyan")
+	marked := false
+	for _, row := range scope.cells {
+		for _, c := range row {
+			if c.char == 'X' && c.overlay {
+				marked = true
+			}
+		}
+	}
+	if !marked {
+		t.Fatal("expected overlay glyph to be marked as overlay")
+	}
+
+	// Put the overlay glyph exactly where the oldest trail point lands
+	distance, bearing := HaversineBearing(52.0, 4.0, 52.5, 4.0)
+	x, y := TargetToRadarPos(distance, bearing, 100.0)
+	scope.cells[y][x] = cell{char: 'X', color: "cyan", overlay: true}
+
+	trails := map[string][]TrailPoint{
+		"abc123": {{Lat: 52.5, Lon: 4.0}, {Lat: 52.6, Lon: 4.0}},
+	}
+	scope.DrawTrails(trails, 52.0, 4.0)
+
+	if c := scope.cells[y][x]; c.color != th.RadarTrail || c.overlay {
+		t.Errorf("expected trail to draw over overlay glyph, got %q", c.char)
+	}
+}
+
+func TestScope_DrawTrails_GapMarker(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+	scope.Clear()
+
+	// The aircraft left coverage after the second point and came back at
+	// the third
+	trails := map[string][]TrailPoint{
+		"abc123": {
+			{Lat: 52.5, Lon: 4.0},
+			{Lat: 52.6, Lon: 4.0},
+			{Lat: 53.0, Lon: 4.0, Break: true},
+			{Lat: 53.1, Lon: 4.0},
+		},
+	}
+	scope.DrawTrails(trails, 52.0, 4.0)
+
+	distance, bearing := HaversineBearing(52.0, 4.0, 53.0, 4.0)
+	x, y := TargetToRadarPos(distance, bearing, 100.0)
+	gap := []rune(th.GlyphSet().TrailGap)[0]
+	if c := scope.cells[y][x]; c.char != gap || c.color != th.RadarTrail {
+		t.Errorf("expected gap marker %q where the trail resumes, got %q", gap, c.char)
+	}
+
+	distance, bearing = HaversineBearing(52.0, 4.0, 52.6, 4.0)
+	x, y = TargetToRadarPos(distance, bearing, 100.0)
+	if c := scope.cells[y][x]; c.char == gap {
+		t.Error("expected ordinary trail glyph before the gap")
+	}
+}
+
 func TestScope_DrawOverlays_NoReceiver(t *testing.T) {
 	th := theme.Get("classic")
 	scope := NewScope(th, 100.0, 4, false)
@@ -1716,3 +1902,308 @@ func TestScope_DrawTrails_DoesNotOverwriteTargets(t *testing.T) {
 		t.Error("trail should not overwrite target symbol")
 	}
 }
+
+func TestRotatedRadarPos_TrackPointsUp(t *testing.T) {
+	for _, track := range []float64{0, 37, 90, 181.5, 270, 359} {
+		x, y := RotatedRadarPos(50, track, track, 100)
+		if x != RadarCenterX {
+			t.Errorf("track %.1f: expected x %d (center), got %d", track, RadarCenterX, x)
+		}
+		if y >= RadarCenterY {
+			t.Errorf("track %.1f: expected target above center (y < %d), got %d", track, RadarCenterY, y)
+		}
+	}
+
+	// Due south is off the right wing when facing east
+	if x, y := RotatedRadarPos(50, 180, 90, 100); x <= RadarCenterX || y != RadarCenterY {
+		t.Errorf("expected bearing 180 due right on a 090 scope, got (%d,%d)", x, y)
+	}
+
+	// Zero rotation matches the north-up projection
+	nx, ny := TargetToRadarPos(40, 123, 100)
+	if rx, ry := RotatedRadarPos(40, 123, 0, 100); rx != nx || ry != ny {
+		t.Errorf("zero rotation should match north-up: (%d,%d) vs (%d,%d)", rx, ry, nx, ny)
+	}
+}
+
+func TestScope_DrawTargets_Rotated(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100, 4, false)
+	scope.SetRotation(250)
+	scope.Clear()
+
+	targets := map[string]*Target{
+		"abc123": {Hex: "abc123", HasLat: true, HasLon: true, Distance: 50, Bearing: 250, Track: 250, HasTrack: true},
+	}
+	scope.DrawTargets(targets, "", false, false, false, false)
+
+	found := false
+	for y := 0; y < RadarCenterY; y++ {
+		if scope.cells[y][RadarCenterX].char == '✦' {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected target on the selected track to render straight above center")
+	}
+}
+
+func TestScope_DrawCompass_Rotated(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100, 4, true)
+	scope.SetRotation(90)
+	scope.Clear()
+	scope.DrawCompass()
+
+	maxRadius := geo.MaxRadarRadius(RadarWidth, RadarHeight)
+	if c := scope.cells[RadarCenterY-maxRadius][RadarCenterX]; c.char != 'E' {
+		t.Errorf("expected E at the top of a 090 scope, got %q", c.char)
+	}
+	if c := scope.cells[RadarCenterY][RadarCenterX-maxRadius*2]; c.char != 'N' {
+		t.Errorf("expected N on the left of a 090 scope, got %q", c.char)
+	}
+
+	scope.SetRotation(45)
+	scope.Clear()
+	scope.DrawCompass()
+	diagonal := false
+	for _, row := range scope.cells {
+		for _, c := range row {
+			if c.char == '╱' || c.char == '╲' {
+				diagonal = true
+			}
+		}
+	}
+	if !diagonal {
+		t.Error("expected diagonal axes on a 045 scope")
+	}
+}
+
+func TestSignalStats_Add(t *testing.T) {
+	var s SignalStats
+	s.Add(-20)
+	if s.Min != -20 || s.Max != -20 || s.Avg != -20 || s.Samples != 1 {
+		t.Fatalf("first sample should seed all stats, got %+v", s)
+	}
+
+	s.Add(-30)
+	s.Add(-10)
+	if s.Min != -30 || s.Max != -10 || s.Samples != 3 {
+		t.Errorf("unexpected min/max %+v", s)
+	}
+	// EWMA: -20 -> -22 -> -19.6
+	if math.Abs(s.Avg-(-19.6)) > 1e-9 {
+		t.Errorf("expected EWMA -19.6, got %.4f", s.Avg)
+	}
+}
+
+func TestTarget_SmoothVS(t *testing.T) {
+	first := &Target{Vertical: -1000, HasVS: true}
+	first.SmoothVS(nil)
+	if !first.HasVSTrend || first.VSTrend != -1000 {
+		t.Fatalf("the first rate should seed the trend, got %+v", first)
+	}
+
+	spike := &Target{Vertical: -9000, HasVS: true}
+	spike.SmoothVS(first)
+	// -1000 + (-9000 - -1000) * 0.3
+	if math.Abs(spike.VSTrend-(-3400)) > 1e-9 {
+		t.Errorf("expected trend -3400, got %.1f", spike.VSTrend)
+	}
+
+	quiet := &Target{}
+	quiet.SmoothVS(spike)
+	if !quiet.HasVSTrend || quiet.VSTrend != spike.VSTrend {
+		t.Errorf("an update without a rate should keep the trend, got %+v", quiet)
+	}
+	none := &Target{}
+	none.SmoothVS(nil)
+	if none.HasVSTrend {
+		t.Error("no rate, no trend")
+	}
+}
+
+func TestScope_Render_ASCIIGlyphs(t *testing.T) {
+	th := theme.Get("classic").WithGlyphs(theme.GlyphsASCII)
+	scope := NewScope(th, 100.0, 4, true)
+	scope.Clear()
+	scope.DrawRangeRings()
+	scope.DrawCompass()
+	scope.DrawTrails(map[string][]TrailPoint{
+		"abc123": {{Lat: 52.00, Lon: 4.00}, {Lat: 52.10, Lon: 4.10}, {Lat: 52.20, Lon: 4.20}, {Lat: 52.30, Lon: 4.30}},
+	}, 51.9, 3.9)
+	scope.DrawSweep(45)
+	scope.SetPinned([]string{"pin001"})
+	scope.SetTurns(map[string]TurnMark{"mil001": {Right: true}})
+	scope.DrawTargets(map[string]*Target{
+		"sel001": {Hex: "sel001", Callsign: "SEL1", Distance: 30, Bearing: 45, Track: 90, HasTrack: true, HasLat: true, HasLon: true},
+		"mil001": {Hex: "mil001", Callsign: "MIL1", Distance: 60, Bearing: 200, Military: true, HasLat: true, HasLon: true},
+		"emg001": {Hex: "emg001", Callsign: "EMG1", Distance: 40, Bearing: 300, Squawk: "7700", HasLat: true, HasLon: true},
+		"pin001": {Hex: "pin001", Callsign: "PIN1", Distance: 80, Bearing: 120, HasLat: true, HasLon: true},
+	}, "sel001", false, false, true, false)
+
+	output := ansi.Strip(scope.Render())
+	for i, r := range output {
+		if r >= 0x80 {
+			t.Fatalf("non-ASCII %q at byte %d in ascii render", r, i)
+		}
+	}
+	if !strings.Contains(output, "@") || !strings.Contains(output, "#") {
+		t.Error("expected ascii selected and military symbols")
+	}
+}
+
+func TestScope_DrawGhost(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+	ghost := &Target{Hex: "4841a1", Callsign: "KLM123", Distance: 40, Bearing: 90, HasLat: true, HasLon: true}
+
+	scope.Clear()
+	scope.DrawGhost(ghost)
+	x, y := TargetToRadarPos(40, 90, 100)
+	if got := string(scope.cells[y][x].char); got != th.GlyphSet().Ghost {
+		t.Errorf("expected the ghost glyph, got %q", got)
+	}
+	label := ""
+	for i := 1; i <= 5; i++ {
+		label += string(scope.cells[y][x+i].char)
+	}
+	if label != "KLM12" {
+		t.Errorf("expected the callsign beside the ghost, got %q", label)
+	}
+
+	// Live targets draw over it
+	scope.DrawTargets(map[string]*Target{"4841a1": {Hex: "4841a1", Distance: 40, Bearing: 90, HasLat: true, HasLon: true}},
+		"", false, false, false, false)
+	if string(scope.cells[y][x].char) == th.GlyphSet().Ghost {
+		t.Error("a live target should cover the ghost")
+	}
+
+	// Nothing to draw without a position or out of range
+	scope.Clear()
+	scope.DrawGhost(nil)
+	scope.DrawGhost(&Target{Hex: "far", Distance: 150, HasLat: true, HasLon: true})
+	scope.DrawGhost(&Target{Hex: "nopos", Distance: 10})
+	for _, row := range scope.cells {
+		for _, c := range row {
+			if c.char != ' ' {
+				t.Fatalf("expected an empty scope, found %q", c.char)
+			}
+		}
+	}
+}
+
+func TestScope_DrawTrailDots(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+	scope.Clear()
+
+	// Dots far enough apart to land in separate cells, newest last
+	dots := map[string][]TrailPoint{
+		"abc123": {
+			{Lat: 52.9, Lon: 4.0, Age: 6},
+			{Lat: 52.6, Lon: 4.0, Age: 3},
+			{Lat: 52.3, Lon: 4.0, Age: 1},
+		},
+	}
+	scope.DrawTrailDots(dots, 6, 52.0, 4.0)
+
+	var bright, dim int
+	for _, row := range scope.cells {
+		for _, c := range row {
+			switch {
+			case c.char == ' ':
+			case c.color == th.RadarTrail:
+				bright++
+			case c.color == th.PrimaryDim:
+				dim++
+			}
+		}
+	}
+	if bright != 2 || dim != 1 {
+		t.Errorf("got %d bright and %d dimmed dots, want 2 and 1", bright, dim)
+	}
+
+	scope.Clear()
+	scope.DrawTrailDots(dots, 6, 0, 0)
+	scope.DrawTrailDots(dots, 0, 52.0, 4.0)
+	for _, row := range scope.cells {
+		for _, c := range row {
+			if c.char != ' ' {
+				t.Fatal("expected no dots without a receiver position or dot count")
+			}
+		}
+	}
+}
+
+func TestTarget_AltSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   Target
+		baro     int
+		hasBaro  bool
+		split    int
+		hasSplit bool
+	}{
+		{"altitude only", Target{Altitude: 12000, HasAlt: true}, 12000, true, 0, false},
+		{"baro only", Target{Altitude: 12000, HasAlt: true, BaroAltitude: 12000, HasBaroAlt: true}, 12000, true, 0, false},
+		{"GNSS only", Target{Altitude: 12100, HasAlt: true, GeomAltitude: 12100, HasGeomAlt: true}, 0, false, 0, false},
+		{"both", Target{Altitude: 35000, HasAlt: true, BaroAltitude: 35000, HasBaroAlt: true, GeomAltitude: 35450, HasGeomAlt: true}, 35000, true, 450, true},
+		{"flying by GNSS", Target{Altitude: 4800, HasAlt: true, BaroAltitude: 5000, HasBaroAlt: true, GeomAltitude: 4800, HasGeomAlt: true}, 5000, true, -200, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baro, ok := tt.target.BaroAlt()
+			if baro != tt.baro || ok != tt.hasBaro {
+				t.Errorf("BaroAlt() = %d, %v, want %d, %v", baro, ok, tt.baro, tt.hasBaro)
+			}
+			split, ok := tt.target.AltSplit()
+			if split != tt.split || ok != tt.hasSplit {
+				t.Errorf("AltSplit() = %d, %v, want %d, %v", split, ok, tt.split, tt.hasSplit)
+			}
+		})
+	}
+}
+
+func TestScope_SetReceiver(t *testing.T) {
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, true)
+	scope.Clear()
+	// The receiver 25nm due west of the view center
+	scope.SetReceiver(25, 270)
+	scope.DrawRangeRings()
+	scope.DrawCompass()
+
+	rx, ry := TargetToRadarPos(25, 270, 100)
+	if c := scope.cells[ry][rx]; c.char != '╋' {
+		t.Errorf("expected the crosshair at the receiver (%d,%d), got %q", rx, ry, c.char)
+	}
+	if c := scope.cells[RadarCenterY][RadarCenterX]; c.char == '╋' {
+		t.Error("the crosshair should leave the view center")
+	}
+
+	// Rings stay around the receiver: the first ring crosses the receiver's
+	// row a quarter of the scope radius to its east
+	rings := NewScope(th, 100.0, 4, false)
+	rings.Clear()
+	rings.SetReceiver(25, 270)
+	rings.DrawRangeRings()
+	ring := float64(geo.MaxRadarRadius(RadarWidth, RadarHeight)) / 4
+	if x := int(float64(rx) + ring*2); rings.cells[ry][x].char != '·' {
+		t.Errorf("expected the first ring at x=%d, got %q", x, rings.cells[ry][x].char)
+	}
+
+	// Off the scope altogether there is no crosshair to draw
+	far := NewScope(th, 10.0, 4, true)
+	far.Clear()
+	far.SetReceiver(80, 90)
+	far.DrawRangeRings()
+	far.DrawCompass()
+	for _, row := range far.cells {
+		for _, c := range row {
+			if c.char == '╋' {
+				t.Fatal("a receiver off the scope shouldn't draw a crosshair")
+			}
+		}
+	}
+}
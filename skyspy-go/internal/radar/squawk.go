@@ -0,0 +1,114 @@
+// Package radar provides squawk code classification for the radar
+package radar
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SquawkSeverity ranks how urgent a special squawk code is
+type SquawkSeverity int
+
+const (
+	SquawkNormal SquawkSeverity = iota
+	SquawkInfo
+	SquawkWarning
+	SquawkEmergency
+)
+
+// String returns the severity's config name
+func (s SquawkSeverity) String() string {
+	switch s {
+	case SquawkInfo:
+		return "info"
+	case SquawkWarning:
+		return "warning"
+	case SquawkEmergency:
+		return "emergency"
+	default:
+		return "normal"
+	}
+}
+
+// ParseSquawkSeverity reads a severity name; "none" is accepted for normal
+func ParseSquawkSeverity(s string) (SquawkSeverity, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "normal", "none":
+		return SquawkNormal, true
+	case "info":
+		return SquawkInfo, true
+	case "warning":
+		return SquawkWarning, true
+	case "emergency":
+		return SquawkEmergency, true
+	}
+	return SquawkNormal, false
+}
+
+// DefaultSquawkCodes returns the built-in special codes: hijack, radio
+// failure and general emergency
+func DefaultSquawkCodes() SquawkTable {
+	return SquawkTable{
+		"7500": SquawkEmergency,
+		"7600": SquawkEmergency,
+		"7700": SquawkEmergency,
+	}
+}
+
+// ParseSquawkCodes applies configured code -> severity entries on top of
+// the defaults. A severity of "normal" or "none" removes a default code.
+// Invalid entries are skipped and reported in the error.
+func ParseSquawkCodes(entries map[string]string) (SquawkTable, error) {
+	codes := DefaultSquawkCodes()
+	var errs []error
+	for code, name := range entries {
+		code = strings.TrimSpace(code)
+		if !isSquawkCode(code) {
+			errs = append(errs, fmt.Errorf("squawk %q: want four octal digits", code))
+			continue
+		}
+		severity, ok := ParseSquawkSeverity(name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("squawk %s: unknown severity %q", code, name))
+			continue
+		}
+		if severity == SquawkNormal {
+			delete(codes, code)
+		} else {
+			codes[code] = severity
+		}
+	}
+	return codes, errors.Join(errs...)
+}
+
+// isSquawkCode reports whether code is a valid four-digit octal squawk
+func isSquawkCode(code string) bool {
+	if len(code) != 4 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '7' {
+			return false
+		}
+	}
+	return true
+}
+
+// SquawkTable maps special squawk codes to their severity. A nil table
+// holds the built-in codes.
+type SquawkTable map[string]SquawkSeverity
+
+// Classify returns the severity of a squawk code
+func (t SquawkTable) Classify(code string) SquawkSeverity {
+	code = strings.TrimSpace(code)
+	if t == nil {
+		return DefaultSquawkCodes()[code]
+	}
+	return t[code]
+}
+
+// SquawkSeverity returns the severity of the target's squawk code
+func (t *Target) SquawkSeverity() SquawkSeverity {
+	return t.SquawkCodes.Classify(t.Squawk)
+}
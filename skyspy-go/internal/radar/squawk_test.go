@@ -0,0 +1,98 @@
+package radar
+
+import (
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+func TestSquawkTable_Defaults(t *testing.T) {
+	for _, code := range []string{"7500", "7600", "7700"} {
+		if got := SquawkTable(nil).Classify(code); got != SquawkEmergency {
+			t.Errorf("%s: expected emergency, got %s", code, got)
+		}
+	}
+	if got := SquawkTable(nil).Classify("1200"); got != SquawkNormal {
+		t.Errorf("1200: expected normal, got %s", got)
+	}
+}
+
+func TestParseSquawkCodes(t *testing.T) {
+	codes, err := ParseSquawkCodes(map[string]string{
+		"7400": "emergency",
+		"7600": "warning",
+		"7000": "info",
+		"7500": "none",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]SquawkSeverity{
+		"7400": SquawkEmergency,
+		"7600": SquawkWarning,
+		"7000": SquawkInfo,
+		"7700": SquawkEmergency, // default kept
+	}
+	if len(codes) != len(want) {
+		t.Fatalf("expected %d codes, got %v", len(want), codes)
+	}
+	for code, severity := range want {
+		if codes[code] != severity {
+			t.Errorf("%s: expected %s, got %s", code, severity, codes[code])
+		}
+	}
+
+	// Bad entries are reported and skipped, the rest still apply
+	codes, err = ParseSquawkCodes(map[string]string{"7400": "warning", "7800": "emergency", "1234": "urgent"})
+	if err == nil {
+		t.Error("expected an error for invalid entries")
+	}
+	if codes["7400"] != SquawkWarning || len(codes) != 4 {
+		t.Errorf("expected valid entries applied on the defaults, got %v", codes)
+	}
+
+	// No entries means the defaults
+	codes, _ = ParseSquawkCodes(nil)
+	if len(codes) != 3 {
+		t.Errorf("expected the three default codes, got %v", codes)
+	}
+}
+
+func TestSquawkTable_Classify(t *testing.T) {
+	codes, _ := ParseSquawkCodes(map[string]string{"7400": "emergency", "7600": "warning"})
+
+	lostLink := &Target{Squawk: "7400", SquawkCodes: codes}
+	if !lostLink.IsEmergency() {
+		t.Error("7400 should be an emergency once configured")
+	}
+	radioFailure := &Target{Squawk: "7600", SquawkCodes: codes}
+	if radioFailure.IsEmergency() || radioFailure.SquawkSeverity() != SquawkWarning {
+		t.Errorf("7600 should be demoted to a warning, got %s", radioFailure.SquawkSeverity())
+	}
+
+	// Each target follows its own table; nil is the defaults
+	radioFailure.SquawkCodes = nil
+	if radioFailure.SquawkSeverity() != SquawkEmergency {
+		t.Error("a target without a table should use the defaults")
+	}
+	if SquawkTable(nil).Classify(" 7700 ") != SquawkEmergency {
+		t.Error("codes should be trimmed")
+	}
+}
+
+func TestDrawTargets_SquawkSeverityColor(t *testing.T) {
+	codes, _ := ParseSquawkCodes(map[string]string{"7600": "warning"})
+
+	th := theme.Get("classic")
+	scope := NewScope(th, 100.0, 4, false)
+	scope.Clear()
+	targets := map[string]*Target{
+		"ABC123": {Hex: "ABC123", Squawk: "7600", SquawkCodes: codes, Distance: 20, Bearing: 90, HasLat: true, HasLon: true},
+	}
+	scope.DrawTargets(targets, "", false, false, false, false)
+
+	x, y := TargetToRadarPos(20, 90, 100.0)
+	if c := scope.cells[y][x]; c.color != th.Warning {
+		t.Errorf("expected a warning squawk drawn in the warning color, got %q", c.color)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/codec"
 	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/radar"
 	"github.com/skyspy/skyspy-go/internal/theme"
 	"github.com/skyspy/skyspy-go/internal/ui"
 	"github.com/skyspy/skyspy-go/internal/ws"
@@ -40,11 +42,14 @@ type Aircraft struct {
 	HasTrack bool
 	HasVS    bool
 	HasRSSI  bool
+
+	// Special squawk codes from the settings; nil for the built-in ones
+	SquawkCodes radar.SquawkTable
 }
 
-// IsEmergency returns true if aircraft has emergency squawk
+// IsEmergency returns true if aircraft's squawk is classed as an emergency
 func (a *Aircraft) IsEmergency() bool {
-	return a.Squawk == "7500" || a.Squawk == "7600" || a.Squawk == "7700"
+	return a.SquawkCodes.Classify(a.Squawk) == radar.SquawkEmergency
 }
 
 // ACARSMessage represents an ACARS message
@@ -99,11 +104,14 @@ type Model struct {
 	// Scanning mode
 	ScanMode        bool
 	FilterFrequency string
+
+	// Special squawk codes the aircraft are classified by
+	squawkCodes radar.SquawkTable
 }
 
 // NewModel creates a new radio display model
 func NewModel(cfg *config.Config, mode DisplayMode) *Model {
-	t := theme.Get(cfg.Display.Theme)
+	t := theme.Get(cfg.Display.Theme).WithGlyphs(cfg.Display.GlyphSet).WithCVD(cfg.Display.ColorblindSafe)
 
 	specWidth := 32
 	specHeight := 6
@@ -112,22 +120,34 @@ func NewModel(cfg *config.Config, mode DisplayMode) *Model {
 		specHeight = 8
 	}
 
+	// Invalid entries are skipped; the radar view reports them
+	codes, _ := radar.ParseSquawkCodes(cfg.Alerts.Squawks)
+
 	return &Model{
 		Mode:          mode,
 		Aircraft:      make(map[string]*Aircraft),
 		ACARSMessages: make([]ACARSMessage, 0, 100),
 		SortedHexes:   []string{},
 		StartTime:     time.Now(),
-		Spinners:      []string{"◐", "◓", "◑", "◒"},
+		Spinners:      t.GlyphSet().Spinner,
 		Spectrum:      ui.NewSpectrum(t, specWidth, specHeight),
 		Waterfall:     ui.NewWaterfall(t, specWidth, 10),
 		FreqDisp:      ui.NewFrequencyDisplay(t),
 		Config:        cfg,
 		Theme:         t,
-		WSClient:      ws.NewClient(cfg.Connection.Host, cfg.Connection.Port, cfg.Connection.ReconnectDelay),
+		WSClient:      newFeedClient(cfg),
+		squawkCodes:   codes,
 	}
 }
 
+// newFeedClient creates the aircraft feed client with the configured keepalive
+func newFeedClient(cfg *config.Config) *ws.Client {
+	client := ws.NewClient(cfg.Connection.Host, cfg.Connection.Port, cfg.Connection.ReconnectDelay)
+	client.SetKeepalive(cfg.Connection.Keepalive())
+	client.SetAutoReconnect(cfg.Connection.AutoReconnect)
+	return client
+}
+
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
 	m.WSClient.Start()
@@ -143,10 +163,10 @@ func (m *Model) Init() tea.Cmd {
 type tickMsg time.Time
 
 // aircraftMsg contains aircraft data
-type aircraftMsg ws.Message
+type aircraftMsg codec.Message
 
 // acarsMsg contains ACARS data
-type acarsMsg ws.Message
+type acarsMsg codec.Message
 
 func tickCmd() tea.Cmd {
 	return tea.Tick(150*time.Millisecond, func(t time.Time) tea.Msg {
@@ -183,11 +203,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleTick()
 
 	case aircraftMsg:
-		m.handleAircraftMsg(ws.Message(msg))
+		m.handleAircraftMsg(codec.Message(msg))
 		return m, aircraftMsgCmd(m.WSClient)
 
 	case acarsMsg:
-		m.handleACARSMsg(ws.Message(msg))
+		m.handleACARSMsg(codec.Message(msg))
 		return m, acarsMsgCmd(m.WSClient)
 	}
 
@@ -213,7 +233,8 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		nextIdx := (currentIdx + 1) % len(themes)
 		m.Config.Display.Theme = themes[nextIdx]
-		m.Theme = theme.Get(themes[nextIdx])
+		m.Theme = theme.Get(themes[nextIdx]).WithGlyphs(m.Config.Display.GlyphSet).WithCVD(m.Config.Display.ColorblindSafe)
+		m.Spinners = m.Theme.GlyphSet().Spinner
 		m.Spectrum.Theme = m.Theme
 		m.Waterfall.Theme = m.Theme
 		m.FreqDisp.Theme = m.Theme
@@ -259,10 +280,10 @@ func (m *Model) handleTick() (tea.Model, tea.Cmd) {
 	return m, tickCmd()
 }
 
-func (m *Model) handleAircraftMsg(msg ws.Message) {
+func (m *Model) handleAircraftMsg(msg codec.Message) {
 	switch msg.Type {
-	case string(ws.AircraftSnapshot):
-		aircraft, err := ws.ParseAircraftSnapshot(msg.Data)
+	case string(codec.AircraftSnapshot):
+		aircraft, err := codec.ParseSnapshot(msg.Data)
 		if err == nil {
 			// Snapshot is authoritative: aircraft:remove events missed
 			// during a disconnect must not leave ghost aircraft behind.
@@ -277,14 +298,14 @@ func (m *Model) handleAircraftMsg(msg ws.Message) {
 				}
 			}
 		}
-	case string(ws.AircraftUpdate), string(ws.AircraftNew):
-		ac, err := ws.ParseAircraft(msg.Data)
+	case string(codec.AircraftUpdate), string(codec.AircraftNew):
+		ac, err := codec.ParseAircraft(msg.Data)
 		if err == nil {
 			m.updateAircraft(ac)
 			m.TotalMessages++
 		}
-	case string(ws.AircraftRemove):
-		ac, err := ws.ParseAircraft(msg.Data)
+	case string(codec.AircraftRemove):
+		ac, err := codec.ParseAircraft(msg.Data)
 		if err == nil && ac.Hex != "" {
 			delete(m.Aircraft, ac.Hex)
 		}
@@ -293,17 +314,18 @@ func (m *Model) handleAircraftMsg(msg ws.Message) {
 	m.sortAircraft()
 }
 
-func (m *Model) updateAircraft(ac *ws.Aircraft) {
+func (m *Model) updateAircraft(ac *codec.Aircraft) {
 	if ac.Hex == "" {
 		return
 	}
 
 	aircraft := &Aircraft{
-		Hex:      ac.Hex,
-		Callsign: strings.TrimSpace(ac.Flight),
-		ACType:   ac.Type,
-		Squawk:   ac.Squawk,
-		Military: ac.Military,
+		Hex:         ac.Hex,
+		Callsign:    strings.TrimSpace(ac.Flight),
+		ACType:      ac.Type,
+		Squawk:      ac.Squawk,
+		Military:    ac.Military,
+		SquawkCodes: m.squawkCodes,
 	}
 
 	if ac.AltBaro != nil {
@@ -366,10 +388,10 @@ func (m *Model) sortAircraft() {
 	}
 }
 
-func (m *Model) handleACARSMsg(msg ws.Message) {
+func (m *Model) handleACARSMsg(msg codec.Message) {
 	switch msg.Type {
-	case string(ws.ACARSMessage), string(ws.ACARSSnapshot):
-		acarsData, err := ws.ParseACARSData(msg.Data)
+	case string(codec.ACARSMessage), string(codec.ACARSSnapshot):
+		acarsData, err := codec.ParseACARS(msg.Data)
 		if err == nil {
 			for _, data := range acarsData {
 				acars := ACARSMessage{
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/skyspy/skyspy-go/internal/codec"
 	"github.com/skyspy/skyspy-go/internal/config"
 	"github.com/skyspy/skyspy-go/internal/ws"
 )
@@ -34,6 +35,22 @@ func TestAircraftIsEmergency(t *testing.T) {
 	}
 }
 
+func TestModel_SquawkCodesPerModel(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Alerts.Squawks = map[string]string{"7600": "warning"}
+	custom := NewModel(cfg, ModeBasic)
+	plain := NewModel(config.DefaultConfig(), ModeBasic)
+
+	custom.updateAircraft(&codec.Aircraft{Hex: "ABC123", Squawk: "7600"})
+	plain.updateAircraft(&codec.Aircraft{Hex: "ABC123", Squawk: "7600"})
+	if custom.Aircraft["ABC123"].IsEmergency() {
+		t.Error("7600 is a warning with the custom codes")
+	}
+	if !plain.Aircraft["ABC123"].IsEmergency() {
+		t.Error("another model's codes shouldn't change the defaults")
+	}
+}
+
 func TestNewModel(t *testing.T) {
 	cfg := config.DefaultConfig()
 
@@ -287,7 +304,7 @@ func TestHandleAircraftMsg(t *testing.T) {
 			},
 		}
 		data, _ := json.Marshal(snapshotData)
-		msg := ws.Message{Type: string(ws.AircraftSnapshot), Data: data}
+		msg := codec.Message{Type: string(codec.AircraftSnapshot), Data: data}
 		m.handleAircraftMsg(msg)
 
 		if len(m.Aircraft) != 2 {
@@ -310,7 +327,7 @@ func TestHandleAircraftMsg(t *testing.T) {
 			},
 		}
 		data, _ := json.Marshal(snapshotData)
-		msg := ws.Message{Type: string(ws.AircraftSnapshot), Data: data}
+		msg := codec.Message{Type: string(codec.AircraftSnapshot), Data: data}
 		m.handleAircraftMsg(msg)
 
 		if _, exists := m.Aircraft["STALE1"]; exists {
@@ -332,7 +349,7 @@ func TestHandleAircraftMsg(t *testing.T) {
 			"flight": "AAL789",
 		}
 		data, _ := json.Marshal(updateData)
-		msg := ws.Message{Type: string(ws.AircraftUpdate), Data: data}
+		msg := codec.Message{Type: string(codec.AircraftUpdate), Data: data}
 		m.handleAircraftMsg(msg)
 
 		if len(m.Aircraft) != 1 {
@@ -351,7 +368,7 @@ func TestHandleAircraftMsg(t *testing.T) {
 			"flight": "SWA012",
 		}
 		data, _ := json.Marshal(newData)
-		msg := ws.Message{Type: string(ws.AircraftNew), Data: data}
+		msg := codec.Message{Type: string(codec.AircraftNew), Data: data}
 		m.handleAircraftMsg(msg)
 
 		if len(m.Aircraft) != 1 {
@@ -368,7 +385,7 @@ func TestHandleAircraftMsg(t *testing.T) {
 			"hex": "MNO345",
 		}
 		data, _ := json.Marshal(removeData)
-		msg := ws.Message{Type: string(ws.AircraftRemove), Data: data}
+		msg := codec.Message{Type: string(codec.AircraftRemove), Data: data}
 		m.handleAircraftMsg(msg)
 
 		if len(m.Aircraft) != 0 {
@@ -385,7 +402,7 @@ func TestHandleAircraftMsg(t *testing.T) {
 			"hex": "",
 		}
 		data, _ := json.Marshal(removeData)
-		msg := ws.Message{Type: string(ws.AircraftRemove), Data: data}
+		msg := codec.Message{Type: string(codec.AircraftRemove), Data: data}
 		m.handleAircraftMsg(msg)
 
 		if len(m.Aircraft) != 1 {
@@ -396,7 +413,7 @@ func TestHandleAircraftMsg(t *testing.T) {
 	// Test invalid snapshot data
 	t.Run("invalid snapshot", func(t *testing.T) {
 		m := NewModel(cfg, ModeBasic)
-		msg := ws.Message{Type: string(ws.AircraftSnapshot), Data: []byte("invalid json")}
+		msg := codec.Message{Type: string(codec.AircraftSnapshot), Data: []byte("invalid json")}
 		m.handleAircraftMsg(msg)
 		// Should not panic, just ignore invalid data
 		if len(m.Aircraft) != 0 {
@@ -407,7 +424,7 @@ func TestHandleAircraftMsg(t *testing.T) {
 	// Test invalid update data
 	t.Run("invalid update", func(t *testing.T) {
 		m := NewModel(cfg, ModeBasic)
-		msg := ws.Message{Type: string(ws.AircraftUpdate), Data: []byte("invalid json")}
+		msg := codec.Message{Type: string(codec.AircraftUpdate), Data: []byte("invalid json")}
 		m.handleAircraftMsg(msg)
 		// Should not panic, just ignore invalid data
 	})
@@ -416,7 +433,7 @@ func TestHandleAircraftMsg(t *testing.T) {
 	t.Run("invalid remove", func(t *testing.T) {
 		m := NewModel(cfg, ModeBasic)
 		m.Aircraft["TEST"] = &Aircraft{Hex: "TEST"}
-		msg := ws.Message{Type: string(ws.AircraftRemove), Data: []byte("invalid json")}
+		msg := codec.Message{Type: string(codec.AircraftRemove), Data: []byte("invalid json")}
 		m.handleAircraftMsg(msg)
 		// Should not panic, aircraft should remain
 		if len(m.Aircraft) != 1 {
@@ -439,7 +456,7 @@ func TestUpdateAircraft(t *testing.T) {
 	rssi := -5.5
 	distance := 25.5
 
-	ac := &ws.Aircraft{
+	ac := &codec.Aircraft{
 		Hex:      "ABC123",
 		Flight:   "  UAL123  ",
 		Type:     "B738",
@@ -506,7 +523,7 @@ func TestUpdateAircraft(t *testing.T) {
 	// Test with Alt instead of AltBaro
 	t.Run("alt fallback", func(t *testing.T) {
 		m := NewModel(cfg, ModeBasic)
-		ac := &ws.Aircraft{
+		ac := &codec.Aircraft{
 			Hex: "DEF456",
 			Alt: &alt,
 		}
@@ -519,7 +536,7 @@ func TestUpdateAircraft(t *testing.T) {
 	// Test with VR instead of BaroRate
 	t.Run("vr fallback", func(t *testing.T) {
 		m := NewModel(cfg, ModeBasic)
-		ac := &ws.Aircraft{
+		ac := &codec.Aircraft{
 			Hex: "GHI789",
 			VR:  &vr,
 		}
@@ -532,7 +549,7 @@ func TestUpdateAircraft(t *testing.T) {
 	// Test with empty hex
 	t.Run("empty hex", func(t *testing.T) {
 		m := NewModel(cfg, ModeBasic)
-		ac := &ws.Aircraft{
+		ac := &codec.Aircraft{
 			Hex: "",
 		}
 		m.updateAircraft(ac)
@@ -608,7 +625,7 @@ func TestHandleACARSMsg(t *testing.T) {
 			"text":     "Hello World",
 		}
 		data, _ := json.Marshal(acarsData)
-		msg := ws.Message{Type: string(ws.ACARSMessage), Data: data}
+		msg := codec.Message{Type: string(codec.ACARSMessage), Data: data}
 		m.handleACARSMsg(msg)
 
 		if len(m.ACARSMessages) != 1 {
@@ -630,7 +647,7 @@ func TestHandleACARSMsg(t *testing.T) {
 			{"callsign": "DAL456", "flight": "DL456", "label": "H2", "text": "Msg2"},
 		}
 		data, _ := json.Marshal(acarsData)
-		msg := ws.Message{Type: string(ws.ACARSSnapshot), Data: data}
+		msg := codec.Message{Type: string(codec.ACARSSnapshot), Data: data}
 		m.handleACARSMsg(msg)
 
 		if len(m.ACARSMessages) != 2 {
@@ -649,7 +666,7 @@ func TestHandleACARSMsg(t *testing.T) {
 				"text":     "Message " + itoa(i),
 			}
 			data, _ := json.Marshal(acarsData)
-			msg := ws.Message{Type: string(ws.ACARSMessage), Data: data}
+			msg := codec.Message{Type: string(codec.ACARSMessage), Data: data}
 			m.handleACARSMsg(msg)
 		}
 
@@ -661,7 +678,7 @@ func TestHandleACARSMsg(t *testing.T) {
 	// Test invalid data
 	t.Run("invalid data", func(t *testing.T) {
 		m := NewModel(cfg, ModeBasic)
-		msg := ws.Message{Type: string(ws.ACARSMessage), Data: []byte("invalid json")}
+		msg := codec.Message{Type: string(codec.ACARSMessage), Data: []byte("invalid json")}
 		m.handleACARSMsg(msg)
 		// Should not panic
 		if len(m.ACARSMessages) != 0 {
@@ -672,7 +689,7 @@ func TestHandleACARSMsg(t *testing.T) {
 	// Test unhandled message type
 	t.Run("unhandled type", func(t *testing.T) {
 		m := NewModel(cfg, ModeBasic)
-		msg := ws.Message{Type: "unknown:type", Data: []byte("{}")}
+		msg := codec.Message{Type: "unknown:type", Data: []byte("{}")}
 		m.handleACARSMsg(msg)
 		// Should not panic
 		if len(m.ACARSMessages) != 0 {
@@ -809,15 +826,15 @@ func TestTickMsgType(t *testing.T) {
 }
 
 func TestAircraftMsgType(t *testing.T) {
-	// Verify aircraftMsg is a ws.Message alias
-	var msg aircraftMsg = aircraftMsg(ws.Message{Type: "test"})
-	_ = ws.Message(msg) // Should compile without error
+	// Verify aircraftMsg is a codec.Message alias
+	var msg aircraftMsg = aircraftMsg(codec.Message{Type: "test"})
+	_ = codec.Message(msg) // Should compile without error
 }
 
 func TestAcarsMsgType(t *testing.T) {
-	// Verify acarsMsg is a ws.Message alias
-	var msg acarsMsg = acarsMsg(ws.Message{Type: "test"})
-	_ = ws.Message(msg) // Should compile without error
+	// Verify acarsMsg is a codec.Message alias
+	var msg acarsMsg = acarsMsg(codec.Message{Type: "test"})
+	_ = codec.Message(msg) // Should compile without error
 }
 
 func TestACARSMessageStruct(t *testing.T) {
@@ -910,7 +927,7 @@ func TestUpdateWithAircraftMsg(t *testing.T) {
 		"hex":    "ABC123",
 		"flight": "UAL123",
 	})
-	msg := aircraftMsg(ws.Message{Type: string(ws.AircraftNew), Data: data})
+	msg := aircraftMsg(codec.Message{Type: string(codec.AircraftNew), Data: data})
 
 	newModel, cmd := m.Update(msg)
 
@@ -937,7 +954,7 @@ func TestUpdateWithACARSMsg(t *testing.T) {
 		"label":    "H1",
 		"text":     "Test",
 	})
-	msg := acarsMsg(ws.Message{Type: string(ws.ACARSMessage), Data: data})
+	msg := acarsMsg(codec.Message{Type: string(codec.ACARSMessage), Data: data})
 
 	newModel, cmd := m.Update(msg)
 
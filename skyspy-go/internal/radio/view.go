@@ -11,8 +11,14 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/skyspy/skyspy-go/internal/ui"
+	"github.com/skyspy/skyspy-go/internal/units"
 )
 
+// distUnit returns the unit distances are shown in
+func (m *Model) distUnit() string {
+	return units.Distance(m.Config.Display.Units)
+}
+
 // View renders the radio display
 func (m *Model) View() string {
 	if m.Mode == ModePro {
@@ -99,25 +105,26 @@ func (m *Model) renderHeader() string {
 	secondaryBright := lipgloss.NewStyle().Foreground(m.Theme.SecondaryBright)
 	infoStyle := lipgloss.NewStyle().Foreground(m.Theme.Info)
 	textDim := lipgloss.NewStyle().Foreground(m.Theme.TextDim)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render(" " + strings.Repeat("═", 69)))
+	sb.WriteString(borderStyle.Render(" " + strings.Repeat(g.DoubleH, 69)))
 	sb.WriteString("\n")
 	sb.WriteString(borderStyle.Render(" "))
-	sb.WriteString(textDim.Render("░░ "))
+	sb.WriteString(textDim.Render(strings.Repeat(g.Shades[0], 2) + " "))
 	sb.WriteString(primaryBright.Render(" SKYSPY RADIO "))
-	sb.WriteString(textDim.Render(" ░░"))
-	sb.WriteString(borderStyle.Render(" ── "))
+	sb.WriteString(textDim.Render(" " + strings.Repeat(g.Shades[0], 2)))
+	sb.WriteString(borderStyle.Render(" " + strings.Repeat(g.H, 2) + " "))
 	sb.WriteString(secondaryBright.Render("ADS-B / ACARS MONITOR"))
-	sb.WriteString(borderStyle.Render(" ── "))
+	sb.WriteString(borderStyle.Render(" " + strings.Repeat(g.H, 2) + " "))
 
 	spin := m.Spinners[m.Frame%4]
 	sb.WriteString(infoStyle.Render(spin + " "))
 	sb.WriteString(infoStyle.Bold(true).Render("LIVE"))
 	sb.WriteString(infoStyle.Render(" " + spin))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render(" " + strings.Repeat("═", 69)))
+	sb.WriteString(borderStyle.Render(" " + strings.Repeat(g.DoubleH, 69)))
 
 	return sb.String()
 }
@@ -128,29 +135,30 @@ func (m *Model) renderProHeader() string {
 	textDim := lipgloss.NewStyle().Foreground(m.Theme.TextDim)
 	infoStyle := lipgloss.NewStyle().Foreground(m.Theme.Info)
 	secondaryBright := lipgloss.NewStyle().Foreground(m.Theme.SecondaryBright)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╔" + strings.Repeat("═", 80) + "╗"))
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 80) + g.DoubleTR))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("║ "))
-	sb.WriteString(textDim.Render("░░░"))
+	sb.WriteString(borderStyle.Render(g.DoubleV + " "))
+	sb.WriteString(textDim.Render(strings.Repeat(g.Shades[0], 3)))
 	sb.WriteString(primaryBright.Render(" SKYSPY RADIO PRO "))
-	sb.WriteString(textDim.Render("░░░"))
-	sb.WriteString(borderStyle.Render(" ── ADS-B & ACARS MONITOR ── "))
+	sb.WriteString(textDim.Render(strings.Repeat(g.Shades[0], 3)))
+	sb.WriteString(borderStyle.Render(" " + strings.Repeat(g.H, 2) + " ADS-B & ACARS MONITOR " + strings.Repeat(g.H, 2) + " "))
 
 	// Animated indicator
-	indicators := []string{"◐", "◓", "◑", "◒"}
+	indicators := m.Spinners
 	sb.WriteString(infoStyle.Render(indicators[m.Frame%4]))
 	sb.WriteString(infoStyle.Bold(true).Render(" LIVE "))
 	sb.WriteString(infoStyle.Render(indicators[(m.Frame+2)%4]))
 
-	sb.WriteString(textDim.Render(" ░░░ "))
+	sb.WriteString(textDim.Render(" " + strings.Repeat(g.Shades[0], 3) + " "))
 	sb.WriteString(secondaryBright.Render(time.Now().Format("15:04:05")))
 	sb.WriteString(" ")
-	sb.WriteString(borderStyle.Render("║"))
+	sb.WriteString(borderStyle.Render(g.DoubleV))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("╠" + strings.Repeat("═", 80) + "╣"))
+	sb.WriteString(borderStyle.Render(g.DoubleTeeLeft + strings.Repeat(g.DoubleH, 80) + g.DoubleTeeRight))
 
 	return sb.String()
 }
@@ -164,22 +172,23 @@ func (m *Model) renderAircraftTable() string {
 	primaryBright := lipgloss.NewStyle().Foreground(m.Theme.PrimaryBright)
 	militaryStyle := lipgloss.NewStyle().Foreground(m.Theme.Military).Bold(true)
 	emergencyStyle := lipgloss.NewStyle().Foreground(m.Theme.Emergency).Bold(true)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 
 	// Title
-	sb.WriteString(borderStyle.Render("╭─"))
-	sb.WriteString(titleStyle.Render("◄ LIVE AIRCRAFT TRACKING ►"))
-	sb.WriteString(borderStyle.Render("────────────────────────────────────────╮"))
+	sb.WriteString(borderStyle.Render(g.TL + g.H))
+	sb.WriteString(titleStyle.Render(g.PagePrev + " LIVE AIRCRAFT TRACKING " + g.PageNext))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.H, 40) + g.TR))
 	sb.WriteString("\n")
 
 	// Header row
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString(headerStyle.Render("  ICAO   CALL      ALT    SPD  HDG   DIST   SQ    SIG   TYPE"))
 	sb.WriteString(strings.Repeat(" ", 8))
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("├" + strings.Repeat("─", 69) + "┤"))
+	sb.WriteString(borderStyle.Render(g.TeeLeft + strings.Repeat(g.H, 69) + g.TeeRight))
 	sb.WriteString("\n")
 
 	// Aircraft rows
@@ -194,7 +203,7 @@ func (m *Model) renderAircraftTable() string {
 			continue
 		}
 
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 
 		// ICAO
 		icaoStyle := secondaryBright
@@ -241,9 +250,9 @@ func (m *Model) renderAircraftTable() string {
 		// Distance
 		dist := "---"
 		if ac.Distance > 0 {
-			dist = fmt.Sprintf("%.1f", ac.Distance)
+			dist = fmt.Sprintf("%.1f", units.FromNM(ac.Distance, m.distUnit()))
 		}
-		sb.WriteString(secondaryBright.Render(fmt.Sprintf("%5snm ", dist)))
+		sb.WriteString(secondaryBright.Render(fmt.Sprintf("%5s%s ", dist, m.distUnit())))
 
 		// Squawk
 		sq := ac.Squawk
@@ -270,26 +279,26 @@ func (m *Model) renderAircraftTable() string {
 		}
 		sb.WriteString(textDim.Render(fmt.Sprintf("%-4s", acType)))
 
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		sb.WriteString("\n")
 		count++
 	}
 
 	// Fill remaining rows
 	for count < maxRows {
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		sb.WriteString(textDim.Render(strings.Repeat(" ", 69)))
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		sb.WriteString("\n")
 		count++
 	}
 
 	// Footer
-	sb.WriteString(borderStyle.Render("╰"))
-	sb.WriteString(borderStyle.Render(strings.Repeat("─", 25)))
+	sb.WriteString(borderStyle.Render(g.BL))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.H, 25)))
 	sb.WriteString(textDim.Render(fmt.Sprintf(" %d aircraft ", len(m.Aircraft))))
-	sb.WriteString(borderStyle.Render(strings.Repeat("─", 30)))
-	sb.WriteString(borderStyle.Render("╯"))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.H, 30)))
+	sb.WriteString(borderStyle.Render(g.BR))
 
 	return sb.String()
 }
@@ -305,19 +314,20 @@ func (m *Model) renderAircraftDisplay() string {
 	emergencyStyle := lipgloss.NewStyle().Foreground(m.Theme.Emergency).Bold(true)
 	successStyle := lipgloss.NewStyle().Foreground(m.Theme.Success)
 	errorStyle := lipgloss.NewStyle().Foreground(m.Theme.Error)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 
 	// Title
-	sb.WriteString(borderStyle.Render("╔══"))
-	sb.WriteString(titleStyle.Render("◄◄ LIVE TRAFFIC ►►"))
-	sb.WriteString(borderStyle.Render("══════════════════════════════════╗"))
+	sb.WriteString(borderStyle.Render(g.DoubleTL + strings.Repeat(g.DoubleH, 2)))
+	sb.WriteString(titleStyle.Render(g.PagePrev + g.PagePrev + " LIVE TRAFFIC " + g.PageNext + g.PageNext))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.DoubleH, 34) + g.DoubleTR))
 	sb.WriteString("\n")
 
 	// Header row
-	sb.WriteString(borderStyle.Render("║"))
-	sb.WriteString(headerStyle.Render(" ■ ICAO   CALLSIGN TYPE   ALT    GS    VS   HDG DST   SIG   SQ  "))
-	sb.WriteString(borderStyle.Render("║"))
+	sb.WriteString(borderStyle.Render(g.DoubleV))
+	sb.WriteString(headerStyle.Render(" " + g.Aircraft + " ICAO   CALLSIGN TYPE   ALT    GS    VS   HDG DST   SIG   SQ  "))
+	sb.WriteString(borderStyle.Render(g.DoubleV))
 	sb.WriteString("\n")
 
 	// Aircraft rows
@@ -332,21 +342,21 @@ func (m *Model) renderAircraftDisplay() string {
 			continue
 		}
 
-		sb.WriteString(borderStyle.Render("║"))
+		sb.WriteString(borderStyle.Render(g.DoubleV))
 
 		// Status indicator
 		if ac.Military {
-			sb.WriteString(militaryStyle.Render(" ◆ "))
+			sb.WriteString(militaryStyle.Render(" " + g.Military + " "))
 		} else if ac.IsEmergency() {
 			if m.Blink {
-				sb.WriteString(emergencyStyle.Render(" ! "))
+				sb.WriteString(emergencyStyle.Render(" " + g.EmergencyAlt + " "))
 			} else {
-				sb.WriteString(emergencyStyle.Render(" ✖ "))
+				sb.WriteString(emergencyStyle.Render(" " + g.Emergency + " "))
 			}
 		} else {
-			ind := "●"
+			ind := g.On
 			if !m.Blink {
-				ind = "○"
+				ind = g.Off
 			}
 			sb.WriteString(successStyle.Render(" " + ind + " "))
 		}
@@ -422,7 +432,7 @@ func (m *Model) renderAircraftDisplay() string {
 		// Distance
 		dist := "---"
 		if ac.Distance > 0 {
-			dist = fmt.Sprintf("%.1f", ac.Distance)
+			dist = fmt.Sprintf("%.1f", units.FromNM(ac.Distance, m.distUnit()))
 		}
 		sb.WriteString(secondaryBright.Render(fmt.Sprintf("%5s ", dist)))
 
@@ -441,26 +451,26 @@ func (m *Model) renderAircraftDisplay() string {
 		}
 		sb.WriteString(sqStyle.Render(fmt.Sprintf("%4s", sq)))
 
-		sb.WriteString(borderStyle.Render("║"))
+		sb.WriteString(borderStyle.Render(g.DoubleV))
 		sb.WriteString("\n")
 		count++
 	}
 
 	// Fill remaining rows
 	for count < maxRows {
-		sb.WriteString(borderStyle.Render("║"))
+		sb.WriteString(borderStyle.Render(g.DoubleV))
 		sb.WriteString(textDim.Render(strings.Repeat(" ", 66)))
-		sb.WriteString(borderStyle.Render("║"))
+		sb.WriteString(borderStyle.Render(g.DoubleV))
 		sb.WriteString("\n")
 		count++
 	}
 
 	// Footer
-	sb.WriteString(borderStyle.Render("╚"))
-	sb.WriteString(borderStyle.Render(strings.Repeat("═", 20)))
+	sb.WriteString(borderStyle.Render(g.DoubleBL))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.DoubleH, 20)))
 	sb.WriteString(textDim.Render(fmt.Sprintf(" %d aircraft tracked ", len(m.Aircraft))))
-	sb.WriteString(borderStyle.Render(strings.Repeat("═", 22)))
-	sb.WriteString(borderStyle.Render("╝"))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.DoubleH, 22)))
+	sb.WriteString(borderStyle.Render(g.DoubleBR))
 
 	return sb.String()
 }
@@ -487,35 +497,36 @@ func (m *Model) renderStatusPanel() string {
 	secondaryBright := lipgloss.NewStyle().Foreground(m.Theme.SecondaryBright)
 	warningStyle := lipgloss.NewStyle().Foreground(m.Theme.Warning)
 	infoStyle := lipgloss.NewStyle().Foreground(m.Theme.Info)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╭─"))
+	sb.WriteString(borderStyle.Render(g.TL + g.H))
 	sb.WriteString(titleStyle.Render("STATUS"))
-	sb.WriteString(borderStyle.Render("─────────────────╮"))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.H, 17) + g.TR))
 	sb.WriteString("\n")
 
 	// Connection status
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	if m.Connected {
-		ind := "◉"
+		ind := g.Live
 		if !m.Blink {
-			ind = "○"
+			ind = g.Off
 		}
 		sb.WriteString(successStyle.Render("  " + ind + " "))
 		sb.WriteString(successStyle.Bold(true).Render("RECEIVING"))
 		sb.WriteString(strings.Repeat(" ", 9))
 	} else {
-		sb.WriteString(errorStyle.Render("  ○ "))
+		sb.WriteString(errorStyle.Render("  " + g.Off + " "))
 		sb.WriteString(errorStyle.Bold(true).Render("SCANNING"))
 		sb.WriteString(strings.Repeat(" ", 10))
 	}
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString(strings.Repeat(" ", 24))
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
 	// Stats
@@ -531,35 +542,35 @@ func (m *Model) renderStatusPanel() string {
 	}
 
 	for _, stat := range stats {
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		sb.WriteString(textDim.Render(fmt.Sprintf("  %-7s ", stat.label)))
 		sb.WriteString(stat.style.Render(fmt.Sprintf("%-13s", stat.value)))
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		sb.WriteString("\n")
 	}
 
 	// VU Meters
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString(strings.Repeat(" ", 24))
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
 	vu := ui.NewVUMeter(m.Theme, 10)
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString(textDim.Render("  VU L "))
 	sb.WriteString(vu.Render(m.VULeft))
 	sb.WriteString(strings.Repeat(" ", 6))
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString(textDim.Render("  VU R "))
 	sb.WriteString(vu.Render(m.VURight))
 	sb.WriteString(strings.Repeat(" ", 6))
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
-	sb.WriteString(borderStyle.Render("╰" + strings.Repeat("─", 24) + "╯"))
+	sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, 24) + g.BR))
 
 	return sb.String()
 }
@@ -568,18 +579,19 @@ func (m *Model) renderFrequencyPanel() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.Theme.Border)
 	titleStyle := lipgloss.NewStyle().Foreground(m.Theme.PrimaryBright)
 	textDim := lipgloss.NewStyle().Foreground(m.Theme.TextDim)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╭─"))
+	sb.WriteString(borderStyle.Render(g.TL + g.H))
 	sb.WriteString(titleStyle.Render("FREQUENCIES"))
-	sb.WriteString(borderStyle.Render("────────────╮"))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.H, 12) + g.TR))
 	sb.WriteString("\n")
 
 	// Frequency list
 	freqLines := m.FreqDisp.RenderList(m.Blink)
 	for _, line := range freqLines {
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		// Pad line to fit panel width
 		lineWidth := lipgloss.Width(line)
 		padding := 24 - lineWidth
@@ -588,12 +600,12 @@ func (m *Model) renderFrequencyPanel() string {
 		}
 		sb.WriteString(line)
 		sb.WriteString(strings.Repeat(" ", padding))
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		sb.WriteString("\n")
 	}
 
 	// Mini spectrum
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString(textDim.Render("  "))
 	compact := m.Spectrum.RenderCompact()
 	if lipgloss.Width(compact) > 20 {
@@ -602,10 +614,10 @@ func (m *Model) renderFrequencyPanel() string {
 	}
 	sb.WriteString(compact)
 	sb.WriteString(strings.Repeat(" ", 22-lipgloss.Width(compact)))
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString("\n")
 
-	sb.WriteString(borderStyle.Render("╰" + strings.Repeat("─", 24) + "╯"))
+	sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, 24) + g.BR))
 
 	return sb.String()
 }
@@ -617,6 +629,7 @@ func (m *Model) renderACARSPanel() string {
 	secondaryBright := lipgloss.NewStyle().Foreground(m.Theme.SecondaryBright)
 	primaryStyle := lipgloss.NewStyle().Foreground(m.Theme.Primary)
 	infoStyle := lipgloss.NewStyle().Foreground(m.Theme.Info)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 
@@ -625,9 +638,9 @@ func (m *Model) renderACARSPanel() string {
 		width = 92
 	}
 
-	sb.WriteString(borderStyle.Render("╭─"))
-	sb.WriteString(titleStyle.Render("◄ ACARS/VDL2 FEED ►"))
-	sb.WriteString(borderStyle.Render(strings.Repeat("─", width-22) + "╮"))
+	sb.WriteString(borderStyle.Render(g.TL + g.H))
+	sb.WriteString(titleStyle.Render(g.PagePrev + " ACARS/VDL2 FEED " + g.PageNext))
+	sb.WriteString(borderStyle.Render(strings.Repeat(g.H, width-22) + g.TR))
 	sb.WriteString("\n")
 
 	// Show last 6 messages (8 for pro)
@@ -645,7 +658,7 @@ func (m *Model) renderACARSPanel() string {
 	for i := start; i < len(m.ACARSMessages); i++ {
 		msg := m.ACARSMessages[i]
 
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 
 		// Timestamp
 		ts := msg.Timestamp
@@ -701,26 +714,26 @@ func (m *Model) renderACARSPanel() string {
 			sb.WriteString(strings.Repeat(" ", width-lineLen))
 		}
 
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		sb.WriteString("\n")
 		count++
 	}
 
 	// Fill remaining rows if needed
 	for count < maxMessages {
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		if count == 0 {
 			sb.WriteString(textDim.Render("  Waiting for ACARS messages..."))
 			sb.WriteString(strings.Repeat(" ", width-32))
 		} else {
 			sb.WriteString(strings.Repeat(" ", width))
 		}
-		sb.WriteString(borderStyle.Render("│"))
+		sb.WriteString(borderStyle.Render(g.V))
 		sb.WriteString("\n")
 		count++
 	}
 
-	sb.WriteString(borderStyle.Render("╰" + strings.Repeat("─", width) + "╯"))
+	sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, width) + g.BR))
 
 	return sb.String()
 }
@@ -732,25 +745,26 @@ func (m *Model) renderStatsBar() string {
 	errorStyle := lipgloss.NewStyle().Foreground(m.Theme.Error).Bold(true)
 	secondaryBright := lipgloss.NewStyle().Foreground(m.Theme.SecondaryBright)
 	militaryStyle := lipgloss.NewStyle().Foreground(m.Theme.Military)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╭" + strings.Repeat("─", 69) + "╮"))
+	sb.WriteString(borderStyle.Render(g.TL + strings.Repeat(g.H, 69) + g.TR))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("│ "))
+	sb.WriteString(borderStyle.Render(g.V + " "))
 
 	// Connection status
 	if m.Connected {
-		ind := "◉"
+		ind := g.Live
 		if !m.Blink {
-			ind = "○"
+			ind = g.Off
 		}
 		sb.WriteString(successStyle.Render(ind + " CONNECTED"))
 	} else {
-		sb.WriteString(errorStyle.Render("○ DISCONNECTED"))
+		sb.WriteString(errorStyle.Render(g.Off + " DISCONNECTED"))
 	}
 
-	sb.WriteString(textDim.Render("  │  "))
+	sb.WriteString(textDim.Render("  " + g.V + "  "))
 
 	// Aircraft count
 	sb.WriteString(textDim.Render("AIRCRAFT: "))
@@ -761,13 +775,13 @@ func (m *Model) renderStatsBar() string {
 		sb.WriteString(militaryStyle.Render(fmt.Sprintf(" (MIL:%d)", milCount)))
 	}
 
-	sb.WriteString(textDim.Render("  │  "))
+	sb.WriteString(textDim.Render("  " + g.V + "  "))
 
 	// Messages
 	sb.WriteString(textDim.Render("MSGS: "))
 	sb.WriteString(secondaryBright.Render(fmt.Sprintf("%d", m.TotalMessages)))
 
-	sb.WriteString(textDim.Render("  │  "))
+	sb.WriteString(textDim.Render("  " + g.V + "  "))
 
 	// Time
 	sb.WriteString(textDim.Render("UTC: "))
@@ -779,9 +793,9 @@ func (m *Model) renderStatsBar() string {
 		sb.WriteString(strings.Repeat(" ", remaining))
 	}
 
-	sb.WriteString(borderStyle.Render("│"))
+	sb.WriteString(borderStyle.Render(g.V))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("╰" + strings.Repeat("─", 69) + "╯"))
+	sb.WriteString(borderStyle.Render(g.BL + strings.Repeat(g.H, 69) + g.BR))
 
 	return sb.String()
 }
@@ -791,16 +805,17 @@ func (m *Model) renderFrequencyLine() string {
 	successStyle := lipgloss.NewStyle().Foreground(m.Theme.Success)
 	infoStyle := lipgloss.NewStyle().Foreground(m.Theme.Info)
 	secondaryBright := lipgloss.NewStyle().Foreground(m.Theme.SecondaryBright)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 	sb.WriteString("  ")
-	sb.WriteString(successStyle.Render("▸ 1090 MHz "))
+	sb.WriteString(successStyle.Render(g.Cursor + " 1090 MHz "))
 	sb.WriteString(textDim.Render("[ADS-B]"))
 	sb.WriteString("  ")
-	sb.WriteString(infoStyle.Render("▸ 136.900 MHz "))
+	sb.WriteString(infoStyle.Render(g.Cursor + " 136.900 MHz "))
 	sb.WriteString(textDim.Render("[ACARS]"))
 	sb.WriteString("  ")
-	sb.WriteString(secondaryBright.Render("▸ 136.725 MHz "))
+	sb.WriteString(secondaryBright.Render(g.Cursor + " 136.725 MHz "))
 	sb.WriteString(textDim.Render("[VDL2]"))
 
 	return sb.String()
@@ -809,12 +824,13 @@ func (m *Model) renderFrequencyLine() string {
 func (m *Model) renderProFooter() string {
 	borderStyle := lipgloss.NewStyle().Foreground(m.Theme.PrimaryBright)
 	textDim := lipgloss.NewStyle().Foreground(m.Theme.TextDim)
+	g := m.Theme.GlyphSet()
 
 	var sb strings.Builder
 
-	sb.WriteString(borderStyle.Render("╠" + strings.Repeat("═", 80) + "╣"))
+	sb.WriteString(borderStyle.Render(g.DoubleTeeLeft + strings.Repeat(g.DoubleH, 80) + g.DoubleTeeRight))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("║ "))
+	sb.WriteString(borderStyle.Render(g.DoubleV + " "))
 
 	// Scan display
 	scanLine := m.FreqDisp.Render()
@@ -826,9 +842,9 @@ func (m *Model) renderProFooter() string {
 		sb.WriteString(strings.Repeat(" ", remaining))
 	}
 
-	sb.WriteString(borderStyle.Render(" ║"))
+	sb.WriteString(borderStyle.Render(" " + g.DoubleV))
 	sb.WriteString("\n")
-	sb.WriteString(borderStyle.Render("╚" + strings.Repeat("═", 80) + "╝"))
+	sb.WriteString(borderStyle.Render(g.DoubleBL + strings.Repeat(g.DoubleH, 80) + g.DoubleBR))
 	sb.WriteString("\n")
 
 	// Help line
@@ -842,7 +858,7 @@ func (m *Model) renderSignalBars(ac *Aircraft) string {
 	if ac.HasRSSI {
 		return sigMeter.Render(ac.RSSI)
 	}
-	return lipgloss.NewStyle().Foreground(m.Theme.TextDim).Render("░░░░░")
+	return lipgloss.NewStyle().Foreground(m.Theme.TextDim).Render(strings.Repeat(m.Theme.GlyphSet().BarEmpty, 5))
 }
 
 func getCompass(heading float64) string {
@@ -4,7 +4,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/theme"
 	"github.com/skyspy/skyspy-go/internal/ui"
 )
 
@@ -101,6 +103,27 @@ func TestViewProSidebarLines(t *testing.T) {
 	}
 }
 
+func TestViewASCIIGlyphs(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Display.GlyphSet = theme.GlyphsASCII
+
+	for _, mode := range []DisplayMode{ModeBasic, ModePro} {
+		m := NewModel(cfg, mode)
+		m.Aircraft["ABC123"] = &Aircraft{Hex: "ABC123", Callsign: "UAL1", RSSI: -10, HasRSSI: true}
+		m.Aircraft["AE0001"] = &Aircraft{Hex: "AE0001", Callsign: "RCH1", Military: true}
+		m.Aircraft["A77000"] = &Aircraft{Hex: "A77000", Callsign: "EMG1", Squawk: "7700"}
+		m.sortAircraft()
+
+		view := ansi.Strip(m.View())
+		for i, r := range view {
+			if r >= 0x80 {
+				t.Errorf("mode %d: non-ASCII %q at byte %d", mode, r, i)
+				break
+			}
+		}
+	}
+}
+
 func TestRenderHeader(t *testing.T) {
 	cfg := config.DefaultConfig()
 	m := NewModel(cfg, ModeBasic)
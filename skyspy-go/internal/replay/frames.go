@@ -0,0 +1,265 @@
+// Package replay plays aircraft exports back as a feed, so a session
+// recorded with the radar's exports can be watched again without a server
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/skyspy/skyspy-go/internal/units"
+)
+
+// Frame is the aircraft an export held at the time it was written
+type Frame struct {
+	Time     time.Time
+	Aircraft []codec.Aircraft
+}
+
+// ErrNoFrames is returned when the files hold no aircraft exports
+var ErrNoFrames = errors.New("no aircraft exports to replay")
+
+// Load reads aircraft exports, JSON or CSV, into frames in time order.
+// Exports written at the same time are merged, the later file's aircraft
+// winning. A JSON file may hold several exports one after another, and a
+// CSV file rows from several, told apart by their timestamp column.
+func Load(paths ...string) ([]Frame, error) {
+	var frames []Frame
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var read []Frame
+		if trimmed := bytes.TrimLeft(data, " \t\r\n\ufeff"); len(trimmed) > 0 && trimmed[0] == '{' {
+			read, err = readJSON(bytes.NewReader(data))
+		} else {
+			read, err = readCSV(bytes.NewReader(data))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		frames = append(frames, read...)
+	}
+	frames = merge(frames)
+	if len(frames) == 0 {
+		return nil, ErrNoFrames
+	}
+	return frames, nil
+}
+
+// readJSON reads exports written by the radar's JSON export
+func readJSON(r io.Reader) ([]Frame, error) {
+	var frames []Frame
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var data export.AircraftExportData
+		err := dec.Decode(&data)
+		if errors.Is(err, io.EOF) {
+			return frames, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not an aircraft export: %w", err)
+		}
+		at, err := time.Parse(time.RFC3339, data.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("export timestamp %q: %w", data.Timestamp, err)
+		}
+		frame := Frame{Time: at}
+		for i := range data.Aircraft {
+			if ac, ok := fromExport(&data.Aircraft[i]); ok {
+				frame.Aircraft = append(frame.Aircraft, ac)
+			}
+		}
+		frames = append(frames, frame)
+	}
+}
+
+// fromExport turns an exported aircraft back into feed data
+func fromExport(e *export.AircraftExport) (codec.Aircraft, bool) {
+	hex := codec.NormalizeHex(e.Hex)
+	if hex == "" {
+		return codec.Aircraft{}, false
+	}
+	return codec.Aircraft{
+		Hex:         hex,
+		Flight:      e.Callsign,
+		Lat:         e.Lat,
+		Lon:         e.Lon,
+		AltBaro:     e.Altitude,
+		AltGeom:     e.GeomAltitude,
+		GS:          e.Speed,
+		Track:       e.Track,
+		BaroRate:    e.VerticalRate,
+		Squawk:      e.Squawk,
+		RSSI:        e.RSSI,
+		Type:        e.AircraftType,
+		Military:    e.Military,
+		Distance:    e.DistanceInNM(),
+		Bearing:     e.Bearing,
+		NavAltitude: e.NavAltitude,
+		NavHeading:  e.NavHeading,
+		NavQNH:      e.NavQNH,
+		NavModes:    e.NavModes,
+	}, true
+}
+
+// readCSV reads exports written by the radar's CSV export, a frame for
+// each timestamp
+func readCSV(r io.Reader) ([]Frame, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("not an aircraft export: %w", err)
+	}
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")] = i
+	}
+	for _, name := range []string{"hex", "timestamp"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("not an aircraft export: no %s column", name)
+		}
+	}
+
+	byTime := make(map[time.Time]*Frame)
+	var frames []*Frame
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := col[name]; ok && i < len(row) {
+				return unguard(strings.TrimSpace(row[i]))
+			}
+			return ""
+		}
+		at, err := time.Parse(time.RFC3339, field("timestamp"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: timestamp %q: %w", line, field("timestamp"), err)
+		}
+		hex := codec.NormalizeHex(field("hex"))
+		if hex == "" {
+			continue
+		}
+		ac := codec.Aircraft{
+			Hex:         hex,
+			Flight:      field("callsign"),
+			Lat:         csvFloat(field("lat")),
+			Lon:         csvFloat(field("lon")),
+			AltBaro:     csvInt(field("altitude")),
+			AltGeom:     csvInt(field("geom_altitude")),
+			GS:          csvFloat(field("speed")),
+			Track:       csvFloat(field("track")),
+			BaroRate:    csvFloat(field("vertical_rate")),
+			Squawk:      field("squawk"),
+			RSSI:        csvFloat(field("rssi")),
+			Type:        field("aircraft_type"),
+			Military:    field("military") == "true",
+			Distance:    csvDistance(field),
+			Bearing:     csvFloat(field("bearing")),
+			NavAltitude: csvInt(field("nav_altitude")),
+			NavHeading:  csvFloat(field("nav_heading")),
+			NavQNH:      csvFloat(field("nav_qnh")),
+			NavModes:    strings.Fields(field("nav_modes")),
+		}
+		f := byTime[at]
+		if f == nil {
+			f = &Frame{Time: at}
+			byTime[at] = f
+			frames = append(frames, f)
+		}
+		f.Aircraft = append(f.Aircraft, ac)
+	}
+
+	out := make([]Frame, len(frames))
+	for i, f := range frames {
+		out[i] = *f
+	}
+	return out, nil
+}
+
+// unguard undoes the quote the CSV export puts before text that a
+// spreadsheet would take for a formula
+func unguard(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
+
+// csvDistance reads the distance column, in whichever unit it was exported
+// in, as nm
+func csvDistance(field func(string) string) *float64 {
+	for _, unit := range units.DistanceUnits {
+		if d := csvFloat(field("distance_" + unit)); d != nil {
+			nm := units.ToNM(*d, unit)
+			return &nm
+		}
+	}
+	return nil
+}
+
+func csvFloat(s string) *float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func csvInt(s string) *int {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// merge sorts frames by time and folds together those written at the
+// same time, later aircraft replacing earlier ones with the same hex
+func merge(frames []Frame) []Frame {
+	sort.SliceStable(frames, func(i, j int) bool { return frames[i].Time.Before(frames[j].Time) })
+	var out []Frame
+	for _, f := range frames {
+		if n := len(out); n > 0 && out[n-1].Time.Equal(f.Time) {
+			out[n-1].Aircraft = mergeAircraft(out[n-1].Aircraft, f.Aircraft)
+			continue
+		}
+		out = append(out, Frame{Time: f.Time, Aircraft: mergeAircraft(nil, f.Aircraft)})
+	}
+	return out
+}
+
+// mergeAircraft adds later to earlier, replacing aircraft with the same hex
+func mergeAircraft(earlier, later []codec.Aircraft) []codec.Aircraft {
+	at := make(map[string]int, len(earlier)+len(later))
+	for i, ac := range earlier {
+		at[ac.Hex] = i
+	}
+	for _, ac := range later {
+		if i, ok := at[ac.Hex]; ok {
+			earlier[i] = ac
+			continue
+		}
+		at[ac.Hex] = len(earlier)
+		earlier = append(earlier, ac)
+	}
+	return earlier
+}
@@ -0,0 +1,141 @@
+package replay
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/export"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_JSONExports(t *testing.T) {
+	path := writeFile(t, "session.json", `
+{"timestamp":"2026-03-01T12:00:10Z","aircraft_count":1,"aircraft":[{"hex":"ABC123","callsign":"KLM1","lat":52.1,"lon":4.8,"altitude":35000}]}
+{"timestamp":"2026-03-01T12:00:00Z","aircraft_count":2,"aircraft":[{"hex":"abc123","lat":52.0,"lon":4.7},{"hex":"","callsign":"NOHEX"}]}
+`)
+	frames, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("got %d frames, want 2", len(frames))
+	}
+	if !frames[0].Time.Before(frames[1].Time) {
+		t.Errorf("frames not in time order: %v, %v", frames[0].Time, frames[1].Time)
+	}
+	if len(frames[0].Aircraft) != 1 {
+		t.Errorf("aircraft without a hex should be skipped, got %d", len(frames[0].Aircraft))
+	}
+	ac := frames[1].Aircraft[0]
+	if ac.Hex != "ABC123" || ac.Flight != "KLM1" || ac.AltBaro == nil || *ac.AltBaro != 35000 {
+		t.Errorf("aircraft = %+v", ac)
+	}
+}
+
+func TestLoad_CSVGroupsRowsByTimestamp(t *testing.T) {
+	path := writeFile(t, "session.csv", "\ufeffhex,callsign,lat,lon,altitude,squawk,military,timestamp\n"+
+		"abc123,'=KLM1,52.0,4.7,35000,7700,true,2026-03-01T12:00:00Z\n"+
+		"def456,,52.2,,,,false,2026-03-01T12:00:00Z\n"+
+		"abc123,KLM1,52.1,4.8,34000,7700,true,2026-03-01T12:00:05Z\n")
+	frames, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 2 || len(frames[0].Aircraft) != 2 || len(frames[1].Aircraft) != 1 {
+		t.Fatalf("frames = %+v", frames)
+	}
+	ac := frames[0].Aircraft[0]
+	if ac.Flight != "=KLM1" {
+		t.Errorf("formula guard not undone: %q", ac.Flight)
+	}
+	if !ac.Military || ac.Squawk != "7700" || ac.Lat == nil || *ac.Lat != 52.0 {
+		t.Errorf("aircraft = %+v", ac)
+	}
+	if other := frames[0].Aircraft[1]; other.Lon != nil || other.AltBaro != nil {
+		t.Errorf("empty cells should be missing values, got %+v", other)
+	}
+}
+
+func TestLoad_CSVDistanceUnits(t *testing.T) {
+	path := writeFile(t, "session.csv", "hex,distance_km,timestamp\n"+
+		"abc123,18.52,2026-03-01T12:00:00Z\n")
+	frames, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := frames[0].Aircraft[0].Distance; d == nil || *d < 9.999 || *d > 10.001 {
+		t.Errorf("distance = %v, want 10nm", d)
+	}
+}
+
+func TestLoad_MergesFilesWrittenTogether(t *testing.T) {
+	a := writeFile(t, "a.json", `{"timestamp":"2026-03-01T12:00:00Z","aircraft":[{"hex":"abc123","altitude":1000},{"hex":"def456"}]}`)
+	b := writeFile(t, "b.json", `{"timestamp":"2026-03-01T12:00:00Z","aircraft":[{"hex":"abc123","altitude":2000}]}`)
+	frames, err := Load(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 1 || len(frames[0].Aircraft) != 2 {
+		t.Fatalf("frames = %+v", frames)
+	}
+	if alt := frames[0].Aircraft[0].AltBaro; alt == nil || *alt != 2000 {
+		t.Errorf("later file should win, got altitude %v", alt)
+	}
+}
+
+func TestLoad_RadarExports(t *testing.T) {
+	aircraft := map[string]*radar.Target{
+		"abc123": {Hex: "abc123", Callsign: "KLM1", Lat: 52.1, HasLat: true, Lon: 4.8, HasLon: true},
+	}
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "export.csv")
+	jsonPath := filepath.Join(dir, "export.json")
+	if err := export.ExportAircraftToFile(aircraft, csvPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := export.ExportAircraftJSONToFile(aircraft, jsonPath); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{csvPath, jsonPath} {
+		frames, err := Load(path)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if len(frames) != 1 || len(frames[0].Aircraft) != 1 || frames[0].Aircraft[0].Flight != "KLM1" {
+			t.Errorf("%s: frames = %+v", path, frames)
+		}
+		if time.Since(frames[0].Time) > time.Minute {
+			t.Errorf("%s: frame time %v", path, frames[0].Time)
+		}
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	empty := writeFile(t, "empty.csv", "hex,timestamp\n")
+	if _, err := Load(empty); !errors.Is(err, ErrNoFrames) {
+		t.Errorf("empty export: err = %v, want ErrNoFrames", err)
+	}
+	noTime := writeFile(t, "notime.csv", "hex,callsign\nabc123,KLM1\n")
+	if _, err := Load(noTime); err == nil {
+		t.Error("expected an error for a CSV without a timestamp column")
+	}
+	badJSON := writeFile(t, "bad.json", `{"timestamp":"yesterday","aircraft":[]}`)
+	if _, err := Load(badJSON); err == nil {
+		t.Error("expected an error for a bad timestamp")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
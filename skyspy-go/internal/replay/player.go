@@ -0,0 +1,290 @@
+// Package replay provides playback of recorded frames as a feed
+package replay
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+// Player plays frames back as feed messages at their recorded pace, sped
+// up by a factor. The first frame, and the frame reached by a seek, is
+// sent as a snapshot; after that each frame is sent as new, update and
+// remove messages against the one before. It runs on its own clock,
+// which the radar takes as the time, so the radar sees the session as it
+// was recorded.
+type Player struct {
+	frames []Frame
+	speed  float64
+	wall   func() time.Time // the real clock; replaced in tests
+
+	aircraft chan codec.Message
+	acars    chan codec.Message
+	wake     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+
+	mu       sync.Mutex
+	started  bool
+	paused   bool
+	at       time.Time // replay time when last set
+	atWall   time.Time // real time at was set
+	next     int       // next frame to send
+	snapshot bool      // the frame before next goes out as a snapshot
+}
+
+// NewPlayer creates a player for frames, which must be in time order, at
+// speed times their recorded pace
+func NewPlayer(frames []Frame, speed float64) *Player {
+	if speed <= 0 {
+		speed = 1
+	}
+	p := &Player{
+		frames:   frames,
+		speed:    speed,
+		wall:     time.Now,
+		aircraft: make(chan codec.Message, 256),
+		acars:    make(chan codec.Message),
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	if len(frames) > 0 {
+		p.at = frames[0].Time
+	}
+	return p
+}
+
+// Start begins playback from the first frame
+func (p *Player) Start() {
+	p.mu.Lock()
+	if p.started {
+		p.mu.Unlock()
+		return
+	}
+	p.started = true
+	p.atWall = p.wall()
+	p.mu.Unlock()
+	go p.run()
+}
+
+// Stop ends playback
+func (p *Player) Stop() {
+	p.stopOnce.Do(func() { close(p.done) })
+}
+
+// Done is closed once playback is stopped
+func (p *Player) Done() <-chan struct{} {
+	return p.done
+}
+
+// IsConnected reports whether playback is running
+func (p *Player) IsConnected() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case <-p.done:
+		return false
+	default:
+		return p.started
+	}
+}
+
+// AircraftMessages returns the channel aircraft messages are played on
+func (p *Player) AircraftMessages() <-chan codec.Message {
+	return p.aircraft
+}
+
+// ACARSMessages returns a channel that stays empty; exports hold no ACARS
+func (p *Player) ACARSMessages() <-chan codec.Message {
+	return p.acars
+}
+
+// Now returns the replay time. It stands still while paused and stops at
+// the last frame.
+func (p *Player) Now() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.nowLocked()
+}
+
+func (p *Player) nowLocked() time.Time {
+	t := p.at
+	if p.started && !p.paused {
+		t = t.Add(time.Duration(float64(p.wall().Sub(p.atWall)) * p.speed))
+	}
+	if n := len(p.frames); n > 0 && t.After(p.frames[n-1].Time) {
+		t = p.frames[n-1].Time
+	}
+	return t
+}
+
+// Span returns the times of the first and last frames
+func (p *Player) Span() (first, last time.Time) {
+	if len(p.frames) == 0 {
+		return time.Time{}, time.Time{}
+	}
+	return p.frames[0].Time, p.frames[len(p.frames)-1].Time
+}
+
+// Speed returns the playback speed
+func (p *Player) Speed() float64 {
+	return p.speed
+}
+
+// Paused reports whether playback is paused
+func (p *Player) Paused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// SetPaused pauses or resumes playback
+func (p *Player) SetPaused(paused bool) {
+	p.mu.Lock()
+	p.at, p.atWall = p.nowLocked(), p.wall()
+	p.paused = paused
+	p.mu.Unlock()
+	p.poke()
+}
+
+// Seek moves playback by d, back for a negative d, within the recording,
+// and returns the replay time reached. The frame there is sent as a
+// snapshot, so aircraft not in it are dropped.
+func (p *Player) Seek(d time.Duration) time.Time {
+	p.mu.Lock()
+	first, last := p.Span()
+	t := p.nowLocked().Add(d)
+	if t.Before(first) {
+		t = first
+	}
+	if t.After(last) {
+		t = last
+	}
+	p.at, p.atWall = t, p.wall()
+	p.next = 0
+	for p.next < len(p.frames) && !p.frames[p.next].Time.After(t) {
+		p.next++
+	}
+	p.snapshot = p.next > 0
+	p.mu.Unlock()
+	p.poke()
+	return t
+}
+
+// poke wakes the playback loop to look at the clock again
+func (p *Player) poke() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run sends frames as they fall due, until stopped
+func (p *Player) run() {
+	for {
+		p.mu.Lock()
+		msgs := p.dueLocked()
+		wait, waiting := p.untilNextLocked()
+		p.mu.Unlock()
+
+		for _, msg := range msgs {
+			select {
+			case p.aircraft <- msg:
+			case <-p.done:
+				return
+			}
+		}
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if waiting {
+			timer = time.NewTimer(wait)
+			fire = timer.C
+		}
+		select {
+		case <-fire:
+		case <-p.wake:
+		case <-p.done:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+	}
+}
+
+// dueLocked returns the messages for the frames whose time has come
+func (p *Player) dueLocked() []codec.Message {
+	var msgs []codec.Message
+	if p.snapshot {
+		msgs = append(msgs, snapshotMessage(p.frames[p.next-1]))
+		p.snapshot = false
+	}
+	now := p.nowLocked()
+	for p.next < len(p.frames) && !p.frames[p.next].Time.After(now) {
+		if p.next == 0 {
+			msgs = append(msgs, snapshotMessage(p.frames[0]))
+		} else {
+			msgs = append(msgs, diffMessages(p.frames[p.next-1], p.frames[p.next])...)
+		}
+		p.next++
+	}
+	return msgs
+}
+
+// untilNextLocked returns how long until the next frame falls due, in
+// real time; waiting is false while paused or when none is left
+func (p *Player) untilNextLocked() (wait time.Duration, waiting bool) {
+	if p.paused || p.next >= len(p.frames) {
+		return 0, false
+	}
+	ahead := p.frames[p.next].Time.Sub(p.nowLocked())
+	return max(time.Duration(float64(ahead)/p.speed), 0), true
+}
+
+// snapshotMessage is a frame as a snapshot of every aircraft
+func snapshotMessage(f Frame) codec.Message {
+	aircraft := f.Aircraft
+	if aircraft == nil {
+		aircraft = []codec.Aircraft{}
+	}
+	return message(codec.AircraftSnapshot, aircraft)
+}
+
+// diffMessages are the messages taking the radar from one frame to the
+// next: new aircraft, updates to those still there, and removals
+func diffMessages(prev, cur Frame) []codec.Message {
+	before := make(map[string]bool, len(prev.Aircraft))
+	for _, ac := range prev.Aircraft {
+		before[ac.Hex] = true
+	}
+	var msgs []codec.Message
+	still := make(map[string]bool, len(cur.Aircraft))
+	for i := range cur.Aircraft {
+		ac := &cur.Aircraft[i]
+		still[ac.Hex] = true
+		if before[ac.Hex] {
+			msgs = append(msgs, message(codec.AircraftUpdate, ac))
+		} else {
+			msgs = append(msgs, message(codec.AircraftNew, ac))
+		}
+	}
+	for _, ac := range prev.Aircraft {
+		if !still[ac.Hex] {
+			msgs = append(msgs, message(codec.AircraftRemove, map[string]string{"hex": ac.Hex}))
+		}
+	}
+	return msgs
+}
+
+// message wraps data as a feed message of the given type
+func message(t codec.MessageType, data interface{}) codec.Message {
+	raw, _ := json.Marshal(data)
+	return codec.Message{Type: string(t), Data: raw}
+}
@@ -0,0 +1,182 @@
+package replay
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+)
+
+var start = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// testFrames has abc123 throughout, def456 joining at 10s and abc123
+// alone again at 20s
+func testFrames() []Frame {
+	return []Frame{
+		{Time: start, Aircraft: []codec.Aircraft{{Hex: "abc123"}}},
+		{Time: start.Add(10 * time.Second), Aircraft: []codec.Aircraft{{Hex: "abc123"}, {Hex: "def456"}}},
+		{Time: start.Add(20 * time.Second), Aircraft: []codec.Aircraft{{Hex: "abc123"}}},
+	}
+}
+
+// fakeWall is a real-time clock the test moves by hand
+type fakeWall struct{ t time.Time }
+
+func (w *fakeWall) now() time.Time          { return w.t }
+func (w *fakeWall) advance(d time.Duration) { w.t = w.t.Add(d) }
+
+// newTestPlayer returns a started player on a fake wall clock whose loop
+// is not running, so the test drives it with due
+func newTestPlayer(speed float64) (*Player, *fakeWall) {
+	wall := &fakeWall{t: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+	p := NewPlayer(testFrames(), speed)
+	p.wall = wall.now
+	p.started = true
+	p.atWall = wall.now()
+	return p, wall
+}
+
+func due(p *Player) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var got []string
+	for _, msg := range p.dueLocked() {
+		if msg.Type == string(codec.AircraftSnapshot) {
+			var list []codec.Aircraft
+			_ = json.Unmarshal(msg.Data, &list)
+			got = append(got, msg.Type+":"+string(rune('0'+len(list))))
+			continue
+		}
+		var ac struct{ Hex string }
+		_ = json.Unmarshal(msg.Data, &ac)
+		got = append(got, msg.Type+":"+ac.Hex)
+	}
+	return got
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPlayer_SendsFramesAsTheyFallDue(t *testing.T) {
+	p, wall := newTestPlayer(1)
+
+	if got := due(p); !equal(got, []string{"aircraft:snapshot:1"}) {
+		t.Errorf("start: %v", got)
+	}
+	wall.advance(5 * time.Second)
+	if got := due(p); len(got) != 0 {
+		t.Errorf("at 5s: %v", got)
+	}
+	wall.advance(5 * time.Second)
+	if got := due(p); !equal(got, []string{"aircraft:update:abc123", "aircraft:new:def456"}) {
+		t.Errorf("at 10s: %v", got)
+	}
+	wall.advance(10 * time.Second)
+	if got := due(p); !equal(got, []string{"aircraft:update:abc123", "aircraft:remove:def456"}) {
+		t.Errorf("at 20s: %v", got)
+	}
+	wall.advance(time.Hour)
+	if got := p.Now(); !got.Equal(start.Add(20 * time.Second)) {
+		t.Errorf("Now past the end = %v, want the last frame", got)
+	}
+}
+
+func TestPlayer_Speed(t *testing.T) {
+	p, wall := newTestPlayer(4)
+	due(p)
+	wall.advance(2500 * time.Millisecond)
+	if got := p.Now(); !got.Equal(start.Add(10 * time.Second)) {
+		t.Errorf("Now = %v, want 10s in", got)
+	}
+	if got := due(p); len(got) != 2 {
+		t.Errorf("frame at 10s should be due: %v", got)
+	}
+	p.mu.Lock()
+	wait, waiting := p.untilNextLocked()
+	p.mu.Unlock()
+	if !waiting || wait != 2500*time.Millisecond {
+		t.Errorf("untilNext = %v, %v; want 2.5s", wait, waiting)
+	}
+}
+
+func TestPlayer_PauseStopsTheClock(t *testing.T) {
+	p, wall := newTestPlayer(1)
+	due(p)
+	wall.advance(3 * time.Second)
+	p.SetPaused(true)
+	wall.advance(time.Minute)
+	if got := p.Now(); !got.Equal(start.Add(3 * time.Second)) {
+		t.Errorf("paused Now = %v, want 3s in", got)
+	}
+	if got := due(p); len(got) != 0 {
+		t.Errorf("frames sent while paused: %v", got)
+	}
+	p.mu.Lock()
+	_, waiting := p.untilNextLocked()
+	p.mu.Unlock()
+	if waiting {
+		t.Error("a paused player should not wait on a frame")
+	}
+	p.SetPaused(false)
+	wall.advance(7 * time.Second)
+	if got := due(p); len(got) != 2 {
+		t.Errorf("frame at 10s after resuming: %v", got)
+	}
+}
+
+func TestPlayer_SeekSendsASnapshot(t *testing.T) {
+	p, _ := newTestPlayer(1)
+	due(p)
+
+	if at := p.Seek(12 * time.Second); !at.Equal(start.Add(12 * time.Second)) {
+		t.Errorf("Seek forward reached %v", at)
+	}
+	if got := due(p); !equal(got, []string{"aircraft:snapshot:2"}) {
+		t.Errorf("after seeking forward: %v", got)
+	}
+	if at := p.Seek(-time.Minute); !at.Equal(start) {
+		t.Errorf("Seek back should stop at the first frame, reached %v", at)
+	}
+	if got := due(p); !equal(got, []string{"aircraft:snapshot:1"}) {
+		t.Errorf("after seeking back: %v", got)
+	}
+	if at := p.Seek(time.Hour); !at.Equal(start.Add(20 * time.Second)) {
+		t.Errorf("Seek forward should stop at the last frame, reached %v", at)
+	}
+}
+
+func TestPlayer_Run(t *testing.T) {
+	p := NewPlayer(testFrames(), 1000)
+	p.Start()
+	defer p.Stop()
+	if !p.IsConnected() {
+		t.Error("a started player should report connected")
+	}
+
+	want := []codec.MessageType{codec.AircraftSnapshot, codec.AircraftUpdate, codec.AircraftNew, codec.AircraftUpdate, codec.AircraftRemove}
+	for i, typ := range want {
+		select {
+		case msg := <-p.AircraftMessages():
+			if msg.Type != string(typ) {
+				t.Errorf("message %d = %s, want %s", i, msg.Type, typ)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for message %d", i)
+		}
+	}
+
+	p.Stop()
+	if p.IsConnected() {
+		t.Error("a stopped player should not report connected")
+	}
+}
@@ -12,14 +12,17 @@ import (
 
 // Filter represents search/filter criteria for aircraft
 type Filter struct {
-	Query        string
-	MilitaryOnly bool
-	MinAltitude  int
-	MaxAltitude  int
-	MinDistance  float64
-	MaxDistance  float64
-	SquawkCodes  []string
-	textQuery    string // Plain text portion of query for callsign/hex matching
+	Query         string
+	MilitaryOnly  bool
+	WatchlistOnly bool // aircraft on the watch list only
+	MinAltitude   int
+	MaxAltitude   int
+	MinDistance   float64
+	MaxDistance   float64
+	SquawkCodes   []string
+	Categories    []string // emitter category codes or classes, e.g. A7 or rotorcraft
+	Types         []string // aircraft type classes or designators, e.g. heavy or A320
+	textQuery     string   // Plain text portion of query for callsign/hex matching
 }
 
 // EmergencySquawks contains the standard emergency squawk codes
@@ -64,7 +67,10 @@ func PresetLowAltitude() *Filter {
 //   - "dist:<50": maximum distance filter
 //   - "dist:>10": minimum distance filter
 //   - "dist:10-50": distance range
+//   - "category:rotorcraft" or "cat:A7,B6": emitter category class or code
+//   - "type:heavy" or "type:A320,B738": aircraft type class or designator
 //   - "mil": military only
+//   - "watched:": aircraft on the watch list only
 func ParseQuery(query string) *Filter {
 	f := &Filter{
 		Query: query,
@@ -87,6 +93,12 @@ func ParseQuery(query string) *Filter {
 			continue
 		}
 
+		// Handle watch list filter: watched:
+		if strings.HasPrefix(tokenLower, "watched:") {
+			f.WatchlistOnly = true
+			continue
+		}
+
 		// Handle squawk filter: sq:7700 or sq:7500,7600,7700
 		if strings.HasPrefix(tokenLower, "sq:") {
 			squawkPart := token[3:]
@@ -100,6 +112,28 @@ func ParseQuery(query string) *Filter {
 			continue
 		}
 
+		// Handle category filter: category:rotorcraft or cat:A7,B6
+		if prefix, ok := categoryPrefix(tokenLower); ok {
+			for _, c := range strings.Split(token[len(prefix):], ",") {
+				c = strings.TrimSpace(c)
+				if c != "" {
+					f.Categories = append(f.Categories, c)
+				}
+			}
+			continue
+		}
+
+		// Handle aircraft type filter: type:heavy or type:A320,B738
+		if strings.HasPrefix(tokenLower, "type:") {
+			for _, t := range strings.Split(token[5:], ",") {
+				t = strings.TrimSpace(t)
+				if t != "" {
+					f.Types = append(f.Types, t)
+				}
+			}
+			continue
+		}
+
 		// Handle altitude filter: alt:>10000, alt:<10000, alt:5000-10000
 		if strings.HasPrefix(tokenLower, "alt:") {
 			altPart := token[4:]
@@ -122,6 +156,23 @@ func ParseQuery(query string) *Filter {
 	return f
 }
 
+// categoryPrefix returns the category filter prefix a token starts with
+func categoryPrefix(token string) (string, bool) {
+	for _, prefix := range []string{"category:", "cat:"} {
+		if strings.HasPrefix(token, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// matchesType reports whether the aircraft's type class ("heavy") or type
+// designator ("A320") is term, ignoring case
+func matchesType(aircraft *radar.Target, term string) bool {
+	return aircraft.TypeClass != "" && strings.EqualFold(aircraft.TypeClass, term) ||
+		aircraft.ACType != "" && strings.EqualFold(strings.TrimSpace(aircraft.ACType), term)
+}
+
 // parseAltitudeFilter parses altitude filter syntax
 func parseAltitudeFilter(s string, f *Filter) {
 	s = strings.TrimSpace(s)
@@ -219,6 +270,11 @@ func MatchesAircraft(aircraft *radar.Target, filter *Filter) bool {
 		return false
 	}
 
+	// Watch list filter
+	if filter.WatchlistOnly && !aircraft.OnWatchlist {
+		return false
+	}
+
 	// Altitude filters
 	if filter.MinAltitude > 0 {
 		if !aircraft.HasAlt || aircraft.Altitude < filter.MinAltitude {
@@ -257,6 +313,34 @@ func MatchesAircraft(aircraft *radar.Target, filter *Filter) bool {
 		}
 	}
 
+	// Emitter category filter
+	if len(filter.Categories) > 0 {
+		found := false
+		for _, c := range filter.Categories {
+			if radar.MatchesCategory(aircraft.Category, c) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	// Aircraft type filter
+	if len(filter.Types) > 0 {
+		found := false
+		for _, t := range filter.Types {
+			if matchesType(aircraft, t) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
 	// Text query filter (callsign or hex)
 	if filter.textQuery != "" {
 		callsignUpper := strings.ToUpper(strings.TrimSpace(aircraft.Callsign))
@@ -277,11 +361,14 @@ func (f *Filter) IsActive() bool {
 		return false
 	}
 	return f.MilitaryOnly ||
+		f.WatchlistOnly ||
 		f.MinAltitude > 0 ||
 		f.MaxAltitude > 0 ||
 		f.MinDistance > 0 ||
 		f.MaxDistance > 0 ||
 		len(f.SquawkCodes) > 0 ||
+		len(f.Categories) > 0 ||
+		len(f.Types) > 0 ||
 		f.textQuery != ""
 }
 
@@ -299,9 +386,18 @@ func (f *Filter) Description() string {
 	if f.MilitaryOnly {
 		parts = append(parts, "MIL")
 	}
+	if f.WatchlistOnly {
+		parts = append(parts, "WATCHED")
+	}
 	if len(f.SquawkCodes) > 0 {
 		parts = append(parts, "SQ:"+strings.Join(f.SquawkCodes, ","))
 	}
+	if len(f.Categories) > 0 {
+		parts = append(parts, "CAT:"+strings.ToUpper(strings.Join(f.Categories, ",")))
+	}
+	if len(f.Types) > 0 {
+		parts = append(parts, "TYPE:"+strings.ToUpper(strings.Join(f.Types, ",")))
+	}
 	if f.MinAltitude > 0 && f.MaxAltitude > 0 {
 		parts = append(parts, "ALT:"+strconv.Itoa(f.MinAltitude)+"-"+strconv.Itoa(f.MaxAltitude))
 	} else if f.MinAltitude > 0 {
@@ -344,6 +344,81 @@ func TestMatchesAircraft_Squawk(t *testing.T) {
 	}
 }
 
+func TestMatchesAircraft_Category(t *testing.T) {
+	heli := &radar.Target{Hex: "ABC123", Category: "A7", HasLat: true, HasLon: true}
+	unknown := &radar.Target{Hex: "DEF456", HasLat: true, HasLon: true}
+
+	tests := []struct {
+		query   string
+		heli    bool
+		unknown bool
+	}{
+		{"category:rotorcraft", true, false},
+		{"cat:A7", true, false},
+		{"CATEGORY:Rotorcraft", true, false},
+		{"cat:uav,rotorcraft", true, false},
+		{"category:heavy", false, false},
+		{"category:", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filter := ParseQuery(tt.query)
+			if got := MatchesAircraft(heli, filter); got != tt.heli {
+				t.Errorf("query %q on A7: expected %v, got %v", tt.query, tt.heli, got)
+			}
+			if got := MatchesAircraft(unknown, filter); got != tt.unknown {
+				t.Errorf("query %q without category: expected %v, got %v", tt.query, tt.unknown, got)
+			}
+		})
+	}
+
+	if desc := ParseQuery("cat:rotorcraft").Description(); desc != "CAT:ROTORCRAFT" {
+		t.Errorf("description = %q", desc)
+	}
+}
+
+func TestMatchesAircraft_Type(t *testing.T) {
+	jumbo := &radar.Target{Hex: "ABC123", ACType: "B744", TypeName: "Boeing 747-400", TypeClass: "heavy"}
+	unknown := &radar.Target{Hex: "DEF456", ACType: "ZZZZ"}
+
+	tests := []struct {
+		query   string
+		jumbo   bool
+		unknown bool
+	}{
+		{"type:heavy", true, false},
+		{"TYPE:Heavy", true, false},
+		{"type:light,heavy", true, false},
+		{"type:b744", true, false},
+		{"type:zzzz", false, true},
+		{"type:rotorcraft", false, false},
+		{"type:", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filter := ParseQuery(tt.query)
+			if got := MatchesAircraft(jumbo, filter); got != tt.jumbo {
+				t.Errorf("query %q on B744: expected %v, got %v", tt.query, tt.jumbo, got)
+			}
+			if got := MatchesAircraft(unknown, filter); got != tt.unknown {
+				t.Errorf("query %q on an unknown type: expected %v, got %v", tt.query, tt.unknown, got)
+			}
+		})
+	}
+
+	if desc := ParseQuery("type:heavy").Description(); desc != "TYPE:HEAVY" {
+		t.Errorf("description = %q", desc)
+	}
+	if !ParseQuery("type:heavy mil").Refines(ParseQuery("type:heavy")) {
+		t.Error("adding a term should refine a type filter")
+	}
+	if ParseQuery("mil").Refines(ParseQuery("type:heavy")) {
+		t.Error("dropping the type filter should not refine it")
+	}
+}
+
 func TestMatchesAircraft_Military(t *testing.T) {
 	militaryAircraft := &radar.Target{
 		Hex:      "MIL001",
@@ -370,6 +445,26 @@ func TestMatchesAircraft_Military(t *testing.T) {
 	}
 }
 
+func TestMatchesAircraft_Watchlist(t *testing.T) {
+	watched := &radar.Target{Hex: "AE1234", Callsign: "RCH123", OnWatchlist: true}
+	other := &radar.Target{Hex: "4CA123", Callsign: "RCH456"}
+
+	filter := ParseQuery("watched: rch")
+	if !filter.WatchlistOnly || !filter.IsActive() {
+		t.Fatal("watched: should filter to the watch list")
+	}
+	if filter.Description() != `"RCH" WATCHED` {
+		t.Errorf("description = %q", filter.Description())
+	}
+
+	if !MatchesAircraft(watched, filter) {
+		t.Error("an aircraft on the watch list should match")
+	}
+	if MatchesAircraft(other, filter) {
+		t.Error("an aircraft off the watch list should not match")
+	}
+}
+
 func TestMatchesAircraft_Altitude(t *testing.T) {
 	aircraft := &radar.Target{
 		Hex:      "ABC123",
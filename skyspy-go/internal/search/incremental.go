@@ -0,0 +1,130 @@
+// Package search provides search and filter functionality for aircraft
+package search
+
+import (
+	"strings"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// Refines reports whether every aircraft matching f also matches prev, so
+// f's results can be found by filtering prev's instead of every aircraft.
+// This holds when f keeps all of prev's criteria at least as tight, which
+// is the usual case while a query is being typed.
+func (f *Filter) Refines(prev *Filter) bool {
+	if f == nil || prev == nil {
+		return false
+	}
+	if prev.MilitaryOnly && !f.MilitaryOnly {
+		return false
+	}
+	if prev.WatchlistOnly && !f.WatchlistOnly {
+		return false
+	}
+	if prev.MinAltitude > 0 && f.MinAltitude < prev.MinAltitude {
+		return false
+	}
+	if prev.MaxAltitude > 0 && (f.MaxAltitude <= 0 || f.MaxAltitude > prev.MaxAltitude) {
+		return false
+	}
+	if prev.MinDistance > 0 && f.MinDistance < prev.MinDistance {
+		return false
+	}
+	if prev.MaxDistance > 0 && (f.MaxDistance <= 0 || f.MaxDistance > prev.MaxDistance) {
+		return false
+	}
+	if len(prev.SquawkCodes) > 0 {
+		if len(f.SquawkCodes) == 0 {
+			return false
+		}
+		for _, sq := range f.SquawkCodes {
+			if !containsFold(prev.SquawkCodes, sq) {
+				return false
+			}
+		}
+	}
+	if len(prev.Categories) > 0 {
+		if len(f.Categories) == 0 {
+			return false
+		}
+		for _, c := range f.Categories {
+			if !containsFold(prev.Categories, c) {
+				return false
+			}
+		}
+	}
+	if len(prev.Types) > 0 {
+		if len(f.Types) == 0 {
+			return false
+		}
+		for _, t := range f.Types {
+			if !containsFold(prev.Types, t) {
+				return false
+			}
+		}
+	}
+	return strings.Contains(f.textQuery, prev.textQuery)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Incremental filters aircraft for a query that is edited one keystroke at
+// a time. When the new query refines the last one only the last results
+// are checked again; anything else, including a change in the number of
+// aircraft, falls back to a full scan. Callers that swap aircraft without
+// changing the count, or change what a tracked aircraft matches on (see
+// SearchableChanged), call Reset so the change is found.
+type Incremental struct {
+	filter   *Filter
+	results  []string
+	universe int // aircraft count at the last full scan
+}
+
+// Update returns the hexes of the aircraft matching query
+func (s *Incremental) Update(aircraft map[string]*radar.Target, query string) []string {
+	filter := ParseQuery(query)
+	if s.filter == nil || len(aircraft) != s.universe || !filter.Refines(s.filter) {
+		s.filter = filter
+		s.results = FilterAircraft(aircraft, filter)
+		s.universe = len(aircraft)
+		return s.results
+	}
+
+	results := make([]string, 0, len(s.results))
+	for _, hex := range s.results {
+		if ac, ok := aircraft[hex]; ok && MatchesAircraft(ac, filter) {
+			results = append(results, hex)
+		}
+	}
+	s.filter = filter
+	s.results = results
+	return results
+}
+
+// SearchableChanged reports whether next differs from prev in anything a
+// query can match on, so results narrowed from earlier ones may be stale
+func SearchableChanged(prev, next *radar.Target) bool {
+	return prev.Hex != next.Hex ||
+		prev.Callsign != next.Callsign ||
+		prev.Squawk != next.Squawk ||
+		prev.Military != next.Military ||
+		prev.OnWatchlist != next.OnWatchlist ||
+		prev.HasAlt != next.HasAlt || prev.Altitude != next.Altitude ||
+		prev.Distance != next.Distance ||
+		prev.Category != next.Category ||
+		prev.ACType != next.ACType || prev.TypeClass != next.TypeClass
+}
+
+// Reset forgets the last results so the next Update scans every aircraft
+func (s *Incremental) Reset() {
+	s.filter = nil
+	s.results = nil
+	s.universe = 0
+}
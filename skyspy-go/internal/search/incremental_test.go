@@ -0,0 +1,190 @@
+package search
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// syntheticAircraft builds n aircraft with varied callsigns, altitudes,
+// distances, squawks and military flags from a fixed seed
+func syntheticAircraft(n int, seed int64) map[string]*radar.Target {
+	rng := rand.New(rand.NewSource(seed))
+	airlines := []string{"UAL", "DAL", "AAL", "BAW", "KLM", "RCH", "SWA", "DLH"}
+	squawks := []string{"1200", "7000", "2341", "7700", "7600", "4521"}
+
+	aircraft := make(map[string]*radar.Target, n)
+	for i := 0; i < n; i++ {
+		hex := fmt.Sprintf("%06X", rng.Intn(0xFFFFFF))
+		aircraft[hex] = &radar.Target{
+			Hex:      hex,
+			Callsign: fmt.Sprintf("%s%d", airlines[rng.Intn(len(airlines))], rng.Intn(10000)),
+			Altitude: rng.Intn(45000),
+			HasAlt:   rng.Intn(10) > 0,
+			Distance: rng.Float64() * 250,
+			Squawk:   squawks[rng.Intn(len(squawks))],
+			Military: rng.Intn(20) == 0,
+		}
+	}
+	return aircraft
+}
+
+func sorted(hexes []string) []string {
+	out := append([]string{}, hexes...)
+	sort.Strings(out)
+	return out
+}
+
+func TestFilter_Refines(t *testing.T) {
+	tests := []struct {
+		prev, next string
+		want       bool
+	}{
+		{"UA", "UAL", true},
+		{"UAL", "UAL1", true},
+		{"UAL1", "UAL", false},
+		{"alt:>1", "alt:>10", true},
+		{"alt:<1", "alt:<10", false},
+		{"dist:<5", "dist:<50", false},
+		{"dist:>5", "dist:>50", true},
+		{"sq:7", "sq:77", false},
+		{"sq:7700,7600", "sq:7700", true},
+		{"cat:rotor", "cat:rotorcraft", false},
+		{"cat:A7,B6", "cat:b6", true},
+		{"cat:A7", "UAL", false},
+		{"mi", "mil", false},
+		{"mil", "mil U", true},
+		{"UAL", "mil UAL", true},
+		{"watched:", "watched: UAL", true},
+		{"watched: UAL", "UAL", false},
+		{"", "anything", true},
+	}
+	for _, tt := range tests {
+		got := ParseQuery(tt.next).Refines(ParseQuery(tt.prev))
+		if got != tt.want {
+			t.Errorf("%q refines %q = %v, want %v", tt.next, tt.prev, got, tt.want)
+		}
+	}
+}
+
+// TestIncremental_MatchesFullScan types random queries a character at a
+// time, with random deletions, and checks every result set against a
+// filter from scratch
+func TestIncremental_MatchesFullScan(t *testing.T) {
+	aircraft := syntheticAircraft(1000, 1)
+	rng := rand.New(rand.NewSource(2))
+	pieces := []string{"U", "A", "L", "1", "7", " ", "mil", "sq:7", "sq:77", "00", ",76", "alt:", ">", "<", "1", "0", "-", "dist:", "5", "D", "K"}
+
+	for round := 0; round < 100; round++ {
+		var inc Incremental
+		query := ""
+		for step := 0; step < 30; step++ {
+			if query != "" && rng.Intn(5) == 0 {
+				query = query[:len(query)-1]
+			} else {
+				for _, ch := range pieces[rng.Intn(len(pieces))] {
+					query += string(ch)
+					got := sorted(inc.Update(aircraft, query))
+					want := sorted(FilterAircraft(aircraft, ParseQuery(query)))
+					if strings.Join(got, ",") != strings.Join(want, ",") {
+						t.Fatalf("query %q: incremental found %d aircraft, full scan %d", query, len(got), len(want))
+					}
+				}
+				continue
+			}
+			got := sorted(inc.Update(aircraft, query))
+			want := sorted(FilterAircraft(aircraft, ParseQuery(query)))
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Fatalf("query %q after delete: incremental found %d aircraft, full scan %d", query, len(got), len(want))
+			}
+		}
+	}
+}
+
+func TestIncremental_RescansWhenAircraftChange(t *testing.T) {
+	aircraft := map[string]*radar.Target{
+		"AAA111": {Hex: "AAA111", Callsign: "UAL1"},
+	}
+	var inc Incremental
+	if got := inc.Update(aircraft, "UAL"); len(got) != 1 {
+		t.Fatalf("expected 1 result, got %v", got)
+	}
+
+	aircraft["BBB222"] = &radar.Target{Hex: "BBB222", Callsign: "UAL12"}
+	if got := inc.Update(aircraft, "UAL1"); len(got) != 2 {
+		t.Errorf("expected a new aircraft to be found, got %v", got)
+	}
+
+	delete(aircraft, "AAA111")
+	if got := inc.Update(aircraft, "UAL12"); len(got) != 1 || got[0] != "BBB222" {
+		t.Errorf("expected the removed aircraft to drop out, got %v", got)
+	}
+
+	// Swapping one aircraft for another keeps the count, so the caller
+	// resets to pick up the newcomer
+	delete(aircraft, "BBB222")
+	aircraft["CCC333"] = &radar.Target{Hex: "CCC333", Callsign: "UAL123"}
+	inc.Reset()
+	if got := inc.Update(aircraft, "UAL123"); len(got) != 1 || got[0] != "CCC333" {
+		t.Errorf("expected a full scan after Reset, got %v", got)
+	}
+}
+
+func TestIncremental_ResetOnSearchableChange(t *testing.T) {
+	aircraft := map[string]*radar.Target{
+		"AAA111": {Hex: "AAA111", Callsign: "UAL1"},
+		"BBB222": {Hex: "BBB222", Callsign: "DAL1"},
+	}
+	var inc Incremental
+	inc.Update(aircraft, "AL1")
+
+	// A tracked aircraft changes callsign; narrowing alone would miss it
+	prev := *aircraft["BBB222"]
+	aircraft["BBB222"] = &radar.Target{Hex: "BBB222", Callsign: "UAL12"}
+	if !SearchableChanged(&prev, aircraft["BBB222"]) {
+		t.Fatal("a callsign change should count as searchable")
+	}
+	inc.Reset()
+	if got := sorted(inc.Update(aircraft, "UAL1")); strings.Join(got, ",") != "AAA111,BBB222" {
+		t.Errorf("expected both aircraft after Reset, got %v", got)
+	}
+
+	same := *aircraft["BBB222"]
+	same.RSSI, same.HasRSSI = -12, true
+	if SearchableChanged(aircraft["BBB222"], &same) {
+		t.Error("signal strength isn't searchable")
+	}
+}
+
+// BenchmarkSearch_Keystroke measures typing "UAL12" into the search box
+// with 5,000 aircraft, rescanning everything on each keystroke
+func BenchmarkSearch_KeystrokeFullScan(b *testing.B) {
+	aircraft := syntheticAircraft(5000, 1)
+	queries := []string{"U", "UA", "UAL", "UAL1", "UAL12"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, q := range queries {
+			FilterAircraft(aircraft, ParseQuery(q))
+		}
+	}
+}
+
+// BenchmarkSearch_KeystrokeIncremental measures the same typing with
+// incremental updates
+func BenchmarkSearch_KeystrokeIncremental(b *testing.B) {
+	aircraft := syntheticAircraft(5000, 1)
+	queries := []string{"U", "UA", "UAL", "UAL1", "UAL12"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var inc Incremental
+		for _, q := range queries {
+			inc.Update(aircraft, q)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package sim generates synthetic air traffic around a receiver, for
+// running the radar without a server
+package sim
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/geo"
+)
+
+// militaryShare is the fraction of generated aircraft flagged military
+const militaryShare = 0.05
+
+var (
+	airlinePrefixes = []string{"AAL", "UAL", "DAL", "SWA", "BAW", "DLH", "AFR", "KLM", "ACA", "QFA", "UAE", "FDX"}
+	airlineTypes    = []string{"A320", "A321", "A333", "A359", "B738", "B739", "B772", "B789", "E175", "CRJ9", "DH8D"}
+	militaryNames   = []string{"RCH", "DUKE", "COBRA", "VIPER", "TITAN", "REACH"}
+	militaryTypes   = []string{"C17", "C130", "KC135", "F16", "E3", "H60"}
+)
+
+// Traffic is a set of aircraft flying straight lines within a range of the
+// receiver. An aircraft that reaches the edge turns back toward the
+// receiver, so the number on the scope stays constant.
+type Traffic struct {
+	lat, lon float64
+	rangeNM  float64
+	rng      *rand.Rand
+	flights  []flight
+}
+
+// flight is one simulated aircraft
+type flight struct {
+	hex      string
+	callsign string
+	acType   string
+	military bool
+	lat, lon float64
+	alt      float64 // feet
+	gs       float64 // knots
+	track    float64 // degrees
+	vr       float64 // feet per minute
+}
+
+// NewTraffic places count aircraft at random within rangeNM of the
+// receiver. The same seed gives the same traffic.
+func NewTraffic(count int, lat, lon, rangeNM float64, seed int64) *Traffic {
+	t := &Traffic{
+		lat:     lat,
+		lon:     lon,
+		rangeNM: rangeNM,
+		rng:     rand.New(rand.NewSource(seed)),
+		flights: make([]flight, count),
+	}
+	for i := range t.flights {
+		f := &t.flights[i]
+		f.hex = fmt.Sprintf("%06X", 0xA00000+i)
+		f.military = t.rng.Float64() < militaryShare
+		if f.military {
+			f.callsign = fmt.Sprintf("%s%02d", militaryNames[t.rng.Intn(len(militaryNames))], t.rng.Intn(100))
+			f.acType = militaryTypes[t.rng.Intn(len(militaryTypes))]
+		} else {
+			f.callsign = fmt.Sprintf("%s%d", airlinePrefixes[t.rng.Intn(len(airlinePrefixes))], 100+t.rng.Intn(9900))
+			f.acType = airlineTypes[t.rng.Intn(len(airlineTypes))]
+		}
+		// sqrt spreads aircraft evenly over the scope's area
+		dist := rangeNM * math.Sqrt(t.rng.Float64())
+		f.lat, f.lon = geo.DestinationPoint(lat, lon, t.rng.Float64()*360, dist)
+		f.alt = float64(1000 + t.rng.Intn(40000))
+		f.gs = 120 + t.rng.Float64()*380
+		f.track = t.rng.Float64() * 360
+		f.vr = (t.rng.Float64() - 0.5) * 3000
+	}
+	return t
+}
+
+// Len returns the number of aircraft
+func (t *Traffic) Len() int {
+	return len(t.flights)
+}
+
+// Step moves every aircraft on by d
+func (t *Traffic) Step(d time.Duration) {
+	hours := d.Hours()
+	for i := range t.flights {
+		f := &t.flights[i]
+		f.lat, f.lon = geo.DestinationPoint(f.lat, f.lon, f.track, f.gs*hours)
+		f.alt = math.Max(500, math.Min(45000, f.alt+f.vr*d.Minutes()))
+		if geo.HaversineDistance(t.lat, t.lon, f.lat, f.lon) > t.rangeNM {
+			inbound := geo.BearingBetween(f.lat, f.lon, t.lat, t.lon)
+			f.track = math.Mod(inbound+(t.rng.Float64()-0.5)*60+360, 360)
+		}
+	}
+}
+
+// Aircraft returns the i'th aircraft as the feed reports it
+func (t *Traffic) Aircraft(i int) codec.Aircraft {
+	f := t.flights[i]
+	lat, lon := f.lat, f.lon
+	alt := int(f.alt)
+	gs, track, vr := f.gs, f.track, f.vr
+	dist := geo.HaversineDistance(t.lat, t.lon, f.lat, f.lon)
+	bearing := geo.BearingBetween(t.lat, t.lon, f.lat, f.lon)
+	rssi := -3 - dist/t.rangeNM*30
+	return codec.Aircraft{
+		Hex:      f.hex,
+		Flight:   f.callsign,
+		Lat:      &lat,
+		Lon:      &lon,
+		AltBaro:  &alt,
+		Alt:      &alt,
+		GS:       &gs,
+		Track:    &track,
+		BaroRate: &vr,
+		Squawk:   "1200",
+		RSSI:     &rssi,
+		Type:     f.acType,
+		Military: f.military,
+		Distance: &dist,
+		Bearing:  &bearing,
+	}
+}
+
+// Snapshot returns an aircraft:snapshot message holding every aircraft
+func (t *Traffic) Snapshot() codec.Message {
+	aircraft := make([]codec.Aircraft, len(t.flights))
+	for i := range t.flights {
+		aircraft[i] = t.Aircraft(i)
+	}
+	return message(codec.AircraftSnapshot, aircraft)
+}
+
+// Update returns an aircraft:update message for the i'th aircraft
+func (t *Traffic) Update(i int) codec.Message {
+	return message(codec.AircraftUpdate, t.Aircraft(i))
+}
+
+// message encodes v the way the server sends it, so readers pay the same
+// decoding cost as for live traffic
+func message(msgType codec.MessageType, v interface{}) codec.Message {
+	// Aircraft hold only numbers and strings, which always encode
+	data, _ := json.Marshal(v)
+	return codec.Message{Type: string(msgType), Data: data}
+}
@@ -0,0 +1,93 @@
+package sim
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/geo"
+)
+
+func TestNewTraffic_WithinRange(t *testing.T) {
+	traffic := NewTraffic(50, 51.5, -0.1, 100, 1)
+	if traffic.Len() != 50 {
+		t.Fatalf("expected 50 aircraft, got %d", traffic.Len())
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < traffic.Len(); i++ {
+		ac := traffic.Aircraft(i)
+		if seen[ac.Hex] {
+			t.Errorf("duplicate hex %s", ac.Hex)
+		}
+		seen[ac.Hex] = true
+		if d := geo.HaversineDistance(51.5, -0.1, *ac.Lat, *ac.Lon); d > 100.01 {
+			t.Errorf("%s placed %.1fnm out, beyond the 100nm range", ac.Hex, d)
+		}
+		if ac.Flight == "" || ac.Type == "" || ac.Alt == nil || ac.GS == nil || ac.Track == nil {
+			t.Errorf("%s is missing fields: %+v", ac.Hex, ac)
+		}
+	}
+}
+
+func TestNewTraffic_SeedRepeats(t *testing.T) {
+	a := NewTraffic(10, 40, -74, 50, 7)
+	b := NewTraffic(10, 40, -74, 50, 7)
+	for i := 0; i < 10; i++ {
+		if !reflect.DeepEqual(a.Aircraft(i), b.Aircraft(i)) {
+			t.Fatalf("aircraft %d differs between runs with the same seed", i)
+		}
+	}
+}
+
+func TestStep_StaysNearReceiver(t *testing.T) {
+	traffic := NewTraffic(20, 40, -74, 25, 3)
+	before := *traffic.Aircraft(0).Lat
+
+	// Two simulated hours is long enough for every aircraft to reach the
+	// edge several times
+	for i := 0; i < 7200; i++ {
+		traffic.Step(time.Second)
+	}
+
+	if *traffic.Aircraft(0).Lat == before {
+		t.Error("aircraft should move")
+	}
+	for i := 0; i < traffic.Len(); i++ {
+		ac := traffic.Aircraft(i)
+		// An aircraft turns once it crosses the edge, so it may overshoot
+		// by a step's travel
+		if *ac.Distance > 26 {
+			t.Errorf("%s drifted %.1fnm out of a 25nm range", ac.Hex, *ac.Distance)
+		}
+	}
+}
+
+func TestMessages_Decode(t *testing.T) {
+	traffic := NewTraffic(5, 40, -74, 50, 1)
+
+	snap := traffic.Snapshot()
+	if snap.Type != string(codec.AircraftSnapshot) {
+		t.Errorf("expected snapshot type, got %s", snap.Type)
+	}
+	aircraft, err := codec.ParseSnapshot(snap.Data)
+	if err != nil {
+		t.Fatalf("ParseSnapshot: %v", err)
+	}
+	if len(aircraft) != 5 {
+		t.Errorf("expected 5 aircraft in the snapshot, got %d", len(aircraft))
+	}
+
+	upd := traffic.Update(2)
+	if upd.Type != string(codec.AircraftUpdate) {
+		t.Errorf("expected update type, got %s", upd.Type)
+	}
+	ac, err := codec.ParseAircraft(upd.Data)
+	if err != nil {
+		t.Fatalf("ParseAircraft: %v", err)
+	}
+	if ac.Hex != traffic.Aircraft(2).Hex {
+		t.Errorf("update is for %s, want %s", ac.Hex, traffic.Aircraft(2).Hex)
+	}
+}
@@ -4,6 +4,7 @@
 package spectrum
 
 import (
+	"fmt"
 	"math"
 	"sync"
 )
@@ -38,6 +39,26 @@ var DefaultDistanceBands = []DistanceBand{
 	{MinDistance: 400, MaxDistance: 600, Label: "400+"},
 }
 
+// RangeBands divides 0 to maxRange nm into count equal distance bands, so
+// the spectrum spreads across whatever range the scope shows. Anything
+// beyond maxRange falls in the last band.
+func RangeBands(maxRange float64, count int) []DistanceBand {
+	if count < 1 {
+		count = 1
+	}
+	bands := make([]DistanceBand, count)
+	width := maxRange / float64(count)
+	for i := range bands {
+		lo, hi := width*float64(i), width*float64(i+1)
+		bands[i] = DistanceBand{
+			MinDistance: lo,
+			MaxDistance: hi,
+			Label:       fmt.Sprintf("%.0f-%.0f", lo, hi),
+		}
+	}
+	return bands
+}
+
 // Sample represents a signal sample with RSSI and metadata
 type Sample struct {
 	RSSI       float64 // Signal strength in dBm (typically -30 to 0)
@@ -113,6 +134,20 @@ func NewAnalyzerWithBands(bands []DistanceBand) *Analyzer {
 	}
 }
 
+// SetDistanceBands replaces the distance bands. Accumulated band data is
+// cleared; smoothing and peak hold carry over, as they follow output bins.
+func (a *Analyzer) SetDistanceBands(bands []DistanceBand) {
+	if len(bands) == 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.distanceBands = bands
+	a.bands = make([]BandData, len(bands))
+	a.resetBandsLocked()
+}
+
 // SetDecayRate sets the decay rate for old samples (0.0 to 1.0)
 func (a *Analyzer) SetDecayRate(rate float64) {
 	a.mu.Lock()
@@ -1380,3 +1380,45 @@ func TestAnalyzer_GetSpectrumSmoothed_NegativeBins(t *testing.T) {
 		t.Errorf("expected %d bins for negative input, got %d", len(DefaultDistanceBands), len(spectrum))
 	}
 }
+
+func TestRangeBands(t *testing.T) {
+	bands := RangeBands(65, 10)
+
+	if len(bands) != 10 {
+		t.Fatalf("expected 10 bands, got %d", len(bands))
+	}
+	if bands[0].MinDistance != 0 || bands[9].MaxDistance != 65 {
+		t.Errorf("bands should cover 0-65nm, got %.1f-%.1f", bands[0].MinDistance, bands[9].MaxDistance)
+	}
+	if bands[1].MinDistance != 6.5 || bands[1].Label != "6-13" {
+		t.Errorf("unexpected second band: %+v", bands[1])
+	}
+	if got := RangeBands(50, 0); len(got) != 1 {
+		t.Errorf("expected at least one band, got %d", len(got))
+	}
+}
+
+func TestAnalyzer_SetDistanceBands(t *testing.T) {
+	analyzer := NewAnalyzer()
+	analyzer.AddSampleSimple(-10, 50)
+
+	analyzer.SetDistanceBands(RangeBands(20, 4))
+
+	if labels := analyzer.GetBandLabels(); len(labels) != 4 || labels[3] != "15-20" {
+		t.Errorf("unexpected labels after SetDistanceBands: %v", labels)
+	}
+	if stats := analyzer.GetStats(); stats.TotalSamples != 0 {
+		t.Errorf("expected samples cleared, got %d", stats.TotalSamples)
+	}
+
+	// Beyond the range lands in the last band
+	analyzer.AddSampleSimple(-10, 35)
+	if stats := analyzer.GetStats(); stats.BandStats[3].SampleCount != 1 {
+		t.Errorf("expected out-of-range sample in last band, got %+v", stats.BandStats)
+	}
+
+	analyzer.SetDistanceBands(nil)
+	if len(analyzer.GetBandLabels()) != 4 {
+		t.Error("empty bands should be ignored")
+	}
+}
@@ -0,0 +1,35 @@
+// Package theme provides altitude color bands for the SkySpy radar display
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// AltitudeBands are the altitudes (ft) where AltitudeColor steps to its
+// next color: below the first is the lowest band, at or above the last
+// the highest
+var AltitudeBands = []int{2000, 6000, 10000, 20000, 30000, 40000}
+
+// AltitudeColor returns the color of an altitude (ft) on the theme's
+// altitude gradient. The gradient runs from the warning color, so low
+// traffic such as approaches stands out, to the info color, or to the
+// bright primary where the theme has the two alike.
+func (t *Theme) AltitudeColor(altitude int) lipgloss.Color {
+	band := 0
+	for band < len(AltitudeBands) && altitude >= AltitudeBands[band] {
+		band++
+	}
+	return t.altitudeGradient(float64(band) / float64(len(AltitudeBands)))
+}
+
+// altitudeGradient blends the gradient's ends, from 0 (low) to 1 (high)
+func (t *Theme) altitudeGradient(at float64) lipgloss.Color {
+	low, high := t.Warning, t.Info
+	if high == low {
+		high = t.PrimaryBright
+	}
+	from := termenv.ConvertToRGB(termenv.TrueColor.Color(string(low)))
+	to := termenv.ConvertToRGB(termenv.TrueColor.Color(string(high)))
+	return lipgloss.Color(from.BlendHsv(to, at).Clamped().Hex())
+}
@@ -0,0 +1,38 @@
+package theme
+
+import "testing"
+
+func TestTheme_AltitudeColor(t *testing.T) {
+	th := Get("classic")
+
+	// The gradient runs from the warning color to the info color
+	if got := th.AltitudeColor(0); got != "#ffff00" {
+		t.Errorf("lowest band = %s, want the warning yellow", got)
+	}
+	if got := th.AltitudeColor(45000); got != "#00ffff" {
+		t.Errorf("highest band = %s, want the info cyan", got)
+	}
+
+	if th.AltitudeColor(1999) == th.AltitudeColor(2000) {
+		t.Error("2,000 ft should start a new band")
+	}
+	if th.AltitudeColor(2000) != th.AltitudeColor(5999) {
+		t.Error("2,000 and 5,999 ft should share a band")
+	}
+	if th.AltitudeColor(40000) != th.AltitudeColor(60000) {
+		t.Error("everything from 40,000 ft up should share a band")
+	}
+}
+
+func TestTheme_AltitudeColorEveryTheme(t *testing.T) {
+	for _, name := range List() {
+		th := Get(name)
+		seen := map[string]bool{}
+		for _, alt := range append([]int{0}, AltitudeBands...) {
+			seen[string(th.AltitudeColor(alt))] = true
+		}
+		if len(seen) < 2 {
+			t.Errorf("%s: altitude colors should vary, got %v", name, seen)
+		}
+	}
+}
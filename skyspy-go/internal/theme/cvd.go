@@ -0,0 +1,89 @@
+// Package theme provides color-vision-deficiency safe palettes for the SkySpy radar display
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// Role names what a color means rather than which color it is, so views
+// that look styles up by role follow the colorblind-safe remapping
+type Role int
+
+// Semantic roles
+const (
+	RoleText Role = iota
+	RoleTextDim
+	RoleTarget    // an ordinary target
+	RoleSelected  // the selected target and its label
+	RoleMilitary  // military targets
+	RoleEmergency // emergency squawks
+	RoleWarning   // lesser special squawks and cautions
+	RoleInfo      // informational squawks
+	RoleClimb     // climbing, and other good news such as a strong signal
+	RoleDescend   // descending
+)
+
+// Okabe-Ito colors, told apart with the common forms of color blindness
+const (
+	cvdSkyBlue   = lipgloss.Color("#56B4E9")
+	cvdBlue      = lipgloss.Color("#0072B2")
+	cvdOrange    = lipgloss.Color("#E69F00")
+	cvdVermilion = lipgloss.Color("#D55E00")
+	cvdYellow    = lipgloss.Color("#F0E442")
+	cvdPurple    = lipgloss.Color("#CC79A7")
+	cvdWhite     = lipgloss.Color("#FFFFFF")
+)
+
+// Color returns the theme's color for a role
+func (t *Theme) Color(r Role) lipgloss.Color {
+	switch r {
+	case RoleTextDim:
+		return t.TextDim
+	case RoleTarget:
+		return t.RadarTarget
+	case RoleSelected:
+		return t.Selected
+	case RoleMilitary:
+		return t.Military
+	case RoleEmergency:
+		return t.Emergency
+	case RoleWarning:
+		return t.Warning
+	case RoleInfo:
+		return t.Info
+	case RoleClimb:
+		return t.Success
+	case RoleDescend:
+		return t.Error
+	}
+	return t.Text
+}
+
+// Style returns a style for a role. A colorblind-safe theme also draws the
+// roles that matter most bold, so they stand out by brightness as well as
+// by hue.
+func (t *Theme) Style(r Role) lipgloss.Style {
+	style := lipgloss.NewStyle().Foreground(t.Color(r))
+	if t.CVD && (r == RoleEmergency || r == RoleSelected) {
+		style = style.Bold(true)
+	}
+	return style
+}
+
+// WithCVD returns a copy of the theme with its status and highlight colors
+// remapped to a colorblind-safe blue, orange and yellow palette, and its
+// shape cues turned on. The theme's own look is kept for everything else.
+// Turning it off, or on for a theme that is already safe, returns t
+// unchanged.
+func (t *Theme) WithCVD(on bool) *Theme {
+	if !on || t.CVD {
+		return t
+	}
+	c := *t
+	c.CVD = true
+	c.Success = cvdSkyBlue
+	c.Error = cvdVermilion
+	c.Warning = cvdYellow
+	c.Emergency = cvdOrange
+	c.Military = cvdSkyBlue
+	c.Selected = cvdWhite
+	return &c
+}
@@ -0,0 +1,78 @@
+package theme
+
+import "testing"
+
+func TestTheme_WithCVD(t *testing.T) {
+	classic := Get("classic")
+	if classic.WithCVD(false) != classic {
+		t.Error("WithCVD(false) should return the theme unchanged")
+	}
+
+	safe := classic.WithCVD(true)
+	if safe == classic || !safe.CVD {
+		t.Fatal("WithCVD(true) should return a colorblind-safe copy")
+	}
+	if classic.CVD || classic.Emergency == safe.Emergency {
+		t.Error("WithCVD should not modify the original theme")
+	}
+	if safe.Emergency != cvdOrange || safe.Military != cvdSkyBlue || safe.Warning != cvdYellow {
+		t.Errorf("semantic colors not remapped: %+v", safe)
+	}
+	if safe.Primary != classic.Primary || safe.Name != classic.Name {
+		t.Error("WithCVD should keep the theme's own look outside the semantic colors")
+	}
+	if safe.WithCVD(true) != safe {
+		t.Error("a theme that is already safe should be returned unchanged")
+	}
+}
+
+func TestTheme_CVDRolesDistinct(t *testing.T) {
+	roles := []Role{RoleEmergency, RoleMilitary, RoleSelected, RoleWarning}
+	for _, name := range List() {
+		safe := Get(name).WithCVD(true)
+		seen := make(map[string]Role)
+		for _, r := range roles {
+			c := string(safe.Color(r))
+			if prev, ok := seen[c]; ok {
+				t.Errorf("%s: roles %d and %d share %s", name, prev, r, c)
+			}
+			seen[c] = r
+		}
+	}
+}
+
+func TestTheme_ColorblindTheme(t *testing.T) {
+	cb := Get("colorblind")
+	if cb.Name == Get("classic").Name || !cb.CVD {
+		t.Fatal("the colorblind theme should exist and be colorblind-safe")
+	}
+	if cb.WithCVD(false) != cb {
+		t.Error("the colorblind theme stays safe with the toggle off")
+	}
+}
+
+func TestTheme_RoleStyle(t *testing.T) {
+	classic := Get("classic")
+	if classic.Color(RoleClimb) != classic.Success || classic.Color(RoleDescend) != classic.Error {
+		t.Error("climb and descend should use the success and error colors")
+	}
+	if classic.Color(RoleTarget) != classic.RadarTarget || classic.Color(Role(-1)) != classic.Text {
+		t.Error("targets use the radar target color, unknown roles the text color")
+	}
+	if classic.Style(RoleEmergency).GetBold() {
+		t.Error("only a colorblind-safe theme brightens emergencies")
+	}
+
+	safe := classic.WithCVD(true)
+	for _, r := range []Role{RoleEmergency, RoleSelected} {
+		if !safe.Style(r).GetBold() {
+			t.Errorf("role %d should be bold in a colorblind-safe theme", r)
+		}
+	}
+	if safe.Style(RoleTarget).GetBold() {
+		t.Error("ordinary targets shouldn't be bold")
+	}
+	if safe.Style(RoleMilitary).GetForeground() != safe.Military {
+		t.Error("styles should use the role's color")
+	}
+}
@@ -0,0 +1,405 @@
+package theme
+
+// Glyph set keys
+const (
+	GlyphsRich   = "rich"
+	GlyphsSimple = "simple"
+	GlyphsASCII  = "ascii"
+)
+
+// GlyphSet defines the characters used to draw the radar and its panels.
+// Every entry is a single terminal cell wide, except Degree which may be
+// empty. Themes pick colors; glyph sets pick shapes, so terminals and fonts
+// without the full Unicode repertoire can still use any theme.
+type GlyphSet struct {
+	Name        string
+	Description string
+
+	// Aircraft symbols
+	Aircraft      string
+	Selected      string
+	Military      string
+	Emergency     string
+	EmergencyAlt  string // alternates with Emergency on blink
+	Rotorcraft    string // by ADS-B emitter category, in place of Aircraft
+	Glider        string // gliders, balloons, parachutists and ultralights
+	UAV           string
+	Vehicle       string // surface vehicles and obstacles
+	Taxiing       string // aircraft on the ground, in surface mode
+	PinOpen       string // drawn either side of a pinned target
+	PinClose      string
+	MilitaryOpen  string // outlines a military target in colorblind-safe mode
+	MilitaryClose string
+	Watchlist     string // before a target on the watch list
+	TurnLeft      string
+	TurnRight     string
+	Conflict      string // beside a target whose ICAO address looks shared
+	Ghost         string // last known position of a selected target that dropped out
+	Vector        string // heading vector of the selected target
+	VectorHead    string
+	OverlayPoint  string
+	OverlayLine   string
+	TrailOld      string // oldest third of a trail
+	TrailMid      string
+	TrailNew      string
+	TrailGap      string // where a trail resumes after a coverage gap
+	RangeRing     string
+	Center        string
+	AxisVertical  string // compass axis characters by screen direction
+	AxisRising    string
+	AxisLevel     string
+	AxisFalling   string
+	Sweep         string
+
+	// Frames: double for the outer window, light for panels
+	DoubleH        string
+	DoubleV        string
+	DoubleTL       string
+	DoubleTR       string
+	DoubleBL       string
+	DoubleBR       string
+	DoubleTeeLeft  string // ╠ joins a double rule to the left edge
+	DoubleTeeRight string
+	DoubleSepLeft  string // ╟ joins a light rule to the double edge
+	DoubleSepRight string
+	H              string
+	V              string
+	TL             string
+	TR             string
+	BL             string
+	BR             string
+	TeeLeft        string // ├ joins a rule to the left edge
+	TeeRight       string
+	AxisTick       string // value axis tick on plots
+	GridDot        string // dotted grid line on plots
+
+	// Bars for VU meters, spectrum and signal strength
+	BarFull   string
+	BarEmpty  string
+	BarLevels []string // eighths from lowest to full
+	Shades    []string // light to full
+	HalfUpper string
+	HalfLower string
+	SignalOn  string // lit and unlit signal strength bars
+	SignalOff string
+
+	// Indicators
+	On        string
+	Off       string
+	Live      string
+	Cursor    string
+	Pin       string
+	TrendUp   string
+	TrendDown string
+	TrendFlat string
+	Degree    string
+	Approx    string
+	Quiet     string // do-not-disturb is silencing audio alerts
+	Timer     string // before a countdown, such as sign-in expiry
+	Lock      string // kiosk mode has locked the controls
+	ArrowUp   string
+	ArrowDown string
+	PagePrev  string
+	PageNext  string
+	Ellipsis  string // after text that goes on, such as "loading"; "..." where there is no one-cell form
+	Spinner   []string
+}
+
+// glyphSets contains all available glyph sets
+var glyphSets = map[string]*GlyphSet{
+	GlyphsRich: {
+		Name:           "Unicode",
+		Description:    "Full Unicode symbols, blocks and rounded frames",
+		Aircraft:       "✦",
+		Selected:       "◉",
+		Military:       "◆",
+		Emergency:      "✖",
+		EmergencyAlt:   "!",
+		Rotorcraft:     "✣",
+		Glider:         "△",
+		UAV:            "◈",
+		Vehicle:        "▪",
+		Taxiing:        "▫",
+		PinOpen:        "(",
+		PinClose:       ")",
+		MilitaryOpen:   "[",
+		MilitaryClose:  "]",
+		Watchlist:      "★",
+		TurnLeft:       "↺",
+		TurnRight:      "↻",
+		Conflict:       "⚠",
+		Ghost:          "◌",
+		Vector:         "─",
+		VectorHead:     "›",
+		OverlayPoint:   "◇",
+		OverlayLine:    "·",
+		TrailOld:       "·",
+		TrailMid:       "•",
+		TrailNew:       "∘",
+		TrailGap:       "╎",
+		RangeRing:      "·",
+		Center:         "╋",
+		AxisVertical:   "│",
+		AxisRising:     "╱",
+		AxisLevel:      "─",
+		AxisFalling:    "╲",
+		Sweep:          "░",
+		DoubleH:        "═",
+		DoubleV:        "║",
+		DoubleTL:       "╔",
+		DoubleTR:       "╗",
+		DoubleBL:       "╚",
+		DoubleBR:       "╝",
+		DoubleTeeLeft:  "╠",
+		DoubleTeeRight: "╣",
+		DoubleSepLeft:  "╟",
+		DoubleSepRight: "╢",
+		H:              "─",
+		V:              "│",
+		TL:             "╭",
+		TR:             "╮",
+		BL:             "╰",
+		BR:             "╯",
+		TeeLeft:        "├",
+		TeeRight:       "┤",
+		AxisTick:       "┤",
+		GridDot:        "┈",
+		BarFull:        "█",
+		BarEmpty:       "░",
+		BarLevels:      []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"},
+		Shades:         []string{"░", "▒", "▓", "█"},
+		HalfUpper:      "▀",
+		HalfLower:      "▄",
+		SignalOn:       "▆",
+		SignalOff:      "▁",
+		On:             "●",
+		Off:            "○",
+		Live:           "◉",
+		Cursor:         "▶",
+		Pin:            "◈",
+		TrendUp:        "▲",
+		TrendDown:      "▼",
+		TrendFlat:      "─",
+		Degree:         "°",
+		Approx:         "≈",
+		Quiet:          "☾",
+		Timer:          "⏲",
+		Lock:           "⚿",
+		ArrowUp:        "↑",
+		ArrowDown:      "↓",
+		PagePrev:       "◄",
+		PageNext:       "►",
+		Ellipsis:       "…",
+		Spinner:        []string{"◐", "◓", "◑", "◒"},
+	},
+	GlyphsSimple: {
+		Name:           "Simple Unicode",
+		Description:    "Box drawing and blocks found in most console fonts",
+		Aircraft:       "■",
+		Selected:       "●",
+		Military:       "♦",
+		Emergency:      "X",
+		EmergencyAlt:   "!",
+		Rotorcraft:     "¤",
+		Glider:         "^",
+		UAV:            "x",
+		Vehicle:        "□",
+		Taxiing:        "∙",
+		PinOpen:        "(",
+		PinClose:       ")",
+		MilitaryOpen:   "[",
+		MilitaryClose:  "]",
+		Watchlist:      "*",
+		TurnLeft:       "◄",
+		TurnRight:      "►",
+		Conflict:       "‼",
+		Ghost:          "ø",
+		Vector:         "─",
+		VectorHead:     "»",
+		OverlayPoint:   "○",
+		OverlayLine:    "·",
+		TrailOld:       "·",
+		TrailMid:       "•",
+		TrailNew:       "°",
+		TrailGap:       "¦",
+		RangeRing:      "·",
+		Center:         "┼",
+		AxisVertical:   "│",
+		AxisRising:     "/",
+		AxisLevel:      "─",
+		AxisFalling:    "\\",
+		Sweep:          "░",
+		DoubleH:        "═",
+		DoubleV:        "║",
+		DoubleTL:       "╔",
+		DoubleTR:       "╗",
+		DoubleBL:       "╚",
+		DoubleBR:       "╝",
+		DoubleTeeLeft:  "╠",
+		DoubleTeeRight: "╣",
+		DoubleSepLeft:  "╟",
+		DoubleSepRight: "╢",
+		H:              "─",
+		V:              "│",
+		TL:             "┌",
+		TR:             "┐",
+		BL:             "└",
+		BR:             "┘",
+		TeeLeft:        "├",
+		TeeRight:       "┤",
+		AxisTick:       "┤",
+		GridDot:        "·",
+		BarFull:        "█",
+		BarEmpty:       "░",
+		BarLevels:      []string{"_", "_", "▄", "▄", "▄", "█", "█", "█"},
+		Shades:         []string{"░", "▒", "▓", "█"},
+		HalfUpper:      "▀",
+		HalfLower:      "▄",
+		SignalOn:       "▄",
+		SignalOff:      "_",
+		On:             "●",
+		Off:            "○",
+		Live:           "●",
+		Cursor:         "►",
+		Pin:            "♦",
+		TrendUp:        "▲",
+		TrendDown:      "▼",
+		TrendFlat:      "─",
+		Degree:         "°",
+		Approx:         "≈",
+		Quiet:          "☾",
+		Timer:          "◷",
+		Lock:           "▣",
+		ArrowUp:        "↑",
+		ArrowDown:      "↓",
+		PagePrev:       "◄",
+		PageNext:       "►",
+		Ellipsis:       "...",
+		Spinner:        []string{"│", "/", "─", "\\"},
+	},
+	GlyphsASCII: {
+		Name:           "ASCII",
+		Description:    "7-bit ASCII only, for any terminal",
+		Aircraft:       "*",
+		Selected:       "@",
+		Military:       "#",
+		Emergency:      "X",
+		EmergencyAlt:   "!",
+		Rotorcraft:     "H",
+		Glider:         "^",
+		UAV:            "u",
+		Vehicle:        "=",
+		Taxiing:        ",",
+		PinOpen:        "(",
+		PinClose:       ")",
+		MilitaryOpen:   "[",
+		MilitaryClose:  "]",
+		Watchlist:      "*",
+		TurnLeft:       "<",
+		TurnRight:      ">",
+		Conflict:       "?",
+		Ghost:          "O",
+		Vector:         "-",
+		VectorHead:     ">",
+		OverlayPoint:   "x",
+		OverlayLine:    ".",
+		TrailOld:       ".",
+		TrailMid:       ":",
+		TrailNew:       "o",
+		TrailGap:       "'",
+		RangeRing:      ".",
+		Center:         "+",
+		AxisVertical:   "|",
+		AxisRising:     "/",
+		AxisLevel:      "-",
+		AxisFalling:    "\\",
+		Sweep:          ":",
+		DoubleH:        "=",
+		DoubleV:        "|",
+		DoubleTL:       "+",
+		DoubleTR:       "+",
+		DoubleBL:       "+",
+		DoubleBR:       "+",
+		DoubleTeeLeft:  "+",
+		DoubleTeeRight: "+",
+		DoubleSepLeft:  "+",
+		DoubleSepRight: "+",
+		H:              "-",
+		V:              "|",
+		TL:             "+",
+		TR:             "+",
+		BL:             "+",
+		BR:             "+",
+		TeeLeft:        "+",
+		TeeRight:       "+",
+		AxisTick:       "+",
+		GridDot:        ".",
+		BarFull:        "#",
+		BarEmpty:       ".",
+		BarLevels:      []string{"_", ".", "-", ":", "=", "+", "*", "#"},
+		Shades:         []string{".", ":", "=", "#"},
+		HalfUpper:      "\"",
+		HalfLower:      "_",
+		SignalOn:       "|",
+		SignalOff:      ".",
+		On:             "*",
+		Off:            "o",
+		Live:           "@",
+		Cursor:         ">",
+		Pin:            "+",
+		TrendUp:        "^",
+		TrendDown:      "v",
+		TrendFlat:      "-",
+		Degree:         "",
+		Approx:         "~",
+		Quiet:          "z",
+		Timer:          "@",
+		Lock:           "#",
+		ArrowUp:        "^",
+		ArrowDown:      "v",
+		PagePrev:       "<",
+		PageNext:       ">",
+		Ellipsis:       "...",
+		Spinner:        []string{"|", "/", "-", "\\"},
+	},
+}
+
+// glyphOrder lists glyph sets from richest to plainest
+var glyphOrder = []string{GlyphsRich, GlyphsSimple, GlyphsASCII}
+
+// Glyphs returns a glyph set by name, defaults to rich if not found
+func Glyphs(name string) *GlyphSet {
+	if g, ok := glyphSets[name]; ok {
+		return g
+	}
+	return glyphSets[GlyphsRich]
+}
+
+// GlyphSetNames returns all glyph set names, richest first
+func GlyphSetNames() []string {
+	names := make([]string, len(glyphOrder))
+	copy(names, glyphOrder)
+	return names
+}
+
+// IsGlyphSet reports whether name is a known glyph set
+func IsGlyphSet(name string) bool {
+	_, ok := glyphSets[name]
+	return ok
+}
+
+// GlyphSet returns the theme's glyph set
+func (t *Theme) GlyphSet() *GlyphSet {
+	return Glyphs(t.Glyphs)
+}
+
+// WithGlyphs returns a copy of the theme drawn with the named glyph set. An
+// empty or unknown name keeps the theme's own set and returns t unchanged.
+func (t *Theme) WithGlyphs(name string) *Theme {
+	if name == "" || name == t.Glyphs || !IsGlyphSet(name) {
+		return t
+	}
+	c := *t
+	c.Glyphs = name
+	return &c
+}
@@ -0,0 +1,108 @@
+package theme
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// glyphFields returns every glyph in a set by field name, expanding slices
+func glyphFields(g *GlyphSet) map[string]string {
+	fields := make(map[string]string)
+	v := reflect.ValueOf(*g)
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		if name == "Name" || name == "Description" {
+			continue
+		}
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.String:
+			fields[name] = f.String()
+		case reflect.Slice:
+			for j := 0; j < f.Len(); j++ {
+				fields[fmt.Sprintf("%s[%d]", name, j)] = f.Index(j).String()
+			}
+		}
+	}
+	return fields
+}
+
+func TestGlyphs_ASCIIOnly(t *testing.T) {
+	g := Glyphs(GlyphsASCII)
+	for name, s := range glyphFields(g) {
+		for _, b := range []byte(s) {
+			if b >= 0x80 {
+				t.Errorf("ascii %s = %q contains a non-ASCII byte", name, s)
+				break
+			}
+		}
+	}
+}
+
+func TestGlyphs_Complete(t *testing.T) {
+	for _, setName := range GlyphSetNames() {
+		g := Glyphs(setName)
+		if g.Name == "" || g.Description == "" {
+			t.Errorf("%s: missing name or description", setName)
+		}
+		if len(g.BarLevels) != 8 {
+			t.Errorf("%s: BarLevels has %d entries, want 8", setName, len(g.BarLevels))
+		}
+		if len(g.Shades) != 4 {
+			t.Errorf("%s: Shades has %d entries, want 4", setName, len(g.Shades))
+		}
+		if len(g.Spinner) == 0 {
+			t.Errorf("%s: empty Spinner", setName)
+		}
+		for name, s := range glyphFields(g) {
+			if name == "Degree" && s == "" || name == "Ellipsis" && s == "..." {
+				continue
+			}
+			if w := lipgloss.Width(s); w != 1 {
+				t.Errorf("%s %s = %q is %d cells wide, want 1", setName, name, s, w)
+			}
+		}
+	}
+}
+
+func TestGlyphs_Default(t *testing.T) {
+	if Glyphs("nonexistent") != Glyphs(GlyphsRich) {
+		t.Error("unknown glyph set should fall back to rich")
+	}
+	if IsGlyphSet("nonexistent") {
+		t.Error("IsGlyphSet should reject unknown names")
+	}
+	for _, name := range GlyphSetNames() {
+		if !IsGlyphSet(name) {
+			t.Errorf("IsGlyphSet(%q) = false", name)
+		}
+	}
+}
+
+func TestTheme_WithGlyphs(t *testing.T) {
+	classic := Get("classic")
+	if classic.GlyphSet() != Glyphs(GlyphsRich) {
+		t.Error("built-in themes should default to the rich glyph set")
+	}
+
+	ascii := classic.WithGlyphs(GlyphsASCII)
+	if ascii == classic {
+		t.Fatal("WithGlyphs should return a copy")
+	}
+	if ascii.GlyphSet() != Glyphs(GlyphsASCII) {
+		t.Error("copy should use the ascii glyph set")
+	}
+	if ascii.PrimaryBright != classic.PrimaryBright {
+		t.Error("copy should keep the theme's colors")
+	}
+	if classic.Glyphs != GlyphsRich {
+		t.Error("WithGlyphs should not modify the original theme")
+	}
+
+	if classic.WithGlyphs("") != classic || classic.WithGlyphs("bogus") != classic {
+		t.Error("empty or unknown names should return the theme unchanged")
+	}
+}
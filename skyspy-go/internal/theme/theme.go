@@ -10,6 +10,13 @@ type Theme struct {
 	Name        string
 	Description string
 
+	// Glyphs names the glyph set the theme draws with (see GlyphSet)
+	Glyphs string
+
+	// CVD marks a colorblind-safe theme, drawn with shape and brightness
+	// cues that don't rely on hue alone (see WithCVD)
+	CVD bool
+
 	// Primary colors
 	Primary       lipgloss.Color
 	PrimaryBright lipgloss.Color
@@ -49,6 +56,7 @@ var themes = map[string]*Theme{
 	"classic": {
 		Name:            "Classic Green",
 		Description:     "Traditional green phosphor display",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("28"),  // green
 		PrimaryBright:   lipgloss.Color("46"),  // bright_green
 		PrimaryDim:      lipgloss.Color("22"),  // dark_green
@@ -74,6 +82,7 @@ var themes = map[string]*Theme{
 	"amber": {
 		Name:            "Amber",
 		Description:     "Vintage amber monochrome display",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("178"), // yellow
 		PrimaryBright:   lipgloss.Color("226"), // bright_yellow
 		PrimaryDim:      lipgloss.Color("130"), // dark_orange
@@ -99,6 +108,7 @@ var themes = map[string]*Theme{
 	"ice": {
 		Name:            "Blue Ice",
 		Description:     "Cold blue tactical display",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("21"),  // blue
 		PrimaryBright:   lipgloss.Color("33"),  // bright_blue
 		PrimaryDim:      lipgloss.Color("18"),  // dark_blue
@@ -124,6 +134,7 @@ var themes = map[string]*Theme{
 	"cyberpunk": {
 		Name:            "Cyberpunk",
 		Description:     "Neon futuristic display",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("165"), // magenta
 		PrimaryBright:   lipgloss.Color("201"), // bright_magenta
 		PrimaryDim:      lipgloss.Color("90"),  // dark_magenta
@@ -149,6 +160,7 @@ var themes = map[string]*Theme{
 	"military": {
 		Name:            "Military",
 		Description:     "Tactical military display",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("28"),  // green
 		PrimaryBright:   lipgloss.Color("46"),  // bright_green
 		PrimaryDim:      lipgloss.Color("22"),  // dark_green
@@ -174,6 +186,7 @@ var themes = map[string]*Theme{
 	"high_contrast": {
 		Name:            "High Contrast",
 		Description:     "Maximum visibility white display",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("231"), // white
 		PrimaryBright:   lipgloss.Color("231"), // bright_white
 		PrimaryDim:      lipgloss.Color("249"), // grey70
@@ -199,6 +212,7 @@ var themes = map[string]*Theme{
 	"phosphor": {
 		Name:            "Phosphor",
 		Description:     "Realistic CRT phosphor glow",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("#33ff33"),
 		PrimaryBright:   lipgloss.Color("#66ff66"),
 		PrimaryDim:      lipgloss.Color("#116611"),
@@ -224,6 +238,7 @@ var themes = map[string]*Theme{
 	"sunset": {
 		Name:            "Sunset",
 		Description:     "Warm orange sunset tones",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("208"), // dark_orange
 		PrimaryBright:   lipgloss.Color("196"), // bright_red
 		PrimaryDim:      lipgloss.Color("160"), // red
@@ -249,6 +264,7 @@ var themes = map[string]*Theme{
 	"matrix": {
 		Name:            "Matrix",
 		Description:     "Matrix digital rain inspired",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("#00ff00"),
 		PrimaryBright:   lipgloss.Color("#00ff00"),
 		PrimaryDim:      lipgloss.Color("#003300"),
@@ -274,6 +290,7 @@ var themes = map[string]*Theme{
 	"ocean": {
 		Name:            "Ocean",
 		Description:     "Deep blue oceanic display",
+		Glyphs:          GlyphsRich,
 		Primary:         lipgloss.Color("#0066cc"),
 		PrimaryBright:   lipgloss.Color("#0099ff"),
 		PrimaryDim:      lipgloss.Color("#003366"),
@@ -296,6 +313,33 @@ var themes = map[string]*Theme{
 		RadarTarget:     lipgloss.Color("#00ffff"),
 		RadarTrail:      lipgloss.Color("#006699"),
 	},
+	"colorblind": {
+		Name:            "Colorblind",
+		Description:     "Blue/orange palette with shape cues",
+		Glyphs:          GlyphsRich,
+		CVD:             true,
+		Primary:         cvdSkyBlue,
+		PrimaryBright:   cvdWhite,
+		PrimaryDim:      lipgloss.Color("#2B5A75"),
+		Secondary:       cvdPurple,
+		SecondaryBright: cvdYellow,
+		Success:         cvdSkyBlue,
+		Warning:         cvdYellow,
+		Error:           cvdVermilion,
+		Info:            cvdPurple,
+		Military:        cvdSkyBlue,
+		Emergency:       cvdOrange,
+		Selected:        cvdWhite,
+		Border:          cvdBlue,
+		BorderDim:       lipgloss.Color("#1F3F52"),
+		Text:            lipgloss.Color("#D0D0D0"),
+		TextDim:         lipgloss.Color("#808080"),
+		Background:      lipgloss.Color("0"),
+		RadarSweep:      cvdSkyBlue,
+		RadarRing:       lipgloss.Color("#1F3F52"),
+		RadarTarget:     lipgloss.Color("#D0D0D0"),
+		RadarTrail:      lipgloss.Color("#2B5A75"),
+	},
 }
 
 // Get returns a theme by name, defaults to classic if not found
@@ -310,7 +354,7 @@ func Get(name string) *Theme {
 func List() []string {
 	names := make([]string, 0, len(themes))
 	// Return in a consistent order
-	order := []string{"classic", "amber", "ice", "cyberpunk", "military", "high_contrast", "phosphor", "sunset", "matrix", "ocean"}
+	order := []string{"classic", "amber", "ice", "cyberpunk", "military", "high_contrast", "phosphor", "sunset", "matrix", "ocean", "colorblind"}
 	for _, name := range order {
 		if _, ok := themes[name]; ok {
 			names = append(names, name)
@@ -328,7 +372,7 @@ type ThemeInfo struct {
 
 // GetInfo returns information about all themes
 func GetInfo() []ThemeInfo {
-	order := []string{"classic", "amber", "ice", "cyberpunk", "military", "high_contrast", "phosphor", "sunset", "matrix", "ocean"}
+	order := []string{"classic", "amber", "ice", "cyberpunk", "military", "high_contrast", "phosphor", "sunset", "matrix", "ocean", "colorblind"}
 	info := make([]ThemeInfo, 0, len(order))
 	for _, key := range order {
 		if t, ok := themes[key]; ok {
@@ -11,6 +11,7 @@ func TestGet_ValidTheme(t *testing.T) {
 	validThemes := []string{
 		"classic", "amber", "ice", "cyberpunk", "military",
 		"high_contrast", "phosphor", "sunset", "matrix", "ocean",
+		"colorblind",
 	}
 
 	for _, name := range validThemes {
@@ -64,6 +65,7 @@ func TestList(t *testing.T) {
 	expectedThemes := []string{
 		"classic", "amber", "ice", "cyberpunk", "military",
 		"high_contrast", "phosphor", "sunset", "matrix", "ocean",
+		"colorblind",
 	}
 
 	if len(list) != len(expectedThemes) {
@@ -85,7 +87,7 @@ func TestGetInfo(t *testing.T) {
 		t.Fatal("GetInfo returned empty slice")
 	}
 
-	expectedCount := 10
+	expectedCount := 11
 	if len(info) != expectedCount {
 		t.Errorf("GetInfo returned %d items, want %d", len(info), expectedCount)
 	}
@@ -232,6 +234,7 @@ func TestTheme_AllStyleMethods(t *testing.T) {
 	themes := []string{
 		"classic", "amber", "ice", "cyberpunk", "military",
 		"high_contrast", "phosphor", "sunset", "matrix", "ocean",
+		"colorblind",
 	}
 
 	for _, name := range themes {
@@ -326,6 +329,7 @@ func TestTheme_AllThemesHaveColorFields(t *testing.T) {
 	themes := []string{
 		"classic", "amber", "ice", "cyberpunk", "military",
 		"high_contrast", "phosphor", "sunset", "matrix", "ocean",
+		"colorblind",
 	}
 
 	for _, name := range themes {
@@ -0,0 +1,194 @@
+// Package trails provides aircraft trail/history tracking functionality
+package trails
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Conflict detection defaults, used when a ConflictSettings field is not
+// positive
+const (
+	// DefaultConflictMaxSpeed (kt) is the implied speed between two fixes
+	// that no ordinary aircraft reaches
+	DefaultConflictMaxSpeed = 1500.0
+	// DefaultConflictSpeedFactor is how far past its reported ground speed
+	// a fast mover may appear to travel before a fix counts as a jump
+	DefaultConflictSpeedFactor = 2.0
+	// DefaultConflictMinJump (nm) is the shortest jump considered; closer
+	// fixes are position noise however fast they imply
+	DefaultConflictMinJump = 2.0
+	// DefaultConflictHold is how long a target stays flagged after its
+	// last jump
+	DefaultConflictHold = 2 * time.Minute
+)
+
+// conflictRelocateFixes is the number of consecutive jumped fixes, each
+// consistent with the one before, after which the aircraft is taken to
+// have genuinely moved and its trail restarts from there
+const conflictRelocateFixes = 3
+
+// ConflictSettings tunes duplicate ICAO address detection
+type ConflictSettings struct {
+	MaxSpeed    float64 // kt
+	SpeedFactor float64
+	MinJump     float64 // nm
+	Hold        time.Duration
+}
+
+// ConflictInfo describes the position jumps seen for one ICAO address
+type ConflictInfo struct {
+	Conflicted   bool
+	Jumps        int       // jumps seen since the address was first tracked
+	ImpliedSpeed float64   // kt, of the most recent jump
+	LastJump     time.Time // zero if there has never been a jump
+}
+
+// FixVerdict says what to do with a position fix
+type FixVerdict int
+
+// Fix verdicts
+const (
+	FixAccept   FixVerdict = iota // plausible; add it to the trail
+	FixJump                       // implausible jump; leave it out of the trail
+	FixRelocate                   // the aircraft really moved; split the trail here
+)
+
+type conflictFix struct {
+	lat, lon float64
+	at       time.Time
+}
+
+type conflictHistory struct {
+	last    conflictFix // last fix accepted into the trail
+	pending []conflictFix
+	info    ConflictInfo
+}
+
+// ConflictTracker watches the position fixes for each ICAO address and
+// flags addresses whose consecutive fixes imply an impossible speed. That
+// usually means two aircraft are transmitting the same address, or one is
+// sending corrupt positions.
+type ConflictTracker struct {
+	mu       sync.RWMutex
+	settings ConflictSettings
+	history  map[string]*conflictHistory
+}
+
+// NewConflictTracker creates an empty ConflictTracker. Non-positive
+// settings use the defaults.
+func NewConflictTracker(s ConflictSettings) *ConflictTracker {
+	if s.MaxSpeed <= 0 {
+		s.MaxSpeed = DefaultConflictMaxSpeed
+	}
+	if s.SpeedFactor <= 0 {
+		s.SpeedFactor = DefaultConflictSpeedFactor
+	}
+	if s.MinJump <= 0 {
+		s.MinJump = DefaultConflictMinJump
+	}
+	if s.Hold <= 0 {
+		s.Hold = DefaultConflictHold
+	}
+	return &ConflictTracker{
+		settings: s,
+		history:  make(map[string]*conflictHistory),
+	}
+}
+
+// AddFix checks a position fix against the last one accepted for hex.
+// groundSpeed is the reported speed in knots, or 0 if unknown. It returns
+// what to do with the fix and the updated conflict state.
+func (c *ConflictTracker) AddFix(hex string, lat, lon, groundSpeed float64, at time.Time) (FixVerdict, ConflictInfo) {
+	if hex == "" {
+		return FixAccept, ConflictInfo{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h, ok := c.history[hex]
+	if !ok {
+		c.history[hex] = &conflictHistory{last: conflictFix{lat: lat, lon: lon, at: at}}
+		return FixAccept, ConflictInfo{}
+	}
+
+	fix := conflictFix{lat: lat, lon: lon, at: at}
+	verdict := FixAccept
+	if speed, jump := c.jump(h.last, fix, groundSpeed); jump {
+		h.info.Jumps++
+		h.info.ImpliedSpeed = speed
+		h.info.LastJump = at
+
+		// A run of jumped fixes that agree with each other is one aircraft
+		// that has moved, not two sharing an address
+		if n := len(h.pending); n > 0 {
+			if _, again := c.jump(h.pending[n-1], fix, groundSpeed); again {
+				h.pending = h.pending[:0]
+			}
+		}
+		h.pending = append(h.pending, fix)
+		verdict = FixJump
+		if len(h.pending) >= conflictRelocateFixes {
+			verdict = FixRelocate
+		}
+	}
+
+	if verdict != FixJump {
+		h.last = fix
+		h.pending = h.pending[:0]
+	}
+	h.info.Conflicted = !h.info.LastJump.IsZero() && at.Sub(h.info.LastJump) <= c.settings.Hold
+	return verdict, h.info
+}
+
+// jump reports whether moving from a to b implies an impossible speed, and
+// that speed in knots
+func (c *ConflictTracker) jump(a, b conflictFix, groundSpeed float64) (float64, bool) {
+	dist := distanceNM(a.lat, a.lon, b.lat, b.lon)
+	if dist < c.settings.MinJump {
+		return 0, false
+	}
+	// Fixes in the same second are timed as a second apart
+	hours := math.Max(b.at.Sub(a.at).Hours(), 1.0/3600)
+	speed := dist / hours
+	limit := math.Max(c.settings.MaxSpeed, groundSpeed*c.settings.SpeedFactor)
+	return speed, speed > limit
+}
+
+// Get returns the conflict state for hex. Conflicted clears once Hold has
+// passed without a jump.
+func (c *ConflictTracker) Get(hex string, now time.Time) (ConflictInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	h, exists := c.history[hex]
+	if !exists {
+		return ConflictInfo{}, false
+	}
+	info := h.info
+	info.Conflicted = !info.LastJump.IsZero() && now.Sub(info.LastJump) <= c.settings.Hold
+	return info, true
+}
+
+// Remove discards the history for hex
+func (c *ConflictTracker) Remove(hex string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.history, hex)
+}
+
+// distanceNM returns the great-circle distance between two points in
+// nautical miles
+func distanceNM(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusNM = 3440.065
+	lat1Rad := lat1 * math.Pi / 180
+	lat2Rad := lat2 * math.Pi / 180
+	deltaLat := (lat2 - lat1) * math.Pi / 180
+	deltaLon := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1Rad)*math.Cos(lat2Rad)*math.Sin(deltaLon/2)*math.Sin(deltaLon/2)
+	return earthRadiusNM * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
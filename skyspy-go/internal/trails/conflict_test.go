@@ -0,0 +1,134 @@
+package trails
+
+import (
+	"testing"
+	"time"
+)
+
+// cruiseStep is the latitude change per second at about 450 kt
+const cruiseStep = 450.0 / 3600 / 60
+
+func TestConflictTracker_SteadyFlightIsAccepted(t *testing.T) {
+	tracker := NewConflictTracker(ConflictSettings{})
+	start := time.Now()
+
+	for i := 0; i < 60; i++ {
+		verdict, info := tracker.AddFix("ABC123", 51+float64(i)*cruiseStep, -1, 450, start.Add(time.Duration(i)*time.Second))
+		if verdict != FixAccept || info.Conflicted {
+			t.Fatalf("fix %d: got verdict %v conflicted %v for steady flight", i, verdict, info.Conflicted)
+		}
+	}
+}
+
+func TestConflictTracker_AlternatingPositionsAreConflicted(t *testing.T) {
+	tracker := NewConflictTracker(ConflictSettings{})
+	start := time.Now()
+
+	// Two aircraft 60 nm apart both reporting as ABC123, one second apart
+	jumps := 0
+	for i := 0; i < 20; i++ {
+		lat := 51 + float64(i/2)*2*cruiseStep
+		if i%2 == 1 {
+			lat += 1
+		}
+		verdict, info := tracker.AddFix("ABC123", lat, -1, 450, start.Add(time.Duration(i)*time.Second))
+		if i%2 == 1 {
+			if verdict != FixJump {
+				t.Fatalf("fix %d: expected the far aircraft to be a jump, got %v", i, verdict)
+			}
+			jumps++
+		} else if verdict != FixAccept {
+			t.Fatalf("fix %d: expected the tracked aircraft to be accepted, got %v", i, verdict)
+		}
+		if i > 0 && !info.Conflicted {
+			t.Fatalf("fix %d: expected the address to be flagged", i)
+		}
+		if info.Jumps != jumps {
+			t.Fatalf("fix %d: expected %d jumps, got %d", i, jumps, info.Jumps)
+		}
+	}
+
+	info, _ := tracker.Get("ABC123", start.Add(20*time.Second))
+	if info.ImpliedSpeed < 100000 {
+		t.Errorf("expected an implied speed of about 216000 kt, got %.0f", info.ImpliedSpeed)
+	}
+}
+
+func TestConflictTracker_ConsistentJumpRelocates(t *testing.T) {
+	tracker := NewConflictTracker(ConflictSettings{})
+	start := time.Now()
+	tracker.AddFix("ABC123", 51, -1, 450, start)
+
+	var verdicts []FixVerdict
+	for i := 1; i <= conflictRelocateFixes+1; i++ {
+		v, _ := tracker.AddFix("ABC123", 52+float64(i)*cruiseStep, -1, 450, start.Add(time.Duration(i)*time.Second))
+		verdicts = append(verdicts, v)
+	}
+
+	want := []FixVerdict{FixJump, FixJump, FixRelocate, FixAccept}
+	for i := range want {
+		if verdicts[i] != want[i] {
+			t.Fatalf("expected verdicts %v, got %v", want, verdicts)
+		}
+	}
+}
+
+func TestConflictTracker_FastMoverUsesGroundSpeed(t *testing.T) {
+	tracker := NewConflictTracker(ConflictSettings{})
+	start := time.Now()
+
+	// 1800 kt is past the default limit but within twice a reported 1000 kt
+	step := 1800.0 / 3600 / 60
+	tracker.AddFix("FAST01", 51, -1, 1000, start)
+	for i := 1; i <= 10; i++ {
+		verdict, _ := tracker.AddFix("FAST01", 51+float64(i)*10*step, -1, 1000, start.Add(time.Duration(i)*10*time.Second))
+		if verdict != FixAccept {
+			t.Fatalf("fix %d: expected a fast mover to be accepted, got %v", i, verdict)
+		}
+	}
+
+	verdict, _ := tracker.AddFix("SLOW01", 51, -1, 120, start)
+	if verdict != FixAccept {
+		t.Fatalf("expected the first fix to be accepted, got %v", verdict)
+	}
+	verdict, _ = tracker.AddFix("SLOW01", 51+10*step, -1, 120, start.Add(10*time.Second))
+	if verdict != FixJump {
+		t.Errorf("expected 1800 kt from a 120 kt aircraft to be a jump, got %v", verdict)
+	}
+}
+
+func TestConflictTracker_IgnoresSmallJumps(t *testing.T) {
+	tracker := NewConflictTracker(ConflictSettings{})
+	start := time.Now()
+
+	// 1 nm in the same second is position noise, not a second aircraft
+	tracker.AddFix("ABC123", 51, -1, 450, start)
+	verdict, info := tracker.AddFix("ABC123", 51+1.0/60, -1, 450, start)
+	if verdict != FixAccept || info.Conflicted {
+		t.Errorf("expected a short jump to be ignored, got verdict %v conflicted %v", verdict, info.Conflicted)
+	}
+}
+
+func TestConflictTracker_HoldExpires(t *testing.T) {
+	tracker := NewConflictTracker(ConflictSettings{Hold: time.Minute})
+	start := time.Now()
+
+	tracker.AddFix("ABC123", 51, -1, 450, start)
+	tracker.AddFix("ABC123", 52, -1, 450, start.Add(time.Second))
+
+	if info, ok := tracker.Get("ABC123", start.Add(30*time.Second)); !ok || !info.Conflicted {
+		t.Error("expected the address to stay flagged within the hold time")
+	}
+	info, ok := tracker.Get("ABC123", start.Add(2*time.Minute))
+	if !ok || info.Conflicted {
+		t.Error("expected the flag to clear after the hold time")
+	}
+	if info.Jumps != 1 {
+		t.Errorf("expected the jump count to be kept, got %d", info.Jumps)
+	}
+
+	tracker.Remove("ABC123")
+	if _, ok := tracker.Get("ABC123", start); ok {
+		t.Error("expected no state after Remove")
+	}
+}
@@ -0,0 +1,53 @@
+// Package trails provides aircraft trail/history tracking functionality
+package trails
+
+import (
+	"sort"
+	"time"
+)
+
+// HistoryDots samples a trail (oldest first) the way a controller's scope
+// shows history: the position at each whole interval before the newest
+// point, at most max of them, returned oldest first. The dots are evenly
+// spaced in time, so their spacing on the scope shows ground speed. An
+// interval with no position within half an interval of it, as across a
+// coverage gap, is left empty. The newest point, the aircraft's current
+// position, is not included.
+func HistoryDots(trail []Position, interval time.Duration, max int) []Position {
+	if len(trail) < 2 || interval <= 0 || max <= 0 {
+		return nil
+	}
+	last := len(trail) - 1
+	latest := trail[last].Timestamp
+
+	dots := make([]Position, 0, max)
+	for k := 1; k <= max; k++ {
+		at := latest.Add(-time.Duration(k) * interval)
+		// The points either side of the sample time; the nearer one is taken
+		after := sort.Search(last, func(i int) bool { return !trail[i].Timestamp.Before(at) })
+		best, bestDiff := -1, interval/2
+		for _, i := range []int{after - 1, after} {
+			if i < 0 || i >= last {
+				continue
+			}
+			diff := at.Sub(trail[i].Timestamp)
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff < bestDiff {
+				best, bestDiff = i, diff
+			}
+		}
+		if best >= 0 {
+			dots = append(dots, trail[best])
+		} else if after == 0 && trail[0].Timestamp.After(at) {
+			// Past the start of the trail
+			break
+		}
+	}
+
+	for i, j := 0, len(dots)-1; i < j; i, j = i+1, j-1 {
+		dots[i], dots[j] = dots[j], dots[i]
+	}
+	return dots
+}
@@ -0,0 +1,70 @@
+package trails
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// straightTrail reports a position every second for the given time,
+// flying east along the equator at knots
+func straightTrail(start time.Time, knots float64, duration time.Duration) []Position {
+	var trail []Position
+	for s := 0; s <= int(duration/time.Second); s++ {
+		// One degree of longitude at the equator is 60 nm
+		lon := knots / 3600 * float64(s) / 60
+		trail = append(trail, Position{Lon: lon, Timestamp: start.Add(time.Duration(s) * time.Second)})
+	}
+	return trail
+}
+
+func TestHistoryDots_SpacingShowsSpeed(t *testing.T) {
+	start := time.Now()
+	interval := 10 * time.Second
+	slow := HistoryDots(straightTrail(start, 120, 2*time.Minute), interval, 6)
+	fast := HistoryDots(straightTrail(start, 480, 2*time.Minute), interval, 6)
+
+	if len(slow) != 6 || len(fast) != 6 {
+		t.Fatalf("got %d slow and %d fast dots, want 6 each", len(slow), len(fast))
+	}
+	for i := 1; i < 6; i++ {
+		if gap := slow[i].Timestamp.Sub(slow[i-1].Timestamp); gap != interval {
+			t.Errorf("slow dots %d and %d are %v apart, want %v", i-1, i, gap, interval)
+		}
+		slowStep := slow[i].Lon - slow[i-1].Lon
+		fastStep := fast[i].Lon - fast[i-1].Lon
+		if math.Abs(fastStep/slowStep-4) > 0.01 {
+			t.Errorf("fast dots should be 4x as far apart as slow ones: %.5f vs %.5f", fastStep, slowStep)
+		}
+	}
+	// The newest dot is one interval behind the current position
+	if got := slow[5].Timestamp; !got.Equal(start.Add(2*time.Minute - interval)) {
+		t.Errorf("newest dot at %v", got.Sub(start))
+	}
+}
+
+func TestHistoryDots_ShortTrailAndGaps(t *testing.T) {
+	start := time.Now()
+	if dots := HistoryDots(straightTrail(start, 300, 25*time.Second), 10*time.Second, 6); len(dots) != 2 {
+		t.Errorf("25 s of trail should give 2 dots, got %d", len(dots))
+	}
+
+	// A 30 s coverage gap leaves its intervals empty
+	trail := straightTrail(start, 300, 20*time.Second)
+	resumed := straightTrail(start.Add(50*time.Second), 300, 30*time.Second)
+	resumed[0].Break = true
+	trail = append(trail, resumed...)
+	dots := HistoryDots(trail, 10*time.Second, 8)
+	for _, d := range dots {
+		if at := d.Timestamp.Sub(start); at > 20*time.Second && at < 50*time.Second {
+			t.Errorf("dot at %v is inside the gap", at)
+		}
+	}
+	if len(dots) != 6 {
+		t.Errorf("got %d dots around the gap, want 6", len(dots))
+	}
+
+	if HistoryDots(trail, 0, 6) != nil || HistoryDots(trail[:1], time.Second, 6) != nil {
+		t.Error("no interval or a single point should give no dots")
+	}
+}
@@ -2,12 +2,24 @@
 package trails
 
 import (
+	"sort"
 	"sync"
 	"time"
+	"unsafe"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
 )
 
-// DefaultMaxTrailLength is the default number of positions to keep per aircraft
-const DefaultMaxTrailLength = 20
+// DefaultRetention is how long trail points are kept by default
+const DefaultRetention = 5 * time.Minute
+
+// DefaultMaxPoints is the default budget for trail points across all
+// aircraft
+const DefaultMaxPoints = 20000
+
+// DefaultGapThreshold is the silence between consecutive points that starts
+// a new trail segment, as when an aircraft leaves coverage and returns
+const DefaultGapThreshold = 60 * time.Second
 
 // StaleTimeout is the duration after which a trail is considered stale
 const StaleTimeout = 5 * time.Minute
@@ -16,64 +28,147 @@ const StaleTimeout = 5 * time.Minute
 type Position struct {
 	Lat       float64
 	Lon       float64
+	Altitude  int
+	HasAlt    bool
 	Timestamp time.Time
+	Break     bool // first point after a position jump or coverage gap; not joined to the one before
+	Segment   int  // trail segment, incremented at each break
+}
+
+// positionSize is the memory held by one trail point
+const positionSize = int(unsafe.Sizeof(Position{}))
+
+// Stats describes the tracker's current memory use
+type Stats struct {
+	Trails int
+	Points int
+	Bytes  int
 }
 
-// TrailTracker manages position history for multiple aircraft
+// TrailTracker manages position history for multiple aircraft. Points are
+// kept for a fixed retention time, and the total across all aircraft is
+// capped so a busy sky can't grow memory without bound.
 type TrailTracker struct {
-	mu             sync.RWMutex
-	trails         map[string][]Position
-	lastSeen       map[string]time.Time
-	maxTrailLength int
+	mu        sync.RWMutex
+	trails    map[string][]Position
+	lastSeen  map[string]time.Time
+	flown     map[string]float64 // nm along each trail, kept past pruning
+	retention time.Duration
+	maxPoints int
+	gap       time.Duration
+	points    int
+	now       func() time.Time
 }
 
 // NewTrailTracker creates a new TrailTracker with default settings
 func NewTrailTracker() *TrailTracker {
-	return &TrailTracker{
-		trails:         make(map[string][]Position),
-		lastSeen:       make(map[string]time.Time),
-		maxTrailLength: DefaultMaxTrailLength,
-	}
+	return NewTrailTrackerWithRetention(DefaultRetention, DefaultMaxPoints)
 }
 
-// NewTrailTrackerWithLength creates a new TrailTracker with a custom max trail length
-func NewTrailTrackerWithLength(maxLength int) *TrailTracker {
-	if maxLength <= 0 {
-		maxLength = DefaultMaxTrailLength
+// NewTrailTrackerWithRetention creates a new TrailTracker keeping points for
+// retention, with at most maxPoints in total. Non-positive values use the
+// defaults.
+func NewTrailTrackerWithRetention(retention time.Duration, maxPoints int) *TrailTracker {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	if maxPoints <= 0 {
+		maxPoints = DefaultMaxPoints
 	}
 	return &TrailTracker{
-		trails:         make(map[string][]Position),
-		lastSeen:       make(map[string]time.Time),
-		maxTrailLength: maxLength,
+		trails:    make(map[string][]Position),
+		lastSeen:  make(map[string]time.Time),
+		flown:     make(map[string]float64),
+		retention: retention,
+		maxPoints: maxPoints,
+		gap:       DefaultGapThreshold,
+		now:       time.Now,
 	}
 }
 
-// SetMaxTrailLength updates the maximum trail length
-func (t *TrailTracker) SetMaxTrailLength(length int) {
-	if length <= 0 {
+// SetClock replaces the tracker's time source (for tests and for sharing
+// the application clock)
+func (t *TrailTracker) SetClock(now func() time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.now = now
+}
+
+// SetRetention updates how long points are kept and prunes older ones
+func (t *TrailTracker) SetRetention(retention time.Duration) {
+	if retention <= 0 {
 		return
 	}
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	t.maxTrailLength = length
+	t.retention = retention
+	t.pruneLocked()
+}
 
-	// Trim existing trails if necessary
-	for hex, trail := range t.trails {
-		if len(trail) > length {
-			t.trails[hex] = trail[len(trail)-length:]
-		}
+// Retention returns how long points are kept
+func (t *TrailTracker) Retention() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.retention
+}
+
+// SetMaxPoints updates the total point budget, evicting points if the
+// tracker is already over it
+func (t *TrailTracker) SetMaxPoints(maxPoints int) {
+	if maxPoints <= 0 {
+		return
 	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.maxPoints = maxPoints
+	t.enforceBudget()
 }
 
-// GetMaxTrailLength returns the current maximum trail length
-func (t *TrailTracker) GetMaxTrailLength() int {
+// MaxPoints returns the total point budget
+func (t *TrailTracker) MaxPoints() int {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
-	return t.maxTrailLength
+	return t.maxPoints
+}
+
+// SetGapThreshold updates the silence between points that starts a new
+// trail segment. Zero or less disables gap detection.
+func (t *TrailTracker) SetGapThreshold(gap time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.gap = gap
+}
+
+// GapThreshold returns the silence between points that starts a new trail
+// segment, or zero when gap detection is off
+func (t *TrailTracker) GapThreshold() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.gap < 0 {
+		return 0
+	}
+	return t.gap
 }
 
 // AddPosition adds a new position to an aircraft's trail
 func (t *TrailTracker) AddPosition(hex string, lat, lon float64) {
+	t.addPosition(hex, Position{Lat: lat, Lon: lon})
+}
+
+// AddPositionWithAltitude adds a new position carrying an altitude sample.
+// hasAlt=false records the point as a gap in the vertical profile.
+func (t *TrailTracker) AddPositionWithAltitude(hex string, lat, lon float64, altitude int, hasAlt bool) {
+	t.addPosition(hex, Position{Lat: lat, Lon: lon, Altitude: altitude, HasAlt: hasAlt})
+}
+
+// StartSegment adds a position that begins a new trail segment, so it is
+// not joined to the earlier points (used after a position jump)
+func (t *TrailTracker) StartSegment(hex string, lat, lon float64, altitude int, hasAlt bool) {
+	t.addPosition(hex, Position{Lat: lat, Lon: lon, Altitude: altitude, HasAlt: hasAlt, Break: true})
+}
+
+func (t *TrailTracker) addPosition(hex string, pos Position) {
+	hex = codec.NormalizeHex(hex)
 	if hex == "" {
 		return
 	}
@@ -81,81 +176,168 @@ func (t *TrailTracker) AddPosition(hex string, lat, lon float64) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	now := time.Now()
-	pos := Position{
-		Lat:       lat,
-		Lon:       lon,
-		Timestamp: now,
-	}
+	now := t.now()
+	pos.Timestamp = now
+	lat, lon := pos.Lat, pos.Lon
 
 	// Update last seen time
+	prevSeen := t.lastSeen[hex]
 	t.lastSeen[hex] = now
 
-	// Get existing trail or create new one
-	trail, exists := t.trails[hex]
-	if !exists {
-		t.trails[hex] = []Position{pos}
-		return
-	}
-
 	// Check if position has actually changed (avoid duplicates)
+	trail := t.trails[hex]
 	if len(trail) > 0 {
 		last := trail[len(trail)-1]
 		// Skip if position hasn't changed significantly (within ~100m)
 		if absFloat(last.Lat-lat) < 0.001 && absFloat(last.Lon-lon) < 0.001 {
 			return
 		}
+		// A long silence means the aircraft was out of coverage; don't
+		// join the points either side of it. Reports at an unchanged
+		// position count, so a parked aircraft isn't mistaken for one.
+		if t.gap > 0 && now.Sub(prevSeen) > t.gap {
+			pos.Break = true
+		}
+		pos.Segment = last.Segment
+		if pos.Break {
+			pos.Segment++
+		} else {
+			t.flown[hex] += distanceNM(last.Lat, last.Lon, lat, lon)
+		}
 	}
 
-	// Append new position
-	trail = append(trail, pos)
+	t.trails[hex] = append(trail, pos)
+	t.points++
+	t.enforceBudget()
+}
 
-	// Trim to max length if needed
-	if len(trail) > t.maxTrailLength {
-		trail = trail[len(trail)-t.maxTrailLength:]
+// Restore replaces an aircraft's trail with points saved from GetTrail,
+// as when loading a state file. The distance flown is counted along them.
+func (t *TrailTracker) Restore(hex string, points []Position) {
+	hex = codec.NormalizeHex(hex)
+	if hex == "" || len(points) == 0 {
+		return
 	}
 
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trail := append([]Position(nil), points...)
+	flown := 0.0
+	for i := 1; i < len(trail); i++ {
+		if !trail[i].Break {
+			flown += distanceNM(trail[i-1].Lat, trail[i-1].Lon, trail[i].Lat, trail[i].Lon)
+		}
+	}
+	t.points += len(trail) - len(t.trails[hex])
 	t.trails[hex] = trail
+	t.lastSeen[hex] = trail[len(trail)-1].Timestamp
+	t.flown[hex] = flown
+	t.enforceBudget()
+}
+
+// Prune drops points older than the retention time and enforces the point
+// budget. It returns the number of points removed.
+func (t *TrailTracker) Prune() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.pruneLocked()
 }
 
-// GetTrail returns the position history for an aircraft
+func (t *TrailTracker) pruneLocked() int {
+	before := t.points
+	cutoff := t.now().Add(-t.retention)
+	for hex, trail := range t.trails {
+		drop := firstWithin(trail, cutoff)
+		if drop == 0 {
+			continue
+		}
+		t.points -= drop
+		if drop == len(trail) {
+			// Keep the (empty) entry until the aircraft goes stale so
+			// lastSeen and trails stay in step
+			t.trails[hex] = nil
+			continue
+		}
+		t.trails[hex] = append([]Position(nil), trail[drop:]...)
+	}
+	t.enforceBudget()
+	return before - t.points
+}
+
+// enforceBudget drops the oldest point of the longest trail until the total
+// is within maxPoints, so short histories survive a crowded sky
+func (t *TrailTracker) enforceBudget() {
+	for t.points > t.maxPoints {
+		longest, n := "", 0
+		for hex, trail := range t.trails {
+			if len(trail) > n || (len(trail) == n && hex < longest) {
+				longest, n = hex, len(trail)
+			}
+		}
+		if n == 0 {
+			return
+		}
+		t.trails[longest] = t.trails[longest][1:]
+		t.points--
+	}
+}
+
+// firstWithin returns the index of the first point at or after cutoff;
+// trails are in chronological order
+func firstWithin(trail []Position, cutoff time.Time) int {
+	return sort.Search(len(trail), func(i int) bool {
+		return !trail[i].Timestamp.Before(cutoff)
+	})
+}
+
+// withinRetention copies the part of trail inside the retention window
+func (t *TrailTracker) withinRetention(trail []Position) []Position {
+	trail = trail[firstWithin(trail, t.now().Add(-t.retention)):]
+	result := make([]Position, len(trail))
+	copy(result, trail)
+	return result
+}
+
+// GetTrail returns the position history for an aircraft within the
+// retention window.
 // Returns positions in chronological order (oldest first)
 func (t *TrailTracker) GetTrail(hex string) []Position {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	trail, exists := t.trails[hex]
+	trail, exists := t.trails[codec.NormalizeHex(hex)]
 	if !exists {
 		return nil
 	}
 
-	// Return a copy to prevent external modification
-	result := make([]Position, len(trail))
-	copy(result, trail)
-	return result
+	// Return a copy to prevent external modification; points past the
+	// retention window are hidden even if they haven't been pruned yet
+	return t.withinRetention(trail)
 }
 
 // GetAllTrails returns all trails for all aircraft
-// Returns a map of hex -> positions
+// Returns a map of normalized hex -> positions
 func (t *TrailTracker) GetAllTrails() map[string][]Position {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
 	result := make(map[string][]Position, len(t.trails))
 	for hex, trail := range t.trails {
-		trailCopy := make([]Position, len(trail))
-		copy(trailCopy, trail)
-		result[hex] = trailCopy
+		result[hex] = t.withinRetention(trail)
 	}
 	return result
 }
 
 // RemoveTrail removes the trail for a specific aircraft
 func (t *TrailTracker) RemoveTrail(hex string) {
+	hex = codec.NormalizeHex(hex)
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	t.points -= len(t.trails[hex])
 	delete(t.trails, hex)
 	delete(t.lastSeen, hex)
+	delete(t.flown, hex)
 }
 
 // Cleanup removes stale trails (aircraft not seen in 5+ minutes)
@@ -163,13 +345,15 @@ func (t *TrailTracker) Cleanup() int {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	cutoff := time.Now().Add(-StaleTimeout)
+	cutoff := t.now().Add(-StaleTimeout)
 	removed := 0
 
 	for hex, lastSeen := range t.lastSeen {
 		if lastSeen.Before(cutoff) {
+			t.points -= len(t.trails[hex])
 			delete(t.trails, hex)
 			delete(t.lastSeen, hex)
+			delete(t.flown, hex)
 			removed++
 		}
 	}
@@ -182,13 +366,15 @@ func (t *TrailTracker) CleanupWithTimeout(timeout time.Duration) int {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	cutoff := time.Now().Add(-timeout)
+	cutoff := t.now().Add(-timeout)
 	removed := 0
 
 	for hex, lastSeen := range t.lastSeen {
 		if lastSeen.Before(cutoff) {
+			t.points -= len(t.trails[hex])
 			delete(t.trails, hex)
 			delete(t.lastSeen, hex)
+			delete(t.flown, hex)
 			removed++
 		}
 	}
@@ -202,6 +388,8 @@ func (t *TrailTracker) Clear() {
 	defer t.mu.Unlock()
 	t.trails = make(map[string][]Position)
 	t.lastSeen = make(map[string]time.Time)
+	t.flown = make(map[string]float64)
+	t.points = 0
 }
 
 // Count returns the number of aircraft being tracked
@@ -215,7 +403,24 @@ func (t *TrailTracker) Count() int {
 func (t *TrailTracker) TrailLength(hex string) int {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
-	return len(t.trails[hex])
+	return len(t.trails[codec.NormalizeHex(hex)])
+}
+
+// Flown returns the distance (nm) an aircraft has flown along its trail,
+// summed as points are added and not counting trail breaks. Points pruned
+// from the trail still count.
+func (t *TrailTracker) Flown(hex string) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.flown[codec.NormalizeHex(hex)]
+}
+
+// Stats returns the number of trails and points held and their approximate
+// memory use
+func (t *TrailTracker) Stats() Stats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return Stats{Trails: len(t.trails), Points: t.points, Bytes: t.points * positionSize}
 }
 
 // absFloat returns the absolute value of a float64
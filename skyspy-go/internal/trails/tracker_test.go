@@ -1,6 +1,7 @@
 package trails
 
 import (
+	"math"
 	"testing"
 	"time"
 )
@@ -10,27 +11,35 @@ func TestNewTrailTracker(t *testing.T) {
 	if tracker == nil {
 		t.Fatal("NewTrailTracker returned nil")
 	}
-	if tracker.GetMaxTrailLength() != DefaultMaxTrailLength {
-		t.Errorf("Expected max trail length %d, got %d", DefaultMaxTrailLength, tracker.GetMaxTrailLength())
+	if tracker.Retention() != DefaultRetention || tracker.MaxPoints() != DefaultMaxPoints {
+		t.Errorf("Expected defaults, got %v/%d", tracker.Retention(), tracker.MaxPoints())
 	}
 	if tracker.Count() != 0 {
 		t.Errorf("Expected 0 trails, got %d", tracker.Count())
 	}
 }
 
-func TestNewTrailTrackerWithLength(t *testing.T) {
-	tracker := NewTrailTrackerWithLength(10)
-	if tracker.GetMaxTrailLength() != 10 {
-		t.Errorf("Expected max trail length 10, got %d", tracker.GetMaxTrailLength())
+func TestNewTrailTrackerWithRetention(t *testing.T) {
+	tracker := NewTrailTrackerWithRetention(time.Minute, 100)
+	if tracker.Retention() != time.Minute || tracker.MaxPoints() != 100 {
+		t.Errorf("Expected 1m/100, got %v/%d", tracker.Retention(), tracker.MaxPoints())
 	}
 
-	// Test with invalid length
-	tracker = NewTrailTrackerWithLength(0)
-	if tracker.GetMaxTrailLength() != DefaultMaxTrailLength {
-		t.Errorf("Expected default max trail length for invalid input, got %d", tracker.GetMaxTrailLength())
+	// Invalid values fall back to the defaults
+	tracker = NewTrailTrackerWithRetention(0, -1)
+	if tracker.Retention() != DefaultRetention || tracker.MaxPoints() != DefaultMaxPoints {
+		t.Errorf("Expected defaults for invalid input, got %v/%d", tracker.Retention(), tracker.MaxPoints())
 	}
 }
 
+// newClockedTracker returns a tracker on a manual clock
+func newClockedTracker(retention time.Duration, maxPoints int) (*TrailTracker, *time.Time) {
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewTrailTrackerWithRetention(retention, maxPoints)
+	tracker.SetClock(func() time.Time { return clock })
+	return tracker, &clock
+}
+
 func TestAddPosition(t *testing.T) {
 	tracker := NewTrailTracker()
 
@@ -75,45 +84,131 @@ func TestDuplicatePositionFiltering(t *testing.T) {
 	}
 }
 
-func TestMaxTrailLength(t *testing.T) {
-	tracker := NewTrailTrackerWithLength(5)
+func TestRetention_KeepsPointsByAge(t *testing.T) {
+	tracker, clock := newClockedTracker(time.Minute, 1000)
+
+	// A fast-updating aircraft keeps all of its recent points, however many
+	for i := 0; i < 50; i++ {
+		tracker.AddPosition("FAST01", float64(i), 0)
+		*clock = clock.Add(time.Second)
+	}
+	if got := tracker.TrailLength("FAST01"); got != 50 {
+		t.Errorf("Expected 50 points within retention, got %d", got)
+	}
+
+	// 30s later the first 20 points have aged out
+	*clock = clock.Add(30 * time.Second)
+	if removed := tracker.Prune(); removed != 20 {
+		t.Errorf("Expected 20 points pruned, got %d", removed)
+	}
+	trail := tracker.GetTrail("FAST01")
+	if len(trail) != 30 || trail[0].Lat != 20 {
+		t.Errorf("Expected 30 points from Lat=20, got %d from %v", len(trail), trail[0].Lat)
+	}
+	if st := tracker.Stats(); st.Points != 30 || st.Bytes != 30*positionSize {
+		t.Errorf("Unexpected stats %+v", st)
+	}
+
+	// Once everything is old the trail empties but stays tracked until stale
+	*clock = clock.Add(2 * time.Minute)
+	tracker.Prune()
+	if tracker.TrailLength("FAST01") != 0 || tracker.Count() != 1 {
+		t.Errorf("Expected empty trail, got %d points in %d trails", tracker.TrailLength("FAST01"), tracker.Count())
+	}
+}
+
+func TestRetention_ReadsHideExpiredPoints(t *testing.T) {
+	tracker, clock := newClockedTracker(time.Minute, 1000)
+	tracker.AddPosition("ABC123", 1, 1)
+	*clock = clock.Add(45 * time.Second)
+	tracker.AddPosition("ABC123", 2, 2)
 
-	// Add more positions than max
+	// Past retention but not yet pruned: reads only return the window
+	*clock = clock.Add(30 * time.Second)
+	if trail := tracker.GetTrail("ABC123"); len(trail) != 1 || trail[0].Lat != 2 {
+		t.Errorf("Expected only the recent point, got %v", trail)
+	}
+	if trail := tracker.GetAllTrails()["ABC123"]; len(trail) != 1 {
+		t.Errorf("Expected only the recent point, got %v", trail)
+	}
+	if tracker.Stats().Points != 2 {
+		t.Error("Reads should not prune")
+	}
+}
+
+func TestSetRetention(t *testing.T) {
+	tracker, clock := newClockedTracker(10*time.Minute, 1000)
 	for i := 0; i < 10; i++ {
-		tracker.AddPosition("ABC123", float64(i), float64(i))
+		tracker.AddPosition("ABC123", float64(i), 0)
+		*clock = clock.Add(time.Minute)
 	}
 
-	trail := tracker.GetTrail("ABC123")
-	if len(trail) != 5 {
-		t.Errorf("Expected 5 positions (max length), got %d", len(trail))
+	tracker.SetRetention(5 * time.Minute)
+	if got := tracker.Stats().Points; got != 5 {
+		t.Errorf("Expected 5 points after shortening retention, got %d", got)
 	}
 
-	// Verify oldest positions were removed (should have 5,6,7,8,9)
-	if trail[0].Lat != 5 {
-		t.Errorf("Expected oldest position to have Lat=5, got %f", trail[0].Lat)
+	// Invalid retention should be ignored
+	tracker.SetRetention(0)
+	if tracker.Retention() != 5*time.Minute {
+		t.Errorf("Expected retention unchanged for invalid input")
 	}
 }
 
-func TestSetMaxTrailLength(t *testing.T) {
-	tracker := NewTrailTracker()
+func TestBudget_EvictsOldestOfLongestTrail(t *testing.T) {
+	tracker, clock := newClockedTracker(time.Hour, 10)
 
-	// Add some positions
-	for i := 0; i < 15; i++ {
-		tracker.AddPosition("ABC123", float64(i), float64(i))
+	for i := 0; i < 7; i++ {
+		tracker.AddPosition("LONG01", float64(i), 0)
+		*clock = clock.Add(time.Second)
+	}
+	for i := 0; i < 3; i++ {
+		tracker.AddPosition("SHORT1", float64(i), 1)
+		*clock = clock.Add(time.Second)
 	}
 
-	// Reduce max length
-	tracker.SetMaxTrailLength(5)
+	// Two more points put the tracker over budget; the long trail pays
+	tracker.AddPosition("SHORT1", 10, 1)
+	tracker.AddPosition("NEW001", 0, 2)
+	if st := tracker.Stats(); st.Points != 10 {
+		t.Errorf("Expected budget of 10 points, got %d", st.Points)
+	}
+	long := tracker.GetTrail("LONG01")
+	if len(long) != 5 || long[0].Lat != 2 {
+		t.Errorf("Expected long trail trimmed from the front to 5, got %d from %v", len(long), long[0].Lat)
+	}
+	if tracker.TrailLength("SHORT1") != 4 || tracker.TrailLength("NEW001") != 1 {
+		t.Errorf("Shorter trails should be untouched, got %d/%d", tracker.TrailLength("SHORT1"), tracker.TrailLength("NEW001"))
+	}
 
-	trail := tracker.GetTrail("ABC123")
-	if len(trail) != 5 {
-		t.Errorf("Expected 5 positions after reducing max length, got %d", len(trail))
+	// Shrinking the budget takes turns between the longest trails so they
+	// even out (5/4/1 -> 2/3/1)
+	tracker.SetMaxPoints(6)
+	if tracker.TrailLength("LONG01") != 2 || tracker.TrailLength("SHORT1") != 3 || tracker.TrailLength("NEW001") != 1 {
+		t.Errorf("Expected 2/3/1 after shrinking budget, got %d/%d/%d",
+			tracker.TrailLength("LONG01"), tracker.TrailLength("SHORT1"), tracker.TrailLength("NEW001"))
 	}
 
-	// Invalid length should be ignored
-	tracker.SetMaxTrailLength(0)
-	if tracker.GetMaxTrailLength() != 5 {
-		t.Errorf("Expected max length unchanged for invalid input")
+	// Invalid budget should be ignored
+	tracker.SetMaxPoints(0)
+	if tracker.MaxPoints() != 6 {
+		t.Errorf("Expected budget unchanged for invalid input")
+	}
+}
+
+func TestStats_TrackRemoval(t *testing.T) {
+	tracker := NewTrailTracker()
+	tracker.AddPosition("ABC123", 1, 1)
+	tracker.AddPosition("ABC123", 2, 2)
+	tracker.AddPosition("DEF456", 1, 1)
+
+	tracker.RemoveTrail("ABC123")
+	if st := tracker.Stats(); st.Points != 1 || st.Trails != 1 {
+		t.Errorf("Unexpected stats after removal %+v", st)
+	}
+	tracker.Clear()
+	if st := tracker.Stats(); st.Points != 0 || st.Trails != 0 {
+		t.Errorf("Unexpected stats after clear %+v", st)
 	}
 }
 
@@ -262,3 +357,160 @@ func TestGetTrailReturnsNilForNonexistent(t *testing.T) {
 		t.Error("Expected nil for non-existent aircraft")
 	}
 }
+
+func TestAddPositionWithAltitude(t *testing.T) {
+	tracker := NewTrailTracker()
+
+	tracker.AddPositionWithAltitude("ALT001", 51.5, -0.1, 12000, true)
+	tracker.AddPositionWithAltitude("ALT001", 51.6, -0.2, 0, false)
+
+	trail := tracker.GetTrail("ALT001")
+	if len(trail) != 2 {
+		t.Fatalf("Expected 2 positions, got %d", len(trail))
+	}
+	if !trail[0].HasAlt || trail[0].Altitude != 12000 {
+		t.Errorf("Expected first point at 12000ft, got %d (has=%v)", trail[0].Altitude, trail[0].HasAlt)
+	}
+	if trail[1].HasAlt {
+		t.Error("Expected second point to have no altitude")
+	}
+
+	// Plain AddPosition records no altitude
+	tracker.AddPosition("ALT002", 40.0, -70.0)
+	if tracker.GetTrail("ALT002")[0].HasAlt {
+		t.Error("AddPosition should not record an altitude")
+	}
+}
+
+func TestGapStartsNewSegment(t *testing.T) {
+	tracker, clock := newClockedTracker(10*time.Minute, 1000)
+
+	tracker.AddPosition("GAP001", 51.50, -0.10)
+	*clock = clock.Add(5 * time.Second)
+	tracker.AddPosition("GAP001", 51.51, -0.11)
+
+	// Out of coverage for two minutes, then back
+	*clock = clock.Add(2 * time.Minute)
+	tracker.AddPosition("GAP001", 51.70, -0.30)
+	*clock = clock.Add(5 * time.Second)
+	tracker.AddPosition("GAP001", 51.71, -0.31)
+
+	trail := tracker.GetTrail("GAP001")
+	if len(trail) != 4 {
+		t.Fatalf("Expected 4 positions, got %d", len(trail))
+	}
+	wantBreak := []bool{false, false, true, false}
+	wantSegment := []int{0, 0, 1, 1}
+	for i, pos := range trail {
+		if pos.Break != wantBreak[i] || pos.Segment != wantSegment[i] {
+			t.Errorf("point %d: break=%v segment=%d, want break=%v segment=%d",
+				i, pos.Break, pos.Segment, wantBreak[i], wantSegment[i])
+		}
+	}
+}
+
+func TestGapIgnoresStationaryReports(t *testing.T) {
+	tracker, clock := newClockedTracker(10*time.Minute, 1000)
+
+	// A parked aircraft keeps reporting the same position
+	tracker.AddPosition("GAP002", 51.50, -0.10)
+	for i := 0; i < 12; i++ {
+		*clock = clock.Add(10 * time.Second)
+		tracker.AddPosition("GAP002", 51.50, -0.10)
+	}
+	*clock = clock.Add(10 * time.Second)
+	tracker.AddPosition("GAP002", 51.51, -0.11)
+
+	if trail := tracker.GetTrail("GAP002"); trail[len(trail)-1].Break {
+		t.Error("Movement after continuous reports should not start a segment")
+	}
+}
+
+func TestSetGapThreshold(t *testing.T) {
+	tracker, clock := newClockedTracker(10*time.Minute, 1000)
+	if got := tracker.GapThreshold(); got != DefaultGapThreshold {
+		t.Errorf("Expected default gap %v, got %v", DefaultGapThreshold, got)
+	}
+
+	tracker.SetGapThreshold(0)
+	if got := tracker.GapThreshold(); got != 0 {
+		t.Errorf("Expected gap detection off, got %v", got)
+	}
+	tracker.AddPosition("GAP003", 51.50, -0.10)
+	*clock = clock.Add(5 * time.Minute)
+	tracker.AddPosition("GAP003", 51.60, -0.20)
+	if tracker.GetTrail("GAP003")[1].Break {
+		t.Error("Gap should not break the trail when detection is off")
+	}
+
+	// Explicit segments still count
+	tracker.StartSegment("GAP003", 52.0, -1.0, 0, false)
+	if trail := tracker.GetTrail("GAP003"); trail[2].Segment != 1 {
+		t.Errorf("Expected StartSegment to begin segment 1, got %d", trail[2].Segment)
+	}
+}
+
+func TestFlownSkipsBreaksAndSurvivesPruning(t *testing.T) {
+	tracker, clock := newClockedTracker(time.Minute, 1000)
+
+	// 0.1° of latitude is 6nm
+	tracker.AddPosition("FLY001", 51.0, 0)
+	*clock = clock.Add(10 * time.Second)
+	tracker.AddPosition("FLY001", 51.1, 0)
+	*clock = clock.Add(10 * time.Second)
+	tracker.AddPosition("FLY001", 51.2, 0)
+	if got := tracker.Flown("fly001"); math.Abs(got-12) > 0.05 {
+		t.Fatalf("Flown = %.2f, want 12", got)
+	}
+
+	// Neither a coverage gap nor a position jump counts
+	*clock = clock.Add(2 * time.Minute)
+	tracker.AddPosition("FLY001", 52.0, 0)
+	tracker.StartSegment("FLY001", 53.0, 0, 0, false)
+	*clock = clock.Add(10 * time.Second)
+	tracker.AddPosition("FLY001", 53.1, 0)
+	if got := tracker.Flown("FLY001"); math.Abs(got-18) > 0.05 {
+		t.Fatalf("Flown = %.2f, want 18 without the breaks", got)
+	}
+
+	// Points pruned from the trail still count
+	tracker.Prune()
+	if got := tracker.Flown("FLY001"); math.Abs(got-18) > 0.05 {
+		t.Errorf("Flown = %.2f after pruning, want 18", got)
+	}
+
+	tracker.RemoveTrail("FLY001")
+	if tracker.Flown("FLY001") != 0 {
+		t.Error("Removing the trail should reset the distance")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tracker, clock := newClockedTracker(time.Minute, 1000)
+	tracker.AddPosition("FLY001", 40.0, 0)
+
+	saved := []Position{
+		{Lat: 51.0, Timestamp: clock.Add(-20 * time.Second)},
+		{Lat: 51.1, Timestamp: clock.Add(-10 * time.Second)},
+		{Lat: 53.0, Timestamp: *clock, Break: true, Segment: 1},
+	}
+	tracker.Restore("fly001", saved)
+
+	got := tracker.GetTrail("FLY001")
+	if len(got) != 3 || got[2] != saved[2] {
+		t.Fatalf("restored trail = %+v", got)
+	}
+	if stats := tracker.Stats(); stats.Points != 3 {
+		t.Errorf("points = %d, want the restored trail's 3", stats.Points)
+	}
+	if flown := tracker.Flown("FLY001"); math.Abs(flown-6) > 0.05 {
+		t.Errorf("Flown = %.2f, want 6 without the break", flown)
+	}
+
+	// The restored trail carries on from its last point
+	*clock = clock.Add(5 * time.Second)
+	tracker.AddPosition("FLY001", 53.1, 0)
+	if got := tracker.GetTrail("FLY001"); len(got) != 4 || got[3].Break || got[3].Segment != 1 {
+		t.Errorf("the next point should join the restored trail: %+v", got)
+	}
+}
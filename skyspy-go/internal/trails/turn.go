@@ -0,0 +1,197 @@
+// Package trails provides aircraft trail/history tracking functionality
+package trails
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// TurnWindow is how much track history is used to estimate the turn rate
+const TurnWindow = 20 * time.Second
+
+// HoldDuration is how long an aircraft must keep turning the same way before
+// it is flagged as a possible holding pattern
+const HoldDuration = 2 * time.Minute
+
+const (
+	// turnMinSpan is the shortest history that gives a usable rate
+	turnMinSpan = 5 * time.Second
+	// turnEnterRate and turnExitRate (deg/s) give the classification
+	// hysteresis: a turn starts above the first and ends below the second
+	turnEnterRate = 1.0
+	turnExitRate  = 0.5
+	// holdLegGrace is the longest straight leg that does not break a hold,
+	// so racetrack patterns count as well as orbits
+	holdLegGrace = 75 * time.Second
+)
+
+// TurnState classifies an aircraft's recent ground track
+type TurnState int
+
+// Turn states
+const (
+	TurnStraight TurnState = iota
+	TurnLeft
+	TurnRight
+)
+
+// TurnInfo is the turn classification for one aircraft
+type TurnInfo struct {
+	State   TurnState
+	Rate    float64 // deg/s, positive is a right (clockwise) turn
+	Holding bool
+}
+
+type trackSample struct {
+	track float64
+	at    time.Time
+}
+
+type turnHistory struct {
+	samples []trackSample
+	info    TurnInfo
+	known   bool
+
+	// Current run of same-direction turning, for hold detection
+	runState    TurnState
+	runStart    time.Time
+	lastTurning time.Time
+}
+
+// TurnTracker keeps a short ground track history per aircraft and classifies
+// it as straight, turning left or turning right
+type TurnTracker struct {
+	mu      sync.RWMutex
+	history map[string]*turnHistory
+}
+
+// NewTurnTracker creates an empty TurnTracker
+func NewTurnTracker() *TurnTracker {
+	return &TurnTracker{
+		history: make(map[string]*turnHistory),
+	}
+}
+
+// AddTrack records a track sample (degrees) taken at the given time and
+// returns the updated classification
+func (t *TurnTracker) AddTrack(hex string, track float64, at time.Time) TurnInfo {
+	if hex == "" {
+		return TurnInfo{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.history[hex]
+	if !ok {
+		h = &turnHistory{}
+		t.history[hex] = h
+	}
+	if n := len(h.samples); n > 0 && !at.After(h.samples[n-1].at) {
+		return h.info
+	}
+
+	h.samples = append(h.samples, trackSample{track: track, at: at})
+	for len(h.samples) > 1 && at.Sub(h.samples[0].at) > TurnWindow {
+		h.samples = h.samples[1:]
+	}
+
+	first := h.samples[0]
+	span := at.Sub(first.at)
+	if span < turnMinSpan {
+		return h.info
+	}
+
+	var turned float64
+	for i := 1; i < len(h.samples); i++ {
+		turned += trackDelta(h.samples[i-1].track, h.samples[i].track)
+	}
+	rate := turned / span.Seconds()
+
+	h.known = true
+	h.info.Rate = rate
+	h.info.State = classifyTurn(h.info.State, rate)
+	h.updateHold(at)
+	return h.info
+}
+
+// Get returns the classification for hex. ok is false until enough history
+// has been seen, or once the history is older than TurnWindow.
+func (t *TurnTracker) Get(hex string, now time.Time) (TurnInfo, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	h, exists := t.history[hex]
+	if !exists || !h.known || now.Sub(h.samples[len(h.samples)-1].at) > TurnWindow {
+		return TurnInfo{}, false
+	}
+	return h.info, true
+}
+
+// Latest returns the most recent classification for hex regardless of age
+func (t *TurnTracker) Latest(hex string) (TurnInfo, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	h, exists := t.history[hex]
+	if !exists || !h.known {
+		return TurnInfo{}, false
+	}
+	return h.info, true
+}
+
+// Remove discards the history for hex
+func (t *TurnTracker) Remove(hex string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.history, hex)
+}
+
+// Count returns the number of aircraft with track history
+func (t *TurnTracker) Count() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.history)
+}
+
+// classifyTurn applies the hysteresis thresholds to the previous state
+func classifyTurn(prev TurnState, rate float64) TurnState {
+	switch {
+	case rate >= turnEnterRate:
+		return TurnRight
+	case rate <= -turnEnterRate:
+		return TurnLeft
+	case prev == TurnRight && rate >= turnExitRate:
+		return TurnRight
+	case prev == TurnLeft && rate <= -turnExitRate:
+		return TurnLeft
+	default:
+		return TurnStraight
+	}
+}
+
+// updateHold tracks how long the aircraft has kept turning the same way,
+// tolerating short straight legs between turns
+func (h *turnHistory) updateHold(at time.Time) {
+	if h.info.State != TurnStraight {
+		if h.info.State != h.runState || at.Sub(h.lastTurning) > holdLegGrace {
+			h.runState = h.info.State
+			h.runStart = at
+		}
+		h.lastTurning = at
+	}
+
+	h.info.Holding = h.runState != TurnStraight &&
+		at.Sub(h.lastTurning) <= holdLegGrace &&
+		at.Sub(h.runStart) > HoldDuration
+}
+
+// trackDelta returns the signed change from a to b in degrees, in (-180, 180]
+func trackDelta(a, b float64) float64 {
+	d := math.Mod(b-a+540, 360) - 180
+	if d == -180 {
+		return 180
+	}
+	return d
+}
@@ -0,0 +1,182 @@
+package trails
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// feedTurn adds one sample per second for the given duration, turning at
+// rate deg/s with an optional alternating noise amplitude, and returns the
+// final track, time and classification
+func feedTurn(tracker *TurnTracker, track float64, start time.Time, d time.Duration, rate, noise float64) (float64, time.Time, TurnInfo) {
+	var info TurnInfo
+	at := start
+	for i := 0; i < int(d.Seconds()); i++ {
+		at = at.Add(time.Second)
+		track = math.Mod(track+rate+360, 360)
+		jitter := noise
+		if i%2 == 0 {
+			jitter = -noise
+		}
+		info = tracker.AddTrack("ABC123", math.Mod(track+jitter+360, 360), at)
+	}
+	return track, at, info
+}
+
+func TestTurnTracker_StraightWithNoise(t *testing.T) {
+	tracker := NewTurnTracker()
+	start := time.Now()
+
+	var info TurnInfo
+	at := start
+	for i := 0; i < 120; i++ {
+		at = at.Add(time.Second)
+		info = tracker.AddTrack("ABC123", 90+1.5*float64(i%3-1), at)
+		if info.State != TurnStraight {
+			t.Fatalf("noisy straight track classified as %v at %ds (rate %.2f)", info.State, i, info.Rate)
+		}
+	}
+	if info.Holding {
+		t.Error("straight flight should never be holding")
+	}
+}
+
+func TestTurnTracker_RightTurn(t *testing.T) {
+	tracker := NewTurnTracker()
+	_, _, info := feedTurn(tracker, 0, time.Now(), 25*time.Second, 3, 0)
+
+	if info.State != TurnRight {
+		t.Fatalf("expected right turn, got %v", info.State)
+	}
+	if math.Abs(info.Rate-3) > 0.01 {
+		t.Errorf("expected 3 deg/s, got %.2f", info.Rate)
+	}
+}
+
+func TestTurnTracker_LeftTurnAcrossNorth(t *testing.T) {
+	tracker := NewTurnTracker()
+	_, _, info := feedTurn(tracker, 20, time.Now(), 25*time.Second, -2, 0)
+
+	if info.State != TurnLeft {
+		t.Fatalf("expected left turn through 360, got %v", info.State)
+	}
+	if math.Abs(info.Rate+2) > 0.01 {
+		t.Errorf("expected -2 deg/s, got %.2f", info.Rate)
+	}
+}
+
+func TestTurnTracker_Hysteresis(t *testing.T) {
+	tracker := NewTurnTracker()
+	track, at, info := feedTurn(tracker, 0, time.Now(), 30*time.Second, 3, 0)
+	if info.State != TurnRight {
+		t.Fatalf("expected right turn, got %v", info.State)
+	}
+
+	// Shallowing below the entry rate but above the exit rate keeps the turn
+	track, at, info = feedTurn(tracker, track, at, 30*time.Second, 0.7, 0.5)
+	if info.State != TurnRight {
+		t.Fatalf("expected turn to persist at 0.7 deg/s, got %v (rate %.2f)", info.State, info.Rate)
+	}
+
+	_, _, info = feedTurn(tracker, track, at, 30*time.Second, 0.2, 0)
+	if info.State != TurnStraight {
+		t.Errorf("expected straight after rolling out, got %v", info.State)
+	}
+}
+
+func TestTurnTracker_NoEntryBelowThreshold(t *testing.T) {
+	tracker := NewTurnTracker()
+	_, _, info := feedTurn(tracker, 0, time.Now(), 60*time.Second, 0.7, 0)
+
+	if info.State != TurnStraight {
+		t.Errorf("a gentle drift should not start a turn, got %v", info.State)
+	}
+}
+
+func TestTurnTracker_OrbitIsHolding(t *testing.T) {
+	tracker := NewTurnTracker()
+
+	track, at, info := feedTurn(tracker, 0, time.Now(), 90*time.Second, 3, 0)
+	if info.Holding {
+		t.Fatal("should not be holding after 90s of turning")
+	}
+
+	_, _, info = feedTurn(tracker, track, at, 90*time.Second, 3, 0)
+	if !info.Holding {
+		t.Error("expected sustained orbit to be flagged as holding")
+	}
+}
+
+func TestTurnTracker_RacetrackIsHolding(t *testing.T) {
+	tracker := NewTurnTracker()
+	start := time.Now()
+
+	track, at, _ := feedTurn(tracker, 0, start, time.Minute, 3, 0)
+	track, at, info := feedTurn(tracker, track, at, time.Minute, 0, 0.5)
+	if info.State != TurnStraight {
+		t.Fatalf("expected straight on the outbound leg, got %v", info.State)
+	}
+	_, _, info = feedTurn(tracker, track, at, 40*time.Second, 3, 0)
+
+	if info.State != TurnRight || !info.Holding {
+		t.Errorf("expected racetrack to be flagged as holding, got %+v", info)
+	}
+}
+
+func TestTurnTracker_SingleTurnIsNotHolding(t *testing.T) {
+	tracker := NewTurnTracker()
+	track, at, _ := feedTurn(tracker, 0, time.Now(), 30*time.Second, 3, 0)
+	_, _, info := feedTurn(tracker, track, at, 3*time.Minute, 0, 0)
+
+	if info.Holding || info.State != TurnStraight {
+		t.Errorf("a single 90 degree turn is not a hold, got %+v", info)
+	}
+}
+
+func TestTurnTracker_Get(t *testing.T) {
+	tracker := NewTurnTracker()
+	start := time.Now()
+
+	tracker.AddTrack("ABC123", 0, start)
+	if _, ok := tracker.Get("ABC123", start); ok {
+		t.Error("classification needs more than one sample")
+	}
+
+	_, at, _ := feedTurn(tracker, 0, start, 10*time.Second, 3, 0)
+	if info, ok := tracker.Get("ABC123", at); !ok || info.State != TurnRight {
+		t.Errorf("expected right turn, got %+v (ok=%v)", info, ok)
+	}
+	if _, ok := tracker.Get("ABC123", at.Add(TurnWindow+time.Second)); ok {
+		t.Error("stale history should not be reported")
+	}
+
+	tracker.Remove("ABC123")
+	if tracker.Count() != 0 {
+		t.Errorf("expected history removed, got %d", tracker.Count())
+	}
+}
+
+func TestTurnTracker_IgnoresOutOfOrderSamples(t *testing.T) {
+	tracker := NewTurnTracker()
+	_, at, before := feedTurn(tracker, 0, time.Now(), 25*time.Second, 3, 0)
+
+	after := tracker.AddTrack("ABC123", 200, at.Add(-5*time.Second))
+	if after != before {
+		t.Errorf("out-of-order sample changed the classification: %+v -> %+v", before, after)
+	}
+}
+
+func TestTrackDelta(t *testing.T) {
+	cases := []struct{ a, b, want float64 }{
+		{10, 20, 10},
+		{350, 10, 20},
+		{10, 350, -20},
+		{0, 180, 180},
+	}
+	for _, c := range cases {
+		if got := trackDelta(c.a, c.b); got != c.want {
+			t.Errorf("trackDelta(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package ui provides reusable UI components for SkySpy applications
+package ui
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+// ChartLabelWidth is the width of a chart's scale label column plus its
+// axis
+const ChartLabelWidth = 6
+
+// Chart renders a time series as columns of block characters, an eighth
+// of a row at a time, scaled from zero to the highest sample. The newest
+// sample is in the rightmost column.
+type Chart struct {
+	Width  int // plot columns, excluding the scale labels
+	Height int // plot rows
+	Theme  *theme.Theme
+}
+
+// NewChart creates a chart of width columns and height rows
+func NewChart(t *theme.Theme, width, height int) *Chart {
+	return &Chart{Width: width, Height: height, Theme: t}
+}
+
+// TotalWidth returns the rendered line width including the label column
+func (c *Chart) TotalWidth() int {
+	return ChartLabelWidth + c.Width
+}
+
+// Columns fits samples, oldest first, to the chart's width. With more
+// samples than columns each column takes the highest of the samples it
+// covers, so short peaks still show; with fewer, they fill the rightmost
+// columns one each.
+func (c *Chart) Columns(samples []float64) []float64 {
+	if c.Width <= 0 || len(samples) <= c.Width {
+		return samples
+	}
+	cols := make([]float64, c.Width)
+	for i := range cols {
+		from := i * len(samples) / c.Width
+		to := max((i+1)*len(samples)/c.Width, from+1)
+		peak := samples[from]
+		for _, v := range samples[from+1 : to] {
+			peak = math.Max(peak, v)
+		}
+		cols[i] = peak
+	}
+	return cols
+}
+
+// Render returns Height lines of TotalWidth cells. The top of the scale
+// is labelled with the highest sample, the bottom with zero.
+func (c *Chart) Render(samples []float64) []string {
+	if c.Width <= 0 || c.Height <= 0 {
+		return nil
+	}
+
+	barStyle := lipgloss.NewStyle().Foreground(c.Theme.PrimaryBright)
+	axisStyle := lipgloss.NewStyle().Foreground(c.Theme.RadarRing)
+	labelStyle := lipgloss.NewStyle().Foreground(c.Theme.TextDim)
+	g := c.Theme.GlyphSet()
+	levels := len(g.BarLevels)
+
+	cols := c.Columns(samples)
+	top := 0.0
+	for _, v := range cols {
+		top = math.Max(top, v)
+	}
+	// Each column's height in eighths of a row
+	eighths := make([]int, c.Width)
+	offset := c.Width - len(cols)
+	for i, v := range cols {
+		if top > 0 && v > 0 {
+			eighths[offset+i] = max(1, int(math.Round(v/top*float64(c.Height*levels))))
+		}
+	}
+
+	lines := make([]string, c.Height)
+	for y := 0; y < c.Height; y++ {
+		var sb strings.Builder
+		label := ""
+		switch y {
+		case 0:
+			label = ChartValue(top)
+		case c.Height - 1:
+			label = "0"
+		}
+		sb.WriteString(labelStyle.Render(padLeft(label, ChartLabelWidth-1)))
+		sb.WriteString(axisStyle.Render(g.V))
+
+		// Eighths of this row filled, counting up from its bottom
+		base := (c.Height - 1 - y) * levels
+		for x := 0; x < c.Width; x++ {
+			switch fill := eighths[x] - base; {
+			case fill >= levels:
+				sb.WriteString(barStyle.Render(g.BarFull))
+			case fill > 0:
+				sb.WriteString(barStyle.Render(g.BarLevels[fill-1]))
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		lines[y] = sb.String()
+	}
+	return lines
+}
+
+// ChartValue writes a value in at most five characters: whole numbers up
+// to 9999, tenths below 10, and thousands as "12.3k"
+func ChartValue(v float64) string {
+	switch {
+	case v >= 9999.5:
+		return strconv.FormatFloat(v/1000, 'f', 1, 64) + "k"
+	case v < 10 && v != math.Trunc(v):
+		return strconv.FormatFloat(v, 'f', 1, 64)
+	default:
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+}
+
+// padLeft right-aligns s in width cells
+func padLeft(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return strings.Repeat(" ", width-w) + s
+	}
+	return s
+}
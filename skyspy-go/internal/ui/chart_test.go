@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+func TestChart_Columns(t *testing.T) {
+	c := NewChart(theme.Get("classic"), 4, 3)
+
+	few := []float64{1, 2}
+	if got := c.Columns(few); len(got) != 2 {
+		t.Errorf("fewer samples than columns should be kept, got %v", got)
+	}
+
+	// Each column keeps the peak of the samples it covers
+	many := []float64{1, 9, 2, 3, 4, 4, 0, 7}
+	got := c.Columns(many)
+	want := []float64{9, 3, 4, 7}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Columns() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestChart_Render(t *testing.T) {
+	th := theme.Get("classic")
+	c := NewChart(th, 10, 3)
+	lines := c.Render([]float64{0, 12, 24})
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w != c.TotalWidth() {
+			t.Errorf("line %d is %d wide, want %d", i, w, c.TotalWidth())
+		}
+	}
+
+	plain := make([]string, len(lines))
+	for i, line := range lines {
+		plain[i] = ansi.Strip(line)
+	}
+	if !strings.HasPrefix(plain[0], "   24") || !strings.HasPrefix(plain[2], "    0") {
+		t.Errorf("the scale should run from 0 to 24:\n%s", strings.Join(plain, "\n"))
+	}
+
+	// The newest sample fills the last column; half of it reaches the
+	// middle of the chart; zero draws nothing
+	g := th.GlyphSet()
+	for _, line := range plain {
+		if !strings.HasSuffix(line, g.BarFull) {
+			t.Errorf("the highest sample should fill its column: %q", line)
+		}
+	}
+	col := func(y, x int) string { return string([]rune(plain[y])[ChartLabelWidth+x]) }
+	if col(2, 8) != g.BarFull || col(1, 8) != g.BarLevels[3] || col(0, 8) != " " {
+		t.Errorf("half the scale should fill a row and a half:\n%s", strings.Join(plain, "\n"))
+	}
+	if col(2, 7) != " " || col(2, 0) != " " {
+		t.Errorf("zero and missing samples should be empty:\n%s", strings.Join(plain, "\n"))
+	}
+}
+
+func TestChart_RenderEmpty(t *testing.T) {
+	c := NewChart(theme.Get("classic"), 8, 2)
+	lines := c.Render(nil)
+	if len(lines) != 2 || strings.TrimSpace(ansi.Strip(lines[1])) != "0"+theme.Get("classic").GlyphSet().V {
+		t.Errorf("an empty chart should still draw its axis: %q", lines)
+	}
+	if NewChart(theme.Get("classic"), 0, 2).Render([]float64{1}) != nil {
+		t.Error("a chart without width should draw nothing")
+	}
+}
+
+func TestChartValue(t *testing.T) {
+	tests := map[float64]string{
+		0:     "0",
+		2.5:   "2.5",
+		7:     "7",
+		42.4:  "42",
+		9999:  "9999",
+		12345: "12.3k",
+	}
+	for v, want := range tests {
+		if got := ChartValue(v); got != want {
+			t.Errorf("ChartValue(%v) = %q, want %q", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Package ui provides reusable UI components for SkySpy applications
+package ui
+
+// HitRegion is a rectangle of terminal cells that answers to the mouse
+type HitRegion struct {
+	ID     string // what was hit, chosen by whoever added the region
+	X, Y   int    // top-left cell
+	Width  int
+	Height int
+}
+
+// Contains reports whether the cell at x, y is inside the region
+func (r HitRegion) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// HitMap records the clickable regions of a render. Views are built from
+// panels rendered on their own, so each panel adds regions relative to its
+// own top-left corner and whoever places the panel shifts them into place:
+//
+//	mark := hits.Len()
+//	panel := renderPanel(&hits)
+//	hits.ShiftFrom(mark, panelX, panelY)
+type HitMap struct {
+	regions []HitRegion
+}
+
+// Reset forgets every region, ready for the next render
+func (h *HitMap) Reset() {
+	h.regions = h.regions[:0]
+}
+
+// Add records a region; empty regions are ignored
+func (h *HitMap) Add(id string, x, y, width, height int) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	h.regions = append(h.regions, HitRegion{ID: id, X: x, Y: y, Width: width, Height: height})
+}
+
+// Len returns how many regions are recorded, to mark where a panel's
+// regions begin
+func (h *HitMap) Len() int {
+	return len(h.regions)
+}
+
+// ShiftFrom moves the regions added since mark by dx, dy
+func (h *HitMap) ShiftFrom(mark, dx, dy int) {
+	if mark < 0 {
+		mark = 0
+	}
+	for i := mark; i < len(h.regions); i++ {
+		h.regions[i].X += dx
+		h.regions[i].Y += dy
+	}
+}
+
+// At returns the ID of the region under the cell at x, y. Regions added
+// later are drawn over earlier ones, so they win where they overlap.
+func (h *HitMap) At(x, y int) (string, bool) {
+	for i := len(h.regions) - 1; i >= 0; i-- {
+		if h.regions[i].Contains(x, y) {
+			return h.regions[i].ID, true
+		}
+	}
+	return "", false
+}
+
+// Regions returns a copy of the recorded regions, in the order added
+func (h *HitMap) Regions() []HitRegion {
+	return append([]HitRegion(nil), h.regions...)
+}
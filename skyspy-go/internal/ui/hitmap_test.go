@@ -0,0 +1,65 @@
+package ui
+
+import "testing"
+
+func TestHitMap_At(t *testing.T) {
+	var h HitMap
+	h.Add("row", 0, 2, 10, 1)
+	h.Add("button", 4, 2, 3, 1)
+	h.Add("empty", 0, 0, 0, 5)
+
+	tests := []struct {
+		x, y int
+		id   string
+		ok   bool
+	}{
+		{0, 2, "row", true},
+		{9, 2, "row", true},
+		{10, 2, "", false},
+		{5, 2, "button", true}, // added later, so on top
+		{6, 2, "button", true},
+		{7, 2, "row", true},
+		{0, 1, "", false},
+		{0, 3, "", false},
+	}
+	for _, tt := range tests {
+		id, ok := h.At(tt.x, tt.y)
+		if id != tt.id || ok != tt.ok {
+			t.Errorf("At(%d, %d) = %q, %v, want %q, %v", tt.x, tt.y, id, ok, tt.id, tt.ok)
+		}
+	}
+	if h.Len() != 2 {
+		t.Errorf("empty regions should be ignored, got %d regions", h.Len())
+	}
+}
+
+func TestHitMap_ShiftFrom(t *testing.T) {
+	var h HitMap
+	h.Add("header", 0, 0, 5, 1)
+
+	// A panel adds regions relative to itself, then is placed at 40, 3
+	mark := h.Len()
+	h.Add("counter", 1, 2, 30, 1)
+	h.ShiftFrom(mark, 40, 3)
+
+	if id, _ := h.At(0, 0); id != "header" {
+		t.Error("regions before the mark should stay put")
+	}
+	if id, ok := h.At(41, 5); !ok || id != "counter" {
+		t.Errorf("the panel's region should move with it, got %q", id)
+	}
+	if _, ok := h.At(1, 2); ok {
+		t.Error("nothing should be left at the panel-relative position")
+	}
+
+	regions := h.Regions()
+	regions[0].X = 99
+	if id, _ := h.At(0, 0); id != "header" {
+		t.Error("Regions should return a copy")
+	}
+
+	h.Reset()
+	if h.Len() != 0 {
+		t.Error("Reset should forget every region")
+	}
+}
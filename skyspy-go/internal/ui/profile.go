@@ -0,0 +1,206 @@
+// Package ui provides reusable UI components for SkySpy applications
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+// DefaultProfileGridStep is the altitude between labelled gridlines (ft)
+const DefaultProfileGridStep = 10000
+
+// profileLabelWidth is the width of the altitude label column plus its axis
+const profileLabelWidth = 5
+
+// ProfilePoint is a single sample on a vertical profile plot.
+// X is the horizontal coordinate (along-track distance or time).
+// Gap marks a sample without usable altitude; it breaks the plotted line.
+type ProfilePoint struct {
+	X        float64
+	Altitude int
+	Gap      bool
+}
+
+// ProfilePlot renders an altitude-vs-distance side view using half-block
+// characters, giving two vertical samples per terminal row.
+type ProfilePlot struct {
+	Width    int // plot columns, excluding the altitude labels
+	Height   int // plot rows
+	GridStep int // altitude between gridlines (ft)
+	Theme    *theme.Theme
+}
+
+// NewProfilePlot creates a new profile plot with default gridlines
+func NewProfilePlot(t *theme.Theme, width, height int) *ProfilePlot {
+	return &ProfilePlot{
+		Width:    width,
+		Height:   height,
+		GridStep: DefaultProfileGridStep,
+		Theme:    t,
+	}
+}
+
+// TotalWidth returns the rendered line width including the label column
+func (p *ProfilePlot) TotalWidth() int {
+	return profileLabelWidth + p.Width
+}
+
+// Ceiling returns the auto-scaled top of the vertical axis: the highest
+// plotted altitude rounded up to the next gridline (at least one gridline).
+func (p *ProfilePlot) Ceiling(points []ProfilePoint) int {
+	step := p.gridStep()
+	maxAlt := 0
+	for _, pt := range points {
+		if !pt.Gap && pt.Altitude > maxAlt {
+			maxAlt = pt.Altitude
+		}
+	}
+	ceiling := ((maxAlt + step - 1) / step) * step
+	if ceiling < step {
+		ceiling = step
+	}
+	return ceiling
+}
+
+// Render returns Height lines of TotalWidth cells. Points must be ordered by X;
+// the last non-gap point is marked as the current position.
+func (p *ProfilePlot) Render(points []ProfilePoint) []string {
+	if p.Width <= 0 || p.Height <= 0 {
+		return nil
+	}
+
+	lineStyle := lipgloss.NewStyle().Foreground(p.Theme.PrimaryBright)
+	gridStyle := lipgloss.NewStyle().Foreground(p.Theme.RadarRing)
+	labelStyle := lipgloss.NewStyle().Foreground(p.Theme.TextDim)
+	markerStyle := lipgloss.NewStyle().Foreground(p.Theme.Selected).Bold(true)
+	g := p.Theme.GlyphSet()
+
+	subRows := p.Height * 2
+	filled := make([][]bool, p.Width)
+	for x := range filled {
+		filled[x] = make([]bool, subRows)
+	}
+
+	ceiling := p.Ceiling(points)
+	minX, maxX := p.xRange(points)
+	column := func(x float64) int {
+		if maxX <= minX {
+			return p.Width - 1
+		}
+		return clampInt(int(math.Round((x-minX)/(maxX-minX)*float64(p.Width-1))), 0, p.Width-1)
+	}
+	subRow := func(alt int) int {
+		return clampInt(int(math.Round(float64(alt)/float64(ceiling)*float64(subRows-1))), 0, subRows-1)
+	}
+
+	markerCol, markerRow := -1, -1
+	for i, pt := range points {
+		if pt.Gap {
+			continue
+		}
+		col, row := column(pt.X), subRow(pt.Altitude)
+		filled[col][row] = true
+		markerCol, markerRow = col, row
+
+		// Connect to the previous sample only when it is real data;
+		// gaps deliberately leave the line broken.
+		if i == 0 || points[i-1].Gap {
+			continue
+		}
+		prevCol, prevRow := column(points[i-1].X), subRow(points[i-1].Altitude)
+		lastRow := prevRow
+		for c := prevCol + 1; c <= col; c++ {
+			r := prevRow + int(math.Round(float64(row-prevRow)*float64(c-prevCol)/float64(col-prevCol)))
+			fillSpan(filled[c], lastRow, r)
+			lastRow = r
+		}
+		if col == prevCol {
+			fillSpan(filled[col], prevRow, row)
+		}
+	}
+
+	// Map each gridline altitude to the terminal row it falls on
+	gridRows := make(map[int]int)
+	for alt := p.gridStep(); alt <= ceiling; alt += p.gridStep() {
+		gridRows[p.Height-1-subRow(alt)/2] = alt
+	}
+
+	lines := make([]string, p.Height)
+	for y := 0; y < p.Height; y++ {
+		var sb strings.Builder
+		upper := (p.Height-1-y)*2 + 1
+		lower := upper - 1
+
+		gridAlt, isGrid := gridRows[y]
+		if isGrid {
+			sb.WriteString(labelStyle.Render(fmt.Sprintf("%3dk", gridAlt/1000)))
+			sb.WriteString(gridStyle.Render(g.AxisTick))
+		} else {
+			sb.WriteString(labelStyle.Render("    "))
+			sb.WriteString(gridStyle.Render(g.V))
+		}
+
+		for x := 0; x < p.Width; x++ {
+			switch {
+			case x == markerCol && markerRow/2 == p.Height-1-y:
+				sb.WriteString(markerStyle.Render(g.Selected))
+			case filled[x][upper] && filled[x][lower]:
+				sb.WriteString(lineStyle.Render(g.BarFull))
+			case filled[x][upper]:
+				sb.WriteString(lineStyle.Render(g.HalfUpper))
+			case filled[x][lower]:
+				sb.WriteString(lineStyle.Render(g.HalfLower))
+			case isGrid:
+				sb.WriteString(gridStyle.Render(g.GridDot))
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		lines[y] = sb.String()
+	}
+
+	return lines
+}
+
+func (p *ProfilePlot) gridStep() int {
+	if p.GridStep <= 0 {
+		return DefaultProfileGridStep
+	}
+	return p.GridStep
+}
+
+func (p *ProfilePlot) xRange(points []ProfilePoint) (float64, float64) {
+	if len(points) == 0 {
+		return 0, 0
+	}
+	minX, maxX := points[0].X, points[0].X
+	for _, pt := range points[1:] {
+		minX = math.Min(minX, pt.X)
+		maxX = math.Max(maxX, pt.X)
+	}
+	return minX, maxX
+}
+
+// fillSpan marks every sub-row between a and b (inclusive)
+func fillSpan(col []bool, a, b int) {
+	if a > b {
+		a, b = b, a
+	}
+	for r := a; r <= b; r++ {
+		col[r] = true
+	}
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+func TestProfilePlot_New(t *testing.T) {
+	th := theme.Get("classic")
+	p := NewProfilePlot(th, 25, 6)
+
+	if p.Width != 25 || p.Height != 6 {
+		t.Errorf("expected 25x6, got %dx%d", p.Width, p.Height)
+	}
+	if p.GridStep != DefaultProfileGridStep {
+		t.Errorf("expected grid step %d, got %d", DefaultProfileGridStep, p.GridStep)
+	}
+	if p.TotalWidth() != 30 {
+		t.Errorf("expected total width 30, got %d", p.TotalWidth())
+	}
+}
+
+func TestProfilePlot_Ceiling(t *testing.T) {
+	p := NewProfilePlot(theme.Get("classic"), 20, 5)
+
+	tests := []struct {
+		name   string
+		points []ProfilePoint
+		want   int
+	}{
+		{"empty", nil, 10000},
+		{"low", []ProfilePoint{{Altitude: 2500}}, 10000},
+		{"exact gridline", []ProfilePoint{{Altitude: 20000}}, 20000},
+		{"rounds up", []ProfilePoint{{Altitude: 1000}, {Altitude: 34000}}, 40000},
+		{"ignores gaps", []ProfilePoint{{Altitude: 5000}, {Altitude: 90000, Gap: true}}, 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Ceiling(tt.points); got != tt.want {
+				t.Errorf("Ceiling() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfilePlot_Render_Dimensions(t *testing.T) {
+	p := NewProfilePlot(theme.Get("classic"), 25, 6)
+	points := []ProfilePoint{
+		{X: 0, Altitude: 1000},
+		{X: 5, Altitude: 8000},
+		{X: 10, Altitude: 21000},
+	}
+
+	lines := p.Render(points)
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != p.TotalWidth() {
+			t.Errorf("line %d: expected width %d, got %d", i, p.TotalWidth(), w)
+		}
+	}
+}
+
+func TestProfilePlot_Render_GridLabels(t *testing.T) {
+	p := NewProfilePlot(theme.Get("classic"), 20, 8)
+	lines := p.Render([]ProfilePoint{{X: 0, Altitude: 5000}, {X: 1, Altitude: 29000}})
+	joined := ansi.Strip(strings.Join(lines, "\n"))
+
+	for _, label := range []string{"10k", "20k", "30k"} {
+		if !strings.Contains(joined, label) {
+			t.Errorf("expected gridline label %q in:\n%s", label, joined)
+		}
+	}
+	if strings.Contains(joined, "40k") {
+		t.Error("axis should auto-scale to 30k, found 40k label")
+	}
+}
+
+func TestProfilePlot_Render_MarksCurrentPosition(t *testing.T) {
+	p := NewProfilePlot(theme.Get("classic"), 20, 5)
+	lines := p.Render([]ProfilePoint{{X: 0, Altitude: 2000}, {X: 10, Altitude: 9000}})
+	joined := ansi.Strip(strings.Join(lines, "\n"))
+
+	if strings.Count(joined, "◉") != 1 {
+		t.Errorf("expected exactly one current-position marker, got:\n%s", joined)
+	}
+	// Marker sits in the last column of the top row (9000ft of a 10k axis)
+	top := []rune(ansi.Strip(lines[0]))
+	if top[len(top)-1] != '◉' {
+		t.Errorf("expected marker at the right edge of the top row, got %q", string(top))
+	}
+}
+
+func TestProfilePlot_Render_GapBreaksLine(t *testing.T) {
+	p := NewProfilePlot(theme.Get("classic"), 21, 4)
+	points := []ProfilePoint{
+		{X: 0, Altitude: 5000},
+		{X: 1, Altitude: 5000},
+		{X: 1, Gap: true},
+		{X: 19, Altitude: 5000},
+		{X: 20, Altitude: 5000},
+	}
+
+	lines := p.Render(points)
+	plotted := 0
+	for _, line := range lines {
+		for _, r := range []rune(ansi.Strip(line))[profileLabelWidth:] {
+			if r == '█' || r == '▀' || r == '▄' || r == '◉' {
+				plotted++
+			}
+		}
+	}
+
+	// Two short segments of two columns each; no bridging across the gap
+	if plotted != 4 {
+		t.Errorf("expected 4 plotted cells with the gap left open, got %d", plotted)
+	}
+}
+
+func TestProfilePlot_Render_ConnectsContinuousData(t *testing.T) {
+	p := NewProfilePlot(theme.Get("classic"), 21, 4)
+	lines := p.Render([]ProfilePoint{{X: 0, Altitude: 5000}, {X: 20, Altitude: 5000}})
+
+	plotted := 0
+	for _, line := range lines {
+		for _, r := range []rune(ansi.Strip(line))[profileLabelWidth:] {
+			if r == '█' || r == '▀' || r == '▄' || r == '◉' {
+				plotted++
+			}
+		}
+	}
+	if plotted != 21 {
+		t.Errorf("expected a continuous 21-column line, got %d cells", plotted)
+	}
+}
+
+func TestProfilePlot_Render_Empty(t *testing.T) {
+	p := NewProfilePlot(theme.Get("classic"), 10, 3)
+	lines := p.Render(nil)
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	if strings.Contains(ansi.Strip(strings.Join(lines, "")), "◉") {
+		t.Error("empty profile should not draw a marker")
+	}
+
+	if (&ProfilePlot{Theme: theme.Get("classic")}).Render(nil) != nil {
+		t.Error("zero-sized plot should render nothing")
+	}
+}
@@ -0,0 +1,150 @@
+// Package ui provides reusable UI components for SkySpy applications
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+// DefaultRibbonCeiling is the top of the altitude ribbon scale (ft) when no
+// altitude band is set
+const DefaultRibbonCeiling = 45000
+
+// RibbonWidth is the rendered width of the altitude ribbon: a two-digit
+// label in thousands of feet, the axis and the mark column
+const RibbonWidth = 4
+
+// RibbonMark is one aircraft placed on the altitude ribbon
+type RibbonMark struct {
+	Altitude  int
+	Selected  bool
+	Military  bool
+	Emergency bool
+}
+
+// AltitudeRibbon renders a vertical strip with a tick for each aircraft at
+// its altitude. Aircraft sharing a row are drawn as one denser shade rather
+// than overdrawing each other.
+type AltitudeRibbon struct {
+	Height  int // rows
+	Floor   int // altitude of the bottom row (ft)
+	Ceiling int // altitude of the top row (ft)
+	Theme   *theme.Theme
+}
+
+// NewAltitudeRibbon creates a ribbon covering the ground to DefaultRibbonCeiling
+func NewAltitudeRibbon(t *theme.Theme, height int) *AltitudeRibbon {
+	return &AltitudeRibbon{
+		Height:  height,
+		Ceiling: DefaultRibbonCeiling,
+		Theme:   t,
+	}
+}
+
+// Row returns the row an altitude falls on, 0 being the top. Altitudes
+// outside the scale are clamped to its ends.
+func (r *AltitudeRibbon) Row(alt int) int {
+	span := r.Ceiling - r.Floor
+	if span <= 0 || r.Height <= 1 {
+		return 0
+	}
+	frac := float64(alt-r.Floor) / float64(span)
+	return r.Height - 1 - clampInt(int(math.Round(frac*float64(r.Height-1))), 0, r.Height-1)
+}
+
+// Render returns Height lines of RibbonWidth cells. The most important
+// aircraft in a row sets its colour: emergencies (flashing with blink),
+// then the selected aircraft, then military. The number sharing the row
+// sets the shade.
+func (r *AltitudeRibbon) Render(marks []RibbonMark, blink bool) []string {
+	if r.Height <= 0 {
+		return nil
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(r.Theme.TextDim)
+	axisStyle := lipgloss.NewStyle().Foreground(r.Theme.RadarRing)
+	g := r.Theme.GlyphSet()
+
+	type rowState struct {
+		count                         int
+		selected, military, emergency bool
+	}
+	rows := make([]rowState, r.Height)
+	for _, mk := range marks {
+		row := &rows[r.Row(mk.Altitude)]
+		row.count++
+		row.selected = row.selected || mk.Selected
+		row.military = row.military || mk.Military
+		row.emergency = row.emergency || mk.Emergency
+	}
+
+	labels := r.labelRows()
+
+	lines := make([]string, r.Height)
+	for y := 0; y < r.Height; y++ {
+		var sb strings.Builder
+		if alt, ok := labels[y]; ok {
+			sb.WriteString(labelStyle.Render(fmt.Sprintf("%2d", alt/1000)))
+			sb.WriteString(axisStyle.Render(g.AxisTick))
+		} else {
+			sb.WriteString("  ")
+			sb.WriteString(axisStyle.Render(g.V))
+		}
+
+		row := rows[y]
+		if row.count == 0 {
+			sb.WriteString(" ")
+			lines[y] = sb.String()
+			continue
+		}
+		mark := g.Shades[min(row.count, len(g.Shades))-1]
+		var style lipgloss.Style
+		switch {
+		case row.emergency:
+			style = r.Theme.Style(theme.RoleEmergency)
+			if blink {
+				mark = g.Emergency
+			}
+		case row.selected:
+			style = r.Theme.Style(theme.RoleSelected).Bold(true)
+		case row.military:
+			style = r.Theme.Style(theme.RoleMilitary)
+		default:
+			style = r.Theme.Style(theme.RoleTarget)
+		}
+		sb.WriteString(style.Render(mark))
+		lines[y] = sb.String()
+	}
+	return lines
+}
+
+// labelRows maps rows to the scale altitude labelled on them. The step is
+// the smallest that keeps labels at least three rows apart.
+func (r *AltitudeRibbon) labelRows() map[int]int {
+	labels := make(map[int]int)
+	span := r.Ceiling - r.Floor
+	if span <= 0 {
+		return labels
+	}
+
+	step := 0
+	for _, s := range []int{1000, 2000, 5000, 10000, 20000} {
+		if float64(s)/float64(span)*float64(r.Height-1) >= 3 {
+			step = s
+			break
+		}
+	}
+	if step == 0 {
+		return labels
+	}
+
+	first := ((r.Floor + step - 1) / step) * step
+	for alt := first; alt <= r.Ceiling && alt < 100000; alt += step {
+		labels[r.Row(alt)] = alt
+	}
+	return labels
+}
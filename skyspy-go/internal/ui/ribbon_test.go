@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skyspy/skyspy-go/internal/theme"
+)
+
+func TestAltitudeRibbon_Row(t *testing.T) {
+	r := NewAltitudeRibbon(theme.Get("classic"), 10)
+	r.Ceiling = 9000
+
+	tests := []struct {
+		alt  int
+		want int
+	}{
+		{9000, 0},
+		{0, 9},
+		{3000, 6},
+		{-500, 9},
+		{60000, 0},
+	}
+	for _, tt := range tests {
+		if got := r.Row(tt.alt); got != tt.want {
+			t.Errorf("Row(%d) = %d, want %d", tt.alt, got, tt.want)
+		}
+	}
+}
+
+func TestAltitudeRibbon_Render_Dimensions(t *testing.T) {
+	r := NewAltitudeRibbon(theme.Get("classic"), 27)
+	lines := r.Render([]RibbonMark{{Altitude: 35000}, {Altitude: 0}}, false)
+
+	if len(lines) != 27 {
+		t.Fatalf("expected 27 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != RibbonWidth {
+			t.Errorf("line %d: expected width %d, got %d", i, RibbonWidth, w)
+		}
+	}
+}
+
+func TestAltitudeRibbon_Render_Labels(t *testing.T) {
+	r := NewAltitudeRibbon(theme.Get("classic"), 27)
+	joined := ansi.Strip(strings.Join(r.Render(nil, false), "\n"))
+
+	for _, label := range []string{"40┤", "20┤", " 0┤"} {
+		if !strings.Contains(joined, label) {
+			t.Errorf("expected scale label %q in:\n%s", label, joined)
+		}
+	}
+
+	// A narrow band gets a finer scale
+	r.Floor, r.Ceiling = 0, 10000
+	joined = ansi.Strip(strings.Join(r.Render(nil, false), "\n"))
+	if !strings.Contains(joined, " 2┤") || strings.Contains(joined, "40┤") {
+		t.Errorf("expected 2,000ft labels for a 10,000ft band, got:\n%s", joined)
+	}
+}
+
+func TestAltitudeRibbon_Render_DensityShading(t *testing.T) {
+	r := NewAltitudeRibbon(theme.Get("classic"), 10)
+	r.Ceiling = 9000
+
+	marks := []RibbonMark{{Altitude: 9000}}
+	for i := 0; i < 3; i++ {
+		marks = append(marks, RibbonMark{Altitude: 0})
+	}
+	lines := r.Render(marks, false)
+
+	if got := lastRune(lines[0]); got != '░' {
+		t.Errorf("a lone aircraft should be the lightest shade, got %q", got)
+	}
+	if got := lastRune(lines[9]); got != '▓' {
+		t.Errorf("three aircraft in a row should be a denser shade, got %q", got)
+	}
+	if got := lastRune(lines[5]); got != ' ' {
+		t.Errorf("an empty row should have no mark, got %q", got)
+	}
+}
+
+func TestAltitudeRibbon_Render_EmergencyFlashes(t *testing.T) {
+	r := NewAltitudeRibbon(theme.Get("classic"), 10)
+	r.Ceiling = 9000
+	marks := []RibbonMark{{Altitude: 9000, Emergency: true}, {Altitude: 9000, Selected: true}}
+
+	on := lastRune(r.Render(marks, true)[0])
+	off := lastRune(r.Render(marks, false)[0])
+	if on == off {
+		t.Errorf("emergency mark should change with blink, got %q both times", on)
+	}
+	if off != '▒' {
+		t.Errorf("emergency row should still show its density between flashes, got %q", off)
+	}
+}
+
+func lastRune(line string) rune {
+	runes := []rune(ansi.Strip(line))
+	return runes[len(runes)-1]
+}
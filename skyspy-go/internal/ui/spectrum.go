@@ -61,6 +61,7 @@ func (s *Spectrum) Render() []string {
 	warningStyle := lipgloss.NewStyle().Foreground(s.Theme.Warning)
 	errorStyle := lipgloss.NewStyle().Foreground(s.Theme.Error)
 	textDim := lipgloss.NewStyle().Foreground(s.Theme.TextDim)
+	g := s.Theme.GlyphSet()
 
 	lines := make([]string, s.Height)
 
@@ -77,14 +78,14 @@ func (s *Spectrum) Render() []string {
 			if value >= threshold {
 				// Color based on height (top = red, middle = yellow, bottom = green)
 				if row < s.Height/3 {
-					sb.WriteString(errorStyle.Render("█"))
+					sb.WriteString(errorStyle.Render(g.BarFull))
 				} else if row < 2*s.Height/3 {
-					sb.WriteString(warningStyle.Render("█"))
+					sb.WriteString(warningStyle.Render(g.BarFull))
 				} else {
-					sb.WriteString(successStyle.Render("█"))
+					sb.WriteString(successStyle.Render(g.BarFull))
 				}
 			} else {
-				sb.WriteString(textDim.Render("░"))
+				sb.WriteString(textDim.Render(g.BarEmpty))
 			}
 		}
 		lines[row] = sb.String()
@@ -99,6 +100,8 @@ func (s *Spectrum) RenderCompact() string {
 	warningStyle := lipgloss.NewStyle().Foreground(s.Theme.Warning)
 	errorStyle := lipgloss.NewStyle().Foreground(s.Theme.Error)
 	textDim := lipgloss.NewStyle().Foreground(s.Theme.TextDim)
+	g := s.Theme.GlyphSet()
+	low := g.BarLevels[0]
 
 	var sb strings.Builder
 	for i := 0; i < s.Width; i++ {
@@ -108,13 +111,13 @@ func (s *Spectrum) RenderCompact() string {
 		}
 
 		if value > 0.8 {
-			sb.WriteString(errorStyle.Render("█"))
+			sb.WriteString(errorStyle.Render(g.BarFull))
 		} else if value > 0.5 {
-			sb.WriteString(warningStyle.Render("▄"))
+			sb.WriteString(warningStyle.Render(g.HalfLower))
 		} else if value > 0.2 {
-			sb.WriteString(successStyle.Render("▁"))
+			sb.WriteString(successStyle.Render(low))
 		} else {
-			sb.WriteString(textDim.Render("▁"))
+			sb.WriteString(textDim.Render(low))
 		}
 	}
 	return sb.String()
@@ -172,6 +175,7 @@ func (w *Waterfall) Render() []string {
 		w.Theme.Error,
 		w.Theme.PrimaryBright,
 	}
+	g := w.Theme.GlyphSet()
 
 	for row := 0; row < w.Height; row++ {
 		var sb strings.Builder
@@ -197,16 +201,16 @@ func (w *Waterfall) Render() []string {
 			// Use different characters based on intensity
 			char := " "
 			if value > 0.1 {
-				char = "░"
+				char = g.Shades[0]
 			}
 			if value > 0.3 {
-				char = "▒"
+				char = g.Shades[1]
 			}
 			if value > 0.6 {
-				char = "▓"
+				char = g.Shades[2]
 			}
 			if value > 0.8 {
-				char = "█"
+				char = g.Shades[3]
 			}
 
 			sb.WriteString(style.Render(char))
@@ -308,6 +312,7 @@ func (f *FrequencyDisplay) RenderList(blink bool) []string {
 	secondaryBright := lipgloss.NewStyle().Foreground(f.Theme.SecondaryBright)
 	errorStyle := lipgloss.NewStyle().Foreground(f.Theme.Error)
 	textDim := lipgloss.NewStyle().Foreground(f.Theme.TextDim)
+	g := f.Theme.GlyphSet()
 
 	lines := make([]string, len(f.Frequencies))
 	for i, freq := range f.Frequencies {
@@ -325,10 +330,10 @@ func (f *FrequencyDisplay) RenderList(blink bool) []string {
 			style = textDim
 		}
 
-		indicator := "○"
+		indicator := g.Off
 		indStyle := textDim
 		if freq.Active && blink {
-			indicator = "●"
+			indicator = g.On
 			indStyle = style
 		}
 
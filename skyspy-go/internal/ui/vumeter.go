@@ -46,6 +46,7 @@ func (v *VUMeter) Render(level float64) string {
 	warningStyle := lipgloss.NewStyle().Foreground(v.Theme.Warning)
 	errorStyle := lipgloss.NewStyle().Foreground(v.Theme.Error)
 	textDim := lipgloss.NewStyle().Foreground(v.Theme.TextDim)
+	g := v.Theme.GlyphSet()
 
 	var sb strings.Builder
 	greenEnd := int(v.GreenZone * float64(v.Width))
@@ -54,14 +55,14 @@ func (v *VUMeter) Render(level float64) string {
 	for i := 0; i < v.Width; i++ {
 		if i < filled {
 			if i < greenEnd {
-				sb.WriteString(successStyle.Render("█"))
+				sb.WriteString(successStyle.Render(g.BarFull))
 			} else if i < yellowEnd {
-				sb.WriteString(warningStyle.Render("█"))
+				sb.WriteString(warningStyle.Render(g.BarFull))
 			} else {
-				sb.WriteString(errorStyle.Render("█"))
+				sb.WriteString(errorStyle.Render(g.BarFull))
 			}
 		} else {
-			sb.WriteString(textDim.Render("░"))
+			sb.WriteString(textDim.Render(g.BarEmpty))
 		}
 	}
 	return sb.String()
@@ -82,6 +83,7 @@ func (v *VUMeter) RenderVertical(level float64, height int) []string {
 	warningStyle := lipgloss.NewStyle().Foreground(v.Theme.Warning)
 	errorStyle := lipgloss.NewStyle().Foreground(v.Theme.Error)
 	textDim := lipgloss.NewStyle().Foreground(v.Theme.TextDim)
+	g := v.Theme.GlyphSet()
 
 	greenEnd := int(v.GreenZone * float64(height))
 	yellowEnd := int(v.YellowZone * float64(height))
@@ -91,14 +93,14 @@ func (v *VUMeter) RenderVertical(level float64, height int) []string {
 		row := height - 1 - i // Invert for bottom-up rendering
 		if row < filled {
 			if row < greenEnd {
-				lines[i] = successStyle.Render("█")
+				lines[i] = successStyle.Render(g.BarFull)
 			} else if row < yellowEnd {
-				lines[i] = warningStyle.Render("█")
+				lines[i] = warningStyle.Render(g.BarFull)
 			} else {
-				lines[i] = errorStyle.Render("█")
+				lines[i] = errorStyle.Render(g.BarFull)
 			}
 		} else {
-			lines[i] = textDim.Render("░")
+			lines[i] = textDim.Render(g.BarEmpty)
 		}
 	}
 	return lines
@@ -152,19 +154,20 @@ func (s *SignalMeter) Render(rssi float64) string {
 	warningStyle := lipgloss.NewStyle().Foreground(s.Theme.Warning)
 	errorStyle := lipgloss.NewStyle().Foreground(s.Theme.Error)
 	textDim := lipgloss.NewStyle().Foreground(s.Theme.TextDim)
+	g := s.Theme.GlyphSet()
 
 	var sb strings.Builder
 	for i := 0; i < s.Bars; i++ {
 		if i < bars {
 			if bars >= 4 {
-				sb.WriteString(successStyle.Render("▆"))
+				sb.WriteString(successStyle.Render(g.SignalOn))
 			} else if bars >= 2 {
-				sb.WriteString(warningStyle.Render("▆"))
+				sb.WriteString(warningStyle.Render(g.SignalOn))
 			} else {
-				sb.WriteString(errorStyle.Render("▆"))
+				sb.WriteString(errorStyle.Render(g.SignalOn))
 			}
 		} else {
-			sb.WriteString(textDim.Render("▁"))
+			sb.WriteString(textDim.Render(g.SignalOff))
 		}
 	}
 	return sb.String()
@@ -185,6 +188,7 @@ func (s *SignalMeter) RenderFromLevel(level float64) string {
 	warningStyle := lipgloss.NewStyle().Foreground(s.Theme.Warning)
 	errorStyle := lipgloss.NewStyle().Foreground(s.Theme.Error)
 	textDim := lipgloss.NewStyle().Foreground(s.Theme.TextDim)
+	g := s.Theme.GlyphSet()
 
 	threshold := int(0.6 * float64(s.Bars))
 	warningThreshold := int(0.4 * float64(s.Bars))
@@ -193,14 +197,14 @@ func (s *SignalMeter) RenderFromLevel(level float64) string {
 	for i := 0; i < s.Bars; i++ {
 		if i < bars {
 			if bars >= threshold {
-				sb.WriteString(successStyle.Render("▆"))
+				sb.WriteString(successStyle.Render(g.SignalOn))
 			} else if bars >= warningThreshold {
-				sb.WriteString(warningStyle.Render("▆"))
+				sb.WriteString(warningStyle.Render(g.SignalOn))
 			} else {
-				sb.WriteString(errorStyle.Render("▆"))
+				sb.WriteString(errorStyle.Render(g.SignalOn))
 			}
 		} else {
-			sb.WriteString(textDim.Render("▁"))
+			sb.WriteString(textDim.Render(g.SignalOff))
 		}
 	}
 	return sb.String()
@@ -0,0 +1,75 @@
+// Package units provides aviation to metric unit conversions for SkySpy
+package units
+
+import "math"
+
+// Conversion factors, exact by definition
+const (
+	MetersPerFoot = 0.3048
+	KmPerNM       = 1.852
+	MilesPerNM    = 1852 / 1609.344
+)
+
+// Distance units for display. Distances are kept in nautical miles and
+// converted only to be shown.
+const (
+	NM = "nm"
+	KM = "km"
+	MI = "mi"
+)
+
+// DistanceUnits are the distance units in the order they're cycled through
+var DistanceUnits = []string{NM, KM, MI}
+
+// Meters converts feet to meters, to the nearest 10 m. Reported
+// altitudes are only good to 25 ft, so finer would be false precision.
+func Meters(ft int) int {
+	return roundTo(float64(ft)*MetersPerFoot, 10)
+}
+
+// KmH converts knots to km/h, to the nearest 5 km/h
+func KmH(kt float64) int {
+	return roundTo(kt*KmPerNM, 5)
+}
+
+// Km converts nautical miles to kilometers
+func Km(nm float64) float64 {
+	return nm * KmPerNM
+}
+
+// Distance returns the distance unit named by s, nautical miles unless
+// it's km or mi
+func Distance(s string) string {
+	switch s {
+	case KM, MI:
+		return s
+	}
+	return NM
+}
+
+// FromNM converts nautical miles to unit
+func FromNM(nm float64, unit string) float64 {
+	switch unit {
+	case KM:
+		return nm * KmPerNM
+	case MI:
+		return nm * MilesPerNM
+	}
+	return nm
+}
+
+// ToNM converts a distance in unit to nautical miles
+func ToNM(d float64, unit string) float64 {
+	switch unit {
+	case KM:
+		return d / KmPerNM
+	case MI:
+		return d / MilesPerNM
+	}
+	return d
+}
+
+// roundTo rounds v to the nearest multiple of step, halves away from zero
+func roundTo(v float64, step int) int {
+	return int(math.Round(v/float64(step))) * step
+}
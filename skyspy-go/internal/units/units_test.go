@@ -0,0 +1,74 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMeters(t *testing.T) {
+	tests := []struct{ ft, want int }{
+		{0, 0},
+		{35000, 10670},
+		{45000, 13720},
+		{1000, 300},
+		{-1000, -300},
+		{-1500, -460},
+		{60000, 18290},
+	}
+	for _, tt := range tests {
+		if got := Meters(tt.ft); got != tt.want {
+			t.Errorf("Meters(%d) = %d, want %d", tt.ft, got, tt.want)
+		}
+	}
+}
+
+func TestKmH(t *testing.T) {
+	tests := []struct {
+		kt   float64
+		want int
+	}{
+		{0, 0},
+		{450, 835},
+		{120, 220},
+		{1500, 2780},
+		{3.4, 5},
+	}
+	for _, tt := range tests {
+		if got := KmH(tt.kt); got != tt.want {
+			t.Errorf("KmH(%v) = %d, want %d", tt.kt, got, tt.want)
+		}
+	}
+}
+
+func TestKm(t *testing.T) {
+	if got := Km(10); got != 18.52 {
+		t.Errorf("Km(10) = %v, want 18.52", got)
+	}
+}
+
+func TestDistanceUnits(t *testing.T) {
+	for in, want := range map[string]string{"nm": NM, "km": KM, "mi": MI, "": NM, "furlong": NM} {
+		if got := Distance(in); got != want {
+			t.Errorf("Distance(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	tests := []struct {
+		unit string
+		nm   float64
+		want float64
+	}{
+		{NM, 100, 100},
+		{KM, 100, 185.2},
+		{MI, 100, 115.0779},
+	}
+	for _, tt := range tests {
+		got := FromNM(tt.nm, tt.unit)
+		if math.Abs(got-tt.want) > 0.0001 {
+			t.Errorf("FromNM(%v, %s) = %v, want %v", tt.nm, tt.unit, got, tt.want)
+		}
+		if back := ToNM(got, tt.unit); math.Abs(back-tt.nm) > 1e-9 {
+			t.Errorf("ToNM(%v, %s) = %v, want %v", got, tt.unit, back, tt.nm)
+		}
+	}
+}
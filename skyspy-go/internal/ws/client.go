@@ -1,70 +1,24 @@
-// Package ws provides WebSocket client functionality for SkySpy
+// Package ws provides the WebSocket transport for SkySpy. It delivers raw
+// codec.Message values; decoding the payloads is left to the codec package.
 //
 //nolint:gocritic // paramTypeCombine style preference
 package ws
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
-)
-
-// MessageType represents the type of WebSocket message
-type MessageType string
 
-const (
-	AircraftSnapshot MessageType = "aircraft:snapshot"
-	AircraftUpdate   MessageType = "aircraft:update"
-	AircraftNew      MessageType = "aircraft:new"
-	AircraftRemove   MessageType = "aircraft:remove"
-	ACARSMessage     MessageType = "acars:message"
-	ACARSSnapshot    MessageType = "acars:snapshot"
+	"github.com/skyspy/skyspy-go/internal/codec"
 )
 
-// Message represents a WebSocket message from the server
-type Message struct {
-	Type string          `json:"type"`
-	Data json.RawMessage `json:"data"`
-}
-
-// Aircraft represents aircraft data from the WebSocket
-type Aircraft struct {
-	Hex      string   `json:"hex"`
-	Flight   string   `json:"flight"`
-	Lat      *float64 `json:"lat"`
-	Lon      *float64 `json:"lon"`
-	AltBaro  *int     `json:"alt_baro"`
-	Alt      *int     `json:"alt"`
-	GS       *float64 `json:"gs"`
-	Track    *float64 `json:"track"`
-	BaroRate *float64 `json:"baro_rate"`
-	VR       *float64 `json:"vr"`
-	Squawk   string   `json:"squawk"`
-	RSSI     *float64 `json:"rssi"`
-	Type     string   `json:"t"`
-	Military bool     `json:"military"`
-	Distance *float64 `json:"distance_nm"`
-	Bearing  *float64 `json:"bearing"`
-}
-
-// AircraftSnapshotData represents snapshot data containing multiple aircraft
-type AircraftSnapshotData struct {
-	Aircraft map[string]Aircraft `json:"aircraft"`
-}
-
-// ACARSData represents ACARS message data
-type ACARSData struct {
-	Callsign string `json:"callsign"`
-	Flight   string `json:"flight"`
-	Label    string `json:"label"`
-	Text     string `json:"text"`
-}
-
 // ClientState represents the connection state
 type ClientState int
 
@@ -74,6 +28,22 @@ const (
 	StateConnected
 )
 
+// ConnState reports on the aircraft connection: connected, or down and
+// waiting Delay before reconnect attempt Attempt. Attempt is zero while
+// the connection is down and nothing will be tried until Retry.
+type ConnState struct {
+	State   ClientState
+	Attempt int
+	Delay   time.Duration
+}
+
+// Reconnect backoff: after a connection drops, the wait before each
+// attempt doubles from backoffStart up to backoffMax
+const (
+	backoffStart = time.Second
+	backoffMax   = 60 * time.Second
+)
+
 // AuthProvider is a function that returns the current auth header value
 type AuthProvider func() (string, error)
 
@@ -88,10 +58,31 @@ type Client struct {
 	mu             sync.RWMutex
 	stopOnce       sync.Once
 	stopCh         chan struct{}
-	aircraftMsgCh  chan Message
-	acarsMsgCh     chan Message
+	aircraftMsgCh  chan codec.Message
+	acarsMsgCh     chan codec.Message
+	lastErr        *ConnectError // why the last aircraft connection attempt failed
+	retryCh        chan struct{} // closed by Retry to cut reconnect waits short
+	autoReconnect  bool          // redial a dropped connection on its own
+	statesCh       chan ConnState
+
+	// Keepalive: ping every pingInterval and drop a connection that has
+	// been silent for pingInterval+pongTimeout. Disabled when zero.
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	lastMessage  time.Time // last message on the aircraft connection, or when it connected
+
+	traffic *traffic // bytes moved over both feeds
+
+	// Live connections by topic, for acknowledging notices. Writes other
+	// than pings are serialized by writeMu.
+	conns   map[string]*websocket.Conn
+	writeMu sync.Mutex
 }
 
+// ErrKeepalive is the cause recorded when a connection stops answering
+// keepalive pings
+var ErrKeepalive = errors.New("no reply to keepalive ping")
+
 // NewClient creates a new WebSocket client
 func NewClient(host string, port int, reconnectDelay int) *Client {
 	return &Client{
@@ -101,8 +92,13 @@ func NewClient(host string, port int, reconnectDelay int) *Client {
 		state:          StateDisconnected,
 		acarsState:     StateDisconnected,
 		stopCh:         make(chan struct{}),
-		aircraftMsgCh:  make(chan Message, 100),
-		acarsMsgCh:     make(chan Message, 100),
+		aircraftMsgCh:  make(chan codec.Message, 100),
+		acarsMsgCh:     make(chan codec.Message, 100),
+		retryCh:        make(chan struct{}),
+		autoReconnect:  true,
+		statesCh:       make(chan ConnState, 16),
+		traffic:        newTraffic(),
+		conns:          make(map[string]*websocket.Conn),
 	}
 }
 
@@ -113,6 +109,48 @@ func NewClientWithAuth(host string, port int, reconnectDelay int, authProvider A
 	return client
 }
 
+// SetKeepalive makes the client ping the server every interval. A
+// connection that has sent nothing, not even a pong, for interval plus
+// timeout is taken as dead and reconnected. A zero interval disables
+// keepalive. Call it before Start.
+func (c *Client) SetKeepalive(interval, timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pingInterval = interval
+	c.pongTimeout = timeout
+}
+
+// SetAutoReconnect sets whether a connection that drops is redialed on its
+// own, with backoff. When off, a dropped connection stays down until Retry;
+// attempts to make the first connection go on regardless. Call it before
+// Start.
+func (c *Client) SetAutoReconnect(on bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.autoReconnect = on
+}
+
+// States returns the channel the aircraft connection's state changes are
+// sent on. Changes are dropped if nobody keeps up with it.
+func (c *Client) States() <-chan ConnState {
+	return c.statesCh
+}
+
+// LastMessage returns when the aircraft connection last received a
+// message, or when it connected if nothing has arrived since. It is the
+// zero time before the first connection.
+func (c *Client) LastMessage() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastMessage
+}
+
+// Traffic returns the data moved so far. It is safe to call from any
+// goroutine.
+func (c *Client) Traffic() TrafficStats {
+	return c.traffic.snapshot()
+}
+
 // SetAuthProvider sets the authentication provider
 func (c *Client) SetAuthProvider(provider AuthProvider) {
 	c.mu.Lock()
@@ -144,13 +182,40 @@ func (c *Client) IsACARSConnected() bool {
 	return c.ACARSState() == StateConnected
 }
 
+// URL returns the aircraft feed URL
+func (c *Client) URL() string {
+	return fmt.Sprintf("ws://%s:%d/ws/aircraft/?topics=aircraft", c.host, c.port)
+}
+
+// LastError returns why the last aircraft connection attempt failed, or nil
+// once connected. A non-nil result is a *ConnectError.
+func (c *Client) LastError() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.lastErr == nil {
+		return nil
+	}
+	return c.lastErr
+}
+
+// Retry makes both connections try again now instead of waiting out the
+// reconnect delay
+func (c *Client) Retry() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.retryCh != nil {
+		close(c.retryCh)
+	}
+	c.retryCh = make(chan struct{})
+}
+
 // AircraftMessages returns the channel for aircraft messages
-func (c *Client) AircraftMessages() <-chan Message {
+func (c *Client) AircraftMessages() <-chan codec.Message {
 	return c.aircraftMsgCh
 }
 
 // ACARSMessages returns the channel for ACARS messages
-func (c *Client) ACARSMessages() <-chan Message {
+func (c *Client) ACARSMessages() <-chan codec.Message {
 	return c.acarsMsgCh
 }
 
@@ -184,6 +249,80 @@ func (c *Client) setACARSState(state ClientState) {
 	c.mu.Unlock()
 }
 
+func (c *Client) setAircraftError(err *ConnectError) {
+	c.mu.Lock()
+	c.lastErr = err
+	c.mu.Unlock()
+}
+
+func (c *Client) touchAircraft() {
+	c.mu.Lock()
+	c.lastMessage = time.Now()
+	c.mu.Unlock()
+}
+
+func (c *Client) keepaliveSettings() (interval, timeout time.Duration) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.pingInterval, c.pongTimeout
+}
+
+func (c *Client) retrySignal() <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.retryCh
+}
+
+func (c *Client) autoReconnecting() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.autoReconnect
+}
+
+// reportState sends a change of the aircraft connection's state, dropping
+// it rather than holding up the connection if the channel is full
+func (c *Client) reportState(state ConnState) {
+	select {
+	case c.statesCh <- state:
+	default:
+	}
+}
+
+// waitReconnect waits out delay or a Retry. It returns false if the client
+// was stopped.
+func (c *Client) waitReconnect(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-c.stopCh:
+		return false
+	case <-c.retrySignal():
+		return true
+	case <-timer.C:
+		return true
+	}
+}
+
+// waitRetry waits for a Retry. It returns false if the client was stopped.
+func (c *Client) waitRetry() bool {
+	select {
+	case <-c.stopCh:
+		return false
+	case <-c.retrySignal():
+		return true
+	}
+}
+
+// backoff returns the wait before the given reconnect attempt, counting
+// from 1: backoffStart, doubling each attempt, up to backoffMax
+func backoff(attempt int) time.Duration {
+	delay := backoffStart
+	for i := 1; i < attempt && delay < backoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, backoffMax)
+}
+
 func (c *Client) getAuthProvider() AuthProvider {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -191,17 +330,49 @@ func (c *Client) getAuthProvider() AuthProvider {
 }
 
 func (c *Client) runAircraftConnection() {
-	url := fmt.Sprintf("ws://%s:%d/ws/aircraft/?topics=aircraft", c.host, c.port)
-	c.runConnection(url, c.aircraftMsgCh, "aircraft", c.setAircraftState)
+	c.runConnection(c.URL(), c.aircraftMsgCh, "aircraft", c.setAircraftState, c.setAircraftError, c.touchAircraft, c.reportState)
 }
 
 func (c *Client) runACARSConnection() {
 	url := fmt.Sprintf("ws://%s:%d/ws/acars/?topics=messages", c.host, c.port)
-	c.runConnection(url, c.acarsMsgCh, "messages", c.setACARSState)
+	c.runConnection(url, c.acarsMsgCh, "messages", c.setACARSState, nil, nil, nil)
 }
 
+// runConnection keeps one feed connected until the client stops. setErr, if
+// set, is told why each attempt failed and is cleared on connecting. touch,
+// if set, is called on connecting and for every message received. report,
+// if set, is told when the feed connects and when it waits to reconnect.
+//
 //nolint:gocyclo // reconnect/read state machine — cohesive, splitting hurts readability
-func (c *Client) runConnection(url string, msgCh chan<- Message, topic string, setState func(ClientState)) {
+func (c *Client) runConnection(url string, msgCh chan<- codec.Message, topic string, setState func(ClientState), setErr func(*ConnectError), touch func(), report func(ConnState)) {
+	if setErr == nil {
+		setErr = func(*ConnectError) {}
+	}
+	if touch == nil {
+		touch = func() {}
+	}
+	if report == nil {
+		report = func(ConnState) {}
+	}
+	connectedBefore := false
+
+	// retry waits before the next attempt: the reconnect delay until the
+	// feed first connects, then backing off from each drop, or only for
+	// Retry with automatic reconnection off
+	attempt := 0
+	retry := func() bool {
+		if !connectedBefore {
+			return c.waitReconnect(c.reconnectDelay)
+		}
+		if !c.autoReconnecting() {
+			report(ConnState{State: StateDisconnected})
+			return c.waitRetry()
+		}
+		attempt++
+		delay := backoff(attempt)
+		report(ConnState{State: StateDisconnected, Attempt: attempt, Delay: delay})
+		return c.waitReconnect(delay)
+	}
 	for {
 		select {
 		case <-c.stopCh:
@@ -240,44 +411,94 @@ func (c *Client) runConnection(url string, msgCh chan<- Message, topic string, s
 			_ = resp.Body.Close()
 		}
 		if err != nil {
+			setErr(classifyDialError(url, resp, err))
 			setState(StateDisconnected)
-			select {
-			case <-c.stopCh:
+			if !retry() {
 				return
-			case <-time.After(c.reconnectDelay):
-				continue
 			}
+			continue
 		}
 
 		// Subscribe to topics
-		subscribeMsg := map[string]interface{}{
+		subscribeMsg, _ := json.Marshal(map[string]interface{}{
 			"action": "subscribe",
 			"topics": []string{topic},
-		}
-		if err := conn.WriteJSON(subscribeMsg); err != nil {
+		})
+		if err := conn.WriteMessage(websocket.TextMessage, subscribeMsg); err != nil {
 			conn.Close()
+			setErr(&ConnectError{Kind: KindOther, URL: url, Err: err})
 			setState(StateDisconnected)
-			select {
-			case <-c.stopCh:
+			if !retry() {
 				return
-			case <-time.After(c.reconnectDelay):
-				continue
 			}
+			continue
+		}
+		c.traffic.sent(len(subscribeMsg))
+
+		// Ask for the aircraft afresh after a reconnect, so targets that
+		// came and went while the feed was down are caught up at once
+		if connectedBefore && topic == "aircraft" {
+			c.requestSnapshot(conn)
 		}
 
+		setErr(nil)
 		setState(StateConnected)
+		c.setConn(topic, conn)
+		touch()
+		attempt = 0
+		report(ConnState{State: StateConnected})
+
+		// Any message or pong proves the connection is alive and pushes the
+		// read deadline out; silence past it fails the read below
+		pingInterval, pongTimeout := c.keepaliveSettings()
+		alive := func() {
+			if pingInterval > 0 {
+				_ = conn.SetReadDeadline(time.Now().Add(pingInterval + pongTimeout))
+			}
+		}
+		alive()
+		conn.SetPongHandler(func(string) error {
+			alive()
+			return nil
+		})
+		pingDone := make(chan struct{})
+		if pingInterval > 0 {
+			go c.keepalive(conn, pingInterval, pongTimeout, pingDone)
+		}
+
+		// Tell the reader that what follows comes from a new session, so
+		// state kept from the old one can be resynchronized
+		if connectedBefore {
+			select {
+			case msgCh <- codec.Message{Type: string(codec.FeedReconnected)}:
+			case <-c.stopCh:
+				close(pingDone)
+				conn.Close()
+				return
+			}
+		}
+		connectedBefore = true
 
 		// Read messages
 		for {
 			_, data, err := conn.ReadMessage()
 			if err != nil {
+				c.setConn(topic, nil)
+				close(pingDone)
 				conn.Close()
+				var ne net.Error
+				if errors.As(err, &ne) && ne.Timeout() {
+					setErr(&ConnectError{Kind: KindTimeout, URL: url, Err: ErrKeepalive})
+				}
 				setState(StateDisconnected)
 				break
 			}
+			alive()
+			touch()
+			c.traffic.received(len(data))
 
-			var msg Message
-			if err := json.Unmarshal(data, &msg); err != nil {
+			msg, err := codec.ParseMessage(data)
+			if err != nil {
 				continue
 			}
 
@@ -287,75 +508,96 @@ func (c *Client) runConnection(url string, msgCh chan<- Message, topic string, s
 			select {
 			case msgCh <- msg:
 			case <-c.stopCh:
+				close(pingDone)
 				conn.Close()
 				return
 			}
 		}
 
 		// Wait before reconnecting
-		select {
-		case <-c.stopCh:
+		if !retry() {
 			return
-		case <-time.After(c.reconnectDelay):
 		}
 	}
 }
 
-// ParseAircraftSnapshot parses aircraft snapshot data
-func ParseAircraftSnapshot(data json.RawMessage) ([]Aircraft, error) {
-	// Try parsing as object with aircraft map
-	var snapshot AircraftSnapshotData
-	if err := json.Unmarshal(data, &snapshot); err == nil && snapshot.Aircraft != nil {
-		aircraft := make([]Aircraft, 0, len(snapshot.Aircraft))
-		for _, ac := range snapshot.Aircraft {
-			aircraft = append(aircraft, ac)
-		}
-		return aircraft, nil
+// snapshotRequestID tags the snapshot request sent on reconnecting
+const snapshotRequestID = "skyspy-resync"
+
+// requestSnapshot asks the server for every aircraft it is tracking. The
+// reply is a codec.Response for codec.SnapshotRequest. The server may send
+// a snapshot for the subscription too; a failed write is left to the read
+// that follows to notice.
+func (c *Client) requestSnapshot(conn *websocket.Conn) {
+	msg, _ := json.Marshal(map[string]interface{}{
+		"action":     "request",
+		"type":       codec.SnapshotRequest,
+		"request_id": snapshotRequestID,
+	})
+	if err := conn.WriteMessage(websocket.TextMessage, msg); err == nil {
+		c.traffic.sent(len(msg))
 	}
+}
 
-	// Try parsing as array
-	var list []Aircraft
-	if err := json.Unmarshal(data, &list); err == nil {
-		return list, nil
+// setConn records the live connection for topic, or nil once it drops
+func (c *Client) setConn(topic string, conn *websocket.Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if conn == nil {
+		delete(c.conns, topic)
+		return
 	}
+	c.conns[topic] = conn
+}
 
-	// Try parsing as object with nested data
-	var nested struct {
-		Aircraft map[string]Aircraft `json:"aircraft"`
-	}
-	if err := json.Unmarshal(data, &nested); err == nil && nested.Aircraft != nil {
-		aircraft := make([]Aircraft, 0, len(nested.Aircraft))
-		for _, ac := range nested.Aircraft {
-			aircraft = append(aircraft, ac)
-		}
-		return aircraft, nil
-	}
+// ErrNotConnected is returned when there is no connection to write to
+var ErrNotConnected = errors.New("not connected")
 
-	return nil, fmt.Errorf("unable to parse aircraft snapshot")
-}
+// ackTimeout bounds how long an acknowledgement may take to write
+const ackTimeout = 5 * time.Second
 
-// ParseAircraft parses single aircraft data
-func ParseAircraft(data json.RawMessage) (*Aircraft, error) {
-	var ac Aircraft
-	if err := json.Unmarshal(data, &ac); err != nil {
-		return nil, err
+// Acknowledge tells the server a notice was received, on the aircraft
+// connection or, failing that, the ACARS one
+func (c *Client) Acknowledge(id string) error {
+	c.mu.RLock()
+	conn := c.conns["aircraft"]
+	if conn == nil {
+		conn = c.conns["messages"]
 	}
-	return &ac, nil
-}
-
-// ParseACARSData parses ACARS message data
-func ParseACARSData(data json.RawMessage) ([]ACARSData, error) {
-	// Try parsing as array
-	var list []ACARSData
-	if err := json.Unmarshal(data, &list); err == nil {
-		return list, nil
+	c.mu.RUnlock()
+	if conn == nil {
+		return ErrNotConnected
 	}
 
-	// Try parsing as single message
-	var single ACARSData
-	if err := json.Unmarshal(data, &single); err == nil {
-		return []ACARSData{single}, nil
+	msg, _ := json.Marshal(map[string]interface{}{
+		"action": "ack",
+		"id":     id,
+	})
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = conn.SetWriteDeadline(time.Now().Add(ackTimeout))
+	if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+		return err
 	}
+	c.traffic.sent(len(msg))
+	return nil
+}
 
-	return nil, fmt.Errorf("unable to parse ACARS data")
+// keepalive pings conn every interval until done is closed. Pong replies
+// are handled by the reader.
+func (c *Client) keepalive(conn *websocket.Conn, interval, timeout time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(timeout)); err != nil {
+				return
+			}
+		}
+	}
 }
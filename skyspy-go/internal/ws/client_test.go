@@ -2,6 +2,7 @@ package ws
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +12,8 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
 )
 
 // testServer provides a test WebSocket server for testing the client
@@ -277,6 +280,244 @@ func TestClient_Reconnect(t *testing.T) {
 	}
 }
 
+func TestClient_ReconnectMarker(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	ts.onMessage = func(conn *websocket.Conn, data []byte) {
+		var msg map[string]interface{}
+		if err := json.Unmarshal(data, &msg); err == nil && msg["action"] == "subscribe" {
+			snapshot, _ := json.Marshal(codec.Message{
+				Type: string(codec.AircraftSnapshot),
+				Data: json.RawMessage(`[{"hex":"ABC123"}]`),
+			})
+			conn.WriteMessage(websocket.TextMessage, snapshot)
+		}
+	}
+
+	host, port := ts.getHostPort()
+	client := NewClient(host, port, 1)
+	client.Start()
+	defer client.Stop()
+
+	// next skips the echoed subscribe messages
+	next := func() codec.Message {
+		t.Helper()
+		for {
+			select {
+			case msg := <-client.AircraftMessages():
+				if msg.Type != "" {
+					return msg
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for an aircraft message")
+				return codec.Message{}
+			}
+		}
+	}
+
+	if msg := next(); msg.Type != string(codec.AircraftSnapshot) {
+		t.Fatalf("first connection should start with the snapshot, got %s", msg.Type)
+	}
+
+	ts.mu.Lock()
+	for _, conn := range ts.connections {
+		conn.Close()
+	}
+	ts.connections = nil
+	ts.mu.Unlock()
+
+	if msg := next(); msg.Type != string(codec.FeedReconnected) {
+		t.Fatalf("expected %s after reconnecting, got %s", codec.FeedReconnected, msg.Type)
+	}
+	if msg := next(); msg.Type != string(codec.AircraftSnapshot) {
+		t.Errorf("expected the new session's snapshot after the marker, got %s", msg.Type)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	want := []time.Duration{1, 2, 4, 8, 16, 32, 60, 60}
+	for i, w := range want {
+		if got := backoff(i + 1); got != w*time.Second {
+			t.Errorf("attempt %d: backoff = %v, want %v", i+1, got, w*time.Second)
+		}
+	}
+}
+
+// dropAll closes the server's side of every connection
+func (ts *testServer) dropAll() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for _, conn := range ts.connections {
+		conn.Close()
+	}
+	ts.connections = nil
+}
+
+// nextState waits for the client's next connection state
+func nextState(t *testing.T, client *Client) ConnState {
+	t.Helper()
+	select {
+	case state := <-client.States():
+		return state
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a connection state")
+		return ConnState{}
+	}
+}
+
+func TestClient_ReconnectBacksOffAndRequestsSnapshot(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	host, port := ts.getHostPort()
+	client := NewClient(host, port, 1)
+	client.Start()
+	defer client.Stop()
+	go func() {
+		for range client.AircraftMessages() {
+		}
+	}()
+
+	if state := nextState(t, client); state.State != StateConnected {
+		t.Fatalf("first state = %+v, want connected", state)
+	}
+	ts.dropAll()
+
+	state := nextState(t, client)
+	if state.State != StateDisconnected || state.Attempt != 1 || state.Delay != time.Second {
+		t.Errorf("after the drop: %+v, want attempt 1 in 1s", state)
+	}
+	if state := nextState(t, client); state.State != StateConnected {
+		t.Fatalf("expected to reconnect, got %+v", state)
+	}
+
+	// The new session asks for a snapshot after subscribing
+	if !waitFor(2*time.Second, func() bool {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+		for _, data := range ts.messages {
+			var msg map[string]interface{}
+			if json.Unmarshal(data, &msg) == nil && msg["action"] == "request" && msg["type"] == codec.SnapshotRequest {
+				return true
+			}
+		}
+		return false
+	}) {
+		t.Error("no snapshot request after reconnecting")
+	}
+}
+
+func TestClient_AutoReconnectOff(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	host, port := ts.getHostPort()
+	client := NewClient(host, port, 0)
+	client.SetAutoReconnect(false)
+	client.Start()
+	defer client.Stop()
+	go func() {
+		for range client.AircraftMessages() {
+		}
+	}()
+
+	if state := nextState(t, client); state.State != StateConnected {
+		t.Fatalf("first state = %+v, want connected", state)
+	}
+	ts.dropAll()
+
+	if state := nextState(t, client); state.State != StateDisconnected || state.Attempt != 0 {
+		t.Errorf("after the drop: %+v, want down with no attempt", state)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	if client.IsConnected() {
+		t.Fatal("the connection shouldn't be redialed on its own")
+	}
+
+	client.Retry()
+	if state := nextState(t, client); state.State != StateConnected {
+		t.Errorf("Retry should reconnect, got %+v", state)
+	}
+}
+
+// waitFor polls cond until it holds or the timeout passes
+func waitFor(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestClient_KeepaliveDetectsDeadConnection(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	// A server behind a NAT that has dropped the mapping: the socket stays
+	// open but pings go unanswered
+	ts.onConnect = func(conn *websocket.Conn) {
+		conn.SetPingHandler(func(string) error { return nil })
+	}
+
+	host, port := ts.getHostPort()
+	client := NewClient(host, port, 1)
+	interval, timeout := 100*time.Millisecond, 200*time.Millisecond
+	client.SetKeepalive(interval, timeout)
+	client.Start()
+	defer client.Stop()
+
+	if !waitFor(2*time.Second, client.IsConnected) {
+		t.Fatal("client did not connect")
+	}
+	connected := client.LastMessage()
+	if connected.IsZero() {
+		t.Error("connecting should set the last message time")
+	}
+
+	if !waitFor(2*time.Second, func() bool { return !client.IsConnected() }) {
+		t.Fatal("dead connection was not detected")
+	}
+	if took := time.Since(connected); took > interval+timeout+300*time.Millisecond {
+		t.Errorf("detection took %v, want within %v", took, interval+timeout)
+	}
+
+	var ce *ConnectError
+	if err := client.LastError(); !errors.As(err, &ce) || ce.Kind != KindTimeout || !errors.Is(ce.Err, ErrKeepalive) {
+		t.Errorf("expected a keepalive timeout error, got %v", err)
+	}
+
+	// The reconnect loop takes over as for any other drop
+	if !waitFor(3*time.Second, client.IsConnected) {
+		t.Error("client did not reconnect after the keepalive timeout")
+	}
+}
+
+func TestClient_KeepaliveKeepsLiveConnection(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	host, port := ts.getHostPort()
+	client := NewClient(host, port, 1)
+	client.SetKeepalive(50*time.Millisecond, 100*time.Millisecond)
+	client.Start()
+	defer client.Stop()
+
+	if !waitFor(2*time.Second, client.IsConnected) {
+		t.Fatal("client did not connect")
+	}
+	connects := ts.connectionCount()
+
+	// Several timeouts' worth of silence is fine while pongs come back
+	time.Sleep(500 * time.Millisecond)
+	if !client.IsConnected() || ts.connectionCount() != connects {
+		t.Error("a connection answering pings should not be dropped")
+	}
+}
+
 func TestClient_ReconnectDelay(t *testing.T) {
 	ts := newTestServer()
 	ts.rejectAuth = true // Reject connections to force reconnect loop
@@ -380,8 +621,8 @@ func TestClient_ReceiveAircraftSnapshot(t *testing.T) {
 		var msg map[string]interface{}
 		if err := json.Unmarshal(data, &msg); err == nil {
 			if msg["action"] == "subscribe" {
-				snapshot := Message{
-					Type: string(AircraftSnapshot),
+				snapshot := codec.Message{
+					Type: string(codec.AircraftSnapshot),
 					Data: json.RawMessage(`{"aircraft":{"ABC123":{"hex":"ABC123","flight":"TEST001","lat":45.0,"lon":-93.0}}}`),
 				}
 				msgBytes, _ := json.Marshal(snapshot)
@@ -396,10 +637,10 @@ func TestClient_ReceiveAircraftSnapshot(t *testing.T) {
 	// Wait for message
 	select {
 	case msg := <-client.AircraftMessages():
-		if msg.Type != string(AircraftSnapshot) {
-			t.Errorf("Expected type %s, got %s", AircraftSnapshot, msg.Type)
+		if msg.Type != string(codec.AircraftSnapshot) {
+			t.Errorf("Expected type %s, got %s", codec.AircraftSnapshot, msg.Type)
 		}
-		aircraft, err := ParseAircraftSnapshot(msg.Data)
+		aircraft, err := codec.ParseSnapshot(msg.Data)
 		if err != nil {
 			t.Errorf("Failed to parse aircraft snapshot: %v", err)
 		}
@@ -422,8 +663,8 @@ func TestClient_ReceiveAircraftUpdate(t *testing.T) {
 		var msg map[string]interface{}
 		if err := json.Unmarshal(data, &msg); err == nil {
 			if msg["action"] == "subscribe" {
-				update := Message{
-					Type: string(AircraftUpdate),
+				update := codec.Message{
+					Type: string(codec.AircraftUpdate),
 					Data: json.RawMessage(`{"hex":"ABC123","flight":"TEST001","lat":45.5,"lon":-93.5,"alt_baro":35000}`),
 				}
 				msgBytes, _ := json.Marshal(update)
@@ -437,10 +678,10 @@ func TestClient_ReceiveAircraftUpdate(t *testing.T) {
 
 	select {
 	case msg := <-client.AircraftMessages():
-		if msg.Type != string(AircraftUpdate) {
-			t.Errorf("Expected type %s, got %s", AircraftUpdate, msg.Type)
+		if msg.Type != string(codec.AircraftUpdate) {
+			t.Errorf("Expected type %s, got %s", codec.AircraftUpdate, msg.Type)
 		}
-		aircraft, err := ParseAircraft(msg.Data)
+		aircraft, err := codec.ParseAircraft(msg.Data)
 		if err != nil {
 			t.Errorf("Failed to parse aircraft: %v", err)
 		}
@@ -466,8 +707,8 @@ func TestClient_ReceiveAircraftNew(t *testing.T) {
 		var msg map[string]interface{}
 		if err := json.Unmarshal(data, &msg); err == nil {
 			if msg["action"] == "subscribe" {
-				newAc := Message{
-					Type: string(AircraftNew),
+				newAc := codec.Message{
+					Type: string(codec.AircraftNew),
 					Data: json.RawMessage(`{"hex":"NEW456","flight":"NEWAIR","lat":44.0,"lon":-94.0,"military":true}`),
 				}
 				msgBytes, _ := json.Marshal(newAc)
@@ -481,10 +722,10 @@ func TestClient_ReceiveAircraftNew(t *testing.T) {
 
 	select {
 	case msg := <-client.AircraftMessages():
-		if msg.Type != string(AircraftNew) {
-			t.Errorf("Expected type %s, got %s", AircraftNew, msg.Type)
+		if msg.Type != string(codec.AircraftNew) {
+			t.Errorf("Expected type %s, got %s", codec.AircraftNew, msg.Type)
 		}
-		aircraft, err := ParseAircraft(msg.Data)
+		aircraft, err := codec.ParseAircraft(msg.Data)
 		if err != nil {
 			t.Errorf("Failed to parse aircraft: %v", err)
 		}
@@ -510,8 +751,8 @@ func TestClient_ReceiveAircraftRemove(t *testing.T) {
 		var msg map[string]interface{}
 		if err := json.Unmarshal(data, &msg); err == nil {
 			if msg["action"] == "subscribe" {
-				removeMsg := Message{
-					Type: string(AircraftRemove),
+				removeMsg := codec.Message{
+					Type: string(codec.AircraftRemove),
 					Data: json.RawMessage(`{"hex":"GONE789"}`),
 				}
 				msgBytes, _ := json.Marshal(removeMsg)
@@ -525,10 +766,10 @@ func TestClient_ReceiveAircraftRemove(t *testing.T) {
 
 	select {
 	case msg := <-client.AircraftMessages():
-		if msg.Type != string(AircraftRemove) {
-			t.Errorf("Expected type %s, got %s", AircraftRemove, msg.Type)
+		if msg.Type != string(codec.AircraftRemove) {
+			t.Errorf("Expected type %s, got %s", codec.AircraftRemove, msg.Type)
 		}
-		aircraft, err := ParseAircraft(msg.Data)
+		aircraft, err := codec.ParseAircraft(msg.Data)
 		if err != nil {
 			t.Errorf("Failed to parse aircraft: %v", err)
 		}
@@ -555,8 +796,8 @@ func TestClient_ReceiveACARSMessage(t *testing.T) {
 				if topics, ok := msg["topics"].([]interface{}); ok {
 					for _, topic := range topics {
 						if topic == "messages" {
-							acarsMsg := Message{
-								Type: string(ACARSMessage),
+							acarsMsg := codec.Message{
+								Type: string(codec.ACARSMessage),
 								Data: json.RawMessage(`{"callsign":"TEST123","flight":"TS123","label":"H1","text":"POSITION REPORT"}`),
 							}
 							msgBytes, _ := json.Marshal(acarsMsg)
@@ -574,10 +815,10 @@ func TestClient_ReceiveACARSMessage(t *testing.T) {
 
 	select {
 	case msg := <-client.ACARSMessages():
-		if msg.Type != string(ACARSMessage) {
-			t.Errorf("Expected type %s, got %s", ACARSMessage, msg.Type)
+		if msg.Type != string(codec.ACARSMessage) {
+			t.Errorf("Expected type %s, got %s", codec.ACARSMessage, msg.Type)
 		}
-		acarsData, err := ParseACARSData(msg.Data)
+		acarsData, err := codec.ParseACARS(msg.Data)
 		if err != nil {
 			t.Errorf("Failed to parse ACARS data: %v", err)
 		}
@@ -610,8 +851,8 @@ func TestClient_ReceiveACARSSnapshot(t *testing.T) {
 				if topics, ok := msg["topics"].([]interface{}); ok {
 					for _, topic := range topics {
 						if topic == "messages" {
-							snapshot := Message{
-								Type: string(ACARSSnapshot),
+							snapshot := codec.Message{
+								Type: string(codec.ACARSSnapshot),
 								Data: json.RawMessage(`[{"callsign":"AC1","flight":"FL1","label":"H1","text":"MSG1"},{"callsign":"AC2","flight":"FL2","label":"H2","text":"MSG2"}]`),
 							}
 							msgBytes, _ := json.Marshal(snapshot)
@@ -629,10 +870,10 @@ func TestClient_ReceiveACARSSnapshot(t *testing.T) {
 
 	select {
 	case msg := <-client.ACARSMessages():
-		if msg.Type != string(ACARSSnapshot) {
-			t.Errorf("Expected type %s, got %s", ACARSSnapshot, msg.Type)
+		if msg.Type != string(codec.ACARSSnapshot) {
+			t.Errorf("Expected type %s, got %s", codec.ACARSSnapshot, msg.Type)
 		}
-		acarsData, err := ParseACARSData(msg.Data)
+		acarsData, err := codec.ParseACARS(msg.Data)
 		if err != nil {
 			t.Errorf("Failed to parse ACARS snapshot: %v", err)
 		}
@@ -644,239 +885,6 @@ func TestClient_ReceiveACARSSnapshot(t *testing.T) {
 	}
 }
 
-// ============================================================================
-// Parser Tests
-// ============================================================================
-
-func TestParseAircraftSnapshot_Map(t *testing.T) {
-	data := json.RawMessage(`{
-		"aircraft": {
-			"ABC123": {"hex": "ABC123", "flight": "TEST1", "lat": 45.0, "lon": -93.0},
-			"DEF456": {"hex": "DEF456", "flight": "TEST2", "lat": 46.0, "lon": -94.0}
-		}
-	}`)
-
-	aircraft, err := ParseAircraftSnapshot(data)
-	if err != nil {
-		t.Fatalf("ParseAircraftSnapshot failed: %v", err)
-	}
-
-	if len(aircraft) != 2 {
-		t.Errorf("Expected 2 aircraft, got %d", len(aircraft))
-	}
-
-	// Check that both aircraft are present (order may vary due to map)
-	hexes := make(map[string]bool)
-	for _, ac := range aircraft {
-		hexes[ac.Hex] = true
-	}
-
-	if !hexes["ABC123"] {
-		t.Error("Missing aircraft ABC123")
-	}
-	if !hexes["DEF456"] {
-		t.Error("Missing aircraft DEF456")
-	}
-}
-
-func TestParseAircraftSnapshot_Array(t *testing.T) {
-	data := json.RawMessage(`[
-		{"hex": "ABC123", "flight": "TEST1", "lat": 45.0, "lon": -93.0},
-		{"hex": "DEF456", "flight": "TEST2", "lat": 46.0, "lon": -94.0},
-		{"hex": "GHI789", "flight": "TEST3", "lat": 47.0, "lon": -95.0}
-	]`)
-
-	aircraft, err := ParseAircraftSnapshot(data)
-	if err != nil {
-		t.Fatalf("ParseAircraftSnapshot failed: %v", err)
-	}
-
-	if len(aircraft) != 3 {
-		t.Errorf("Expected 3 aircraft, got %d", len(aircraft))
-	}
-
-	if aircraft[0].Hex != "ABC123" {
-		t.Errorf("Expected first aircraft hex ABC123, got %s", aircraft[0].Hex)
-	}
-	if aircraft[2].Hex != "GHI789" {
-		t.Errorf("Expected third aircraft hex GHI789, got %s", aircraft[2].Hex)
-	}
-}
-
-func TestParseAircraft_AllFields(t *testing.T) {
-	lat := 45.5
-	lon := -93.5
-	altBaro := 35000
-	alt := 34800
-	gs := 450.5
-	track := 180.5
-	baroRate := -500.0
-	vr := -480.0
-	rssi := -25.5
-	distance := 15.5
-	bearing := 270.0
-
-	data := json.RawMessage(`{
-		"hex": "ABC123",
-		"flight": "UAL123  ",
-		"lat": 45.5,
-		"lon": -93.5,
-		"alt_baro": 35000,
-		"alt": 34800,
-		"gs": 450.5,
-		"track": 180.5,
-		"baro_rate": -500.0,
-		"vr": -480.0,
-		"squawk": "7500",
-		"rssi": -25.5,
-		"t": "B738",
-		"military": true,
-		"distance_nm": 15.5,
-		"bearing": 270.0
-	}`)
-
-	aircraft, err := ParseAircraft(data)
-	if err != nil {
-		t.Fatalf("ParseAircraft failed: %v", err)
-	}
-
-	// Verify all fields
-	tests := []struct {
-		name     string
-		got      interface{}
-		expected interface{}
-	}{
-		{"Hex", aircraft.Hex, "ABC123"},
-		{"Flight", aircraft.Flight, "UAL123  "},
-		{"Lat", *aircraft.Lat, lat},
-		{"Lon", *aircraft.Lon, lon},
-		{"AltBaro", *aircraft.AltBaro, altBaro},
-		{"Alt", *aircraft.Alt, alt},
-		{"GS", *aircraft.GS, gs},
-		{"Track", *aircraft.Track, track},
-		{"BaroRate", *aircraft.BaroRate, baroRate},
-		{"VR", *aircraft.VR, vr},
-		{"Squawk", aircraft.Squawk, "7500"},
-		{"RSSI", *aircraft.RSSI, rssi},
-		{"Type", aircraft.Type, "B738"},
-		{"Military", aircraft.Military, true},
-		{"Distance", *aircraft.Distance, distance},
-		{"Bearing", *aircraft.Bearing, bearing},
-	}
-
-	for _, tt := range tests {
-		if tt.got != tt.expected {
-			t.Errorf("%s: got %v, expected %v", tt.name, tt.got, tt.expected)
-		}
-	}
-}
-
-func TestParseAircraft_PartialFields(t *testing.T) {
-	data := json.RawMessage(`{
-		"hex": "ABC123",
-		"flight": "TEST001",
-		"lat": 45.0
-	}`)
-
-	aircraft, err := ParseAircraft(data)
-	if err != nil {
-		t.Fatalf("ParseAircraft failed: %v", err)
-	}
-
-	// Required/present fields
-	if aircraft.Hex != "ABC123" {
-		t.Errorf("Expected hex ABC123, got %s", aircraft.Hex)
-	}
-	if aircraft.Flight != "TEST001" {
-		t.Errorf("Expected flight TEST001, got %s", aircraft.Flight)
-	}
-	if aircraft.Lat == nil {
-		t.Error("Expected Lat to be set")
-	} else if *aircraft.Lat != 45.0 {
-		t.Errorf("Expected Lat 45.0, got %f", *aircraft.Lat)
-	}
-
-	// Optional/missing fields should be nil
-	if aircraft.Lon != nil {
-		t.Error("Expected Lon to be nil")
-	}
-	if aircraft.AltBaro != nil {
-		t.Error("Expected AltBaro to be nil")
-	}
-	if aircraft.GS != nil {
-		t.Error("Expected GS to be nil")
-	}
-	if aircraft.Track != nil {
-		t.Error("Expected Track to be nil")
-	}
-	if aircraft.RSSI != nil {
-		t.Error("Expected RSSI to be nil")
-	}
-	if aircraft.Military {
-		t.Error("Expected Military to be false (default)")
-	}
-}
-
-func TestParseACARSData_Single(t *testing.T) {
-	data := json.RawMessage(`{
-		"callsign": "UAL123",
-		"flight": "UA123",
-		"label": "H1",
-		"text": "POSITION REPORT LAT 45.0 LON -93.0"
-	}`)
-
-	acarsData, err := ParseACARSData(data)
-	if err != nil {
-		t.Fatalf("ParseACARSData failed: %v", err)
-	}
-
-	if len(acarsData) != 1 {
-		t.Fatalf("Expected 1 ACARS message, got %d", len(acarsData))
-	}
-
-	msg := acarsData[0]
-	if msg.Callsign != "UAL123" {
-		t.Errorf("Expected callsign UAL123, got %s", msg.Callsign)
-	}
-	if msg.Flight != "UA123" {
-		t.Errorf("Expected flight UA123, got %s", msg.Flight)
-	}
-	if msg.Label != "H1" {
-		t.Errorf("Expected label H1, got %s", msg.Label)
-	}
-	if msg.Text != "POSITION REPORT LAT 45.0 LON -93.0" {
-		t.Errorf("Unexpected text: %s", msg.Text)
-	}
-}
-
-func TestParseACARSData_Array(t *testing.T) {
-	data := json.RawMessage(`[
-		{"callsign": "UAL123", "flight": "UA123", "label": "H1", "text": "MSG1"},
-		{"callsign": "DAL456", "flight": "DL456", "label": "H2", "text": "MSG2"},
-		{"callsign": "AAL789", "flight": "AA789", "label": "H3", "text": "MSG3"}
-	]`)
-
-	acarsData, err := ParseACARSData(data)
-	if err != nil {
-		t.Fatalf("ParseACARSData failed: %v", err)
-	}
-
-	if len(acarsData) != 3 {
-		t.Fatalf("Expected 3 ACARS messages, got %d", len(acarsData))
-	}
-
-	expectedCallsigns := []string{"UAL123", "DAL456", "AAL789"}
-	for i, expected := range expectedCallsigns {
-		if acarsData[i].Callsign != expected {
-			t.Errorf("Message %d: expected callsign %s, got %s", i, expected, acarsData[i].Callsign)
-		}
-	}
-}
-
-// ============================================================================
-// State Tests
-// ============================================================================
-
 func TestClient_State(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
@@ -1081,79 +1089,6 @@ func TestClient_SetAuthProvider(t *testing.T) {
 // Edge Case Tests
 // ============================================================================
 
-func TestParseAircraftSnapshot_Empty(t *testing.T) {
-	// Empty object with aircraft map
-	data := json.RawMessage(`{"aircraft":{}}`)
-	aircraft, err := ParseAircraftSnapshot(data)
-	if err != nil {
-		t.Fatalf("ParseAircraftSnapshot failed on empty: %v", err)
-	}
-	if len(aircraft) != 0 {
-		t.Errorf("Expected 0 aircraft, got %d", len(aircraft))
-	}
-
-	// Empty array
-	data = json.RawMessage(`[]`)
-	aircraft, err = ParseAircraftSnapshot(data)
-	if err != nil {
-		t.Fatalf("ParseAircraftSnapshot failed on empty array: %v", err)
-	}
-	if len(aircraft) != 0 {
-		t.Errorf("Expected 0 aircraft, got %d", len(aircraft))
-	}
-}
-
-func TestParseAircraftSnapshot_Invalid(t *testing.T) {
-	tests := []struct {
-		name      string
-		data      json.RawMessage
-		expectErr bool
-	}{
-		{"string", json.RawMessage(`"not an object or array"`), true},
-		{"number", json.RawMessage(`123`), true},
-		// Note: null and empty object parse successfully as empty arrays
-		{"null", json.RawMessage(`null`), false},
-		{"other_field", json.RawMessage(`{"other_field": "value"}`), true},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			_, err := ParseAircraftSnapshot(tt.data)
-			if tt.expectErr && err == nil {
-				t.Error("Expected error for invalid data, got none")
-			}
-			if !tt.expectErr && err != nil {
-				t.Errorf("Unexpected error: %v", err)
-			}
-		})
-	}
-}
-
-func TestParseACARSData_Invalid(t *testing.T) {
-	tests := []struct {
-		name      string
-		data      json.RawMessage
-		expectErr bool
-	}{
-		{"string", json.RawMessage(`"not valid"`), true},
-		{"number", json.RawMessage(`123`), true},
-		// Note: null parses successfully as empty array in Go
-		{"null", json.RawMessage(`null`), false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			_, err := ParseACARSData(tt.data)
-			if tt.expectErr && err == nil {
-				t.Error("Expected error for invalid ACARS data, got none")
-			}
-			if !tt.expectErr && err != nil {
-				t.Errorf("Unexpected error: %v", err)
-			}
-		})
-	}
-}
-
 func TestClient_ChannelBuffer(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
@@ -1169,8 +1104,8 @@ func TestClient_ChannelBuffer(t *testing.T) {
 			if msg["action"] == "subscribe" {
 				// Send more messages than buffer size to test overflow handling
 				for i := 0; i < 150; i++ {
-					update := Message{
-						Type: string(AircraftUpdate),
+					update := codec.Message{
+						Type: string(codec.AircraftUpdate),
 						Data: json.RawMessage(`{"hex":"TEST` + string(rune('0'+i%10)) + `"}`),
 					}
 					msgBytes, _ := json.Marshal(update)
@@ -1287,30 +1222,6 @@ func TestClient_MessageChannels(t *testing.T) {
 }
 
 // Table-driven test for message types
-func TestMessageTypes(t *testing.T) {
-	tests := []struct {
-		name     string
-		msgType  MessageType
-		expected string
-	}{
-		{"AircraftSnapshot", AircraftSnapshot, "aircraft:snapshot"},
-		{"AircraftUpdate", AircraftUpdate, "aircraft:update"},
-		{"AircraftNew", AircraftNew, "aircraft:new"},
-		{"AircraftRemove", AircraftRemove, "aircraft:remove"},
-		{"ACARSMessage", ACARSMessage, "acars:message"},
-		{"ACARSSnapshot", ACARSSnapshot, "acars:snapshot"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if string(tt.msgType) != tt.expected {
-				t.Errorf("Expected %s, got %s", tt.expected, string(tt.msgType))
-			}
-		})
-	}
-}
-
-// Table-driven test for client states
 func TestClientStates(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1439,38 +1350,6 @@ func TestClient_AuthProvider_UnknownFormat(t *testing.T) {
 	}
 }
 
-func TestParseAircraft_InvalidJSON(t *testing.T) {
-	data := json.RawMessage(`{invalid json}`)
-
-	aircraft, err := ParseAircraft(data)
-	if err == nil {
-		t.Error("Expected error for invalid JSON")
-	}
-	if aircraft != nil {
-		t.Error("Expected nil aircraft for invalid JSON")
-	}
-}
-
-func TestParseAircraftSnapshot_NestedAircraftFormat(t *testing.T) {
-	// Test the nested format parsing (third try in ParseAircraftSnapshot)
-	data := json.RawMessage(`{
-		"aircraft": {
-			"TEST1": {"hex": "TEST1", "flight": "FL1"},
-			"TEST2": {"hex": "TEST2", "flight": "FL2"}
-		},
-		"extra_field": "ignored"
-	}`)
-
-	aircraft, err := ParseAircraftSnapshot(data)
-	if err != nil {
-		t.Fatalf("ParseAircraftSnapshot failed: %v", err)
-	}
-
-	if len(aircraft) != 2 {
-		t.Errorf("Expected 2 aircraft, got %d", len(aircraft))
-	}
-}
-
 func TestClient_InvalidMessageJSON(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
@@ -1485,8 +1364,8 @@ func TestClient_InvalidMessageJSON(t *testing.T) {
 				// Send invalid JSON message
 				conn.WriteMessage(websocket.TextMessage, []byte(`{invalid json`))
 				// Then send a valid message
-				validMsg := Message{
-					Type: string(AircraftUpdate),
+				validMsg := codec.Message{
+					Type: string(codec.AircraftUpdate),
 					Data: json.RawMessage(`{"hex":"VALID123"}`),
 				}
 				msgBytes, _ := json.Marshal(validMsg)
@@ -1501,7 +1380,7 @@ func TestClient_InvalidMessageJSON(t *testing.T) {
 	// Should still receive the valid message after the invalid one
 	select {
 	case msg := <-client.AircraftMessages():
-		if msg.Type != string(AircraftUpdate) {
+		if msg.Type != string(codec.AircraftUpdate) {
 			t.Errorf("Expected update message, got %s", msg.Type)
 		}
 	case <-time.After(3 * time.Second):
@@ -1577,25 +1456,6 @@ func TestClient_StopBeforeConnect(t *testing.T) {
 	// If we get here without hanging, test passes
 }
 
-func TestParseAircraftSnapshot_FallbackFormats(t *testing.T) {
-	// Test with data that passes the first parse but has nil Aircraft
-	// This will fall through to try array parsing
-	data := json.RawMessage(`{}`)
-
-	aircraft, err := ParseAircraftSnapshot(data)
-	// Empty object should fail or return empty
-	if err == nil && len(aircraft) > 0 {
-		t.Error("Expected empty result or error for empty object")
-	}
-
-	// Test data that is definitely not an array and not the expected format
-	data = json.RawMessage(`{"not_aircraft": {"a": "b"}}`)
-	_, err = ParseAircraftSnapshot(data)
-	if err == nil {
-		t.Error("Expected error for object without aircraft field")
-	}
-}
-
 func TestClient_StopImmediately(t *testing.T) {
 	// Create a client and immediately stop it before starting
 	// This tests the stopCh check at the beginning of the connection loop
@@ -1729,8 +1589,8 @@ func TestClient_StopDuringInitialSelectCheck(t *testing.T) {
 		reconnectDelay: time.Second,
 		state:          StateDisconnected,
 		stopCh:         make(chan struct{}),
-		aircraftMsgCh:  make(chan Message, 100),
-		acarsMsgCh:     make(chan Message, 100),
+		aircraftMsgCh:  make(chan codec.Message, 100),
+		acarsMsgCh:     make(chan codec.Message, 100),
 	}
 
 	// Close stopCh before running
@@ -1741,7 +1601,7 @@ func TestClient_StopDuringInitialSelectCheck(t *testing.T) {
 
 	// Run the connection loop - it should exit immediately due to closed stopCh
 	go func() {
-		client.runConnection("ws://localhost:9999/test", client.aircraftMsgCh, "test", client.setAircraftState)
+		client.runConnection("ws://localhost:9999/test", client.aircraftMsgCh, "test", client.setAircraftState, nil, nil, nil)
 		done <- true
 	}()
 
@@ -1869,3 +1729,40 @@ func TestClient_WriteJSONConnectionClosed(t *testing.T) {
 
 	// If we get here, the test passes
 }
+
+func TestClient_Acknowledge(t *testing.T) {
+	client := NewClient("localhost", 1, 1)
+	if err := client.Acknowledge("m1"); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("expected ErrNotConnected before connecting, got %v", err)
+	}
+
+	ts := newTestServer()
+	defer ts.Close()
+
+	host, port := ts.getHostPort()
+	client = NewClient(host, port, 1)
+	client.Start()
+	defer client.Stop()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !client.IsConnected() && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err := client.Acknowledge("m1"); err != nil {
+		t.Fatalf("Acknowledge failed: %v", err)
+	}
+
+	for time.Now().Before(deadline) {
+		ts.mu.Lock()
+		for _, data := range ts.messages {
+			var msg map[string]string
+			if json.Unmarshal(data, &msg) == nil && msg["action"] == "ack" && msg["id"] == "m1" {
+				ts.mu.Unlock()
+				return
+			}
+		}
+		ts.mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Error("server did not receive the acknowledgement")
+}
@@ -0,0 +1,101 @@
+// Package ws provides connection error classification for the SkySpy WebSocket client
+package ws
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// ErrorKind classifies why a connection attempt failed
+type ErrorKind int
+
+const (
+	KindOther ErrorKind = iota
+	KindDNS
+	KindRefused
+	KindTimeout
+	KindTLS
+	KindAuth
+	KindHTTP
+)
+
+// String returns a short description of the failure kind
+func (k ErrorKind) String() string {
+	switch k {
+	case KindDNS:
+		return "host not found"
+	case KindRefused:
+		return "connection refused"
+	case KindTimeout:
+		return "timed out"
+	case KindTLS:
+		return "TLS error"
+	case KindAuth:
+		return "authentication required"
+	case KindHTTP:
+		return "rejected by server"
+	default:
+		return "connection failed"
+	}
+}
+
+// ConnectError describes a failed connection attempt
+type ConnectError struct {
+	Kind   ErrorKind
+	URL    string
+	Status int // HTTP status of a rejected handshake, or 0
+	Err    error
+}
+
+func (e *ConnectError) Error() string {
+	msg := e.Kind.String()
+	if e.Status != 0 {
+		msg += fmt.Sprintf(" (HTTP %d)", e.Status)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ConnectError) Unwrap() error {
+	return e.Err
+}
+
+// classifyDialError wraps a failed dial. resp is the handshake response, if
+// the server sent one.
+func classifyDialError(url string, resp *http.Response, err error) *ConnectError {
+	ce := &ConnectError{Kind: KindOther, URL: url, Err: err}
+	if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+		ce.Status = resp.StatusCode
+		ce.Kind = KindHTTP
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			ce.Kind = KindAuth
+		}
+		return ce
+	}
+
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &dnsErr):
+		ce.Kind = KindDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		ce.Kind = KindRefused
+	case errors.As(err, &certErr), errors.As(err, &recordErr),
+		errors.As(err, &authorityErr), errors.As(err, &hostnameErr):
+		ce.Kind = KindTLS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		ce.Kind = KindTimeout
+	}
+	return ce
+}
@@ -0,0 +1,126 @@
+package ws
+
+import (
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestClassifyDialError(t *testing.T) {
+	const url = "ws://example:8000/ws/aircraft/"
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}}
+	timeout := &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}
+
+	tests := []struct {
+		name   string
+		resp   *http.Response
+		err    error
+		want   ErrorKind
+		status int
+	}{
+		{"dns", nil, &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "example", IsNotFound: true}}, KindDNS, 0},
+		{"refused", nil, refused, KindRefused, 0},
+		{"timeout", nil, timeout, KindTimeout, 0},
+		{"tls", nil, x509.UnknownAuthorityError{}, KindTLS, 0},
+		{"unauthorized", &http.Response{StatusCode: http.StatusUnauthorized}, errors.New("bad handshake"), KindAuth, 401},
+		{"forbidden", &http.Response{StatusCode: http.StatusForbidden}, errors.New("bad handshake"), KindAuth, 403},
+		{"not found", &http.Response{StatusCode: http.StatusNotFound}, errors.New("bad handshake"), KindHTTP, 404},
+		{"other", nil, errors.New("boom"), KindOther, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ce := classifyDialError(url, tt.resp, tt.err)
+			if ce.Kind != tt.want {
+				t.Errorf("Kind = %v, want %v", ce.Kind, tt.want)
+			}
+			if ce.Status != tt.status {
+				t.Errorf("Status = %d, want %d", ce.Status, tt.status)
+			}
+			if ce.URL != url {
+				t.Errorf("URL = %q, want %q", ce.URL, url)
+			}
+			if !errors.Is(ce, tt.err) {
+				t.Error("ConnectError should unwrap to the dial error")
+			}
+		})
+	}
+}
+
+func TestConnectError_Error(t *testing.T) {
+	err := &ConnectError{Kind: KindAuth, Status: 401, Err: errors.New("bad handshake")}
+	if got, want := err.Error(), "authentication required (HTTP 401): bad handshake"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestClient_LastError_Auth(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.rejectAuth = true
+
+	host, port := ts.getHostPort()
+	client := NewClient(host, port, 60)
+	client.Start()
+	defer client.Stop()
+
+	var ce *ConnectError
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := client.LastError(); err != nil && errors.As(err, &ce) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if ce == nil {
+		t.Fatal("expected a connection error")
+	}
+	if ce.Kind != KindAuth || ce.Status != http.StatusUnauthorized {
+		t.Errorf("got %v (HTTP %d), want auth failure", ce.Kind, ce.Status)
+	}
+	if !strings.HasPrefix(ce.URL, "ws://") || ce.URL != client.URL() {
+		t.Errorf("URL = %q, want %q", ce.URL, client.URL())
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.rejectAuth = true
+
+	host, port := ts.getHostPort()
+	client := NewClient(host, port, 60)
+	client.Start()
+	defer client.Stop()
+
+	// Wait for the first failure; the next attempt is a minute away
+	deadline := time.Now().Add(5 * time.Second)
+	for client.LastError() == nil && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if client.LastError() == nil {
+		t.Fatal("expected a connection error")
+	}
+
+	ts.mu.Lock()
+	ts.rejectAuth = false
+	ts.mu.Unlock()
+	client.Retry()
+
+	deadline = time.Now().Add(5 * time.Second)
+	for !client.IsConnected() && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if !client.IsConnected() {
+		t.Fatal("Retry should reconnect without waiting out the delay")
+	}
+	if err := client.LastError(); err != nil {
+		t.Errorf("LastError should clear once connected, got %v", err)
+	}
+}
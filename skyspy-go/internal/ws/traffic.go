@@ -0,0 +1,87 @@
+// Package ws provides traffic counters for the SkySpy WebSocket client
+package ws
+
+import (
+	"sync"
+	"time"
+)
+
+// rateWindow is the span the traffic rate is measured over, kept as one
+// bucket per second
+const rateWindow = 60
+
+// TrafficStats is a snapshot of the data a client has moved, counted as
+// WebSocket message payloads. Totals cover the whole session, across
+// reconnects and both feeds.
+type TrafficStats struct {
+	RxBytes     int64   // payload bytes received
+	TxBytes     int64   // payload bytes sent (subscriptions)
+	RxMessages  int64   // messages received
+	RxPerMinute float64 // bytes received over the last minute
+}
+
+// traffic counts bytes for a client. It is shared by the connection
+// goroutines and read by the UI, so every access takes the lock.
+type traffic struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	rx, tx   int64
+	messages int64
+	buckets  [rateWindow]int64 // bytes received per second, by unix second mod rateWindow
+	newest   int64             // unix second of the newest bucket written
+}
+
+func newTraffic() *traffic {
+	return &traffic{now: time.Now}
+}
+
+// received counts one message of n bytes
+func (t *traffic) received(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rx += int64(n)
+	t.messages++
+	sec := t.now().Unix()
+	t.advance(sec)
+	t.buckets[sec%rateWindow] += int64(n)
+}
+
+// sent counts n bytes written
+func (t *traffic) sent(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tx += int64(n)
+}
+
+// advance clears the buckets of seconds that passed without traffic, up to
+// sec
+func (t *traffic) advance(sec int64) {
+	if sec <= t.newest {
+		return
+	}
+	if sec-t.newest >= rateWindow {
+		t.buckets = [rateWindow]int64{}
+	} else {
+		for s := t.newest + 1; s <= sec; s++ {
+			t.buckets[s%rateWindow] = 0
+		}
+	}
+	t.newest = sec
+}
+
+// snapshot returns the current totals and rate
+func (t *traffic) snapshot() TrafficStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.advance(t.now().Unix())
+	var window int64
+	for _, b := range t.buckets {
+		window += b
+	}
+	return TrafficStats{
+		RxBytes:     t.rx,
+		TxBytes:     t.tx,
+		RxMessages:  t.messages,
+		RxPerMinute: float64(window),
+	}
+}
@@ -0,0 +1,137 @@
+package ws
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestTraffic_RollingRate(t *testing.T) {
+	clock := time.Unix(1_700_000_000, 0)
+	tr := newTraffic()
+	tr.now = func() time.Time { return clock }
+
+	tr.received(1000)
+	clock = clock.Add(30 * time.Second)
+	tr.received(500)
+	tr.sent(40)
+
+	st := tr.snapshot()
+	if st.RxBytes != 1500 || st.TxBytes != 40 || st.RxMessages != 2 {
+		t.Errorf("totals = %+v", st)
+	}
+	if st.RxPerMinute != 1500 {
+		t.Errorf("rate = %v, want 1500 within the minute", st.RxPerMinute)
+	}
+
+	// The first message ages out of the window; totals are kept
+	clock = clock.Add(31 * time.Second)
+	if st := tr.snapshot(); st.RxPerMinute != 500 || st.RxBytes != 1500 {
+		t.Errorf("after 61s: %+v, want rate 500 and total 1500", st)
+	}
+
+	// A long silence clears the window entirely
+	clock = clock.Add(10 * time.Minute)
+	if st := tr.snapshot(); st.RxPerMinute != 0 {
+		t.Errorf("after silence: rate = %v, want 0", st.RxPerMinute)
+	}
+	tr.received(200)
+	if st := tr.snapshot(); st.RxPerMinute != 200 || st.RxBytes != 1700 {
+		t.Errorf("after resuming: %+v", st)
+	}
+}
+
+func TestClient_TrafficCountsMessagesAcrossReconnects(t *testing.T) {
+	payloads := []string{
+		`{"type":"aircraft:snapshot","data":[]}`,
+		`{"type":"aircraft:update","data":{"hex":"ABC123"}}`,
+		strings.Repeat(" ", 1000) + `{"type":"aircraft:update","data":{"hex":"DEF456"}}`,
+	}
+	var perSession int64
+	for _, p := range payloads {
+		perSession += int64(len(p))
+	}
+
+	var sessions atomic.Int32
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, _, err := conn.ReadMessage(); err != nil { // subscribe
+			return
+		}
+		if strings.Contains(r.URL.Path, "acars") {
+			// The ACARS feed stays quiet
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}
+		for _, p := range payloads {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(p))
+		}
+		// Drop the first session so the client reconnects and the counts
+		// carry over
+		if sessions.Add(1) == 1 {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	addr := strings.TrimPrefix(server.URL, "http://")
+	host, portStr, _ := strings.Cut(addr, ":")
+	var port int
+	_ = json.Unmarshal([]byte(portStr), &port)
+
+	client := NewClient(host, port, 0)
+	client.Start()
+	defer client.Stop()
+
+	go func() {
+		for range client.AircraftMessages() {
+		}
+	}()
+
+	// Subscriptions: the aircraft feed twice and the ACARS feed once, and
+	// the snapshot request on reconnecting
+	aircraftSub := len(`{"action":"subscribe","topics":["aircraft"]}`)
+	acarsSub := len(`{"action":"subscribe","topics":["messages"]}`)
+	resync := len(`{"action":"request","request_id":"skyspy-resync","type":"aircraft-snapshot"}`)
+	wantTx := int64(2*aircraftSub + acarsSub + resync)
+
+	if !waitFor(5*time.Second, func() bool {
+		st := client.Traffic()
+		return st.RxMessages >= 6 && st.TxBytes >= wantTx
+	}) {
+		t.Fatalf("timed out waiting for two sessions, got %+v", client.Traffic())
+	}
+
+	st := client.Traffic()
+	if st.RxBytes != 2*perSession {
+		t.Errorf("RxBytes = %d, want %d", st.RxBytes, 2*perSession)
+	}
+	if st.RxMessages != 6 {
+		t.Errorf("RxMessages = %d, want 6", st.RxMessages)
+	}
+	if st.RxPerMinute != float64(2*perSession) {
+		t.Errorf("RxPerMinute = %v, want %d", st.RxPerMinute, 2*perSession)
+	}
+	if st.TxBytes != wantTx {
+		t.Errorf("TxBytes = %d, want %d", st.TxBytes, wantTx)
+	}
+}
@@ -0,0 +1,157 @@
+package skyspy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/auth"
+	"github.com/skyspy/skyspy-go/internal/config"
+	"github.com/skyspy/skyspy-go/internal/ws"
+)
+
+// Errors returned by NewClient and Run
+var (
+	// ErrAuthRequired means the server requires authentication and there
+	// is neither an API key nor a saved login for it
+	ErrAuthRequired = errors.New("skyspy: server requires authentication")
+	// ErrAlreadyRun is returned by a second call to Run
+	ErrAlreadyRun = errors.New("skyspy: client has already been run")
+)
+
+// sweepInterval is how often Run looks for timed-out aircraft while the
+// feed is quiet
+const sweepInterval = time.Second
+
+// Client is a connection to a SkySpy server. It authenticates once when
+// created and reconnects on its own when the connection drops. Create one
+// with NewClient, start it with Run and read changes from Events or the
+// Store.
+type Client struct {
+	feed    app.Feed
+	model   *app.Model
+	store   *Store
+	events  chan Event
+	dropped atomic.Int64
+	ran     atomic.Bool
+	now     func() time.Time
+}
+
+// NewClient checks the server's authentication requirements and prepares
+// a connection; nothing is streamed until Run is called. It returns
+// ErrAuthRequired if the server needs credentials that are not available.
+func NewClient(opts Options) (*Client, error) {
+	cfg := opts.config()
+
+	authMgr, err := auth.NewManager(cfg.Connection.Host, cfg.Connection.Port)
+	if err != nil {
+		return nil, fmt.Errorf("skyspy: failed to initialize auth: %w", err)
+	}
+	if opts.APIKey != "" {
+		authMgr.SetAPIKey(opts.APIKey)
+	}
+	if authMgr.RequiresAuth() && !authMgr.IsAuthenticated() {
+		return nil, ErrAuthRequired
+	}
+
+	var feed *ws.Client
+	if authMgr.IsAuthenticated() {
+		feed = ws.NewClientWithAuth(cfg.Connection.Host, cfg.Connection.Port, cfg.Connection.ReconnectDelay, authMgr.GetAuthHeader)
+	} else {
+		feed = ws.NewClient(cfg.Connection.Host, cfg.Connection.Port, cfg.Connection.ReconnectDelay)
+	}
+	feed.SetKeepalive(cfg.Connection.Keepalive())
+	feed.SetAutoReconnect(cfg.Connection.AutoReconnect)
+
+	c := newClient(opts, cfg, feed)
+	if skew, ok := authMgr.ClockSkew(); ok {
+		c.model.SetClockSkew(skew)
+	}
+	return c, nil
+}
+
+// newClient wires feed through a headless radar model, so events are
+// normalized exactly as the TUI sees them
+func newClient(opts Options, cfg *config.Config, feed app.Feed) *Client {
+	buffer := opts.EventBuffer
+	if buffer < 1 {
+		buffer = DefaultEventBuffer
+	}
+
+	c := &Client{
+		feed:   feed,
+		model:  app.NewModel(cfg),
+		store:  NewStore(),
+		events: make(chan Event, buffer),
+		now:    time.Now,
+	}
+	c.model.SetAudioEnabled(false)
+	c.model.SetEventHandler(c.handle)
+	return c
+}
+
+// Run connects and processes the feed until ctx is canceled, then closes
+// the Events channel and returns ctx.Err(). Connection failures are
+// retried rather than returned. Run may only be called once.
+func (c *Client) Run(ctx context.Context) error {
+	if !c.ran.CompareAndSwap(false, true) {
+		return ErrAlreadyRun
+	}
+	defer close(c.events)
+
+	c.feed.Start()
+	defer c.feed.Stop()
+
+	sweep := time.NewTicker(sweepInterval)
+	defer sweep.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg := <-c.feed.AircraftMessages():
+			c.model.IngestAircraftMessage(msg)
+		case msg := <-c.feed.ACARSMessages():
+			c.model.IngestACARSMessage(msg)
+		case <-sweep.C:
+			c.model.Sweep()
+		}
+	}
+}
+
+// Events returns the event channel. It is closed when Run returns. The
+// client never waits for the reader: when the channel is full, events are
+// dropped and counted by Dropped, though the Store is still updated.
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+// Dropped returns the number of events dropped because the reader fell
+// behind
+func (c *Client) Dropped() int64 {
+	return c.dropped.Load()
+}
+
+// Store returns the client's view of every tracked aircraft
+func (c *Client) Store() *Store {
+	return c.store
+}
+
+// Connected reports whether the aircraft feed is currently connected
+func (c *Client) Connected() bool {
+	return c.feed.IsConnected()
+}
+
+// handle applies an engine event to the store and queues it for the reader
+func (c *Client) handle(ev app.Event) {
+	out := newEvent(ev, c.now())
+	c.store.Apply(out)
+	select {
+	case c.events <- out:
+	default:
+		c.dropped.Add(1)
+	}
+}
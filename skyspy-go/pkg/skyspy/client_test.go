@@ -0,0 +1,264 @@
+package skyspy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/codec"
+	"github.com/skyspy/skyspy-go/internal/testutil"
+)
+
+// fakeFeed is an in-memory feed driven by the test
+type fakeFeed struct {
+	aircraft chan codec.Message
+	acars    chan codec.Message
+	done     chan struct{}
+	started  chan struct{}
+}
+
+func newFakeFeed() *fakeFeed {
+	return &fakeFeed{
+		aircraft: make(chan codec.Message),
+		acars:    make(chan codec.Message),
+		done:     make(chan struct{}),
+		started:  make(chan struct{}),
+	}
+}
+
+func (f *fakeFeed) Start()                                 { close(f.started) }
+func (f *fakeFeed) Stop()                                  { close(f.done) }
+func (f *fakeFeed) Done() <-chan struct{}                  { return f.done }
+func (f *fakeFeed) IsConnected() bool                      { return true }
+func (f *fakeFeed) AircraftMessages() <-chan codec.Message { return f.aircraft }
+func (f *fakeFeed) ACARSMessages() <-chan codec.Message    { return f.acars }
+
+func testMessage(t *testing.T, typ codec.MessageType, data interface{}) codec.Message {
+	t.Helper()
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return codec.Message{Type: string(typ), Data: raw}
+}
+
+// startTestClient runs a client on a fake feed until the test ends
+func startTestClient(t *testing.T, opts Options) (*Client, *fakeFeed) {
+	t.Helper()
+	feed := newFakeFeed()
+	c := newClient(opts, opts.config(), feed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- c.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-errCh; !errors.Is(err, context.Canceled) {
+			t.Errorf("Run returned %v, want context.Canceled", err)
+		}
+	})
+	<-feed.started
+	return c, feed
+}
+
+func nextEvent(t *testing.T, c *Client) Event {
+	t.Helper()
+	select {
+	case ev := <-c.Events():
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return Event{}
+	}
+}
+
+func TestClient_AircraftLifecycle(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Alerts.Enabled = false
+	c, feed := startTestClient(t, opts)
+
+	lat, lon, alt, dist := 52.5, 4.9, 12000, 12.5
+	feed.aircraft <- testMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "abc123", Flight: "KLM123  ", Lat: &lat, Lon: &lon, AltBaro: &alt, Distance: &dist})
+
+	ev := nextEvent(t, c)
+	if ev.Type != EventNew || ev.Aircraft == nil {
+		t.Fatalf("expected a new aircraft event, got %+v", ev)
+	}
+	if ev.Aircraft.Callsign != "KLM123" {
+		t.Errorf("expected trimmed callsign, got %q", ev.Aircraft.Callsign)
+	}
+	if !ev.Aircraft.HasPosition || !ev.Aircraft.HasAltitude || ev.Aircraft.Altitude != 12000 {
+		t.Errorf("expected position and altitude, got %+v", ev.Aircraft)
+	}
+	if ev.Aircraft.HasSpeed {
+		t.Error("speed was not reported and should not be marked present")
+	}
+	if got, ok := c.Store().Get(ev.Aircraft.Hex); !ok || got.Callsign != "KLM123" {
+		t.Errorf("store should hold the aircraft once its event is delivered, got %+v, %v", got, ok)
+	}
+
+	feed.aircraft <- testMessage(t, codec.AircraftUpdate, codec.Aircraft{Hex: "abc123", Flight: "KLM123", Squawk: "7700"})
+	ev = nextEvent(t, c)
+	if ev.Type != EventUpdate || !ev.Aircraft.Emergency {
+		t.Errorf("expected an emergency update, got %+v", ev)
+	}
+
+	feed.aircraft <- testMessage(t, codec.AircraftRemove, codec.Aircraft{Hex: ev.Aircraft.Hex})
+	ev = nextEvent(t, c)
+	if ev.Type != EventRemove || ev.Aircraft == nil || ev.Aircraft.Callsign != "KLM123" {
+		t.Errorf("expected removal with the last known state, got %+v", ev)
+	}
+	if c.Store().Len() != 0 {
+		t.Errorf("store should be empty after removal, has %d", c.Store().Len())
+	}
+}
+
+func TestClient_ACARSAndAlerts(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Alerts.Enabled = true
+	opts.Alerts.Rules = []AlertRule{{
+		ID:         "watch",
+		Name:       "Watch KLM",
+		Enabled:    true,
+		Conditions: []AlertCondition{{Type: "callsign", Value: "KLM*"}},
+		Actions:    []AlertAction{{Type: "notify"}},
+		Priority:   5,
+	}}
+	c, feed := startTestClient(t, opts)
+
+	feed.aircraft <- testMessage(t, codec.AircraftNew, codec.Aircraft{Hex: "abc123", Flight: "KLM123"})
+
+	var alert *Alert
+	for i := 0; i < 2 && alert == nil; i++ {
+		if ev := nextEvent(t, c); ev.Type == EventAlert {
+			alert = ev.Alert
+			if ev.Aircraft == nil || ev.Aircraft.Hex == "" {
+				t.Error("alert from an aircraft should carry it")
+			}
+		}
+	}
+	if alert == nil {
+		t.Fatal("expected an alert event")
+	}
+	if alert.RuleID != "watch" || alert.RuleName != "Watch KLM" || alert.Priority != 5 || alert.ACARS {
+		t.Errorf("unexpected alert %+v", alert)
+	}
+
+	feed.acars <- testMessage(t, codec.ACARSMessage, []codec.ACARSData{{Flight: "KLM123", Label: "H1", Text: "HELLO"}})
+	ev := nextEvent(t, c)
+	if ev.Type != EventACARS || ev.ACARS == nil || ev.ACARS.Text != "HELLO" {
+		t.Fatalf("expected an ACARS event, got %+v", ev)
+	}
+	if ev.Aircraft == nil || ev.Aircraft.Callsign != "KLM123" {
+		t.Error("ACARS from a tracked aircraft should carry it")
+	}
+}
+
+func TestClient_DropsWhenReaderStalls(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Alerts.Enabled = false
+	opts.EventBuffer = 1
+	c, feed := startTestClient(t, opts)
+
+	for _, hex := range []string{"aaa001", "aaa002", "aaa003"} {
+		feed.aircraft <- testMessage(t, codec.AircraftNew, codec.Aircraft{Hex: hex})
+	}
+	// An unbuffered feed send returns once Run has the message, not once
+	// it has been handled; the next send waits for that
+	feed.aircraft <- testMessage(t, codec.AircraftUpdate, codec.Aircraft{Hex: "aaa001"})
+
+	if c.Dropped() < 2 {
+		t.Errorf("expected at least 2 dropped events, got %d", c.Dropped())
+	}
+	if c.Store().Len() != 3 {
+		t.Errorf("store should track every aircraft despite drops, has %d", c.Store().Len())
+	}
+}
+
+func TestClient_RunOnce(t *testing.T) {
+	c, _ := startTestClient(t, DefaultOptions())
+	if err := c.Run(context.Background()); !errors.Is(err, ErrAlreadyRun) {
+		t.Errorf("second Run returned %v, want ErrAlreadyRun", err)
+	}
+}
+
+func TestClient_EventsClosedAfterRun(t *testing.T) {
+	feed := newFakeFeed()
+	opts := DefaultOptions()
+	c := newClient(opts, opts.config(), feed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, want context.Canceled", err)
+	}
+	if _, ok := <-c.Events(); ok {
+		t.Error("Events should be closed once Run returns")
+	}
+}
+
+func TestNewClient_Server(t *testing.T) {
+	_, cleanup := testutil.TempConfigDirWithEnv()
+	defer cleanup()
+
+	server := testutil.NewMockServer()
+	serverPort := testutil.FreePort()
+	if err := server.Start(serverPort); err != nil {
+		t.Fatalf("Failed to start mock server: %v", err)
+	}
+	defer server.Stop()
+
+	opts := DefaultOptions()
+	opts.Connection.Host = "localhost"
+	opts.Connection.Port = serverPort
+	opts.Alerts.Enabled = false
+	c, err := NewClient(opts)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Run(ctx)
+
+	if err := server.WaitForClients(1, 1, 5*time.Second); err != nil {
+		t.Fatalf("client did not connect: %v", err)
+	}
+	if err := server.SendAircraftNew(testutil.AircraftWithHex("def456")); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	ev := nextEvent(t, c)
+	if ev.Type != EventNew || ev.Aircraft.Hex != "DEF456" {
+		t.Errorf("expected the server's aircraft, got %+v", ev)
+	}
+	if !c.Connected() {
+		t.Error("client should report connected")
+	}
+}
+
+func TestNewClient_AuthRequired(t *testing.T) {
+	_, cleanup := testutil.TempConfigDirWithEnv()
+	defer cleanup()
+
+	server := testutil.NewMockServer()
+	serverPort := testutil.FreePort()
+	if err := server.Start(serverPort); err != nil {
+		t.Fatalf("Failed to start mock server: %v", err)
+	}
+	defer server.Stop()
+	server.SetAuthMode(testutil.AuthModeAPIKey)
+
+	opts := DefaultOptions()
+	opts.Connection.Host = "localhost"
+	opts.Connection.Port = serverPort
+	if _, err := NewClient(opts); !errors.Is(err, ErrAuthRequired) {
+		t.Errorf("expected ErrAuthRequired, got %v", err)
+	}
+
+	opts.APIKey = "sk_test"
+	if _, err := NewClient(opts); err != nil {
+		t.Errorf("an API key should satisfy the auth check, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+// Package skyspy embeds the SkySpy client in other Go programs.
+//
+// A Client connects to a SkySpy server, authenticates, reconnects when the
+// connection drops and runs every feed message through the same engine the
+// radar uses, so aircraft carry distance and bearing from your receiver,
+// cleaned-up callsigns and alert rule results exactly as the TUI shows
+// them. Changes arrive on a typed event channel and are mirrored in an
+// in-memory Store that can be queried with the radar's search syntax.
+//
+//	opts := skyspy.DefaultOptions()
+//	opts.Connection.Host = "radar.local"
+//	client, err := skyspy.NewClient(opts)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	go client.Run(ctx)
+//	for ev := range client.Events() {
+//		if ev.Type == skyspy.EventNew {
+//			fmt.Println("new aircraft", ev.Aircraft.Hex)
+//		}
+//	}
+//
+// # Compatibility
+//
+// The package follows semantic versioning independently of the CLI; the
+// current API version is Version. Within a major version, exported names
+// are not removed or changed incompatibly. New fields may be added to
+// structs, so construct them with field names, and new event types may
+// appear, so switch on Event.Type with a default case.
+package skyspy
+
+// Version is the semantic version of this package's API
+const Version = "0.1.0"
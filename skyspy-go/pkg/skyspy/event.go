@@ -0,0 +1,203 @@
+package skyspy
+
+import (
+	"strings"
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/alerts"
+	"github.com/skyspy/skyspy-go/internal/app"
+	"github.com/skyspy/skyspy-go/internal/radar"
+)
+
+// EventType identifies what an Event reports. The values match the event
+// names written by `skyspy stream`.
+type EventType string
+
+const (
+	EventNew    EventType = EventType(app.EventNew)    // an aircraft was first seen
+	EventUpdate EventType = EventType(app.EventUpdate) // a tracked aircraft changed
+	EventRemove EventType = EventType(app.EventRemove) // an aircraft was removed or timed out
+	EventACARS  EventType = EventType(app.EventACARS)  // an ACARS message arrived
+	EventAlert  EventType = EventType(app.EventAlert)  // an alert rule fired
+)
+
+// Event is one change to the tracked picture, after it has been applied
+// to the Store.
+//
+// Aircraft is set for aircraft events (the last known state for removals),
+// for ACARS messages whose sender is tracked, and for alerts raised by a
+// tracked aircraft. ACARS is set for ACARS events and alerts raised by an
+// ACARS message. Alert is set for alert events.
+type Event struct {
+	Type     EventType
+	Time     time.Time
+	Aircraft *Aircraft
+	ACARS    *ACARSMessage
+	Alert    *Alert
+}
+
+// Aircraft is the normalized state of one aircraft. Optional values are
+// only meaningful when the matching Has field is set.
+type Aircraft struct {
+	Hex          string
+	Callsign     string
+	Squawk       string
+	Type         string // ICAO type designator, e.g. "B738"
+	Military     bool
+	Emergency    bool // squawk classed as an emergency (7500, 7600 or 7700 by default)
+	Conflicted   bool // position jumps suggest two aircraft share this address
+	Lat          float64
+	Lon          float64
+	Altitude     int     // feet
+	Speed        float64 // ground speed, knots
+	Track        float64 // degrees
+	VerticalRate float64 // feet per minute
+	Distance     float64 // nautical miles from the receiver
+	Bearing      float64 // degrees from the receiver
+	RSSI         float64 // dBFS
+
+	HasPosition     bool
+	HasAltitude     bool
+	HasSpeed        bool
+	HasTrack        bool
+	HasVerticalRate bool
+	HasRSSI         bool
+
+	// Selected altitude and heading, baro setting and autopilot modes
+	NavAltitude    int     // feet
+	NavHeading     float64 // degrees
+	NavQNH         float64 // hPa
+	NavModes       []string
+	HasNavAltitude bool
+	HasNavHeading  bool
+	HasNavQNH      bool
+}
+
+// ACARSMessage is a datalink message
+type ACARSMessage struct {
+	Callsign string
+	Flight   string
+	Label    string
+	Text     string
+	Received time.Time // local arrival time
+	Sent     time.Time // server send time, zero if not given
+}
+
+// Alert is a triggered alert rule
+type Alert struct {
+	RuleID   string
+	RuleName string
+	Priority int // higher is more important
+	Hex      string
+	Callsign string
+	Message  string
+	Time     time.Time
+	ACARS    bool // raised by an ACARS message rather than an aircraft update
+}
+
+// newEvent converts an engine event
+func newEvent(ev app.Event, now time.Time) Event {
+	out := Event{Type: EventType(ev.Type), Time: now}
+	if ev.Target != nil {
+		out.Aircraft = newAircraft(ev.Target)
+	}
+	if ev.ACARS != nil {
+		out.ACARS = &ACARSMessage{
+			Callsign: ev.ACARS.Callsign,
+			Flight:   ev.ACARS.Flight,
+			Label:    ev.ACARS.Label,
+			Text:     ev.ACARS.Text,
+			Received: ev.ACARS.Received,
+			Sent:     ev.ACARS.Sent,
+		}
+	}
+	if ev.Alert != nil {
+		out.Alert = newAlert(ev.Alert)
+	}
+	return out
+}
+
+func newAircraft(t *radar.Target) *Aircraft {
+	return &Aircraft{
+		Hex:             t.Hex,
+		Callsign:        t.Callsign,
+		Squawk:          t.Squawk,
+		Type:            t.ACType,
+		Military:        t.Military,
+		Emergency:       t.IsEmergency(),
+		Conflicted:      t.Conflicted,
+		Lat:             t.Lat,
+		Lon:             t.Lon,
+		Altitude:        t.Altitude,
+		Speed:           t.Speed,
+		Track:           t.Track,
+		VerticalRate:    t.Vertical,
+		Distance:        t.Distance,
+		Bearing:         t.Bearing,
+		RSSI:            t.RSSI,
+		HasPosition:     t.HasLat && t.HasLon,
+		HasAltitude:     t.HasAlt,
+		HasSpeed:        t.HasSpeed,
+		HasTrack:        t.HasTrack,
+		HasVerticalRate: t.HasVS,
+		HasRSSI:         t.HasRSSI,
+		NavAltitude:     t.NavAltitude,
+		NavHeading:      t.NavHeading,
+		NavQNH:          t.NavQNH,
+		NavModes:        append([]string(nil), t.NavModes...),
+		HasNavAltitude:  t.HasNavAlt,
+		HasNavHeading:   t.HasNavHeading,
+		HasNavQNH:       t.HasNavQNH,
+	}
+}
+
+// target converts a back to the engine's form for search matching
+func (a *Aircraft) target() *radar.Target {
+	return &radar.Target{
+		Hex:           a.Hex,
+		Callsign:      a.Callsign,
+		Squawk:        a.Squawk,
+		ACType:        a.Type,
+		Military:      a.Military,
+		Conflicted:    a.Conflicted,
+		Lat:           a.Lat,
+		Lon:           a.Lon,
+		Altitude:      a.Altitude,
+		Speed:         a.Speed,
+		Track:         a.Track,
+		Vertical:      a.VerticalRate,
+		Distance:      a.Distance,
+		Bearing:       a.Bearing,
+		RSSI:          a.RSSI,
+		HasLat:        a.HasPosition,
+		HasLon:        a.HasPosition,
+		HasAlt:        a.HasAltitude,
+		HasSpeed:      a.HasSpeed,
+		HasTrack:      a.HasTrack,
+		HasVS:         a.HasVerticalRate,
+		HasRSSI:       a.HasRSSI,
+		NavAltitude:   a.NavAltitude,
+		NavHeading:    a.NavHeading,
+		NavQNH:        a.NavQNH,
+		NavModes:      a.NavModes,
+		HasNavAlt:     a.HasNavAltitude,
+		HasNavHeading: a.HasNavHeading,
+		HasNavQNH:     a.HasNavQNH,
+	}
+}
+
+func newAlert(a *alerts.TriggeredAlert) *Alert {
+	out := &Alert{
+		Hex:      a.Hex,
+		Callsign: strings.TrimSpace(a.Callsign),
+		Message:  a.Message,
+		Time:     a.Timestamp,
+		ACARS:    a.ACARS,
+	}
+	if a.Rule != nil {
+		out.RuleID = a.Rule.ID
+		out.RuleName = a.Rule.Name
+		out.Priority = a.Rule.Priority
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package skyspy_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/skyspy/skyspy-go/pkg/skyspy"
+)
+
+func ExampleNewClient() {
+	opts := skyspy.DefaultOptions()
+	opts.Connection.Host = "radar.local"
+	opts.Connection.Port = 8080
+	opts.APIKey = os.Getenv("SKYSPY_API_KEY")
+
+	client, err := skyspy.NewClient(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go client.Run(ctx)
+
+	for ev := range client.Events() {
+		switch ev.Type {
+		case skyspy.EventNew:
+			fmt.Printf("%s %s at %.1f nm\n", ev.Aircraft.Hex, ev.Aircraft.Callsign, ev.Aircraft.Distance)
+		case skyspy.EventAlert:
+			fmt.Println("ALERT:", ev.Alert.Message)
+		default:
+		}
+	}
+}
+
+func ExampleStore_Query() {
+	store := skyspy.NewStore()
+	store.Apply(skyspy.Event{Type: skyspy.EventNew, Aircraft: &skyspy.Aircraft{
+		Hex: "ae1234", Callsign: "RCH401", Military: true, Altitude: 28000, HasAltitude: true, Distance: 40,
+	}})
+	store.Apply(skyspy.Event{Type: skyspy.EventNew, Aircraft: &skyspy.Aircraft{
+		Hex: "484abc", Callsign: "KLM123", Altitude: 3000, HasAltitude: true, Distance: 5,
+	}})
+
+	for _, a := range store.Query("mil alt:>10000") {
+		fmt.Println(a.Callsign, a.Altitude)
+	}
+	fmt.Println(len(store.All()), "tracked")
+	// Output:
+	// RCH401 28000
+	// 2 tracked
+}
+
+func ExampleOptions_alerts() {
+	opts := skyspy.DefaultOptions()
+	opts.Alerts.Enabled = true
+	opts.Alerts.Rules = []skyspy.AlertRule{{
+		ID:         "low-mil",
+		Name:       "Low military",
+		Enabled:    true,
+		Conditions: []skyspy.AlertCondition{{Type: "military", Value: "true"}, {Type: "altitude_below", Value: "5000"}},
+		Actions:    []skyspy.AlertAction{{Type: "notify"}},
+		Priority:   50,
+	}}
+	fmt.Println(len(opts.Alerts.Rules), "rule")
+	// Output: 1 rule
+}
@@ -0,0 +1,272 @@
+package skyspy
+
+import (
+	"time"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+// The option sections below mirror the matching sections of the SkySpy
+// settings file: field names, units and JSON keys are the same, so a
+// section read from the file unmarshals into them.
+
+// ConnectionOptions is the server and receiver location. Host and Port name
+// the SkySpy server; ReceiverLat and ReceiverLon are used for distance and
+// bearing.
+type ConnectionOptions struct {
+	Host           string  `json:"host"`
+	Port           int     `json:"port"`
+	ReceiverLat    float64 `json:"receiver_lat"`
+	ReceiverLon    float64 `json:"receiver_lon"`
+	AutoReconnect  bool    `json:"auto_reconnect"`  // redial a dropped connection, backing off from 1s to 60s
+	ReconnectDelay int     `json:"reconnect_delay"` // seconds between attempts to make the first connection
+	PingInterval   int     `json:"ping_interval"`   // seconds between keepalive pings; 0 disables keepalive
+	PongTimeout    int     `json:"pong_timeout"`    // seconds past a ping without a reply before reconnecting
+}
+
+// AlertOptions holds the alert rules and geofences. With Enabled set and no
+// Rules, the built-in default rules are used.
+type AlertOptions struct {
+	Enabled   bool              `json:"enabled"`
+	Rules     []AlertRule       `json:"rules"`
+	Geofences []Geofence        `json:"geofences"`
+	Squawks   map[string]string `json:"squawks"` // special code -> emergency, warning, info or none
+	// Built-in proximity rule: an alert when an aircraft comes within
+	// ProximityRadiusNM of the receiver
+	Proximity         bool    `json:"proximity"`
+	ProximityRadiusNM float64 `json:"proximity_radius_nm"`
+}
+
+// AlertRule is one alert rule, in the settings file's form
+type AlertRule struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Enabled     bool             `json:"enabled"`
+	Conditions  []AlertCondition `json:"conditions"`
+	Actions     []AlertAction    `json:"actions"`
+	CooldownSec int              `json:"cooldown_sec"`
+	Priority    int              `json:"priority"`
+}
+
+// AlertCondition is one condition of an alert rule
+type AlertCondition struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Regex bool   `json:"regex,omitempty"` // acars_text value is a regular expression
+}
+
+// AlertAction is one action of an alert rule
+type AlertAction struct {
+	Type    string `json:"type"`
+	Message string `json:"message,omitempty"`
+	Sound   string `json:"sound,omitempty"`
+}
+
+// Geofence is a circle or polygon that alert rules can refer to by ID
+type Geofence struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Type        string          `json:"type"` // circle or polygon
+	Points      []GeofencePoint `json:"points,omitempty"`
+	CenterLat   float64         `json:"center_lat,omitempty"`
+	CenterLon   float64         `json:"center_lon,omitempty"`
+	RadiusNM    float64         `json:"radius_nm,omitempty"`
+	Enabled     bool            `json:"enabled"`
+	Description string          `json:"description,omitempty"`
+}
+
+// GeofencePoint is one vertex of a polygon geofence
+type GeofencePoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// ACARSOptions tunes ACARS message retention and de-duplication
+type ACARSOptions struct {
+	MaxMessages int `json:"max_messages"` // retained messages
+	DedupWindow int `json:"dedup_window"` // seconds; 0 disables de-duplication
+}
+
+// ConflictOptions tunes duplicate ICAO address detection
+type ConflictOptions struct {
+	MaxSpeed    float64 `json:"max_speed"`    // knots; faster implied speeds between fixes are jumps
+	SpeedFactor float64 `json:"speed_factor"` // fast movers may also reach this multiple of their ground speed
+	MinJump     float64 `json:"min_jump"`     // nm; shorter jumps are ignored as position noise
+	HoldSeconds int     `json:"hold_seconds"` // how long a target stays flagged after its last jump
+}
+
+// TrackingOptions controls how long aircraft are kept without an update
+type TrackingOptions struct {
+	AircraftTimeout time.Duration // removal after this long without an update
+	CleanupInterval time.Duration // how often timed-out aircraft are looked for
+}
+
+// Options configures a Client. Start from DefaultOptions and change what
+// you need; zero durations and counts use the defaults.
+type Options struct {
+	Connection ConnectionOptions
+	Tracking   TrackingOptions
+	Alerts     AlertOptions
+	ACARS      ACARSOptions
+	Conflicts  ConflictOptions
+
+	// APIKey authenticates to servers that require it. Without one, the
+	// tokens saved by `skyspy login` are used.
+	APIKey string
+
+	// Privacy reports an approximate receiver position, as the radar's
+	// privacy mode does
+	Privacy bool
+
+	// EventBuffer is how many events are held for a slow reader before
+	// new ones are dropped
+	EventBuffer int
+}
+
+// DefaultEventBuffer is the event channel capacity used when
+// Options.EventBuffer is not positive
+const DefaultEventBuffer = 1024
+
+// DefaultOptions returns the options the radar uses with a fresh settings
+// file
+func DefaultOptions() Options {
+	cfg := config.DefaultConfig()
+	return Options{
+		Connection: connectionOptions(cfg.Connection),
+		Tracking: TrackingOptions{
+			AircraftTimeout: time.Duration(cfg.Radar.AircraftTimeout) * time.Second,
+			CleanupInterval: time.Duration(cfg.Radar.CleanupInterval) * time.Second,
+		},
+		Alerts:      alertOptions(cfg.Alerts),
+		ACARS:       ACARSOptions(cfg.ACARS),
+		Conflicts:   ConflictOptions(cfg.Conflicts),
+		EventBuffer: DefaultEventBuffer,
+	}
+}
+
+// config builds the engine configuration for o
+func (o Options) config() *config.Config {
+	cfg := config.DefaultConfig()
+	c := o.Connection
+	cfg.Connection.Host, cfg.Connection.Port = c.Host, c.Port
+	cfg.Connection.ReceiverLat, cfg.Connection.ReceiverLon = c.ReceiverLat, c.ReceiverLon
+	cfg.Connection.AutoReconnect = c.AutoReconnect
+	cfg.Connection.ReconnectDelay = c.ReconnectDelay
+	cfg.Connection.PingInterval, cfg.Connection.PongTimeout = c.PingInterval, c.PongTimeout
+	cfg.Radar.AircraftTimeout = int(o.Tracking.AircraftTimeout / time.Second)
+	cfg.Radar.CleanupInterval = int(o.Tracking.CleanupInterval / time.Second)
+	cfg.Alerts.Enabled = o.Alerts.Enabled
+	cfg.Alerts.Rules = make([]config.AlertRuleConfig, 0, len(o.Alerts.Rules))
+	for _, rule := range o.Alerts.Rules {
+		cfg.Alerts.Rules = append(cfg.Alerts.Rules, rule.config())
+	}
+	cfg.Alerts.Geofences = make([]config.GeofenceConfig, 0, len(o.Alerts.Geofences))
+	for _, gf := range o.Alerts.Geofences {
+		cfg.Alerts.Geofences = append(cfg.Alerts.Geofences, gf.config())
+	}
+	cfg.Alerts.Squawks = o.Alerts.Squawks
+	cfg.Alerts.Proximity = o.Alerts.Proximity
+	cfg.Alerts.ProximityRadiusNM = o.Alerts.ProximityRadiusNM
+	cfg.ACARS = config.ACARSSettings(o.ACARS)
+	cfg.Conflicts = config.ConflictSettings(o.Conflicts)
+	cfg.Display.PrivacyMode = o.Privacy
+	return cfg
+}
+
+// connectionOptions takes the client's part of the connection settings
+func connectionOptions(c config.ConnectionSettings) ConnectionOptions {
+	return ConnectionOptions{
+		Host:           c.Host,
+		Port:           c.Port,
+		ReceiverLat:    c.ReceiverLat,
+		ReceiverLon:    c.ReceiverLon,
+		AutoReconnect:  c.AutoReconnect,
+		ReconnectDelay: c.ReconnectDelay,
+		PingInterval:   c.PingInterval,
+		PongTimeout:    c.PongTimeout,
+	}
+}
+
+// alertOptions takes the client's part of the alert settings
+func alertOptions(a config.AlertSettings) AlertOptions {
+	opts := AlertOptions{
+		Enabled:           a.Enabled,
+		Rules:             make([]AlertRule, 0, len(a.Rules)),
+		Geofences:         make([]Geofence, 0, len(a.Geofences)),
+		Squawks:           a.Squawks,
+		Proximity:         a.Proximity,
+		ProximityRadiusNM: a.ProximityRadiusNM,
+	}
+	for _, rule := range a.Rules {
+		r := AlertRule{
+			ID:          rule.ID,
+			Name:        rule.Name,
+			Description: rule.Description,
+			Enabled:     rule.Enabled,
+			CooldownSec: rule.CooldownSec,
+			Priority:    rule.Priority,
+		}
+		for _, cond := range rule.Conditions {
+			r.Conditions = append(r.Conditions, AlertCondition(cond))
+		}
+		for _, action := range rule.Actions {
+			r.Actions = append(r.Actions, AlertAction(action))
+		}
+		opts.Rules = append(opts.Rules, r)
+	}
+	for _, gf := range a.Geofences {
+		g := Geofence{
+			ID:          gf.ID,
+			Name:        gf.Name,
+			Type:        gf.Type,
+			CenterLat:   gf.CenterLat,
+			CenterLon:   gf.CenterLon,
+			RadiusNM:    gf.RadiusNM,
+			Enabled:     gf.Enabled,
+			Description: gf.Description,
+		}
+		for _, p := range gf.Points {
+			g.Points = append(g.Points, GeofencePoint(p))
+		}
+		opts.Geofences = append(opts.Geofences, g)
+	}
+	return opts
+}
+
+// config converts r to the settings file's form
+func (r AlertRule) config() config.AlertRuleConfig {
+	rule := config.AlertRuleConfig{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Enabled:     r.Enabled,
+		CooldownSec: r.CooldownSec,
+		Priority:    r.Priority,
+	}
+	for _, cond := range r.Conditions {
+		rule.Conditions = append(rule.Conditions, config.ConditionConfig(cond))
+	}
+	for _, action := range r.Actions {
+		rule.Actions = append(rule.Actions, config.ActionConfig(action))
+	}
+	return rule
+}
+
+// config converts g to the settings file's form
+func (g Geofence) config() config.GeofenceConfig {
+	gf := config.GeofenceConfig{
+		ID:          g.ID,
+		Name:        g.Name,
+		Type:        g.Type,
+		CenterLat:   g.CenterLat,
+		CenterLon:   g.CenterLon,
+		RadiusNM:    g.RadiusNM,
+		Enabled:     g.Enabled,
+		Description: g.Description,
+	}
+	for _, p := range g.Points {
+		gf.Points = append(gf.Points, config.GeofencePointConfig(p))
+	}
+	return gf
+}
@@ -0,0 +1,48 @@
+package skyspy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/skyspy/skyspy-go/internal/config"
+)
+
+func TestOptions_DefaultsMatchTheRadar(t *testing.T) {
+	got, want := DefaultOptions().config(), config.DefaultConfig()
+	if !reflect.DeepEqual(got.Connection, want.Connection) {
+		t.Errorf("connection = %+v, want %+v", got.Connection, want.Connection)
+	}
+	if !reflect.DeepEqual(got.Alerts, want.Alerts) {
+		t.Errorf("alerts = %+v, want %+v", got.Alerts, want.Alerts)
+	}
+	if got.ACARS != want.ACARS || got.Conflicts != want.Conflicts {
+		t.Error("ACARS and conflict defaults should carry over")
+	}
+}
+
+func TestOptions_SettingsFileSection(t *testing.T) {
+	section := `{"enabled": true, "proximity": true, "proximity_radius_nm": 3,
+		"rules": [{"id": "klm", "name": "KLM", "enabled": true, "cooldown_sec": 60,
+			"conditions": [{"type": "callsign", "value": "KLM*"}],
+			"actions": [{"type": "notify", "message": "{callsign}"}]}],
+		"geofences": [{"id": "home", "type": "circle", "center_lat": 52.3, "center_lon": 4.9, "radius_nm": 2, "enabled": true}]}`
+	var alerts AlertOptions
+	if err := json.Unmarshal([]byte(section), &alerts); err != nil {
+		t.Fatal(err)
+	}
+	opts := DefaultOptions()
+	opts.Alerts = alerts
+
+	cfg := opts.config().Alerts
+	if !cfg.Proximity || cfg.ProximityRadiusNM != 3 || len(cfg.Rules) != 1 || len(cfg.Geofences) != 1 {
+		t.Fatalf("alerts = %+v", cfg)
+	}
+	rule := cfg.Rules[0]
+	if rule.CooldownSec != 60 || rule.Conditions[0].Value != "KLM*" || rule.Actions[0].Message != "{callsign}" {
+		t.Errorf("rule = %+v", rule)
+	}
+	if gf := cfg.Geofences[0]; gf.CenterLat != 52.3 || gf.RadiusNM != 2 || !gf.Enabled {
+		t.Errorf("geofence = %+v", gf)
+	}
+}
@@ -0,0 +1,91 @@
+package skyspy
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/skyspy/skyspy-go/internal/search"
+)
+
+// Store holds the current state of every tracked aircraft. A Client keeps
+// its Store up to date before delivering each event, so a reader that sees
+// an event can rely on the Store reflecting it. Store is safe for
+// concurrent use.
+type Store struct {
+	mu       sync.RWMutex
+	aircraft map[string]*Aircraft
+}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{aircraft: make(map[string]*Aircraft)}
+}
+
+// Apply updates the store from an aircraft event. Other event types are
+// ignored.
+func (s *Store) Apply(ev Event) {
+	if ev.Aircraft == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch ev.Type {
+	case EventNew, EventUpdate:
+		a := *ev.Aircraft
+		s.aircraft[a.Hex] = &a
+	case EventRemove:
+		delete(s.aircraft, ev.Aircraft.Hex)
+	}
+}
+
+// Get returns a copy of the aircraft with the given ICAO hex address
+func (s *Store) Get(hex string) (Aircraft, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.aircraft[hex]
+	if !ok {
+		return Aircraft{}, false
+	}
+	return *a, true
+}
+
+// Len returns the number of tracked aircraft
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.aircraft)
+}
+
+// All returns every tracked aircraft, nearest first
+func (s *Store) All() []Aircraft {
+	return s.Query("")
+}
+
+// Query returns the aircraft matching a query in the radar's search
+// syntax, nearest first. Plain words match part of the callsign or hex;
+// "mil", "sq:7500,7700", "alt:>10000", "alt:5000-10000" and "dist:<50"
+// narrow the result. An empty query matches everything.
+func (s *Store) Query(query string) []Aircraft {
+	filter := search.ParseQuery(query)
+
+	s.mu.RLock()
+	out := make([]Aircraft, 0, len(s.aircraft))
+	for _, a := range s.aircraft {
+		if filter.IsActive() && !search.MatchesAircraft(a.target(), filter) {
+			continue
+		}
+		out = append(out, *a)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Distance != out[j].Distance {
+			return out[i].Distance < out[j].Distance
+		}
+		return out[i].Hex < out[j].Hex
+	})
+	return out
+}
@@ -0,0 +1,85 @@
+package skyspy
+
+import "testing"
+
+func storeWith(aircraft ...Aircraft) *Store {
+	s := NewStore()
+	for i := range aircraft {
+		s.Apply(Event{Type: EventNew, Aircraft: &aircraft[i]})
+	}
+	return s
+}
+
+func hexes(list []Aircraft) []string {
+	out := make([]string, len(list))
+	for i, a := range list {
+		out[i] = a.Hex
+	}
+	return out
+}
+
+func TestStore_Query(t *testing.T) {
+	s := storeWith(
+		Aircraft{Hex: "ae1234", Callsign: "RCH401", Military: true, Altitude: 28000, HasAltitude: true, Distance: 40},
+		Aircraft{Hex: "484abc", Callsign: "KLM123", Altitude: 3000, HasAltitude: true, Distance: 5},
+		Aircraft{Hex: "a00001", Callsign: "N123AB", Squawk: "7700", Distance: 12},
+		Aircraft{Hex: "a00002", Callsign: "DAL88", Altitude: 36000, HasAltitude: true, Distance: 5},
+	)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"484abc", "a00002", "a00001", "ae1234"}},
+		{"klm", []string{"484abc"}},
+		{"A0000", []string{"a00002", "a00001"}},
+		{"mil", []string{"ae1234"}},
+		{"alt:>10000", []string{"a00002", "ae1234"}},
+		{"dist:<10", []string{"484abc", "a00002"}},
+		{"sq:7700", []string{"a00001"}},
+		{"mil alt:<10000", []string{}},
+	}
+	for _, tt := range tests {
+		got := hexes(s.Query(tt.query))
+		if len(got) != len(tt.want) {
+			t.Errorf("Query(%q) = %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Query(%q) = %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestStore_Apply(t *testing.T) {
+	s := storeWith(Aircraft{Hex: "abc123", Callsign: "OLD"})
+
+	s.Apply(Event{Type: EventUpdate, Aircraft: &Aircraft{Hex: "abc123", Callsign: "NEW"}})
+	if a, _ := s.Get("abc123"); a.Callsign != "NEW" {
+		t.Errorf("update should replace the aircraft, got %q", a.Callsign)
+	}
+
+	// ACARS and alert events carry an aircraft but don't change the store
+	s.Apply(Event{Type: EventACARS, Aircraft: &Aircraft{Hex: "abc123", Callsign: "ACARS"}})
+	s.Apply(Event{Type: EventAlert, Aircraft: &Aircraft{Hex: "fff000"}})
+	if a, _ := s.Get("abc123"); a.Callsign != "NEW" || s.Len() != 1 {
+		t.Errorf("non-aircraft events should be ignored, got %q and %d aircraft", a.Callsign, s.Len())
+	}
+
+	s.Apply(Event{Type: EventRemove, Aircraft: &Aircraft{Hex: "abc123"}})
+	if _, ok := s.Get("abc123"); ok {
+		t.Error("remove should delete the aircraft")
+	}
+}
+
+func TestStore_GetReturnsCopy(t *testing.T) {
+	s := storeWith(Aircraft{Hex: "abc123", Callsign: "KLM1"})
+	a, _ := s.Get("abc123")
+	a.Callsign = "CHANGED"
+	if b, _ := s.Get("abc123"); b.Callsign != "KLM1" {
+		t.Error("changing a returned aircraft should not change the store")
+	}
+}